@@ -7,7 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
@@ -45,12 +45,12 @@ func init() {
 }
 
 func validateCluster(ctx context.Context, cluster *types.Cluster, clusterName string) error {
-	executableBuilder, close, err := executables.NewExecutableBuilder(ctx, executables.DefaultEksaImage())
+	deps, err := dependencies.NewFactory().WithKubectl().Build(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to initialize executables: %v", err)
 	}
-	defer close.CheckErr(ctx)
-	kubectl := executableBuilder.BuildKubectlExecutable()
+	defer deps.Close(ctx)
+	kubectl := deps.Kubectl
 	err = kubectl.ValidateNodes(ctx, cluster.KubeconfigFile)
 	if err != nil {
 		return err
@@ -7,7 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
 )
 
 var versionsCmd = &cobra.Command{
@@ -28,12 +28,11 @@ func init() {
 }
 
 func versions(ctx context.Context) error {
-	executableBuilder, close, err := executables.NewExecutableBuilder(ctx, executables.DefaultEksaImage())
+	deps, err := dependencies.NewFactory().WithKubectl().Build(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to initialize executables: %v", err)
 	}
-	defer close.CheckErr(ctx)
-	kubectl := executableBuilder.BuildKubectlExecutable()
+	defer deps.Close(ctx)
 
-	return kubectl.ListCluster(ctx)
+	return deps.Kubectl.ListCluster(ctx)
 }
@@ -8,8 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/aws/eks-anywhere/pkg/executables"
-	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
@@ -46,14 +45,11 @@ func init() {
 }
 
 func vsphereRmVms(ctx context.Context, clusterName string, dryRun bool) error {
-	executableBuilder, close, err := executables.NewExecutableBuilder(ctx, executables.DefaultEksaImage())
+	deps, err := dependencies.NewFactory().WithWriterFolder("rmvms").WithGovc().Build(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to initialize executables: %v", err)
 	}
-	defer close.CheckErr(ctx)
-	tmpWriter, _ := filewriter.NewWriter("rmvms")
-	govc := executableBuilder.BuildGovcExecutable(tmpWriter)
-	defer govc.Close(ctx)
+	defer deps.Close(ctx)
 
-	return govc.CleanupVms(ctx, clusterName, dryRun)
+	return deps.Govc.CleanupVms(ctx, clusterName, dryRun)
 }
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build resources",
+	Long:  "Use eksctl anywhere build to build resources, such as node images",
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/images"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+type buildImageOptions struct {
+	clusterOptions
+	osFamily      string
+	extraPackages []string
+	caCertFiles   []string
+	skipRegister  bool
+}
+
+var bio = &buildImageOptions{}
+
+var buildImageCmd = &cobra.Command{
+	Use:          "image -f <cluster-config-file>",
+	Short:        "Build a node image for the Kubernetes version of a cluster",
+	Long:         "This command runs image-builder to build a node image with the Kubernetes version of the cluster bundle and the given customizations. vSphere images are registered as templates, bare metal images are only built",
+	PreRunE:      preRunBuildImage,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return bio.buildImage(cmd.Context())
+	},
+}
+
+func preRunBuildImage(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	buildCmd.AddCommand(buildImageCmd)
+	buildImageCmd.Flags().StringVarP(&bio.fileName, "filename", "f", "", clusterConfigFileUsage)
+	buildImageCmd.Flags().StringVar(&bio.osFamily, "os", string(v1alpha1.Ubuntu), "OS family of the image")
+	buildImageCmd.Flags().StringSliceVar(&bio.extraPackages, "extra-packages", nil, "Additional packages to install in the image")
+	buildImageCmd.Flags().StringSliceVar(&bio.caCertFiles, "ca-certs", nil, "Files with additional CA certificates to trust in the image")
+	buildImageCmd.Flags().BoolVar(&bio.skipRegister, "skip-register", false, "Only build the vSphere image, without registering it as a template")
+	buildImageCmd.Flags().StringVar(&bio.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	err := buildImageCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (bio *buildImageOptions) imageSpec(clusterSpec *cluster.Spec) (images.Spec, error) {
+	spec := images.Spec{
+		OSFamily:       v1alpha1.OSFamily(bio.osFamily),
+		VersionsBundle: clusterSpec.VersionsBundle,
+		Customization: images.Customization{
+			ExtraPackages: bio.extraPackages,
+			CACertFiles:   bio.caCertFiles,
+		},
+	}
+	if proxy := clusterSpec.Cluster.Spec.ProxyConfiguration; proxy != nil {
+		spec.Customization.HTTPProxy = proxy.HttpProxy
+		spec.Customization.HTTPSProxy = proxy.HttpsProxy
+		spec.Customization.NoProxy = proxy.NoProxy
+	}
+
+	switch clusterSpec.Cluster.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind:
+		spec.Hypervisor = images.VSphere
+	case v1alpha1.TinkerbellDatacenterKind:
+		spec.Hypervisor = images.BareMetal
+	default:
+		return images.Spec{}, fmt.Errorf("provider %s doesn't use node images", clusterSpec.Cluster.Spec.DatacenterRef.Kind)
+	}
+
+	return spec, nil
+}
+
+func (bio *buildImageOptions) buildImage(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(bio.clusterOptions)
+	if err != nil {
+		return err
+	}
+	spec, err := bio.imageSpec(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	factory := dependencies.ForSpec(ctx, clusterSpec).WithImageBuilder().WithWriter()
+	if spec.Hypervisor == images.VSphere {
+		factory.WithGovc()
+	}
+	deps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	writer, err := deps.Writer.WithDir("images")
+	if err != nil {
+		return fmt.Errorf("failed creating images folder: %v", err)
+	}
+
+	var opts []images.BuilderOpt
+	if spec.Hypervisor == images.VSphere {
+		datacenterConfig, machineConfig, err := bio.vsphereConfigs(ctx, clusterSpec, deps)
+		if err != nil {
+			return err
+		}
+		spec.VSphere = &images.VSphereConfig{
+			Server:       datacenterConfig.Spec.Server,
+			Username:     os.Getenv(vsphere.EksavSphereUsernameKey),
+			Password:     os.Getenv(vsphere.EksavSpherePasswordKey),
+			Insecure:     datacenterConfig.Spec.Insecure,
+			Datacenter:   datacenterConfig.Spec.Datacenter,
+			Datastore:    machineConfig.Spec.Datastore,
+			Network:      datacenterConfig.Spec.Network,
+			Folder:       machineConfig.Spec.Folder,
+			ResourcePool: machineConfig.Spec.ResourcePool,
+		}
+		if !bio.skipRegister {
+			opts = append(opts, images.WithRegistrar(vsphere.NewTemplateRegistrar(deps.Govc, datacenterConfig, machineConfig)))
+		}
+	}
+
+	logger.Info("Building node image, this might take a while", "os", spec.OSFamily, "kubernetes", spec.VersionsBundle.EksD.KubeVersion)
+	image, err := images.NewBuilder(deps.ImageBuilder, writer, opts...).Build(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Node image built", "image", image.Path)
+	if image.Location != "" {
+		logger.Info("Image registered, set it as the template of the machine configs", "template", image.Location)
+	}
+	if spec.Hypervisor == images.BareMetal {
+		logger.Info("Bare metal images aren't registered, serve the image over http and set its url as the IMG_URL of the image2disk action in the templateOverride of the machine configs")
+	}
+
+	return nil
+}
+
+// vsphereConfigs returns the datacenter config and the control plane machine config, the image is built in their
+// vCenter, datastore, network and folder and registered as a template there
+func (bio *buildImageOptions) vsphereConfigs(ctx context.Context, clusterSpec *cluster.Spec, deps *dependencies.Dependencies) (*v1alpha1.VSphereDatacenterConfig, *v1alpha1.VSphereMachineConfig, error) {
+	datacenterConfig, err := v1alpha1.GetVSphereDatacenterConfig(bio.fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get vsphere datacenter config: %v", err)
+	}
+	machineConfigs, err := v1alpha1.GetVSphereMachineConfigs(bio.fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get vsphere machine configs: %v", err)
+	}
+	ref := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef
+	if ref == nil || machineConfigs[ref.Name] == nil {
+		return nil, nil, fmt.Errorf("control plane VSphereMachineConfig not found, it's required to build the image")
+	}
+
+	if err = vsphere.SetupEnvVars(datacenterConfig); err != nil {
+		return nil, nil, err
+	}
+	if err = vsphere.NewDefaulter(deps.Govc).SetDefaultsForDatacenterConfig(ctx, datacenterConfig); err != nil {
+		return nil, nil, err
+	}
+
+	return datacenterConfig, machineConfigs[ref.Name], nil
+}
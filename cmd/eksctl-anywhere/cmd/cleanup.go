@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/lock"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 )
 
 func cleanup(ctx context.Context, deps *dependencies.Dependencies, commandErr *error) {
@@ -22,3 +24,24 @@ func close(ctx context.Context, closer types.Closer) {
 		logger.Error(err, "Closer failed", "closerType", fmt.Sprintf("%T", closer))
 	}
 }
+
+// lockCluster takes the operation lock of the cluster, so other operations mutating it fail fast instead of
+// running concurrently. The lease is taken in managementCluster, which is nil for clusters that don't exist yet
+func lockCluster(ctx context.Context, client lock.LeaseClient, clusterName, operation string, managementCluster *types.Cluster) (*lock.Lock, error) {
+	return lock.NewLocker(client, lock.WithDir(workspace.Default().Dir())).Lock(ctx, clusterName, operation, managementCluster)
+}
+
+func unlockCluster(ctx context.Context, l *lock.Lock) {
+	if err := l.Release(ctx); err != nil {
+		logger.Error(err, "Failed releasing cluster lock")
+	}
+	refreshWorkspaceIndex()
+}
+
+// refreshWorkspaceIndex updates the index of the workspace once an operation changed a cluster folder.
+// Failing to update it is logged, the index is rebuilt from the folders next time
+func refreshWorkspaceIndex() {
+	if _, err := workspace.Default().Refresh(); err != nil {
+		logger.V(3).Info("Failed refreshing workspace index", "error", err.Error())
+	}
+}
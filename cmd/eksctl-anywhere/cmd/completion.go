@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// completionProgramName is the program the completions are registered for. The root command is named after
+// the eksctl subcommand, but the shells complete the eksctl-anywhere binary the plugin is installed as
+const completionProgramName = "eksctl-anywhere"
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate the autocompletion script for the specified shell",
+	Long: `This command prints the autocompletion script of eksctl-anywhere for the specified shell.
+For example, to load the completions in the current bash session run:
+
+  source <(eksctl-anywhere completion bash)
+
+See each shell's documentation on how to load them in every new session`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.ExactValidArgs(1),
+	DisableFlagsInUseLine: true,
+	SilenceUsage:          true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return generateCompletion(cmd.Root(), args[0], os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+func generateCompletion(root *cobra.Command, shell string, w io.Writer) error {
+	root.Use = completionProgramName
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %s", shell)
+	}
+}
+
+// registerFlagValues completes a flag with a fixed list of values, so shells don't suggest file names for it
+func registerFlagValues(cmd *cobra.Command, flagName string, values ...string) error {
+	return cmd.RegisterFlagCompletionFunc(flagName, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	})
+}
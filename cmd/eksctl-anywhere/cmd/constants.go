@@ -1,5 +1,17 @@
 package cmd
 
+import (
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
 const (
 	kubeconfigPattern = "%s-eks-a-cluster.kubeconfig"
+	// clusterConfigFileUsage is the help of the -f flag of the cluster config
+	clusterConfigFileUsage = "Filename that contains EKS-A cluster configuration, or - to read it from stdin"
 )
+
+// defaultKubeconfig returns the kubeconfig the CLI writes for a cluster, in the cluster folder of the workspace
+func defaultKubeconfig(clusterName string) string {
+	return kubeconfig.FromClusterFolder(workspace.Default().Dir(), clusterName)
+}
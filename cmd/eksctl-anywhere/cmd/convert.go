@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert resources",
+	Long:  "Use eksctl anywhere convert to convert resources, such as cluster configs, to a newer api version",
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+}
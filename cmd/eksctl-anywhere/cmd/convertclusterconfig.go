@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type convertClusterConfigOptions struct {
+	fileName string
+	output   string
+}
+
+var cco = &convertClusterConfigOptions{}
+
+var convertClusterConfigCmd = &cobra.Command{
+	Use:          "clusterconfig -f <cluster-config-file>",
+	Short:        "Convert a cluster config file to the v1beta1 api",
+	Long:         "This command converts the v1alpha1 objects in a cluster config file to v1beta1. Kinds without a v1beta1 version are kept as they are",
+	PreRunE:      preRunConvertClusterConfig,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cco.convertClusterConfig()
+	},
+}
+
+func preRunConvertClusterConfig(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	convertCmd.AddCommand(convertClusterConfigCmd)
+	convertClusterConfigCmd.Flags().StringVarP(&cco.fileName, "filename", "f", "", clusterConfigFileUsage)
+	convertClusterConfigCmd.Flags().StringVarP(&cco.output, "output", "o", "", "File to write the converted cluster configuration to. Defaults to stdout")
+	err := convertClusterConfigCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (cco *convertClusterConfigOptions) convertClusterConfig() error {
+	content, err := v1alpha1.ReadClusterConfigFile(cco.fileName)
+	if err != nil {
+		return fmt.Errorf("unable to read file due to: %v", err)
+	}
+
+	converted, err := v1beta1.ConvertClusterConfig(content)
+	if err != nil {
+		return fmt.Errorf("failed to convert cluster config %s: %v", cco.fileName, err)
+	}
+
+	if cco.output == "" {
+		fmt.Print(string(converted))
+		return nil
+	}
+
+	if err = ioutil.WriteFile(cco.output, converted, 0o644); err != nil {
+		return fmt.Errorf("failed writing converted cluster config: %v", err)
+	}
+	logger.MarkSuccess("Cluster config converted", "file", cco.output)
+
+	return nil
+}
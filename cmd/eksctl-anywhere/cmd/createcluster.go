@@ -9,16 +9,24 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
 	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 )
 
 type createClusterOptions struct {
 	clusterOptions
+	confirmOptions
+	healthProbeOptions
+	notificationOptions
+	policyOptions
+	validationReportOptions
 	forceClean       bool
 	skipIpCheck      bool
 	hardwareFileName string
@@ -33,6 +41,11 @@ var createClusterCmd = &cobra.Command{
 	PreRunE:      preRunCreateCluster,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		removeProfileConfig, err := cc.applyProfile(cmd.Flags())
+		if err != nil {
+			return err
+		}
+		defer removeProfileConfig()
 		if err := cc.validate(cmd.Context()); err != nil {
 			return err
 		}
@@ -45,14 +58,24 @@ var createClusterCmd = &cobra.Command{
 
 func init() {
 	createCmd.AddCommand(createClusterCmd)
-	createClusterCmd.Flags().StringVarP(&cc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	createClusterCmd.Flags().StringVarP(&cc.fileName, "filename", "f", "", clusterConfigFileUsage)
 	if features.IsActive(features.TinkerbellProvider()) {
 		createClusterCmd.Flags().StringVarP(&cc.hardwareFileName, "hardwarefile", "w", "", "Filename that contains datacenter hardware information")
 	}
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
+	cc.confirmOptions.addFlags(createClusterCmd.Flags())
+	cc.policyOptions.addFlags(createClusterCmd.Flags())
+	cc.notificationOptions.addFlags(createClusterCmd.Flags())
+	cc.healthProbeOptions.addFlags(createClusterCmd.Flags())
+	cc.validationReportOptions.addFlags(createClusterCmd.Flags())
+	cc.addProfileFlags(createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	createClusterCmd.Flags().StringVar(&cc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	createClusterCmd.Flags().StringVar(&cc.artifactsDir, "artifacts-dir", "", "Directory extracted from the 'eksctl anywhere download artifacts' tarball. Manifests are read from it instead of downloaded, for creates without network access")
+	createClusterCmd.Flags().BoolVar(&cc.strictAirGap, "strict-air-gap", false, strictAirGapUsage)
+	createClusterCmd.Flags().BoolVar(&cc.allowSinglePointsOfFailure, "allow-single-points-of-failure", false, allowSinglePointsOfFailureUsage)
+	createClusterCmd.Flags().DurationVar(&cc.timeout, "timeout", 0, "Maximum time the whole operation can take, for example 90m. When exceeded, the task running at that point fails (default no timeout)")
 	err := createClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -70,30 +93,87 @@ func preRunCreateCluster(cmd *cobra.Command, args []string) error {
 }
 
 func (cc *createClusterOptions) validate(ctx context.Context) error {
-	clusterConfig, err := commonValidation(ctx, cc.fileName)
-	if err != nil {
-		return err
+	_, err := commonValidation(ctx, cc.fileName)
+	return err
+}
+
+// resumingCreate checks if a previous run left a bootstrap cluster holding the cluster state, for example
+// when the admin machine rebooted in the middle of the create. Only then the cluster files can already exist.
+// It fails early on the bootstrap cluster and the cluster folder of another cluster with the same name
+func (cc *createClusterOptions) resumingCreate(ctx context.Context, b *bootstrapper.Bootstrapper, clusterSpec *cluster.Spec) (bool, error) {
+	resuming := false
+	if clusterSpec.ManagementCluster == nil && !cc.forceClean {
+		existing, err := b.GetExistingBootstrapCluster(ctx, clusterSpec.Name)
+		if err != nil {
+			return false, fmt.Errorf("%v, try rerunning with --force-cleanup to force delete previously created bootstrap cluster", err)
+		}
+		if err = existing.Collision(); err != nil {
+			return false, err
+		}
+		resuming = existing != nil && existing.CAPICluster != nil
 	}
-	if validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, "", kubeconfigPattern) {
-		return fmt.Errorf("old cluster config file exists under %s, please use a different clusterName to proceed", clusterConfig.Name)
+
+	if !resuming {
+		if err := validations.ValidateClusterFolder(workspace.Default().ClusterDir(clusterSpec.Name), clusterSpec.Cluster, kubeconfigPattern); err != nil {
+			return false, err
+		}
 	}
-	return nil
+	return resuming, nil
 }
 
 func (cc *createClusterOptions) createCluster(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 
-	clusterSpec, err := newClusterSpec(cc.clusterOptions)
+	localArtifacts, err := cc.localArtifacts()
 	if err != nil {
 		return err
 	}
+	var specOpts []cluster.SpecOpt
+	if localArtifacts != nil {
+		specOpts = append(specOpts, cluster.WithLocalArtifacts(cc.artifactsDir, localArtifacts))
+	} else if cc.managementKubeconfig != "" && cc.bundlesOverride == "" {
+		specOpts = clusterManifestsSpecOpts(ctx, cc.managementKubeconfig)
+	}
+	clusterSpec, err := newClusterSpec(cc.clusterOptions, specOpts...)
+	if err != nil {
+		return err
+	}
+	if err = cc.validateStrictAirGap(clusterSpec, localArtifacts); err != nil {
+		return err
+	}
+	healthProbe, err := cc.startHealthProbe("create", clusterSpec.Name)
+	if err != nil {
+		return err
+	}
+	defer stopHealthProbe(ctx, healthProbe)
+
+	if cc.forceClean && clusterSpec.ManagementCluster == nil {
+		if err = cc.confirmBootstrapCleanup(clusterSpec.Name); err != nil {
+			return err
+		}
+	}
 
-	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(cc.mountDirs()...).
-		WithBootstrapper().
+	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(cc.mountDirs()...).
+		WithBootstrapper()
+	bootstrapDeps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	resuming, err := cc.resumingCreate(ctx, bootstrapDeps.Bootstrapper, clusterSpec)
+	if err != nil {
+		close(ctx, bootstrapDeps)
+		return err
+	}
+
+	// The control plane ip of a resumed create is already taken by its own control plane
+	deps, err := factory.
+		WithPolicyBundles(cc.policyBundles...).
 		WithClusterManager(clusterSpec.Cluster).
-		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck, cc.hardwareFileName).
+		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck || resuming, cc.hardwareFileName).
 		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
 		WithWriter().
+		WithArtifactStore().
 		Build(ctx)
 	if err != nil {
 		return err
@@ -118,12 +198,21 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command) error {
 		}
 	}
 
+	notificationOpts, err := cc.notificationOptions.workflowOpts(clusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
+	resultFile := operationResultFile(clusterSpec.Name, "create")
+	workflowOpts := append([]workflows.Opt{workflows.WithTimeout(cc.timeout), workflows.WithResultFile(resultFile)}, notificationOpts...)
+	workflowOpts = append(workflowOpts, cc.healthProbeOptions.workflowOpts(healthProbe)...)
+	workflowOpts = append(workflowOpts, cc.validationReportOptions.workflowOpts()...)
 	createCluster := workflows.NewCreate(
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
 		deps.FluxAddonClient,
 		deps.Writer,
+		workflowOpts...,
 	)
 
 	var cluster *types.Cluster
@@ -139,6 +228,12 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command) error {
 		}
 	}
 
+	clusterLock, err := lockCluster(ctx, deps.Kubectl, clusterSpec.Name, "create", clusterSpec.ManagementCluster)
+	if err != nil {
+		return err
+	}
+	defer unlockCluster(ctx, clusterLock)
+
 	validationOpts := &validations.Opts{
 		Kubectl: deps.Kubectl,
 		Spec:    clusterSpec,
@@ -146,11 +241,13 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command) error {
 			Name:           clusterSpec.Name,
 			KubeconfigFile: uc.kubeConfig(clusterSpec.Name),
 		},
-		ManagementCluster: cluster,
-		Provider:          deps.Provider,
+		ManagementCluster:          cluster,
+		Provider:                   deps.Provider,
+		AllowSinglePointsOfFailure: cc.allowSinglePointsOfFailure,
+		LocalArtifacts:             localArtifacts,
 	}
 	createValidations := createvalidations.New(validationOpts)
 
 	err = createCluster.Run(ctx, clusterSpec, createValidations, cc.forceClean)
-	return err
+	return workflowError(createCluster.Result(), resultFile, err)
 }
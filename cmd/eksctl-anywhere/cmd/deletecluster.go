@@ -9,20 +9,36 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/prompt"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 )
 
 type deleteClusterOptions struct {
 	clusterOptions
+	backupOptions
+	evictionOptions
+	confirmOptions
+	bmcOptions
+	notificationOptions
 	wConfig          string
 	forceCleanup     bool
 	hardwareFileName string
 }
 
+func (dc *deleteClusterOptions) kubeConfig(clusterName string) string {
+	if dc.wConfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return dc.wConfig
+}
+
 var dc = &deleteClusterOptions{}
 
 var deleteClusterCmd = &cobra.Command{
@@ -56,8 +72,16 @@ func init() {
 	deleteCmd.AddCommand(deleteClusterCmd)
 	deleteClusterCmd.Flags().StringVarP(&dc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration, required if <cluster-name> is not provided")
 	deleteClusterCmd.Flags().StringVarP(&dc.wConfig, "w-config", "w", "", "Kubeconfig file to use when deleting a workload cluster")
-	deleteClusterCmd.Flags().BoolVar(&dc.forceCleanup, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
+	deleteClusterCmd.Flags().BoolVar(&dc.forceCleanup, "force-cleanup", false, "Force deletion of previously created bootstrap cluster, unless it holds the state of a failed delete, which is resumed instead")
 	deleteClusterCmd.Flags().StringVar(&dc.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to a management cluster")
+	dc.backupOptions.addFlags(deleteClusterCmd.Flags(), "delete")
+	dc.evictionOptions.addFlags(deleteClusterCmd.Flags())
+	dc.confirmOptions.addFlags(deleteClusterCmd.Flags())
+	dc.notificationOptions.addFlags(deleteClusterCmd.Flags())
+	if features.IsActive(features.TinkerbellProvider()) {
+		dc.bmcOptions.addFlags(deleteClusterCmd.Flags(), "Hardware inventory of the cluster machines, to power them off through their BMC once the cluster is deleted")
+	}
+	deleteClusterCmd.Flags().DurationVar(&dc.timeout, "timeout", 0, "Maximum time the whole operation can take, for example 90m. When exceeded, the task running at that point fails (default no timeout)")
 	deleteClusterCmd.Flags().StringVar(&dc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 }
 
@@ -77,17 +101,54 @@ func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) err
 	if err != nil {
 		return err
 	}
-	if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, dc.wConfig, kubeconfigPattern) {
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterConfig.Name), clusterConfig.Name, dc.wConfig, kubeconfigPattern) {
 		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
 	}
 	return nil
 }
 
+// destruction summarizes everything the delete removes, for the confirmation
+func (dc *deleteClusterOptions) destruction(clusterSpec *cluster.Spec) prompt.Destruction {
+	resources := []string{fmt.Sprintf("cluster %s and all its machines", clusterSpec.Name)}
+	if clusterSpec.ManagementCluster != nil {
+		resources = append(resources, fmt.Sprintf("cluster objects of %s in management cluster %s", clusterSpec.Name, clusterSpec.ManagementCluster.Name))
+	}
+	if clusterSpec.GitOpsConfig != nil {
+		resources = append(resources, fmt.Sprintf("cluster config of %s in the GitOps repository", clusterSpec.Name))
+	}
+	if dc.evictWorkloads {
+		resources = append(resources, fmt.Sprintf("LoadBalancer services and pods with persistent volumes of cluster %s, evicted before deleting the machines", clusterSpec.Name))
+	}
+	if n := dc.machinesWithBMC(); n > 0 {
+		resources = append(resources, fmt.Sprintf("power of the %d machines with a BMC in hardware inventory %s, they will be powered off", n, dc.inventory))
+	}
+	if dc.forceCleanup {
+		resources = append(resources, fmt.Sprintf("bootstrap cluster %s-eks-a-cluster left by a previous run, unless it holds the state of a failed delete", clusterSpec.Name))
+	}
+
+	return prompt.Destruction{
+		Action:    "Delete cluster " + clusterSpec.Name,
+		Resources: resources,
+	}
+}
+
 func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 	clusterSpec, err := newClusterSpec(dc.clusterOptions)
 	if err != nil {
 		return fmt.Errorf("unable to get cluster config from file: %v", err)
 	}
+	if err = dc.readInventory(); err != nil {
+		return err
+	}
+
+	confirmed, err := dc.confirm(dc.destruction(clusterSpec))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		logger.Info("Skipping cluster deletion")
+		return nil
+	}
 
 	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(cc.mountDirs()...).
 		WithBootstrapper().
@@ -95,6 +156,10 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 		WithProvider(dc.fileName, clusterSpec.Cluster, cc.skipIpCheck, dc.hardwareFileName).
 		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
 		WithWriter().
+		WithArtifactStore().
+		WithVelero().
+		WithKubectl().
+		WithIpmitool().
 		Build(ctx)
 	if err != nil {
 		return err
@@ -105,11 +170,28 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 		return fmt.Errorf("Error: provider tinkerbell is not supported in this release")
 	}
 
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: dc.kubeConfig(clusterSpec.Name),
+	}
+
+	resultFile := operationResultFile(clusterSpec.Name, "delete")
+	workflowOpts := append([]workflows.Opt{workflows.WithTimeout(dc.timeout), workflows.WithResultFile(resultFile)}, dc.backupOptions.workflowOpts(deps.Velero, workloadCluster)...)
+	workflowOpts = append(workflowOpts, dc.evictionOptions.workflowOpts(deps.Kubectl, workloadCluster)...)
+	if len(dc.machines) > 0 {
+		workflowOpts = append(workflowOpts, workflows.WithMachinesPowerOff(dc.powerManager(deps.Ipmitool)))
+	}
+	notificationOpts, err := dc.notificationOptions.workflowOpts(clusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
+	workflowOpts = append(workflowOpts, notificationOpts...)
 	deleteCluster := workflows.NewDelete(
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
 		deps.FluxAddonClient,
+		workflowOpts...,
 	)
 
 	var cluster *types.Cluster
@@ -126,5 +208,5 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 	}
 
 	err = deleteCluster.Run(ctx, cluster, clusterSpec, dc.forceCleanup, dc.managementKubeconfig)
-	return err
+	return workflowError(deleteCluster.Result(), resultFile, err)
 }
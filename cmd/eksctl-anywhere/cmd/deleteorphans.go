@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/gc"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/prompt"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+type deleteOrphansOptions struct {
+	clusterOptions
+	wConfig string
+	all     bool
+	confirmOptions
+	dryRun bool
+}
+
+func (do *deleteOrphansOptions) kubeConfig(clusterName string) string {
+	if do.wConfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return do.wConfig
+}
+
+// managementCluster returns the cluster where the CAPI Machines of the cluster live, nil when all
+// the resources of the cluster should be considered orphans
+func (do *deleteOrphansOptions) managementCluster(clusterSpec *cluster.Spec) (*types.Cluster, error) {
+	if do.all {
+		return nil, nil
+	}
+	if clusterSpec.ManagementCluster != nil {
+		return &types.Cluster{
+			Name:           clusterSpec.ManagementCluster.Name,
+			KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+		}, nil
+	}
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterSpec.Name), clusterSpec.Name, do.wConfig, kubeconfigPattern) {
+		return nil, fmt.Errorf("KubeConfig doesn't exists for cluster %s, use --kubeconfig for a workload cluster or --all if the cluster was deleted", clusterSpec.Name)
+	}
+	return &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: do.kubeConfig(clusterSpec.Name),
+	}, nil
+}
+
+var dor = &deleteOrphansOptions{}
+
+var deleteOrphansCmd = &cobra.Command{
+	Use:          "orphans -f <cluster-config-file>",
+	Short:        "Orphaned infrastructure resources",
+	Long:         "This command finds the infrastructure resources tagged for a cluster that don't back any of its machines, usually left behind by failed operations, and deletes them after confirmation",
+	PreRunE:      preRunDeleteOrphans,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := dor.deleteOrphans(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to delete orphaned resources: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunDeleteOrphans(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteOrphansCmd)
+	deleteOrphansCmd.Flags().StringVarP(&dor.fileName, "filename", "f", "", clusterConfigFileUsage)
+	deleteOrphansCmd.Flags().StringVarP(&dor.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster, when it manages itself")
+	deleteOrphansCmd.Flags().StringVar(&dor.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	deleteOrphansCmd.Flags().BoolVar(&dor.all, "all", false, "Consider all the resources of the cluster orphans, for clusters that no longer exist")
+	deleteOrphansCmd.Flags().BoolVar(&dor.dryRun, "dry-run", false, "Only report the orphaned resources, without deleting them")
+	dor.confirmOptions.addFlags(deleteOrphansCmd.Flags())
+	err := deleteOrphansCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (do *deleteOrphansOptions) deleteOrphans(ctx context.Context) error {
+	clusterConfig, err := commonValidation(ctx, do.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+
+	clusterSpec, err := newClusterSpec(do.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	managementCluster, err := do.managementCluster(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(do.mountDirs()...).
+		WithProvider(do.fileName, clusterConfig, true, "").
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	collector := gc.NewCollector(deps.Provider, deps.Kubectl)
+	orphans, err := collector.FindOrphans(ctx, clusterSpec.Name, managementCluster)
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		logger.MarkSuccess("No orphaned resources found", "cluster", clusterSpec.Name)
+		return nil
+	}
+
+	logger.Info("Found orphaned resources", "cluster", clusterSpec.Name, "count", len(orphans))
+	for _, r := range orphans {
+		logger.Info(fmt.Sprintf("  %s %s", r.Kind, r.Name))
+	}
+
+	if do.dryRun {
+		return nil
+	}
+
+	// The orphans were already listed above, also with --yes
+	confirmed, err := do.confirm(prompt.Destruction{Action: fmt.Sprintf("Delete %d orphaned resources of cluster %s", len(orphans), clusterSpec.Name)})
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		logger.Info("Skipping deletion")
+		return nil
+	}
+
+	if err = collector.Delete(ctx, orphans); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Orphaned resources deleted", "count", len(orphans))
+	return nil
+}
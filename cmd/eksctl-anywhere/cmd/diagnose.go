@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Diagnose resources",
+	Long:  "Use eksctl anywhere diagnose to check the state of the resources of a cluster, such as its bare metal machines or the logs of its nodes",
+}
+
+func init() {
+	rootCmd.AddCommand(diagnoseCmd)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/bmc"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type diagnoseHardwareOptions struct {
+	bmcOptions
+	powerCycle []string
+}
+
+var dho = &diagnoseHardwareOptions{}
+
+var diagnoseHardwareCmd = &cobra.Command{
+	Use:          "hardware --hardware-inventory <hardware-inventory-file>",
+	Short:        "Check the power of bare metal machines through their BMC",
+	Long:         "This command reports the power state of every machine with a BMC in a hardware inventory, and power cycles the stuck machines given with --power-cycle",
+	PreRunE:      preRunDiagnoseHardware,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return dho.diagnoseHardware(cmd.Context())
+	},
+}
+
+func preRunDiagnoseHardware(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	diagnoseCmd.AddCommand(diagnoseHardwareCmd)
+	dho.bmcOptions.addFlags(diagnoseHardwareCmd.Flags(), "Csv or yaml file with the hardware inventory")
+	diagnoseHardwareCmd.Flags().StringSliceVar(&dho.powerCycle, "power-cycle", nil, "Ids of the stuck machines to power cycle, the machines that are off are powered on")
+	err := diagnoseHardwareCmd.MarkFlagRequired("hardware-inventory")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (dho *diagnoseHardwareOptions) diagnoseHardware(ctx context.Context) error {
+	if err := dho.readInventory(); err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().WithIpmitool().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	power := dho.powerManager(deps.Ipmitool)
+	for _, id := range dho.powerCycle {
+		if err = power.PowerCycle(ctx, id); err != nil {
+			return err
+		}
+		logger.Info("Machine power cycled", "machine", id)
+	}
+
+	states := power.States(ctx)
+	if err = printMachinePower(states); err != nil {
+		return err
+	}
+
+	unreachable := 0
+	for _, s := range states {
+		if s.Err != nil {
+			unreachable++
+		}
+	}
+	if unreachable > 0 {
+		return fmt.Errorf("%d of %d BMCs couldn't be reached", unreachable, len(states))
+	}
+
+	return nil
+}
+
+func printMachinePower(states []bmc.MachinePower) error {
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "MACHINE\tHOSTNAME\tBMC\tPROTOCOL\tPOWER\tERROR")
+	for _, s := range states {
+		errMessage := ""
+		if s.Err != nil {
+			errMessage = s.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", s.Machine.ID, s.Machine.Hostname, s.Machine.BMCIPAddress, s.Machine.Protocol(), s.State, errMessage)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return nil
+}
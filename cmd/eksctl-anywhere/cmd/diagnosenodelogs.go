@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+type diagnoseNodeLogsOptions struct {
+	nodeSSHOptions
+	fileName  string
+	wConfig   string
+	nodes     []string
+	since     string
+	sinceTime string
+}
+
+var dnlo = &diagnoseNodeLogsOptions{}
+
+var diagnoseNodeLogsCmd = &cobra.Command{
+	Use:          "node-logs -f <cluster-config-file> --ssh-key <private-key>",
+	Short:        "Collect the kubelet and containerd logs of the cluster nodes over SSH",
+	Long:         "This command collects the kubelet and containerd logs of the cluster nodes over SSH, through a bastion host when the nodes aren't reachable from the admin machine, for nodes that can't be diagnosed through the API server",
+	PreRunE:      preRunDiagnoseNodeLogs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return dnlo.collectNodeLogs(cmd.Context())
+	},
+}
+
+func preRunDiagnoseNodeLogs(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	diagnoseCmd.AddCommand(diagnoseNodeLogsCmd)
+	diagnoseNodeLogsCmd.Flags().StringVarP(&dnlo.fileName, "filename", "f", "", clusterConfigFileUsage)
+	diagnoseNodeLogsCmd.Flags().StringVarP(&dnlo.wConfig, "w-config", "w", "", "Kubeconfig file to list the nodes of a workload cluster")
+	diagnoseNodeLogsCmd.Flags().StringSliceVar(&dnlo.nodes, "nodes", nil, "Addresses of the nodes to collect the logs from (default the InternalIP of every node of the cluster)")
+	diagnoseNodeLogsCmd.Flags().StringVar(&dnlo.since, "since", "", "Collect the logs in the latest duration like 5s, 2m, or 3h")
+	diagnoseNodeLogsCmd.Flags().StringVar(&dnlo.sinceTime, "since-time", "", "Collect the logs after a specific datetime(RFC3339) like 2021-06-28T15:04:05Z")
+	dnlo.nodeSSHOptions.addFlags(diagnoseNodeLogsCmd.Flags())
+	err := diagnoseNodeLogsCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *diagnoseNodeLogsOptions) kubeConfig(clusterName string) string {
+	if o.wConfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return o.wConfig
+}
+
+func (o *diagnoseNodeLogsOptions) collectNodeLogs(ctx context.Context) error {
+	if err := o.nodeSSHOptions.validate(); err != nil {
+		return err
+	}
+	clusterConfig, err := v1alpha1.GetClusterConfig(o.fileName)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster config from file: %v", err)
+	}
+	sinceTime, err := diagnostics.ParseTimeOptions(o.since, o.sinceTime)
+	if err != nil {
+		return fmt.Errorf("failed parse since time: %v", err)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(o.keyDirs()...).
+		WithWriterFolder(workspace.Default().ClusterDir(clusterConfig.Name)).
+		WithWriter().
+		WithKubectl().
+		WithSSH().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	nodes := o.nodes
+	if len(nodes) == 0 {
+		if nodes, err = nodeAddresses(ctx, deps.Kubectl, o.kubeConfig(clusterConfig.Name)); err != nil {
+			return err
+		}
+	}
+
+	collector := diagnostics.NewNodeLogsCollector(deps.SSH, deps.Writer, o.bastion())
+	failed := 0
+	for _, address := range nodes {
+		folder, err := collector.Collect(ctx, o.node(address), sinceTime)
+		if err != nil {
+			logger.Info("Failed collecting node logs", "node", address, "error", err)
+			failed++
+			continue
+		}
+		logger.Info("Node logs collected", "node", address, "folder", folder)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed collecting the logs of %d of %d nodes", failed, len(nodes))
+	}
+
+	return nil
+}
+
+// nodeAddresses returns the InternalIP of the cluster nodes
+func nodeAddresses(ctx context.Context, kubectl *executables.Kubectl, kubeconfig string) ([]string, error) {
+	nodes, err := kubectl.GetNodes(ctx, executables.WithKubeconfig(kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed listing the cluster nodes, use --nodes to give their addresses: %v", err)
+	}
+
+	addresses := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				addresses = append(addresses, address.Address)
+				break
+			}
+		}
+	}
+	return addresses, nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Diff resources",
+	Long:  "Use eksctl anywhere diff to compare what different versions of EKS Anywhere ship",
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/compatibility"
+	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type diffBundlesOptions struct {
+	from        string
+	to          string
+	fromBundles string
+	toBundles   string
+	output      string
+}
+
+var dbo = &diffBundlesOptions{}
+
+func init() {
+	diffCmd.AddCommand(diffBundlesCmd)
+	diffBundlesCmd.Flags().StringVar(&dbo.from, "from", "", "EKS Anywhere version to diff from, like v0.7.0")
+	diffBundlesCmd.Flags().StringVar(&dbo.to, "to", "", "EKS Anywhere version to diff to, defaults to the version of the CLI")
+	diffBundlesCmd.Flags().StringVar(&dbo.fromBundles, "from-bundles", "", "Bundles manifest to diff from instead of the one of the --from version")
+	diffBundlesCmd.Flags().StringVar(&dbo.toBundles, "to-bundles", "", "Bundles manifest to diff to instead of the one of the --to version")
+	diffBundlesCmd.Flags().StringVarP(&dbo.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	if err := registerFlagValues(diffBundlesCmd, outputFlagName, outputText, outputJson); err != nil {
+		log.Fatalf("Error registering output completion: %v", err)
+	}
+}
+
+var diffBundlesCmd = &cobra.Command{
+	Use:   "bundles",
+	Short: "Diff the components and images of the bundles of two EKS Anywhere versions",
+	Long:  "This command lists, per Kubernetes version, the components whose version or images change between the bundles of two EKS Anywhere versions, so the images a version bump pulls can be reviewed before upgrading",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if err := viper.BindPFlag(flag.Name, flag); err != nil {
+				log.Fatalf("Error initializing flags: %v", err)
+			}
+		})
+		return nil
+	},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return diffBundles(dbo, os.Stdout)
+	},
+}
+
+func diffBundles(options *diffBundlesOptions, w io.Writer) error {
+	if options.from == "" && options.fromBundles == "" {
+		return fmt.Errorf("either --from or --from-bundles is required")
+	}
+
+	from, err := loadBundles(options.from, options.fromBundles)
+	if err != nil {
+		return err
+	}
+	to, err := loadBundles(options.to, options.toBundles)
+	if err != nil {
+		return err
+	}
+
+	diff := compatibility.DiffBundles(from, to)
+	switch options.output {
+	case outputText:
+		return writeBundlesDiffText(diff, w)
+	case outputJson:
+		return writeBundlesDiffJson(diff, w)
+	default:
+		return fmt.Errorf("invalid output format [%s]", options.output)
+	}
+}
+
+// loadBundles reads the bundles manifest if set, otherwise the bundles of the EKS Anywhere version, the CLI one if empty
+func loadBundles(eksaVersion, bundlesManifest string) (*releasev1alpha1.Bundles, error) {
+	cliVersion := version.Get()
+	if eksaVersion != "" {
+		cliVersion = version.Info{GitVersion: eksaVersion}
+	}
+	source := "version " + cliVersion.GitVersion
+	var specOpts []cluster.SpecOpt
+	if bundlesManifest != "" {
+		source = bundlesManifest
+		specOpts = append(specOpts, cluster.WithOverrideBundlesManifest(bundlesManifest))
+	}
+
+	bundles, err := cluster.LoadBundles(cliVersion, specOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get bundles for %s: %v", source, err)
+	}
+	return bundles, nil
+}
+
+func writeBundlesDiffJson(diff *compatibility.BundlesDiff, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(diff); err != nil {
+		return fmt.Errorf("failed serializing bundles diff: %v", err)
+	}
+	return nil
+}
+
+func writeBundlesDiffText(diff *compatibility.BundlesDiff, w io.Writer) error {
+	fmt.Fprintf(w, "Bundles: %d -> %d\n", diff.FromNumber, diff.ToNumber)
+	if diff.Empty() {
+		fmt.Fprintln(w, "No component or image changes")
+		return nil
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "KUBERNETES VERSION\tCOMPONENT\tCHANGE\tFROM\tTO")
+	for _, v := range diff.KubernetesVersions {
+		kubeVersion := v.KubernetesVersion
+		if v.Change != compatibility.Changed {
+			kubeVersion = fmt.Sprintf("%s (%s)", kubeVersion, v.Change)
+		}
+		for _, c := range v.Components {
+			if c.FromVersion != c.ToVersion {
+				fmt.Fprintf(tw, "%s\t%s\tversion\t%s\t%s\n", kubeVersion, c.Name, orNone(c.FromVersion), orNone(c.ToVersion))
+			}
+			for _, i := range c.Images {
+				fmt.Fprintf(tw, "%s\t%s\timage %s %s\t%s\t%s\n", kubeVersion, c.Name, i.Name, i.Change, orNone(i.From), orNone(i.To))
+			}
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+	return nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
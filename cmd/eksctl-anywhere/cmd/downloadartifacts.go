@@ -33,7 +33,7 @@ var downloadArtifactsopts = &downloadArtifactsOptions{}
 
 func init() {
 	downloadCmd.AddCommand(downloadArtifactsCmd)
-	downloadArtifactsCmd.Flags().StringVarP(&downloadArtifactsopts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	downloadArtifactsCmd.Flags().StringVarP(&downloadArtifactsopts.fileName, "filename", "f", "", clusterConfigFileUsage)
 	downloadArtifactsCmd.Flags().StringVarP(&downloadArtifactsopts.downloadDir, "download-dir", "d", "eks-anywhere-downloads", "Directory to download the artifacts to")
 	downloadArtifactsCmd.Flags().BoolVarP(&downloadArtifactsopts.dryRun, "dry-run", "", false, "Print the manifest URIs without downloading them")
 	downloadArtifactsCmd.Flags().BoolVarP(&downloadArtifactsopts.retainDir, "retain-dir", "r", false, "Do not delete the download folder after creating a tarball")
@@ -75,12 +75,13 @@ func downloadArtifacts(context context.Context, opts *downloadArtifactsOptions)
 		clusterSpec.GetReleaseManifestUrl(),
 	}
 	reader := files.NewReader(files.WithUserAgent(fmt.Sprintf("eks-a-cli-download/%s", version.Get().GitVersion)))
+	index := files.NewArtifactsIndex()
 	for _, manifestURI := range specManifests {
 		if opts.dryRun {
 			logger.Info(fmt.Sprintf("Found artifact: %s\n", manifestURI))
 			continue
 		}
-		if err = downloadArtifact("", opts.downloadDir, manifestURI, reader); err != nil {
+		if err = downloadArtifact("", opts.downloadDir, manifestURI, reader, index); err != nil {
 			return fmt.Errorf("error downloading artifact: %v", err)
 		}
 	}
@@ -92,13 +93,17 @@ func downloadArtifacts(context context.Context, opts *downloadArtifactsOptions)
 				logger.Info(fmt.Sprintf("Found artifact: %s\n", manifest.URI))
 				continue
 			}
-			if err = downloadArtifact(component, opts.downloadDir, manifest.URI, reader); err != nil {
+			if err = downloadArtifact(component, opts.downloadDir, manifest.URI, reader, index); err != nil {
 				return fmt.Errorf("error downloading artifact for component %s: %v", component, err)
 			}
 		}
 	}
 
 	if !opts.dryRun {
+		if err = index.Write(opts.downloadDir); err != nil {
+			return err
+		}
+
 		if err = createTarball(opts.downloadDir); err != nil {
 			return err
 		}
@@ -122,17 +127,12 @@ func preRunDownloadArtifactsCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func downloadArtifact(component, downloadDir, artifactUri string, reader *files.Reader) error {
+// downloadArtifact downloads the artifact to the component folder in downloadDir and records it in the index
+func downloadArtifact(component, downloadDir, artifactUri string, reader *files.Reader, index *files.ArtifactsIndex) error {
 	logger.V(3).Info(fmt.Sprintf("Downloading artifact: %s", artifactUri))
 
-	fileName := filepath.Base(artifactUri)
-
-	var filePath string
-	if component != "" {
-		filePath = filepath.Join(downloadDir, component, fileName)
-	} else {
-		filePath = filepath.Join(downloadDir, fileName)
-	}
+	relativePath := filepath.Join(component, filepath.Base(artifactUri))
+	filePath := filepath.Join(downloadDir, relativePath)
 	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
 		return err
 	}
@@ -147,6 +147,7 @@ func downloadArtifact(component, downloadDir, artifactUri string, reader *files.
 		return err
 	}
 
+	index.Add(artifactUri, relativePath)
 	logger.V(3).Info(fmt.Sprintf("Successfully downloaded artifact %s to %s", artifactUri, filePath))
 
 	return nil
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+// Exit codes of eksctl anywhere. They are part of the CLI contract for pipelines, so existing codes must not change
+const (
+	ExitSuccess            = 0
+	ExitFailure            = 1
+	ExitValidationFailed   = 2
+	ExitProviderAuthFailed = 3
+	ExitTimeout            = 4
+	ExitPartialCreate      = 5
+	ExitInterrupted        = 130
+)
+
+var reasonExitCodes = map[workflows.FailureReason]int{
+	workflows.ReasonValidationFailed:   ExitValidationFailed,
+	workflows.ReasonProviderAuthFailed: ExitProviderAuthFailed,
+	workflows.ReasonTimeout:            ExitTimeout,
+	workflows.ReasonPartialCreate:      ExitPartialCreate,
+}
+
+// exitCodeError carries the exit code of a failed command up to main
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the code the CLI exits with after a command returns err
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
+	}
+	return ExitFailure
+}
+
+// workflowError finishes a cluster operation: in quiet mode it prints the path of the result document, the
+// only output of the command, and it attaches the exit code matching the failure reason of the result to err
+func workflowError(result *workflows.Result, resultFile string, err error) error {
+	if result != nil && viper.GetBool("quiet") {
+		fmt.Println(resultFile)
+	}
+	if err == nil || result == nil {
+		return err
+	}
+	code, ok := reasonExitCodes[result.Reason]
+	if !ok {
+		return err
+	}
+	return &exitCodeError{code: code, err: err}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type exportClusterConfigOptions struct {
+	managementKubeconfig string
+}
+
+var ecc = &exportClusterConfigOptions{}
+
+var exportClusterConfigCmd = &cobra.Command{
+	Use:          "clusterconfig <cluster-name>",
+	Short:        "Export the cluster config of an existing cluster",
+	Long:         "This command rebuilds the cluster config of an existing cluster from the EKS-A objects in its management cluster and prints it to stdout, for clusters whose original config file was lost",
+	PreRunE:      preRunExportClusterConfig,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterName, err := validations.ValidateClusterNameArg(args)
+		if err != nil {
+			return err
+		}
+		if err := ecc.exportClusterConfig(cmd.Context(), clusterName); err != nil {
+			return fmt.Errorf("failed to export cluster config: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunExportClusterConfig(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	exportCmd.AddCommand(exportClusterConfigCmd)
+	exportClusterConfigCmd.Flags().StringVar(&ecc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file, defaults to the kubeconfig in the cluster folder for self managed clusters")
+}
+
+func (ecc *exportClusterConfigOptions) exportClusterConfig(ctx context.Context, clusterName string) error {
+	kubeconfigFile := ecc.managementKubeconfig
+	if kubeconfigFile == "" {
+		kubeconfigFile = defaultKubeconfig(clusterName)
+	}
+	if !validations.FileExists(kubeconfigFile) {
+		return fmt.Errorf("kubeconfig %s for cluster %s not found, provide the management cluster kubeconfig with --kubeconfig", kubeconfigFile, clusterName)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(kubeconfigFile)).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{KubeconfigFile: kubeconfigFile}
+	config, err := clustermarshaller.NewExporter(deps.Kubectl).Export(ctx, managementCluster, clusterName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(config))
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const defaultKubeconfigBundleDir = "eksa-kubeconfigs"
+
+type exportKubeconfigsOptions struct {
+	managementKubeconfig string
+	clusters             []string
+	outputDir            string
+}
+
+var eko = &exportKubeconfigsOptions{}
+
+var exportKubeconfigsCmd = &cobra.Command{
+	Use:          "kubeconfigs",
+	Short:        "Export the kubeconfigs of many clusters to a single directory",
+	Long:         "This command writes the kubeconfig of each cluster with a <cluster-name>-admin@<cluster-name> context, a kubeconfig with all of them merged and an index of the clusters to a directory",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return eko.exportKubeconfigs(cmd.Context())
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportKubeconfigsCmd)
+	exportKubeconfigsCmd.Flags().StringSliceVar(&eko.clusters, "clusters", nil, "Clusters to export (default all the clusters with a folder in the current directory, or the clusters of the management cluster with --kubeconfig)")
+	exportKubeconfigsCmd.Flags().StringVar(&eko.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file, to export the management cluster and all its workload clusters with the kubeconfigs stored in it")
+	exportKubeconfigsCmd.Flags().StringVarP(&eko.outputDir, "output-dir", "o", defaultKubeconfigBundleDir, "Directory to write the kubeconfigs to")
+}
+
+func (o *exportKubeconfigsOptions) exportKubeconfigs(ctx context.Context) error {
+	if o.managementKubeconfig == "" {
+		return o.exportFromClusterFolders()
+	}
+	if !validations.FileExists(o.managementKubeconfig) {
+		return fmt.Errorf("management cluster kubeconfig %s not found", o.managementKubeconfig)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(o.managementKubeconfig)).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	clusters := o.clusters
+	if len(clusters) == 0 {
+		eksaClusters, err := deps.Kubectl.GetEksaClusters(ctx, &types.Cluster{KubeconfigFile: o.managementKubeconfig})
+		if err != nil {
+			return err
+		}
+		for _, c := range eksaClusters {
+			clusters = append(clusters, c.Name)
+		}
+	}
+
+	index, err := kubeconfig.WriteBundleFromManagementCluster(ctx, deps.Kubectl, o.managementKubeconfig, o.outputDir, clusters)
+	if err != nil {
+		return err
+	}
+	logger.MarkSuccess("Kubeconfigs exported", "clusters", len(index.Clusters), "kubeconfig", index.MergedPath())
+	return nil
+}
+
+// exportFromClusterFolders exports the kubeconfigs the CLI wrote to the cluster folders of the current directory,
+// for when there's no management cluster kubeconfig to read them from
+func (o *exportKubeconfigsOptions) exportFromClusterFolders() error {
+	clusters := o.clusters
+	if len(clusters) == 0 {
+		dirs, err := ioutil.ReadDir(".")
+		if err != nil {
+			return err
+		}
+		for _, d := range dirs {
+			if d.IsDir() && validations.FileExists(kubeconfig.FromClusterFolder(".", d.Name())) {
+				clusters = append(clusters, d.Name())
+			}
+		}
+	}
+	if len(clusters) == 0 {
+		return fmt.Errorf("no cluster kubeconfigs found, run the command from the directory with the cluster folders, pass the clusters with --clusters or the management cluster kubeconfig with --kubeconfig")
+	}
+
+	kubeconfigs := make(map[string]string, len(clusters))
+	for _, c := range clusters {
+		file := kubeconfig.FromClusterFolder(".", c)
+		if !validations.FileExists(file) {
+			return fmt.Errorf("kubeconfig %s for cluster %s not found", file, c)
+		}
+		kubeconfigs[c] = file
+	}
+
+	index, err := kubeconfig.WriteBundle(o.outputDir, kubeconfigs)
+	if err != nil {
+		return err
+	}
+	logger.MarkSuccess("Kubeconfigs exported", "clusters", len(index.Clusters), "kubeconfig", index.MergedPath())
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+const defaultWorkspaceArchive = "eksa-workspace.tar.gz"
+
+type exportWorkspaceOptions struct {
+	workspaceDir string
+	clusters     []string
+	output       string
+}
+
+var ewo = &exportWorkspaceOptions{}
+
+var exportWorkspaceCmd = &cobra.Command{
+	Use:          "workspace",
+	Short:        "Export the folders of clusters to a tarball",
+	Long:         "This command writes the cluster folders of the workspace, with their configs, kubeconfigs, generated manifests and operation results, to a tarball that can be imported on another admin machine with eksctl anywhere import workspace",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ewo.exportWorkspace()
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportWorkspaceCmd)
+	exportWorkspaceCmd.Flags().StringVar(&ewo.workspaceDir, "workspace", workspace.Default().Dir(), fmt.Sprintf("Workspace directory with the cluster folders, defaults to $%s or the current directory", workspace.EnvVar))
+	exportWorkspaceCmd.Flags().StringSliceVar(&ewo.clusters, "clusters", nil, "Clusters to export (default all the clusters of the workspace)")
+	exportWorkspaceCmd.Flags().StringVarP(&ewo.output, "output", "o", defaultWorkspaceArchive, "Tarball to write the clusters to")
+}
+
+func (o *exportWorkspaceOptions) exportWorkspace() error {
+	w := workspace.New(o.workspaceDir)
+	clusters := o.clusters
+	if len(clusters) == 0 {
+		var err error
+		if clusters, err = w.Clusters(); err != nil {
+			return err
+		}
+	}
+	if len(clusters) == 0 {
+		return fmt.Errorf("no cluster folders found in workspace %s", o.workspaceDir)
+	}
+
+	f, err := os.OpenFile(o.output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed creating workspace archive: %v", err)
+	}
+	if err = w.Export(f, clusters); err != nil {
+		f.Close()
+		os.Remove(o.output)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed writing workspace archive: %v", err)
+	}
+
+	logger.MarkSuccess("Workspace exported", "clusters", len(clusters), "archive", o.output)
+	logger.Info("The archive has the admin kubeconfigs of the clusters, keep it as safe as the kubeconfigs")
+	return nil
+}
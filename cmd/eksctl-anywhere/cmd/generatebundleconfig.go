@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -14,7 +13,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
-	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
@@ -49,7 +47,7 @@ var generateBundleConfigCmd = &cobra.Command{
 
 func init() {
 	generateCmd.AddCommand(generateBundleConfigCmd)
-	generateBundleConfigCmd.Flags().StringVarP(&gsbo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	generateBundleConfigCmd.Flags().StringVarP(&gsbo.fileName, "filename", "f", "", clusterConfigFileUsage)
 }
 
 func preRunGenerateBundleConfigCmd(cmd *cobra.Command, args []string) error {
@@ -65,8 +63,7 @@ func preRunGenerateBundleConfigCmd(cmd *cobra.Command, args []string) error {
 func (gsbo *generateSupportBundleOptions) validateCmdInput() error {
 	f := gsbo.fileName
 	if f != "" {
-		clusterConfigFileExist := validations.FileExists(f)
-		if !clusterConfigFileExist {
+		if !clusterConfigFileExists(f) {
 			return fmt.Errorf("the cluster config file %s does not exist", f)
 		}
 		_, err := v1alpha1.GetAndValidateClusterConfig(f)
@@ -106,7 +103,7 @@ func (gsbo *generateSupportBundleOptions) generateBundleConfig(ctx context.Conte
 
 func (gsbo *generateSupportBundleOptions) kubeConfig(clusterName string) string {
 	if csbo.wConfig == "" {
-		return filepath.Join(clusterName, fmt.Sprintf(kubeconfigPattern, clusterName))
+		return defaultKubeconfig(clusterName)
 	}
 	return csbo.wConfig
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -30,7 +31,7 @@ var generateClusterConfigCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		err = generateClusterConfig(clusterName)
+		err = generateClusterConfig(cmd.Context(), clusterName)
 		if err != nil {
 			return fmt.Errorf("failed to generate eks-a cluster config: %v", err) // need to have better error handling here in own func
 		}
@@ -54,15 +55,38 @@ func init() {
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
 	}
+	err = generateClusterConfigCmd.RegisterFlagCompletionFunc("provider", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return supportedProviders(), cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		log.Fatalf("Error registering provider completion: %v", err)
+	}
+	generateClusterConfigCmd.Flags().Bool("discover", false, "Query the provider to fill the cluster config with existing values")
+	generateClusterConfigCmd.Flags().BoolP("interactive", "i", false, "Prompt to choose the values found in the provider, implies --discover")
+	generateClusterConfigCmd.Flags().String("control-plane-endpoint", "", "Control plane endpoint host for the cluster")
+	generateClusterConfigCmd.Flags().String("vsphere-server", "", "vCenter server (vsphere only)")
+	generateClusterConfigCmd.Flags().String("thumbprint", "", "vCenter certificate thumbprint (vsphere only)")
+	generateClusterConfigCmd.Flags().Bool("insecure", false, "Skip vCenter certificate verification (vsphere only)")
+	generateClusterConfigCmd.Flags().String("datacenter", "", "vSphere datacenter (vsphere only)")
+	generateClusterConfigCmd.Flags().String("network", "", "vSphere network (vsphere only)")
+	generateClusterConfigCmd.Flags().String("datastore", "", "vSphere datastore (vsphere only)")
+	generateClusterConfigCmd.Flags().String("resource-pool", "", "vSphere resource pool (vsphere only)")
+	generateClusterConfigCmd.Flags().String("folder", "", "vSphere VM folder (vsphere only)")
+	generateClusterConfigCmd.Flags().String("template", "", "vSphere VM template (vsphere only)")
 }
 
-func generateClusterConfig(clusterName string) error {
+func generateClusterConfig(ctx context.Context, clusterName string) error {
 	var resources [][]byte
 	var datacenterYaml []byte
 	var machineGroupYaml [][]byte
 	var clusterConfigOpts []v1alpha1.ClusterGenerateOpt
 	switch strings.ToLower(viper.GetString("provider")) {
 	case constants.DockerProviderName:
+		if discoverEnabled() {
+			if err := discoverDocker(ctx); err != nil {
+				return err
+			}
+		}
 		datacenterConfig := v1alpha1.NewDockerDatacenterConfigGenerate(clusterName)
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
@@ -77,8 +101,19 @@ func generateClusterConfig(clusterName string) error {
 		}
 		datacenterYaml = dcyaml
 	case constants.VSphereProviderName:
-		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpoint())
+		vsphereConfig := vsphereDiscoveryConfigFromFlags()
+		if discoverEnabled() {
+			if err := discoverVSphere(ctx, vsphereConfig); err != nil {
+				return err
+			}
+		}
+		endpoint, err := controlPlaneEndpoint()
+		if err != nil {
+			return err
+		}
+		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpointHost(endpoint))
 		datacenterConfig := v1alpha1.NewVSphereDatacenterConfigGenerate(clusterName)
+		setVSphereDatacenterValues(datacenterConfig, vsphereConfig)
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(2),
@@ -96,6 +131,9 @@ func generateClusterConfig(clusterName string) error {
 		cpMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(clusterName + "-cp")
 		workerMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(clusterName)
 		etcdMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(fmt.Sprintf("%s-etcd", clusterName))
+		for _, m := range []*v1alpha1.VSphereMachineConfigGenerate{cpMachineConfig, workerMachineConfig, etcdMachineConfig} {
+			setVSphereMachineValues(m, vsphereConfig)
+		}
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
 			v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/prompt"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const ubuntuDefaultUser = "capv"
+
+func discoverEnabled() bool {
+	return viper.GetBool("discover") || viper.GetBool("interactive")
+}
+
+// newPrompter writes to stderr so the generated config can still be redirected from stdout
+func newPrompter() *prompt.TerminalPrompter {
+	return prompt.NewTerminalPrompter(os.Stdin, os.Stderr)
+}
+
+func controlPlaneEndpoint() (string, error) {
+	endpoint := viper.GetString("control-plane-endpoint")
+	if endpoint != "" || !viper.GetBool("interactive") {
+		return endpoint, nil
+	}
+
+	return newPrompter().Input("control plane endpoint host")
+}
+
+func vsphereDiscoveryConfigFromFlags() *vsphere.DiscoveryConfig {
+	return &vsphere.DiscoveryConfig{
+		Server:       viper.GetString("vsphere-server"),
+		Thumbprint:   viper.GetString("thumbprint"),
+		Insecure:     viper.GetBool("insecure"),
+		Datacenter:   viper.GetString("datacenter"),
+		Network:      viper.GetString("network"),
+		Datastore:    viper.GetString("datastore"),
+		ResourcePool: viper.GetString("resource-pool"),
+		Folder:       viper.GetString("folder"),
+		Template:     viper.GetString("template"),
+	}
+}
+
+func discoverVSphere(ctx context.Context, config *vsphere.DiscoveryConfig) (err error) {
+	if config.Server == "" && viper.GetBool("interactive") {
+		if config.Server, err = newPrompter().Input("vCenter server"); err != nil {
+			return err
+		}
+	}
+
+	deps, err := dependencies.NewFactory().WithGovc().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup(ctx, deps, &err)
+
+	var prompter vsphere.Prompter
+	if viper.GetBool("interactive") {
+		prompter = newPrompter()
+	}
+
+	return vsphere.NewDiscovery(deps.Govc, prompter).Discover(ctx, config)
+}
+
+func discoverDocker(ctx context.Context) (err error) {
+	deps, err := dependencies.NewFactory().WithDocker().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	return validations.CheckMinimumDockerVersion(ctx, deps.DockerClient)
+}
+
+func setVSphereDatacenterValues(datacenterConfig *v1alpha1.VSphereDatacenterConfigGenerate, config *vsphere.DiscoveryConfig) {
+	datacenterConfig.Spec.Server = config.Server
+	datacenterConfig.Spec.Thumbprint = config.Thumbprint
+	datacenterConfig.Spec.Insecure = config.Insecure
+	datacenterConfig.Spec.Datacenter = config.Datacenter
+	datacenterConfig.Spec.Network = config.Network
+}
+
+func setVSphereMachineValues(machineConfig *v1alpha1.VSphereMachineConfigGenerate, config *vsphere.DiscoveryConfig) {
+	machineConfig.Spec.Datastore = config.Datastore
+	machineConfig.Spec.ResourcePool = config.ResourcePool
+	machineConfig.Spec.Folder = config.Folder
+	machineConfig.Spec.Template = config.Template
+
+	if strings.Contains(strings.ToLower(config.Template), string(v1alpha1.Ubuntu)) {
+		machineConfig.Spec.OSFamily = v1alpha1.Ubuntu
+		machineConfig.Spec.Users[0].Name = ubuntuDefaultUser
+	}
+
+	if discoverEnabled() {
+		// An empty key makes the cli generate a new key pair when creating the cluster
+		machineConfig.Spec.Users[0].SshAuthorizedKeys = []string{""}
+	}
+}
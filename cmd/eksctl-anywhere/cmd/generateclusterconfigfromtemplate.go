@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clustertemplate"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type generateFromTemplateOptions struct {
+	library    string
+	template   string
+	valuesFile string
+	values     map[string]string
+	output     string
+}
+
+var gfto = &generateFromTemplateOptions{}
+
+var generateFromTemplateCmd = &cobra.Command{
+	Use:          "clusterconfig-from-template --library <library> --values <values-file>",
+	Short:        "Generate a cluster config from a template",
+	Long:         "This command instantiates a cluster template of a library with the values of a cluster and validates the resulting cluster config",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return gfto.generate(cmd)
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateFromTemplateCmd)
+	generateFromTemplateCmd.Flags().StringVarP(&gfto.library, "library", "l", "", "Directory or git repository url with the cluster templates. Use <url>//<dir> for a directory inside the repository")
+	generateFromTemplateCmd.Flags().StringVarP(&gfto.template, "template", "t", "", "Name of the template, overrides the template in the values file")
+	generateFromTemplateCmd.Flags().StringVar(&gfto.valuesFile, "values", "", "File with the template name, the values of its variables and the overrides for the cluster")
+	generateFromTemplateCmd.Flags().StringToStringVar(&gfto.values, "set", nil, "Values of the template variables, like --set clusterName=prod-1. They take precedence over the values file")
+	generateFromTemplateCmd.Flags().StringVarP(&gfto.output, "output", "o", "", "File to write the cluster config to. Defaults to stdout")
+	err := generateFromTemplateCmd.MarkFlagRequired("library")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *generateFromTemplateOptions) generate(cmd *cobra.Command) error {
+	values := &clustertemplate.Values{}
+	if o.valuesFile != "" {
+		var err error
+		if values, err = clustertemplate.ReadValues(o.valuesFile); err != nil {
+			return err
+		}
+	}
+	if o.template != "" {
+		values.Template = o.template
+	}
+	if values.Template == "" {
+		return fmt.Errorf("template name is required, set it with --template or in the values file")
+	}
+	if len(o.values) > 0 && values.Values == nil {
+		values.Values = map[string]string{}
+	}
+	for k, v := range o.values {
+		values.Values[k] = v
+	}
+
+	library, err := clustertemplate.NewLibrary(cmd.Context(), o.library)
+	if err != nil {
+		return err
+	}
+	defer library.Close()
+
+	template, err := library.Get(values.Template)
+	if err != nil {
+		return err
+	}
+
+	content, err := template.Instantiate(values)
+	if err != nil {
+		return err
+	}
+
+	if o.output == "" {
+		fmt.Print(string(content))
+		return nil
+	}
+	if err = ioutil.WriteFile(o.output, content, 0o644); err != nil {
+		return fmt.Errorf("failed writing cluster config: %v", err)
+	}
+	logger.MarkSuccess("Cluster config generated from template", "template", template.Name, "file", o.output)
+	return nil
+}
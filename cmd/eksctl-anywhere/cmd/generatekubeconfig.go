@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const defaultCredentialsTTL = time.Hour
+
+type generateKubeconfigOptions struct {
+	clusterName string
+	kubeconfig  string
+	user        string
+	groups      []string
+	ttl         time.Duration
+	outputFile  string
+}
+
+var gko = &generateKubeconfigOptions{}
+
+var generateKubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig",
+	Short: "Generate a kubeconfig with short-lived credentials for a workload cluster",
+	Long: "This command writes a kubeconfig for a user of a workload cluster with a short-lived certificate. The certificate is minted " +
+		"through the CertificateSigningRequest API of the cluster with its admin kubeconfig, read from the management cluster, and embedded in the kubeconfig",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return gko.generateKubeconfig(cmd.Context())
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateKubeconfigCmd)
+	flags := generateKubeconfigCmd.Flags()
+	flags.StringVarP(&gko.clusterName, "cluster-name", "n", "", "Name of the workload cluster")
+	flags.StringVar(&gko.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file of the issuer, its user needs to read the kubeconfig secret of the workload cluster")
+	flags.StringVar(&gko.user, "user", "", "User the certificate is issued to, the RBAC rules of the workload cluster apply to it")
+	flags.StringSliceVar(&gko.groups, "groups", nil, "Groups of the user in the certificate, system: groups like system:masters are rejected")
+	flags.DurationVar(&gko.ttl, "ttl", defaultCredentialsTTL, fmt.Sprintf("Validity of the certificate, between %s and %s", kubeconfig.MinCredentialsTTL, kubeconfig.MaxCredentialsTTL))
+	flags.StringVarP(&gko.outputFile, "output", "o", "", "Kubeconfig file to write (default <cluster-name>-<user>.kubeconfig)")
+	for _, flag := range []string{"cluster-name", "kubeconfig", "user"} {
+		if err := generateKubeconfigCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (o *generateKubeconfigOptions) request() kubeconfig.CredentialsRequest {
+	return kubeconfig.CredentialsRequest{
+		Cluster:    o.clusterName,
+		Kubeconfig: o.kubeconfig,
+		User:       o.user,
+		Groups:     o.groups,
+		TTL:        o.ttl,
+	}
+}
+
+func (o *generateKubeconfigOptions) generateKubeconfig(ctx context.Context) error {
+	request := o.request()
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	outputFile := o.outputFile
+	if outputFile == "" {
+		outputFile = fmt.Sprintf("%s-%s.kubeconfig", o.clusterName, o.user)
+	}
+
+	// the admin kubeconfig of the workload cluster is only written while the certificate is issued
+	issuerDir, err := ioutil.TempDir("", "eksa-credentials-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(issuerDir)
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(o.kubeconfig), issuerDir).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	credentials, err := kubeconfig.NewMinter(deps.Kubectl, kubeconfig.WithKubeconfigDir(issuerDir)).WriteKubeconfig(ctx, request, outputFile)
+	if err != nil {
+		return err
+	}
+	logger.MarkSuccess("Kubeconfig generated", "kubeconfig", outputFile, "expiration", credentials.Expiration.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+type generateSchemaOptions struct {
+	kind      string
+	outputDir string
+}
+
+var gso = &generateSchemaOptions{}
+
+var generateSchemaCmd = &cobra.Command{
+	Use:          "schema",
+	Short:        "Generate the JSON Schema for the cluster config kinds",
+	Long:         "This command is used to generate the JSON Schema for the EKS Anywhere cluster config kinds, for use in editors and CI linting",
+	PreRunE:      preRunGenerateSchema,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gso.generateSchema(); err != nil {
+			return fmt.Errorf("failed to generate schema: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunGenerateSchema(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	generateCmd.AddCommand(generateSchemaCmd)
+	generateSchemaCmd.Flags().StringVar(&gso.kind, "kind", "", "Kind to generate the schema for. Required when printing to stdout, all kinds are generated by default when using --output-dir")
+	generateSchemaCmd.Flags().StringVar(&gso.outputDir, "output-dir", "", "Directory to write one <kind>.json schema file per kind")
+}
+
+func (gso *generateSchemaOptions) generateSchema() error {
+	validator, err := schema.NewValidator()
+	if err != nil {
+		return err
+	}
+
+	if gso.outputDir == "" {
+		if gso.kind == "" {
+			return fmt.Errorf("--kind is required when --output-dir is not set, supported kinds: %s", strings.Join(validator.Kinds(), ", "))
+		}
+		jsonSchema, err := validator.JSONSchema(gso.kind)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonSchema))
+		return nil
+	}
+
+	kinds := validator.Kinds()
+	if gso.kind != "" {
+		kinds = []string{gso.kind}
+	}
+
+	if err = os.MkdirAll(gso.outputDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating output dir: %v", err)
+	}
+
+	for _, kind := range kinds {
+		jsonSchema, err := validator.JSONSchema(kind)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(gso.outputDir, strings.ToLower(kind)+".json")
+		if err = ioutil.WriteFile(path, jsonSchema, 0o644); err != nil {
+			return fmt.Errorf("failed writing schema for %s: %v", kind, err)
+		}
+	}
+
+	return nil
+}
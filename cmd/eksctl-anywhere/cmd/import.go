@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import resources",
+	Long:  "Use eksctl anywhere import to import resources exported from another machine",
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
@@ -26,7 +26,7 @@ var opts = &importImagesOptions{}
 
 func init() {
 	rootCmd.AddCommand(importImagesCmd)
-	importImagesCmd.Flags().StringVarP(&opts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	importImagesCmd.Flags().StringVarP(&opts.fileName, "filename", "f", "", clusterConfigFileUsage)
 	err := importImagesCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking filename flag as required: %v", err)
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+type importWorkspaceOptions struct {
+	workspaceDir string
+	fileName     string
+	overwrite    bool
+}
+
+var iwo = &importWorkspaceOptions{}
+
+var importWorkspaceCmd = &cobra.Command{
+	Use:          "workspace",
+	Short:        "Import the cluster folders exported from another machine",
+	Long:         "This command extracts a tarball written by eksctl anywhere export workspace to the workspace, so the clusters can be managed from this machine",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return iwo.importWorkspace()
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importWorkspaceCmd)
+	importWorkspaceCmd.Flags().StringVar(&iwo.workspaceDir, "workspace", workspace.Default().Dir(), fmt.Sprintf("Workspace directory to import the cluster folders to, defaults to $%s or the current directory", workspace.EnvVar))
+	importWorkspaceCmd.Flags().StringVarP(&iwo.fileName, "filename", "f", defaultWorkspaceArchive, "Tarball written by eksctl anywhere export workspace")
+	importWorkspaceCmd.Flags().BoolVar(&iwo.overwrite, "overwrite", false, "Replace the folders of the clusters that already exist in the workspace")
+}
+
+func (o *importWorkspaceOptions) importWorkspace() error {
+	f, err := os.Open(o.fileName)
+	if err != nil {
+		return fmt.Errorf("failed opening workspace archive: %v", err)
+	}
+	defer f.Close()
+
+	var opts []workspace.ImportOpt
+	if o.overwrite {
+		opts = append(opts, workspace.WithOverwrite())
+	}
+	clusters, err := workspace.New(o.workspaceDir).Import(f, opts...)
+	if err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Workspace imported", "clusters", strings.Join(clusters, ","), "workspace", o.workspaceDir)
+	return nil
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type listCapabilitiesOptions struct {
+	bundlesOverride string
+	output          string
+}
+
+// capabilities is the machine readable description of what this version of the CLI supports,
+// so wrappers can build on top of it without parsing the help text
+type capabilities struct {
+	Version            string             `json:"version"`
+	Providers          []string           `json:"providers"`
+	KubernetesVersions []string           `json:"kubernetesVersions"`
+	Bundle             bundleCapabilities `json:"bundle"`
+}
+
+type bundleCapabilities struct {
+	Number          int                          `json:"number"`
+	CliMinVersion   string                       `json:"cliMinVersion"`
+	CliMaxVersion   string                       `json:"cliMaxVersion"`
+	VersionsBundles []versionsBundleCapabilities `json:"versionsBundles"`
+}
+
+type versionsBundleCapabilities struct {
+	KubernetesVersion string            `json:"kubernetesVersion"`
+	EksdRelease       string            `json:"eksdRelease"`
+	Components        map[string]string `json:"components"`
+	Images            []string          `json:"images"`
+}
+
+var lco = &listCapabilitiesOptions{}
+
+func init() {
+	listCmd.AddCommand(listCapabilitiesCmd)
+	listCapabilitiesCmd.Flags().StringVar(&lco.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	listCapabilitiesCmd.Flags().StringVarP(&lco.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	if err := registerFlagValues(listCapabilitiesCmd, outputFlagName, outputText, outputJson); err != nil {
+		log.Fatalf("Error registering output completion: %v", err)
+	}
+}
+
+var listCapabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "List the providers, Kubernetes versions and bundle contents supported by this version of EKS Anywhere",
+	Long:  "This command lists the providers, the Kubernetes versions and the components and images of the bundle supported by the current version of the EKS Anywhere CLI. Use --output json for a machine readable output",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if err := viper.BindPFlag(flag.Name, flag); err != nil {
+				log.Fatalf("Error initializing flags: %v", err)
+			}
+		})
+		return nil
+	},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listCapabilities(lco, os.Stdout)
+	},
+}
+
+func listCapabilities(options *listCapabilitiesOptions, w io.Writer) error {
+	var specOpts []cluster.SpecOpt
+	if options.bundlesOverride != "" {
+		specOpts = append(specOpts, cluster.WithOverrideBundlesManifest(options.bundlesOverride))
+	}
+	bundles, err := cluster.LoadBundles(version.Get(), specOpts...)
+	if err != nil {
+		return fmt.Errorf("unable to get bundles: %v", err)
+	}
+
+	c := buildCapabilities(version.Get().GitVersion, supportedProviders(), bundles)
+	switch options.output {
+	case outputText:
+		return writeCapabilitiesText(c, w)
+	case outputJson:
+		return writeCapabilitiesJson(c, w)
+	default:
+		return fmt.Errorf("invalid output format [%s]", options.output)
+	}
+}
+
+// supportedProviders returns the providers that can be used to create clusters, including the ones behind active feature flags
+func supportedProviders() []string {
+	providers := []string{constants.VSphereProviderName, constants.DockerProviderName}
+	if features.IsActive(features.TinkerbellProvider()) {
+		providers = append(providers, constants.TinkerbellProviderName)
+	}
+	return providers
+}
+
+func buildCapabilities(cliVersion string, providers []string, bundles *releasev1alpha1.Bundles) *capabilities {
+	c := &capabilities{
+		Version:            cliVersion,
+		Providers:          providers,
+		KubernetesVersions: []string{},
+		Bundle: bundleCapabilities{
+			Number:          bundles.Spec.Number,
+			CliMinVersion:   bundles.Spec.CliMinVersion,
+			CliMaxVersion:   bundles.Spec.CliMaxVersion,
+			VersionsBundles: make([]versionsBundleCapabilities, 0, len(bundles.Spec.VersionsBundles)),
+		},
+	}
+
+	for i := range bundles.Spec.VersionsBundles {
+		vb := &bundles.Spec.VersionsBundles[i]
+		c.KubernetesVersions = append(c.KubernetesVersions, vb.KubeVersion)
+
+		images := make([]string, 0, len(vb.Images()))
+		for _, image := range vb.Images() {
+			if image.URI != "" {
+				images = append(images, image.VersionedImage())
+			}
+		}
+		c.Bundle.VersionsBundles = append(c.Bundle.VersionsBundles, versionsBundleCapabilities{
+			KubernetesVersion: vb.KubeVersion,
+			EksdRelease:       vb.EksD.Name,
+			Components:        bundleComponents(vb),
+			Images:            images,
+		})
+	}
+	sort.Strings(c.KubernetesVersions)
+
+	return c
+}
+
+func bundleComponents(vb *releasev1alpha1.VersionsBundle) map[string]string {
+	components := map[string]string{
+		"eks-anywhere":                    vb.Eksa.Version,
+		"cluster-api":                     vb.ClusterAPI.Version,
+		"kubeadm-bootstrap":               vb.Bootstrap.Version,
+		"kubeadm-control-plane":           vb.ControlPlane.Version,
+		"etcdadm-bootstrap":               vb.ExternalEtcdBootstrap.Version,
+		"etcdadm-controller":              vb.ExternalEtcdController.Version,
+		"cert-manager":                    vb.CertManager.Version,
+		"cilium":                          vb.Cilium.Version,
+		"kindnetd":                        vb.Kindnetd.Version,
+		"kube-vip":                        vb.KubeVip.Version,
+		"local-path-provisioner":          vb.LocalPathProvisioner.Version,
+		"flux":                            vb.Flux.Version,
+		"cluster-api-provider-vsphere":    vb.VSphere.Version,
+		"cluster-api-provider-docker":     vb.Docker.Version,
+		"cluster-api-provider-tinkerbell": vb.Tinkerbell.Version,
+	}
+	for name, v := range components {
+		if v == "" {
+			delete(components, name)
+		}
+	}
+	return components
+}
+
+func writeCapabilitiesJson(c *capabilities, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("failed serializing capabilities: %v", err)
+	}
+	return nil
+}
+
+func writeCapabilitiesText(c *capabilities, w io.Writer) error {
+	fmt.Fprintf(w, "Version: %s\n", c.Version)
+	fmt.Fprintf(w, "Providers: %s\n", strings.Join(c.Providers, ", "))
+	fmt.Fprintf(w, "Kubernetes versions: %s\n", strings.Join(c.KubernetesVersions, ", "))
+	fmt.Fprintf(w, "Bundle: %d\n\n", c.Bundle.Number)
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "KUBERNETES VERSION\tCOMPONENT\tVERSION")
+	for _, vb := range c.Bundle.VersionsBundles {
+		names := make([]string, 0, len(vb.Components))
+		for name := range vb.Components {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", vb.KubernetesVersion, name, vb.Components[name])
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+	return nil
+}
@@ -18,7 +18,7 @@ var lio = &listImagesOptions{}
 
 func init() {
 	listCmd.AddCommand(listImagesCommand)
-	listImagesCommand.Flags().StringVarP(&lio.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	listImagesCommand.Flags().StringVarP(&lio.fileName, "filename", "f", "", clusterConfigFileUsage)
 	err := listImagesCommand.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking filename flag as required: %v", err)
@@ -30,7 +30,7 @@ var listOvaOpts = &listOvasOptions{}
 
 func init() {
 	listCmd.AddCommand(listOvasCmd)
-	listOvasCmd.Flags().StringVarP(&listOvaOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	listOvasCmd.Flags().StringVarP(&listOvaOpts.fileName, "filename", "f", "", clusterConfigFileUsage)
 	err := listOvasCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking filename flag as required: %v", err)
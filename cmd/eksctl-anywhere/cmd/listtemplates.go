@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clustertemplate"
+)
+
+type listTemplatesOptions struct {
+	library string
+}
+
+var listTemplatesOpts = &listTemplatesOptions{}
+
+func init() {
+	listCmd.AddCommand(listTemplatesCmd)
+	listTemplatesCmd.Flags().StringVarP(&listTemplatesOpts.library, "library", "l", "", "Directory or git repository url with the cluster templates. Use <url>//<dir> for a directory inside the repository")
+	err := listTemplatesCmd.MarkFlagRequired("library")
+	if err != nil {
+		log.Fatalf("Error marking library flag as required: %v", err)
+	}
+}
+
+var listTemplatesCmd = &cobra.Command{
+	Use:          "templates",
+	Short:        "List the cluster templates of a library",
+	Long:         "This command lists the cluster templates of a library with their variables",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listTemplates(cmd.Context(), listTemplatesOpts.library)
+	},
+}
+
+func listTemplates(ctx context.Context, source string) error {
+	library, err := clustertemplate.NewLibrary(ctx, source)
+	if err != nil {
+		return err
+	}
+	defer library.Close()
+
+	templates, err := library.List()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tDESCRIPTION\tVARIABLES")
+	for _, t := range templates {
+		variables := make([]string, 0, len(t.Variables))
+		for _, v := range t.Variables {
+			if v.Required() {
+				variables = append(variables, v.Name)
+			} else {
+				variables = append(variables, fmt.Sprintf("%s=%s", v.Name, *v.Default))
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, t.Description, strings.Join(variables, ","))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var maintainCmd = &cobra.Command{
+	Use:   "maintain",
+	Short: "Run maintenance routines",
+	Long:  "Use eksctl anywhere maintain to run maintenance routines on cluster components, such as the external etcd defragmentation",
+}
+
+func init() {
+	rootCmd.AddCommand(maintainCmd)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/etcd"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type maintainEtcdOptions struct {
+	nodeSSHOptions
+	fileName             string
+	managementKubeconfig string
+	maxDBSize            string
+	maxFragmentation     float64
+	checkOnly            bool
+}
+
+var meo = &maintainEtcdOptions{}
+
+var maintainEtcdCmd = &cobra.Command{
+	Use:          "etcd -f <cluster-config-file> --ssh-key <private-key>",
+	Short:        "Defragment the external etcd members and clear their alarms",
+	Long:         "This command checks the external etcd members over SSH, defragments the ones whose database crossed the size or fragmentation thresholds, one at a time, clears the alarms like NOSPACE and records the members health in the cluster status. It can be run on demand or periodically, for example from a cron job",
+	PreRunE:      preRunMaintainEtcd,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return meo.maintainEtcd(cmd.Context())
+	},
+}
+
+func preRunMaintainEtcd(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	maintainCmd.AddCommand(maintainEtcdCmd)
+	maintainEtcdCmd.Flags().StringVarP(&meo.fileName, "filename", "f", "", clusterConfigFileUsage)
+	maintainEtcdCmd.Flags().StringVar(&meo.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file (default the kubeconfig of the cluster)")
+	maintainEtcdCmd.Flags().StringVar(&meo.maxDBSize, "max-db-size", resource.NewQuantity(etcd.DefaultMaxDBSize, resource.BinarySI).String(), "Defragment the members whose database file is bigger, below the etcd quota of 2Gi")
+	maintainEtcdCmd.Flags().Float64Var(&meo.maxFragmentation, "max-fragmentation", etcd.DefaultMaxFragmentation, "Defragment the members whose database file has a larger fraction of free space, between 0 and 1")
+	maintainEtcdCmd.Flags().BoolVar(&meo.checkOnly, "check-only", false, "Only check the members health and record it in the cluster status, without defragmenting them or clearing alarms")
+	meo.nodeSSHOptions.addFlags(maintainEtcdCmd.Flags())
+	err := maintainEtcdCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *maintainEtcdOptions) kubeConfig(clusterName string) string {
+	if o.managementKubeconfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return o.managementKubeconfig
+}
+
+func (o *maintainEtcdOptions) maintainerOpts() ([]etcd.MaintainerOpt, error) {
+	maxDBSize, err := resource.ParseQuantity(o.maxDBSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --max-db-size %s: %v", o.maxDBSize, err)
+	}
+	if o.maxFragmentation <= 0 || o.maxFragmentation >= 1 {
+		return nil, fmt.Errorf("--max-fragmentation must be between 0 and 1, got %v", o.maxFragmentation)
+	}
+	opts := []etcd.MaintainerOpt{etcd.WithMaxDBSize(maxDBSize.Value()), etcd.WithMaxFragmentation(o.maxFragmentation)}
+	if o.checkOnly {
+		opts = append(opts, etcd.WithCheckOnly())
+	}
+
+	return opts, nil
+}
+
+func (o *maintainEtcdOptions) maintainEtcd(ctx context.Context) error {
+	if err := o.nodeSSHOptions.validate(); err != nil {
+		return err
+	}
+	opts, err := o.maintainerOpts()
+	if err != nil {
+		return err
+	}
+	clusterConfig, err := v1alpha1.GetClusterConfig(o.fileName)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster config from file: %v", err)
+	}
+	if clusterConfig.Spec.ExternalEtcdConfiguration == nil {
+		return fmt.Errorf("cluster %s doesn't have external etcd, its stacked etcd is maintained with the control plane", clusterConfig.Name)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(o.keyDirs()...).
+		WithKubectl().
+		WithSSH().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{Name: clusterConfig.ManagedBy(), KubeconfigFile: o.kubeConfig(clusterConfig.Name)}
+	etcdadmCluster, err := deps.Kubectl.GetEtcdadmCluster(ctx, managementCluster, clusterConfig.Name,
+		executables.WithCluster(managementCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return err
+	}
+	members, err := etcd.MembersFromEndpoints(etcdadmCluster.Status.Endpoints, o.node)
+	if err != nil {
+		return err
+	}
+
+	status, err := etcd.NewMaintainer(deps.SSH, o.bastion(), opts...).Maintain(ctx, members)
+	for _, m := range status.Members {
+		logger.Info("Etcd member", "endpoint", m.Endpoint, "healthy", m.Healthy, "leader", m.Leader,
+			"dbSize", resource.NewQuantity(m.DBSize, resource.BinarySI).String(), "dbSizeInUse", resource.NewQuantity(m.DBSizeInUse, resource.BinarySI).String(), "alarms", m.Alarms)
+	}
+	if statusErr := deps.Kubectl.SetEksaClusterEtcdStatus(ctx, managementCluster, clusterConfig.Name, clusterNamespace(clusterConfig), status); statusErr != nil {
+		logger.Error(statusErr, "Failed recording etcd status in the cluster")
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Etcd maintenance finished")
+	return nil
+}
+
+func clusterNamespace(c *v1alpha1.Cluster) string {
+	if c.Namespace == "" {
+		return "default"
+	}
+	return c.Namespace
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/networking/migration"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+type migrateCNIOptions struct {
+	clusterOptions
+	wConfig           string
+	to                string
+	customCNIManifest string
+	drainTimeout      time.Duration
+	nodeTimeout       time.Duration
+	rollback          bool
+}
+
+func (mco *migrateCNIOptions) kubeConfig(clusterName string) string {
+	if mco.wConfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return mco.wConfig
+}
+
+var mco = &migrateCNIOptions{}
+
+var migrateCNICmd = &cobra.Command{
+	Use:          "cni -f <cluster-config-file> --to <cilium|custom> --custom-cni-manifest <manifest-file>",
+	Short:        "Migrate a cluster between Cilium and a user managed CNI",
+	Long:         "This command moves the nodes of a cluster one at a time from the EKS-A managed Cilium to a CNI installed by the user, or back, without recreating the cluster",
+	PreRunE:      preRunMigrateCNI,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := mco.migrateCNI(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to migrate cni: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunMigrateCNI(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateCNICmd)
+	migrateCNICmd.Flags().StringVarP(&mco.fileName, "filename", "f", "", clusterConfigFileUsage)
+	migrateCNICmd.Flags().StringVar(&mco.to, "to", "", fmt.Sprintf("CNI to migrate the cluster to: %s or %s", v1alpha1.Cilium, v1alpha1.Custom))
+	migrateCNICmd.Flags().StringVar(&mco.customCNIManifest, "custom-cni-manifest", "", "Manifest that installs the user managed CNI, needed in both directions")
+	migrateCNICmd.Flags().StringVarP(&mco.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to migrate")
+	migrateCNICmd.Flags().StringVar(&mco.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	migrateCNICmd.Flags().DurationVar(&mco.drainTimeout, "drain-timeout", 10*time.Minute, "Time to wait for each node to be drained")
+	migrateCNICmd.Flags().DurationVar(&mco.nodeTimeout, "node-timeout", 10*time.Minute, "Time to wait for the new CNI to be ready in each node")
+	migrateCNICmd.Flags().BoolVar(&mco.rollback, "rollback", false, "Roll back a failed migration to --to, moving the nodes already migrated back to the CNI of the cluster")
+	for _, flag := range []string{"filename", "to", "custom-cni-manifest"} {
+		if err := migrateCNICmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (mco *migrateCNIOptions) migrateCNI(ctx context.Context) error {
+	to := v1alpha1.CNI(mco.to)
+	if to != v1alpha1.Cilium && to != v1alpha1.Custom {
+		return fmt.Errorf("invalid cni %s, only %s and %s are supported", mco.to, v1alpha1.Cilium, v1alpha1.Custom)
+	}
+	customManifest, err := os.ReadFile(mco.customCNIManifest)
+	if err != nil {
+		return fmt.Errorf("reading custom cni manifest: %v", err)
+	}
+
+	clusterConfig, err := commonValidation(ctx, mco.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterConfig.Name), clusterConfig.Name, mco.wConfig, kubeconfigPattern) {
+		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(mco.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(mco.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		WithKubectl().
+		WithHelm().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: mco.kubeConfig(clusterSpec.Name),
+	}
+	managementCluster := workloadCluster
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = &types.Cluster{
+			Name:           clusterSpec.ManagementCluster.Name,
+			KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+		}
+	}
+
+	// Cilium is generated with the bundle stored with the cluster, so it matches the version running in it
+	currentSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, managementCluster, clusterSpec.Name)
+	if err != nil {
+		return err
+	}
+	ciliumManifest, err := cilium.NewCilium(deps.Kubectl, deps.Helm).GenerateManifest(currentSpec)
+	if err != nil {
+		return fmt.Errorf("generating cilium manifest: %v", err)
+	}
+
+	plan, err := migration.NewPlan(currentSpec.Cluster.Spec.ClusterNetwork.CNI, to, ciliumManifest, customManifest)
+	if err != nil {
+		return err
+	}
+	plan.CustomCNI = &v1alpha1.CustomCNIConfig{Manifest: mco.customCNIManifest}
+	migrator := migration.NewMigrator(deps.Kubectl, workloadCluster, managementCluster,
+		migration.WithDrainTimeout(mco.drainTimeout),
+		migration.WithNodeTimeout(mco.nodeTimeout),
+	)
+	if mco.rollback {
+		if err = migrator.Rollback(ctx, plan); err != nil {
+			return err
+		}
+		logger.MarkSuccess("Cluster cni migration rolled back", "cni", plan.From)
+		return nil
+	}
+	if err = migrator.Migrate(ctx, plan); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Cluster cni migrated", "cni", to)
+	change := fmt.Sprintf("clusterNetwork.cni to %s", to)
+	if to == v1alpha1.Custom {
+		change += fmt.Sprintf(" and clusterNetwork.customCNI.manifest to %s", mco.customCNIManifest)
+	} else {
+		change += " and remove clusterNetwork.customCNI"
+	}
+	logger.Info(fmt.Sprintf("Set %s in %s so the next upgrade keeps it", change, mco.fileName))
+	if currentSpec.Cluster.Spec.GitOpsRef != nil {
+		logger.Info(fmt.Sprintf("Set %s in the cluster config of the GitOps repository too, or the next sync reverts it", change))
+	}
+	return nil
+}
@@ -1,17 +1,128 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/spf13/pflag"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/backup"
+	"github.com/aws/eks-anywhere/pkg/bmc"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/eviction"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/hardware"
+	"github.com/aws/eks-anywhere/pkg/healthprobe"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/notification"
+	"github.com/aws/eks-anywhere/pkg/profile"
+	"github.com/aws/eks-anywhere/pkg/prompt"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 )
 
 type clusterOptions struct {
-	fileName             string
-	bundlesOverride      string
-	managementKubeconfig string
+	fileName                   string
+	bundlesOverride            string
+	managementKubeconfig       string
+	timeout                    time.Duration
+	allowSinglePointsOfFailure bool
+	artifactsDir               string
+	strictAirGap               bool
+	profile                    string
+	profilesFile               string
+}
+
+const (
+	allowSinglePointsOfFailureUsage = "Acknowledge the single points of failure of the cluster topology, like etcd and control plane machines sharing a datastore or host, and only warn about them"
+	strictAirGapUsage               = "Fail before starting if any manifest or image would be fetched from the internet instead of the --artifacts-dir artifacts and the registry mirror, listing them"
+)
+
+// localArtifacts reads the index of the --artifacts-dir artifacts, nil without the flag
+func (c clusterOptions) localArtifacts() (*files.ArtifactsIndex, error) {
+	if c.artifactsDir == "" {
+		return nil, nil
+	}
+	return files.ReadArtifactsIndex(c.artifactsDir)
+}
+
+// validateStrictAirGap fails with --strict-air-gap when the operation needs internet access. It runs
+// before the dependencies are built, since building them already pulls the tools image
+func (c clusterOptions) validateStrictAirGap(clusterSpec *cluster.Spec, artifacts *files.ArtifactsIndex) error {
+	if !c.strictAirGap {
+		return nil
+	}
+	if err := validations.ValidateStrictAirGapped(clusterSpec, artifacts); err != nil {
+		return fmt.Errorf("strict air-gap validation failed: %v", err)
+	}
+	logger.MarkPass("No manifest or image requires internet access")
+	return nil
+}
+
+func (c *clusterOptions) addProfileFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&c.profile, "profile", "", "Name of the environment profile to deploy the cluster config to. Its variables, overrides and timeout are applied to the operation")
+	flags.StringVar(&c.profilesFile, "profiles-file", "", fmt.Sprintf("File with the environment profiles (default $HOME/%s)", profile.DefaultFile))
+}
+
+// applyProfile applies the --profile environment before the cluster config is read: its variables are set in the
+// environment, its timeout is used when --timeout is not set and its overrides are merged into a copy of the cluster
+// config, which replaces --filename for the operation. The returned func removes that copy
+func (c *clusterOptions) applyProfile(flags *pflag.FlagSet) (func(), error) {
+	noop := func() {}
+	if c.profile == "" {
+		return noop, nil
+	}
+	p, err := profile.Load(c.profilesFile, c.profile)
+	if err != nil {
+		return noop, err
+	}
+	if err = p.SetEnv(); err != nil {
+		return noop, err
+	}
+	if !flags.Changed("timeout") {
+		c.timeout = p.TimeoutOrDefault(c.timeout)
+	}
+	if len(p.Overrides) == 0 {
+		logger.V(3).Info("Profile applied", "profile", p.Name)
+		return noop, nil
+	}
+
+	content, err := v1alpha1.ReadClusterConfigFile(c.fileName)
+	if err != nil {
+		return noop, fmt.Errorf("failed reading cluster config: %v", err)
+	}
+	if content, err = p.Apply(content); err != nil {
+		return noop, err
+	}
+	dir, err := os.MkdirTemp("", "eksa-profile-")
+	if err != nil {
+		return noop, fmt.Errorf("failed creating directory for the cluster config of profile %s: %v", p.Name, err)
+	}
+	remove := func() { os.RemoveAll(dir) }
+	baseName := filepath.Base(c.fileName)
+	if c.fileName == v1alpha1.StdinFileName {
+		baseName = "cluster.yaml"
+	}
+	fileName := filepath.Join(dir, baseName)
+	if err = os.WriteFile(fileName, content, 0o600); err != nil {
+		remove()
+		return noop, fmt.Errorf("failed writing the cluster config of profile %s: %v", p.Name, err)
+	}
+	logger.V(3).Info("Profile applied", "profile", p.Name, "clusterConfig", fileName)
+	c.fileName = fileName
+
+	return remove, nil
 }
 
 func (c clusterOptions) mountDirs() []string {
@@ -23,8 +134,8 @@ func (c clusterOptions) mountDirs() []string {
 	return dirs
 }
 
-func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
-	var specOpts []cluster.SpecOpt
+func newClusterSpec(options clusterOptions, opts ...cluster.SpecOpt) (*cluster.Spec, error) {
+	specOpts := append([]cluster.SpecOpt{}, opts...)
 	if options.bundlesOverride != "" {
 		specOpts = append(specOpts, cluster.WithOverrideBundlesManifest(options.bundlesOverride))
 	}
@@ -40,6 +151,342 @@ func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to get cluster config from file: %v", err)
 	}
+	features.FeedGates(clusterSpec.Cluster.FeatureGates())
 
 	return clusterSpec, nil
 }
+
+// clusterManifestsSpecOpts reads the Release and Bundles served by the management cluster in kubeconfig, so the versions
+// are resolved from them before downloading the release manifests. Any error only falls back to the downloaded manifests
+func clusterManifestsSpecOpts(ctx context.Context, kubeconfig string) []cluster.SpecOpt {
+	// The tools image comes from the bundles, so this uses the default one
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(kubeconfig)).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		logger.Info("Warning: can't read the release manifests from the management cluster, downloading them", "error", err)
+		return nil
+	}
+	defer close(ctx, deps)
+
+	releases, err := deps.Kubectl.GetEksaRelease(ctx, kubeconfig, constants.EksaReleasesName, constants.EksaSystemNamespace)
+	if err != nil {
+		logger.Info("Warning: can't read the Release from the management cluster, downloading the releases manifest", "error", err)
+	}
+	bundles, err := deps.Kubectl.ListBundles(ctx, kubeconfig)
+	if err != nil {
+		logger.Info("Warning: can't read the Bundles from the management cluster, downloading the bundles manifest", "error", err)
+	}
+	if releases == nil && len(bundles) == 0 {
+		return nil
+	}
+
+	return []cluster.SpecOpt{cluster.WithClusterManifests(releases, bundles)}
+}
+
+// operationResultFile is where the result of an operation is written, in the cluster folder
+func operationResultFile(clusterName, operation string) string {
+	return filepath.Join(workspace.Default().ClusterDir(clusterName), fmt.Sprintf("%s-%s-result.json", clusterName, operation))
+}
+
+// confirmOptions are shared by the commands with destructive steps, so all of them ask
+// for confirmation the same way and can skip it with the same flag
+type confirmOptions struct {
+	yes bool
+}
+
+func (c *confirmOptions) addFlags(flags *pflag.FlagSet) {
+	flags.BoolVarP(&c.yes, "yes", "y", false, "Confirm the destructive steps without asking, required when running without a terminal")
+}
+
+// confirm asks on stderr before the destructive step, so stdout can still be redirected
+func (c confirmOptions) confirm(d prompt.Destruction) (bool, error) {
+	confirmer := prompt.NewConfirmer(os.Stdin, os.Stderr, prompt.WithAssumeYes(c.yes), prompt.WithInteractive(isTerminal(os.Stdin)))
+	return confirmer.Confirm(d)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmBootstrapCleanup confirms deleting the bootstrap cluster left by a previous run with --force-cleanup
+func (c confirmOptions) confirmBootstrapCleanup(clusterName string) error {
+	confirmed, err := c.confirm(prompt.Destruction{
+		Action:    "Force delete the bootstrap cluster of " + clusterName,
+		Resources: []string{fmt.Sprintf("bootstrap cluster %s-eks-a-cluster and the cluster state it holds", clusterName)},
+	})
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("deleting the bootstrap cluster was not confirmed, rerun without --force-cleanup to keep it")
+	}
+	return nil
+}
+
+// bmcOptions read a hardware inventory to manage the power of its machines through their BMC
+type bmcOptions struct {
+	inventory   string
+	bmcInsecure bool
+	machines    []hardware.Machine
+}
+
+func (b *bmcOptions) addFlags(flags *pflag.FlagSet, inventoryUsage string) {
+	flags.StringVar(&b.inventory, "hardware-inventory", "", inventoryUsage)
+	flags.BoolVar(&b.bmcInsecure, "bmc-insecure", false, "Skip the verification of the Redfish BMC certificates")
+}
+
+// readInventory loads the machines of the inventory, if any
+func (b *bmcOptions) readInventory() error {
+	if b.inventory == "" {
+		return nil
+	}
+	catalog, err := hardware.ReadInventory(b.inventory)
+	if err != nil {
+		return err
+	}
+	b.machines = catalog.Machines()
+
+	return nil
+}
+
+// machinesWithBMC returns the number of machines of the inventory the BMC clients can manage
+func (b bmcOptions) machinesWithBMC() int {
+	count := 0
+	for _, m := range b.machines {
+		if m.HasBMC() {
+			count++
+		}
+	}
+	return count
+}
+
+func (b bmcOptions) powerManager(ipmitool *executables.Ipmitool) *bmc.PowerManager {
+	return bmc.NewPowerManager(bmc.NewFactory(ipmitool, b.bmcInsecure), b.machines)
+}
+
+// nodeSSHOptions are the login of the cluster nodes for the commands that access them over SSH,
+// and the bastion host the connections are proxied through when the nodes aren't reachable directly
+type nodeSSHOptions struct {
+	sshUser        string
+	sshKey         string
+	bastionAddress string
+	bastionUser    string
+	bastionSSHKey  string
+	// bastionKnownHosts verifies the bastion host key. The node host keys aren't verified, they change
+	// every time a machine is replaced
+	bastionKnownHosts string
+}
+
+func (n *nodeSSHOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&n.sshUser, "ssh-user", "ec2-user", "User to log into the nodes")
+	flags.StringVar(&n.sshKey, "ssh-key", "", "Private key to log into the nodes, matching one of the machine configs sshAuthorizedKeys")
+	flags.StringVar(&n.bastionAddress, "bastion-address", "", "Address of the bastion host the node connections are proxied through, optionally with a port like bastion.example.com:2222")
+	flags.StringVar(&n.bastionUser, "bastion-user", "", "User to log into the bastion host (default --ssh-user)")
+	flags.StringVar(&n.bastionSSHKey, "bastion-ssh-key", "", "Private key to log into the bastion host (default --ssh-key)")
+	flags.StringVar(&n.bastionKnownHosts, "bastion-known-hosts", "", "Known hosts file with the bastion host key, which is verified before connecting (default ~/.ssh/known_hosts)")
+}
+
+func (n *nodeSSHOptions) validate() error {
+	if n.sshKey == "" {
+		return fmt.Errorf("--ssh-key is required to log into the nodes")
+	}
+	if n.bastionAddress == "" && (n.bastionUser != "" || n.bastionSSHKey != "" || n.bastionKnownHosts != "") {
+		return fmt.Errorf("--bastion-user, --bastion-ssh-key and --bastion-known-hosts require --bastion-address")
+	}
+	if n.bastionUser == "" {
+		n.bastionUser = n.sshUser
+	}
+	if n.bastionSSHKey == "" {
+		n.bastionSSHKey = n.sshKey
+	}
+
+	// the keys are mounted in the tools container, which needs their absolute paths
+	for _, key := range []*string{&n.sshKey, &n.bastionSSHKey} {
+		path, err := filepath.Abs(*key)
+		if err != nil {
+			return fmt.Errorf("failed getting absolute path of ssh key %s: %v", *key, err)
+		}
+		if _, err = os.Stat(path); err != nil {
+			return fmt.Errorf("ssh key %s can't be read: %v", *key, err)
+		}
+		*key = path
+	}
+
+	if n.bastionAddress == "" {
+		return nil
+	}
+	if n.bastionKnownHosts == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed getting home directory for the bastion known hosts: %v", err)
+		}
+		n.bastionKnownHosts = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	path, err := filepath.Abs(n.bastionKnownHosts)
+	if err != nil {
+		return fmt.Errorf("failed getting absolute path of known hosts file %s: %v", n.bastionKnownHosts, err)
+	}
+	if _, err = os.Stat(path); err != nil {
+		return fmt.Errorf("bastion host key can't be verified, known hosts file %s can't be read: %v", n.bastionKnownHosts, err)
+	}
+	n.bastionKnownHosts = path
+	return nil
+}
+
+// keyDirs returns the folders of the private keys and the bastion known hosts, to mount them in the tools container
+func (n nodeSSHOptions) keyDirs() []string {
+	var dirs []string
+	seen := map[string]bool{}
+	for _, path := range []string{n.sshKey, n.bastionSSHKey, n.bastionKnownHosts} {
+		if d := filepath.Dir(path); path != "" && !seen[d] {
+			seen[d] = true
+			dirs = append(dirs, d)
+		}
+	}
+	return dirs
+}
+
+func (n nodeSSHOptions) node(address string) executables.SSHHost {
+	return executables.SSHHost{Address: address, User: n.sshUser, PrivateKeyPath: n.sshKey}
+}
+
+// bastion returns the bastion host, nil when the nodes are accessed directly
+func (n nodeSSHOptions) bastion() *executables.SSHHost {
+	if n.bastionAddress == "" {
+		return nil
+	}
+	return &executables.SSHHost{Address: n.bastionAddress, User: n.bastionUser, PrivateKeyPath: n.bastionSSHKey, KnownHostsFile: n.bastionKnownHosts}
+}
+
+type backupOptions struct {
+	backup           bool
+	backupNamespaces []string
+}
+
+func (b *backupOptions) addFlags(flags *pflag.FlagSet, operation string) {
+	flags.BoolVar(&b.backup, "backup", false, fmt.Sprintf("Back up the cluster workloads and volumes with Velero before the %s. Velero must be installed in the cluster", operation))
+	flags.StringSliceVar(&b.backupNamespaces, "backup-namespaces", nil, "Namespaces to back up, implies --backup (default all namespaces)")
+}
+
+func (b backupOptions) workflowOpts(velero *executables.Velero, workloadCluster *types.Cluster) []workflows.Opt {
+	if !b.backup && len(b.backupNamespaces) == 0 {
+		return nil
+	}
+
+	return []workflows.Opt{workflows.WithWorkloadBackup(backup.NewWorkload(velero, workloadCluster, b.backupNamespaces))}
+}
+
+type evictionOptions struct {
+	evictWorkloads  bool
+	evictionTimeout time.Duration
+}
+
+func (e *evictionOptions) addFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&e.evictWorkloads, "evict-workloads", false, "Before deleting the machines, cordon all the nodes, delete the LoadBalancer services and the pods with persistent volumes, and wait for the volumes to be detached, so their infrastructure resources are released")
+	flags.DurationVar(&e.evictionTimeout, "eviction-timeout", 10*time.Minute, "Maximum time to wait for the volumes to be detached with --evict-workloads")
+}
+
+func (e evictionOptions) workflowOpts(kubectl *executables.Kubectl, workloadCluster *types.Cluster) []workflows.Opt {
+	if !e.evictWorkloads {
+		return nil
+	}
+
+	return []workflows.Opt{workflows.WithWorkloadEviction(eviction.NewWorkload(kubectl, workloadCluster, eviction.WithTimeout(e.evictionTimeout)))}
+}
+
+type notificationOptions struct {
+	notificationsConfig string
+}
+
+func (n *notificationOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&n.notificationsConfig, "notifications-config", "", "File with the notifications config, in the same format as the cluster spec notifications. The start and outcome of the operation are sent to them on top of the ones in the cluster spec")
+}
+
+func (n notificationOptions) workflowOpts(cluster *v1alpha1.Cluster) ([]workflows.Opt, error) {
+	notifiers, err := notification.ForCluster(cluster, n.notificationsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if len(notifiers) == 0 {
+		return nil, nil
+	}
+
+	return []workflows.Opt{workflows.WithNotifier(notifiers)}, nil
+}
+
+type healthProbeOptions struct {
+	healthProbeAddress string
+}
+
+func (h *healthProbeOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&h.healthProbeAddress, "health-probe-address", "", "Address, like 127.0.0.1:8090, to serve /healthz and the progress of the operation in /status while it runs, for Jobs and pipelines (default disabled)")
+}
+
+// startHealthProbe starts the health probe server when an address is set. The returned server is nil
+// otherwise, closing it is a no-op
+func (h healthProbeOptions) startHealthProbe(operation, clusterName string) (*healthprobe.Server, error) {
+	if h.healthProbeAddress == "" {
+		return nil, nil
+	}
+	server := healthprobe.NewServer(operation, clusterName)
+	if err := server.Start(h.healthProbeAddress); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+func (h healthProbeOptions) workflowOpts(server *healthprobe.Server) []workflows.Opt {
+	if server == nil {
+		return nil
+	}
+
+	return []workflows.Opt{workflows.WithProgressSink(server)}
+}
+
+// stopHealthProbe gives in-flight requests some time to finish before stopping the server
+func stopHealthProbe(ctx context.Context, server *healthprobe.Server) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := server.Close(ctx); err != nil {
+		logger.V(4).Info("Failed stopping health probe server", "error", err)
+	}
+}
+
+type policyOptions struct {
+	policyBundles []string
+}
+
+func (p *policyOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&p.policyBundles, "policy-bundle", nil, "Policy bundle files or directories. The generated cluster manifests must follow their policies before they are applied")
+}
+
+type validationReportOptions struct {
+	validationReport string
+}
+
+func (v *validationReportOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&v.validationReport, "validation-report", "", "File to write the validation report to, with the duration, severity and remediation of each check. It's JSON for .json files, JUnit XML for .xml files, for CI test reports, and a table otherwise")
+}
+
+func (v validationReportOptions) workflowOpts() []workflows.Opt {
+	if v.validationReport == "" {
+		return nil
+	}
+
+	return []workflows.Opt{workflows.WithValidationReport(v.validationReport)}
+}
+
+func (v validationReportOptions) runnerOpts() []validations.RunnerOpt {
+	if v.validationReport == "" {
+		return nil
+	}
+
+	return []validations.RunnerOpt{validations.WithReportFile(v.validationReport)}
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause resources",
+	Long:  "Use eksctl anywhere pause to stop the reconciliation of resources, such as clusters, during maintenance windows",
+}
+
+func init() {
+	rootCmd.AddCommand(pauseCmd)
+}
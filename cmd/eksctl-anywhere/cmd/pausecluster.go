@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+type pauseClusterOptions struct {
+	clusterOptions
+	wConfig    string
+	statusOnly bool
+}
+
+func (pc *pauseClusterOptions) kubeConfig(clusterName string) string {
+	if pc.wConfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return pc.wConfig
+}
+
+func (pc *pauseClusterOptions) managementCluster(clusterSpec *cluster.Spec) *types.Cluster {
+	if clusterSpec.ManagementCluster == nil {
+		return &types.Cluster{
+			Name:           clusterSpec.Name,
+			KubeconfigFile: pc.kubeConfig(clusterSpec.Name),
+		}
+	}
+	return &types.Cluster{
+		Name:           clusterSpec.ManagementCluster.Name,
+		KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+	}
+}
+
+var pc = &pauseClusterOptions{}
+
+var pauseClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Pause cluster reconciliation",
+	Long:         "This command stops the EKS-A and CAPI controllers from reconciling a cluster, for maintenance operations in the underlying infrastructure",
+	PreRunE:      preRunPauseCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := pc.pauseCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to pause cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunPauseCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	pauseCmd.AddCommand(pauseClusterCmd)
+	pauseClusterCmd.Flags().StringVarP(&pc.fileName, "filename", "f", "", clusterConfigFileUsage)
+	pauseClusterCmd.Flags().StringVarP(&pc.wConfig, "w-config", "w", "", "Kubeconfig file to use when pausing a workload cluster")
+	pauseClusterCmd.Flags().StringVar(&pc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	pauseClusterCmd.Flags().BoolVar(&pc.statusOnly, "status", false, "Only report which controllers are paused for the cluster, without pausing it")
+	err := pauseClusterCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (pc *pauseClusterOptions) pauseCluster(ctx context.Context) error {
+	return pc.run(ctx, func(ctx context.Context, deps *dependencies.Dependencies, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+		status, err := deps.ClusterManager.GetPauseStatus(ctx, managementCluster, clusterSpec.Name)
+		if err != nil {
+			return err
+		}
+		if status.InMaintenance {
+			logger.Info("Cluster is already paused for maintenance", "cluster", clusterSpec.Name)
+			return nil
+		}
+		if status.EKSAReconcilePaused {
+			return fmt.Errorf("cluster %s reconciliation is paused by an ongoing operation, wait for it to finish before pausing the cluster", clusterSpec.Name)
+		}
+
+		logger.Info("Pausing cluster reconciliation", "cluster", clusterSpec.Name)
+		return deps.ClusterManager.PauseClusterForMaintenance(ctx, managementCluster, clusterSpec, deps.Provider)
+	})
+}
+
+type pauseClusterAction func(ctx context.Context, deps *dependencies.Dependencies, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
+
+// run builds the dependencies for the cluster in the config file, executes the action unless only
+// the status was requested and reports the resulting pause status
+func (pc *pauseClusterOptions) run(ctx context.Context, action pauseClusterAction) error {
+	clusterConfig, err := commonValidation(ctx, pc.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterConfig.Name), clusterConfig.Name, pc.wConfig, kubeconfigPattern) {
+		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(pc.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(pc.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(pc.fileName, clusterSpec.Cluster, cc.skipIpCheck, "").
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := pc.managementCluster(clusterSpec)
+	if !pc.statusOnly {
+		if err = action(ctx, deps, managementCluster, clusterSpec); err != nil {
+			return err
+		}
+	}
+
+	status, err := deps.ClusterManager.GetPauseStatus(ctx, managementCluster, clusterSpec.Name)
+	if err != nil {
+		return err
+	}
+
+	serializedStatus, err := serializePauseStatus(status)
+	if err != nil {
+		return err
+	}
+	fmt.Print(serializedStatus)
+
+	return nil
+}
+
+func serializePauseStatus(status *clustermanager.PauseStatus) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "CONTROLLER\tPAUSED")
+	fmt.Fprintf(w, "%s\t%t\n", "EKS-A", status.EKSAReconcilePaused)
+	fmt.Fprintf(w, "%s\t%t\n", "CAPI", status.CAPIReconcilePaused)
+	fmt.Fprintf(w, "%s\t%t\n", "maintenance mode", status.InMaintenance)
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render resources",
+	Long:  "Use eksctl anywhere render to preview the resources a cluster operation would create",
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/render"
+)
+
+type renderClusterOptions struct {
+	clusterOptions
+	hardwareFileName string
+	outputDir        string
+}
+
+var rdc = &renderClusterOptions{}
+
+var renderClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file>",
+	Short:        "Render the CAPI manifests of a cluster",
+	Long:         "This command runs the provider template generation for a cluster config and prints the control plane and worker manifests the create would apply, without creating or changing any cluster",
+	PreRunE:      preRunRenderCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rdc.renderCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to render cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunRenderCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	renderCmd.AddCommand(renderClusterCmd)
+	renderClusterCmd.Flags().StringVarP(&rdc.fileName, "filename", "f", "", clusterConfigFileUsage)
+	if features.IsActive(features.TinkerbellProvider()) {
+		renderClusterCmd.Flags().StringVarP(&rdc.hardwareFileName, "hardwarefile", "w", "", "Filename that contains datacenter hardware information")
+	}
+	renderClusterCmd.Flags().StringVar(&rdc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	renderClusterCmd.Flags().StringVar(&rdc.outputDir, "output-dir", "", "Write the control plane and worker manifests to files in this directory instead of stdout")
+	err := renderClusterCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (rdc *renderClusterOptions) renderCluster(ctx context.Context) error {
+	if _, err := commonValidation(ctx, rdc.fileName); err != nil {
+		return err
+	}
+	clusterSpec, err := newClusterSpec(rdc.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithProvider(rdc.fileName, clusterSpec.Cluster, true, rdc.hardwareFileName).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	// The provider setup, which resolves defaults from the infrastructure, doesn't run, so the manifests
+	// show the config as written. Credentials read from the environment are never printed
+	renderer := render.NewRenderer(deps.Provider, render.WithRedactedValues(os.Getenv(vsphere.EksavSpherePasswordKey)))
+	manifests, err := renderer.Render(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	if rdc.outputDir == "" {
+		return manifests.Write(os.Stdout)
+	}
+
+	writer, err := filewriter.NewWriter(rdc.outputDir)
+	if err != nil {
+		return err
+	}
+	controlPlaneFile, workersFile, err := manifests.WriteFiles(writer)
+	if err != nil {
+		return err
+	}
+	logger.Info("Manifests rendered", "control plane", controlPlaneFile, "workers", workersFile)
+	return nil
+}
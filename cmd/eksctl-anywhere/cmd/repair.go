@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Repair resources",
+	Long:  "Use eksctl anywhere repair to re-install the EKS-A managed components of a cluster that were deleted or modified, or to finish an interrupted CAPI move",
+}
+
+func init() {
+	rootCmd.AddCommand(repairCmd)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/drift"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+type repairClusterOptions struct {
+	clusterOptions
+	wConfig string
+}
+
+func (rpc *repairClusterOptions) kubeConfig(clusterName string) string {
+	if rpc.wConfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return rpc.wConfig
+}
+
+func (rpc *repairClusterOptions) managementCluster(clusterSpec *cluster.Spec) *types.Cluster {
+	if clusterSpec.ManagementCluster == nil {
+		return &types.Cluster{
+			Name:           clusterSpec.Name,
+			KubeconfigFile: rpc.kubeConfig(clusterSpec.Name),
+		}
+	}
+	return &types.Cluster{
+		Name:           clusterSpec.ManagementCluster.Name,
+		KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+	}
+}
+
+var rpc = &repairClusterOptions{}
+
+var repairClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file>",
+	Short:        "Re-install drifted cluster components",
+	Long:         "This command re-installs the Cilium, CAPI and EKS-A controller components found missing or modified in the cluster, using the versions of the cluster bundle and without running a full upgrade",
+	PreRunE:      preRunRepairCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rpc.repairCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to repair cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunRepairCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	repairCmd.AddCommand(repairClusterCmd)
+	repairClusterCmd.Flags().StringVarP(&rpc.fileName, "filename", "f", "", clusterConfigFileUsage)
+	repairClusterCmd.Flags().StringVarP(&rpc.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to repair")
+	repairClusterCmd.Flags().StringVar(&rpc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	err := repairClusterCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (rpc *repairClusterOptions) repairCluster(ctx context.Context) error {
+	clusterConfig, err := commonValidation(ctx, rpc.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterConfig.Name), clusterConfig.Name, rpc.wConfig, kubeconfigPattern) {
+		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(rpc.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(rpc.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(rpc.fileName, clusterSpec.Cluster, cc.skipIpCheck, "").
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := rpc.managementCluster(clusterSpec)
+	// Components are re-installed with the bundle stored with the cluster, not with the one of this CLI version
+	currentSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, managementCluster, clusterSpec.Name)
+	if err != nil {
+		return err
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: rpc.kubeConfig(clusterSpec.Name),
+	}
+	detector := drift.NewDetector(drift.NewKubectlClient(deps.Kubectl, workloadCluster))
+	report, err := detector.Detect(ctx, currentSpec)
+	if err != nil {
+		return err
+	}
+	if !report.Drifted() {
+		logger.Info("No drift detected in the EKS-A managed components, nothing to repair", "cluster", clusterSpec.Name)
+		return nil
+	}
+	logger.Info("Drift detected in EKS-A managed components", "components", strings.Join(report.Components(), ", "))
+
+	if drift.RequiresCAPI(report) {
+		// clusterctl needs the provider env vars, which are set up along with the provider validations
+		if err = deps.Provider.SetupAndValidateUpgradeCluster(ctx, managementCluster, clusterSpec); err != nil {
+			return err
+		}
+	}
+
+	repairer := drift.NewRepairer(deps.ClusterManager, deps.Clusterctl, deps.Provider)
+	unrepaired, err := repairer.Repair(ctx, workloadCluster, currentSpec, report)
+	if err != nil {
+		return err
+	}
+	for _, d := range unrepaired {
+		logger.Info("Warning: drift can't be repaired without rolling out the control plane nodes", "drift", d.String())
+	}
+
+	report, err = detector.Detect(ctx, currentSpec)
+	if err != nil {
+		return err
+	}
+	remaining := 0
+	for _, d := range report.Drifts {
+		if drift.Repairable(d) {
+			logger.Info("Component still drifted after repair", "drift", d.String())
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		return fmt.Errorf("%d drifts remain after repair, check them with eksctl anywhere validate drift", remaining)
+	}
+
+	logger.MarkSuccess("Cluster components repaired")
+	return nil
+}
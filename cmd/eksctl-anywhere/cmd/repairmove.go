@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type repairMoveOptions struct {
+	clusterOptions
+	fromKubeconfig string
+	toKubeconfig   string
+	namespaces     []string
+}
+
+func (rm *repairMoveOptions) mountDirs() []string {
+	return append(rm.clusterOptions.mountDirs(), filepath.Dir(rm.fromKubeconfig), filepath.Dir(rm.toKubeconfig))
+}
+
+var rm = &repairMoveOptions{}
+
+var repairMoveCmd = &cobra.Command{
+	Use:          "move -f <cluster-config-file> --from-kubeconfig <source-kubeconfig> --to-kubeconfig <target-kubeconfig>",
+	Short:        "Finish an interrupted CAPI move",
+	Long:         "This command moves the Cluster API objects left in the source cluster by an interrupted or failed move to the target cluster, and verifies the target has all the objects",
+	PreRunE:      preRunRepairMove,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rm.repairMove(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to repair move: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunRepairMove(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	repairCmd.AddCommand(repairMoveCmd)
+	repairMoveCmd.Flags().StringVarP(&rm.fileName, "filename", "f", "", clusterConfigFileUsage)
+	repairMoveCmd.Flags().StringVar(&rm.fromKubeconfig, "from-kubeconfig", "", "Kubeconfig file of the cluster the objects were moved from, like the bootstrap cluster")
+	repairMoveCmd.Flags().StringVar(&rm.toKubeconfig, "to-kubeconfig", "", "Kubeconfig file of the cluster the objects were moved to")
+	repairMoveCmd.Flags().StringSliceVar(&rm.namespaces, "namespace", nil, "Namespaces with CAPI objects to move in addition to eksa-system")
+	for _, flag := range []string{"filename", "from-kubeconfig", "to-kubeconfig"} {
+		if err := repairMoveCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (rm *repairMoveOptions) repairMove(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(rm.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	from, err := cluster.LoadManagement(rm.fromKubeconfig)
+	if err != nil {
+		return fmt.Errorf("unable to get source cluster from kubeconfig: %v", err)
+	}
+	to, err := cluster.LoadManagement(rm.toKubeconfig)
+	if err != nil {
+		return fmt.Errorf("unable to get target cluster from kubeconfig: %v", err)
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(rm.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	scope := clustermanager.MoveScope{Namespaces: rm.namespaces}
+	if err = deps.ClusterManager.FinishMove(ctx, from, to, scope); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("CAPI move finished", "from", from.Name, "to", to.Name)
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/backup"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type restoreBackupOptions struct {
+	fileName string
+	wConfig  string
+}
+
+var rb = &restoreBackupOptions{}
+
+var restoreBackupCmd = &cobra.Command{
+	Use:          "backup <backup-name>",
+	Short:        "Restore a workload backup",
+	Long:         "This command restores in a cluster the workload backup taken with the --backup flag of the upgrade and delete cluster commands",
+	PreRunE:      preRunRestoreBackup,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rb.restoreBackup(cmd.Context(), args[0]); err != nil {
+			return fmt.Errorf("failed to restore backup: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunRestoreBackup(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreBackupCmd)
+	restoreBackupCmd.Flags().StringVarP(&rb.fileName, "filename", "f", "", clusterConfigFileUsage)
+	restoreBackupCmd.Flags().StringVarP(&rb.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to restore the backup in")
+	err := restoreBackupCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (rb *restoreBackupOptions) restoreBackup(ctx context.Context, backupName string) error {
+	if _, err := commonValidation(ctx, rb.fileName); err != nil {
+		return err
+	}
+	clusterSpec, err := newClusterSpec(clusterOptions{fileName: rb.fileName})
+	if err != nil {
+		return err
+	}
+
+	kubeconfigFile := rb.wConfig
+	if kubeconfigFile == "" {
+		kubeconfigFile = defaultKubeconfig(clusterSpec.Name)
+	}
+	if !validations.FileExists(kubeconfigFile) {
+		return fmt.Errorf("kubeconfig %s for cluster %s not found, provide it with -w", kubeconfigFile, clusterSpec.Name)
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithExecutableMountDirs(filepath.Dir(kubeconfigFile)).
+		WithVelero().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	cluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: kubeconfigFile,
+	}
+
+	logger.Info("Restoring backup", "backup", backupName, "cluster", cluster.Name)
+	if err = backup.NewWorkload(deps.Velero, cluster, nil).Restore(ctx, backupName); err != nil {
+		return err
+	}
+	logger.MarkSuccess("Backup restored")
+
+	return nil
+}
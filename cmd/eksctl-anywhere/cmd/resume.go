@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume resources",
+	Long:  "Use eksctl anywhere resume to resume the reconciliation of paused resources, such as clusters",
+}
+
+func init() {
+	rootCmd.AddCommand(resumeCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var rc = &pauseClusterOptions{}
+
+var resumeClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Resume cluster reconciliation",
+	Long:         "This command resumes the reconciliation of a cluster paused with eksctl anywhere pause cluster",
+	PreRunE:      preRunResumeCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rc.resumeCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to resume cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunResumeCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	resumeCmd.AddCommand(resumeClusterCmd)
+	resumeClusterCmd.Flags().StringVarP(&rc.fileName, "filename", "f", "", clusterConfigFileUsage)
+	resumeClusterCmd.Flags().StringVarP(&rc.wConfig, "w-config", "w", "", "Kubeconfig file to use when resuming a workload cluster")
+	resumeClusterCmd.Flags().StringVar(&rc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	err := resumeClusterCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (rc *pauseClusterOptions) resumeCluster(ctx context.Context) error {
+	return rc.run(ctx, func(ctx context.Context, deps *dependencies.Dependencies, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+		status, err := deps.ClusterManager.GetPauseStatus(ctx, managementCluster, clusterSpec.Name)
+		if err != nil {
+			return err
+		}
+		if !status.InMaintenance {
+			return fmt.Errorf("cluster %s is not paused for maintenance", clusterSpec.Name)
+		}
+
+		logger.Info("Resuming cluster reconciliation", "cluster", clusterSpec.Name)
+		return deps.ClusterManager.ResumeClusterFromMaintenance(ctx, managementCluster, clusterSpec, deps.Provider)
+	})
+}
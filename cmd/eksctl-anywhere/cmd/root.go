@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
 var rootCmd = &cobra.Command{
@@ -20,6 +21,14 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the log level verbosity")
+	rootCmd.PersistentFlags().StringSlice("log-levels", nil, "Set the log level verbosity of some subsystems (executables, workflows, providers), for example executables=6")
+	rootCmd.PersistentFlags().String("log-format", string(logger.ConsoleFormat), "Format of the logs written to stderr: console or json")
+	rootCmd.PersistentFlags().String("log-file", "", "Also write the logs in json format to this file")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Don't write logs to stderr. Cluster operations only print the path of their result document")
+	rootCmd.PersistentFlags().Float64("wait-multiplier", 1, "Multiply every timeout and wait by this factor, for slow environments like nested virtualization")
+	if err := registerFlagValues(rootCmd, "log-format", string(logger.ConsoleFormat), string(logger.JSONFormat)); err != nil {
+		log.Fatalf("failed to register log-format completion: %v", err)
+	}
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		log.Fatalf("failed to bind flags for root: %v", err)
 	}
@@ -29,10 +38,29 @@ func rootPersistentPreRun(cmd *cobra.Command, args []string) {
 	if err := initLogger(); err != nil {
 		log.Fatal(err)
 	}
+	if err := retrier.SetWaitMultiplier(viper.GetFloat64("wait-multiplier")); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func initLogger() error {
-	if err := logger.InitZap(viper.GetInt("verbosity")); err != nil {
+	subsystemLevels, err := logger.ParseSubsystemLevels(viper.GetStringSlice("log-levels"))
+	if err != nil {
+		return err
+	}
+
+	sinks := []logger.Sink{{Format: logger.Format(viper.GetString("log-format"))}}
+	if logFile := viper.GetString("log-file"); logFile != "" {
+		sinks = append(sinks, logger.Sink{Format: logger.JSONFormat, Path: logFile})
+	}
+
+	config := logger.Config{
+		Level:           viper.GetInt("verbosity"),
+		SubsystemLevels: subsystemLevels,
+		Sinks:           sinks,
+		Quiet:           viper.GetBool("quiet"),
+	}
+	if err = logger.Init(config); err != nil {
 		return fmt.Errorf("failed init zap logger in root command: %v", err)
 	}
 
@@ -4,18 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/artifacts"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 )
 
 type createSupportBundleOptions struct {
@@ -29,7 +30,7 @@ type createSupportBundleOptions struct {
 
 func (csbo *createSupportBundleOptions) kubeConfig(clusterName string) string {
 	if csbo.wConfig == "" {
-		return filepath.Join(clusterName, fmt.Sprintf(kubeconfigPattern, clusterName))
+		return defaultKubeconfig(clusterName)
 	}
 	return csbo.wConfig
 }
@@ -58,7 +59,7 @@ func init() {
 	supportbundleCmd.Flags().StringVarP(&csbo.sinceTime, "since-time", "", "", "Collect pod logs after a specific datetime(RFC3339) like 2021-06-28T15:04:05Z")
 	supportbundleCmd.Flags().StringVarP(&csbo.since, "since", "", "", "Collect pod logs in the latest duration like 5s, 2m, or 3h.")
 	supportbundleCmd.Flags().StringVarP(&csbo.bundleConfig, "bundle-config", "", "", "Bundle Config file to use when generating support bundle")
-	supportbundleCmd.Flags().StringVarP(&csbo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	supportbundleCmd.Flags().StringVarP(&csbo.fileName, "filename", "f", "", clusterConfigFileUsage)
 	supportbundleCmd.Flags().StringVarP(&csbo.wConfig, "w-config", "w", "", "Kubeconfig file to use when creating support bundle for a workload cluster")
 	err := supportbundleCmd.MarkFlagRequired("filename")
 	if err != nil {
@@ -71,7 +72,7 @@ func (csbo *createSupportBundleOptions) validate(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, csbo.wConfig, kubeconfigPattern) {
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterConfig.Name), clusterConfig.Name, csbo.wConfig, kubeconfigPattern) {
 		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
 	}
 	return nil
@@ -96,6 +97,7 @@ func (csbo *createSupportBundleOptions) createBundle(ctx context.Context, since,
 	deps, err := dependencies.ForSpec(ctx, clusterSpec).
 		WithProvider(csbo.fileName, clusterSpec.Cluster, cc.skipIpCheck, csbo.hardwareFileName).
 		WithDiagnosticBundleFactory().
+		WithArtifactStore().
 		Build(ctx)
 	if err != nil {
 		return err
@@ -118,6 +120,12 @@ func (csbo *createSupportBundleOptions) createBundle(ctx context.Context, since,
 		return fmt.Errorf("error while collecting and analyzing bundle: %v", err)
 	}
 
+	if deps.ArtifactStore != nil {
+		if err = artifacts.UploadFile(ctx, deps.ArtifactStore, supportBundle.ArchivePath(), clusterSpec.Name); err != nil {
+			return fmt.Errorf("failed uploading support bundle archive: %v", err)
+		}
+	}
+
 	err = supportBundle.PrintAnalysis()
 	if err != nil {
 		return fmt.Errorf("error when printing analysis")
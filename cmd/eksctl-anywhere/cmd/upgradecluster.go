@@ -4,30 +4,40 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
 	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 )
 
 type upgradeClusterOptions struct {
 	clusterOptions
+	backupOptions
+	confirmOptions
+	healthProbeOptions
+	notificationOptions
+	policyOptions
+	validationReportOptions
 	wConfig          string
 	forceClean       bool
 	hardwareFileName string
+	componentsOnly   bool
+	overridesKeep    int
 }
 
 func (uc *upgradeClusterOptions) kubeConfig(clusterName string) string {
 	if uc.wConfig == "" {
-		return filepath.Join(clusterName, fmt.Sprintf(kubeconfigPattern, clusterName))
+		return defaultKubeconfig(clusterName)
 	}
 	return uc.wConfig
 }
@@ -41,6 +51,11 @@ var upgradeClusterCmd = &cobra.Command{
 	PreRunE:      preRunUpgradeCluster,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		removeProfileConfig, err := uc.applyProfile(cmd.Flags())
+		if err != nil {
+			return err
+		}
+		defer removeProfileConfig()
 		if err := uc.upgradeCluster(cmd.Context()); err != nil {
 			return fmt.Errorf("failed to upgrade cluster: %v", err)
 		}
@@ -60,11 +75,24 @@ func preRunUpgradeCluster(cmd *cobra.Command, args []string) error {
 
 func init() {
 	upgradeCmd.AddCommand(upgradeClusterCmd)
-	upgradeClusterCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	upgradeClusterCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", clusterConfigFileUsage)
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	upgradeClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradeClusterCmd.Flags().StringVar(&uc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	upgradeClusterCmd.Flags().BoolVar(&uc.componentsOnly, "components-only", false, "Only upgrade the management components (Cluster API providers, EKS-A controller, Flux and Cilium), without rolling out the cluster machines")
+	upgradeClusterCmd.Flags().IntVar(&uc.overridesKeep, "overrides-retention", executables.DefaultOverridesRetention, "Versions of each component to keep in the overrides layer of the cluster folder after a successful upgrade, the ones of the current bundle are always kept. 0 removes the layer")
+	upgradeClusterCmd.Flags().StringVar(&uc.artifactsDir, "artifacts-dir", "", "Directory extracted from the 'eksctl anywhere download artifacts' tarball. Manifests are read from it instead of downloaded, for upgrades without network access")
+	uc.backupOptions.addFlags(upgradeClusterCmd.Flags(), "upgrade")
+	uc.confirmOptions.addFlags(upgradeClusterCmd.Flags())
+	uc.policyOptions.addFlags(upgradeClusterCmd.Flags())
+	uc.notificationOptions.addFlags(upgradeClusterCmd.Flags())
+	uc.healthProbeOptions.addFlags(upgradeClusterCmd.Flags())
+	uc.validationReportOptions.addFlags(upgradeClusterCmd.Flags())
+	uc.addProfileFlags(upgradeClusterCmd.Flags())
+	upgradeClusterCmd.Flags().BoolVar(&uc.strictAirGap, "strict-air-gap", false, strictAirGapUsage)
+	upgradeClusterCmd.Flags().BoolVar(&uc.allowSinglePointsOfFailure, "allow-single-points-of-failure", false, allowSinglePointsOfFailureUsage)
+	upgradeClusterCmd.Flags().DurationVar(&uc.timeout, "timeout", 0, "Maximum time the whole operation can take, for example 90m. When exceeded, the task running at that point fails (default no timeout)")
 	err := upgradeClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -72,22 +100,61 @@ func init() {
 }
 
 func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
-	if _, err := uc.commonValidations(ctx); err != nil {
+	clusterConfig, err := uc.commonValidations(ctx)
+	if err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
-	clusterSpec, err := newClusterSpec(uc.clusterOptions)
+	localArtifacts, err := uc.localArtifacts()
+	if err != nil {
+		return err
+	}
+	var specOpts []cluster.SpecOpt
+	if localArtifacts != nil {
+		specOpts = append(specOpts, cluster.WithLocalArtifacts(uc.artifactsDir, localArtifacts))
+	} else if uc.bundlesOverride == "" {
+		managementKubeconfig := uc.managementKubeconfig
+		if managementKubeconfig == "" {
+			managementKubeconfig = uc.kubeConfig(clusterConfig.Name)
+		}
+		specOpts = append(specOpts, clusterManifestsSpecOpts(ctx, managementKubeconfig)...)
+	}
+
+	clusterSpec, err := newClusterSpec(uc.clusterOptions, specOpts...)
+	if err != nil {
+		return err
+	}
+	if err = uc.validateStrictAirGap(clusterSpec, localArtifacts); err != nil {
+		return err
+	}
+	healthProbe, err := uc.startHealthProbe("upgrade", clusterSpec.Name)
 	if err != nil {
 		return err
 	}
+	defer stopHealthProbe(ctx, healthProbe)
+
+	if uc.forceClean && clusterSpec.ManagementCluster == nil {
+		if err = uc.confirmBootstrapCleanup(clusterSpec.Name); err != nil {
+			return err
+		}
+	}
+
+	factory := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(cc.mountDirs()...)
+	// Clusters upgraded from their management cluster don't need a bootstrap cluster. Without --kubeconfig the
+	// cluster is upgraded by itself through a bootstrap cluster, even if its config references a management cluster
+	if clusterSpec.ManagementCluster == nil {
+		factory.WithBootstrapper()
+	}
 
-	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(cc.mountDirs()...).
-		WithBootstrapper().
+	deps, err := factory.
+		WithPolicyBundles(uc.policyBundles...).
 		WithClusterManager(clusterSpec.Cluster).
 		WithProvider(uc.fileName, clusterSpec.Cluster, cc.skipIpCheck, uc.hardwareFileName).
 		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
 		WithWriter().
+		WithArtifactStore().
 		WithCAPIManager().
 		WithKubectl().
+		WithVelero().
 		Build(ctx)
 	if err != nil {
 		return err
@@ -98,6 +165,24 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 		return fmt.Errorf("Error: upgrade operation is not supported for provider tinkerbell")
 	}
 
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: uc.kubeConfig(clusterSpec.Name),
+	}
+
+	resultFile := operationResultFile(clusterSpec.Name, "upgrade")
+	workflowOpts := append([]workflows.Opt{workflows.WithTimeout(uc.timeout), workflows.WithResultFile(resultFile)}, uc.backupOptions.workflowOpts(deps.Velero, workloadCluster)...)
+	if uc.componentsOnly {
+		workflowOpts = append(workflowOpts, workflows.WithComponentsOnly())
+	}
+	notificationOpts, err := uc.notificationOptions.workflowOpts(clusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
+	workflowOpts = append(workflowOpts, notificationOpts...)
+	workflowOpts = append(workflowOpts, uc.healthProbeOptions.workflowOpts(healthProbe)...)
+	workflowOpts = append(workflowOpts, uc.validationReportOptions.workflowOpts()...)
+	workflowOpts = append(workflowOpts, workflows.WithWorkspaceCleanup(executables.OverridesRetention{Keep: uc.overridesKeep, Provider: deps.Provider}))
 	upgradeCluster := workflows.NewUpgrade(
 		deps.Bootstrapper,
 		deps.Provider,
@@ -105,13 +190,9 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 		deps.ClusterManager,
 		deps.FluxAddonClient,
 		deps.Writer,
+		workflowOpts...,
 	)
 
-	workloadCluster := &types.Cluster{
-		Name:           clusterSpec.Name,
-		KubeconfigFile: uc.kubeConfig(clusterSpec.Name),
-	}
-
 	var cluster *types.Cluster
 	if clusterSpec.ManagementCluster == nil {
 		cluster = &types.Cluster{
@@ -125,25 +206,40 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 		}
 	}
 
+	clusterLock, err := lockCluster(ctx, deps.Kubectl, clusterSpec.Name, "upgrade", cluster)
+	if err != nil {
+		return err
+	}
+	defer unlockCluster(ctx, clusterLock)
+
 	validationOpts := &validations.Opts{
-		Kubectl:           deps.Kubectl,
-		Spec:              clusterSpec,
-		WorkloadCluster:   workloadCluster,
-		ManagementCluster: cluster,
-		Provider:          deps.Provider,
+		Kubectl:                    deps.Kubectl,
+		Spec:                       clusterSpec,
+		WorkloadCluster:            workloadCluster,
+		ManagementCluster:          cluster,
+		Provider:                   deps.Provider,
+		LocalArtifacts:             localArtifacts,
+		AllowSinglePointsOfFailure: uc.allowSinglePointsOfFailure,
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
 
 	err = upgradeCluster.Run(ctx, clusterSpec, cluster, upgradeValidations, uc.forceClean)
-	return err
+	return workflowError(upgradeCluster.Result(), resultFile, err)
 }
 
+// commonValidations only checks docker for clusters not upgraded from a management cluster, which use a local bootstrap
+// cluster during the upgrade
 func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster *v1alpha1.Cluster, err error) {
-	clusterConfig, err := commonValidation(ctx, uc.fileName)
+	clusterConfig, err := validateClusterConfigFile(uc.fileName)
 	if err != nil {
 		return nil, err
 	}
-	if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, uc.wConfig, kubeconfigPattern) {
+	if uc.managementKubeconfig == "" {
+		if err = validateDocker(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterConfig.Name), clusterConfig.Name, uc.wConfig, kubeconfigPattern) {
 		return nil, fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
 	}
 	return clusterConfig, nil
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/fleet"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+const (
+	fleetUpgradeConfigPattern = "%s-eks-a-fleet-upgrade.yaml"
+	fleetUpgradeLogPattern    = "%s-eks-a-fleet-upgrade.log"
+)
+
+type upgradeClustersOptions struct {
+	managementKubeconfig string
+	clusters             []string
+	configDir            string
+	bundlesOverride      string
+	reportFile           string
+	kubeconfigBundle     string
+	timeout              time.Duration
+	strategy             fleet.Strategy
+}
+
+var ucs = &upgradeClustersOptions{}
+
+var upgradeClustersCmd = &cobra.Command{
+	Use:          "clusters --kubeconfig <management-cluster-kubeconfig>",
+	Short:        "Upgrade the workload clusters of a management cluster",
+	Long:         "This command upgrades many workload clusters managed by the same management cluster, starting with the canary clusters and then upgrading up to max-parallel clusters at a time",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ucs.upgradeClusters(cmd.Context())
+	},
+}
+
+func init() {
+	upgradeCmd.AddCommand(upgradeClustersCmd)
+	upgradeClustersCmd.Flags().StringVar(&ucs.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	upgradeClustersCmd.Flags().StringSliceVar(&ucs.clusters, "clusters", nil, "Workload clusters to upgrade (default all the workload clusters of the management cluster)")
+	upgradeClustersCmd.Flags().StringVar(&ucs.configDir, "config-dir", "", "Directory with <cluster-name>.yaml cluster configs to upgrade to. The config of the clusters without a file is exported from the management cluster, so they are only upgraded to this release")
+	upgradeClustersCmd.Flags().StringSliceVar(&ucs.strategy.Canary, "canary", nil, "Clusters upgraded first, one at a time. The rest are only upgraded if all the canaries succeed")
+	upgradeClustersCmd.Flags().IntVar(&ucs.strategy.MaxParallel, "max-parallel", 1, "Maximum number of clusters upgraded at the same time")
+	upgradeClustersCmd.Flags().BoolVar(&ucs.strategy.HaltOnFailure, "halt-on-failure", false, "Don't start more upgrades after a cluster upgrade fails")
+	upgradeClustersCmd.Flags().StringVar(&ucs.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	upgradeClustersCmd.Flags().StringVar(&ucs.reportFile, "report", "", "File to write the result of each cluster upgrade to, in json")
+	upgradeClustersCmd.Flags().StringVar(&ucs.kubeconfigBundle, "kubeconfig-bundle", "", "Directory to write the kubeconfigs of the upgraded clusters to, merged in a single kubeconfig and with an index of the clusters")
+	upgradeClustersCmd.Flags().DurationVar(&ucs.timeout, "timeout", 0, "Maximum time the upgrade of each cluster can take, for example 90m (default no timeout)")
+	err := upgradeClustersCmd.MarkFlagRequired("kubeconfig")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *upgradeClustersOptions) upgradeClusters(ctx context.Context) error {
+	if !validations.FileExists(o.managementKubeconfig) {
+		return fmt.Errorf("management cluster kubeconfig %s not found", o.managementKubeconfig)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(o.managementKubeconfig)).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{KubeconfigFile: o.managementKubeconfig}
+	clusters := o.clusters
+	if len(clusters) == 0 {
+		if clusters, err = workloadClusters(ctx, deps.Kubectl, managementCluster); err != nil {
+			return err
+		}
+	}
+	if len(clusters) == 0 {
+		logger.Info("The management cluster doesn't have workload clusters to upgrade")
+		return nil
+	}
+
+	// the cluster configs are prepared before any upgrade, so a cluster that can't be exported doesn't halt the fleet midway
+	configs := make(map[string]string, len(clusters))
+	exporter := clustermarshaller.NewExporter(deps.Kubectl)
+	for _, c := range clusters {
+		if configs[c], err = o.clusterConfig(ctx, exporter, managementCluster, c); err != nil {
+			return err
+		}
+	}
+
+	upgrader := fleet.UpgraderFunc(func(ctx context.Context, cluster string) error {
+		if o.strategy.MaxParallel > 1 {
+			return o.upgradeInSubprocess(ctx, cluster, configs[cluster])
+		}
+		options := &upgradeClusterOptions{
+			clusterOptions: clusterOptions{
+				fileName:             configs[cluster],
+				bundlesOverride:      o.bundlesOverride,
+				managementKubeconfig: o.managementKubeconfig,
+				timeout:              o.timeout,
+			},
+		}
+		return options.upgradeCluster(ctx)
+	})
+
+	logger.Info("Upgrading workload clusters", "clusters", len(clusters), "canaries", len(o.strategy.Canary), "maxParallel", o.strategy.MaxParallel)
+	report, err := fleet.NewOrchestrator(upgrader, o.strategy).Upgrade(ctx, clusters)
+	if err != nil {
+		return err
+	}
+
+	if err = printFleetReport(report); err != nil {
+		return err
+	}
+	if o.reportFile != "" {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(o.reportFile, content, 0o644); err != nil {
+			return fmt.Errorf("failed writing fleet upgrade report: %v", err)
+		}
+	}
+
+	if o.kubeconfigBundle != "" {
+		if err = writeFleetKubeconfigBundle(ctx, deps.Kubectl, o.managementKubeconfig, o.kubeconfigBundle, report); err != nil {
+			return err
+		}
+	}
+
+	if !report.Succeeded() {
+		return fmt.Errorf("%d of %d cluster upgrades failed and %d were skipped", report.Count(fleet.StatusFailed), len(report.Results), report.Count(fleet.StatusSkipped))
+	}
+	logger.MarkSuccess("All the workload clusters were upgraded")
+	return nil
+}
+
+// workloadClusters returns the clusters managed by the management cluster, without the management cluster itself
+func workloadClusters(ctx context.Context, kubectl *executables.Kubectl, managementCluster *types.Cluster) ([]string, error) {
+	eksaClusters, err := kubectl.GetEksaClusters(ctx, managementCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []string
+	for _, c := range eksaClusters {
+		if c.IsManaged() {
+			clusters = append(clusters, c.Name)
+		}
+	}
+	return clusters, nil
+}
+
+// clusterConfig returns the config file in the config dir for the cluster, or exports it from the management cluster
+func (o *upgradeClustersOptions) clusterConfig(ctx context.Context, exporter *clustermarshaller.Exporter, managementCluster *types.Cluster, clusterName string) (string, error) {
+	if o.configDir != "" {
+		file := filepath.Join(o.configDir, clusterName+".yaml")
+		if validations.FileExists(file) {
+			return file, nil
+		}
+	}
+
+	config, err := exporter.Export(ctx, managementCluster, clusterName)
+	if err != nil {
+		return "", fmt.Errorf("failed exporting config of cluster %s: %v", clusterName, err)
+	}
+	dir := workspace.Default().ClusterDir(clusterName)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, fmt.Sprintf(fleetUpgradeConfigPattern, clusterName))
+	if err = ioutil.WriteFile(file, config, 0o644); err != nil {
+		return "", fmt.Errorf("failed writing config of cluster %s: %v", clusterName, err)
+	}
+	return file, nil
+}
+
+// upgradeInSubprocess runs the upgrade cluster command for the cluster in its own process. The providers set their
+// endpoint and credentials in the environment of the process, so clusters upgraded at the same time can't share one.
+// The output of the upgrade is written to a log file in the cluster folder
+func (o *upgradeClustersOptions) upgradeInSubprocess(ctx context.Context, clusterName, configFile string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed finding the eksctl anywhere executable: %v", err)
+	}
+
+	args := []string{
+		"upgrade", "cluster",
+		"--filename", configFile,
+		"--kubeconfig", o.managementKubeconfig,
+		"--verbosity", strconv.Itoa(viper.GetInt("verbosity")),
+		"--wait-multiplier", strconv.FormatFloat(viper.GetFloat64("wait-multiplier"), 'f', -1, 64),
+	}
+	if levels := viper.GetStringSlice("log-levels"); len(levels) > 0 {
+		args = append(args, "--log-levels", strings.Join(levels, ","))
+	}
+	if o.bundlesOverride != "" {
+		args = append(args, "--bundles-override", o.bundlesOverride)
+	}
+	if o.timeout > 0 {
+		args = append(args, "--timeout", o.timeout.String())
+	}
+
+	dir := workspace.Default().ClusterDir(clusterName)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	logFile := filepath.Join(dir, fmt.Sprintf(fleetUpgradeLogPattern, clusterName))
+	out, err := os.Create(logFile)
+	if err != nil {
+		return fmt.Errorf("failed creating upgrade log of cluster %s: %v", clusterName, err)
+	}
+	defer out.Close()
+
+	logger.Info("Upgrading cluster", "cluster", clusterName, "log", logFile)
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err = cmd.Run(); err != nil {
+		return fmt.Errorf("upgrade of cluster %s failed: %v, see %s", clusterName, err, logFile)
+	}
+
+	return nil
+}
+
+func printFleetReport(report *fleet.Report) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tSTATUS\tDURATION\tERROR")
+	for _, r := range report.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Cluster, r.Status, r.Duration.Round(time.Second), r.Error)
+	}
+	return w.Flush()
+}
+
+// writeFleetKubeconfigBundle writes the kubeconfigs of the upgraded clusters, read from the management cluster, to dir
+func writeFleetKubeconfigBundle(ctx context.Context, client kubeconfig.SecretClient, managementKubeconfig, dir string, report *fleet.Report) error {
+	var upgraded []string
+	for _, r := range report.Results {
+		if r.Status == fleet.StatusSucceeded {
+			upgraded = append(upgraded, r.Cluster)
+		}
+	}
+	if len(upgraded) == 0 {
+		return nil
+	}
+
+	index, err := kubeconfig.WriteBundleFromManagementCluster(ctx, client, managementKubeconfig, dir, upgraded)
+	if err != nil {
+		return fmt.Errorf("failed writing kubeconfig bundle: %v", err)
+	}
+	logger.Info("Kubeconfigs of the upgraded clusters written", "kubeconfig", index.MergedPath())
+	return nil
+}
@@ -56,13 +56,16 @@ func preRunUpgradePlanCluster(cmd *cobra.Command, args []string) error {
 
 func init() {
 	upgradePlanCmd.AddCommand(upgradePlanClusterCmd)
-	upgradePlanClusterCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	upgradePlanClusterCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", clusterConfigFileUsage)
 	upgradePlanClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradePlanClusterCmd.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format: text|json")
 	err := upgradePlanClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
 	}
+	if err = registerFlagValues(upgradePlanClusterCmd, outputFlagName, outputText, outputJson); err != nil {
+		log.Fatalf("Error registering output completion: %v", err)
+	}
 }
 
 func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
@@ -95,10 +98,16 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 		return err
 	}
 
-	componentChangeDiffs := eksaupgrader.EksaChangeDiff(currentSpec, newClusterSpec)
+	appliedOverrides, err := deps.ClusterManager.AppliedEksaOverrides(ctx, workloadCluster, currentSpec)
+	if err != nil {
+		return err
+	}
+
+	componentChangeDiffs := eksaupgrader.EksaChangeDiff(currentSpec, newClusterSpec, appliedOverrides)
 	componentChangeDiffs.Append(fluxupgrader.FluxChangeDiff(currentSpec, newClusterSpec))
 	componentChangeDiffs.Append(capiupgrader.CapiChangeDiff(currentSpec, newClusterSpec, deps.Provider))
 	componentChangeDiffs.Append(cilium.ChangeDiff(currentSpec, newClusterSpec))
+	componentChangeDiffs.Append(eksaupgrader.KubernetesVersionsChangeDiff(currentSpec, newClusterSpec))
 
 	serializedDiff, err := serialize(componentChangeDiffs, output)
 	if err != nil {
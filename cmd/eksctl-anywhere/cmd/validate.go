@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate resources",
+	Long:  "Use eksctl anywhere validate to check resources, such as cluster configs, or the access to the provider before running a cluster operation",
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
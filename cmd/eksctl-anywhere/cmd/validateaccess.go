@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/features"
+	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/git/gogit"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type validateAccessOptions struct {
+	validationReportOptions
+	fileName string
+}
+
+var vao = &validateAccessOptions{}
+
+var validateAccessCmd = &cobra.Command{
+	Use:          "access -f <cluster-config-file>",
+	Short:        "Validate access to the provider and git",
+	Long:         "This command only checks that the provider endpoint and the GitOps repository are reachable with the configured credentials, without running the full preflight validations",
+	PreRunE:      preRunValidateAccess,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vao.validateAccess(cmd.Context())
+	},
+}
+
+func preRunValidateAccess(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	validateCmd.AddCommand(validateAccessCmd)
+	validateAccessCmd.Flags().StringVarP(&vao.fileName, "filename", "f", "", clusterConfigFileUsage)
+	vao.validationReportOptions.addFlags(validateAccessCmd.Flags())
+	err := validateAccessCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (vao *validateAccessOptions) validateAccess(ctx context.Context) error {
+	clusterConfig, err := v1alpha1.GetClusterConfig(vao.fileName)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster config from file: %v", err)
+	}
+	features.FeedGates(clusterConfig.FeatureGates())
+
+	deps, err := dependencies.NewFactory().
+		WithProvider(vao.fileName, clusterConfig, true, "").
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	runner := validations.NewRunner(vao.runnerOpts()...)
+	runner.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:        fmt.Sprintf("validate %s access", deps.Provider.Name()),
+			Remediation: "check the provider endpoint and the credentials env variables",
+			Err:         deps.Provider.ValidateAccess(ctx),
+		}
+	})
+
+	if clusterConfig.Spec.GitOpsRef != nil {
+		runner.Register(func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate git access",
+				Remediation: "check the git token and its scopes",
+				Err:         validateGitAccess(ctx, vao.fileName, clusterConfig),
+			}
+		})
+	}
+
+	if err = runner.Run(); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Access validated")
+	return nil
+}
+
+func validateGitAccess(ctx context.Context, fileName string, clusterConfig *v1alpha1.Cluster) error {
+	gitOpsConfig, err := v1alpha1.GetAndValidateGitOpsConfig(fileName, clusterConfig.Spec.GitOpsRef.Name, clusterConfig)
+	if err != nil {
+		return err
+	}
+
+	provider, err := gitFactory.New(gitFactory.Options{GithubGitClient: gogit.New(gogit.Options{})}).BuildProvider(ctx, &gitOpsConfig.Spec)
+	if err != nil {
+		return err
+	}
+
+	return provider.Validate(ctx)
+}
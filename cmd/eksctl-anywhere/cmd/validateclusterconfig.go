@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+type validateClusterConfigOptions struct {
+	fileName string
+}
+
+var vco = &validateClusterConfigOptions{}
+
+var validateClusterConfigCmd = &cobra.Command{
+	Use:          "clusterconfig -f <cluster-config-file>",
+	Short:        "Validate a cluster config file against the schema",
+	Long:         "This command checks every EKS Anywhere object in a cluster config file against its schema, verifies the references between them and reports all the problems found",
+	PreRunE:      preRunValidateClusterConfig,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vco.validateClusterConfig()
+	},
+}
+
+func preRunValidateClusterConfig(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	validateCmd.AddCommand(validateClusterConfigCmd)
+	validateClusterConfigCmd.Flags().StringVarP(&vco.fileName, "filename", "f", "", clusterConfigFileUsage)
+	err := validateClusterConfigCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (vco *validateClusterConfigOptions) validateClusterConfig() error {
+	validator, err := schema.NewValidator()
+	if err != nil {
+		return err
+	}
+
+	if err = validator.ValidateFile(vco.fileName); err != nil {
+		return fmt.Errorf("invalid cluster config %s:\n%v", vco.fileName, err)
+	}
+
+	docs, err := v1alpha1.ParseClusterConfigFile(vco.fileName, v1alpha1.WithStrictParsing())
+	if err != nil {
+		return fmt.Errorf("invalid cluster config %s:\n%v", vco.fileName, err)
+	}
+	if err = docs.ValidateReferences(); err != nil {
+		return fmt.Errorf("invalid cluster config %s:\n%v", vco.fileName, err)
+	}
+
+	logger.MarkPass("Cluster config is valid", "file", vco.fileName)
+	return nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/drift"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+type validateDriftOptions struct {
+	clusterOptions
+	wConfig string
+	output  string
+}
+
+func (vd *validateDriftOptions) kubeConfig(clusterName string) string {
+	if vd.wConfig == "" {
+		return defaultKubeconfig(clusterName)
+	}
+	return vd.wConfig
+}
+
+func (vd *validateDriftOptions) managementCluster(clusterSpec *cluster.Spec) *types.Cluster {
+	if clusterSpec.ManagementCluster == nil {
+		return &types.Cluster{
+			Name:           clusterSpec.Name,
+			KubeconfigFile: vd.kubeConfig(clusterSpec.Name),
+		}
+	}
+	return &types.Cluster{
+		Name:           clusterSpec.ManagementCluster.Name,
+		KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+	}
+}
+
+var vd = &validateDriftOptions{}
+
+var validateDriftCmd = &cobra.Command{
+	Use:          "drift -f <cluster-config-file>",
+	Short:        "Detect drift in the EKS-A managed components",
+	Long:         "This command compares the Cilium, kube-vip, CAPI and EKS-A controllers running in the cluster with the versions of the cluster bundle and reports the ones missing or modified",
+	PreRunE:      preRunValidateDrift,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vd.validateDrift(cmd.Context())
+	},
+}
+
+func preRunValidateDrift(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	validateCmd.AddCommand(validateDriftCmd)
+	validateDriftCmd.Flags().StringVarP(&vd.fileName, "filename", "f", "", clusterConfigFileUsage)
+	validateDriftCmd.Flags().StringVarP(&vd.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster to check")
+	validateDriftCmd.Flags().StringVar(&vd.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	validateDriftCmd.Flags().StringVarP(&vd.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	err := validateDriftCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (vd *validateDriftOptions) validateDrift(ctx context.Context) error {
+	clusterConfig, err := commonValidation(ctx, vd.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(workspace.Default().ClusterDir(clusterConfig.Name), clusterConfig.Name, vd.wConfig, kubeconfigPattern) {
+		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(vd.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(vd.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	// The expected state comes from the bundle stored with the cluster, not from the one of this CLI version
+	currentSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, vd.managementCluster(clusterSpec), clusterSpec.Name)
+	if err != nil {
+		return err
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: vd.kubeConfig(clusterSpec.Name),
+	}
+	logger.V(0).Info("Checking EKS-A managed components for drift", "cluster", clusterSpec.Name)
+	report, err := drift.NewDetector(drift.NewKubectlClient(deps.Kubectl, workloadCluster)).Detect(ctx, currentSpec)
+	if err != nil {
+		return err
+	}
+
+	serializedReport, err := serializeDriftReport(report, vd.output)
+	if err != nil {
+		return err
+	}
+	fmt.Print(serializedReport)
+
+	if report.Drifted() {
+		return fmt.Errorf("drift detected in components: %s", strings.Join(report.Components(), ", "))
+	}
+
+	return nil
+}
+
+func serializeDriftReport(report *drift.Report, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeDriftReportToText(report)
+	case outputJson:
+		jsonReport, err := json.Marshal(report)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the drift report to json: %v", err)
+		}
+		return string(jsonReport), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeDriftReportToText(report *drift.Report) (string, error) {
+	if !report.Drifted() {
+		return "No drift detected in the EKS-A managed components\n", nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tKIND\tNAMESPACE\tNAME\tCONTAINER\tEXPECTED IMAGE\tACTUAL IMAGE")
+	for _, d := range report.Drifts {
+		actual := d.Actual
+		if d.Missing() {
+			actual = "<missing>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", d.Component, d.Kind, d.Namespace, d.Name, d.Container, d.Expected, actual)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
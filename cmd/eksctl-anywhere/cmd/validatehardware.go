@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/hardware"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type validateHardwareOptions struct {
+	fileName  string
+	inventory string
+}
+
+var vho = &validateHardwareOptions{}
+
+var validateHardwareCmd = &cobra.Command{
+	Use:          "hardware -f <cluster-config-file> --inventory <hardware-inventory-file>",
+	Short:        "Validate a hardware inventory against a bare metal cluster config",
+	Long:         "This command checks the machines of a csv or yaml hardware inventory and verifies there are enough of them, matching the hardware selectors of the machine configs, for all the nodes of the cluster",
+	PreRunE:      preRunValidateHardware,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vho.validateHardware()
+	},
+}
+
+func preRunValidateHardware(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	validateCmd.AddCommand(validateHardwareCmd)
+	validateHardwareCmd.Flags().StringVarP(&vho.fileName, "filename", "f", "", clusterConfigFileUsage)
+	validateHardwareCmd.Flags().StringVar(&vho.inventory, "inventory", "", "Csv or yaml file with the hardware inventory")
+	for _, flag := range []string{"filename", "inventory"} {
+		if err := validateHardwareCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (vho *validateHardwareOptions) validateHardware() error {
+	catalog, err := hardware.ReadInventory(vho.inventory)
+	if err != nil {
+		return err
+	}
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(vho.fileName)
+	if err != nil {
+		return fmt.Errorf("failed reading cluster config %s: %v", vho.fileName, err)
+	}
+	machineConfigs, err := v1alpha1.GetTinkerbellMachineConfigs(vho.fileName)
+	if err != nil {
+		return fmt.Errorf("failed reading tinkerbell machine configs %s: %v", vho.fileName, err)
+	}
+
+	if err = catalog.ValidateRequirements(hardware.RequirementsForCluster(clusterConfig, machineConfigs)); err != nil {
+		return fmt.Errorf("hardware inventory %s can't fit cluster %s: %v", vho.inventory, clusterConfig.Name, err)
+	}
+
+	logger.MarkPass("Hardware inventory is valid", "inventory", vho.inventory, "machines", len(catalog.Machines()))
+	return nil
+}
@@ -11,20 +11,30 @@ import (
 )
 
 func commonValidation(ctx context.Context, clusterConfigFile string) (*v1alpha1.Cluster, error) {
+	if err := validateDocker(ctx); err != nil {
+		return nil, err
+	}
+	return validateClusterConfigFile(clusterConfigFile)
+}
+
+func validateDocker(ctx context.Context) error {
 	docker := executables.BuildDockerExecutable()
 	err := validations.CheckMinimumDockerVersion(ctx, docker)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate docker: %v", err)
+		return fmt.Errorf("failed to validate docker: %v", err)
 	}
 	if runtime.GOOS == "darwin" {
 		err = validations.CheckDockerDesktopVersion(ctx, docker)
 		if err != nil {
-			return nil, fmt.Errorf("failed to validate docker desktop: %v", err)
+			return fmt.Errorf("failed to validate docker desktop: %v", err)
 		}
 	}
 	validations.CheckDockerAllocatedMemory(ctx, docker)
-	clusterConfigFileExist := validations.FileExists(clusterConfigFile)
-	if !clusterConfigFileExist {
+	return nil
+}
+
+func validateClusterConfigFile(clusterConfigFile string) (*v1alpha1.Cluster, error) {
+	if !clusterConfigFileExists(clusterConfigFile) {
 		return nil, fmt.Errorf("the cluster config file %s does not exist", clusterConfigFile)
 	}
 	clusterConfig, err := v1alpha1.GetAndValidateClusterConfig(clusterConfigFile)
@@ -33,3 +43,8 @@ func commonValidation(ctx context.Context, clusterConfigFile string) (*v1alpha1.
 	}
 	return clusterConfig, nil
 }
+
+// clusterConfigFileExists checks the cluster config file of the -f flag exists, always true for the standard input
+func clusterConfigFileExists(fileName string) bool {
+	return fileName == v1alpha1.StdinFileName || validations.FileExists(fileName)
+}
@@ -17,17 +17,14 @@ func main() {
 	go func() {
 		<-sigChannel
 		logger.Info("Warning: Terminating this operation may leave the cluster in an irrecoverable state")
-		os.Exit(-1)
+		os.Exit(cmd.ExitInterrupted)
 	}()
 	if eksctl.Enabled() {
 		err := eksctl.ValidateVersion()
 		if err != nil {
 			fmt.Println(err)
-			os.Exit(-1)
+			os.Exit(cmd.ExitFailure)
 		}
 	}
-	if cmd.Execute() == nil {
-		os.Exit(0)
-	}
-	os.Exit(-1)
+	os.Exit(cmd.ExitCode(cmd.Execute()))
 }
@@ -73,6 +73,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 		}
 	}()
 
+	if reconcileDeletionProtection(cluster) {
+		log.Info("Cluster deletion is blocked by its deletion protection")
+		return ctrl.Result{}, nil
+	}
+
 	if !cluster.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, cluster, log)
 	}
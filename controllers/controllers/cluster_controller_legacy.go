@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -16,6 +18,8 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers/controllers/resource"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 )
 
 // ClusterReconcilerLegacy reconciles a Cluster object
@@ -27,7 +31,13 @@ type ClusterReconcilerLegacy struct {
 	resourceFetcher resource.ResourceFetcher
 }
 
-func NewClusterReconcilerLegacy(client client.Client, log logr.Logger, scheme *runtime.Scheme) *ClusterReconcilerLegacy {
+// NewClusterReconcilerLegacy builds the legacy cluster reconciler. When govc is set, it tags the vSphere machines
+// and folders with the cluster resource tags
+func NewClusterReconcilerLegacy(client client.Client, log logr.Logger, scheme *runtime.Scheme, govc *executables.Govc) *ClusterReconcilerLegacy {
+	var opts []resource.ClusterReconcilerOpt
+	if govc != nil {
+		opts = append(opts, resource.WithVSphereResourceTagger(vsphere.NewResourceTagger(govc)))
+	}
 	return &ClusterReconcilerLegacy{
 		Client: client,
 		Log:    log,
@@ -37,7 +47,8 @@ func NewClusterReconcilerLegacy(client client.Client, log logr.Logger, scheme *r
 				resource.NewCAPIResourceFetcher(client, log),
 				resource.NewCAPIResourceUpdater(client, log),
 				time.Now,
-				log),
+				log,
+				opts...),
 		},
 		resourceFetcher: resource.NewCAPIResourceFetcher(client, log),
 	}
@@ -70,6 +81,11 @@ func (r *ClusterReconcilerLegacy) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}()
 
+	if reconcileDeletionProtection(cluster) {
+		r.Log.Info("Cluster deletion is blocked by its deletion protection")
+		return ctrl.Result{}, nil
+	}
+
 	// Ignore deleted Clusters, this can happen when foregroundDeletion
 	// is enabled
 	if !cluster.DeletionTimestamp.IsZero() {
@@ -84,16 +100,67 @@ func (r *ClusterReconcilerLegacy) Reconcile(ctx context.Context, req ctrl.Reques
 
 	// dry run
 	result, err := r.reconcile(ctx, req.NamespacedName, true)
+	if deferred := changesDeferred(err); deferred != nil {
+		return r.deferChanges(cluster, deferred), nil
+	}
 	if err != nil {
 		r.Log.Error(err, "Dry run failed to reconcile Cluster")
 		return result, err
 	}
 	// non dry run
 	result, err = r.reconcile(ctx, req.NamespacedName, false)
+	if deferred := changesDeferred(err); deferred != nil {
+		return r.deferChanges(cluster, deferred), nil
+	}
 	if err != nil {
 		r.Log.Error(err, "Failed to reconcile Cluster")
+		return result, err
+	}
+
+	if len(cluster.Spec.MaintenanceWindows) > 0 {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               anywherev1.PendingChangesCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ChangesApplied",
+			ObservedGeneration: cluster.Generation,
+		})
+	} else {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, anywherev1.PendingChangesCondition)
+	}
+	return result, nil
+}
+
+// deferChanges reports the pending changes in the cluster status and requeues the cluster for when the next maintenance window starts
+func (r *ClusterReconcilerLegacy) deferChanges(cluster *anywherev1.Cluster, deferred *resource.ChangesDeferredError) ctrl.Result {
+	r.Log.Info("Deferring changes until the next maintenance window", "resources", deferred.Resources, "nextWindow", deferred.NextWindow)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               anywherev1.PendingChangesCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "OutsideMaintenanceWindow",
+		Message:            deferred.Error(),
+		ObservedGeneration: cluster.Generation,
+	})
+
+	if deferred.NextWindow.IsZero() {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: time.Until(deferred.NextWindow)}
+}
+
+func changesDeferred(err error) *resource.ChangesDeferredError {
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	if agg, ok := err.(kerrors.Aggregate); ok {
+		errs = agg.Errors()
+	}
+	for _, e := range errs {
+		if deferred, ok := e.(*resource.ChangesDeferredError); ok {
+			return deferred
+		}
 	}
-	return result, err
+	return nil
 }
 
 func (r *ClusterReconcilerLegacy) reconcile(ctx context.Context, objectKey types.NamespacedName, dryRun bool) (ctrl.Result, error) {
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// reconcileDeletionProtection keeps the deletion protection finalizer in sync with the cluster spec
+// and reports if the cluster deletion is blocked by it. The changes are persisted by the patch helper
+func reconcileDeletionProtection(cluster *anywherev1.Cluster) (blocked bool) {
+	if !cluster.Spec.DeletionProtection {
+		controllerutil.RemoveFinalizer(cluster, anywherev1.DeletionProtectionFinalizer)
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, anywherev1.DeletionBlockedCondition)
+		return false
+	}
+
+	if cluster.DeletionTimestamp.IsZero() {
+		controllerutil.AddFinalizer(cluster, anywherev1.DeletionProtectionFinalizer)
+		return false
+	}
+
+	// Only block the deletion of clusters that were protected before the deletion started,
+	// the finalizer can't be added once the object is being deleted
+	if !controllerutil.ContainsFinalizer(cluster, anywherev1.DeletionProtectionFinalizer) {
+		return false
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               anywherev1.DeletionBlockedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DeletionProtectionEnabled",
+		Message:            "set spec.deletionProtection to false to delete the cluster",
+		ObservedGeneration: cluster.Generation,
+	})
+	return true
+}
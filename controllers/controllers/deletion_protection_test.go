@@ -0,0 +1,74 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers/controllers"
+	_ "github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func protectedCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec:       anywherev1.ClusterSpec{DeletionProtection: true},
+	}
+}
+
+func reconcileCluster(g *WithT, cl client.Client, c *anywherev1.Cluster) *anywherev1.Cluster {
+	ctx := context.Background()
+	r := controllers.NewClusterReconciler(cl, logf.Log, cl.Scheme(), nil)
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(c)})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(c), got)).To(Succeed())
+	return got
+}
+
+func TestClusterReconcilerAddsDeletionProtectionFinalizer(t *testing.T) {
+	g := NewWithT(t)
+	c := protectedCluster()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(c).Build()
+
+	got := reconcileCluster(g, cl, c)
+	g.Expect(got.Finalizers).To(ConsistOf(anywherev1.DeletionProtectionFinalizer))
+	g.Expect(meta.FindStatusCondition(got.Status.Conditions, anywherev1.DeletionBlockedCondition)).To(BeNil())
+}
+
+func TestClusterReconcilerBlocksDeletionOfProtectedCluster(t *testing.T) {
+	g := NewWithT(t)
+	c := protectedCluster()
+	now := metav1.Now()
+	c.DeletionTimestamp = &now
+	c.Finalizers = []string{anywherev1.DeletionProtectionFinalizer}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(c).Build()
+
+	got := reconcileCluster(g, cl, c)
+	g.Expect(got.Finalizers).To(ConsistOf(anywherev1.DeletionProtectionFinalizer))
+	condition := meta.FindStatusCondition(got.Status.Conditions, anywherev1.DeletionBlockedCondition)
+	g.Expect(condition).NotTo(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+}
+
+func TestClusterReconcilerRemovesDeletionProtectionFinalizer(t *testing.T) {
+	g := NewWithT(t)
+	c := protectedCluster()
+	c.Spec.DeletionProtection = false
+	c.Finalizers = []string{anywherev1.DeletionProtectionFinalizer, "other"}
+	c.Status.Conditions = []metav1.Condition{{Type: anywherev1.DeletionBlockedCondition, Status: metav1.ConditionTrue}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(c).Build()
+
+	got := reconcileCluster(g, cl, c)
+	g.Expect(got.Finalizers).To(ConsistOf("other"))
+	g.Expect(meta.FindStatusCondition(got.Status.Conditions, anywherev1.DeletionBlockedCondition)).To(BeNil())
+}
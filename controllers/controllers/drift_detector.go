@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/drift"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const ComponentDriftReason = "ComponentDrift"
+
+// DriftDetector periodically compares the EKS-A managed components running in the management cluster
+// with the bundle of its Cluster and reports the drifts as warning events on the Cluster
+type DriftDetector struct {
+	client   client.Reader
+	log      logr.Logger
+	recorder record.EventRecorder
+	interval time.Duration
+	detector *drift.Detector
+}
+
+// NewDriftDetector takes an uncached reader, so the detection doesn't need to watch every pod in the cluster
+func NewDriftDetector(client client.Reader, log logr.Logger, recorder record.EventRecorder, interval time.Duration) *DriftDetector {
+	return &DriftDetector{
+		client:   client,
+		log:      log,
+		recorder: recorder,
+		interval: interval,
+		detector: drift.NewDetector(drift.NewRuntimeClient(client)),
+	}
+}
+
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+
+// Start runs the detection every interval until the context is cancelled. It implements manager.Runnable
+func (d *DriftDetector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.DetectAll(ctx)
+		}
+	}
+}
+
+// DetectAll checks the components of the self managed clusters, which are the ones running this controller.
+// Errors are logged and the detection is retried in the next interval
+func (d *DriftDetector) DetectAll(ctx context.Context) {
+	clusters := &anywherev1.ClusterList{}
+	if err := d.client.List(ctx, clusters); err != nil {
+		d.log.Error(err, "Failed listing clusters for drift detection")
+		return
+	}
+
+	for i := range clusters.Items {
+		c := &clusters.Items[i]
+		if !c.IsSelfManaged() || !c.DeletionTimestamp.IsZero() || c.IsReconcilePaused() {
+			continue
+		}
+		if err := d.Detect(ctx, c); err != nil {
+			d.log.Error(err, "Failed detecting drift", "cluster", c.Name)
+		}
+	}
+}
+
+// Detect compares the components of the cluster with its bundle and records an event for each drift
+func (d *DriftDetector) Detect(ctx context.Context, c *anywherev1.Cluster) error {
+	clusterSpec, err := cluster.BuildSpecForCluster(ctx, c, d.bundles, nil)
+	if err != nil {
+		return err
+	}
+
+	report, err := d.detector.Detect(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	if !report.Drifted() {
+		d.log.V(4).Info("No drift detected", "cluster", c.Name)
+		return nil
+	}
+
+	d.log.Info("Drift detected in EKS-A managed components", "cluster", c.Name, "components", report.Components())
+	for _, drift := range report.Drifts {
+		d.recorder.Event(c, corev1.EventTypeWarning, ComponentDriftReason, drift.String())
+	}
+
+	return nil
+}
+
+func (d *DriftDetector) bundles(ctx context.Context, name, namespace string) (*releasev1alpha1.Bundles, error) {
+	bundles := &releasev1alpha1.Bundles{}
+	if err := d.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, bundles); err != nil {
+		return nil, err
+	}
+
+	return bundles, nil
+}
@@ -0,0 +1,60 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/controllers/controllers"
+	_ "github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// getsRecorder records the objects read with Get
+type getsRecorder struct {
+	client.Reader
+	gets []client.ObjectKey
+}
+
+func (r *getsRecorder) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	r.gets = append(r.gets, key)
+	return r.Reader.Get(ctx, key, obj)
+}
+
+func TestDriftDetectorDetectMissingBundles(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(c).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	d := controllers.NewDriftDetector(cl, logf.Log, recorder, 0)
+	g.Expect(d.Detect(ctx, c)).To(MatchError(ContainSubstring("failed fetching Bundles for cluster")))
+	g.Expect(recorder.Events).To(BeEmpty())
+}
+
+func TestDriftDetectorDetectAllSkipsClusters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	workload := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec:       anywherev1.ClusterSpec{ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"}},
+	}
+	paused := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "paused", Namespace: "default"},
+	}
+	paused.PauseReconcile()
+	mgmt := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"}}
+	cl := &getsRecorder{Reader: fake.NewClientBuilder().WithRuntimeObjects(workload, paused, mgmt).Build()}
+	recorder := record.NewFakeRecorder(10)
+
+	controllers.NewDriftDetector(cl, logf.Log, recorder, 0).DetectAll(ctx)
+	g.Expect(cl.gets).To(ConsistOf(client.ObjectKey{Name: "mgmt", Namespace: "default"}))
+	g.Expect(recorder.Events).To(BeEmpty())
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/notification"
+)
+
+// NotifiersBuilder builds the notifiers of a cluster notifications config
+type NotifiersBuilder func(config *anywherev1.NotificationsConfiguration) (notification.Notifier, error)
+
+// HealthMonitor periodically checks the Ready condition of the CAPI cluster of each EKS-A cluster with notifications
+// and notifies the health transitions. The first check of a cluster only records its health, so restarting
+// the controller doesn't send a notification for every cluster
+type HealthMonitor struct {
+	client       client.Reader
+	log          logr.Logger
+	interval     time.Duration
+	now          func() time.Time
+	newNotifiers NotifiersBuilder
+	healthy      map[types.NamespacedName]bool
+}
+
+func NewHealthMonitor(client client.Reader, log logr.Logger, interval time.Duration, newNotifiers NotifiersBuilder) *HealthMonitor {
+	return &HealthMonitor{
+		client:       client,
+		log:          log,
+		interval:     interval,
+		now:          time.Now,
+		newNotifiers: newNotifiers,
+		healthy:      map[types.NamespacedName]bool{},
+	}
+}
+
+// DefaultNotifiersBuilder builds the Slack, webhook and SNS notifiers set in the config
+func DefaultNotifiersBuilder(config *anywherev1.NotificationsConfiguration) (notification.Notifier, error) {
+	return notification.New(config)
+}
+
+// Start runs the checks every interval until the context is cancelled. It implements manager.Runnable
+func (m *HealthMonitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.CheckAll(ctx)
+		}
+	}
+}
+
+// CheckAll checks the clusters with notifications. Errors are logged and the check is retried in the next interval
+func (m *HealthMonitor) CheckAll(ctx context.Context) {
+	clusters := &anywherev1.ClusterList{}
+	if err := m.client.List(ctx, clusters); err != nil {
+		m.log.Error(err, "Failed listing clusters for health monitoring")
+		return
+	}
+
+	seen := make(map[types.NamespacedName]bool, len(clusters.Items))
+	for i := range clusters.Items {
+		c := &clusters.Items[i]
+		if c.Spec.Notifications == nil || !c.DeletionTimestamp.IsZero() || c.IsReconcilePaused() {
+			continue
+		}
+		seen[types.NamespacedName{Name: c.Name, Namespace: c.Namespace}] = true
+		if err := m.Check(ctx, c); err != nil {
+			m.log.Error(err, "Failed checking cluster health", "cluster", c.Name)
+		}
+	}
+
+	// forget the clusters that are gone or don't have notifications anymore
+	for key := range m.healthy {
+		if !seen[key] {
+			delete(m.healthy, key)
+		}
+	}
+}
+
+// Check reads the health of the cluster and notifies if it changed since the last check
+func (m *HealthMonitor) Check(ctx context.Context, c *anywherev1.Cluster) error {
+	capiCluster := &clusterv1.Cluster{}
+	err := m.client.Get(ctx, types.NamespacedName{Name: c.Name, Namespace: constants.EksaSystemNamespace}, capiCluster)
+	if apierrors.IsNotFound(err) {
+		m.log.V(4).Info("CAPI cluster not found, skipping health check", "cluster", c.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	key := types.NamespacedName{Name: c.Name, Namespace: c.Namespace}
+	healthy := conditions.IsTrue(capiCluster, clusterv1.ReadyCondition)
+	previous, checked := m.healthy[key]
+	m.healthy[key] = healthy
+	if !checked || previous == healthy {
+		return nil
+	}
+
+	event := notification.Event{
+		Type:    notification.ClusterHealthy,
+		Cluster: c.Name,
+		Time:    m.now().UTC(),
+	}
+	if !healthy {
+		event.Type = notification.ClusterUnhealthy
+		event.Message = conditions.GetMessage(capiCluster, clusterv1.ReadyCondition)
+	}
+	m.log.Info("Cluster health changed", "cluster", c.Name, "healthy", healthy)
+
+	notifier, err := m.newNotifiers(c.Spec.Notifications)
+	if err != nil {
+		return err
+	}
+	return notifier.Notify(ctx, event)
+}
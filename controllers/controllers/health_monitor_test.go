@@ -0,0 +1,86 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/controllers/controllers"
+	_ "github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/notification"
+)
+
+type eventsRecorder struct {
+	events []notification.Event
+}
+
+func (r *eventsRecorder) Notify(_ context.Context, event notification.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *eventsRecorder) builder(*anywherev1.NotificationsConfiguration) (notification.Notifier, error) {
+	return r, nil
+}
+
+func capiCluster(name string, ready bool) *clusterv1.Cluster {
+	c := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace}}
+	if ready {
+		conditions.MarkTrue(c, clusterv1.ReadyCondition)
+	} else {
+		conditions.MarkFalse(c, clusterv1.ReadyCondition, "ControlPlaneNotReady", clusterv1.ConditionSeverityError, "1 of 3 control plane machines unhealthy")
+	}
+	return c
+}
+
+func TestHealthMonitorCheckAllNotifiesTransitions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	withNotifications := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{Notifications: &anywherev1.NotificationsConfiguration{
+			Webhooks: []anywherev1.WebhookNotification{{URL: "https://hooks.example.com"}},
+		}},
+	}
+	withoutNotifications := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "default"}}
+	prod := capiCluster("prod", true)
+	cl := fake.NewClientBuilder().WithRuntimeObjects(withNotifications, withoutNotifications, prod, capiCluster("dev", false)).Build()
+	recorder := &eventsRecorder{}
+	m := controllers.NewHealthMonitor(cl, logf.Log, 0, recorder.builder)
+
+	m.CheckAll(ctx)
+	g.Expect(recorder.events).To(BeEmpty(), "first check only records the health")
+
+	conditions.MarkFalse(prod, clusterv1.ReadyCondition, "ControlPlaneNotReady", clusterv1.ConditionSeverityError, "1 of 3 control plane machines unhealthy")
+	g.Expect(cl.Status().Update(ctx, prod)).To(Succeed())
+	m.CheckAll(ctx)
+	m.CheckAll(ctx)
+	g.Expect(recorder.events).To(HaveLen(1))
+	g.Expect(recorder.events[0].Type).To(Equal(notification.ClusterUnhealthy))
+	g.Expect(recorder.events[0].Cluster).To(Equal("prod"))
+	g.Expect(recorder.events[0].Message).To(Equal("1 of 3 control plane machines unhealthy"))
+
+	conditions.MarkTrue(prod, clusterv1.ReadyCondition)
+	g.Expect(cl.Status().Update(ctx, prod)).To(Succeed())
+	m.CheckAll(ctx)
+	g.Expect(recorder.events).To(HaveLen(2))
+	g.Expect(recorder.events[1].Type).To(Equal(notification.ClusterHealthy))
+}
+
+func TestHealthMonitorCheckMissingCAPICluster(t *testing.T) {
+	g := NewWithT(t)
+	c := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"}}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(c).Build()
+	recorder := &eventsRecorder{}
+
+	g.Expect(controllers.NewHealthMonitor(cl, logf.Log, 0, recorder.builder).Check(context.Background(), c)).To(Succeed())
+	g.Expect(recorder.events).To(BeEmpty())
+}
@@ -193,7 +193,7 @@ func (r *CapiResourceFetcher) MachineDeployment(ctx context.Context, cs *anywher
 		return nil, fmt.Errorf("no machine deployments found for cluster %s", cs.Name)
 	}
 
-	mdName := fmt.Sprintf("%s-%s", cs.Name, wnc.Name)
+	mdName := cs.MachineDeploymentName(wnc.Name)
 	if _, ok := deployments[mdName]; ok {
 		return deployments[mdName], nil
 	} else {
@@ -0,0 +1,105 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// disruptiveKinds are the objects that roll out new machines when their spec changes
+var disruptiveKinds = map[string]bool{
+	"KubeadmControlPlane": true,
+	"MachineDeployment":   true,
+	"EtcdadmCluster":      true,
+}
+
+// ChangesDeferredError is returned by the reconciler when the cluster has changes that would
+// replace machines but it's outside all of its maintenance windows
+type ChangesDeferredError struct {
+	Resources  []string
+	NextWindow time.Time
+}
+
+func (e *ChangesDeferredError) Error() string {
+	return fmt.Sprintf("changes to %s deferred until the next maintenance window at %s", strings.Join(e.Resources, ", "), e.NextWindow.UTC().Format(time.RFC3339))
+}
+
+// deferDisruptiveChanges returns a ChangesDeferredError if the cluster is outside its maintenance windows
+// and applying the resources would roll out new machines. Nothing is applied in that case, so the
+// objects that go together, like a machine template and the MachineDeployment using it, don't get out of sync
+func (cor *clusterReconciler) deferDisruptiveChanges(ctx context.Context, cs *anywherev1.Cluster, resources []*unstructured.Unstructured) error {
+	now := cor.now()
+	if cs.InMaintenanceWindow(now) {
+		return nil
+	}
+
+	var disrupted []string
+	for _, resource := range resources {
+		if !disruptiveKinds[resource.GetKind()] {
+			continue
+		}
+		existing, err := cor.Fetch(ctx, resource.GetName(), resource.GetNamespace(), resource.GetKind(), resource.GetAPIVersion())
+		if err != nil {
+			// new objects don't replace any machine
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if specChanged(resource.Object["spec"], existing.Object["spec"], true) {
+			disrupted = append(disrupted, fmt.Sprintf("%s %s", resource.GetKind(), resource.GetName()))
+		}
+	}
+
+	if len(disrupted) == 0 {
+		return nil
+	}
+	sort.Strings(disrupted)
+	return &ChangesDeferredError{Resources: disrupted, NextWindow: cs.NextMaintenanceWindow(now)}
+}
+
+// specChanged returns true if any field set in desired has a different value in existing.
+// The fields only set in existing are ignored since the api server and the CAPI webhooks default them.
+// Replicas are ignored at the top level since scaling doesn't replace the existing machines
+func specChanged(desired, existing interface{}, topLevel bool) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		e, ok := existing.(map[string]interface{})
+		if !ok {
+			return true
+		}
+		for k, v := range d {
+			if topLevel && k == "replicas" {
+				continue
+			}
+			if specChanged(v, e[k], false) {
+				return true
+			}
+		}
+		return false
+	case []interface{}:
+		e, ok := existing.([]interface{})
+		if !ok || len(d) != len(e) {
+			return true
+		}
+		for i := range d {
+			if specChanged(d[i], e[i], false) {
+				return true
+			}
+		}
+		return false
+	case nil:
+		return false
+	default:
+		// numbers can be decoded as int64 or float64 depending on where the object comes from
+		return !reflect.DeepEqual(d, existing) && fmt.Sprint(d) != fmt.Sprint(existing)
+	}
+}
@@ -26,10 +26,21 @@ type clusterReconciler struct {
 	vsphereTemplate      VsphereTemplate
 	dockerTemplate       DockerTemplate
 	awsIamConfigTemplate AWSIamConfigTemplate
+	now                  anywhereTypes.NowFunc
 }
 
-func NewClusterReconciler(resourceFetcher ResourceFetcher, resourceUpdater ResourceUpdater, now anywhereTypes.NowFunc, log logr.Logger) *clusterReconciler {
-	return &clusterReconciler{
+// ClusterReconcilerOpt configures the clusterReconciler
+type ClusterReconcilerOpt func(*clusterReconciler)
+
+// WithVSphereResourceTagger tags the vSphere machines and folders of the clusters with their resource tags
+func WithVSphereResourceTagger(tagger VSphereResourceTagger) ClusterReconcilerOpt {
+	return func(r *clusterReconciler) {
+		r.vsphereTemplate.ResourceTagger = tagger
+	}
+}
+
+func NewClusterReconciler(resourceFetcher ResourceFetcher, resourceUpdater ResourceUpdater, now anywhereTypes.NowFunc, log logr.Logger, opts ...ClusterReconcilerOpt) *clusterReconciler {
+	r := &clusterReconciler{
 		Log:             log,
 		ResourceFetcher: resourceFetcher,
 		ResourceUpdater: resourceUpdater,
@@ -45,7 +56,12 @@ func NewClusterReconciler(resourceFetcher ResourceFetcher, resourceUpdater Resou
 		awsIamConfigTemplate: AWSIamConfigTemplate{
 			ResourceFetcher: resourceFetcher,
 		},
+		now: now,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (cor *clusterReconciler) Reconcile(ctx context.Context, objectKey types.NamespacedName, dryRun bool) error {
@@ -117,6 +133,9 @@ func (cor *clusterReconciler) Reconcile(ctx context.Context, objectKey types.Nam
 			resources = append(resources, r...)
 		}
 	}
+	if err = cor.deferDisruptiveChanges(ctx, cs, resources); err != nil {
+		return err
+	}
 	return cor.applyTemplates(ctx, resources, dryRun)
 }
 
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
@@ -25,6 +26,7 @@ import (
 	"github.com/aws/eks-anywhere/controllers/controllers/resource/mocks"
 	"github.com/aws/eks-anywhere/internal/test"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
 //go:embed testdata/kubeadmcontrolplane.yaml
@@ -283,3 +285,166 @@ func TestClusterReconcilerReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterReconcilerReconcileMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		// test.FakeNow is a Friday at 23:31 UTC
+		windows      []anywherev1.MaintenanceWindow
+		existing     *unstructured.Unstructured
+		wantDeferred []string
+	}{
+		{
+			name:     "outside window with changes that replace machines",
+			windows:  []anywherev1.MaintenanceWindow{{Days: []string{"Saturday"}, Start: "02:00", Duration: "4h"}},
+			existing: &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"version": "v1.18.0"}}},
+			wantDeferred: []string{
+				"EtcdadmCluster test_cluster-etcd",
+				"KubeadmControlPlane test_cluster",
+				"MachineDeployment test_cluster-md-0",
+			},
+		},
+		{
+			name:    "outside window with new objects",
+			windows: []anywherev1.MaintenanceWindow{{Days: []string{"Saturday"}, Start: "02:00", Duration: "4h"}},
+		},
+		{
+			name:     "inside window",
+			windows:  []anywherev1.MaintenanceWindow{{Days: []string{"Friday"}, Start: "22:00", Duration: "4h"}},
+			existing: &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"version": "v1.18.0"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			fetcher := mocks.NewMockResourceFetcher(mockCtrl)
+			resourceUpdater := mocks.NewMockResourceUpdater(mockCtrl)
+
+			spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+			spec.Spec.DatacenterRef.Kind = anywherev1.DockerDatacenterKind
+			spec.Spec.MaintenanceWindows = tt.windows
+			cluster := &anywherev1.Cluster{}
+			cluster.SetName(spec.Name)
+			cluster.SetNamespace("namespaceA")
+			cluster.Spec = spec.Spec
+
+			kubeadmControlPlane := &controlplanev1.KubeadmControlPlane{}
+			if err := yaml.Unmarshal([]byte(kubeadmcontrolplaneFile), kubeadmControlPlane); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			etcdadmCluster := &etcdv1.EtcdadmCluster{}
+			if err := yaml.Unmarshal([]byte(etcdadmclusterFile), etcdadmCluster); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			machineDeployment := &clusterv1.MachineDeployment{}
+			if err := yaml.Unmarshal([]byte(machineDeploymentFile), machineDeployment); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+
+			fetcher.EXPECT().FetchCluster(ctx, gomock.Any()).Return(cluster, nil)
+			fetcher.EXPECT().FetchAppliedSpec(ctx, gomock.Any()).Return(spec, nil)
+			fetcher.EXPECT().MachineDeployment(ctx, cluster, gomock.Any()).Return(machineDeployment, nil)
+			fetcher.EXPECT().ControlPlane(ctx, cluster).Return(kubeadmControlPlane, nil)
+			fetcher.EXPECT().Etcd(ctx, cluster).Return(etcdadmCluster, nil)
+			fetcher.EXPECT().Fetch(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, name, namespace, kind, apiVersion string) (*unstructured.Unstructured, error) {
+					if tt.existing == nil {
+						return nil, errors.NewNotFound(schema.GroupResource{Group: "testgroup", Resource: "testresource"}, name)
+					}
+					return tt.existing.DeepCopy(), nil
+				},
+			).AnyTimes()
+
+			if len(tt.wantDeferred) == 0 {
+				resourceUpdater.EXPECT().ApplyUpdatedTemplate(ctx, gomock.Any(), false).Return(nil).AnyTimes()
+				resourceUpdater.EXPECT().ForceApplyTemplate(ctx, gomock.Any(), false).Return(nil).AnyTimes()
+			}
+
+			cor := resource.NewClusterReconciler(fetcher, resourceUpdater, test.FakeNow, log.NullLogger{})
+			err := cor.Reconcile(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, false)
+			if len(tt.wantDeferred) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			deferred, ok := err.(*resource.ChangesDeferredError)
+			if !ok {
+				t.Fatalf("Reconcile() error = %v, want ChangesDeferredError", err)
+			}
+			assert.Equal(t, tt.wantDeferred, deferred.Resources)
+			assert.Equal(t, "2009-02-14T02:00:00Z", deferred.NextWindow.Format(time.RFC3339))
+		})
+	}
+}
+
+type fakeResourceTagger struct {
+	folders []string
+}
+
+func (f *fakeResourceTagger) EnsureTags(ctx context.Context, clusterSpec *cluster.Spec, folders []string) (map[string][]string, error) {
+	f.folders = folders
+	return map[string][]string{"md-0": {"urn:md-0"}}, nil
+}
+
+func TestClusterReconcilerReconcileVSphereResourceTags(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	fetcher := mocks.NewMockResourceFetcher(mockCtrl)
+	resourceUpdater := mocks.NewMockResourceUpdater(mockCtrl)
+	tagger := &fakeResourceTagger{}
+
+	cluster := &anywherev1.Cluster{}
+	cluster.SetName("nameA")
+	cluster.SetNamespace("namespaceA")
+	spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+	cluster.Spec = spec.Spec
+	datacenter := &anywherev1.VSphereDatacenterConfig{}
+	if err := yaml.Unmarshal([]byte(vsphereDatacenterConfigSpecPath), datacenter); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	machineConfig := &anywherev1.VSphereMachineConfig{}
+	if err := yaml.Unmarshal([]byte(vsphereMachineConfigSpecPath), machineConfig); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	etcdadmCluster := &etcdv1.EtcdadmCluster{}
+	if err := yaml.Unmarshal([]byte(etcdadmclusterFile), etcdadmCluster); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	fetcher.EXPECT().FetchCluster(ctx, gomock.Any()).Return(cluster, nil)
+	fetcher.EXPECT().FetchAppliedSpec(ctx, gomock.Any()).Return(spec, nil)
+	fetcher.EXPECT().FetchObject(ctx, gomock.Any(), gomock.Any()).Do(func(ctx context.Context, objectKey types.NamespacedName, obj client.Object) {
+		switch o := obj.(type) {
+		case *anywherev1.VSphereDatacenterConfig:
+			o.Spec = datacenter.Spec
+		case *anywherev1.VSphereMachineConfig:
+			o.Spec = machineConfig.Spec
+		}
+	}).Return(nil).AnyTimes()
+	fetcher.EXPECT().Etcd(ctx, gomock.Any()).Return(etcdadmCluster, nil)
+	fetcher.EXPECT().ExistingVSphereDatacenterConfig(ctx, gomock.Any(), gomock.Any()).Return(&anywherev1.VSphereDatacenterConfig{}, nil)
+	fetcher.EXPECT().ExistingVSphereControlPlaneMachineConfig(ctx, gomock.Any()).Return(&anywherev1.VSphereMachineConfig{}, nil)
+	fetcher.EXPECT().ExistingVSphereEtcdMachineConfig(ctx, gomock.Any()).Return(&anywherev1.VSphereMachineConfig{}, nil)
+	fetcher.EXPECT().ExistingVSphereWorkerMachineConfig(ctx, gomock.Any(), gomock.Any()).Return(&anywherev1.VSphereMachineConfig{}, nil)
+	fetcher.EXPECT().VSphereCredentials(ctx).Return(&corev1.Secret{
+		Data: map[string][]byte{"username": []byte("username"), "password": []byte("password")},
+	}, nil)
+	fetcher.EXPECT().Fetch(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, errors.NewNotFound(schema.GroupResource{Group: "testgroup", Resource: "testresource"}, ""))
+
+	var workerTagIDs []string
+	resourceUpdater.EXPECT().ApplyPatch(ctx, gomock.Any(), false).Return(nil)
+	resourceUpdater.EXPECT().ForceApplyTemplate(ctx, gomock.Any(), false).Do(func(ctx context.Context, template *unstructured.Unstructured, dryRun bool) {
+		if template.GetKind() == "VSphereMachineTemplate" && strings.Contains(template.GetName(), "md-0") {
+			workerTagIDs, _, _ = unstructured.NestedStringSlice(template.Object, "spec", "template", "spec", "tagIDs")
+		}
+	}).AnyTimes().Return(nil)
+
+	cor := resource.NewClusterReconciler(fetcher, resourceUpdater, test.FakeNow, log.NullLogger{}, resource.WithVSphereResourceTagger(tagger))
+	if err := cor.Reconcile(ctx, types.NamespacedName{Name: "nameA", Namespace: "namespaceA"}, false); err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil", err)
+	}
+
+	assert.Equal(t, []string{"/SDDC-Datacenter/vm/capv/testuser"}, tagger.folders)
+	assert.Equal(t, []string{"urn:md-0"}, workerTagIDs)
+}
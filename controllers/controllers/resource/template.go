@@ -3,6 +3,7 @@ package resource
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/google/uuid"
@@ -31,10 +32,17 @@ type DockerTemplate struct {
 	now anywhereTypes.NowFunc
 }
 
+// VSphereResourceTagger creates the vSphere tags for the machines of a cluster and attaches them to its folders
+type VSphereResourceTagger interface {
+	EnsureTags(ctx context.Context, clusterSpec *cluster.Spec, folders []string) (map[string][]string, error)
+}
+
 type VsphereTemplate struct {
 	ResourceFetcher
 	ResourceUpdater
-	now anywhereTypes.NowFunc
+	// ResourceTagger is optional, without it the machines aren't tagged
+	ResourceTagger VSphereResourceTagger
+	now            anywhereTypes.NowFunc
 }
 
 type AWSIamConfigTemplate struct {
@@ -51,8 +59,40 @@ func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *an
 	for _, wnConfig := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
 		workerNodeGroupMachineSpecs[wnConfig.MachineGroupRef.Name] = workerVmcs[wnConfig.MachineGroupRef.Name].Spec
 	}
+
+	// Get vsphere credentials so that the template can apply correctly instead of with empty values
+	credSecret, err := r.VSphereCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usernameBytes, ok := credSecret.Data["username"]
+	if !ok {
+		return nil, fmt.Errorf("unable to retrieve username from secret")
+	}
+	passwordBytes, ok := credSecret.Data["password"]
+	if !ok {
+		return nil, fmt.Errorf("unable to retrieve password from secret")
+	}
+
+	builderOpts := []vsphere.TemplateBuilderOpt{vsphere.WithResourceNaming(eksaCluster.Spec.ResourceNaming)}
+	if r.ResourceTagger != nil {
+		if err := setupVSphereEnvVars(&vdc, string(usernameBytes), string(passwordBytes)); err != nil {
+			return nil, err
+		}
+		folders := []*anywherev1.VSphereMachineConfigSpec{&cpVmc.Spec, &etcdVmc.Spec}
+		for _, vmc := range workerVmcs {
+			vmc := vmc
+			folders = append(folders, &vmc.Spec)
+		}
+		tagIDs, err := r.ResourceTagger.EnsureTags(ctx, clusterSpec, vsphere.ResourceFolders(folders...))
+		if err != nil {
+			return nil, err
+		}
+		builderOpts = append(builderOpts, vsphere.WithResourceTagIDs(tagIDs))
+	}
+
 	// control plane and etcd updates are prohibited in controller so those specs should not change
-	templateBuilder := vsphere.NewVsphereTemplateBuilder(&vdc.Spec, &cpVmc.Spec, &etcdVmc.Spec, workerNodeGroupMachineSpecs, r.now, true)
+	templateBuilder := vsphere.NewVsphereTemplateBuilder(&vdc.Spec, &cpVmc.Spec, &etcdVmc.Spec, workerNodeGroupMachineSpecs, r.now, true, builderOpts...)
 	clusterName := clusterSpec.ObjectMeta.Name
 
 	oldVdc, err := r.ExistingVSphereDatacenterConfig(ctx, eksaCluster, clusterSpec.Spec.WorkerNodeGroupConfigurations[0])
@@ -119,20 +159,6 @@ func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *an
 		}
 	}
 
-	// Get vsphere credentials so that the template can apply correctly instead of with empty values
-	credSecret, err := r.VSphereCredentials(ctx)
-	if err != nil {
-		return nil, err
-	}
-	usernameBytes, ok := credSecret.Data["username"]
-	if !ok {
-		return nil, fmt.Errorf("unable to retrieve username from secret")
-	}
-	passwordBytes, ok := credSecret.Data["password"]
-	if !ok {
-		return nil, fmt.Errorf("unable to retrieve password from secret")
-	}
-
 	cpOpt := func(values map[string]interface{}) {
 		values["controlPlaneTemplateName"] = controlPlaneTemplateName
 		values["vsphereControlPlaneSshAuthorizedKey"] = sshAuthorizedKey(cpVmc.Spec.Users)
@@ -239,6 +265,17 @@ func (r *DockerTemplate) TemplateResources(ctx context.Context, eksaCluster *any
 	return generateTemplateResources(templateBuilder, clusterSpec, workloadTemplateNames, cpOpt)
 }
 
+// setupVSphereEnvVars sets the env vars govc needs to reach the vCenter of the datacenter
+func setupVSphereEnvVars(vdc *anywherev1.VSphereDatacenterConfig, username, password string) error {
+	if err := os.Setenv(vsphere.EksavSphereUsernameKey, username); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", vsphere.EksavSphereUsernameKey, err)
+	}
+	if err := os.Setenv(vsphere.EksavSpherePasswordKey, password); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", vsphere.EksavSpherePasswordKey, err)
+	}
+	return vsphere.SetupEnvVars(vdc)
+}
+
 func sshAuthorizedKey(users []anywherev1.UserConfiguration) string {
 	if len(users) <= 0 || len(users[0].SshAuthorizedKeys) <= 0 {
 		return ""
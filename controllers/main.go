@@ -3,7 +3,11 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
+	"time"
+	// the controller image doesn't have the zoneinfo database, it's needed for the maintenance windows time zones
+	_ "time/tzdata"
 
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
 	"github.com/spf13/pflag"
@@ -20,6 +24,8 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers/controllers"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	anywherev1beta1 "github.com/aws/eks-anywhere/pkg/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/features"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -32,6 +38,8 @@ var (
 	enableLeaderElection bool
 	probeAddr            string
 	gates                = []string{}
+	driftInterval        time.Duration
+	healthInterval       time.Duration
 )
 
 const WEBHOOK = "webhook"
@@ -39,6 +47,7 @@ const WEBHOOK = "webhook"
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(anywherev1.AddToScheme(scheme))
+	utilruntime.Must(anywherev1beta1.AddToScheme(scheme))
 	utilruntime.Must(releasev1.AddToScheme(scheme))
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
 	utilruntime.Must(controlplanev1.AddToScheme(scheme))
@@ -54,6 +63,8 @@ func initFlags(fs *pflag.FlagSet) {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	fs.StringSliceVar(&gates, "feature-gates", []string{}, "A set of key=value pairs that describe feature gates for alpha/experimental features. ")
+	fs.DurationVar(&driftInterval, "drift-detection-interval", time.Hour, "How often the EKS-A managed components are checked for drift against the cluster bundle. 0 or less disables the detection.")
+	fs.DurationVar(&healthInterval, "health-check-interval", time.Minute, "How often the health of the clusters with notifications is checked to notify its changes. 0 or less disables the checks.")
 }
 
 func main() {
@@ -86,6 +97,9 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupReconcilers(ctx, mgr)
+	setupDriftDetector(mgr)
+	setupHealthMonitor(mgr)
+	setupWebhookTLS(mgr)
 	setupWebhooks(mgr)
 	//+kubebuilder:scaffold:builder
 	setupChecks(mgr)
@@ -99,7 +113,8 @@ func main() {
 
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	if features.IsActive(features.FullLifecycleAPI()) {
-		factory := dependencies.NewFactory()
+		// The controller can't start a tools container, it runs the binaries in its PATH
+		factory := dependencies.NewFactory(dependencies.UseLocalExecutables())
 		deps, err := factory.WithGovc().Build(ctx)
 		if err != nil {
 			setupLog.Error(err, "unable to build dependencies")
@@ -138,21 +153,86 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		}
 	} else {
 		setupLog.Info("Setting up legacy cluster controller")
-		setupLegacyClusterReconciler(mgr)
+		setupLegacyClusterReconciler(ctx, mgr)
 	}
 }
 
-func setupLegacyClusterReconciler(mgr ctrl.Manager) {
+func setupLegacyClusterReconciler(ctx context.Context, mgr ctrl.Manager) {
+	// govc creates the vSphere resource tags of the machines
+	factory := dependencies.NewFactory(dependencies.UseLocalExecutables())
+	deps, err := factory.WithGovc().Build(ctx)
+	if err != nil {
+		setupLog.Error(err, "unable to build dependencies")
+		os.Exit(1)
+	}
+
 	if err := (controllers.NewClusterReconcilerLegacy(
 		mgr.GetClient(),
 		ctrl.Log.WithName("controllers").WithName(anywherev1.ClusterKind),
 		mgr.GetScheme(),
+		deps.Govc,
 	)).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create legacy cluster controller", "controller", anywherev1.ClusterKind)
 		os.Exit(1)
 	}
 }
 
+func setupDriftDetector(mgr ctrl.Manager) {
+	if driftInterval <= 0 {
+		return
+	}
+
+	setupLog.Info("Setting up drift detector", "interval", driftInterval)
+	if err := mgr.Add(controllers.NewDriftDetector(
+		mgr.GetAPIReader(),
+		ctrl.Log.WithName("drift-detector"),
+		mgr.GetEventRecorderFor("eksa-drift-detector"),
+		driftInterval,
+	)); err != nil {
+		setupLog.Error(err, "unable to set up drift detector")
+		os.Exit(1)
+	}
+}
+
+func setupHealthMonitor(mgr ctrl.Manager) {
+	if healthInterval <= 0 {
+		return
+	}
+
+	setupLog.Info("Setting up health monitor", "interval", healthInterval)
+	if err := mgr.Add(controllers.NewHealthMonitor(
+		mgr.GetAPIReader(),
+		ctrl.Log.WithName("health-monitor"),
+		healthInterval,
+		controllers.DefaultNotifiersBuilder,
+	)); err != nil {
+		setupLog.Error(err, "unable to set up health monitor")
+		os.Exit(1)
+	}
+}
+
+// webhookTLSVersions maps the cluster TLS policy versions to the ones taken by the webhook server
+var webhookTLSVersions = map[anywherev1.TLSVersion]string{
+	anywherev1.TLSVersion12: "1.2",
+	anywherev1.TLSVersion13: "1.3",
+}
+
+// setupWebhookTLS sets the min TLS version of the webhook server from the cluster TLS policy, which the CLI
+// sets in the manager environment. The webhook server doesn't support configuring the cipher suites
+func setupWebhookTLS(mgr ctrl.Manager) {
+	minVersion := anywherev1.TLSVersion(os.Getenv(constants.WebhookTLSMinVersionEnv))
+	if minVersion == "" {
+		return
+	}
+	version, ok := webhookTLSVersions[minVersion]
+	if !ok {
+		setupLog.Error(fmt.Errorf("unsupported TLS min version %s", minVersion), "unable to configure webhook TLS", WEBHOOK, constants.WebhookTLSMinVersionEnv)
+		os.Exit(1)
+	}
+	setupLog.Info("Setting webhook TLS min version", "version", version)
+	mgr.GetWebhookServer().TLSMinVersion = version
+}
+
 func setupWebhooks(mgr ctrl.Manager) {
 	if err := (&anywherev1.Cluster{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.ClusterKind)
@@ -179,6 +259,27 @@ func setupWebhooks(mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.AWSIamConfigKind)
 		os.Exit(1)
 	}
+
+	setupConversionWebhooks(mgr)
+}
+
+func setupConversionWebhooks(mgr ctrl.Manager) {
+	if err := (&anywherev1beta1.Cluster{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", WEBHOOK, anywherev1.ClusterKind)
+		os.Exit(1)
+	}
+	if err := (&anywherev1beta1.VSphereDatacenterConfig{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", WEBHOOK, anywherev1.VSphereDatacenterKind)
+		os.Exit(1)
+	}
+	if err := (&anywherev1beta1.VSphereMachineConfig{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", WEBHOOK, anywherev1.VSphereMachineConfigKind)
+		os.Exit(1)
+	}
+	if err := (&anywherev1beta1.DockerDatacenterConfig{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", WEBHOOK, anywherev1.DockerDatacenterKind)
+		os.Exit(1)
+	}
 }
 
 func setupChecks(mgr ctrl.Manager) {
@@ -2,6 +2,7 @@ package s3
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -25,14 +26,19 @@ func UploadFile(session *session.Session, file, key, bucket string, opts ...Uplo
 		return fmt.Errorf("error opening file for upload: %v", err)
 	}
 
-	return upload(session, fileBody, key, bucket, opts...)
+	return upload(context.Background(), session, fileBody, key, bucket, opts...)
 }
 
 func Upload(session *session.Session, body []byte, key, bucket string, opts ...UploadOpt) error {
-	return upload(session, bytes.NewBuffer(body), key, bucket, opts...)
+	return UploadWithContext(context.Background(), session, body, key, bucket, opts...)
 }
 
-func upload(session *session.Session, body io.Reader, key, bucket string, opts ...UploadOpt) error {
+// UploadWithContext is Upload, but the upload is canceled when ctx is done
+func UploadWithContext(ctx context.Context, session *session.Session, body []byte, key, bucket string, opts ...UploadOpt) error {
+	return upload(ctx, session, bytes.NewBuffer(body), key, bucket, opts...)
+}
+
+func upload(ctx context.Context, session *session.Session, body io.Reader, key, bucket string, opts ...UploadOpt) error {
 	s3Uploader := s3manager.NewUploader(session)
 	i := &s3manager.UploadInput{
 		Bucket: aws.String(bucket),
@@ -44,7 +50,7 @@ func upload(session *session.Session, body io.Reader, key, bucket string, opts .
 		opt(i)
 	}
 
-	_, err := s3Uploader.Upload(i)
+	_, err := s3Uploader.UploadWithContext(ctx, i)
 	if err != nil {
 		return fmt.Errorf("error uploading to s3: %v", err)
 	}
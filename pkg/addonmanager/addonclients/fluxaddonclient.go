@@ -106,7 +106,7 @@ func NewFluxAddonClient(flux Flux, gitOpts *GitOptions) *FluxAddonClient {
 // Flux is an interface that abstracts the basic commands of flux executable.
 type Flux interface {
 	// BootstrapToolkitsComponents bootstraps toolkit components in a GitHub repository.
-	BootstrapToolkitsComponents(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error
+	BootstrapToolkitsComponents(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 
 	// UninstallToolkitsComponents UninstallFluxComponents removes the Flux components and the toolkit.fluxcd.io resources from the cluster.
 	UninstallToolkitsComponents(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error
@@ -165,8 +165,8 @@ func (f *FluxAddonClient) InstallGitOps(ctx context.Context, cluster *types.Clus
 	}
 
 	if !cluster.ExistingManagement {
-		err := f.retrier.Retry(func() error {
-			return fc.flux.BootstrapToolkitsComponents(ctx, cluster, clusterSpec.GitOpsConfig)
+		err := f.retrier.RetryWithContext(ctx, func() error {
+			return fc.flux.BootstrapToolkitsComponents(ctx, cluster, clusterSpec)
 		})
 		if err != nil {
 			uninstallErr := f.uninstallGitOpsToolkits(ctx, cluster, clusterSpec)
@@ -180,7 +180,7 @@ func (f *FluxAddonClient) InstallGitOps(ctx context.Context, cluster *types.Clus
 	logger.V(3).Info("pulling from remote after Flux Bootstrap to ensure configuration files in local git repository are in sync",
 		"remote", defaultRemote, "branch", fc.branch())
 
-	err := f.retrier.Retry(func() error {
+	err := f.retrier.RetryWithContext(ctx, func() error {
 		return f.gitOpts.Git.Pull(ctx, fc.branch())
 	})
 	if err != nil {
@@ -196,7 +196,7 @@ func (f *FluxAddonClient) uninstallGitOpsToolkits(ctx context.Context, cluster *
 		clusterSpec:     clusterSpec,
 	}
 
-	return f.retrier.Retry(func() error {
+	return f.retrier.RetryWithContext(ctx, func() error {
 		return fc.flux.UninstallToolkitsComponents(ctx, cluster, clusterSpec.GitOpsConfig)
 	})
 }
@@ -214,7 +214,7 @@ func (f *FluxAddonClient) PauseGitOpsKustomization(ctx context.Context, cluster
 
 	logger.V(3).Info("pause reconciliation of all Kustomization", "namespace", fc.namespace())
 
-	return f.retrier.Retry(func() error {
+	return f.retrier.RetryWithContext(ctx, func() error {
 		return fc.flux.PauseKustomization(ctx, cluster, clusterSpec.GitOpsConfig)
 	})
 }
@@ -231,7 +231,7 @@ func (f *FluxAddonClient) ResumeGitOpsKustomization(ctx context.Context, cluster
 	}
 
 	logger.V(3).Info("resume reconciliation of all Kustomization", "namespace", fc.namespace())
-	return f.retrier.Retry(func() error {
+	return f.retrier.RetryWithContext(ctx, func() error {
 		return fc.flux.ResumeKustomization(ctx, cluster, clusterSpec.GitOpsConfig)
 	})
 }
@@ -342,7 +342,7 @@ func (f *FluxAddonClient) pushToRemoteRepo(ctx context.Context, path, msg string
 		return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when committing %s to git:  %v", path, err)}
 	}
 
-	err = f.retrier.Retry(func() error {
+	err = f.retrier.RetryWithContext(ctx, func() error {
 		return f.gitOpts.Git.Push(ctx)
 	})
 	if err != nil {
@@ -529,11 +529,10 @@ func (f *FluxAddonClient) generateFluxSyncFile(t *templater.Templater) error {
 func (fc *fluxForCluster) generateFluxPatchFile(t *templater.Templater) error {
 	bundle := fc.clusterSpec.VersionsBundle
 	values := map[string]string{
-		"Namespace":                   fc.namespace(),
-		"SourceControllerImage":       bundle.Flux.SourceController.VersionedImage(),
-		"KustomizeControllerImage":    bundle.Flux.KustomizeController.VersionedImage(),
-		"HelmControllerImage":         bundle.Flux.HelmController.VersionedImage(),
-		"NotificationControllerImage": bundle.Flux.NotificationController.VersionedImage(),
+		"Namespace":                fc.namespace(),
+		"SourceControllerImage":    bundle.Flux.SourceController.VersionedImage(),
+		"KustomizeControllerImage": bundle.Flux.KustomizeController.VersionedImage(),
+		"HelmControllerImage":      bundle.Flux.HelmController.VersionedImage(),
 	}
 	if filePath, err := t.WriteToFile(fluxPatchContent, values, fluxPatchFileName, filewriter.PersistentFile); err != nil {
 		return fmt.Errorf("error creating flux-system patch manifest file into %s: %v", filePath, err)
@@ -577,7 +576,7 @@ func (fc *fluxForCluster) setupRepository(ctx context.Context) error {
 func (fc *fluxForCluster) clone(ctx context.Context) (*git.Repository, error) {
 	var r *git.Repository
 	var err error
-	err = fc.FluxAddonClient.retrier.Retry(func() error {
+	err = fc.FluxAddonClient.retrier.RetryWithContext(ctx, func() error {
 		r, err = fc.gitOpts.Git.GetRepo(ctx)
 		return err
 	})
@@ -586,7 +585,7 @@ func (fc *fluxForCluster) clone(ctx context.Context) (*git.Repository, error) {
 	}
 	if r != nil {
 		logger.V(3).Info("Cloning remote repository", "repo", r.Name)
-		err = fc.FluxAddonClient.retrier.Retry(func() error {
+		err = fc.FluxAddonClient.retrier.RetryWithContext(ctx, func() error {
 			return fc.gitOpts.Git.Clone(ctx)
 		})
 		if err != nil {
@@ -613,7 +612,7 @@ func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
 
 	opts := git.CreateRepoOpts{Name: n, Owner: o, Description: d, Personal: p, Privacy: true}
 	logger.V(3).Info("Creating remote Github repo", "options", opts)
-	err := fc.FluxAddonClient.retrier.Retry(func() error {
+	err := fc.FluxAddonClient.retrier.RetryWithContext(ctx, func() error {
 		_, err := fc.gitOpts.Git.CreateRepo(ctx, opts)
 		return err
 	})
@@ -88,7 +88,7 @@ func TestFluxAddonClientInstallGitOpsOnManagementClusterWithPrexistingRepo(t *te
 			f, m, g := newAddonClient(t)
 			clusterSpec := newClusterSpec(clusterConfig, tt.fluxpath)
 
-			m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec.GitOpsConfig)
+			m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec)
 
 			m.git.EXPECT().GetRepo(ctx).Return(&git.Repository{Name: clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository}, nil)
 			m.git.EXPECT().Clone(ctx).Return(nil)
@@ -129,7 +129,7 @@ func TestFluxAddonClientInstallGitOpsOnWorkloadClusterWithPrexistingRepo(t *test
 	f, m, g := newAddonClient(t)
 	clusterSpec := newClusterSpec(clusterConfig, "")
 
-	m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec.GitOpsConfig)
+	m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec)
 
 	m.git.EXPECT().GetRepo(ctx).Return(&git.Repository{Name: clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository}, nil)
 	m.git.EXPECT().Clone(ctx).Return(nil)
@@ -214,7 +214,7 @@ func TestFluxAddonClientInstallGitOpsNoPrexistingRepo(t *testing.T) {
 			f, m, g := newAddonClient(t)
 			clusterSpec := newClusterSpec(clusterConfig, tt.fluxpath)
 
-			m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec.GitOpsConfig)
+			m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec)
 
 			n := clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository
 			o := clusterSpec.GitOpsConfig.Spec.Flux.Github.Owner
@@ -297,7 +297,7 @@ func TestFluxAddonClientInstallGitOpsToolkitsBareRepo(t *testing.T) {
 			f, m, g := newAddonClient(t)
 			clusterSpec := newClusterSpec(clusterConfig, tt.fluxpath)
 
-			m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec.GitOpsConfig)
+			m.flux.EXPECT().BootstrapToolkitsComponents(ctx, cluster, clusterSpec)
 
 			m.git.EXPECT().GetRepo(ctx).MaxTimes(2).Return(&git.Repository{Name: clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository}, nil)
 			m.git.EXPECT().Clone(ctx).MaxTimes(2).Return(&git.RepositoryIsEmptyError{Repository: "testRepo"})
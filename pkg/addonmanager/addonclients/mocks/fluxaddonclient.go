@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -37,7 +38,7 @@ func (m *MockFlux) EXPECT() *MockFluxMockRecorder {
 }
 
 // BootstrapToolkitsComponents mocks base method.
-func (m *MockFlux) BootstrapToolkitsComponents(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.GitOpsConfig) error {
+func (m *MockFlux) BootstrapToolkitsComponents(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BootstrapToolkitsComponents", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,187 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+)
+
+// nodeRestrictionPlugin is enabled by kubeadm, it's kept when enable-admission-plugins is overridden
+const nodeRestrictionPlugin = "NodeRestriction"
+
+// admissionPlugin is the range of Kubernetes 1.x minor versions with an admission plugin
+type admissionPlugin struct {
+	// since is the first minor version with the plugin, 0 when all the supported versions have it
+	since int
+	// removed is the first minor version without the plugin, 0 when it's still available
+	removed int
+	// needsConfig marks the plugins that can't run without their configuration in the admission config file
+	needsConfig bool
+}
+
+func (p admissionPlugin) availableIn(minor int) bool {
+	return minor >= p.since && (p.removed == 0 || minor < p.removed)
+}
+
+var admissionPlugins = map[string]admissionPlugin{
+	"AlwaysAdmit":                          {},
+	"AlwaysDeny":                           {},
+	"AlwaysPullImages":                     {},
+	"CertificateApproval":                  {since: 18},
+	"CertificateSigning":                   {since: 18},
+	"CertificateSubjectRestriction":        {since: 18},
+	"DefaultIngressClass":                  {since: 18},
+	"DefaultStorageClass":                  {},
+	"DefaultTolerationSeconds":             {},
+	"DenyServiceExternalIPs":               {since: 21},
+	"EventRateLimit":                       {needsConfig: true},
+	"ExtendedResourceToleration":           {},
+	"ImagePolicyWebhook":                   {needsConfig: true},
+	"LimitPodHardAntiAffinityTopology":     {},
+	"LimitRanger":                          {},
+	"MutatingAdmissionWebhook":             {},
+	"NamespaceAutoProvision":               {},
+	"NamespaceExists":                      {},
+	"NamespaceLifecycle":                   {},
+	nodeRestrictionPlugin:                  {},
+	"OwnerReferencesPermissionEnforcement": {},
+	"PersistentVolumeClaimResize":          {},
+	"PersistentVolumeLabel":                {},
+	"PodNodeSelector":                      {},
+	"PodSecurity":                          {since: minPodSecurityKubernetesMinor},
+	"PodSecurityPolicy":                    {removed: 25},
+	"PodTolerationRestriction":             {},
+	"Priority":                             {},
+	"ResourceQuota":                        {},
+	"RuntimeClass":                         {},
+	"SecurityContextDeny":                  {},
+	"ServiceAccount":                       {},
+	"StorageObjectInUseProtection":         {},
+	"TaintNodesByCondition":                {},
+	"ValidatingAdmissionPolicy":            {since: 26},
+	"ValidatingAdmissionWebhook":           {},
+}
+
+var admissionProfilePlugins = map[AdmissionProfile][]string{
+	AdmissionProfileBaseline:   {nodeRestrictionPlugin, "DenyServiceExternalIPs"},
+	AdmissionProfileRestricted: {nodeRestrictionPlugin, "DenyServiceExternalIPs", "AlwaysPullImages"},
+}
+
+// EnabledPlugins returns the admission plugins enabled in the API server on top of the Kubernetes defaults,
+// sorted by name. The profile plugins the Kubernetes version doesn't have are left out.
+func (n *AdmissionConfiguration) EnabledPlugins(version KubernetesVersion) []string {
+	if n == nil {
+		return nil
+	}
+	// the validations reject the versions that can't be parsed, minor 0 leaves out the plugins of newer versions
+	_, minor, _ := parseKubernetesVersion(version)
+
+	var plugins []string
+	switch n.Profile {
+	case AdmissionProfileCustom:
+		disabled := map[string]bool{}
+		for _, p := range n.DisablePlugins {
+			disabled[p] = true
+		}
+		if !disabled[nodeRestrictionPlugin] {
+			plugins = append(plugins, nodeRestrictionPlugin)
+		}
+		for _, p := range n.EnablePlugins {
+			if p != nodeRestrictionPlugin {
+				plugins = append(plugins, p)
+			}
+		}
+	default:
+		for _, p := range admissionProfilePlugins[n.Profile] {
+			if admissionPlugins[p].availableIn(minor) {
+				plugins = append(plugins, p)
+			}
+		}
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// DisabledPlugins returns the default admission plugins disabled in the API server, sorted by name
+func (n *AdmissionConfiguration) DisabledPlugins() []string {
+	if n == nil || n.Profile != AdmissionProfileCustom || len(n.DisablePlugins) == 0 {
+		return nil
+	}
+	plugins := append([]string{}, n.DisablePlugins...)
+	sort.Strings(plugins)
+	return plugins
+}
+
+func validateAdmission(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.Admission
+	if config == nil {
+		return nil
+	}
+	// the API server flags are rendered in the kubeadm templates, which only the vSphere and Docker providers configure
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+	if kind != VSphereDatacenterKind && kind != DockerDatacenterKind {
+		return fmt.Errorf("admission is only supported for %s and %s clusters", VSphereDatacenterKind, DockerDatacenterKind)
+	}
+
+	switch config.Profile {
+	case AdmissionProfileBaseline, AdmissionProfileRestricted:
+		if len(config.EnablePlugins) > 0 || len(config.DisablePlugins) > 0 {
+			return fmt.Errorf("admission enablePlugins and disablePlugins can only be set with profile %s", AdmissionProfileCustom)
+		}
+		return nil
+	case AdmissionProfileCustom:
+		if len(config.EnablePlugins) == 0 && len(config.DisablePlugins) == 0 {
+			return fmt.Errorf("admission profile %s requires enablePlugins or disablePlugins", AdmissionProfileCustom)
+		}
+	default:
+		return fmt.Errorf("admission profile %s is invalid, please use one of %s, %s or %s", config.Profile, AdmissionProfileBaseline, AdmissionProfileRestricted, AdmissionProfileCustom)
+	}
+
+	_, minor, err := parseKubernetesVersion(clusterConfig.Spec.KubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	enabled := map[string]bool{}
+	for _, p := range config.EnablePlugins {
+		if err := validateAdmissionPlugin(p, minor, clusterConfig.Spec.KubernetesVersion); err != nil {
+			return err
+		}
+		if enabled[p] {
+			return fmt.Errorf("admission plugin %s is enabled more than once", p)
+		}
+		enabled[p] = true
+	}
+
+	disabled := map[string]bool{}
+	for _, p := range config.DisablePlugins {
+		if err := validateAdmissionPlugin(p, minor, clusterConfig.Spec.KubernetesVersion); err != nil {
+			return err
+		}
+		if disabled[p] {
+			return fmt.Errorf("admission plugin %s is disabled more than once", p)
+		}
+		if enabled[p] {
+			return fmt.Errorf("admission plugin %s can't be both enabled and disabled", p)
+		}
+		disabled[p] = true
+	}
+
+	if disabled["PodSecurity"] && clusterConfig.Spec.PodSecurity != nil {
+		return fmt.Errorf("admission plugin PodSecurity can't be disabled with podSecurity set")
+	}
+	return nil
+}
+
+func validateAdmissionPlugin(name string, minor int, version KubernetesVersion) error {
+	plugin, ok := admissionPlugins[name]
+	if !ok {
+		return fmt.Errorf("admission plugin %s is not a known Kubernetes admission plugin", name)
+	}
+	if !plugin.availableIn(minor) {
+		return fmt.Errorf("admission plugin %s is not available in kubernetes version %s", name, version)
+	}
+	if plugin.needsConfig {
+		return fmt.Errorf("admission plugin %s requires an admission configuration file, which is not supported", name)
+	}
+	return nil
+}
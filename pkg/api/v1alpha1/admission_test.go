@@ -0,0 +1,179 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateAdmission(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        string
+		version     KubernetesVersion
+		admission   *AdmissionConfiguration
+		podSecurity *PodSecurityConfiguration
+		wantErr     string
+	}{
+		{
+			name: "not configured",
+			kind: AWSDatacenterKind,
+		},
+		{
+			name:      "baseline",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileBaseline},
+		},
+		{
+			name:      "restricted in a version without all the profile plugins",
+			kind:      DockerDatacenterKind,
+			version:   Kube118,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileRestricted},
+		},
+		{
+			name:      "custom",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"DenyServiceExternalIPs", "PodNodeSelector"}, DisablePlugins: []string{"DefaultStorageClass"}},
+		},
+		{
+			name:      "unsupported provider",
+			kind:      AWSDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileBaseline},
+			wantErr:   "admission is only supported for VSphereDatacenterConfig and DockerDatacenterConfig clusters",
+		},
+		{
+			name:      "invalid profile",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: "strict"},
+			wantErr:   "admission profile strict is invalid, please use one of baseline, restricted or custom",
+		},
+		{
+			name:      "plugins with a predefined profile",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileBaseline, EnablePlugins: []string{"AlwaysPullImages"}},
+			wantErr:   "admission enablePlugins and disablePlugins can only be set with profile custom",
+		},
+		{
+			name:      "custom without plugins",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom},
+			wantErr:   "admission profile custom requires enablePlugins or disablePlugins",
+		},
+		{
+			name:      "unknown plugin",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"NoSuchPlugin"}},
+			wantErr:   "admission plugin NoSuchPlugin is not a known Kubernetes admission plugin",
+		},
+		{
+			name:      "plugin newer than the version",
+			kind:      VSphereDatacenterKind,
+			version:   Kube120,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"DenyServiceExternalIPs"}},
+			wantErr:   "admission plugin DenyServiceExternalIPs is not available in kubernetes version 1.20",
+		},
+		{
+			name:      "plugin removed in the version",
+			kind:      VSphereDatacenterKind,
+			version:   "1.25",
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"PodSecurityPolicy"}},
+			wantErr:   "admission plugin PodSecurityPolicy is not available in kubernetes version 1.25",
+		},
+		{
+			name:      "plugin that needs a configuration",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"EventRateLimit"}},
+			wantErr:   "admission plugin EventRateLimit requires an admission configuration file, which is not supported",
+		},
+		{
+			name:      "duplicated plugin",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, DisablePlugins: []string{"DefaultStorageClass", "DefaultStorageClass"}},
+			wantErr:   "admission plugin DefaultStorageClass is disabled more than once",
+		},
+		{
+			name:      "plugin enabled and disabled",
+			kind:      VSphereDatacenterKind,
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"AlwaysPullImages"}, DisablePlugins: []string{"AlwaysPullImages"}},
+			wantErr:   "admission plugin AlwaysPullImages can't be both enabled and disabled",
+		},
+		{
+			name:        "pod security disabled with pod security set",
+			kind:        VSphereDatacenterKind,
+			version:     "1.23",
+			admission:   &AdmissionConfiguration{Profile: AdmissionProfileCustom, DisablePlugins: []string{"PodSecurity"}},
+			podSecurity: &PodSecurityConfiguration{Enforce: PodSecurityBaseline},
+			wantErr:     "admission plugin PodSecurity can't be disabled with podSecurity set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{
+				KubernetesVersion: tt.version,
+				DatacenterRef:     Ref{Kind: tt.kind},
+				Admission:         tt.admission,
+				PodSecurity:       tt.podSecurity,
+			}}
+			err := validateAdmission(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestAdmissionConfigurationEnabledPlugins(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   KubernetesVersion
+		admission *AdmissionConfiguration
+		want      []string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:      "baseline",
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileBaseline},
+			want:      []string{"DenyServiceExternalIPs", "NodeRestriction"},
+		},
+		{
+			name:      "restricted without the plugins the version doesn't have",
+			version:   Kube119,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileRestricted},
+			want:      []string{"AlwaysPullImages", "NodeRestriction"},
+		},
+		{
+			name:      "custom keeps node restriction",
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"PodNodeSelector", "NodeRestriction"}},
+			want:      []string{"NodeRestriction", "PodNodeSelector"},
+		},
+		{
+			name:      "custom disabling node restriction",
+			version:   Kube121,
+			admission: &AdmissionConfiguration{Profile: AdmissionProfileCustom, EnablePlugins: []string{"PodNodeSelector"}, DisablePlugins: []string{"NodeRestriction"}},
+			want:      []string{"PodNodeSelector"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.admission.EnabledPlugins(tt.version)).To(Equal(tt.want))
+		})
+	}
+}
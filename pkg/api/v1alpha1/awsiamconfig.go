@@ -13,7 +13,16 @@ const (
 )
 
 func GetAndValidateAWSIamConfig(fileName string, refName string, clusterConfig *Cluster) (*AWSIamConfig, error) {
-	config, err := getAWSIamConfig(fileName)
+	return getAndValidateAWSIamConfig(fileConfigParser(fileName), refName, clusterConfig)
+}
+
+// GetAndValidateAWSIamConfigFromContent is like GetAndValidateAWSIamConfig but reads the AWSIamConfig from a multiobject yaml in memory
+func GetAndValidateAWSIamConfigFromContent(content []byte, refName string, clusterConfig *Cluster) (*AWSIamConfig, error) {
+	return getAndValidateAWSIamConfig(contentConfigParser(content), refName, clusterConfig)
+}
+
+func getAndValidateAWSIamConfig(parse configParser, refName string, clusterConfig *Cluster) (*AWSIamConfig, error) {
+	config, err := getAWSIamConfig(parse)
 	if err != nil {
 		return nil, err
 	}
@@ -24,9 +33,9 @@ func GetAndValidateAWSIamConfig(fileName string, refName string, clusterConfig *
 	return config, nil
 }
 
-func getAWSIamConfig(fileName string) (*AWSIamConfig, error) {
+func getAWSIamConfig(parse configParser) (*AWSIamConfig, error) {
 	var config AWSIamConfig
-	err := ParseClusterConfig(fileName, &config)
+	err := parse(&config)
 	if err != nil {
 		return nil, err
 	}
@@ -1,17 +1,21 @@
 package v1alpha1
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/url"
 	"os"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/crypto"
@@ -91,6 +95,12 @@ func WithClusterEndpoint() ClusterGenerateOpt {
 	}
 }
 
+func WithClusterEndpointHost(host string) ClusterGenerateOpt {
+	return func(c *ClusterGenerate) {
+		c.Spec.ControlPlaneConfiguration.Endpoint = &Endpoint{Host: host}
+	}
+}
+
 func WithDatacenterRef(ref ProviderRefAccessor) ClusterGenerateOpt {
 	return func(c *ClusterGenerate) {
 		c.Spec.DatacenterRef = Ref{
@@ -165,6 +175,7 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateClusterConfigName,
 	validateControlPlaneReplicas,
 	validateWorkerNodeGroups,
+	validateWorkerNodeGroupsKubernetesVersion,
 	validateNetworking,
 	validateGitOps,
 	validateEtcdReplicas,
@@ -172,6 +183,21 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateProxyConfig,
 	validateMirrorConfig,
 	validatePodIAMConfig,
+	validateResourceTags,
+	validateServiceLoadBalancer,
+	validateLocalPathStorage,
+	validateFIPS,
+	validateTLSPolicy,
+	validatePodSecurity,
+	validateHostEntries,
+	validateMaintenanceWindows,
+	validateNotifications,
+	validateReadinessGates,
+	validateCoreDNS,
+	validateKubeProxy,
+	validateAdmission,
+	validateResourceNaming,
+	validateForcedUnsupportedChanges,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -188,13 +214,34 @@ func GetClusterConfig(fileName string) (*Cluster, error) {
 	return clusterConfig, nil
 }
 
+// GetClusterConfigFromContent parses a Cluster object from a multiobject yaml in memory
+// and sets defaults if necessary
+func GetClusterConfigFromContent(content []byte) (*Cluster, error) {
+	clusterConfig := &Cluster{}
+	err := ParseClusterConfigFromContent(content, clusterConfig)
+	if err != nil {
+		return clusterConfig, err
+	}
+	if err := setClusterDefaults(clusterConfig); err != nil {
+		return clusterConfig, err
+	}
+	return clusterConfig, nil
+}
+
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
-// sets defaults if necessary and validates the Cluster
+// sets defaults if necessary and validates the Cluster and its references to the other objects in the file
 func GetAndValidateClusterConfig(fileName string) (*Cluster, error) {
 	clusterConfig, err := GetClusterConfig(fileName)
 	if err != nil {
 		return nil, err
 	}
+	docs, err := ParseClusterConfigFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster config %s: %v", fileName, err)
+	}
+	if err = docs.ValidateReferences(); err != nil {
+		return nil, fmt.Errorf("invalid cluster config %s: %v", fileName, err)
+	}
 	err = ValidateClusterConfigContent(clusterConfig)
 	if err != nil {
 		return nil, err
@@ -217,22 +264,62 @@ func ValidateClusterConfigContent(clusterConfig *Cluster) error {
 // ParseClusterConfig unmarshalls an API object implementing the KindAccessor interface
 // from a multiobject yaml file in disk. It doesn't set defaults nor validates the object
 func ParseClusterConfig(fileName string, clusterConfig KindAccessor) error {
-	content, err := ioutil.ReadFile(fileName)
+	content, err := readClusterConfigFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return parseClusterConfig("cluster spec file "+fileName, content, clusterConfig)
+}
+
+// ParseClusterConfigFromContent unmarshalls an API object implementing the KindAccessor interface
+// from a multiobject yaml in memory, so the config can be read without a file. The content is used as is,
+// environment variables and secret references are not substituted. It doesn't set defaults nor validates the object
+func ParseClusterConfigFromContent(content []byte, clusterConfig KindAccessor) error {
+	return parseClusterConfig("cluster spec", content, clusterConfig)
+}
+
+// configParser unmarshalls an API object from a cluster config, either in disk or in memory
+type configParser func(clusterConfig KindAccessor) error
+
+func fileConfigParser(fileName string) configParser {
+	return func(clusterConfig KindAccessor) error {
+		return ParseClusterConfig(fileName, clusterConfig)
+	}
+}
+
+func contentConfigParser(content []byte) configParser {
+	return func(clusterConfig KindAccessor) error {
+		return ParseClusterConfigFromContent(content, clusterConfig)
+	}
+}
+
+func parseClusterConfig(source string, content []byte, clusterConfig KindAccessor) error {
+	docs, err := splitYamlDocuments(content)
 	if err != nil {
-		return fmt.Errorf("unable to read file due to: %v", err)
+		return fmt.Errorf("unable to parse %s: %v", source, err)
 	}
 
-	for _, c := range strings.Split(string(content), YamlSeparator) {
-		if err = yaml.Unmarshal([]byte(c), clusterConfig); err != nil {
-			return fmt.Errorf("unable to parse %s\nyaml: %s\n %v", fileName, c, err)
+	var found []byte
+	for _, c := range docs {
+		meta := &metav1.TypeMeta{}
+		if err = yaml.Unmarshal(c, meta); err != nil {
+			return fmt.Errorf("unable to parse %s\nyaml: %s\n %v", source, c, err)
 		}
 
-		if clusterConfig.Kind() == clusterConfig.ExpectedKind() {
-			return yaml.UnmarshalStrict([]byte(c), clusterConfig)
+		if meta.Kind == clusterConfig.ExpectedKind() {
+			if found != nil {
+				return fmt.Errorf("%s contains more than one object of kind %s", source, clusterConfig.ExpectedKind())
+			}
+			found = c
 		}
 	}
 
-	return fmt.Errorf("cluster spec file %s is invalid or does not contain kind %s", fileName, clusterConfig.ExpectedKind())
+	if found == nil {
+		return fmt.Errorf("%s is invalid or does not contain kind %s", source, clusterConfig.ExpectedKind())
+	}
+
+	return yaml.UnmarshalStrict(found, clusterConfig)
 }
 
 func (c *Cluster) PauseReconcile() {
@@ -248,6 +335,99 @@ func (c *Cluster) ClearPauseAnnotation() {
 	}
 }
 
+func (c *Cluster) ControlPlaneEndpointMigrationAnnotation() string {
+	return controlPlaneEndpointMigrationAnnotation
+}
+
+// AllowControlPlaneEndpointMigration marks the cluster so a new control plane endpoint host
+// is rolled out during upgrade instead of being rejected as an immutable field change
+func (c *Cluster) AllowControlPlaneEndpointMigration() {
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+	c.Annotations[controlPlaneEndpointMigrationAnnotation] = "true"
+}
+
+// ClearControlPlaneEndpointMigrationAnnotation removes the annotation once the migration it allowed is complete
+func (c *Cluster) ClearControlPlaneEndpointMigrationAnnotation() {
+	if c.Annotations != nil {
+		delete(c.Annotations, controlPlaneEndpointMigrationAnnotation)
+	}
+}
+
+func (c *Cluster) IsControlPlaneEndpointMigrationAllowed() bool {
+	if s, ok := c.Annotations[controlPlaneEndpointMigrationAnnotation]; ok {
+		return s == "true"
+	}
+	return false
+}
+
+// FeatureGates returns the name=value pairs set in the feature gates annotation, used to enable
+// experimental features only for the operations run with this cluster config
+func (c *Cluster) FeatureGates() []string {
+	var gates []string
+	for _, gate := range strings.Split(c.Annotations[featureGatesAnnotation], ",") {
+		if gate = strings.TrimSpace(gate); gate != "" {
+			gates = append(gates, gate)
+		}
+	}
+	return gates
+}
+
+// EksaComponentsOverride returns the EKS-A components manifest URI set in the override annotation, empty if not set
+func (c *Cluster) EksaComponentsOverride() string {
+	return strings.TrimSpace(c.Annotations[eksaComponentsOverrideAnnotation])
+}
+
+// EksaControllerImageOverride returns the EKS-A controller image set in the override annotation, empty if not set
+func (c *Cluster) EksaControllerImageOverride() string {
+	return strings.TrimSpace(c.Annotations[eksaControllerImageOverrideAnnotation])
+}
+
+// ForceUnsupportedChange adds the field to the force unsupported changes annotation, so a change to it
+// is accepted even if the upgrade path doesn't support it
+func (c *Cluster) ForceUnsupportedChange(field string) {
+	if c.IsUnsupportedChangeForced(field) {
+		return
+	}
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+	c.Annotations[forceUnsupportedChangesAnnotation] = strings.Join(append(c.ForcedUnsupportedChanges(), field), ",")
+}
+
+// ForceUnsupportedChangesAnnotation returns the annotation with the fields whose unsupported changes are forced
+func (c *Cluster) ForceUnsupportedChangesAnnotation() string {
+	return forceUnsupportedChangesAnnotation
+}
+
+// ClearForcedUnsupportedChanges removes the force unsupported changes annotation once the changes it allowed are applied
+func (c *Cluster) ClearForcedUnsupportedChanges() {
+	if c.Annotations != nil {
+		delete(c.Annotations, forceUnsupportedChangesAnnotation)
+	}
+}
+
+// ForcedUnsupportedChanges returns the fields listed in the force unsupported changes annotation
+func (c *Cluster) ForcedUnsupportedChanges() []string {
+	var fields []string
+	for _, field := range strings.Split(c.Annotations[forceUnsupportedChangesAnnotation], ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func (c *Cluster) IsUnsupportedChangeForced(field string) bool {
+	for _, f := range c.ForcedUnsupportedChanges() {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Cluster) UseImageMirror(defaultImage string) string {
 	if c.Spec.RegistryMirrorConfiguration == nil {
 		return defaultImage
@@ -263,6 +443,17 @@ func (c *Cluster) IsReconcilePaused() bool {
 	return false
 }
 
+func (c *Cluster) MaintenanceAnnotation() string {
+	return maintenanceAnnotation
+}
+
+func (c *Cluster) IsInMaintenance() bool {
+	if s, ok := c.Annotations[maintenanceAnnotation]; ok {
+		return s == "true"
+	}
+	return false
+}
+
 func ValidateClusterName(clusterName string) error {
 	// this regex will not work for AWS provider as CFN has restrictions with UPPERCASE chars;
 	// if you are using AWS provider please use only lowercase chars
@@ -325,6 +516,54 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 	return nil
 }
 
+// maxWorkerNodeGroupVersionSkew is the max number of minor versions a worker node group
+// can fall behind the control plane, following the upstream kubelet version skew policy.
+const maxWorkerNodeGroupVersionSkew = 2
+
+func validateWorkerNodeGroupsKubernetesVersion(clusterConfig *Cluster) error {
+	for _, workerNodeGroupConfig := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroupConfig.KubernetesVersion == nil {
+			continue
+		}
+		if err := validateWorkerNodeGroupVersionSkew(clusterConfig.Spec.KubernetesVersion, *workerNodeGroupConfig.KubernetesVersion); err != nil {
+			return fmt.Errorf("invalid kubernetesVersion for worker node group %s: %v", workerNodeGroupConfig.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateWorkerNodeGroupVersionSkew(controlPlaneVersion, workerVersion KubernetesVersion) error {
+	cpMajor, cpMinor, err := parseKubernetesVersion(controlPlaneVersion)
+	if err != nil {
+		return err
+	}
+	workerMajor, workerMinor, err := parseKubernetesVersion(workerVersion)
+	if err != nil {
+		return err
+	}
+	if workerMajor != cpMajor || workerMinor > cpMinor {
+		return fmt.Errorf("worker node group version %s can't be newer than control plane version %s", workerVersion, controlPlaneVersion)
+	}
+	if cpMinor-workerMinor > maxWorkerNodeGroupVersionSkew {
+		return fmt.Errorf("worker node group version %s can't be more than %d minor versions older than control plane version %s", workerVersion, maxWorkerNodeGroupVersionSkew, controlPlaneVersion)
+	}
+	return nil
+}
+
+func parseKubernetesVersion(version KubernetesVersion) (major, minor int, err error) {
+	parts := strings.Split(string(version), ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("kubernetes version %s is not in the format major.minor", version)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid major version in kubernetes version %s: %v", version, err)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid minor version in kubernetes version %s: %v", version, err)
+	}
+	return major, minor, nil
+}
+
 func validateEtcdReplicas(clusterConfig *Cluster) error {
 	if clusterConfig.Spec.ExternalEtcdConfiguration == nil {
 		return nil
@@ -374,6 +613,13 @@ func validateNetworking(clusterConfig *Cluster) error {
 	if _, ok := validCNIs[clusterConfig.Spec.ClusterNetwork.CNI]; !ok {
 		return fmt.Errorf("cni %s not supported", clusterConfig.Spec.ClusterNetwork.CNI)
 	}
+	customCNI := clusterConfig.Spec.ClusterNetwork.CustomCNI
+	if clusterConfig.Spec.ClusterNetwork.CNI == Custom && (customCNI == nil || customCNI.Manifest == "") {
+		return fmt.Errorf("customCNI.manifest is required for cni %s, EKS Anywhere installs it when the cluster is created", Custom)
+	}
+	if clusterConfig.Spec.ClusterNetwork.CNI != Custom && customCNI != nil {
+		return fmt.Errorf("customCNI is only supported for cni %s", Custom)
+	}
 	return nil
 }
 
@@ -503,3 +749,500 @@ func validatePodIAMConfig(clusterConfig *Cluster) error {
 	}
 	return nil
 }
+
+// reservedTagPrefix is used by the standard tags EKS Anywhere adds to the infrastructure resources
+const reservedTagPrefix = "anywhere.eks.amazonaws.com/"
+
+func validateResourceTags(clusterConfig *Cluster) error {
+	for key, value := range clusterConfig.Spec.ResourceTags {
+		if key == "" {
+			return errors.New("resource tag keys can't be empty")
+		}
+		if strings.HasPrefix(key, reservedTagPrefix) {
+			return fmt.Errorf("resource tag %s is invalid: the %s prefix is reserved for EKS Anywhere tags", key, reservedTagPrefix)
+		}
+		if strings.Contains(key, ":") {
+			return fmt.Errorf("resource tag %s is invalid: keys can't contain ':'", key)
+		}
+		if value == "" {
+			return fmt.Errorf("resource tag %s is invalid: values can't be empty", key)
+		}
+	}
+	return nil
+}
+
+// maxASN is the largest 4-byte AS number
+const maxASN = 4294967295
+
+func validateServiceLoadBalancer(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.ServiceLoadBalancer
+	if config == nil {
+		return nil
+	}
+	if len(config.AddressPools) == 0 {
+		return errors.New("service load balancer requires at least one address pool")
+	}
+
+	var controlPlaneHost net.IP
+	if clusterConfig.Spec.ControlPlaneConfiguration.Endpoint != nil {
+		controlPlaneHost = net.ParseIP(clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
+	}
+	namespaces := map[string]struct{}{}
+	for _, pool := range config.AddressPools {
+		if _, ok := namespaces[pool.Namespace]; ok {
+			if pool.Namespace == "" {
+				return errors.New("service load balancer can only have one address pool without namespace")
+			}
+			return fmt.Errorf("service load balancer has more than one address pool for namespace %s", pool.Namespace)
+		}
+		namespaces[pool.Namespace] = struct{}{}
+
+		if len(pool.Addresses) == 0 {
+			return errors.New("service load balancer address pools require at least one address")
+		}
+		for _, address := range pool.Addresses {
+			contains, err := serviceLoadBalancerAddressContains(address, controlPlaneHost)
+			if err != nil {
+				return err
+			}
+			if contains {
+				return fmt.Errorf("service load balancer address %s includes the control plane endpoint %s", address, controlPlaneHost)
+			}
+		}
+	}
+
+	if config.BGP == nil {
+		return nil
+	}
+	if config.BGP.LocalASN < 1 || config.BGP.LocalASN > maxASN {
+		return fmt.Errorf("service load balancer BGP localAsn %d is invalid, it must be between 1 and %d", config.BGP.LocalASN, maxASN)
+	}
+	if len(config.BGP.Peers) == 0 {
+		return errors.New("service load balancer BGP requires at least one peer")
+	}
+	for _, peer := range config.BGP.Peers {
+		if net.ParseIP(peer.Address) == nil {
+			return fmt.Errorf("service load balancer BGP peer address %s is not a valid IP", peer.Address)
+		}
+		if peer.ASN < 1 || peer.ASN > maxASN {
+			return fmt.Errorf("service load balancer BGP peer %s asn %d is invalid, it must be between 1 and %d", peer.Address, peer.ASN, maxASN)
+		}
+	}
+	return nil
+}
+
+// serviceLoadBalancerAddressContains validates a pool address, either a CIDR or a range of IPs like
+// 10.0.0.10-10.0.0.20, and checks if it contains the ip
+func serviceLoadBalancerAddressContains(address string, ip net.IP) (bool, error) {
+	if strings.Contains(address, "/") {
+		_, ipNet, err := net.ParseCIDR(address)
+		if err != nil {
+			return false, fmt.Errorf("service load balancer address %s is not a valid CIDR: %v", address, err)
+		}
+		return ip != nil && ipNet.Contains(ip), nil
+	}
+
+	ips := strings.Split(address, "-")
+	if len(ips) != 2 {
+		return false, fmt.Errorf("service load balancer address %s is invalid, it must be a CIDR or a range like 10.0.0.10-10.0.0.20", address)
+	}
+	start, end := net.ParseIP(strings.TrimSpace(ips[0])), net.ParseIP(strings.TrimSpace(ips[1]))
+	if start == nil || end == nil {
+		return false, fmt.Errorf("service load balancer address range %s has invalid IPs", address)
+	}
+	if (start.To4() == nil) != (end.To4() == nil) {
+		return false, fmt.Errorf("service load balancer address range %s mixes IPv4 and IPv6", address)
+	}
+	if bytes.Compare(start.To16(), end.To16()) > 0 {
+		return false, fmt.Errorf("service load balancer address range %s starts after it ends", address)
+	}
+	return ip != nil && bytes.Compare(start.To16(), ip.To16()) <= 0 && bytes.Compare(ip.To16(), end.To16()) <= 0, nil
+}
+
+func validateLocalPathStorage(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.LocalPathStorage
+	if config == nil {
+		return nil
+	}
+	if clusterConfig.Spec.DatacenterRef.Kind == VSphereDatacenterKind {
+		return errors.New("local path storage is only supported for providers without a CSI driver, vSphere clusters use the vSphere CSI driver")
+	}
+	if config.Path != "" && !path.IsAbs(config.Path) {
+		return fmt.Errorf("local path storage path %s must be absolute", config.Path)
+	}
+	return nil
+}
+
+func validateFIPS(clusterConfig *Cluster) error {
+	if !clusterConfig.Spec.FIPSEnabled {
+		return nil
+	}
+	// FIPS enabled templates are validated through their tags, which is only supported for vSphere
+	if clusterConfig.Spec.DatacenterRef.Kind != VSphereDatacenterKind {
+		return fmt.Errorf("FIPS is only supported for %s clusters", VSphereDatacenterKind)
+	}
+	return nil
+}
+
+// tlsCipherSuites are the TLS 1.2 cipher suites with forward secrecy and AEAD accepted by the Kubernetes components
+var tlsCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
+}
+
+// kubeVersionTLSCipherSuites are the cipher suites names only accepted starting from a Kubernetes version,
+// 1.19 takes the names of the go crypto/tls package, which adds the SHA256 suffix to the CHACHA20 suites
+var kubeVersionTLSCipherSuites = map[KubernetesVersion][]string{
+	Kube119: {"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+	Kube120: {"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+	Kube121: {"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+}
+
+func supportedTLSCipherSuites(kubeVersion KubernetesVersion) map[string]struct{} {
+	supported := map[string]struct{}{}
+	for _, s := range tlsCipherSuites {
+		supported[s] = struct{}{}
+	}
+	for _, s := range kubeVersionTLSCipherSuites[kubeVersion] {
+		supported[s] = struct{}{}
+	}
+	return supported
+}
+
+func validateTLSPolicy(clusterConfig *Cluster) error {
+	policy := clusterConfig.Spec.TLSPolicy
+	if policy == nil {
+		return nil
+	}
+	// the policy is applied through the kubeadm templates, which only the vSphere and Docker providers configure
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+	if kind != VSphereDatacenterKind && kind != DockerDatacenterKind {
+		return fmt.Errorf("TLS policy is only supported for %s and %s clusters", VSphereDatacenterKind, DockerDatacenterKind)
+	}
+
+	switch policy.MinVersion {
+	case "", TLSVersion12:
+	case TLSVersion13:
+		if len(policy.CipherSuites) > 0 {
+			return fmt.Errorf("TLS policy cipher suites can't be set with min version %s, TLS 1.3 cipher suites are not configurable", TLSVersion13)
+		}
+		if clusterConfig.Spec.FIPSEnabled {
+			return fmt.Errorf("TLS policy min version %s is not supported in FIPS mode", TLSVersion13)
+		}
+	default:
+		return fmt.Errorf("TLS policy min version %s is not supported, supported versions: %s, %s", policy.MinVersion, TLSVersion12, TLSVersion13)
+	}
+
+	supported := supportedTLSCipherSuites(clusterConfig.Spec.KubernetesVersion)
+	seen := map[string]struct{}{}
+	for _, suite := range policy.CipherSuites {
+		if _, ok := supported[suite]; !ok {
+			return fmt.Errorf("TLS policy cipher suite %s is not supported for kubernetes version %s", suite, clusterConfig.Spec.KubernetesVersion)
+		}
+		if _, ok := seen[suite]; ok {
+			return fmt.Errorf("TLS policy cipher suite %s is duplicated", suite)
+		}
+		seen[suite] = struct{}{}
+		if clusterConfig.Spec.FIPSEnabled && !crypto.IsFIPSCipherSuite(suite) {
+			return fmt.Errorf("TLS policy cipher suite %s is not FIPS approved", suite)
+		}
+	}
+	return nil
+}
+
+// minPodSecurityKubernetesMinor is the first Kubernetes 1.x minor version with the PodSecurity admission plugin enabled by default.
+// The bundles don't ship it yet, so the CLI also requires the PodSecuritySupport feature gate
+const minPodSecurityKubernetesMinor = 23
+
+func validatePodSecurity(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.PodSecurity
+	if config == nil {
+		return nil
+	}
+	// the admission configuration is rendered in the kubeadm templates, which only the vSphere and Docker providers configure
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+	if kind != VSphereDatacenterKind && kind != DockerDatacenterKind {
+		return fmt.Errorf("pod security is only supported for %s and %s clusters", VSphereDatacenterKind, DockerDatacenterKind)
+	}
+
+	major, minor, err := parseKubernetesVersion(clusterConfig.Spec.KubernetesVersion)
+	if err != nil {
+		return err
+	}
+	if major == 1 && minor < minPodSecurityKubernetesMinor {
+		return fmt.Errorf("pod security requires kubernetes version 1.%d or newer, the PodSecurity admission plugin is not available in %s", minPodSecurityKubernetesMinor, clusterConfig.Spec.KubernetesVersion)
+	}
+
+	levels := []struct {
+		mode  string
+		level PodSecurityLevel
+	}{
+		{mode: "enforce", level: config.Enforce},
+		{mode: "audit", level: config.Audit},
+		{mode: "warn", level: config.Warn},
+	}
+	for _, l := range levels {
+		switch l.level {
+		case "", PodSecurityPrivileged, PodSecurityBaseline, PodSecurityRestricted:
+		default:
+			return fmt.Errorf("pod security %s level %s is not supported, supported levels: %s, %s, %s", l.mode, l.level, PodSecurityPrivileged, PodSecurityBaseline, PodSecurityRestricted)
+		}
+	}
+
+	for _, username := range config.Exemptions.Usernames {
+		if username == "" {
+			return errors.New("pod security exempted usernames can't be empty")
+		}
+	}
+	for _, runtimeClass := range config.Exemptions.RuntimeClasses {
+		if errs := validation.IsDNS1123Subdomain(runtimeClass); len(errs) > 0 {
+			return fmt.Errorf("pod security exempted runtime class %s is invalid: %s", runtimeClass, strings.Join(errs, ", "))
+		}
+	}
+	for _, namespace := range config.Exemptions.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("pod security exempted namespace %s is invalid: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+func validateHostEntries(clusterConfig *Cluster) error {
+	entries := clusterConfig.Spec.HostEntries
+	if len(entries) == 0 {
+		return nil
+	}
+	// the entries are written to /etc/hosts by the kubeadm templates, only the vSphere ones do it
+	if clusterConfig.Spec.DatacenterRef.Kind != VSphereDatacenterKind {
+		return fmt.Errorf("host entries are only supported for %s clusters", VSphereDatacenterKind)
+	}
+
+	hostnames := map[string]string{}
+	for _, e := range entries {
+		if net.ParseIP(e.IP) == nil {
+			return fmt.Errorf("host entry ip %s is invalid, please provide a valid ip", e.IP)
+		}
+		if len(e.Hostnames) == 0 {
+			return fmt.Errorf("host entry %s doesn't have any hostnames", e.IP)
+		}
+		for _, h := range e.Hostnames {
+			if errs := validation.IsDNS1123Subdomain(strings.ToLower(h)); len(errs) > 0 {
+				return fmt.Errorf("host entry hostname %s is invalid: %s", h, strings.Join(errs, ", "))
+			}
+			if ip, ok := hostnames[strings.ToLower(h)]; ok && ip != e.IP {
+				return fmt.Errorf("host entry hostname %s can't point to both %s and %s", h, ip, e.IP)
+			}
+			hostnames[strings.ToLower(h)] = e.IP
+		}
+	}
+	return nil
+}
+
+func validateNotifications(clusterConfig *Cluster) error {
+	return ValidateNotifications(clusterConfig.Spec.Notifications)
+}
+
+// ValidateNotifications checks the notifications config, either from the cluster spec or from a local file
+func ValidateNotifications(n *NotificationsConfiguration) error {
+	if n == nil {
+		return nil
+	}
+	if n.Slack != nil {
+		if err := validateNotificationURL(n.Slack.WebhookURL); err != nil {
+			return fmt.Errorf("slack notification webhookURL is invalid: %v", err)
+		}
+	}
+	for _, w := range n.Webhooks {
+		if err := validateNotificationURL(w.URL); err != nil {
+			return fmt.Errorf("webhook notification url is invalid: %v", err)
+		}
+	}
+	if n.SNS != nil {
+		if _, err := SNSTopicRegion(n.SNS.TopicARN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNotificationURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%s is not an http or https url", u)
+	}
+	return nil
+}
+
+// SNSTopicRegion returns the region of an SNS topic from its ARN, arn:<partition>:sns:<region>:<account>:<name>
+func SNSTopicRegion(arn string) (string, error) {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sns" || parts[3] == "" || parts[5] == "" {
+		return "", fmt.Errorf("sns notification topicARN %s is invalid, please provide an arn like arn:aws:sns:us-west-2:123456789012:my-topic", arn)
+	}
+	return parts[3], nil
+}
+
+func validateReadinessGates(clusterConfig *Cluster) error {
+	names := map[string]bool{}
+	for _, g := range clusterConfig.Spec.ReadinessGates {
+		if g.Name == "" {
+			return errors.New("readiness gate name can't be empty")
+		}
+		if names[g.Name] {
+			return fmt.Errorf("readiness gate %s is duplicated", g.Name)
+		}
+		names[g.Name] = true
+
+		checks := 0
+		if g.HTTP != nil {
+			checks++
+			if err := validateNotificationURL(g.HTTP.URL); err != nil {
+				return fmt.Errorf("readiness gate %s url is invalid: %v", g.Name, err)
+			}
+			if g.HTTP.ExpectedStatus != 0 && (g.HTTP.ExpectedStatus < 100 || g.HTTP.ExpectedStatus > 599) {
+				return fmt.Errorf("readiness gate %s expectedStatus %d is not an http status code", g.Name, g.HTTP.ExpectedStatus)
+			}
+		}
+		for _, object := range []*ObjectReadinessGate{g.DaemonSet, g.Job} {
+			if object == nil {
+				continue
+			}
+			checks++
+			if object.Name == "" {
+				return fmt.Errorf("readiness gate %s object name can't be empty", g.Name)
+			}
+		}
+		if checks != 1 {
+			return fmt.Errorf("readiness gate %s must set exactly one of http, daemonSet and job", g.Name)
+		}
+
+		if g.Timeout != "" {
+			if d, err := time.ParseDuration(g.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("readiness gate %s timeout %s is invalid, please provide a duration like 5m", g.Name, g.Timeout)
+			}
+		}
+	}
+	return nil
+}
+
+func validateCoreDNS(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.CoreDNS
+	if config == nil {
+		return nil
+	}
+
+	domains := map[string]bool{}
+	for _, d := range config.StubDomains {
+		domain := strings.ToLower(strings.TrimSuffix(d.Domain, "."))
+		if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+			return fmt.Errorf("coreDNS stub domain %s is invalid: %s", d.Domain, strings.Join(errs, ", "))
+		}
+		if domains[domain] {
+			return fmt.Errorf("coreDNS stub domain %s is duplicated", d.Domain)
+		}
+		domains[domain] = true
+		if len(d.Servers) == 0 {
+			return fmt.Errorf("coreDNS stub domain %s doesn't have any servers", d.Domain)
+		}
+		for _, server := range d.Servers {
+			if err := validateDNSServer(server); err != nil {
+				return fmt.Errorf("coreDNS stub domain %s: %v", d.Domain, err)
+			}
+		}
+	}
+
+	for _, server := range config.UpstreamResolvers {
+		if err := validateDNSServer(server); err != nil {
+			return fmt.Errorf("coreDNS upstream resolvers: %v", err)
+		}
+	}
+
+	if config.Replicas < 0 {
+		return fmt.Errorf("coreDNS replicas %d is invalid, it can't be negative", config.Replicas)
+	}
+	if config.CacheTTL < 0 {
+		return fmt.Errorf("coreDNS cacheTTL %d is invalid, it can't be negative", config.CacheTTL)
+	}
+	return nil
+}
+
+var ipvsSchedulers = map[string]bool{
+	"rr": true, "wrr": true, "lc": true, "wlc": true, "lblc": true,
+	"lblcr": true, "sh": true, "dh": true, "sed": true, "nq": true,
+}
+
+func validateKubeProxy(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.ClusterNetwork.KubeProxy
+	if config == nil {
+		return nil
+	}
+	// the kube-proxy config is rendered by the kubeadm templates, only the vSphere ones do it
+	if clusterConfig.Spec.DatacenterRef.Kind != VSphereDatacenterKind {
+		return fmt.Errorf("kubeProxy is only supported for %s clusters", VSphereDatacenterKind)
+	}
+
+	switch mode := config.ModeOrDefault(); mode {
+	case KubeProxyModeIPTables:
+	case KubeProxyModeIPVS:
+		if clusterConfig.Spec.ServiceLoadBalancer != nil && (config.IPVS == nil || !config.IPVS.StrictARP) {
+			return fmt.Errorf("kubeProxy ipvs mode requires ipvs.strictARP with the service load balancer")
+		}
+	case KubeProxyModeDisabled:
+		cni := clusterConfig.Spec.ClusterNetwork.CNI
+		if cni != Cilium && cni != Custom {
+			return fmt.Errorf("kubeProxy mode %s requires a cni that replaces kube-proxy: %s or %s, not %s", mode, Cilium, Custom, cni)
+		}
+	default:
+		return fmt.Errorf("kubeProxy mode %s is invalid, please use one of %s, %s or %s", mode, KubeProxyModeIPTables, KubeProxyModeIPVS, KubeProxyModeDisabled)
+	}
+
+	if config.IPVS != nil {
+		if config.ModeOrDefault() != KubeProxyModeIPVS {
+			return fmt.Errorf("kubeProxy ipvs can only be set with mode %s", KubeProxyModeIPVS)
+		}
+		if config.IPVS.Scheduler != "" && !ipvsSchedulers[config.IPVS.Scheduler] {
+			return fmt.Errorf("kubeProxy ipvs scheduler %s is invalid", config.IPVS.Scheduler)
+		}
+	}
+	return nil
+}
+
+// validateDNSServer checks the server is an IP, optionally with a port
+func validateDNSServer(server string) error {
+	host := server
+	if h, port, err := net.SplitHostPort(server); err == nil {
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			return fmt.Errorf("dns server %s port is invalid", server)
+		}
+		host = h
+	}
+	if net.ParseIP(host) == nil {
+		return fmt.Errorf("dns server %s is invalid, please provide an ip with an optional port", server)
+	}
+	return nil
+}
+
+func validateForcedUnsupportedChanges(clusterConfig *Cluster) error {
+	for _, field := range clusterConfig.ForcedUnsupportedChanges() {
+		if !forceableField(field) {
+			return fmt.Errorf("%s annotation: changes to %s can't be forced, only to %s", forceUnsupportedChangesAnnotation, field, strings.Join(forceableFields, ", "))
+		}
+	}
+	return nil
+}
+
+func forceableField(field string) bool {
+	for _, f := range forceableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
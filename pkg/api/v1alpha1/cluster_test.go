@@ -3,10 +3,12 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
 
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -89,6 +91,66 @@ func TestClusterNameLength(t *testing.T) {
 	}
 }
 
+func TestValidateWorkerNodeGroupsKubernetesVersion(t *testing.T) {
+	kubeVersion := func(v KubernetesVersion) *KubernetesVersion { return &v }
+	tests := []struct {
+		name          string
+		workerVersion *KubernetesVersion
+		wantErr       string
+	}{
+		{
+			name:          "no version override",
+			workerVersion: nil,
+		},
+		{
+			name:          "same version as control plane",
+			workerVersion: kubeVersion(Kube121),
+		},
+		{
+			name:          "two minor versions older",
+			workerVersion: kubeVersion(Kube119),
+		},
+		{
+			name:          "three minor versions older",
+			workerVersion: kubeVersion(Kube118),
+			wantErr:       "invalid kubernetesVersion for worker node group md-0: worker node group version 1.18 can't be more than 2 minor versions older than control plane version 1.21",
+		},
+		{
+			name:          "newer than control plane",
+			workerVersion: kubeVersion("1.22"),
+			wantErr:       "invalid kubernetesVersion for worker node group md-0: worker node group version 1.22 can't be newer than control plane version 1.21",
+		},
+		{
+			name:          "invalid format",
+			workerVersion: kubeVersion("1.21.2"),
+			wantErr:       "invalid kubernetesVersion for worker node group md-0: kubernetes version 1.21.2 is not in the format major.minor",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			c := &Cluster{
+				Spec: ClusterSpec{
+					KubernetesVersion: Kube121,
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{
+							Name:              "md-0",
+							KubernetesVersion: tc.workerVersion,
+						},
+					},
+				},
+			}
+			err := validateWorkerNodeGroupsKubernetesVersion(c)
+			if tc.wantErr == "" && err != nil {
+				tt.Errorf("validateWorkerNodeGroupsKubernetesVersion() error = %v, want nil", err)
+			}
+			if tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				tt.Errorf("validateWorkerNodeGroupsKubernetesVersion() error = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestGetAndValidateClusterConfig(t *testing.T) {
 	tests := []struct {
 		testName    string
@@ -858,6 +920,24 @@ func TestGetClusterConfig(t *testing.T) {
 	}
 }
 
+func TestGetClusterConfigFromContent(t *testing.T) {
+	g := NewWithT(t)
+	content, err := os.ReadFile("testdata/cluster_1_18.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	want, err := GetClusterConfig("testdata/cluster_1_18.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	got, err := GetClusterConfigFromContent(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(want))
+}
+
+func TestParseClusterConfigFromContentMissingKind(t *testing.T) {
+	g := NewWithT(t)
+	err := ParseClusterConfigFromContent([]byte("kind: VSphereDatacenterConfig"), &Cluster{})
+	g.Expect(err).To(MatchError("cluster spec is invalid or does not contain kind Cluster"))
+}
+
 func TestParseClusterConfig(t *testing.T) {
 	type args struct {
 		fileName      string
@@ -965,6 +1045,15 @@ func TestParseClusterConfig(t *testing.T) {
 			wantErr:    true,
 			matchError: fmt.Errorf("error unmarshaling JSON: while decoding JSON: json: unknown field \"invalidField\""),
 		},
+		{
+			name: "Duplicated kind",
+			args: args{
+				fileName:      "testdata/clusterconfig_duplicated.yaml",
+				clusterConfig: &Cluster{},
+			},
+			wantErr:    true,
+			matchError: fmt.Errorf("contains more than one object of kind Cluster"),
+		},
 		{
 			name: "Cluster definition at the end",
 			args: args{
@@ -1602,3 +1691,953 @@ func TestRefEquals(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterFeatureGates(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{}
+	g.Expect(c.FeatureGates()).To(BeEmpty())
+
+	c.Annotations = map[string]string{featureGatesAnnotation: "TinkerbellProvider=true, TaintsSupport=false,,"}
+	g.Expect(c.FeatureGates()).To(Equal([]string{"TinkerbellProvider=true", "TaintsSupport=false"}))
+}
+
+func TestClusterEksaOverrides(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{}
+	g.Expect(c.EksaComponentsOverride()).To(BeEmpty())
+	g.Expect(c.EksaControllerImageOverride()).To(BeEmpty())
+
+	c.Annotations = map[string]string{
+		eksaComponentsOverrideAnnotation:      " https://example.com/eksa-components.yaml ",
+		eksaControllerImageOverrideAnnotation: "example.com/eks-anywhere-cluster-controller:patched",
+	}
+	g.Expect(c.EksaComponentsOverride()).To(Equal("https://example.com/eksa-components.yaml"))
+	g.Expect(c.EksaControllerImageOverride()).To(Equal("example.com/eks-anywhere-cluster-controller:patched"))
+}
+
+func TestClusterForceUnsupportedChange(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{}
+	g.Expect(c.IsUnsupportedChangeForced(ProxyConfigurationField)).To(BeFalse())
+
+	c.ForceUnsupportedChange(ProxyConfigurationField)
+	c.ForceUnsupportedChange(ClusterNetworkField)
+	c.ForceUnsupportedChange(ProxyConfigurationField)
+	g.Expect(c.Annotations[forceUnsupportedChangesAnnotation]).To(Equal("spec.proxyConfiguration,spec.clusterNetwork"))
+	g.Expect(c.IsUnsupportedChangeForced(ProxyConfigurationField)).To(BeTrue())
+	g.Expect(c.IsUnsupportedChangeForced(IdentityProviderRefsField)).To(BeFalse())
+	g.Expect(validateForcedUnsupportedChanges(c)).To(Succeed())
+}
+
+func TestValidateForcedUnsupportedChangesNotForceable(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{}
+	c.Annotations = map[string]string{forceUnsupportedChangesAnnotation: "spec.clusterNetwork, spec.datacenterRef"}
+
+	g.Expect(validateForcedUnsupportedChanges(c)).To(MatchError(ContainSubstring("changes to spec.datacenterRef can't be forced")))
+}
+
+func TestValidateResourceTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		wantErr string
+	}{
+		{
+			name: "no tags",
+		},
+		{
+			name: "valid tags",
+			tags: map[string]string{"team": "platform", "cost-center": "1234"},
+		},
+		{
+			name:    "empty key",
+			tags:    map[string]string{"": "platform"},
+			wantErr: "resource tag keys can't be empty",
+		},
+		{
+			name:    "reserved prefix",
+			tags:    map[string]string{"anywhere.eks.amazonaws.com/cluster-name": "other"},
+			wantErr: "resource tag anywhere.eks.amazonaws.com/cluster-name is invalid: the anywhere.eks.amazonaws.com/ prefix is reserved for EKS Anywhere tags",
+		},
+		{
+			name:    "key with colon",
+			tags:    map[string]string{"team:name": "platform"},
+			wantErr: "resource tag team:name is invalid: keys can't contain ':'",
+		},
+		{
+			name:    "empty value",
+			tags:    map[string]string{"team": ""},
+			wantErr: "resource tag team is invalid: values can't be empty",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			g := NewWithT(tt)
+			c := &Cluster{Spec: ClusterSpec{ResourceTags: tc.tags}}
+			err := validateResourceTags(c)
+			if tc.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateServiceLoadBalancer(t *testing.T) {
+	pool := func(namespace string, addresses ...string) ServiceLoadBalancerAddressPool {
+		return ServiceLoadBalancerAddressPool{Namespace: namespace, Addresses: addresses}
+	}
+	tests := []struct {
+		name    string
+		config  *ServiceLoadBalancerConfiguration
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid l2",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/28", "10.0.1.10-10.0.1.20"), pool("apps", "10.0.2.0/29")},
+			},
+		},
+		{
+			name: "valid bgp",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/28")},
+				BGP: &ServiceLoadBalancerBGP{
+					LocalASN: 65000,
+					Peers:    []ServiceLoadBalancerBGPPeer{{Address: "192.168.0.1", ASN: 65001}},
+				},
+			},
+		},
+		{
+			name:    "no pools",
+			config:  &ServiceLoadBalancerConfiguration{},
+			wantErr: "service load balancer requires at least one address pool",
+		},
+		{
+			name: "two global pools",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/28"), pool("", "10.0.1.0/28")},
+			},
+			wantErr: "service load balancer can only have one address pool without namespace",
+		},
+		{
+			name: "two pools for a namespace",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("apps", "10.0.0.0/28"), pool("apps", "10.0.1.0/28")},
+			},
+			wantErr: "service load balancer has more than one address pool for namespace apps",
+		},
+		{
+			name: "pool without addresses",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("")},
+			},
+			wantErr: "service load balancer address pools require at least one address",
+		},
+		{
+			name: "invalid cidr",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/33")},
+			},
+			wantErr: "service load balancer address 10.0.0.0/33 is not a valid CIDR: invalid CIDR address: 10.0.0.0/33",
+		},
+		{
+			name: "invalid address",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.1")},
+			},
+			wantErr: "service load balancer address 10.0.0.1 is invalid, it must be a CIDR or a range like 10.0.0.10-10.0.0.20",
+		},
+		{
+			name: "reversed range",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.20-10.0.0.10")},
+			},
+			wantErr: "service load balancer address range 10.0.0.20-10.0.0.10 starts after it ends",
+		},
+		{
+			name: "range with control plane endpoint",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "1.2.3.1-1.2.3.10")},
+			},
+			wantErr: "service load balancer address 1.2.3.1-1.2.3.10 includes the control plane endpoint 1.2.3.4",
+		},
+		{
+			name: "cidr with control plane endpoint",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("apps", "1.2.3.0/24")},
+			},
+			wantErr: "service load balancer address 1.2.3.0/24 includes the control plane endpoint 1.2.3.4",
+		},
+		{
+			name: "bgp invalid local asn",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/28")},
+				BGP:          &ServiceLoadBalancerBGP{},
+			},
+			wantErr: "service load balancer BGP localAsn 0 is invalid, it must be between 1 and 4294967295",
+		},
+		{
+			name: "bgp without peers",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/28")},
+				BGP:          &ServiceLoadBalancerBGP{LocalASN: 65000},
+			},
+			wantErr: "service load balancer BGP requires at least one peer",
+		},
+		{
+			name: "bgp invalid peer address",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/28")},
+				BGP: &ServiceLoadBalancerBGP{
+					LocalASN: 65000,
+					Peers:    []ServiceLoadBalancerBGPPeer{{Address: "router", ASN: 65001}},
+				},
+			},
+			wantErr: "service load balancer BGP peer address router is not a valid IP",
+		},
+		{
+			name: "bgp invalid peer asn",
+			config: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{pool("", "10.0.0.0/28")},
+				BGP: &ServiceLoadBalancerBGP{
+					LocalASN: 65000,
+					Peers:    []ServiceLoadBalancerBGPPeer{{Address: "192.168.0.1", ASN: 4294967296}},
+				},
+			},
+			wantErr: "service load balancer BGP peer 192.168.0.1 asn 4294967296 is invalid, it must be between 1 and 4294967295",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			g := NewWithT(tt)
+			c := &Cluster{Spec: ClusterSpec{
+				ControlPlaneConfiguration: ControlPlaneConfiguration{Endpoint: &Endpoint{Host: "1.2.3.4"}},
+				ServiceLoadBalancer:       tc.config,
+			}}
+			err := validateServiceLoadBalancer(c)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateLocalPathStorage(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		config         *LocalPathStorageConfiguration
+		wantErr        string
+	}{
+		{
+			name:           "not configured",
+			datacenterKind: VSphereDatacenterKind,
+		},
+		{
+			name:           "default path",
+			datacenterKind: DockerDatacenterKind,
+			config:         &LocalPathStorageConfiguration{},
+		},
+		{
+			name:           "custom path",
+			datacenterKind: TinkerbellDatacenterKind,
+			config:         &LocalPathStorageConfiguration{Path: "/var/lib/volumes"},
+		},
+		{
+			name:           "relative path",
+			datacenterKind: DockerDatacenterKind,
+			config:         &LocalPathStorageConfiguration{Path: "volumes"},
+			wantErr:        "local path storage path volumes must be absolute",
+		},
+		{
+			name:           "vsphere",
+			datacenterKind: VSphereDatacenterKind,
+			config:         &LocalPathStorageConfiguration{},
+			wantErr:        "local path storage is only supported for providers without a CSI driver, vSphere clusters use the vSphere CSI driver",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			g := NewWithT(tt)
+			c := &Cluster{Spec: ClusterSpec{
+				DatacenterRef:    Ref{Kind: tc.datacenterKind},
+				LocalPathStorage: tc.config,
+			}}
+			err := validateLocalPathStorage(c)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateFIPS(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		fipsEnabled    bool
+		wantErr        string
+	}{
+		{
+			name:           "disabled",
+			datacenterKind: DockerDatacenterKind,
+		},
+		{
+			name:           "vsphere",
+			datacenterKind: VSphereDatacenterKind,
+			fipsEnabled:    true,
+		},
+		{
+			name:           "docker",
+			datacenterKind: DockerDatacenterKind,
+			fipsEnabled:    true,
+			wantErr:        "FIPS is only supported for VSphereDatacenterConfig clusters",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			g := NewWithT(tt)
+			c := &Cluster{Spec: ClusterSpec{
+				DatacenterRef: Ref{Kind: tc.datacenterKind},
+				FIPSEnabled:   tc.fipsEnabled,
+			}}
+			err := validateFIPS(c)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateTLSPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		kubeVersion    KubernetesVersion
+		fipsEnabled    bool
+		policy         *TLSPolicy
+		wantErr        string
+	}{
+		{
+			name:           "not configured",
+			datacenterKind: TinkerbellDatacenterKind,
+		},
+		{
+			name:           "min version and cipher suites",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			policy: &TLSPolicy{
+				MinVersion:   TLSVersion12,
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+			},
+		},
+		{
+			name:           "tls 1.3",
+			datacenterKind: DockerDatacenterKind,
+			kubeVersion:    Kube120,
+			policy:         &TLSPolicy{MinVersion: TLSVersion13},
+		},
+		{
+			name:           "unsupported provider",
+			datacenterKind: TinkerbellDatacenterKind,
+			kubeVersion:    Kube121,
+			policy:         &TLSPolicy{MinVersion: TLSVersion12},
+			wantErr:        "TLS policy is only supported for VSphereDatacenterConfig and DockerDatacenterConfig clusters",
+		},
+		{
+			name:           "unsupported min version",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			policy:         &TLSPolicy{MinVersion: "VersionTLS11"},
+			wantErr:        "TLS policy min version VersionTLS11 is not supported, supported versions: VersionTLS12, VersionTLS13",
+		},
+		{
+			name:           "tls 1.3 with cipher suites",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			policy:         &TLSPolicy{MinVersion: TLSVersion13, CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			wantErr:        "TLS policy cipher suites can't be set with min version VersionTLS13, TLS 1.3 cipher suites are not configurable",
+		},
+		{
+			name:           "insecure cipher suite",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			policy:         &TLSPolicy{CipherSuites: []string{"TLS_RSA_WITH_AES_128_CBC_SHA"}},
+			wantErr:        "TLS policy cipher suite TLS_RSA_WITH_AES_128_CBC_SHA is not supported for kubernetes version 1.21",
+		},
+		{
+			name:           "cipher suite name not supported by kubernetes version",
+			datacenterKind: DockerDatacenterKind,
+			kubeVersion:    Kube118,
+			policy:         &TLSPolicy{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}},
+			wantErr:        "TLS policy cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256 is not supported for kubernetes version 1.18",
+		},
+		{
+			name:           "duplicated cipher suite",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			policy:         &TLSPolicy{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			wantErr:        "TLS policy cipher suite TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384 is duplicated",
+		},
+		{
+			name:           "fips with approved cipher suites",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			fipsEnabled:    true,
+			policy:         &TLSPolicy{MinVersion: TLSVersion12, CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+		},
+		{
+			name:           "fips with cipher suite not approved",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			fipsEnabled:    true,
+			policy:         &TLSPolicy{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305"}},
+			wantErr:        "TLS policy cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305 is not FIPS approved",
+		},
+		{
+			name:           "fips with tls 1.3",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			fipsEnabled:    true,
+			policy:         &TLSPolicy{MinVersion: TLSVersion13},
+			wantErr:        "TLS policy min version VersionTLS13 is not supported in FIPS mode",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			g := NewWithT(tt)
+			c := &Cluster{Spec: ClusterSpec{
+				DatacenterRef:     Ref{Kind: tc.datacenterKind},
+				KubernetesVersion: tc.kubeVersion,
+				FIPSEnabled:       tc.fipsEnabled,
+				TLSPolicy:         tc.policy,
+			}}
+			err := validateTLSPolicy(c)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidatePodSecurity(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		kubeVersion    KubernetesVersion
+		config         *PodSecurityConfiguration
+		wantErr        string
+	}{
+		{
+			name:           "not configured",
+			datacenterKind: TinkerbellDatacenterKind,
+			kubeVersion:    Kube121,
+		},
+		{
+			name:           "levels and exemptions",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    "1.23",
+			config: &PodSecurityConfiguration{
+				Enforce: PodSecurityBaseline,
+				Audit:   PodSecurityRestricted,
+				Warn:    PodSecurityRestricted,
+				Exemptions: PodSecurityExemptions{
+					Usernames:      []string{"system:serviceaccount:monitoring:node-exporter"},
+					RuntimeClasses: []string{"kata"},
+					Namespaces:     []string{"monitoring"},
+				},
+			},
+		},
+		{
+			name:           "defaults",
+			datacenterKind: DockerDatacenterKind,
+			kubeVersion:    "1.24",
+			config:         &PodSecurityConfiguration{},
+		},
+		{
+			name:           "unsupported provider",
+			datacenterKind: TinkerbellDatacenterKind,
+			kubeVersion:    "1.23",
+			config:         &PodSecurityConfiguration{Enforce: PodSecurityBaseline},
+			wantErr:        "pod security is only supported for VSphereDatacenterConfig and DockerDatacenterConfig clusters",
+		},
+		{
+			name:           "kubernetes version without pod security admission",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    Kube121,
+			config:         &PodSecurityConfiguration{Enforce: PodSecurityBaseline},
+			wantErr:        "pod security requires kubernetes version 1.23 or newer, the PodSecurity admission plugin is not available in 1.21",
+		},
+		{
+			name:           "invalid level",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    "1.23",
+			config:         &PodSecurityConfiguration{Audit: "strict"},
+			wantErr:        "pod security audit level strict is not supported, supported levels: privileged, baseline, restricted",
+		},
+		{
+			name:           "empty username",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    "1.23",
+			config:         &PodSecurityConfiguration{Exemptions: PodSecurityExemptions{Usernames: []string{""}}},
+			wantErr:        "pod security exempted usernames can't be empty",
+		},
+		{
+			name:           "invalid namespace",
+			datacenterKind: VSphereDatacenterKind,
+			kubeVersion:    "1.23",
+			config:         &PodSecurityConfiguration{Exemptions: PodSecurityExemptions{Namespaces: []string{"Monitoring"}}},
+			wantErr:        "pod security exempted namespace Monitoring is invalid: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			g := NewWithT(tt)
+			c := &Cluster{Spec: ClusterSpec{
+				DatacenterRef:     Ref{Kind: tc.datacenterKind},
+				KubernetesVersion: tc.kubeVersion,
+				PodSecurity:       tc.config,
+			}}
+			err := validatePodSecurity(c)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateHostEntries(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		entries        []HostEntry
+		wantErr        string
+	}{
+		{
+			name:           "not configured",
+			datacenterKind: DockerDatacenterKind,
+		},
+		{
+			name:           "valid entries",
+			datacenterKind: VSphereDatacenterKind,
+			entries: []HostEntry{
+				{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local", "registry"}},
+				{IP: "10.0.0.11", Hostnames: []string{"vcenter.lab.local"}},
+			},
+		},
+		{
+			name:           "unsupported provider",
+			datacenterKind: DockerDatacenterKind,
+			entries:        []HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}},
+			wantErr:        "host entries are only supported for VSphereDatacenterConfig clusters",
+		},
+		{
+			name:           "invalid ip",
+			datacenterKind: VSphereDatacenterKind,
+			entries:        []HostEntry{{IP: "10.0.0", Hostnames: []string{"registry.lab.local"}}},
+			wantErr:        "host entry ip 10.0.0 is invalid, please provide a valid ip",
+		},
+		{
+			name:           "no hostnames",
+			datacenterKind: VSphereDatacenterKind,
+			entries:        []HostEntry{{IP: "10.0.0.10"}},
+			wantErr:        "host entry 10.0.0.10 doesn't have any hostnames",
+		},
+		{
+			name:           "invalid hostname",
+			datacenterKind: VSphereDatacenterKind,
+			entries:        []HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry_lab"}}},
+			wantErr:        "host entry hostname registry_lab is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+		{
+			name:           "hostname with two ips",
+			datacenterKind: VSphereDatacenterKind,
+			entries: []HostEntry{
+				{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}},
+				{IP: "10.0.0.11", Hostnames: []string{"Registry.lab.local"}},
+			},
+			wantErr: "host entry hostname Registry.lab.local can't point to both 10.0.0.10 and 10.0.0.11",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			g := NewWithT(tt)
+			c := &Cluster{Spec: ClusterSpec{
+				DatacenterRef: Ref{Kind: tc.datacenterKind},
+				HostEntries:   tc.entries,
+			}}
+			err := validateHostEntries(c)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateNotifications(t *testing.T) {
+	tests := []struct {
+		name          string
+		notifications *NotificationsConfiguration
+		wantErr       string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid notifications",
+			notifications: &NotificationsConfiguration{
+				Slack:    &SlackNotification{WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX"},
+				Webhooks: []WebhookNotification{{URL: "http://events.lab.local:8080/eksa"}},
+				SNS:      &SNSNotification{TopicARN: "arn:aws:sns:us-west-2:123456789012:eksa"},
+			},
+		},
+		{
+			name:          "invalid slack url",
+			notifications: &NotificationsConfiguration{Slack: &SlackNotification{WebhookURL: "hooks.slack.com/services"}},
+			wantErr:       "slack notification webhookURL is invalid: hooks.slack.com/services is not an http or https url",
+		},
+		{
+			name:          "invalid webhook url",
+			notifications: &NotificationsConfiguration{Webhooks: []WebhookNotification{{URL: "ftp://events.lab.local"}}},
+			wantErr:       "webhook notification url is invalid: ftp://events.lab.local is not an http or https url",
+		},
+		{
+			name:          "invalid sns topic",
+			notifications: &NotificationsConfiguration{SNS: &SNSNotification{TopicARN: "arn:aws:sqs:us-west-2:123456789012:eksa"}},
+			wantErr:       "sns notification topicARN arn:aws:sqs:us-west-2:123456789012:eksa is invalid, please provide an arn like arn:aws:sns:us-west-2:123456789012:my-topic",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{Notifications: tt.notifications}}
+			err := validateNotifications(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateReadinessGates(t *testing.T) {
+	tests := []struct {
+		name    string
+		gates   []ReadinessGate
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid gates",
+			gates: []ReadinessGate{
+				{Name: "ingress", HTTP: &HTTPReadinessGate{URL: "https://app.lab.local/healthz", ExpectedStatus: 204}, Timeout: "5m"},
+				{Name: "agent", DaemonSet: &ObjectReadinessGate{Name: "security-agent", Namespace: "security"}},
+				{Name: "smoke-test", Job: &ObjectReadinessGate{Name: "smoke-test"}},
+			},
+		},
+		{
+			name:    "no name",
+			gates:   []ReadinessGate{{Job: &ObjectReadinessGate{Name: "smoke-test"}}},
+			wantErr: "readiness gate name can't be empty",
+		},
+		{
+			name: "duplicated name",
+			gates: []ReadinessGate{
+				{Name: "smoke-test", Job: &ObjectReadinessGate{Name: "smoke-test"}},
+				{Name: "smoke-test", Job: &ObjectReadinessGate{Name: "smoke-test-2"}},
+			},
+			wantErr: "readiness gate smoke-test is duplicated",
+		},
+		{
+			name:    "no check",
+			gates:   []ReadinessGate{{Name: "ingress"}},
+			wantErr: "readiness gate ingress must set exactly one of http, daemonSet and job",
+		},
+		{
+			name: "many checks",
+			gates: []ReadinessGate{{
+				Name:      "ingress",
+				HTTP:      &HTTPReadinessGate{URL: "https://app.lab.local/healthz"},
+				DaemonSet: &ObjectReadinessGate{Name: "ingress-nginx"},
+			}},
+			wantErr: "readiness gate ingress must set exactly one of http, daemonSet and job",
+		},
+		{
+			name:    "invalid url",
+			gates:   []ReadinessGate{{Name: "ingress", HTTP: &HTTPReadinessGate{URL: "app.lab.local/healthz"}}},
+			wantErr: "readiness gate ingress url is invalid: app.lab.local/healthz is not an http or https url",
+		},
+		{
+			name:    "invalid status",
+			gates:   []ReadinessGate{{Name: "ingress", HTTP: &HTTPReadinessGate{URL: "https://app.lab.local/healthz", ExpectedStatus: 2000}}},
+			wantErr: "readiness gate ingress expectedStatus 2000 is not an http status code",
+		},
+		{
+			name:    "no object name",
+			gates:   []ReadinessGate{{Name: "agent", DaemonSet: &ObjectReadinessGate{Namespace: "security"}}},
+			wantErr: "readiness gate agent object name can't be empty",
+		},
+		{
+			name:    "invalid timeout",
+			gates:   []ReadinessGate{{Name: "smoke-test", Job: &ObjectReadinessGate{Name: "smoke-test"}, Timeout: "5"}},
+			wantErr: "readiness gate smoke-test timeout 5 is invalid, please provide a duration like 5m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{ReadinessGates: tt.gates}}
+			err := validateReadinessGates(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateCoreDNS(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *CoreDNSConfiguration
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid config",
+			config: &CoreDNSConfiguration{
+				StubDomains:       []CoreDNSStubDomain{{Domain: "corp.example.com", Servers: []string{"10.0.0.2", "10.0.0.3:5353"}}},
+				UpstreamResolvers: []string{"1.1.1.1", "[2606:4700:4700::1111]:53"},
+				Replicas:          3,
+				CacheTTL:          60,
+			},
+		},
+		{
+			name:    "invalid domain",
+			config:  &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{{Domain: "corp_example", Servers: []string{"10.0.0.2"}}}},
+			wantErr: "coreDNS stub domain corp_example is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+		{
+			name: "duplicated domain",
+			config: &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{
+				{Domain: "corp.example.com", Servers: []string{"10.0.0.2"}},
+				{Domain: "corp.example.com.", Servers: []string{"10.0.0.3"}},
+			}},
+			wantErr: "coreDNS stub domain corp.example.com. is duplicated",
+		},
+		{
+			name:    "no servers",
+			config:  &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{{Domain: "corp.example.com"}}},
+			wantErr: "coreDNS stub domain corp.example.com doesn't have any servers",
+		},
+		{
+			name:    "invalid stub domain server",
+			config:  &CoreDNSConfiguration{StubDomains: []CoreDNSStubDomain{{Domain: "corp.example.com", Servers: []string{"dns.corp.example.com"}}}},
+			wantErr: "coreDNS stub domain corp.example.com: dns server dns.corp.example.com is invalid, please provide an ip with an optional port",
+		},
+		{
+			name:    "invalid upstream resolver port",
+			config:  &CoreDNSConfiguration{UpstreamResolvers: []string{"10.0.0.2:70000"}},
+			wantErr: "coreDNS upstream resolvers: dns server 10.0.0.2:70000 port is invalid",
+		},
+		{
+			name:    "negative replicas",
+			config:  &CoreDNSConfiguration{Replicas: -1},
+			wantErr: "coreDNS replicas -1 is invalid, it can't be negative",
+		},
+		{
+			name:    "negative cache ttl",
+			config:  &CoreDNSConfiguration{CacheTTL: -1},
+			wantErr: "coreDNS cacheTTL -1 is invalid, it can't be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{CoreDNS: tt.config}}
+			err := validateCoreDNS(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateKubeProxy(t *testing.T) {
+	tests := []struct {
+		name                string
+		kind                string
+		cni                 CNI
+		config              *KubeProxyConfiguration
+		serviceLoadBalancer bool
+		wantErr             string
+	}{
+		{
+			name: "not configured",
+			kind: DockerDatacenterKind,
+		},
+		{
+			name:   "iptables",
+			config: &KubeProxyConfiguration{Mode: KubeProxyModeIPTables},
+		},
+		{
+			name:   "ipvs",
+			config: &KubeProxyConfiguration{Mode: KubeProxyModeIPVS, IPVS: &KubeProxyIPVSConfiguration{Scheduler: "lc"}},
+		},
+		{
+			name:                "ipvs with service load balancer",
+			config:              &KubeProxyConfiguration{Mode: KubeProxyModeIPVS, IPVS: &KubeProxyIPVSConfiguration{StrictARP: true}},
+			serviceLoadBalancer: true,
+		},
+		{
+			name:   "disabled with cilium",
+			config: &KubeProxyConfiguration{Mode: KubeProxyModeDisabled},
+		},
+		{
+			name:   "disabled with custom cni",
+			cni:    Custom,
+			config: &KubeProxyConfiguration{Mode: KubeProxyModeDisabled},
+		},
+		{
+			name:    "not vsphere",
+			kind:    DockerDatacenterKind,
+			config:  &KubeProxyConfiguration{Mode: KubeProxyModeIPVS},
+			wantErr: "kubeProxy is only supported for VSphereDatacenterConfig clusters",
+		},
+		{
+			name:    "invalid mode",
+			config:  &KubeProxyConfiguration{Mode: "userspace"},
+			wantErr: "kubeProxy mode userspace is invalid, please use one of iptables, ipvs or disabled",
+		},
+		{
+			name:    "disabled with kindnetd",
+			cni:     Kindnetd,
+			config:  &KubeProxyConfiguration{Mode: KubeProxyModeDisabled},
+			wantErr: "kubeProxy mode disabled requires a cni that replaces kube-proxy: cilium or custom, not kindnetd",
+		},
+		{
+			name:                "ipvs with service load balancer without strict arp",
+			config:              &KubeProxyConfiguration{Mode: KubeProxyModeIPVS},
+			serviceLoadBalancer: true,
+			wantErr:             "kubeProxy ipvs mode requires ipvs.strictARP with the service load balancer",
+		},
+		{
+			name:    "ipvs config with iptables mode",
+			config:  &KubeProxyConfiguration{IPVS: &KubeProxyIPVSConfiguration{Scheduler: "rr"}},
+			wantErr: "kubeProxy ipvs can only be set with mode ipvs",
+		},
+		{
+			name:    "invalid scheduler",
+			config:  &KubeProxyConfiguration{Mode: KubeProxyModeIPVS, IPVS: &KubeProxyIPVSConfiguration{Scheduler: "random"}},
+			wantErr: "kubeProxy ipvs scheduler random is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			if tt.kind == "" {
+				tt.kind = VSphereDatacenterKind
+			}
+			if tt.cni == "" {
+				tt.cni = Cilium
+			}
+			cluster := &Cluster{Spec: ClusterSpec{
+				DatacenterRef:  Ref{Kind: tt.kind},
+				ClusterNetwork: ClusterNetwork{CNI: tt.cni, KubeProxy: tt.config},
+			}}
+			if tt.serviceLoadBalancer {
+				cluster.Spec.ServiceLoadBalancer = &ServiceLoadBalancerConfiguration{}
+			}
+			err := validateKubeProxy(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateNetworkingCustomCNI(t *testing.T) {
+	tests := []struct {
+		name      string
+		cni       CNI
+		customCNI *CustomCNIConfig
+		wantErr   string
+	}{
+		{
+			name:      "custom with manifest",
+			cni:       Custom,
+			customCNI: &CustomCNIConfig{Manifest: "calico.yaml"},
+		},
+		{
+			name:    "custom without customCNI",
+			cni:     Custom,
+			wantErr: "customCNI.manifest is required for cni custom, EKS Anywhere installs it when the cluster is created",
+		},
+		{
+			name:      "custom with empty manifest",
+			cni:       Custom,
+			customCNI: &CustomCNIConfig{},
+			wantErr:   "customCNI.manifest is required for cni custom, EKS Anywhere installs it when the cluster is created",
+		},
+		{
+			name:      "customCNI with cilium",
+			cni:       Cilium,
+			customCNI: &CustomCNIConfig{Manifest: "calico.yaml"},
+			wantErr:   "customCNI is only supported for cni custom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{
+				ClusterNetwork: ClusterNetwork{
+					Pods:      Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+					Services:  Services{CidrBlocks: []string{"10.96.0.0/12"}},
+					CNI:       tt.cni,
+					CustomCNI: tt.customCNI,
+				},
+			}}
+			err := validateNetworking(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
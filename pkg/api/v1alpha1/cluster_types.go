@@ -27,6 +27,50 @@ const (
 
 	// defaultEksaNamespace is the default namespace for EKS-A resources when not specified.
 	defaultEksaNamespace = "default"
+
+	// controlPlaneEndpointMigrationAnnotation can be applied to the EKS-A cluster object to
+	// allow changing the control plane endpoint host of an existing cluster
+	controlPlaneEndpointMigrationAnnotation = "anywhere.eks.amazonaws.com/control-plane-endpoint-migration"
+
+	// maintenanceAnnotation is applied to the EKS-A cluster object when the cluster is explicitly paused
+	// for maintenance, to tell it apart from the temporary pauses done during cluster operations
+	maintenanceAnnotation = "anywhere.eks.amazonaws.com/maintenance"
+
+	// featureGatesAnnotation holds a comma separated list of name=true|false feature gates
+	// that enable experimental features for the CLI operations run with the cluster config
+	featureGatesAnnotation = "anywhere.eks.amazonaws.com/feature-gates"
+
+	// forceUnsupportedChangesAnnotation holds a comma separated list of the fields the upgrade path
+	// doesn't support changing, whose changes the user accepts to roll out anyway at their own risk
+	forceUnsupportedChangesAnnotation = "anywhere.eks.amazonaws.com/force-unsupported-changes"
+
+	// eksaComponentsOverrideAnnotation holds the URI of an EKS-A components manifest installed instead of the
+	// one in the bundle, to run patched controllers and CRDs during development or support cases
+	eksaComponentsOverrideAnnotation = "anywhere.eks.amazonaws.com/eksa-components-override"
+
+	// eksaControllerImageOverrideAnnotation holds an EKS-A controller image run instead of the one in the bundle
+	eksaControllerImageOverrideAnnotation = "anywhere.eks.amazonaws.com/eksa-controller-image-override"
+)
+
+// Fields that are immutable because the upgrade path doesn't support changing them, but can still be
+// changed by listing them in the force unsupported changes annotation
+const (
+	ClusterNetworkField       = "spec.clusterNetwork"
+	ProxyConfigurationField   = "spec.proxyConfiguration"
+	ExternalEtcdCountField    = "spec.externalEtcdConfiguration.count"
+	IdentityProviderRefsField = "spec.identityProviderRefs"
+)
+
+var forceableFields = []string{ClusterNetworkField, ProxyConfigurationField, ExternalEtcdCountField, IdentityProviderRefsField}
+
+const (
+	// DeletionProtectionFinalizer is added by the controller to the clusters with deletion protection
+	// enabled, so the cluster object isn't removed until the protection is disabled
+	DeletionProtectionFinalizer = "clusters.anywhere.eks.amazonaws.com/deletion-protection"
+
+	// DeletionBlockedCondition is set in the Cluster status when the cluster is being deleted
+	// but its deletion protection is enabled
+	DeletionBlockedCondition = "DeletionBlocked"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -48,6 +92,51 @@ type ClusterSpec struct {
 	RegistryMirrorConfiguration *RegistryMirrorConfiguration `json:"registryMirrorConfiguration,omitempty"`
 	ManagementCluster           ManagementCluster            `json:"managementCluster,omitempty"`
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
+	// ResourceTags are added to every infrastructure resource created for the cluster, on top of the standard
+	// EKS Anywhere tags. Keys can't use the anywhere.eks.amazonaws.com/ prefix, which is reserved for those.
+	ResourceTags map[string]string `json:"resourceTags,omitempty"`
+	// ServiceLoadBalancer installs kube-vip in services mode so the cluster can serve LoadBalancer services
+	// with addresses from the configured pools.
+	ServiceLoadBalancer *ServiceLoadBalancerConfiguration `json:"serviceLoadBalancer,omitempty"`
+	// LocalPathStorage installs local-path-provisioner as the default StorageClass, for providers
+	// without a CSI driver. Volumes are directories in the node where the pod runs.
+	LocalPathStorage *LocalPathStorageConfiguration `json:"localPathStorage,omitempty"`
+	// FIPSEnabled runs the cluster nodes with the FIPS 140-2 validated builds of the components from the bundle
+	// and FIPS approved TLS settings. The machine templates must be FIPS enabled.
+	FIPSEnabled bool `json:"fipsEnabled,omitempty"`
+	// TLSPolicy sets the minimum TLS version and the cipher suites accepted by the API server, the kubelet,
+	// etcd and the EKS Anywhere controller webhooks.
+	TLSPolicy *TLSPolicy `json:"tlsPolicy,omitempty"`
+	// PodSecurity sets the Pod Security Standards levels the API server applies to the namespaces
+	// without their own pod-security.kubernetes.io labels.
+	PodSecurity *PodSecurityConfiguration `json:"podSecurity,omitempty"`
+	// HostEntries are static entries added to /etc/hosts in the cluster nodes and the bootstrap cluster,
+	// for networks without a DNS server that resolves names like the registry mirror, vCenter or git server.
+	HostEntries []HostEntry `json:"hostEntries,omitempty"`
+	// MaintenanceWindows are the periods when the controller can roll out changes that replace machines,
+	// like upgrades. Outside of them those changes are deferred. Changes are rolled out anytime when empty.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Notifications sets where the start and end of the CLI operations and the health changes
+	// detected by the controller are sent.
+	Notifications *NotificationsConfiguration `json:"notifications,omitempty"`
+	// DeletionProtection makes the CLI and the controller refuse to delete the cluster until
+	// it's set back to false, to avoid destroying a cluster by accident.
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+	// ReadinessGates are extra checks create waits for before reporting the cluster as created,
+	// like an ingress answering or an organization agent running in all the nodes.
+	ReadinessGates []ReadinessGate `json:"readinessGates,omitempty"`
+	// CoreAddons enables core components installed with the cluster from the images in the bundle
+	// and upgraded with it, for basic observability without a separate packaging step.
+	CoreAddons *CoreAddonsConfiguration `json:"coreAddons,omitempty"`
+	// CoreDNS customizes the cluster DNS: the domains resolved by their own servers, the upstream resolvers,
+	// the number of CoreDNS replicas and how long the answers are cached.
+	CoreDNS *CoreDNSConfiguration `json:"coreDNS,omitempty"`
+	// Admission sets the admission plugins of the API server from a profile, along with the API server flags
+	// the profile needs, instead of setting them with extra args.
+	Admission *AdmissionConfiguration `json:"admission,omitempty"`
+	// ResourceNaming customizes the names of the resources generated for the cluster, like the MachineDeployments
+	// the worker machine names start with.
+	ResourceNaming *ResourceNamingConfiguration `json:"resourceNaming,omitempty"`
 }
 
 func (n *Cluster) Equal(o *Cluster) bool {
@@ -90,6 +179,51 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.ManagementClusterEqual(o) {
 		return false
 	}
+	if !MapEqual(n.Spec.ResourceTags, o.Spec.ResourceTags) {
+		return false
+	}
+	if !n.Spec.ServiceLoadBalancer.Equal(o.Spec.ServiceLoadBalancer) {
+		return false
+	}
+	if !n.Spec.LocalPathStorage.Equal(o.Spec.LocalPathStorage) {
+		return false
+	}
+	if n.Spec.FIPSEnabled != o.Spec.FIPSEnabled {
+		return false
+	}
+	if !n.Spec.TLSPolicy.Equal(o.Spec.TLSPolicy) {
+		return false
+	}
+	if !n.Spec.PodSecurity.Equal(o.Spec.PodSecurity) {
+		return false
+	}
+	if !HostEntriesSliceEqual(n.Spec.HostEntries, o.Spec.HostEntries) {
+		return false
+	}
+	if !MaintenanceWindowsSliceEqual(n.Spec.MaintenanceWindows, o.Spec.MaintenanceWindows) {
+		return false
+	}
+	if !n.Spec.Notifications.Equal(o.Spec.Notifications) {
+		return false
+	}
+	if n.Spec.DeletionProtection != o.Spec.DeletionProtection {
+		return false
+	}
+	if !ReadinessGatesSliceEqual(n.Spec.ReadinessGates, o.Spec.ReadinessGates) {
+		return false
+	}
+	if !n.Spec.CoreAddons.Equal(o.Spec.CoreAddons) {
+		return false
+	}
+	if !n.Spec.CoreDNS.Equal(o.Spec.CoreDNS) {
+		return false
+	}
+	if !n.Spec.Admission.Equal(o.Spec.Admission) {
+		return false
+	}
+	if !n.Spec.ResourceNaming.Equal(o.Spec.ResourceNaming) {
+		return false
+	}
 	return true
 }
 
@@ -131,6 +265,68 @@ func (n *RegistryMirrorConfiguration) Equal(o *RegistryMirrorConfiguration) bool
 	return n.Endpoint == o.Endpoint && n.Port == o.Port && n.CACertContent == o.CACertContent
 }
 
+// HostEntry maps an IP to the hostnames that should resolve to it, like a line in /etc/hosts
+type HostEntry struct {
+	IP        string   `json:"ip"`
+	Hostnames []string `json:"hostnames"`
+}
+
+func (n *HostEntry) Equal(o *HostEntry) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.IP == o.IP && SliceEqual(n.Hostnames, o.Hostnames)
+}
+
+func HostEntriesSliceEqual(a, b []HostEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MaintenanceWindow is a recurring period when disruptive changes can be rolled out to the cluster
+type MaintenanceWindow struct {
+	// Days of the week the window starts on, like Saturday or Sat. Every day when empty.
+	Days []string `json:"days,omitempty"`
+	// Start is the time of the day the window starts at, in HH:MM format.
+	Start string `json:"start"`
+	// Duration of the window, like 4h or 90m.
+	Duration string `json:"duration"`
+	// TimeZone is the IANA name of the time zone for Start, like Europe/Madrid. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+func (n *MaintenanceWindow) Equal(o *MaintenanceWindow) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Start == o.Start && n.Duration == o.Duration && n.TimeZone == o.TimeZone && SliceEqual(n.Days, o.Days)
+}
+
+func MaintenanceWindowsSliceEqual(a, b []MaintenanceWindow) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 type ControlPlaneConfiguration struct {
 	// Count defines the number of desired control plane nodes. Defaults to 1.
 	Count int `json:"count,omitempty"`
@@ -195,12 +391,19 @@ type WorkerNodeGroupConfiguration struct {
 	MachineGroupRef *Ref `json:"machineGroupRef,omitempty"`
 	// Labels define the labels to assign to the node
 	Labels map[string]string `json:"labels,omitempty"`
+	// KubernetesVersion overrides the cluster kubernetes version for this worker node group.
+	// It can't be newer than the control plane version nor more than 2 minor versions older.
+	// Defaults to the cluster kubernetes version.
+	KubernetesVersion *KubernetesVersion `json:"kubernetesVersion,omitempty"`
 }
 
 func generateWorkerNodeGroupKey(c WorkerNodeGroupConfiguration) (key string) {
 	if c.MachineGroupRef != nil {
 		key = c.MachineGroupRef.Kind + c.MachineGroupRef.Name
 	}
+	if c.KubernetesVersion != nil {
+		key += string(*c.KubernetesVersion)
+	}
 	return strconv.Itoa(c.Count) + key
 }
 
@@ -232,7 +435,19 @@ type ClusterNetwork struct {
 	Services Services `json:"services,omitempty"`
 	// CNI specifies the CNI plugin to be installed in the cluster
 	CNI CNI `json:"cni,omitempty"`
-	DNS DNS `json:"dns,omitempty"`
+	// CustomCNI is the CNI installed by the user, required when CNI is custom
+	CustomCNI *CustomCNIConfig `json:"customCNI,omitempty"`
+	DNS       DNS              `json:"dns,omitempty"`
+	// KubeProxy sets how kube-proxy routes the service traffic, or disables it when Cilium replaces it.
+	// It can't be changed after the cluster is created.
+	KubeProxy *KubeProxyConfiguration `json:"kubeProxy,omitempty"`
+}
+
+// CustomCNIConfig is a CNI installed and upgraded by the user
+type CustomCNIConfig struct {
+	// Manifest is the path or URL of the manifest that installs the CNI. It's applied when the cluster is created,
+	// after that the CNI is upgraded by the user, so it can be changed
+	Manifest string `json:"manifest"`
 }
 
 func (n *ClusterNetwork) Equal(o *ClusterNetwork) bool {
@@ -244,7 +459,63 @@ func (n *ClusterNetwork) Equal(o *ClusterNetwork) bool {
 	}
 	return SliceEqual(n.Pods.CidrBlocks, o.Pods.CidrBlocks) &&
 		SliceEqual(n.Services.CidrBlocks, o.Services.CidrBlocks) &&
-		n.CNI == o.CNI && n.DNS.ResolvConf.Equal(o.DNS.ResolvConf)
+		n.CNI == o.CNI && n.DNS.ResolvConf.Equal(o.DNS.ResolvConf) &&
+		n.KubeProxy.Equal(o.KubeProxy)
+}
+
+// KubeProxyMode is how kube-proxy implements the services in the nodes
+type KubeProxyMode string
+
+const (
+	KubeProxyModeIPTables KubeProxyMode = "iptables"
+	KubeProxyModeIPVS     KubeProxyMode = "ipvs"
+	// KubeProxyModeDisabled doesn't deploy kube-proxy, the CNI replaces it
+	KubeProxyModeDisabled KubeProxyMode = "disabled"
+)
+
+type KubeProxyConfiguration struct {
+	// Mode is iptables, ipvs or disabled. disabled requires Cilium, which is configured to replace kube-proxy.
+	// Defaults to iptables.
+	Mode KubeProxyMode `json:"mode,omitempty"`
+	// IPVS configures the ipvs mode.
+	IPVS *KubeProxyIPVSConfiguration `json:"ipvs,omitempty"`
+}
+
+// ModeOrDefault returns the kube-proxy mode, iptables if it's not set
+func (n *KubeProxyConfiguration) ModeOrDefault() KubeProxyMode {
+	if n == nil || n.Mode == "" {
+		return KubeProxyModeIPTables
+	}
+	return n.Mode
+}
+
+func (n *KubeProxyConfiguration) Equal(o *KubeProxyConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Mode == o.Mode && n.IPVS.Equal(o.IPVS)
+}
+
+type KubeProxyIPVSConfiguration struct {
+	// Scheduler is the IPVS scheduler that balances the connections between the service endpoints,
+	// like rr or lc. Defaults to rr.
+	Scheduler string `json:"scheduler,omitempty"`
+	// StrictARP stops the nodes from answering ARP requests for the service IPs bound to the kube-ipvs0
+	// interface. Layer 2 load balancers, like the service load balancer, require it.
+	StrictARP bool `json:"strictARP,omitempty"`
+}
+
+func (n *KubeProxyIPVSConfiguration) Equal(o *KubeProxyIPVSConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return *n == *o
 }
 
 func SliceEqual(a, b []string) bool {
@@ -268,6 +539,19 @@ func SliceEqual(a, b []string) bool {
 	return len(m) == 0
 }
 
+func MapEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
 func RefSliceEqual(a, b []Ref) bool {
 	if len(a) != len(b) {
 		return false
@@ -332,11 +616,14 @@ const (
 	Cilium           CNI = "cilium"
 	CiliumEnterprise CNI = "cilium-enterprise"
 	Kindnetd         CNI = "kindnetd"
+	// Custom is a CNI installed and upgraded by the user, EKS Anywhere doesn't manage it
+	Custom CNI = "custom"
 )
 
 var validCNIs = map[CNI]struct{}{
 	Cilium:   {},
 	Kindnetd: {},
+	Custom:   {},
 }
 
 // ClusterStatus defines the observed state of Cluster
@@ -344,6 +631,43 @@ type ClusterStatus struct {
 	// Descriptive message about a fatal problem while reconciling a cluster
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
+	// Conditions of the cluster reconciliation, like PendingChanges
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Etcd is the health of the external etcd members, recorded by the etcd maintenance
+	// +optional
+	Etcd *EtcdStatus `json:"etcd,omitempty"`
+}
+
+// EtcdStatus is the health of the external etcd members when they were last checked
+type EtcdStatus struct {
+	// LastCheckTime is when the etcd members were last checked
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+	// LastDefragTime is when a member was last defragmented
+	// +optional
+	LastDefragTime *metav1.Time `json:"lastDefragTime,omitempty"`
+	// +optional
+	Members []EtcdMemberStatus `json:"members,omitempty"`
+}
+
+// EtcdMemberStatus is the health and database size of an etcd member
+type EtcdMemberStatus struct {
+	Endpoint string `json:"endpoint"`
+	Healthy  bool   `json:"healthy"`
+	// +optional
+	Leader bool `json:"leader,omitempty"`
+	// DBSize is the size of the database file in bytes
+	// +optional
+	DBSize int64 `json:"dbSize,omitempty"`
+	// DBSizeInUse is the size of the database in use in bytes, the rest is reclaimed by a defragmentation
+	// +optional
+	DBSizeInUse int64 `json:"dbSizeInUse,omitempty"`
+	// Alarms raised by the member, like NOSPACE
+	// +optional
+	Alarms []string `json:"alarms,omitempty"`
+	// Message explains why the member is not healthy
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type Ref struct {
@@ -414,7 +738,396 @@ func (n *PodIAMConfig) Equal(o *PodIAMConfig) bool {
 	return n.ServiceAccountIssuer == o.ServiceAccountIssuer
 }
 
+// ServiceLoadBalancerConfiguration defines the addresses assigned to LoadBalancer services
+// and how they are advertised
+type ServiceLoadBalancerConfiguration struct {
+	// AddressPools are the addresses assigned to LoadBalancer services. The pool without namespace is used
+	// by all the namespaces that don't have their own pool.
+	AddressPools []ServiceLoadBalancerAddressPool `json:"addressPools,omitempty"`
+	// BGP advertises the service addresses to BGP peers. When not set, the addresses are advertised
+	// with ARP in the nodes network.
+	BGP *ServiceLoadBalancerBGP `json:"bgp,omitempty"`
+}
+
+type ServiceLoadBalancerAddressPool struct {
+	// Namespace restricts the pool to the LoadBalancer services in that namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Addresses are CIDRs like 10.0.0.0/28 or ranges like 10.0.0.10-10.0.0.20
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+type ServiceLoadBalancerBGP struct {
+	// LocalASN is the AS number of the nodes
+	LocalASN int64 `json:"localAsn,omitempty"`
+	// Peers are the routers the nodes establish BGP sessions with
+	Peers []ServiceLoadBalancerBGPPeer `json:"peers,omitempty"`
+}
+
+type ServiceLoadBalancerBGPPeer struct {
+	Address string `json:"address"`
+	ASN     int64  `json:"asn"`
+}
+
+func (n *ServiceLoadBalancerConfiguration) Equal(o *ServiceLoadBalancerConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if len(n.AddressPools) != len(o.AddressPools) {
+		return false
+	}
+	for i := range n.AddressPools {
+		if n.AddressPools[i].Namespace != o.AddressPools[i].Namespace || !SliceEqual(n.AddressPools[i].Addresses, o.AddressPools[i].Addresses) {
+			return false
+		}
+	}
+	return n.BGP.Equal(o.BGP)
+}
+
+func (n *ServiceLoadBalancerBGP) Equal(o *ServiceLoadBalancerBGP) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if n.LocalASN != o.LocalASN || len(n.Peers) != len(o.Peers) {
+		return false
+	}
+	for i := range n.Peers {
+		if n.Peers[i] != o.Peers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultLocalPathStoragePath is the node directory where the local path volumes are created by default
+const DefaultLocalPathStoragePath = "/opt/local-path-provisioner"
+
+type LocalPathStorageConfiguration struct {
+	// Path is the node directory where the volumes are created. Defaults to /opt/local-path-provisioner.
+	Path string `json:"path,omitempty"`
+}
+
+// PathOrDefault returns the configured path or the default one if empty
+func (n *LocalPathStorageConfiguration) PathOrDefault() string {
+	if n.Path == "" {
+		return DefaultLocalPathStoragePath
+	}
+	return n.Path
+}
+
+func (n *LocalPathStorageConfiguration) Equal(o *LocalPathStorageConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Path == o.Path
+}
+
+type CoreAddonsConfiguration struct {
+	// MetricsServer installs metrics-server, which serves the resource metrics used by kubectl top
+	// and the horizontal pod autoscaler.
+	MetricsServer bool `json:"metricsServer,omitempty"`
+	// NodeProblemDetector installs node-problem-detector, which reports node problems like kernel
+	// deadlocks or a read-only file system as node conditions and events.
+	NodeProblemDetector bool `json:"nodeProblemDetector,omitempty"`
+}
+
+func (n *CoreAddonsConfiguration) Equal(o *CoreAddonsConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return *n == *o
+}
+
+type CoreDNSConfiguration struct {
+	// StubDomains are domains resolved by their own DNS servers instead of the upstream resolvers.
+	StubDomains []CoreDNSStubDomain `json:"stubDomains,omitempty"`
+	// UpstreamResolvers are the DNS servers queried for the names outside the cluster,
+	// like 10.0.0.2 or 10.0.0.2:5353. Defaults to the resolvers in the node /etc/resolv.conf.
+	UpstreamResolvers []string `json:"upstreamResolvers,omitempty"`
+	// Replicas is the number of CoreDNS pods. Defaults to 2.
+	Replicas int `json:"replicas,omitempty"`
+	// CacheTTL is the maximum time in seconds the answers are cached for. Defaults to 30.
+	CacheTTL int `json:"cacheTTL,omitempty"`
+}
+
+func (n *CoreDNSConfiguration) Equal(o *CoreDNSConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if len(n.StubDomains) != len(o.StubDomains) {
+		return false
+	}
+	for i := range n.StubDomains {
+		if !n.StubDomains[i].Equal(&o.StubDomains[i]) {
+			return false
+		}
+	}
+	return SliceEqual(n.UpstreamResolvers, o.UpstreamResolvers) && n.Replicas == o.Replicas && n.CacheTTL == o.CacheTTL
+}
+
+// CoreDNSStubDomain is a domain CoreDNS forwards to its own DNS servers
+type CoreDNSStubDomain struct {
+	// Domain resolved by the servers, like corp.example.com.
+	Domain string `json:"domain"`
+	// Servers are the DNS servers for the domain, like 10.0.0.2 or 10.0.0.2:5353.
+	Servers []string `json:"servers"`
+}
+
+func (n *CoreDNSStubDomain) Equal(o *CoreDNSStubDomain) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Domain == o.Domain && SliceEqual(n.Servers, o.Servers)
+}
+
+// AdmissionProfile is a set of admission plugins and API server flags applied together
+type AdmissionProfile string
+
+const (
+	// AdmissionProfileBaseline adds the plugins that close known escalation paths to the Kubernetes defaults
+	AdmissionProfileBaseline AdmissionProfile = "baseline"
+	// AdmissionProfileRestricted adds AlwaysPullImages and the CIS Kubernetes benchmark flags that need no extra files
+	// in the nodes, service-account-lookup and terminated-pod-gc-threshold, on top of baseline. It doesn't cover the
+	// whole benchmark
+	AdmissionProfileRestricted AdmissionProfile = "restricted"
+	// AdmissionProfileCustom enables and disables the plugins in the lists
+	AdmissionProfileCustom AdmissionProfile = "custom"
+)
+
+type AdmissionConfiguration struct {
+	// Profile is the set of admission plugins applied. Supported values: baseline, restricted, custom.
+	Profile AdmissionProfile `json:"profile"`
+	// EnablePlugins are the admission plugins enabled on top of the Kubernetes defaults. Only with the custom profile.
+	EnablePlugins []string `json:"enablePlugins,omitempty"`
+	// DisablePlugins are the admission plugins enabled by default that are disabled. Only with the custom profile.
+	DisablePlugins []string `json:"disablePlugins,omitempty"`
+}
+
+func (n *AdmissionConfiguration) Equal(o *AdmissionConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Profile == o.Profile && SliceEqual(n.EnablePlugins, o.EnablePlugins) && SliceEqual(n.DisablePlugins, o.DisablePlugins)
+}
+
+type ResourceNamingConfiguration struct {
+	// MachineDeployment is the Go template of the MachineDeployment names of the worker node groups,
+	// with the fields .ClusterName and .NodeGroupName. Defaults to {{.ClusterName}}-{{.NodeGroupName}}.
+	MachineDeployment string `json:"machineDeployment,omitempty"`
+	// VM is the Go template of the start of the VM names of each node group, with the fields .ClusterName and
+	// .NodeGroupName, which is control-plane and etcd for those VMs. The VM names continue with a timestamp and
+	// a random suffix. Only supported for vSphere.
+	VM string `json:"vm,omitempty"`
+}
+
+func (n *ResourceNamingConfiguration) Equal(o *ResourceNamingConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.MachineDeployment == o.MachineDeployment && n.VM == o.VM
+}
+
+// TLSVersion is a TLS version with the name used by the Kubernetes components flags
+type TLSVersion string
+
+const (
+	TLSVersion12 TLSVersion = "VersionTLS12"
+	TLSVersion13 TLSVersion = "VersionTLS13"
+)
+
+type TLSPolicy struct {
+	// MinVersion is the minimum TLS version accepted. Supported values: VersionTLS12, VersionTLS13.
+	MinVersion TLSVersion `json:"minVersion,omitempty"`
+	// CipherSuites are the TLS 1.2 cipher suites accepted, with their IANA names.
+	// TLS 1.3 cipher suites are not configurable.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+func (n *TLSPolicy) Equal(o *TLSPolicy) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.MinVersion == o.MinVersion && SliceEqual(n.CipherSuites, o.CipherSuites)
+}
+
+// PodSecurityLevel is a Pod Security Standards level
+type PodSecurityLevel string
+
+const (
+	PodSecurityPrivileged PodSecurityLevel = "privileged"
+	PodSecurityBaseline   PodSecurityLevel = "baseline"
+	PodSecurityRestricted PodSecurityLevel = "restricted"
+)
+
+type PodSecurityConfiguration struct {
+	// Enforce is the level of the pods allowed. Defaults to privileged.
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+	// Audit is the level over which pods are recorded in the audit log. Defaults to privileged.
+	Audit PodSecurityLevel `json:"audit,omitempty"`
+	// Warn is the level over which users get a warning when creating pods. Defaults to privileged.
+	Warn PodSecurityLevel `json:"warn,omitempty"`
+	// Exemptions are the requests the pod security admission doesn't evaluate.
+	Exemptions PodSecurityExemptions `json:"exemptions,omitempty"`
+}
+
+type PodSecurityExemptions struct {
+	// Usernames are the authenticated users exempted.
+	Usernames []string `json:"usernames,omitempty"`
+	// RuntimeClasses are the runtime class names exempted.
+	RuntimeClasses []string `json:"runtimeClasses,omitempty"`
+	// Namespaces are the namespaces exempted, on top of the ones of the components installed by EKS Anywhere.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+func (n *PodSecurityConfiguration) Equal(o *PodSecurityConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Enforce == o.Enforce && n.Audit == o.Audit && n.Warn == o.Warn &&
+		SliceEqual(n.Exemptions.Usernames, o.Exemptions.Usernames) &&
+		SliceEqual(n.Exemptions.RuntimeClasses, o.Exemptions.RuntimeClasses) &&
+		SliceEqual(n.Exemptions.Namespaces, o.Exemptions.Namespaces)
+}
+
+type NotificationsConfiguration struct {
+	// Slack posts the events to a channel through an incoming webhook.
+	Slack *SlackNotification `json:"slack,omitempty"`
+	// Webhooks receive each event as a json POST request.
+	Webhooks []WebhookNotification `json:"webhooks,omitempty"`
+	// SNS publishes the events to an AWS SNS topic.
+	SNS *SNSNotification `json:"sns,omitempty"`
+}
+
+type SlackNotification struct {
+	// WebhookURL is the URL of the Slack incoming webhook.
+	WebhookURL string `json:"webhookURL"`
+}
+
+type WebhookNotification struct {
+	// URL the events are sent to.
+	URL string `json:"url"`
+}
+
+type SNSNotification struct {
+	// TopicARN is the ARN of the topic, its region is used to reach SNS.
+	TopicARN string `json:"topicARN"`
+}
+
+func (n *NotificationsConfiguration) Equal(o *NotificationsConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if (n.Slack == nil) != (o.Slack == nil) || (n.Slack != nil && *n.Slack != *o.Slack) {
+		return false
+	}
+	if (n.SNS == nil) != (o.SNS == nil) || (n.SNS != nil && *n.SNS != *o.SNS) {
+		return false
+	}
+	if len(n.Webhooks) != len(o.Webhooks) {
+		return false
+	}
+	for i := range n.Webhooks {
+		if n.Webhooks[i] != o.Webhooks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadinessGate is a check the cluster has to pass to be considered created. Exactly one of HTTP, DaemonSet
+// and Job has to be set.
+type ReadinessGate struct {
+	// Name identifies the gate in the logs and errors.
+	Name string `json:"name"`
+	// HTTP waits for a GET request to the URL, sent from the admin machine, to return the expected status.
+	HTTP *HTTPReadinessGate `json:"http,omitempty"`
+	// DaemonSet waits for the pods of a DaemonSet in the cluster to be ready in all its nodes.
+	DaemonSet *ObjectReadinessGate `json:"daemonSet,omitempty"`
+	// Job waits for a Job in the cluster to complete.
+	Job *ObjectReadinessGate `json:"job,omitempty"`
+	// Timeout for the gate to pass, like 5m. Defaults to 10m.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type HTTPReadinessGate struct {
+	// URL the request is sent to.
+	URL string `json:"url"`
+	// ExpectedStatus is the status code the URL has to return. Defaults to 200.
+	ExpectedStatus int `json:"expectedStatus,omitempty"`
+}
+
+type ObjectReadinessGate struct {
+	// Name of the object.
+	Name string `json:"name"`
+	// Namespace of the object. Defaults to default.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (n *ReadinessGate) Equal(o *ReadinessGate) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if (n.HTTP == nil) != (o.HTTP == nil) || (n.HTTP != nil && *n.HTTP != *o.HTTP) {
+		return false
+	}
+	if (n.DaemonSet == nil) != (o.DaemonSet == nil) || (n.DaemonSet != nil && *n.DaemonSet != *o.DaemonSet) {
+		return false
+	}
+	if (n.Job == nil) != (o.Job == nil) || (n.Job != nil && *n.Job != *o.Job) {
+		return false
+	}
+	return n.Name == o.Name && n.Timeout == o.Timeout
+}
+
+func ReadinessGatesSliceEqual(a, b []ReadinessGate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // Cluster is the Schema for the clusters API
 type Cluster struct {
 	metav1.TypeMeta   `json:",inline"`
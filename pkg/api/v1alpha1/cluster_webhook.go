@@ -67,6 +67,12 @@ func (r *Cluster) ValidateUpdate(old runtime.Object) error {
 
 	var allErrs field.ErrorList
 
+	if err := validateForcedUnsupportedChanges(r); err != nil {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("metadata", "annotations"), r.Annotations[forceUnsupportedChangesAnnotation], err.Error()))
+	}
+
 	allErrs = append(allErrs, validateImmutableFieldsCluster(r, oldCluster)...)
 
 	if len(allErrs) == 0 {
@@ -89,7 +95,7 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Invalid(field.NewPath("spec", "managementCluster"), new.Spec.ManagementCluster, "field is immutable"))
 	}
 
-	if !new.Spec.ControlPlaneConfiguration.Endpoint.Equal(old.Spec.ControlPlaneConfiguration.Endpoint) {
+	if !new.Spec.ControlPlaneConfiguration.Endpoint.Equal(old.Spec.ControlPlaneConfiguration.Endpoint) && !new.IsControlPlaneEndpointMigrationAllowed() {
 		allErrs = append(
 			allErrs,
 			field.Invalid(field.NewPath("spec", "ControlPlaneConfiguration.endpoint"), new.Spec.ControlPlaneConfiguration.Endpoint, "field is immutable"))
@@ -102,15 +108,15 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 	}
 
 	if !new.Spec.ClusterNetwork.Equal(&old.Spec.ClusterNetwork) {
-		allErrs = append(
-			allErrs,
-			field.Invalid(field.NewPath("spec", "ClusterNetwork"), new.Spec.ClusterNetwork, "field is immutable"))
+		if err := unsupportedChange(new, ClusterNetworkField, field.NewPath("spec", "ClusterNetwork"), new.Spec.ClusterNetwork); err != nil {
+			allErrs = append(allErrs, err)
+		}
 	}
 
 	if !new.Spec.ProxyConfiguration.Equal(old.Spec.ProxyConfiguration) {
-		allErrs = append(
-			allErrs,
-			field.Invalid(field.NewPath("spec", "ProxyConfiguration"), new.Spec.ProxyConfiguration, "field is immutable"))
+		if err := unsupportedChange(new, ProxyConfigurationField, field.NewPath("spec", "ProxyConfiguration"), new.Spec.ProxyConfiguration); err != nil {
+			allErrs = append(allErrs, err)
+		}
 	}
 
 	if new.Spec.ExternalEtcdConfiguration != nil && old.Spec.ExternalEtcdConfiguration == nil {
@@ -121,10 +127,9 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 	}
 	if new.Spec.ExternalEtcdConfiguration != nil && old.Spec.ExternalEtcdConfiguration != nil {
 		if old.Spec.ExternalEtcdConfiguration.Count != new.Spec.ExternalEtcdConfiguration.Count {
-			allErrs = append(
-				allErrs,
-				field.Invalid(field.NewPath("spec.externalEtcdConfiguration.count"), new.Spec.ExternalEtcdConfiguration.Count, "field is immutable"),
-			)
+			if err := unsupportedChange(new, ExternalEtcdCountField, field.NewPath("spec.externalEtcdConfiguration.count"), new.Spec.ExternalEtcdConfiguration.Count); err != nil {
+				allErrs = append(allErrs, err)
+			}
 		}
 	}
 
@@ -134,10 +139,22 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Invalid(field.NewPath("spec", "GitOpsRef"), new.Spec.GitOpsRef, "field is immutable"))
 	}
 
-	if !RefSliceEqual(new.Spec.IdentityProviderRefs, old.Spec.IdentityProviderRefs) {
+	if !new.Spec.ResourceNaming.Equal(old.Spec.ResourceNaming) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "resourceNaming"), new.Spec.ResourceNaming, "field is immutable"))
+	}
+
+	if new.Spec.FIPSEnabled != old.Spec.FIPSEnabled {
 		allErrs = append(
 			allErrs,
-			field.Invalid(field.NewPath("spec", "IdentityProviderRefs"), new.Spec.IdentityProviderRefs, "field is immutable"))
+			field.Invalid(field.NewPath("spec", "fipsEnabled"), new.Spec.FIPSEnabled, "field is immutable"))
+	}
+
+	if !RefSliceEqual(new.Spec.IdentityProviderRefs, old.Spec.IdentityProviderRefs) {
+		if err := unsupportedChange(new, IdentityProviderRefsField, field.NewPath("spec", "IdentityProviderRefs"), new.Spec.IdentityProviderRefs); err != nil {
+			allErrs = append(allErrs, err)
+		}
 	}
 
 	if !old.IsSelfManaged() {
@@ -169,6 +186,17 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 	return allErrs
 }
 
+// unsupportedChange returns the error for a change the upgrade path doesn't support, unless the change
+// is forced with the annotation. Forced changes are logged to keep a record of the accepted risk
+func unsupportedChange(new *Cluster, fieldName string, path *field.Path, value interface{}) *field.Error {
+	if new.IsUnsupportedChangeForced(fieldName) {
+		clusterlog.Info("Accepting unsupported change forced by annotation", "name", new.Name, "field", fieldName, "annotation", forceUnsupportedChangesAnnotation)
+		return nil
+	}
+
+	return field.Invalid(path, value, fmt.Sprintf("field is immutable, add it to the %s annotation to force the change", forceUnsupportedChangesAnnotation))
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Cluster) ValidateDelete() error {
 	clusterlog.Info("validate delete", "name", r.Name)
@@ -187,6 +187,24 @@ func TestClusterValidateUpdateControlPlaneConfigurationOldEndpointImmutable(t *t
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
+func TestClusterValidateUpdateControlPlaneConfigurationEndpointMigrationAllowed(t *testing.T) {
+	cOld := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint: &v1alpha1.Endpoint{Host: "1.1.1.1/1"},
+			},
+		},
+	}
+	c := cOld.DeepCopy()
+	c.AllowControlPlaneEndpointMigration()
+	c.Spec.ControlPlaneConfiguration = v1alpha1.ControlPlaneConfiguration{
+		Endpoint: &v1alpha1.Endpoint{Host: "1.1.1.1/2"},
+	}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
 func TestClusterValidateUpdateControlPlaneConfigurationOldEndpointNilImmutable(t *testing.T) {
 	cOld := &v1alpha1.Cluster{
 		Spec: v1alpha1.ClusterSpec{
@@ -543,6 +561,31 @@ func TestClusterValidateUpdateClusterNetworkNewEmptyImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
+func TestClusterValidateUpdateProxyConfigurationForced(t *testing.T) {
+	cOld := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ProxyConfiguration: &v1alpha1.ProxyConfiguration{HttpProxy: "1.1.1.1:3128"},
+		},
+	}
+	c := cOld.DeepCopy()
+	c.Spec.ProxyConfiguration.HttpProxy = "2.2.2.2:3128"
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("anywhere.eks.amazonaws.com/force-unsupported-changes")))
+
+	c.ForceUnsupportedChange(v1alpha1.ProxyConfigurationField)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
+func TestClusterValidateUpdateForceNotForceableField(t *testing.T) {
+	cOld := &v1alpha1.Cluster{}
+	c := cOld.DeepCopy()
+	c.ForceUnsupportedChange("spec.managementCluster")
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("changes to spec.managementCluster can't be forced")))
+}
+
 func TestClusterValidateUpdateProxyConfigurationEqualOrder(t *testing.T) {
 	cOld := &v1alpha1.Cluster{
 		Spec: v1alpha1.ClusterSpec{
@@ -728,6 +771,38 @@ func TestClusterValidateUpdateGitOpsRefNewNilImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
+func TestClusterValidateUpdateFIPSEnabledImmutable(t *testing.T) {
+	cOld := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{FIPSEnabled: false},
+	}
+	c := cOld.DeepCopy()
+	c.Spec.FIPSEnabled = true
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
+}
+
+func TestClusterValidateUpdateResourceNamingImmutable(t *testing.T) {
+	cOld := &v1alpha1.Cluster{}
+	c := cOld.DeepCopy()
+	c.Spec.ResourceNaming = &v1alpha1.ResourceNamingConfiguration{MachineDeployment: "{{.ClusterName}}-workers-{{.NodeGroupName}}"}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
+}
+
+func TestClusterValidateUpdateDeletionProtectionMutable(t *testing.T) {
+	cOld := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{DeletionProtection: true},
+	}
+	c := cOld.DeepCopy()
+	c.Spec.DeletionProtection = false
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+	g.Expect(c.Equal(cOld)).To(BeFalse())
+}
+
 func TestClusterValidateUpdateIdentityProviderRefsImmutableEqualOrder(t *testing.T) {
 	cOld := &v1alpha1.Cluster{
 		Spec: v1alpha1.ClusterSpec{
@@ -0,0 +1,322 @@
+package v1alpha1
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/substitution"
+)
+
+// datacenterMachineConfigKinds maps each datacenter kind to the machine config kind
+// its machine group refs must use. Datacenters without machine configs are not included
+var datacenterMachineConfigKinds = map[string]string{
+	VSphereDatacenterKind:    VSphereMachineConfigKind,
+	TinkerbellDatacenterKind: TinkerbellMachineConfigKind,
+}
+
+// ClusterConfigDocuments holds all the EKS-A objects defined in a multi document cluster config
+type ClusterConfigDocuments struct {
+	Cluster                  *Cluster
+	VSphereDatacenters       map[string]*VSphereDatacenterConfig
+	DockerDatacenters        map[string]*DockerDatacenterConfig
+	TinkerbellDatacenters    map[string]*TinkerbellDatacenterConfig
+	AWSDatacenters           map[string]*AWSDatacenterConfig
+	VSphereMachineConfigs    map[string]*VSphereMachineConfig
+	TinkerbellMachineConfigs map[string]*TinkerbellMachineConfig
+	OIDCConfigs              map[string]*OIDCConfig
+	AWSIamConfigs            map[string]*AWSIamConfig
+	GitOpsConfigs            map[string]*GitOpsConfig
+
+	refs map[Ref]bool
+}
+
+type parseConfig struct {
+	strict bool
+}
+
+type ParseOpt func(*parseConfig)
+
+// WithStrictParsing makes parsing fail for documents with fields unknown to the EKS-A API
+func WithStrictParsing() ParseOpt {
+	return func(c *parseConfig) {
+		c.strict = true
+	}
+}
+
+// ParseClusterConfigFile parses all the EKS-A objects from a multi document yaml file in disk
+func ParseClusterConfigFile(fileName string, opts ...ParseOpt) (*ClusterConfigDocuments, error) {
+	content, err := readClusterConfigFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseClusterConfigDocuments(content, opts...)
+}
+
+// StdinFileName is the cluster config file name that reads the config from the standard input, like in -f -
+const StdinFileName = "-"
+
+// stdinConfig keeps the cluster config read from the standard input, which can only be read once
+type stdinConfig struct {
+	reader  io.Reader
+	once    sync.Once
+	content []byte
+	err     error
+}
+
+var stdin = &stdinConfig{reader: os.Stdin}
+
+func (s *stdinConfig) read() ([]byte, error) {
+	s.once.Do(func() {
+		s.content, s.err = ioutil.ReadAll(s.reader)
+	})
+	return s.content, s.err
+}
+
+// ReadClusterConfigFile reads a cluster config file from disk as is, or from the standard input for StdinFileName.
+// The standard input is read the first time and the same content is returned for the next reads
+func ReadClusterConfigFile(fileName string) ([]byte, error) {
+	if fileName == StdinFileName {
+		return stdin.read()
+	}
+	return ioutil.ReadFile(fileName)
+}
+
+// readClusterConfigFile reads a cluster config file from disk or the standard input and substitutes
+// the environment variables in it. Secret references are checked but kept, they are resolved in the provider manifests
+func readClusterConfigFile(fileName string) ([]byte, error) {
+	content, err := ReadClusterConfigFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file due to: %v", err)
+	}
+
+	content, err = substitution.Substitute(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to substitute values in %s: %v", fileName, err)
+	}
+
+	return content, nil
+}
+
+// ParseClusterConfigDocuments parses all the EKS-A objects from a multi document yaml. Documents can be in any order
+// and documents from other api groups are ignored. It doesn't stop at the first invalid document,
+// the returned error aggregates the problems found in all of them
+func ParseClusterConfigDocuments(content []byte, opts ...ParseOpt) (*ClusterConfigDocuments, error) {
+	config := &parseConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	docs, err := splitYamlDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ClusterConfigDocuments{
+		VSphereDatacenters:       map[string]*VSphereDatacenterConfig{},
+		DockerDatacenters:        map[string]*DockerDatacenterConfig{},
+		TinkerbellDatacenters:    map[string]*TinkerbellDatacenterConfig{},
+		AWSDatacenters:           map[string]*AWSDatacenterConfig{},
+		VSphereMachineConfigs:    map[string]*VSphereMachineConfig{},
+		TinkerbellMachineConfigs: map[string]*TinkerbellMachineConfig{},
+		OIDCConfigs:              map[string]*OIDCConfig{},
+		AWSIamConfigs:            map[string]*AWSIamConfig{},
+		GitOpsConfigs:            map[string]*GitOpsConfig{},
+		refs:                     map[Ref]bool{},
+	}
+
+	var errs []error
+	for i, doc := range docs {
+		if err := d.add(i, doc, config.strict); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if d.Cluster == nil && len(errs) == 0 {
+		errs = append(errs, fmt.Errorf("cluster config does not contain kind %s", ClusterKind))
+	}
+
+	if len(errs) > 0 {
+		return nil, kerrors.NewAggregate(errs)
+	}
+
+	return d, nil
+}
+
+func (d *ClusterConfigDocuments) add(index int, doc []byte, strict bool) error {
+	meta := &struct {
+		APIVersion string     `json:"apiVersion"`
+		Kind       string     `json:"kind"`
+		Metadata   ObjectMeta `json:"metadata"`
+	}{}
+	if err := yaml.Unmarshal(doc, meta); err != nil {
+		return fmt.Errorf("document %d: unable to parse yaml: %v", index, err)
+	}
+
+	if !strings.HasPrefix(meta.APIVersion, GroupVersion.Group+"/") {
+		return nil
+	}
+	if meta.APIVersion != GroupVersion.String() {
+		return fmt.Errorf("document %d: %s %s has unsupported apiVersion %s", index, meta.Kind, meta.Metadata.Name, meta.APIVersion)
+	}
+
+	ref := Ref{Kind: meta.Kind, Name: meta.Metadata.Name}
+	if d.refs[ref] || (meta.Kind == ClusterKind && d.Cluster != nil) {
+		return fmt.Errorf("document %d: %s %s is defined more than once", index, meta.Kind, meta.Metadata.Name)
+	}
+
+	obj := newDocumentObject(meta.Kind)
+	if obj == nil {
+		return fmt.Errorf("document %d: unknown kind %s", index, meta.Kind)
+	}
+
+	unmarshal := yaml.Unmarshal
+	if strict {
+		unmarshal = yaml.UnmarshalStrict
+	}
+	if err := unmarshal(doc, obj); err != nil {
+		return fmt.Errorf("document %d: unable to parse %s %s: %v", index, meta.Kind, meta.Metadata.Name, err)
+	}
+
+	d.refs[ref] = true
+	switch o := obj.(type) {
+	case *Cluster:
+		d.Cluster = o
+	case *VSphereDatacenterConfig:
+		d.VSphereDatacenters[o.Name] = o
+	case *DockerDatacenterConfig:
+		d.DockerDatacenters[o.Name] = o
+	case *TinkerbellDatacenterConfig:
+		d.TinkerbellDatacenters[o.Name] = o
+	case *AWSDatacenterConfig:
+		d.AWSDatacenters[o.Name] = o
+	case *VSphereMachineConfig:
+		d.VSphereMachineConfigs[o.Name] = o
+	case *TinkerbellMachineConfig:
+		d.TinkerbellMachineConfigs[o.Name] = o
+	case *OIDCConfig:
+		d.OIDCConfigs[o.Name] = o
+	case *AWSIamConfig:
+		d.AWSIamConfigs[o.Name] = o
+	case *GitOpsConfig:
+		d.GitOpsConfigs[o.Name] = o
+	}
+
+	return nil
+}
+
+func newDocumentObject(kind string) interface{} {
+	switch kind {
+	case ClusterKind:
+		return &Cluster{}
+	case VSphereDatacenterKind:
+		return &VSphereDatacenterConfig{}
+	case DockerDatacenterKind:
+		return &DockerDatacenterConfig{}
+	case TinkerbellDatacenterKind:
+		return &TinkerbellDatacenterConfig{}
+	case AWSDatacenterKind:
+		return &AWSDatacenterConfig{}
+	case VSphereMachineConfigKind:
+		return &VSphereMachineConfig{}
+	case TinkerbellMachineConfigKind:
+		return &TinkerbellMachineConfig{}
+	case OIDCConfigKind:
+		return &OIDCConfig{}
+	case AWSIamConfigKind:
+		return &AWSIamConfig{}
+	case GitOpsConfigKind:
+		return &GitOpsConfig{}
+	default:
+		return nil
+	}
+}
+
+// ValidateReferences checks that every object referenced by the Cluster is defined in the documents
+// and that the machine configs match the datacenter provider. The returned error aggregates all the problems found
+func (d *ClusterConfigDocuments) ValidateReferences() error {
+	c := d.Cluster
+	var errs []error
+
+	datacenterRef := c.Spec.DatacenterRef
+	if !d.refs[datacenterRef] {
+		errs = append(errs, fmt.Errorf("datacenterRef %s %s is not defined", datacenterRef.Kind, datacenterRef.Name))
+	}
+
+	machineConfigKind, hasMachineConfigs := datacenterMachineConfigKinds[datacenterRef.Kind]
+	for _, r := range d.machineGroupRefs() {
+		switch {
+		case !hasMachineConfigs:
+			errs = append(errs, fmt.Errorf("%s: machineGroupRef is not supported with datacenter kind %s", r.field, datacenterRef.Kind))
+		case r.ref.Kind != machineConfigKind:
+			errs = append(errs, fmt.Errorf("%s: machineGroupRef kind %s does not match datacenter kind %s, must be %s", r.field, r.ref.Kind, datacenterRef.Kind, machineConfigKind))
+		case !d.refs[*r.ref]:
+			errs = append(errs, fmt.Errorf("%s: machineGroupRef %s %s is not defined", r.field, r.ref.Kind, r.ref.Name))
+		}
+	}
+
+	for i, ref := range c.Spec.IdentityProviderRefs {
+		if !d.refs[ref] {
+			errs = append(errs, fmt.Errorf("identityProviderRefs[%d]: %s %s is not defined", i, ref.Kind, ref.Name))
+		}
+	}
+
+	if c.Spec.GitOpsRef != nil && !d.refs[*c.Spec.GitOpsRef] {
+		errs = append(errs, fmt.Errorf("gitOpsRef %s %s is not defined", c.Spec.GitOpsRef.Kind, c.Spec.GitOpsRef.Name))
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+type fieldRef struct {
+	field string
+	ref   *Ref
+}
+
+func (d *ClusterConfigDocuments) machineGroupRefs() []fieldRef {
+	var refs []fieldRef
+	c := d.Cluster
+	if c.Spec.ControlPlaneConfiguration.MachineGroupRef != nil {
+		refs = append(refs, fieldRef{field: "controlPlaneConfiguration", ref: c.Spec.ControlPlaneConfiguration.MachineGroupRef})
+	}
+	for i, w := range c.Spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef != nil {
+			refs = append(refs, fieldRef{field: fmt.Sprintf("workerNodeGroupConfigurations[%d]", i), ref: w.MachineGroupRef})
+		}
+	}
+	if c.Spec.ExternalEtcdConfiguration != nil && c.Spec.ExternalEtcdConfiguration.MachineGroupRef != nil {
+		refs = append(refs, fieldRef{field: "externalEtcdConfiguration", ref: c.Spec.ExternalEtcdConfiguration.MachineGroupRef})
+	}
+
+	return refs
+}
+
+// splitYamlDocuments splits a multi document yaml, accepting separators with trailing spaces
+// or windows line endings and a separator at the beginning of the content
+func splitYamlDocuments(content []byte) ([][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to split yaml documents: %v", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+}
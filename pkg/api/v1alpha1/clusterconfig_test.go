@@ -0,0 +1,203 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseClusterConfigFileUnordered(t *testing.T) {
+	g := NewWithT(t)
+	docs, err := ParseClusterConfigFile("testdata/clusterconfig_unordered.yaml", WithStrictParsing())
+	g.Expect(err).To(BeNil())
+	g.Expect(docs.Cluster.Name).To(Equal("eksa-unit-test"))
+	g.Expect(docs.VSphereDatacenters).To(HaveKey("eksa-unit-test"))
+	g.Expect(docs.VSphereMachineConfigs).To(HaveKey("eksa-unit-test"))
+	g.Expect(docs.ValidateReferences()).To(Succeed())
+}
+
+func TestParseClusterConfigFileAggregatesErrors(t *testing.T) {
+	g := NewWithT(t)
+	_, err := ParseClusterConfigFile("testdata/clusterconfig_duplicated.yaml", WithStrictParsing())
+	g.Expect(err).To(MatchError(And(
+		ContainSubstring("document 2: DockerDatacenterConfig eksa-unit-test is defined more than once"),
+		ContainSubstring("document 3: Cluster other-cluster is defined more than once"),
+		ContainSubstring(`document 4: unable to parse GitOpsConfig eksa-unit-test`),
+	)))
+}
+
+func TestParseClusterConfigFileUnknownFieldsNotStrict(t *testing.T) {
+	g := NewWithT(t)
+	content := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: eksa-unit-test
+spec:
+  unknownField: true
+`)
+	_, err := ParseClusterConfigDocuments(content)
+	g.Expect(err).To(BeNil())
+
+	_, err = ParseClusterConfigDocuments(content, WithStrictParsing())
+	g.Expect(err).To(MatchError(ContainSubstring("unknown field")))
+}
+
+func TestParseClusterConfigDocumentsErrors(t *testing.T) {
+	tests := []struct {
+		testName string
+		content  string
+		wantErr  string
+	}{
+		{
+			testName: "missing cluster",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: DockerDatacenterConfig
+metadata:
+  name: eksa-unit-test
+`,
+			wantErr: "cluster config does not contain kind Cluster",
+		},
+		{
+			testName: "unknown kind",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: FakeConfig
+metadata:
+  name: eksa-unit-test
+`,
+			wantErr: "document 0: unknown kind FakeConfig",
+		},
+		{
+			testName: "unsupported api version",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1
+kind: Cluster
+metadata:
+  name: eksa-unit-test
+`,
+			wantErr: "document 0: Cluster eksa-unit-test has unsupported apiVersion anywhere.eks.amazonaws.com/v1",
+		},
+		{
+			testName: "invalid yaml",
+			content:  "kind: [",
+			wantErr:  "document 0: unable to parse yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := ParseClusterConfigDocuments([]byte(tt.content))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestClusterConfigDocumentsValidateReferences(t *testing.T) {
+	g := NewWithT(t)
+	docs, err := ParseClusterConfigFile("testdata/clusterconfig_invalid_references.yaml")
+	g.Expect(err).To(BeNil())
+
+	err = docs.ValidateReferences()
+	g.Expect(err).To(MatchError(And(
+		ContainSubstring("datacenterRef VSphereDatacenterConfig eksa-unit-test is not defined"),
+		ContainSubstring("controlPlaneConfiguration: machineGroupRef VSphereMachineConfig eksa-unit-test-cp is not defined"),
+		ContainSubstring("workerNodeGroupConfigurations[0]: machineGroupRef kind TinkerbellMachineConfig does not match datacenter kind VSphereDatacenterConfig, must be VSphereMachineConfig"),
+		ContainSubstring("identityProviderRefs[0]: OIDCConfig eksa-unit-test is not defined"),
+		ContainSubstring("gitOpsRef GitOpsConfig eksa-unit-test is not defined"),
+	)))
+}
+
+func TestClusterConfigDocumentsValidateReferencesMachineGroupRefNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	content := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: eksa-unit-test
+spec:
+  controlPlaneConfiguration:
+    machineGroupRef:
+      name: eksa-unit-test
+      kind: VSphereMachineConfig
+  datacenterRef:
+    kind: DockerDatacenterConfig
+    name: eksa-unit-test
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: DockerDatacenterConfig
+metadata:
+  name: eksa-unit-test
+`)
+	docs, err := ParseClusterConfigDocuments(content)
+	g.Expect(err).To(BeNil())
+	g.Expect(docs.ValidateReferences()).To(MatchError("controlPlaneConfiguration: machineGroupRef is not supported with datacenter kind DockerDatacenterConfig"))
+}
+
+func TestSplitYamlDocuments(t *testing.T) {
+	g := NewWithT(t)
+	docs, err := splitYamlDocuments([]byte("---\na: 1\n--- \r\nb: 2\n---\n\n---\nc: 3"))
+	g.Expect(err).To(BeNil())
+	g.Expect(docs).To(HaveLen(3))
+}
+
+func TestParseClusterConfigFileSubstitutesEnvVars(t *testing.T) {
+	g := NewWithT(t)
+	setEnv(t, "EKSA_TEST_CLUSTER_NAME", "substituted")
+
+	docs, err := ParseClusterConfigFile("testdata/cluster_substitution.yaml", WithStrictParsing())
+	g.Expect(err).To(BeNil())
+	g.Expect(docs.Cluster.Name).To(Equal("substituted"))
+	g.Expect(docs.Cluster.Spec.ControlPlaneConfiguration.Count).To(Equal(3))
+	g.Expect(docs.DockerDatacenters).To(HaveKey("substituted"))
+	g.Expect(docs.ValidateReferences()).To(Succeed())
+}
+
+func TestParseClusterConfigFileSubstitutionMissingEnvVar(t *testing.T) {
+	g := NewWithT(t)
+	unsetEnv(t, "EKSA_TEST_CLUSTER_NAME")
+
+	_, err := ParseClusterConfigFile("testdata/cluster_substitution.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("environment variable EKSA_TEST_CLUSTER_NAME is not set")))
+}
+
+func setEnv(t *testing.T, key, value string) {
+	restoreEnv(t, key)
+	os.Setenv(key, value)
+}
+
+func unsetEnv(t *testing.T, key string) {
+	restoreEnv(t, key)
+	os.Unsetenv(key)
+}
+
+func restoreEnv(t *testing.T, key string) {
+	value, ok := os.LookupEnv(key)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, value)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestReadClusterConfigFileStdin(t *testing.T) {
+	g := NewWithT(t)
+	content, err := os.ReadFile("testdata/cluster_1_18.yaml")
+	g.Expect(err).To(BeNil())
+	original := stdin
+	stdin = &stdinConfig{reader: bytes.NewReader(content)}
+	t.Cleanup(func() { stdin = original })
+
+	got, err := ReadClusterConfigFile(StdinFileName)
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(Equal(content))
+
+	want, err := ConfigFile("testdata/cluster_1_18.yaml").Cluster()
+	g.Expect(err).To(BeNil())
+	source := ConfigFile(StdinFileName)
+	g.Expect(source.String()).To(Equal("standard input"))
+	cluster, err := source.Cluster()
+	g.Expect(err).To(BeNil(), "the standard input is read again from memory")
+	g.Expect(cluster).To(Equal(want))
+}
@@ -0,0 +1,110 @@
+package v1alpha1
+
+import "fmt"
+
+// ConfigSource reads the EKS-A objects of a cluster config, either from a file or from memory
+// +kubebuilder:object:generate=false
+type ConfigSource interface {
+	fmt.Stringer
+	Cluster() (*Cluster, error)
+	OIDCConfig(name string, cluster *Cluster) (*OIDCConfig, error)
+	AWSIamConfig(name string, cluster *Cluster) (*AWSIamConfig, error)
+	GitOpsConfig(name string, cluster *Cluster) (*GitOpsConfig, error)
+	VSphereDatacenterConfig() (*VSphereDatacenterConfig, error)
+	VSphereMachineConfigs() (map[string]*VSphereMachineConfig, error)
+	TinkerbellDatacenterConfig() (*TinkerbellDatacenterConfig, error)
+	TinkerbellMachineConfigs() (map[string]*TinkerbellMachineConfig, error)
+	DockerDatacenterConfig() (*DockerDatacenterConfig, error)
+}
+
+// ConfigFile is a cluster config file in disk, or the standard input for StdinFileName
+// +kubebuilder:object:generate=false
+type ConfigFile string
+
+func (f ConfigFile) String() string {
+	if f == StdinFileName {
+		return "standard input"
+	}
+	return "file " + string(f)
+}
+
+func (f ConfigFile) Cluster() (*Cluster, error) {
+	return GetClusterConfig(string(f))
+}
+
+func (f ConfigFile) OIDCConfig(name string, cluster *Cluster) (*OIDCConfig, error) {
+	return GetAndValidateOIDCConfig(string(f), name, cluster)
+}
+
+func (f ConfigFile) AWSIamConfig(name string, cluster *Cluster) (*AWSIamConfig, error) {
+	return GetAndValidateAWSIamConfig(string(f), name, cluster)
+}
+
+func (f ConfigFile) GitOpsConfig(name string, cluster *Cluster) (*GitOpsConfig, error) {
+	return GetAndValidateGitOpsConfig(string(f), name, cluster)
+}
+
+func (f ConfigFile) VSphereDatacenterConfig() (*VSphereDatacenterConfig, error) {
+	return GetVSphereDatacenterConfig(string(f))
+}
+
+func (f ConfigFile) VSphereMachineConfigs() (map[string]*VSphereMachineConfig, error) {
+	return GetVSphereMachineConfigs(string(f))
+}
+
+func (f ConfigFile) TinkerbellDatacenterConfig() (*TinkerbellDatacenterConfig, error) {
+	return GetTinkerbellDatacenterConfig(string(f))
+}
+
+func (f ConfigFile) TinkerbellMachineConfigs() (map[string]*TinkerbellMachineConfig, error) {
+	return GetTinkerbellMachineConfigs(string(f))
+}
+
+func (f ConfigFile) DockerDatacenterConfig() (*DockerDatacenterConfig, error) {
+	return GetDockerDatacenterConfig(string(f))
+}
+
+// ConfigContent is a cluster config in memory. It's used as is, environment variables
+// and secret references in it are not substituted
+// +kubebuilder:object:generate=false
+type ConfigContent []byte
+
+func (c ConfigContent) String() string {
+	return "cluster config content"
+}
+
+func (c ConfigContent) Cluster() (*Cluster, error) {
+	return GetClusterConfigFromContent(c)
+}
+
+func (c ConfigContent) OIDCConfig(name string, cluster *Cluster) (*OIDCConfig, error) {
+	return GetAndValidateOIDCConfigFromContent(c, name, cluster)
+}
+
+func (c ConfigContent) AWSIamConfig(name string, cluster *Cluster) (*AWSIamConfig, error) {
+	return GetAndValidateAWSIamConfigFromContent(c, name, cluster)
+}
+
+func (c ConfigContent) GitOpsConfig(name string, cluster *Cluster) (*GitOpsConfig, error) {
+	return GetAndValidateGitOpsConfigFromContent(c, name, cluster)
+}
+
+func (c ConfigContent) VSphereDatacenterConfig() (*VSphereDatacenterConfig, error) {
+	return GetVSphereDatacenterConfigFromContent(c)
+}
+
+func (c ConfigContent) VSphereMachineConfigs() (map[string]*VSphereMachineConfig, error) {
+	return GetVSphereMachineConfigsFromContent(c)
+}
+
+func (c ConfigContent) TinkerbellDatacenterConfig() (*TinkerbellDatacenterConfig, error) {
+	return GetTinkerbellDatacenterConfigFromContent(c)
+}
+
+func (c ConfigContent) TinkerbellMachineConfigs() (map[string]*TinkerbellMachineConfig, error) {
+	return GetTinkerbellMachineConfigsFromContent(c)
+}
+
+func (c ConfigContent) DockerDatacenterConfig() (*DockerDatacenterConfig, error) {
+	return GetDockerDatacenterConfigFromContent(c)
+}
@@ -0,0 +1,16 @@
+package v1alpha1
+
+// v1alpha1 is the storage version for the kinds served in more than one version,
+// the rest of the versions convert to and from it
+
+// Hub marks this type as a conversion hub.
+func (*Cluster) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*VSphereDatacenterConfig) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*VSphereMachineConfig) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*DockerDatacenterConfig) Hub() {}
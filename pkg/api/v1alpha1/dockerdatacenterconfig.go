@@ -40,3 +40,13 @@ func GetDockerDatacenterConfig(fileName string) (*DockerDatacenterConfig, error)
 	}
 	return &clusterConfig, nil
 }
+
+// GetDockerDatacenterConfigFromContent parses the DockerDatacenterConfig from a multiobject yaml in memory
+func GetDockerDatacenterConfigFromContent(content []byte) (*DockerDatacenterConfig, error) {
+	var clusterConfig DockerDatacenterConfig
+	err := ParseClusterConfigFromContent(content, &clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &clusterConfig, nil
+}
@@ -18,6 +18,7 @@ type DockerDatacenterConfigStatus struct { // Important: Run "make generate" to
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // DockerDatacenterConfig is the Schema for the DockerDatacenterConfigs API
 type DockerDatacenterConfig struct {
@@ -9,7 +9,16 @@ import (
 const GitOpsConfigKind = "GitOpsConfig"
 
 func GetAndValidateGitOpsConfig(fileName string, refName string, clusterConfig *Cluster) (*GitOpsConfig, error) {
-	config, err := getGitOpsConfig(fileName)
+	return getAndValidateGitOpsConfig(fileConfigParser(fileName), refName, clusterConfig)
+}
+
+// GetAndValidateGitOpsConfigFromContent is like GetAndValidateGitOpsConfig but reads the GitOpsConfig from a multiobject yaml in memory
+func GetAndValidateGitOpsConfigFromContent(content []byte, refName string, clusterConfig *Cluster) (*GitOpsConfig, error) {
+	return getAndValidateGitOpsConfig(contentConfigParser(content), refName, clusterConfig)
+}
+
+func getAndValidateGitOpsConfig(parse configParser, refName string, clusterConfig *Cluster) (*GitOpsConfig, error) {
+	config, err := getGitOpsConfig(parse)
 	if err != nil {
 		return nil, err
 	}
@@ -20,9 +29,9 @@ func GetAndValidateGitOpsConfig(fileName string, refName string, clusterConfig *
 	return config, nil
 }
 
-func getGitOpsConfig(fileName string) (*GitOpsConfig, error) {
+func getGitOpsConfig(parse configParser) (*GitOpsConfig, error) {
 	var config GitOpsConfig
-	err := ParseClusterConfig(fileName, &config)
+	err := parse(&config)
 	if err != nil {
 		return nil, err
 	}
@@ -13,6 +13,13 @@ type GitOpsConfigSpec struct {
 type Flux struct {
 	// github is the name of the Git Provider to host the Git repo.
 	Github Github `json:"github,omitempty"`
+
+	// DisableNetworkPolicy skips the network policies that restrict the ingress traffic to the flux controllers.
+	DisableNetworkPolicy bool `json:"disableNetworkPolicy,omitempty"`
+
+	// WatchFluxNamespaceOnly makes the flux controllers watch only the resources in the flux system namespace.
+	// By default they watch all namespaces.
+	WatchFluxNamespaceOnly bool `json:"watchFluxNamespaceOnly,omitempty"`
 }
 
 type Github struct {
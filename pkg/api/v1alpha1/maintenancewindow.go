@@ -0,0 +1,134 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// PendingChangesCondition is set in the Cluster status when the controller defers changes
+	// that would replace machines until the next maintenance window
+	PendingChangesCondition = "PendingChanges"
+
+	maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+)
+
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[strings.ToLower(d.String())] = d
+		weekdays[strings.ToLower(d.String()[:3])] = d
+	}
+}
+
+type maintenanceSchedule struct {
+	days     map[time.Weekday]bool
+	hour     int
+	minute   int
+	duration time.Duration
+	location *time.Location
+}
+
+func (w *MaintenanceWindow) schedule() (*maintenanceSchedule, error) {
+	s := &maintenanceSchedule{days: map[time.Weekday]bool{}}
+	for _, d := range w.Days {
+		day, ok := weekdays[strings.ToLower(d)]
+		if !ok {
+			return nil, fmt.Errorf("maintenance window day %s is invalid, please provide a day of the week like Saturday or Sat", d)
+		}
+		s.days[day] = true
+	}
+
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance window start %s is invalid, please provide a time in HH:MM format", w.Start)
+	}
+	s.hour, s.minute = start.Hour(), start.Minute()
+
+	s.duration, err = time.ParseDuration(w.Duration)
+	if err != nil || s.duration <= 0 || s.duration > maxMaintenanceWindowDuration {
+		return nil, fmt.Errorf("maintenance window duration %s is invalid, please provide a duration between 1m and %s", w.Duration, maxMaintenanceWindowDuration)
+	}
+
+	s.location = time.UTC
+	if w.TimeZone != "" {
+		s.location, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window timeZone %s is invalid: %v", w.TimeZone, err)
+		}
+	}
+	return s, nil
+}
+
+// startOn returns when the window starts on the day that is offset days away from now, in the window time zone,
+// and false if the window doesn't start that day of the week
+func (s *maintenanceSchedule) startOn(now time.Time, offset int) (time.Time, bool) {
+	local := now.In(s.location)
+	start := time.Date(local.Year(), local.Month(), local.Day()+offset, s.hour, s.minute, 0, 0, s.location)
+	return start, len(s.days) == 0 || s.days[start.Weekday()]
+}
+
+func (s *maintenanceSchedule) contains(now time.Time) bool {
+	// a window can last up to a week, so it might have started any day of the last one
+	for offset := -7; offset <= 0; offset++ {
+		start, ok := s.startOn(now, offset)
+		if ok && !now.Before(start) && now.Before(start.Add(s.duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *maintenanceSchedule) next(now time.Time) time.Time {
+	for offset := 0; offset <= 7; offset++ {
+		if start, ok := s.startOn(now, offset); ok && start.After(now) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// InMaintenanceWindow returns true if disruptive changes can be rolled out to the cluster at the given time,
+// which is always the case when it doesn't have maintenance windows
+func (c *Cluster) InMaintenanceWindow(now time.Time) bool {
+	if len(c.Spec.MaintenanceWindows) == 0 {
+		return true
+	}
+	for _, w := range c.Spec.MaintenanceWindows {
+		s, err := w.schedule()
+		if err != nil {
+			continue
+		}
+		if s.contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextMaintenanceWindow returns when the next maintenance window after the given time starts.
+// The zero time is returned if the cluster doesn't have valid maintenance windows
+func (c *Cluster) NextMaintenanceWindow(now time.Time) time.Time {
+	var next time.Time
+	for _, w := range c.Spec.MaintenanceWindows {
+		s, err := w.schedule()
+		if err != nil {
+			continue
+		}
+		if start := s.next(now); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}
+
+func validateMaintenanceWindows(clusterConfig *Cluster) error {
+	for _, w := range clusterConfig.Spec.MaintenanceWindows {
+		if _, err := w.schedule(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
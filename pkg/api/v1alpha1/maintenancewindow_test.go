@@ -0,0 +1,119 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateMaintenanceWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []MaintenanceWindow
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid windows",
+			windows: []MaintenanceWindow{
+				{Days: []string{"Saturday", "sun"}, Start: "22:00", Duration: "6h", TimeZone: "Europe/Madrid"},
+				{Start: "03:30", Duration: "90m"},
+			},
+		},
+		{
+			name:    "invalid day",
+			windows: []MaintenanceWindow{{Days: []string{"Someday"}, Start: "22:00", Duration: "6h"}},
+			wantErr: "maintenance window day Someday is invalid, please provide a day of the week like Saturday or Sat",
+		},
+		{
+			name:    "invalid start",
+			windows: []MaintenanceWindow{{Start: "25:00", Duration: "6h"}},
+			wantErr: "maintenance window start 25:00 is invalid, please provide a time in HH:MM format",
+		},
+		{
+			name:    "invalid duration",
+			windows: []MaintenanceWindow{{Start: "22:00", Duration: "six hours"}},
+			wantErr: "maintenance window duration six hours is invalid, please provide a duration between 1m and 168h0m0s",
+		},
+		{
+			name:    "duration longer than a week",
+			windows: []MaintenanceWindow{{Start: "22:00", Duration: "169h"}},
+			wantErr: "maintenance window duration 169h is invalid, please provide a duration between 1m and 168h0m0s",
+		},
+		{
+			name:    "invalid time zone",
+			windows: []MaintenanceWindow{{Start: "22:00", Duration: "6h", TimeZone: "Mars/Olympus"}},
+			wantErr: "maintenance window timeZone Mars/Olympus is invalid: unknown time zone Mars/Olympus",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{MaintenanceWindows: tt.windows}}
+			err := validateMaintenanceWindows(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestClusterInMaintenanceWindow(t *testing.T) {
+	// Friday
+	now := time.Date(2022, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		windows  []MaintenanceWindow
+		inWindow bool
+		next     time.Time
+	}{
+		{
+			name:     "no windows",
+			inWindow: true,
+		},
+		{
+			name:     "every day window in progress",
+			windows:  []MaintenanceWindow{{Start: "11:00", Duration: "2h"}},
+			inWindow: true,
+			next:     time.Date(2022, time.March, 5, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "every day window later today",
+			windows: []MaintenanceWindow{{Start: "22:00", Duration: "2h"}},
+			next:    time.Date(2022, time.March, 4, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekend window started the day before",
+			windows:  []MaintenanceWindow{{Days: []string{"Thu"}, Start: "22:00", Duration: "16h"}},
+			inWindow: true,
+			next:     time.Date(2022, time.March, 10, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "earliest of several windows",
+			windows: []MaintenanceWindow{
+				{Days: []string{"Sunday"}, Start: "01:00", Duration: "4h"},
+				{Days: []string{"Saturday"}, Start: "01:00", Duration: "4h"},
+			},
+			next: time.Date(2022, time.March, 5, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "window in another time zone",
+			windows: []MaintenanceWindow{{Days: []string{"Friday"}, Start: "22:00", Duration: "1h", TimeZone: "Asia/Tokyo"}},
+			next:    time.Date(2022, time.March, 4, 13, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{MaintenanceWindows: tt.windows}}
+			g.Expect(cluster.InMaintenanceWindow(now)).To(Equal(tt.inWindow))
+			g.Expect(cluster.NextMaintenanceWindow(now).Equal(tt.next)).To(BeTrue(), "next window %s, want %s", cluster.NextMaintenanceWindow(now), tt.next)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+const (
+	// maxMachineDeploymentNameLength keeps the worker machine names within the 63 characters of a hostname,
+	// the VMs take their name and hostname from the machines. CAPI appends a MachineSet hash of up to
+	// 10 characters and a random suffix of 5 characters to the MachineDeployment name, each after a dash
+	maxMachineDeploymentNameLength = 63 - 17
+	// maxVMNamePrefixLength keeps the VM names within the 63 characters of a hostname. The machine template
+	// names end with a dash and a timestamp in milliseconds of 13 digits, and CAPI appends a dash and a random
+	// suffix of 5 characters to them to name the machines and VMs
+	maxVMNamePrefixLength = 63 - 14 - 6
+	// truncatedNameHashLength is the length of the hash of the full name that ends a truncated name
+	truncatedNameHashLength = 8
+
+	defaultMachineDeploymentNameTemplate = "{{.ClusterName}}-{{.NodeGroupName}}"
+	// vmNameControlPlane and vmNameEtcd are the node group names of the control plane and etcd VMs in the vm template
+	vmNameControlPlane = "control-plane"
+	vmNameEtcd         = "etcd"
+)
+
+var resourceNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// resourceNameValues are the fields of the resourceNaming templates
+type resourceNameValues struct {
+	ClusterName   string
+	NodeGroupName string
+}
+
+// MachineDeploymentName returns the name of the MachineDeployment of a worker node group, from the
+// resourceNaming template or <cluster name>-<node group name>. Names from the template too long for the
+// worker machine hostnames are truncated, see TruncateName. The default names are never truncated, so the
+// MachineDeployments of existing clusters keep their names
+func (c *Cluster) MachineDeploymentName(nodeGroupName string) string {
+	if c.Spec.ResourceNaming == nil || c.Spec.ResourceNaming.MachineDeployment == "" {
+		name, _ := renderResourceName(defaultMachineDeploymentNameTemplate, c.Name, nodeGroupName)
+		return name
+	}
+	name, err := c.machineDeploymentName(nodeGroupName)
+	if err != nil {
+		// the validations reject the templates that don't render, this only keeps the names stable without them
+		name, _ = renderResourceName(defaultMachineDeploymentNameTemplate, c.Name, nodeGroupName)
+	}
+	return TruncateName(name, maxMachineDeploymentNameLength)
+}
+
+func (c *Cluster) machineDeploymentName(nodeGroupName string) (string, error) {
+	nameTemplate := defaultMachineDeploymentNameTemplate
+	if c.Spec.ResourceNaming != nil && c.Spec.ResourceNaming.MachineDeployment != "" {
+		nameTemplate = c.Spec.ResourceNaming.MachineDeployment
+	}
+	return renderResourceName(nameTemplate, c.Name, nodeGroupName)
+}
+
+// VMNamePrefix returns the start of the names of the VMs of a node group from the resourceNaming vm template,
+// truncated so the VM names fit in a hostname. The machine templates of the node group are named after it,
+// and CAPI names the VMs after them. Without a template, it returns defaultPrefix.
+// The control plane and etcd VMs use the node group names control-plane and etcd
+func (n *ResourceNamingConfiguration) VMNamePrefix(clusterName, nodeGroupName, defaultPrefix string) string {
+	if n == nil || n.VM == "" {
+		return defaultPrefix
+	}
+	name, err := renderResourceName(n.VM, clusterName, nodeGroupName)
+	if err != nil {
+		// the validations reject the templates that don't render
+		return defaultPrefix
+	}
+	return TruncateName(name, maxVMNamePrefixLength)
+}
+
+func renderResourceName(nameTemplate, clusterName, nodeGroupName string) (string, error) {
+	t, err := template.New("resourceName").Parse(nameTemplate)
+	if err != nil {
+		return "", err
+	}
+	name := &strings.Builder{}
+	if err = t.Execute(name, resourceNameValues{ClusterName: clusterName, NodeGroupName: nodeGroupName}); err != nil {
+		return "", err
+	}
+	return name.String(), nil
+}
+
+// TruncateName returns name when it has at most maxLength characters. Otherwise it returns the start of name
+// followed by a dash and a hash of the full name, maxLength characters in total, so names that only differ
+// after the cut don't collide
+func TruncateName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:truncatedNameHashLength]
+	return strings.TrimRight(name[:maxLength-truncatedNameHashLength-1], "-") + "-" + hash
+}
+
+func validateResourceNaming(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.ResourceNaming
+	if config == nil {
+		return nil
+	}
+	if config.MachineDeployment != "" {
+		if err := validateMachineDeploymentNaming(clusterConfig); err != nil {
+			return err
+		}
+	}
+	if config.VM != "" {
+		if err := validateVMNaming(clusterConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMachineDeploymentNaming(clusterConfig *Cluster) error {
+	// the MachineDeployment names are set in the templates of the vSphere and Docker providers
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+	if kind != VSphereDatacenterKind && kind != DockerDatacenterKind {
+		return fmt.Errorf("resourceNaming is only supported for %s and %s clusters", VSphereDatacenterKind, DockerDatacenterKind)
+	}
+
+	nodeGroups := map[string]string{}
+	for _, group := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		name, err := clusterConfig.machineDeploymentName(group.Name)
+		if err != nil {
+			return fmt.Errorf("resourceNaming machineDeployment template is invalid: %v", err)
+		}
+		name = TruncateName(name, maxMachineDeploymentNameLength)
+		if !resourceNameRegex.MatchString(name) {
+			return fmt.Errorf("resourceNaming machineDeployment template gives the invalid name %s to worker node group %s, names can only have lowercase letters, numbers and dashes, and start and end with a letter or a number", name, group.Name)
+		}
+		if other, ok := nodeGroups[name]; ok {
+			return fmt.Errorf("resourceNaming machineDeployment template gives the same name %s to worker node groups %s and %s, use {{.NodeGroupName}} in it", name, other, group.Name)
+		}
+		nodeGroups[name] = group.Name
+	}
+	return nil
+}
+
+func validateVMNaming(clusterConfig *Cluster) error {
+	// the machine template names are set by the vSphere provider
+	if clusterConfig.Spec.DatacenterRef.Kind != VSphereDatacenterKind {
+		return fmt.Errorf("resourceNaming vm is only supported for %s clusters", VSphereDatacenterKind)
+	}
+
+	groupNames := []string{vmNameControlPlane}
+	if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
+		groupNames = append(groupNames, vmNameEtcd)
+	}
+	for _, group := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		groupNames = append(groupNames, group.Name)
+	}
+
+	nodeGroups := map[string]string{}
+	for _, group := range groupNames {
+		name, err := renderResourceName(clusterConfig.Spec.ResourceNaming.VM, clusterConfig.Name, group)
+		if err != nil {
+			return fmt.Errorf("resourceNaming vm template is invalid: %v", err)
+		}
+		name = TruncateName(name, maxVMNamePrefixLength)
+		if !resourceNameRegex.MatchString(name) {
+			return fmt.Errorf("resourceNaming vm template gives the invalid name %s to node group %s, names can only have lowercase letters, numbers and dashes, and start and end with a letter or a number", name, group)
+		}
+		if other, ok := nodeGroups[name]; ok {
+			return fmt.Errorf("resourceNaming vm template gives the same name %s to node groups %s and %s, use {{.NodeGroupName}} in it", name, other, group)
+		}
+		nodeGroups[name] = group
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterMachineDeploymentName(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterName string
+		naming      *ResourceNamingConfiguration
+		want        string
+	}{
+		{
+			name:        "default",
+			clusterName: "test-cluster",
+			want:        "test-cluster-md-0",
+		},
+		{
+			name:        "template",
+			clusterName: "test-cluster",
+			naming:      &ResourceNamingConfiguration{MachineDeployment: "{{.NodeGroupName}}-{{.ClusterName}}"},
+			want:        "md-0-test-cluster",
+		},
+		{
+			name:        "long cluster name without template",
+			clusterName: strings.Repeat("a", 50),
+			want:        strings.Repeat("a", 50) + "-md-0",
+		},
+		{
+			name:        "long cluster name with template",
+			clusterName: strings.Repeat("a", 50),
+			naming:      &ResourceNamingConfiguration{MachineDeployment: "{{.ClusterName}}-{{.NodeGroupName}}"},
+			want:        strings.Repeat("a", 37) + "-2bf402d7",
+		},
+		{
+			name:        "template that doesn't render",
+			clusterName: "test-cluster",
+			naming:      &ResourceNamingConfiguration{MachineDeployment: "{{.Namespace}}-{{.NodeGroupName}}"},
+			want:        "test-cluster-md-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{}
+			cluster.Name = tt.clusterName
+			cluster.Spec.ResourceNaming = tt.naming
+			g.Expect(cluster.MachineDeploymentName("md-0")).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestResourceNamingVMNamePrefix(t *testing.T) {
+	g := NewWithT(t)
+	var naming *ResourceNamingConfiguration
+	g.Expect(naming.VMNamePrefix("test-cluster", "md-0", "test-cluster-md-0")).To(Equal("test-cluster-md-0"))
+
+	naming = &ResourceNamingConfiguration{VM: "{{.NodeGroupName}}-{{.ClusterName}}"}
+	g.Expect(naming.VMNamePrefix("test-cluster", "control-plane", "test-cluster-control-plane-template")).To(Equal("control-plane-test-cluster"))
+
+	long := naming.VMNamePrefix(strings.Repeat("a", 50), "md-0", "default")
+	g.Expect(long).To(HaveLen(43))
+	g.Expect(long).To(HavePrefix("md-0-aaa"))
+
+	naming = &ResourceNamingConfiguration{VM: "{{.Namespace}}"}
+	g.Expect(naming.VMNamePrefix("test-cluster", "md-0", "test-cluster-md-0")).To(Equal("test-cluster-md-0"))
+}
+
+func TestTruncateName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(TruncateName("short-name", 20)).To(Equal("short-name"))
+
+	first := TruncateName("a-very-long-name-for-group-1", 20)
+	second := TruncateName("a-very-long-name-for-group-2", 20)
+	g.Expect(first).To(HaveLen(20))
+	g.Expect(first).To(HavePrefix("a-very-long-"))
+	g.Expect(first).NotTo(Equal(second))
+	g.Expect(TruncateName("a-very-long-name-for-group-1", 20)).To(Equal(first))
+}
+
+func TestValidateResourceNaming(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		naming  *ResourceNamingConfiguration
+		wantErr string
+	}{
+		{
+			name: "not configured",
+			kind: AWSDatacenterKind,
+		},
+		{
+			name:   "valid template",
+			kind:   VSphereDatacenterKind,
+			naming: &ResourceNamingConfiguration{MachineDeployment: "{{.ClusterName}}-{{.NodeGroupName}}-workers"},
+		},
+		{
+			name:    "unsupported provider",
+			kind:    AWSDatacenterKind,
+			naming:  &ResourceNamingConfiguration{MachineDeployment: "{{.ClusterName}}-{{.NodeGroupName}}"},
+			wantErr: "resourceNaming is only supported for VSphereDatacenterConfig and DockerDatacenterConfig clusters",
+		},
+		{
+			name:    "template that doesn't parse",
+			kind:    DockerDatacenterKind,
+			naming:  &ResourceNamingConfiguration{MachineDeployment: "{{.ClusterName}-{{.NodeGroupName}}"},
+			wantErr: "resourceNaming machineDeployment template is invalid",
+		},
+		{
+			name:    "unknown field",
+			kind:    VSphereDatacenterKind,
+			naming:  &ResourceNamingConfiguration{MachineDeployment: "{{.Namespace}}-{{.NodeGroupName}}"},
+			wantErr: "resourceNaming machineDeployment template is invalid",
+		},
+		{
+			name:    "invalid name",
+			kind:    VSphereDatacenterKind,
+			naming:  &ResourceNamingConfiguration{MachineDeployment: "{{.ClusterName}}_{{.NodeGroupName}}"},
+			wantErr: "resourceNaming machineDeployment template gives the invalid name test-cluster_md-0 to worker node group md-0",
+		},
+		{
+			name:   "valid vm template",
+			kind:   VSphereDatacenterKind,
+			naming: &ResourceNamingConfiguration{VM: "{{.ClusterName}}-{{.NodeGroupName}}"},
+		},
+		{
+			name:    "vm template unsupported provider",
+			kind:    DockerDatacenterKind,
+			naming:  &ResourceNamingConfiguration{VM: "{{.ClusterName}}-{{.NodeGroupName}}"},
+			wantErr: "resourceNaming vm is only supported for VSphereDatacenterConfig clusters",
+		},
+		{
+			name:    "vm template unknown field",
+			kind:    VSphereDatacenterKind,
+			naming:  &ResourceNamingConfiguration{VM: "{{.Role}}-{{.NodeGroupName}}"},
+			wantErr: "resourceNaming vm template is invalid",
+		},
+		{
+			name:    "vm template invalid name",
+			kind:    VSphereDatacenterKind,
+			naming:  &ResourceNamingConfiguration{VM: "{{.ClusterName}}.{{.NodeGroupName}}"},
+			wantErr: "resourceNaming vm template gives the invalid name test-cluster.control-plane to node group control-plane",
+		},
+		{
+			name:    "vm template same name for control plane and workers",
+			kind:    VSphereDatacenterKind,
+			naming:  &ResourceNamingConfiguration{VM: "{{.ClusterName}}"},
+			wantErr: "resourceNaming vm template gives the same name test-cluster to node groups control-plane and md-0, use {{.NodeGroupName}} in it",
+		},
+		{
+			name:    "same name for every node group",
+			kind:    VSphereDatacenterKind,
+			naming:  &ResourceNamingConfiguration{MachineDeployment: "{{.ClusterName}}-workers"},
+			wantErr: "resourceNaming machineDeployment template gives the same name test-cluster-workers to worker node groups md-0 and md-1, use {{.NodeGroupName}} in it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{
+				DatacenterRef:  Ref{Kind: tt.kind},
+				ResourceNaming: tt.naming,
+				WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+					{Name: "md-0"},
+					{Name: "md-1"},
+				},
+			}}
+			cluster.Name = "test-cluster"
+			err := validateResourceNaming(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
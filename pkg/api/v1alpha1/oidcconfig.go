@@ -8,7 +8,16 @@ import (
 const OIDCConfigKind = "OIDCConfig"
 
 func GetAndValidateOIDCConfig(fileName string, refName string, clusterConfig *Cluster) (*OIDCConfig, error) {
-	config, err := getOIDCConfig(fileName)
+	return getAndValidateOIDCConfig(fileConfigParser(fileName), refName, clusterConfig)
+}
+
+// GetAndValidateOIDCConfigFromContent is like GetAndValidateOIDCConfig but reads the OIDCConfig from a multiobject yaml in memory
+func GetAndValidateOIDCConfigFromContent(content []byte, refName string, clusterConfig *Cluster) (*OIDCConfig, error) {
+	return getAndValidateOIDCConfig(contentConfigParser(content), refName, clusterConfig)
+}
+
+func getAndValidateOIDCConfig(parse configParser, refName string, clusterConfig *Cluster) (*OIDCConfig, error) {
+	config, err := getOIDCConfig(parse)
 	if err != nil {
 		return nil, err
 	}
@@ -19,9 +28,9 @@ func GetAndValidateOIDCConfig(fileName string, refName string, clusterConfig *Cl
 	return config, nil
 }
 
-func getOIDCConfig(fileName string) (*OIDCConfig, error) {
+func getOIDCConfig(parse configParser) (*OIDCConfig, error) {
 	var config OIDCConfig
-	err := ParseClusterConfig(fileName, &config)
+	err := parse(&config)
 	if err != nil {
 		return nil, err
 	}
@@ -40,3 +40,13 @@ func GetTinkerbellDatacenterConfig(fileName string) (*TinkerbellDatacenterConfig
 	}
 	return &clusterConfig, nil
 }
+
+// GetTinkerbellDatacenterConfigFromContent parses the TinkerbellDatacenterConfig from a multiobject yaml in memory
+func GetTinkerbellDatacenterConfigFromContent(content []byte) (*TinkerbellDatacenterConfig, error) {
+	var clusterConfig TinkerbellDatacenterConfig
+	err := ParseClusterConfigFromContent(content, &clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &clusterConfig, nil
+}
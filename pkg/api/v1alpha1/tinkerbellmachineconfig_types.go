@@ -11,8 +11,14 @@ type TinkerbellMachineConfigSpec struct {
 	TemplateOverride string              `json:"templateOverride,omitempty"`
 	OSFamily         OSFamily            `json:"osFamily"`
 	Users            []UserConfiguration `json:"users,omitempty"`
+	// HardwareSelector picks the machines of the hardware inventory with all these labels.
+	// When empty, any machine of the inventory can be used
+	HardwareSelector HardwareSelector `json:"hardwareSelector,omitempty"`
 }
 
+// HardwareSelector matches the labels of the hardware inventory machines
+type HardwareSelector map[string]string
+
 func (c *TinkerbellMachineConfig) PauseReconcile() {
 	c.Annotations[pausedAnnotation] = "true"
 }
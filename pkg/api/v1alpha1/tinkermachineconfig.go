@@ -2,8 +2,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"io/ioutil"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
@@ -153,20 +151,32 @@ func (c *TinkerbellMachineConfigGenerate) Name() string {
 }
 
 func GetTinkerbellMachineConfigs(fileName string) (map[string]*TinkerbellMachineConfig, error) {
+	content, err := readClusterConfigFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return GetTinkerbellMachineConfigsFromContent(content)
+}
+
+// GetTinkerbellMachineConfigsFromContent parses all the TinkerbellMachineConfigs from a multiobject yaml in memory
+func GetTinkerbellMachineConfigsFromContent(content []byte) (map[string]*TinkerbellMachineConfig, error) {
 	configs := make(map[string]*TinkerbellMachineConfig)
-	content, err := ioutil.ReadFile(fileName)
+	docs, err := splitYamlDocuments(content)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read file due to: %v", err)
+		return nil, err
 	}
-	for _, c := range strings.Split(string(content), YamlSeparator) {
+	for _, c := range docs {
 		var config TinkerbellMachineConfig
-		if err = yaml.UnmarshalStrict([]byte(c), &config); err == nil {
+		if err = yaml.UnmarshalStrict(c, &config); err == nil {
 			if config.Kind == TinkerbellMachineConfigKind {
+				if _, ok := configs[config.Name]; ok {
+					return nil, fmt.Errorf("%s %s is defined more than once", TinkerbellMachineConfigKind, config.Name)
+				}
 				configs[config.Name] = &config
 				continue
 			}
 		}
-		_ = yaml.Unmarshal([]byte(c), &config) // this is to check if there is a bad spec in the file
+		_ = yaml.Unmarshal(c, &config) // this is to check if there is a bad spec in the file
 		if config.Kind == TinkerbellMachineConfigKind {
 			return nil, fmt.Errorf("unable to unmarshall content from file due to: %v", err)
 		}
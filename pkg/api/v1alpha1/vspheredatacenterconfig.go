@@ -1,7 +1,9 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"path/filepath"
 	"strings"
 
@@ -12,6 +14,13 @@ import (
 
 const VSphereDatacenterKind = "VSphereDatacenterConfig"
 
+// DefaultVSphereStorageClass is the storage class created when the VSphereDatacenterConfig doesn't have any
+var DefaultVSphereStorageClass = VSphereStorageClass{
+	Name:              "standard",
+	StoragePolicyName: "vSAN Default Storage Policy",
+	Default:           true,
+}
+
 type folderType string
 
 const (
@@ -53,6 +62,16 @@ func GetVSphereDatacenterConfig(fileName string) (*VSphereDatacenterConfig, erro
 	return &clusterConfig, nil
 }
 
+// GetVSphereDatacenterConfigFromContent parses the VSphereDatacenterConfig from a multiobject yaml in memory
+func GetVSphereDatacenterConfigFromContent(content []byte) (*VSphereDatacenterConfig, error) {
+	var clusterConfig VSphereDatacenterConfig
+	err := ParseClusterConfigFromContent(content, &clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &clusterConfig, nil
+}
+
 func generateFullVCenterPath(foldType folderType, folderPath string, datacenter string) string {
 	if folderPath == "" {
 		return folderPath
@@ -77,3 +96,59 @@ func validatePath(foldType folderType, folderPath string, datacenter string) err
 
 	return nil
 }
+
+// StorageClassesOrDefault returns the storage classes of the datacenter or the default storage class if there aren't any
+func (v *VSphereDatacenterConfig) StorageClassesOrDefault() []VSphereStorageClass {
+	if len(v.Spec.StorageClasses) == 0 {
+		return []VSphereStorageClass{DefaultVSphereStorageClass}
+	}
+	return v.Spec.StorageClasses
+}
+
+func validateStorageClasses(storageClasses []VSphereStorageClass) error {
+	names := map[string]struct{}{}
+	defaults := 0
+	for _, sc := range storageClasses {
+		if sc.Name == "" {
+			return errors.New("VSphereDatacenterConfig storage class name is not set or is empty")
+		}
+		if _, ok := names[sc.Name]; ok {
+			return fmt.Errorf("VSphereDatacenterConfig storage class %s is duplicated", sc.Name)
+		}
+		names[sc.Name] = struct{}{}
+		if sc.StoragePolicyName == "" && sc.DatastoreURL == "" {
+			return fmt.Errorf("VSphereDatacenterConfig storage class %s needs a storagePolicyName or a datastoreURL", sc.Name)
+		}
+		if sc.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return errors.New("VSphereDatacenterConfig can only have one default storage class")
+	}
+
+	return nil
+}
+
+func validateCloudProvider(cloudProvider *VSphereCloudProviderConfig) error {
+	if cloudProvider == nil {
+		return nil
+	}
+
+	if n := cloudProvider.Nodes; n != nil {
+		for _, cidr := range []string{n.InternalNetworkSubnetCIDR, n.ExternalNetworkSubnetCIDR} {
+			if cidr == "" {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("VSphereDatacenterConfig cloud provider node subnet %s is invalid: %v", cidr, err)
+			}
+		}
+	}
+
+	if l := cloudProvider.Labels; l != nil && (l.Region == "" || l.Zone == "") {
+		return errors.New("VSphereDatacenterConfig cloud provider labels need both the region and zone tag categories")
+	}
+
+	return nil
+}
@@ -129,3 +129,125 @@ func TestGetVSphereDatacenterConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestVSphereDatacenterConfigValidateFieldsStorageClasses(t *testing.T) {
+	tests := []struct {
+		testName       string
+		storageClasses []VSphereStorageClass
+		wantErr        string
+	}{
+		{
+			testName: "valid",
+			storageClasses: []VSphereStorageClass{
+				{Name: "gold", StoragePolicyName: "gold-policy", Default: true},
+				{Name: "local", DatastoreURL: "ds:///vmfs/volumes/abc/", FsType: "xfs"},
+			},
+		},
+		{
+			testName:       "empty name",
+			storageClasses: []VSphereStorageClass{{StoragePolicyName: "gold-policy"}},
+			wantErr:        "VSphereDatacenterConfig storage class name is not set or is empty",
+		},
+		{
+			testName: "duplicated name",
+			storageClasses: []VSphereStorageClass{
+				{Name: "gold", StoragePolicyName: "gold-policy"},
+				{Name: "gold", StoragePolicyName: "silver-policy"},
+			},
+			wantErr: "VSphereDatacenterConfig storage class gold is duplicated",
+		},
+		{
+			testName:       "no policy or datastore",
+			storageClasses: []VSphereStorageClass{{Name: "gold"}},
+			wantErr:        "VSphereDatacenterConfig storage class gold needs a storagePolicyName or a datastoreURL",
+		},
+		{
+			testName: "two defaults",
+			storageClasses: []VSphereStorageClass{
+				{Name: "gold", StoragePolicyName: "gold-policy", Default: true},
+				{Name: "silver", StoragePolicyName: "silver-policy", Default: true},
+			},
+			wantErr: "VSphereDatacenterConfig can only have one default storage class",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			config := &VSphereDatacenterConfig{
+				Spec: VSphereDatacenterConfigSpec{
+					Datacenter:     "SDDC-Datacenter",
+					Network:        "/SDDC-Datacenter/network/net",
+					Server:         "vcenter",
+					StorageClasses: tt.storageClasses,
+				},
+			}
+			err := config.ValidateFields()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ValidateFields() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("ValidateFields() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVSphereDatacenterConfigStorageClassesOrDefault(t *testing.T) {
+	config := &VSphereDatacenterConfig{}
+	if got := config.StorageClassesOrDefault(); !reflect.DeepEqual(got, []VSphereStorageClass{DefaultVSphereStorageClass}) {
+		t.Fatalf("StorageClassesOrDefault() = %v, want default storage class", got)
+	}
+
+	config.Spec.StorageClasses = []VSphereStorageClass{{Name: "gold", StoragePolicyName: "gold-policy"}}
+	if got := config.StorageClassesOrDefault(); !reflect.DeepEqual(got, config.Spec.StorageClasses) {
+		t.Fatalf("StorageClassesOrDefault() = %v, want %v", got, config.Spec.StorageClasses)
+	}
+}
+
+func TestVSphereDatacenterConfigValidateFieldsCloudProvider(t *testing.T) {
+	tests := []struct {
+		testName      string
+		cloudProvider *VSphereCloudProviderConfig
+		wantErr       string
+	}{
+		{
+			testName: "valid",
+			cloudProvider: &VSphereCloudProviderConfig{
+				Nodes:  &VSphereCloudProviderNodes{InternalNetworkSubnetCIDR: "10.0.0.0/24", ExternalVMNetworkName: "public"},
+				Labels: &VSphereCloudProviderLabels{Region: "k8s-region", Zone: "k8s-zone"},
+			},
+		},
+		{
+			testName: "invalid subnet",
+			cloudProvider: &VSphereCloudProviderConfig{
+				Nodes: &VSphereCloudProviderNodes{ExternalNetworkSubnetCIDR: "10.0.0.0"},
+			},
+			wantErr: "VSphereDatacenterConfig cloud provider node subnet 10.0.0.0 is invalid: invalid CIDR address: 10.0.0.0",
+		},
+		{
+			testName: "missing zone",
+			cloudProvider: &VSphereCloudProviderConfig{
+				Labels: &VSphereCloudProviderLabels{Region: "k8s-region"},
+			},
+			wantErr: "VSphereDatacenterConfig cloud provider labels need both the region and zone tag categories",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			config := &VSphereDatacenterConfig{
+				Spec: VSphereDatacenterConfigSpec{
+					Datacenter:    "SDDC-Datacenter",
+					Network:       "/SDDC-Datacenter/network/net",
+					Server:        "vcenter",
+					CloudProvider: tt.cloudProvider,
+				},
+			}
+			err := config.ValidateFields()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ValidateFields() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("ValidateFields() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
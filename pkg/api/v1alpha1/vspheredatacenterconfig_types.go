@@ -19,6 +19,47 @@ type VSphereDatacenterConfigSpec struct {
 	Server     string `json:"server"`
 	Thumbprint string `json:"thumbprint"`
 	Insecure   bool   `json:"insecure"`
+	// StorageClasses are the vSphere CSI storage classes created in the cluster. A default storage class named standard
+	// backed by the vSAN Default Storage Policy is created when empty
+	StorageClasses []VSphereStorageClass `json:"storageClasses,omitempty"`
+	// CloudProvider configures the vSphere cloud provider (CPI) running in the cluster
+	CloudProvider *VSphereCloudProviderConfig `json:"cloudProvider,omitempty"`
+}
+
+// VSphereCloudProviderConfig is the configuration of the vSphere cloud provider
+type VSphereCloudProviderConfig struct {
+	// Nodes selects the IPs the cloud provider reports for the nodes when the VMs have more than one
+	Nodes *VSphereCloudProviderNodes `json:"nodes,omitempty"`
+	// Labels maps the vSphere tag categories used for the topology labels of the nodes
+	Labels *VSphereCloudProviderLabels `json:"labels,omitempty"`
+}
+
+// VSphereCloudProviderNodes selects the node IPs by subnet or by VM network name
+type VSphereCloudProviderNodes struct {
+	InternalNetworkSubnetCIDR string `json:"internalNetworkSubnetCidr,omitempty"`
+	ExternalNetworkSubnetCIDR string `json:"externalNetworkSubnetCidr,omitempty"`
+	InternalVMNetworkName     string `json:"internalVmNetworkName,omitempty"`
+	ExternalVMNetworkName     string `json:"externalVmNetworkName,omitempty"`
+}
+
+// VSphereCloudProviderLabels are the vSphere tag categories whose tags, attached to the datacenters, clusters or hosts,
+// become the topology.kubernetes.io/region and topology.kubernetes.io/zone labels of the nodes
+type VSphereCloudProviderLabels struct {
+	Region string `json:"region"`
+	Zone   string `json:"zone"`
+}
+
+// VSphereStorageClass is a storage class provisioned by the vSphere CSI driver
+type VSphereStorageClass struct {
+	Name string `json:"name"`
+	// StoragePolicyName is the vSphere storage policy used to place the volumes
+	StoragePolicyName string `json:"storagePolicyName,omitempty"`
+	// DatastoreURL is the URL of the datastore to place the volumes in, ds:///vmfs/volumes/<uuid>/
+	DatastoreURL string `json:"datastoreURL,omitempty"`
+	// FsType is the filesystem the volumes are formatted with, ext4 if not set
+	FsType string `json:"fsType,omitempty"`
+	// Default marks the storage class as the default one of the cluster
+	Default bool `json:"default,omitempty"`
 }
 
 // VSphereDatacenterConfigStatus defines the observed state of VSphereDatacenterConfig
@@ -36,6 +77,7 @@ type VSphereDatacenterConfigStatus struct { // Important: Run "make generate" to
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // VSphereDatacenterConfig is the Schema for the VSphereDatacenterConfigs API
 type VSphereDatacenterConfig struct {
@@ -100,6 +142,14 @@ func (v *VSphereDatacenterConfig) ValidateFields() error {
 		return err
 	}
 
+	if err := validateStorageClasses(v.Spec.StorageClasses); err != nil {
+		return err
+	}
+
+	if err := validateCloudProvider(v.Spec.CloudProvider); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1,16 +1,58 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"net"
+	"path"
+	"regexp"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
 )
 
 const VSphereMachineConfigKind = "VSphereMachineConfig"
 
+const (
+	maxBootstrapCommands           = 50
+	maxBootstrapCustomizationBytes = 32 * 1024
+	minNetworkInterfaceMTU         = 576
+	maxNetworkInterfaceMTU         = 9000
+)
+
+var networkInterfaceNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+var bootstrapFilePermissionsRegex = regexp.MustCompile(`^0?[0-7]{3}$`)
+
+// reservedBootstrapFilePaths are written by EKS Anywhere and can't be overwritten with custom files
+var reservedBootstrapFilePaths = map[string]struct{}{
+	constants.KubeVipManifestPath:        {},
+	constants.AuditPolicyPath:            {},
+	constants.AdmissionConfigurationPath: {},
+	constants.ContainerdProxyConfigPath:  {},
+	constants.ContainerdConfigAppendPath: {},
+	constants.AwsIamAuthKubeconfigPath:   {},
+	constants.AwsIamAuthCertPath:         {},
+	constants.AwsIamAuthKeyPath:          {},
+	constants.CISSysctlPath:              {},
+	constants.CISAuditRulesPath:          {},
+	constants.CISSshdConfigPath:          {},
+	constants.HardeningReportPath:        {},
+}
+
+// reservedBootstrapFilePath returns true for the files written by EKS Anywhere, including the CA of the
+// registry mirror, whose path depends on the mirror endpoint
+func reservedBootstrapFilePath(p string) bool {
+	if _, ok := reservedBootstrapFilePaths[p]; ok {
+		return true
+	}
+	matched, _ := path.Match(constants.RegistryMirrorCACertPath("*"), p)
+	return matched
+}
+
 // Used for generating yaml for generate clusterconfig command
 func NewVSphereMachineConfigGenerate(name string) *VSphereMachineConfigGenerate {
 	return &VSphereMachineConfigGenerate{
@@ -47,20 +89,32 @@ func (c *VSphereMachineConfigGenerate) Name() string {
 }
 
 func GetVSphereMachineConfigs(fileName string) (map[string]*VSphereMachineConfig, error) {
+	content, err := readClusterConfigFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return GetVSphereMachineConfigsFromContent(content)
+}
+
+// GetVSphereMachineConfigsFromContent parses all the VSphereMachineConfigs from a multiobject yaml in memory
+func GetVSphereMachineConfigsFromContent(content []byte) (map[string]*VSphereMachineConfig, error) {
 	configs := make(map[string]*VSphereMachineConfig)
-	content, err := ioutil.ReadFile(fileName)
+	docs, err := splitYamlDocuments(content)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read file due to: %v", err)
+		return nil, err
 	}
-	for _, c := range strings.Split(string(content), YamlSeparator) {
+	for _, c := range docs {
 		var config VSphereMachineConfig
-		if err = yaml.UnmarshalStrict([]byte(c), &config); err == nil {
+		if err = yaml.UnmarshalStrict(c, &config); err == nil {
 			if config.Kind == VSphereMachineConfigKind {
+				if _, ok := configs[config.Name]; ok {
+					return nil, fmt.Errorf("%s %s is defined more than once", VSphereMachineConfigKind, config.Name)
+				}
 				configs[config.Name] = &config
 				continue
 			}
 		}
-		_ = yaml.Unmarshal([]byte(c), &config) // this is to check if there is a bad spec in the file
+		_ = yaml.Unmarshal(c, &config) // this is to check if there is a bad spec in the file
 		if config.Kind == VSphereMachineConfigKind {
 			return nil, fmt.Errorf("unable to unmarshall content from file due to: %v", err)
 		}
@@ -70,3 +124,160 @@ func GetVSphereMachineConfigs(fileName string) (map[string]*VSphereMachineConfig
 	}
 	return configs, nil
 }
+
+// ValidateBootstrapCustomizations validates the custom files and pre/post kubeadm commands
+// to be rendered in the machines bootstrap config
+func (c *VSphereMachineConfig) ValidateBootstrapCustomizations() error {
+	return validateBootstrapCustomizations(c.Spec.OSFamily, c.Spec.PreKubeadmCommands, c.Spec.PostKubeadmCommands, c.Spec.Files)
+}
+
+// ValidateHardeningProfile validates the host OS hardening profile is supported for the machines OS
+func (c *VSphereMachineConfig) ValidateHardeningProfile() error {
+	return validateHardeningProfile(c.Spec.OSFamily, c.Spec.HardeningProfile)
+}
+
+// ValidateNetworkInterfaces validates the network interfaces of the machines. It doesn't check the networks exist in vCenter
+func (c *VSphereMachineConfig) ValidateNetworkInterfaces() error {
+	interfaces := c.Spec.NetworkInterfaces
+	if len(interfaces) == 0 {
+		return nil
+	}
+	if c.Spec.OSFamily == Bottlerocket && (len(interfaces) > 1 || c.Spec.HasStaticNetworkInterfaces()) {
+		return fmt.Errorf("osFamily %s only supports a single network interface with DHCP", Bottlerocket)
+	}
+
+	names := make(map[string]struct{}, len(interfaces))
+	for _, nic := range interfaces {
+		if !networkInterfaceNameRegex.MatchString(nic.Name) {
+			return fmt.Errorf("network interface name %q is invalid, it can only have lowercase letters, numbers and dashes", nic.Name)
+		}
+		if _, ok := names[nic.Name]; ok {
+			return fmt.Errorf("network interface %s is defined more than once", nic.Name)
+		}
+		names[nic.Name] = struct{}{}
+		if nic.Network == "" {
+			return fmt.Errorf("network interface %s needs a network", nic.Name)
+		}
+		if err := validateNetworkInterfaceAddressing(nic); err != nil {
+			return fmt.Errorf("network interface %s: %v", nic.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateNetworkInterfaceAddressing(nic VSphereNetworkInterface) error {
+	if nic.MTU != 0 && (nic.MTU < minNetworkInterfaceMTU || nic.MTU > maxNetworkInterfaceMTU) {
+		return fmt.Errorf("mtu %d must be between %d and %d", nic.MTU, minNetworkInterfaceMTU, maxNetworkInterfaceMTU)
+	}
+	if len(nic.IPAddresses) == 0 {
+		if nic.Gateway != "" || len(nic.Nameservers) > 0 {
+			return errors.New("gateway and nameservers need static ipAddresses")
+		}
+		return nil
+	}
+
+	for _, address := range nic.IPAddresses {
+		if _, _, err := net.ParseCIDR(address); err != nil {
+			return fmt.Errorf("ipAddress %s must be in CIDR notation, i.e. 10.0.0.10/24", address)
+		}
+	}
+	if nic.Gateway != "" && net.ParseIP(nic.Gateway).To4() == nil {
+		return fmt.Errorf("gateway %s must be an IPv4 address", nic.Gateway)
+	}
+	for _, nameserver := range nic.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			return fmt.Errorf("nameserver %s must be an IP address", nameserver)
+		}
+	}
+
+	return nil
+}
+
+// HasStaticNetworkInterfaces returns true if any network interface of the machines has static addresses
+func (s *VSphereMachineConfigSpec) HasStaticNetworkInterfaces() bool {
+	for _, nic := range s.NetworkInterfaces {
+		if len(nic.IPAddresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkInterfacesInDatacenter returns the network interfaces of the machines with the full path of their networks
+// in the datacenter, nil when the machine config doesn't set any
+func (s *VSphereMachineConfigSpec) NetworkInterfacesInDatacenter(datacenter string) []VSphereNetworkInterface {
+	if len(s.NetworkInterfaces) == 0 {
+		return nil
+	}
+	interfaces := make([]VSphereNetworkInterface, 0, len(s.NetworkInterfaces))
+	for _, nic := range s.NetworkInterfaces {
+		nic.Network = generateFullVCenterPath(networkFolderType, nic.Network, datacenter)
+		interfaces = append(interfaces, nic)
+	}
+	return interfaces
+}
+
+func validateHardeningProfile(osFamily OSFamily, profile HardeningProfile) error {
+	switch profile {
+	case "":
+		return nil
+	case CISHardeningProfile:
+		if osFamily == Bottlerocket {
+			return fmt.Errorf("hardeningProfile %s is not supported for osFamily %s", profile, Bottlerocket)
+		}
+		return nil
+	default:
+		return fmt.Errorf("hardeningProfile %s is not supported, please use %s", profile, CISHardeningProfile)
+	}
+}
+
+func validateBootstrapCustomizations(osFamily OSFamily, preKubeadmCommands, postKubeadmCommands []string, files []BootstrapFile) error {
+	if len(preKubeadmCommands) == 0 && len(postKubeadmCommands) == 0 && len(files) == 0 {
+		return nil
+	}
+	if osFamily == Bottlerocket {
+		return fmt.Errorf("preKubeadmCommands, postKubeadmCommands and files are not supported for osFamily %s", Bottlerocket)
+	}
+
+	size := 0
+	for _, c := range []struct {
+		name     string
+		commands []string
+	}{{"preKubeadmCommands", preKubeadmCommands}, {"postKubeadmCommands", postKubeadmCommands}} {
+		name, commands := c.name, c.commands
+		if len(commands) > maxBootstrapCommands {
+			return fmt.Errorf("%s can't have more than %d commands", name, maxBootstrapCommands)
+		}
+		for _, command := range commands {
+			if strings.TrimSpace(command) == "" {
+				return fmt.Errorf("%s can't contain empty commands", name)
+			}
+			size += len(command)
+		}
+	}
+
+	paths := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		if !path.IsAbs(file.Path) {
+			return fmt.Errorf("file path %q must be absolute", file.Path)
+		}
+		if reservedBootstrapFilePath(path.Clean(file.Path)) {
+			return fmt.Errorf("file path %s is managed by EKS Anywhere and can't be overwritten", file.Path)
+		}
+		if _, ok := paths[path.Clean(file.Path)]; ok {
+			return fmt.Errorf("file path %s is defined more than once", file.Path)
+		}
+		paths[path.Clean(file.Path)] = struct{}{}
+		if file.Permissions != "" && !bootstrapFilePermissionsRegex.MatchString(file.Permissions) {
+			return fmt.Errorf("file %s has invalid permissions %q, must be in octal format, i.e. 0644", file.Path, file.Permissions)
+		}
+		size += len(file.Content)
+	}
+
+	if size > maxBootstrapCustomizationBytes {
+		return fmt.Errorf("preKubeadmCommands, postKubeadmCommands and files content can't exceed %d bytes in total, got %d", maxBootstrapCustomizationBytes, size)
+	}
+
+	return nil
+}
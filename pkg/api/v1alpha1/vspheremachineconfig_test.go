@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -224,3 +225,229 @@ func TestGetVSphereMachineConfigs(t *testing.T) {
 		})
 	}
 }
+
+func TestVSphereMachineConfigValidateBootstrapCustomizations(t *testing.T) {
+	tests := []struct {
+		testName string
+		spec     VSphereMachineConfigSpec
+		wantErr  string
+	}{
+		{
+			testName: "no customizations",
+			spec:     VSphereMachineConfigSpec{OSFamily: Bottlerocket},
+		},
+		{
+			testName: "valid customizations",
+			spec: VSphereMachineConfigSpec{
+				OSFamily:            Ubuntu,
+				PreKubeadmCommands:  []string{"mkdir -p /data"},
+				PostKubeadmCommands: []string{"systemctl enable --now agent"},
+				Files: []BootstrapFile{
+					{Path: "/etc/agent/config.yaml", Content: "endpoint: example.com", Owner: "agent:agent", Permissions: "0600"},
+					{Path: "/etc/agent/token", Content: "token", Permissions: "400"},
+				},
+			},
+		},
+		{
+			testName: "bottlerocket",
+			spec:     VSphereMachineConfigSpec{OSFamily: Bottlerocket, PostKubeadmCommands: []string{"echo hi"}},
+			wantErr:  "preKubeadmCommands, postKubeadmCommands and files are not supported for osFamily bottlerocket",
+		},
+		{
+			testName: "empty command",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, PreKubeadmCommands: []string{" "}},
+			wantErr:  "preKubeadmCommands can't contain empty commands",
+		},
+		{
+			testName: "too many commands",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, PostKubeadmCommands: make([]string, maxBootstrapCommands+1)},
+			wantErr:  "postKubeadmCommands can't have more than 50 commands",
+		},
+		{
+			testName: "relative file path",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "etc/agent.conf"}}},
+			wantErr:  "file path \"etc/agent.conf\" must be absolute",
+		},
+		{
+			testName: "reserved file path",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "/etc/kubernetes/manifests/kube-vip.yaml"}}},
+			wantErr:  "file path /etc/kubernetes/manifests/kube-vip.yaml is managed by EKS Anywhere and can't be overwritten",
+		},
+		{
+			testName: "reserved audit policy path",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "/etc/kubernetes/audit-policy.yaml"}}},
+			wantErr:  "file path /etc/kubernetes/audit-policy.yaml is managed by EKS Anywhere and can't be overwritten",
+		},
+		{
+			testName: "reserved aws-iam-authenticator path",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "/var/lib/kubeadm/aws-iam-authenticator/pki/key.pem"}}},
+			wantErr:  "file path /var/lib/kubeadm/aws-iam-authenticator/pki/key.pem is managed by EKS Anywhere and can't be overwritten",
+		},
+		{
+			testName: "reserved hardening profile path",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "/etc/ssh/sshd_config.d/90-eksa-cis.conf"}}},
+			wantErr:  "file path /etc/ssh/sshd_config.d/90-eksa-cis.conf is managed by EKS Anywhere and can't be overwritten",
+		},
+		{
+			testName: "reserved registry mirror CA path",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "/etc/containerd/certs.d/1.2.3.4:443/ca.crt"}}},
+			wantErr:  "file path /etc/containerd/certs.d/1.2.3.4:443/ca.crt is managed by EKS Anywhere and can't be overwritten",
+		},
+		{
+			testName: "duplicated file path",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "/etc/agent.conf"}, {Path: "/etc//agent.conf"}}},
+			wantErr:  "file path /etc//agent.conf is defined more than once",
+		},
+		{
+			testName: "invalid permissions",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, Files: []BootstrapFile{{Path: "/etc/agent.conf", Permissions: "rw-r--r--"}}},
+			wantErr:  "file /etc/agent.conf has invalid permissions \"rw-r--r--\", must be in octal format, i.e. 0644",
+		},
+		{
+			testName: "content too big",
+			spec: VSphereMachineConfigSpec{
+				OSFamily:           Ubuntu,
+				PreKubeadmCommands: []string{"echo hi"},
+				Files:              []BootstrapFile{{Path: "/etc/agent.conf", Content: strings.Repeat("a", maxBootstrapCustomizationBytes)}},
+			},
+			wantErr: "preKubeadmCommands, postKubeadmCommands and files content can't exceed 32768 bytes in total, got 32775",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			c := &VSphereMachineConfig{Spec: tt.spec}
+			err := c.ValidateBootstrapCustomizations()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ValidateBootstrapCustomizations() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("ValidateBootstrapCustomizations() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVSphereMachineConfigValidateNetworkInterfaces(t *testing.T) {
+	tests := []struct {
+		testName string
+		spec     VSphereMachineConfigSpec
+		wantErr  string
+	}{
+		{
+			testName: "no interfaces",
+			spec:     VSphereMachineConfigSpec{OSFamily: Bottlerocket},
+		},
+		{
+			testName: "valid interfaces",
+			spec: VSphereMachineConfigSpec{
+				OSFamily: Ubuntu,
+				NetworkInterfaces: []VSphereNetworkInterface{
+					{Name: "management", Network: "management-network"},
+					{Name: "storage", Network: "/SDDC-Datacenter/network/storage", IPAddresses: []string{"172.16.0.10/24"}, Gateway: "172.16.0.1", Nameservers: []string{"172.16.0.2"}, MTU: 9000},
+				},
+			},
+		},
+		{
+			testName: "bottlerocket single dhcp interface",
+			spec:     VSphereMachineConfigSpec{OSFamily: Bottlerocket, NetworkInterfaces: []VSphereNetworkInterface{{Name: "management", Network: "net"}}},
+		},
+		{
+			testName: "bottlerocket several interfaces",
+			spec:     VSphereMachineConfigSpec{OSFamily: Bottlerocket, NetworkInterfaces: []VSphereNetworkInterface{{Name: "management", Network: "net"}, {Name: "storage", Network: "storage"}}},
+			wantErr:  "osFamily bottlerocket only supports a single network interface with DHCP",
+		},
+		{
+			testName: "invalid name",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, NetworkInterfaces: []VSphereNetworkInterface{{Name: "Storage Network", Network: "net"}}},
+			wantErr:  "network interface name \"Storage Network\" is invalid, it can only have lowercase letters, numbers and dashes",
+		},
+		{
+			testName: "missing network",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, NetworkInterfaces: []VSphereNetworkInterface{{Name: "storage"}}},
+			wantErr:  "network interface storage needs a network",
+		},
+		{
+			testName: "address without prefix",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, NetworkInterfaces: []VSphereNetworkInterface{{Name: "storage", Network: "net", IPAddresses: []string{"172.16.0.10"}}}},
+			wantErr:  "network interface storage: ipAddress 172.16.0.10 must be in CIDR notation, i.e. 10.0.0.10/24",
+		},
+		{
+			testName: "gateway with dhcp",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, NetworkInterfaces: []VSphereNetworkInterface{{Name: "storage", Network: "net", Gateway: "172.16.0.1"}}},
+			wantErr:  "network interface storage: gateway and nameservers need static ipAddresses",
+		},
+		{
+			testName: "invalid mtu",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, NetworkInterfaces: []VSphereNetworkInterface{{Name: "storage", Network: "net", MTU: 100}}},
+			wantErr:  "network interface storage: mtu 100 must be between 576 and 9000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			c := &VSphereMachineConfig{Spec: tt.spec}
+			err := c.ValidateNetworkInterfaces()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ValidateNetworkInterfaces() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("ValidateNetworkInterfaces() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVSphereMachineConfigSpecNetworkInterfacesInDatacenter(t *testing.T) {
+	spec := &VSphereMachineConfigSpec{NetworkInterfaces: []VSphereNetworkInterface{
+		{Name: "management", Network: "/SDDC-Datacenter/network/management"},
+		{Name: "storage", Network: "storage"},
+	}}
+	want := []VSphereNetworkInterface{
+		{Name: "management", Network: "/SDDC-Datacenter/network/management"},
+		{Name: "storage", Network: "/SDDC-Datacenter/network/storage"},
+	}
+	if got := spec.NetworkInterfacesInDatacenter("SDDC-Datacenter"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("NetworkInterfacesInDatacenter() = %v, want %v", got, want)
+	}
+	if spec.NetworkInterfaces[1].Network != "storage" {
+		t.Fatalf("NetworkInterfacesInDatacenter() modified the spec")
+	}
+}
+
+func TestVSphereMachineConfigValidateHardeningProfile(t *testing.T) {
+	tests := []struct {
+		testName string
+		spec     VSphereMachineConfigSpec
+		wantErr  string
+	}{
+		{
+			testName: "no profile",
+			spec:     VSphereMachineConfigSpec{OSFamily: Bottlerocket},
+		},
+		{
+			testName: "cis on ubuntu",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, HardeningProfile: CISHardeningProfile},
+		},
+		{
+			testName: "cis on bottlerocket",
+			spec:     VSphereMachineConfigSpec{OSFamily: Bottlerocket, HardeningProfile: CISHardeningProfile},
+			wantErr:  "hardeningProfile cis is not supported for osFamily bottlerocket",
+		},
+		{
+			testName: "unknown profile",
+			spec:     VSphereMachineConfigSpec{OSFamily: Ubuntu, HardeningProfile: "stig"},
+			wantErr:  "hardeningProfile stig is not supported, please use cis",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			c := &VSphereMachineConfig{Spec: tt.spec}
+			err := c.ValidateHardeningProfile()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ValidateHardeningProfile() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("ValidateHardeningProfile() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,6 +18,17 @@ type VSphereMachineConfigSpec struct {
 	StoragePolicyName string              `json:"storagePolicyName,omitempty"`
 	Template          string              `json:"template,omitempty"`
 	Users             []UserConfiguration `json:"users,omitempty"`
+	// PreKubeadmCommands are run on the machines before kubeadm init/join, after the EKS Anywhere bootstrap commands
+	PreKubeadmCommands []string `json:"preKubeadmCommands,omitempty"`
+	// PostKubeadmCommands are run on the machines after kubeadm init/join
+	PostKubeadmCommands []string `json:"postKubeadmCommands,omitempty"`
+	// Files are written to the machines before running the PreKubeadmCommands
+	Files []BootstrapFile `json:"files,omitempty"`
+	// HardeningProfile applies a host OS hardening configuration to the machines at bootstrap
+	HardeningProfile HardeningProfile `json:"hardeningProfile,omitempty"`
+	// NetworkInterfaces are the network interfaces of the machines, in order. The first one is the management
+	// interface the nodes are reached on. When not set, the machines get a single DHCP interface in the datacenter network
+	NetworkInterfaces []VSphereNetworkInterface `json:"networkInterfaces,omitempty"`
 }
 
 func (c *VSphereMachineConfig) PauseReconcile() {
@@ -91,13 +102,51 @@ const (
 type UserConfiguration struct {
 	Name              string   `json:"name"`
 	SshAuthorizedKeys []string `json:"sshAuthorizedKeys"`
-}
+	// Sudo is the sudoers rule of the user. The first user gets ALL=(ALL) NOPASSWD:ALL when it's not set,
+	// the other users don't get any sudo access
+	Sudo string `json:"sudo,omitempty"`
+}
+
+// BootstrapFile defines a file to be written to the machine during bootstrap
+type BootstrapFile struct {
+	Path        string `json:"path"`
+	Content     string `json:"content"`
+	Owner       string `json:"owner,omitempty"`
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// VSphereNetworkInterface defines a network interface of the VSphere VM
+type VSphereNetworkInterface struct {
+	// Name identifies the interface, like management, workload or storage
+	Name string `json:"name"`
+	// Network is the vSphere network of the interface, relative to the network folder of the datacenter or a full path
+	Network string `json:"network"`
+	// IPAddresses are static addresses in CIDR notation, used instead of DHCP. All the machines of the machine config
+	// get the same addresses, so they are only supported for worker node groups with a single machine
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// Gateway is the IPv4 gateway of an interface with static addresses
+	Gateway string `json:"gateway,omitempty"`
+	// Nameservers are the DNS servers of an interface with static addresses
+	Nameservers []string `json:"nameservers,omitempty"`
+	// MTU of the interface, the network default when not set
+	MTU int `json:"mtu,omitempty"`
+}
+
+// HardeningProfile defines a host OS hardening configuration
+type HardeningProfile string
+
+const (
+	// CISHardeningProfile applies a configuration aligned with the CIS benchmarks
+	// for the kernel parameters, auditd rules, ssh daemon and kubelet
+	CISHardeningProfile HardeningProfile = "cis"
+)
 
 // VSphereMachineConfigStatus defines the observed state of VSphereMachineConfig
 type VSphereMachineConfigStatus struct{}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // VSphereMachineConfig is the Schema for the vspheremachineconfigs API
 type VSphereMachineConfig struct {
@@ -32,6 +32,24 @@ var _ webhook.Validator = &VSphereMachineConfig{}
 func (r *VSphereMachineConfig) ValidateCreate() error {
 	vspheremachineconfiglog.Info("validate create", "name", r.Name)
 
+	if err := r.ValidateBootstrapCustomizations(); err != nil {
+		return apierrors.NewInvalid(GroupVersion.WithKind(VSphereMachineConfigKind).GroupKind(), r.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec"), r.Spec, err.Error()),
+		})
+	}
+
+	if err := r.ValidateHardeningProfile(); err != nil {
+		return apierrors.NewInvalid(GroupVersion.WithKind(VSphereMachineConfigKind).GroupKind(), r.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "hardeningProfile"), r.Spec.HardeningProfile, err.Error()),
+		})
+	}
+
+	if err := r.ValidateNetworkInterfaces(); err != nil {
+		return apierrors.NewInvalid(GroupVersion.WithKind(VSphereMachineConfigKind).GroupKind(), r.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "networkInterfaces"), r.Spec.NetworkInterfaces, err.Error()),
+		})
+	}
+
 	return nil
 }
 
@@ -48,6 +66,18 @@ func (r *VSphereMachineConfig) ValidateUpdate(old runtime.Object) error {
 
 	allErrs = append(allErrs, validateImmutableFieldsVSphereMachineConfig(r, oldVSphereMachineConfig)...)
 
+	if err := r.ValidateBootstrapCustomizations(); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), r.Spec, err.Error()))
+	}
+
+	if err := r.ValidateHardeningProfile(); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "hardeningProfile"), r.Spec.HardeningProfile, err.Error()))
+	}
+
+	if err := r.ValidateNetworkInterfaces(); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "networkInterfaces"), r.Spec.NetworkInterfaces, err.Error()))
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
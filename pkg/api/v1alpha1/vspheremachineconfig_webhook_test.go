@@ -583,6 +583,45 @@ func TestVSphereMachineValidateUpdateStoragePolicyImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(&vOld)).NotTo(Succeed())
 }
 
+func TestVSphereMachineValidateCreateBootstrapCustomizationsSuccess(t *testing.T) {
+	c := vsphereMachineConfig()
+	c.Spec.OSFamily = v1alpha1.Ubuntu
+	c.Spec.PreKubeadmCommands = []string{"mkdir -p /data"}
+	c.Spec.PostKubeadmCommands = []string{"systemctl enable --now agent"}
+	c.Spec.Files = []v1alpha1.BootstrapFile{{Path: "/etc/agent/config.yaml", Content: "endpoint: example.com", Permissions: "0600"}}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateCreate()).To(Succeed())
+}
+
+func TestVSphereMachineValidateCreateBootstrapCustomizationsInvalid(t *testing.T) {
+	c := vsphereMachineConfig()
+	c.Spec.OSFamily = v1alpha1.Bottlerocket
+	c.Spec.PreKubeadmCommands = []string{"mkdir -p /data"}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateCreate()).NotTo(Succeed())
+}
+
+func TestVSphereMachineValidateUpdateBootstrapCustomizationsInvalid(t *testing.T) {
+	vOld := vsphereMachineConfig()
+	vOld.Spec.OSFamily = v1alpha1.Ubuntu
+	c := vOld.DeepCopy()
+
+	c.Spec.Files = []v1alpha1.BootstrapFile{{Path: "etc/agent/config.yaml"}}
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(&vOld)).NotTo(Succeed())
+}
+
+func TestVSphereMachineValidateCreateHardeningProfileInvalid(t *testing.T) {
+	c := vsphereMachineConfig()
+	c.Spec.OSFamily = v1alpha1.Ubuntu
+	c.Spec.HardeningProfile = "stig"
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateCreate()).NotTo(Succeed())
+}
+
 func vsphereMachineConfig() v1alpha1.VSphereMachineConfig {
 	return v1alpha1.VSphereMachineConfig{
 		TypeMeta:   metav1.TypeMeta{},
@@ -20,6 +20,7 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -220,6 +221,46 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionConfiguration) DeepCopyInto(out *AdmissionConfiguration) {
+	*out = *in
+	if in.EnablePlugins != nil {
+		in, out := &in.EnablePlugins, &out.EnablePlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisablePlugins != nil {
+		in, out := &in.DisablePlugins, &out.DisablePlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionConfiguration.
+func (in *AdmissionConfiguration) DeepCopy() *AdmissionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapFile) DeepCopyInto(out *BootstrapFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapFile.
+func (in *BootstrapFile) DeepCopy() *BootstrapFile {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -284,7 +325,17 @@ func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
 	*out = *in
 	in.Pods.DeepCopyInto(&out.Pods)
 	in.Services.DeepCopyInto(&out.Services)
+	if in.CustomCNI != nil {
+		in, out := &in.CustomCNI, &out.CustomCNI
+		*out = new(CustomCNIConfig)
+		**out = **in
+	}
 	out.DNS = in.DNS
+	if in.KubeProxy != nil {
+		in, out := &in.KubeProxy, &out.KubeProxy
+		*out = new(KubeProxyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetwork.
@@ -341,6 +392,79 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(PodIAMConfig)
 		**out = **in
 	}
+	if in.ResourceTags != nil {
+		in, out := &in.ResourceTags, &out.ResourceTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceLoadBalancer != nil {
+		in, out := &in.ServiceLoadBalancer, &out.ServiceLoadBalancer
+		*out = new(ServiceLoadBalancerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalPathStorage != nil {
+		in, out := &in.LocalPathStorage, &out.LocalPathStorage
+		*out = new(LocalPathStorageConfiguration)
+		**out = **in
+	}
+	if in.TLSPolicy != nil {
+		in, out := &in.TLSPolicy, &out.TLSPolicy
+		*out = new(TLSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurityConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostEntries != nil {
+		in, out := &in.HostEntries, &out.HostEntries
+		*out = make([]HostEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ReadinessGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CoreAddons != nil {
+		in, out := &in.CoreAddons, &out.CoreAddons
+		*out = new(CoreAddonsConfiguration)
+		**out = **in
+	}
+	if in.CoreDNS != nil {
+		in, out := &in.CoreDNS, &out.CoreDNS
+		*out = new(CoreDNSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(AdmissionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceNaming != nil {
+		in, out := &in.ResourceNaming, &out.ResourceNaming
+		*out = new(ResourceNamingConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -361,6 +485,18 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(EtcdStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -412,6 +548,83 @@ func (in *ControlPlaneConfiguration) DeepCopy() *ControlPlaneConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreAddonsConfiguration) DeepCopyInto(out *CoreAddonsConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreAddonsConfiguration.
+func (in *CoreAddonsConfiguration) DeepCopy() *CoreAddonsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreAddonsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSConfiguration) DeepCopyInto(out *CoreDNSConfiguration) {
+	*out = *in
+	if in.StubDomains != nil {
+		in, out := &in.StubDomains, &out.StubDomains
+		*out = make([]CoreDNSStubDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpstreamResolvers != nil {
+		in, out := &in.UpstreamResolvers, &out.UpstreamResolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSConfiguration.
+func (in *CoreDNSConfiguration) DeepCopy() *CoreDNSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSStubDomain) DeepCopyInto(out *CoreDNSStubDomain) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSStubDomain.
+func (in *CoreDNSStubDomain) DeepCopy() *CoreDNSStubDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSStubDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomCNIConfig) DeepCopyInto(out *CustomCNIConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomCNIConfig.
+func (in *CustomCNIConfig) DeepCopy() *CustomCNIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomCNIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNS) DeepCopyInto(out *DNS) {
 	*out = *in
@@ -532,6 +745,53 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdMemberStatus) DeepCopyInto(out *EtcdMemberStatus) {
+	*out = *in
+	if in.Alarms != nil {
+		in, out := &in.Alarms, &out.Alarms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdMemberStatus.
+func (in *EtcdMemberStatus) DeepCopy() *EtcdMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdStatus) DeepCopyInto(out *EtcdStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.LastDefragTime != nil {
+		in, out := &in.LastDefragTime, &out.LastDefragTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]EtcdMemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdStatus.
+func (in *EtcdStatus) DeepCopy() *EtcdStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalEtcdConfiguration) DeepCopyInto(out *ExternalEtcdConfiguration) {
 	*out = *in
@@ -673,6 +933,132 @@ func (in *Github) DeepCopy() *Github {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in HardwareSelector) DeepCopyInto(out *HardwareSelector) {
+	{
+		in := &in
+		*out = make(HardwareSelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareSelector.
+func (in HardwareSelector) DeepCopy() HardwareSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareSelector)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPReadinessGate) DeepCopyInto(out *HTTPReadinessGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPReadinessGate.
+func (in *HTTPReadinessGate) DeepCopy() *HTTPReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostEntry) DeepCopyInto(out *HostEntry) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostEntry.
+func (in *HostEntry) DeepCopy() *HostEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(HostEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeProxyConfiguration) DeepCopyInto(out *KubeProxyConfiguration) {
+	*out = *in
+	if in.IPVS != nil {
+		in, out := &in.IPVS, &out.IPVS
+		*out = new(KubeProxyIPVSConfiguration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxyConfiguration.
+func (in *KubeProxyConfiguration) DeepCopy() *KubeProxyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeProxyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeProxyIPVSConfiguration) DeepCopyInto(out *KubeProxyIPVSConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxyIPVSConfiguration.
+func (in *KubeProxyIPVSConfiguration) DeepCopy() *KubeProxyIPVSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeProxyIPVSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalPathStorageConfiguration) DeepCopyInto(out *LocalPathStorageConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalPathStorageConfiguration.
+func (in *LocalPathStorageConfiguration) DeepCopy() *LocalPathStorageConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalPathStorageConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
 	*out = *in
@@ -703,278 +1089,546 @@ func (in *MapRoles) DeepCopy() *MapRoles {
 	if in == nil {
 		return nil
 	}
-	out := new(MapRoles)
+	out := new(MapRoles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MapUsers) DeepCopyInto(out *MapUsers) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapUsers.
+func (in *MapUsers) DeepCopy() *MapUsers {
+	if in == nil {
+		return nil
+	}
+	out := new(MapUsers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsConfiguration) DeepCopyInto(out *NotificationsConfiguration) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackNotification)
+		**out = **in
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]WebhookNotification, len(*in))
+		copy(*out, *in)
+	}
+	if in.SNS != nil {
+		in, out := &in.SNS, &out.SNS
+		*out = new(SNSNotification)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfiguration.
+func (in *NotificationsConfiguration) DeepCopy() *NotificationsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
+func (in *OIDCConfig) DeepCopy() *OIDCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OIDCConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfigList) DeepCopyInto(out *OIDCConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OIDCConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigList.
+func (in *OIDCConfigList) DeepCopy() *OIDCConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OIDCConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfigRequiredClaim) DeepCopyInto(out *OIDCConfigRequiredClaim) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigRequiredClaim.
+func (in *OIDCConfigRequiredClaim) DeepCopy() *OIDCConfigRequiredClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfigRequiredClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfigSpec) DeepCopyInto(out *OIDCConfigSpec) {
+	*out = *in
+	if in.RequiredClaims != nil {
+		in, out := &in.RequiredClaims, &out.RequiredClaims
+		*out = make([]OIDCConfigRequiredClaim, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigSpec.
+func (in *OIDCConfigSpec) DeepCopy() *OIDCConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfigStatus) DeepCopyInto(out *OIDCConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigStatus.
+func (in *OIDCConfigStatus) DeepCopy() *OIDCConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMeta) DeepCopyInto(out *ObjectMeta) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMeta.
+func (in *ObjectMeta) DeepCopy() *ObjectMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReadinessGate) DeepCopyInto(out *ObjectReadinessGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReadinessGate.
+func (in *ObjectReadinessGate) DeepCopy() *ObjectReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodIAMConfig) DeepCopyInto(out *PodIAMConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIAMConfig.
+func (in *PodIAMConfig) DeepCopy() *PodIAMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodIAMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityConfiguration) DeepCopyInto(out *PodSecurityConfiguration) {
+	*out = *in
+	in.Exemptions.DeepCopyInto(&out.Exemptions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityConfiguration.
+func (in *PodSecurityConfiguration) DeepCopy() *PodSecurityConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityExemptions) DeepCopyInto(out *PodSecurityExemptions) {
+	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClasses != nil {
+		in, out := &in.RuntimeClasses, &out.RuntimeClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityExemptions.
+func (in *PodSecurityExemptions) DeepCopy() *PodSecurityExemptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityExemptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pods) DeepCopyInto(out *Pods) {
+	*out = *in
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pods.
+func (in *Pods) DeepCopy() *Pods {
+	if in == nil {
+		return nil
+	}
+	out := new(Pods)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MapUsers) DeepCopyInto(out *MapUsers) {
+func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapUsers.
-func (in *MapUsers) DeepCopy() *MapUsers {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfiguration.
+func (in *ProxyConfiguration) DeepCopy() *ProxyConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(MapUsers)
+	out := new(ProxyConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+func (in *ReadinessGate) DeepCopyInto(out *ReadinessGate) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPReadinessGate)
+		**out = **in
+	}
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(ObjectReadinessGate)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(ObjectReadinessGate)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
-func (in *OIDCConfig) DeepCopy() *OIDCConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGate.
+func (in *ReadinessGate) DeepCopy() *ReadinessGate {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCConfig)
+	out := new(ReadinessGate)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OIDCConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCConfigList) DeepCopyInto(out *OIDCConfigList) {
+func (in *Ref) DeepCopyInto(out *Ref) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]OIDCConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigList.
-func (in *OIDCConfigList) DeepCopy() *OIDCConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ref.
+func (in *Ref) DeepCopy() *Ref {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCConfigList)
+	out := new(Ref)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OIDCConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCConfigRequiredClaim) DeepCopyInto(out *OIDCConfigRequiredClaim) {
+func (in *RegistryMirrorConfiguration) DeepCopyInto(out *RegistryMirrorConfiguration) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigRequiredClaim.
-func (in *OIDCConfigRequiredClaim) DeepCopy() *OIDCConfigRequiredClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirrorConfiguration.
+func (in *RegistryMirrorConfiguration) DeepCopy() *RegistryMirrorConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCConfigRequiredClaim)
+	out := new(RegistryMirrorConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCConfigSpec) DeepCopyInto(out *OIDCConfigSpec) {
+func (in *ResolvConf) DeepCopyInto(out *ResolvConf) {
 	*out = *in
-	if in.RequiredClaims != nil {
-		in, out := &in.RequiredClaims, &out.RequiredClaims
-		*out = make([]OIDCConfigRequiredClaim, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigSpec.
-func (in *OIDCConfigSpec) DeepCopy() *OIDCConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvConf.
+func (in *ResolvConf) DeepCopy() *ResolvConf {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCConfigSpec)
+	out := new(ResolvConf)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCConfigStatus) DeepCopyInto(out *OIDCConfigStatus) {
+func (in *ResourceNamingConfiguration) DeepCopyInto(out *ResourceNamingConfiguration) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigStatus.
-func (in *OIDCConfigStatus) DeepCopy() *OIDCConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNamingConfiguration.
+func (in *ResourceNamingConfiguration) DeepCopy() *ResourceNamingConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCConfigStatus)
+	out := new(ResourceNamingConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectMeta) DeepCopyInto(out *ObjectMeta) {
+func (in *SNSNotification) DeepCopyInto(out *SNSNotification) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMeta.
-func (in *ObjectMeta) DeepCopy() *ObjectMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSNotification.
+func (in *SNSNotification) DeepCopy() *SNSNotification {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectMeta)
+	out := new(SNSNotification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodIAMConfig) DeepCopyInto(out *PodIAMConfig) {
+func (in *ServiceLoadBalancerAddressPool) DeepCopyInto(out *ServiceLoadBalancerAddressPool) {
 	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIAMConfig.
-func (in *PodIAMConfig) DeepCopy() *PodIAMConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLoadBalancerAddressPool.
+func (in *ServiceLoadBalancerAddressPool) DeepCopy() *ServiceLoadBalancerAddressPool {
 	if in == nil {
 		return nil
 	}
-	out := new(PodIAMConfig)
+	out := new(ServiceLoadBalancerAddressPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pods) DeepCopyInto(out *Pods) {
+func (in *ServiceLoadBalancerBGP) DeepCopyInto(out *ServiceLoadBalancerBGP) {
 	*out = *in
-	if in.CidrBlocks != nil {
-		in, out := &in.CidrBlocks, &out.CidrBlocks
-		*out = make([]string, len(*in))
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]ServiceLoadBalancerBGPPeer, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pods.
-func (in *Pods) DeepCopy() *Pods {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLoadBalancerBGP.
+func (in *ServiceLoadBalancerBGP) DeepCopy() *ServiceLoadBalancerBGP {
 	if in == nil {
 		return nil
 	}
-	out := new(Pods)
+	out := new(ServiceLoadBalancerBGP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
+func (in *ServiceLoadBalancerBGPPeer) DeepCopyInto(out *ServiceLoadBalancerBGPPeer) {
 	*out = *in
-	if in.NoProxy != nil {
-		in, out := &in.NoProxy, &out.NoProxy
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfiguration.
-func (in *ProxyConfiguration) DeepCopy() *ProxyConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLoadBalancerBGPPeer.
+func (in *ServiceLoadBalancerBGPPeer) DeepCopy() *ServiceLoadBalancerBGPPeer {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyConfiguration)
+	out := new(ServiceLoadBalancerBGPPeer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Ref) DeepCopyInto(out *Ref) {
+func (in *ServiceLoadBalancerConfiguration) DeepCopyInto(out *ServiceLoadBalancerConfiguration) {
 	*out = *in
+	if in.AddressPools != nil {
+		in, out := &in.AddressPools, &out.AddressPools
+		*out = make([]ServiceLoadBalancerAddressPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(ServiceLoadBalancerBGP)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ref.
-func (in *Ref) DeepCopy() *Ref {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLoadBalancerConfiguration.
+func (in *ServiceLoadBalancerConfiguration) DeepCopy() *ServiceLoadBalancerConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(Ref)
+	out := new(ServiceLoadBalancerConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RegistryMirrorConfiguration) DeepCopyInto(out *RegistryMirrorConfiguration) {
+func (in *Services) DeepCopyInto(out *Services) {
 	*out = *in
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirrorConfiguration.
-func (in *RegistryMirrorConfiguration) DeepCopy() *RegistryMirrorConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Services.
+func (in *Services) DeepCopy() *Services {
 	if in == nil {
 		return nil
 	}
-	out := new(RegistryMirrorConfiguration)
+	out := new(Services)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResolvConf) DeepCopyInto(out *ResolvConf) {
+func (in *SlackNotification) DeepCopyInto(out *SlackNotification) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvConf.
-func (in *ResolvConf) DeepCopy() *ResolvConf {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotification.
+func (in *SlackNotification) DeepCopy() *SlackNotification {
 	if in == nil {
 		return nil
 	}
-	out := new(ResolvConf)
+	out := new(SlackNotification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Services) DeepCopyInto(out *Services) {
+func (in *TLSPolicy) DeepCopyInto(out *TLSPolicy) {
 	*out = *in
-	if in.CidrBlocks != nil {
-		in, out := &in.CidrBlocks, &out.CidrBlocks
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Services.
-func (in *Services) DeepCopy() *Services {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSPolicy.
+func (in *TLSPolicy) DeepCopy() *TLSPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Services)
+	out := new(TLSPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1137,6 +1791,13 @@ func (in *TinkerbellMachineConfigSpec) DeepCopyInto(out *TinkerbellMachineConfig
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HardwareSelector != nil {
+		in, out := &in.HardwareSelector, &out.HardwareSelector
+		*out = make(HardwareSelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellMachineConfigSpec.
@@ -1184,12 +1845,67 @@ func (in *UserConfiguration) DeepCopy() *UserConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereCloudProviderConfig) DeepCopyInto(out *VSphereCloudProviderConfig) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(VSphereCloudProviderNodes)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(VSphereCloudProviderLabels)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereCloudProviderConfig.
+func (in *VSphereCloudProviderConfig) DeepCopy() *VSphereCloudProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereCloudProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereCloudProviderLabels) DeepCopyInto(out *VSphereCloudProviderLabels) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereCloudProviderLabels.
+func (in *VSphereCloudProviderLabels) DeepCopy() *VSphereCloudProviderLabels {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereCloudProviderLabels)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereCloudProviderNodes) DeepCopyInto(out *VSphereCloudProviderNodes) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereCloudProviderNodes.
+func (in *VSphereCloudProviderNodes) DeepCopy() *VSphereCloudProviderNodes {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereCloudProviderNodes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereDatacenterConfig) DeepCopyInto(out *VSphereDatacenterConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1246,6 +1962,16 @@ func (in *VSphereDatacenterConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereDatacenterConfigSpec) DeepCopyInto(out *VSphereDatacenterConfigSpec) {
 	*out = *in
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]VSphereStorageClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudProvider != nil {
+		in, out := &in.CloudProvider, &out.CloudProvider
+		*out = new(VSphereCloudProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDatacenterConfigSpec.
@@ -1347,6 +2073,28 @@ func (in *VSphereMachineConfigSpec) DeepCopyInto(out *VSphereMachineConfigSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreKubeadmCommands != nil {
+		in, out := &in.PreKubeadmCommands, &out.PreKubeadmCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostKubeadmCommands != nil {
+		in, out := &in.PostKubeadmCommands, &out.PostKubeadmCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]BootstrapFile, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]VSphereNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineConfigSpec.
@@ -1374,6 +2122,61 @@ func (in *VSphereMachineConfigStatus) DeepCopy() *VSphereMachineConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereNetworkInterface) DeepCopyInto(out *VSphereNetworkInterface) {
+	*out = *in
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereNetworkInterface.
+func (in *VSphereNetworkInterface) DeepCopy() *VSphereNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereStorageClass) DeepCopyInto(out *VSphereStorageClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereStorageClass.
+func (in *VSphereStorageClass) DeepCopy() *VSphereStorageClass {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereStorageClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotification) DeepCopyInto(out *WebhookNotification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotification.
+func (in *WebhookNotification) DeepCopy() *WebhookNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfiguration) {
 	*out = *in
@@ -1389,6 +2192,11 @@ func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfigu
 			(*out)[key] = val
 		}
 	}
+	if in.KubernetesVersion != nil {
+		in, out := &in.KubernetesVersion, &out.KubernetesVersion
+		*out = new(KubernetesVersion)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroupConfiguration.
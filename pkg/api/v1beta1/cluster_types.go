@@ -0,0 +1,150 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const ClusterKind = "Cluster"
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ClusterSpec defines the desired state of Cluster
+type ClusterSpec struct {
+	KubernetesVersion         v1alpha1.KubernetesVersion         `json:"kubernetesVersion,omitempty"`
+	ControlPlaneConfiguration v1alpha1.ControlPlaneConfiguration `json:"controlPlaneConfiguration,omitempty"`
+	// WorkerNodeGroups is the list of worker node groups of the cluster, identified by their name
+	// +listType=map
+	// +listMapKey=name
+	WorkerNodeGroups     []WorkerNodeGroup `json:"workerNodeGroups,omitempty"`
+	DatacenterRef        v1alpha1.Ref      `json:"datacenterRef,omitempty"`
+	IdentityProviderRefs []v1alpha1.Ref    `json:"identityProviderRefs,omitempty"`
+	GitOpsRef            *v1alpha1.Ref     `json:"gitOpsRef,omitempty"`
+	ClusterNetwork       ClusterNetwork    `json:"clusterNetwork,omitempty"`
+	// +kubebuilder:validation:Optional
+	ExternalEtcdConfiguration   *v1alpha1.ExternalEtcdConfiguration   `json:"externalEtcdConfiguration,omitempty"`
+	ProxyConfiguration          *v1alpha1.ProxyConfiguration          `json:"proxyConfiguration,omitempty"`
+	RegistryMirrorConfiguration *v1alpha1.RegistryMirrorConfiguration `json:"registryMirrorConfiguration,omitempty"`
+	ManagementCluster           v1alpha1.ManagementCluster            `json:"managementCluster,omitempty"`
+	PodIAMConfig                *v1alpha1.PodIAMConfig                `json:"podIamConfig,omitempty"`
+	// ResourceTags are added to every infrastructure resource created for the cluster, on top of the standard
+	// EKS Anywhere tags. Keys can't use the anywhere.eks.amazonaws.com/ prefix, which is reserved for those.
+	ResourceTags map[string]string `json:"resourceTags,omitempty"`
+	// ServiceLoadBalancer installs kube-vip in services mode so the cluster can serve LoadBalancer services
+	// with addresses from the configured pools.
+	ServiceLoadBalancer *v1alpha1.ServiceLoadBalancerConfiguration `json:"serviceLoadBalancer,omitempty"`
+	// LocalPathStorage installs local-path-provisioner as the default StorageClass, for providers
+	// without a CSI driver. Volumes are directories in the node where the pod runs.
+	LocalPathStorage *v1alpha1.LocalPathStorageConfiguration `json:"localPathStorage,omitempty"`
+	// FIPSEnabled runs the cluster nodes with the FIPS 140-2 validated builds of the components from the bundle
+	// and FIPS approved TLS settings. The machine templates must be FIPS enabled.
+	FIPSEnabled bool `json:"fipsEnabled,omitempty"`
+	// TLSPolicy sets the minimum TLS version and the cipher suites accepted by the API server, the kubelet,
+	// etcd and the EKS Anywhere controller webhooks.
+	TLSPolicy *v1alpha1.TLSPolicy `json:"tlsPolicy,omitempty"`
+	// PodSecurity sets the Pod Security Standards levels the API server applies to the namespaces
+	// without their own pod-security.kubernetes.io labels.
+	PodSecurity *v1alpha1.PodSecurityConfiguration `json:"podSecurity,omitempty"`
+	// HostEntries are static entries added to /etc/hosts in the cluster nodes and the bootstrap cluster,
+	// for networks without a DNS server that resolves names like the registry mirror, vCenter or git server.
+	HostEntries []v1alpha1.HostEntry `json:"hostEntries,omitempty"`
+	// MaintenanceWindows are the periods when the controller can roll out changes that replace machines,
+	// like upgrades. Outside of them those changes are deferred. Changes are rolled out anytime when empty.
+	MaintenanceWindows []v1alpha1.MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Notifications sets where the start and end of the CLI operations and the health changes
+	// detected by the controller are sent.
+	Notifications *v1alpha1.NotificationsConfiguration `json:"notifications,omitempty"`
+	// DeletionProtection makes the CLI and the controller refuse to delete the cluster until
+	// it's set back to false, to avoid destroying a cluster by accident.
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+	// ReadinessGates are extra checks create waits for before reporting the cluster as created,
+	// like an ingress answering or an organization agent running in all the nodes.
+	ReadinessGates []v1alpha1.ReadinessGate `json:"readinessGates,omitempty"`
+	// CoreAddons enables core components installed with the cluster from the images in the bundle
+	// and upgraded with it, for basic observability without a separate packaging step.
+	CoreAddons *v1alpha1.CoreAddonsConfiguration `json:"coreAddons,omitempty"`
+	// CoreDNS customizes the cluster DNS: the domains resolved by their own servers, the upstream resolvers,
+	// the number of CoreDNS replicas and how long the answers are cached.
+	CoreDNS *v1alpha1.CoreDNSConfiguration `json:"coreDNS,omitempty"`
+	// Admission sets the admission plugins of the API server from a profile, along with the API server flags
+	// the profile needs, instead of setting them with extra args.
+	Admission *v1alpha1.AdmissionConfiguration `json:"admission,omitempty"`
+	// ResourceNaming customizes the names of the resources generated for the cluster, like the MachineDeployments
+	// the worker machine names start with.
+	ResourceNaming *v1alpha1.ResourceNamingConfiguration `json:"resourceNaming,omitempty"`
+}
+
+type WorkerNodeGroup struct {
+	// Name refers to the name of the worker node group. It must be unique in the cluster
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Count defines the number of desired worker nodes. Defaults to 1.
+	Count int `json:"count,omitempty"`
+	// MachineGroupRef defines the machine group configuration for the worker nodes.
+	MachineGroupRef *v1alpha1.Ref `json:"machineGroupRef,omitempty"`
+	// Labels define the labels to assign to the node
+	Labels map[string]string `json:"labels,omitempty"`
+	// KubernetesVersion overrides the cluster kubernetes version for this worker node group.
+	// It can't be newer than the control plane version nor more than 2 minor versions older.
+	// Defaults to the cluster kubernetes version.
+	KubernetesVersion *v1alpha1.KubernetesVersion `json:"kubernetesVersion,omitempty"`
+}
+
+type ClusterNetwork struct {
+	// Comma-separated list of CIDR blocks to use for pod and service subnets.
+	// Defaults to 192.168.0.0/16 for pod subnet.
+	Pods     v1alpha1.Pods     `json:"pods,omitempty"`
+	Services v1alpha1.Services `json:"services,omitempty"`
+	// CNIConfig specifies the CNI plugin to be installed in the cluster and its configuration
+	CNIConfig *CNIConfig   `json:"cniConfig,omitempty"`
+	DNS       v1alpha1.DNS `json:"dns,omitempty"`
+	// KubeProxy sets how kube-proxy routes the service traffic, or disables it when Cilium replaces it.
+	// It can't be changed after the cluster is created.
+	KubeProxy *v1alpha1.KubeProxyConfiguration `json:"kubeProxy,omitempty"`
+}
+
+// CNIConfig holds the configuration for the CNI plugin. Only one plugin can be set
+// +kubebuilder:validation:MaxProperties=1
+type CNIConfig struct {
+	Cilium   *CiliumConfig   `json:"cilium,omitempty"`
+	Kindnetd *KindnetdConfig `json:"kindnetd,omitempty"`
+	Custom   *CustomConfig   `json:"custom,omitempty"`
+}
+
+// CiliumConfig contains configuration specific to the Cilium CNI
+type CiliumConfig struct{}
+
+// KindnetdConfig contains configuration specific to the Kindnetd CNI
+type KindnetdConfig struct{}
+
+// CustomConfig marks the CNI as installed and upgraded by the user, EKS Anywhere only installs it with the manifest
+// when the cluster is created
+type CustomConfig struct {
+	// Manifest is the path or URL of the manifest that installs the CNI
+	Manifest string `json:"manifest"`
+}
+
+// +kubebuilder:object:root=true
+
+// Cluster is the Schema for the clusters API
+type Cluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSpec            `json:"spec,omitempty"`
+	Status v1alpha1.ClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterList contains a list of Cluster
+type ClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Cluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Cluster{}, &ClusterList{})
+}
@@ -0,0 +1,134 @@
+package v1beta1
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const defaultWorkerNodeGroupName = "md-0"
+
+// ConvertClusterConfig up-converts the v1alpha1 objects in a multi document cluster config to v1beta1.
+// Kinds without a v1beta1 version and documents from other api groups are kept as they are
+func ConvertClusterConfig(content []byte) ([]byte, error) {
+	docs, err := splitYamlDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]string, 0, len(docs))
+	for i, doc := range docs {
+		c, err := convertDocument(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %v", i, err)
+		}
+		converted = append(converted, strings.TrimSpace(string(c)))
+	}
+
+	return []byte(strings.Join(converted, v1alpha1.YamlSeparator) + "\n"), nil
+}
+
+func convertDocument(doc []byte) ([]byte, error) {
+	meta := &struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}{}
+	if err := yaml.Unmarshal(doc, meta); err != nil {
+		return nil, fmt.Errorf("unable to parse yaml: %v", err)
+	}
+	if meta.APIVersion != v1alpha1.GroupVersion.String() {
+		return doc, nil
+	}
+
+	var src conversion.Hub
+	var dst conversion.Convertible
+	switch meta.Kind {
+	case v1alpha1.ClusterKind:
+		src, dst = &v1alpha1.Cluster{}, &Cluster{}
+	case v1alpha1.VSphereDatacenterKind:
+		src, dst = &v1alpha1.VSphereDatacenterConfig{}, &VSphereDatacenterConfig{}
+	case v1alpha1.VSphereMachineConfigKind:
+		src, dst = &v1alpha1.VSphereMachineConfig{}, &VSphereMachineConfig{}
+	case v1alpha1.DockerDatacenterKind:
+		src, dst = &v1alpha1.DockerDatacenterConfig{}, &DockerDatacenterConfig{}
+	default:
+		return doc, nil
+	}
+
+	if err := yaml.UnmarshalStrict(doc, src); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", meta.Kind, err)
+	}
+	if cluster, ok := src.(*v1alpha1.Cluster); ok {
+		if err := setWorkerNodeGroupNames(cluster); err != nil {
+			return nil, err
+		}
+	}
+	if err := dst.ConvertFrom(src); err != nil {
+		return nil, fmt.Errorf("unable to convert %s: %v", meta.Kind, err)
+	}
+	dst.GetObjectKind().SetGroupVersionKind(GroupVersion.WithKind(meta.Kind))
+
+	return marshalConfig(dst)
+}
+
+// setWorkerNodeGroupNames applies the same default the cli uses for v1alpha1, since names are required in v1beta1
+func setWorkerNodeGroupNames(cluster *v1alpha1.Cluster) error {
+	groups := cluster.Spec.WorkerNodeGroupConfigurations
+	if len(groups) == 1 && groups[0].Name == "" {
+		groups[0].Name = defaultWorkerNodeGroupName
+	}
+
+	for i, g := range groups {
+		if g.Name == "" {
+			return fmt.Errorf("workerNodeGroupConfigurations[%d] needs a name to be converted to %s", i, GroupVersion)
+		}
+	}
+
+	return nil
+}
+
+// marshalConfig marshals the object as a cluster config, without status nor creation timestamp
+func marshalConfig(obj interface{}) ([]byte, error) {
+	j, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	if err = json.Unmarshal(j, &m); err != nil {
+		return nil, err
+	}
+	delete(m, "status")
+	if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+
+	return yaml.Marshal(m)
+}
+
+func splitYamlDocuments(content []byte) ([][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to split yaml documents: %v", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+}
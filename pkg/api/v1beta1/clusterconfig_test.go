@@ -0,0 +1,44 @@
+package v1beta1_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1beta1"
+)
+
+func TestConvertClusterConfig(t *testing.T) {
+	g := NewWithT(t)
+	content, err := ioutil.ReadFile("testdata/cluster_v1alpha1.yaml")
+	g.Expect(err).To(BeNil())
+
+	got, err := v1beta1.ConvertClusterConfig(content)
+	g.Expect(err).To(BeNil())
+	test.AssertContentToFile(t, string(got), "testdata/cluster_v1beta1_expected.yaml")
+}
+
+func TestConvertClusterConfigMissingWorkerNodeGroupNames(t *testing.T) {
+	g := NewWithT(t)
+	content, err := ioutil.ReadFile("testdata/cluster_unnamed_worker_node_groups.yaml")
+	g.Expect(err).To(BeNil())
+
+	_, err = v1beta1.ConvertClusterConfig(content)
+	g.Expect(err).To(MatchError(ContainSubstring("workerNodeGroupConfigurations[0] needs a name")))
+}
+
+func TestConvertClusterConfigUnknownField(t *testing.T) {
+	g := NewWithT(t)
+	content := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: eksa-unit-test
+spec:
+  unknownField: true
+`)
+
+	_, err := v1beta1.ConvertClusterConfig(content)
+	g.Expect(err).To(MatchError(ContainSubstring("unable to parse Cluster")))
+}
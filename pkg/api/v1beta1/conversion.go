@@ -0,0 +1,310 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	// cniAnnotation keeps v1alpha1 cni values that can't be represented in a v1beta1 cniConfig
+	cniAnnotation = "anywhere.eks.amazonaws.com/v1alpha1-cni"
+	// overrideClusterSpecFileAnnotation keeps the deprecated v1alpha1 overrideClusterSpecFile field
+	overrideClusterSpecFileAnnotation = "anywhere.eks.amazonaws.com/v1alpha1-override-cluster-spec-file"
+)
+
+var (
+	_ conversion.Convertible = &Cluster{}
+	_ conversion.Convertible = &VSphereDatacenterConfig{}
+	_ conversion.Convertible = &VSphereMachineConfig{}
+	_ conversion.Convertible = &DockerDatacenterConfig{}
+)
+
+// ConvertTo converts this Cluster to the Hub version (v1alpha1)
+func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.Cluster)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Cluster but got a %T", dstRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Status = in.Status
+	dst.Spec = v1alpha1.ClusterSpec{
+		KubernetesVersion:           in.Spec.KubernetesVersion,
+		ControlPlaneConfiguration:   in.Spec.ControlPlaneConfiguration,
+		DatacenterRef:               in.Spec.DatacenterRef,
+		IdentityProviderRefs:        in.Spec.IdentityProviderRefs,
+		GitOpsRef:                   in.Spec.GitOpsRef,
+		ExternalEtcdConfiguration:   in.Spec.ExternalEtcdConfiguration,
+		ProxyConfiguration:          in.Spec.ProxyConfiguration,
+		RegistryMirrorConfiguration: in.Spec.RegistryMirrorConfiguration,
+		ManagementCluster:           in.Spec.ManagementCluster,
+		PodIAMConfig:                in.Spec.PodIAMConfig,
+		ResourceTags:                in.Spec.ResourceTags,
+		ServiceLoadBalancer:         in.Spec.ServiceLoadBalancer,
+		LocalPathStorage:            in.Spec.LocalPathStorage,
+		FIPSEnabled:                 in.Spec.FIPSEnabled,
+		TLSPolicy:                   in.Spec.TLSPolicy,
+		PodSecurity:                 in.Spec.PodSecurity,
+		HostEntries:                 in.Spec.HostEntries,
+		MaintenanceWindows:          in.Spec.MaintenanceWindows,
+		Notifications:               in.Spec.Notifications,
+		DeletionProtection:          in.Spec.DeletionProtection,
+		ReadinessGates:              in.Spec.ReadinessGates,
+		CoreAddons:                  in.Spec.CoreAddons,
+		CoreDNS:                     in.Spec.CoreDNS,
+		Admission:                   in.Spec.Admission,
+		ResourceNaming:              in.Spec.ResourceNaming,
+		ClusterNetwork: v1alpha1.ClusterNetwork{
+			Pods:      in.Spec.ClusterNetwork.Pods,
+			Services:  in.Spec.ClusterNetwork.Services,
+			DNS:       in.Spec.ClusterNetwork.DNS,
+			KubeProxy: in.Spec.ClusterNetwork.KubeProxy,
+		},
+	}
+
+	for _, w := range in.Spec.WorkerNodeGroups {
+		dst.Spec.WorkerNodeGroupConfigurations = append(dst.Spec.WorkerNodeGroupConfigurations, v1alpha1.WorkerNodeGroupConfiguration{
+			Name:              w.Name,
+			Count:             w.Count,
+			MachineGroupRef:   w.MachineGroupRef,
+			Labels:            w.Labels,
+			KubernetesVersion: w.KubernetesVersion,
+		})
+	}
+
+	cni, err := cniFromConfig(in.Spec.ClusterNetwork.CNIConfig)
+	if err != nil {
+		return err
+	}
+	// the annotation is ignored if the cni has been configured since it was set
+	if c, ok := popAnnotation(&dst.ObjectMeta, cniAnnotation); ok && cni == "" {
+		cni = v1alpha1.CNI(c)
+	}
+	dst.Spec.ClusterNetwork.CNI = cni
+	if config := in.Spec.ClusterNetwork.CNIConfig; config != nil && config.Custom != nil {
+		dst.Spec.ClusterNetwork.CustomCNI = &v1alpha1.CustomCNIConfig{Manifest: config.Custom.Manifest}
+	}
+
+	dst.Spec.OverrideClusterSpecFile, _ = popAnnotation(&dst.ObjectMeta, overrideClusterSpecFileAnnotation)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *Cluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.Cluster)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Cluster but got a %T", srcRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Status = in.Status
+	dst.Spec = ClusterSpec{
+		KubernetesVersion:           in.Spec.KubernetesVersion,
+		ControlPlaneConfiguration:   in.Spec.ControlPlaneConfiguration,
+		DatacenterRef:               in.Spec.DatacenterRef,
+		IdentityProviderRefs:        in.Spec.IdentityProviderRefs,
+		GitOpsRef:                   in.Spec.GitOpsRef,
+		ExternalEtcdConfiguration:   in.Spec.ExternalEtcdConfiguration,
+		ProxyConfiguration:          in.Spec.ProxyConfiguration,
+		RegistryMirrorConfiguration: in.Spec.RegistryMirrorConfiguration,
+		ManagementCluster:           in.Spec.ManagementCluster,
+		PodIAMConfig:                in.Spec.PodIAMConfig,
+		ResourceTags:                in.Spec.ResourceTags,
+		ServiceLoadBalancer:         in.Spec.ServiceLoadBalancer,
+		LocalPathStorage:            in.Spec.LocalPathStorage,
+		FIPSEnabled:                 in.Spec.FIPSEnabled,
+		TLSPolicy:                   in.Spec.TLSPolicy,
+		PodSecurity:                 in.Spec.PodSecurity,
+		HostEntries:                 in.Spec.HostEntries,
+		MaintenanceWindows:          in.Spec.MaintenanceWindows,
+		Notifications:               in.Spec.Notifications,
+		DeletionProtection:          in.Spec.DeletionProtection,
+		ReadinessGates:              in.Spec.ReadinessGates,
+		CoreAddons:                  in.Spec.CoreAddons,
+		CoreDNS:                     in.Spec.CoreDNS,
+		Admission:                   in.Spec.Admission,
+		ResourceNaming:              in.Spec.ResourceNaming,
+		ClusterNetwork: ClusterNetwork{
+			Pods:      in.Spec.ClusterNetwork.Pods,
+			Services:  in.Spec.ClusterNetwork.Services,
+			DNS:       in.Spec.ClusterNetwork.DNS,
+			KubeProxy: in.Spec.ClusterNetwork.KubeProxy,
+		},
+	}
+
+	for _, w := range in.Spec.WorkerNodeGroupConfigurations {
+		dst.Spec.WorkerNodeGroups = append(dst.Spec.WorkerNodeGroups, WorkerNodeGroup{
+			Name:              w.Name,
+			Count:             w.Count,
+			MachineGroupRef:   w.MachineGroupRef,
+			Labels:            w.Labels,
+			KubernetesVersion: w.KubernetesVersion,
+		})
+	}
+
+	switch cni := in.Spec.ClusterNetwork.CNI; cni {
+	case "":
+	case v1alpha1.Cilium:
+		dst.Spec.ClusterNetwork.CNIConfig = &CNIConfig{Cilium: &CiliumConfig{}}
+	case v1alpha1.Kindnetd:
+		dst.Spec.ClusterNetwork.CNIConfig = &CNIConfig{Kindnetd: &KindnetdConfig{}}
+	case v1alpha1.Custom:
+		custom := &CustomConfig{}
+		if in.Spec.ClusterNetwork.CustomCNI != nil {
+			custom.Manifest = in.Spec.ClusterNetwork.CustomCNI.Manifest
+		}
+		dst.Spec.ClusterNetwork.CNIConfig = &CNIConfig{Custom: custom}
+	default:
+		setAnnotation(&dst.ObjectMeta, cniAnnotation, string(cni))
+	}
+
+	if in.Spec.OverrideClusterSpecFile != "" {
+		setAnnotation(&dst.ObjectMeta, overrideClusterSpecFileAnnotation, in.Spec.OverrideClusterSpecFile)
+	}
+
+	return nil
+}
+
+func cniFromConfig(config *CNIConfig) (v1alpha1.CNI, error) {
+	if config == nil {
+		return "", nil
+	}
+
+	configured := 0
+	for _, set := range []bool{config.Cilium != nil, config.Kindnetd != nil, config.Custom != nil} {
+		if set {
+			configured++
+		}
+	}
+
+	switch {
+	case configured > 1:
+		return "", fmt.Errorf("only one cni can be configured in cniConfig")
+	case config.Cilium != nil:
+		return v1alpha1.Cilium, nil
+	case config.Kindnetd != nil:
+		return v1alpha1.Kindnetd, nil
+	case config.Custom != nil:
+		return v1alpha1.Custom, nil
+	default:
+		return "", nil
+	}
+}
+
+func setAnnotation(meta *metav1.ObjectMeta, key, value string) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[key] = value
+}
+
+func popAnnotation(meta *metav1.ObjectMeta, key string) (string, bool) {
+	value, ok := meta.Annotations[key]
+	if !ok {
+		return "", false
+	}
+
+	delete(meta.Annotations, key)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	return value, true
+}
+
+// ConvertTo converts this VSphereDatacenterConfig to the Hub version (v1alpha1)
+func (src *VSphereDatacenterConfig) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.VSphereDatacenterConfig)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 VSphereDatacenterConfig but got a %T", dstRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = in.Spec
+	dst.Status = in.Status
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *VSphereDatacenterConfig) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.VSphereDatacenterConfig)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 VSphereDatacenterConfig but got a %T", srcRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = in.Spec
+	dst.Status = in.Status
+
+	return nil
+}
+
+// ConvertTo converts this VSphereMachineConfig to the Hub version (v1alpha1)
+func (src *VSphereMachineConfig) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.VSphereMachineConfig)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 VSphereMachineConfig but got a %T", dstRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = in.Spec
+	dst.Status = in.Status
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *VSphereMachineConfig) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.VSphereMachineConfig)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 VSphereMachineConfig but got a %T", srcRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = in.Spec
+	dst.Status = in.Status
+
+	return nil
+}
+
+// ConvertTo converts this DockerDatacenterConfig to the Hub version (v1alpha1)
+func (src *DockerDatacenterConfig) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.DockerDatacenterConfig)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 DockerDatacenterConfig but got a %T", dstRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = in.Spec
+	dst.Status = in.Status
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *DockerDatacenterConfig) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.DockerDatacenterConfig)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 DockerDatacenterConfig but got a %T", srcRaw)
+	}
+
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = in.Spec
+	dst.Status = in.Status
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package v1beta1_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1beta1"
+)
+
+func v1alpha1Cluster() *v1alpha1.Cluster {
+	kube121 := v1alpha1.Kube121
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "eksa-unit-test",
+			Namespace:   "eksa-system",
+			Annotations: map[string]string{"anywhere.eks.amazonaws.com/paused": "true"},
+		},
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion: v1alpha1.Kube121,
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Count:           3,
+				Endpoint:        &v1alpha1.Endpoint{Host: "1.2.3.4"},
+				MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "cp"},
+			},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:            "md-0",
+					Count:           3,
+					MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "workers"},
+					Labels:          map[string]string{"group": "md-0"},
+				},
+				{
+					Name:              "md-1",
+					Count:             1,
+					MachineGroupRef:   &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "workers"},
+					KubernetesVersion: &kube121,
+				},
+			},
+			DatacenterRef:        v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: "datacenter"},
+			IdentityProviderRefs: []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: "oidc"}},
+			GitOpsRef:            &v1alpha1.Ref{Kind: v1alpha1.GitOpsConfigKind, Name: "gitops"},
+			ClusterNetwork: v1alpha1.ClusterNetwork{
+				Pods:     v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+				Services: v1alpha1.Services{CidrBlocks: []string{"10.96.0.0/12"}},
+				CNI:      v1alpha1.Cilium,
+			},
+			ManagementCluster: v1alpha1.ManagementCluster{Name: "mgmt"},
+		},
+	}
+}
+
+func TestClusterConvertFrom(t *testing.T) {
+	g := NewWithT(t)
+	src := v1alpha1Cluster()
+	dst := &v1beta1.Cluster{}
+
+	g.Expect(dst.ConvertFrom(src)).To(Succeed())
+	g.Expect(dst.ObjectMeta).To(Equal(src.ObjectMeta))
+	g.Expect(dst.Spec.ClusterNetwork.CNIConfig).To(Equal(&v1beta1.CNIConfig{Cilium: &v1beta1.CiliumConfig{}}))
+	g.Expect(dst.Spec.WorkerNodeGroups).To(HaveLen(2))
+	g.Expect(dst.Spec.WorkerNodeGroups[0].Name).To(Equal("md-0"))
+	g.Expect(dst.Spec.WorkerNodeGroups[0].Labels).To(Equal(map[string]string{"group": "md-0"}))
+	g.Expect(*dst.Spec.WorkerNodeGroups[1].KubernetesVersion).To(Equal(v1alpha1.Kube121))
+	g.Expect(dst.Spec.DatacenterRef).To(Equal(src.Spec.DatacenterRef))
+}
+
+func TestClusterConvertFromDoesNotModifySource(t *testing.T) {
+	g := NewWithT(t)
+	src := v1alpha1Cluster()
+	src.Spec.ClusterNetwork.CNI = v1alpha1.CiliumEnterprise
+	dst := &v1beta1.Cluster{}
+
+	g.Expect(dst.ConvertFrom(src)).To(Succeed())
+	dst.Spec.WorkerNodeGroups[0].Labels["group"] = "changed"
+	g.Expect(src.Spec.WorkerNodeGroupConfigurations[0].Labels["group"]).To(Equal("md-0"))
+	g.Expect(src.Annotations).To(HaveLen(1))
+}
+
+func TestClusterRoundTripFromHub(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*v1alpha1.Cluster)
+	}{
+		{
+			name:   "cilium",
+			modify: func(c *v1alpha1.Cluster) {},
+		},
+		{
+			name: "kindnetd",
+			modify: func(c *v1alpha1.Cluster) {
+				c.Spec.ClusterNetwork.CNI = v1alpha1.Kindnetd
+			},
+		},
+		{
+			name: "custom",
+			modify: func(c *v1alpha1.Cluster) {
+				c.Spec.ClusterNetwork.CNI = v1alpha1.Custom
+				c.Spec.ClusterNetwork.CustomCNI = &v1alpha1.CustomCNIConfig{Manifest: "calico.yaml"}
+			},
+		},
+		{
+			name: "cni not supported in cniConfig",
+			modify: func(c *v1alpha1.Cluster) {
+				c.Spec.ClusterNetwork.CNI = v1alpha1.CiliumEnterprise
+			},
+		},
+		{
+			name: "no cni",
+			modify: func(c *v1alpha1.Cluster) {
+				c.Spec.ClusterNetwork.CNI = ""
+			},
+		},
+		{
+			name: "deprecated override cluster spec file",
+			modify: func(c *v1alpha1.Cluster) {
+				c.Spec.OverrideClusterSpecFile = "override.yaml"
+			},
+		},
+		{
+			name: "no annotations",
+			modify: func(c *v1alpha1.Cluster) {
+				c.Annotations = nil
+				c.Spec.ClusterNetwork.CNI = v1alpha1.CiliumEnterprise
+				c.Spec.OverrideClusterSpecFile = "override.yaml"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			original := v1alpha1Cluster()
+			tt.modify(original)
+
+			spoke := &v1beta1.Cluster{}
+			g.Expect(spoke.ConvertFrom(original)).To(Succeed())
+			hub := &v1alpha1.Cluster{}
+			g.Expect(spoke.ConvertTo(hub)).To(Succeed())
+
+			g.Expect(hub).To(Equal(original))
+		})
+	}
+}
+
+func TestClusterRoundTripFromSpoke(t *testing.T) {
+	g := NewWithT(t)
+	hub := v1alpha1Cluster()
+	original := &v1beta1.Cluster{}
+	g.Expect(original.ConvertFrom(hub)).To(Succeed())
+	original.Spec.ClusterNetwork.CNIConfig = &v1beta1.CNIConfig{Kindnetd: &v1beta1.KindnetdConfig{}}
+
+	converted := &v1alpha1.Cluster{}
+	g.Expect(original.ConvertTo(converted)).To(Succeed())
+	spoke := &v1beta1.Cluster{}
+	g.Expect(spoke.ConvertFrom(converted)).To(Succeed())
+
+	g.Expect(spoke).To(Equal(original))
+}
+
+func TestClusterConvertToCNIConfigOverridesAnnotation(t *testing.T) {
+	g := NewWithT(t)
+	src := v1alpha1Cluster()
+	src.Spec.ClusterNetwork.CNI = v1alpha1.CiliumEnterprise
+	spoke := &v1beta1.Cluster{}
+	g.Expect(spoke.ConvertFrom(src)).To(Succeed())
+	spoke.Spec.ClusterNetwork.CNIConfig = &v1beta1.CNIConfig{Kindnetd: &v1beta1.KindnetdConfig{}}
+
+	hub := &v1alpha1.Cluster{}
+	g.Expect(spoke.ConvertTo(hub)).To(Succeed())
+	g.Expect(hub.Spec.ClusterNetwork.CNI).To(Equal(v1alpha1.Kindnetd))
+	g.Expect(hub.Annotations).To(Equal(src.Annotations))
+}
+
+func TestClusterConvertToMultipleCNIs(t *testing.T) {
+	g := NewWithT(t)
+	spoke := &v1beta1.Cluster{}
+	spoke.Spec.ClusterNetwork.CNIConfig = &v1beta1.CNIConfig{
+		Cilium:   &v1beta1.CiliumConfig{},
+		Kindnetd: &v1beta1.KindnetdConfig{},
+	}
+
+	g.Expect(spoke.ConvertTo(&v1alpha1.Cluster{})).To(MatchError(ContainSubstring("only one cni")))
+}
+
+func TestProviderConfigsRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	meta := metav1.ObjectMeta{Name: "test", Namespace: "eksa-system", Annotations: map[string]string{"a": "b"}}
+	failure := "failed"
+
+	datacenter := &v1alpha1.VSphereDatacenterConfig{
+		ObjectMeta: meta,
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{
+			Datacenter: "SDDC-Datacenter",
+			Network:    "/SDDC-Datacenter/network/sddc-cgw-network-1",
+			Server:     "vsphere_server",
+			Thumbprint: "ABCDEFG",
+			Insecure:   true,
+		},
+		Status: v1alpha1.VSphereDatacenterConfigStatus{FailureMessage: &failure},
+	}
+	datacenterSpoke := &v1beta1.VSphereDatacenterConfig{}
+	g.Expect(datacenterSpoke.ConvertFrom(datacenter)).To(Succeed())
+	datacenterHub := &v1alpha1.VSphereDatacenterConfig{}
+	g.Expect(datacenterSpoke.ConvertTo(datacenterHub)).To(Succeed())
+	g.Expect(datacenterHub).To(Equal(datacenter))
+
+	machineConfig := &v1alpha1.VSphereMachineConfig{
+		ObjectMeta: meta,
+		Spec: v1alpha1.VSphereMachineConfigSpec{
+			DiskGiB:   25,
+			MemoryMiB: 8192,
+			NumCPUs:   2,
+			OSFamily:  v1alpha1.Ubuntu,
+			Users:     []v1alpha1.UserConfiguration{{Name: "capv", SshAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+		},
+	}
+	machineConfigSpoke := &v1beta1.VSphereMachineConfig{}
+	g.Expect(machineConfigSpoke.ConvertFrom(machineConfig)).To(Succeed())
+	machineConfigHub := &v1alpha1.VSphereMachineConfig{}
+	g.Expect(machineConfigSpoke.ConvertTo(machineConfigHub)).To(Succeed())
+	g.Expect(machineConfigHub).To(Equal(machineConfig))
+
+	docker := &v1alpha1.DockerDatacenterConfig{ObjectMeta: meta}
+	dockerSpoke := &v1beta1.DockerDatacenterConfig{}
+	g.Expect(dockerSpoke.ConvertFrom(docker)).To(Succeed())
+	dockerHub := &v1alpha1.DockerDatacenterConfig{}
+	g.Expect(dockerSpoke.ConvertTo(dockerHub)).To(Succeed())
+	g.Expect(dockerHub).To(Equal(docker))
+}
+
+func TestConvertToWrongHubType(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect((&v1beta1.Cluster{}).ConvertTo(&v1alpha1.DockerDatacenterConfig{})).NotTo(Succeed())
+	g.Expect((&v1beta1.DockerDatacenterConfig{}).ConvertFrom(&v1alpha1.Cluster{})).NotTo(Succeed())
+}
@@ -0,0 +1,34 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// DockerDatacenterConfig is the Schema for the DockerDatacenterConfigs API
+type DockerDatacenterConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   v1alpha1.DockerDatacenterConfigSpec   `json:"spec,omitempty"`
+	Status v1alpha1.DockerDatacenterConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DockerDatacenterConfigList contains a list of DockerDatacenterConfig
+type DockerDatacenterConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DockerDatacenterConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DockerDatacenterConfig{}, &DockerDatacenterConfigList{})
+}
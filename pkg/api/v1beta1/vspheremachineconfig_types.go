@@ -0,0 +1,34 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// VSphereMachineConfig is the Schema for the vspheremachineconfigs API
+type VSphereMachineConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   v1alpha1.VSphereMachineConfigSpec   `json:"spec,omitempty"`
+	Status v1alpha1.VSphereMachineConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VSphereMachineConfigList contains a list of VSphereMachineConfig
+type VSphereMachineConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VSphereMachineConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VSphereMachineConfig{}, &VSphereMachineConfigList{})
+}
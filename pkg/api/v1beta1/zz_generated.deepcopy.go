@@ -0,0 +1,524 @@
+// +build !ignore_autogenerated
+
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNIConfig) DeepCopyInto(out *CNIConfig) {
+	*out = *in
+	if in.Cilium != nil {
+		in, out := &in.Cilium, &out.Cilium
+		*out = new(CiliumConfig)
+		**out = **in
+	}
+	if in.Kindnetd != nil {
+		in, out := &in.Kindnetd, &out.Kindnetd
+		*out = new(KindnetdConfig)
+		**out = **in
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIConfig.
+func (in *CNIConfig) DeepCopy() *CNIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CNIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumConfig.
+func (in *CiliumConfig) DeepCopy() *CiliumConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cluster) DeepCopyInto(out *Cluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
+func (in *Cluster) DeepCopy() *Cluster {
+	if in == nil {
+		return nil
+	}
+	out := new(Cluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Cluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterList) DeepCopyInto(out *ClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Cluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
+func (in *ClusterList) DeepCopy() *ClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
+	*out = *in
+	in.Pods.DeepCopyInto(&out.Pods)
+	in.Services.DeepCopyInto(&out.Services)
+	if in.CNIConfig != nil {
+		in, out := &in.CNIConfig, &out.CNIConfig
+		*out = new(CNIConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.DNS.DeepCopyInto(&out.DNS)
+	if in.KubeProxy != nil {
+		in, out := &in.KubeProxy, &out.KubeProxy
+		*out = new(v1alpha1.KubeProxyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetwork.
+func (in *ClusterNetwork) DeepCopy() *ClusterNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+	in.ControlPlaneConfiguration.DeepCopyInto(&out.ControlPlaneConfiguration)
+	if in.WorkerNodeGroups != nil {
+		in, out := &in.WorkerNodeGroups, &out.WorkerNodeGroups
+		*out = make([]WorkerNodeGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.DatacenterRef = in.DatacenterRef
+	if in.IdentityProviderRefs != nil {
+		in, out := &in.IdentityProviderRefs, &out.IdentityProviderRefs
+		*out = make([]v1alpha1.Ref, len(*in))
+		copy(*out, *in)
+	}
+	if in.GitOpsRef != nil {
+		in, out := &in.GitOpsRef, &out.GitOpsRef
+		*out = new(v1alpha1.Ref)
+		**out = **in
+	}
+	in.ClusterNetwork.DeepCopyInto(&out.ClusterNetwork)
+	if in.ExternalEtcdConfiguration != nil {
+		in, out := &in.ExternalEtcdConfiguration, &out.ExternalEtcdConfiguration
+		*out = new(v1alpha1.ExternalEtcdConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyConfiguration != nil {
+		in, out := &in.ProxyConfiguration, &out.ProxyConfiguration
+		*out = new(v1alpha1.ProxyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryMirrorConfiguration != nil {
+		in, out := &in.RegistryMirrorConfiguration, &out.RegistryMirrorConfiguration
+		*out = new(v1alpha1.RegistryMirrorConfiguration)
+		**out = **in
+	}
+	out.ManagementCluster = in.ManagementCluster
+	if in.PodIAMConfig != nil {
+		in, out := &in.PodIAMConfig, &out.PodIAMConfig
+		*out = new(v1alpha1.PodIAMConfig)
+		**out = **in
+	}
+	if in.ResourceTags != nil {
+		in, out := &in.ResourceTags, &out.ResourceTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceLoadBalancer != nil {
+		in, out := &in.ServiceLoadBalancer, &out.ServiceLoadBalancer
+		*out = new(v1alpha1.ServiceLoadBalancerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalPathStorage != nil {
+		in, out := &in.LocalPathStorage, &out.LocalPathStorage
+		*out = new(v1alpha1.LocalPathStorageConfiguration)
+		**out = **in
+	}
+	if in.TLSPolicy != nil {
+		in, out := &in.TLSPolicy, &out.TLSPolicy
+		*out = new(v1alpha1.TLSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(v1alpha1.PodSecurityConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostEntries != nil {
+		in, out := &in.HostEntries, &out.HostEntries
+		*out = make([]v1alpha1.HostEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]v1alpha1.MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(v1alpha1.NotificationsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]v1alpha1.ReadinessGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CoreAddons != nil {
+		in, out := &in.CoreAddons, &out.CoreAddons
+		*out = new(v1alpha1.CoreAddonsConfiguration)
+		**out = **in
+	}
+	if in.CoreDNS != nil {
+		in, out := &in.CoreDNS, &out.CoreDNS
+		*out = new(v1alpha1.CoreDNSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(v1alpha1.AdmissionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceNaming != nil {
+		in, out := &in.ResourceNaming, &out.ResourceNaming
+		*out = new(v1alpha1.ResourceNamingConfiguration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerDatacenterConfig) DeepCopyInto(out *DockerDatacenterConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerDatacenterConfig.
+func (in *DockerDatacenterConfig) DeepCopy() *DockerDatacenterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerDatacenterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DockerDatacenterConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerDatacenterConfigList) DeepCopyInto(out *DockerDatacenterConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DockerDatacenterConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerDatacenterConfigList.
+func (in *DockerDatacenterConfigList) DeepCopy() *DockerDatacenterConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerDatacenterConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DockerDatacenterConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomConfig) DeepCopyInto(out *CustomConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomConfig.
+func (in *CustomConfig) DeepCopy() *CustomConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KindnetdConfig) DeepCopyInto(out *KindnetdConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KindnetdConfig.
+func (in *KindnetdConfig) DeepCopy() *KindnetdConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KindnetdConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereDatacenterConfig) DeepCopyInto(out *VSphereDatacenterConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDatacenterConfig.
+func (in *VSphereDatacenterConfig) DeepCopy() *VSphereDatacenterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereDatacenterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereDatacenterConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereDatacenterConfigList) DeepCopyInto(out *VSphereDatacenterConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereDatacenterConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDatacenterConfigList.
+func (in *VSphereDatacenterConfigList) DeepCopy() *VSphereDatacenterConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereDatacenterConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereDatacenterConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachineConfig) DeepCopyInto(out *VSphereMachineConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineConfig.
+func (in *VSphereMachineConfig) DeepCopy() *VSphereMachineConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachineConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereMachineConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachineConfigList) DeepCopyInto(out *VSphereMachineConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereMachineConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineConfigList.
+func (in *VSphereMachineConfigList) DeepCopy() *VSphereMachineConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachineConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereMachineConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerNodeGroup) DeepCopyInto(out *WorkerNodeGroup) {
+	*out = *in
+	if in.MachineGroupRef != nil {
+		in, out := &in.MachineGroupRef, &out.MachineGroupRef
+		*out = new(v1alpha1.Ref)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubernetesVersion != nil {
+		in, out := &in.KubernetesVersion, &out.KubernetesVersion
+		*out = new(v1alpha1.KubernetesVersion)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroup.
+func (in *WorkerNodeGroup) DeepCopy() *WorkerNodeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerNodeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,222 @@
+package applier
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	defaultRetryTimeout     = 5 * time.Minute
+	defaultRetryBackoff     = 5 * time.Second
+	defaultEstablishTimeout = "2m"
+)
+
+type Client interface {
+	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
+	WaitForCRDsEstablished(ctx context.Context, cluster *types.Cluster, timeout string, crds ...string) error
+}
+
+// phase groups the kinds that need to exist before the kinds of the next phases can be created
+type phase int
+
+const (
+	crdsPhase phase = iota
+	namespacesPhase
+	rbacPhase
+	workloadsPhase
+	numPhases
+)
+
+var phaseNames = [numPhases]string{"CRDs", "namespaces", "RBAC", "workloads"}
+
+var rbacKinds = map[string]struct{}{
+	"ServiceAccount":     {},
+	"Role":               {},
+	"ClusterRole":        {},
+	"RoleBinding":        {},
+	"ClusterRoleBinding": {},
+}
+
+// permanentErrors are the apply errors retrying can't fix, because the manifest or the change it makes is rejected.
+// Any other error, like a CRD not served yet, a webhook without ready endpoints, an unknown certificate authority
+// while the API server rotates its certs or a dropped connection, is retried
+var permanentErrors = []string{
+	"is invalid",
+	"field is immutable",
+	"denied the request",
+	"error validating data",
+	"error parsing",
+	"unknown field",
+}
+
+// Applier applies multi-document manifests in dependency order: CRDs, namespaces, RBAC and the rest of the objects.
+// It waits for the CRDs to be established before applying the objects that might use them and retries the
+// failed applies, except for the permanent errors, which are returned right away
+type Applier struct {
+	client           Client
+	retrier          *retrier.Retrier
+	establishTimeout string
+}
+
+type ApplierOpt func(*Applier)
+
+// WithRetryPolicy sets for how long the failed applies are retried and the wait between retries
+func WithRetryPolicy(timeout, backoff time.Duration) ApplierOpt {
+	return func(a *Applier) {
+		a.retrier = newRetrier(timeout, backoff)
+	}
+}
+
+// WithRetrier sets the retrier for the failed applies. The permanent errors are still returned right away
+func WithRetrier(r *retrier.Retrier) ApplierOpt {
+	return func(a *Applier) {
+		a.retrier = r
+	}
+}
+
+// WithEstablishTimeout sets the maximum time to wait for the CRDs to be established, in kubectl duration format
+func WithEstablishTimeout(timeout string) ApplierOpt {
+	return func(a *Applier) {
+		a.establishTimeout = timeout
+	}
+}
+
+func New(client Client, opts ...ApplierOpt) *Applier {
+	a := &Applier{
+		client:           client,
+		retrier:          newRetrier(defaultRetryTimeout, defaultRetryBackoff),
+		establishTimeout: defaultEstablishTimeout,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Apply applies all the objects in manifest to the cluster, one phase at a time
+func (a *Applier) Apply(ctx context.Context, cluster *types.Cluster, manifest []byte) error {
+	phases, crds, err := splitInPhases(manifest)
+	if err != nil {
+		return err
+	}
+
+	for p, objs := range phases {
+		if len(objs) == 0 {
+			continue
+		}
+
+		logger.V(4).Info("Applying objects", "phase", phaseNames[p], "count", len(objs))
+		if err = a.apply(ctx, cluster, templater.AppendYamlResources(objs...)); err != nil {
+			return fmt.Errorf("error applying %s: %v", phaseNames[p], err)
+		}
+
+		if phase(p) == crdsPhase {
+			logger.V(4).Info("Waiting for CRDs to be established", "count", len(crds))
+			if err = a.client.WaitForCRDsEstablished(ctx, cluster, a.establishTimeout, crds...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *Applier) apply(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	var permanentErr error
+	err := a.retrier.RetryWithContext(ctx, func() error {
+		err := a.client.ApplyKubeSpecFromBytesForce(ctx, cluster, data)
+		if err != nil && !IsTransientError(err) {
+			// Stop retrying, the error is returned below
+			permanentErr = err
+			return nil
+		}
+		return err
+	})
+	if permanentErr != nil {
+		return permanentErr
+	}
+
+	return err
+}
+
+func newRetrier(timeout, backoff time.Duration) *retrier.Retrier {
+	return retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, backoff
+	}))
+}
+
+// IsTransientError returns true if the apply error might go away by retrying, which is every error but the permanent ones
+func IsTransientError(err error) bool {
+	for _, msg := range permanentErrors {
+		if strings.Contains(err.Error(), msg) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitInPhases returns the documents in manifest grouped by phase, keeping their order, and the names of the CRDs
+func splitInPhases(manifest []byte) ([numPhases][][]byte, []string, error) {
+	var phases [numPhases][][]byte
+	var crds []string
+
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return phases, nil, fmt.Errorf("error reading manifest: %v", err)
+		}
+
+		// Unstructured can't decode objects without kind, those are left to the server and applied with the workloads
+		content := map[string]interface{}{}
+		if err = yaml.Unmarshal(doc, &content); err != nil {
+			return phases, nil, fmt.Errorf("error parsing object in manifest: %v", err)
+		}
+		if len(content) == 0 {
+			continue
+		}
+		obj := &unstructured.Unstructured{Object: content}
+
+		p := phaseFor(obj)
+		if p == crdsPhase {
+			crds = append(crds, obj.GetName())
+		}
+		phases[p] = append(phases[p], bytes.TrimSpace(doc))
+	}
+
+	return phases, crds, nil
+}
+
+func phaseFor(obj *unstructured.Unstructured) phase {
+	kind := obj.GetKind()
+	switch kind {
+	case "CustomResourceDefinition":
+		return crdsPhase
+	case "Namespace":
+		return namespacesPhase
+	}
+
+	if _, ok := rbacKinds[kind]; ok {
+		return rbacPhase
+	}
+	return workloadsPhase
+}
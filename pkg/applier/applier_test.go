@@ -0,0 +1,197 @@
+package applier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/applier"
+	"github.com/aws/eks-anywhere/pkg/applier/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const manifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: eksa-system
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: controller
+  namespace: eksa-system
+---
+# comment only document
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: clusters.anywhere.eks.amazonaws.com
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: eksa-system
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: mgmt
+`
+
+const crds = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: clusters.anywhere.eks.amazonaws.com
+---
+`
+
+const namespaces = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: eksa-system
+---
+`
+
+const rbac = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: controller
+  namespace: eksa-system
+---
+`
+
+const workloads = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: eksa-system
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: mgmt
+---
+`
+
+type applierTest struct {
+	*WithT
+	ctx     context.Context
+	client  *mocks.MockClient
+	cluster *types.Cluster
+	applier *applier.Applier
+}
+
+func newApplierTest(t *testing.T) *applierTest {
+	client := mocks.NewMockClient(gomock.NewController(t))
+	return &applierTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  client,
+		cluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		applier: applier.New(client, applier.WithRetryPolicy(time.Second, 0), applier.WithEstablishTimeout("1m")),
+	}
+}
+
+func (tt *applierTest) expectApply(manifest string) *gomock.Call {
+	return tt.client.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, []byte(manifest))
+}
+
+func TestApplierApplyInOrder(t *testing.T) {
+	tt := newApplierTest(t)
+	gomock.InOrder(
+		tt.expectApply(crds),
+		tt.client.EXPECT().WaitForCRDsEstablished(tt.ctx, tt.cluster, "1m", "clusters.anywhere.eks.amazonaws.com"),
+		tt.expectApply(namespaces),
+		tt.expectApply(rbac),
+		tt.expectApply(workloads),
+	)
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte(manifest))).To(Succeed())
+}
+
+func TestApplierApplyWithoutCRDs(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.expectApply(rbac)
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte(rbac))).To(Succeed())
+}
+
+func TestApplierApplyRetriesTransientErrors(t *testing.T) {
+	tt := newApplierTest(t)
+	gomock.InOrder(
+		tt.expectApply(workloads).Return(errors.New(`Internal error occurred: failed calling webhook "validation.cluster.anywhere.amazonaws.com"`)),
+		tt.expectApply(workloads).Return(errors.New(`no matches for kind "Cluster" in version "anywhere.eks.amazonaws.com/v1alpha1"`)),
+		tt.expectApply(workloads).Return(errors.New("Unable to connect to the server: x509: certificate signed by unknown authority")),
+		tt.expectApply(workloads).Return(errors.New("Error from server (ServiceUnavailable): the server is currently unable to handle the request")),
+		tt.expectApply(workloads).Return(errors.New("unexpected EOF")),
+		tt.expectApply(workloads),
+	)
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte(workloads))).To(Succeed())
+}
+
+func TestApplierApplyPermanentError(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.expectApply(workloads).Return(errors.New("field is immutable")).Times(1)
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte(workloads))).To(MatchError("error applying workloads: field is immutable"))
+}
+
+func TestApplierApplyWithRetrierPermanentError(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.applier = applier.New(tt.client, applier.WithRetrier(retrier.NewWithMaxRetries(5, 0)))
+	tt.expectApply(workloads).Return(errors.New("field is immutable")).Times(1)
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte(workloads))).To(MatchError("error applying workloads: field is immutable"))
+}
+
+func TestApplierApplyWithRetrierMaxRetries(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.applier = applier.New(tt.client, applier.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+	tt.expectApply(workloads).Return(errors.New("unexpected EOF")).Times(2)
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte(workloads))).To(MatchError("error applying workloads: unexpected EOF"))
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err  string
+		want bool
+	}{
+		{err: "connection refused", want: true},
+		{err: "x509: certificate signed by unknown authority", want: true},
+		{err: "the server is currently unable to handle the request", want: true},
+		{err: "EOF", want: true},
+		{err: `The Cluster "test" is invalid: spec.controlPlaneConfiguration.count: Invalid value: 2`, want: false},
+		{err: `admission webhook "validation.cluster.anywhere.amazonaws.com" denied the request: kubernetesVersion is immutable`, want: false},
+		{err: "spec.selector: Invalid value: field is immutable", want: false},
+		{err: `error validating data: ValidationError(Deployment.spec): unknown field "replica"`, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.err, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(applier.IsTransientError(errors.New(tc.err))).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestApplierApplyCRDsNotEstablished(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.expectApply(crds)
+	tt.client.EXPECT().WaitForCRDsEstablished(tt.ctx, tt.cluster, "1m", "clusters.anywhere.eks.amazonaws.com").Return(errors.New("timed out"))
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte(crds+workloads))).To(MatchError("timed out"))
+}
+
+func TestApplierApplyInvalidManifest(t *testing.T) {
+	tt := newApplierTest(t)
+
+	tt.Expect(tt.applier.Apply(tt.ctx, tt.cluster, []byte("kind: [Cluster"))).To(MatchError(ContainSubstring("error parsing object in manifest")))
+}
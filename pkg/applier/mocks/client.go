@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/applier/applier.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytesForce mocks base method.
+func (m *MockClient) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytesForce", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytesForce indicates an expected call of ApplyKubeSpecFromBytesForce.
+func (mr *MockClientMockRecorder) ApplyKubeSpecFromBytesForce(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesForce", reflect.TypeOf((*MockClient)(nil).ApplyKubeSpecFromBytesForce), ctx, cluster, data)
+}
+
+// WaitForCRDsEstablished mocks base method.
+func (m *MockClient) WaitForCRDsEstablished(ctx context.Context, cluster *types.Cluster, timeout string, crds ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, cluster, timeout}
+	for _, a := range crds {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForCRDsEstablished", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForCRDsEstablished indicates an expected call of WaitForCRDsEstablished.
+func (mr *MockClientMockRecorder) WaitForCRDsEstablished(ctx, cluster, timeout interface{}, crds ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, cluster, timeout}, crds...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCRDsEstablished", reflect.TypeOf((*MockClient)(nil).WaitForCRDsEstablished), varargs...)
+}
@@ -0,0 +1,30 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+func (l *LocalStore) Put(ctx context.Context, key string, content []byte) error {
+	path := filepath.Join(l.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating artifact directory [%s]: %v", filepath.Dir(path), err)
+	}
+
+	if err := ioutil.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed writing artifact [%s]: %v", path, err)
+	}
+
+	return nil
+}
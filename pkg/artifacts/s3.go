@@ -0,0 +1,41 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/aws/eks-anywhere/internal/pkg/s3"
+)
+
+type S3Store struct {
+	session *session.Session
+	bucket  string
+	prefix  string
+}
+
+// NewS3Store builds a Store backed by an s3 bucket. If endpoint is not empty, requests are sent to it
+// with path style addressing, which allows to use s3 compatible services like MinIO
+func NewS3Store(bucket, prefix, endpoint string) (*S3Store, error) {
+	config := aws.Config{}
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating aws session for artifact store: %v", err)
+	}
+
+	return &S3Store{session: sess, bucket: bucket, prefix: prefix}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, content []byte) error {
+	return s3.UploadWithContext(ctx, s.session, content, joinKey(s.prefix, key), s.bucket)
+}
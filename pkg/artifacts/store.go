@@ -0,0 +1,103 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// StoreEnvVar holds the location where the cli uploads generated artifacts and logs.
+	// It can be a local directory or an s3 url in the form s3://bucket/prefix
+	StoreEnvVar = "EKSA_ARTIFACT_STORE"
+	// S3EndpointEnvVar allows to use an s3 compatible service like MinIO as artifact store
+	S3EndpointEnvVar = "EKSA_ARTIFACT_STORE_S3_ENDPOINT"
+
+	s3Scheme = "s3"
+)
+
+// Store persists artifacts under a key, keys use / as separator independently of the backend
+type Store interface {
+	Put(ctx context.Context, key string, content []byte) error
+}
+
+// NewStore builds a Store for location. s3://bucket/prefix locations use S3, anything else is treated as a local directory
+func NewStore(location string) (Store, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact store location [%s]: %v", location, err)
+	}
+
+	if u.Scheme == s3Scheme {
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid artifact store location [%s]: missing s3 bucket", location)
+		}
+		return NewS3Store(u.Host, strings.Trim(u.Path, "/"), os.Getenv(S3EndpointEnvVar))
+	}
+
+	return NewLocalStore(location), nil
+}
+
+// NewStoreFromEnv builds a Store from StoreEnvVar. It returns nil if the env var is not set
+func NewStoreFromEnv() (Store, error) {
+	location, ok := os.LookupEnv(StoreEnvVar)
+	if !ok || len(location) == 0 {
+		return nil, nil
+	}
+
+	return NewStore(location)
+}
+
+// UploadDir puts all the files under dir in store, using prefix plus the file path relative to dir as key.
+// A missing dir is not considered an error
+func UploadDir(ctx context.Context, store Store, dir, prefix string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed reading artifact [%s]: %v", path, err)
+		}
+
+		return store.Put(ctx, joinKey(prefix, filepath.ToSlash(rel)), content)
+	})
+}
+
+// UploadFile puts the file in store, using prefix plus the file name as key
+func UploadFile(ctx context.Context, store Store, file, prefix string) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed reading artifact [%s]: %v", file, err)
+	}
+
+	return store.Put(ctx, joinKey(prefix, filepath.Base(file)), content)
+}
+
+func joinKey(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.Trim(p, "/"); p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+
+	return strings.Join(nonEmpty, "/")
+}
@@ -0,0 +1,117 @@
+package artifacts_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/artifacts"
+)
+
+func TestNewStore(t *testing.T) {
+	tests := []struct {
+		testName string
+		location string
+		want     interface{}
+	}{
+		{
+			testName: "local dir",
+			location: "/tmp/artifacts",
+			want:     &artifacts.LocalStore{},
+		},
+		{
+			testName: "s3 bucket",
+			location: "s3://bucket/prefix",
+			want:     &artifacts.S3Store{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			store, err := artifacts.NewStore(tt.location)
+			g.Expect(err).To(BeNil())
+			g.Expect(store).To(BeAssignableToTypeOf(tt.want))
+		})
+	}
+}
+
+func TestNewStoreError(t *testing.T) {
+	tests := []struct {
+		testName string
+		location string
+	}{
+		{
+			testName: "invalid url",
+			location: ":s3/bucket",
+		},
+		{
+			testName: "missing s3 bucket",
+			location: "s3:///prefix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := artifacts.NewStore(tt.location)
+			g.Expect(err).NotTo(BeNil())
+		})
+	}
+}
+
+func TestNewStoreFromEnvNotSet(t *testing.T) {
+	g := NewWithT(t)
+	os.Unsetenv(artifacts.StoreEnvVar)
+	store, err := artifacts.NewStoreFromEnv()
+	g.Expect(err).To(BeNil())
+	g.Expect(store).To(BeNil())
+}
+
+func TestUploadDir(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(dir, "generated"), os.ModePerm)).To(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(dir, "cluster.yaml"), []byte("cluster"), 0o644)).To(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(dir, "generated", "checkpoint.yaml"), []byte("checkpoint"), 0o644)).To(Succeed())
+
+	storeDir := t.TempDir()
+	store := artifacts.NewLocalStore(storeDir)
+	g.Expect(artifacts.UploadDir(context.Background(), store, dir, "my-cluster/run")).To(Succeed())
+
+	assertFileContent(g, filepath.Join(storeDir, "my-cluster", "run", "cluster.yaml"), "cluster")
+	assertFileContent(g, filepath.Join(storeDir, "my-cluster", "run", "generated", "checkpoint.yaml"), "checkpoint")
+}
+
+func TestUploadDirMissingDir(t *testing.T) {
+	g := NewWithT(t)
+	store := artifacts.NewLocalStore(t.TempDir())
+	g.Expect(artifacts.UploadDir(context.Background(), store, "fake-dir", "prefix")).To(Succeed())
+}
+
+func TestUploadFile(t *testing.T) {
+	g := NewWithT(t)
+	file := filepath.Join(t.TempDir(), "support-bundle.tar.gz")
+	g.Expect(ioutil.WriteFile(file, []byte("bundle"), 0o644)).To(Succeed())
+
+	storeDir := t.TempDir()
+	g.Expect(artifacts.UploadFile(context.Background(), artifacts.NewLocalStore(storeDir), file, "my-cluster")).To(Succeed())
+
+	assertFileContent(g, filepath.Join(storeDir, "my-cluster", "support-bundle.tar.gz"), "bundle")
+}
+
+func TestUploadFileMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	store := artifacts.NewLocalStore(t.TempDir())
+	g.Expect(artifacts.UploadFile(context.Background(), store, "fake-file", "prefix")).NotTo(Succeed())
+}
+
+func assertFileContent(g *WithT, path, want string) {
+	got, err := ioutil.ReadFile(path)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal(want))
+}
@@ -0,0 +1,29 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// DirUploader uploads the content of a directory to a Store when closed,
+// so artifacts are persisted even if the command fails
+type DirUploader struct {
+	store  Store
+	dir    string
+	prefix string
+}
+
+func NewDirUploader(store Store, dir, prefix string) *DirUploader {
+	return &DirUploader{store: store, dir: dir, prefix: prefix}
+}
+
+func (u *DirUploader) Close(ctx context.Context) error {
+	logger.V(4).Info("Uploading artifacts", "dir", u.dir, "prefix", u.prefix)
+	if err := UploadDir(ctx, u.store, u.dir, u.prefix); err != nil {
+		return fmt.Errorf("failed uploading artifacts from [%s]: %v", u.dir, err)
+	}
+
+	return nil
+}
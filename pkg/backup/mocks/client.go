@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/backup/workload.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVeleroClient is a mock of VeleroClient interface.
+type MockVeleroClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockVeleroClientMockRecorder
+}
+
+// MockVeleroClientMockRecorder is the mock recorder for MockVeleroClient.
+type MockVeleroClientMockRecorder struct {
+	mock *MockVeleroClient
+}
+
+// NewMockVeleroClient creates a new mock instance.
+func NewMockVeleroClient(ctrl *gomock.Controller) *MockVeleroClient {
+	mock := &MockVeleroClient{ctrl: ctrl}
+	mock.recorder = &MockVeleroClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVeleroClient) EXPECT() *MockVeleroClientMockRecorder {
+	return m.recorder
+}
+
+// CreateBackup mocks base method.
+func (m *MockVeleroClient) CreateBackup(ctx context.Context, cluster *types.Cluster, name string, namespaces []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBackup", ctx, cluster, name, namespaces)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBackup indicates an expected call of CreateBackup.
+func (mr *MockVeleroClientMockRecorder) CreateBackup(ctx, cluster, name, namespaces interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBackup", reflect.TypeOf((*MockVeleroClient)(nil).CreateBackup), ctx, cluster, name, namespaces)
+}
+
+// Restore mocks base method.
+func (m *MockVeleroClient) Restore(ctx context.Context, cluster *types.Cluster, backupName, restoreName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, cluster, backupName, restoreName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockVeleroClientMockRecorder) Restore(ctx, cluster, backupName, restoreName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockVeleroClient)(nil).Restore), ctx, cluster, backupName, restoreName)
+}
@@ -0,0 +1,53 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const timestampFormat = "20060102150405"
+
+type VeleroClient interface {
+	CreateBackup(ctx context.Context, cluster *types.Cluster, name string, namespaces []string) error
+	Restore(ctx context.Context, cluster *types.Cluster, backupName, restoreName string) error
+}
+
+// Workload backs up and restores the workloads running in a cluster with Velero
+type Workload struct {
+	client     VeleroClient
+	cluster    *types.Cluster
+	namespaces []string
+	now        func() time.Time
+}
+
+// NewWorkload returns a Workload for the namespaces of the cluster. An empty namespaces list means all the namespaces
+func NewWorkload(client VeleroClient, cluster *types.Cluster, namespaces []string) *Workload {
+	return &Workload{
+		client:     client,
+		cluster:    cluster,
+		namespaces: namespaces,
+		now:        time.Now,
+	}
+}
+
+// Backup creates a backup for the operation about to run on the cluster and returns its name
+func (w *Workload) Backup(ctx context.Context, operation string) (string, error) {
+	name := fmt.Sprintf("eksa-%s-%s-%s", operation, w.cluster.Name, w.now().UTC().Format(timestampFormat))
+	logger.V(3).Info("Creating velero backup", "backup", name, "namespaces", w.namespaces)
+	if err := w.client.CreateBackup(ctx, w.cluster, name, w.namespaces); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// Restore restores a backup in the cluster
+func (w *Workload) Restore(ctx context.Context, backupName string) error {
+	restoreName := fmt.Sprintf("%s-restore-%s", backupName, w.now().UTC().Format(timestampFormat))
+	logger.V(3).Info("Creating velero restore", "backup", backupName, "restore", restoreName)
+	return w.client.Restore(ctx, w.cluster, backupName, restoreName)
+}
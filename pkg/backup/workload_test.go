@@ -0,0 +1,59 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/backup/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type workloadTest struct {
+	*WithT
+	ctx      context.Context
+	client   *mocks.MockVeleroClient
+	cluster  *types.Cluster
+	workload *Workload
+}
+
+func newWorkloadTest(t *testing.T) *workloadTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockVeleroClient(ctrl)
+	cluster := &types.Cluster{Name: "w01", KubeconfigFile: "w01.kubeconfig"}
+	w := NewWorkload(client, cluster, []string{"apps"})
+	w.now = func() time.Time { return time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return &workloadTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		client:   client,
+		cluster:  cluster,
+		workload: w,
+	}
+}
+
+func TestWorkloadBackup(t *testing.T) {
+	tt := newWorkloadTest(t)
+	tt.client.EXPECT().CreateBackup(tt.ctx, tt.cluster, "eksa-upgrade-w01-20220102030405", []string{"apps"})
+
+	tt.Expect(tt.workload.Backup(tt.ctx, "upgrade")).To(Equal("eksa-upgrade-w01-20220102030405"))
+}
+
+func TestWorkloadBackupError(t *testing.T) {
+	tt := newWorkloadTest(t)
+	tt.client.EXPECT().CreateBackup(tt.ctx, tt.cluster, gomock.Any(), gomock.Any()).Return(errors.New("backup failed"))
+
+	_, err := tt.workload.Backup(tt.ctx, "delete")
+	tt.Expect(err).To(MatchError("backup failed"))
+}
+
+func TestWorkloadRestore(t *testing.T) {
+	tt := newWorkloadTest(t)
+	tt.client.EXPECT().Restore(tt.ctx, tt.cluster, "b1", "b1-restore-20220102030405")
+
+	tt.Expect(tt.workload.Restore(tt.ctx, "b1")).To(Succeed())
+}
@@ -0,0 +1,33 @@
+package bmc
+
+import "context"
+
+// PowerState is the power of a machine as reported by its BMC
+type PowerState string
+
+const (
+	PowerOn      PowerState = "on"
+	PowerOff     PowerState = "off"
+	PowerUnknown PowerState = "unknown"
+)
+
+// Credentials are the address and the login of a BMC
+type Credentials struct {
+	Host     string
+	Username string
+	Password string
+}
+
+// Client manages the power of one machine through its BMC
+type Client interface {
+	PowerState(ctx context.Context) (PowerState, error)
+	PowerOn(ctx context.Context) error
+	PowerOff(ctx context.Context) error
+	PowerCycle(ctx context.Context) error
+}
+
+// IpmitoolClient runs the ipmitool commands of the IPMI client, implemented by executables.Ipmitool
+type IpmitoolClient interface {
+	PowerStatus(ctx context.Context, host, username, password string) (string, error)
+	Power(ctx context.Context, host, username, password, action string) error
+}
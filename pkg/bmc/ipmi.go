@@ -0,0 +1,38 @@
+package bmc
+
+import "context"
+
+type ipmiClient struct {
+	ipmitool    IpmitoolClient
+	credentials Credentials
+}
+
+// NewIPMIClient returns a Client for the BMCs that only support IPMI over LAN
+func NewIPMIClient(ipmitool IpmitoolClient, credentials Credentials) Client {
+	return &ipmiClient{ipmitool: ipmitool, credentials: credentials}
+}
+
+func (c *ipmiClient) PowerState(ctx context.Context) (PowerState, error) {
+	status, err := c.ipmitool.PowerStatus(ctx, c.credentials.Host, c.credentials.Username, c.credentials.Password)
+	if err != nil {
+		return PowerUnknown, err
+	}
+
+	return PowerState(status), nil
+}
+
+func (c *ipmiClient) PowerOn(ctx context.Context) error {
+	return c.power(ctx, "on")
+}
+
+func (c *ipmiClient) PowerOff(ctx context.Context) error {
+	return c.power(ctx, "off")
+}
+
+func (c *ipmiClient) PowerCycle(ctx context.Context) error {
+	return c.power(ctx, "cycle")
+}
+
+func (c *ipmiClient) power(ctx context.Context, action string) error {
+	return c.ipmitool.Power(ctx, c.credentials.Host, c.credentials.Username, c.credentials.Password, action)
+}
@@ -0,0 +1,29 @@
+package bmc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/bmc"
+	"github.com/aws/eks-anywhere/pkg/bmc/mocks"
+)
+
+func TestIPMIClient(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ipmitool := mocks.NewMockIpmitoolClient(gomock.NewController(t))
+	client := bmc.NewIPMIClient(ipmitool, bmc.Credentials{Host: "10.0.1.1", Username: "admin", Password: "pass"})
+
+	ipmitool.EXPECT().PowerStatus(ctx, "10.0.1.1", "admin", "pass").Return("off", nil)
+	ipmitool.EXPECT().Power(ctx, "10.0.1.1", "admin", "pass", "on").Return(nil)
+	ipmitool.EXPECT().Power(ctx, "10.0.1.1", "admin", "pass", "off").Return(nil)
+	ipmitool.EXPECT().Power(ctx, "10.0.1.1", "admin", "pass", "cycle").Return(nil)
+
+	g.Expect(client.PowerState(ctx)).To(Equal(bmc.PowerOff))
+	g.Expect(client.PowerOn(ctx)).To(Succeed())
+	g.Expect(client.PowerOff(ctx)).To(Succeed())
+	g.Expect(client.PowerCycle(ctx)).To(Succeed())
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/bmc/bmc.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	bmc "github.com/aws/eks-anywhere/pkg/bmc"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// PowerCycle mocks base method.
+func (m *MockClient) PowerCycle(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerCycle", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerCycle indicates an expected call of PowerCycle.
+func (mr *MockClientMockRecorder) PowerCycle(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerCycle", reflect.TypeOf((*MockClient)(nil).PowerCycle), ctx)
+}
+
+// PowerOff mocks base method.
+func (m *MockClient) PowerOff(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOff", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOff indicates an expected call of PowerOff.
+func (mr *MockClientMockRecorder) PowerOff(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOff", reflect.TypeOf((*MockClient)(nil).PowerOff), ctx)
+}
+
+// PowerOn mocks base method.
+func (m *MockClient) PowerOn(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOn", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOn indicates an expected call of PowerOn.
+func (mr *MockClientMockRecorder) PowerOn(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOn", reflect.TypeOf((*MockClient)(nil).PowerOn), ctx)
+}
+
+// PowerState mocks base method.
+func (m *MockClient) PowerState(ctx context.Context) (bmc.PowerState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerState", ctx)
+	ret0, _ := ret[0].(bmc.PowerState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PowerState indicates an expected call of PowerState.
+func (mr *MockClientMockRecorder) PowerState(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerState", reflect.TypeOf((*MockClient)(nil).PowerState), ctx)
+}
+
+// MockIpmitoolClient is a mock of IpmitoolClient interface.
+type MockIpmitoolClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpmitoolClientMockRecorder
+}
+
+// MockIpmitoolClientMockRecorder is the mock recorder for MockIpmitoolClient.
+type MockIpmitoolClientMockRecorder struct {
+	mock *MockIpmitoolClient
+}
+
+// NewMockIpmitoolClient creates a new mock instance.
+func NewMockIpmitoolClient(ctrl *gomock.Controller) *MockIpmitoolClient {
+	mock := &MockIpmitoolClient{ctrl: ctrl}
+	mock.recorder = &MockIpmitoolClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpmitoolClient) EXPECT() *MockIpmitoolClientMockRecorder {
+	return m.recorder
+}
+
+// Power mocks base method.
+func (m *MockIpmitoolClient) Power(ctx context.Context, host, username, password, action string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Power", ctx, host, username, password, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Power indicates an expected call of Power.
+func (mr *MockIpmitoolClientMockRecorder) Power(ctx, host, username, password, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Power", reflect.TypeOf((*MockIpmitoolClient)(nil).Power), ctx, host, username, password, action)
+}
+
+// PowerStatus mocks base method.
+func (m *MockIpmitoolClient) PowerStatus(ctx context.Context, host, username, password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerStatus", ctx, host, username, password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PowerStatus indicates an expected call of PowerStatus.
+func (mr *MockIpmitoolClientMockRecorder) PowerStatus(ctx, host, username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerStatus", reflect.TypeOf((*MockIpmitoolClient)(nil).PowerStatus), ctx, host, username, password)
+}
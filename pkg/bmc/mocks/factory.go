@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/bmc/power.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	bmc "github.com/aws/eks-anywhere/pkg/bmc"
+	hardware "github.com/aws/eks-anywhere/pkg/hardware"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClientFactory is a mock of ClientFactory interface.
+type MockClientFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientFactoryMockRecorder
+}
+
+// MockClientFactoryMockRecorder is the mock recorder for MockClientFactory.
+type MockClientFactoryMockRecorder struct {
+	mock *MockClientFactory
+}
+
+// NewMockClientFactory creates a new mock instance.
+func NewMockClientFactory(ctrl *gomock.Controller) *MockClientFactory {
+	mock := &MockClientFactory{ctrl: ctrl}
+	mock.recorder = &MockClientFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientFactory) EXPECT() *MockClientFactoryMockRecorder {
+	return m.recorder
+}
+
+// ClientFor mocks base method.
+func (m *MockClientFactory) ClientFor(machine hardware.Machine) (bmc.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientFor", machine)
+	ret0, _ := ret[0].(bmc.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClientFor indicates an expected call of ClientFor.
+func (mr *MockClientFactoryMockRecorder) ClientFor(machine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientFor", reflect.TypeOf((*MockClientFactory)(nil).ClientFor), machine)
+}
@@ -0,0 +1,156 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/hardware"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var l = logger.For(logger.Providers)
+
+// ClientFactory builds the BMC client of a machine
+type ClientFactory interface {
+	ClientFor(machine hardware.Machine) (Client, error)
+}
+
+// Factory builds the BMC clients of the machines using the protocol of each one
+type Factory struct {
+	ipmitool IpmitoolClient
+	insecure bool
+}
+
+// NewFactory returns a Factory that uses ipmitool for the IPMI machines. insecure skips the verification
+// of the Redfish BMC certificates
+func NewFactory(ipmitool IpmitoolClient, insecure bool) *Factory {
+	return &Factory{ipmitool: ipmitool, insecure: insecure}
+}
+
+func (f *Factory) ClientFor(machine hardware.Machine) (Client, error) {
+	if !machine.HasBMC() {
+		return nil, fmt.Errorf("machine %s doesn't have a BMC", machine.ID)
+	}
+
+	credentials := Credentials{Host: machine.BMCIPAddress, Username: machine.BMCUsername, Password: machine.BMCPassword}
+	switch machine.Protocol() {
+	case hardware.BMCProtocolRedfish:
+		return NewRedfishClient(credentials, f.insecure), nil
+	case hardware.BMCProtocolIPMI:
+		return NewIPMIClient(f.ipmitool, credentials), nil
+	default:
+		return nil, fmt.Errorf("unsupported BMC protocol %s for machine %s", machine.Protocol(), machine.ID)
+	}
+}
+
+// MachinePower is the power state of a machine, or the error that prevented reading it
+type MachinePower struct {
+	Machine hardware.Machine
+	State   PowerState
+	Err     error
+}
+
+// PowerManager manages the power of the machines of a hardware inventory. The machines without a BMC are skipped
+type PowerManager struct {
+	clients  ClientFactory
+	machines []hardware.Machine
+}
+
+func NewPowerManager(clients ClientFactory, machines []hardware.Machine) *PowerManager {
+	return &PowerManager{clients: clients, machines: machines}
+}
+
+// States returns the power state of every machine with a BMC, for the machine diagnosis
+func (p *PowerManager) States(ctx context.Context) []MachinePower {
+	var states []MachinePower
+	for _, m := range p.machines {
+		if !m.HasBMC() {
+			continue
+		}
+		power := MachinePower{Machine: m, State: PowerUnknown}
+		client, err := p.clients.ClientFor(m)
+		if err == nil {
+			power.State, err = client.PowerState(ctx)
+		}
+		power.Err = err
+		states = append(states, power)
+	}
+
+	return states
+}
+
+// PowerOffMachines powers off all the machines with a BMC that are not already off. It tries all the machines
+// and returns the errors of the ones that failed
+func (p *PowerManager) PowerOffMachines(ctx context.Context) error {
+	var errs []error
+	for _, m := range p.machines {
+		if !m.HasBMC() {
+			l.V(4).Info("Machine doesn't have a BMC, skipping power off", "machine", m.ID)
+			continue
+		}
+		if err := p.powerOff(ctx, m); err != nil {
+			errs = append(errs, fmt.Errorf("machine %s: %v", m.ID, err))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (p *PowerManager) powerOff(ctx context.Context, m hardware.Machine) error {
+	client, err := p.clients.ClientFor(m)
+	if err != nil {
+		return err
+	}
+	state, err := client.PowerState(ctx)
+	if err != nil {
+		return err
+	}
+	if state == PowerOff {
+		l.V(4).Info("Machine already powered off", "machine", m.ID)
+		return nil
+	}
+
+	l.V(3).Info("Powering off machine", "machine", m.ID)
+	return client.PowerOff(ctx)
+}
+
+// PowerCycle restarts a stuck machine. A machine that is off is powered on instead, since most BMCs
+// refuse to cycle it
+func (p *PowerManager) PowerCycle(ctx context.Context, id string) error {
+	m, ok := p.machine(id)
+	if !ok {
+		return fmt.Errorf("machine %s not found in the hardware inventory", id)
+	}
+	client, err := p.clients.ClientFor(m)
+	if err != nil {
+		return err
+	}
+	state, err := client.PowerState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed power cycling machine %s: %v", id, err)
+	}
+
+	if state == PowerOff {
+		l.V(3).Info("Machine is off, powering it on", "machine", id)
+		err = client.PowerOn(ctx)
+	} else {
+		l.V(3).Info("Power cycling machine", "machine", id)
+		err = client.PowerCycle(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed power cycling machine %s: %v", id, err)
+	}
+
+	return nil
+}
+
+func (p *PowerManager) machine(id string) (hardware.Machine, bool) {
+	for _, m := range p.machines {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return hardware.Machine{}, false
+}
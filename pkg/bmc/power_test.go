@@ -0,0 +1,97 @@
+package bmc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/bmc"
+	"github.com/aws/eks-anywhere/pkg/bmc/mocks"
+	"github.com/aws/eks-anywhere/pkg/hardware"
+)
+
+type powerTest struct {
+	*WithT
+	ctx      context.Context
+	factory  *mocks.MockClientFactory
+	clients  map[string]*mocks.MockClient
+	machines []hardware.Machine
+	manager  *bmc.PowerManager
+}
+
+func newPowerTest(t *testing.T) *powerTest {
+	ctrl := gomock.NewController(t)
+	machines := []hardware.Machine{
+		{ID: "m-1", BMCIPAddress: "10.0.1.1", BMCUsername: "admin", BMCPassword: "pass"},
+		{ID: "m-2", BMCIPAddress: "10.0.1.2", BMCUsername: "admin", BMCPassword: "pass", BMCProtocol: hardware.BMCProtocolIPMI},
+		{ID: "m-3"},
+	}
+	tt := &powerTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		factory:  mocks.NewMockClientFactory(ctrl),
+		clients:  map[string]*mocks.MockClient{},
+		machines: machines,
+	}
+	for _, m := range machines[:2] {
+		client := mocks.NewMockClient(ctrl)
+		tt.clients[m.ID] = client
+		tt.factory.EXPECT().ClientFor(m).Return(client, nil).AnyTimes()
+	}
+	tt.manager = bmc.NewPowerManager(tt.factory, machines)
+
+	return tt
+}
+
+func TestPowerManagerStates(t *testing.T) {
+	tt := newPowerTest(t)
+	tt.clients["m-1"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerOn, nil)
+	tt.clients["m-2"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerUnknown, errors.New("timeout"))
+
+	tt.Expect(tt.manager.States(tt.ctx)).To(Equal([]bmc.MachinePower{
+		{Machine: tt.machines[0], State: bmc.PowerOn},
+		{Machine: tt.machines[1], State: bmc.PowerUnknown, Err: errors.New("timeout")},
+	}))
+}
+
+func TestPowerManagerPowerOffMachines(t *testing.T) {
+	tt := newPowerTest(t)
+	tt.clients["m-1"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerOn, nil)
+	tt.clients["m-1"].EXPECT().PowerOff(tt.ctx).Return(nil)
+	tt.clients["m-2"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerOff, nil)
+
+	tt.Expect(tt.manager.PowerOffMachines(tt.ctx)).To(Succeed())
+}
+
+func TestPowerManagerPowerOffMachinesErrors(t *testing.T) {
+	tt := newPowerTest(t)
+	tt.clients["m-1"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerOn, nil)
+	tt.clients["m-1"].EXPECT().PowerOff(tt.ctx).Return(errors.New("refused"))
+	tt.clients["m-2"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerUnknown, errors.New("timeout"))
+
+	tt.Expect(tt.manager.PowerOffMachines(tt.ctx)).To(MatchError("[machine m-1: refused, machine m-2: timeout]"))
+}
+
+func TestPowerManagerPowerCycle(t *testing.T) {
+	tt := newPowerTest(t)
+	tt.clients["m-1"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerOn, nil)
+	tt.clients["m-1"].EXPECT().PowerCycle(tt.ctx).Return(nil)
+	tt.clients["m-2"].EXPECT().PowerState(tt.ctx).Return(bmc.PowerOff, nil)
+	tt.clients["m-2"].EXPECT().PowerOn(tt.ctx).Return(nil)
+
+	tt.Expect(tt.manager.PowerCycle(tt.ctx, "m-1")).To(Succeed())
+	tt.Expect(tt.manager.PowerCycle(tt.ctx, "m-2")).To(Succeed())
+	tt.Expect(tt.manager.PowerCycle(tt.ctx, "m-4")).To(MatchError("machine m-4 not found in the hardware inventory"))
+}
+
+func TestFactoryClientFor(t *testing.T) {
+	g := NewWithT(t)
+	factory := bmc.NewFactory(mocks.NewMockIpmitoolClient(gomock.NewController(t)), true)
+
+	_, err := factory.ClientFor(hardware.Machine{ID: "m-1"})
+	g.Expect(err).To(MatchError("machine m-1 doesn't have a BMC"))
+	g.Expect(factory.ClientFor(hardware.Machine{ID: "m-1", BMCIPAddress: "10.0.1.1"})).NotTo(BeNil())
+}
@@ -0,0 +1,178 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	redfishSystemsPath = "/redfish/v1/Systems"
+	redfishResetAction = "/Actions/ComputerSystem.Reset"
+	redfishTimeout     = 30 * time.Second
+)
+
+type redfishClient struct {
+	endpoint    string
+	credentials Credentials
+	httpClient  *http.Client
+	system      string
+}
+
+type RedfishOpt func(*redfishClient)
+
+// WithRedfishHTTPClient replaces the http client used to reach the BMC
+func WithRedfishHTTPClient(httpClient *http.Client) RedfishOpt {
+	return func(c *redfishClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRedfishEndpoint replaces the https://<host> endpoint built from the credentials
+func WithRedfishEndpoint(endpoint string) RedfishOpt {
+	return func(c *redfishClient) {
+		c.endpoint = endpoint
+	}
+}
+
+// NewRedfishClient returns a Client for the BMCs that implement the Redfish API. It manages the first system
+// of the BMC, which is the only one for a bare metal server. insecure skips the verification of the BMC
+// certificate, which is usually self signed
+func NewRedfishClient(credentials Credentials, insecure bool, opts ...RedfishOpt) Client {
+	c := &redfishClient{
+		endpoint:    "https://" + credentials.Host,
+		credentials: credentials,
+		httpClient: &http.Client{
+			Timeout: redfishTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+type redfishCollection struct {
+	Members []struct {
+		ID string `json:"@odata.id"`
+	} `json:"Members"`
+}
+
+type redfishSystem struct {
+	PowerState string `json:"PowerState"`
+}
+
+func (c *redfishClient) PowerState(ctx context.Context) (PowerState, error) {
+	path, err := c.systemPath(ctx)
+	if err != nil {
+		return PowerUnknown, err
+	}
+
+	system := &redfishSystem{}
+	if err = c.do(ctx, http.MethodGet, path, nil, system); err != nil {
+		return PowerUnknown, err
+	}
+
+	// a machine powering on is already running as far as powering it off is concerned, so only a machine
+	// that is fully off is reported as off
+	switch system.PowerState {
+	case "On", "PoweringOn", "PoweringOff":
+		return PowerOn, nil
+	case "Off":
+		return PowerOff, nil
+	default:
+		return PowerUnknown, nil
+	}
+}
+
+func (c *redfishClient) PowerOn(ctx context.Context) error {
+	return c.reset(ctx, "On")
+}
+
+func (c *redfishClient) PowerOff(ctx context.Context) error {
+	return c.reset(ctx, "ForceOff")
+}
+
+func (c *redfishClient) PowerCycle(ctx context.Context) error {
+	return c.reset(ctx, "ForceRestart")
+}
+
+func (c *redfishClient) reset(ctx context.Context, resetType string) error {
+	path, err := c.systemPath(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPost, path+redfishResetAction, map[string]string{"ResetType": resetType}, nil)
+}
+
+// systemPath finds the path of the system managed by the BMC the first time it's needed
+func (c *redfishClient) systemPath(ctx context.Context) (string, error) {
+	if c.system != "" {
+		return c.system, nil
+	}
+
+	systems := &redfishCollection{}
+	if err := c.do(ctx, http.MethodGet, redfishSystemsPath, nil, systems); err != nil {
+		return "", err
+	}
+	if len(systems.Members) == 0 {
+		return "", fmt.Errorf("BMC %s doesn't manage any system", c.credentials.Host)
+	}
+	c.system = systems.Members[0].ID
+
+	return c.system, nil
+}
+
+func (c *redfishClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed marshalling redfish request: %v", err)
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed building redfish request: %v", err)
+	}
+	req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed calling BMC %s: %v", c.credentials.Host, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed reading response of BMC %s: %v", c.credentials.Host, err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("BMC %s returned %s for %s %s: %s", c.credentials.Host, resp.Status, method, path, bytes.TrimSpace(content))
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("failed parsing response of BMC %s: %v", c.credentials.Host, err)
+	}
+
+	return nil
+}
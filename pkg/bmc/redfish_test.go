@@ -0,0 +1,84 @@
+package bmc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/bmc"
+)
+
+type fakeRedfish struct {
+	powerState string
+	resets     []string
+}
+
+func (f *fakeRedfish) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "pass" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/Systems":
+		_, _ = w.Write([]byte(`{"Members":[{"@odata.id":"/redfish/v1/Systems/1"}]}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/Systems/1":
+		_, _ = w.Write([]byte(`{"Id":"1","PowerState":"` + f.powerState + `"}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset":
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.resets = append(f.resets, body["ResetType"])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newRedfishClient(t *testing.T, fake *fakeRedfish, password string) bmc.Client {
+	server := httptest.NewTLSServer(fake)
+	t.Cleanup(server.Close)
+	return bmc.NewRedfishClient(
+		bmc.Credentials{Host: "10.0.1.1", Username: "admin", Password: password}, false,
+		bmc.WithRedfishEndpoint(server.URL), bmc.WithRedfishHTTPClient(server.Client()),
+	)
+}
+
+func TestRedfishPowerState(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fake := &fakeRedfish{powerState: "On"}
+	client := newRedfishClient(t, fake, "pass")
+
+	g.Expect(client.PowerState(ctx)).To(Equal(bmc.PowerOn))
+	fake.powerState = "PoweringOn"
+	g.Expect(client.PowerState(ctx)).To(Equal(bmc.PowerOn))
+	fake.powerState = "PoweringOff"
+	g.Expect(client.PowerState(ctx)).To(Equal(bmc.PowerOn))
+	fake.powerState = "Off"
+	g.Expect(client.PowerState(ctx)).To(Equal(bmc.PowerOff))
+	fake.powerState = "Paused"
+	g.Expect(client.PowerState(ctx)).To(Equal(bmc.PowerUnknown))
+}
+
+func TestRedfishPowerActions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fake := &fakeRedfish{}
+	client := newRedfishClient(t, fake, "pass")
+
+	g.Expect(client.PowerOn(ctx)).To(Succeed())
+	g.Expect(client.PowerOff(ctx)).To(Succeed())
+	g.Expect(client.PowerCycle(ctx)).To(Succeed())
+	g.Expect(fake.resets).To(Equal([]string{"On", "ForceOff", "ForceRestart"}))
+}
+
+func TestRedfishUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	client := newRedfishClient(t, &fakeRedfish{}, "wrong")
+
+	_, err := client.PowerState(context.Background())
+	g.Expect(err).To(MatchError("BMC 10.0.1.1 returned 401 Unauthorized for GET /redfish/v1/Systems: "))
+}
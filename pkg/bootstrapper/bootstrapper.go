@@ -4,17 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/hosts"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+const (
+	deleteMaxRetries = 3
+	deleteBackOff    = 5 * time.Second
+)
+
 type Bootstrapper struct {
-	clusterClient ClusterClient
+	clusterClient    ClusterClient
+	deleteMaxRetries int
+	deleteBackOff    time.Duration
 }
 
+type BootstrapperOpt func(*Bootstrapper)
+
 type ClusterClient interface {
 	CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.Spec, opts ...BootstrapClusterClientOption) (kubeconfig string, err error)
 	DeleteBootstrapCluster(ctx context.Context, cluster *types.Cluster) error
@@ -28,6 +44,10 @@ type ClusterClient interface {
 	ValidateClustersCRD(ctx context.Context, cluster *types.Cluster) error
 	CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error
 	GetNamespace(ctx context.Context, kubeconfig string, namespace string) error
+	GetNodes(ctx context.Context, clusterName string) ([]string, error)
+	RemoveContainerWithVolumes(ctx context.Context, name string) error
+	AppendToHostsFile(ctx context.Context, container string, lines []string) error
+	GetConfigMap(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.ConfigMap, error)
 }
 
 type (
@@ -35,9 +55,24 @@ type (
 	BootstrapClusterOption       func(b *Bootstrapper) BootstrapClusterClientOption
 )
 
-func New(clusterClient ClusterClient) *Bootstrapper {
-	return &Bootstrapper{
-		clusterClient: clusterClient,
+func New(clusterClient ClusterClient, opts ...BootstrapperOpt) *Bootstrapper {
+	b := &Bootstrapper{
+		clusterClient:    clusterClient,
+		deleteMaxRetries: deleteMaxRetries,
+		deleteBackOff:    deleteBackOff,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithDeleteRetryPolicy sets how many times deleting the bootstrap cluster is attempted and the wait
+// before the first retry, the wait doubles after each attempt
+func WithDeleteRetryPolicy(maxRetries int, backOff time.Duration) BootstrapperOpt {
+	return func(b *Bootstrapper) {
+		b.deleteMaxRetries = maxRetries
+		b.deleteBackOff = backOff
 	}
 }
 
@@ -59,6 +94,10 @@ func (b *Bootstrapper) CreateBootstrapCluster(ctx context.Context, clusterSpec *
 		}
 	}
 
+	if err = b.configureHostEntries(ctx, c, clusterSpec); err != nil {
+		return nil, fmt.Errorf("error adding host entries to bootstrap cluster: %v", err)
+	}
+
 	err = cluster.ApplyExtraObjects(ctx, b.clusterClient, c, clusterSpec)
 	if err != nil {
 		return nil, fmt.Errorf("error applying extra objects to bootstrap cluster: %v", err)
@@ -67,6 +106,27 @@ func (b *Bootstrapper) CreateBootstrapCluster(ctx context.Context, clusterSpec *
 	return c, nil
 }
 
+// configureHostEntries adds the cluster host entries to /etc/hosts in the kind nodes, for containerd to pull
+// from the registry mirror, and to CoreDNS, for the CAPI providers and controllers to reach vCenter and the git server
+func (b *Bootstrapper) configureHostEntries(ctx context.Context, c *types.Cluster, clusterSpec *cluster.Spec) error {
+	entries := clusterSpec.Spec.HostEntries
+	if len(entries) == 0 {
+		return nil
+	}
+
+	nodes, err := b.clusterClient.GetNodes(ctx, c.Name)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err = b.clusterClient.AppendToHostsFile(ctx, node, hosts.Lines(entries)); err != nil {
+			return err
+		}
+	}
+
+	return hosts.ConfigureCoreDNS(ctx, b.clusterClient, c, entries)
+}
+
 func (b *Bootstrapper) DeleteBootstrapCluster(ctx context.Context, cluster *types.Cluster, isUpgrade bool) error {
 	clusterExists, err := b.clusterClient.ClusterExists(ctx, cluster.Name)
 	if err != nil {
@@ -87,7 +147,121 @@ func (b *Bootstrapper) DeleteBootstrapCluster(ctx context.Context, cluster *type
 		}
 	}
 
-	return b.clusterClient.DeleteBootstrapCluster(ctx, cluster)
+	return b.deleteKindCluster(ctx, cluster)
+}
+
+// deleteKindCluster retries the kind cluster deletion with backoff until the context is done and, when it
+// keeps failing, force removes the node containers with their volumes. The error of a failed cleanup lists
+// the resources left behind
+func (b *Bootstrapper) deleteKindCluster(ctx context.Context, cluster *types.Cluster) error {
+	backoff := retrier.Backoff{Initial: b.deleteBackOff, Factor: 2, Jitter: retrier.DefaultJitter}
+	policy := func(totalRetries int, err error) (bool, time.Duration) {
+		if totalRetries >= b.deleteMaxRetries {
+			return false, 0
+		}
+		logger.V(3).Info("Deleting bootstrap cluster failed, retrying", "error", err)
+		return true, backoff.Wait(totalRetries)
+	}
+	r := retrier.New(maxWait(ctx), retrier.WithRetryPolicy(policy))
+	deleteErr := r.RetryWithContext(ctx, func() error {
+		return b.clusterClient.DeleteBootstrapCluster(ctx, cluster)
+	})
+	if deleteErr == nil {
+		return nil
+	}
+
+	logger.Info("Deleting bootstrap cluster failed, force removing its containers", "error", deleteErr)
+	nodes, err := b.clusterClient.GetNodes(ctx, cluster.Name)
+	if err != nil {
+		return fmt.Errorf("error deleting bootstrap cluster: %v, listing its containers failed: %v", deleteErr, err)
+	}
+	var leftovers []string
+	for _, node := range nodes {
+		if err := b.clusterClient.RemoveContainerWithVolumes(ctx, node); err != nil {
+			logger.V(3).Info("Removing bootstrap cluster container failed", "container", node, "error", err)
+			leftovers = append(leftovers, node)
+		}
+	}
+	if len(leftovers) > 0 {
+		return fmt.Errorf("error deleting bootstrap cluster: %v, containers left behind: %s, remove them with docker rm -f -v", deleteErr, strings.Join(leftovers, ", "))
+	}
+
+	return nil
+}
+
+// maxWait returns the time left before the context deadline, or no limit without one
+func maxWait(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return time.Duration(math.MaxInt64)
+}
+
+// ExistingBootstrapCluster is a bootstrap cluster left behind by a previous run, for example when the admin machine
+// rebooted in the middle of a create
+type ExistingBootstrapCluster struct {
+	Cluster *types.Cluster
+	// CAPICluster is the CAPI cluster object for the cluster being created, nil when the previous run
+	// didn't get to create it and the bootstrap cluster doesn't hold any state
+	CAPICluster *types.CAPICluster
+	// OtherClusters are the names of the CAPI clusters in the bootstrap cluster other than the cluster being created
+	OtherClusters []string
+}
+
+// Collision returns an error when the bootstrap cluster holds the CAPI state of other clusters and not the one
+// being created, so it doesn't come from a previous run and can't be deleted as a leftover without losing that state
+func (e *ExistingBootstrapCluster) Collision() error {
+	if e == nil || e.CAPICluster != nil || len(e.OtherClusters) == 0 {
+		return nil
+	}
+	return fmt.Errorf("bootstrap cluster for %s holds the clusters %s and doesn't come from a previous create of this cluster, "+
+		"move those clusters out of it, delete it with --force-cleanup if they aren't needed, or use a different cluster name",
+		e.Cluster.Name, strings.Join(e.OtherClusters, ", "))
+}
+
+// GetExistingBootstrapCluster finds the bootstrap cluster for clusterName and checks if it holds the CAPI state of the cluster.
+// It returns nil when there is no bootstrap cluster and an error when it exists but its contents can't be verified
+func (b *Bootstrapper) GetExistingBootstrapCluster(ctx context.Context, clusterName string) (*ExistingBootstrapCluster, error) {
+	clusterExists, err := b.clusterClient.ClusterExists(ctx, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if bootstrap cluster exists: %v", err)
+	}
+	if !clusterExists {
+		return nil, nil
+	}
+
+	kubeconfig, err := b.clusterClient.GetKubeconfig(ctx, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching existing bootstrap cluster's kubeconfig: %v", err)
+	}
+	existing := &ExistingBootstrapCluster{
+		Cluster: &types.Cluster{
+			Name:           clusterName,
+			KubeconfigFile: kubeconfig,
+		},
+	}
+
+	if err = b.clusterClient.ValidateClustersCRD(ctx, existing.Cluster); err != nil {
+		// Without the CAPI CRDs there is no state to lose, as long as the cluster is reachable and that's really the reason
+		if err = b.clusterClient.GetNamespace(ctx, kubeconfig, "default"); err != nil {
+			return nil, fmt.Errorf("existing bootstrap cluster %s is not reachable: %v", clusterName, err)
+		}
+		return existing, nil
+	}
+
+	clusters, err := b.clusterClient.GetClusters(ctx, existing.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("error getting clusters from existing bootstrap cluster: %v", err)
+	}
+	for i := range clusters {
+		if clusters[i].Metadata.Name == clusterName {
+			existing.CAPICluster = &clusters[i]
+		} else {
+			existing.OtherClusters = append(existing.OtherClusters, clusters[i].Metadata.Name)
+		}
+	}
+
+	return existing, nil
 }
 
 func (b *Bootstrapper) managementInCluster(ctx context.Context, cluster *types.Cluster) (*types.CAPICluster, error) {
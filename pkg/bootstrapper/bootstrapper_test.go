@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper/mocks"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -73,6 +76,58 @@ func TestBootstrapperCreateBootstrapClusterSuccessExtraObjects(t *testing.T) {
 	}
 }
 
+func TestBootstrapperCreateBootstrapClusterSuccessHostEntries(t *testing.T) {
+	kubeconfigFile := "c.kubeconfig"
+	clusterName := "cluster-name"
+	clusterSpec, wantCluster := given(t, clusterName, kubeconfigFile)
+	clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+	node := "cluster-name-eks-a-cluster-control-plane"
+	coreDNS := &corev1.ConfigMap{Data: map[string]string{"Corefile": ".:53 {\n    forward . /etc/resolv.conf\n}\n"}}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+	client.EXPECT().CreateBootstrapCluster(ctx, clusterSpec).Return(kubeconfigFile, nil)
+	client.EXPECT().GetNamespace(ctx, kubeconfigFile, constants.EksaSystemNamespace)
+	client.EXPECT().GetNodes(ctx, clusterName).Return([]string{node}, nil)
+	client.EXPECT().AppendToHostsFile(ctx, node, []string{"10.0.0.10 registry.lab.local"})
+	client.EXPECT().GetConfigMap(ctx, kubeconfigFile, "coredns", "kube-system").Return(coreDNS, nil)
+	client.EXPECT().ApplyKubeSpecFromBytes(ctx, wantCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			if !strings.Contains(string(data), "10.0.0.10 registry.lab.local") {
+				t.Fatalf("CoreDNS config doesn't have the host entries:\n%s", data)
+			}
+			return nil
+		},
+	)
+
+	got, err := b.CreateBootstrapCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("Bootstrapper.CreateBootstrapCluster() error = %v, wantErr nil", err)
+	}
+
+	if !reflect.DeepEqual(got, wantCluster) {
+		t.Fatalf("Bootstrapper.CreateBootstrapCluster() cluster = %#v, want %#v", got, wantCluster)
+	}
+}
+
+func TestBootstrapperCreateBootstrapClusterHostEntriesError(t *testing.T) {
+	kubeconfigFile := "c.kubeconfig"
+	clusterName := "cluster-name"
+	clusterSpec, _ := given(t, clusterName, kubeconfigFile)
+	clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+	client.EXPECT().CreateBootstrapCluster(ctx, clusterSpec).Return(kubeconfigFile, nil)
+	client.EXPECT().GetNamespace(ctx, kubeconfigFile, constants.EksaSystemNamespace)
+	client.EXPECT().GetNodes(ctx, clusterName).Return(nil, errors.New("error getting nodes"))
+
+	_, err := b.CreateBootstrapCluster(ctx, clusterSpec)
+	if err == nil || err.Error() != "error adding host entries to bootstrap cluster: error getting nodes" {
+		t.Fatalf("Bootstrapper.CreateBootstrapCluster() error = %v, want error adding host entries", err)
+	}
+}
+
 func TestBootstrapperDeleteBootstrapClusterNoBootstrap(t *testing.T) {
 	cluster := &types.Cluster{
 		Name:           "cluster-name",
@@ -296,11 +351,195 @@ func TestBootstrapperDeleteBootstrapClusterUpgrade(t *testing.T) {
 	}
 }
 
+func TestBootstrapperGetExistingBootstrapClusterNoBootstrap(t *testing.T) {
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+	client.EXPECT().ClusterExists(ctx, "cluster-name").Return(false, nil)
+
+	got, err := b.GetExistingBootstrapCluster(ctx, "cluster-name")
+	if err != nil {
+		t.Fatalf("Bootstrapper.GetExistingBootstrapCluster() error = %v, wantErr nil", err)
+	}
+	if got != nil {
+		t.Fatalf("Bootstrapper.GetExistingBootstrapCluster() = %#v, want nil", got)
+	}
+}
+
+func TestBootstrapperGetExistingBootstrapClusterNoState(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: "c.kubeconfig"}
+	b, client := newBootstrapper(t)
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().GetKubeconfig(ctx, cluster.Name).Return(cluster.KubeconfigFile, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(errors.New("no CRD"))
+	client.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, "default").Return(nil)
+
+	got, err := b.GetExistingBootstrapCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("Bootstrapper.GetExistingBootstrapCluster() error = %v, wantErr nil", err)
+	}
+	want := &bootstrapper.ExistingBootstrapCluster{Cluster: cluster}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Bootstrapper.GetExistingBootstrapCluster() = %#v, want %#v", got, want)
+	}
+}
+
+func TestBootstrapperGetExistingBootstrapClusterNotReachable(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: "c.kubeconfig"}
+	b, client := newBootstrapper(t)
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().GetKubeconfig(ctx, cluster.Name).Return(cluster.KubeconfigFile, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(errors.New("connection refused"))
+	client.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, "default").Return(errors.New("connection refused"))
+
+	if _, err := b.GetExistingBootstrapCluster(ctx, cluster.Name); err == nil {
+		t.Fatal("Bootstrapper.GetExistingBootstrapCluster() error = nil, want not nil")
+	}
+}
+
+func TestBootstrapperGetExistingBootstrapClusterWithState(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: "c.kubeconfig"}
+	capiCluster := types.CAPICluster{Metadata: types.Metadata{Name: cluster.Name}, Status: types.ClusterStatus{Phase: "Provisioning"}}
+	b, client := newBootstrapper(t)
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().GetKubeconfig(ctx, cluster.Name).Return(cluster.KubeconfigFile, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(nil)
+	client.EXPECT().GetClusters(ctx, cluster).Return([]types.CAPICluster{{Metadata: types.Metadata{Name: "other"}}, capiCluster}, nil)
+
+	got, err := b.GetExistingBootstrapCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("Bootstrapper.GetExistingBootstrapCluster() error = %v, wantErr nil", err)
+	}
+	want := &bootstrapper.ExistingBootstrapCluster{Cluster: cluster, CAPICluster: &capiCluster, OtherClusters: []string{"other"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Bootstrapper.GetExistingBootstrapCluster() = %#v, want %#v", got, want)
+	}
+	if err = got.Collision(); err != nil {
+		t.Fatalf("ExistingBootstrapCluster.Collision() error = %v, wantErr nil", err)
+	}
+}
+
+func TestBootstrapperGetExistingBootstrapClusterOfOtherClusters(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: "c.kubeconfig"}
+	b, client := newBootstrapper(t)
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().GetKubeconfig(ctx, cluster.Name).Return(cluster.KubeconfigFile, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(nil)
+	client.EXPECT().GetClusters(ctx, cluster).Return([]types.CAPICluster{{Metadata: types.Metadata{Name: "other"}}}, nil)
+
+	got, err := b.GetExistingBootstrapCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("Bootstrapper.GetExistingBootstrapCluster() error = %v, wantErr nil", err)
+	}
+	if err = got.Collision(); err == nil {
+		t.Fatal("ExistingBootstrapCluster.Collision() error = nil, want not nil")
+	}
+}
+
+func TestBootstrapperDeleteBootstrapClusterRetrySuccess(t *testing.T) {
+	cluster := &types.Cluster{
+		Name:           "cluster-name",
+		KubeconfigFile: "c.kubeconfig",
+	}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(nil)
+	client.EXPECT().GetClusters(ctx, cluster).Return(nil, nil)
+	gomock.InOrder(
+		client.EXPECT().DeleteBootstrapCluster(ctx, cluster).Return(errors.New("error deleting kind cluster")),
+		client.EXPECT().DeleteBootstrapCluster(ctx, cluster).Return(nil),
+	)
+	client.EXPECT().GetNodes(ctx, cluster.Name).Times(0)
+
+	err := b.DeleteBootstrapCluster(ctx, cluster, false)
+	if err != nil {
+		t.Fatalf("Bootstrapper.DeleteBootstrapCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestBootstrapperDeleteBootstrapClusterForceRemoveContainers(t *testing.T) {
+	cluster := &types.Cluster{
+		Name:           "cluster-name",
+		KubeconfigFile: "c.kubeconfig",
+	}
+	nodes := []string{"cluster-name-eks-a-cluster-control-plane", "cluster-name-eks-a-cluster-worker"}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(nil)
+	client.EXPECT().GetClusters(ctx, cluster).Return(nil, nil)
+	client.EXPECT().DeleteBootstrapCluster(ctx, cluster).Return(errors.New("error deleting kind cluster")).Times(3)
+	client.EXPECT().GetNodes(ctx, cluster.Name).Return(nodes, nil)
+	client.EXPECT().RemoveContainerWithVolumes(ctx, nodes[0]).Return(nil)
+	client.EXPECT().RemoveContainerWithVolumes(ctx, nodes[1]).Return(nil)
+
+	err := b.DeleteBootstrapCluster(ctx, cluster, false)
+	if err != nil {
+		t.Fatalf("Bootstrapper.DeleteBootstrapCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestBootstrapperDeleteBootstrapClusterContainersLeft(t *testing.T) {
+	cluster := &types.Cluster{
+		Name:           "cluster-name",
+		KubeconfigFile: "c.kubeconfig",
+	}
+	nodes := []string{"cluster-name-eks-a-cluster-control-plane", "cluster-name-eks-a-cluster-worker"}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(nil)
+	client.EXPECT().GetClusters(ctx, cluster).Return(nil, nil)
+	client.EXPECT().DeleteBootstrapCluster(ctx, cluster).Return(errors.New("error deleting kind cluster")).Times(3)
+	client.EXPECT().GetNodes(ctx, cluster.Name).Return(nodes, nil)
+	client.EXPECT().RemoveContainerWithVolumes(ctx, nodes[0]).Return(nil)
+	client.EXPECT().RemoveContainerWithVolumes(ctx, nodes[1]).Return(errors.New("error removing container"))
+
+	err := b.DeleteBootstrapCluster(ctx, cluster, false)
+	if err == nil || !strings.Contains(err.Error(), "containers left behind: "+nodes[1]) {
+		t.Fatalf("Bootstrapper.DeleteBootstrapCluster() error = %v, want error listing %s", err, nodes[1])
+	}
+}
+
+func TestBootstrapperDeleteBootstrapClusterContextDone(t *testing.T) {
+	cluster := &types.Cluster{
+		Name:           "cluster-name",
+		KubeconfigFile: "c.kubeconfig",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b, client := newBootstrapper(t)
+
+	client.EXPECT().ClusterExists(ctx, cluster.Name).Return(true, nil)
+	client.EXPECT().ValidateClustersCRD(ctx, cluster).Return(nil)
+	client.EXPECT().GetClusters(ctx, cluster).Return(nil, nil)
+	client.EXPECT().DeleteBootstrapCluster(ctx, cluster).DoAndReturn(func(context.Context, *types.Cluster) error {
+		cancel()
+		return errors.New("error deleting kind cluster")
+	})
+	client.EXPECT().GetNodes(ctx, cluster.Name).Return(nil, context.Canceled)
+
+	err := b.DeleteBootstrapCluster(ctx, cluster, false)
+	if err == nil {
+		t.Fatal("Bootstrapper.DeleteBootstrapCluster() error = nil, want not nil")
+	}
+}
+
 func newBootstrapper(t *testing.T) (*bootstrapper.Bootstrapper, *mocks.MockClusterClient) {
 	mockCtrl := gomock.NewController(t)
 
 	client := mocks.NewMockClusterClient(mockCtrl)
-	b := bootstrapper.New(client)
+	b := bootstrapper.New(client, bootstrapper.WithDeleteRetryPolicy(3, 0))
 	return b, client
 }
 
@@ -12,6 +12,7 @@ import (
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 )
 
 // MockClusterClient is a mock of ClusterClient interface.
@@ -37,6 +38,20 @@ func (m *MockClusterClient) EXPECT() *MockClusterClientMockRecorder {
 	return m.recorder
 }
 
+// AppendToHostsFile mocks base method.
+func (m *MockClusterClient) AppendToHostsFile(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendToHostsFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendToHostsFile indicates an expected call of AppendToHostsFile.
+func (mr *MockClusterClientMockRecorder) AppendToHostsFile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendToHostsFile", reflect.TypeOf((*MockClusterClient)(nil).AppendToHostsFile), arg0, arg1, arg2)
+}
+
 // ApplyKubeSpecFromBytes mocks base method.
 func (m *MockClusterClient) ApplyKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
 	m.ctrl.T.Helper()
@@ -129,6 +144,21 @@ func (mr *MockClusterClientMockRecorder) GetClusters(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockClusterClient)(nil).GetClusters), arg0, arg1)
 }
 
+// GetConfigMap mocks base method.
+func (m *MockClusterClient) GetConfigMap(arg0 context.Context, arg1, arg2, arg3 string) (*v1.ConfigMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMap", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ConfigMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMap indicates an expected call of GetConfigMap.
+func (mr *MockClusterClientMockRecorder) GetConfigMap(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockClusterClient)(nil).GetConfigMap), arg0, arg1, arg2, arg3)
+}
+
 // GetKubeconfig mocks base method.
 func (m *MockClusterClient) GetKubeconfig(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +188,35 @@ func (mr *MockClusterClientMockRecorder) GetNamespace(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockClusterClient)(nil).GetNamespace), arg0, arg1, arg2)
 }
 
+// GetNodes mocks base method.
+func (m *MockClusterClient) GetNodes(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodes", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodes indicates an expected call of GetNodes.
+func (mr *MockClusterClientMockRecorder) GetNodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockClusterClient)(nil).GetNodes), arg0, arg1)
+}
+
+// RemoveContainerWithVolumes mocks base method.
+func (m *MockClusterClient) RemoveContainerWithVolumes(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveContainerWithVolumes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveContainerWithVolumes indicates an expected call of RemoveContainerWithVolumes.
+func (mr *MockClusterClientMockRecorder) RemoveContainerWithVolumes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContainerWithVolumes", reflect.TypeOf((*MockClusterClient)(nil).RemoveContainerWithVolumes), arg0, arg1)
+}
+
 // ValidateClustersCRD mocks base method.
 func (m *MockClusterClient) ValidateClustersCRD(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	v1alpha1release "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -24,8 +26,13 @@ func BuildSpecForCluster(ctx context.Context, cluster *v1alpha1.Cluster, bundles
 	return BuildSpecFromBundles(cluster, bundles, WithGitOpsConfig(gitOpsConfig))
 }
 
+// GetBundlesForCluster returns the Bundles of the cluster. Workload clusters created without the CLI, like with kubectl
+// or GitOps, have no Bundles of their own and get the ones of their management cluster
 func GetBundlesForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch BundlesFetch) (*v1alpha1release.Bundles, error) {
 	bundles, err := fetch(ctx, cluster.Name, cluster.Namespace)
+	if apierrors.IsNotFound(err) && cluster.IsManaged() {
+		bundles, err = fetch(ctx, cluster.ManagedBy(), cluster.Namespace)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed fetching Bundles for cluster: %v", err)
 	}
@@ -2,9 +2,11 @@ package cluster_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -32,3 +34,46 @@ func TestGetBundlesForCluster(t *testing.T) {
 	g.Expect(err).To(BeNil())
 	g.Expect(gotBundles).To(Equal(wantBundles))
 }
+
+func TestGetBundlesForClusterFromManagementCluster(t *testing.T) {
+	g := NewWithT(t)
+	c := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eksa-cluster",
+			Namespace: "eksa",
+		},
+	}
+	c.SetManagedBy("management-cluster")
+	wantBundles := &v1alpha1release.Bundles{}
+	mockFetch := func(ctx context.Context, name, namespace string) (*v1alpha1release.Bundles, error) {
+		g.Expect(namespace).To(Equal(c.Namespace))
+		if name == c.Name {
+			return nil, apierrors.NewNotFound(v1alpha1release.GroupVersion.WithResource("bundles").GroupResource(), name)
+		}
+		g.Expect(name).To(Equal("management-cluster"))
+
+		return wantBundles, nil
+	}
+
+	gotBundles, err := cluster.GetBundlesForCluster(context.Background(), c, mockFetch)
+	g.Expect(err).To(BeNil())
+	g.Expect(gotBundles).To(Equal(wantBundles))
+}
+
+func TestGetBundlesForClusterError(t *testing.T) {
+	g := NewWithT(t)
+	c := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eksa-cluster",
+			Namespace: "eksa",
+		},
+	}
+	c.SetManagedBy("management-cluster")
+	mockFetch := func(ctx context.Context, name, namespace string) (*v1alpha1release.Bundles, error) {
+		g.Expect(name).To(Equal(c.Name))
+		return nil, errors.New("connection refused")
+	}
+
+	_, err := cluster.GetBundlesForCluster(context.Background(), c, mockFetch)
+	g.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
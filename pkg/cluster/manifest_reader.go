@@ -2,6 +2,9 @@ package cluster
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	"sigs.k8s.io/yaml"
@@ -11,6 +14,16 @@ import (
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
+const (
+	// ManifestMirrorsEnvVar holds a comma separated list of origin=mirror base url pairs
+	// e.g. https://anywhere-assets.eks.amazonaws.com=https://artifactory.example.com/eks-anywhere
+	ManifestMirrorsEnvVar          = "EKSA_MANIFEST_MIRRORS"
+	ManifestCacheDirEnvVar         = "EKSA_MANIFEST_CACHE_DIR"
+	ManifestRateLimitMaxWaitEnvVar = "EKSA_MANIFEST_RATE_LIMIT_MAX_WAIT"
+	eksaGithubTokenEnvVar          = "EKSA_GITHUB_TOKEN"
+	githubTokenEnvVar              = "GITHUB_TOKEN"
+)
+
 type ManifestReader struct {
 	*files.Reader
 }
@@ -62,3 +75,37 @@ func (m *ManifestReader) GetBundles(bundlesURL string) (*v1alpha1.Bundles, error
 
 	return bundles, nil
 }
+
+func manifestReaderOptsFromEnv() ([]files.ReaderOpt, error) {
+	var opts []files.ReaderOpt
+	if mirrors, ok := os.LookupEnv(ManifestMirrorsEnvVar); ok && len(mirrors) > 0 {
+		for _, m := range strings.Split(mirrors, ",") {
+			pair := strings.SplitN(strings.TrimSpace(m), "=", 2)
+			if len(pair) != 2 || pair[0] == "" || pair[1] == "" {
+				return nil, fmt.Errorf("invalid %s entry [%s], must be in the format origin=mirror", ManifestMirrorsEnvVar, m)
+			}
+			opts = append(opts, files.WithMirror(pair[0], pair[1]))
+		}
+	}
+
+	if dir, ok := os.LookupEnv(ManifestCacheDirEnvVar); ok && len(dir) > 0 {
+		opts = append(opts, files.WithCacheDir(dir))
+	}
+
+	if wait, ok := os.LookupEnv(ManifestRateLimitMaxWaitEnvVar); ok && len(wait) > 0 {
+		maxWait, err := time.ParseDuration(wait)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", ManifestRateLimitMaxWaitEnvVar, err)
+		}
+		opts = append(opts, files.WithRateLimitMaxWait(maxWait))
+	}
+
+	for _, tokenEnvVar := range []string{eksaGithubTokenEnvVar, githubTokenEnvVar} {
+		if token, ok := os.LookupEnv(tokenEnvVar); ok && len(token) > 0 {
+			opts = append(opts, files.WithGithubToken(token))
+			break
+		}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	// EksaComponentsOverrideEnvVar holds the URI of an EKS-A components manifest installed instead of the one in the bundle
+	EksaComponentsOverrideEnvVar = "EKSA_COMPONENTS_OVERRIDE"
+	// EksaControllerImageOverrideEnvVar holds an EKS-A controller image run instead of the one in the bundle
+	EksaControllerImageOverrideEnvVar = "EKSA_CONTROLLER_IMAGE_OVERRIDE"
+)
+
+// EksaOverrides replace the EKS-A components of the bundle, so developers and support engineers can run
+// patched controllers against real clusters without building a new bundle. They are meant for development only
+type EksaOverrides struct {
+	ComponentsManifest string
+	ControllerImage    string
+}
+
+// IsEmpty returns true if no EKS-A component is overridden
+func (o EksaOverrides) IsEmpty() bool {
+	return o.ComponentsManifest == "" && o.ControllerImage == ""
+}
+
+// EksaOverrides returns the overrides set in the environment or, if not set there, in the cluster annotations
+func (s *Spec) EksaOverrides() EksaOverrides {
+	overrides := EksaOverrides{
+		ComponentsManifest: envOverride(EksaComponentsOverrideEnvVar),
+		ControllerImage:    envOverride(EksaControllerImageOverrideEnvVar),
+	}
+	if s.Cluster == nil {
+		return overrides
+	}
+	if overrides.ComponentsManifest == "" {
+		overrides.ComponentsManifest = s.Cluster.EksaComponentsOverride()
+	}
+	if overrides.ControllerImage == "" {
+		overrides.ControllerImage = s.Cluster.EksaControllerImageOverride()
+	}
+
+	return overrides
+}
+
+// EksaComponents returns the EKS-A components manifest to install, the override if there is one
+func (s *Spec) EksaComponents() v1alpha1.Manifest {
+	if manifest := s.EksaOverrides().ComponentsManifest; manifest != "" {
+		return v1alpha1.Manifest{URI: manifest}
+	}
+	return s.VersionsBundle.Eksa.Components
+}
+
+func envOverride(envVar string) string {
+	return strings.TrimSpace(os.Getenv(envVar))
+}
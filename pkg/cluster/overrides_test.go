@@ -0,0 +1,53 @@
+package cluster_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func TestSpecEksaOverridesEmpty(t *testing.T) {
+	g := NewWithT(t)
+	s := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundle.Eksa.Components = v1alpha1.Manifest{URI: "eksa-components.yaml"}
+	})
+
+	g.Expect(s.EksaOverrides().IsEmpty()).To(BeTrue())
+	g.Expect(s.EksaComponents()).To(Equal(v1alpha1.Manifest{URI: "eksa-components.yaml"}))
+}
+
+func TestSpecEksaOverridesFromAnnotations(t *testing.T) {
+	g := NewWithT(t)
+	s := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Annotations = map[string]string{
+			"anywhere.eks.amazonaws.com/eksa-components-override":       "https://example.com/eksa-components.yaml",
+			"anywhere.eks.amazonaws.com/eksa-controller-image-override": "example.com/eks-anywhere-cluster-controller:patched",
+		}
+	})
+
+	g.Expect(s.EksaOverrides()).To(Equal(cluster.EksaOverrides{
+		ComponentsManifest: "https://example.com/eksa-components.yaml",
+		ControllerImage:    "example.com/eks-anywhere-cluster-controller:patched",
+	}))
+	g.Expect(s.EksaComponents()).To(Equal(v1alpha1.Manifest{URI: "https://example.com/eksa-components.yaml"}))
+}
+
+func TestSpecEksaOverridesEnvTakesPrecedence(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv(cluster.EksaControllerImageOverrideEnvVar, "example.com/eks-anywhere-cluster-controller:env")
+	defer os.Unsetenv(cluster.EksaControllerImageOverrideEnvVar)
+	s := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Annotations = map[string]string{
+			"anywhere.eks.amazonaws.com/eksa-controller-image-override": "example.com/eks-anywhere-cluster-controller:patched",
+		}
+	})
+
+	g.Expect(s.EksaOverrides()).To(Equal(cluster.EksaOverrides{
+		ControllerImage: "example.com/eks-anywhere-cluster-controller:env",
+	}))
+}
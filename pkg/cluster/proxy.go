@@ -0,0 +1,62 @@
+package cluster
+
+import (
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+var noProxyDefaults = []string{"localhost", "127.0.0.1", ".svc"}
+
+// NoProxy returns the hosts and CIDRs that bypass the proxy: the ones in the proxy configuration, the pod and
+// service CIDRs, the control plane endpoint, the local addresses and the provider endpoints passed, without duplicates
+func NoProxy(clusterConfig *v1alpha1.Cluster, providerEndpoints ...string) []string {
+	if clusterConfig.Spec.ProxyConfiguration == nil {
+		return nil
+	}
+
+	var entries []string
+	entries = append(entries, clusterConfig.Spec.ProxyConfiguration.NoProxy...)
+	entries = append(entries, clusterConfig.Spec.ClusterNetwork.Pods.CidrBlocks...)
+	entries = append(entries, clusterConfig.Spec.ClusterNetwork.Services.CidrBlocks...)
+	if clusterConfig.Spec.ControlPlaneConfiguration.Endpoint != nil {
+		entries = append(entries, clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
+	}
+	entries = append(entries, noProxyDefaults...)
+	entries = append(entries, providerEndpoints...)
+
+	seen := make(map[string]bool, len(entries))
+	noProxy := make([]string, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" || seen[e] {
+			continue
+		}
+		seen[e] = true
+		noProxy = append(noProxy, e)
+	}
+
+	return noProxy
+}
+
+// ProxyEnv returns the HTTP_PROXY, HTTPS_PROXY and NO_PROXY env vars for the proxy configuration of the cluster,
+// in upper and lower case since tools read one or the other. It's empty when the cluster has no proxy configuration
+func ProxyEnv(clusterConfig *v1alpha1.Cluster, providerEndpoints ...string) map[string]string {
+	env := map[string]string{}
+	proxy := clusterConfig.Spec.ProxyConfiguration
+	if proxy == nil {
+		return env
+	}
+
+	noProxy := strings.Join(NoProxy(clusterConfig, providerEndpoints...), ",")
+	for k, v := range map[string]string{
+		"HTTP_PROXY":  proxy.HttpProxy,
+		"HTTPS_PROXY": proxy.HttpsProxy,
+		"NO_PROXY":    noProxy,
+	} {
+		env[k] = v
+		env[strings.ToLower(k)] = v
+	}
+
+	return env
+}
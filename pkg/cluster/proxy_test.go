@@ -0,0 +1,46 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+func TestProxyEnv(t *testing.T) {
+	g := NewWithT(t)
+	clusterConfig := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint: &v1alpha1.Endpoint{Host: "1.2.3.4"},
+			},
+			ClusterNetwork: v1alpha1.ClusterNetwork{
+				Pods:     v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+				Services: v1alpha1.Services{CidrBlocks: []string{"10.96.0.0/12"}},
+			},
+			ProxyConfiguration: &v1alpha1.ProxyConfiguration{
+				HttpProxy:  "http://proxy:3128",
+				HttpsProxy: "http://proxy:3129",
+				NoProxy:    []string{"internal.corp", "localhost", ""},
+			},
+		},
+	}
+
+	noProxy := "internal.corp,localhost,192.168.0.0/16,10.96.0.0/12,1.2.3.4,127.0.0.1,.svc,vcenter.corp"
+	g.Expect(cluster.ProxyEnv(clusterConfig, "vcenter.corp", "1.2.3.4")).To(Equal(map[string]string{
+		"HTTP_PROXY":  "http://proxy:3128",
+		"http_proxy":  "http://proxy:3128",
+		"HTTPS_PROXY": "http://proxy:3129",
+		"https_proxy": "http://proxy:3129",
+		"NO_PROXY":    noProxy,
+		"no_proxy":    noProxy,
+	}))
+}
+
+func TestProxyEnvNoProxyConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(cluster.ProxyEnv(&v1alpha1.Cluster{}, "vcenter.corp")).To(BeEmpty())
+	g.Expect(cluster.NoProxy(&v1alpha1.Cluster{})).To(BeNil())
+}
@@ -6,13 +6,16 @@ import (
 	"net/url"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/compatibility"
 	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/semver"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -37,10 +40,18 @@ type Spec struct {
 	configFS            embed.FS
 	userAgent           string
 	reader              *ManifestReader
+	readerOpts          []files.ReaderOpt
+	mirrorOpts          []files.ReaderOpt
 	VersionsBundle      *VersionsBundle
-	eksdRelease         *eksdv1alpha1.Release
-	Bundles             *v1alpha1.Bundles
-	ManagementCluster   *types.Cluster
+	// WorkerNodeGroupsVersionsBundles holds the versions bundles for the worker node groups
+	// that override the cluster kubernetes version, indexed by worker node group name
+	WorkerNodeGroupsVersionsBundles map[string]*VersionsBundle
+	eksdRelease                     *eksdv1alpha1.Release
+	Bundles                         *v1alpha1.Bundles
+	ManagementCluster               *types.Cluster
+	releases                        *v1alpha1.Release
+	clusterReleases                 *v1alpha1.Release
+	clusterBundles                  []v1alpha1.Bundles
 }
 
 func (s *Spec) DeepCopy() *Spec {
@@ -57,11 +68,47 @@ func (s *Spec) DeepCopy() *Spec {
 			VersionsBundle: s.VersionsBundle.VersionsBundle.DeepCopy(),
 			KubeDistro:     s.VersionsBundle.KubeDistro.deepCopy(),
 		},
-		eksdRelease: s.eksdRelease.DeepCopy(),
-		Bundles:     s.Bundles.DeepCopy(),
+		WorkerNodeGroupsVersionsBundles: s.deepCopyWorkerNodeGroupsVersionsBundles(),
+		eksdRelease:                     s.eksdRelease.DeepCopy(),
+		Bundles:                         s.Bundles.DeepCopy(),
+		releases:                        s.releases,
+		clusterReleases:                 s.clusterReleases,
+		clusterBundles:                  s.clusterBundles,
 	}
 }
 
+func (s *Spec) deepCopyWorkerNodeGroupsVersionsBundles() map[string]*VersionsBundle {
+	if s.WorkerNodeGroupsVersionsBundles == nil {
+		return nil
+	}
+	bundles := make(map[string]*VersionsBundle, len(s.WorkerNodeGroupsVersionsBundles))
+	for name, b := range s.WorkerNodeGroupsVersionsBundles {
+		bundles[name] = &VersionsBundle{
+			VersionsBundle: b.VersionsBundle.DeepCopy(),
+			KubeDistro:     b.KubeDistro.deepCopy(),
+		}
+	}
+	return bundles
+}
+
+// WorkerNodeGroupVersionsBundle returns the versions bundle to use for a worker node group.
+// It defaults to the cluster versions bundle when the group doesn't override the kubernetes version.
+func (s *Spec) WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration eksav1alpha1.WorkerNodeGroupConfiguration) *VersionsBundle {
+	if b, ok := s.WorkerNodeGroupsVersionsBundles[workerNodeGroupConfiguration.Name]; ok {
+		return b
+	}
+	return s.VersionsBundle
+}
+
+// WorkerNodeGroupKubernetesVersion returns the kubernetes version for a worker node group,
+// defaulting to the cluster kubernetes version
+func (s *Spec) WorkerNodeGroupKubernetesVersion(workerNodeGroupConfiguration eksav1alpha1.WorkerNodeGroupConfiguration) eksav1alpha1.KubernetesVersion {
+	if workerNodeGroupConfiguration.KubernetesVersion != nil {
+		return *workerNodeGroupConfiguration.KubernetesVersion
+	}
+	return s.Cluster.Spec.KubernetesVersion
+}
+
 func (cs *Spec) SetDefaultGitOps() {
 	if cs != nil && cs.GitOpsConfig != nil {
 		c := &cs.GitOpsConfig.Spec.Flux
@@ -142,6 +189,44 @@ func WithUserAgent(userAgent string) SpecOpt {
 	}
 }
 
+// WithManifestMirror makes the spec try to download the release manifests under originBaseURL
+// from mirrorBaseURL first. These mirrors are tried before the ones set in the environment
+func WithManifestMirror(originBaseURL, mirrorBaseURL string) SpecOpt {
+	return func(s *Spec) {
+		s.mirrorOpts = append(s.mirrorOpts, files.WithMirror(originBaseURL, mirrorBaseURL))
+	}
+}
+
+// WithManifestCacheDir caches the downloaded release manifests in dir
+func WithManifestCacheDir(dir string) SpecOpt {
+	return func(s *Spec) {
+		s.readerOpts = append(s.readerOpts, files.WithCacheDir(dir))
+	}
+}
+
+// WithLocalArtifacts reads the release manifests from the artifacts in dir instead of downloading them
+func WithLocalArtifacts(dir string, index *files.ArtifactsIndex) SpecOpt {
+	return func(s *Spec) {
+		s.readerOpts = append(s.readerOpts, files.WithLocalArtifacts(dir, index))
+	}
+}
+
+func withManifestReaderOpts(opts ...files.ReaderOpt) SpecOpt {
+	return func(s *Spec) {
+		s.readerOpts = append(s.readerOpts, opts...)
+	}
+}
+
+// WithClusterManifests resolves the release and bundles for the CLI version from the Release and Bundles served by
+// the management cluster, before downloading the manifests. Day 2 operations then get the same versions the
+// cluster was created with, even from an admin machine without the original manifests
+func WithClusterManifests(releases *v1alpha1.Release, bundles []v1alpha1.Bundles) SpecOpt {
+	return func(s *Spec) {
+		s.clusterReleases = releases
+		s.clusterBundles = bundles
+	}
+}
+
 func WithGitOpsConfig(gitOpsConfig *eksav1alpha1.GitOpsConfig) SpecOpt {
 	return func(s *Spec) {
 		s.GitOpsConfig = gitOpsConfig
@@ -170,9 +255,39 @@ func newWithCliVersion(cliVersion version.Info, opts ...SpecOpt) *Spec {
 }
 
 func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info, opts ...SpecOpt) (*Spec, error) {
+	return newSpecFromClusterConfig(eksav1alpha1.ConfigFile(clusterConfigPath), cliVersion, opts...)
+}
+
+// NewSpecFromClusterConfigContent builds the Spec from a cluster config in memory instead of a file, so
+// programs embedding EKS-A can load it without writing to disk. The content is used as is, environment
+// variables and secret references in it are not substituted
+func NewSpecFromClusterConfigContent(content []byte, cliVersion version.Info, opts ...SpecOpt) (*Spec, error) {
+	return newSpecFromClusterConfig(eksav1alpha1.ConfigContent(content), cliVersion, opts...)
+}
+
+// LoadBundles returns the Bundles for the CLI version, resolved the same way as when building a Spec
+// from a cluster config, so they can be inspected without one
+func LoadBundles(cliVersion version.Info, opts ...SpecOpt) (*v1alpha1.Bundles, error) {
+	readerOpts, err := manifestReaderOptsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]SpecOpt{withManifestReaderOpts(readerOpts...)}, opts...)
+
+	return newWithCliVersion(cliVersion, opts...).GetBundles(cliVersion)
+}
+
+func newSpecFromClusterConfig(config eksav1alpha1.ConfigSource, cliVersion version.Info, opts ...SpecOpt) (*Spec, error) {
+	readerOpts, err := manifestReaderOptsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	// explicit opts are applied after the env ones so they take precedence, their mirrors are added
+	// to the reader before the env ones in newManifestReader
+	opts = append([]SpecOpt{withManifestReaderOpts(readerOpts...)}, opts...)
 	s := newWithCliVersion(cliVersion, opts...)
 
-	clusterConfig, err := eksav1alpha1.GetClusterConfig(clusterConfigPath)
+	clusterConfig, err := config.Cluster()
 	if err != nil {
 		return nil, err
 	}
@@ -192,28 +307,28 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 		return nil, err
 	}
 
-	kubeDistro, err := buildKubeDistro(eksd)
+	vb, err := buildVersionsBundle(clusterConfig, versionsBundle, eksd)
 	if err != nil {
 		return nil, err
 	}
 
 	s.Bundles = bundles
 	s.Cluster = clusterConfig
-	s.VersionsBundle = &VersionsBundle{
-		VersionsBundle: versionsBundle,
-		KubeDistro:     kubeDistro,
-	}
+	s.VersionsBundle = vb
 	s.eksdRelease = eksd
+	if err = s.setWorkerNodeGroupsVersionsBundles(); err != nil {
+		return nil, err
+	}
 	for _, identityProvider := range s.Cluster.Spec.IdentityProviderRefs {
 		switch identityProvider.Kind {
 		case eksav1alpha1.OIDCConfigKind:
-			oidcConfig, err := eksav1alpha1.GetAndValidateOIDCConfig(clusterConfigPath, identityProvider.Name, clusterConfig)
+			oidcConfig, err := config.OIDCConfig(identityProvider.Name, clusterConfig)
 			if err != nil {
 				return nil, err
 			}
 			s.OIDCConfig = oidcConfig
 		case eksav1alpha1.AWSIamConfigKind:
-			awsIamConfig, err := eksav1alpha1.GetAndValidateAWSIamConfig(clusterConfigPath, identityProvider.Name, clusterConfig)
+			awsIamConfig, err := config.AWSIamConfig(identityProvider.Name, clusterConfig)
 			if err != nil {
 				return nil, err
 			}
@@ -222,7 +337,7 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 	}
 
 	if s.Cluster.Spec.GitOpsRef != nil {
-		gitOpsConfig, err := eksav1alpha1.GetAndValidateGitOpsConfig(clusterConfigPath, s.Cluster.Spec.GitOpsRef.Name, clusterConfig)
+		gitOpsConfig, err := config.GitOpsConfig(s.Cluster.Spec.GitOpsRef.Name, clusterConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -231,13 +346,13 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 
 	switch s.Cluster.Spec.DatacenterRef.Kind {
 	case eksav1alpha1.VSphereDatacenterKind:
-		datacenterConfig, err := eksav1alpha1.GetVSphereDatacenterConfig(clusterConfigPath)
+		datacenterConfig, err := config.VSphereDatacenterConfig()
 		if err != nil {
 			return nil, err
 		}
 		s.DatacenterConfig = &datacenterConfig.ObjectMeta
 	case eksav1alpha1.DockerDatacenterKind:
-		datacenterConfig, err := eksav1alpha1.GetDockerDatacenterConfig(clusterConfigPath)
+		datacenterConfig, err := config.DockerDatacenterConfig()
 		if err != nil {
 			return nil, err
 		}
@@ -266,37 +381,83 @@ func BuildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundles *v1alpha1.Bundl
 		return nil, err
 	}
 
-	kubeDistro, err := buildKubeDistro(eksd)
+	vb, err := buildVersionsBundle(cluster, versionsBundle, eksd)
 	if err != nil {
 		return nil, err
 	}
 
 	s.Bundles = bundles
 	s.Cluster = cluster
-	s.VersionsBundle = &VersionsBundle{
-		VersionsBundle: versionsBundle,
-		KubeDistro:     kubeDistro,
-	}
+	s.VersionsBundle = vb
 	s.eksdRelease = eksd
+	if err = s.setWorkerNodeGroupsVersionsBundles(); err != nil {
+		return nil, err
+	}
 	return s, nil
 }
 
 func (s *Spec) newManifestReader() *ManifestReader {
-	return NewManifestReader(files.WithEmbedFS(s.configFS), files.WithUserAgent(s.userAgent))
+	opts := append([]files.ReaderOpt{files.WithEmbedFS(s.configFS), files.WithUserAgent(s.userAgent)}, s.mirrorOpts...)
+	opts = append(opts, s.readerOpts...)
+	return NewManifestReader(opts...)
 }
 
 func (s *Spec) getVersionsBundle(clusterConfig *eksav1alpha1.Cluster, bundles *v1alpha1.Bundles) (*v1alpha1.VersionsBundle, error) {
+	return getVersionsBundleForKubernetesVersion(clusterConfig.Spec.KubernetesVersion, bundles)
+}
+
+func getVersionsBundleForKubernetesVersion(kubeVersion eksav1alpha1.KubernetesVersion, bundles *v1alpha1.Bundles) (*v1alpha1.VersionsBundle, error) {
 	for _, versionsBundle := range bundles.Spec.VersionsBundles {
-		if versionsBundle.KubeVersion == string(clusterConfig.Spec.KubernetesVersion) {
+		if versionsBundle.KubeVersion == string(kubeVersion) {
 			return &versionsBundle, nil
 		}
 	}
-	return nil, fmt.Errorf("kubernetes version %s is not supported by bundles manifest %d", clusterConfig.Spec.KubernetesVersion, bundles.Spec.Number)
+	return nil, fmt.Errorf("kubernetes version %s is not supported by bundles manifest %d, supported versions: %s",
+		kubeVersion, bundles.Spec.Number, strings.Join(compatibility.NewMatrix(bundles).KubernetesVersions(), ", "))
+}
+
+func (s *Spec) setWorkerNodeGroupsVersionsBundles() error {
+	s.WorkerNodeGroupsVersionsBundles = nil
+	for _, workerNodeGroupConfiguration := range s.Cluster.Spec.WorkerNodeGroupConfigurations {
+		kubeVersion := s.WorkerNodeGroupKubernetesVersion(workerNodeGroupConfiguration)
+		if kubeVersion == s.Cluster.Spec.KubernetesVersion {
+			continue
+		}
+
+		versionsBundle, err := getVersionsBundleForKubernetesVersion(kubeVersion, s.Bundles)
+		if err != nil {
+			return fmt.Errorf("worker node group %s: %v", workerNodeGroupConfiguration.Name, err)
+		}
+
+		eksd, err := s.reader.GetEksdRelease(versionsBundle)
+		if err != nil {
+			return err
+		}
+
+		vb, err := buildVersionsBundle(s.Cluster, versionsBundle, eksd)
+		if err != nil {
+			return err
+		}
+
+		if s.WorkerNodeGroupsVersionsBundles == nil {
+			s.WorkerNodeGroupsVersionsBundles = map[string]*VersionsBundle{}
+		}
+		s.WorkerNodeGroupsVersionsBundles[workerNodeGroupConfiguration.Name] = vb
+	}
+	return nil
 }
 
 func (s *Spec) GetBundles(cliVersion version.Info) (*v1alpha1.Bundles, error) {
 	bundlesURL := s.bundlesManifestURL
 	if bundlesURL == "" {
+		bundles, err := s.clusterBundlesFor(cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		if bundles != nil {
+			return bundles, nil
+		}
+
 		release, err := s.GetRelease(cliVersion)
 		if err != nil {
 			return nil, err
@@ -314,23 +475,92 @@ func (s *Spec) GetRelease(cliVersion version.Info) (*v1alpha1.EksARelease, error
 		return nil, fmt.Errorf("invalid cli version: %v", err)
 	}
 
+	if s.clusterReleases != nil {
+		release, err := findRelease(s.clusterReleases, cliSemVersion)
+		if err != nil {
+			return nil, err
+		}
+		if release != nil {
+			s.releases = s.clusterReleases
+			return release, nil
+		}
+		logger.V(4).Info("Release not found in the management cluster, reading the releases manifest", "version", cliVersion.GitVersion)
+	}
+
 	releases, err := s.reader.GetReleases(s.releasesManifestURL)
 	if err != nil {
 		return nil, err
 	}
 
+	release, err := findRelease(releases, cliSemVersion)
+	if err != nil {
+		return nil, err
+	}
+	if release == nil {
+		return nil, fmt.Errorf("eksa release %s does not exist in manifest %s", cliVersion, s.releasesManifestURL)
+	}
+	s.releases = releases
+
+	return release, nil
+}
+
+func findRelease(releases *v1alpha1.Release, cliVersion *semver.Version) (*v1alpha1.EksARelease, error) {
 	for _, release := range releases.Spec.Releases {
 		releaseVersion, err := semver.New(release.Version)
 		if err != nil {
 			return nil, fmt.Errorf("invalid version for release %d: %v", release.Number, err)
 		}
 
-		if cliSemVersion.SamePrerelease(releaseVersion) {
+		if cliVersion.SamePrerelease(releaseVersion) {
 			return &release, nil
 		}
 	}
 
-	return nil, fmt.Errorf("eksa release %s does not exist in manifest %s", cliVersion, s.releasesManifestURL)
+	return nil, nil
+}
+
+// Releases returns the releases manifest the bundles were resolved from, nil if they weren't resolved from a release
+func (s *Spec) Releases() *v1alpha1.Release {
+	return s.releases
+}
+
+// clusterBundlesFor returns the newest Bundles served by the management cluster built for the CLI version, nil if none is
+func (s *Spec) clusterBundlesFor(cliVersion version.Info) (*v1alpha1.Bundles, error) {
+	if len(s.clusterBundles) == 0 {
+		return nil, nil
+	}
+	cliSemVersion, err := semver.New(cliVersion.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cli version: %v", err)
+	}
+
+	var found *v1alpha1.Bundles
+	for i := range s.clusterBundles {
+		bundles := &s.clusterBundles[i]
+		minVersion, err := semver.New(bundles.Spec.CliMinVersion)
+		if err != nil {
+			continue
+		}
+		maxVersion, err := semver.New(bundles.Spec.CliMaxVersion)
+		if err != nil {
+			continue
+		}
+		if cliSemVersion.LessThan(minVersion) || cliSemVersion.GreaterThan(maxVersion) {
+			continue
+		}
+		if found == nil || bundles.Spec.Number > found.Spec.Number {
+			found = bundles
+		}
+	}
+	if found == nil {
+		logger.V(4).Info("No Bundles for the cli version in the management cluster", "version", cliVersion.GitVersion)
+		return nil, nil
+	}
+
+	logger.V(4).Info("Using Bundles from the management cluster", "bundles", found.Name, "number", found.Spec.Number)
+	bundles := found.DeepCopy()
+	bundles.ObjectMeta = metav1.ObjectMeta{Name: found.Name}
+	return bundles, nil
 }
 
 func (s *Spec) KubeDistroImages() []v1alpha1.Image {
@@ -345,6 +575,76 @@ func (s *Spec) KubeDistroImages() []v1alpha1.Image {
 	return images
 }
 
+// ImageRegistries returns the registry hosts of the images of the bundle and the kube distro, sorted. A registry
+// mirror is configured as the mirror of all of them, so the nodes pull every image through it
+func (s *Spec) ImageRegistries() []string {
+	images := s.VersionsBundle.Images()
+	if s.eksdRelease != nil {
+		images = append(images, s.KubeDistroImages()...)
+	}
+	seen := map[string]bool{}
+	registries := []string{}
+	for _, image := range images {
+		if image.URI == "" {
+			continue
+		}
+		registry := strings.SplitN(image.URI, "/", 2)[0]
+		if !seen[registry] {
+			seen[registry] = true
+			registries = append(registries, registry)
+		}
+	}
+	sort.Strings(registries)
+	return registries
+}
+
+func buildVersionsBundle(cluster *eksav1alpha1.Cluster, versionsBundle *v1alpha1.VersionsBundle, eksd *eksdv1alpha1.Release) (*VersionsBundle, error) {
+	kubeDistro, err := buildKubeDistro(eksd)
+	if err != nil {
+		return nil, err
+	}
+
+	vb := &VersionsBundle{
+		VersionsBundle: versionsBundle,
+		KubeDistro:     kubeDistro,
+	}
+
+	if cluster.Spec.FIPSEnabled {
+		if err = useFIPSImages(vb); err != nil {
+			return nil, err
+		}
+	}
+
+	return vb, nil
+}
+
+// useFIPSImages replaces the images of the components running in the nodes with their FIPS builds
+func useFIPSImages(vb *VersionsBundle) error {
+	fips := vb.VersionsBundle.FIPS
+	if fips == nil {
+		return fmt.Errorf("bundle for kubernetes version %s doesn't include FIPS images", vb.KubeVersion)
+	}
+
+	vb.KubeDistro.Kubernetes.Repository, vb.KubeDistro.Kubernetes.Tag = kubeDistroRepository(&eksdv1alpha1.AssetImage{URI: fips.KubeAPIServer.URI})
+	vb.KubeDistro.Etcd.Repository, vb.KubeDistro.Etcd.Tag = kubeDistroRepository(&eksdv1alpha1.AssetImage{URI: fips.Etcd.URI})
+	vb.KubeDistro.CoreDNS.Repository, vb.KubeDistro.CoreDNS.Tag = kubeDistroRepository(&eksdv1alpha1.AssetImage{URI: fips.CoreDNS.URI})
+	vb.KubeDistro.EtcdImage = fips.Etcd
+	vb.KubeDistro.Pause = fips.Pause
+	vb.VSphere.KubeVip = fips.KubeVip
+	vb.KubeVip.KubeVip = fips.KubeVip
+
+	return nil
+}
+
+// FIPSImages returns the FIPS builds used by the cluster nodes, empty if FIPS is not enabled
+func (s *Spec) FIPSImages() []v1alpha1.Image {
+	if !s.Cluster.Spec.FIPSEnabled || s.VersionsBundle.FIPS == nil {
+		return nil
+	}
+	fips := s.VersionsBundle.FIPS
+	return []v1alpha1.Image{fips.KubeAPIServer, fips.Etcd, fips.CoreDNS, fips.Pause, fips.KubeVip}
+}
+
 func buildKubeDistro(eksd *eksdv1alpha1.Release) (*KubeDistro, error) {
 	kubeDistro := &KubeDistro{}
 	assets := make(map[string]*eksdv1alpha1.AssetImage)
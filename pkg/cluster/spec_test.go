@@ -2,8 +2,14 @@ package cluster_test
 
 import (
 	"embed"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
 	"testing"
 
+	"sigs.k8s.io/yaml"
+
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -69,6 +75,12 @@ func TestNewSpecError(t *testing.T) {
 			releaseURL:        "testdata/simple_release.yaml",
 			cliVersion:        "v0.0.1",
 		},
+		{
+			testName:          "WorkerNodeGroupKubernetesVersionNotSupported",
+			clusterConfigFile: "testdata/cluster_1_19_worker_1_18.yaml",
+			releaseURL:        "testdata/simple_release.yaml",
+			cliVersion:        "v0.0.1",
+		},
 		{
 			testName:          "ReadingEkdDRelease",
 			clusterConfigFile: "testdata/cluster_1_19.yaml",
@@ -104,6 +116,35 @@ func TestNewSpecError(t *testing.T) {
 	}
 }
 
+func TestNewSpecInvalidManifestReaderEnv(t *testing.T) {
+	tests := []struct {
+		testName string
+		envVar   string
+		value    string
+	}{
+		{
+			testName: "InvalidMirror",
+			envVar:   cluster.ManifestMirrorsEnvVar,
+			value:    "https://github.com",
+		},
+		{
+			testName: "InvalidRateLimitMaxWait",
+			envVar:   cluster.ManifestRateLimitMaxWaitEnvVar,
+			value:    "forever",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			os.Setenv(tt.envVar, tt.value)
+			defer os.Unsetenv(tt.envVar)
+			v := version.Info{GitVersion: "v0.0.1"}
+			if _, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v, cluster.WithReleasesManifest("testdata/simple_release.yaml")); err == nil {
+				t.Fatal("NewSpec() error nil, want err not nil")
+			}
+		})
+	}
+}
+
 func TestNewSpecValidEmbedManifest(t *testing.T) {
 	v := version.Info{GitVersion: "v0.0.1"}
 	_, err := cluster.NewSpecFromClusterConfig(
@@ -127,6 +168,54 @@ func TestNewSpecValid(t *testing.T) {
 	validateSpecFromSimpleBundle(t, gotSpec)
 }
 
+func TestNewSpecFromClusterConfigContentValid(t *testing.T) {
+	content, err := os.ReadFile("testdata/cluster_1_19.yaml")
+	if err != nil {
+		t.Fatalf("failed reading cluster config: %v", err)
+	}
+	v := version.Info{GitVersion: "v0.0.1"}
+	gotSpec, err := cluster.NewSpecFromClusterConfigContent(content, v, cluster.WithReleasesManifest("testdata/simple_release.yaml"))
+	if err != nil {
+		t.Fatalf("NewSpecFromClusterConfigContent() error = %v, want err nil", err)
+	}
+
+	validateSpecFromSimpleBundle(t, gotSpec)
+}
+
+func TestNewSpecFromClusterConfigContentInvalid(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	if _, err := cluster.NewSpecFromClusterConfigContent([]byte("kind: VSphereDatacenterConfig"), v, cluster.WithReleasesManifest("testdata/simple_release.yaml")); err == nil {
+		t.Fatal("NewSpecFromClusterConfigContent() error nil, want err not nil")
+	}
+}
+
+func TestLoadBundles(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	bundles, err := cluster.LoadBundles(v, cluster.WithReleasesManifest("testdata/simple_release.yaml"))
+	if err != nil {
+		t.Fatalf("LoadBundles() error = %v, want err nil", err)
+	}
+	if len(bundles.Spec.VersionsBundles) == 0 {
+		t.Fatal("LoadBundles() returned no versions bundles")
+	}
+}
+
+func TestNewSpecWorkerNodeGroupVersionsBundleDefault(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v, cluster.WithReleasesManifest("testdata/simple_release.yaml"))
+	if err != nil {
+		t.Fatalf("NewSpec() error = %v, want err nil", err)
+	}
+
+	workerNodeGroup := gotSpec.Spec.WorkerNodeGroupConfigurations[0]
+	if gotSpec.WorkerNodeGroupKubernetesVersion(workerNodeGroup) != gotSpec.Spec.KubernetesVersion {
+		t.Errorf("WorkerNodeGroupKubernetesVersion() = %s, want %s", gotSpec.WorkerNodeGroupKubernetesVersion(workerNodeGroup), gotSpec.Spec.KubernetesVersion)
+	}
+	if gotSpec.WorkerNodeGroupVersionsBundle(workerNodeGroup) != gotSpec.VersionsBundle {
+		t.Error("WorkerNodeGroupVersionsBundle() should default to the cluster versions bundle")
+	}
+}
+
 func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	v := version.Info{GitVersion: "v0.0.1"}
 	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v,
@@ -140,6 +229,135 @@ func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	validateSpecFromSimpleBundle(t, gotSpec)
 }
 
+func TestNewSpecWithClusterManifestsBundles(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.2"}
+	older := readBundles(t, "testdata/simple_bundle.yaml")
+	older.Name = "bundles-1"
+	older.Spec.Number = 1
+	older.Spec.CliMinVersion = "v0.0.1"
+	older.Spec.CliMaxVersion = "v0.0.2"
+	newer := older.DeepCopy()
+	newer.Name = "bundles-2"
+	newer.Namespace = "default"
+	newer.Spec.Number = 2
+	newerCli := older.DeepCopy()
+	newerCli.Name = "bundles-3"
+	newerCli.Spec.Number = 3
+	newerCli.Spec.CliMinVersion = "v0.0.3"
+	newerCli.Spec.CliMaxVersion = "v0.0.3"
+
+	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v,
+		cluster.WithReleasesManifest("testdata/invalid_release_version.yaml"),
+		cluster.WithClusterManifests(nil, []v1alpha1.Bundles{*older, *newer, *newerCli}),
+	)
+	if err != nil {
+		t.Fatalf("NewSpec() error = %v, want err nil", err)
+	}
+
+	validateSpecFromSimpleBundle(t, gotSpec)
+	if gotSpec.Bundles.Spec.Number != 2 {
+		t.Errorf("NewSpec() Bundles number = %d, want 2", gotSpec.Bundles.Spec.Number)
+	}
+	if gotSpec.Bundles.Namespace != "" {
+		t.Errorf("NewSpec() Bundles namespace = %s, want empty", gotSpec.Bundles.Namespace)
+	}
+}
+
+func TestNewSpecWithClusterManifestsRelease(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	releases := &v1alpha1.Release{
+		Spec: v1alpha1.ReleaseSpec{
+			Releases: []v1alpha1.EksARelease{{Version: "v0.0.1", Number: 1, BundleManifestUrl: "testdata/simple_bundle.yaml"}},
+		},
+	}
+
+	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v,
+		cluster.WithReleasesManifest("testdata/invalid_release_version.yaml"),
+		cluster.WithClusterManifests(releases, nil),
+	)
+	if err != nil {
+		t.Fatalf("NewSpec() error = %v, want err nil", err)
+	}
+
+	validateSpecFromSimpleBundle(t, gotSpec)
+	if gotSpec.Releases() != releases {
+		t.Error("NewSpec() Releases() should be the Release from the management cluster")
+	}
+}
+
+func TestNewSpecWithClusterManifestsFallback(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	releases := &v1alpha1.Release{
+		Spec: v1alpha1.ReleaseSpec{
+			Releases: []v1alpha1.EksARelease{{Version: "v0.0.2", Number: 2, BundleManifestUrl: "testdata/invalid_bundle.yaml"}},
+		},
+	}
+	bundles := readBundles(t, "testdata/simple_bundle.yaml")
+	bundles.Spec.CliMinVersion = "v0.0.2"
+	bundles.Spec.CliMaxVersion = "v0.0.2"
+
+	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v,
+		cluster.WithReleasesManifest("testdata/simple_release.yaml"),
+		cluster.WithClusterManifests(releases, []v1alpha1.Bundles{*bundles}),
+	)
+	if err != nil {
+		t.Fatalf("NewSpec() error = %v, want err nil", err)
+	}
+
+	validateSpecFromSimpleBundle(t, gotSpec)
+	if gotSpec.Releases() == releases || len(gotSpec.Releases().Spec.Releases) != 1 || gotSpec.Releases().Spec.Releases[0].Version != "v0.0.1" {
+		t.Error("NewSpec() Releases() should be the downloaded releases manifest")
+	}
+}
+
+func readBundles(t *testing.T, file string) *v1alpha1.Bundles {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundles := &v1alpha1.Bundles{}
+	if err = yaml.Unmarshal(content, bundles); err != nil {
+		t.Fatal(err)
+	}
+	return bundles
+}
+
+func TestNewSpecFIPSValid(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19_fips.yaml", v,
+		cluster.WithReleasesManifest("testdata/invalid_release_version.yaml"),
+		cluster.WithOverrideBundlesManifest("testdata/simple_bundle_fips.yaml"),
+	)
+	if err != nil {
+		t.Fatalf("NewSpec() error = %v, want err nil", err)
+	}
+
+	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.Kubernetes, "public.ecr.aws/eks-distro-fips/kubernetes", "v1.19.8-eks-1-19-4-fips")
+	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.CoreDNS, "public.ecr.aws/eks-distro-fips/coredns", "v1.8.0-eks-1-19-4-fips")
+	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.Etcd, "public.ecr.aws/eks-distro-fips/etcd-io", "v3.4.14-eks-1-19-4-fips")
+	validateImageURI(t, gotSpec.VersionsBundle.KubeDistro.EtcdImage, "public.ecr.aws/eks-distro-fips/etcd-io/etcd:v3.4.14-eks-1-19-4-fips")
+	validateImageURI(t, gotSpec.VersionsBundle.KubeDistro.Pause, "public.ecr.aws/eks-distro-fips/kubernetes/pause:v1.19.8-eks-1-19-4-fips")
+	validateImageURI(t, gotSpec.VersionsBundle.VSphere.KubeVip, "public.ecr.aws/l0g8r8j6/plunder-app/kube-vip:v0.3.7-eks-a-fips")
+	validateImageURI(t, gotSpec.VersionsBundle.KubeVip.KubeVip, "public.ecr.aws/l0g8r8j6/plunder-app/kube-vip:v0.3.7-eks-a-fips")
+	// Components without a FIPS build keep the EKS-D images
+	validateImageURI(t, gotSpec.VersionsBundle.KubeDistro.NodeDriverRegistrar, "public.ecr.aws/eks-distro/kubernetes-csi/node-driver-registrar:v2.1.0-eks-1-19-4")
+	if len(gotSpec.FIPSImages()) != 5 {
+		t.Errorf("FIPSImages() = %v, want 5 images", gotSpec.FIPSImages())
+	}
+}
+
+func TestNewSpecFIPSMissingInBundle(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	_, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19_fips.yaml", v,
+		cluster.WithReleasesManifest("testdata/invalid_release_version.yaml"),
+		cluster.WithOverrideBundlesManifest("testdata/simple_bundle.yaml"),
+	)
+	wantErr := "bundle for kubernetes version 1.19 doesn't include FIPS images"
+	if err == nil || err.Error() != wantErr {
+		t.Fatalf("NewSpec() error = %v, want %s", err, wantErr)
+	}
+}
+
 func validateSpecFromSimpleBundle(t *testing.T, gotSpec *cluster.Spec) {
 	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.Kubernetes, "public.ecr.aws/eks-distro/kubernetes", "v1.19.8-eks-1-19-4")
 	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.CoreDNS, "public.ecr.aws/eks-distro/coredns", "v1.8.0-eks-1-19-4")
@@ -209,3 +427,58 @@ func TestSpecLoadManifestSuccess(t *testing.T) {
 
 	test.AssertContentToFile(t, string(m.Content), filename)
 }
+
+func TestSpecImageRegistries(t *testing.T) {
+	s := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundle.Cilium.Cilium.URI = "public.ecr.aws/isovalent/cilium:v1.9.13"
+		s.VersionsBundle.Cilium.Operator.URI = "public.ecr.aws/isovalent/operator-generic:v1.9.13"
+		s.VersionsBundle.Flux.SourceController.URI = "registry.example.com/fluxcd/source-controller:v0.12.1"
+	})
+	want := []string{"public.ecr.aws", "registry.example.com"}
+
+	got := s.ImageRegistries()
+	if len(got) != len(want) {
+		t.Fatalf("spec.ImageRegistries() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("spec.ImageRegistries() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLoadBundlesExplicitMirrorTriedBeforeEnvMirror(t *testing.T) {
+	var lock sync.Mutex
+	var requested []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		requested = append(requested, req.URL.Path)
+		lock.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	// the manifest reader uses the default transport, which needs to trust the test server
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	defer func() { http.DefaultTransport = defaultTransport }()
+	os.Setenv(cluster.ManifestMirrorsEnvVar, server.URL+"/origin="+server.URL+"/env")
+	defer os.Unsetenv(cluster.ManifestMirrorsEnvVar)
+
+	_, err := cluster.LoadBundles(version.Info{GitVersion: "v0.0.1"},
+		cluster.WithReleasesManifest(server.URL+"/origin/releases.yaml"),
+		cluster.WithManifestMirror(server.URL+"/origin", server.URL+"/explicit"),
+	)
+	if err == nil {
+		t.Fatal("LoadBundles() error nil, want err not nil")
+	}
+
+	want := []string{"/explicit/releases.yaml", "/env/releases.yaml", "/origin/releases.yaml"}
+	if len(requested) < len(want) {
+		t.Fatalf("LoadBundles() requested %v, want %v first", requested, want)
+	}
+	for i, path := range want {
+		if requested[i] != path {
+			t.Fatalf("LoadBundles() requested %v, want %v first", requested, want)
+		}
+	}
+}
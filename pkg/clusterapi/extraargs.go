@@ -73,6 +73,91 @@ func SecureEtcdTlsCipherSuitesExtraArgs() ExtraArgs {
 	return args
 }
 
+// FIPSTlsExtraArgs restricts the Kubernetes components TLS to the FIPS approved versions and cipher suites.
+// It must be appended after SecureTlsCipherSuitesExtraArgs to override its cipher suites
+func FIPSTlsExtraArgs(fipsEnabled bool) ExtraArgs {
+	args := ExtraArgs{}
+	if !fipsEnabled {
+		return args
+	}
+	args.AddIfNotEmpty("tls-cipher-suites", crypto.FIPSCipherSuitesString())
+	args.AddIfNotEmpty("tls-min-version", crypto.FIPSMinTLSVersion)
+	return args
+}
+
+// FIPSEtcdTlsExtraArgs restricts etcd TLS to the FIPS approved cipher suites.
+// It must be appended after SecureEtcdTlsCipherSuitesExtraArgs to override its cipher suites
+func FIPSEtcdTlsExtraArgs(fipsEnabled bool) ExtraArgs {
+	args := ExtraArgs{}
+	if !fipsEnabled {
+		return args
+	}
+	args.AddIfNotEmpty("cipher-suites", crypto.FIPSCipherSuitesString())
+	return args
+}
+
+// TlsPolicyExtraArgs sets the Kubernetes components TLS min version and cipher suites from the cluster TLS policy.
+// It must be appended after SecureTlsCipherSuitesExtraArgs and FIPSTlsExtraArgs to override them
+func TlsPolicyExtraArgs(policy *v1alpha1.TLSPolicy) ExtraArgs {
+	args := ExtraArgs{}
+	if policy == nil {
+		return args
+	}
+	args.AddIfNotEmpty("tls-cipher-suites", strings.Join(policy.CipherSuites, ","))
+	args.AddIfNotEmpty("tls-min-version", string(policy.MinVersion))
+	return args
+}
+
+// TlsPolicyEtcdExtraArgs sets the etcd cipher suites from the cluster TLS policy. etcd doesn't take
+// a TLS min version in the supported releases, so only the cipher suites are applied.
+// It must be appended after SecureEtcdTlsCipherSuitesExtraArgs and FIPSEtcdTlsExtraArgs to override them
+func TlsPolicyEtcdExtraArgs(policy *v1alpha1.TLSPolicy) ExtraArgs {
+	args := ExtraArgs{}
+	if policy == nil {
+		return args
+	}
+	args.AddIfNotEmpty("cipher-suites", strings.Join(policy.CipherSuites, ","))
+	return args
+}
+
+// admissionProfileFlags are the flags of the CIS Kubernetes benchmark a profile sets along with its plugins. Only the
+// checks that work without extra files or certificates in the nodes are covered, profiling is disabled for every
+// profile in the templates
+type admissionProfileFlags struct {
+	apiServer         ExtraArgs
+	controllerManager ExtraArgs
+}
+
+var admissionProfileExtraArgs = map[v1alpha1.AdmissionProfile]admissionProfileFlags{
+	v1alpha1.AdmissionProfileRestricted: {
+		// CIS 1.2.24
+		apiServer: ExtraArgs{"service-account-lookup": "true"},
+		// CIS 1.3.1
+		controllerManager: ExtraArgs{"terminated-pod-gc-threshold": "10"},
+	},
+}
+
+// AdmissionExtraArgs sets the API server admission plugins from the cluster admission profile for the Kubernetes version,
+// with the flags the profile needs along with them
+func AdmissionExtraArgs(admission *v1alpha1.AdmissionConfiguration, version v1alpha1.KubernetesVersion) ExtraArgs {
+	args := ExtraArgs{}
+	if admission == nil {
+		return args
+	}
+	args.AddIfNotEmpty("enable-admission-plugins", strings.Join(admission.EnabledPlugins(version), ","))
+	args.AddIfNotEmpty("disable-admission-plugins", strings.Join(admission.DisabledPlugins(), ","))
+	return args.Append(admissionProfileExtraArgs[admission.Profile].apiServer)
+}
+
+// AdmissionControllerManagerExtraArgs sets the controller manager flags of the cluster admission profile
+func AdmissionControllerManagerExtraArgs(admission *v1alpha1.AdmissionConfiguration) ExtraArgs {
+	args := ExtraArgs{}
+	if admission == nil {
+		return args
+	}
+	return args.Append(admissionProfileExtraArgs[admission.Profile].controllerManager)
+}
+
 func WorkerNodeLabelsExtraArgs(wnc v1alpha1.WorkerNodeGroupConfiguration) ExtraArgs {
 	return nodeLabelsExtraArgs(wnc.Labels)
 }
@@ -309,6 +309,245 @@ func TestSecureEtcdTlsCipherSuitesExtraArgs(t *testing.T) {
 	}
 }
 
+func TestFIPSTlsExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName    string
+		fipsEnabled bool
+		want        clusterapi.ExtraArgs
+	}{
+		{
+			testName:    "disabled",
+			fipsEnabled: false,
+			want:        clusterapi.ExtraArgs{},
+		},
+		{
+			testName:    "enabled",
+			fipsEnabled: true,
+			want: clusterapi.ExtraArgs{
+				"tls-cipher-suites": crypto.FIPSCipherSuitesString(),
+				"tls-min-version":   "VersionTLS12",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.FIPSTlsExtraArgs(tt.fipsEnabled); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FIPSTlsExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFIPSEtcdTlsExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName    string
+		fipsEnabled bool
+		want        clusterapi.ExtraArgs
+	}{
+		{
+			testName:    "disabled",
+			fipsEnabled: false,
+			want:        clusterapi.ExtraArgs{},
+		},
+		{
+			testName:    "enabled",
+			fipsEnabled: true,
+			want: clusterapi.ExtraArgs{
+				"cipher-suites": crypto.FIPSCipherSuitesString(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.FIPSEtcdTlsExtraArgs(tt.fipsEnabled); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FIPSEtcdTlsExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTlsPolicyExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName string
+		policy   *v1alpha1.TLSPolicy
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "no policy",
+			policy:   nil,
+			want:     clusterapi.ExtraArgs{},
+		},
+		{
+			testName: "min version",
+			policy:   &v1alpha1.TLSPolicy{MinVersion: v1alpha1.TLSVersion13},
+			want: clusterapi.ExtraArgs{
+				"tls-min-version": "VersionTLS13",
+			},
+		},
+		{
+			testName: "min version and cipher suites",
+			policy: &v1alpha1.TLSPolicy{
+				MinVersion:   v1alpha1.TLSVersion12,
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305"},
+			},
+			want: clusterapi.ExtraArgs{
+				"tls-cipher-suites": "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
+				"tls-min-version":   "VersionTLS12",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.TlsPolicyExtraArgs(tt.policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TlsPolicyExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTlsPolicyEtcdExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName string
+		policy   *v1alpha1.TLSPolicy
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "no policy",
+			policy:   nil,
+			want:     clusterapi.ExtraArgs{},
+		},
+		{
+			testName: "min version only",
+			policy:   &v1alpha1.TLSPolicy{MinVersion: v1alpha1.TLSVersion13},
+			want:     clusterapi.ExtraArgs{},
+		},
+		{
+			testName: "cipher suites",
+			policy: &v1alpha1.TLSPolicy{
+				MinVersion:   v1alpha1.TLSVersion12,
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			},
+			want: clusterapi.ExtraArgs{
+				"cipher-suites": "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.TlsPolicyEtcdExtraArgs(tt.policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TlsPolicyEtcdExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdmissionExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName  string
+		admission *v1alpha1.AdmissionConfiguration
+		version   v1alpha1.KubernetesVersion
+		want      clusterapi.ExtraArgs
+	}{
+		{
+			testName:  "no admission",
+			admission: nil,
+			version:   v1alpha1.Kube121,
+			want:      clusterapi.ExtraArgs{},
+		},
+		{
+			testName:  "baseline",
+			admission: &v1alpha1.AdmissionConfiguration{Profile: v1alpha1.AdmissionProfileBaseline},
+			version:   v1alpha1.Kube121,
+			want: clusterapi.ExtraArgs{
+				"enable-admission-plugins": "DenyServiceExternalIPs,NodeRestriction",
+			},
+		},
+		{
+			testName:  "baseline without the plugins the version doesn't have",
+			admission: &v1alpha1.AdmissionConfiguration{Profile: v1alpha1.AdmissionProfileBaseline},
+			version:   v1alpha1.Kube120,
+			want: clusterapi.ExtraArgs{
+				"enable-admission-plugins": "NodeRestriction",
+			},
+		},
+		{
+			testName:  "restricted",
+			admission: &v1alpha1.AdmissionConfiguration{Profile: v1alpha1.AdmissionProfileRestricted},
+			version:   v1alpha1.Kube121,
+			want: clusterapi.ExtraArgs{
+				"enable-admission-plugins": "AlwaysPullImages,DenyServiceExternalIPs,NodeRestriction",
+				"service-account-lookup":   "true",
+			},
+		},
+		{
+			testName: "custom",
+			admission: &v1alpha1.AdmissionConfiguration{
+				Profile:        v1alpha1.AdmissionProfileCustom,
+				EnablePlugins:  []string{"PodNodeSelector", "AlwaysPullImages"},
+				DisablePlugins: []string{"DefaultStorageClass"},
+			},
+			version: v1alpha1.Kube121,
+			want: clusterapi.ExtraArgs{
+				"enable-admission-plugins":  "AlwaysPullImages,NodeRestriction,PodNodeSelector",
+				"disable-admission-plugins": "DefaultStorageClass",
+			},
+		},
+		{
+			testName: "custom disabling node restriction",
+			admission: &v1alpha1.AdmissionConfiguration{
+				Profile:        v1alpha1.AdmissionProfileCustom,
+				DisablePlugins: []string{"NodeRestriction"},
+			},
+			version: v1alpha1.Kube121,
+			want: clusterapi.ExtraArgs{
+				"disable-admission-plugins": "NodeRestriction",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.AdmissionExtraArgs(tt.admission, tt.version); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AdmissionExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdmissionControllerManagerExtraArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		admission *v1alpha1.AdmissionConfiguration
+		want      clusterapi.ExtraArgs
+	}{
+		{
+			name: "no admission",
+			want: clusterapi.ExtraArgs{},
+		},
+		{
+			name:      "baseline",
+			admission: &v1alpha1.AdmissionConfiguration{Profile: v1alpha1.AdmissionProfileBaseline},
+			want:      clusterapi.ExtraArgs{},
+		},
+		{
+			name:      "restricted",
+			admission: &v1alpha1.AdmissionConfiguration{Profile: v1alpha1.AdmissionProfileRestricted},
+			want:      clusterapi.ExtraArgs{"terminated-pod-gc-threshold": "10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clusterapi.AdmissionControllerManagerExtraArgs(tt.admission); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AdmissionControllerManagerExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNodeLabelsExtraArgs(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -0,0 +1,66 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	// KubeProxyConfigPath is the file in the control plane nodes with the kube-proxy config added to the kubeadm config
+	KubeProxyConfigPath = "/etc/kubernetes/kube-proxy-config.yaml"
+
+	kubeadmConfigPath = "/run/kubeadm/kubeadm.yaml"
+	defaultScheduler  = "rr"
+)
+
+// KubeProxyConfig returns the kubeadm KubeProxyConfiguration document for the config. It's empty for
+// the modes that run with the kubeadm defaults
+func KubeProxyConfig(config *v1alpha1.KubeProxyConfiguration) string {
+	if config.ModeOrDefault() != v1alpha1.KubeProxyModeIPVS {
+		return ""
+	}
+
+	scheduler, strictARP := defaultScheduler, false
+	if config.IPVS != nil {
+		if config.IPVS.Scheduler != "" {
+			scheduler = config.IPVS.Scheduler
+		}
+		strictARP = config.IPVS.StrictARP
+	}
+	return fmt.Sprintf(`---
+apiVersion: kubeproxy.config.k8s.io/v1alpha1
+kind: KubeProxyConfiguration
+mode: ipvs
+ipvs:
+  scheduler: %s
+  strictARP: %t
+`, scheduler, strictARP)
+}
+
+// KubeProxyPreKubeadmCommands returns the commands that add the kube-proxy config to the kubeadm config.
+// kube-proxy is deployed by kubeadm init, so it's only added in the first control plane node
+func KubeProxyPreKubeadmCommands(config *v1alpha1.KubeProxyConfiguration) []string {
+	if KubeProxyConfig(config) == "" {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("if grep -q 'kind: InitConfiguration' %[1]s; then cat %[2]s >> %[1]s; fi", kubeadmConfigPath, KubeProxyConfigPath),
+	}
+}
+
+// KubeProxyPostKubeadmCommands returns the commands that remove the kube-proxy kubeadm init deploys when it's disabled.
+// The KubeadmControlPlane has to skip kube-proxy too, see SkipKubeProxy
+func KubeProxyPostKubeadmCommands(config *v1alpha1.KubeProxyConfiguration) []string {
+	if !SkipKubeProxy(config) {
+		return nil
+	}
+	return []string{
+		"kubectl --kubeconfig /etc/kubernetes/admin.conf delete daemonset kube-proxy --namespace kube-system --ignore-not-found",
+	}
+}
+
+// SkipKubeProxy returns true if the cluster doesn't run kube-proxy
+func SkipKubeProxy(config *v1alpha1.KubeProxyConfiguration) bool {
+	return config.ModeOrDefault() == v1alpha1.KubeProxyModeDisabled
+}
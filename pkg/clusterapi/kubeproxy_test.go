@@ -0,0 +1,62 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestKubeProxyConfigIPVS(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.KubeProxyConfiguration{
+		Mode: v1alpha1.KubeProxyModeIPVS,
+		IPVS: &v1alpha1.KubeProxyIPVSConfiguration{Scheduler: "lc", StrictARP: true},
+	}
+
+	g.Expect(clusterapi.KubeProxyConfig(config)).To(Equal(`---
+apiVersion: kubeproxy.config.k8s.io/v1alpha1
+kind: KubeProxyConfiguration
+mode: ipvs
+ipvs:
+  scheduler: lc
+  strictARP: true
+`))
+	g.Expect(clusterapi.KubeProxyPreKubeadmCommands(config)).To(Equal([]string{
+		"if grep -q 'kind: InitConfiguration' /run/kubeadm/kubeadm.yaml; then cat /etc/kubernetes/kube-proxy-config.yaml >> /run/kubeadm/kubeadm.yaml; fi",
+	}))
+	g.Expect(clusterapi.KubeProxyPostKubeadmCommands(config)).To(BeEmpty())
+	g.Expect(clusterapi.SkipKubeProxy(config)).To(BeFalse())
+}
+
+func TestKubeProxyConfigIPVSDefaults(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.KubeProxyConfiguration{Mode: v1alpha1.KubeProxyModeIPVS}
+
+	g.Expect(clusterapi.KubeProxyConfig(config)).To(ContainSubstring("scheduler: rr\n  strictARP: false\n"))
+}
+
+func TestKubeProxyConfigIPTables(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, config := range []*v1alpha1.KubeProxyConfiguration{nil, {Mode: v1alpha1.KubeProxyModeIPTables}} {
+		g.Expect(clusterapi.KubeProxyConfig(config)).To(BeEmpty())
+		g.Expect(clusterapi.KubeProxyPreKubeadmCommands(config)).To(BeEmpty())
+		g.Expect(clusterapi.KubeProxyPostKubeadmCommands(config)).To(BeEmpty())
+		g.Expect(clusterapi.SkipKubeProxy(config)).To(BeFalse())
+	}
+}
+
+func TestKubeProxyConfigDisabled(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.KubeProxyConfiguration{Mode: v1alpha1.KubeProxyModeDisabled}
+
+	g.Expect(clusterapi.KubeProxyConfig(config)).To(BeEmpty())
+	g.Expect(clusterapi.KubeProxyPreKubeadmCommands(config)).To(BeEmpty())
+	g.Expect(clusterapi.KubeProxyPostKubeadmCommands(config)).To(Equal([]string{
+		"kubectl --kubeconfig /etc/kubernetes/admin.conf delete daemonset kube-proxy --namespace kube-system --ignore-not-found",
+	}))
+	g.Expect(clusterapi.SkipKubeProxy(config)).To(BeTrue())
+}
@@ -5,24 +5,41 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
+	"regexp"
+	"sort"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/applier"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controllermonitor"
+	"github.com/aws/eks-anywhere/pkg/coreaddons"
+	"github.com/aws/eks-anywhere/pkg/coredns"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/hosts"
+	"github.com/aws/eks-anywhere/pkg/localpath"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/provenance"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/readiness"
 	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/servicelb"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/vipmonitor"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -33,11 +50,17 @@ const (
 	machineBackoff    = 1 * time.Second
 	machinesMinWait   = 30 * time.Minute
 	moveCAPIWait      = 15 * time.Minute
+	moveMaxRetries    = 3
+	moveBackOffPeriod = 30 * time.Second
 	ctrlPlaneWaitStr  = "60m"
 	etcdWaitStr       = "60m"
 	deploymentWaitStr = "30m"
+
+	controlPlaneEndpointPort = "6443"
 )
 
+var kubeconfigServerRegex = regexp.MustCompile("server:.*")
+
 type ClusterManager struct {
 	*Upgrader
 	clusterClient      *retrierClient
@@ -45,17 +68,23 @@ type ClusterManager struct {
 	networking         Networking
 	diagnosticsFactory diagnostics.DiagnosticBundleFactory
 	Retrier            *retrier.Retrier
+	moveRetrier        *retrier.Retrier
 	machineMaxWait     time.Duration
 	machineBackoff     time.Duration
 	machinesMinWait    time.Duration
 	awsIamAuth         AwsIamAuth
+	applier            *applier.Applier
+	manifestPolicy     ManifestPolicy
+	vipMonitor         VIPMonitor
+	controllerMonitor  ControllerMonitor
 }
 
 type ClusterClient interface {
-	MoveManagement(ctx context.Context, org, target *types.Cluster) error
+	MoveManagement(ctx context.Context, org, target *types.Cluster, opts ...executables.MoveOpt) error
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error
 	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	WaitForControlPlaneReady(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
 	WaitForManagedExternalEtcdReady(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
 	GetWorkloadKubeconfig(ctx context.Context, clusterName string, cluster *types.Cluster) ([]byte, error)
@@ -70,9 +99,12 @@ type ClusterClient interface {
 	SaveLog(ctx context.Context, cluster *types.Cluster, deployment *types.Deployment, fileName string, writer filewriter.FileWriter) error
 	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
 	GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error)
+	GetObjectNames(ctx context.Context, cluster *types.Cluster, namespace, selector string, resourceTypes ...string) ([]string, error)
+	SetCAPIClusterPaused(ctx context.Context, managementCluster *types.Cluster, clusterName string, paused bool) error
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
 	GetEksaVSphereDatacenterConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
 	UpdateEnvironmentVariablesInNamespace(ctx context.Context, resourceType, resourceName string, envMap map[string]string, cluster *types.Cluster, namespace string) error
+	SetDeploymentImage(ctx context.Context, kubeconfigFile, name, namespace, container, image string) error
 	UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error
 	RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error
 	GetEksaVSphereMachineConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error)
@@ -84,6 +116,13 @@ type ClusterClient interface {
 	GetApiServerUrl(ctx context.Context, cluster *types.Cluster) (string, error)
 	GetClusterCATlsCert(ctx context.Context, clusterName string, cluster *types.Cluster, namespace string) ([]byte, error)
 	KubeconfigSecretAvailable(ctx context.Context, kubeconfig string, clusterName string, namespace string) (bool, error)
+	DrainNodeInCluster(ctx context.Context, cluster *types.Cluster, node, timeout string) error
+	DeleteMachine(ctx context.Context, managementCluster *types.Cluster, name, namespace string) error
+	WaitForCRDsEstablished(ctx context.Context, cluster *types.Cluster, timeout string, crds ...string) error
+	GetConfigMap(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.ConfigMap, error)
+	RolloutStatus(ctx context.Context, kind, name, timeout string, opts ...executables.KubectlOpt) error
+	Scale(ctx context.Context, kind, name string, replicas int, opts ...executables.KubectlOpt) error
+	Wait(ctx context.Context, kubeconfig string, timeout string, forCondition string, property string, namespace string) error
 }
 
 type Networking interface {
@@ -97,9 +136,25 @@ type AwsIamAuth interface {
 	GenerateAwsIamAuthKubeconfig(clusterSpec *cluster.Spec, serverUrl, tlsCert string) ([]byte, error)
 }
 
+// ManifestPolicy checks the generated manifests follow the organization rules before they are applied
+type ManifestPolicy interface {
+	Enforce(manifest []byte) error
+}
+
+// VIPMonitor watches the control plane VIP while the control plane comes up
+type VIPMonitor interface {
+	Watch(ctx context.Context, target vipmonitor.Target) error
+}
+
+// ControllerMonitor watches the pods of the CAPI and provider controllers while waiting for them to reconcile the cluster
+type ControllerMonitor interface {
+	Watch(ctx context.Context, target controllermonitor.Target) error
+}
+
 type ClusterManagerOpt func(*ClusterManager)
 
 func New(clusterClient ClusterClient, networking Networking, writer filewriter.FileWriter, diagnosticBundleFactory diagnostics.DiagnosticBundleFactory, awsIamAuth AwsIamAuth, opts ...ClusterManagerOpt) *ClusterManager {
+	moveRetrier := retrier.NewWithMaxRetries(moveMaxRetries, moveBackOffPeriod)
 	retrier := retrier.NewWithMaxRetries(maxRetries, backOffPeriod)
 	retrierClient := NewRetrierClient(NewClient(clusterClient), retrier)
 	c := &ClusterManager{
@@ -108,6 +163,7 @@ func New(clusterClient ClusterClient, networking Networking, writer filewriter.F
 		writer:             writer,
 		networking:         networking,
 		Retrier:            retrier,
+		moveRetrier:        moveRetrier,
 		diagnosticsFactory: diagnosticBundleFactory,
 		machineMaxWait:     machineMaxWait,
 		machineBackoff:     machineBackoff,
@@ -118,6 +174,7 @@ func New(clusterClient ClusterClient, networking Networking, writer filewriter.F
 	for _, o := range opts {
 		o(c)
 	}
+	c.applier = applier.New(clusterClient, applier.WithRetrier(c.Retrier))
 
 	return c
 }
@@ -137,6 +194,95 @@ func WithRetrier(retrier *retrier.Retrier) ClusterManagerOpt {
 	}
 }
 
+// WithMoveRetrier sets how a failed CAPI move is retried
+func WithMoveRetrier(retrier *retrier.Retrier) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.moveRetrier = retrier
+	}
+}
+
+// WithManifestPolicy evaluates the CAPI and EKS-A manifests with the policy in the preflight validations
+func WithManifestPolicy(policy ManifestPolicy) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.manifestPolicy = policy
+	}
+}
+
+// WithVIPMonitor watches the control plane VIP of the providers that run kube-vip while waiting for the control plane,
+// failing as soon as the VIP flaps instead of waiting for the timeout
+func WithVIPMonitor(monitor VIPMonitor) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.vipMonitor = monitor
+	}
+}
+
+// WithControllerMonitor watches the CAPI and provider controller pods while waiting for the cluster, failing as soon as
+// one of them crash loops or can't pull its image instead of waiting for the timeout
+func WithControllerMonitor(monitor ControllerMonitor) ClusterManagerOpt {
+	return func(c *ClusterManager) {
+		c.controllerMonitor = monitor
+	}
+}
+
+// CreateManifestPolicyValidations returns the validations that generate the CAPI and EKS-A manifests of a new cluster
+// and evaluate them with the manifest policy, so violations stop the create before anything is applied
+func (c *ClusterManager) CreateManifestPolicyValidations(ctx context.Context, clusterSpec *cluster.Spec, provider providers.Provider) []validations.Validation {
+	if c.manifestPolicy == nil {
+		return nil
+	}
+
+	return []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "generated manifests follow the policies",
+				Remediation: "Change the cluster config so the generated manifests don't violate the policies",
+				Err: c.enforceManifestPolicy(clusterSpec, provider, func() ([]byte, []byte, error) {
+					return provider.GenerateCAPISpecForCreate(ctx, &types.Cluster{Name: clusterSpec.Name}, clusterSpec)
+				}),
+			}
+		},
+	}
+}
+
+// UpgradeManifestPolicyValidations returns the validations that generate the CAPI and EKS-A manifests of an upgrade
+// and evaluate them with the manifest policy. managementCluster holds the CAPI objects of workloadCluster
+func (c *ClusterManager) UpgradeManifestPolicyValidations(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) []validations.Validation {
+	if c.manifestPolicy == nil {
+		return nil
+	}
+
+	return []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "generated manifests follow the policies",
+				Remediation: "Change the cluster config so the generated manifests don't violate the policies",
+				Err: c.enforceManifestPolicy(clusterSpec, provider, func() ([]byte, []byte, error) {
+					currentSpec, err := c.GetCurrentClusterSpec(ctx, workloadCluster, clusterSpec.Name)
+					if err != nil {
+						return nil, nil, fmt.Errorf("error getting current cluster spec: %v", err)
+					}
+					return provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, workloadCluster, currentSpec, clusterSpec)
+				}),
+			}
+		},
+	}
+}
+
+// enforceManifestPolicy evaluates the CAPI manifests generated with generateCAPISpec and the EKS-A manifests of the cluster
+func (c *ClusterManager) enforceManifestPolicy(clusterSpec *cluster.Spec, provider providers.Provider, generateCAPISpec func() (cp, md []byte, err error)) error {
+	cpContent, mdContent, err := generateCAPISpec()
+	if err != nil {
+		return fmt.Errorf("error generating capi spec: %v", err)
+	}
+	resourcesSpec, err := clustermarshaller.MarshalClusterSpec(clusterSpec, provider.DatacenterConfig(), provider.MachineConfigs())
+	if err != nil {
+		return err
+	}
+
+	logger.V(4).Info("Evaluating manifest policies")
+	return c.manifestPolicy.Enforce(templater.AppendYamlResources(cpContent, mdContent, resourcesSpec))
+}
+
 func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error {
 	logger.V(3).Info("Waiting for management machines to be ready before move")
 	labels := []string{clusterv1.MachineControlPlaneLabelName, clusterv1.MachineDeploymentLabelName}
@@ -144,9 +290,9 @@ func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster,
 		return err
 	}
 
-	err := c.clusterClient.MoveManagement(ctx, from, to)
+	err := c.moveManagement(ctx, from, to, MoveScope{})
 	if err != nil {
-		return fmt.Errorf("error moving CAPI management from source to target: %v", err)
+		return err
 	}
 
 	logger.V(3).Info("Waiting for control planes to be ready after move")
@@ -175,6 +321,61 @@ func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster,
 	return nil
 }
 
+// MoveCAPIAndVerify moves the CAPI objects like MoveCAPI and then checks that the cluster and all its machines are in
+// the target cluster and none were left behind, so the target can manage the cluster even after the source goes away
+func (c *ClusterManager) MoveCAPIAndVerify(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error {
+	machines, err := c.clusterClient.GetMachines(ctx, from, clusterName)
+	if err != nil {
+		return fmt.Errorf("error getting machines before move: %v", err)
+	}
+
+	if err = c.MoveCAPI(ctx, from, to, clusterName, clusterSpec, checkers...); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Verifying all CAPI objects were moved", "cluster", clusterName)
+	clusters, err := c.clusterClient.GetClusters(ctx, to)
+	if err != nil {
+		return fmt.Errorf("error getting clusters after move: %v", err)
+	}
+	if !containsCAPICluster(clusters, clusterName) {
+		return fmt.Errorf("cluster %s not found in %s after move", clusterName, to.Name)
+	}
+
+	moved, err := c.clusterClient.GetMachines(ctx, to, clusterName)
+	if err != nil {
+		return fmt.Errorf("error getting moved machines: %v", err)
+	}
+	movedNames := make(map[string]struct{}, len(moved))
+	for _, m := range moved {
+		movedNames[m.Metadata.Name] = struct{}{}
+	}
+	for _, m := range machines {
+		if _, ok := movedNames[m.Metadata.Name]; !ok {
+			return fmt.Errorf("machine %s not found in %s after move", m.Metadata.Name, to.Name)
+		}
+	}
+
+	left, err := c.clusterClient.GetMachines(ctx, from, clusterName)
+	if err != nil {
+		return fmt.Errorf("error getting machines left after move: %v", err)
+	}
+	if len(left) != 0 {
+		return fmt.Errorf("%d machines were left in %s after move", len(left), from.Name)
+	}
+
+	return nil
+}
+
+func containsCAPICluster(clusters []types.CAPICluster, name string) bool {
+	for _, c := range clusters {
+		if c.Metadata.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ClusterManager) writeCAPISpecFile(clusterName string, content []byte) error {
 	fileName := fmt.Sprintf("%s-eks-a-cluster.yaml", clusterName)
 	if _, err := c.writer.Write(fileName, content); err != nil {
@@ -204,7 +405,7 @@ func (c *ClusterManager) CreateWorkloadCluster(ctx context.Context, managementCl
 		return nil, err
 	}
 
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, content, constants.EksaSystemNamespace)
 		},
@@ -213,18 +414,45 @@ func (c *ClusterManager) CreateWorkloadCluster(ctx context.Context, managementCl
 		return nil, fmt.Errorf("error applying capi spec: %v", err)
 	}
 
+	if err = c.waitForWorkloadCluster(ctx, managementCluster, workloadCluster, clusterSpec, provider); err != nil {
+		return nil, err
+	}
+
+	return workloadCluster, nil
+}
+
+// ResumeWorkloadCluster picks up the creation of a workload cluster whose CAPI spec was already applied to the management
+// cluster by a previous run. The spec is not generated again, since the new machine template names would roll out the machines
+func (c *ClusterManager) ResumeWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error) {
+	workloadCluster := &types.Cluster{
+		Name:               clusterSpec.Name,
+		ExistingManagement: managementCluster.ExistingManagement,
+	}
+
+	if err := c.waitForWorkloadCluster(ctx, managementCluster, workloadCluster, clusterSpec, provider); err != nil {
+		return nil, err
+	}
+
+	return workloadCluster, nil
+}
+
+// waitForWorkloadCluster waits for the machines of the workload cluster to be ready and sets its kubeconfig
+func (c *ClusterManager) waitForWorkloadCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (err error) {
+	ctx, stopMonitor := c.monitorControllers(ctx, managementCluster, provider, clusterSpec.Spec.ExternalEtcdConfiguration != nil)
+	defer func() { err = stopMonitor(err) }()
+
 	if clusterSpec.Spec.ExternalEtcdConfiguration != nil {
 		logger.V(3).Info("Waiting for external etcd to be ready", "cluster", workloadCluster.Name)
 		err = c.clusterClient.WaitForManagedExternalEtcdReady(ctx, managementCluster, etcdWaitStr, workloadCluster.Name)
 		if err != nil {
-			return nil, fmt.Errorf("error waiting for external etcd for workload cluster to be ready: %v", err)
+			return fmt.Errorf("error waiting for external etcd for workload cluster to be ready: %v", err)
 		}
 		logger.V(3).Info("External etcd is ready")
 		// the condition external etcd ready if true indicates that all etcd machines are ready and the etcd cluster is ready to accept requests
 	}
 
 	logger.V(3).Info("Waiting for workload kubeconfig secret to be ready", "cluster", workloadCluster.Name)
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			found, err := c.clusterClient.KubeconfigSecretAvailable(ctx, managementCluster.KubeconfigFile, workloadCluster.Name, constants.EksaSystemNamespace)
 			if err == nil && !found {
@@ -234,39 +462,39 @@ func (c *ClusterManager) CreateWorkloadCluster(ctx context.Context, managementCl
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error checking availability of kubeconfig secret: %v", err)
+		return fmt.Errorf("error checking availability of kubeconfig secret: %v", err)
 	}
 
 	logger.V(3).Info("Waiting for workload kubeconfig generation", "cluster", workloadCluster.Name)
 	workloadCluster.KubeconfigFile, err = c.generateWorkloadKubeconfig(ctx, workloadCluster.Name, managementCluster, provider)
 	if err != nil {
-		return nil, fmt.Errorf("error generating workload kubeconfig: %v", err)
+		return fmt.Errorf("error generating workload kubeconfig: %v", err)
 	}
 
 	logger.V(3).Info("Run post control plane creation operations")
 	err = provider.RunPostControlPlaneCreation(ctx, clusterSpec, workloadCluster)
 	if err != nil {
-		return nil, fmt.Errorf("error running post control plane creation operations: %v", err)
+		return fmt.Errorf("error running post control plane creation operations: %v", err)
 	}
 
 	logger.V(3).Info("Waiting for control plane to be ready")
-	err = c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, ctrlPlaneWaitStr, workloadCluster.Name)
+	err = c.waitForControlPlaneReady(ctx, managementCluster, workloadCluster, clusterSpec, 0)
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for workload cluster control plane to be ready: %v", err)
+		return fmt.Errorf("error waiting for workload cluster control plane to be ready: %v", err)
 	}
 
 	logger.V(3).Info("Waiting for controlplane and worker machines to be ready")
 	labels := []string{clusterv1.MachineControlPlaneLabelName, clusterv1.MachineDeploymentLabelName}
 	if err = c.waitForNodesReady(ctx, managementCluster, workloadCluster.Name, labels, types.WithNodeRef()); err != nil {
-		return nil, err
+		return err
 	}
 
 	err = cluster.ApplyExtraObjects(ctx, c.clusterClient, workloadCluster, clusterSpec)
 	if err != nil {
-		return nil, fmt.Errorf("error applying extra resources to workload cluster: %v", err)
+		return fmt.Errorf("error applying extra resources to workload cluster: %v", err)
 	}
 
-	return workloadCluster, nil
+	return nil
 }
 
 func (c *ClusterManager) generateWorkloadKubeconfig(ctx context.Context, clusterName string, cluster *types.Cluster, provider providers.Provider) (string, error) {
@@ -286,8 +514,81 @@ func (c *ClusterManager) generateWorkloadKubeconfig(ctx context.Context, cluster
 	return writtenFile, nil
 }
 
+func controlPlaneEndpointChanged(currentSpec, newSpec *cluster.Spec) bool {
+	currentEndpoint := currentSpec.Spec.ControlPlaneConfiguration.Endpoint
+	newEndpoint := newSpec.Spec.ControlPlaneConfiguration.Endpoint
+	return currentEndpoint != nil && newEndpoint != nil && !currentEndpoint.Equal(newEndpoint)
+}
+
+// migrateWorkloadKubeconfig rewrites the workload kubeconfig to point to the new control plane endpoint
+// and checks the api server is reachable through it before the rest of the upgrade continues
+func (c *ClusterManager) migrateWorkloadKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) error {
+	fileName := fmt.Sprintf("%s-eks-a-cluster.kubeconfig", workloadCluster.Name)
+	kubeconfig, err := c.clusterClient.GetWorkloadKubeconfig(ctx, workloadCluster.Name, managementCluster)
+	if err != nil {
+		return fmt.Errorf("error getting workload kubeconfig: %v", err)
+	}
+
+	server := fmt.Sprintf("server: https://%s", net.JoinHostPort(newClusterSpec.Spec.ControlPlaneConfiguration.Endpoint.Host, controlPlaneEndpointPort))
+	kubeconfig = kubeconfigServerRegex.ReplaceAll(kubeconfig, []byte(server))
+	if err := provider.UpdateKubeConfig(&kubeconfig, workloadCluster.Name); err != nil {
+		return err
+	}
+
+	writtenFile, err := c.writer.Write(fileName, kubeconfig, filewriter.PersistentFile, filewriter.Permission0600)
+	if err != nil {
+		return fmt.Errorf("error writing workload kubeconfig: %v", err)
+	}
+	workloadCluster.KubeconfigFile = writtenFile
+
+	return c.validateEndpointReachable(ctx, workloadCluster)
+}
+
+// completeEndpointMigration rolls the control plane again without the previous endpoint in the api server certificate,
+// once every machine uses the new endpoint, and checks the api server is still reachable through the new one
+func (c *ClusterManager) completeEndpointMigration(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) error {
+	logger.V(3).Info("Removing previous control plane endpoint from api server certificate")
+	cpContent, _, err := provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, workloadCluster, newClusterSpec, newClusterSpec)
+	if err != nil {
+		return fmt.Errorf("error generating capi spec: %v", err)
+	}
+
+	err = c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, cpContent, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying capi control plane spec: %v", err)
+	}
+
+	err = c.waitForControlPlaneReady(ctx, managementCluster, workloadCluster, newClusterSpec, newClusterSpec.Spec.ControlPlaneConfiguration.Count)
+	if err != nil {
+		return fmt.Errorf("error waiting for workload cluster control plane to be ready: %v", err)
+	}
+	if err = c.waitForControlPlaneReplicasReady(ctx, managementCluster, newClusterSpec); err != nil {
+		return fmt.Errorf("error waiting for workload cluster control plane replicas to be ready: %v", err)
+	}
+
+	return c.validateEndpointReachable(ctx, workloadCluster)
+}
+
+func (c *ClusterManager) validateEndpointReachable(ctx context.Context, workloadCluster *types.Cluster) error {
+	logger.V(3).Info("Validating api server is reachable through new control plane endpoint")
+	err := c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.GetNamespace(ctx, workloadCluster.KubeconfigFile, constants.KubeSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("api server not reachable through new control plane endpoint: %v", err)
+	}
+
+	return nil
+}
+
 func (c *ClusterManager) DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) error {
-	return c.Retrier.Retry(
+	return c.Retrier.RetryWithContext(ctx,
 		func() error {
 			if clusterSpec.IsManaged() {
 				if err := c.PauseEKSAControllerReconcile(ctx, clusterToDelete, clusterSpec, provider); err != nil {
@@ -320,12 +621,19 @@ func (c *ClusterManager) DeleteCluster(ctx context.Context, managementCluster, c
 				}
 			}
 
-			return c.clusterClient.DeleteCluster(ctx, managementCluster, clusterToDelete)
+			if err := c.clusterClient.DeleteCluster(ctx, managementCluster, clusterToDelete); err != nil {
+				return err
+			}
+
+			return provider.DeleteResourceTags(ctx, clusterSpec)
 		},
 	)
 }
 
-func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) error {
+func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) (err error) {
+	ctx, stopMonitor := c.monitorControllers(ctx, managementCluster, provider, newClusterSpec.Spec.ExternalEtcdConfiguration != nil)
+	defer func() { err = stopMonitor(err) }()
+
 	currentSpec, err := c.GetCurrentClusterSpec(ctx, workloadCluster, newClusterSpec.Name)
 	if err != nil {
 		return fmt.Errorf("error getting current cluster spec: %v", err)
@@ -336,11 +644,12 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("error generating capi spec: %v", err)
 	}
 
-	if err = c.writeCAPISpecFile(newClusterSpec.ObjectMeta.Name, templater.AppendYamlResources(cpContent, mdContent)); err != nil {
+	content := templater.AppendYamlResources(cpContent, mdContent)
+	if err = c.writeCAPISpecFile(newClusterSpec.ObjectMeta.Name, content); err != nil {
 		return err
 	}
 
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, cpContent, constants.EksaSystemNamespace)
 		},
@@ -366,7 +675,12 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 	}
 
 	logger.V(3).Info("Waiting for control plane to be ready")
-	err = c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, ctrlPlaneWaitStr, newClusterSpec.Name)
+	// The control plane machines are replaced one by one, each of them can move the VIP
+	rolledMachines := newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count
+	if currentCount := currentSpec.Cluster.Spec.ControlPlaneConfiguration.Count; currentCount > rolledMachines {
+		rolledMachines = currentCount
+	}
+	err = c.waitForControlPlaneReady(ctx, managementCluster, workloadCluster, newClusterSpec, rolledMachines)
 	if err != nil {
 		return fmt.Errorf("error waiting for workload cluster control plane to be ready: %v", err)
 	}
@@ -388,7 +702,27 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("error waiting for workload cluster control plane replicas to be ready: %v", err)
 	}
 
-	err = c.Retrier.Retry(
+	hooks, hasHooks := provider.(providers.UpgradeHooks)
+	if hasHooks {
+		if err = hooks.PostControlPlaneUpgrade(ctx, managementCluster, workloadCluster, currentSpec, newClusterSpec); err != nil {
+			return fmt.Errorf("error running provider post control plane upgrade hook: %v", err)
+		}
+	}
+
+	if controlPlaneEndpointChanged(currentSpec, newClusterSpec) {
+		logger.V(3).Info("Updating workload kubeconfig with new control plane endpoint")
+		if err = c.migrateWorkloadKubeconfig(ctx, managementCluster, workloadCluster, newClusterSpec, provider); err != nil {
+			return fmt.Errorf("error migrating workload cluster to new control plane endpoint: %v", err)
+		}
+	}
+
+	if hasHooks {
+		if err = hooks.PreMachineRollout(ctx, managementCluster, workloadCluster, currentSpec, newClusterSpec); err != nil {
+			return fmt.Errorf("error running provider pre machine rollout hook: %v", err)
+		}
+	}
+
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, mdContent, constants.EksaSystemNamespace)
 		},
@@ -408,6 +742,12 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return err
 	}
 
+	if controlPlaneEndpointChanged(currentSpec, newClusterSpec) {
+		if err = c.completeEndpointMigration(ctx, managementCluster, workloadCluster, newClusterSpec, provider); err != nil {
+			return fmt.Errorf("error completing migration to new control plane endpoint: %v", err)
+		}
+	}
+
 	logger.V(3).Info("Waiting for workload cluster capi components to be ready after upgrade")
 	err = c.waitForCAPI(ctx, workloadCluster, provider, externalEtcdTopology)
 	if err != nil {
@@ -418,6 +758,37 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("error applying extra resources to workload cluster: %v", err)
 	}
 
+	if err = c.upgradeServiceLoadBalancer(ctx, workloadCluster, currentSpec, newClusterSpec); err != nil {
+		return err
+	}
+
+	if err = c.upgradeCoreAddons(ctx, workloadCluster, currentSpec, newClusterSpec); err != nil {
+		return err
+	}
+
+	if len(currentSpec.Spec.HostEntries) > 0 || len(newClusterSpec.Spec.HostEntries) > 0 {
+		logger.V(3).Info("Upgrading host entries")
+		if err = c.InstallHostEntries(ctx, workloadCluster, newClusterSpec); err != nil {
+			return err
+		}
+	}
+
+	// the control plane upgrade redeploys CoreDNS, so the customizations are applied again
+	if currentSpec.Spec.CoreDNS != nil || newClusterSpec.Spec.CoreDNS != nil {
+		logger.V(3).Info("Upgrading CoreDNS configuration")
+		if err = c.ConfigureCoreDNS(ctx, workloadCluster, newClusterSpec); err != nil {
+			return err
+		}
+	}
+
+	// local-path-provisioner isn't removed if it's disabled, since the volumes it manages could still be in use
+	if newClusterSpec.Spec.LocalPathStorage != nil {
+		logger.V(3).Info("Upgrading local path storage")
+		if err = c.installLocalPathStorage(ctx, workloadCluster, newClusterSpec); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -510,8 +881,11 @@ func (c *ClusterManager) InstallCAPI(ctx context.Context, clusterSpec *cluster.S
 	return c.waitForCAPI(ctx, cluster, provider, clusterSpec.Spec.ExternalEtcdConfiguration != nil)
 }
 
-func (c *ClusterManager) waitForCAPI(ctx context.Context, cluster *types.Cluster, provider providers.Provider, externalEtcdTopology bool) error {
-	err := c.clusterClient.waitForDeployments(ctx, internal.CAPIDeployments, cluster)
+func (c *ClusterManager) waitForCAPI(ctx context.Context, cluster *types.Cluster, provider providers.Provider, externalEtcdTopology bool) (err error) {
+	ctx, stopMonitor := c.monitorControllers(ctx, cluster, provider, externalEtcdTopology)
+	defer func() { err = stopMonitor(err) }()
+
+	err = c.clusterClient.waitForDeployments(ctx, internal.CAPIDeployments, cluster)
 	if err != nil {
 		return err
 	}
@@ -536,7 +910,11 @@ func (c *ClusterManager) InstallNetworking(ctx context.Context, cluster *types.C
 	if err != nil {
 		return fmt.Errorf("error generating networking manifest: %v", err)
 	}
-	err = c.Retrier.Retry(
+	if len(networkingManifestContent) == 0 {
+		logger.V(3).Info("No networking manifest to apply, the CNI is managed by the user")
+		return nil
+	}
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, networkingManifestContent)
 		},
@@ -551,13 +929,21 @@ func (c *ClusterManager) UpgradeNetworking(ctx context.Context, cluster *types.C
 	return c.networking.Upgrade(ctx, cluster, currentSpec, newSpec)
 }
 
-func (c *ClusterManager) InstallStorageClass(ctx context.Context, cluster *types.Cluster, provider providers.Provider) error {
-	storageClass := provider.GenerateStorageClass()
+// InstallStorageClass applies the provider storage classes or, for providers without a CSI driver,
+// local-path-provisioner if the cluster spec enables local path storage
+func (c *ClusterManager) InstallStorageClass(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	storageClass, err := provider.GenerateStorageClass()
+	if err != nil {
+		return err
+	}
 	if storageClass == nil {
-		return nil
+		if clusterSpec.Spec.LocalPathStorage == nil {
+			return nil
+		}
+		return c.installLocalPathStorage(ctx, cluster, clusterSpec)
 	}
 
-	err := c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, storageClass)
 		},
@@ -568,6 +954,183 @@ func (c *ClusterManager) InstallStorageClass(ctx context.Context, cluster *types
 	return nil
 }
 
+func (c *ClusterManager) installLocalPathStorage(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	manifest, err := localpath.GenerateManifest(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	err = c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying local path storage manifest: %v", err)
+	}
+	return nil
+}
+
+// InstallServiceLoadBalancer applies the kube-vip manifests that serve the LoadBalancer services
+// with the addresses from the cluster spec pools
+func (c *ClusterManager) InstallServiceLoadBalancer(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	manifest, err := servicelb.GenerateManifest(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	err = c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying service load balancer manifest: %v", err)
+	}
+	return nil
+}
+
+// InstallHostEntries adds the cluster host entries to CoreDNS, so the pods resolve them like the nodes do
+// with /etc/hosts. The entries are removed from CoreDNS when the spec doesn't have any
+func (c *ClusterManager) InstallHostEntries(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	err := c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return hosts.ConfigureCoreDNS(ctx, c.clusterClient, cluster, clusterSpec.Spec.HostEntries)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error adding host entries to CoreDNS: %v", err)
+	}
+	return nil
+}
+
+// ConfigureCoreDNS applies the CoreDNS customizations in the spec, or the kubeadm defaults when it doesn't have any
+func (c *ClusterManager) ConfigureCoreDNS(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	err := c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return coredns.Configure(ctx, c.clusterClient, cluster, clusterSpec.Spec.CoreDNS)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring CoreDNS: %v", err)
+	}
+	return nil
+}
+
+// ApplyProvenance records in the cluster how it was built, including whether it runs in FIPS mode
+// and the FIPS images in use
+func (c *ClusterManager) ApplyProvenance(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	manifest, err := provenance.GenerateManifest(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	err = c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying provenance manifest: %v", err)
+	}
+	return nil
+}
+
+// WaitForReadinessGates waits for the readiness gates in the spec, the organization's own checks for
+// the cluster to be considered created
+func (c *ClusterManager) WaitForReadinessGates(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if len(clusterSpec.Spec.ReadinessGates) == 0 {
+		return nil
+	}
+	return readiness.NewWaiter(c.clusterClient).Wait(ctx, cluster, clusterSpec.Spec.ReadinessGates)
+}
+
+// upgradeServiceLoadBalancer applies the service load balancer with the new spec and bundle,
+// or removes it when it's not configured anymore
+func (c *ClusterManager) upgradeServiceLoadBalancer(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error {
+	if newSpec.Spec.ServiceLoadBalancer != nil {
+		logger.V(3).Info("Upgrading service load balancer")
+		return c.InstallServiceLoadBalancer(ctx, cluster, newSpec)
+	}
+
+	if currentSpec.Spec.ServiceLoadBalancer == nil {
+		return nil
+	}
+
+	logger.V(3).Info("Removing service load balancer")
+	manifest, err := servicelb.GenerateManifest(currentSpec)
+	if err != nil {
+		return err
+	}
+	err = c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.DeleteKubeSpecFromBytes(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting service load balancer: %v", err)
+	}
+	return nil
+}
+
+// InstallCoreAddons applies the core add-ons enabled in the spec with the images pinned in the bundle
+func (c *ClusterManager) InstallCoreAddons(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	for _, addon := range coreaddons.Enabled(clusterSpec) {
+		manifest, err := coreaddons.GenerateManifest(clusterSpec, addon)
+		if err != nil {
+			return err
+		}
+
+		err = c.Retrier.RetryWithContext(ctx,
+			func() error {
+				return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, manifest)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error applying %s manifest: %v", addon, err)
+		}
+	}
+	return nil
+}
+
+// upgradeCoreAddons applies the enabled core add-ons with the new spec and bundle,
+// and removes the ones that were enabled in the current spec but not anymore
+func (c *ClusterManager) upgradeCoreAddons(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error {
+	if currentSpec.Spec.CoreAddons == nil && newSpec.Spec.CoreAddons == nil {
+		return nil
+	}
+
+	logger.V(3).Info("Upgrading core add-ons")
+	if err := c.InstallCoreAddons(ctx, cluster, newSpec); err != nil {
+		return err
+	}
+
+	enabled := map[coreaddons.Addon]bool{}
+	for _, addon := range coreaddons.Enabled(newSpec) {
+		enabled[addon] = true
+	}
+	for _, addon := range coreaddons.Enabled(currentSpec) {
+		if enabled[addon] {
+			continue
+		}
+
+		logger.V(3).Info("Removing core add-on", "addon", addon)
+		manifest, err := coreaddons.GenerateManifest(currentSpec, addon)
+		if err != nil {
+			return err
+		}
+		err = c.Retrier.RetryWithContext(ctx,
+			func() error {
+				return c.clusterClient.DeleteKubeSpecFromBytes(ctx, cluster, manifest)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error deleting %s: %v", addon, err)
+		}
+	}
+	return nil
+}
+
 func (c *ClusterManager) InstallMachineHealthChecks(ctx context.Context, workloadCluster *types.Cluster, provider providers.Provider) error {
 	mhc, err := provider.GenerateMHC()
 	if err != nil {
@@ -577,7 +1140,7 @@ func (c *ClusterManager) InstallMachineHealthChecks(ctx context.Context, workloa
 		logger.V(4).Info("Skipping machine health checks")
 		return nil
 	}
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, mhc)
 		},
@@ -595,7 +1158,7 @@ func (c *ClusterManager) InstallAwsIamAuth(ctx context.Context, managementCluste
 	if err != nil {
 		return fmt.Errorf("error generating aws-iam-authenticator manifest: %v", err)
 	}
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, workloadCluster, awsIamAuthManifest)
 		},
@@ -615,7 +1178,7 @@ func (c *ClusterManager) CreateAwsIamAuthCaSecret(ctx context.Context, cluster *
 	if err != nil {
 		return fmt.Errorf("error generating aws-iam-authenticator ca secret: %v", err)
 	}
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, awsIamAuthCaSecret)
 		},
@@ -699,6 +1262,104 @@ func collectDiagnosticBundle(ctx context.Context, bundle diagnostics.DiagnosticB
 	return nil
 }
 
+// waitForControlPlaneReady waits for the control plane while the VIP monitor watches its endpoint. A flapping VIP
+// stops the wait right away, and a VIP that never answered is added to the error when the wait fails. expectedVIPMoves
+// are the VIP moves that don't count as flapping, like the ones of a rolling upgrade
+func (c *ClusterManager) waitForControlPlaneReady(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, expectedVIPMoves int) error {
+	vip := controlPlaneVIP(clusterSpec)
+	if c.vipMonitor == nil || vip == "" {
+		return c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, ctrlPlaneWaitStr, clusterSpec.Name)
+	}
+
+	waitCtx, cancelWait := context.WithCancel(ctx)
+	defer cancelWait()
+	monitorCtx, stopMonitor := context.WithCancel(ctx)
+	defer stopMonitor()
+	monitorErr := make(chan error, 1)
+	go func() {
+		err := c.vipMonitor.Watch(monitorCtx, vipmonitor.Target{VIP: vip, Cluster: workloadCluster, ExpectedMoves: expectedVIPMoves})
+		if isVIPFlapping(err) {
+			cancelWait()
+		}
+		monitorErr <- err
+	}()
+
+	err := c.clusterClient.WaitForControlPlaneReady(waitCtx, managementCluster, ctrlPlaneWaitStr, clusterSpec.Name)
+	stopMonitor()
+	vipErr := <-monitorErr
+	if isVIPFlapping(vipErr) {
+		return vipErr
+	}
+	if err != nil && vipErr != nil {
+		return fmt.Errorf("%v: %v", err, vipErr)
+	}
+	return err
+}
+
+// monitorControllers watches the CAPI and provider controllers of the cluster while a wait runs. The returned context is
+// cancelled when a controller fails, so the wait stops, and stop returns the controller failure instead of the wait error
+func (c *ClusterManager) monitorControllers(ctx context.Context, cluster *types.Cluster, provider providers.Provider, externalEtcdTopology bool) (waitCtx context.Context, stop func(error) error) {
+	if c.controllerMonitor == nil {
+		return ctx, func(err error) error { return err }
+	}
+	namespaces := controllerNamespaces(provider, externalEtcdTopology)
+
+	waitCtx, cancelWait := context.WithCancel(ctx)
+	monitorCtx, stopMonitor := context.WithCancel(ctx)
+	monitorErr := make(chan error, 1)
+	go func() {
+		err := c.controllerMonitor.Watch(monitorCtx, controllermonitor.Target{Cluster: cluster, Namespaces: namespaces})
+		if err != nil {
+			cancelWait()
+		}
+		monitorErr <- err
+	}()
+
+	return waitCtx, func(err error) error {
+		stopMonitor()
+		controllerErr := <-monitorErr
+		cancelWait()
+		if controllerErr != nil {
+			return fmt.Errorf("controller failed while waiting for the cluster: %v", controllerErr)
+		}
+		return err
+	}
+}
+
+// controllerNamespaces returns the namespaces of the CAPI and provider controllers
+func controllerNamespaces(provider providers.Provider, externalEtcdTopology bool) []string {
+	deployments := []map[string][]string{internal.CAPIDeployments, provider.GetDeployments()}
+	if externalEtcdTopology {
+		deployments = append(deployments, internal.ExternalEtcdDeployments)
+	}
+	var namespaces []string
+	for _, d := range deployments {
+		for namespace := range d {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func isVIPFlapping(err error) bool {
+	var flapping *vipmonitor.FlappingError
+	return errors.As(err, &flapping)
+}
+
+// controlPlaneVIP returns the control plane endpoint of the providers that serve it with kube-vip
+func controlPlaneVIP(clusterSpec *cluster.Spec) string {
+	switch clusterSpec.Cluster.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind, v1alpha1.TinkerbellDatacenterKind:
+	default:
+		return ""
+	}
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint == nil {
+		return ""
+	}
+	return clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+}
+
 func (c *ClusterManager) waitForControlPlaneReplicasReady(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	isCpReady := func() error {
 		return c.clusterClient.ValidateControlPlaneNodes(ctx, managementCluster, clusterSpec.Name)
@@ -715,7 +1376,7 @@ func (c *ClusterManager) waitForControlPlaneReplicasReady(ctx context.Context, m
 	}
 
 	r := retrier.New(timeout)
-	if err := r.Retry(isCpReady); err != nil {
+	if err := r.RetryWithContext(ctx, isCpReady); err != nil {
 		return fmt.Errorf("retries exhausted waiting for controlplane replicas to be ready: %v", err)
 	}
 	return nil
@@ -742,7 +1403,7 @@ func (c *ClusterManager) waitForMachineDeploymentReplicasReady(ctx context.Conte
 	}
 
 	r := retrier.New(timeout)
-	if err := r.Retry(isMdReady); err != nil {
+	if err := r.RetryWithContext(ctx, isMdReady); err != nil {
 		return fmt.Errorf("retries exhausted waiting for machinedeployment replicas to be ready: %v", err)
 	}
 	return nil
@@ -780,8 +1441,9 @@ func (c *ClusterManager) waitForNodesReady(ctx context.Context, managementCluste
 		timeout = c.machinesMinWait
 	}
 
+	// The context is cancelled when a controller fails, there is no point waiting for the machines after that
 	r := retrier.New(timeout, retrier.WithRetryPolicy(policy))
-	if err := r.Retry(areNodesReady); err != nil {
+	if err := r.RetryWithContext(ctx, areNodesReady); err != nil {
 		return fmt.Errorf("retries exhausted waiting for machines to be ready: %v", err)
 	}
 
@@ -852,13 +1514,58 @@ func (c *ClusterManager) CreateEKSAResources(ctx context.Context, cluster *types
 	}
 	logger.V(4).Info("Applying eksa yaml resources to cluster")
 	logger.V(6).Info(string(resourcesSpec))
-	err = c.applyResource(ctx, cluster, resourcesSpec)
-	if err != nil {
+	if err = c.applier.Apply(ctx, cluster, resourcesSpec); err != nil {
+		return fmt.Errorf("error applying eks-a spec: %v", err)
+	}
+	if err = c.clearEndpointMigrationAnnotation(ctx, cluster, clusterSpec); err != nil {
+		return err
+	}
+	if err = c.clearForcedUnsupportedChanges(ctx, cluster, clusterSpec); err != nil {
 		return err
 	}
 	return c.ApplyBundles(ctx, clusterSpec, cluster)
 }
 
+// clearEndpointMigrationAnnotation removes the control plane endpoint migration annotation once the cluster with
+// the new endpoint is applied, so a later endpoint change has to be allowed again
+func (c *ClusterManager) clearEndpointMigrationAnnotation(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if !clusterSpec.Cluster.IsControlPlaneEndpointMigrationAllowed() {
+		return nil
+	}
+
+	annotation := clusterSpec.Cluster.ControlPlaneEndpointMigrationAnnotation()
+	err := c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.RemoveAnnotationInNamespace(ctx, clusterSpec.ResourceType(), clusterSpec.Name, annotation, cluster, clusterSpec.Namespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error removing control plane endpoint migration annotation: %v", err)
+	}
+	clusterSpec.Cluster.ClearControlPlaneEndpointMigrationAnnotation()
+	return nil
+}
+
+// clearForcedUnsupportedChanges removes the force unsupported changes annotation once the cluster with the forced
+// changes is applied, so later changes to those fields are rejected again unless they are forced explicitly
+func (c *ClusterManager) clearForcedUnsupportedChanges(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if len(clusterSpec.Cluster.ForcedUnsupportedChanges()) == 0 {
+		return nil
+	}
+
+	annotation := clusterSpec.Cluster.ForceUnsupportedChangesAnnotation()
+	err := c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.RemoveAnnotationInNamespace(ctx, clusterSpec.ResourceType(), clusterSpec.Name, annotation, cluster, clusterSpec.Namespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error removing force unsupported changes annotation: %v", err)
+	}
+	clusterSpec.Cluster.ClearForcedUnsupportedChanges()
+	return nil
+}
+
 func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	clusterSpec.Bundles.Name = clusterSpec.Name
 	clusterSpec.Bundles.Namespace = clusterSpec.Namespace
@@ -867,7 +1574,7 @@ func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.
 		return fmt.Errorf("error outputting bundle yaml: %v", err)
 	}
 	logger.V(1).Info("Applying Bundles to cluster")
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, bundleObj)
 		},
@@ -875,12 +1582,33 @@ func (c *ClusterManager) ApplyBundles(ctx context.Context, clusterSpec *cluster.
 	if err != nil {
 		return fmt.Errorf("error applying bundle spec: %v", err)
 	}
+
+	if releases := clusterSpec.Releases(); releases != nil {
+		c.applyReleases(ctx, releases, cluster)
+	}
 	return nil
 }
 
+// applyReleases stores the releases manifest in the cluster, so the CLI can resolve the versions from it in later operations.
+// The manifest can still be downloaded when it's not stored, so failing to store it doesn't fail the operation
+func (c *ClusterManager) applyReleases(ctx context.Context, releases *releasev1alpha1.Release, cluster *types.Cluster) {
+	stored := releases.DeepCopy()
+	stored.TypeMeta = metav1.TypeMeta{APIVersion: releasev1alpha1.GroupVersion.String(), Kind: releasev1alpha1.ReleaseKind}
+	stored.ObjectMeta = metav1.ObjectMeta{Name: constants.EksaReleasesName, Namespace: constants.EksaSystemNamespace}
+	releasesObj, err := yaml.Marshal(stored)
+	if err != nil {
+		logger.Info("Warning: failed storing the releases manifest in the cluster", "error", err)
+		return
+	}
+	logger.V(3).Info("Applying Release to cluster")
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, releasesObj); err != nil {
+		logger.Info("Warning: failed storing the releases manifest in the cluster", "error", err)
+	}
+}
+
 func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
 	pausedAnnotation := map[string]string{clusterSpec.PausedAnnotation(): "true"}
-	err := c.Retrier.Retry(
+	err := c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.UpdateAnnotationInNamespace(ctx, provider.DatacenterResourceType(), clusterSpec.Spec.DatacenterRef.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
 		},
@@ -890,7 +1618,7 @@ func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, clust
 	}
 	if provider.MachineResourceType() != "" {
 		for _, machineConfigRef := range clusterSpec.MachineConfigRefs() {
-			err := c.Retrier.Retry(
+			err := c.Retrier.RetryWithContext(ctx,
 				func() error {
 					return c.clusterClient.UpdateAnnotationInNamespace(ctx, provider.MachineResourceType(), machineConfigRef.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
 				},
@@ -901,7 +1629,7 @@ func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, clust
 		}
 	}
 
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.UpdateAnnotationInNamespace(ctx, clusterSpec.ResourceType(), clusterSpec.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
 		},
@@ -914,7 +1642,7 @@ func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, clust
 
 func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
 	pausedAnnotation := clusterSpec.PausedAnnotation()
-	err := c.Retrier.Retry(
+	err := c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.RemoveAnnotationInNamespace(ctx, provider.DatacenterResourceType(), clusterSpec.Spec.DatacenterRef.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
 		},
@@ -924,7 +1652,7 @@ func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, clus
 	}
 	if provider.MachineResourceType() != "" {
 		for _, machineConfigRef := range clusterSpec.MachineConfigRefs() {
-			err := c.Retrier.Retry(
+			err := c.Retrier.RetryWithContext(ctx,
 				func() error {
 					return c.clusterClient.RemoveAnnotationInNamespace(ctx, provider.MachineResourceType(), machineConfigRef.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
 				},
@@ -935,7 +1663,7 @@ func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, clus
 		}
 	}
 
-	err = c.Retrier.Retry(
+	err = c.Retrier.RetryWithContext(ctx,
 		func() error {
 			return c.clusterClient.RemoveAnnotationInNamespace(ctx, clusterSpec.ResourceType(), clusterSpec.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
 		},
@@ -949,18 +1677,99 @@ func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, clus
 	return nil
 }
 
-func (c *ClusterManager) applyResource(ctx context.Context, cluster *types.Cluster, resourcesSpec []byte) error {
-	err := c.Retrier.Retry(
+// PauseClusterForMaintenance stops both the EKS-A and the CAPI controllers from reconciling a cluster
+// and marks it as in maintenance, so it stays untouched until ResumeClusterFromMaintenance is called
+func (c *ClusterManager) PauseClusterForMaintenance(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if err := c.PauseEKSAControllerReconcile(ctx, managementCluster, clusterSpec, provider); err != nil {
+		return err
+	}
+
+	maintenanceAnnotation := map[string]string{clusterSpec.MaintenanceAnnotation(): "true"}
+	err := c.Retrier.RetryWithContext(ctx,
 		func() error {
-			return c.clusterClient.ApplyKubeSpecFromBytesForce(ctx, cluster, resourcesSpec)
+			return c.clusterClient.UpdateAnnotationInNamespace(ctx, clusterSpec.ResourceType(), clusterSpec.Name, maintenanceAnnotation, managementCluster, clusterSpec.Namespace)
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("error applying eks-a spec: %v", err)
+		return fmt.Errorf("error updating annotation when marking cluster in maintenance: %v", err)
+	}
+
+	err = c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.SetCAPIClusterPaused(ctx, managementCluster, clusterSpec.Name, true)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error pausing CAPI cluster reconciliation: %v", err)
 	}
 	return nil
 }
 
+// ResumeClusterFromMaintenance reverts PauseClusterForMaintenance, resuming CAPI reconciliation first
+// so the EKS-A controller doesn't try to reconcile a cluster CAPI is still ignoring
+func (c *ClusterManager) ResumeClusterFromMaintenance(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	err := c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.SetCAPIClusterPaused(ctx, managementCluster, clusterSpec.Name, false)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error resuming CAPI cluster reconciliation: %v", err)
+	}
+
+	err = c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.clusterClient.RemoveAnnotationInNamespace(ctx, clusterSpec.ResourceType(), clusterSpec.Name, clusterSpec.MaintenanceAnnotation(), managementCluster, clusterSpec.Namespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error removing annotation when taking cluster out of maintenance: %v", err)
+	}
+
+	return c.ResumeEKSAControllerReconcile(ctx, managementCluster, clusterSpec, provider)
+}
+
+// IsDeletionProtected reports if the cluster object has deletion protection enabled
+func (c *ClusterManager) IsDeletionProtected(ctx context.Context, cluster *types.Cluster, clusterName string) (bool, error) {
+	eksaCluster, err := c.clusterClient.GetEksaCluster(ctx, cluster, clusterName)
+	if err != nil {
+		return false, err
+	}
+	return eksaCluster.Spec.DeletionProtection, nil
+}
+
+type PauseStatus struct {
+	InMaintenance       bool
+	EKSAReconcilePaused bool
+	CAPIReconcilePaused bool
+}
+
+// GetPauseStatus reports which controllers are currently paused for a cluster
+func (c *ClusterManager) GetPauseStatus(ctx context.Context, managementCluster *types.Cluster, clusterName string) (*PauseStatus, error) {
+	eksaCluster, err := c.clusterClient.GetEksaCluster(ctx, managementCluster, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &PauseStatus{
+		InMaintenance:       eksaCluster.IsInMaintenance(),
+		EKSAReconcilePaused: eksaCluster.IsReconcilePaused(),
+	}
+
+	capiClusters, err := c.clusterClient.GetClusters(ctx, managementCluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, capiCluster := range capiClusters {
+		if capiCluster.Metadata.Name == clusterName {
+			status.CAPIReconcilePaused = capiCluster.Spec.Paused
+			break
+		}
+	}
+
+	return status, nil
+}
+
 func (c *ClusterManager) GetCurrentClusterSpec(ctx context.Context, clus *types.Cluster, clusterName string) (*cluster.Spec, error) {
 	eksaCluster, err := c.clusterClient.GetEksaCluster(ctx, clus, clusterName)
 	if err != nil {
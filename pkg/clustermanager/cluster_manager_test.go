@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
@@ -21,12 +23,16 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
 	mocksmanager "github.com/aws/eks-anywhere/pkg/clustermanager/mocks"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controllermonitor"
 	mocksdiagnostics "github.com/aws/eks-anywhere/pkg/diagnostics/interfaces/mocks"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	mockswriter "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	mocksprovider "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/version"
+	"github.com/aws/eks-anywhere/pkg/vipmonitor"
 	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -52,6 +58,19 @@ func TestClusterManagerInstallNetworkingSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerInstallNetworkingEmptyManifest(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec()
+
+	c, m := newClusterManager(t)
+	m.networking.EXPECT().GenerateManifest(clusterSpec).Return(nil, nil)
+
+	if err := c.InstallNetworking(ctx, cluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.InstallNetworking() error = %v, wantErr nil", err)
+	}
+}
+
 func TestClusterManagerInstallNetworkingNetworkingError(t *testing.T) {
 	ctx := context.Background()
 	cluster := &types.Cluster{}
@@ -89,10 +108,10 @@ func TestClusterManagerInstallStorageClassSuccess(t *testing.T) {
 	storageClassManifest := []byte("yaml: values")
 
 	c, m := newClusterManager(t)
-	m.provider.EXPECT().GenerateStorageClass().Return(storageClassManifest)
+	m.provider.EXPECT().GenerateStorageClass().Return(storageClassManifest, nil)
 	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, storageClassManifest)
 
-	if err := c.InstallStorageClass(ctx, cluster, m.provider); err != nil {
+	if err := c.InstallStorageClass(ctx, cluster, test.NewClusterSpec(), m.provider); err != nil {
 		t.Errorf("ClusterManager.InstallStorageClass() error = %v, wantErr nil", err)
 	}
 }
@@ -102,13 +121,46 @@ func TestClusterManagerInstallStorageClassProviderNothing(t *testing.T) {
 	cluster := &types.Cluster{}
 
 	c, m := newClusterManager(t)
-	m.provider.EXPECT().GenerateStorageClass().Return(nil)
+	m.provider.EXPECT().GenerateStorageClass().Return(nil, nil)
+
+	if err := c.InstallStorageClass(ctx, cluster, test.NewClusterSpec(), m.provider); err != nil {
+		t.Errorf("ClusterManager.InstallStorageClass() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallStorageClassLocalPath(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec()
+	clusterSpec.Spec.LocalPathStorage = &v1alpha1.LocalPathStorageConfiguration{}
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GenerateStorageClass().Return(nil, nil)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, test.OfType("[]uint8"))
 
-	if err := c.InstallStorageClass(ctx, cluster, m.provider); err != nil {
+	if err := c.InstallStorageClass(ctx, cluster, clusterSpec, m.provider); err != nil {
 		t.Errorf("ClusterManager.InstallStorageClass() error = %v, wantErr nil", err)
 	}
 }
 
+func TestClusterManagerInstallStorageClassLocalPathClientError(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec()
+	clusterSpec.Spec.LocalPathStorage = &v1alpha1.LocalPathStorageConfiguration{}
+	retries := 2
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GenerateStorageClass().Return(nil, nil)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, test.OfType("[]uint8")).Return(
+		errors.New("error from client")).Times(retries)
+
+	c.Retrier = retrier.NewWithMaxRetries(retries, 1*time.Microsecond)
+	if err := c.InstallStorageClass(ctx, cluster, clusterSpec, m.provider); err == nil {
+		t.Errorf("ClusterManager.InstallStorageClass() error = nil, wantErr not nil")
+	}
+}
+
 func TestClusterManagerInstallStorageClassClientError(t *testing.T) {
 	ctx := context.Background()
 	cluster := &types.Cluster{}
@@ -116,12 +168,245 @@ func TestClusterManagerInstallStorageClassClientError(t *testing.T) {
 	retries := 2
 
 	c, m := newClusterManager(t)
-	m.provider.EXPECT().GenerateStorageClass().Return(storageClassManifest)
+	m.provider.EXPECT().GenerateStorageClass().Return(storageClassManifest, nil)
 	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, storageClassManifest).Return(
 		errors.New("error from client")).Times(retries)
 
 	c.Retrier = retrier.NewWithMaxRetries(retries, 1*time.Microsecond)
-	if err := c.InstallStorageClass(ctx, cluster, m.provider); err == nil {
+	if err := c.InstallStorageClass(ctx, cluster, test.NewClusterSpec(), m.provider); err == nil {
+		t.Errorf("ClusterManager.InstallStorageClass() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerInstallServiceLoadBalancerSuccess(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.ServiceLoadBalancer = &v1alpha1.ServiceLoadBalancerConfiguration{
+			AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{{Addresses: []string{"10.0.0.0/28"}}},
+		}
+	})
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8"))
+
+	if err := c.InstallServiceLoadBalancer(ctx, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.InstallServiceLoadBalancer() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallServiceLoadBalancerClientError(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.ServiceLoadBalancer = &v1alpha1.ServiceLoadBalancerConfiguration{
+			AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{{Addresses: []string{"10.0.0.0/28"}}},
+		}
+	})
+	retries := 2
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8")).Return(
+		errors.New("error from client")).Times(retries)
+
+	c.Retrier = retrier.NewWithMaxRetries(retries, 1*time.Microsecond)
+	if err := c.InstallServiceLoadBalancer(ctx, workloadCluster, clusterSpec); err == nil {
+		t.Errorf("ClusterManager.InstallServiceLoadBalancer() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerInstallCoreAddonsSuccess(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.CoreAddons = &v1alpha1.CoreAddonsConfiguration{MetricsServer: true, NodeProblemDetector: true}
+		s.VersionsBundle.MetricsServer.MetricsServer = anywherev1alpha1.Image{URI: "public.ecr.aws/metrics-server:v0.5.2"}
+		s.VersionsBundle.NodeProblemDetector.NodeProblemDetector = anywherev1alpha1.Image{URI: "public.ecr.aws/node-problem-detector:v0.8.10"}
+	})
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8")).Times(2)
+
+	if err := c.InstallCoreAddons(ctx, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.InstallCoreAddons() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallCoreAddonsNotInBundle(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.CoreAddons = &v1alpha1.CoreAddonsConfiguration{MetricsServer: true}
+	})
+
+	c, _ := newClusterManager(t)
+	if err := c.InstallCoreAddons(ctx, workloadCluster, clusterSpec); err == nil {
+		t.Errorf("ClusterManager.InstallCoreAddons() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerInstallCoreAddonsClientError(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.CoreAddons = &v1alpha1.CoreAddonsConfiguration{MetricsServer: true}
+		s.VersionsBundle.MetricsServer.MetricsServer = anywherev1alpha1.Image{URI: "public.ecr.aws/metrics-server:v0.5.2"}
+	})
+	retries := 2
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8")).Return(
+		errors.New("error from client")).Times(retries)
+
+	c.Retrier = retrier.NewWithMaxRetries(retries, 1*time.Microsecond)
+	if err := c.InstallCoreAddons(ctx, workloadCluster, clusterSpec); err == nil {
+		t.Errorf("ClusterManager.InstallCoreAddons() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerConfigureCoreDNSSuccess(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "workload.kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.CoreDNS = &v1alpha1.CoreDNSConfiguration{UpstreamResolvers: []string{"10.0.0.2"}, Replicas: 3}
+	})
+	corefile := ".:53 {\n    forward . /etc/resolv.conf\n    cache 30\n}\n"
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetConfigMap(ctx, "workload.kubeconfig", "coredns", "kube-system").Return(
+		&corev1.ConfigMap{Data: map[string]string{"Corefile": corefile}}, nil,
+	)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8"))
+	m.client.EXPECT().Scale(ctx, "deployment", "coredns", 3, gomock.Any(), gomock.Any())
+
+	if err := c.ConfigureCoreDNS(ctx, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.ConfigureCoreDNS() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerConfigureCoreDNSClientError(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "workload.kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.CoreDNS = &v1alpha1.CoreDNSConfiguration{Replicas: 3}
+	})
+	retries := 2
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetConfigMap(ctx, "workload.kubeconfig", "coredns", "kube-system").Return(
+		nil, errors.New("error from client")).Times(retries)
+
+	c.Retrier = retrier.NewWithMaxRetries(retries, 1*time.Microsecond)
+	if err := c.ConfigureCoreDNS(ctx, workloadCluster, clusterSpec); err == nil {
+		t.Errorf("ClusterManager.ConfigureCoreDNS() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerWaitForReadinessGates(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "workload.kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.ReadinessGates = []v1alpha1.ReadinessGate{
+			{Name: "smoke-test", Job: &v1alpha1.ObjectReadinessGate{Name: "smoke-test", Namespace: "tests"}, Timeout: "1m"},
+		}
+	})
+
+	c, m := newClusterManager(t)
+	// Each gate waits with its own deadline, derived from ctx
+	m.client.EXPECT().Wait(gomock.Any(), "workload.kubeconfig", "1m0s", "complete", "job/smoke-test", "tests")
+
+	if err := c.WaitForReadinessGates(ctx, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.WaitForReadinessGates() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerWaitForReadinessGatesNoGates(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "workload.kubeconfig"}
+	clusterSpec := test.NewClusterSpec()
+
+	c, _ := newClusterManager(t)
+	if err := c.WaitForReadinessGates(ctx, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.WaitForReadinessGates() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallHostEntriesSuccess(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "workload.kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+	})
+	coreDNS := &corev1.ConfigMap{Data: map[string]string{"Corefile": ".:53 {\n    forward . /etc/resolv.conf\n}\n"}}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetConfigMap(ctx, "workload.kubeconfig", "coredns", "kube-system").Return(coreDNS, nil)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8"))
+
+	if err := c.InstallHostEntries(ctx, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.InstallHostEntries() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallHostEntriesClientError(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "workload.kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+	})
+	retries := 2
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetConfigMap(ctx, "workload.kubeconfig", "coredns", "kube-system").Return(
+		nil, errors.New("error from client")).Times(retries)
+
+	c.Retrier = retrier.NewWithMaxRetries(retries, 1*time.Microsecond)
+	if err := c.InstallHostEntries(ctx, workloadCluster, clusterSpec); err == nil {
+		t.Errorf("ClusterManager.InstallHostEntries() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerApplyProvenanceSuccess(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "cluster-name"
+	})
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8"))
+
+	if err := c.ApplyProvenance(ctx, workloadCluster, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.ApplyProvenance() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerApplyProvenanceClientError(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "cluster-name"
+	})
+	retries := 2
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, test.OfType("[]uint8")).Return(
+		errors.New("error from client")).Times(retries)
+
+	c.Retrier = retrier.NewWithMaxRetries(retries, 1*time.Microsecond)
+	if err := c.ApplyProvenance(ctx, workloadCluster, clusterSpec); err == nil {
+		t.Errorf("ClusterManager.ApplyProvenance() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerInstallStorageClassProviderError(t *testing.T) {
+	ctx := context.Background()
+	cluster := &types.Cluster{}
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GenerateStorageClass().Return(nil, errors.New("invalid storage class"))
+
+	if err := c.InstallStorageClass(ctx, cluster, test.NewClusterSpec(), m.provider); err == nil {
 		t.Errorf("ClusterManager.InstallStorageClass() error = nil, wantErr not nil")
 	}
 }
@@ -180,6 +465,62 @@ func TestClusterManagerCAPIWaitForDeploymentExternalEtcd(t *testing.T) {
 	}
 }
 
+func TestClusterManagerCAPIWaitForDeploymentControllerFailed(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{Name: "cluster-name", KubeconfigFile: "cluster-name.kubeconfig"}
+	mockCtrl := gomock.NewController(t)
+	monitor := mocksmanager.NewMockControllerMonitor(mockCtrl)
+	c, m := newClusterManager(t, clustermanager.WithControllerMonitor(monitor))
+	clusterSpec := test.NewClusterSpec()
+	failed := &controllermonitor.ControllerFailedError{
+		Namespace: "capv-system",
+		Pod:       "capv-controller-manager-0",
+		Container: "manager",
+		Reason:    controllermonitor.ImagePullBackOff,
+	}
+
+	m.client.EXPECT().InitInfrastructure(ctx, clusterSpec, clusterObj, m.provider)
+	m.provider.EXPECT().GetDeployments().Return(map[string][]string{"capv-system": {"capv-controller-manager"}})
+	monitor.EXPECT().Watch(gomock.Any(), controllermonitor.Target{
+		Cluster:    clusterObj,
+		Namespaces: []string{"capi-kubeadm-bootstrap-system", "capi-kubeadm-control-plane-system", "capi-system", "capv-system", "cert-manager"},
+	}).Return(failed)
+	m.client.EXPECT().WaitForDeployment(gomock.Any(), clusterObj, "30m", "Available", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *types.Cluster, _, _, _, _ string) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+
+	err := c.InstallCAPI(ctx, clusterSpec, clusterObj, m.provider)
+	if err == nil || !strings.Contains(err.Error(), "controller failed while waiting for the cluster: container manager of pod capv-system/capv-controller-manager-0 is in ImagePullBackOff") {
+		t.Errorf("ClusterManager.InstallCAPI() error = %v, want controller failed error", err)
+	}
+}
+
+func TestClusterManagerCAPIWaitForDeploymentControllersHealthy(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{}
+	mockCtrl := gomock.NewController(t)
+	monitor := mocksmanager.NewMockControllerMonitor(mockCtrl)
+	c, m := newClusterManager(t, clustermanager.WithControllerMonitor(monitor))
+	clusterSpec := test.NewClusterSpec()
+
+	m.client.EXPECT().InitInfrastructure(ctx, clusterSpec, clusterObj, m.provider)
+	m.provider.EXPECT().GetDeployments().Return(map[string][]string{}).Times(2)
+	monitor.EXPECT().Watch(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ controllermonitor.Target) error {
+			<-ctx.Done()
+			return nil
+		},
+	)
+	m.client.EXPECT().WaitForDeployment(gomock.Any(), clusterObj, "30m", "Available", gomock.Any(), gomock.Any()).Times(6)
+
+	if err := c.InstallCAPI(ctx, clusterSpec, clusterObj, m.provider); err != nil {
+		t.Errorf("ClusterManager.InstallCAPI() error = %v, wantErr nil", err)
+	}
+}
+
 func TestClusterManagerSaveLogsSuccess(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -248,6 +589,191 @@ func TestClusterManagerCreateWorkloadClusterSuccess(t *testing.T) {
 	}
 }
 
+type manifestPolicy struct {
+	manifests [][]byte
+	err       error
+}
+
+func (p *manifestPolicy) Enforce(manifest []byte) error {
+	p.manifests = append(p.manifests, manifest)
+	return p.err
+}
+
+func TestClusterManagerCreateManifestPolicyValidations(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = clusterName
+	})
+	policy := &manifestPolicy{err: errors.New("manifests denied by policies")}
+
+	c, m := newClusterManager(t, clustermanager.WithManifestPolicy(policy))
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, &types.Cluster{Name: clusterName}, clusterSpec).Return([]byte("cp"), []byte("md"), nil)
+	m.provider.EXPECT().DatacenterConfig().Return(&v1alpha1.VSphereDatacenterConfig{})
+	m.provider.EXPECT().MachineConfigs()
+
+	validations := c.CreateManifestPolicyValidations(ctx, clusterSpec, m.provider)
+	if len(validations) != 1 {
+		t.Fatalf("ClusterManager.CreateManifestPolicyValidations() = %d validations, want 1", len(validations))
+	}
+	if result := validations[0](); result.Err == nil {
+		t.Error("ClusterManager.CreateManifestPolicyValidations() error = nil, want policy error")
+	}
+	if len(policy.manifests) != 1 || !strings.HasPrefix(string(policy.manifests[0]), "cp\n---\nmd\n---\n") || !strings.Contains(string(policy.manifests[0]), "name: cluster-name") {
+		t.Errorf("ManifestPolicy.Enforce() manifests = %q, want the CAPI and EKS-A specs", policy.manifests)
+	}
+}
+
+func TestClusterManagerUpgradeManifestPolicyValidations(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = clusterName
+	})
+	managementCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	policy := &manifestPolicy{}
+
+	c, m := newClusterManager(t, clustermanager.WithManifestPolicy(policy))
+	m.client.EXPECT().GetEksaCluster(ctx, managementCluster, clusterName).Return(nil, errors.New("cluster not found"))
+
+	validations := c.UpgradeManifestPolicyValidations(ctx, managementCluster, managementCluster, clusterSpec, m.provider)
+	if len(validations) != 1 {
+		t.Fatalf("ClusterManager.UpgradeManifestPolicyValidations() = %d validations, want 1", len(validations))
+	}
+	if result := validations[0](); result.Err == nil {
+		t.Error("ClusterManager.UpgradeManifestPolicyValidations() error = nil, want current spec error")
+	}
+	if len(policy.manifests) != 0 {
+		t.Errorf("ManifestPolicy.Enforce() calls = %d, want 0", len(policy.manifests))
+	}
+}
+
+func TestClusterManagerManifestPolicyValidationsWithoutPolicy(t *testing.T) {
+	c, m := newClusterManager(t)
+
+	if validations := c.CreateManifestPolicyValidations(context.Background(), test.NewClusterSpec(), m.provider); len(validations) != 0 {
+		t.Errorf("ClusterManager.CreateManifestPolicyValidations() = %d validations, want 0", len(validations))
+	}
+}
+
+func vipClusterSpec(clusterName string) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = clusterName
+		s.Spec.DatacenterRef = v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: clusterName}
+		s.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	})
+}
+
+func expectWorkloadClusterUntilControlPlane(ctx context.Context, m *clusterManagerMocks, cluster *types.Cluster, clusterSpec *cluster.Spec) {
+	m.provider.EXPECT().GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	m.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(ctx, cluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	m.client.EXPECT().KubeconfigSecretAvailable(ctx, "", cluster.Name, constants.EksaSystemNamespace).Return(true, nil)
+	m.provider.EXPECT().RunPostControlPlaneCreation(ctx, clusterSpec, gomock.Any())
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, cluster.Name, cluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, cluster.Name)
+	m.writer.EXPECT().Write(cluster.Name+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil())).Return("cluster-name.kubeconfig", nil)
+	m.writer.EXPECT().Write(cluster.Name+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+}
+
+func TestClusterManagerCreateWorkloadClusterVIPFlapping(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := vipClusterSpec(clusterName)
+	cluster := &types.Cluster{Name: clusterName}
+	mockCtrl := gomock.NewController(t)
+	monitor := mocksmanager.NewMockVIPMonitor(mockCtrl)
+	flapping := &vipmonitor.FlappingError{VIP: "1.2.3.4"}
+
+	c, m := newClusterManager(t, clustermanager.WithVIPMonitor(monitor))
+	expectWorkloadClusterUntilControlPlane(ctx, m, cluster, clusterSpec)
+	monitor.EXPECT().Watch(gomock.Any(), vipmonitor.Target{VIP: "1.2.3.4", Cluster: &types.Cluster{Name: clusterName, KubeconfigFile: "cluster-name.kubeconfig"}}).Return(flapping)
+	m.client.EXPECT().WaitForControlPlaneReady(gomock.Any(), cluster, "60m", clusterName).DoAndReturn(
+		func(ctx context.Context, _ *types.Cluster, _, _ string) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+
+	_, err := c.CreateWorkloadCluster(ctx, cluster, clusterSpec, m.provider)
+	if err == nil || !strings.Contains(err.Error(), "control plane VIP 1.2.3.4 is flapping") {
+		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, want VIP flapping error", err)
+	}
+}
+
+func TestClusterManagerCreateWorkloadClusterVIPNeverReachable(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := vipClusterSpec(clusterName)
+	cluster := &types.Cluster{Name: clusterName}
+	mockCtrl := gomock.NewController(t)
+	monitor := mocksmanager.NewMockVIPMonitor(mockCtrl)
+
+	c, m := newClusterManager(t, clustermanager.WithVIPMonitor(monitor))
+	expectWorkloadClusterUntilControlPlane(ctx, m, cluster, clusterSpec)
+	monitor.EXPECT().Watch(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ vipmonitor.Target) error {
+			<-ctx.Done()
+			return &vipmonitor.UnreachableError{VIP: "1.2.3.4", Err: errors.New("i/o timeout")}
+		},
+	)
+	m.client.EXPECT().WaitForControlPlaneReady(gomock.Any(), cluster, "60m", clusterName).Return(errors.New("timed out"))
+
+	_, err := c.CreateWorkloadCluster(ctx, cluster, clusterSpec, m.provider)
+	if err == nil || !strings.Contains(err.Error(), "timed out: control plane VIP 1.2.3.4 never answered, last error: i/o timeout") {
+		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, want wait error with VIP diagnostics", err)
+	}
+}
+
+func TestClusterManagerCreateWorkloadClusterVIPMonitorSkippedWithoutKubeVip(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = clusterName
+		s.Spec.DatacenterRef = v1alpha1.Ref{Kind: v1alpha1.DockerDatacenterKind, Name: clusterName}
+	})
+	cluster := &types.Cluster{Name: clusterName}
+	mockCtrl := gomock.NewController(t)
+	monitor := mocksmanager.NewMockVIPMonitor(mockCtrl)
+
+	c, m := newClusterManager(t, clustermanager.WithVIPMonitor(monitor))
+	expectWorkloadClusterUntilControlPlane(ctx, m, cluster, clusterSpec)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, cluster, "60m", clusterName)
+	m.client.EXPECT().GetMachines(ctx, cluster, cluster.Name).Return([]types.Machine{}, nil)
+
+	if _, err := c.CreateWorkloadCluster(ctx, cluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.CreateWorkloadCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerResumeWorkloadClusterSuccess(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "cluster-name"
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = clusterName
+		s.Spec.ControlPlaneConfiguration.Count = 3
+		s.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+	})
+
+	cluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().KubeconfigSecretAvailable(ctx, "", clusterName, constants.EksaSystemNamespace).Return(true, nil)
+	m.provider.EXPECT().RunPostControlPlaneCreation(ctx, clusterSpec, cluster)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, cluster, "60m", clusterName)
+	m.client.EXPECT().GetMachines(ctx, cluster, cluster.Name).Return([]types.Machine{}, nil)
+	kubeconfig := []byte("content")
+	m.client.EXPECT().GetWorkloadKubeconfig(ctx, clusterName, cluster).Return(kubeconfig, nil)
+	m.provider.EXPECT().UpdateKubeConfig(&kubeconfig, clusterName)
+	m.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	if _, err := c.ResumeWorkloadCluster(ctx, cluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.ResumeWorkloadCluster() error = %v, wantErr nil", err)
+	}
+}
+
 func TestClusterManagerCreateWorkloadClusterWithExternalEtcdSuccess(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -460,7 +986,167 @@ func TestClusterManagerCreateWorkloadClusterWaitForMachinesSuccessAfterRetries(t
 	}
 }
 
-func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
+func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+type providerWithUpgradeHooks struct {
+	*mocksprovider.MockProvider
+	*mocksprovider.MockUpgradeHooks
+}
+
+func TestClusterManagerUpgradeWorkloadClusterRunsProviderHooks(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	hooks := mocksprovider.NewMockUpgradeHooks(gomock.NewController(t))
+	provider := providerWithUpgradeHooks{tt.mocks.provider, hooks}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace),
+		hooks.EXPECT().PostControlPlaneUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec),
+		hooks.EXPECT().PreMachineRollout(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace),
+	)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterPreMachineRolloutHookError(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	hooks := mocksprovider.NewMockUpgradeHooks(gomock.NewController(t))
+	provider := providerWithUpgradeHooks{tt.mocks.provider, hooks}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	hooks.EXPECT().PostControlPlaneUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec)
+	hooks.EXPECT().PreMachineRollout(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec).Return(errors.New("template import failed"))
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, provider); err == nil {
+		t.Error("ClusterManager.UpgradeCluster() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterRemoveServiceLoadBalancer(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	tt.oldClusterConfig.Spec.ServiceLoadBalancer = &v1alpha1.ServiceLoadBalancerConfiguration{
+		AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{{Addresses: []string{"10.0.0.0/28"}}},
+	}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, gomock.Any(), tt.clusterSpec)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, wCluster, test.OfType("[]uint8"))
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterEnableCoreAddons(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Spec.CoreAddons = &v1alpha1.CoreAddonsConfiguration{MetricsServer: true}
+	tt.clusterSpec.VersionsBundle.MetricsServer.MetricsServer = anywherev1alpha1.Image{URI: "public.ecr.aws/metrics-server:v0.5.2"}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, gomock.Any(), tt.clusterSpec)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, wCluster, test.OfType("[]uint8"))
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterRemoveCoreDNSConfiguration(t *testing.T) {
 	clusterName := "cluster-name"
 	mCluster := &types.Cluster{
 		Name: clusterName,
@@ -470,11 +1156,12 @@ func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
 	}
 
 	tt := newSpecChangedTest(t)
+	tt.oldClusterConfig.Spec.CoreDNS = &v1alpha1.CoreDNSConfiguration{Replicas: 3}
 	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
 	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
-	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, gomock.Any(), tt.clusterSpec)
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
-	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
 	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
@@ -482,12 +1169,57 @@ func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
 	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
 	tt.mocks.provider.EXPECT().GetDeployments()
 	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetConfigMap(tt.ctx, wCluster.KubeconfigFile, "coredns", "kube-system").Return(
+		&corev1.ConfigMap{Data: map[string]string{"Corefile": ".:53 {\n    forward . /etc/resolv.conf\n    cache 30\n}\n"}}, nil,
+	)
+	tt.mocks.client.EXPECT().Scale(tt.ctx, "deployment", "coredns", 2, gomock.Any(), gomock.Any())
 
 	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
 		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
 	}
 }
 
+func TestClusterManagerUpgradeWorkloadClusterControlPlaneEndpointMigration(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "2.2.2.2"}
+	kubeconfig := []byte("server: https://1.1.1.1:6443")
+	newKubeconfig := []byte("server: https://2.2.2.2:6443")
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	gomock.InOrder(
+		tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, test.OfType("*cluster.Spec"), tt.clusterSpec).Do(
+			func(_ context.Context, _, _ *types.Cluster, currentSpec, _ *cluster.Spec) {
+				tt.Expect(currentSpec.Spec.ControlPlaneConfiguration.Endpoint.Host).NotTo(Equal("2.2.2.2"))
+			}),
+		// the control plane is rolled again without the previous endpoint once the migration is complete
+		tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec),
+	)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(3)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MinTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).MinTimes(2)
+	tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, clusterName, mCluster).Return(kubeconfig, nil)
+	tt.mocks.provider.EXPECT().UpdateKubeConfig(&newKubeconfig, clusterName)
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.kubeconfig", newKubeconfig, gomock.Not(gomock.Nil())).Return("new.kubeconfig", nil)
+	tt.mocks.client.EXPECT().GetNamespace(tt.ctx, "new.kubeconfig", constants.KubeSystemNamespace).Times(2)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil).Times(2)
+	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+	tt.Expect(wCluster.KubeconfigFile).To(Equal("new.kubeconfig"))
+}
+
 func TestClusterManagerUpgradeWorkloadClusterWaitForMachinesTimeout(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -601,7 +1333,7 @@ func TestClusterManagerMoveCAPISuccess(t *testing.T) {
 
 	c, m := newClusterManager(t)
 	m.client.EXPECT().GetMachines(ctx, from, to.Name)
-	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.expectMoveObjects(ctx, from, to)
 	capiClusterName := "capi-cluster"
 	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}}}
 	m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil)
@@ -629,12 +1361,15 @@ func TestClusterManagerMoveCAPIErrorMove(t *testing.T) {
 	})
 	ctx := context.Background()
 
-	c, m := newClusterManager(t)
+	c, m := newClusterManager(t, clustermanager.WithMoveRetrier(retrier.NewWithMaxRetries(2, 0)))
 	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("error moving"))
+	m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil)
+	m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil).Times(3)
+	m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("error moving")).Times(2)
 
-	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
-		t.Error("ClusterManager.MoveCAPI() error = nil, wantErr not nil")
+	err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec)
+	if err == nil || err.Error() != "error moving CAPI management from source to target: error moving" {
+		t.Errorf("ClusterManager.MoveCAPI() error = %v, want error moving", err)
 	}
 }
 
@@ -654,7 +1389,7 @@ func TestClusterManagerMoveCAPIErrorGetClusters(t *testing.T) {
 
 	c, m := newClusterManager(t)
 	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.expectMoveObjects(ctx, from, to)
 	m.client.EXPECT().GetClusters(ctx, to).Return(nil, errors.New("error getting clusters"))
 
 	if err := c.MoveCAPI(ctx, from, to, from.Name, clusterSpec); err == nil {
@@ -677,7 +1412,7 @@ func TestClusterManagerMoveCAPIErrorWaitForControlPlane(t *testing.T) {
 	ctx := context.Background()
 
 	c, m := newClusterManager(t)
-	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.expectMoveObjects(ctx, from, to)
 	capiClusterName := "capi-cluster"
 	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}}}
 	m.client.EXPECT().GetMachines(ctx, from, from.Name)
@@ -705,7 +1440,7 @@ func TestClusterManagerMoveCAPIErrorGetMachines(t *testing.T) {
 
 	c, m := newClusterManager(t, clustermanager.WithWaitForMachines(0, 10*time.Microsecond, 20*time.Microsecond))
 	m.client.EXPECT().GetMachines(ctx, from, from.Name)
-	m.client.EXPECT().MoveManagement(ctx, from, to)
+	m.expectMoveObjects(ctx, from, to)
 	m.client.EXPECT().GetClusters(ctx, to)
 	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, to.Name)
 	m.client.EXPECT().ValidateWorkerNodes(ctx, to, to.Name)
@@ -716,6 +1451,260 @@ func TestClusterManagerMoveCAPIErrorGetMachines(t *testing.T) {
 	}
 }
 
+func TestClusterManagerMoveCAPIAndVerifySuccess(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = from.Name
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: 1, MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+	})
+	machines := []types.Machine{
+		{Metadata: types.MachineMetadata{Name: "cp", Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}},
+		{Metadata: types.MachineMetadata{Name: "md-0", Labels: map[string]string{clusterv1.MachineDeploymentLabelName: "md-0"}}},
+	}
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: from.Name}}}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.expectMoveObjects(ctx, from, to)
+	gomock.InOrder(
+		m.client.EXPECT().GetMachines(ctx, from, from.Name).Return(machines, nil),
+		m.client.EXPECT().GetMachines(ctx, from, from.Name).Return(machines, nil),
+		m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil),
+		m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", from.Name),
+		m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, from.Name),
+		m.client.EXPECT().ValidateWorkerNodes(ctx, to, from.Name),
+		m.client.EXPECT().GetMachines(ctx, to, from.Name).Return(machines, nil),
+		m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil),
+		m.client.EXPECT().GetMachines(ctx, to, from.Name).Return(machines, nil),
+		m.client.EXPECT().GetMachines(ctx, from, from.Name).Return(nil, nil),
+	)
+
+	if err := c.MoveCAPIAndVerify(ctx, from, to, from.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPIAndVerify() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerMoveCAPIAndVerifyMachinesLeft(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = from.Name
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: 1, MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}}}
+	})
+	machines := []types.Machine{
+		{Metadata: types.MachineMetadata{Name: "cp", Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}},
+		{Metadata: types.MachineMetadata{Name: "md-0", Labels: map[string]string{clusterv1.MachineDeploymentLabelName: "md-0"}}},
+	}
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: from.Name}}}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.expectMoveObjects(ctx, from, to)
+	gomock.InOrder(
+		m.client.EXPECT().GetMachines(ctx, from, from.Name).Return(machines, nil),
+		m.client.EXPECT().GetMachines(ctx, from, from.Name).Return(machines, nil),
+		m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil),
+		m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", from.Name),
+		m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, from.Name),
+		m.client.EXPECT().ValidateWorkerNodes(ctx, to, from.Name),
+		m.client.EXPECT().GetMachines(ctx, to, from.Name).Return(machines[:1], nil),
+		m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil),
+		m.client.EXPECT().GetMachines(ctx, to, from.Name).Return(machines[:1], nil),
+	)
+
+	err := c.MoveCAPIAndVerify(ctx, from, to, from.Name, clusterSpec)
+	if err == nil || err.Error() != "machine md-0 not found in to-cluster after move" {
+		t.Errorf("ClusterManager.MoveCAPIAndVerify() error = %v, want machine md-0 not found", err)
+	}
+}
+
+var capiObjects = []string{"cluster.cluster.x-k8s.io/cluster", "machine.cluster.x-k8s.io/cluster-cp", "machine.cluster.x-k8s.io/cluster-md-0"}
+
+// expectMoveObjects expects a move that moves all the CAPI objects at the first attempt
+func (m *clusterManagerMocks) expectMoveObjects(ctx context.Context, from, to *types.Cluster) {
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+	)
+}
+
+func TestClusterManagerFinishMoveRetriesPartialMove(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t, clustermanager.WithMoveRetrier(retrier.NewWithMaxRetries(2, 0)))
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("connection refused")),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects[:1], nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+	)
+
+	if err := c.FinishMove(ctx, from, to, clustermanager.MoveScope{}); err != nil {
+		t.Errorf("ClusterManager.FinishMove() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerFinishMoveResumesInterruptedMove(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+
+	// The previous move created all the objects in the target and deleted some of them from the source
+	c, m := newClusterManager(t)
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects[1:], nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+	)
+
+	if err := c.FinishMove(ctx, from, to, clustermanager.MoveScope{}); err != nil {
+		t.Errorf("ClusterManager.FinishMove() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerFinishMoveAlreadyMoved(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetClusters(ctx, to).Return([]types.CAPICluster{{Metadata: types.Metadata{Name: "cluster"}}}, nil),
+		m.client.EXPECT().SetCAPIClusterPaused(ctx, to, "cluster", false),
+	)
+	m.client.EXPECT().MoveManagement(ctx, from, to).Times(0)
+
+	if err := c.FinishMove(ctx, from, to, clustermanager.MoveScope{}); err != nil {
+		t.Errorf("ClusterManager.FinishMove() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerFinishMoveFailsAfterMovingAll(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to).Return(errors.New("timed out unpausing clusters")),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().GetClusters(ctx, to).Return([]types.CAPICluster{{Metadata: types.Metadata{Name: "cluster"}}}, nil),
+		m.client.EXPECT().SetCAPIClusterPaused(ctx, to, "cluster", false),
+	)
+
+	if err := c.FinishMove(ctx, from, to, clustermanager.MoveScope{}); err != nil {
+		t.Errorf("ClusterManager.FinishMove() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerFinishMoveObjectsLeft(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t, clustermanager.WithMoveRetrier(retrier.NewWithMaxRetries(1, 0)))
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects[2:], nil),
+	)
+
+	err := c.FinishMove(ctx, from, to, clustermanager.MoveScope{})
+	if err == nil || err.Error() != "1 CAPI objects were left in from-cluster after move: machine.cluster.x-k8s.io=1" {
+		t.Errorf("ClusterManager.FinishMove() error = %v, want objects left", err)
+	}
+}
+
+func TestClusterManagerFinishMoveCountMismatch(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t, clustermanager.WithMoveRetrier(retrier.NewWithMaxRetries(1, 0)))
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(capiObjects[:2], nil),
+	)
+
+	err := c.FinishMove(ctx, from, to, clustermanager.MoveScope{})
+	if err == nil || err.Error() != "CAPI objects in to-cluster after move don't match the objects to move: machine.cluster.x-k8s.io: 1, want 2" {
+		t.Errorf("ClusterManager.FinishMove() error = %v, want count mismatch", err)
+	}
+}
+
+func TestClusterManagerFinishMoveNothingToMove(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil)
+	m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil)
+
+	err := c.FinishMove(ctx, from, to, clustermanager.MoveScope{})
+	if err == nil || err.Error() != "no CAPI objects found to move from from-cluster to to-cluster" {
+		t.Errorf("ClusterManager.FinishMove() error = %v, want nothing to move", err)
+	}
+}
+
+func TestClusterManagerFinishMoveWithNamespaces(t *testing.T) {
+	from := &types.Cluster{Name: "from-cluster"}
+	to := &types.Cluster{Name: "to-cluster"}
+	ctx := context.Background()
+	g := NewWithT(t)
+	objects := []string{"cluster.cluster.x-k8s.io/w-1", "machine.cluster.x-k8s.io/w-1-cp"}
+	scope := clustermanager.MoveScope{Namespaces: []string{"capi"}}
+
+	c, m := newClusterManager(t)
+	gomock.InOrder(
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, "capi", "", gomock.Any()).Return(objects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, "capi", "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().MoveManagement(ctx, from, to, gomock.Any()).Do(
+			func(_ context.Context, _, _ *types.Cluster, opts ...executables.MoveOpt) {
+				g.Expect(opts).To(HaveLen(2))
+			},
+		),
+		m.client.EXPECT().GetObjectNames(ctx, to, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().GetObjectNames(ctx, to, "capi", "", gomock.Any()).Return(objects, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, constants.EksaSystemNamespace, "", gomock.Any()).Return(nil, nil),
+		m.client.EXPECT().GetObjectNames(ctx, from, "capi", "", gomock.Any()).Return(nil, nil),
+	)
+
+	g.Expect(c.FinishMove(ctx, from, to, scope)).To(Succeed())
+}
+
 func TestClusterManagerCreateEKSAResourcesSuccess(t *testing.T) {
 	clusterSpec := &cluster.Spec{
 		Cluster: &v1alpha1.Cluster{
@@ -749,6 +1738,52 @@ func TestClusterManagerCreateEKSAResourcesSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerCreateEKSAResourcesClearsEndpointMigrationAnnotation(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "cluster-name"
+		s.Cluster.AllowControlPlaneEndpointMigration()
+	})
+	ctx := context.Background()
+	cluster := &types.Cluster{
+		Name: "cluster-name",
+	}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
+	m.client.EXPECT().RemoveAnnotationInNamespace(ctx, clusterSpec.ResourceType(), "cluster-name", clusterSpec.Cluster.ControlPlaneEndpointMigrationAnnotation(), cluster, clusterSpec.Namespace)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any())
+
+	if err := c.CreateEKSAResources(ctx, cluster, clusterSpec, &v1alpha1.VSphereDatacenterConfig{}, nil); err != nil {
+		t.Errorf("ClusterManager.CreateEKSAResources() error = %v, wantErr nil", err)
+	}
+	if clusterSpec.Cluster.IsControlPlaneEndpointMigrationAllowed() {
+		t.Error("ClusterManager.CreateEKSAResources() kept the control plane endpoint migration annotation")
+	}
+}
+
+func TestClusterManagerCreateEKSAResourcesClearsForcedUnsupportedChanges(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "cluster-name"
+		s.Cluster.ForceUnsupportedChange("spec.proxyConfiguration")
+	})
+	ctx := context.Background()
+	cluster := &types.Cluster{
+		Name: "cluster-name",
+	}
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any())
+	m.client.EXPECT().RemoveAnnotationInNamespace(ctx, clusterSpec.ResourceType(), "cluster-name", clusterSpec.Cluster.ForceUnsupportedChangesAnnotation(), cluster, clusterSpec.Namespace)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any())
+
+	if err := c.CreateEKSAResources(ctx, cluster, clusterSpec, &v1alpha1.VSphereDatacenterConfig{}, nil); err != nil {
+		t.Errorf("ClusterManager.CreateEKSAResources() error = %v, wantErr nil", err)
+	}
+	if len(clusterSpec.Cluster.ForcedUnsupportedChanges()) != 0 {
+		t.Error("ClusterManager.CreateEKSAResources() kept the force unsupported changes annotation")
+	}
+}
+
 func TestClusterManagerPauseEKSAControllerReconcileSuccessWithoutMachineConfig(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -874,6 +1909,135 @@ func TestClusterManagerResumeEKSAControllerReconcileSuccessWithoutMachineConfig(
 	}
 }
 
+func TestClusterManagerPauseClusterForMaintenanceSuccess(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{
+		Name: "cluster-name",
+	}
+	clusterSpec := &cluster.Spec{
+		Cluster: &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-name",
+			},
+			Spec: v1alpha1.ClusterSpec{
+				DatacenterRef: v1alpha1.Ref{
+					Kind: v1alpha1.VSphereDatacenterKind,
+					Name: "data-center-name",
+				},
+			},
+		},
+	}
+	expectedPauseAnnotation := map[string]string{"anywhere.eks.amazonaws.com/paused": "true"}
+	expectedMaintenanceAnnotation := map[string]string{"anywhere.eks.amazonaws.com/maintenance": "true"}
+
+	cm, m := newClusterManager(t)
+	m.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType)
+	m.provider.EXPECT().MachineResourceType().Return("")
+	m.client.EXPECT().UpdateAnnotationInNamespace(ctx, eksaVSphereDatacenterResourceType, clusterSpec.Spec.DatacenterRef.Name, expectedPauseAnnotation, clusterObj, "").Return(nil)
+	m.client.EXPECT().UpdateAnnotationInNamespace(ctx, eksaClusterResourceType, clusterSpec.Name, expectedPauseAnnotation, clusterObj, "").Return(nil)
+	m.client.EXPECT().UpdateAnnotationInNamespace(ctx, eksaClusterResourceType, clusterSpec.Name, expectedMaintenanceAnnotation, clusterObj, "").Return(nil)
+	m.client.EXPECT().SetCAPIClusterPaused(ctx, clusterObj, clusterSpec.Name, true).Return(nil)
+
+	if err := cm.PauseClusterForMaintenance(ctx, clusterObj, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.PauseClusterForMaintenance() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerResumeClusterFromMaintenanceSuccess(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{
+		Name: "cluster-name",
+	}
+	clusterSpec := &cluster.Spec{
+		Cluster: &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-name",
+			},
+			Spec: v1alpha1.ClusterSpec{
+				DatacenterRef: v1alpha1.Ref{
+					Kind: v1alpha1.VSphereDatacenterKind,
+					Name: "data-center-name",
+				},
+			},
+		},
+	}
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+
+	cm, m := newClusterManager(t)
+	gomock.InOrder(
+		m.client.EXPECT().SetCAPIClusterPaused(ctx, clusterObj, clusterSpec.Name, false).Return(nil),
+		m.client.EXPECT().RemoveAnnotationInNamespace(ctx, eksaClusterResourceType, clusterSpec.Name, "anywhere.eks.amazonaws.com/maintenance", clusterObj, "").Return(nil),
+		m.client.EXPECT().RemoveAnnotationInNamespace(ctx, eksaVSphereDatacenterResourceType, clusterSpec.Spec.DatacenterRef.Name, "anywhere.eks.amazonaws.com/paused", clusterObj, "").Return(nil),
+		m.client.EXPECT().RemoveAnnotationInNamespace(ctx, eksaClusterResourceType, clusterSpec.Name, "anywhere.eks.amazonaws.com/paused", clusterObj, "").Return(nil),
+	)
+	m.provider.EXPECT().DatacenterResourceType().Return(eksaVSphereDatacenterResourceType)
+	m.provider.EXPECT().MachineResourceType().Return("")
+	m.provider.EXPECT().DatacenterConfig().Return(datacenterConfig)
+
+	if err := cm.ResumeClusterFromMaintenance(ctx, clusterObj, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.ResumeClusterFromMaintenance() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerGetPauseStatus(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{
+		Name: "cluster-name",
+	}
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-name",
+			Annotations: map[string]string{
+				"anywhere.eks.amazonaws.com/paused":      "true",
+				"anywhere.eks.amazonaws.com/maintenance": "true",
+			},
+		},
+	}
+	capiClusters := []types.CAPICluster{
+		{Metadata: types.Metadata{Name: "other-cluster"}},
+		{Metadata: types.Metadata{Name: "cluster-name"}, Spec: types.CAPIClusterSpec{Paused: true}},
+	}
+
+	cm, m := newClusterManager(t)
+	m.client.EXPECT().GetEksaCluster(ctx, clusterObj, "cluster-name").Return(eksaCluster, nil)
+	m.client.EXPECT().GetClusters(ctx, clusterObj).Return(capiClusters, nil)
+
+	want := &clustermanager.PauseStatus{
+		InMaintenance:       true,
+		EKSAReconcilePaused: true,
+		CAPIReconcilePaused: true,
+	}
+	got, err := cm.GetPauseStatus(ctx, clusterObj, "cluster-name")
+	if err != nil {
+		t.Fatalf("ClusterManager.GetPauseStatus() error = %v, wantErr nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClusterManager.GetPauseStatus() = %+v, want %+v", got, want)
+	}
+}
+
+func TestClusterManagerIsDeletionProtected(t *testing.T) {
+	ctx := context.Background()
+	clusterObj := &types.Cluster{
+		Name: "cluster-name",
+	}
+	eksaCluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-name"},
+		Spec:       v1alpha1.ClusterSpec{DeletionProtection: true},
+	}
+
+	cm, m := newClusterManager(t)
+	m.client.EXPECT().GetEksaCluster(ctx, clusterObj, "cluster-name").Return(eksaCluster, nil)
+
+	got, err := cm.IsDeletionProtected(ctx, clusterObj, "cluster-name")
+	if err != nil {
+		t.Fatalf("ClusterManager.IsDeletionProtected() error = %v, wantErr nil", err)
+	}
+	if !got {
+		t.Errorf("ClusterManager.IsDeletionProtected() = %t, want true", got)
+	}
+}
+
 func TestClusterManagerInstallCustomComponentsSuccess(t *testing.T) {
 	ctx := context.Background()
 	tt := newTest(t)
@@ -1112,6 +2276,75 @@ func newTest(t *testing.T, opts ...clustermanager.ClusterManagerOpt) *testSetup
 	}
 }
 
+func TestClusterManagerApplyBundlesWithoutReleases(t *testing.T) {
+	ctx := context.Background()
+	c, m := newClusterManager(t)
+	cluster := &types.Cluster{Name: "m01", KubeconfigFile: "m01.kubeconfig"}
+	clusterSpec := test.NewClusterSpec()
+	clusterSpec.Cluster.Name = "m01"
+
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any())
+
+	if err := c.ApplyBundles(ctx, clusterSpec, cluster); err != nil {
+		t.Errorf("ClusterManager.ApplyBundles() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerApplyBundlesStoresReleases(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, m := newClusterManager(t)
+	cluster := &types.Cluster{Name: "m01", KubeconfigFile: "m01.kubeconfig"}
+	clusterSpec := specWithClusterReleases(g)
+
+	gomock.InOrder(
+		m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()),
+		m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, data []byte) error {
+				manifest := string(data)
+				g.Expect(manifest).To(ContainSubstring("kind: Release"))
+				g.Expect(manifest).To(ContainSubstring("name: " + constants.EksaReleasesName))
+				g.Expect(manifest).To(ContainSubstring("namespace: " + constants.EksaSystemNamespace))
+				g.Expect(manifest).To(ContainSubstring("version: v0.7.0"))
+				return nil
+			},
+		),
+	)
+
+	g.Expect(c.ApplyBundles(ctx, clusterSpec, cluster)).To(Succeed())
+}
+
+func TestClusterManagerApplyBundlesStoreReleasesError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, m := newClusterManager(t)
+	cluster := &types.Cluster{Name: "m01", KubeconfigFile: "m01.kubeconfig"}
+	clusterSpec := specWithClusterReleases(g)
+
+	gomock.InOrder(
+		m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()),
+		m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).Return(errors.New("invalid release")),
+	)
+
+	g.Expect(c.ApplyBundles(ctx, clusterSpec, cluster)).To(Succeed())
+}
+
+func specWithClusterReleases(g *WithT) *cluster.Spec {
+	clusterSpec := test.NewClusterSpec()
+	clusterSpec.Cluster.Name = "m01"
+	releases := &anywherev1alpha1.Release{
+		Spec: anywherev1alpha1.ReleaseSpec{
+			LatestVersion: "v0.7.0",
+			Releases:      []anywherev1alpha1.EksARelease{{Version: "v0.7.0", Number: 3}},
+		},
+	}
+	cluster.WithClusterManifests(releases, nil)(clusterSpec)
+	_, err := clusterSpec.GetRelease(version.Info{GitVersion: "v0.7.0"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return clusterSpec
+}
+
 type clusterManagerMocks struct {
 	writer             *mockswriter.MockFileWriter
 	networking         *mocksmanager.MockNetworking
@@ -1157,3 +2390,24 @@ func TestClusterManagerGetCurrentClusterSpecGetBundlesError(t *testing.T) {
 	_, err := tt.clusterManager.GetCurrentClusterSpec(tt.ctx, tt.cluster, tt.clusterName)
 	tt.Expect(err).ToNot(BeNil())
 }
+
+func TestClusterManagerDeleteClusterDeletesResourceTags(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"}
+
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster),
+		tt.mocks.provider.EXPECT().DeleteResourceTags(tt.ctx, tt.clusterSpec),
+	)
+
+	tt.Expect(tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerDeleteClusterErrorKeepsResourceTags(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	managementCluster := &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"}
+
+	tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster).Return(errors.New("timed out"))
+
+	tt.Expect(tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec)).To(MatchError(ContainSubstring("timed out")))
+}
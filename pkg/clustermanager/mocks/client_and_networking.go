@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,AwsIamAuth)
+// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,AwsIamAuth,VIPMonitor,ControllerMonitor)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -10,11 +10,15 @@ import (
 
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	controllermonitor "github.com/aws/eks-anywhere/pkg/controllermonitor"
+	executables "github.com/aws/eks-anywhere/pkg/executables"
 	filewriter "github.com/aws/eks-anywhere/pkg/filewriter"
 	providers "github.com/aws/eks-anywhere/pkg/providers"
 	types "github.com/aws/eks-anywhere/pkg/types"
+	vipmonitor "github.com/aws/eks-anywhere/pkg/vipmonitor"
 	v1alpha10 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 )
 
 // MockClusterClient is a mock of ClusterClient interface.
@@ -152,6 +156,34 @@ func (mr *MockClusterClientMockRecorder) DeleteGitOpsConfig(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitOpsConfig", reflect.TypeOf((*MockClusterClient)(nil).DeleteGitOpsConfig), arg0, arg1, arg2, arg3)
 }
 
+// DeleteKubeSpecFromBytes mocks base method.
+func (m *MockClusterClient) DeleteKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKubeSpecFromBytes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKubeSpecFromBytes indicates an expected call of DeleteKubeSpecFromBytes.
+func (mr *MockClusterClientMockRecorder) DeleteKubeSpecFromBytes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKubeSpecFromBytes", reflect.TypeOf((*MockClusterClient)(nil).DeleteKubeSpecFromBytes), arg0, arg1, arg2)
+}
+
+// DeleteMachine mocks base method.
+func (m *MockClusterClient) DeleteMachine(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMachine", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMachine indicates an expected call of DeleteMachine.
+func (mr *MockClusterClientMockRecorder) DeleteMachine(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMachine", reflect.TypeOf((*MockClusterClient)(nil).DeleteMachine), arg0, arg1, arg2, arg3)
+}
+
 // DeleteOIDCConfig mocks base method.
 func (m *MockClusterClient) DeleteOIDCConfig(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -166,6 +198,20 @@ func (mr *MockClusterClientMockRecorder) DeleteOIDCConfig(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOIDCConfig", reflect.TypeOf((*MockClusterClient)(nil).DeleteOIDCConfig), arg0, arg1, arg2, arg3)
 }
 
+// DrainNodeInCluster mocks base method.
+func (m *MockClusterClient) DrainNodeInCluster(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainNodeInCluster", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DrainNodeInCluster indicates an expected call of DrainNodeInCluster.
+func (mr *MockClusterClientMockRecorder) DrainNodeInCluster(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainNodeInCluster", reflect.TypeOf((*MockClusterClient)(nil).DrainNodeInCluster), arg0, arg1, arg2, arg3)
+}
+
 // GetApiServerUrl mocks base method.
 func (m *MockClusterClient) GetApiServerUrl(arg0 context.Context, arg1 *types.Cluster) (string, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +272,21 @@ func (mr *MockClusterClientMockRecorder) GetClusters(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockClusterClient)(nil).GetClusters), arg0, arg1)
 }
 
+// GetConfigMap mocks base method.
+func (m *MockClusterClient) GetConfigMap(arg0 context.Context, arg1, arg2, arg3 string) (*v1.ConfigMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMap", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ConfigMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMap indicates an expected call of GetConfigMap.
+func (mr *MockClusterClientMockRecorder) GetConfigMap(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockClusterClient)(nil).GetConfigMap), arg0, arg1, arg2, arg3)
+}
+
 // GetEksaCluster mocks base method.
 func (m *MockClusterClient) GetEksaCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -315,6 +376,26 @@ func (mr *MockClusterClientMockRecorder) GetNamespace(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockClusterClient)(nil).GetNamespace), arg0, arg1, arg2)
 }
 
+// GetObjectNames mocks base method.
+func (m *MockClusterClient) GetObjectNames(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string, arg4 ...string) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetObjectNames", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectNames indicates an expected call of GetObjectNames.
+func (mr *MockClusterClientMockRecorder) GetObjectNames(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectNames", reflect.TypeOf((*MockClusterClient)(nil).GetObjectNames), varargs...)
+}
+
 // GetWorkloadKubeconfig mocks base method.
 func (m *MockClusterClient) GetWorkloadKubeconfig(arg0 context.Context, arg1 string, arg2 *types.Cluster) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -360,17 +441,22 @@ func (mr *MockClusterClientMockRecorder) KubeconfigSecretAvailable(arg0, arg1, a
 }
 
 // MoveManagement mocks base method.
-func (m *MockClusterClient) MoveManagement(arg0 context.Context, arg1, arg2 *types.Cluster) error {
+func (m *MockClusterClient) MoveManagement(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 ...executables.MoveOpt) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MoveManagement", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveManagement", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MoveManagement indicates an expected call of MoveManagement.
-func (mr *MockClusterClientMockRecorder) MoveManagement(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockClusterClientMockRecorder) MoveManagement(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveManagement", reflect.TypeOf((*MockClusterClient)(nil).MoveManagement), arg0, arg1, arg2)
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveManagement", reflect.TypeOf((*MockClusterClient)(nil).MoveManagement), varargs...)
 }
 
 // RemoveAnnotationInNamespace mocks base method.
@@ -387,6 +473,25 @@ func (mr *MockClusterClientMockRecorder) RemoveAnnotationInNamespace(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAnnotationInNamespace", reflect.TypeOf((*MockClusterClient)(nil).RemoveAnnotationInNamespace), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// RolloutStatus mocks base method.
+func (m *MockClusterClient) RolloutStatus(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RolloutStatus", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutStatus indicates an expected call of RolloutStatus.
+func (mr *MockClusterClientMockRecorder) RolloutStatus(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutStatus", reflect.TypeOf((*MockClusterClient)(nil).RolloutStatus), varargs...)
+}
+
 // SaveLog mocks base method.
 func (m *MockClusterClient) SaveLog(arg0 context.Context, arg1 *types.Cluster, arg2 *types.Deployment, arg3 string, arg4 filewriter.FileWriter) error {
 	m.ctrl.T.Helper()
@@ -401,6 +506,53 @@ func (mr *MockClusterClientMockRecorder) SaveLog(arg0, arg1, arg2, arg3, arg4 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLog", reflect.TypeOf((*MockClusterClient)(nil).SaveLog), arg0, arg1, arg2, arg3, arg4)
 }
 
+// Scale mocks base method.
+func (m *MockClusterClient) Scale(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scale", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scale indicates an expected call of Scale.
+func (mr *MockClusterClientMockRecorder) Scale(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scale", reflect.TypeOf((*MockClusterClient)(nil).Scale), varargs...)
+}
+
+// SetCAPIClusterPaused mocks base method.
+func (m *MockClusterClient) SetCAPIClusterPaused(arg0 context.Context, arg1 *types.Cluster, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCAPIClusterPaused", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCAPIClusterPaused indicates an expected call of SetCAPIClusterPaused.
+func (mr *MockClusterClientMockRecorder) SetCAPIClusterPaused(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCAPIClusterPaused", reflect.TypeOf((*MockClusterClient)(nil).SetCAPIClusterPaused), arg0, arg1, arg2, arg3)
+}
+
+// SetDeploymentImage mocks base method.
+func (m *MockClusterClient) SetDeploymentImage(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeploymentImage", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeploymentImage indicates an expected call of SetDeploymentImage.
+func (mr *MockClusterClientMockRecorder) SetDeploymentImage(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeploymentImage", reflect.TypeOf((*MockClusterClient)(nil).SetDeploymentImage), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // UpdateAnnotationInNamespace mocks base method.
 func (m *MockClusterClient) UpdateAnnotationInNamespace(arg0 context.Context, arg1, arg2 string, arg3 map[string]string, arg4 *types.Cluster, arg5 string) error {
 	m.ctrl.T.Helper()
@@ -457,6 +609,39 @@ func (mr *MockClusterClientMockRecorder) ValidateWorkerNodes(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateWorkerNodes", reflect.TypeOf((*MockClusterClient)(nil).ValidateWorkerNodes), arg0, arg1, arg2)
 }
 
+// Wait mocks base method.
+func (m *MockClusterClient) Wait(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockClusterClientMockRecorder) Wait(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockClusterClient)(nil).Wait), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// WaitForCRDsEstablished mocks base method.
+func (m *MockClusterClient) WaitForCRDsEstablished(arg0 context.Context, arg1 *types.Cluster, arg2 string, arg3 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForCRDsEstablished", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForCRDsEstablished indicates an expected call of WaitForCRDsEstablished.
+func (mr *MockClusterClientMockRecorder) WaitForCRDsEstablished(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCRDsEstablished", reflect.TypeOf((*MockClusterClient)(nil).WaitForCRDsEstablished), varargs...)
+}
+
 // WaitForControlPlaneReady mocks base method.
 func (m *MockClusterClient) WaitForControlPlaneReady(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -619,3 +804,77 @@ func (mr *MockAwsIamAuthMockRecorder) GenerateManifest(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateManifest", reflect.TypeOf((*MockAwsIamAuth)(nil).GenerateManifest), arg0)
 }
+
+// MockVIPMonitor is a mock of VIPMonitor interface.
+type MockVIPMonitor struct {
+	ctrl     *gomock.Controller
+	recorder *MockVIPMonitorMockRecorder
+}
+
+// MockVIPMonitorMockRecorder is the mock recorder for MockVIPMonitor.
+type MockVIPMonitorMockRecorder struct {
+	mock *MockVIPMonitor
+}
+
+// NewMockVIPMonitor creates a new mock instance.
+func NewMockVIPMonitor(ctrl *gomock.Controller) *MockVIPMonitor {
+	mock := &MockVIPMonitor{ctrl: ctrl}
+	mock.recorder = &MockVIPMonitorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVIPMonitor) EXPECT() *MockVIPMonitorMockRecorder {
+	return m.recorder
+}
+
+// Watch mocks base method.
+func (m *MockVIPMonitor) Watch(arg0 context.Context, arg1 vipmonitor.Target) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockVIPMonitorMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockVIPMonitor)(nil).Watch), arg0, arg1)
+}
+
+// MockControllerMonitor is a mock of ControllerMonitor interface.
+type MockControllerMonitor struct {
+	ctrl     *gomock.Controller
+	recorder *MockControllerMonitorMockRecorder
+}
+
+// MockControllerMonitorMockRecorder is the mock recorder for MockControllerMonitor.
+type MockControllerMonitorMockRecorder struct {
+	mock *MockControllerMonitor
+}
+
+// NewMockControllerMonitor creates a new mock instance.
+func NewMockControllerMonitor(ctrl *gomock.Controller) *MockControllerMonitor {
+	mock := &MockControllerMonitor{ctrl: ctrl}
+	mock.recorder = &MockControllerMonitorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockControllerMonitor) EXPECT() *MockControllerMonitorMockRecorder {
+	return m.recorder
+}
+
+// Watch mocks base method.
+func (m *MockControllerMonitor) Watch(arg0 context.Context, arg1 controllermonitor.Target) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockControllerMonitorMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockControllerMonitor)(nil).Watch), arg0, arg1)
+}
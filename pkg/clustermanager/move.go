@@ -0,0 +1,223 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var clustersResource = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+
+// moveInventoryResources are the CAPI resources counted in both clusters to verify a move didn't leave objects behind
+var moveInventoryResources = []string{
+	clustersResource,
+	fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group),
+	fmt.Sprintf("machinesets.%s", clusterv1.GroupVersion.Group),
+	fmt.Sprintf("machines.%s", clusterv1.GroupVersion.Group),
+	fmt.Sprintf("machinehealthchecks.%s", clusterv1.GroupVersion.Group),
+	fmt.Sprintf("kubeadmcontrolplanes.controlplane.%s", clusterv1.GroupVersion.Group),
+	fmt.Sprintf("kubeadmconfigs.bootstrap.%s", clusterv1.GroupVersion.Group),
+}
+
+// objectInventory counts the CAPI objects of each kind in a cluster
+type objectInventory map[string]int
+
+func newObjectInventory(names []string) objectInventory {
+	inventory := objectInventory{}
+	for _, name := range names {
+		kind := strings.SplitN(name, "/", 2)[0]
+		inventory[kind]++
+	}
+	return inventory
+}
+
+func (i objectInventory) total() int {
+	total := 0
+	for _, count := range i {
+		total += count
+	}
+	return total
+}
+
+// merge returns the highest count of each kind. A move creates all the objects in the target before deleting
+// them from the source, so for an interrupted move the highest count is the number of objects to move
+func (i objectInventory) merge(other objectInventory) objectInventory {
+	merged := objectInventory{}
+	for kind, count := range i {
+		merged[kind] = count
+	}
+	for kind, count := range other {
+		if count > merged[kind] {
+			merged[kind] = count
+		}
+	}
+	return merged
+}
+
+// mismatches lists the kinds with a different count in other
+func (i objectInventory) mismatches(other objectInventory) []string {
+	var mismatches []string
+	for _, kind := range i.merge(other).kinds() {
+		if i[kind] != other[kind] {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %d, want %d", kind, other[kind], i[kind]))
+		}
+	}
+	return mismatches
+}
+
+func (i objectInventory) kinds() []string {
+	kinds := make([]string, 0, len(i))
+	for kind := range i {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func (i objectInventory) String() string {
+	counts := make([]string, 0, len(i))
+	for _, kind := range i.kinds() {
+		counts = append(counts, fmt.Sprintf("%s=%d", kind, i[kind]))
+	}
+	return strings.Join(counts, ", ")
+}
+
+// MoveScope selects the CAPI objects to move. The zero value moves all the objects in eksa-system.
+// clusterctl move can only be scoped by namespace, so all the clusters in the namespaces are moved
+type MoveScope struct {
+	// Namespaces are moved in addition to eksa-system, for CAPI objects created outside of it
+	Namespaces []string
+}
+
+func (s MoveScope) namespaces() []string {
+	return append([]string{constants.EksaSystemNamespace}, s.Namespaces...)
+}
+
+func (c *ClusterManager) moveInventory(ctx context.Context, cluster *types.Cluster, scope MoveScope) (objectInventory, error) {
+	var objects []string
+	for _, namespace := range scope.namespaces() {
+		names, err := c.clusterClient.GetObjectNames(ctx, cluster, namespace, "", moveInventoryResources...)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, names...)
+	}
+	return newObjectInventory(objects), nil
+}
+
+// moveOpts returns the clusterctl options to move the namespaces in scope
+func moveOpts(scope MoveScope) []executables.MoveOpt {
+	if len(scope.Namespaces) == 0 {
+		return nil
+	}
+	opts := make([]executables.MoveOpt, 0, len(scope.Namespaces)+1)
+	for _, namespace := range scope.namespaces() {
+		opts = append(opts, executables.WithMoveNamespace(namespace))
+	}
+	return opts
+}
+
+// moveManagement moves the CAPI objects from one cluster to the other and verifies the object counts of the target
+// match the ones to move. When the move fails midway, it's retried, and the retries only move the objects left in
+// the source. A move interrupted by a previous run is finished the same way
+func (c *ClusterManager) moveManagement(ctx context.Context, from, to *types.Cluster, scope MoveScope) error {
+	source, err := c.moveInventory(ctx, from, scope)
+	if err != nil {
+		return fmt.Errorf("error getting CAPI objects to move: %v", err)
+	}
+	target, err := c.moveInventory(ctx, to, scope)
+	if err != nil {
+		return fmt.Errorf("error getting CAPI objects in move target: %v", err)
+	}
+	expected := source.merge(target)
+
+	if source.total() == 0 {
+		if target.total() == 0 {
+			return fmt.Errorf("no CAPI objects found to move from %s to %s", from.Name, to.Name)
+		}
+		logger.Info("All CAPI objects are already in the target cluster, finishing move", "target", to.Name)
+		return c.unpauseMovedClusters(ctx, to)
+	}
+	if target.total() > 0 {
+		logger.Info("Found CAPI objects from a previous move in the target cluster, resuming move", "target", to.Name, "objects", target.String())
+	}
+
+	logger.V(3).Info("Moving CAPI objects", "objects", expected.String())
+	attempt := 0
+	return c.moveRetrier.RetryWithContext(ctx, func() error {
+		attempt++
+		return c.moveAndVerify(ctx, from, to, scope, expected, attempt)
+	})
+}
+
+func (c *ClusterManager) moveAndVerify(ctx context.Context, from, to *types.Cluster, scope MoveScope, expected objectInventory, attempt int) error {
+	moveErr := c.clusterClient.MoveManagement(ctx, from, to, moveOpts(scope)...)
+	if moveErr != nil {
+		logger.Info("CAPI move failed, checking the objects moved", "attempt", attempt, "error", moveErr)
+	}
+
+	verifyErr := c.verifyMove(ctx, from, to, scope, expected)
+	switch {
+	case verifyErr == nil && moveErr == nil:
+		return nil
+	case verifyErr == nil:
+		// clusterctl unpauses the clusters in the target after moving all the objects, so it might not have done it
+		logger.Info("All CAPI objects were moved despite the move failure", "target", to.Name)
+		return c.unpauseMovedClusters(ctx, to)
+	case moveErr != nil:
+		return fmt.Errorf("error moving CAPI management from source to target: %v", moveErr)
+	default:
+		logger.Info("CAPI move didn't move all the objects", "attempt", attempt, "error", verifyErr)
+		return verifyErr
+	}
+}
+
+// verifyMove checks the target has as many objects of each kind as expected, and the source none
+func (c *ClusterManager) verifyMove(ctx context.Context, from, to *types.Cluster, scope MoveScope, expected objectInventory) error {
+	moved, err := c.moveInventory(ctx, to, scope)
+	if err != nil {
+		return fmt.Errorf("error getting moved CAPI objects: %v", err)
+	}
+	if mismatches := expected.mismatches(moved); len(mismatches) > 0 {
+		return fmt.Errorf("CAPI objects in %s after move don't match the objects to move: %s", to.Name, strings.Join(mismatches, "; "))
+	}
+
+	left, err := c.moveInventory(ctx, from, scope)
+	if err != nil {
+		return fmt.Errorf("error getting CAPI objects left after move: %v", err)
+	}
+	if left.total() > 0 {
+		return fmt.Errorf("%d CAPI objects were left in %s after move: %s", left.total(), from.Name, left.String())
+	}
+
+	logger.V(3).Info("Verified all CAPI objects were moved", "objects", moved.String())
+	return nil
+}
+
+func (c *ClusterManager) unpauseMovedClusters(ctx context.Context, to *types.Cluster) error {
+	clusters, err := c.clusterClient.GetClusters(ctx, to)
+	if err != nil {
+		return fmt.Errorf("error getting moved clusters: %v", err)
+	}
+	for _, cluster := range clusters {
+		if err = c.clusterClient.SetCAPIClusterPaused(ctx, to, cluster.Metadata.Name, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FinishMove finishes a CAPI move interrupted before all the objects were moved from one cluster to the other,
+// and verifies no objects were lost. It can run again after a failed attempt. The scope adds the namespaces with CAPI
+// objects outside of eksa-system
+func (c *ClusterManager) FinishMove(ctx context.Context, from, to *types.Cluster, scope MoveScope) error {
+	return c.moveManagement(ctx, from, to, scope)
+}
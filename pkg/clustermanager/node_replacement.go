@@ -0,0 +1,169 @@
+package clustermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const nodeDrainTimeout = "5m"
+
+// ReplaceNode replaces the node of a workload cluster by deleting its backing Machine, so CAPI creates a
+// new one. The node is drained first, but an unhealthy node might not be drainable, so a failed drain
+// doesn't stop the replacement. It fails before making any change if the replacement would leave the
+// control plane without etcd quorum or a worker node group without any ready node.
+func (c *ClusterManager) ReplaceNode(ctx context.Context, managementCluster, workloadCluster *types.Cluster, nodeName string) error {
+	machines, err := c.clusterClient.GetMachines(ctx, managementCluster, workloadCluster.Name)
+	if err != nil {
+		return fmt.Errorf("error getting machines resources from management cluster: %v", err)
+	}
+
+	machine, err := machineForNode(machines, nodeName)
+	if err != nil {
+		return err
+	}
+
+	group := machineGroup(machines, machine)
+	if err = validateNodeReplacement(group, machine); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Draining node", "node", nodeName)
+	if err = c.clusterClient.DrainNodeInCluster(ctx, workloadCluster, nodeName, nodeDrainTimeout); err != nil {
+		logger.Info("Warning: failed draining node, continuing with the replacement", "node", nodeName, "error", err)
+	}
+
+	logger.V(3).Info("Deleting machine", "machine", machine.Metadata.Name, "node", nodeName)
+	if err = c.clusterClient.DeleteMachine(ctx, managementCluster, machine.Metadata.Name, constants.EksaSystemNamespace); err != nil {
+		return fmt.Errorf("error deleting machine %s for node %s: %v", machine.Metadata.Name, nodeName, err)
+	}
+
+	logger.V(3).Info("Waiting for replacement node to be ready", "node", nodeName)
+	if err = c.waitForNodeReplacement(ctx, managementCluster, workloadCluster.Name, machine, group); err != nil {
+		return fmt.Errorf("error waiting for replacement of node %s: %v", nodeName, err)
+	}
+
+	return nil
+}
+
+// waitForNodeReplacement waits for the replaced machine to be deleted and for a new machine in its group to be ready.
+// The other machines of the group aren't waited for, since some might have been unhealthy before the replacement
+func (c *ClusterManager) waitForNodeReplacement(ctx context.Context, managementCluster *types.Cluster, clusterName string, replaced *types.Machine, group []types.Machine) error {
+	existing := make(map[string]bool, len(group))
+	for _, m := range group {
+		existing[m.Metadata.Name] = true
+	}
+
+	isReplaced := func() error {
+		machines, err := c.clusterClient.GetMachines(ctx, managementCluster, clusterName)
+		if err != nil {
+			return fmt.Errorf("error getting machines resources from management cluster: %v", err)
+		}
+
+		var replacement *types.Machine
+		for _, m := range machineGroup(machines, replaced) {
+			if m.Metadata.Name == replaced.Metadata.Name {
+				return fmt.Errorf("machine %s is not deleted yet", replaced.Metadata.Name)
+			}
+			if existing[m.Metadata.Name] {
+				continue
+			}
+			m := m
+			replacement = &m
+			if machineReady(m) {
+				logger.V(3).Info("Replacement node is ready", "machine", m.Metadata.Name, "node", m.Status.NodeRef.Name)
+				return nil
+			}
+		}
+
+		if replacement == nil {
+			logger.V(4).Info("Replacement machine is not created yet")
+			return errors.New("replacement machine is not created yet")
+		}
+		logger.V(4).Info("Replacement node is not ready yet", "machine", replacement.Metadata.Name)
+		return fmt.Errorf("replacement machine %s is not ready yet", replacement.Metadata.Name)
+	}
+
+	timeout := c.machineMaxWait
+	if timeout <= c.machinesMinWait {
+		timeout = c.machinesMinWait
+	}
+
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, c.machineBackoff
+	}))
+	if err := r.RetryWithContext(ctx, isReplaced); err != nil {
+		return fmt.Errorf("retries exhausted: %v", err)
+	}
+
+	return nil
+}
+
+func machineForNode(machines []types.Machine, nodeName string) (*types.Machine, error) {
+	for i := range machines {
+		m := &machines[i]
+		if m.Status.NodeRef != nil && m.Status.NodeRef.Name == nodeName {
+			return m, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no machine found for node %s", nodeName)
+}
+
+// machineGroup returns the machines that are replicas of the same control plane or machine deployment as machine
+func machineGroup(machines []types.Machine, machine *types.Machine) []types.Machine {
+	var group []types.Machine
+	for _, m := range machines {
+		if _, ok := machine.Metadata.Labels[clusterv1.MachineControlPlaneLabelName]; ok {
+			if _, ok := m.Metadata.Labels[clusterv1.MachineControlPlaneLabelName]; ok {
+				group = append(group, m)
+			}
+			continue
+		}
+
+		if d, ok := machine.Metadata.Labels[clusterv1.MachineDeploymentLabelName]; ok && m.Metadata.Labels[clusterv1.MachineDeploymentLabelName] == d {
+			group = append(group, m)
+		}
+	}
+
+	return group
+}
+
+func validateNodeReplacement(group []types.Machine, machine *types.Machine) error {
+	readyOthers := 0
+	for _, m := range group {
+		if m.Metadata.Name != machine.Metadata.Name && machineReady(m) {
+			readyOthers++
+		}
+	}
+
+	if _, ok := machine.Metadata.Labels[clusterv1.MachineControlPlaneLabelName]; ok {
+		quorum := len(group)/2 + 1
+		if readyOthers < quorum {
+			return fmt.Errorf("can't replace control plane node %s: %d ready control plane nodes would remain, at least %d are needed to keep etcd quorum", machine.Status.NodeRef.Name, readyOthers, quorum)
+		}
+		return nil
+	}
+
+	if _, ok := machine.Metadata.Labels[clusterv1.MachineDeploymentLabelName]; !ok {
+		return fmt.Errorf("can't replace node %s: its machine %s doesn't belong to the control plane or a machine deployment", machine.Status.NodeRef.Name, machine.Metadata.Name)
+	}
+
+	if readyOthers == 0 {
+		return fmt.Errorf("can't replace worker node %s: it would leave its node group without ready nodes", machine.Status.NodeRef.Name)
+	}
+
+	return nil
+}
+
+func machineReady(m types.Machine) bool {
+	return types.WithNodeRef()(m.Status) && types.WithNodeHealthy()(m.Status)
+}
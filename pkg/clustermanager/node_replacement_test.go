@@ -0,0 +1,143 @@
+package clustermanager_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type nodeReplacementTest struct {
+	*testSetup
+	managementCluster *types.Cluster
+}
+
+func newNodeReplacementTest(t *testing.T) *nodeReplacementTest {
+	return &nodeReplacementTest{
+		testSetup:         newTest(t, clustermanager.WithWaitForMachines(1*time.Nanosecond, 50*time.Microsecond, 100*time.Microsecond)),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+	}
+}
+
+func machine(name, node string, healthy bool, labels map[string]string) types.Machine {
+	status := "False"
+	if healthy {
+		status = "True"
+	}
+	return types.Machine{
+		Metadata: types.MachineMetadata{Name: name, Labels: labels},
+		Status: types.MachineStatus{
+			NodeRef:    &types.ResourceRef{Kind: "Node", Name: node},
+			Conditions: types.Conditions{{Type: "NodeHealthy", Status: types.ConditionStatus(status)}},
+		},
+	}
+}
+
+func controlPlaneMachine(name string, healthy bool) types.Machine {
+	return machine(name, name, healthy, map[string]string{clusterv1.MachineControlPlaneLabelName: ""})
+}
+
+func workerMachine(name string, healthy bool) types.Machine {
+	return machine(name, name, healthy, map[string]string{clusterv1.MachineDeploymentLabelName: "cluster-name-md-0"})
+}
+
+func TestClusterManagerReplaceNodeWorker(t *testing.T) {
+	tt := newNodeReplacementTest(t)
+	before := []types.Machine{controlPlaneMachine("cp-1", true), workerMachine("md-0-a", false), workerMachine("md-0-b", true)}
+	replacing := []types.Machine{controlPlaneMachine("cp-1", true), workerMachine("md-0-b", true), workerMachine("md-0-c", false)}
+	after := []types.Machine{controlPlaneMachine("cp-1", true), workerMachine("md-0-b", true), workerMachine("md-0-c", true)}
+
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(before, nil)
+	tt.mocks.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "md-0-a", "5m")
+	tt.mocks.client.EXPECT().DeleteMachine(tt.ctx, tt.managementCluster, "md-0-a", constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(before, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(replacing, nil)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(after, nil)
+
+	tt.Expect(tt.clusterManager.ReplaceNode(tt.ctx, tt.managementCluster, tt.cluster, "md-0-a")).To(Succeed())
+}
+
+func TestClusterManagerReplaceNodeWaitsOnlyForReplacement(t *testing.T) {
+	tt := newNodeReplacementTest(t)
+	before := []types.Machine{workerMachine("md-0-a", false), workerMachine("md-0-b", true), workerMachine("md-0-x", false)}
+	recovered := []types.Machine{workerMachine("md-0-b", true), workerMachine("md-0-x", true)}
+	replacing := []types.Machine{workerMachine("md-0-b", true), workerMachine("md-0-x", false), workerMachine("md-0-c", false)}
+	after := []types.Machine{workerMachine("md-0-b", true), workerMachine("md-0-x", false), workerMachine("md-0-c", true)}
+
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(before, nil)
+	tt.mocks.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "md-0-a", "5m")
+	tt.mocks.client.EXPECT().DeleteMachine(tt.ctx, tt.managementCluster, "md-0-a", constants.EksaSystemNamespace)
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(recovered, nil),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(replacing, nil),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(after, nil),
+	)
+
+	tt.Expect(tt.clusterManager.ReplaceNode(tt.ctx, tt.managementCluster, tt.cluster, "md-0-a")).To(Succeed())
+}
+
+func TestClusterManagerReplaceNodeDrainErrorContinues(t *testing.T) {
+	tt := newNodeReplacementTest(t)
+	machines := []types.Machine{controlPlaneMachine("cp-1", true), controlPlaneMachine("cp-2", true), controlPlaneMachine("cp-3", false)}
+	replaced := []types.Machine{controlPlaneMachine("cp-1", true), controlPlaneMachine("cp-2", true), controlPlaneMachine("cp-4", true)}
+
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(machines, nil)
+	tt.mocks.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "cp-3", "5m").Return(errors.New("timed out"))
+	tt.mocks.client.EXPECT().DeleteMachine(tt.ctx, tt.managementCluster, "cp-3", constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(replaced, nil)
+
+	tt.Expect(tt.clusterManager.ReplaceNode(tt.ctx, tt.managementCluster, tt.cluster, "cp-3")).To(Succeed())
+}
+
+func TestClusterManagerReplaceNodeControlPlaneQuorum(t *testing.T) {
+	tt := newNodeReplacementTest(t)
+	machines := []types.Machine{controlPlaneMachine("cp-1", true), controlPlaneMachine("cp-2", false), controlPlaneMachine("cp-3", true)}
+
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(machines, nil)
+
+	tt.Expect(tt.clusterManager.ReplaceNode(tt.ctx, tt.managementCluster, tt.cluster, "cp-1")).To(
+		MatchError(ContainSubstring("at least 2 are needed to keep etcd quorum")),
+	)
+}
+
+func TestClusterManagerReplaceNodeLastWorker(t *testing.T) {
+	tt := newNodeReplacementTest(t)
+	machines := []types.Machine{controlPlaneMachine("cp-1", true), workerMachine("md-0-a", true)}
+
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(machines, nil)
+
+	tt.Expect(tt.clusterManager.ReplaceNode(tt.ctx, tt.managementCluster, tt.cluster, "md-0-a")).To(
+		MatchError(ContainSubstring("it would leave its node group without ready nodes")),
+	)
+}
+
+func TestClusterManagerReplaceNodeNotFound(t *testing.T) {
+	tt := newNodeReplacementTest(t)
+
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return([]types.Machine{workerMachine("md-0-a", true)}, nil)
+
+	tt.Expect(tt.clusterManager.ReplaceNode(tt.ctx, tt.managementCluster, tt.cluster, "md-0-z")).To(
+		MatchError(ContainSubstring("no machine found for node md-0-z")),
+	)
+}
+
+func TestClusterManagerReplaceNodeTimeout(t *testing.T) {
+	tt := newNodeReplacementTest(t)
+	machines := []types.Machine{workerMachine("md-0-a", false), workerMachine("md-0-b", true)}
+
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).Return(machines, nil)
+	tt.mocks.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "md-0-a", "5m")
+	tt.mocks.client.EXPECT().DeleteMachine(tt.ctx, tt.managementCluster, "md-0-a", constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.cluster.Name).MinTimes(1).Return(machines, nil)
+
+	tt.Expect(tt.clusterManager.ReplaceNode(tt.ctx, tt.managementCluster, tt.cluster, "md-0-a")).To(
+		MatchError(ContainSubstring("machine md-0-a is not deleted yet")),
+	)
+}
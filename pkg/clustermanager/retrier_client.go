@@ -7,6 +7,8 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -24,12 +26,16 @@ func NewRetrierClient(client *client, retrier *retrier.Retrier) *retrierClient {
 }
 
 func (c *retrierClient) installCustomComponents(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
-	componentsManifest, err := clusterSpec.LoadManifest(clusterSpec.VersionsBundle.Eksa.Components)
+	overrides := clusterSpec.EksaOverrides()
+	if overrides.ComponentsManifest != "" {
+		logger.Info("Warning: installing the EKS-A components from the override manifest, only meant for development", "manifest", overrides.ComponentsManifest)
+	}
+	componentsManifest, err := clusterSpec.LoadManifest(clusterSpec.EksaComponents())
 	if err != nil {
 		return fmt.Errorf("failed loading manifest for eksa components: %v", err)
 	}
 
-	err = c.Retry(
+	err = c.RetryWithContext(ctx,
 		func() error {
 			return c.ApplyKubeSpecFromBytes(ctx, cluster, componentsManifest.Content)
 		},
@@ -38,6 +44,18 @@ func (c *retrierClient) installCustomComponents(ctx context.Context, clusterSpec
 		return fmt.Errorf("error applying eks-a components spec: %v", err)
 	}
 
+	if overrides.ControllerImage != "" {
+		logger.Info("Warning: running the EKS-A controller from the override image, only meant for development", "image", overrides.ControllerImage)
+		err = c.Retrier.RetryWithContext(ctx,
+			func() error {
+				return c.SetDeploymentImage(ctx, cluster.KubeconfigFile, "eksa-controller-manager", constants.EksaSystemNamespace, "manager", overrides.ControllerImage)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error setting the eks-a controller override image: %v", err)
+		}
+	}
+
 	// inject proxy env vars the eksa-controller-manager deployment if proxy is configured
 	if clusterSpec.Spec.ProxyConfiguration != nil {
 		noProxyList := append(clusterSpec.Spec.ProxyConfiguration.NoProxy, clusterSpec.Spec.ClusterNetwork.Pods.CidrBlocks...)
@@ -47,7 +65,7 @@ func (c *retrierClient) installCustomComponents(ctx context.Context, clusterSpec
 			"HTTPS_PROXY": clusterSpec.Spec.ProxyConfiguration.HttpsProxy,
 			"NO_PROXY":    strings.Join(noProxyList[:], ","),
 		}
-		err = c.Retrier.Retry(
+		err = c.Retrier.RetryWithContext(ctx,
 			func() error {
 				return c.UpdateEnvironmentVariablesInNamespace(ctx, "deployment", "eksa-controller-manager", envMap, cluster, "eksa-system")
 			},
@@ -56,5 +74,30 @@ func (c *retrierClient) installCustomComponents(ctx context.Context, clusterSpec
 			return fmt.Errorf("error applying eks-a components spec: %v", err)
 		}
 	}
+
+	if clusterSpec.Spec.TLSPolicy != nil && clusterSpec.Spec.TLSPolicy.MinVersion != "" {
+		if err = c.updateWebhookTLSMinVersion(ctx, clusterSpec, cluster); err != nil {
+			return err
+		}
+	}
 	return c.waitForDeployments(ctx, internal.EksaDeployments, cluster)
 }
+
+// updateWebhookTLSMinVersion sets the min TLS version accepted by the eksa-controller-manager webhooks
+// from the cluster TLS policy. An empty version resets the webhooks to the default one
+func (c *retrierClient) updateWebhookTLSMinVersion(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
+	minVersion := ""
+	if clusterSpec.Spec.TLSPolicy != nil {
+		minVersion = string(clusterSpec.Spec.TLSPolicy.MinVersion)
+	}
+	envMap := map[string]string{constants.WebhookTLSMinVersionEnv: minVersion}
+	err := c.Retrier.RetryWithContext(ctx,
+		func() error {
+			return c.UpdateEnvironmentVariablesInNamespace(ctx, "deployment", "eksa-controller-manager", envMap, cluster, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error updating eks-a controller webhooks TLS min version: %v", err)
+	}
+	return nil
+}
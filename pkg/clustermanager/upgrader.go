@@ -3,9 +3,16 @@ package clustermanager
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/provenance"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -25,10 +32,14 @@ func (u *Upgrader) Upgrade(ctx context.Context, cluster *types.Cluster, currentS
 		logger.V(1).Info("Skipping EKS-A components upgrade, not a self-managed cluster")
 		return nil, nil
 	}
-	changeDiff := EksaChangeDiff(currentSpec, newSpec)
+	appliedOverrides, err := u.AppliedEksaOverrides(ctx, cluster, currentSpec)
+	if err != nil {
+		return nil, err
+	}
+	changeDiff := EksaChangeDiff(currentSpec, newSpec, appliedOverrides)
 	if changeDiff == nil {
 		logger.V(1).Info("Nothing to upgrade for controller and CRDs")
-		return nil, nil
+		return nil, u.upgradeWebhookTLSMinVersion(ctx, cluster, currentSpec, newSpec)
 	}
 	logger.V(1).Info("Starting EKS-A components upgrade")
 	oldVersion := currentSpec.VersionsBundle.Eksa.Version
@@ -40,7 +51,51 @@ func (u *Upgrader) Upgrade(ctx context.Context, cluster *types.Cluster, currentS
 	return changeDiff, nil
 }
 
-func EksaChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+// AppliedEksaOverrides returns the EKS-A components overrides installed in the cluster, recorded in its provenance
+// ConfigMap. Clusters without provenance only have the overrides of their annotations
+func (u *Upgrader) AppliedEksaOverrides(ctx context.Context, clus *types.Cluster, currentSpec *cluster.Spec) (cluster.EksaOverrides, error) {
+	var configMap *corev1.ConfigMap
+	err := u.retrier.RetryWithContext(ctx,
+		func() error {
+			var err error
+			configMap, err = u.retrier.GetConfigMap(ctx, clus.KubeconfigFile, provenance.ConfigMapName(currentSpec.Name), constants.EksaSystemNamespace)
+			if err != nil && strings.Contains(err.Error(), "NotFound") {
+				configMap = nil
+				return nil
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return cluster.EksaOverrides{}, fmt.Errorf("failed getting the provenance of cluster %s: %v", currentSpec.Name, err)
+	}
+	if configMap == nil {
+		return cluster.EksaOverrides{
+			ComponentsManifest: currentSpec.Cluster.EksaComponentsOverride(),
+			ControllerImage:    currentSpec.Cluster.EksaControllerImageOverride(),
+		}, nil
+	}
+	return provenance.EksaOverrides(configMap), nil
+}
+
+// EksaChangeDiff reports the EKS-A components change between the current and the new spec. appliedOverrides are
+// the overrides installed in the cluster, which can't be read from the current spec when they were set in the
+// environment of a previous upgrade
+func EksaChangeDiff(currentSpec, newSpec *cluster.Spec, appliedOverrides cluster.EksaOverrides) *types.ChangeDiff {
+	// The components are reinstalled while there are overrides, since the patched controller can change without
+	// its reference changing, and once more when they are removed to go back to the bundle ones
+	newOverrides := newSpec.EksaOverrides()
+	if !newOverrides.IsEmpty() || appliedOverrides != newOverrides {
+		return &types.ChangeDiff{
+			ComponentReports: []types.ComponentChangeDiff{
+				{
+					ComponentName: "EKS-A",
+					NewVersion:    eksaVersion(newSpec, newOverrides),
+					OldVersion:    eksaVersion(currentSpec, appliedOverrides),
+				},
+			},
+		}
+	}
 	if currentSpec.VersionsBundle.Eksa.Version != newSpec.VersionsBundle.Eksa.Version {
 		return &types.ChangeDiff{
 			ComponentReports: []types.ComponentChangeDiff{
@@ -54,3 +109,62 @@ func EksaChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 	}
 	return nil
 }
+
+func eksaVersion(spec *cluster.Spec, overrides cluster.EksaOverrides) string {
+	if overrides.IsEmpty() {
+		return spec.VersionsBundle.Eksa.Version
+	}
+	return spec.VersionsBundle.Eksa.Version + " (overridden)"
+}
+
+// KubernetesVersionsChangeDiff reports the kubernetes version changes for the control plane
+// and for each worker node group, since worker node groups can be upgraded independently
+func KubernetesVersionsChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+	changeDiff := &types.ChangeDiff{}
+	if currentSpec.VersionsBundle.KubeDistro.Kubernetes.Tag != newSpec.VersionsBundle.KubeDistro.Kubernetes.Tag {
+		changeDiff.ComponentReports = append(changeDiff.ComponentReports, types.ComponentChangeDiff{
+			ComponentName: "kubernetes control plane",
+			NewVersion:    newSpec.VersionsBundle.KubeDistro.Kubernetes.Tag,
+			OldVersion:    currentSpec.VersionsBundle.KubeDistro.Kubernetes.Tag,
+		})
+	}
+
+	currentWorkerNodeGroups := make(map[string]v1alpha1.WorkerNodeGroupConfiguration, len(currentSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range currentSpec.Spec.WorkerNodeGroupConfigurations {
+		currentWorkerNodeGroups[w.Name] = w
+	}
+
+	for _, w := range newSpec.Spec.WorkerNodeGroupConfigurations {
+		currentWorkerNodeGroup, ok := currentWorkerNodeGroups[w.Name]
+		if !ok {
+			continue
+		}
+		oldVersion := currentSpec.WorkerNodeGroupVersionsBundle(currentWorkerNodeGroup).KubeDistro.Kubernetes.Tag
+		newVersion := newSpec.WorkerNodeGroupVersionsBundle(w).KubeDistro.Kubernetes.Tag
+		if oldVersion != newVersion {
+			changeDiff.ComponentReports = append(changeDiff.ComponentReports, types.ComponentChangeDiff{
+				ComponentName: fmt.Sprintf("kubernetes worker node group %s", w.Name),
+				NewVersion:    newVersion,
+				OldVersion:    oldVersion,
+			})
+		}
+	}
+
+	if len(changeDiff.ComponentReports) == 0 {
+		return nil
+	}
+	return changeDiff
+}
+
+// upgradeWebhookTLSMinVersion updates the controller webhooks min TLS version when the TLS policy changes
+// without a new EKS-A version, since that's only set when the components are installed
+func (u *Upgrader) upgradeWebhookTLSMinVersion(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error {
+	if currentSpec.Spec.TLSPolicy.Equal(newSpec.Spec.TLSPolicy) {
+		return nil
+	}
+	logger.V(1).Info("Updating EKS-A controller webhooks TLS min version")
+	if err := u.retrier.updateWebhookTLSMinVersion(ctx, newSpec, cluster); err != nil {
+		return err
+	}
+	return u.retrier.waitForDeployments(ctx, internal.EksaDeployments, cluster)
+}
@@ -2,12 +2,17 @@ package clustermanager_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/mocks"
@@ -24,6 +29,8 @@ type upgraderTest struct {
 	newSpec     *cluster.Spec
 	upgrader    *clustermanager.Upgrader
 	cluster     *types.Cluster
+	// provenance is the provenance ConfigMap of the cluster, not found when nil
+	provenance *corev1.ConfigMap
 }
 
 func newUpgraderTest(t *testing.T) *upgraderTest {
@@ -33,7 +40,7 @@ func newUpgraderTest(t *testing.T) *upgraderTest {
 		s.VersionsBundle.Eksa.Version = "v0.1.0"
 	})
 
-	return &upgraderTest{
+	tt := &upgraderTest{
 		WithT:  NewWithT(t),
 		ctx:    context.Background(),
 		client: client,
@@ -45,7 +52,18 @@ func newUpgraderTest(t *testing.T) *upgraderTest {
 			Name:           "cluster-name",
 			KubeconfigFile: "k.kubeconfig",
 		},
+		provenance: &corev1.ConfigMap{},
 	}
+	client.EXPECT().GetConfigMap(tt.ctx, "k.kubeconfig", "fluxAddonTestCluster-provenance", "eksa-system").DoAndReturn(
+		func(_ context.Context, _, name, _ string) (*corev1.ConfigMap, error) {
+			if tt.provenance == nil {
+				return nil, fmt.Errorf("Error from server (NotFound): configmaps %q not found", name)
+			}
+			return tt.provenance, nil
+		},
+	).AnyTimes()
+
+	return tt
 }
 
 func TestUpgraderUpgradeNoSelfManaged(t *testing.T) {
@@ -84,6 +102,128 @@ func TestUpgraderUpgradeSuccess(t *testing.T) {
 	tt.Expect(tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
 }
 
+func TestUpgraderUpgradeControllerImageOverride(t *testing.T) {
+	tt := newUpgraderTest(t)
+	os.Setenv(cluster.EksaControllerImageOverrideEnvVar, "example.com/eks-anywhere-cluster-controller:patched")
+	defer os.Unsetenv(cluster.EksaControllerImageOverrideEnvVar)
+
+	tt.newSpec.VersionsBundle.Eksa.Components = v1alpha1.Manifest{
+		URI: "testdata/eksa_components.yaml",
+	}
+
+	wantDiff := &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{
+				ComponentName: "EKS-A",
+				NewVersion:    "v0.1.0 (overridden)",
+				OldVersion:    "v0.1.0",
+			},
+		},
+	}
+
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte("test data")).Return(nil)
+	tt.client.EXPECT().SetDeploymentImage(
+		tt.ctx, "k.kubeconfig", "eksa-controller-manager", "eksa-system", "manager", "example.com/eks-anywhere-cluster-controller:patched",
+	)
+	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m", "Available", "eksa-controller-manager", "eksa-system")
+	tt.Expect(tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
+}
+
+func TestEksaChangeDiffOverrideRemoved(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.currentSpec.Cluster.Annotations = map[string]string{
+		"anywhere.eks.amazonaws.com/eksa-controller-image-override": "example.com/eks-anywhere-cluster-controller:patched",
+	}
+
+	wantDiff := &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{
+				ComponentName: "EKS-A",
+				NewVersion:    "v0.1.0",
+				OldVersion:    "v0.1.0 (overridden)",
+			},
+		},
+	}
+
+	tt.Expect(clustermanager.EksaChangeDiff(tt.currentSpec, tt.newSpec, tt.currentSpec.EksaOverrides())).To(Equal(wantDiff))
+}
+
+func TestUpgraderUpgradeEnvOverrideRemoved(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.provenance.Data = map[string]string{
+		"eksaControllerImageOverride": "example.com/eks-anywhere-cluster-controller:patched",
+	}
+	tt.newSpec.VersionsBundle.Eksa.Components = v1alpha1.Manifest{
+		URI: "testdata/eksa_components.yaml",
+	}
+
+	wantDiff := &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{
+				ComponentName: "EKS-A",
+				NewVersion:    "v0.1.0",
+				OldVersion:    "v0.1.0 (overridden)",
+			},
+		},
+	}
+
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte("test data")).Return(nil)
+	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m", "Available", "eksa-controller-manager", "eksa-system")
+	tt.Expect(tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
+}
+
+func TestUpgraderAppliedEksaOverridesWithoutProvenance(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.provenance = nil
+	tt.currentSpec.Cluster.Annotations = map[string]string{
+		"anywhere.eks.amazonaws.com/eksa-controller-image-override": "example.com/eks-anywhere-cluster-controller:patched",
+	}
+
+	tt.Expect(tt.upgrader.AppliedEksaOverrides(tt.ctx, tt.cluster, tt.currentSpec)).To(Equal(cluster.EksaOverrides{
+		ControllerImage: "example.com/eks-anywhere-cluster-controller:patched",
+	}))
+}
+
+func TestUpgraderAppliedEksaOverridesError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClusterClient(ctrl)
+	upgrader := clustermanager.NewUpgrader(clustermanager.NewRetrierClient(clustermanager.NewClient(client), retrier.NewWithMaxRetries(1, 0)))
+	currentSpec := test.NewClusterSpec(func(s *cluster.Spec) { s.Name = "cluster-name" })
+	clus := &types.Cluster{Name: "cluster-name", KubeconfigFile: "k.kubeconfig"}
+	client.EXPECT().GetConfigMap(context.Background(), "k.kubeconfig", "cluster-name-provenance", "eksa-system").Return(nil, errors.New("connection refused"))
+
+	_, err := upgrader.AppliedEksaOverrides(context.Background(), clus, currentSpec)
+	NewWithT(t).Expect(err).To(MatchError(ContainSubstring("failed getting the provenance of cluster cluster-name")))
+}
+
+func TestUpgraderUpgradeSuccessWithTLSPolicy(t *testing.T) {
+	tt := newUpgraderTest(t)
+
+	tt.newSpec.VersionsBundle.Eksa.Version = "v0.2.0"
+	tt.newSpec.VersionsBundle.Eksa.Components = v1alpha1.Manifest{
+		URI: "testdata/eksa_components.yaml",
+	}
+	tt.newSpec.Spec.TLSPolicy = &anywherev1.TLSPolicy{MinVersion: anywherev1.TLSVersion13}
+
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte("test data")).Return(nil)
+	tt.client.EXPECT().UpdateEnvironmentVariablesInNamespace(
+		tt.ctx, "deployment", "eksa-controller-manager", map[string]string{"EKSA_WEBHOOK_TLS_MIN_VERSION": "VersionTLS13"}, tt.cluster, "eksa-system",
+	)
+	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m", "Available", "eksa-controller-manager", "eksa-system")
+	tt.Expect(tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).NotTo(BeNil())
+}
+
+func TestUpgraderUpgradeTLSPolicyChanged(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.currentSpec.Spec.TLSPolicy = &anywherev1.TLSPolicy{MinVersion: anywherev1.TLSVersion13}
+
+	tt.client.EXPECT().UpdateEnvironmentVariablesInNamespace(
+		tt.ctx, "deployment", "eksa-controller-manager", map[string]string{"EKSA_WEBHOOK_TLS_MIN_VERSION": ""}, tt.cluster, "eksa-system",
+	)
+	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m", "Available", "eksa-controller-manager", "eksa-system")
+	tt.Expect(tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil())
+}
+
 func TestUpgraderUpgradeInstallError(t *testing.T) {
 	tt := newUpgraderTest(t)
 
@@ -93,3 +233,42 @@ func TestUpgraderUpgradeInstallError(t *testing.T) {
 	_, err := tt.upgrader.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)
 	tt.Expect(err).NotTo(BeNil())
 }
+
+func TestKubernetesVersionsChangeDiff(t *testing.T) {
+	tt := newUpgraderTest(t)
+	kube120 := anywherev1.Kube120
+	tt.currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+		{Name: "md-0"},
+		{Name: "md-1"},
+	}
+	tt.currentSpec.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.20.7-eks-1-20-8"
+	tt.newSpec = tt.currentSpec.DeepCopy()
+	tt.newSpec.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.21.2-eks-1-21-4"
+	tt.newSpec.Cluster.Spec.WorkerNodeGroupConfigurations[1].KubernetesVersion = &kube120
+	tt.newSpec.WorkerNodeGroupsVersionsBundles = map[string]*cluster.VersionsBundle{
+		"md-1": tt.currentSpec.VersionsBundle,
+	}
+
+	wantDiff := &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{
+				ComponentName: "kubernetes control plane",
+				NewVersion:    "v1.21.2-eks-1-21-4",
+				OldVersion:    "v1.20.7-eks-1-20-8",
+			},
+			{
+				ComponentName: "kubernetes worker node group md-0",
+				NewVersion:    "v1.21.2-eks-1-21-4",
+				OldVersion:    "v1.20.7-eks-1-20-8",
+			},
+		},
+	}
+
+	tt.Expect(clustermanager.KubernetesVersionsChangeDiff(tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
+}
+
+func TestKubernetesVersionsChangeDiffNoChanges(t *testing.T) {
+	tt := newUpgraderTest(t)
+
+	tt.Expect(clustermanager.KubernetesVersionsChangeDiff(tt.currentSpec, tt.newSpec)).To(BeNil())
+}
@@ -35,13 +35,17 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 		marshallables = append(marshallables, clusterSpec.AWSIamConfig.ConvertConfigToConfigGenerateStruct())
 	}
 
+	return marshalResources(&clusterSpec.Spec, marshallables)
+}
+
+func marshalResources(clusterSpec *v1alpha1.ClusterSpec, marshallables []v1alpha1.Marshallable) ([]byte, error) {
 	resources := make([][]byte, 0, len(marshallables))
 	for _, marshallable := range marshallables {
 		resource, err := yaml.Marshal(marshallable)
 		if err != nil {
 			return nil, fmt.Errorf("failed marshalling resource for cluster spec: %v", err)
 		}
-		if clusterSpec.Spec.ClusterNetwork.DNS.ResolvConf == nil {
+		if clusterSpec.ClusterNetwork.DNS.ResolvConf == nil {
 			removeFromDefaultConfig := []string{"spec.clusterNetwork.dns"}
 			resource, err = api.CleanupPathsFromYaml(resource, removeFromDefaultConfig)
 			if err != nil {
@@ -0,0 +1,178 @@
+package clustermarshaller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// KubectlClient reads the EKS-A objects of a cluster from its management cluster
+type KubectlClient interface {
+	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
+	GetEksaVSphereDatacenterConfig(ctx context.Context, vsphereDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
+	GetEksaVSphereMachineConfig(ctx context.Context, vsphereMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error)
+	GetEksaDockerDatacenterConfig(ctx context.Context, dockerDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.DockerDatacenterConfig, error)
+	GetEksaTinkerbellDatacenterConfig(ctx context.Context, tinkerbellDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.TinkerbellDatacenterConfig, error)
+	GetEksaTinkerbellMachineConfig(ctx context.Context, tinkerbellMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.TinkerbellMachineConfig, error)
+	GetEksaAWSDatacenterConfig(ctx context.Context, awsDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.AWSDatacenterConfig, error)
+	GetEksaGitOpsConfig(ctx context.Context, gitOpsConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.GitOpsConfig, error)
+	GetEksaOIDCConfig(ctx context.Context, oidcConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.OIDCConfig, error)
+	GetEksaAWSIamConfig(ctx context.Context, awsIamConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.AWSIamConfig, error)
+}
+
+// Exporter rebuilds the cluster config of an existing cluster from the EKS-A objects in its management cluster,
+// for when the original file is lost. It produces the same documents WriteClusterConfig writes during create
+type Exporter struct {
+	kubectl KubectlClient
+}
+
+func NewExporter(kubectl KubectlClient) *Exporter {
+	return &Exporter{kubectl: kubectl}
+}
+
+// Export returns the cluster config yaml of clusterName, read from managementCluster. The status and the metadata
+// set by the API server and the EKS-A operations are dropped, so the result can be applied again
+func (e *Exporter) Export(ctx context.Context, managementCluster *types.Cluster, clusterName string) ([]byte, error) {
+	eksaCluster, err := e.kubectl.GetEksaCluster(ctx, managementCluster, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed exporting cluster config: %v", err)
+	}
+	cleanAnnotations(eksaCluster, eksaCluster.PausedAnnotation(), eksaCluster.ControlPlaneEndpointMigrationAnnotation())
+
+	marshallables := []v1alpha1.Marshallable{eksaCluster.ConvertConfigToConfigGenerateStruct()}
+
+	datacenter, err := e.datacenterConfig(ctx, managementCluster, eksaCluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed exporting cluster config: %v", err)
+	}
+	marshallables = append(marshallables, datacenter)
+
+	for _, ref := range eksaCluster.MachineConfigRefs() {
+		machineConfig, err := e.machineConfig(ctx, managementCluster, eksaCluster.Namespace, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed exporting cluster config: %v", err)
+		}
+		marshallables = append(marshallables, machineConfig)
+	}
+
+	if eksaCluster.Spec.GitOpsRef != nil {
+		gitOps, err := e.kubectl.GetEksaGitOpsConfig(ctx, eksaCluster.Spec.GitOpsRef.Name, managementCluster.KubeconfigFile, eksaCluster.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed exporting cluster config: %v", err)
+		}
+		cleanAnnotations(gitOps)
+		marshallables = append(marshallables, gitOps.ConvertConfigToConfigGenerateStruct())
+	}
+
+	for _, ref := range eksaCluster.Spec.IdentityProviderRefs {
+		identityProvider, err := e.identityProvider(ctx, managementCluster, eksaCluster.Namespace, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed exporting cluster config: %v", err)
+		}
+		marshallables = append(marshallables, identityProvider)
+	}
+
+	return marshalResources(&eksaCluster.Spec, marshallables)
+}
+
+func (e *Exporter) datacenterConfig(ctx context.Context, managementCluster *types.Cluster, eksaCluster *v1alpha1.Cluster) (v1alpha1.Marshallable, error) {
+	ref := eksaCluster.Spec.DatacenterRef
+	kubeconfig := managementCluster.KubeconfigFile
+	switch ref.Kind {
+	case v1alpha1.VSphereDatacenterKind:
+		config, err := e.kubectl.GetEksaVSphereDatacenterConfig(ctx, ref.Name, kubeconfig, eksaCluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.Marshallable(), nil
+	case v1alpha1.DockerDatacenterKind:
+		config, err := e.kubectl.GetEksaDockerDatacenterConfig(ctx, ref.Name, kubeconfig, eksaCluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.Marshallable(), nil
+	case v1alpha1.TinkerbellDatacenterKind:
+		config, err := e.kubectl.GetEksaTinkerbellDatacenterConfig(ctx, ref.Name, kubeconfig, eksaCluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.Marshallable(), nil
+	case v1alpha1.AWSDatacenterKind:
+		config, err := e.kubectl.GetEksaAWSDatacenterConfig(ctx, ref.Name, kubeconfig, eksaCluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.ConvertConfigToConfigGenerateStruct(), nil
+	default:
+		return nil, fmt.Errorf("unsupported datacenter kind %s", ref.Kind)
+	}
+}
+
+func (e *Exporter) machineConfig(ctx context.Context, managementCluster *types.Cluster, namespace string, ref v1alpha1.Ref) (v1alpha1.Marshallable, error) {
+	switch ref.Kind {
+	case v1alpha1.VSphereMachineConfigKind:
+		config, err := e.kubectl.GetEksaVSphereMachineConfig(ctx, ref.Name, managementCluster.KubeconfigFile, namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.Marshallable(), nil
+	case v1alpha1.TinkerbellMachineConfigKind:
+		config, err := e.kubectl.GetEksaTinkerbellMachineConfig(ctx, ref.Name, managementCluster.KubeconfigFile, namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.Marshallable(), nil
+	default:
+		return nil, fmt.Errorf("unsupported machine config kind %s", ref.Kind)
+	}
+}
+
+func (e *Exporter) identityProvider(ctx context.Context, managementCluster *types.Cluster, namespace string, ref v1alpha1.Ref) (v1alpha1.Marshallable, error) {
+	switch ref.Kind {
+	case v1alpha1.OIDCConfigKind:
+		config, err := e.kubectl.GetEksaOIDCConfig(ctx, ref.Name, managementCluster.KubeconfigFile, namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.ConvertConfigToConfigGenerateStruct(), nil
+	case v1alpha1.AWSIamConfigKind:
+		config, err := e.kubectl.GetEksaAWSIamConfig(ctx, ref.Name, managementCluster.KubeconfigFile, namespace)
+		if err != nil {
+			return nil, err
+		}
+		cleanAnnotations(config)
+		return config.ConvertConfigToConfigGenerateStruct(), nil
+	default:
+		return nil, fmt.Errorf("unsupported identity provider kind %s", ref.Kind)
+	}
+}
+
+// cleanAnnotations drops the annotations added by kubectl apply and the ones passed,
+// which only make sense during the operation that set them
+func cleanAnnotations(obj metav1.Object, annotations ...string) {
+	current := obj.GetAnnotations()
+	if current == nil {
+		return
+	}
+
+	delete(current, corev1.LastAppliedConfigAnnotation)
+	for _, a := range annotations {
+		delete(current, a)
+	}
+	if len(current) == 0 {
+		current = nil
+	}
+	obj.SetAnnotations(current)
+}
@@ -0,0 +1,122 @@
+package clustermarshaller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type exportTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	exporter          *clustermarshaller.Exporter
+	managementCluster *types.Cluster
+}
+
+func newExportTest(t *testing.T) *exportTest {
+	kubectl := mocks.NewMockKubectlClient(gomock.NewController(t))
+	return &exportTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		kubectl:           kubectl,
+		exporter:          clustermarshaller.NewExporter(kubectl),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+	}
+}
+
+// liveMeta is the metadata of an object read from the API server
+func liveMeta(name string, annotations map[string]string) v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Name:            name,
+		Namespace:       "eksa-ns",
+		ResourceVersion: "1234",
+		UID:             "8f2e2c5a-42d1-4f6b-9a5e-0f0c1c3b7a11",
+		Generation:      3,
+		Annotations:     annotations,
+		ManagedFields:   []v1.ManagedFieldsEntry{{Manager: "manager", Operation: v1.ManagedFieldsOperationUpdate}},
+	}
+}
+
+func TestExporterExport(t *testing.T) {
+	tt := newExportTest(t)
+	failure := "failed"
+	eksaCluster := &v1alpha1.Cluster{
+		TypeMeta: v1.TypeMeta{Kind: v1alpha1.ClusterKind, APIVersion: v1alpha1.GroupVersion.String()},
+		ObjectMeta: liveMeta("mycluster", map[string]string{
+			corev1.LastAppliedConfigAnnotation:                            "{}",
+			"anywhere.eks.amazonaws.com/paused":                           "true",
+			"anywhere.eks.amazonaws.com/managed-by":                       "mgmt",
+			"anywhere.eks.amazonaws.com/control-plane-endpoint-migration": "true",
+		}),
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion: v1alpha1.Kube121,
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Count:           3,
+				Endpoint:        &v1alpha1.Endpoint{Host: "1.2.3.4"},
+				MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "mycluster-cp"},
+			},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: 2, MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "mycluster-worker"}},
+				{Name: "md-1", Count: 1, MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "mycluster-worker"}},
+			},
+			DatacenterRef:        v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: "mycluster"},
+			GitOpsRef:            &v1alpha1.Ref{Kind: v1alpha1.GitOpsConfigKind, Name: "mycluster"},
+			IdentityProviderRefs: []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: "mycluster"}},
+			ManagementCluster:    v1alpha1.ManagementCluster{Name: "mgmt"},
+		},
+		Status: v1alpha1.ClusterStatus{FailureMessage: &failure},
+	}
+	tt.kubectl.EXPECT().GetEksaCluster(tt.ctx, tt.managementCluster, "mycluster").Return(eksaCluster, nil)
+	tt.kubectl.EXPECT().GetEksaVSphereDatacenterConfig(tt.ctx, "mycluster", "mgmt.kubeconfig", "eksa-ns").Return(&v1alpha1.VSphereDatacenterConfig{
+		TypeMeta:   v1.TypeMeta{Kind: v1alpha1.VSphereDatacenterKind, APIVersion: v1alpha1.GroupVersion.String()},
+		ObjectMeta: liveMeta("mycluster", map[string]string{corev1.LastAppliedConfigAnnotation: "{}"}),
+		Spec:       v1alpha1.VSphereDatacenterConfigSpec{Server: "vcenter.local", Datacenter: "dc", Network: "net"},
+		Status:     v1alpha1.VSphereDatacenterConfigStatus{SpecValid: true},
+	}, nil)
+	tt.kubectl.EXPECT().GetEksaVSphereMachineConfig(tt.ctx, "mycluster-cp", "mgmt.kubeconfig", "eksa-ns").Return(&v1alpha1.VSphereMachineConfig{
+		TypeMeta:   v1.TypeMeta{Kind: v1alpha1.VSphereMachineConfigKind, APIVersion: v1alpha1.GroupVersion.String()},
+		ObjectMeta: liveMeta("mycluster-cp", map[string]string{"anywhere.eks.amazonaws.com/control-plane": "true"}),
+		Spec:       v1alpha1.VSphereMachineConfigSpec{Folder: "my-folder", MemoryMiB: 8192, NumCPUs: 2},
+	}, nil)
+	tt.kubectl.EXPECT().GetEksaVSphereMachineConfig(tt.ctx, "mycluster-worker", "mgmt.kubeconfig", "eksa-ns").Return(&v1alpha1.VSphereMachineConfig{
+		TypeMeta:   v1.TypeMeta{Kind: v1alpha1.VSphereMachineConfigKind, APIVersion: v1alpha1.GroupVersion.String()},
+		ObjectMeta: liveMeta("mycluster-worker", nil),
+		Spec:       v1alpha1.VSphereMachineConfigSpec{Folder: "my-folder", MemoryMiB: 4096, NumCPUs: 2},
+	}, nil)
+	tt.kubectl.EXPECT().GetEksaGitOpsConfig(tt.ctx, "mycluster", "mgmt.kubeconfig", "eksa-ns").Return(&v1alpha1.GitOpsConfig{
+		TypeMeta:   v1.TypeMeta{Kind: v1alpha1.GitOpsConfigKind, APIVersion: v1alpha1.GroupVersion.String()},
+		ObjectMeta: liveMeta("mycluster", nil),
+		Spec:       v1alpha1.GitOpsConfigSpec{Flux: v1alpha1.Flux{Github: v1alpha1.Github{Owner: "me", Repository: "fleet"}}},
+	}, nil)
+	tt.kubectl.EXPECT().GetEksaOIDCConfig(tt.ctx, "mycluster", "mgmt.kubeconfig", "eksa-ns").Return(&v1alpha1.OIDCConfig{
+		TypeMeta:   v1.TypeMeta{Kind: v1alpha1.OIDCConfigKind, APIVersion: v1alpha1.GroupVersion.String()},
+		ObjectMeta: liveMeta("mycluster", nil),
+		Spec:       v1alpha1.OIDCConfigSpec{IssuerUrl: "https://issuer", ClientId: "eksa"},
+	}, nil)
+
+	got, err := tt.exporter.Export(tt.ctx, tt.managementCluster, "mycluster")
+	tt.Expect(err).To(BeNil())
+	test.AssertContentToFile(t, string(got), "testdata/expected_exported_cluster.yaml")
+}
+
+func TestExporterExportUnsupportedDatacenter(t *testing.T) {
+	tt := newExportTest(t)
+	tt.kubectl.EXPECT().GetEksaCluster(tt.ctx, tt.managementCluster, "mycluster").Return(&v1alpha1.Cluster{
+		ObjectMeta: v1.ObjectMeta{Name: "mycluster"},
+		Spec:       v1alpha1.ClusterSpec{DatacenterRef: v1alpha1.Ref{Kind: "CloudStackDatacenterConfig", Name: "mycluster"}},
+	}, nil)
+
+	_, err := tt.exporter.Export(tt.ctx, tt.managementCluster, "mycluster")
+	tt.Expect(err).To(MatchError("failed exporting cluster config: unsupported datacenter kind CloudStackDatacenterConfig"))
+}
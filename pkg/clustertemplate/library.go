@@ -0,0 +1,146 @@
+package clustertemplate
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const gitSubdirSeparator = "//"
+
+// Library is a set of templates, one per yaml file of a directory
+type Library struct {
+	dir     string
+	cleanup func() error
+}
+
+// NewLibrary opens a local directory or clones a git repository with the templates. For git repositories,
+// a directory inside it can be selected with <repository url>//<dir>, like https://github.com/org/repo.git//clusters
+func NewLibrary(ctx context.Context, source string) (*Library, error) {
+	if !isGitSource(source) {
+		info, err := os.Stat(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening cluster template library: %v", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("cluster template library %s is not a directory", source)
+		}
+		return &Library{dir: source}, nil
+	}
+
+	url, subdir := splitGitSource(source)
+	dir, err := ioutil.TempDir("", "eksa-cluster-templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed creating directory for cluster template library: %v", err)
+	}
+
+	logger.V(3).Info("Cloning cluster template library", "url", url)
+	if _, err = gogit.PlainCloneContext(ctx, dir, false, &gogit.CloneOptions{URL: url, Depth: 1}); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed cloning cluster template library %s: %v", url, err)
+	}
+
+	return &Library{
+		dir:     filepath.Join(dir, subdir),
+		cleanup: func() error { return os.RemoveAll(dir) },
+	}, nil
+}
+
+// Close removes the clone of git libraries
+func (l *Library) Close() error {
+	if l.cleanup == nil {
+		return nil
+	}
+	return l.cleanup()
+}
+
+// List returns the templates of the library sorted by name
+func (l *Library) List() ([]*Template, error) {
+	files, err := l.files()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*Template, 0, len(files))
+	names := map[string]string{}
+	for _, f := range files {
+		t, err := readTemplate(f)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := names[t.Name]; ok {
+			return nil, fmt.Errorf("cluster template %s is defined in %s and %s", t.Name, other, f)
+		}
+		names[t.Name] = f
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Get returns the template with the given name
+func (l *Library) Get(name string) (*Template, error) {
+	templates, err := l.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("cluster template %s not found in library", name)
+}
+
+func (l *Library) files() ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(l.dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func readTemplate(filename string) (*Template, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading cluster template: %v", err)
+	}
+	t, err := ParseTemplate(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return t, nil
+}
+
+func isGitSource(source string) bool {
+	return strings.HasPrefix(source, "https://") ||
+		strings.HasPrefix(source, "http://") ||
+		strings.HasPrefix(source, "ssh://") ||
+		strings.HasPrefix(source, "git@") ||
+		strings.HasPrefix(source, "file://")
+}
+
+func splitGitSource(source string) (url, subdir string) {
+	start := 0
+	if i := strings.Index(source, "://"); i != -1 {
+		start = i + len("://")
+	}
+	i := strings.Index(source[start:], gitSubdirSeparator)
+	if i == -1 {
+		return source, ""
+	}
+	return source[:start+i], source[start+i+len(gitSubdirSeparator):]
+}
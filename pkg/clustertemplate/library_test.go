@@ -0,0 +1,82 @@
+package clustertemplate_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/clustertemplate"
+)
+
+func TestLibraryListDirectory(t *testing.T) {
+	g := NewWithT(t)
+	library, err := clustertemplate.NewLibrary(context.Background(), "testdata/library")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer library.Close()
+
+	templates, err := library.List()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(templates).To(HaveLen(2))
+	g.Expect(templates[0].Name).To(Equal("docker-dev"))
+	g.Expect(templates[1].Name).To(Equal("docker-ha"))
+}
+
+func TestLibraryGetNotFound(t *testing.T) {
+	g := NewWithT(t)
+	library, err := clustertemplate.NewLibrary(context.Background(), "testdata/library")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = library.Get("vsphere-prod")
+	g.Expect(err).To(MatchError("cluster template vsphere-prod not found in library"))
+}
+
+func TestLibraryNotADirectory(t *testing.T) {
+	g := NewWithT(t)
+	_, err := clustertemplate.NewLibrary(context.Background(), "testdata/values.yaml")
+	g.Expect(err).To(MatchError("cluster template library testdata/values.yaml is not a directory"))
+}
+
+func TestLibraryDuplicatedTemplate(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	content := test.ReadFile(t, "testdata/library/docker-dev.yaml")
+	for _, f := range []string{"a.yaml", "b.yaml"} {
+		g.Expect(ioutil.WriteFile(filepath.Join(dir, f), []byte(content), 0o644)).To(Succeed())
+	}
+
+	library, err := clustertemplate.NewLibrary(context.Background(), dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = library.List()
+	g.Expect(err).To(MatchError(ContainSubstring("cluster template docker-dev is defined in")))
+}
+
+func TestLibraryGitRepository(t *testing.T) {
+	g := NewWithT(t)
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.Mkdir(filepath.Join(repoDir, "clusters"), 0o755)).To(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(repoDir, "clusters", "docker-dev.yaml"), []byte(test.ReadFile(t, "testdata/library/docker-dev.yaml")), 0o644)).To(Succeed())
+	w, err := repo.Worktree()
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = w.Add("clusters/docker-dev.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = w.Commit("Add template", &gogit.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	library, err := clustertemplate.NewLibrary(context.Background(), "file://"+repoDir+"//clusters")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	template, err := library.Get("docker-dev")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(template.Description).To(Equal("Docker cluster for local development"))
+	g.Expect(library.Close()).To(Succeed())
+}
@@ -0,0 +1,255 @@
+package clustertemplate
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+// variableRegex matches the ${name} placeholders. The ones not declared as variables of the template are kept,
+// so environment variables and secret references are still handled when the cluster config is read
+var variableRegex = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// variableNameRegex matches the valid variable names
+var variableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Template is a reusable cluster config, with ${name} placeholders for the values that change between clusters
+type Template struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Variables   []Variable `json:"variables,omitempty"`
+	Spec        string     `json:"spec"`
+}
+
+// Variable is a value the template needs. It's required when it doesn't have a default
+type Variable struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+func (v Variable) Required() bool {
+	return v.Default == nil
+}
+
+// Values are the per-cluster inputs of a template. Overrides are partial objects merged on top of the objects
+// of the template with the same kind, and name if set. Overrides that don't match any object are added as new objects
+type Values struct {
+	Template  string                   `json:"template,omitempty"`
+	Values    map[string]string        `json:"values,omitempty"`
+	Overrides []map[string]interface{} `json:"overrides,omitempty"`
+}
+
+// ParseTemplate reads a template and checks its variables
+func ParseTemplate(content []byte) (*Template, error) {
+	t := &Template{}
+	if err := yaml.UnmarshalStrict(content, t); err != nil {
+		return nil, fmt.Errorf("failed parsing cluster template: %v", err)
+	}
+	if t.Name == "" {
+		return nil, errors.New("cluster template name is required")
+	}
+	if strings.TrimSpace(t.Spec) == "" {
+		return nil, fmt.Errorf("cluster template %s doesn't have a spec", t.Name)
+	}
+
+	names := map[string]bool{}
+	for _, v := range t.Variables {
+		if !variableNameRegex.MatchString(v.Name) {
+			return nil, fmt.Errorf("cluster template %s variable name %q is invalid, it must only contain letters, digits and underscores and not start with a digit", t.Name, v.Name)
+		}
+		if names[v.Name] {
+			return nil, fmt.Errorf("cluster template %s variable %s is declared more than once", t.Name, v.Name)
+		}
+		names[v.Name] = true
+	}
+	return t, nil
+}
+
+// ReadValues reads a per-cluster values file
+func ReadValues(filename string) (*Values, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading template values: %v", err)
+	}
+	values := &Values{}
+	if err = yaml.UnmarshalStrict(content, values); err != nil {
+		return nil, fmt.Errorf("failed parsing template values %s: %v", filename, err)
+	}
+	return values, nil
+}
+
+// Instantiate replaces the variables of the template with the values, merges the overrides and validates
+// the result is a complete cluster config. It returns the multi document cluster config
+func (t *Template) Instantiate(values *Values) ([]byte, error) {
+	content, err := t.substitute(values.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values.Overrides) > 0 {
+		content, err = MergeOverrides(content, values.Overrides)
+		if err != nil {
+			return nil, fmt.Errorf("failed merging overrides into cluster template %s: %v", t.Name, err)
+		}
+	}
+
+	if err = validate(content); err != nil {
+		return nil, fmt.Errorf("cluster template %s instantiated with the values is invalid:\n%v", t.Name, err)
+	}
+	return content, nil
+}
+
+func (t *Template) substitute(values map[string]string) ([]byte, error) {
+	declared := make(map[string]Variable, len(t.Variables))
+	for _, v := range t.Variables {
+		declared[v.Name] = v
+	}
+
+	var errs []error
+	var unknown []string
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("value %s is not a variable of cluster template %s", name, t.Name))
+	}
+
+	for _, v := range t.Variables {
+		if _, ok := values[v.Name]; !ok && v.Required() {
+			errs = append(errs, fmt.Errorf("cluster template %s requires a value for %s", t.Name, v.Name))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, kerrors.NewAggregate(errs)
+	}
+
+	content := variableRegex.ReplaceAllStringFunc(t.Spec, func(placeholder string) string {
+		// $${ is an escaped placeholder, left for the cluster config substitution
+		if strings.HasPrefix(placeholder, "$$") {
+			return placeholder
+		}
+		v, ok := declared[variableRegex.FindStringSubmatch(placeholder)[1]]
+		if !ok {
+			return placeholder
+		}
+		if value, ok := values[v.Name]; ok {
+			return value
+		}
+		return *v.Default
+	})
+	return []byte(content), nil
+}
+
+// MergeOverrides merges partial objects into the objects of a multi document cluster config with the same kind,
+// and name if set. Overrides that don't match any object are added when they have an apiVersion
+func MergeOverrides(content []byte, overrides []map[string]interface{}) ([]byte, error) {
+	objs, err := splitObjects(content)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		kind, _ := override["kind"].(string)
+		if kind == "" {
+			return nil, errors.New("overrides must have a kind")
+		}
+		name := objectName(override)
+
+		merged := false
+		for _, obj := range objs {
+			if obj["kind"] == kind && (name == "" || objectName(obj) == name) {
+				mergeMaps(obj, override)
+				merged = true
+			}
+		}
+		if !merged {
+			if _, ok := override["apiVersion"]; !ok {
+				return nil, fmt.Errorf("override for %s %s doesn't match any object and doesn't have an apiVersion to add it", kind, name)
+			}
+			objs = append(objs, override)
+		}
+	}
+
+	docs := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		doc, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return bytes.Join(docs, []byte("---\n")), nil
+}
+
+// mergeMaps merges src into dst. Maps are merged recursively, any other value, lists included, replaces the one in dst
+func mergeMaps(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func objectName(obj map[string]interface{}) string {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+func splitObjects(content []byte) ([]map[string]interface{}, error) {
+	var objs []map[string]interface{}
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj := map[string]interface{}{}
+		if err = yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, err
+		}
+		if len(obj) > 0 {
+			objs = append(objs, obj)
+		}
+	}
+}
+
+func validate(content []byte) error {
+	validator, err := schema.NewValidator()
+	if err != nil {
+		return err
+	}
+	if err = validator.Validate(content); err != nil {
+		return err
+	}
+
+	docs, err := v1alpha1.ParseClusterConfigDocuments(content, v1alpha1.WithStrictParsing())
+	if err != nil {
+		return err
+	}
+	return docs.ValidateReferences()
+}
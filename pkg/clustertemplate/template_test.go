@@ -0,0 +1,151 @@
+package clustertemplate_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/clustertemplate"
+)
+
+func TestTemplateInstantiateWithOverrides(t *testing.T) {
+	g := NewWithT(t)
+	template := readTemplate(t, "testdata/library/docker-dev.yaml")
+	values, err := clustertemplate.ReadValues("testdata/values.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	content, err := template.Instantiate(values)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(content), "testdata/docker-dev-expected.yaml")
+}
+
+func TestTemplateInstantiateDefaults(t *testing.T) {
+	g := NewWithT(t)
+	template := readTemplate(t, "testdata/library/docker-dev.yaml")
+
+	content, err := template.Instantiate(&clustertemplate.Values{Values: map[string]string{"clusterName": "dev-2"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring("name: dev-2"))
+	g.Expect(string(content)).To(ContainSubstring("- count: 1"))
+	g.Expect(string(content)).To(ContainSubstring(`kubernetesVersion: "1.21"`))
+}
+
+func TestTemplateInstantiateKeepsOtherPlaceholders(t *testing.T) {
+	g := NewWithT(t)
+	template := readTemplate(t, "testdata/library/docker-dev.yaml")
+	template.Spec = strings.Replace(template.Spec, "  name: ${clusterName}\nspec:\n  controlPlaneConfiguration", "  name: ${clusterName}\n  annotations:\n    owner: ${OWNER}\n    literal: $${clusterName}\nspec:\n  controlPlaneConfiguration", 1)
+
+	content, err := template.Instantiate(&clustertemplate.Values{Values: map[string]string{"clusterName": "dev-2"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring("owner: ${OWNER}"))
+	g.Expect(string(content)).To(ContainSubstring("literal: $${clusterName}"))
+}
+
+func TestTemplateInstantiateValueErrors(t *testing.T) {
+	g := NewWithT(t)
+	template := readTemplate(t, "testdata/library/docker-dev.yaml")
+
+	_, err := template.Instantiate(&clustertemplate.Values{Values: map[string]string{"workerCount": "2", "region": "us-west-2"}})
+	g.Expect(err).To(MatchError("[value region is not a variable of cluster template docker-dev, cluster template docker-dev requires a value for clusterName]"))
+}
+
+func TestTemplateInstantiateInvalidResult(t *testing.T) {
+	g := NewWithT(t)
+	template := readTemplate(t, "testdata/library/docker-dev.yaml")
+
+	_, err := template.Instantiate(&clustertemplate.Values{
+		Values: map[string]string{"clusterName": "dev-1", "workerCount": "two"},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("cluster template docker-dev instantiated with the values is invalid")))
+}
+
+func TestTemplateInstantiateOverrideErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		override map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name:     "missing kind",
+			override: map[string]interface{}{"spec": map[string]interface{}{}},
+			wantErr:  "overrides must have a kind",
+		},
+		{
+			name:     "new object without apiVersion",
+			override: map[string]interface{}{"kind": "OIDCConfig"},
+			wantErr:  "override for OIDCConfig  doesn't match any object and doesn't have an apiVersion to add it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			template := readTemplate(t, "testdata/library/docker-dev.yaml")
+			_, err := template.Instantiate(&clustertemplate.Values{
+				Values:    map[string]string{"clusterName": "dev-1"},
+				Overrides: []map[string]interface{}{tt.override},
+			})
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			content: "spec: kind: Cluster",
+			wantErr: "failed parsing cluster template",
+		},
+		{
+			name:    "no name",
+			content: "spec: test",
+			wantErr: "cluster template name is required",
+		},
+		{
+			name:    "no spec",
+			content: "name: test",
+			wantErr: "cluster template test doesn't have a spec",
+		},
+		{
+			name:    "invalid variable name",
+			content: "name: test\nspec: test\nvariables:\n- name: cluster-name",
+			wantErr: `cluster template test variable name "cluster-name" is invalid, it must only contain letters, digits and underscores`,
+		},
+		{
+			name:    "variable name with a placeholder",
+			content: "name: test\nspec: test\nvariables:\n- name: 'a}${b'",
+			wantErr: `cluster template test variable name "a}${b" is invalid`,
+		},
+		{
+			name:    "variable name starting with a digit",
+			content: "name: test\nspec: test\nvariables:\n- name: 1a",
+			wantErr: `cluster template test variable name "1a" is invalid`,
+		},
+		{
+			name:    "duplicated variable",
+			content: "name: test\nspec: test\nvariables:\n- name: a\n- name: a",
+			wantErr: "cluster template test variable a is declared more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := clustertemplate.ParseTemplate([]byte(tt.content))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func readTemplate(t *testing.T, filename string) *clustertemplate.Template {
+	template, err := clustertemplate.ParseTemplate([]byte(test.ReadFile(t, filename)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return template
+}
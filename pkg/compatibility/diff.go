@@ -0,0 +1,249 @@
+package compatibility
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// Change is how a Kubernetes version or an image changes between two bundles
+type Change string
+
+const (
+	Added   Change = "added"
+	Removed Change = "removed"
+	Changed Change = "changed"
+)
+
+// BundlesDiff lists the changes between two Bundles, per Kubernetes version and component
+type BundlesDiff struct {
+	FromNumber         int                     `json:"fromNumber"`
+	ToNumber           int                     `json:"toNumber"`
+	KubernetesVersions []KubernetesVersionDiff `json:"kubernetesVersions"`
+}
+
+// KubernetesVersionDiff lists the components that change for a Kubernetes version. For added and removed
+// versions, it lists all the components of the version
+type KubernetesVersionDiff struct {
+	KubernetesVersion string          `json:"kubernetesVersion"`
+	Change            Change          `json:"change"`
+	Components        []ComponentDiff `json:"components"`
+}
+
+// ComponentDiff is a component of the bundle whose version or images change, like cilium or clusterAPI
+type ComponentDiff struct {
+	Name        string      `json:"name"`
+	FromVersion string      `json:"fromVersion,omitempty"`
+	ToVersion   string      `json:"toVersion,omitempty"`
+	Images      []ImageDiff `json:"images,omitempty"`
+}
+
+// ImageDiff is an image of a component that is added, removed or changes its URI or digest
+type ImageDiff struct {
+	Name   string `json:"name"`
+	Change Change `json:"change"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// Empty returns true if the bundles ship the same components and images
+func (d *BundlesDiff) Empty() bool {
+	return len(d.KubernetesVersions) == 0
+}
+
+// DiffBundles returns the component versions and images that change from one bundles manifest to another,
+// which is what an EKS Anywhere version bump pulls into the environment
+func DiffBundles(from, to *releasev1alpha1.Bundles) *BundlesDiff {
+	diff := &BundlesDiff{
+		FromNumber:         from.Spec.Number,
+		ToNumber:           to.Spec.Number,
+		KubernetesVersions: []KubernetesVersionDiff{},
+	}
+
+	fromBundles := versionsBundlesByKubeVersion(from)
+	toBundles := versionsBundlesByKubeVersion(to)
+	kubeVersions := make([]string, 0, len(fromBundles)+len(toBundles))
+	for v := range fromBundles {
+		kubeVersions = append(kubeVersions, v)
+	}
+	for v := range toBundles {
+		if _, ok := fromBundles[v]; !ok {
+			kubeVersions = append(kubeVersions, v)
+		}
+	}
+	sort.Slice(kubeVersions, func(i, j int) bool {
+		return compareMinorVersions(kubeVersions[i], kubeVersions[j]) < 0
+	})
+
+	for _, v := range kubeVersions {
+		vDiff := KubernetesVersionDiff{
+			KubernetesVersion: v,
+			Change:            Changed,
+			Components:        diffComponents(bundleComponents(fromBundles[v]), bundleComponents(toBundles[v])),
+		}
+		if fromBundles[v] == nil {
+			vDiff.Change = Added
+		} else if toBundles[v] == nil {
+			vDiff.Change = Removed
+		}
+		if len(vDiff.Components) > 0 {
+			diff.KubernetesVersions = append(diff.KubernetesVersions, vDiff)
+		}
+	}
+
+	return diff
+}
+
+func versionsBundlesByKubeVersion(bundles *releasev1alpha1.Bundles) map[string]*releasev1alpha1.VersionsBundle {
+	m := make(map[string]*releasev1alpha1.VersionsBundle, len(bundles.Spec.VersionsBundles))
+	for i := range bundles.Spec.VersionsBundles {
+		m[bundles.Spec.VersionsBundles[i].KubeVersion] = &bundles.Spec.VersionsBundles[i]
+	}
+	return m
+}
+
+// component is the version and the images, by field path, of a VersionsBundle field
+type component struct {
+	version string
+	images  map[string]string
+}
+
+var imageType = reflect.TypeOf(releasev1alpha1.Image{})
+
+// bundleComponents walks the VersionsBundle fields so new components and images are diffed without changes here.
+// Components are named after their json field
+func bundleComponents(vb *releasev1alpha1.VersionsBundle) map[string]component {
+	components := map[string]component{}
+	if vb == nil {
+		return components
+	}
+
+	v := reflect.ValueOf(vb).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := jsonName(v.Type().Field(i))
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+
+		c := component{images: map[string]string{}}
+		collectImages(field, "", c.images)
+		if version := field.FieldByName("Version"); version.IsValid() && version.Kind() == reflect.String {
+			c.version = version.String()
+		} else if eksd, ok := field.Interface().(releasev1alpha1.EksDRelease); ok {
+			c.version = eksd.Name
+		}
+		if c.version != "" || len(c.images) > 0 {
+			components[name] = c
+		}
+	}
+
+	return components
+}
+
+func collectImages(v reflect.Value, path string, images map[string]string) {
+	if v.Type() == imageType {
+		image := v.Interface().(releasev1alpha1.Image)
+		if image.URI != "" {
+			images[path] = imageRef(image)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			collectImages(v.Elem(), path, images)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name := path
+			if !f.Anonymous {
+				name = strings.TrimPrefix(path+"."+jsonName(f), ".")
+			}
+			collectImages(v.Field(i), name, images)
+		}
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return f.Name
+}
+
+func imageRef(image releasev1alpha1.Image) string {
+	if image.ImageDigest != "" {
+		return image.URI + "@" + image.ImageDigest
+	}
+	return image.URI
+}
+
+func diffComponents(from, to map[string]component) []ComponentDiff {
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diffs := []ComponentDiff{}
+	for _, name := range names {
+		f, t := from[name], to[name]
+		images := diffImages(f.images, t.images)
+		if f.version == t.version && len(images) == 0 {
+			continue
+		}
+		diffs = append(diffs, ComponentDiff{
+			Name:        name,
+			FromVersion: f.version,
+			ToVersion:   t.version,
+			Images:      images,
+		})
+	}
+
+	return diffs
+}
+
+func diffImages(from, to map[string]string) []ImageDiff {
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []ImageDiff
+	for _, name := range names {
+		f, inFrom := from[name]
+		t, inTo := to[name]
+		switch {
+		case !inFrom:
+			diffs = append(diffs, ImageDiff{Name: name, Change: Added, To: t})
+		case !inTo:
+			diffs = append(diffs, ImageDiff{Name: name, Change: Removed, From: f})
+		case f != t:
+			diffs = append(diffs, ImageDiff{Name: name, Change: Changed, From: f, To: t})
+		}
+	}
+
+	return diffs
+}
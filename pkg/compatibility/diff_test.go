@@ -0,0 +1,100 @@
+package compatibility_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/compatibility"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func diffBundles(number int, versionsBundles ...releasev1alpha1.VersionsBundle) *releasev1alpha1.Bundles {
+	return &releasev1alpha1.Bundles{
+		Spec: releasev1alpha1.BundlesSpec{Number: number, VersionsBundles: versionsBundles},
+	}
+}
+
+func versionsBundle(kubeVersion, eksd, cilium string) releasev1alpha1.VersionsBundle {
+	return releasev1alpha1.VersionsBundle{
+		KubeVersion: kubeVersion,
+		EksD:        releasev1alpha1.EksDRelease{Name: eksd},
+		Cilium: releasev1alpha1.CiliumBundle{
+			Version:  cilium,
+			Cilium:   releasev1alpha1.Image{URI: "public.ecr.aws/isovalent/cilium:" + cilium},
+			Operator: releasev1alpha1.Image{URI: "public.ecr.aws/isovalent/operator:" + cilium},
+		},
+		Eksa: releasev1alpha1.EksaBundle{
+			Version:  "v0.7.0",
+			CliTools: releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/cli-tools:v0.7.0", ImageDigest: "sha256:a"},
+		},
+	}
+}
+
+func TestDiffBundles(t *testing.T) {
+	g := NewWithT(t)
+	from := diffBundles(1,
+		versionsBundle("1.20", "kubernetes-1-20-eks-10", "v1.9.11"),
+		versionsBundle("1.21", "kubernetes-1-21-eks-7", "v1.9.11"),
+	)
+	upgraded := versionsBundle("1.21", "kubernetes-1-21-eks-8", "v1.9.13")
+	upgraded.Cilium.Operator = releasev1alpha1.Image{}
+	upgraded.Eksa.CliTools.ImageDigest = "sha256:b"
+	to := diffBundles(2, upgraded, versionsBundle("1.22", "kubernetes-1-22-eks-1", "v1.9.13"))
+
+	diff := compatibility.DiffBundles(from, to)
+	g.Expect(diff.Empty()).To(BeFalse())
+	g.Expect(diff.FromNumber).To(Equal(1))
+	g.Expect(diff.ToNumber).To(Equal(2))
+	g.Expect(diff.KubernetesVersions).To(HaveLen(3))
+
+	g.Expect(diff.KubernetesVersions[0].KubernetesVersion).To(Equal("1.20"))
+	g.Expect(diff.KubernetesVersions[0].Change).To(Equal(compatibility.Removed))
+	g.Expect(diff.KubernetesVersions[0].Components).To(HaveLen(3))
+
+	g.Expect(diff.KubernetesVersions[1]).To(Equal(compatibility.KubernetesVersionDiff{
+		KubernetesVersion: "1.21",
+		Change:            compatibility.Changed,
+		Components: []compatibility.ComponentDiff{
+			{
+				Name:        "cilium",
+				FromVersion: "v1.9.11",
+				ToVersion:   "v1.9.13",
+				Images: []compatibility.ImageDiff{
+					{Name: "cilium", Change: compatibility.Changed, From: "public.ecr.aws/isovalent/cilium:v1.9.11", To: "public.ecr.aws/isovalent/cilium:v1.9.13"},
+					{Name: "operator", Change: compatibility.Removed, From: "public.ecr.aws/isovalent/operator:v1.9.11"},
+				},
+			},
+			{
+				Name:        "eksD",
+				FromVersion: "kubernetes-1-21-eks-7",
+				ToVersion:   "kubernetes-1-21-eks-8",
+			},
+			{
+				Name:        "eksa",
+				FromVersion: "v0.7.0",
+				ToVersion:   "v0.7.0",
+				Images: []compatibility.ImageDiff{
+					{
+						Name:   "cliTools",
+						Change: compatibility.Changed,
+						From:   "public.ecr.aws/eks-anywhere/cli-tools:v0.7.0@sha256:a",
+						To:     "public.ecr.aws/eks-anywhere/cli-tools:v0.7.0@sha256:b",
+					},
+				},
+			},
+		},
+	}))
+
+	g.Expect(diff.KubernetesVersions[2].KubernetesVersion).To(Equal("1.22"))
+	g.Expect(diff.KubernetesVersions[2].Change).To(Equal(compatibility.Added))
+	g.Expect(diff.KubernetesVersions[2].Components[0].Images[0]).To(Equal(compatibility.ImageDiff{
+		Name: "cilium", Change: compatibility.Added, To: "public.ecr.aws/isovalent/cilium:v1.9.13",
+	}))
+}
+
+func TestDiffBundlesSame(t *testing.T) {
+	g := NewWithT(t)
+	b := diffBundles(1, versionsBundle("1.21", "kubernetes-1-21-eks-7", "v1.9.11"))
+	g.Expect(compatibility.DiffBundles(b, b).Empty()).To(BeTrue())
+}
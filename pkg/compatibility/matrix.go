@@ -0,0 +1,235 @@
+package compatibility
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/semver"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// ciliumMinKernelVersions are the minimum Linux kernel versions required by each Cilium minor version, from the
+// Cilium system requirements. Versions not in the list require the kernel of the closest lower version listed
+var ciliumMinKernelVersions = []struct {
+	cilium string
+	kernel string
+}{
+	{cilium: "1.9.0", kernel: "4.9.17"},
+	{cilium: "1.13.0", kernel: "4.19.57"},
+}
+
+// ClusterAPIVersions are the versions of the Cluster API providers shipped for a Kubernetes version
+type ClusterAPIVersions struct {
+	Core                   string `json:"core"`
+	Bootstrap              string `json:"bootstrap"`
+	ControlPlane           string `json:"controlPlane"`
+	EtcdadmBootstrap       string `json:"etcdadmBootstrap"`
+	EtcdadmController      string `json:"etcdadmController"`
+	VSphereProvider        string `json:"vsphereProvider"`
+	DockerProvider         string `json:"dockerProvider"`
+	TinkerbellProvider     string `json:"tinkerbellProvider,omitempty"`
+	KubernetesVersion      string `json:"kubernetesVersion"`
+	KubernetesDistribution string `json:"kubernetesDistribution"`
+}
+
+// OSImage is a node image shipped in the bundle
+type OSImage struct {
+	OSName            string   `json:"osName"`
+	KubernetesVersion string   `json:"kubernetesVersion"`
+	Arch              []string `json:"arch"`
+	URI               string   `json:"uri"`
+}
+
+// Matrix answers compatibility questions about the component versions shipped in a Bundles, like which
+// Cluster API versions pair with a Kubernetes version or which OS images exist for an architecture
+type Matrix struct {
+	bundles *releasev1alpha1.Bundles
+}
+
+func NewMatrix(bundles *releasev1alpha1.Bundles) *Matrix {
+	return &Matrix{bundles: bundles}
+}
+
+// KubernetesVersions returns the Kubernetes minor versions supported by the bundle, sorted from older to newer
+func (m *Matrix) KubernetesVersions() []string {
+	versions := make([]string, 0, len(m.bundles.Spec.VersionsBundles))
+	for _, vb := range m.bundles.Spec.VersionsBundles {
+		versions = append(versions, vb.KubeVersion)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return compareMinorVersions(versions[i], versions[j]) < 0
+	})
+
+	return versions
+}
+
+// SupportsKubernetesVersion returns true if the bundle ships components for the Kubernetes minor version
+func (m *Matrix) SupportsKubernetesVersion(kubeVersion string) bool {
+	_, err := m.versionsBundle(kubeVersion)
+	return err == nil
+}
+
+// ClusterAPIVersions returns the versions of the Cluster API providers that run clusters of the Kubernetes version
+func (m *Matrix) ClusterAPIVersions(kubeVersion string) (*ClusterAPIVersions, error) {
+	vb, err := m.versionsBundle(kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClusterAPIVersions{
+		Core:                   vb.ClusterAPI.Version,
+		Bootstrap:              vb.Bootstrap.Version,
+		ControlPlane:           vb.ControlPlane.Version,
+		EtcdadmBootstrap:       vb.ExternalEtcdBootstrap.Version,
+		EtcdadmController:      vb.ExternalEtcdController.Version,
+		VSphereProvider:        vb.VSphere.Version,
+		DockerProvider:         vb.Docker.Version,
+		TinkerbellProvider:     vb.Tinkerbell.Version,
+		KubernetesVersion:      vb.KubeVersion,
+		KubernetesDistribution: vb.EksD.Name,
+	}, nil
+}
+
+// KubernetesVersionsForClusterAPI returns the Kubernetes minor versions that are run with the core Cluster API version
+func (m *Matrix) KubernetesVersionsForClusterAPI(capiVersion string) []string {
+	var versions []string
+	for _, kubeVersion := range m.KubernetesVersions() {
+		vb, _ := m.versionsBundle(kubeVersion)
+		if vb.ClusterAPI.Version == capiVersion {
+			versions = append(versions, kubeVersion)
+		}
+	}
+
+	return versions
+}
+
+// CiliumVersion returns the version of Cilium shipped for the Kubernetes version
+func (m *Matrix) CiliumVersion(kubeVersion string) (string, error) {
+	vb, err := m.versionsBundle(kubeVersion)
+	if err != nil {
+		return "", err
+	}
+
+	return vb.Cilium.Version, nil
+}
+
+// CiliumMinKernelVersion returns the minimum Linux kernel version required by the Cilium shipped for the Kubernetes version
+func (m *Matrix) CiliumMinKernelVersion(kubeVersion string) (string, error) {
+	ciliumVersion, err := m.CiliumVersion(kubeVersion)
+	if err != nil {
+		return "", err
+	}
+
+	cilium, err := semver.New(ciliumVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid cilium version in bundle: %v", err)
+	}
+
+	minKernel := ""
+	for _, r := range ciliumMinKernelVersions {
+		v, _ := semver.New(r.cilium)
+		if cilium.Major < v.Major || cilium.Major == v.Major && cilium.Minor < v.Minor {
+			break
+		}
+		minKernel = r.kernel
+	}
+
+	if minKernel == "" {
+		return "", fmt.Errorf("kernel requirements for cilium %s are unknown", ciliumVersion)
+	}
+
+	return minKernel, nil
+}
+
+// CiliumSupportsKernel returns true if the Cilium shipped for the Kubernetes version runs on the Linux kernel version.
+// Distribution suffixes in the kernel version, like the one in 5.4.0-100-generic, are ignored
+func (m *Matrix) CiliumSupportsKernel(kubeVersion, kernelVersion string) (bool, error) {
+	minKernelVersion, err := m.CiliumMinKernelVersion(kubeVersion)
+	if err != nil {
+		return false, err
+	}
+
+	minKernel, _ := semver.New(minKernelVersion)
+	kernel, err := parseKernelVersion(kernelVersion)
+	if err != nil {
+		return false, err
+	}
+
+	return !kernel.LessThan(minKernel), nil
+}
+
+// OSImages returns the node images shipped for the Kubernetes version that can run on the architecture.
+// An empty arch returns the images for all the architectures
+func (m *Matrix) OSImages(kubeVersion, arch string) ([]OSImage, error) {
+	vb, err := m.versionsBundle(kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []OSImage
+	for _, ova := range vb.Ovas() {
+		if ova.URI == "" || (arch != "" && !contains(ova.Arch, arch)) {
+			continue
+		}
+		images = append(images, OSImage{
+			OSName:            ova.OSName,
+			KubernetesVersion: vb.KubeVersion,
+			Arch:              ova.Arch,
+			URI:               ova.URI,
+		})
+	}
+
+	return images, nil
+}
+
+func (m *Matrix) versionsBundle(kubeVersion string) (*releasev1alpha1.VersionsBundle, error) {
+	for i := range m.bundles.Spec.VersionsBundles {
+		if m.bundles.Spec.VersionsBundles[i].KubeVersion == kubeVersion {
+			return &m.bundles.Spec.VersionsBundles[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("kubernetes version %s is not supported by bundles manifest %d, supported versions: %s",
+		kubeVersion, m.bundles.Spec.Number, strings.Join(m.KubernetesVersions(), ", "))
+}
+
+func parseKernelVersion(version string) (*semver.Version, error) {
+	version = strings.SplitN(version, "-", 2)[0]
+	if strings.Count(version, ".") == 1 {
+		version += ".0"
+	}
+
+	v, err := semver.New(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kernel version: %v", err)
+	}
+
+	return v, nil
+}
+
+// compareMinorVersions compares Kubernetes minor versions like 1.21, putting the ones that can't be parsed last
+func compareMinorVersions(v1, v2 string) int {
+	s1, err1 := semver.New(v1 + ".0")
+	s2, err2 := semver.New(v2 + ".0")
+	switch {
+	case err1 != nil && err2 != nil:
+		return strings.Compare(v1, v2)
+	case err1 != nil:
+		return 1
+	case err2 != nil:
+		return -1
+	default:
+		return s1.Compare(s2)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
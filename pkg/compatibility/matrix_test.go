@@ -0,0 +1,131 @@
+package compatibility_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/compatibility"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func bundles() *releasev1alpha1.Bundles {
+	return &releasev1alpha1.Bundles{
+		Spec: releasev1alpha1.BundlesSpec{
+			Number: 2,
+			VersionsBundles: []releasev1alpha1.VersionsBundle{
+				{
+					KubeVersion: "1.21",
+					EksD: releasev1alpha1.EksDRelease{
+						Name: "kubernetes-1-21-eks-8",
+						Ova: releasev1alpha1.ArchiveBundle{
+							Bottlerocket: releasev1alpha1.OvaArchive{
+								Archive: releasev1alpha1.Archive{OSName: "bottlerocket", Arch: []string{"amd64"}, URI: "https://images/1-21/bottlerocket.ova"},
+							},
+							Ubuntu: releasev1alpha1.OvaArchive{
+								Archive: releasev1alpha1.Archive{OSName: "ubuntu", Arch: []string{"amd64", "arm64"}, URI: "https://images/1-21/ubuntu.ova"},
+							},
+						},
+					},
+					ClusterAPI:   releasev1alpha1.CoreClusterAPI{Version: "v1.0.2+eksa.1"},
+					Bootstrap:    releasev1alpha1.KubeadmBootstrapBundle{Version: "v1.0.2+eksa.2"},
+					ControlPlane: releasev1alpha1.KubeadmControlPlaneBundle{Version: "v1.0.2+eksa.3"},
+					VSphere:      releasev1alpha1.VSphereBundle{Version: "v1.0.1"},
+					Docker:       releasev1alpha1.DockerBundle{Version: "v1.0.2"},
+					Cilium:       releasev1alpha1.CiliumBundle{Version: "v1.9.13-eksa.2"},
+				},
+				{
+					KubeVersion: "1.9",
+					ClusterAPI:  releasev1alpha1.CoreClusterAPI{Version: "v0.3.23"},
+					Cilium:      releasev1alpha1.CiliumBundle{Version: "1.8.0"},
+				},
+				{
+					KubeVersion: "1.20",
+					ClusterAPI:  releasev1alpha1.CoreClusterAPI{Version: "v1.0.2+eksa.1"},
+					Cilium:      releasev1alpha1.CiliumBundle{Version: "v1.13.1"},
+				},
+			},
+		},
+	}
+}
+
+func TestMatrixKubernetesVersions(t *testing.T) {
+	g := NewWithT(t)
+	m := compatibility.NewMatrix(bundles())
+
+	g.Expect(m.KubernetesVersions()).To(Equal([]string{"1.9", "1.20", "1.21"}))
+	g.Expect(m.SupportsKubernetesVersion("1.21")).To(BeTrue())
+	g.Expect(m.SupportsKubernetesVersion("1.22")).To(BeFalse())
+}
+
+func TestMatrixClusterAPIVersions(t *testing.T) {
+	g := NewWithT(t)
+	m := compatibility.NewMatrix(bundles())
+
+	g.Expect(m.ClusterAPIVersions("1.21")).To(Equal(&compatibility.ClusterAPIVersions{
+		Core:                   "v1.0.2+eksa.1",
+		Bootstrap:              "v1.0.2+eksa.2",
+		ControlPlane:           "v1.0.2+eksa.3",
+		VSphereProvider:        "v1.0.1",
+		DockerProvider:         "v1.0.2",
+		KubernetesVersion:      "1.21",
+		KubernetesDistribution: "kubernetes-1-21-eks-8",
+	}))
+	g.Expect(m.KubernetesVersionsForClusterAPI("v1.0.2+eksa.1")).To(Equal([]string{"1.20", "1.21"}))
+	g.Expect(m.KubernetesVersionsForClusterAPI("v1.1.0")).To(BeEmpty())
+}
+
+func TestMatrixUnsupportedKubernetesVersion(t *testing.T) {
+	g := NewWithT(t)
+	m := compatibility.NewMatrix(bundles())
+
+	_, err := m.ClusterAPIVersions("1.22")
+	g.Expect(err).To(MatchError("kubernetes version 1.22 is not supported by bundles manifest 2, supported versions: 1.9, 1.20, 1.21"))
+}
+
+func TestMatrixCiliumKernel(t *testing.T) {
+	tests := []struct {
+		name        string
+		kubeVersion string
+		kernel      string
+		wantMin     string
+		want        bool
+	}{
+		{name: "cilium 1.9 with newer kernel", kubeVersion: "1.21", kernel: "5.4.0-100-generic", wantMin: "4.9.17", want: true},
+		{name: "cilium 1.9 with same kernel", kubeVersion: "1.21", kernel: "4.9.17", wantMin: "4.9.17", want: true},
+		{name: "cilium 1.9 with older kernel", kubeVersion: "1.21", kernel: "4.9", wantMin: "4.9.17", want: false},
+		{name: "cilium 1.13 with older kernel", kubeVersion: "1.20", kernel: "4.14.262", wantMin: "4.19.57", want: false},
+		{name: "cilium 1.13 with newer kernel", kubeVersion: "1.20", kernel: "5.10.93", wantMin: "4.19.57", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := compatibility.NewMatrix(bundles())
+
+			g.Expect(m.CiliumMinKernelVersion(tt.kubeVersion)).To(Equal(tt.wantMin))
+			g.Expect(m.CiliumSupportsKernel(tt.kubeVersion, tt.kernel)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMatrixCiliumKernelErrors(t *testing.T) {
+	g := NewWithT(t)
+	m := compatibility.NewMatrix(bundles())
+
+	_, err := m.CiliumMinKernelVersion("1.9")
+	g.Expect(err).To(MatchError("kernel requirements for cilium 1.8.0 are unknown"))
+
+	_, err = m.CiliumSupportsKernel("1.21", "latest")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid kernel version")))
+}
+
+func TestMatrixOSImages(t *testing.T) {
+	g := NewWithT(t)
+	m := compatibility.NewMatrix(bundles())
+
+	g.Expect(m.OSImages("1.21", "arm64")).To(Equal([]compatibility.OSImage{
+		{OSName: "ubuntu", KubernetesVersion: "1.21", Arch: []string{"amd64", "arm64"}, URI: "https://images/1-21/ubuntu.ova"},
+	}))
+	g.Expect(m.OSImages("1.21", "")).To(HaveLen(2))
+	g.Expect(m.OSImages("1.20", "amd64")).To(BeEmpty())
+}
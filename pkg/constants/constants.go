@@ -15,6 +15,7 @@ const (
 	DefaultNamespace                        = "default"
 	EtcdAdmBootstrapProviderSystemNamespace = "etcdadm-bootstrap-provider-system"
 	EtcdAdmControllerSystemNamespace        = "etcdadm-controller-system"
+	FluxSystemNamespace                     = "flux-system"
 	KubeNodeLeaseNamespace                  = "kube-node-lease"
 	KubePublicNamespace                     = "kube-public"
 	KubeSystemNamespace                     = "kube-system"
@@ -31,4 +32,37 @@ const (
 
 	VSphereCredentialsName = "vsphere-credentials"
 	EksaLicenseName        = "eksa-license"
+	// EksaReleasesName is the Release the CLI stores in the management cluster with the EKS-A releases manifest
+	EksaReleasesName = "eksa-releases"
 )
+
+// WebhookTLSMinVersionEnv is the eksa-controller-manager environment variable with the min TLS version
+// accepted by its webhooks, set from the cluster TLS policy
+const WebhookTLSMinVersionEnv = "EKSA_WEBHOOK_TLS_MIN_VERSION"
+
+// Files the kubeadm templates write in the machines, custom bootstrap files can't overwrite them
+const (
+	KubeVipManifestPath        = "/etc/kubernetes/manifests/kube-vip.yaml"
+	AuditPolicyPath            = "/etc/kubernetes/audit-policy.yaml"
+	AdmissionConfigurationPath = "/etc/kubernetes/admission-configuration.yaml"
+	ContainerdProxyConfigPath  = "/etc/systemd/system/containerd.service.d/http-proxy.conf"
+	ContainerdConfigAppendPath = "/etc/containerd/config_append.toml"
+	ContainerdCertsDir         = "/etc/containerd/certs.d"
+	AwsIamAuthKubeconfigPath   = "/var/lib/kubeadm/aws-iam-authenticator/kubeconfig.yaml"
+	AwsIamAuthCertPath         = "/var/lib/kubeadm/aws-iam-authenticator/pki/cert.pem"
+	AwsIamAuthKeyPath          = "/var/lib/kubeadm/aws-iam-authenticator/pki/key.pem"
+)
+
+// Files the hardening profiles write in the machines, custom bootstrap files can't overwrite them either
+const (
+	CISSysctlPath           = "/etc/sysctl.d/90-eksa-cis.conf"
+	CISAuditRulesPath       = "/etc/audit/rules.d/90-eksa-cis.rules"
+	CISSshdConfigPath       = "/etc/ssh/sshd_config.d/90-eksa-cis.conf"
+	HardeningReportPath     = "/usr/local/bin/eksa-hardening-report.sh"
+	HardeningReportLogsPath = "/var/log/eksa-hardening-report.log"
+)
+
+// RegistryMirrorCACertPath returns the file containerd reads the CA of a registry mirror from
+func RegistryMirrorCACertPath(registry string) string {
+	return ContainerdCertsDir + "/" + registry + "/ca.crt"
+}
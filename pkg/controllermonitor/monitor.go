@@ -0,0 +1,231 @@
+package controllermonitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	defaultInterval         = 15 * time.Second
+	defaultRestartThreshold = 5
+	defaultPullThreshold    = 4
+	defaultLogLines         = 20
+)
+
+type KubectlClient interface {
+	GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Pod, error)
+	GetPodLogs(ctx context.Context, kubeconfigFile, name, namespace, container string, tailLines int, previous bool) (string, error)
+}
+
+// Monitor checks the pods of the controllers while a wait runs. A controller that can't pull its image, usually
+// because the registry mirror is missing or doesn't have the image in air gapped environments, or that keeps crashing,
+// never reconciles the cluster, so the monitor fails early instead of letting the wait time out
+type Monitor struct {
+	client           KubectlClient
+	interval         time.Duration
+	restartThreshold int32
+	pullThreshold    int
+	logLines         int
+}
+
+type MonitorOpt func(*Monitor)
+
+// WithInterval sets how often the pods are checked. Defaults to 15 seconds
+func WithInterval(interval time.Duration) MonitorOpt {
+	return func(m *Monitor) {
+		m.interval = interval
+	}
+}
+
+// WithThresholds sets the restarts after which a container in CrashLoopBackOff fails the wait and the consecutive
+// checks a container has to fail pulling its image. Defaults to 5 restarts and 4 checks
+func WithThresholds(restarts int32, pullChecks int) MonitorOpt {
+	return func(m *Monitor) {
+		m.restartThreshold = restarts
+		m.pullThreshold = pullChecks
+	}
+}
+
+// WithLogLines sets how many log lines of a crashing container are added to the error. Defaults to 20
+func WithLogLines(lines int) MonitorOpt {
+	return func(m *Monitor) {
+		m.logLines = lines
+	}
+}
+
+func New(client KubectlClient, opts ...MonitorOpt) *Monitor {
+	m := &Monitor{
+		client:           client,
+		interval:         defaultInterval,
+		restartThreshold: defaultRestartThreshold,
+		pullThreshold:    defaultPullThreshold,
+		logLines:         defaultLogLines,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+type Reason string
+
+const (
+	// CrashLoopBackOff is a container that keeps exiting after it starts
+	CrashLoopBackOff Reason = "CrashLoopBackOff"
+	// ImagePullBackOff is a container whose image can't be pulled. Kubernetes reports it alternating ErrImagePull
+	// and ImagePullBackOff
+	ImagePullBackOff Reason = "ImagePullBackOff"
+)
+
+// ControllerFailedError is returned when a controller pod crash loops or can't pull its image. For crash loops,
+// Logs has the last lines of the container before its last exit
+type ControllerFailedError struct {
+	Namespace    string
+	Pod          string
+	Container    string
+	Reason       Reason
+	Message      string
+	RestartCount int32
+	Logs         string
+}
+
+func (e *ControllerFailedError) Error() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "container %s of pod %s/%s is in %s", e.Container, e.Namespace, e.Pod, e.Reason)
+	if e.Reason == CrashLoopBackOff {
+		fmt.Fprintf(b, " after %d restarts", e.RestartCount)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(b, ": %s", e.Message)
+	}
+	if e.Logs != "" {
+		b.WriteString("\nlast log lines:")
+		for _, line := range strings.Split(strings.TrimRight(e.Logs, "\n"), "\n") {
+			fmt.Fprintf(b, "\n  %s", line)
+		}
+	}
+	b.WriteString("\n" + e.hint())
+	return b.String()
+}
+
+func (e *ControllerFailedError) hint() string {
+	if e.Reason == ImagePullBackOff {
+		return "the image can't be pulled: check that the registry mirror in the cluster config is reachable from the nodes, " +
+			"that its CA certificate is configured and that the EKS Anywhere images were imported into it"
+	}
+	return "the controller keeps crashing: check its logs for the cause, common ones are a registry mirror or proxy " +
+		"blocking the access to the infrastructure provider endpoint and invalid provider credentials"
+}
+
+// Target is the set of controllers watched by the monitor
+type Target struct {
+	// Cluster is the cluster the controllers run in
+	Cluster *types.Cluster
+	// Namespaces are the namespaces of the controllers
+	Namespaces []string
+}
+
+// Watch checks the pods until the context is done or until a container fails, when it returns a ControllerFailedError.
+// Errors reading the pods are ignored, since the API server can be briefly down while the waits run
+func (m *Monitor) Watch(ctx context.Context, target Target) error {
+	pullFailures := map[string]int{}
+	for {
+		if err := m.check(ctx, target, pullFailures); err != nil {
+			return err
+		}
+
+		if retrier.Sleep(ctx, m.interval) != nil {
+			return nil
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context, target Target, pullFailures map[string]int) error {
+	namespaces := append([]string(nil), target.Namespaces...)
+	sort.Strings(namespaces)
+	failing := map[string]bool{}
+	for _, namespace := range namespaces {
+		pods, err := m.client.GetPods(ctx, executables.WithCluster(target.Cluster), executables.WithNamespace(namespace))
+		if err != nil {
+			logger.V(4).Info("Can't read controller pods", "namespace", namespace, "error", err)
+			continue
+		}
+
+		for _, pod := range pods {
+			for _, status := range containerStatuses(pod) {
+				if err := m.checkContainer(ctx, target, pod, status, pullFailures, failing); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Only consecutive pull failures count, a container that pulled its image or that moved to another state starts over
+	for key := range pullFailures {
+		if !failing[key] {
+			delete(pullFailures, key)
+		}
+	}
+	return nil
+}
+
+func (m *Monitor) checkContainer(ctx context.Context, target Target, pod corev1.Pod, status corev1.ContainerStatus, pullFailures map[string]int, failing map[string]bool) error {
+	waiting := status.State.Waiting
+	if waiting == nil {
+		return nil
+	}
+
+	switch waiting.Reason {
+	case "ImagePullBackOff", "ErrImagePull":
+		key := pod.Namespace + "/" + pod.Name + "/" + status.Name
+		failing[key] = true
+		pullFailures[key]++
+		logger.V(4).Info("Controller container can't pull its image", "pod", pod.Name, "container", status.Name, "checks", pullFailures[key])
+		if pullFailures[key] < m.pullThreshold {
+			return nil
+		}
+		return &ControllerFailedError{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Container: status.Name,
+			Reason:    ImagePullBackOff,
+			Message:   waiting.Message,
+		}
+	case string(CrashLoopBackOff):
+		logger.V(4).Info("Controller container is crash looping", "pod", pod.Name, "container", status.Name, "restarts", status.RestartCount)
+		if status.RestartCount < m.restartThreshold {
+			return nil
+		}
+		logs, err := m.client.GetPodLogs(ctx, target.Cluster.KubeconfigFile, pod.Name, pod.Namespace, status.Name, m.logLines, true)
+		if err != nil {
+			logger.V(4).Info("Can't read logs of crashing container", "pod", pod.Name, "container", status.Name, "error", err)
+		}
+		return &ControllerFailedError{
+			Namespace:    pod.Namespace,
+			Pod:          pod.Name,
+			Container:    status.Name,
+			Reason:       CrashLoopBackOff,
+			Message:      waiting.Message,
+			RestartCount: status.RestartCount,
+			Logs:         logs,
+		}
+	}
+	return nil
+}
+
+func containerStatuses(pod corev1.Pod) []corev1.ContainerStatus {
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	return append(statuses, pod.Status.ContainerStatuses...)
+}
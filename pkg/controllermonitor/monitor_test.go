@@ -0,0 +1,131 @@
+package controllermonitor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/controllermonitor"
+	"github.com/aws/eks-anywhere/pkg/controllermonitor/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type monitorTest struct {
+	*WithT
+	ctx    context.Context
+	client *mocks.MockKubectlClient
+	target controllermonitor.Target
+}
+
+func newMonitorTest(t *testing.T) *monitorTest {
+	ctrl := gomock.NewController(t)
+	return &monitorTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		client: mocks.NewMockKubectlClient(ctrl),
+		target: controllermonitor.Target{
+			Cluster:    &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+			Namespaces: []string{"capi-system"},
+		},
+	}
+}
+
+func (tt *monitorTest) monitor() *controllermonitor.Monitor {
+	return controllermonitor.New(tt.client, controllermonitor.WithInterval(time.Millisecond), controllermonitor.WithThresholds(3, 2))
+}
+
+func (tt *monitorTest) expectGetPods(pods ...corev1.Pod) *gomock.Call {
+	return tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return(pods, nil)
+}
+
+func pod(reason string, restarts int32) corev1.Pod {
+	status := corev1.ContainerStatus{Name: "manager", RestartCount: restarts}
+	if reason != "" {
+		status.State.Waiting = &corev1.ContainerStateWaiting{Reason: reason, Message: "back-off restarting failed container"}
+	} else {
+		status.State.Running = &corev1.ContainerStateRunning{}
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "capi-controller-manager-0", Namespace: "capi-system"},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}},
+	}
+}
+
+func TestMonitorWatchCrashLoop(t *testing.T) {
+	tt := newMonitorTest(t)
+	gomock.InOrder(
+		tt.expectGetPods(pod("CrashLoopBackOff", 1)),
+		tt.expectGetPods(pod("", 2)),
+		tt.expectGetPods(pod("CrashLoopBackOff", 3)),
+	)
+	tt.client.EXPECT().GetPodLogs(tt.ctx, "mgmt.kubeconfig", "capi-controller-manager-0", "capi-system", "manager", 20, true).
+		Return("starting manager\nfailed to get informer: x509: certificate signed by unknown authority\n", nil)
+
+	err := tt.monitor().Watch(tt.ctx, tt.target)
+	failed := &controllermonitor.ControllerFailedError{}
+	tt.Expect(errors.As(err, &failed)).To(BeTrue())
+	tt.Expect(failed.Reason).To(Equal(controllermonitor.CrashLoopBackOff))
+	tt.Expect(failed.RestartCount).To(Equal(int32(3)))
+	tt.Expect(err.Error()).To(ContainSubstring("container manager of pod capi-system/capi-controller-manager-0 is in CrashLoopBackOff after 3 restarts"))
+	tt.Expect(err.Error()).To(ContainSubstring("\n  failed to get informer: x509: certificate signed by unknown authority"))
+}
+
+func TestMonitorWatchCrashLoopWithoutLogs(t *testing.T) {
+	tt := newMonitorTest(t)
+	tt.expectGetPods(pod("CrashLoopBackOff", 4))
+	tt.client.EXPECT().GetPodLogs(tt.ctx, "mgmt.kubeconfig", "capi-controller-manager-0", "capi-system", "manager", 20, true).
+		Return("", errors.New("previous terminated container not found"))
+
+	err := tt.monitor().Watch(tt.ctx, tt.target)
+	tt.Expect(err).To(MatchError(ContainSubstring("is in CrashLoopBackOff after 4 restarts")))
+	tt.Expect(err.Error()).NotTo(ContainSubstring("last log lines"))
+}
+
+func TestMonitorWatchImagePullBackOff(t *testing.T) {
+	tt := newMonitorTest(t)
+	gomock.InOrder(
+		tt.expectGetPods(pod("ErrImagePull", 0)),
+		tt.expectGetPods(pod("ContainerCreating", 0)),
+		tt.expectGetPods(pod("ErrImagePull", 0)),
+		tt.expectGetPods(pod("ImagePullBackOff", 0)),
+	)
+
+	err := tt.monitor().Watch(tt.ctx, tt.target)
+	failed := &controllermonitor.ControllerFailedError{}
+	tt.Expect(errors.As(err, &failed)).To(BeTrue())
+	tt.Expect(failed.Reason).To(Equal(controllermonitor.ImagePullBackOff))
+	tt.Expect(err.Error()).To(ContainSubstring("check that the registry mirror in the cluster config is reachable"))
+}
+
+func TestMonitorWatchInitContainer(t *testing.T) {
+	tt := newMonitorTest(t)
+	p := pod("", 0)
+	p.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{Name: "init", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+	}
+	tt.expectGetPods(p).Times(2)
+
+	err := tt.monitor().Watch(tt.ctx, tt.target)
+	tt.Expect(err).To(MatchError(ContainSubstring("container init of pod capi-system/capi-controller-manager-0 is in ImagePullBackOff")))
+}
+
+func TestMonitorWatchStopsWithContext(t *testing.T) {
+	tt := newMonitorTest(t)
+	ctx, cancel := context.WithCancel(tt.ctx)
+	tt.ctx = ctx
+	tt.client.EXPECT().GetPods(ctx, gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+	tt.client.EXPECT().GetPods(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ ...interface{}) ([]corev1.Pod, error) {
+			cancel()
+			return []corev1.Pod{pod("", 0)}, nil
+		},
+	)
+
+	tt.Expect(tt.monitor().Watch(ctx, tt.target)).To(Succeed())
+}
@@ -0,0 +1,69 @@
+package coreaddons
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// Addon is one of the core add-ons EKS Anywhere can install and manage in a cluster
+type Addon string
+
+const (
+	MetricsServer       Addon = "metrics-server"
+	NodeProblemDetector Addon = "node-problem-detector"
+)
+
+//go:embed config/metrics-server.yaml
+var metricsServerTemplate string
+
+//go:embed config/node-problem-detector.yaml
+var nodeProblemDetectorTemplate string
+
+// Enabled returns the core add-ons turned on in the cluster spec, in install order
+func Enabled(clusterSpec *cluster.Spec) []Addon {
+	config := clusterSpec.Spec.CoreAddons
+	if config == nil {
+		return nil
+	}
+
+	var addons []Addon
+	if config.MetricsServer {
+		addons = append(addons, MetricsServer)
+	}
+	if config.NodeProblemDetector {
+		addons = append(addons, NodeProblemDetector)
+	}
+	return addons
+}
+
+// All returns every core add-on, in install order
+func All() []Addon {
+	return []Addon{MetricsServer, NodeProblemDetector}
+}
+
+// GenerateManifest returns the manifest for the add-on with the image pinned in the cluster spec bundle
+func GenerateManifest(clusterSpec *cluster.Spec, addon Addon) ([]byte, error) {
+	var tmpl, image string
+	switch addon {
+	case MetricsServer:
+		tmpl = metricsServerTemplate
+		image = clusterSpec.VersionsBundle.MetricsServer.MetricsServer.VersionedImage()
+	case NodeProblemDetector:
+		tmpl = nodeProblemDetectorTemplate
+		image = clusterSpec.VersionsBundle.NodeProblemDetector.NodeProblemDetector.VersionedImage()
+	default:
+		return nil, fmt.Errorf("unknown core add-on %s", addon)
+	}
+	if image == "" {
+		return nil, fmt.Errorf("bundle for kubernetes version %s doesn't include core add-on %s", clusterSpec.Spec.KubernetesVersion, addon)
+	}
+
+	manifest, err := templater.Execute(tmpl, map[string]interface{}{"image": image})
+	if err != nil {
+		return nil, fmt.Errorf("error generating %s manifest: %v", addon, err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,79 @@
+package coreaddons_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/coreaddons"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func givenClusterSpec(config *v1alpha1.CoreAddonsConfiguration) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = v1alpha1.Kube121
+		s.Spec.CoreAddons = config
+		s.VersionsBundle.MetricsServer.MetricsServer = releasev1alpha1.Image{
+			URI: "public.ecr.aws/l0g8r8j6/kubernetes-sigs/metrics-server:v0.5.2-eks-a-v0.0.0-dev-build.581",
+		}
+		s.VersionsBundle.NodeProblemDetector.NodeProblemDetector = releasev1alpha1.Image{
+			URI: "public.ecr.aws/l0g8r8j6/kubernetes/node-problem-detector:v0.8.10-eks-a-v0.0.0-dev-build.581",
+		}
+	})
+}
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1alpha1.CoreAddonsConfiguration
+		want   []coreaddons.Addon
+	}{
+		{
+			name:   "not configured",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "metrics server",
+			config: &v1alpha1.CoreAddonsConfiguration{MetricsServer: true},
+			want:   []coreaddons.Addon{coreaddons.MetricsServer},
+		},
+		{
+			name:   "all",
+			config: &v1alpha1.CoreAddonsConfiguration{MetricsServer: true, NodeProblemDetector: true},
+			want:   []coreaddons.Addon{coreaddons.MetricsServer, coreaddons.NodeProblemDetector},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(coreaddons.Enabled(givenClusterSpec(tt.config))).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGenerateManifestMetricsServer(t *testing.T) {
+	g := NewWithT(t)
+	manifest, err := coreaddons.GenerateManifest(givenClusterSpec(nil), coreaddons.MetricsServer)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results_metrics_server.yaml")
+}
+
+func TestGenerateManifestNodeProblemDetector(t *testing.T) {
+	g := NewWithT(t)
+	manifest, err := coreaddons.GenerateManifest(givenClusterSpec(nil), coreaddons.NodeProblemDetector)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results_node_problem_detector.yaml")
+}
+
+func TestGenerateManifestNotInBundle(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenClusterSpec(nil)
+	spec.VersionsBundle.MetricsServer.MetricsServer = releasev1alpha1.Image{}
+	_, err := coreaddons.GenerateManifest(spec, coreaddons.MetricsServer)
+	g.Expect(err).To(MatchError("bundle for kubernetes version 1.21 doesn't include core add-on metrics-server"))
+}
@@ -0,0 +1,185 @@
+package coredns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// DefaultReplicas is the number of CoreDNS pods kubeadm deploys
+	DefaultReplicas = 2
+	// DefaultCacheTTL is the cache time in seconds of the kubeadm Corefile
+	DefaultCacheTTL = 30
+
+	defaultUpstream = "/etc/resolv.conf"
+
+	coreDNSName      = "coredns"
+	coreDNSNamespace = "kube-system"
+	corefileKey      = "Corefile"
+
+	// the stub domains are delimited with these comments so they can be replaced or removed on upgrades
+	stubDomainsStart = "# eks-anywhere stub domains"
+	stubDomainsEnd   = "# end eks-anywhere stub domains"
+)
+
+// Replicas returns the number of CoreDNS pods for the config
+func Replicas(config *v1alpha1.CoreDNSConfiguration) int {
+	if config == nil || config.Replicas == 0 {
+		return DefaultReplicas
+	}
+	return config.Replicas
+}
+
+func cacheTTL(config *v1alpha1.CoreDNSConfiguration) int {
+	if config == nil || config.CacheTTL == 0 {
+		return DefaultCacheTTL
+	}
+	return config.CacheTTL
+}
+
+func upstreams(config *v1alpha1.CoreDNSConfiguration) string {
+	if config == nil || len(config.UpstreamResolvers) == 0 {
+		return defaultUpstream
+	}
+	return strings.Join(config.UpstreamResolvers, " ")
+}
+
+// Corefile returns the CoreDNS Corefile with the upstream resolvers, cache TTL and stub domains of the config.
+// The rest of the Corefile, like the host entries, is kept. A nil config restores the kubeadm defaults
+func Corefile(corefile string, config *v1alpha1.CoreDNSConfiguration) (string, error) {
+	lines := strings.Split(strings.TrimRight(corefile, "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	inBlock := false
+	for _, l := range lines {
+		switch strings.TrimSpace(l) {
+		case stubDomainsStart:
+			inBlock = true
+			continue
+		case stubDomainsEnd:
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			out = append(out, l)
+		}
+	}
+
+	// the forward and cache plugins edited are the first ones, the ones of the main server block
+	forward, cache := -1, -1
+	for i, l := range out {
+		trimmed := strings.TrimSpace(l)
+		if forward < 0 && strings.HasPrefix(trimmed, "forward . ") {
+			forward = i
+		}
+		if cache < 0 && (trimmed == "cache" || strings.HasPrefix(trimmed, "cache ")) {
+			cache = i
+		}
+	}
+	if forward < 0 {
+		return "", fmt.Errorf("can't configure CoreDNS upstream resolvers, the Corefile doesn't have a forward plugin")
+	}
+	if cache < 0 {
+		return "", fmt.Errorf("can't configure CoreDNS cache, the Corefile doesn't have a cache plugin")
+	}
+
+	out[forward] = indentation(out[forward]) + "forward . " + upstreams(config) + pluginBlock(out[forward])
+	out[cache] = indentation(out[cache]) + "cache " + strconv.Itoa(cacheTTL(config)) + pluginBlock(out[cache])
+
+	if config != nil && len(config.StubDomains) > 0 {
+		out = append(out, stubDomainsStart)
+		for _, d := range config.StubDomains {
+			out = append(out,
+				fmt.Sprintf("%s:53 {", strings.TrimSuffix(d.Domain, ".")),
+				"    errors",
+				fmt.Sprintf("    cache %d", cacheTTL(config)),
+				fmt.Sprintf("    forward . %s", strings.Join(d.Servers, " ")),
+				"}",
+			)
+		}
+		out = append(out, stubDomainsEnd)
+	}
+
+	return strings.Join(out, "\n") + "\n", nil
+}
+
+func indentation(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// pluginBlock returns the opening of the plugin options block in the line, if it has one
+func pluginBlock(line string) string {
+	if strings.HasSuffix(strings.TrimSpace(line), "{") {
+		return " {"
+	}
+	return ""
+}
+
+// Client reads and applies the CoreDNS config of a cluster. executables.Kubectl implements it
+type Client interface {
+	GetConfigMap(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.ConfigMap, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	Scale(ctx context.Context, kind, name string, replicas int, opts ...executables.KubectlOpt) error
+}
+
+// Configure applies the config to the CoreDNS of the cluster: the Corefile changes and the number of replicas.
+// CoreDNS reloads the Corefile by itself, so the pods don't need to be restarted
+func Configure(ctx context.Context, client Client, cluster *types.Cluster, config *v1alpha1.CoreDNSConfiguration) error {
+	cm, err := client.GetConfigMap(ctx, cluster.KubeconfigFile, coreDNSName, coreDNSNamespace)
+	if err != nil {
+		return fmt.Errorf("failed reading CoreDNS config: %v", err)
+	}
+
+	corefile, err := Corefile(cm.Data[corefileKey], config)
+	if err != nil {
+		return err
+	}
+	if corefile != cm.Data[corefileKey] {
+		if err = applyCorefile(ctx, client, cluster, cm, corefile); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Scale(ctx, "deployment", coreDNSName, Replicas(config),
+		executables.WithCluster(cluster), executables.WithNamespace(coreDNSNamespace),
+	); err != nil {
+		return fmt.Errorf("failed scaling CoreDNS: %v", err)
+	}
+	return nil
+}
+
+func applyCorefile(ctx context.Context, client Client, cluster *types.Cluster, cm *corev1.ConfigMap, corefile string) error {
+	data := make(map[string]string, len(cm.Data))
+	for k, v := range cm.Data {
+		data[k] = v
+	}
+	data[corefileKey] = corefile
+	manifest, err := yaml.Marshal(&corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      coreDNSName,
+			Namespace: coreDNSNamespace,
+		},
+		Data: data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed generating CoreDNS config: %v", err)
+	}
+
+	if err = client.ApplyKubeSpecFromBytes(ctx, cluster, manifest); err != nil {
+		return fmt.Errorf("failed applying CoreDNS config: %v", err)
+	}
+	return nil
+}
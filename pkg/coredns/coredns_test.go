@@ -0,0 +1,108 @@
+package coredns_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/coredns"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var config = &v1alpha1.CoreDNSConfiguration{
+	StubDomains: []v1alpha1.CoreDNSStubDomain{
+		{Domain: "corp.example.com", Servers: []string{"10.0.0.2", "10.0.0.3:5353"}},
+		{Domain: "lab.local.", Servers: []string{"10.0.1.2"}},
+	},
+	UpstreamResolvers: []string{"1.1.1.1", "8.8.8.8"},
+	Replicas:          3,
+	CacheTTL:          60,
+}
+
+func TestCorefile(t *testing.T) {
+	g := NewWithT(t)
+	corefile := test.ReadFile(t, "testdata/corefile")
+
+	got, err := coredns.Corefile(corefile, config)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, got, "testdata/expected_corefile")
+
+	got, err = coredns.Corefile(got, config)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, got, "testdata/expected_corefile")
+}
+
+func TestCorefileDefaults(t *testing.T) {
+	g := NewWithT(t)
+	corefile := test.ReadFile(t, "testdata/expected_corefile")
+
+	got, err := coredns.Corefile(corefile, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, got, "testdata/corefile")
+}
+
+func TestCorefileNoForward(t *testing.T) {
+	g := NewWithT(t)
+	_, err := coredns.Corefile(".:53 {\n    errors\n    cache 30\n}\n", config)
+	g.Expect(err).To(MatchError("can't configure CoreDNS upstream resolvers, the Corefile doesn't have a forward plugin"))
+}
+
+func TestReplicas(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(coredns.Replicas(nil)).To(Equal(coredns.DefaultReplicas))
+	g.Expect(coredns.Replicas(&v1alpha1.CoreDNSConfiguration{})).To(Equal(coredns.DefaultReplicas))
+	g.Expect(coredns.Replicas(config)).To(Equal(3))
+}
+
+type fakeClient struct {
+	corefile string
+	applied  []byte
+	replicas int
+	err      error
+}
+
+func (f *fakeClient) GetConfigMap(_ context.Context, _, _, _ string) (*corev1.ConfigMap, error) {
+	return &corev1.ConfigMap{Data: map[string]string{"Corefile": f.corefile}}, nil
+}
+
+func (f *fakeClient) ApplyKubeSpecFromBytes(_ context.Context, _ *types.Cluster, data []byte) error {
+	f.applied = data
+	return nil
+}
+
+func (f *fakeClient) Scale(_ context.Context, _, _ string, replicas int, _ ...executables.KubectlOpt) error {
+	f.replicas = replicas
+	return f.err
+}
+
+func TestConfigure(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeClient{corefile: test.ReadFile(t, "testdata/corefile")}
+
+	g.Expect(coredns.Configure(context.Background(), client, &types.Cluster{}, config)).To(Succeed())
+	g.Expect(string(client.applied)).To(ContainSubstring("forward . 1.1.1.1 8.8.8.8"))
+	g.Expect(client.replicas).To(Equal(3))
+}
+
+func TestConfigureUnchangedCorefile(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeClient{corefile: test.ReadFile(t, "testdata/corefile")}
+
+	g.Expect(coredns.Configure(context.Background(), client, &types.Cluster{}, nil)).To(Succeed())
+	g.Expect(client.applied).To(BeNil())
+	g.Expect(client.replicas).To(Equal(coredns.DefaultReplicas))
+}
+
+func TestConfigureScaleError(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeClient{corefile: test.ReadFile(t, "testdata/corefile"), err: errors.New("deployment not found")}
+
+	err := coredns.Configure(context.Background(), client, &types.Cluster{}, config)
+	g.Expect(err).To(MatchError("failed scaling CoreDNS: deployment not found"))
+}
@@ -14,3 +14,30 @@ func secureCipherSuiteNames() []string {
 func SecureCipherSuitesString() string {
 	return strings.Join(secureCipherSuiteNames(), ",")
 }
+
+// FIPSMinTLSVersion is the minimum TLS version for the Kubernetes components in FIPS mode
+const FIPSMinTLSVersion = "VersionTLS12"
+
+// fipsCipherSuiteNames are the cipher suites approved by FIPS 140-2 supported by the Kubernetes components
+func fipsCipherSuiteNames() []string {
+	return []string{
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	}
+}
+
+func FIPSCipherSuitesString() string {
+	return strings.Join(fipsCipherSuiteNames(), ",")
+}
+
+// IsFIPSCipherSuite returns true if the cipher suite is approved by FIPS 140-2
+func IsFIPSCipherSuite(name string) bool {
+	for _, n := range fipsCipherSuiteNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
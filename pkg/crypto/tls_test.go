@@ -17,3 +17,13 @@ func TestSecureCipherSuiteNames(t *testing.T) {
 		assert.Equal(t, validCipherSuitesString, string, "cipher suites don't match")
 	}
 }
+
+func TestFIPSCipherSuiteNames(t *testing.T) {
+	want := "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"
+	assert.Equal(t, want, crypto.FIPSCipherSuitesString(), "cipher suites don't match")
+}
+
+func TestIsFIPSCipherSuite(t *testing.T) {
+	assert.True(t, crypto.IsFIPSCipherSuite("TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"))
+	assert.False(t, crypto.IsFIPSCipherSuite("TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305"))
+}
@@ -2,26 +2,39 @@ package dependencies
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/aws/eks-anywhere/pkg/addonmanager/addonclients"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/artifacts"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/clients/flux"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/controllermonitor"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/networking/custom"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/policy"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/factory"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/vipmonitor"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 )
 
 type Dependencies struct {
@@ -36,6 +49,12 @@ type Dependencies struct {
 	Flux                      *executables.Flux
 	Troubleshoot              *executables.Troubleshoot
 	Helm                      *executables.Helm
+	Velero                    *executables.Velero
+	Ipmitool                  *executables.Ipmitool
+	SSH                       *executables.SSH
+	ImageBuilder              *executables.ImageBuilder
+	AwsCli                    *executables.AwsCli
+	Sonobuoy                  *executables.Sonobuoy
 	Networking                clustermanager.Networking
 	AwsIamAuth                clustermanager.AwsIamAuth
 	ClusterManager            *clustermanager.ClusterManager
@@ -46,6 +65,7 @@ type Dependencies struct {
 	DignosticCollectorFactory diagnostics.DiagnosticBundleFactory
 	CAPIManager               *clusterapi.Manager
 	ResourceSetManager        *clusterapi.ResourceSetManager
+	ArtifactStore             artifacts.Store
 	closers                   []types.Closer
 }
 
@@ -60,37 +80,86 @@ func (d *Dependencies) Close(ctx context.Context) error {
 	return nil
 }
 
-func ForSpec(ctx context.Context, clusterSpec *cluster.Spec) *Factory {
+func ForSpec(ctx context.Context, clusterSpec *cluster.Spec, opts ...FactoryOpt) *Factory {
 	eksaToolsImage := clusterSpec.VersionsBundle.Eksa.CliTools
-	return NewFactory().
+	return NewFactory(opts...).
 		WithExecutableImage(clusterSpec.UseImageMirror(eksaToolsImage.VersionedImage())).
-		WithWriterFolder(clusterSpec.Name).
+		WithWriterFolder(workspace.Default().ClusterDir(clusterSpec.Name)).
+		WithProxyConfiguration(clusterSpec.Cluster).
 		WithDiagnosticCollectorImage(clusterSpec.VersionsBundle.Eksa.DiagnosticCollector.VersionedImage())
 }
 
 type Factory struct {
 	executableBuilder        *executables.ExecutableBuilder
+	localExecutables         bool
 	providerFactory          *factory.ProviderFactory
 	executablesImage         string
 	executablesMountDirs     []string
 	writerFolder             string
+	inMemoryFiles            bool
 	diagnosticCollectorImage string
+	policyBundles            []string
+	proxyClusterConfig       *v1alpha1.Cluster
 	buildSteps               []buildStep
 	dependencies             Dependencies
 }
 
 type buildStep func(ctx context.Context) error
 
-func NewFactory() *Factory {
-	return &Factory{
-		writerFolder: "./",
-		buildSteps:   make([]buildStep, 0),
+// FactoryOpt configures how the Factory builds the dependencies
+type FactoryOpt func(*Factory)
+
+// UseLocalExecutables runs the binaries found in the PATH instead of the ones in the tools image,
+// so no tools container is started
+func UseLocalExecutables() FactoryOpt {
+	return func(f *Factory) {
+		f.localExecutables = true
+	}
+}
+
+// UseDependencies sets dependencies built outside the factory, which only builds the ones left nil.
+// This allows programs embedding EKS-A and tests to replace any of them. The factory doesn't close them
+func UseDependencies(deps Dependencies) FactoryOpt {
+	return func(f *Factory) {
+		deps.closers = nil
+		f.dependencies = deps
+	}
+}
+
+// UseInMemoryFiles keeps the files the dependencies write in memory instead of the writer folder, so programs
+// embedding EKS-A in containers with a read-only filesystem can run the operations that don't call the CLI
+// tools, like rendering and validating cluster configs. The CLI tools read their input files from disk,
+// so the executables fail when run instead of getting paths that don't exist
+func UseInMemoryFiles() FactoryOpt {
+	return func(f *Factory) {
+		f.inMemoryFiles = true
 	}
 }
 
+func NewFactory(opts ...FactoryOpt) *Factory {
+	f := &Factory{
+		executablesImage: executables.DefaultEksaImage(),
+		writerFolder:     "./",
+		buildSteps:       make([]buildStep, 0),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Build runs the pending build steps. If any of them fails, the dependencies built by this call are
+// closed and discarded, so the factory is left as it was before the call
 func (f *Factory) Build(ctx context.Context) (*Dependencies, error) {
+	previous := f.dependencies
+	previousBuilder := f.executableBuilder
 	for _, step := range f.buildSteps {
 		if err := step(ctx); err != nil {
+			f.teardown(ctx, len(previous.closers))
+			f.dependencies = previous
+			f.executableBuilder = previousBuilder
+			f.buildSteps = make([]buildStep, 0)
 			return nil, err
 		}
 	}
@@ -104,6 +173,15 @@ func (f *Factory) Build(ctx context.Context) (*Dependencies, error) {
 	return &d, nil
 }
 
+// teardown closes the closers added after the first n, in reverse order
+func (f *Factory) teardown(ctx context.Context, n int) {
+	for i := len(f.dependencies.closers) - 1; i >= n; i-- {
+		if err := f.dependencies.closers[i].Close(ctx); err != nil {
+			logger.Error(err, "Failed closing dependency after build error")
+		}
+	}
+}
+
 func (f *Factory) WithWriterFolder(folder string) *Factory {
 	f.writerFolder = folder
 	return f
@@ -119,27 +197,105 @@ func (f *Factory) WithExecutableMountDirs(mountDirs ...string) *Factory {
 	return f
 }
 
+// WithPolicyBundles evaluates the manifests applied by the ClusterManager with the policies of the bundle files or directories
+func (f *Factory) WithPolicyBundles(paths ...string) *Factory {
+	f.policyBundles = paths
+	return f
+}
+
+// WithProxyConfiguration sets the proxy env vars of the cluster proxy configuration for all the executables.
+// NO_PROXY includes the cluster CIDRs, the control plane endpoint and the provider endpoints
+func (f *Factory) WithProxyConfiguration(clusterConfig *v1alpha1.Cluster) *Factory {
+	f.proxyClusterConfig = clusterConfig
+	return f
+}
+
+// setProxyEnv sets the proxy env vars in the executable builder, with the provider endpoints once it's built
+func (f *Factory) setProxyEnv() {
+	if f.proxyClusterConfig == nil || f.executableBuilder == nil {
+		return
+	}
+
+	var endpoints []string
+	if p, ok := f.dependencies.Provider.(providers.ProxyEndpoints); ok {
+		endpoints = p.NoProxyEndpoints()
+	}
+	f.executableBuilder.WithEnv(cluster.ProxyEnv(f.proxyClusterConfig, endpoints...))
+}
+
+// WithExecutableBuilder starts the tools container even if no executable is built.
+// Otherwise it's started the first time an executable is needed
 func (f *Factory) WithExecutableBuilder() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
-		if f.executableBuilder != nil {
+		_, err := f.executables(ctx)
+		return err
+	})
+
+	return f
+}
+
+// executables returns the executable builder, creating it on first use
+func (f *Factory) executables(ctx context.Context) (*executables.ExecutableBuilder, error) {
+	if f.executableBuilder != nil {
+		return f.executableBuilder, nil
+	}
+
+	if f.inMemoryFiles {
+		f.executableBuilder = executables.NewInMemoryExecutableBuilder()
+		return f.executableBuilder, nil
+	}
+
+	if f.localExecutables {
+		f.executableBuilder = executables.NewLocalExecutableBuilder()
+		f.setProxyEnv()
+		return f.executableBuilder, nil
+	}
+
+	mountDirs := f.executablesMountDirs
+	if dir := os.Getenv(workspace.EnvVar); dir != "" {
+		// The cluster folders are outside the current directory, which is the only one mounted by default
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed getting the path of workspace %s: %v", dir, err)
+		}
+		mountDirs = append(mountDirs, abs)
+	}
+
+	b, close, err := executables.NewExecutableBuilder(ctx, f.executablesImage, mountDirs...)
+	if err != nil {
+		return nil, err
+	}
+
+	f.dependencies.closers = append(f.dependencies.closers, close)
+	f.executableBuilder = b
+	f.setProxyEnv()
+
+	return b, nil
+}
+
+func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, skipIpCheck bool, hardwareConfigFile string) *Factory {
+	f.WithProviderFactory(clusterConfig)
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.Provider != nil {
 			return nil
 		}
 
-		b, close, err := executables.NewExecutableBuilder(ctx, f.executablesImage, f.executablesMountDirs...)
+		var err error
+		f.dependencies.Provider, err = f.providerFactory.BuildProvider(clusterConfigFile, clusterConfig, skipIpCheck, hardwareConfigFile)
 		if err != nil {
 			return err
 		}
+		f.setProxyEnv()
 
-		f.dependencies.closers = append(f.dependencies.closers, close)
-
-		f.executableBuilder = b
 		return nil
 	})
 
 	return f
 }
 
-func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, skipIpCheck bool, hardwareConfigFile string) *Factory {
+// WithProviderFromContent is like WithProvider but reads the provider configs from a cluster config in memory
+func (f *Factory) WithProviderFromContent(clusterConfigContent []byte, clusterConfig *v1alpha1.Cluster, skipIpCheck bool, hardwareConfigFile string) *Factory {
 	f.WithProviderFactory(clusterConfig)
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -148,10 +304,11 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 		}
 
 		var err error
-		f.dependencies.Provider, err = f.providerFactory.BuildProvider(clusterConfigFile, clusterConfig, skipIpCheck, hardwareConfigFile)
+		f.dependencies.Provider, err = f.providerFactory.BuildProviderFromContent(clusterConfigContent, clusterConfig, skipIpCheck, hardwareConfigFile)
 		if err != nil {
 			return err
 		}
+		f.setProxyEnv()
 
 		return nil
 	})
@@ -191,14 +348,17 @@ func (f *Factory) WithProviderFactory(clusterConfig *v1alpha1.Cluster) *Factory
 }
 
 func (f *Factory) WithClusterAwsCli() *Factory {
-	f.WithExecutableBuilder()
-
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.ClusterAwsCli != nil {
 			return nil
 		}
 
-		f.dependencies.ClusterAwsCli = f.executableBuilder.BuildClusterAwsAdmExecutable()
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.ClusterAwsCli = b.BuildClusterAwsAdmExecutable()
 		return nil
 	})
 
@@ -206,8 +366,6 @@ func (f *Factory) WithClusterAwsCli() *Factory {
 }
 
 func (f *Factory) WithDocker() *Factory {
-	f.WithExecutableBuilder()
-
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.DockerClient != nil {
 			return nil
@@ -221,14 +379,17 @@ func (f *Factory) WithDocker() *Factory {
 }
 
 func (f *Factory) WithKubectl() *Factory {
-	f.WithExecutableBuilder()
-
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Kubectl != nil {
 			return nil
 		}
 
-		f.dependencies.Kubectl = f.executableBuilder.BuildKubectlExecutable()
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Kubectl = b.BuildKubectlExecutable()
 		return nil
 	})
 
@@ -236,14 +397,19 @@ func (f *Factory) WithKubectl() *Factory {
 }
 
 func (f *Factory) WithGovc() *Factory {
-	f.WithExecutableBuilder().WithWriter()
+	f.WithWriter()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Govc != nil {
 			return nil
 		}
 
-		f.dependencies.Govc = f.executableBuilder.BuildGovcExecutable(f.dependencies.Writer)
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Govc = b.BuildGovcExecutable(f.dependencies.Writer)
 		f.dependencies.closers = append(f.dependencies.closers, f.dependencies.Govc)
 
 		return nil
@@ -258,6 +424,11 @@ func (f *Factory) WithWriter() *Factory {
 			return nil
 		}
 
+		if f.inMemoryFiles {
+			f.dependencies.Writer = filewriter.NewInMemoryWriter(f.writerFolder)
+			return nil
+		}
+
 		var err error
 		f.dependencies.Writer, err = filewriter.NewWriter(f.writerFolder)
 		if err != nil {
@@ -270,15 +441,62 @@ func (f *Factory) WithWriter() *Factory {
 	return f
 }
 
+// WithArtifactStore configures the artifact store from the environment. When configured and the dependencies
+// have a writer, the content of the writer folder is uploaded to the store when the dependencies are closed
+func (f *Factory) WithArtifactStore() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.ArtifactStore != nil {
+			return nil
+		}
+
+		store, err := artifacts.NewStoreFromEnv()
+		if err != nil {
+			return err
+		}
+		if store == nil {
+			return nil
+		}
+
+		f.dependencies.ArtifactStore = store
+		prefix := path.Join(f.writerFolder, time.Now().UTC().Format("2006-01-02T15_04_05"))
+		f.dependencies.closers = append(f.dependencies.closers, &writerUploader{dependencies: &f.dependencies, store: store, prefix: prefix})
+
+		return nil
+	})
+
+	return f
+}
+
+// writerUploader uploads the writer folder to the artifact store when closed. The writer is looked up then,
+// since it can be built after the store
+type writerUploader struct {
+	dependencies *Dependencies
+	store        artifacts.Store
+	prefix       string
+}
+
+func (u *writerUploader) Close(ctx context.Context) error {
+	if u.dependencies.Writer == nil {
+		return nil
+	}
+	return artifacts.NewDirUploader(u.store, u.dependencies.Writer.Dir(), u.prefix).Close(ctx)
+}
+
 func (f *Factory) WithKind() *Factory {
-	f.WithExecutableBuilder().WithWriter()
+	f.WithWriter()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Kind != nil {
 			return nil
 		}
 
-		f.dependencies.Kind = f.executableBuilder.BuildKindExecutable(f.dependencies.Writer)
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Kind = b.BuildKindExecutable(f.dependencies.Writer)
+		f.dependencies.closers = append(f.dependencies.closers, f.dependencies.Kind)
 		return nil
 	})
 
@@ -286,14 +504,19 @@ func (f *Factory) WithKind() *Factory {
 }
 
 func (f *Factory) WithClusterctl() *Factory {
-	f.WithExecutableBuilder().WithWriter()
+	f.WithWriter()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Clusterctl != nil {
 			return nil
 		}
 
-		f.dependencies.Clusterctl = f.executableBuilder.BuildClusterCtlExecutable(f.dependencies.Writer)
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Clusterctl = b.BuildClusterCtlExecutable(f.dependencies.Writer)
 		return nil
 	})
 
@@ -301,14 +524,17 @@ func (f *Factory) WithClusterctl() *Factory {
 }
 
 func (f *Factory) WithFlux() *Factory {
-	f.WithExecutableBuilder()
-
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Flux != nil {
 			return nil
 		}
 
-		f.dependencies.Flux = f.executableBuilder.BuildFluxExecutable()
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Flux = b.BuildFluxExecutable()
 		return nil
 	})
 
@@ -316,14 +542,17 @@ func (f *Factory) WithFlux() *Factory {
 }
 
 func (f *Factory) WithTroubleshoot() *Factory {
-	f.WithExecutableBuilder()
-
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Troubleshoot != nil {
 			return nil
 		}
 
-		f.dependencies.Troubleshoot = f.executableBuilder.BuildTroubleshootExecutable()
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Troubleshoot = b.BuildTroubleshootExecutable()
 		return nil
 	})
 
@@ -331,14 +560,117 @@ func (f *Factory) WithTroubleshoot() *Factory {
 }
 
 func (f *Factory) WithHelm() *Factory {
-	f.WithExecutableBuilder()
-
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Helm != nil {
 			return nil
 		}
 
-		f.dependencies.Helm = f.executableBuilder.BuildHelmExecutable()
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Helm = b.BuildHelmExecutable()
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) WithVelero() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.Velero != nil {
+			return nil
+		}
+
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Velero = b.BuildVeleroExecutable()
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) WithIpmitool() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.Ipmitool != nil {
+			return nil
+		}
+
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.Ipmitool = b.BuildIpmitoolExecutable()
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) WithSSH() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.SSH != nil {
+			return nil
+		}
+
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.SSH = b.BuildSSHExecutable()
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) WithAwsCli() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.AwsCli != nil {
+			return nil
+		}
+
+		b, err := f.executables(ctx)
+		if err != nil {
+			return err
+		}
+
+		f.dependencies.AwsCli = b.BuildAwsCli()
+		return nil
+	})
+
+	return f
+}
+
+// WithSonobuoy always uses the local sonobuoy binary, it's not included in the tools image
+func (f *Factory) WithSonobuoy() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.Sonobuoy != nil {
+			return nil
+		}
+
+		f.dependencies.Sonobuoy = executables.BuildSonobuoyExecutable()
+		return nil
+	})
+
+	return f
+}
+
+// WithImageBuilder always uses the local image-builder binary, it needs the hypervisor tooling of the host
+func (f *Factory) WithImageBuilder() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.ImageBuilder != nil {
+			return nil
+		}
+
+		f.dependencies.ImageBuilder = executables.BuildImageBuilderExecutable()
 		return nil
 	})
 
@@ -347,12 +679,17 @@ func (f *Factory) WithHelm() *Factory {
 
 func (f *Factory) WithNetworking(clusterConfig *v1alpha1.Cluster) *Factory {
 	var networkingBuilder func() clustermanager.Networking
-	if clusterConfig.Spec.ClusterNetwork.CNI == v1alpha1.Kindnetd {
+	switch clusterConfig.Spec.ClusterNetwork.CNI {
+	case v1alpha1.Kindnetd:
 		f.WithKubectl()
 		networkingBuilder = func() clustermanager.Networking {
 			return kindnetd.NewKindnetd(f.dependencies.Kubectl)
 		}
-	} else {
+	case v1alpha1.Custom:
+		networkingBuilder = func() clustermanager.Networking {
+			return custom.NewCustom()
+		}
+	default:
 		f.WithKubectl().WithHelm()
 		networkingBuilder = func() clustermanager.Networking {
 			return cilium.NewCilium(f.dependencies.Kubectl, f.dependencies.Helm)
@@ -388,17 +725,23 @@ func (f *Factory) WithAwsIamAuth() *Factory {
 type bootstrapperClient struct {
 	*executables.Kind
 	*executables.Kubectl
+	*executables.Docker
+}
+
+// GetNodes returns the node containers of the kind cluster, both Kind and Kubectl have a GetNodes method
+func (b *bootstrapperClient) GetNodes(ctx context.Context, clusterName string) ([]string, error) {
+	return b.Kind.GetNodes(ctx, clusterName)
 }
 
 func (f *Factory) WithBootstrapper() *Factory {
-	f.WithKind().WithKubectl()
+	f.WithKind().WithKubectl().WithDocker()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Bootstrapper != nil {
 			return nil
 		}
 
-		f.dependencies.Bootstrapper = bootstrapper.New(&bootstrapperClient{f.dependencies.Kind, f.dependencies.Kubectl})
+		f.dependencies.Bootstrapper = bootstrapper.New(&bootstrapperClient{f.dependencies.Kind, f.dependencies.Kubectl, f.dependencies.DockerClient})
 		return nil
 	})
 
@@ -418,6 +761,18 @@ func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster) *Factory {
 			return nil
 		}
 
+		opts := []clustermanager.ClusterManagerOpt{
+			clustermanager.WithVIPMonitor(vipmonitor.New(f.dependencies.Kubectl, &networkutils.DefaultNetClient{})),
+			clustermanager.WithControllerMonitor(controllermonitor.New(f.dependencies.Kubectl)),
+		}
+		if len(f.policyBundles) > 0 {
+			engine, err := policy.Load(f.policyBundles...)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, clustermanager.WithManifestPolicy(engine))
+		}
+
 		f.dependencies.ClusterManager = clustermanager.New(
 			&clusterManagerClient{
 				f.dependencies.Clusterctl,
@@ -427,6 +782,7 @@ func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster) *Factory {
 			f.dependencies.Writer,
 			f.dependencies.DignosticCollectorFactory,
 			f.dependencies.AwsIamAuth,
+			opts...,
 		)
 		return nil
 	})
@@ -3,13 +3,17 @@ package dependencies_test
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/artifacts"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 )
 
 type factoryTest struct {
@@ -46,6 +50,24 @@ func TestFactoryBuildWithProvider(t *testing.T) {
 	tt.Expect(deps.DockerClient).To(BeNil(), "it only builds deps for vsphere")
 }
 
+func TestFactoryBuildWithProviderFromContentInMemory(t *testing.T) {
+	tt := newTest(t)
+	content, err := os.ReadFile(tt.clusterConfigFile)
+	tt.Expect(err).To(BeNil())
+	writerFolder := filepath.Join(t.TempDir(), "cluster")
+
+	deps, err := dependencies.NewFactory(dependencies.UseInMemoryFiles()).
+		WithWriterFolder(writerFolder).
+		WithProviderFromContent(content, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Provider).NotTo(BeNil())
+	tt.Expect(deps.Writer).To(BeAssignableToTypeOf(&filewriter.InMemoryWriter{}))
+	_, err = os.Stat(writerFolder)
+	tt.Expect(os.IsNotExist(err)).To(BeTrue(), "in memory files should not create the writer folder")
+}
+
 func TestFactoryBuildWithClusterManager(t *testing.T) {
 	tt := newTest(t)
 	deps, err := dependencies.NewFactory().
@@ -56,6 +78,27 @@ func TestFactoryBuildWithClusterManager(t *testing.T) {
 	tt.Expect(deps.ClusterManager).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithClusterManagerPolicyBundles(t *testing.T) {
+	tt := newTest(t)
+	deps, err := dependencies.NewFactory().
+		WithPolicyBundles("testdata/policies.yaml").
+		WithClusterManager(tt.clusterSpec.Cluster).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.ClusterManager).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithClusterManagerInvalidPolicyBundle(t *testing.T) {
+	tt := newTest(t)
+	_, err := dependencies.NewFactory().
+		WithPolicyBundles("testdata/missing_policies.yaml").
+		WithClusterManager(tt.clusterSpec.Cluster).
+		Build(context.Background())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("failed reading policy bundle")))
+}
+
 func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 	tt := newTest(t)
 	deps, err := dependencies.NewFactory().
@@ -82,3 +125,102 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 	tt.Expect(deps.Troubleshoot).NotTo(BeNil())
 	tt.Expect(deps.CAPIManager).NotTo(BeNil())
 }
+
+func TestFactoryBuildWithArtifactStore(t *testing.T) {
+	tt := newTest(t)
+	storeDir := t.TempDir()
+	os.Setenv(artifacts.StoreEnvVar, storeDir)
+	defer os.Unsetenv(artifacts.StoreEnvVar)
+
+	writerDir := t.TempDir()
+	deps, err := dependencies.NewFactory().
+		WithWriterFolder(writerDir).
+		WithArtifactStore().
+		WithWriter().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.ArtifactStore).NotTo(BeNil())
+
+	_, err = deps.Writer.Write("cluster.yaml", []byte("content"), filewriter.PersistentFile)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Close(context.Background())).To(Succeed())
+
+	uploaded, err := filepath.Glob(filepath.Join(storeDir, writerDir, "*", "cluster.yaml"))
+	tt.Expect(err).To(BeNil())
+	tt.Expect(uploaded).To(HaveLen(1))
+}
+
+func TestFactoryBuildWithArtifactStoreNotConfigured(t *testing.T) {
+	tt := newTest(t)
+	os.Unsetenv(artifacts.StoreEnvVar)
+	deps, err := dependencies.NewFactory().
+		WithWriterFolder(t.TempDir()).
+		WithArtifactStore().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.ArtifactStore).To(BeNil())
+}
+
+func TestFactoryBuildWithArtifactStoreWithoutWriter(t *testing.T) {
+	tt := newTest(t)
+	os.Setenv(artifacts.StoreEnvVar, t.TempDir())
+	defer os.Unsetenv(artifacts.StoreEnvVar)
+
+	deps, err := dependencies.NewFactory().
+		WithWriterFolder(t.TempDir()).
+		WithArtifactStore().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.ArtifactStore).NotTo(BeNil())
+	tt.Expect(deps.Writer).To(BeNil())
+	tt.Expect(deps.Close(context.Background())).To(Succeed())
+}
+
+func TestFactoryBuildWithUseDependencies(t *testing.T) {
+	tt := newTest(t)
+	kubectl := &executables.Kubectl{}
+	deps, err := dependencies.NewFactory(dependencies.UseDependencies(dependencies.Dependencies{Kubectl: kubectl})).
+		WithKubectl().
+		WithCAPIManager().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Kubectl).To(BeIdenticalTo(kubectl))
+	tt.Expect(deps.Clusterctl).NotTo(BeNil())
+	tt.Expect(deps.CAPIManager).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithLocalExecutables(t *testing.T) {
+	tt := newTest(t)
+	deps, err := dependencies.NewFactory(dependencies.UseLocalExecutables()).
+		WithKubectl().
+		WithAwsCli().
+		WithSonobuoy().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Kubectl).NotTo(BeNil())
+	tt.Expect(deps.AwsCli).NotTo(BeNil())
+	tt.Expect(deps.Sonobuoy).NotTo(BeNil())
+	tt.Expect(deps.Close(context.Background())).To(Succeed())
+}
+
+func TestFactoryBuildErrorDiscardsDependencies(t *testing.T) {
+	tt := newTest(t)
+	os.Setenv(artifacts.StoreEnvVar, "s3://")
+	defer os.Unsetenv(artifacts.StoreEnvVar)
+
+	f := dependencies.NewFactory().WithWriterFolder(t.TempDir())
+	_, err := f.WithKubectl().WithArtifactStore().Build(context.Background())
+	tt.Expect(err).To(MatchError(ContainSubstring("missing s3 bucket")))
+
+	os.Unsetenv(artifacts.StoreEnvVar)
+	deps, err := f.WithDocker().Build(context.Background())
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.DockerClient).NotTo(BeNil())
+	tt.Expect(deps.Kubectl).To(BeNil(), "it discards the dependencies of the failed build")
+	tt.Expect(deps.Writer).To(BeNil(), "it discards the dependencies of the failed build")
+}
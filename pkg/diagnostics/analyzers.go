@@ -3,13 +3,20 @@ package diagnostics
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
 )
 
 const (
 	logAnalysisAnalyzerPrefix = "log analysis:"
+	// probableCauseAnalyzerPrefix starts the check names of the analyzers that match known failure signatures,
+	// their failed outcomes are reported as probable causes when an operation fails
+	probableCauseAnalyzerPrefix = "probable cause:"
+	// clusterResourcesCollectorName is the folder of the cluster resources troubleshoot collects by default
+	clusterResourcesCollectorName = "cluster-resources"
 )
 
 type analyzerFactory struct{}
@@ -20,7 +27,8 @@ func NewAnalyzerFactory() *analyzerFactory {
 
 func (a *analyzerFactory) DefaultAnalyzers() []*Analyze {
 	var analyzers []*Analyze
-	return append(analyzers, a.defaultDeploymentAnalyzers()...)
+	analyzers = append(analyzers, a.defaultDeploymentAnalyzers()...)
+	return append(analyzers, a.eventFailureSignatureAnalyzers()...)
 }
 
 func (a *analyzerFactory) defaultDeploymentAnalyzers() []*Analyze {
@@ -175,6 +183,8 @@ func (a *analyzerFactory) EksaLogTextAnalyzers(collectors []*Collect) []*Analyze
 func (a *analyzerFactory) namespaceLogTextAnalyzersMap() map[string][]*Analyze {
 	return map[string][]*Analyze{
 		constants.CapiKubeadmControlPlaneSystemNamespace: a.capiKubeadmControlPlaneSystemLogAnalyzers(),
+		constants.CapvSystemNamespace:                    a.capvSystemLogAnalyzers(),
+		constants.KubeSystemNamespace:                    a.kubeSystemLogAnalyzers(),
 	}
 }
 
@@ -210,6 +220,101 @@ func (a *analyzerFactory) capiKubeadmControlPlaneSystemLogAnalyzers() []*Analyze
 	}
 }
 
+// failureSignature is a pattern of a known failure mode in the collected files, with the cause it points to
+type failureSignature struct {
+	name          string
+	collectorName string
+	fileName      string
+	regex         string
+	cause         string
+}
+
+func (a *analyzerFactory) capvSystemLogAnalyzers() []*Analyze {
+	capvManagerLogFile := path.Join("capv-controller-manager-*", "manager.log")
+	return a.failureSignatureAnalyzers([]failureSignature{
+		{
+			name:          "DHCP exhaustion",
+			collectorName: constants.CapvSystemNamespace,
+			fileName:      capvManagerLogFile,
+			regex:         `(?i)(WaitingForIPAllocation|waiting for (an )?ip address|no ip addresses? (assigned|available))`,
+			cause:         "VMs are not getting an IP address, the DHCP server of the VM network may be out of leases or unreachable",
+		},
+		{
+			name:          "template hardware version",
+			collectorName: constants.CapvSystemNamespace,
+			fileName:      capvManagerLogFile,
+			regex:         `(?i)(hardware version|vmx-[0-9]+).*(not supported|unsupported|too old|minimum)`,
+			cause:         "The VM template hardware version is not supported, upgrade the hardware version of the template",
+		},
+	})
+}
+
+func (a *analyzerFactory) kubeSystemLogAnalyzers() []*Analyze {
+	return a.failureSignatureAnalyzers([]failureSignature{
+		{
+			name:          "control plane VIP conflict",
+			collectorName: constants.KubeSystemNamespace,
+			fileName:      path.Join("kube-vip-*", "kube-vip.log"),
+			regex:         `(?i)(ip conflict|duplicate (ip|address)|address already in use)`,
+			cause:         "The control plane VIP is used by another host, set controlPlaneConfiguration.endpoint.host to a free IP outside of the DHCP range",
+		},
+	})
+}
+
+func (a *analyzerFactory) eventFailureSignatureAnalyzers() []*Analyze {
+	return a.failureSignatureAnalyzers([]failureSignature{
+		{
+			name:          "registry authentication failure",
+			collectorName: clusterResourcesCollectorName,
+			fileName:      path.Join("events", "*.json"),
+			regex:         `(?i)failed to pull image.*(401 unauthorized|403 forbidden|authentication required|no basic auth credentials)`,
+			cause:         "The registry rejects the image pulls, check the registry credentials and the registry mirror configuration",
+		},
+	})
+}
+
+func (a *analyzerFactory) failureSignatureAnalyzers(signatures []failureSignature) []*Analyze {
+	analyzers := make([]*Analyze, 0, len(signatures))
+	for _, s := range signatures {
+		analyzers = append(analyzers, &Analyze{
+			TextAnalyze: &textAnalyze{
+				analyzeMeta: analyzeMeta{
+					CheckName: fmt.Sprintf("%s %s. File: %s", probableCauseAnalyzerPrefix, s.name, path.Join(s.collectorName, s.fileName)),
+				},
+				CollectorName: s.collectorName,
+				FileName:      s.fileName,
+				RegexPattern:  s.regex,
+				Outcomes: []*outcome{
+					{
+						Fail: &singleOutcome{
+							When:    "true",
+							Message: s.cause,
+						},
+					},
+					{
+						Pass: &singleOutcome{
+							When:    "false",
+							Message: fmt.Sprintf("No %s found", s.name),
+						},
+					},
+				},
+			},
+		})
+	}
+	return analyzers
+}
+
+// ProbableCauses returns the messages of the failed analyzers that match known failure signatures
+func ProbableCauses(analysis []*executables.SupportBundleAnalysis) []string {
+	var causes []string
+	for _, a := range analysis {
+		if a.IsFail && strings.HasPrefix(a.Title, probableCauseAnalyzerPrefix) {
+			causes = append(causes, a.Message)
+		}
+	}
+	return causes
+}
+
 type eksaDeployment struct {
 	Name             string
 	Namespace        string
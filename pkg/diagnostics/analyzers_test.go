@@ -0,0 +1,51 @@
+package diagnostics_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+func TestEksaLogTextAnalyzersFailureSignatures(t *testing.T) {
+	g := NewWithT(t)
+	collectors := diagnostics.NewDefaultCollectorFactory().DefaultCollectors()
+	collectors = append(collectors, diagnostics.NewDefaultCollectorFactory().DataCenterConfigCollectors(v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind})...)
+
+	var checks []string
+	for _, a := range diagnostics.NewAnalyzerFactory().EksaLogTextAnalyzers(collectors) {
+		g.Expect(a.TextAnalyze).NotTo(BeNil())
+		checks = append(checks, a.TextAnalyze.CheckName)
+	}
+	g.Expect(strings.Join(checks, "\n")).To(And(
+		ContainSubstring("probable cause: DHCP exhaustion. File: capv-system/capv-controller-manager-*/manager.log"),
+		ContainSubstring("probable cause: template hardware version"),
+		ContainSubstring("probable cause: control plane VIP conflict. File: kube-system/kube-vip-*/kube-vip.log"),
+	))
+}
+
+func TestDefaultAnalyzersRegistryAuthFailure(t *testing.T) {
+	g := NewWithT(t)
+	var found bool
+	for _, a := range diagnostics.NewAnalyzerFactory().DefaultAnalyzers() {
+		if a.TextAnalyze != nil && strings.HasPrefix(a.TextAnalyze.CheckName, "probable cause: registry authentication failure") {
+			found = true
+			g.Expect(a.TextAnalyze.CollectorName).To(Equal("cluster-resources"))
+		}
+	}
+	g.Expect(found).To(BeTrue())
+}
+
+func TestProbableCauses(t *testing.T) {
+	g := NewWithT(t)
+	analysis := []*executables.SupportBundleAnalysis{
+		{Title: "probable cause: DHCP exhaustion. File: capv-system/capv-controller-manager-*/manager.log", IsFail: true, Message: "VMs are not getting an IP address"},
+		{Title: "probable cause: control plane VIP conflict. File: kube-system/kube-vip-*/kube-vip.log", IsPass: true, Message: "No control plane VIP conflict found"},
+		{Title: "coredns", IsFail: true, Message: "coredns is not ready."},
+	}
+	g.Expect(diagnostics.ProbableCauses(analysis)).To(Equal([]string{"VMs are not getting an IP address"}))
+}
@@ -220,6 +220,12 @@ func (c *collectorFactory) managementClusterLogCollectors() []*Collect {
 				Name:      logpath(constants.EtcdAdmControllerSystemNamespace),
 			},
 		},
+		{
+			Logs: &logs{
+				Namespace: constants.FluxSystemNamespace,
+				Name:      logpath(constants.FluxSystemNamespace),
+			},
+		},
 	}
 }
 
@@ -227,6 +233,7 @@ func (c *collectorFactory) managementClusterCrdCollectors() []*Collect {
 	mgmtCrds := []string{
 		"clusters.anywhere.eks.amazonaws.com",
 		"bundles.anywhere.eks.amazonaws.com",
+		"releases.anywhere.eks.amazonaws.com",
 		"clusters.cluster.x-k8s.io",
 		"machinedeployments.cluster.x-k8s.io",
 		"machines.cluster.x-k8s.io",
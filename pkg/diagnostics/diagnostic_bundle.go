@@ -44,6 +44,7 @@ type EksaDiagnosticBundle struct {
 	retrier          *retrier.Retrier
 	writer           filewriter.FileWriter
 	analysis         []*executables.SupportBundleAnalysis
+	archivePath      string
 }
 
 func newDiagnosticBundleManagementCluster(af AnalyzerFactory, cf CollectorFactory, client BundleClient,
@@ -161,6 +162,7 @@ func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeV
 	}
 
 	logger.Info("Support bundle archive created", "path", archivePath)
+	e.archivePath = archivePath
 
 	logger.Info("Analyzing support bundle", "bundle", e.bundlePath, "archive", archivePath)
 	analysis, err := e.client.Analyze(ctx, e.bundlePath, archivePath)
@@ -168,6 +170,9 @@ func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeV
 		return fmt.Errorf("error when analyzing bundle: %v", err)
 	}
 	e.analysis = analysis
+	for _, cause := range ProbableCauses(analysis) {
+		logger.Info("Probable cause found in the support bundle", "cause", cause)
+	}
 
 	analysisPath, err := e.WriteAnalysisToFile()
 	if err != nil {
@@ -179,6 +184,11 @@ func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeV
 	return nil
 }
 
+// ArchivePath returns the path of the support bundle archive, it's empty until the bundle is collected
+func (e *EksaDiagnosticBundle) ArchivePath() string {
+	return e.archivePath
+}
+
 func (e *EksaDiagnosticBundle) PrintBundleConfig() error {
 	bundleYaml, err := yaml.Marshal(e.bundle)
 	if err != nil {
@@ -300,7 +310,7 @@ func (e *EksaDiagnosticBundle) createDiagnosticNamespaceAndRoles(ctx context.Con
 	}
 
 	logger.V(1).Info("creating temporary namespace for diagnostic collector", "namespace", constants.EksaDiagnosticsNamespace)
-	err := e.retrier.Retry(
+	err := e.retrier.RetryWithContext(ctx,
 		func() error {
 			return e.kubectl.CreateNamespace(ctx, e.kubeconfig, constants.EksaDiagnosticsNamespace)
 		},
@@ -310,7 +320,7 @@ func (e *EksaDiagnosticBundle) createDiagnosticNamespaceAndRoles(ctx context.Con
 	}
 
 	logger.V(1).Info("creating temporary ClusterRole and RoleBinding for diagnostic collector")
-	err = e.retrier.Retry(
+	err = e.retrier.RetryWithContext(ctx,
 		func() error {
 			return e.kubectl.ApplyKubeSpecFromBytes(ctx, targetCluster, diagnosticCollectorRbac)
 		},
@@ -326,7 +336,7 @@ func (e *EksaDiagnosticBundle) deleteDiagnosticNamespaceAndRoles(ctx context.Con
 	}
 
 	logger.V(1).Info("cleaning up temporary roles for diagnostic collectors")
-	err := e.retrier.Retry(
+	err := e.retrier.RetryWithContext(ctx,
 		func() error {
 			return e.kubectl.DeleteKubeSpecFromBytes(ctx, targetCluster, diagnosticCollectorRbac)
 		},
@@ -336,7 +346,7 @@ func (e *EksaDiagnosticBundle) deleteDiagnosticNamespaceAndRoles(ctx context.Con
 	}
 
 	logger.V(1).Info("cleaning up temporary namespace  for diagnostic collectors", "namespace", constants.EksaDiagnosticsNamespace)
-	err = e.retrier.Retry(
+	err = e.retrier.RetryWithContext(ctx,
 		func() error {
 			return e.kubectl.DeleteNamespace(ctx, e.kubeconfig, constants.EksaDiagnosticsNamespace)
 		},
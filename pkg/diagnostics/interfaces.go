@@ -29,6 +29,7 @@ type DiagnosticBundle interface {
 	PrintAnalysis() error
 	WriteAnalysisToFile() (path string, err error)
 	CollectAndAnalyze(ctx context.Context, sinceTimeValue *time.Time) error
+	ArchivePath() string
 	WithDefaultAnalyzers() *EksaDiagnosticBundle
 	WithDefaultCollectors() *EksaDiagnosticBundle
 	WithDatacenterConfig(config v1alpha1.Ref) *EksaDiagnosticBundle
@@ -55,3 +56,8 @@ type CollectorFactory interface {
 	EksaHostCollectors(configs []providers.MachineConfig) []*Collect
 	DataCenterConfigCollectors(datacenter v1alpha1.Ref) []*Collect
 }
+
+// NodeClient runs commands in the cluster nodes over SSH, implemented by executables.SSH
+type NodeClient interface {
+	RunCommand(ctx context.Context, host executables.SSHHost, bastion *executables.SSHHost, command string) (string, error)
+}
@@ -189,6 +189,20 @@ func (m *MockDiagnosticBundle) EXPECT() *MockDiagnosticBundleMockRecorder {
 	return m.recorder
 }
 
+// ArchivePath mocks base method.
+func (m *MockDiagnosticBundle) ArchivePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ArchivePath indicates an expected call of ArchivePath.
+func (mr *MockDiagnosticBundleMockRecorder) ArchivePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePath", reflect.TypeOf((*MockDiagnosticBundle)(nil).ArchivePath))
+}
+
 // CollectAndAnalyze mocks base method.
 func (m *MockDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeValue *time.Time) error {
 	m.ctrl.T.Helper()
@@ -571,3 +585,41 @@ func (mr *MockCollectorFactoryMockRecorder) ManagementClusterCollectors() *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagementClusterCollectors", reflect.TypeOf((*MockCollectorFactory)(nil).ManagementClusterCollectors))
 }
+
+// MockNodeClient is a mock of NodeClient interface.
+type MockNodeClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeClientMockRecorder
+}
+
+// MockNodeClientMockRecorder is the mock recorder for MockNodeClient.
+type MockNodeClientMockRecorder struct {
+	mock *MockNodeClient
+}
+
+// NewMockNodeClient creates a new mock instance.
+func NewMockNodeClient(ctrl *gomock.Controller) *MockNodeClient {
+	mock := &MockNodeClient{ctrl: ctrl}
+	mock.recorder = &MockNodeClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeClient) EXPECT() *MockNodeClientMockRecorder {
+	return m.recorder
+}
+
+// RunCommand mocks base method.
+func (m *MockNodeClient) RunCommand(ctx context.Context, host executables.SSHHost, bastion *executables.SSHHost, command string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunCommand", ctx, host, bastion, command)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCommand indicates an expected call of RunCommand.
+func (mr *MockNodeClientMockRecorder) RunCommand(ctx, host, bastion, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommand", reflect.TypeOf((*MockNodeClient)(nil).RunCommand), ctx, host, bastion, command)
+}
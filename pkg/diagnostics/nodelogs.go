@@ -0,0 +1,66 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const nodeLogsFolder = "node-logs"
+
+// nodeLogsUnits are the systemd services whose journal is collected from the nodes
+var nodeLogsUnits = []string{"kubelet", "containerd"}
+
+// NodeLogsCollector collects the logs of the node services over SSH, for the nodes that can't be reached
+// through the API server, like the ones that never joined the cluster
+type NodeLogsCollector struct {
+	client  NodeClient
+	writer  filewriter.FileWriter
+	bastion *executables.SSHHost
+}
+
+// NewNodeLogsCollector returns a collector that connects to the nodes through bastion when it's not nil
+func NewNodeLogsCollector(client NodeClient, writer filewriter.FileWriter, bastion *executables.SSHHost) *NodeLogsCollector {
+	return &NodeLogsCollector{
+		client:  client,
+		writer:  writer,
+		bastion: bastion,
+	}
+}
+
+// Collect writes the journal of the node services to node-logs/<address>/<service>.log in the writer folder,
+// from sinceTime when it's not nil. It returns the folder with the node logs
+func (c *NodeLogsCollector) Collect(ctx context.Context, node executables.SSHHost, sinceTime *time.Time) (string, error) {
+	writer, err := c.writer.WithDir(filepath.Join(nodeLogsFolder, strings.ReplaceAll(node.Address, ":", "_")))
+	if err != nil {
+		return "", fmt.Errorf("failed creating node logs folder for %s: %v", node.Address, err)
+	}
+
+	for _, unit := range nodeLogsUnits {
+		logger.V(4).Info("Collecting node logs", "node", node.Address, "service", unit)
+		out, err := c.client.RunCommand(ctx, node, c.bastion, journalCommand(unit, sinceTime))
+		if err != nil {
+			return "", fmt.Errorf("failed collecting %s logs: %v", unit, err)
+		}
+		if _, err = writer.Write(unit+".log", []byte(out), filewriter.PersistentFile); err != nil {
+			return "", err
+		}
+	}
+
+	return writer.Dir(), nil
+}
+
+func journalCommand(unit string, sinceTime *time.Time) string {
+	command := fmt.Sprintf("sudo journalctl --no-pager --unit %s", unit)
+	if sinceTime != nil {
+		// journalctl takes the unix time with @, so it doesn't depend on the node time zone
+		command = fmt.Sprintf("%s --since @%d", command, sinceTime.Unix())
+	}
+	return command
+}
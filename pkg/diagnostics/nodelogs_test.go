@@ -0,0 +1,51 @@
+package diagnostics_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	supportMocks "github.com/aws/eks-anywhere/pkg/diagnostics/interfaces/mocks"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+func TestNodeLogsCollectorCollect(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := supportMocks.NewMockNodeClient(gomock.NewController(t))
+	dir, writer := test.NewWriter(t)
+	node := executables.SSHHost{Address: "10.0.1.10:2222", User: "ec2-user", PrivateKeyPath: "/home/user/.ssh/node"}
+	bastion := &executables.SSHHost{Address: "bastion.example.com", User: "jump", PrivateKeyPath: "/home/user/.ssh/bastion"}
+	since := time.Unix(1640995200, 0)
+
+	client.EXPECT().RunCommand(ctx, node, bastion, "sudo journalctl --no-pager --unit kubelet --since @1640995200").Return("kubelet started\n", nil)
+	client.EXPECT().RunCommand(ctx, node, bastion, "sudo journalctl --no-pager --unit containerd --since @1640995200").Return("containerd started\n", nil)
+
+	collector := diagnostics.NewNodeLogsCollector(client, writer, bastion)
+	folder, err := collector.Collect(ctx, node, &since)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(folder).To(Equal(filepath.Join(dir, "node-logs", "10.0.1.10_2222")))
+	g.Expect(test.ReadFile(t, filepath.Join(folder, "kubelet.log"))).To(Equal("kubelet started\n"))
+	g.Expect(test.ReadFile(t, filepath.Join(folder, "containerd.log"))).To(Equal("containerd started\n"))
+}
+
+func TestNodeLogsCollectorCollectError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := supportMocks.NewMockNodeClient(gomock.NewController(t))
+	_, writer := test.NewWriter(t)
+	node := executables.SSHHost{Address: "10.0.1.10", User: "ec2-user", PrivateKeyPath: "/home/user/.ssh/node"}
+
+	client.EXPECT().RunCommand(ctx, node, nil, "sudo journalctl --no-pager --unit kubelet").Return("", errors.New("connection timed out"))
+
+	collector := diagnostics.NewNodeLogsCollector(client, writer, nil)
+	_, err := collector.Collect(ctx, node, nil)
+	g.Expect(err).To(MatchError("failed collecting kubelet logs: connection timed out"))
+}
@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Client reads the live state of the workloads in a namespace
+type Client interface {
+	Deployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error)
+	DaemonSets(ctx context.Context, namespace string) ([]appsv1.DaemonSet, error)
+	Pods(ctx context.Context, namespace string) ([]corev1.Pod, error)
+}
+
+type KubectlClient interface {
+	GetDeployments(ctx context.Context, opts ...executables.KubectlOpt) ([]appsv1.Deployment, error)
+	GetDaemonSets(ctx context.Context, opts ...executables.KubectlOpt) ([]appsv1.DaemonSet, error)
+	GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Pod, error)
+}
+
+type kubectlReader struct {
+	kubectl KubectlClient
+	cluster *types.Cluster
+}
+
+// NewKubectlClient returns a Client that reads the cluster workloads with kubectl, for the CLI
+func NewKubectlClient(kubectl KubectlClient, cluster *types.Cluster) Client {
+	return &kubectlReader{kubectl: kubectl, cluster: cluster}
+}
+
+func (k *kubectlReader) Deployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
+	return k.kubectl.GetDeployments(ctx, executables.WithCluster(k.cluster), executables.WithNamespace(namespace))
+}
+
+func (k *kubectlReader) DaemonSets(ctx context.Context, namespace string) ([]appsv1.DaemonSet, error) {
+	return k.kubectl.GetDaemonSets(ctx, executables.WithCluster(k.cluster), executables.WithNamespace(namespace))
+}
+
+func (k *kubectlReader) Pods(ctx context.Context, namespace string) ([]corev1.Pod, error) {
+	return k.kubectl.GetPods(ctx, executables.WithCluster(k.cluster), executables.WithNamespace(namespace))
+}
+
+type runtimeReader struct {
+	reader client.Reader
+}
+
+// NewRuntimeClient returns a Client that reads the workloads with a controller-runtime reader, for the controller
+func NewRuntimeClient(reader client.Reader) Client {
+	return &runtimeReader{reader: reader}
+}
+
+func (r *runtimeReader) Deployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
+	list := &appsv1.DeploymentList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (r *runtimeReader) DaemonSets(ctx context.Context, namespace string) ([]appsv1.DaemonSet, error) {
+	list := &appsv1.DaemonSetList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (r *runtimeReader) Pods(ctx context.Context, namespace string) ([]corev1.Pod, error) {
+	list := &corev1.PodList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
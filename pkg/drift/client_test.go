@@ -0,0 +1,76 @@
+package drift_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/drift"
+	"github.com/aws/eks-anywhere/pkg/drift/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestKubectlClient(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	cluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	c := drift.NewKubectlClient(kubectl, cluster)
+
+	kubectl.EXPECT().GetDeployments(ctx, gomock.Any(), gomock.Any()).Return([]appsv1.Deployment{deployment("capi-controller-manager", "manager", "capi")}, nil)
+	kubectl.EXPECT().GetDaemonSets(ctx, gomock.Any(), gomock.Any()).Return([]appsv1.DaemonSet{daemonSet("cilium", "cilium-agent", "cilium")}, nil)
+	kubectl.EXPECT().GetPods(ctx, gomock.Any(), gomock.Any()).Return([]corev1.Pod{pod("kube-vip-cp-1", "kube-vip", "kube-vip")}, nil)
+
+	deployments, err := c.Deployments(ctx, "capi-system")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(deployments).To(HaveLen(1))
+	daemonSets, err := c.DaemonSets(ctx, "kube-system")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(daemonSets).To(HaveLen(1))
+	pods, err := c.Pods(ctx, "kube-system")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pods).To(HaveLen(1))
+}
+
+func TestRuntimeClient(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+	capi := deployment("capi-controller-manager", "manager", "capi")
+	capi.Namespace = "capi-system"
+	coredns := deployment("coredns", "coredns", "coredns")
+	coredns.Namespace = "kube-system"
+	cilium := daemonSet("cilium", "cilium-agent", "cilium")
+	cilium.Namespace = "kube-system"
+	kubeVip := staticPod("kube-vip-cp-1", "kube-vip", "kube-vip")
+	kubeVip.Namespace = "kube-system"
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"}}
+
+	c := drift.NewRuntimeClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(&capi, &coredns, &cilium, &kubeVip, other).Build())
+
+	deployments, err := c.Deployments(ctx, "capi-system")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(deployments).To(HaveLen(1))
+	g.Expect(deployments[0].Name).To(Equal("capi-controller-manager"))
+
+	daemonSets, err := c.DaemonSets(ctx, "kube-system")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(daemonSets).To(HaveLen(1))
+	g.Expect(daemonSets[0].Name).To(Equal("cilium"))
+
+	pods, err := c.Pods(ctx, "kube-system")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pods).To(HaveLen(1))
+	g.Expect(pods[0].Name).To(Equal("kube-vip-cp-1"))
+}
@@ -0,0 +1,268 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	ComponentCilium              = "cilium"
+	ComponentClusterAPI          = "cluster-api"
+	ComponentKubeadmBootstrap    = "kubeadm-bootstrap"
+	ComponentKubeadmControlPlane = "kubeadm-control-plane"
+	ComponentVSphereProvider     = "cluster-api-provider-vsphere"
+	ComponentDockerProvider      = "cluster-api-provider-docker"
+	ComponentKubeVip             = "kube-vip"
+	ComponentEksaController      = "eksa-controller"
+)
+
+const (
+	KindDeployment = "Deployment"
+	KindDaemonSet  = "DaemonSet"
+	KindStaticPod  = "StaticPod"
+)
+
+const managerContainer = "manager"
+
+// Workload is a container of an EKS Anywhere managed component and the image it's expected to run
+type Workload struct {
+	Component string
+	Kind      string
+	Namespace string
+	Name      string
+	Container string
+	Image     string
+}
+
+func (w Workload) String() string {
+	return fmt.Sprintf("%s %s/%s", w.Kind, w.Namespace, w.Name)
+}
+
+// Drift is a difference between the live state of a workload and the one expected from the bundle.
+// Actual is empty when the workload or its container is missing
+type Drift struct {
+	Component string `json:"component"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Container string `json:"container"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual"`
+}
+
+func (d Drift) Missing() bool {
+	return d.Actual == ""
+}
+
+func (d Drift) String() string {
+	if d.Missing() {
+		return fmt.Sprintf("%s: %s %s/%s container %s is missing", d.Component, d.Kind, d.Namespace, d.Name, d.Container)
+	}
+	return fmt.Sprintf("%s: %s %s/%s container %s runs %s instead of %s", d.Component, d.Kind, d.Namespace, d.Name, d.Container, d.Actual, d.Expected)
+}
+
+type Report struct {
+	Drifts []Drift `json:"drifts"`
+}
+
+func (r *Report) Drifted() bool {
+	return len(r.Drifts) > 0
+}
+
+// Components returns the names of the components with drifts, sorted
+func (r *Report) Components() []string {
+	set := map[string]struct{}{}
+	for _, d := range r.Drifts {
+		set[d.Component] = struct{}{}
+	}
+	components := make([]string, 0, len(set))
+	for c := range set {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+	return components
+}
+
+type Detector struct {
+	client Client
+}
+
+func NewDetector(client Client) *Detector {
+	return &Detector{client: client}
+}
+
+// Detect compares the workloads of the components EKS Anywhere installed in the cluster with the images
+// of the cluster bundle and reports the ones missing or modified
+func (d *Detector) Detect(ctx context.Context, clusterSpec *cluster.Spec) (*Report, error) {
+	report := &Report{Drifts: []Drift{}}
+	for _, w := range ExpectedWorkloads(clusterSpec) {
+		drifts, err := d.detectWorkload(ctx, w)
+		if err != nil {
+			return nil, fmt.Errorf("error detecting drift for %s: %v", w, err)
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+
+	return report, nil
+}
+
+func (d *Detector) detectWorkload(ctx context.Context, w Workload) ([]Drift, error) {
+	switch w.Kind {
+	case KindDeployment:
+		deployments, err := d.client.Deployments(ctx, w.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, deployment := range deployments {
+			if deployment.Name == w.Name {
+				return compareContainers(w, deployment.Name, deployment.Spec.Template.Spec.Containers), nil
+			}
+		}
+	case KindDaemonSet:
+		daemonSets, err := d.client.DaemonSets(ctx, w.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, daemonSet := range daemonSets {
+			if daemonSet.Name == w.Name {
+				return compareContainers(w, daemonSet.Name, daemonSet.Spec.Template.Spec.Containers), nil
+			}
+		}
+	case KindStaticPod:
+		pods, err := d.client.Pods(ctx, w.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		drifts := []Drift{}
+		found := false
+		// Static pods are mirrored in the API server with the node name as suffix, one per control plane node
+		for _, pod := range pods {
+			if strings.HasPrefix(pod.Name, w.Name+"-") && isMirrorPod(pod) {
+				found = true
+				drifts = append(drifts, compareContainers(w, pod.Name, pod.Spec.Containers)...)
+			}
+		}
+		if found {
+			return drifts, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s", w.Kind)
+	}
+
+	return []Drift{newDrift(w, w.Name, "")}, nil
+}
+
+func compareContainers(w Workload, name string, containers []corev1.Container) []Drift {
+	for _, c := range containers {
+		if c.Name != w.Container {
+			continue
+		}
+		if c.Image != w.Image {
+			return []Drift{newDrift(w, name, c.Image)}
+		}
+		return nil
+	}
+
+	return []Drift{newDrift(w, name, "")}
+}
+
+func newDrift(w Workload, name, actual string) Drift {
+	return Drift{
+		Component: w.Component,
+		Kind:      w.Kind,
+		Namespace: w.Namespace,
+		Name:      name,
+		Container: w.Container,
+		Expected:  w.Image,
+		Actual:    actual,
+	}
+}
+
+func isMirrorPod(pod corev1.Pod) bool {
+	_, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]
+	return ok
+}
+
+// ExpectedWorkloads returns the workloads EKS Anywhere installs in the cluster for its spec. The cluster API
+// and EKS Anywhere controllers only run in management clusters
+func ExpectedWorkloads(clusterSpec *cluster.Spec) []Workload {
+	bundle := clusterSpec.VersionsBundle
+	workloads := []Workload{}
+
+	if clusterSpec.Cluster.Spec.ClusterNetwork.CNI == v1alpha1.Cilium {
+		workloads = append(workloads,
+			Workload{
+				Component: ComponentCilium,
+				Kind:      KindDaemonSet,
+				Namespace: constants.KubeSystemNamespace,
+				Name:      "cilium",
+				Container: "cilium-agent",
+				Image:     bundle.Cilium.Cilium.VersionedImage(),
+			},
+			Workload{
+				Component: ComponentCilium,
+				Kind:      KindDeployment,
+				Namespace: constants.KubeSystemNamespace,
+				Name:      "cilium-operator",
+				Container: "cilium-operator",
+				Image:     bundle.Cilium.Operator.VersionedImage(),
+			},
+		)
+	}
+
+	switch clusterSpec.Cluster.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind:
+		workloads = append(workloads, kubeVip(bundle.VSphere.KubeVip.VersionedImage()))
+	case v1alpha1.TinkerbellDatacenterKind:
+		workloads = append(workloads, kubeVip(bundle.Tinkerbell.KubeVip.VersionedImage()))
+	}
+
+	if !clusterSpec.Cluster.IsSelfManaged() {
+		return workloads
+	}
+
+	workloads = append(workloads,
+		manager(ComponentClusterAPI, constants.CapiSystemNamespace, "capi-controller-manager", bundle.ClusterAPI.Controller.VersionedImage()),
+		manager(ComponentKubeadmBootstrap, constants.CapiKubeadmBootstrapSystemNamespace, "capi-kubeadm-bootstrap-controller-manager", bundle.Bootstrap.Controller.VersionedImage()),
+		manager(ComponentKubeadmControlPlane, constants.CapiKubeadmControlPlaneSystemNamespace, "capi-kubeadm-control-plane-controller-manager", bundle.ControlPlane.Controller.VersionedImage()),
+	)
+
+	switch clusterSpec.Cluster.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind:
+		workloads = append(workloads, manager(ComponentVSphereProvider, constants.CapvSystemNamespace, "capv-controller-manager", bundle.VSphere.ClusterAPIController.VersionedImage()))
+	case v1alpha1.DockerDatacenterKind:
+		workloads = append(workloads, manager(ComponentDockerProvider, constants.CapdSystemNamespace, "capd-controller-manager", bundle.Docker.Manager.VersionedImage()))
+	}
+
+	return append(workloads, manager(ComponentEksaController, constants.EksaSystemNamespace, "eksa-controller-manager", bundle.Eksa.ClusterController.VersionedImage()))
+}
+
+func kubeVip(image string) Workload {
+	return Workload{
+		Component: ComponentKubeVip,
+		Kind:      KindStaticPod,
+		Namespace: constants.KubeSystemNamespace,
+		Name:      "kube-vip",
+		Container: "kube-vip",
+		Image:     image,
+	}
+}
+
+func manager(component, namespace, name, image string) Workload {
+	return Workload{
+		Component: component,
+		Kind:      KindDeployment,
+		Namespace: namespace,
+		Name:      name,
+		Container: managerContainer,
+		Image:     image,
+	}
+}
@@ -0,0 +1,232 @@
+package drift_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/drift"
+	"github.com/aws/eks-anywhere/pkg/drift/mocks"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type detectorTest struct {
+	*WithT
+	ctx      context.Context
+	client   *mocks.MockClient
+	detector *drift.Detector
+	spec     *cluster.Spec
+}
+
+func newDetectorTest(t *testing.T) *detectorTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	return &detectorTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		client:   client,
+		detector: drift.NewDetector(client),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "mgmt"
+			s.Cluster.Spec.DatacenterRef.Kind = v1alpha1.VSphereDatacenterKind
+			s.Cluster.Spec.ClusterNetwork.CNI = v1alpha1.Cilium
+			s.VersionsBundle.Cilium.Cilium = releasev1alpha1.Image{URI: "public.ecr.aws/isovalent/cilium:v1.9.13-eksa.2"}
+			s.VersionsBundle.Cilium.Operator = releasev1alpha1.Image{URI: "public.ecr.aws/isovalent/operator-generic:v1.9.13-eksa.2"}
+			s.VersionsBundle.VSphere.KubeVip = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.3.7-eks-a-v0.0.0-dev-build.1"}
+			s.VersionsBundle.VSphere.ClusterAPIController = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/kubernetes-sigs/cluster-api-provider-vsphere/release/manager:v1.0.1-eks-a-v0.0.0-dev-build.1"}
+			s.VersionsBundle.ClusterAPI.Controller = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/kubernetes-sigs/cluster-api/cluster-api-controller:v1.0.2-eks-a-v0.0.0-dev-build.1"}
+			s.VersionsBundle.Bootstrap.Controller = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/kubernetes-sigs/cluster-api/kubeadm-bootstrap-controller:v1.0.2-eks-a-v0.0.0-dev-build.1"}
+			s.VersionsBundle.ControlPlane.Controller = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/kubernetes-sigs/cluster-api/kubeadm-control-plane-controller:v1.0.2-eks-a-v0.0.0-dev-build.1"}
+			s.VersionsBundle.Eksa.ClusterController = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/eks-anywhere-cluster-controller:v0.0.0-eks-a-v0.0.0-dev-build.1"}
+		}),
+	}
+}
+
+func (tt *detectorTest) expectWorkloads(deployments map[string][]appsv1.Deployment, daemonSets []appsv1.DaemonSet, pods []corev1.Pod) {
+	for _, namespace := range []string{"kube-system", "capi-system", "capi-kubeadm-bootstrap-system", "capi-kubeadm-control-plane-system", "capv-system", "eksa-system"} {
+		tt.client.EXPECT().Deployments(tt.ctx, namespace).Return(deployments[namespace], nil)
+	}
+	tt.client.EXPECT().DaemonSets(tt.ctx, "kube-system").Return(daemonSets, nil)
+	tt.client.EXPECT().Pods(tt.ctx, "kube-system").Return(pods, nil)
+}
+
+func (tt *detectorTest) liveDeployments() map[string][]appsv1.Deployment {
+	b := tt.spec.VersionsBundle
+	return map[string][]appsv1.Deployment{
+		"kube-system": {
+			deployment("coredns", "coredns", "public.ecr.aws/eks-distro/coredns/coredns:v1.8.4-eks-1-21-4"),
+			deployment("cilium-operator", "cilium-operator", b.Cilium.Operator.VersionedImage()),
+		},
+		"capi-system":                       {deployment("capi-controller-manager", "manager", b.ClusterAPI.Controller.VersionedImage())},
+		"capi-kubeadm-bootstrap-system":     {deployment("capi-kubeadm-bootstrap-controller-manager", "manager", b.Bootstrap.Controller.VersionedImage())},
+		"capi-kubeadm-control-plane-system": {deployment("capi-kubeadm-control-plane-controller-manager", "manager", b.ControlPlane.Controller.VersionedImage())},
+		"capv-system":                       {deployment("capv-controller-manager", "manager", b.VSphere.ClusterAPIController.VersionedImage())},
+		"eksa-system":                       {deployment("eksa-controller-manager", "manager", b.Eksa.ClusterController.VersionedImage())},
+	}
+}
+
+func (tt *detectorTest) liveDaemonSets() []appsv1.DaemonSet {
+	return []appsv1.DaemonSet{
+		daemonSet("kube-proxy", "kube-proxy", "public.ecr.aws/eks-distro/kubernetes/kube-proxy:v1.21.2-eks-1-21-4"),
+		daemonSet("cilium", "cilium-agent", tt.spec.VersionsBundle.Cilium.Cilium.VersionedImage()),
+	}
+}
+
+func (tt *detectorTest) livePods() []corev1.Pod {
+	image := tt.spec.VersionsBundle.VSphere.KubeVip.VersionedImage()
+	return []corev1.Pod{
+		staticPod("kube-vip-mgmt-cp-1", "kube-vip", image),
+		staticPod("kube-vip-mgmt-cp-2", "kube-vip", image),
+		pod("kube-vip-ds-x2ktv", "kube-vip", "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.3.2"),
+	}
+}
+
+func deployment(name, container, image string) appsv1.Deployment {
+	d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	d.Spec.Template.Spec.Containers = []corev1.Container{{Name: "kube-rbac-proxy", Image: "kube-rbac-proxy"}, {Name: container, Image: image}}
+	return d
+}
+
+func daemonSet(name, container, image string) appsv1.DaemonSet {
+	d := appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	d.Spec.Template.Spec.Containers = []corev1.Container{{Name: container, Image: image}}
+	return d
+}
+
+func pod(name, container, image string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: container, Image: image}}},
+	}
+}
+
+func staticPod(name, container, image string) corev1.Pod {
+	p := pod(name, container, image)
+	p.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "a1b2c3"}
+	return p
+}
+
+func TestDetectorDetectNoDrift(t *testing.T) {
+	tt := newDetectorTest(t)
+	tt.expectWorkloads(tt.liveDeployments(), tt.liveDaemonSets(), tt.livePods())
+
+	report, err := tt.detector.Detect(tt.ctx, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Drifted()).To(BeFalse())
+	tt.Expect(report.Components()).To(BeEmpty())
+}
+
+func TestDetectorDetectModifiedImages(t *testing.T) {
+	tt := newDetectorTest(t)
+	deployments := tt.liveDeployments()
+	deployments["eksa-system"] = []appsv1.Deployment{deployment("eksa-controller-manager", "manager", "my-registry/eks-anywhere-cluster-controller:patched")}
+	pods := tt.livePods()
+	pods[1] = staticPod("kube-vip-mgmt-cp-2", "kube-vip", "ghcr.io/kube-vip/kube-vip:v0.4.0")
+	tt.expectWorkloads(deployments, tt.liveDaemonSets(), pods)
+
+	report, err := tt.detector.Detect(tt.ctx, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Drifts).To(ConsistOf(
+		drift.Drift{
+			Component: drift.ComponentKubeVip,
+			Kind:      drift.KindStaticPod,
+			Namespace: "kube-system",
+			Name:      "kube-vip-mgmt-cp-2",
+			Container: "kube-vip",
+			Expected:  tt.spec.VersionsBundle.VSphere.KubeVip.VersionedImage(),
+			Actual:    "ghcr.io/kube-vip/kube-vip:v0.4.0",
+		},
+		drift.Drift{
+			Component: drift.ComponentEksaController,
+			Kind:      drift.KindDeployment,
+			Namespace: "eksa-system",
+			Name:      "eksa-controller-manager",
+			Container: "manager",
+			Expected:  tt.spec.VersionsBundle.Eksa.ClusterController.VersionedImage(),
+			Actual:    "my-registry/eks-anywhere-cluster-controller:patched",
+		},
+	))
+	tt.Expect(report.Components()).To(Equal([]string{drift.ComponentEksaController, drift.ComponentKubeVip}))
+	tt.Expect(report.Drifts[1].String()).To(Equal(
+		"eksa-controller: Deployment eksa-system/eksa-controller-manager container manager runs my-registry/eks-anywhere-cluster-controller:patched instead of " +
+			tt.spec.VersionsBundle.Eksa.ClusterController.VersionedImage(),
+	))
+}
+
+func TestDetectorDetectMissingWorkloads(t *testing.T) {
+	tt := newDetectorTest(t)
+	deployments := tt.liveDeployments()
+	deployments["capv-system"] = nil
+	tt.expectWorkloads(deployments, tt.liveDaemonSets()[:1], tt.livePods()[2:])
+
+	report, err := tt.detector.Detect(tt.ctx, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Components()).To(Equal([]string{drift.ComponentCilium, drift.ComponentVSphereProvider, drift.ComponentKubeVip}))
+	for _, d := range report.Drifts {
+		tt.Expect(d.Missing()).To(BeTrue(), d.String())
+	}
+	tt.Expect(report.Drifts[0].String()).To(Equal("cilium: DaemonSet kube-system/cilium container cilium-agent is missing"))
+}
+
+func TestDetectorDetectMissingContainer(t *testing.T) {
+	tt := newDetectorTest(t)
+	deployments := tt.liveDeployments()
+	deployments["kube-system"][1].Spec.Template.Spec.Containers[1].Name = "operator"
+	tt.expectWorkloads(deployments, tt.liveDaemonSets(), tt.livePods())
+
+	report, err := tt.detector.Detect(tt.ctx, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Drifts).To(HaveLen(1))
+	tt.Expect(report.Drifts[0].Missing()).To(BeTrue())
+	tt.Expect(report.Drifts[0].Name).To(Equal("cilium-operator"))
+}
+
+func TestDetectorDetectWorkloadCluster(t *testing.T) {
+	tt := newDetectorTest(t)
+	tt.spec.Cluster.Spec.ManagementCluster.Name = "mgmt-2"
+	tt.client.EXPECT().DaemonSets(tt.ctx, "kube-system").Return(tt.liveDaemonSets(), nil)
+	tt.client.EXPECT().Deployments(tt.ctx, "kube-system").Return(tt.liveDeployments()["kube-system"], nil)
+	tt.client.EXPECT().Pods(tt.ctx, "kube-system").Return(tt.livePods(), nil)
+
+	report, err := tt.detector.Detect(tt.ctx, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Drifted()).To(BeFalse())
+}
+
+func TestDetectorDetectClientError(t *testing.T) {
+	tt := newDetectorTest(t)
+	tt.client.EXPECT().DaemonSets(tt.ctx, "kube-system").Return(nil, errors.New("connection refused"))
+
+	_, err := tt.detector.Detect(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError("error detecting drift for DaemonSet kube-system/cilium: connection refused"))
+}
+
+func TestExpectedWorkloadsDockerKindnetd(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "mgmt"
+		s.Cluster.Spec.DatacenterRef.Kind = v1alpha1.DockerDatacenterKind
+		s.Cluster.Spec.ClusterNetwork.CNI = v1alpha1.Kindnetd
+	})
+
+	components := []string{}
+	for _, w := range drift.ExpectedWorkloads(spec) {
+		components = append(components, w.Component)
+	}
+	g.Expect(components).To(Equal([]string{
+		drift.ComponentClusterAPI,
+		drift.ComponentKubeadmBootstrap,
+		drift.ComponentKubeadmControlPlane,
+		drift.ComponentDockerProvider,
+		drift.ComponentEksaController,
+	}))
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/drift/client.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/apps/v1"
+	v10 "k8s.io/api/core/v1"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// DaemonSets mocks base method.
+func (m *MockClient) DaemonSets(ctx context.Context, namespace string) ([]v1.DaemonSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DaemonSets", ctx, namespace)
+	ret0, _ := ret[0].([]v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DaemonSets indicates an expected call of DaemonSets.
+func (mr *MockClientMockRecorder) DaemonSets(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DaemonSets", reflect.TypeOf((*MockClient)(nil).DaemonSets), ctx, namespace)
+}
+
+// Deployments mocks base method.
+func (m *MockClient) Deployments(ctx context.Context, namespace string) ([]v1.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deployments", ctx, namespace)
+	ret0, _ := ret[0].([]v1.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deployments indicates an expected call of Deployments.
+func (mr *MockClientMockRecorder) Deployments(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deployments", reflect.TypeOf((*MockClient)(nil).Deployments), ctx, namespace)
+}
+
+// Pods mocks base method.
+func (m *MockClient) Pods(ctx context.Context, namespace string) ([]v10.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pods", ctx, namespace)
+	ret0, _ := ret[0].([]v10.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pods indicates an expected call of Pods.
+func (mr *MockClientMockRecorder) Pods(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pods", reflect.TypeOf((*MockClient)(nil).Pods), ctx, namespace)
+}
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// GetDaemonSets mocks base method.
+func (m *MockKubectlClient) GetDaemonSets(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.DaemonSet, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDaemonSets", varargs...)
+	ret0, _ := ret[0].([]v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDaemonSets indicates an expected call of GetDaemonSets.
+func (mr *MockKubectlClientMockRecorder) GetDaemonSets(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaemonSets", reflect.TypeOf((*MockKubectlClient)(nil).GetDaemonSets), varargs...)
+}
+
+// GetDeployments mocks base method.
+func (m *MockKubectlClient) GetDeployments(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.Deployment, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDeployments", varargs...)
+	ret0, _ := ret[0].([]v1.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeployments indicates an expected call of GetDeployments.
+func (mr *MockKubectlClientMockRecorder) GetDeployments(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployments", reflect.TypeOf((*MockKubectlClient)(nil).GetDeployments), varargs...)
+}
+
+// GetPods mocks base method.
+func (m *MockKubectlClient) GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]v10.Pod, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPods", varargs...)
+	ret0, _ := ret[0].([]v10.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPods indicates an expected call of GetPods.
+func (mr *MockKubectlClientMockRecorder) GetPods(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPods", reflect.TypeOf((*MockKubectlClient)(nil).GetPods), varargs...)
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/drift/repair.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	clusterapi "github.com/aws/eks-anywhere/pkg/clusterapi"
+	providers "github.com/aws/eks-anywhere/pkg/providers"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClusterManager is a mock of ClusterManager interface.
+type MockClusterManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterManagerMockRecorder
+}
+
+// MockClusterManagerMockRecorder is the mock recorder for MockClusterManager.
+type MockClusterManagerMockRecorder struct {
+	mock *MockClusterManager
+}
+
+// NewMockClusterManager creates a new mock instance.
+func NewMockClusterManager(ctrl *gomock.Controller) *MockClusterManager {
+	mock := &MockClusterManager{ctrl: ctrl}
+	mock.recorder = &MockClusterManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterManager) EXPECT() *MockClusterManagerMockRecorder {
+	return m.recorder
+}
+
+// InstallCustomComponents mocks base method.
+func (m *MockClusterManager) InstallCustomComponents(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallCustomComponents", ctx, clusterSpec, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallCustomComponents indicates an expected call of InstallCustomComponents.
+func (mr *MockClusterManagerMockRecorder) InstallCustomComponents(ctx, clusterSpec, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCustomComponents", reflect.TypeOf((*MockClusterManager)(nil).InstallCustomComponents), ctx, clusterSpec, cluster)
+}
+
+// InstallNetworking mocks base method.
+func (m *MockClusterManager) InstallNetworking(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallNetworking", ctx, cluster, clusterSpec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallNetworking indicates an expected call of InstallNetworking.
+func (mr *MockClusterManagerMockRecorder) InstallNetworking(ctx, cluster, clusterSpec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallNetworking", reflect.TypeOf((*MockClusterManager)(nil).InstallNetworking), ctx, cluster, clusterSpec)
+}
+
+// MockCAPIClient is a mock of CAPIClient interface.
+type MockCAPIClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCAPIClientMockRecorder
+}
+
+// MockCAPIClientMockRecorder is the mock recorder for MockCAPIClient.
+type MockCAPIClientMockRecorder struct {
+	mock *MockCAPIClient
+}
+
+// NewMockCAPIClient creates a new mock instance.
+func NewMockCAPIClient(ctrl *gomock.Controller) *MockCAPIClient {
+	mock := &MockCAPIClient{ctrl: ctrl}
+	mock.recorder = &MockCAPIClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCAPIClient) EXPECT() *MockCAPIClientMockRecorder {
+	return m.recorder
+}
+
+// Upgrade mocks base method.
+func (m *MockCAPIClient) Upgrade(ctx context.Context, managementCluster *types.Cluster, provider providers.Provider, newSpec *cluster.Spec, changeDiff *clusterapi.CAPIChangeDiff) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upgrade", ctx, managementCluster, provider, newSpec, changeDiff)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upgrade indicates an expected call of Upgrade.
+func (mr *MockCAPIClientMockRecorder) Upgrade(ctx, managementCluster, provider, newSpec, changeDiff interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockCAPIClient)(nil).Upgrade), ctx, managementCluster, provider, newSpec, changeDiff)
+}
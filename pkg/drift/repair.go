@@ -0,0 +1,142 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type ClusterManager interface {
+	InstallNetworking(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	InstallCustomComponents(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error
+}
+
+type CAPIClient interface {
+	Upgrade(ctx context.Context, managementCluster *types.Cluster, provider providers.Provider, newSpec *cluster.Spec, changeDiff *clusterapi.CAPIChangeDiff) error
+}
+
+// Repairer re-installs the drifted components with the versions of the bundle stored with the cluster
+type Repairer struct {
+	clusterManager ClusterManager
+	capiClient     CAPIClient
+	provider       providers.Provider
+}
+
+func NewRepairer(clusterManager ClusterManager, capiClient CAPIClient, provider providers.Provider) *Repairer {
+	return &Repairer{
+		clusterManager: clusterManager,
+		capiClient:     capiClient,
+		provider:       provider,
+	}
+}
+
+// Repair re-applies the manifests of every component in the report and returns the drifts it can't repair.
+// kube-vip runs as a static pod, so it's only restored when the control plane nodes are rolled out
+func (r *Repairer) Repair(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, report *Report) ([]Drift, error) {
+	components := map[string]struct{}{}
+	for _, c := range report.Components() {
+		components[c] = struct{}{}
+	}
+
+	if _, ok := components[ComponentCilium]; ok {
+		logger.Info("Re-installing Cilium")
+		if err := r.clusterManager.InstallNetworking(ctx, cluster, clusterSpec); err != nil {
+			return nil, fmt.Errorf("failed repairing %s: %v", ComponentCilium, err)
+		}
+	}
+
+	if changeDiff := r.capiChangeDiff(clusterSpec, components); changeDiff != nil {
+		logger.Info("Re-installing Cluster API providers")
+		if err := r.capiClient.Upgrade(ctx, cluster, r.provider, clusterSpec, changeDiff); err != nil {
+			return nil, fmt.Errorf("failed repairing Cluster API providers: %v", err)
+		}
+	}
+
+	if _, ok := components[ComponentEksaController]; ok {
+		logger.Info("Re-installing EKS-A controller")
+		if err := r.clusterManager.InstallCustomComponents(ctx, clusterSpec, cluster); err != nil {
+			return nil, fmt.Errorf("failed repairing %s: %v", ComponentEksaController, err)
+		}
+	}
+
+	unrepaired := []Drift{}
+	for _, d := range report.Drifts {
+		if !Repairable(d) {
+			unrepaired = append(unrepaired, d)
+		}
+	}
+
+	return unrepaired, nil
+}
+
+// RequiresCAPI returns true when repairing the report re-installs any of the Cluster API providers
+func RequiresCAPI(report *Report) bool {
+	for _, d := range report.Drifts {
+		if isCAPIComponent(d.Component) {
+			return true
+		}
+	}
+	return false
+}
+
+func Repairable(d Drift) bool {
+	return d.Component != ComponentKubeVip
+}
+
+func isCAPIComponent(component string) bool {
+	switch component {
+	case ComponentClusterAPI, ComponentKubeadmBootstrap, ComponentKubeadmControlPlane, ComponentVSphereProvider, ComponentDockerProvider:
+		return true
+	default:
+		return false
+	}
+}
+
+// capiChangeDiff builds a diff with the same old and new versions, so clusterctl upgrade re-applies the
+// providers' manifests without changing their versions
+func (r *Repairer) capiChangeDiff(clusterSpec *cluster.Spec, components map[string]struct{}) *clusterapi.CAPIChangeDiff {
+	bundle := clusterSpec.VersionsBundle
+	changeDiff := &clusterapi.CAPIChangeDiff{}
+	changed := false
+
+	if _, ok := components[ComponentClusterAPI]; ok {
+		changeDiff.Core = sameVersion("cluster-api", bundle.ClusterAPI.Version)
+		changed = true
+	}
+
+	if _, ok := components[ComponentKubeadmControlPlane]; ok {
+		changeDiff.ControlPlane = sameVersion("kubeadm", bundle.ControlPlane.Version)
+		changed = true
+	}
+
+	if _, ok := components[ComponentKubeadmBootstrap]; ok {
+		changeDiff.BootstrapProviders = append(changeDiff.BootstrapProviders, *sameVersion("kubeadm", bundle.Bootstrap.Version))
+		changed = true
+	}
+
+	_, vsphere := components[ComponentVSphereProvider]
+	_, docker := components[ComponentDockerProvider]
+	if vsphere || docker {
+		changeDiff.InfrastructureProvider = sameVersion(r.provider.Name(), r.provider.Version(clusterSpec))
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return changeDiff
+}
+
+func sameVersion(component, version string) *types.ComponentChangeDiff {
+	return &types.ComponentChangeDiff{
+		ComponentName: component,
+		NewVersion:    version,
+		OldVersion:    version,
+	}
+}
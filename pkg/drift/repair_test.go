@@ -0,0 +1,138 @@
+package drift_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/drift"
+	"github.com/aws/eks-anywhere/pkg/drift/mocks"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type repairerTest struct {
+	*WithT
+	ctx            context.Context
+	clusterManager *mocks.MockClusterManager
+	capiClient     *mocks.MockCAPIClient
+	provider       *providermocks.MockProvider
+	repairer       *drift.Repairer
+	cluster        *types.Cluster
+	spec           *cluster.Spec
+}
+
+func newRepairerTest(t *testing.T) *repairerTest {
+	ctrl := gomock.NewController(t)
+	clusterManager := mocks.NewMockClusterManager(ctrl)
+	capiClient := mocks.NewMockCAPIClient(ctrl)
+	provider := providermocks.NewMockProvider(ctrl)
+	return &repairerTest{
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		clusterManager: clusterManager,
+		capiClient:     capiClient,
+		provider:       provider,
+		repairer:       drift.NewRepairer(clusterManager, capiClient, provider),
+		cluster:        &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "mgmt"
+			s.VersionsBundle.ClusterAPI.Version = "v1.0.2+eksa.1"
+			s.VersionsBundle.ControlPlane.Version = "v1.0.2+eksa.2"
+			s.VersionsBundle.Bootstrap.Version = "v1.0.2+eksa.3"
+		}),
+	}
+}
+
+func report(components ...string) *drift.Report {
+	r := &drift.Report{}
+	for _, c := range components {
+		r.Drifts = append(r.Drifts, drift.Drift{Component: c, Expected: "image"})
+	}
+	return r
+}
+
+func TestRepairerRepairNoDrift(t *testing.T) {
+	tt := newRepairerTest(t)
+
+	unrepaired, err := tt.repairer.Repair(tt.ctx, tt.cluster, tt.spec, report())
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(unrepaired).To(BeEmpty())
+}
+
+func TestRepairerRepairCiliumAndController(t *testing.T) {
+	tt := newRepairerTest(t)
+	gomock.InOrder(
+		tt.clusterManager.EXPECT().InstallNetworking(tt.ctx, tt.cluster, tt.spec),
+		tt.clusterManager.EXPECT().InstallCustomComponents(tt.ctx, tt.spec, tt.cluster),
+	)
+
+	unrepaired, err := tt.repairer.Repair(tt.ctx, tt.cluster, tt.spec, report(drift.ComponentEksaController, drift.ComponentCilium, drift.ComponentCilium))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(unrepaired).To(BeEmpty())
+}
+
+func TestRepairerRepairCAPIProviders(t *testing.T) {
+	tt := newRepairerTest(t)
+	wantChangeDiff := &clusterapi.CAPIChangeDiff{
+		Core:         &types.ComponentChangeDiff{ComponentName: "cluster-api", NewVersion: "v1.0.2+eksa.1", OldVersion: "v1.0.2+eksa.1"},
+		ControlPlane: &types.ComponentChangeDiff{ComponentName: "kubeadm", NewVersion: "v1.0.2+eksa.2", OldVersion: "v1.0.2+eksa.2"},
+		BootstrapProviders: []types.ComponentChangeDiff{
+			{ComponentName: "kubeadm", NewVersion: "v1.0.2+eksa.3", OldVersion: "v1.0.2+eksa.3"},
+		},
+		InfrastructureProvider: &types.ComponentChangeDiff{ComponentName: "vsphere", NewVersion: "v1.0.1", OldVersion: "v1.0.1"},
+	}
+	tt.provider.EXPECT().Name().Return("vsphere")
+	tt.provider.EXPECT().Version(tt.spec).Return("v1.0.1")
+	tt.capiClient.EXPECT().Upgrade(tt.ctx, tt.cluster, tt.provider, tt.spec, wantChangeDiff)
+
+	r := report(drift.ComponentClusterAPI, drift.ComponentKubeadmControlPlane, drift.ComponentKubeadmBootstrap, drift.ComponentVSphereProvider)
+	tt.Expect(drift.RequiresCAPI(r)).To(BeTrue())
+	unrepaired, err := tt.repairer.Repair(tt.ctx, tt.cluster, tt.spec, r)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(unrepaired).To(BeEmpty())
+}
+
+func TestRepairerRepairOnlyCore(t *testing.T) {
+	tt := newRepairerTest(t)
+	wantChangeDiff := &clusterapi.CAPIChangeDiff{
+		Core: &types.ComponentChangeDiff{ComponentName: "cluster-api", NewVersion: "v1.0.2+eksa.1", OldVersion: "v1.0.2+eksa.1"},
+	}
+	tt.capiClient.EXPECT().Upgrade(tt.ctx, tt.cluster, tt.provider, tt.spec, wantChangeDiff)
+
+	unrepaired, err := tt.repairer.Repair(tt.ctx, tt.cluster, tt.spec, report(drift.ComponentClusterAPI))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(unrepaired).To(BeEmpty())
+}
+
+func TestRepairerRepairKubeVipNotRepairable(t *testing.T) {
+	tt := newRepairerTest(t)
+	r := report(drift.ComponentKubeVip)
+
+	tt.Expect(drift.RequiresCAPI(r)).To(BeFalse())
+	unrepaired, err := tt.repairer.Repair(tt.ctx, tt.cluster, tt.spec, r)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(unrepaired).To(Equal(r.Drifts))
+}
+
+func TestRepairerRepairNetworkingError(t *testing.T) {
+	tt := newRepairerTest(t)
+	tt.clusterManager.EXPECT().InstallNetworking(tt.ctx, tt.cluster, tt.spec).Return(errors.New("error applying"))
+
+	_, err := tt.repairer.Repair(tt.ctx, tt.cluster, tt.spec, report(drift.ComponentCilium, drift.ComponentEksaController))
+	tt.Expect(err).To(MatchError(ContainSubstring("failed repairing cilium: error applying")))
+}
+
+func TestRepairerRepairCAPIError(t *testing.T) {
+	tt := newRepairerTest(t)
+	tt.capiClient.EXPECT().Upgrade(tt.ctx, tt.cluster, tt.provider, tt.spec, gomock.Any()).Return(errors.New("error in clusterctl"))
+
+	_, err := tt.repairer.Repair(tt.ctx, tt.cluster, tt.spec, report(drift.ComponentKubeadmBootstrap))
+	tt.Expect(err).To(MatchError(ContainSubstring("failed repairing Cluster API providers: error in clusterctl")))
+}
@@ -0,0 +1,436 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// DefaultMaxDBSize is below the 2GiB default quota of etcd, so members are defragmented before the
+	// NOSPACE alarm makes the cluster read only
+	DefaultMaxDBSize int64 = 1536 * 1024 * 1024
+	// DefaultMaxFragmentation defragments members when more than half of their database file is free space
+	DefaultMaxFragmentation = 0.5
+
+	noSpaceAlarm = "NOSPACE"
+	etcdctlPath  = "/opt/bin/etcdctl"
+	etcdPKIDir   = "/etc/etcd/pki"
+	// defragTimeout is the time etcdctl waits for a defragmentation, which blocks the member while it rewrites its database
+	defragTimeout = "5m"
+)
+
+// NodeClient runs commands in the etcd machines over SSH, implemented by executables.SSH
+type NodeClient interface {
+	RunCommand(ctx context.Context, host executables.SSHHost, bastion *executables.SSHHost, command string) (string, error)
+}
+
+// Member is an etcd member of an external etcd cluster and the machine it runs in
+type Member struct {
+	// Endpoint is the client URL of the member, like https://10.0.0.1:2379
+	Endpoint string
+	Host     executables.SSHHost
+}
+
+// MembersFromEndpoints returns the members of the comma separated endpoints of an EtcdadmCluster status,
+// logging into each machine as returned by host for the endpoint hostname
+func MembersFromEndpoints(endpoints string, host func(address string) executables.SSHHost) ([]Member, error) {
+	var members []Member
+	for _, endpoint := range strings.Split(endpoints, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Hostname() == "" {
+			return nil, fmt.Errorf("invalid etcd endpoint %q", endpoint)
+		}
+		members = append(members, Member{Endpoint: endpoint, Host: host(u.Hostname())})
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no etcd endpoints, the etcd cluster might not be ready")
+	}
+
+	return members, nil
+}
+
+// Maintainer keeps the external etcd members healthy: it defragments the members whose database crossed
+// the thresholds and clears the alarms, like NOSPACE, once there's space again
+type Maintainer struct {
+	client           NodeClient
+	bastion          *executables.SSHHost
+	maxDBSize        int64
+	maxFragmentation float64
+	checkOnly        bool
+	now              func() time.Time
+}
+
+type MaintainerOpt func(*Maintainer)
+
+// WithMaxDBSize defragments the members whose database file is bigger than size bytes
+func WithMaxDBSize(size int64) MaintainerOpt {
+	return func(m *Maintainer) {
+		m.maxDBSize = size
+	}
+}
+
+// WithMaxFragmentation defragments the members whose database file has a larger fraction of free space
+func WithMaxFragmentation(fraction float64) MaintainerOpt {
+	return func(m *Maintainer) {
+		m.maxFragmentation = fraction
+	}
+}
+
+// WithCheckOnly only checks the members health, without defragmenting them or clearing alarms
+func WithCheckOnly() MaintainerOpt {
+	return func(m *Maintainer) {
+		m.checkOnly = true
+	}
+}
+
+// NewMaintainer returns a Maintainer that connects to the etcd machines through bastion when it's not nil
+func NewMaintainer(client NodeClient, bastion *executables.SSHHost, opts ...MaintainerOpt) *Maintainer {
+	m := &Maintainer{
+		client:           client,
+		bastion:          bastion,
+		maxDBSize:        DefaultMaxDBSize,
+		maxFragmentation: DefaultMaxFragmentation,
+		now:              time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// memberState is the status of a member with what's needed to maintain it
+type memberState struct {
+	member   Member
+	status   v1alpha1.EtcdMemberStatus
+	id       uint64
+	revision int64
+	// alarms are the alarms of the member reported in its endpoint status
+	alarms []string
+}
+
+// Maintain checks the members and, unless check only, defragments the ones over the thresholds one at a time,
+// the leader last, and clears the alarms. Nothing is changed if any member is unhealthy, since defragmenting
+// a member blocks it and could leave the cluster without quorum. The returned status is the health of
+// the members at the end, also when an error is returned
+func (m *Maintainer) Maintain(ctx context.Context, members []Member) (*v1alpha1.EtcdStatus, error) {
+	states := m.check(ctx, members)
+	status := m.status(states)
+	if unhealthy := unhealthyMembers(states); len(unhealthy) > 0 {
+		return status, fmt.Errorf("etcd members %s are not healthy, skipping maintenance", strings.Join(unhealthy, ", "))
+	}
+	if m.checkOnly {
+		return status, nil
+	}
+
+	noSpace := hasAlarm(states, noSpaceAlarm)
+	defrag := m.membersToDefrag(states, noSpace)
+	if len(defrag) == 0 && len(alarmedMembers(states)) == 0 {
+		logger.Info("No etcd maintenance needed")
+		return status, nil
+	}
+
+	err := m.repair(ctx, states, defrag, noSpace, status)
+	after := m.check(ctx, members)
+	final := m.status(after)
+	final.LastDefragTime = status.LastDefragTime
+	if err != nil {
+		return final, err
+	}
+	if unhealthy := unhealthyMembers(after); len(unhealthy) > 0 {
+		return final, fmt.Errorf("etcd members %s are not healthy after maintenance", strings.Join(unhealthy, ", "))
+	}
+
+	return final, nil
+}
+
+// repair compacts the history when the database is over its quota, defragments the members and clears the alarms,
+// setting the last defragmentation time in status
+func (m *Maintainer) repair(ctx context.Context, states, defrag []memberState, noSpace bool, status *v1alpha1.EtcdStatus) error {
+	if noSpace {
+		// the database is over its quota, so the old revisions have to be compacted before defragmenting frees space
+		if err := m.compact(ctx, states[0]); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range defrag {
+		if err := m.defrag(ctx, s); err != nil {
+			return err
+		}
+		status.LastDefragTime = &metav1.Time{Time: m.now()}
+	}
+
+	if alarms := alarmedMembers(states); len(alarms) > 0 {
+		logger.Info("Clearing etcd alarms", "members", strings.Join(alarms, ", "))
+		if _, err := m.etcdctl(ctx, states[0].member, "alarm", "disarm"); err != nil {
+			return fmt.Errorf("failed clearing etcd alarms: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Maintainer) check(ctx context.Context, members []Member) []memberState {
+	alarms, alarmsErr := m.alarms(ctx, members)
+	states := make([]memberState, 0, len(members))
+	for _, member := range members {
+		s := memberState{member: member, status: v1alpha1.EtcdMemberStatus{Endpoint: member.Endpoint}}
+		if err := m.endpointStatus(ctx, &s); err != nil {
+			logger.V(3).Info("Etcd member not healthy", "endpoint", member.Endpoint, "error", err)
+			s.status.Message = err.Error()
+		} else if alarmsErr != nil {
+			s.status.Message = alarmsErr.Error()
+		} else {
+			s.status.Healthy = true
+			s.status.Alarms = mergeAlarms(alarms[s.id], s.alarms)
+		}
+		states = append(states, s)
+	}
+
+	return states
+}
+
+type endpointStatusResponse struct {
+	Endpoint string `json:"Endpoint"`
+	Status   struct {
+		Header struct {
+			MemberID uint64 `json:"member_id"`
+			Revision int64  `json:"revision"`
+		} `json:"header"`
+		Leader      uint64   `json:"leader"`
+		DBSize      int64    `json:"dbSize"`
+		DBSizeInUse int64    `json:"dbSizeInUse"`
+		Errors      []string `json:"errors"`
+	} `json:"Status"`
+}
+
+func (m *Maintainer) endpointStatus(ctx context.Context, s *memberState) error {
+	out, err := m.etcdctl(ctx, s.member, "endpoint", "status", "-w", "json")
+	if err != nil {
+		return fmt.Errorf("failed getting status: %v", err)
+	}
+	var response []endpointStatusResponse
+	if err = json.Unmarshal([]byte(out), &response); err != nil || len(response) != 1 {
+		return fmt.Errorf("failed parsing status %q: %v", out, err)
+	}
+
+	r := response[0].Status
+	s.id = r.Header.MemberID
+	s.revision = r.Header.Revision
+	s.status.Leader = r.Leader != 0 && r.Leader == r.Header.MemberID
+	s.status.DBSize = r.DBSize
+	s.status.DBSizeInUse = r.DBSizeInUse
+
+	// the active alarms of the cluster are reported as errors too, like "memberID:1234 alarm:NOSPACE ", but they
+	// are repaired by the maintenance instead of making the member unhealthy
+	var errs, alarms []string
+	for _, e := range r.Errors {
+		if strings.Contains(e, "alarm:") {
+			alarms = append(alarms, e)
+		} else {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("member reports errors: %s", strings.Join(errs, ", "))
+	}
+	reported, err := parseAlarms(strings.Join(alarms, "\n"))
+	if err != nil {
+		return err
+	}
+	s.alarms = reported[s.id]
+
+	return nil
+}
+
+// alarms returns the alarms of the cluster by member id. They're read from the first member that answers,
+// since every member returns the alarms of the whole cluster
+func (m *Maintainer) alarms(ctx context.Context, members []Member) (map[uint64][]string, error) {
+	var err error
+	for _, member := range members {
+		var out string
+		if out, err = m.etcdctl(ctx, member, "alarm", "list"); err == nil {
+			return parseAlarms(out)
+		}
+	}
+
+	return nil, fmt.Errorf("failed listing alarms: %v", err)
+}
+
+// parseAlarms parses the alarm list output, with a line like "memberID:1234 alarm:NOSPACE" per alarm
+func parseAlarms(out string) (map[uint64][]string, error) {
+	alarms := map[uint64][]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var id uint64
+		alarm := ""
+		for _, field := range strings.Fields(line) {
+			kv := strings.SplitN(field, ":", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch kv[0] {
+			case "memberID":
+				parsed, err := strconv.ParseUint(kv[1], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("failed parsing alarm %q: %v", line, err)
+				}
+				id = parsed
+			case "alarm":
+				alarm = kv[1]
+			}
+		}
+		if alarm == "" {
+			return nil, fmt.Errorf("failed parsing alarm %q", line)
+		}
+		alarms[id] = append(alarms[id], alarm)
+	}
+
+	return alarms, nil
+}
+
+// membersToDefrag returns the members over the thresholds, all of them with the NOSPACE alarm, with the leader last
+// so the cluster only changes leader once, if at all
+func (m *Maintainer) membersToDefrag(states []memberState, all bool) []memberState {
+	var defrag []memberState
+	for _, s := range states {
+		if all || m.overThresholds(s.status) {
+			defrag = append(defrag, s)
+		}
+	}
+	sort.SliceStable(defrag, func(i, j int) bool {
+		return !defrag[i].status.Leader && defrag[j].status.Leader
+	})
+
+	return defrag
+}
+
+func (m *Maintainer) overThresholds(s v1alpha1.EtcdMemberStatus) bool {
+	if m.maxDBSize > 0 && s.DBSize > m.maxDBSize {
+		return true
+	}
+	if s.DBSize == 0 || s.DBSizeInUse == 0 {
+		return false
+	}
+	return float64(s.DBSize-s.DBSizeInUse)/float64(s.DBSize) > m.maxFragmentation
+}
+
+func (m *Maintainer) compact(ctx context.Context, s memberState) error {
+	logger.Info("Compacting etcd history", "revision", s.revision)
+	if _, err := m.etcdctl(ctx, s.member, "compact", strconv.FormatInt(s.revision, 10)); err != nil {
+		return fmt.Errorf("failed compacting etcd history: %v", err)
+	}
+
+	return nil
+}
+
+// defrag defragments the member and checks it's healthy again before the next one is defragmented
+func (m *Maintainer) defrag(ctx context.Context, s memberState) error {
+	logger.Info("Defragmenting etcd member", "endpoint", s.member.Endpoint, "dbSize", s.status.DBSize, "dbSizeInUse", s.status.DBSizeInUse)
+	if _, err := m.etcdctl(ctx, s.member, "defrag", "--command-timeout="+defragTimeout); err != nil {
+		return fmt.Errorf("failed defragmenting etcd member %s: %v", s.member.Endpoint, err)
+	}
+
+	after := memberState{member: s.member, status: v1alpha1.EtcdMemberStatus{Endpoint: s.member.Endpoint}}
+	if err := m.endpointStatus(ctx, &after); err != nil {
+		return fmt.Errorf("etcd member %s not healthy after defragmentation: %v", s.member.Endpoint, err)
+	}
+	logger.MarkPass("Etcd member defragmented", "endpoint", s.member.Endpoint, "dbSize", after.status.DBSize)
+
+	return nil
+}
+
+func (m *Maintainer) etcdctl(ctx context.Context, member Member, args ...string) (string, error) {
+	return m.client.RunCommand(ctx, member.Host, m.bastion, etcdctlCommand(member.Endpoint, args...))
+}
+
+// etcdctlCommand runs etcdctl in the etcd machine with the client certificate etcdadm creates for it
+func etcdctlCommand(endpoint string, args ...string) string {
+	command := []string{
+		"sudo", "ETCDCTL_API=3", etcdctlPath,
+		"--endpoints=" + endpoint,
+		"--cacert=" + etcdPKIDir + "/ca.crt",
+		"--cert=" + etcdPKIDir + "/etcdctl-etcd-client.crt",
+		"--key=" + etcdPKIDir + "/etcdctl-etcd-client.key",
+	}
+
+	return strings.Join(append(command, args...), " ")
+}
+
+func (m *Maintainer) status(states []memberState) *v1alpha1.EtcdStatus {
+	status := &v1alpha1.EtcdStatus{LastCheckTime: metav1.Time{Time: m.now()}}
+	for _, s := range states {
+		status.Members = append(status.Members, s.status)
+	}
+
+	return status
+}
+
+func unhealthyMembers(states []memberState) []string {
+	var unhealthy []string
+	for _, s := range states {
+		if !s.status.Healthy {
+			unhealthy = append(unhealthy, s.member.Endpoint)
+		}
+	}
+
+	return unhealthy
+}
+
+// mergeAlarms returns the alarms in any of the lists, without duplicates
+func mergeAlarms(lists ...[]string) []string {
+	var merged []string
+	seen := map[string]bool{}
+	for _, list := range lists {
+		for _, a := range list {
+			if !seen[a] {
+				seen[a] = true
+				merged = append(merged, a)
+			}
+		}
+	}
+
+	return merged
+}
+
+func alarmedMembers(states []memberState) []string {
+	var alarmed []string
+	for _, s := range states {
+		if len(s.status.Alarms) > 0 {
+			alarmed = append(alarmed, s.member.Endpoint)
+		}
+	}
+
+	return alarmed
+}
+
+func hasAlarm(states []memberState, alarm string) bool {
+	for _, s := range states {
+		for _, a := range s.status.Alarms {
+			if a == alarm {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,207 @@
+package etcd_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcd"
+	"github.com/aws/eks-anywhere/pkg/etcd/mocks"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+const mib = 1024 * 1024
+
+type maintainerTest struct {
+	*WithT
+	ctx     context.Context
+	client  *mocks.MockNodeClient
+	members []etcd.Member
+}
+
+func newMaintainerTest(t *testing.T) *maintainerTest {
+	var members []etcd.Member
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		members = append(members, etcd.Member{
+			Endpoint: fmt.Sprintf("https://%s:2379", ip),
+			Host:     executables.SSHHost{Address: ip, User: "ec2-user", PrivateKeyPath: "/home/user/.ssh/etcd"},
+		})
+	}
+
+	return &maintainerTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  mocks.NewMockNodeClient(gomock.NewController(t)),
+		members: members,
+	}
+}
+
+func etcdctl(member etcd.Member, args ...string) string {
+	return fmt.Sprintf("sudo ETCDCTL_API=3 /opt/bin/etcdctl --endpoints=%s --cacert=/etc/etcd/pki/ca.crt --cert=/etc/etcd/pki/etcdctl-etcd-client.crt --key=/etc/etcd/pki/etcdctl-etcd-client.key %s", member.Endpoint, strings.Join(args, " "))
+}
+
+func (tt *maintainerTest) expectCommand(member int, out string, args ...string) *gomock.Call {
+	m := tt.members[member]
+	return tt.client.EXPECT().RunCommand(tt.ctx, m.Host, nil, etcdctl(m, args...)).Return(out, nil)
+}
+
+func (tt *maintainerTest) expectAlarms(out string) *gomock.Call {
+	return tt.expectCommand(0, out, "alarm", "list")
+}
+
+func (tt *maintainerTest) expectStatus(member int, id, leader uint64, dbSize, inUse int64) *gomock.Call {
+	out := fmt.Sprintf(`[{"Endpoint":"%s","Status":{"header":{"member_id":%d,"revision":4242},"leader":%d,"dbSize":%d,"dbSizeInUse":%d}}]`, tt.members[member].Endpoint, id, leader, dbSize, inUse)
+	return tt.expectCommand(member, out, "endpoint", "status", "-w", "json")
+}
+
+func TestMaintainNothingToDo(t *testing.T) {
+	tt := newMaintainerTest(t)
+	tt.expectAlarms("")
+	tt.expectStatus(0, 1, 1, 100*mib, 90*mib)
+	tt.expectStatus(1, 2, 1, 100*mib, 80*mib)
+
+	status, err := etcd.NewMaintainer(tt.client, nil).Maintain(tt.ctx, tt.members)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(status.LastDefragTime).To(BeNil())
+	tt.Expect(status.Members).To(Equal([]v1alpha1.EtcdMemberStatus{
+		{Endpoint: "https://10.0.0.1:2379", Healthy: true, Leader: true, DBSize: 100 * mib, DBSizeInUse: 90 * mib},
+		{Endpoint: "https://10.0.0.2:2379", Healthy: true, DBSize: 100 * mib, DBSizeInUse: 80 * mib},
+	}))
+}
+
+func TestMaintainDefragmentsLeaderLast(t *testing.T) {
+	tt := newMaintainerTest(t)
+	gomock.InOrder(
+		tt.expectAlarms(""),
+		tt.expectStatus(0, 1, 1, 100*mib, 20*mib),
+		tt.expectStatus(1, 2, 1, 2000*mib, 1900*mib),
+		tt.expectCommand(1, "", "defrag", "--command-timeout=5m"),
+		tt.expectStatus(1, 2, 1, 1900*mib, 1900*mib),
+		tt.expectCommand(0, "", "defrag", "--command-timeout=5m"),
+		tt.expectStatus(0, 1, 1, 20*mib, 20*mib),
+		tt.expectAlarms(""),
+		tt.expectStatus(0, 1, 1, 20*mib, 20*mib),
+		tt.expectStatus(1, 2, 1, 1900*mib, 1900*mib),
+	)
+
+	status, err := etcd.NewMaintainer(tt.client, nil).Maintain(tt.ctx, tt.members)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(status.LastDefragTime).NotTo(BeNil())
+	tt.Expect(status.Members[0].DBSize).To(Equal(int64(20 * mib)))
+}
+
+func TestMaintainNoSpaceAlarm(t *testing.T) {
+	tt := newMaintainerTest(t)
+	gomock.InOrder(
+		tt.expectAlarms("memberID:2 alarm:NOSPACE\n"),
+		tt.expectStatus(0, 1, 1, 2048*mib, 2000*mib),
+		tt.expectStatus(1, 2, 1, 2048*mib, 2000*mib),
+		tt.expectCommand(0, "", "compact", "4242"),
+		tt.expectCommand(1, "", "defrag", "--command-timeout=5m"),
+		tt.expectStatus(1, 2, 1, 500*mib, 500*mib),
+		tt.expectCommand(0, "", "defrag", "--command-timeout=5m"),
+		tt.expectStatus(0, 1, 1, 500*mib, 500*mib),
+		tt.expectCommand(0, "", "alarm", "disarm"),
+		tt.expectAlarms(""),
+		tt.expectStatus(0, 1, 1, 500*mib, 500*mib),
+		tt.expectStatus(1, 2, 1, 500*mib, 500*mib),
+	)
+
+	status, err := etcd.NewMaintainer(tt.client, nil, etcd.WithMaxDBSize(4096*mib)).Maintain(tt.ctx, tt.members)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(status.LastDefragTime).NotTo(BeNil())
+	tt.Expect(status.Members[1].Alarms).To(BeEmpty())
+}
+
+func TestMaintainNoSpaceAlarmInStatus(t *testing.T) {
+	tt := newMaintainerTest(t)
+	noSpaceStatus := func(member int, id uint64, dbSize int64) *gomock.Call {
+		out := fmt.Sprintf(`[{"Endpoint":"%s","Status":{"header":{"member_id":%d,"revision":4242},"leader":1,"dbSize":%d,"dbSizeInUse":%d,"errors":["memberID:2 alarm:NOSPACE "]}}]`, tt.members[member].Endpoint, id, dbSize, dbSize)
+		return tt.expectCommand(member, out, "endpoint", "status", "-w", "json")
+	}
+	gomock.InOrder(
+		tt.expectAlarms(""),
+		noSpaceStatus(0, 1, 2048*mib),
+		noSpaceStatus(1, 2, 2048*mib),
+		tt.expectCommand(0, "", "compact", "4242"),
+		tt.expectCommand(1, "", "defrag", "--command-timeout=5m"),
+		noSpaceStatus(1, 2, 500*mib),
+		tt.expectCommand(0, "", "defrag", "--command-timeout=5m"),
+		noSpaceStatus(0, 1, 500*mib),
+		tt.expectCommand(0, "", "alarm", "disarm"),
+		tt.expectAlarms(""),
+		tt.expectStatus(0, 1, 1, 500*mib, 500*mib),
+		tt.expectStatus(1, 2, 1, 500*mib, 500*mib),
+	)
+
+	status, err := etcd.NewMaintainer(tt.client, nil, etcd.WithMaxDBSize(4096*mib)).Maintain(tt.ctx, tt.members)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(status.LastDefragTime).NotTo(BeNil())
+	tt.Expect(status.Members[0].Healthy).To(BeTrue())
+	tt.Expect(status.Members[1].Healthy).To(BeTrue())
+	tt.Expect(status.Members[1].Alarms).To(BeEmpty())
+}
+
+func TestMaintainCheckOnly(t *testing.T) {
+	tt := newMaintainerTest(t)
+	tt.expectAlarms("memberID:2 alarm:NOSPACE\n")
+	tt.expectStatus(0, 1, 1, 2048*mib, 100*mib)
+	tt.expectStatus(1, 2, 1, 2048*mib, 100*mib)
+
+	status, err := etcd.NewMaintainer(tt.client, nil, etcd.WithCheckOnly()).Maintain(tt.ctx, tt.members)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(status.Members[1].Alarms).To(Equal([]string{"NOSPACE"}))
+}
+
+func TestMaintainUnhealthyMember(t *testing.T) {
+	tt := newMaintainerTest(t)
+	tt.expectAlarms("")
+	tt.expectStatus(0, 1, 1, 2048*mib, 100*mib)
+	tt.client.EXPECT().RunCommand(tt.ctx, tt.members[1].Host, nil, etcdctl(tt.members[1], "endpoint", "status", "-w", "json")).Return("", errors.New("connection refused"))
+
+	status, err := etcd.NewMaintainer(tt.client, nil).Maintain(tt.ctx, tt.members)
+	tt.Expect(err).To(MatchError("etcd members https://10.0.0.2:2379 are not healthy, skipping maintenance"))
+	tt.Expect(status.Members[1].Healthy).To(BeFalse())
+	tt.Expect(status.Members[1].Message).To(Equal("failed getting status: connection refused"))
+}
+
+func TestMaintainDefragError(t *testing.T) {
+	tt := newMaintainerTest(t)
+	gomock.InOrder(
+		tt.expectAlarms(""),
+		tt.expectStatus(0, 1, 1, 100*mib, 100*mib),
+		tt.expectStatus(1, 2, 1, 2000*mib, 100*mib),
+		tt.client.EXPECT().RunCommand(tt.ctx, tt.members[1].Host, nil, etcdctl(tt.members[1], "defrag", "--command-timeout=5m")).Return("", errors.New("context deadline exceeded")),
+		tt.expectAlarms(""),
+		tt.expectStatus(0, 1, 1, 100*mib, 100*mib),
+		tt.expectStatus(1, 2, 1, 2000*mib, 100*mib),
+	)
+
+	status, err := etcd.NewMaintainer(tt.client, nil).Maintain(tt.ctx, tt.members)
+	tt.Expect(err).To(MatchError("failed defragmenting etcd member https://10.0.0.2:2379: context deadline exceeded"))
+	tt.Expect(status.LastDefragTime).To(BeNil())
+	tt.Expect(status.Members).To(HaveLen(2))
+}
+
+func TestMembersFromEndpoints(t *testing.T) {
+	g := NewWithT(t)
+	host := func(address string) executables.SSHHost {
+		return executables.SSHHost{Address: address, User: "ec2-user"}
+	}
+
+	members, err := etcd.MembersFromEndpoints("https://10.0.0.1:2379, https://10.0.0.2:2379", host)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(members).To(Equal([]etcd.Member{
+		{Endpoint: "https://10.0.0.1:2379", Host: executables.SSHHost{Address: "10.0.0.1", User: "ec2-user"}},
+		{Endpoint: "https://10.0.0.2:2379", Host: executables.SSHHost{Address: "10.0.0.2", User: "ec2-user"}},
+	}))
+
+	_, err = etcd.MembersFromEndpoints("", host)
+	g.Expect(err).To(MatchError("no etcd endpoints, the etcd cluster might not be ready"))
+}
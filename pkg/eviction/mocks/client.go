@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/eviction/workload.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/api/storage/v1"
+)
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// CordonNode mocks base method.
+func (m *MockKubectlClient) CordonNode(ctx context.Context, node string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, node}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CordonNode", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CordonNode indicates an expected call of CordonNode.
+func (mr *MockKubectlClientMockRecorder) CordonNode(ctx, node interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, node}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CordonNode", reflect.TypeOf((*MockKubectlClient)(nil).CordonNode), varargs...)
+}
+
+// DeletePod mocks base method.
+func (m *MockKubectlClient) DeletePod(ctx context.Context, name, namespace string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, name, namespace}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeletePod", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePod indicates an expected call of DeletePod.
+func (mr *MockKubectlClientMockRecorder) DeletePod(ctx, name, namespace interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, name, namespace}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePod", reflect.TypeOf((*MockKubectlClient)(nil).DeletePod), varargs...)
+}
+
+// DeleteService mocks base method.
+func (m *MockKubectlClient) DeleteService(ctx context.Context, name, namespace string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, name, namespace}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteService", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteService indicates an expected call of DeleteService.
+func (mr *MockKubectlClientMockRecorder) DeleteService(ctx, name, namespace interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, name, namespace}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockKubectlClient)(nil).DeleteService), varargs...)
+}
+
+// GetNodes mocks base method.
+func (m *MockKubectlClient) GetNodes(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.Node, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetNodes", varargs...)
+	ret0, _ := ret[0].([]v1.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodes indicates an expected call of GetNodes.
+func (mr *MockKubectlClientMockRecorder) GetNodes(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockKubectlClient)(nil).GetNodes), varargs...)
+}
+
+// GetPods mocks base method.
+func (m *MockKubectlClient) GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.Pod, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPods", varargs...)
+	ret0, _ := ret[0].([]v1.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPods indicates an expected call of GetPods.
+func (mr *MockKubectlClientMockRecorder) GetPods(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPods", reflect.TypeOf((*MockKubectlClient)(nil).GetPods), varargs...)
+}
+
+// GetServices mocks base method.
+func (m *MockKubectlClient) GetServices(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.Service, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetServices", varargs...)
+	ret0, _ := ret[0].([]v1.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServices indicates an expected call of GetServices.
+func (mr *MockKubectlClientMockRecorder) GetServices(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServices", reflect.TypeOf((*MockKubectlClient)(nil).GetServices), varargs...)
+}
+
+// GetVolumeAttachments mocks base method.
+func (m *MockKubectlClient) GetVolumeAttachments(ctx context.Context, opts ...executables.KubectlOpt) ([]v10.VolumeAttachment, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVolumeAttachments", varargs...)
+	ret0, _ := ret[0].([]v10.VolumeAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolumeAttachments indicates an expected call of GetVolumeAttachments.
+func (mr *MockKubectlClientMockRecorder) GetVolumeAttachments(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumeAttachments", reflect.TypeOf((*MockKubectlClient)(nil).GetVolumeAttachments), varargs...)
+}
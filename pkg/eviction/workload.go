@@ -0,0 +1,182 @@
+package eviction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	defaultTimeout      = 10 * time.Minute
+	defaultPollInterval = 5 * time.Second
+)
+
+type KubectlClient interface {
+	GetNodes(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Node, error)
+	CordonNode(ctx context.Context, node string, opts ...executables.KubectlOpt) error
+	GetServices(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Service, error)
+	DeleteService(ctx context.Context, name, namespace string, opts ...executables.KubectlOpt) error
+	GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Pod, error)
+	DeletePod(ctx context.Context, name, namespace string, opts ...executables.KubectlOpt) error
+	GetVolumeAttachments(ctx context.Context, opts ...executables.KubectlOpt) ([]storagev1.VolumeAttachment, error)
+}
+
+// Workload evicts the workloads of a cluster before it's deleted, so the infrastructure resources
+// they hold, like load balancers and volumes, are released instead of leaked
+type Workload struct {
+	client       KubectlClient
+	cluster      *types.Cluster
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+type WorkloadOpt func(*Workload)
+
+// WithTimeout bounds the time to wait for the volumes to be detached. Defaults to 10 minutes
+func WithTimeout(timeout time.Duration) WorkloadOpt {
+	return func(w *Workload) {
+		if timeout > 0 {
+			w.timeout = timeout
+		}
+	}
+}
+
+// WithPollInterval sets how often the volume attachments are checked
+func WithPollInterval(interval time.Duration) WorkloadOpt {
+	return func(w *Workload) {
+		w.pollInterval = interval
+	}
+}
+
+// NewWorkload returns a Workload that evicts the workloads of the cluster
+func NewWorkload(client KubectlClient, cluster *types.Cluster, opts ...WorkloadOpt) *Workload {
+	w := &Workload{
+		client:       client,
+		cluster:      cluster,
+		timeout:      defaultTimeout,
+		pollInterval: defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Evict cordons all the nodes so no pod is scheduled again, deletes the LoadBalancer services to release
+// their load balancers, deletes the pods with persistent volumes and waits until all the volumes are detached
+func (w *Workload) Evict(ctx context.Context) error {
+	if err := w.cordonNodes(ctx); err != nil {
+		return err
+	}
+	if err := w.deleteLoadBalancers(ctx); err != nil {
+		return err
+	}
+	if err := w.deletePodsWithVolumes(ctx); err != nil {
+		return err
+	}
+
+	return w.waitForVolumesDetached(ctx)
+}
+
+func (w *Workload) cordonNodes(ctx context.Context) error {
+	nodes, err := w.client.GetNodes(ctx, executables.WithCluster(w.cluster))
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		logger.V(3).Info("Cordoning node", "node", node.Name)
+		if err := w.client.CordonNode(ctx, node.Name, executables.WithCluster(w.cluster)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *Workload) deleteLoadBalancers(ctx context.Context) error {
+	services, err := w.client.GetServices(ctx, executables.WithCluster(w.cluster), executables.WithAllNamespaces())
+	if err != nil {
+		return err
+	}
+	for _, service := range services {
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		logger.V(3).Info("Deleting LoadBalancer service", "service", service.Name, "namespace", service.Namespace)
+		if err := w.client.DeleteService(ctx, service.Name, service.Namespace, executables.WithCluster(w.cluster)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deletePodsWithVolumes deletes the pods that mount persistent volume claims. Since all the nodes are
+// cordoned, the pods recreated by their controllers stay pending and the volumes are detached
+func (w *Workload) deletePodsWithVolumes(ctx context.Context) error {
+	pods, err := w.client.GetPods(ctx, executables.WithCluster(w.cluster), executables.WithAllNamespaces())
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || !mountsClaims(pod) {
+			continue
+		}
+		logger.V(3).Info("Deleting pod with persistent volumes", "pod", pod.Name, "namespace", pod.Namespace)
+		if err := w.client.DeletePod(ctx, pod.Name, pod.Namespace, executables.WithCluster(w.cluster)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mountsClaims(pod corev1.Pod) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Workload) waitForVolumesDetached(ctx context.Context) error {
+	timeout := retrier.Scale(w.timeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		attachments, err := w.client.GetVolumeAttachments(ctx, executables.WithCluster(w.cluster))
+		if err != nil {
+			return err
+		}
+		if len(attachments) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %d volumes to be detached, first one is %s", timeout, len(attachments), attachmentSource(attachments[0]))
+		}
+		logger.V(4).Info("Waiting for volumes to be detached", "attached", len(attachments))
+
+		if err = retrier.Sleep(ctx, w.pollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+func attachmentSource(a storagev1.VolumeAttachment) string {
+	if a.Spec.Source.PersistentVolumeName != nil {
+		return fmt.Sprintf("volume %s in node %s", *a.Spec.Source.PersistentVolumeName, a.Spec.NodeName)
+	}
+	return fmt.Sprintf("attachment %s in node %s", a.Name, a.Spec.NodeName)
+}
@@ -0,0 +1,105 @@
+package eviction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/eviction"
+	"github.com/aws/eks-anywhere/pkg/eviction/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type workloadTest struct {
+	*WithT
+	ctx     context.Context
+	client  *mocks.MockKubectlClient
+	cluster *types.Cluster
+}
+
+func newWorkloadTest(t *testing.T) *workloadTest {
+	ctrl := gomock.NewController(t)
+	return &workloadTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  mocks.NewMockKubectlClient(ctrl),
+		cluster: &types.Cluster{Name: "w01", KubeconfigFile: "w01.kubeconfig"},
+	}
+}
+
+func (tt *workloadTest) workload(opts ...eviction.WorkloadOpt) *eviction.Workload {
+	return eviction.NewWorkload(tt.client, tt.cluster, append([]eviction.WorkloadOpt{eviction.WithPollInterval(time.Millisecond)}, opts...)...)
+}
+
+func (tt *workloadTest) expectCleanup(nodes []corev1.Node, services []corev1.Service, pods []corev1.Pod) {
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return(nodes, nil)
+	tt.client.EXPECT().GetServices(tt.ctx, gomock.Any(), gomock.Any()).Return(services, nil)
+	tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return(pods, nil)
+}
+
+func claimPod(name, node string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Volumes: []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-" + name}},
+			}},
+		},
+	}
+}
+
+func TestWorkloadEvict(t *testing.T) {
+	tt := newWorkloadTest(t)
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cp"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "md-0"}},
+	}
+	services := []corev1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "apps"}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "apps"}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+	}
+	pods := []corev1.Pod{
+		claimPod("db-0", "md-0"),
+		claimPod("db-1", ""),
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"}, Spec: corev1.PodSpec{NodeName: "md-0"}},
+	}
+	tt.expectCleanup(nodes, services, pods)
+	tt.client.EXPECT().CordonNode(tt.ctx, "md-0", gomock.Any())
+	tt.client.EXPECT().DeleteService(tt.ctx, "ingress", "apps", gomock.Any())
+	tt.client.EXPECT().DeletePod(tt.ctx, "db-0", "apps", gomock.Any())
+	gomock.InOrder(
+		tt.client.EXPECT().GetVolumeAttachments(tt.ctx, gomock.Any()).Return([]storagev1.VolumeAttachment{{}}, nil),
+		tt.client.EXPECT().GetVolumeAttachments(tt.ctx, gomock.Any()).Return(nil, nil),
+	)
+
+	tt.Expect(tt.workload().Evict(tt.ctx)).To(Succeed())
+}
+
+func TestWorkloadEvictTimesOutWaitingForVolumes(t *testing.T) {
+	tt := newWorkloadTest(t)
+	pv := "pvc-1234"
+	tt.expectCleanup(nil, nil, nil)
+	tt.client.EXPECT().GetVolumeAttachments(tt.ctx, gomock.Any()).Return([]storagev1.VolumeAttachment{{
+		Spec: storagev1.VolumeAttachmentSpec{NodeName: "md-0", Source: storagev1.VolumeAttachmentSource{PersistentVolumeName: &pv}},
+	}}, nil).MinTimes(1)
+
+	err := tt.workload(eviction.WithTimeout(5 * time.Millisecond)).Evict(tt.ctx)
+	tt.Expect(err).To(MatchError(ContainSubstring("waiting for 1 volumes to be detached, first one is volume pvc-1234 in node md-0")))
+}
+
+func TestWorkloadEvictCordonError(t *testing.T) {
+	tt := newWorkloadTest(t)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "md-0"}}}, nil)
+	tt.client.EXPECT().CordonNode(tt.ctx, "md-0", gomock.Any()).Return(errors.New("error cordoning node md-0"))
+
+	tt.Expect(tt.workload().Evict(tt.ctx)).To(MatchError("error cordoning node md-0"))
+}
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/filewriter"
-	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 const defaultEksaImage = "public.ecr.aws/l0g8r8j6/eks-anywhere-cli-tools:v0.1.0-eks-a-v0.0.0-dev-build.529"
@@ -18,6 +17,8 @@ type ExecutableBuilder struct {
 	mountDirs  []string
 	workingDir string
 	container  *dockerContainer
+	env        map[string]string
+	inMemory   bool
 }
 
 func (b *ExecutableBuilder) BuildKindExecutable(writer filewriter.FileWriter) *Kind {
@@ -56,6 +57,18 @@ func (b *ExecutableBuilder) BuildHelmExecutable() *Helm {
 	return NewHelm(b.buildExecutable(helmPath))
 }
 
+func (b *ExecutableBuilder) BuildVeleroExecutable() *Velero {
+	return NewVelero(b.buildExecutable(veleroPath))
+}
+
+func (b *ExecutableBuilder) BuildIpmitoolExecutable() *Ipmitool {
+	return NewIpmitool(b.buildExecutable(ipmitoolPath))
+}
+
+func (b *ExecutableBuilder) BuildSSHExecutable() *SSH {
+	return NewSSH(b.buildExecutable(sshPath))
+}
+
 func (b *ExecutableBuilder) Close(ctx context.Context) *Troubleshoot {
 	return NewTroubleshoot(b.buildExecutable(troubleshootPath))
 }
@@ -66,24 +79,42 @@ func BuildSonobuoyExecutable() *Sonobuoy {
 	})
 }
 
+// BuildImageBuilderExecutable always uses the local image-builder, it needs the hypervisor tooling of the host
+func BuildImageBuilderExecutable() *ImageBuilder {
+	return NewImageBuilder(&executable{
+		cli: imageBuilderPath,
+	})
+}
+
 func BuildDockerExecutable() *Docker {
 	return NewDocker(&executable{
 		cli: dockerPath,
 	})
 }
 
+// WithEnv sets env vars for every executable of the builder, including the ones already built, like the
+// proxy configuration. The env vars passed to a command override them
+func (b *ExecutableBuilder) WithEnv(env map[string]string) {
+	for k, v := range env {
+		b.env[k] = v
+	}
+}
+
 func (b *ExecutableBuilder) buildExecutable(cli string) Executable {
+	if b.inMemory {
+		return &inMemoryExecutable{cli: cli}
+	}
 	if !b.useDocker {
-		return NewExecutable(cli)
+		return &executable{cli: cli, env: b.env}
 	} else {
-		return NewDockerExecutable(cli, b.container)
+		return &linuxDockerExecutable{cli: cli, dockerContainer: b.container, env: b.env}
 	}
 }
 
 // this is suppose to be only called by executables.builder
 func checkMRToolsDisabled() bool {
 	if env, ok := os.LookupEnv("MR_TOOLS_DISABLE"); ok && strings.EqualFold(env, "true") {
-		logger.Info("Warning: eks-a tools image disabled, using client's executables")
+		l.Info("Warning: eks-a tools image disabled, using client's executables")
 		return true
 	}
 	return false
@@ -103,6 +134,7 @@ func NewExecutableBuilder(ctx context.Context, image string, mountDirs ...string
 		image:      image,
 		mountDirs:  mountDirs,
 		workingDir: currentDir,
+		env:        map[string]string{},
 	}
 
 	if useDocker {
@@ -132,6 +164,17 @@ func NewLocalExecutableBuilder() *ExecutableBuilder {
 	return &ExecutableBuilder{
 		useDocker: false,
 		image:     "",
+		env:       map[string]string{},
+	}
+}
+
+// NewInMemoryExecutableBuilder builds executables that fail when run. The files of a filewriter.InMemoryWriter
+// don't exist in disk, so the CLI tools can't read them: the executables are only there to build the dependencies
+// for the operations that don't need them, like rendering and validating the cluster config
+func NewInMemoryExecutableBuilder() *ExecutableBuilder {
+	return &ExecutableBuilder{
+		inMemory: true,
+		env:      map[string]string{},
 	}
 }
 
@@ -150,6 +193,6 @@ func (c Closer) Close(ctx context.Context) error {
 // It's mostly a helper for defering the close in a oneliner without ignoring the error
 func (c Closer) CheckErr(ctx context.Context) {
 	if err := c(ctx); err != nil {
-		logger.Error(err, "Failed closing container for executables")
+		l.Error(err, "Failed closing container for executables")
 	}
 }
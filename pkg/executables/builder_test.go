@@ -0,0 +1,21 @@
+package executables_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+func TestInMemoryExecutableBuilderExecutablesFail(t *testing.T) {
+	g := NewWithT(t)
+	b := executables.NewInMemoryExecutableBuilder()
+	writer := filewriter.NewInMemoryWriter("cluster")
+
+	clusterctl := b.BuildClusterCtlExecutable(writer)
+	_, err := clusterctl.Execute(context.Background(), "version")
+	g.Expect(err).To(MatchError("clusterctl can't run with in-memory files, it reads its input files from disk"))
+}
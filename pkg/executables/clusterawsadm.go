@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-
-	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 const clusterAwsAdminPath = "clusterawsadm"
@@ -44,7 +42,7 @@ func (c *Clusterawsadm) ListAccessKeys(ctx context.Context, userName string) (st
 }
 
 func (c *Clusterawsadm) DeleteCloudformationStack(ctx context.Context, envs map[string]string, fileName string) error {
-	logger.V(1).Info("Deleting AWS user")
+	l.V(1).Info("Deleting AWS user")
 	_, err := c.ExecuteWithEnv(ctx, envs, "bootstrap", "iam", "delete-cloudformation-stack", "--config", fileName)
 	if err != nil {
 		if strings.Contains(err.Error(), "status code: 400") {
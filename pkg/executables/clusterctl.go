@@ -23,7 +23,6 @@ import (
 const (
 	clusterCtlPath                = "clusterctl"
 	clusterctlConfigFile          = "clusterctl_tmp.yaml"
-	capiPrefix                    = "/generated/overrides"
 	etcdadmBootstrapProviderName  = "etcdadm-bootstrap"
 	etcdadmControllerProviderName = "etcdadm-controller"
 	kubeadmBootstrapProviderName  = "kubeadm"
@@ -61,17 +60,31 @@ func imageRepository(image v1alpha1.Image) string {
 // used by cluster api to install components.
 // See: https://cluster-api.sigs.k8s.io/clusterctl/configuration.html
 func buildOverridesLayer(clusterSpec *cluster.Spec, clusterName string, provider providers.Provider) error {
-	bundle := clusterSpec.VersionsBundle
+	// The layer is in the cluster folder of the workspace, the same folder the writer
+	// passed to NewClusterctl writes to
+	prefix := OverridesLayerDir(clusterName)
 
-	// Adding cluster name to path temporarily following suggestion.
-	//
-	// This adds an implicit dependency between this method
-	// and the writer passed to NewClusterctl
-	// Ideally the writer implementation should be modified to
-	// accept a path and file name and it should create the path in case it
-	// does not exists.
-	prefix := filepath.Join(clusterName, generatedDir, overridesDir)
+	for _, infraBundle := range overridesLayerBundles(clusterSpec, provider) {
+		if err := writeInfrastructureBundle(clusterSpec, prefix, &infraBundle); err != nil {
+			return err
+		}
+	}
 
+	return nil
+}
+
+// OverridesLayerVersions returns the component/version folders of the overrides layer the bundle of the cluster
+// spec references
+func OverridesLayerVersions(clusterSpec *cluster.Spec, provider providers.Provider) []string {
+	var folders []string
+	for _, b := range overridesLayerBundles(clusterSpec, provider) {
+		folders = append(folders, b.FolderName)
+	}
+	return folders
+}
+
+func overridesLayerBundles(clusterSpec *cluster.Spec, provider providers.Provider) []types.InfrastructureBundle {
+	bundle := clusterSpec.VersionsBundle
 	infraBundles := []types.InfrastructureBundle{
 		{
 			FolderName: filepath.Join("cert-manager", bundle.CertManager.Version),
@@ -116,14 +129,10 @@ func buildOverridesLayer(clusterSpec *cluster.Spec, clusterName string, provider
 		},
 	}
 
-	infraBundles = append(infraBundles, *provider.GetInfrastructureBundle(clusterSpec))
-	for _, infraBundle := range infraBundles {
-		if err := writeInfrastructureBundle(clusterSpec, prefix, &infraBundle); err != nil {
-			return err
-		}
+	if infraBundle := provider.GetInfrastructureBundle(clusterSpec); infraBundle != nil {
+		infraBundles = append(infraBundles, *infraBundle)
 	}
-
-	return nil
+	return infraBundles
 }
 
 func writeInfrastructureBundle(clusterSpec *cluster.Spec, rootFolder string, bundle *types.InfrastructureBundle) error {
@@ -149,8 +158,41 @@ func writeInfrastructureBundle(clusterSpec *cluster.Spec, rootFolder string, bun
 	return nil
 }
 
-func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster) error {
-	params := []string{"move", "--to-kubeconfig", to.KubeconfigFile, "--namespace", constants.EksaSystemNamespace}
+// MoveOpt selects the CAPI objects moved by MoveManagement
+type MoveOpt func(*moveOptions)
+
+type moveOptions struct {
+	namespaces []string
+}
+
+// WithMoveNamespace moves all the CAPI clusters in the namespace. Without options, the ones in eksa-system are moved
+func WithMoveNamespace(namespace string) MoveOpt {
+	return func(o *moveOptions) {
+		o.namespaces = append(o.namespaces, namespace)
+	}
+}
+
+// MoveManagement runs a clusterctl move for each namespace selected by the options. clusterctl move can't filter
+// the clusters of a namespace, all of them are moved
+func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster, opts ...MoveOpt) error {
+	o := &moveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.namespaces) == 0 {
+		o.namespaces = []string{constants.EksaSystemNamespace}
+	}
+
+	for _, namespace := range o.namespaces {
+		if err := c.move(ctx, from, to, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Clusterctl) move(ctx context.Context, from, to *types.Cluster, namespace string) error {
+	params := []string{"move", "--to-kubeconfig", to.KubeconfigFile, "--namespace", namespace}
 	if from.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", from.KubeconfigFile)
 	}
@@ -158,7 +200,7 @@ func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster
 	if err != nil {
 		return fmt.Errorf("failed moving management cluster: %v", err)
 	}
-	return err
+	return nil
 }
 
 func (c *Clusterctl) GetWorkloadKubeconfig(ctx context.Context, clusterName string, cluster *types.Cluster) ([]byte, error) {
@@ -217,7 +259,7 @@ func (c *Clusterctl) buildConfig(clusterSpec *cluster.Spec, clusterName string,
 	t := templater.New(c.writer)
 	bundle := clusterSpec.VersionsBundle
 
-	path, err := os.Getwd()
+	overridesLayer, err := filepath.Abs(OverridesLayerDir(clusterName))
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +319,7 @@ func (c *Clusterctl) buildConfig(clusterSpec *cluster.Spec, clusterName string,
 		"KubeadmBootstrapProviderVersion":                 bundle.Bootstrap.Version,
 		"EtcdadmBootstrapProviderVersion":                 bundle.ExternalEtcdBootstrap.Version,
 		"EtcdadmControllerProviderVersion":                bundle.ExternalEtcdController.Version,
-		"dir":                                             path + "/" + clusterName + capiPrefix,
+		"dir":                                             overridesLayer,
 	}
 
 	filePath, err := t.WriteToFile(clusterctlConfigTemplate, data, clusterctlConfigFile)
@@ -22,6 +22,7 @@ import (
 	mockproviders "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workspace"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -40,6 +41,9 @@ func newClusterctlTest(t *testing.T) *clusterctlTest {
 	ctrl := gomock.NewController(t)
 	_, writer := test.NewWriter(t)
 	e := mockexecutables.NewMockExecutable(ctrl)
+	// The overrides layer is written in the cluster folder of the workspace
+	os.Setenv(workspace.EnvVar, t.TempDir())
+	t.Cleanup(func() { os.Unsetenv(workspace.EnvVar) })
 
 	return &clusterctlTest{
 		WithT: NewWithT(t),
@@ -291,6 +295,39 @@ func TestClusterctlMoveManagement(t *testing.T) {
 	}
 }
 
+func TestClusterctlMoveManagementWithOptions(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	writer := mockswriter.NewMockFileWriter(mockCtrl)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	from := &types.Cluster{KubeconfigFile: "from.kubeconfig"}
+	to := &types.Cluster{KubeconfigFile: "to.kubeconfig"}
+	gomock.InOrder(
+		executable.EXPECT().Execute(ctx, "move", "--to-kubeconfig", "to.kubeconfig", "--namespace", "capi-objects", "--kubeconfig", "from.kubeconfig"),
+		executable.EXPECT().Execute(ctx, "move", "--to-kubeconfig", "to.kubeconfig", "--namespace", constants.EksaSystemNamespace, "--kubeconfig", "from.kubeconfig"),
+	)
+
+	c := executables.NewClusterctl(executable, writer)
+	err := c.MoveManagement(ctx, from, to, executables.WithMoveNamespace("capi-objects"), executables.WithMoveNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		t.Fatalf("Clusterctl.MoveManagement() error = %v, want nil", err)
+	}
+}
+
+func TestClusterctlMoveManagementError(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	writer := mockswriter.NewMockFileWriter(mockCtrl)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error from execute"))
+
+	c := executables.NewClusterctl(executable, writer)
+	err := c.MoveManagement(ctx, &types.Cluster{}, &types.Cluster{}, executables.WithMoveNamespace(constants.EksaSystemNamespace), executables.WithMoveNamespace("capi-objects"))
+	if err == nil || err.Error() != "failed moving management cluster: error from execute" {
+		t.Fatalf("Clusterctl.MoveManagement() error = %v, want failed moving", err)
+	}
+}
+
 func TestClusterctlUpgradeAllProvidersSucess(t *testing.T) {
 	tt := newClusterctlTest(t)
 
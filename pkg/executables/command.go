@@ -3,6 +3,10 @@ package executables
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
 )
 
 type commandRunner interface {
@@ -15,6 +19,11 @@ type Command struct {
 	args          []string
 	stdIn         []byte
 	envVars       map[string]string
+	defaultEnv    map[string]string
+	isolatedEnv   bool
+	workingDir    string
+	stdout        io.Writer
+	stderr        io.Writer
 }
 
 func NewCommand(ctx context.Context, commandRunner commandRunner, args ...string) *Command {
@@ -30,11 +39,77 @@ func (c *Command) WithEnvVars(envVars map[string]string) *Command {
 	return c
 }
 
+// withDefaultEnv sets the env vars of the executable, like the proxy ones, which the ones set with WithEnvVars override
+func (c *Command) withDefaultEnv(env map[string]string) *Command {
+	c.defaultEnv = env
+	return c
+}
+
+// WithIsolatedEnv runs the command only with the env vars set with WithEnvVars.
+// By default they are added to the environment of the process running the command
+func (c *Command) WithIsolatedEnv() *Command {
+	c.isolatedEnv = true
+	return c
+}
+
 func (c *Command) WithStdIn(stdIn []byte) *Command {
 	c.stdIn = stdIn
 	return c
 }
 
+// WithWorkingDir runs the command in dir instead of the current directory.
+// For executables running in the tools container, dir needs to be mounted in it
+func (c *Command) WithWorkingDir(dir string) *Command {
+	c.workingDir = dir
+	return c
+}
+
+// WithStdout copies the stdout of the command to w as it's written. Run still returns the whole stdout
+func (c *Command) WithStdout(w io.Writer) *Command {
+	c.stdout = w
+	return c
+}
+
+// WithStderr copies the stderr of the command to w as it's written, which is useful for tools that report
+// their progress in stderr. Run still returns the stderr as the error when the command fails
+func (c *Command) WithStderr(w io.Writer) *Command {
+	c.stderr = w
+	return c
+}
+
 func (c *Command) Run() (out bytes.Buffer, err error) {
 	return c.commandRunner.Run(c)
 }
+
+// sortedEnvVars returns the env vars of the command in the KEY=value format, sorted by key
+func (c *Command) sortedEnvVars() []string {
+	env := make(map[string]string, len(c.defaultEnv)+len(c.envVars))
+	for k, v := range c.defaultEnv {
+		env[k] = v
+	}
+	for k, v := range c.envVars {
+		env[k] = v
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	envVars := make([]string, 0, len(keys))
+	for _, k := range keys {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, env[k]))
+	}
+
+	return envVars
+}
+
+// environ returns the environment to run the command with
+func (c *Command) environ() []string {
+	if c.isolatedEnv {
+		return c.sortedEnvVars()
+	}
+
+	return append(os.Environ(), c.sortedEnvVars()...)
+}
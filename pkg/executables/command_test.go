@@ -0,0 +1,90 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+func TestCommandRunWithWorkingDir(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	out, err := executables.NewExecutable("pwd").Command(context.Background()).WithWorkingDir(dir).Run()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(strings.TrimSpace(out.String())).To(Equal(dir))
+}
+
+func TestCommandRunWithEnvVars(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv("EKSA_TEST_INHERITED", "inherited")
+	defer os.Unsetenv("EKSA_TEST_INHERITED")
+
+	out, err := executables.NewExecutable("sh").
+		Command(context.Background(), "-c", "echo \"$EKSA_TEST_INHERITED|$EKSA_TEST_VAR\"").
+		WithEnvVars(map[string]string{"EKSA_TEST_VAR": "value"}).
+		Run()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.String()).To(Equal("inherited|value\n"))
+	_, found := os.LookupEnv("EKSA_TEST_VAR")
+	g.Expect(found).To(BeFalse(), "it doesn't set the env vars in the current process")
+}
+
+func TestCommandRunWithIsolatedEnv(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv("EKSA_TEST_INHERITED", "inherited")
+	defer os.Unsetenv("EKSA_TEST_INHERITED")
+
+	out, err := executables.NewExecutable("/bin/sh").
+		Command(context.Background(), "-c", "echo \"$EKSA_TEST_INHERITED|$EKSA_TEST_VAR\"").
+		WithEnvVars(map[string]string{"EKSA_TEST_VAR": "value"}).
+		WithIsolatedEnv().
+		Run()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.String()).To(Equal("|value\n"))
+}
+
+func TestCommandRunWithOutputSinks(t *testing.T) {
+	g := NewWithT(t)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	out, err := executables.NewExecutable("sh").
+		Command(context.Background(), "-c", "echo out; echo progress >&2").
+		WithStdout(stdout).
+		WithStderr(stderr).
+		Run()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.String()).To(Equal("out\n"))
+	g.Expect(stdout.String()).To(Equal("out\n"))
+	g.Expect(stderr.String()).To(Equal("progress\n"))
+}
+
+func TestCommandRunWithStderrSinkError(t *testing.T) {
+	g := NewWithT(t)
+	stderr := &bytes.Buffer{}
+
+	_, err := executables.NewExecutable("sh").
+		Command(context.Background(), "-c", "echo failed >&2; exit 1").
+		WithStderr(stderr).
+		Run()
+	g.Expect(err).To(MatchError("failed\n"))
+	g.Expect(stderr.String()).To(Equal("failed\n"))
+}
+
+func TestLogWriter(t *testing.T) {
+	g := NewWithT(t)
+	w := executables.NewLogWriter(4)
+
+	n, err := w.Write([]byte("first line\nsecond"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(n).To(Equal(17))
+	_, err = w.Write([]byte(" line\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	w.Flush()
+}
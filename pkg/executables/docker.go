@@ -2,11 +2,10 @@ package executables
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
-
-	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 const (
@@ -31,8 +30,50 @@ func (d *Docker) GetDockerLBPort(ctx context.Context, clusterName string) (port
 	}
 }
 
+// ListContainers returns the names of the containers with the label, including the stopped ones
+func (d *Docker) ListContainers(ctx context.Context, label string) ([]string, error) {
+	stdout, err := d.Execute(ctx, "ps", "-a", "--filter", "label="+label, "--format", "{{.Names}}")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing containers with label %s: %v", label, err)
+	}
+
+	var names []string
+	for _, name := range strings.Split(stdout.String(), "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// RemoveContainer stops and removes the container
+func (d *Docker) RemoveContainer(ctx context.Context, name string) error {
+	if _, err := d.Execute(ctx, "rm", "-f", name); err != nil {
+		return fmt.Errorf("failed removing container %s: %v", name, err)
+	}
+	return nil
+}
+
+// RemoveContainerWithVolumes force removes the container and its anonymous volumes
+func (d *Docker) RemoveContainerWithVolumes(ctx context.Context, name string) error {
+	if _, err := d.Execute(ctx, "rm", "-f", "-v", name); err != nil {
+		return fmt.Errorf("failed removing container %s with its volumes: %v", name, err)
+	}
+	return nil
+}
+
+// AppendToHostsFile adds the lines to /etc/hosts in the container
+func (d *Docker) AppendToHostsFile(ctx context.Context, container string, lines []string) error {
+	// the lines are passed as arguments to the script so they don't need quoting
+	params := append([]string{"exec", container, "sh", "-c", `printf '%s\n' "$@" >>/etc/hosts`, "sh"}, lines...)
+	if _, err := d.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("failed adding host entries to container %s: %v", container, err)
+	}
+	return nil
+}
+
 func (d *Docker) PullImage(ctx context.Context, image string) error {
-	logger.V(2).Info("Pulling docker image", "image", image)
+	l.V(2).Info("Pulling docker image", "image", image)
 	if _, err := d.Execute(ctx, "pull", image); err != nil {
 		return err
 	} else {
@@ -41,7 +82,7 @@ func (d *Docker) PullImage(ctx context.Context, image string) error {
 }
 
 func (d *Docker) SetUpCLITools(ctx context.Context, image string) error {
-	logger.V(1).Info("Setting up cli docker dependencies")
+	l.V(1).Info("Setting up cli docker dependencies")
 	if err := d.PullImage(ctx, image); err != nil {
 		return err
 	} else {
@@ -90,7 +131,7 @@ func (d *Docker) CgroupVersion(ctx context.Context) (int, error) {
 
 func (d *Docker) TagImage(ctx context.Context, image string, endpoint string) error {
 	localImage := strings.ReplaceAll(image, defaultRegistry, endpoint)
-	logger.Info("Tagging image", "image", image, "local image", localImage)
+	l.Info("Tagging image", "image", image, "local image", localImage)
 	if _, err := d.Execute(ctx, "tag", image, localImage); err != nil {
 		return err
 	}
@@ -99,7 +140,7 @@ func (d *Docker) TagImage(ctx context.Context, image string, endpoint string) er
 
 func (d *Docker) PushImage(ctx context.Context, image string, endpoint string) error {
 	localImage := strings.ReplaceAll(image, defaultRegistry, endpoint)
-	logger.Info("Pushing", "image", localImage)
+	l.Info("Pushing", "image", localImage)
 	if _, err := d.Execute(ctx, "push", localImage); err != nil {
 		return err
 	}
@@ -108,7 +149,92 @@ func (d *Docker) PushImage(ctx context.Context, image string, endpoint string) e
 
 func (d *Docker) Login(ctx context.Context, endpoint, username, password string) error {
 	params := []string{"login", endpoint, "--username", username, "--password-stdin"}
-	logger.Info(fmt.Sprintf("Logging in to docker registry %s", endpoint))
+	l.Info(fmt.Sprintf("Logging in to docker registry %s", endpoint))
 	_, err := d.ExecuteWithStdin(ctx, []byte(password), params...)
 	return err
 }
+
+// LoginWithStoredCredentials logs in to the registry with the credentials of the credentials store or
+// helper configured for it in the docker config file, so no password needs to be passed to the CLI
+func (d *Docker) LoginWithStoredCredentials(ctx context.Context, endpoint string) error {
+	l.Info(fmt.Sprintf("Logging in to docker registry %s with stored credentials", endpoint))
+	if _, err := d.Execute(ctx, "login", endpoint); err != nil {
+		return fmt.Errorf("failed logging in to registry %s with stored credentials: %v", endpoint, err)
+	}
+	return nil
+}
+
+// ImageManifest is the manifest or manifest list of an image in a registry
+type ImageManifest struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	MediaType     string               `json:"mediaType"`
+	Manifests     []ImageManifestEntry `json:"manifests,omitempty"`
+}
+
+// ImageManifestEntry is the manifest of one of the platforms of a manifest list
+type ImageManifestEntry struct {
+	MediaType string        `json:"mediaType"`
+	Digest    string        `json:"digest"`
+	Platform  ImagePlatform `json:"platform"`
+}
+
+type ImagePlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// InspectManifest fetches the manifest of the image from its registry, without pulling the image
+func (d *Docker) InspectManifest(ctx context.Context, image string) (*ImageManifest, error) {
+	stdout, err := d.Execute(ctx, "manifest", "inspect", image)
+	if err != nil {
+		return nil, fmt.Errorf("failed inspecting manifest of image %s: %v", image, err)
+	}
+
+	manifest := &ImageManifest{}
+	if err = json.Unmarshal(stdout.Bytes(), manifest); err != nil {
+		return nil, fmt.Errorf("failed parsing manifest of image %s: %v", image, err)
+	}
+
+	return manifest, nil
+}
+
+// ImageExistsInRegistry checks if the registry has a manifest for the image
+func (d *Docker) ImageExistsInRegistry(ctx context.Context, image string) (bool, error) {
+	if _, err := d.Execute(ctx, "manifest", "inspect", image); err != nil {
+		if strings.Contains(err.Error(), "no such manifest") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed inspecting manifest of image %s: %v", image, err)
+	}
+	return true, nil
+}
+
+// ImageExists checks if the image is present in the local docker image store
+func (d *Docker) ImageExists(ctx context.Context, image string) (bool, error) {
+	if _, err := d.Execute(ctx, "image", "inspect", "--format", "{{.Id}}", image); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "no such image") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed inspecting image %s: %v", image, err)
+	}
+	return true, nil
+}
+
+// SaveImages writes the images, which need to be present locally, to a tar archive
+func (d *Docker) SaveImages(ctx context.Context, file string, images ...string) error {
+	l.V(2).Info("Saving docker images", "file", file, "images", images)
+	params := append([]string{"save", "-o", file}, images...)
+	if _, err := d.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("failed saving images to %s: %v", file, err)
+	}
+	return nil
+}
+
+// LoadImages loads the images from a tar archive created with SaveImages
+func (d *Docker) LoadImages(ctx context.Context, file string) error {
+	l.V(2).Info("Loading docker images", "file", file)
+	if _, err := d.Execute(ctx, "load", "-i", file); err != nil {
+		return fmt.Errorf("failed loading images from %s: %v", file, err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package executables_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -30,6 +31,68 @@ func TestGetDockerLBPort(t *testing.T) {
 	}
 }
 
+func TestDockerListContainers(t *testing.T) {
+	label := "io.x-k8s.kind.cluster=my-cluster"
+	wantNames := []string{"my-cluster-lb", "my-cluster-eks-a-cluster-control-plane"}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "ps", "-a", "--filter", "label="+label, "--format", "{{.Names}}").Return(*bytes.NewBufferString("my-cluster-lb\nmy-cluster-eks-a-cluster-control-plane\n"), nil)
+	d := executables.NewDocker(executable)
+	names, err := d.ListContainers(ctx, label)
+	if err != nil {
+		t.Fatalf("Docker.ListContainers() error = %v, want nil", err)
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("Docker.ListContainers() = %v, want %v", names, wantNames)
+	}
+}
+
+func TestDockerRemoveContainer(t *testing.T) {
+	name := "my-cluster-md-0-abcde"
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "rm", "-f", name).Return(bytes.Buffer{}, nil)
+	d := executables.NewDocker(executable)
+	if err := d.RemoveContainer(ctx, name); err != nil {
+		t.Fatalf("Docker.RemoveContainer() error = %v, want nil", err)
+	}
+}
+
+func TestDockerRemoveContainerWithVolumes(t *testing.T) {
+	name := "my-cluster-eks-a-cluster-control-plane"
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "rm", "-f", "-v", name).Return(bytes.Buffer{}, nil)
+	d := executables.NewDocker(executable)
+	if err := d.RemoveContainerWithVolumes(ctx, name); err != nil {
+		t.Fatalf("Docker.RemoveContainerWithVolumes() error = %v, want nil", err)
+	}
+}
+
+func TestDockerAppendToHostsFile(t *testing.T) {
+	container := "my-cluster-eks-a-cluster-control-plane"
+	lines := []string{"10.0.0.10 registry.lab.local registry", "10.0.0.11 vcenter.lab.local"}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "exec", container, "sh", "-c", `printf '%s\n' "$@" >>/etc/hosts`, "sh", lines[0], lines[1]).Return(bytes.Buffer{}, nil)
+	d := executables.NewDocker(executable)
+	if err := d.AppendToHostsFile(ctx, container, lines); err != nil {
+		t.Fatalf("Docker.AppendToHostsFile() error = %v, want nil", err)
+	}
+}
+
 func TestDockerPullImage(t *testing.T) {
 	image := "test_image"
 
@@ -97,3 +160,105 @@ func TestDockerCgroupVersion(t *testing.T) {
 		t.Fatalf("Docker.Version() version = %v, want %v", cgroupVersion, wantVersion)
 	}
 }
+
+func TestDockerLoginWithStoredCredentials(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "login", "123456789012.dkr.ecr.us-west-2.amazonaws.com").Return(bytes.Buffer{}, nil)
+	d := executables.NewDocker(executable)
+	if err := d.LoginWithStoredCredentials(ctx, "123456789012.dkr.ecr.us-west-2.amazonaws.com"); err != nil {
+		t.Fatalf("Docker.LoginWithStoredCredentials() error = %v, want nil", err)
+	}
+}
+
+func TestDockerInspectManifest(t *testing.T) {
+	image := "public.ecr.aws/eks-anywhere/cli-tools:v0.7.0"
+	manifest := `{
+		"schemaVersion": 2,
+		"mediaType": "application/vnd.docker.distribution.manifest.list.v2+json",
+		"manifests": [
+			{"mediaType": "application/vnd.docker.distribution.manifest.v2+json", "digest": "sha256:abc", "platform": {"architecture": "amd64", "os": "linux"}}
+		]
+	}`
+	want := &executables.ImageManifest{
+		SchemaVersion: 2,
+		MediaType:     "application/vnd.docker.distribution.manifest.list.v2+json",
+		Manifests: []executables.ImageManifestEntry{
+			{
+				MediaType: "application/vnd.docker.distribution.manifest.v2+json",
+				Digest:    "sha256:abc",
+				Platform:  executables.ImagePlatform{Architecture: "amd64", OS: "linux"},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "manifest", "inspect", image).Return(*bytes.NewBufferString(manifest), nil)
+	d := executables.NewDocker(executable)
+	got, err := d.InspectManifest(ctx, image)
+	if err != nil {
+		t.Fatalf("Docker.InspectManifest() error = %v, want nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Docker.InspectManifest() = %v, want %v", got, want)
+	}
+}
+
+func TestDockerImageExistsInRegistryFalse(t *testing.T) {
+	image := "registry.local/eks-anywhere/cli-tools:v0.7.0"
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "manifest", "inspect", image).Return(bytes.Buffer{}, errors.New("no such manifest: "+image))
+	d := executables.NewDocker(executable)
+	exists, err := d.ImageExistsInRegistry(ctx, image)
+	if err != nil {
+		t.Fatalf("Docker.ImageExistsInRegistry() error = %v, want nil", err)
+	}
+	if exists {
+		t.Fatal("Docker.ImageExistsInRegistry() = true, want false")
+	}
+}
+
+func TestDockerImageExistsFalse(t *testing.T) {
+	image := "public.ecr.aws/eks-anywhere/cli-tools:v0.7.0"
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "image", "inspect", "--format", "{{.Id}}", image).Return(bytes.Buffer{}, errors.New("Error: No such image: "+image))
+	d := executables.NewDocker(executable)
+	exists, err := d.ImageExists(ctx, image)
+	if err != nil {
+		t.Fatalf("Docker.ImageExists() error = %v, want nil", err)
+	}
+	if exists {
+		t.Fatal("Docker.ImageExists() = true, want false")
+	}
+}
+
+func TestDockerSaveAndLoadImages(t *testing.T) {
+	images := []string{"public.ecr.aws/eks-anywhere/cli-tools:v0.7.0", "public.ecr.aws/eks-anywhere/cluster-controller:v0.7.0"}
+
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "save", "-o", "images.tar", images[0], images[1]).Return(bytes.Buffer{}, nil)
+	executable.EXPECT().Execute(ctx, "load", "-i", "images.tar").Return(bytes.Buffer{}, nil)
+	d := executables.NewDocker(executable)
+	if err := d.SaveImages(ctx, "images.tar", images...); err != nil {
+		t.Fatalf("Docker.SaveImages() error = %v, want nil", err)
+	}
+	if err := d.LoadImages(ctx, "images.tar"); err != nil {
+		t.Fatalf("Docker.LoadImages() error = %v, want nil", err)
+	}
+}
@@ -7,8 +7,6 @@ import (
 	"strconv"
 	"sync"
 	"time"
-
-	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 type dockerContainer struct {
@@ -57,7 +55,7 @@ func (d *dockerContainer) init(ctx context.Context) error {
 		}
 
 		// start container and keep it running in the background
-		logger.V(3).Info("Initializing long running container", "name", d.containerName, "image", d.image)
+		l.V(3).Info("Initializing long running container", "name", d.containerName, "image", d.image)
 		params = append(params, "--entrypoint", "sleep", d.image, "infinity")
 		_, err = d.dockerBinary.Execute(ctx, params...)
 	})
@@ -72,7 +70,7 @@ func (d *dockerContainer) close(ctx context.Context) error {
 
 	var err error
 	d.closeOnce.Do(func() {
-		logger.V(3).Info("Cleaning up long running container", "name", d.containerName)
+		l.V(3).Info("Cleaning up long running container", "name", d.containerName)
 		_, err = d.dockerBinary.Execute(ctx, "rm", "-f", "-v", d.containerName)
 	})
 
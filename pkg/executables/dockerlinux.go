@@ -3,7 +3,9 @@ package executables
 import (
 	"bytes"
 	"context"
-	"fmt"
+	"os/exec"
+
+	"github.com/aws/eks-anywhere/pkg/faultinjection"
 )
 
 const containerNamePrefix = "eksa_"
@@ -11,6 +13,7 @@ const containerNamePrefix = "eksa_"
 type linuxDockerExecutable struct {
 	*dockerContainer
 	cli string
+	env map[string]string
 }
 
 // This currently returns a linuxDockerExecutable, but if we support other types of docker executables we can change
@@ -35,24 +38,36 @@ func (e *linuxDockerExecutable) ExecuteWithEnv(ctx context.Context, envs map[str
 }
 
 func (e *linuxDockerExecutable) Command(ctx context.Context, args ...string) *Command {
-	return NewCommand(ctx, e, args...)
+	return NewCommand(ctx, e, args...).withDefaultEnv(e.env)
 }
 
 func (e *linuxDockerExecutable) Run(cmd *Command) (stdout bytes.Buffer, err error) {
-	return execute(cmd.ctx, "docker", cmd.stdIn, e.buildCommand(cmd.envVars, e.cli, cmd.args...)...)
+	if err = faultinjection.Exec(e.cli, cmd.args); err != nil {
+		return stdout, err
+	}
+	return execute(exec.CommandContext(cmd.ctx, "docker", e.buildCommand(cmd)...), cmd)
 }
 
-func (e *linuxDockerExecutable) buildCommand(envs map[string]string, cli string, args ...string) []string {
-	var envVars []string
-	for k, v := range envs {
-		envVars = append(envVars, "-e", fmt.Sprintf("%s=%s", k, v))
+// buildCommand returns the docker exec args to run the command in the container. An isolated
+// environment is set with env -i, since docker exec always adds the environment of the container
+func (e *linuxDockerExecutable) buildCommand(cmd *Command) []string {
+	dockerCommands := []string{"exec", "-i"}
+	if cmd.workingDir != "" {
+		dockerCommands = append(dockerCommands, "-w", cmd.workingDir)
 	}
 
-	dockerCommands := []string{"exec", "-i"}
-	dockerCommands = append(dockerCommands, envVars...)
+	if cmd.isolatedEnv {
+		dockerCommands = append(dockerCommands, e.containerName, "env", "-i")
+		dockerCommands = append(dockerCommands, cmd.sortedEnvVars()...)
+	} else {
+		for _, envVar := range cmd.sortedEnvVars() {
+			dockerCommands = append(dockerCommands, "-e", envVar)
+		}
+		dockerCommands = append(dockerCommands, e.containerName)
+	}
 
-	dockerCommands = append(dockerCommands, e.containerName, e.cli)
-	dockerCommands = append(dockerCommands, args...)
+	dockerCommands = append(dockerCommands, e.cli)
+	dockerCommands = append(dockerCommands, cmd.args...)
 
 	return dockerCommands
 }
@@ -5,13 +5,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
 
+	"github.com/aws/eks-anywhere/pkg/faultinjection"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
+var l = logger.For(logger.Executables)
+
 const (
 	redactMask = "*****"
 )
@@ -20,6 +24,7 @@ var redactedEnvKeys = []string{vSphereUsernameKey, vSpherePasswordKey}
 
 type executable struct {
 	cli string
+	env map[string]string
 }
 
 type Executable interface {
@@ -50,63 +55,118 @@ func (e *executable) ExecuteWithEnv(ctx context.Context, envs map[string]string,
 }
 
 func (e *executable) Command(ctx context.Context, args ...string) *Command {
-	return NewCommand(ctx, e, args...)
+	return NewCommand(ctx, e, args...).withDefaultEnv(e.env)
 }
 
 func (e *executable) Run(cmd *Command) (stdout bytes.Buffer, err error) {
-	for k, v := range cmd.envVars {
-		os.Setenv(k, v)
+	if err = faultinjection.Exec(e.cli, cmd.args); err != nil {
+		return stdout, err
 	}
-	return execute(cmd.ctx, e.cli, cmd.stdIn, cmd.args...)
+	c := exec.CommandContext(cmd.ctx, e.cli, cmd.args...)
+	c.Dir = cmd.workingDir
+	c.Env = cmd.environ()
+	return execute(c, cmd)
 }
 
 func (e *executable) Close(ctx context.Context) error {
 	return nil
 }
 
-func redactCreds(cmd string) string {
+// inMemoryExecutable is an executable of NewInMemoryExecutableBuilder, it can't run
+type inMemoryExecutable struct {
+	cli string
+}
+
+func (e *inMemoryExecutable) Execute(ctx context.Context, args ...string) (stdout bytes.Buffer, err error) {
+	return e.Command(ctx, args...).Run()
+}
+
+func (e *inMemoryExecutable) ExecuteWithStdin(ctx context.Context, in []byte, args ...string) (stdout bytes.Buffer, err error) {
+	return e.Command(ctx, args...).WithStdIn(in).Run()
+}
+
+func (e *inMemoryExecutable) ExecuteWithEnv(ctx context.Context, envs map[string]string, args ...string) (stdout bytes.Buffer, err error) {
+	return e.Command(ctx, args...).WithEnvVars(envs).Run()
+}
+
+func (e *inMemoryExecutable) Command(ctx context.Context, args ...string) *Command {
+	return NewCommand(ctx, e, args...)
+}
+
+func (e *inMemoryExecutable) Run(cmd *Command) (stdout bytes.Buffer, err error) {
+	return stdout, fmt.Errorf("%s can't run with in-memory files, it reads its input files from disk", e.cli)
+}
+
+func redactCreds(cmd string, envVars map[string]string) string {
 	redactedEnvs := []string{}
 	for _, redactedEnvKey := range redactedEnvKeys {
 		if env, found := os.LookupEnv(redactedEnvKey); found {
 			redactedEnvs = append(redactedEnvs, env)
 		}
+		if env, found := envVars[redactedEnvKey]; found {
+			redactedEnvs = append(redactedEnvs, env)
+		}
 	}
 
 	for _, redactedEnv := range redactedEnvs {
+		if redactedEnv == "" {
+			continue
+		}
 		cmd = strings.ReplaceAll(cmd, redactedEnv, redactMask)
 	}
 	return cmd
 }
 
-func execute(ctx context.Context, cli string, in []byte, args ...string) (stdout bytes.Buffer, err error) {
+// flusher is implemented by the output sinks that buffer incomplete lines, like LogWriter
+type flusher interface {
+	Flush()
+}
+
+// withSink returns a writer that writes to w and to sink, if present
+func withSink(w, sink io.Writer) io.Writer {
+	if sink == nil {
+		return w
+	}
+	return io.MultiWriter(w, sink)
+}
+
+func flushSink(sink io.Writer) {
+	if f, ok := sink.(flusher); ok {
+		f.Flush()
+	}
+}
+
+func execute(cmd *exec.Cmd, command *Command) (stdout bytes.Buffer, err error) {
 	var stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, cli, args...)
-	logger.V(6).Info("Executing command", "cmd", redactCreds(cmd.String()))
-	cmd.Stdout = &stdout
-	if logger.MaxLogging() {
-		cmd.Stderr = os.Stderr
+	cli := cmd.Args[0]
+	l.V(6).Info("Executing command", "cmd", redactCreds(cmd.String(), command.envVars))
+	cmd.Stdout = withSink(&stdout, command.stdout)
+	if l.MaxLogging() {
+		cmd.Stderr = withSink(os.Stderr, command.stderr)
 	} else {
-		cmd.Stderr = &stderr
+		cmd.Stderr = withSink(&stderr, command.stderr)
 	}
-	if len(in) != 0 {
-		cmd.Stdin = bytes.NewReader(in)
+	if len(command.stdIn) != 0 {
+		cmd.Stdin = bytes.NewReader(command.stdIn)
 	}
 
 	err = cmd.Run()
+	flushSink(command.stdout)
+	flushSink(command.stderr)
 	if err != nil {
 		if stderr.Len() > 0 {
 			return stdout, errors.New(stderr.String())
 		} else {
-			if !logger.MaxLogging() {
-				logger.V(8).Info(cli, "stdout", stdout.String())
-				logger.V(8).Info(cli, "stderr", stderr.String())
+			if !l.MaxLogging() {
+				l.V(8).Info(cli, "stdout", stdout.String())
+				l.V(8).Info(cli, "stderr", stderr.String())
 			}
 			return stdout, errors.New(fmt.Sprint(err))
 		}
 	}
-	if !logger.MaxLogging() {
-		logger.V(8).Info(cli, "stdout", stdout.String())
-		logger.V(8).Info(cli, "stderr", stderr.String())
+	if !l.MaxLogging() {
+		l.V(8).Info(cli, "stdout", stdout.String())
+		l.V(8).Info(cli, "stderr", stderr.String())
 	}
 	return stdout, nil
 }
@@ -3,8 +3,10 @@ package executables
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -16,6 +18,10 @@ const (
 	privateKeyAlgorithm = "ecdsa"
 )
 
+// fluxComponents are the toolkit components installed by bootstrap. The notification and
+// image automation controllers are not used by EKS-A, so they are skipped
+var fluxComponents = []string{"source-controller", "kustomize-controller", "helm-controller"}
+
 type Flux struct {
 	Executable
 }
@@ -29,8 +35,10 @@ func NewFlux(executable Executable) *Flux {
 // BootstrapToolkitsComponents creates the GitHub repository if it doesn’t exist, and commits the toolkit
 // components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
 // If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
-func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
-	c := gitOpsConfig.Spec.Flux.Github
+// The toolkit version is pinned to the one of the Flux bundle.
+func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	fluxConfig := clusterSpec.GitOpsConfig.Spec.Flux
+	c := fluxConfig.Github
 	params := []string{
 		"bootstrap",
 		gitProvider,
@@ -38,8 +46,12 @@ func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.C
 		"--owner", c.Owner,
 		"--path", c.ClusterConfigPath,
 		"--ssh-key-algorithm", privateKeyAlgorithm,
+		"--components", strings.Join(fluxComponents, ","),
 	}
 
+	if version := fluxVersion(clusterSpec); version != "" {
+		params = append(params, "--version", version)
+	}
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
 	}
@@ -52,6 +64,15 @@ func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.C
 	if c.FluxSystemNamespace != "" {
 		params = append(params, "--namespace", c.FluxSystemNamespace)
 	}
+	if fluxConfig.DisableNetworkPolicy {
+		params = append(params, "--network-policy=false")
+	}
+	if fluxConfig.WatchFluxNamespaceOnly {
+		params = append(params, "--watch-all-namespaces=false")
+	}
+	if l.MaxLogging() {
+		params = append(params, "--verbose")
+	}
 
 	token, err := github.GetGithubAccessTokenFromEnv()
 	if err != nil {
@@ -61,7 +82,8 @@ func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.C
 	env := make(map[string]string)
 	env[githubTokenEnv] = token
 
-	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	// flux reports the bootstrap progress in stderr
+	_, err = f.Command(ctx, params...).WithEnvVars(env).WithStderr(NewLogWriter(4)).Run()
 	if err != nil {
 		return fmt.Errorf("error executing flux bootstrap: %v", err)
 	}
@@ -69,6 +91,14 @@ func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.C
 	return err
 }
 
+// fluxVersion returns the flux2 release of the bundle, without the build metadata eks-a adds to it
+func fluxVersion(clusterSpec *cluster.Spec) string {
+	if clusterSpec.VersionsBundle == nil {
+		return ""
+	}
+	return strings.SplitN(clusterSpec.VersionsBundle.Flux.Version, "+", 2)[0]
+}
+
 func (f *Flux) UninstallToolkitsComponents(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
 	c := gitOpsConfig.Spec.Flux.Github
 	params := []string{
@@ -9,9 +9,11 @@ import (
 	"github.com/golang/mock/gomock"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const (
@@ -70,7 +72,7 @@ func TestFluxInstallGitOpsToolkitsSuccess(t *testing.T) {
 				},
 			},
 			wantExecArgs: []interface{}{
-				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--kubeconfig", "f.kubeconfig",
+				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--components", "source-controller,kustomize-controller,helm-controller", "--version", "v0.17.2", "--kubeconfig", "f.kubeconfig",
 			},
 		},
 		{
@@ -85,7 +87,7 @@ func TestFluxInstallGitOpsToolkitsSuccess(t *testing.T) {
 				},
 			},
 			wantExecArgs: []interface{}{
-				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--personal",
+				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--components", "source-controller,kustomize-controller,helm-controller", "--version", "v0.17.2", "--personal",
 			},
 		},
 		{
@@ -100,7 +102,7 @@ func TestFluxInstallGitOpsToolkitsSuccess(t *testing.T) {
 				},
 			},
 			wantExecArgs: []interface{}{
-				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--branch", "main",
+				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--components", "source-controller,kustomize-controller,helm-controller", "--version", "v0.17.2", "--branch", "main",
 			},
 		},
 		{
@@ -115,7 +117,23 @@ func TestFluxInstallGitOpsToolkitsSuccess(t *testing.T) {
 				},
 			},
 			wantExecArgs: []interface{}{
-				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--namespace", "flux-system",
+				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--components", "source-controller,kustomize-controller,helm-controller", "--version", "v0.17.2", "--namespace", "flux-system",
+			},
+		},
+		{
+			testName: "with network policy disabled and watching only flux namespace",
+			cluster:  &types.Cluster{},
+			fluxConfig: v1alpha1.Flux{
+				Github: v1alpha1.Github{
+					Owner:             owner,
+					Repository:        repo,
+					ClusterConfigPath: path,
+				},
+				DisableNetworkPolicy:   true,
+				WatchFluxNamespaceOnly: true,
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", gitProvider, "--repository", repo, "--owner", owner, "--path", path, "--ssh-key-algorithm", "ecdsa", "--components", "source-controller,kustomize-controller,helm-controller", "--version", "v0.17.2", "--network-policy=false", "--watch-all-namespaces=false",
 			},
 		},
 		{
@@ -125,7 +143,7 @@ func TestFluxInstallGitOpsToolkitsSuccess(t *testing.T) {
 				Github: v1alpha1.Github{},
 			},
 			wantExecArgs: []interface{}{
-				"bootstrap", gitProvider, "--repository", "", "--owner", "", "--path", "", "--ssh-key-algorithm", "ecdsa",
+				"bootstrap", gitProvider, "--repository", "", "--owner", "", "--path", "", "--ssh-key-algorithm", "ecdsa", "--components", "source-controller,kustomize-controller,helm-controller", "--version", "v0.17.2",
 			},
 		},
 	}
@@ -135,20 +153,27 @@ func TestFluxInstallGitOpsToolkitsSuccess(t *testing.T) {
 			ctx := context.Background()
 			executable := mockexecutables.NewMockExecutable(mockCtrl)
 			env := map[string]string{githubToken: validPATValue}
-			gitOpsConfig := v1alpha1.GitOpsConfig{
-				Spec: v1alpha1.GitOpsConfigSpec{
-					Flux: tt.fluxConfig,
+			clusterSpec := &cluster.Spec{
+				GitOpsConfig: &v1alpha1.GitOpsConfig{
+					Spec: v1alpha1.GitOpsConfigSpec{
+						Flux: tt.fluxConfig,
+					},
+				},
+				VersionsBundle: &cluster.VersionsBundle{
+					VersionsBundle: &releasev1alpha1.VersionsBundle{
+						Flux: releasev1alpha1.FluxBundle{Version: "v0.17.2+0a1b2c3d"},
+					},
 				},
 			}
 
-			executable.EXPECT().ExecuteWithEnv(
-				ctx,
-				env,
-				tt.wantExecArgs...,
-			).Return(bytes.Buffer{}, nil)
+			args := make([]string, 0, len(tt.wantExecArgs))
+			for _, arg := range tt.wantExecArgs {
+				args = append(args, arg.(string))
+			}
+			expectCommand(executable, ctx, args...).withEnvVars(env).withStderr(executables.NewLogWriter(4)).to().Return(bytes.Buffer{}, nil)
 
 			f := executables.NewFlux(executable)
-			if err := f.BootstrapToolkitsComponents(ctx, tt.cluster, &gitOpsConfig); err != nil {
+			if err := f.BootstrapToolkitsComponents(ctx, tt.cluster, clusterSpec); err != nil {
 				t.Errorf("flux.BootstrapToolkitsComponents() error = %v, want nil", err)
 			}
 		})
@@ -19,7 +19,6 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -90,7 +89,7 @@ func (g *Govc) Close(ctx context.Context) error {
 }
 
 func (g *Govc) Logout(ctx context.Context) error {
-	logger.V(3).Info("Logging out from current govc session")
+	l.V(3).Info("Logging out from current govc session")
 	if _, err := g.exec(ctx, "session.logout"); err != nil {
 		return fmt.Errorf("govc returned error when logging out: %v", err)
 	}
@@ -114,13 +113,13 @@ func (g *Govc) SearchTemplate(ctx context.Context, datacenter string, machineCon
 	templateJson := templateResponse.String()
 	templateJson = strings.TrimSuffix(templateJson, "\n")
 	if templateJson == "null" || templateJson == "" {
-		logger.V(2).Info(fmt.Sprintf("Template not found: %s", machineConfig.Spec.Template))
+		l.V(2).Info(fmt.Sprintf("Template not found: %s", machineConfig.Spec.Template))
 		return "", nil
 	}
 
 	templateInfo := make([]string, 0)
 	if err = json.Unmarshal([]byte(templateJson), &templateInfo); err != nil {
-		logger.V(2).Info(fmt.Sprintf("Failed unmarshalling govc response: %s, %v", templateJson, err))
+		l.V(2).Info(fmt.Sprintf("Failed unmarshalling govc response: %s, %v", templateJson, err))
 		return "", nil
 	}
 
@@ -136,7 +135,7 @@ func (g *Govc) SearchTemplate(ctx context.Context, datacenter string, machineCon
 		}
 	}
 	if !bTemplateFound {
-		logger.V(2).Info(fmt.Sprintf("Template '%s' not found", machineConfig.Spec.Template))
+		l.V(2).Info(fmt.Sprintf("Template '%s' not found", machineConfig.Spec.Template))
 		return "", nil
 	}
 
@@ -188,6 +187,38 @@ func (g *Govc) DeleteLibraryElement(ctx context.Context, element string) error {
 	return nil
 }
 
+// ListLibraries returns the paths of the content libraries in the vCenter
+func (g *Govc) ListLibraries(ctx context.Context) ([]string, error) {
+	response, err := g.exec(ctx, "library.ls")
+	if err != nil {
+		return nil, fmt.Errorf("govc failed listing libraries: %v", err)
+	}
+
+	return splitLines(response, "library.ls")
+}
+
+// ListLibraryItems returns the paths of the items in a content library
+func (g *Govc) ListLibraryItems(ctx context.Context, library string) ([]string, error) {
+	response, err := g.exec(ctx, "library.ls", libraryPath(library)+"/*")
+	if err != nil {
+		return nil, fmt.Errorf("govc failed listing items in library %s: %v", library, err)
+	}
+
+	return splitLines(response, "library.ls")
+}
+
+// DeleteLibrary deletes a content library with all its items
+func (g *Govc) DeleteLibrary(ctx context.Context, library string) error {
+	if _, err := g.exec(ctx, "library.rm", libraryPath(library)); err != nil {
+		return fmt.Errorf("govc failed deleting library %s: %v", library, err)
+	}
+	return nil
+}
+
+func libraryPath(library string) string {
+	return "/" + strings.Trim(library, "/")
+}
+
 func (g *Govc) ResizeDisk(ctx context.Context, datacenter, template, diskName string, diskSizeInGB int) error {
 	_, err := g.exec(ctx, "vm.disk.change", "-dc", datacenter, "-vm", template, "-disk.name", diskName, "-size", strconv.Itoa(diskSizeInGB)+"G")
 	if err != nil {
@@ -256,6 +287,43 @@ func (g *Govc) GetWorkloadAvailableSpace(ctx context.Context, datastore string)
 	return 0, fmt.Errorf("error getting datastore available space response: %v", err)
 }
 
+// DatastoreFileExists checks if a file or directory exists in the datastore
+func (g *Govc) DatastoreFileExists(ctx context.Context, datastore, path string) (bool, error) {
+	_, err := g.exec(ctx, "datastore.ls", "-ds", datastore, path)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return false, nil
+		}
+		return false, fmt.Errorf("govc failed checking file %s in datastore %s: %v", path, datastore, err)
+	}
+
+	return true, nil
+}
+
+// UploadDatastoreFile uploads the local file src to the dst path of the datastore
+func (g *Govc) UploadDatastoreFile(ctx context.Context, datastore, src, dst string) error {
+	if _, err := g.exec(ctx, "datastore.upload", "-ds", datastore, src, dst); err != nil {
+		return fmt.Errorf("govc failed uploading %s to datastore %s: %v", src, datastore, err)
+	}
+	return nil
+}
+
+// CreateDatastoreDir creates a directory in the datastore, including its parents
+func (g *Govc) CreateDatastoreDir(ctx context.Context, datastore, path string) error {
+	if _, err := g.exec(ctx, "datastore.mkdir", "-ds", datastore, "-p", path); err != nil {
+		return fmt.Errorf("govc failed creating directory %s in datastore %s: %v", path, datastore, err)
+	}
+	return nil
+}
+
+// DeleteDatastoreFile deletes a file or directory from the datastore. A missing file is not an error
+func (g *Govc) DeleteDatastoreFile(ctx context.Context, datastore, path string) error {
+	if _, err := g.exec(ctx, "datastore.rm", "-ds", datastore, "-f", path); err != nil {
+		return fmt.Errorf("govc failed deleting %s from datastore %s: %v", path, datastore, err)
+	}
+	return nil
+}
+
 func (g *Govc) CreateLibrary(ctx context.Context, datastore, library string) error {
 	if _, err := g.exec(ctx, "library.create", "-ds", datastore, library); err != nil {
 		return fmt.Errorf("error creating library %s: %v", library, err)
@@ -264,14 +332,14 @@ func (g *Govc) CreateLibrary(ctx context.Context, datastore, library string) err
 }
 
 func (g *Govc) DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, library, datacenter, datastore, resourcePool string, resizeDisk2 bool) error {
-	logger.V(4).Info("Deploying template", "dir", templateDir, "templateName", templateName)
+	l.V(4).Info("Deploying template", "dir", templateDir, "templateName", templateName)
 	if err := g.deployTemplate(ctx, library, templateName, templateDir, datacenter, datastore, resourcePool); err != nil {
 		return err
 	}
 
 	if resizeDisk2 {
 		// Get devices information template to identify second disk properly
-		logger.V(4).Info("Getting devices info for template")
+		l.V(4).Info("Getting devices info for template")
 		devicesInfo, err := g.DevicesInfo(ctx, datacenter, templateName)
 		if err != nil {
 			return err
@@ -282,7 +350,7 @@ func (g *Govc) DeployTemplateFromLibrary(ctx context.Context, templateDir, templ
 			if strings.EqualFold(deviceLabel, "Hard disk 2") {
 				// Get the name of the hard disk and resize the disk to 20G
 				diskName := deviceInfo.(map[string]interface{})["Name"].(string)
-				logger.V(4).Info("Resizing disk 2 of template to 20G")
+				l.V(4).Info("Resizing disk 2 of template to 20G")
 				err := g.ResizeDisk(ctx, datacenter, templateName, diskName, 20)
 				if err != nil {
 					return fmt.Errorf("error resizing disk 2 to 20G: %v", err)
@@ -294,12 +362,12 @@ func (g *Govc) DeployTemplateFromLibrary(ctx context.Context, templateDir, templ
 
 	templateFullPath := filepath.Join(templateDir, templateName)
 
-	logger.V(4).Info("Taking template snapshot", "templateName", templateFullPath)
+	l.V(4).Info("Taking template snapshot", "templateName", templateFullPath)
 	if err := g.createVMSnapshot(ctx, datacenter, templateFullPath); err != nil {
 		return err
 	}
 
-	logger.V(4).Info("Marking vm as template", "templateName", templateFullPath)
+	l.V(4).Info("Marking vm as template", "templateName", templateFullPath)
 	if err := g.markVMAsTemplate(ctx, datacenter, templateFullPath); err != nil {
 		return err
 	}
@@ -308,7 +376,7 @@ func (g *Govc) DeployTemplateFromLibrary(ctx context.Context, templateDir, templ
 }
 
 func (g *Govc) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
-	logger.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
+	l.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
 	if _, err := g.exec(ctx, "library.import", "-k", "-pull", "-n", name, library, ovaURL); err != nil {
 		return fmt.Errorf("error importing template: %v", err)
 	}
@@ -333,7 +401,7 @@ func (g *Govc) deployTemplate(ctx context.Context, library, templateName, deploy
 
 	bFolderNotFound := false
 	params := []string{"folder.info", deployFolder}
-	err = g.retrier.Retry(func() error {
+	err = g.retrier.RetryWithContext(ctx, func() error {
 		errBuffer, err := g.ExecuteWithEnv(ctx, envMap, params...)
 		errString := strings.ToLower(errBuffer.String())
 		if err != nil {
@@ -347,7 +415,7 @@ func (g *Govc) deployTemplate(ctx context.Context, library, templateName, deploy
 	})
 	if err != nil || bFolderNotFound {
 		params = []string{"folder.create", deployFolder}
-		err = g.retrier.Retry(func() error {
+		err = g.retrier.RetryWithContext(ctx, func() error {
 			errBuffer, err := g.ExecuteWithEnv(ctx, envMap, params...)
 			errString := strings.ToLower(errBuffer.String())
 			if err != nil && !strings.Contains(errString, "already exists") {
@@ -383,7 +451,7 @@ func (g *Govc) DeleteTemplate(ctx context.Context, resourcePool, templatePath st
 	if err := g.removeSnapshotsFromVM(ctx, templatePath); err != nil {
 		return err
 	}
-	if err := g.deleteVM(ctx, templatePath); err != nil {
+	if err := g.DeleteVM(ctx, templatePath); err != nil {
 		return err
 	}
 
@@ -404,7 +472,8 @@ func (g *Govc) removeSnapshotsFromVM(ctx context.Context, path string) error {
 	return nil
 }
 
-func (g *Govc) deleteVM(ctx context.Context, path string) error {
+// DeleteVM powers off and deletes the VM
+func (g *Govc) DeleteVM(ctx context.Context, path string) error {
 	if _, err := g.exec(ctx, "vm.destroy", path); err != nil {
 		return fmt.Errorf("error deleting vm: %v", err)
 	}
@@ -412,12 +481,35 @@ func (g *Govc) deleteVM(ctx context.Context, path string) error {
 }
 
 func (g *Govc) createVMSnapshot(ctx context.Context, datacenter, name string) error {
-	if _, err := g.exec(ctx, "snapshot.create", "-dc", datacenter, "-m=false", "-vm", name, "root"); err != nil {
+	return g.CreateVMSnapshot(ctx, datacenter, name, "root")
+}
+
+// CreateVMSnapshot takes a snapshot of the VM without its memory, as needed by linked clones
+func (g *Govc) CreateVMSnapshot(ctx context.Context, datacenter, vm, snapshot string) error {
+	if _, err := g.exec(ctx, "snapshot.create", "-dc", datacenter, "-m=false", "-vm", vm, snapshot); err != nil {
 		return fmt.Errorf("govc failed taking vm snapshot: %v", err)
 	}
 	return nil
 }
 
+// DeleteVMSnapshot deletes a snapshot of the VM. Its children are kept
+func (g *Govc) DeleteVMSnapshot(ctx context.Context, vm, snapshot string) error {
+	if _, err := g.exec(ctx, "snapshot.remove", "-vm", vm, snapshot); err != nil {
+		return fmt.Errorf("govc failed deleting snapshot %s from vm %s: %v", snapshot, vm, err)
+	}
+	return nil
+}
+
+// ListVMSnapshots returns the names of all the snapshots of the VM
+func (g *Govc) ListVMSnapshots(ctx context.Context, vm string) ([]string, error) {
+	response, err := g.exec(ctx, "snapshot.tree", "-vm", vm)
+	if err != nil {
+		return nil, fmt.Errorf("govc failed listing snapshots of vm %s: %v", vm, err)
+	}
+
+	return splitLines(response, "snapshot.tree")
+}
+
 func (g *Govc) markVMAsTemplate(ctx context.Context, datacenter, vmName string) error {
 	if _, err := g.exec(ctx, "vm.markastemplate", "-dc", datacenter, vmName); err != nil {
 		return fmt.Errorf("error marking VM as template: %v", err)
@@ -436,7 +528,7 @@ func (g *Govc) getEnvMap() (map[string]string, error) {
 			}
 			err := os.Setenv(govcInsecure, "false")
 			if err != nil {
-				logger.Info("Warning: Unable to set <%s>", govcInsecure)
+				l.Info("Warning: Unable to set <%s>", govcInsecure)
 			}
 		}
 	}
@@ -495,14 +587,14 @@ func (g *Govc) CleanupVms(ctx context.Context, clusterName string, dryRun bool)
 	for scanner.Scan() {
 		vmName := scanner.Text()
 		if dryRun {
-			logger.Info("Found ", "vm_name", vmName)
+			l.Info("Found ", "vm_name", vmName)
 			continue
 		}
 		params = strings.Fields("vm.power -off -force " + vmName)
 		result, _ = g.ExecuteWithEnv(ctx, envMap, params...)
 		params = strings.Fields("object.destroy " + vmName)
 		result, _ = g.ExecuteWithEnv(ctx, envMap, params...)
-		logger.Info("Deleted ", "vm_name", vmName)
+		l.Info("Deleted ", "vm_name", vmName)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -524,7 +616,7 @@ func (g *Govc) ValidateVCenterConnection(ctx context.Context, server string) err
 }
 
 func (g *Govc) ValidateVCenterAuthentication(ctx context.Context) error {
-	err := g.retrier.Retry(func() error {
+	err := g.retrier.RetryWithContext(ctx, func() error {
 		_, err := g.exec(ctx, "about", "-k")
 		return err
 	})
@@ -571,7 +663,7 @@ func (g *Govc) ConfigureCertThumbprint(ctx context.Context, server, thumbprint s
 
 func (g *Govc) DatacenterExists(ctx context.Context, datacenter string) (bool, error) {
 	exists := false
-	err := g.retrier.Retry(func() error {
+	err := g.retrier.RetryWithContext(ctx, func() error {
 		result, err := g.exec(ctx, "datacenter.info", datacenter)
 		if err == nil {
 			exists = true
@@ -595,7 +687,7 @@ func (g *Govc) DatacenterExists(ctx context.Context, datacenter string) (bool, e
 func (g *Govc) NetworkExists(ctx context.Context, network string) (bool, error) {
 	exists := false
 
-	err := g.retrier.Retry(func() error {
+	err := g.retrier.RetryWithContext(ctx, func() error {
 		networkResponse, err := g.exec(ctx, "find", "-maxdepth=1", filepath.Dir(network), "-type", "n", "-name", filepath.Base(network))
 		if err != nil {
 			return err
@@ -626,7 +718,7 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 		return err
 	}
 	params := []string{"datastore.info", machineConfig.Spec.Datastore}
-	err = g.retrier.Retry(func() error {
+	err = g.retrier.RetryWithContext(ctx, func() error {
 		_, err = g.ExecuteWithEnv(ctx, envMap, params...)
 		if err != nil {
 			datastorePath := filepath.Dir(machineConfig.Spec.Datastore)
@@ -643,7 +735,7 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 	if err != nil {
 		return fmt.Errorf("failed to get datastore: %v", err)
 	}
-	logger.MarkPass("Datastore validated")
+	l.MarkPass("Datastore validated")
 
 	if len(machineConfig.Spec.Folder) > 0 {
 		machineConfig.Spec.Folder, err = prependPath(vm, machineConfig.Spec.Folder, datacenterConfig.Spec.Datacenter)
@@ -651,7 +743,7 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 			return err
 		}
 		params = []string{"folder.info", machineConfig.Spec.Folder}
-		err = g.retrier.Retry(func() error {
+		err = g.retrier.RetryWithContext(ctx, func() error {
 			_, err := g.ExecuteWithEnv(ctx, envMap, params...)
 			if err != nil {
 				err = g.createFolder(ctx, envMap, machineConfig)
@@ -672,12 +764,12 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 		if err != nil {
 			return fmt.Errorf("failed to get folder: %v", err)
 		}
-		logger.MarkPass("Folder validated")
+		l.MarkPass("Folder validated")
 	}
 
 	var poolInfoResponse bytes.Buffer
 	params = []string{"find", "-json", "/" + datacenterConfig.Spec.Datacenter, "-type", "p", "-name", filepath.Base(machineConfig.Spec.ResourcePool)}
-	err = g.retrier.Retry(func() error {
+	err = g.retrier.RetryWithContext(ctx, func() error {
 		poolInfoResponse, err = g.ExecuteWithEnv(ctx, envMap, params...)
 		return err
 	})
@@ -713,7 +805,7 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 	}
 	machineConfig.Spec.ResourcePool = foundPool
 
-	logger.MarkPass("Resource pool validated")
+	l.MarkPass("Resource pool validated")
 	return nil
 }
 
@@ -722,7 +814,7 @@ func prependPath(folderType FolderType, folderPath string, datacenter string) (s
 	modPath := folderPath
 	if !strings.HasPrefix(folderPath, prefix) {
 		modPath = fmt.Sprintf("%s/%s/%s", prefix, folderType, folderPath)
-		logger.V(4).Info(fmt.Sprintf("Relative %s path specified, using path %s", folderType, modPath))
+		l.V(4).Info(fmt.Sprintf("Relative %s path specified, using path %s", folderType, modPath))
 		return modPath, nil
 	}
 	prefix += fmt.Sprintf("/%s", folderType)
@@ -734,7 +826,7 @@ func prependPath(folderType FolderType, folderPath string, datacenter string) (s
 
 func (g *Govc) createFolder(ctx context.Context, envMap map[string]string, machineConfig *v1alpha1.VSphereMachineConfig) error {
 	params := []string{"folder.create", machineConfig.Spec.Folder}
-	err := g.retrier.Retry(func() error {
+	err := g.retrier.RetryWithContext(ctx, func() error {
 		_, err := g.ExecuteWithEnv(ctx, envMap, params...)
 		if err != nil {
 			return fmt.Errorf("error creating folder: %v", err)
@@ -776,6 +868,35 @@ type tag struct {
 }
 
 func (g *Govc) ListTags(ctx context.Context) ([]string, error) {
+	tags, err := g.listTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tagNames := make([]string, 0, len(tags))
+	for _, t := range tags {
+		tagNames = append(tagNames, t.Name)
+	}
+
+	return tagNames, nil
+}
+
+// TagIDs returns the ids of all the tags in the vCenter by tag name
+func (g *Govc) TagIDs(ctx context.Context) (map[string]string, error) {
+	tags, err := g.listTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(tags))
+	for _, t := range tags {
+		ids[t.Name] = t.Id
+	}
+
+	return ids, nil
+}
+
+func (g *Govc) listTags(ctx context.Context) ([]tag, error) {
 	tagsResponse, err := g.exec(ctx, "tags.ls", "-json")
 	if err != nil {
 		return nil, fmt.Errorf("govc returned error when listing tags: %v", err)
@@ -791,12 +912,67 @@ func (g *Govc) ListTags(ctx context.Context) ([]string, error) {
 		return nil, fmt.Errorf("failed unmarshalling govc response from list tags: %v", err)
 	}
 
-	tagNames := make([]string, 0, len(tags))
-	for _, t := range tags {
-		tagNames = append(tagNames, t.Name)
+	return tags, nil
+}
+
+type objectReference struct {
+	Type  string
+	Value string
+}
+
+// ListVMsWithTag returns the inventory paths of the VMs with the tag attached
+func (g *Govc) ListVMsWithTag(ctx context.Context, tag string) ([]string, error) {
+	response, err := g.exec(ctx, "tags.attached.ls", "-json", tag)
+	if err != nil {
+		return nil, fmt.Errorf("govc returned error when listing objects with tag %s: %v", tag, err)
 	}
 
-	return tagNames, nil
+	objectsJson := response.String()
+	if objectsJson == "null" {
+		return nil, nil
+	}
+
+	objects := make([]objectReference, 0)
+	if err = json.Unmarshal([]byte(objectsJson), &objects); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling govc response to list objects with tag %s: %v", tag, err)
+	}
+
+	params := []string{"ls", "-L"}
+	for _, o := range objects {
+		if o.Type == string(virtualMachine) {
+			params = append(params, fmt.Sprintf("%s:%s", o.Type, o.Value))
+		}
+	}
+	if len(params) == 2 {
+		return nil, nil
+	}
+
+	response, err = g.exec(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("govc returned error when getting the paths of the VMs with tag %s: %v", tag, err)
+	}
+
+	return splitLines(response, "ls")
+}
+
+// TagAttached returns whether the tag is attached to any object
+func (g *Govc) TagAttached(ctx context.Context, tag string) (bool, error) {
+	response, err := g.exec(ctx, "tags.attached.ls", "-json", tag)
+	if err != nil {
+		return false, fmt.Errorf("govc returned error when listing objects with tag %s: %v", tag, err)
+	}
+
+	objectsJson := response.String()
+	if objectsJson == "null" {
+		return false, nil
+	}
+
+	objects := make([]objectReference, 0)
+	if err = json.Unmarshal([]byte(objectsJson), &objects); err != nil {
+		return false, fmt.Errorf("failed unmarshalling govc response to list objects with tag %s: %v", tag, err)
+	}
+
+	return len(objects) > 0, nil
 }
 
 func (g *Govc) AddTag(ctx context.Context, path, tag string) error {
@@ -813,6 +989,21 @@ func (g *Govc) CreateTag(ctx context.Context, tag, category string) error {
 	return nil
 }
 
+// RemoveTag detaches the tag from the object in path
+func (g *Govc) RemoveTag(ctx context.Context, path, tag string) error {
+	if _, err := g.exec(ctx, "tags.detach", tag, path); err != nil {
+		return fmt.Errorf("govc returned error when detaching tag from %s: %v", path, err)
+	}
+	return nil
+}
+
+func (g *Govc) DeleteTag(ctx context.Context, tag string) error {
+	if _, err := g.exec(ctx, "tags.rm", tag); err != nil {
+		return fmt.Errorf("govc returned error when deleting tag %s: %v", tag, err)
+	}
+	return nil
+}
+
 type category struct {
 	Id              string
 	Name            string
@@ -846,12 +1037,28 @@ func (g *Govc) ListCategories(ctx context.Context) ([]string, error) {
 
 type objectType string
 
-const virtualMachine objectType = "VirtualMachine"
+const (
+	virtualMachine objectType = "VirtualMachine"
+	folder         objectType = "Folder"
+)
 
 func (g *Govc) CreateCategoryForVM(ctx context.Context, name string) error {
 	return g.createCategory(ctx, name, []objectType{virtualMachine})
 }
 
+// CreateCategoryForVMAndFolder creates a tag category whose tags can be attached to VMs and folders
+func (g *Govc) CreateCategoryForVMAndFolder(ctx context.Context, name string) error {
+	return g.createCategory(ctx, name, []objectType{virtualMachine, folder})
+}
+
+// DeleteCategory deletes a tag category. It fails if the category still has tags
+func (g *Govc) DeleteCategory(ctx context.Context, name string) error {
+	if _, err := g.exec(ctx, "tags.category.rm", name); err != nil {
+		return fmt.Errorf("govc returned error when deleting category %s: %v", name, err)
+	}
+	return nil
+}
+
 func (g *Govc) createCategory(ctx context.Context, name string, objectTypes []objectType) error {
 	params := []string{"tags.category.create"}
 	for _, t := range objectTypes {
@@ -864,3 +1071,125 @@ func (g *Govc) createCategory(ctx context.Context, name string, objectTypes []ob
 	}
 	return nil
 }
+
+// govc find object types
+const (
+	datacenterObject   = "d"
+	networkObject      = "n"
+	datastoreObject    = "s"
+	resourcePoolObject = "p"
+	folderObject       = "f"
+	vmObject           = "m"
+	hostObject         = "h"
+)
+
+// rootResourcePool is the name of the root resource pool of a cluster or standalone host
+const rootResourcePool = "Resources"
+
+// ListDatacenters returns the names of all the datacenters in the vCenter
+func (g *Govc) ListDatacenters(ctx context.Context) ([]string, error) {
+	paths, err := g.find(ctx, "/", datacenterObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing datacenters: %v", err)
+	}
+
+	datacenters := make([]string, 0, len(paths))
+	for _, p := range paths {
+		datacenters = append(datacenters, strings.TrimPrefix(p, "/"))
+	}
+
+	return datacenters, nil
+}
+
+// ListNetworks returns the full paths of the networks in a datacenter
+func (g *Govc) ListNetworks(ctx context.Context, datacenter string) ([]string, error) {
+	networks, err := g.find(ctx, "/"+datacenter, networkObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing networks in datacenter %s: %v", datacenter, err)
+	}
+
+	return networks, nil
+}
+
+// ListDatastores returns the full paths of the datastores in a datacenter
+func (g *Govc) ListDatastores(ctx context.Context, datacenter string) ([]string, error) {
+	datastores, err := g.find(ctx, "/"+datacenter, datastoreObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing datastores in datacenter %s: %v", datacenter, err)
+	}
+
+	return datastores, nil
+}
+
+// ListResourcePools returns the full paths of the resource pools in a datacenter
+func (g *Govc) ListResourcePools(ctx context.Context, datacenter string) ([]string, error) {
+	pools, err := g.find(ctx, "/"+datacenter, resourcePoolObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing resource pools in datacenter %s: %v", datacenter, err)
+	}
+
+	return pools, nil
+}
+
+// ResourcePoolHosts returns the full paths of the ESXi hosts the machines of a resource pool can be placed on, the
+// hosts of the cluster or standalone host that owns it. The resource pool must be a full path, like
+// /Datacenter/host/Cluster/Resources/pool
+func (g *Govc) ResourcePoolHosts(ctx context.Context, resourcePool string) ([]string, error) {
+	i := strings.Index(resourcePool+"/", "/"+rootResourcePool+"/")
+	if !strings.HasPrefix(resourcePool, "/") || i < 0 {
+		return nil, fmt.Errorf("resource pool %s is not a full path, the hosts it places the machines on can't be found", resourcePool)
+	}
+
+	hosts, err := g.find(ctx, resourcePool[:i], hostObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing the hosts of resource pool %s: %v", resourcePool, err)
+	}
+
+	return hosts, nil
+}
+
+// ListVMFolders returns the full paths of the vm folders in a datacenter
+func (g *Govc) ListVMFolders(ctx context.Context, datacenter string) ([]string, error) {
+	folders, err := g.find(ctx, fmt.Sprintf("/%s/%s", datacenter, vm), folderObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing vm folders in datacenter %s: %v", datacenter, err)
+	}
+
+	return folders, nil
+}
+
+// ListTemplates returns the full paths of the vm templates in a datacenter
+func (g *Govc) ListTemplates(ctx context.Context, datacenter string) ([]string, error) {
+	templates, err := g.find(ctx, "/"+datacenter, vmObject, "-config.template", "true")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing templates in datacenter %s: %v", datacenter, err)
+	}
+
+	return templates, nil
+}
+
+func (g *Govc) find(ctx context.Context, path, objectType string, filters ...string) ([]string, error) {
+	params := append([]string{"find", path, "-type", objectType}, filters...)
+	response, err := g.exec(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitLines(response, "find")
+}
+
+// splitLines returns the non empty lines of a govc response, trimmed
+func splitLines(response bytes.Buffer, command string) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(&response)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed reading govc %s response: %v", command, err)
+	}
+
+	return lines, nil
+}
@@ -602,6 +602,115 @@ func TestGetTagsSuccessHasTags(t *testing.T) {
 	}
 }
 
+func TestGovcTagIDs(t *testing.T) {
+	ctx := context.Background()
+	tagsResponse := `[
+		{
+			"id": "urn:vmomi:InventoryServiceTag:5555:GLOBAL",
+			"name": "anywhere.eks.amazonaws.com/cluster-name:my-cluster",
+			"category_id": "urn:vmomi:InventoryServiceCategory:1111:GLOBAL"
+		}
+	]`
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.ls", "-json").Return(*bytes.NewBufferString(tagsResponse), nil)
+
+	ids, err := g.TagIDs(ctx)
+	if err != nil {
+		t.Fatalf("Govc.TagIDs() err = %v, want err nil", err)
+	}
+
+	want := map[string]string{"anywhere.eks.amazonaws.com/cluster-name:my-cluster": "urn:vmomi:InventoryServiceTag:5555:GLOBAL"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("Govc.TagIDs() = %v, want %v", ids, want)
+	}
+}
+
+func TestGovcListVMsWithTag(t *testing.T) {
+	ctx := context.Background()
+	tag := "anywhere.eks.amazonaws.com/cluster-name:my-cluster"
+	objectsResponse := `[
+		{"Type": "VirtualMachine", "Value": "vm-1"},
+		{"Type": "Folder", "Value": "group-v1"},
+		{"Type": "VirtualMachine", "Value": "vm-2"}
+	]`
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.attached.ls", "-json", tag).Return(*bytes.NewBufferString(objectsResponse), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "ls", "-L", "VirtualMachine:vm-1", "VirtualMachine:vm-2").Return(*bytes.NewBufferString("/SDDC-Datacenter/vm/my-cluster-abcde\n/SDDC-Datacenter/vm/my-cluster-md-0-fghij\n"), nil)
+
+	vms, err := g.ListVMsWithTag(ctx, tag)
+	if err != nil {
+		t.Fatalf("Govc.ListVMsWithTag() err = %v, want err nil", err)
+	}
+
+	want := []string{"/SDDC-Datacenter/vm/my-cluster-abcde", "/SDDC-Datacenter/vm/my-cluster-md-0-fghij"}
+	if !reflect.DeepEqual(vms, want) {
+		t.Fatalf("Govc.ListVMsWithTag() = %v, want %v", vms, want)
+	}
+}
+
+func TestGovcListVMsWithTagNoObjects(t *testing.T) {
+	ctx := context.Background()
+	tag := "anywhere.eks.amazonaws.com/cluster-name:my-cluster"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.attached.ls", "-json", tag).Return(*bytes.NewBufferString("null"), nil)
+
+	vms, err := g.ListVMsWithTag(ctx, tag)
+	if err != nil {
+		t.Fatalf("Govc.ListVMsWithTag() err = %v, want err nil", err)
+	}
+	if len(vms) != 0 {
+		t.Fatalf("Govc.ListVMsWithTag() = %v, want no VMs", vms)
+	}
+}
+
+func TestGovcTagAttached(t *testing.T) {
+	ctx := context.Background()
+	tag := "anywhere.eks.amazonaws.com/cluster-name:my-cluster"
+	objectsResponse := `[{"Type": "Folder", "Value": "group-v1"}]`
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.attached.ls", "-json", tag).Return(*bytes.NewBufferString(objectsResponse), nil)
+
+	attached, err := g.TagAttached(ctx, tag)
+	if err != nil {
+		t.Fatalf("Govc.TagAttached() err = %v, want err nil", err)
+	}
+	if !attached {
+		t.Fatal("Govc.TagAttached() = false, want true")
+	}
+}
+
+func TestGovcTagAttachedNoObjects(t *testing.T) {
+	ctx := context.Background()
+	tag := "anywhere.eks.amazonaws.com/cluster-name:my-cluster"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.attached.ls", "-json", tag).Return(*bytes.NewBufferString("null"), nil)
+
+	attached, err := g.TagAttached(ctx, tag)
+	if err != nil {
+		t.Fatalf("Govc.TagAttached() err = %v, want err nil", err)
+	}
+	if attached {
+		t.Fatal("Govc.TagAttached() = true, want false")
+	}
+}
+
+func TestGovcDeleteVM(t *testing.T) {
+	ctx := context.Background()
+	path := "/SDDC-Datacenter/vm/my-cluster-md-0-fghij"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.destroy", path).Return(bytes.Buffer{}, nil)
+
+	if err := g.DeleteVM(ctx, path); err != nil {
+		t.Fatalf("Govc.DeleteVM() err = %v, want err nil", err)
+	}
+}
+
 func TestGetTagsErrorGovc(t *testing.T) {
 	path := "/SDDC-Datacenter/vm/Templates/ubuntu-2004-kube-v1.19.6"
 	ctx := context.Background()
@@ -844,6 +953,18 @@ func TestCreateCategoryForVMSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateCategoryForVMAndFolderSuccess(t *testing.T) {
+	category := "category"
+	ctx := context.Background()
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.category.create", "-t", "VirtualMachine", "-t", "Folder", category).Return(*bytes.NewBufferString(""), nil)
+
+	if err := g.CreateCategoryForVMAndFolder(ctx, category); err != nil {
+		t.Fatalf("Govc.CreateCategoryForVMAndFolder() err = %v, want err nil", err)
+	}
+}
+
 func TestCreateCategoryForVMError(t *testing.T) {
 	category := "category"
 	ctx := context.Background()
@@ -1117,3 +1238,250 @@ func TestGovcNetworkExistsFalse(t *testing.T) {
 		t.Fatalf("Govc.NetworkExists() = true, want false")
 	}
 }
+
+func TestGovcListDatacenters(t *testing.T) {
+	ctx := context.Background()
+	g, executable, env := setup(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/", "-type", "d").Return(*bytes.NewBufferString("/SDDC-Datacenter\n/Other-Datacenter\n"), nil)
+
+	datacenters, err := g.ListDatacenters(ctx)
+	if err != nil {
+		t.Fatalf("Govc.ListDatacenters() err = %v, want err nil", err)
+	}
+
+	want := []string{"SDDC-Datacenter", "Other-Datacenter"}
+	if !reflect.DeepEqual(datacenters, want) {
+		t.Fatalf("Govc.ListDatacenters() = %v, want %v", datacenters, want)
+	}
+}
+
+func TestGovcListTemplates(t *testing.T) {
+	ctx := context.Background()
+	g, executable, env := setup(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter", "-type", "m", "-config.template", "true").Return(*bytes.NewBufferString("/SDDC-Datacenter/vm/Templates/ubuntu-2004-kube-v1.21\n"), nil)
+
+	templates, err := g.ListTemplates(ctx, "SDDC-Datacenter")
+	if err != nil {
+		t.Fatalf("Govc.ListTemplates() err = %v, want err nil", err)
+	}
+
+	want := []string{"/SDDC-Datacenter/vm/Templates/ubuntu-2004-kube-v1.21"}
+	if !reflect.DeepEqual(templates, want) {
+		t.Fatalf("Govc.ListTemplates() = %v, want %v", templates, want)
+	}
+}
+
+func TestGovcListVMFoldersEmpty(t *testing.T) {
+	ctx := context.Background()
+	g, executable, env := setup(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter/vm", "-type", "f").Return(*bytes.NewBufferString(""), nil)
+
+	folders, err := g.ListVMFolders(ctx, "SDDC-Datacenter")
+	if err != nil {
+		t.Fatalf("Govc.ListVMFolders() err = %v, want err nil", err)
+	}
+
+	if len(folders) != 0 {
+		t.Fatalf("Govc.ListVMFolders() = %v, want empty", folders)
+	}
+}
+
+func TestGovcListNetworksError(t *testing.T) {
+	ctx := context.Background()
+	g, executable, env := setup(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter", "-type", "n").Return(bytes.Buffer{}, errors.New("error from execute with env"))
+
+	if _, err := g.ListNetworks(ctx, "SDDC-Datacenter"); err == nil {
+		t.Fatal("Govc.ListNetworks() err = nil, want err not nil")
+	}
+}
+
+func TestGovcListLibraryItems(t *testing.T) {
+	ctx := context.Background()
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.ls", "/eks-a-templates/*").Return(*bytes.NewBufferString("/eks-a-templates/ubuntu-v1.21\n/eks-a-templates/bottlerocket-v1.21\n"), nil)
+
+	items, err := g.ListLibraryItems(ctx, "eks-a-templates")
+	if err != nil {
+		t.Fatalf("Govc.ListLibraryItems() err = %v, want err nil", err)
+	}
+
+	want := []string{"/eks-a-templates/ubuntu-v1.21", "/eks-a-templates/bottlerocket-v1.21"}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("Govc.ListLibraryItems() = %v, want %v", items, want)
+	}
+}
+
+func TestGovcDeleteLibrary(t *testing.T) {
+	ctx := context.Background()
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.rm", "/eks-a-templates").Return(bytes.Buffer{}, nil)
+
+	if err := g.DeleteLibrary(ctx, "/eks-a-templates/"); err != nil {
+		t.Fatalf("Govc.DeleteLibrary() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcCreateVMSnapshot(t *testing.T) {
+	ctx := context.Background()
+	vm := "/SDDC-Datacenter/vm/ubuntu-v1.21"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "snapshot.create", "-dc", "SDDC-Datacenter", "-m=false", "-vm", vm, "linked-clone").Return(bytes.Buffer{}, nil)
+
+	if err := g.CreateVMSnapshot(ctx, "SDDC-Datacenter", vm, "linked-clone"); err != nil {
+		t.Fatalf("Govc.CreateVMSnapshot() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcDeleteVMSnapshot(t *testing.T) {
+	ctx := context.Background()
+	vm := "/SDDC-Datacenter/vm/ubuntu-v1.21"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "snapshot.remove", "-vm", vm, "linked-clone").Return(bytes.Buffer{}, nil)
+
+	if err := g.DeleteVMSnapshot(ctx, vm, "linked-clone"); err != nil {
+		t.Fatalf("Govc.DeleteVMSnapshot() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcListVMSnapshots(t *testing.T) {
+	ctx := context.Background()
+	vm := "/SDDC-Datacenter/vm/ubuntu-v1.21"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "snapshot.tree", "-vm", vm).Return(*bytes.NewBufferString("root\n  linked-clone\n"), nil)
+
+	snapshots, err := g.ListVMSnapshots(ctx, vm)
+	if err != nil {
+		t.Fatalf("Govc.ListVMSnapshots() err = %v, want err nil", err)
+	}
+
+	want := []string{"root", "linked-clone"}
+	if !reflect.DeepEqual(snapshots, want) {
+		t.Fatalf("Govc.ListVMSnapshots() = %v, want %v", snapshots, want)
+	}
+}
+
+func TestGovcRemoveTag(t *testing.T) {
+	ctx := context.Background()
+	vm := "/SDDC-Datacenter/vm/my-cluster-abcde"
+	tag := "anywhere.eks.amazonaws.com/cluster-name:my-cluster"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.detach", tag, vm).Return(bytes.Buffer{}, nil)
+
+	if err := g.RemoveTag(ctx, vm, tag); err != nil {
+		t.Fatalf("Govc.RemoveTag() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcDeleteTagAndCategory(t *testing.T) {
+	ctx := context.Background()
+	tag := "anywhere.eks.amazonaws.com/cluster-name:my-cluster"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.rm", tag).Return(bytes.Buffer{}, nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "tags.category.rm", "anywhere.eks.amazonaws.com/cluster-name").Return(bytes.Buffer{}, nil)
+
+	if err := g.DeleteTag(ctx, tag); err != nil {
+		t.Fatalf("Govc.DeleteTag() err = %v, want err nil", err)
+	}
+	if err := g.DeleteCategory(ctx, "anywhere.eks.amazonaws.com/cluster-name"); err != nil {
+		t.Fatalf("Govc.DeleteCategory() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcDatastoreFileExistsTrue(t *testing.T) {
+	ctx := context.Background()
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "datastore.ls", "-ds", "WorkloadDatastore", "eks-a/ubuntu.ova").Return(*bytes.NewBufferString("ubuntu.ova\n"), nil)
+
+	exists, err := g.DatastoreFileExists(ctx, "WorkloadDatastore", "eks-a/ubuntu.ova")
+	if err != nil {
+		t.Fatalf("Govc.DatastoreFileExists() err = %v, want err nil", err)
+	}
+	if !exists {
+		t.Fatal("Govc.DatastoreFileExists() = false, want true")
+	}
+}
+
+func TestGovcDatastoreFileExistsFalse(t *testing.T) {
+	ctx := context.Background()
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "datastore.ls", "-ds", "WorkloadDatastore", "eks-a/ubuntu.ova").Return(bytes.Buffer{}, errors.New("govc: File [WorkloadDatastore] eks-a/ubuntu.ova was not found"))
+
+	exists, err := g.DatastoreFileExists(ctx, "WorkloadDatastore", "eks-a/ubuntu.ova")
+	if err != nil {
+		t.Fatalf("Govc.DatastoreFileExists() err = %v, want err nil", err)
+	}
+	if exists {
+		t.Fatal("Govc.DatastoreFileExists() = true, want false")
+	}
+}
+
+func TestGovcDatastoreFileExistsError(t *testing.T) {
+	ctx := context.Background()
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "datastore.ls", "-ds", "WorkloadDatastore", "eks-a/ubuntu.ova").Return(bytes.Buffer{}, errors.New("permission denied"))
+
+	if _, err := g.DatastoreFileExists(ctx, "WorkloadDatastore", "eks-a/ubuntu.ova"); err == nil {
+		t.Fatal("Govc.DatastoreFileExists() err = nil, want err not nil")
+	}
+}
+
+func TestGovcDatastoreFileOperations(t *testing.T) {
+	ctx := context.Background()
+	ds := "WorkloadDatastore"
+
+	g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "datastore.mkdir", "-ds", ds, "-p", "eks-a").Return(bytes.Buffer{}, nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "datastore.upload", "-ds", ds, "ubuntu.ova", "eks-a/ubuntu.ova").Return(bytes.Buffer{}, nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "datastore.rm", "-ds", ds, "-f", "eks-a/ubuntu.ova").Return(bytes.Buffer{}, nil)
+
+	if err := g.CreateDatastoreDir(ctx, ds, "eks-a"); err != nil {
+		t.Fatalf("Govc.CreateDatastoreDir() err = %v, want err nil", err)
+	}
+	if err := g.UploadDatastoreFile(ctx, ds, "ubuntu.ova", "eks-a/ubuntu.ova"); err != nil {
+		t.Fatalf("Govc.UploadDatastoreFile() err = %v, want err nil", err)
+	}
+	if err := g.DeleteDatastoreFile(ctx, ds, "eks-a/ubuntu.ova"); err != nil {
+		t.Fatalf("Govc.DeleteDatastoreFile() err = %v, want err nil", err)
+	}
+}
+
+func TestGovcResourcePoolHosts(t *testing.T) {
+	ctx := context.Background()
+	g, executable, env := setup(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter/host/Cluster-01", "-type", "h").Return(*bytes.NewBufferString("/SDDC-Datacenter/host/Cluster-01/esxi-1\n/SDDC-Datacenter/host/Cluster-01/esxi-2\n"), nil)
+
+	hosts, err := g.ResourcePoolHosts(ctx, "/SDDC-Datacenter/host/Cluster-01/Resources/eksa")
+	if err != nil {
+		t.Fatalf("Govc.ResourcePoolHosts() err = %v, want err nil", err)
+	}
+
+	want := []string{"/SDDC-Datacenter/host/Cluster-01/esxi-1", "/SDDC-Datacenter/host/Cluster-01/esxi-2"}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Fatalf("Govc.ResourcePoolHosts() = %v, want %v", hosts, want)
+	}
+}
+
+func TestGovcResourcePoolHostsNotFullPath(t *testing.T) {
+	ctx := context.Background()
+	g, _, _ := setup(t)
+
+	if _, err := g.ResourcePoolHosts(ctx, "*/Resources"); err == nil {
+		t.Fatal("Govc.ResourcePoolHosts() err = nil, want err not nil")
+	}
+}
@@ -2,6 +2,7 @@ package executables_test
 
 import (
 	"context"
+	"io"
 
 	"github.com/golang/mock/gomock"
 
@@ -32,6 +33,21 @@ func (c *commandExpect) withStdIn(stdIn []byte) *commandExpect {
 	return c
 }
 
+func (c *commandExpect) withWorkingDir(dir string) *commandExpect {
+	c.command.WithWorkingDir(dir)
+	return c
+}
+
+func (c *commandExpect) withStdout(w io.Writer) *commandExpect {
+	c.command.WithStdout(w)
+	return c
+}
+
+func (c *commandExpect) withStderr(w io.Writer) *commandExpect {
+	c.command.WithStderr(w)
+	return c
+}
+
 func (c *commandExpect) to() *gomock.Call {
 	return c.e.EXPECT().Run(c.command)
 }
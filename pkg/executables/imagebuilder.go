@@ -0,0 +1,36 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+)
+
+const imageBuilderPath = "image-builder"
+
+type ImageBuilder struct {
+	executable Executable
+}
+
+func NewImageBuilder(executable Executable) *ImageBuilder {
+	return &ImageBuilder{
+		executable: executable,
+	}
+}
+
+// BuildImage builds a node image for the hypervisor, vsphere or baremetal, with the Kubernetes version of the
+// EKS-D release channel. The image is written to outputDir, where the command runs. The build takes a while,
+// its progress is logged with verbosity 4
+func (b *ImageBuilder) BuildImage(ctx context.Context, osFamily, hypervisor, releaseChannel, configFile, outputDir string) error {
+	params := []string{
+		"build",
+		"--os", osFamily,
+		"--hypervisor", hypervisor,
+		"--release-channel", releaseChannel,
+		fmt.Sprintf("--%s-config", hypervisor), configFile,
+	}
+	if _, err := b.executable.Command(ctx, params...).WithWorkingDir(outputDir).WithStdout(NewLogWriter(4)).Run(); err != nil {
+		return fmt.Errorf("failed building %s %s image: %v", osFamily, hypervisor, err)
+	}
+
+	return nil
+}
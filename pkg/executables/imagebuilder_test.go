@@ -0,0 +1,35 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func TestImageBuilderBuildImage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	e := mocks.NewMockExecutable(gomock.NewController(t))
+	expectCommand(e, ctx, "build", "--os", "ubuntu", "--hypervisor", "vsphere", "--release-channel", "1-21", "--vsphere-config", "images/config.json").
+		withWorkingDir("images").withStdout(executables.NewLogWriter(4)).to().Return(bytes.Buffer{}, nil)
+
+	g.Expect(executables.NewImageBuilder(e).BuildImage(ctx, "ubuntu", "vsphere", "1-21", "images/config.json", "images")).To(Succeed())
+}
+
+func TestImageBuilderBuildImageError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	e := mocks.NewMockExecutable(gomock.NewController(t))
+	expectCommand(e, ctx, "build", "--os", "ubuntu", "--hypervisor", "baremetal", "--release-channel", "1-21", "--baremetal-config", "config.json").
+		withWorkingDir("out").withStdout(executables.NewLogWriter(4)).to().Return(bytes.Buffer{}, errors.New("packer failed"))
+
+	err := executables.NewImageBuilder(e).BuildImage(ctx, "ubuntu", "baremetal", "1-21", "config.json", "out")
+	g.Expect(err).To(MatchError("failed building ubuntu baremetal image: packer failed"))
+}
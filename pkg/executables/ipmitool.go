@@ -0,0 +1,62 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	ipmitoolPath = "ipmitool"
+
+	// ipmiPasswordKey is read by ipmitool -E, so the BMC password never shows in the command line
+	ipmiPasswordKey = "IPMI_PASSWORD"
+)
+
+type Ipmitool struct {
+	executable Executable
+}
+
+func NewIpmitool(executable Executable) *Ipmitool {
+	return &Ipmitool{
+		executable: executable,
+	}
+}
+
+// PowerStatus returns the chassis power of the machine behind the BMC at host, on or off
+func (i *Ipmitool) PowerStatus(ctx context.Context, host, username, password string) (string, error) {
+	out, err := i.execute(ctx, host, username, password, "chassis", "power", "status")
+	if err != nil {
+		return "", fmt.Errorf("failed getting power status of %s: %v", host, err)
+	}
+
+	// ipmitool prints Chassis Power is on
+	status := strings.TrimSpace(out)
+	if i := strings.LastIndex(status, " "); i != -1 {
+		status = status[i+1:]
+	}
+	if status != "on" && status != "off" {
+		return "", fmt.Errorf("unexpected power status of %s: %s", host, strings.TrimSpace(out))
+	}
+
+	return status, nil
+}
+
+// Power runs a chassis power action, like on, off or cycle, on the machine behind the BMC at host
+func (i *Ipmitool) Power(ctx context.Context, host, username, password, action string) error {
+	if _, err := i.execute(ctx, host, username, password, "chassis", "power", action); err != nil {
+		return fmt.Errorf("failed running power %s on %s: %v", action, host, err)
+	}
+
+	return nil
+}
+
+func (i *Ipmitool) execute(ctx context.Context, host, username, password string, args ...string) (string, error) {
+	params := append([]string{"-I", "lanplus", "-H", host, "-U", username, "-E"}, args...)
+	out, err := i.executable.ExecuteWithEnv(ctx, map[string]string{ipmiPasswordKey: password}, params...)
+	if err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
@@ -0,0 +1,64 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+type ipmitoolTest struct {
+	*WithT
+	ctx  context.Context
+	i    *executables.Ipmitool
+	e    *mocks.MockExecutable
+	env  map[string]string
+	args []string
+}
+
+func newIpmitoolTest(t *testing.T) *ipmitoolTest {
+	e := mocks.NewMockExecutable(gomock.NewController(t))
+	return &ipmitoolTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		i:     executables.NewIpmitool(e),
+		e:     e,
+		env:   map[string]string{"IPMI_PASSWORD": "pass"},
+		args:  []string{"-I", "lanplus", "-H", "10.0.1.1", "-U", "admin", "-E", "chassis", "power"},
+	}
+}
+
+func TestIpmitoolPowerStatus(t *testing.T) {
+	tt := newIpmitoolTest(t)
+	tt.e.EXPECT().ExecuteWithEnv(tt.ctx, tt.env, append(tt.args, "status")).Return(*bytes.NewBufferString("Chassis Power is off\n"), nil)
+
+	tt.Expect(tt.i.PowerStatus(tt.ctx, "10.0.1.1", "admin", "pass")).To(Equal("off"))
+}
+
+func TestIpmitoolPowerStatusUnexpected(t *testing.T) {
+	tt := newIpmitoolTest(t)
+	tt.e.EXPECT().ExecuteWithEnv(tt.ctx, tt.env, append(tt.args, "status")).Return(*bytes.NewBufferString("Chassis Power Control: Up/On\n"), nil)
+
+	_, err := tt.i.PowerStatus(tt.ctx, "10.0.1.1", "admin", "pass")
+	tt.Expect(err).To(MatchError("unexpected power status of 10.0.1.1: Chassis Power Control: Up/On"))
+}
+
+func TestIpmitoolPower(t *testing.T) {
+	tt := newIpmitoolTest(t)
+	tt.e.EXPECT().ExecuteWithEnv(tt.ctx, tt.env, append(tt.args, "cycle")).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.i.Power(tt.ctx, "10.0.1.1", "admin", "pass", "cycle")).To(Succeed())
+}
+
+func TestIpmitoolPowerError(t *testing.T) {
+	tt := newIpmitoolTest(t)
+	tt.e.EXPECT().ExecuteWithEnv(tt.ctx, tt.env, append(tt.args, "off")).Return(bytes.Buffer{}, errors.New("unable to establish session"))
+
+	tt.Expect(tt.i.Power(tt.ctx, "10.0.1.1", "admin", "pass", "off")).To(MatchError("failed running power off on 10.0.1.1: unable to establish session"))
+}
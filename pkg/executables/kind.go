@@ -14,7 +14,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -30,6 +29,8 @@ type Kind struct {
 	writer filewriter.FileWriter
 	Executable
 	execConfig *kindExecConfig
+	// kubeconfigDir holds the bootstrap cluster kubeconfigs of the current operation in the cluster folder, Close removes it
+	kubeconfigDir string
 }
 
 // kindExecConfig contains transient information for the execution of kind commands
@@ -81,7 +82,7 @@ func (k *Kind) CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.
 	}
 	executionArgs := k.execArguments(clusterSpec.Name, kubeconfigName)
 
-	logger.V(4).Info("Creating kind cluster", "name", getInternalName(clusterSpec.Name), "kubeconfig", kubeconfigName)
+	l.V(4).Info("Creating kind cluster", "name", getInternalName(clusterSpec.Name), "kubeconfig", kubeconfigName)
 	_, err = k.ExecuteWithEnv(ctx, k.execConfig.env, executionArgs...)
 	if err != nil {
 		return "", fmt.Errorf("error executing create cluster: %v", err)
@@ -97,7 +98,7 @@ func (k *Kind) ClusterExists(ctx context.Context, clusterName string) (bool, err
 		return false, fmt.Errorf("error executing get clusters: %v", err)
 	}
 
-	logger.V(5).Info("Executed kind get clusters", "response", stdOut.String())
+	l.V(5).Info("Executed kind get clusters", "response", stdOut.String())
 
 	scanner := bufio.NewScanner(&stdOut)
 	for scanner.Scan() {
@@ -122,6 +123,24 @@ func (k *Kind) GetKubeconfig(ctx context.Context, clusterName string) (string, e
 	return k.createKubeConfig(clusterName, stdOut.Bytes())
 }
 
+// GetNodes returns the names of the node containers of the kind cluster
+func (k *Kind) GetNodes(ctx context.Context, clusterName string) ([]string, error) {
+	stdOut, err := k.Execute(ctx, "get", "nodes", "--name", getInternalName(clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("error executing get nodes: %v", err)
+	}
+
+	var nodes []string
+	scanner := bufio.NewScanner(&stdOut)
+	for scanner.Scan() {
+		if node := scanner.Text(); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes, scanner.Err()
+}
+
 func (k *Kind) WithExtraDockerMounts() bootstrapper.BootstrapClusterClientOption {
 	return func() error {
 		if k.execConfig == nil {
@@ -174,7 +193,7 @@ func (k *Kind) WithRegistryMirror(endpoint string, caCertFile string) bootstrapp
 
 func (k *Kind) DeleteBootstrapCluster(ctx context.Context, cluster *types.Cluster) error {
 	internalName := getInternalName(cluster.Name)
-	logger.V(4).Info("Deleting kind cluster", "name", internalName)
+	l.V(4).Info("Deleting kind cluster", "name", internalName)
 	_, err := k.Execute(ctx, "delete", "cluster", "--name", internalName)
 	if err != nil {
 		return fmt.Errorf("error executing delete cluster: %v", err)
@@ -236,14 +255,54 @@ func (k *Kind) execArguments(clusterName string, kubeconfigName string) []string
 	}
 }
 
+// createKubeConfig writes the kubeconfig readable only by the current user in a temporary directory private
+// to the operation, instead of the cluster folder where it would outlive the bootstrap cluster
 func (k *Kind) createKubeConfig(clusterName string, content []byte) (string, error) {
-	fileName, err := k.writer.Write(fmt.Sprintf("%s.kind.kubeconfig", clusterName), content)
-	if err != nil {
+	if k.kubeconfigDir == "" {
+		// The temp folder is created in the cluster folder, since only the working directory is mounted in the tools
+		// container. Its name is random and it's only readable by the current user
+		dir, err := os.MkdirTemp(k.writer.Dir(), "bootstrap-kubeconfig-")
+		if err != nil {
+			return "", fmt.Errorf("error creating temp directory for storing kind kubeconfig: %v", err)
+		}
+		k.kubeconfigDir = dir
+	}
+	fileName := k.kubeconfigPath(clusterName)
+	if err := ioutil.WriteFile(fileName, content, 0o600); err != nil {
 		return "", fmt.Errorf("error generating temp file for storing kind kubeconfig: %v", err)
 	}
 	return fileName, nil
 }
 
+func (k *Kind) kubeconfigPath(clusterName string) string {
+	return filepath.Join(k.kubeconfigDir, fmt.Sprintf("%s.kind.kubeconfig", clusterName))
+}
+
+// BootstrapKubeconfig returns the kubeconfig file of the bootstrap cluster created or read by the current
+// operation. The file only exists until Close
+func (k *Kind) BootstrapKubeconfig(clusterName string) (string, error) {
+	if k.kubeconfigDir == "" {
+		return "", fmt.Errorf("no kubeconfig generated for bootstrap cluster %s", clusterName)
+	}
+	fileName := k.kubeconfigPath(clusterName)
+	if _, err := os.Stat(fileName); err != nil {
+		return "", fmt.Errorf("no kubeconfig generated for bootstrap cluster %s: %v", clusterName, err)
+	}
+	return fileName, nil
+}
+
+// Close removes the bootstrap cluster kubeconfigs generated by the operation
+func (k *Kind) Close(ctx context.Context) error {
+	if k == nil || k.kubeconfigDir == "" {
+		return nil
+	}
+	if err := os.RemoveAll(k.kubeconfigDir); err != nil {
+		return fmt.Errorf("error removing kind kubeconfigs: %v", err)
+	}
+	k.kubeconfigDir = ""
+	return nil
+}
+
 func processOpts(opts []bootstrapper.BootstrapClusterClientOption) error {
 	for _, opt := range opts {
 		err := opt()
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -315,6 +318,24 @@ func TestKindClusterExists(t *testing.T) {
 	}
 }
 
+func TestKindGetNodes(t *testing.T) {
+	clusterName := "cluster-name"
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "get", "nodes", "--name", "cluster-name-eks-a-cluster").Return(*bytes.NewBufferString("cluster-name-eks-a-cluster-control-plane\n"), nil)
+	k := executables.NewKind(executable, writer)
+	nodes, err := k.GetNodes(ctx, clusterName)
+	if err != nil {
+		t.Fatalf("Kind.GetNodes() error = %v, wantErr nil", err)
+	}
+	if !reflect.DeepEqual(nodes, []string{"cluster-name-eks-a-cluster-control-plane"}) {
+		t.Fatalf("Kind.GetNodes() = %v, want [cluster-name-eks-a-cluster-control-plane]", nodes)
+	}
+}
+
 func TestKindGetKubeconfig(t *testing.T) {
 	clusterName := "cluster-name"
 	ctx := context.Background()
@@ -329,3 +350,45 @@ func TestKindGetKubeconfig(t *testing.T) {
 		t.Fatalf("Kind.GetKubeconfig() error = %v, wantErr nil", err)
 	}
 }
+
+func TestKindKubeconfigLifetime(t *testing.T) {
+	clusterName := "cluster-name"
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "get", "kubeconfig", "--name", fmt.Sprintf("%s-eks-a-cluster", clusterName)).Return(*bytes.NewBufferString("kubeconfig"), nil)
+	k := executables.NewKind(executable, writer)
+	if _, err := k.BootstrapKubeconfig(clusterName); err == nil {
+		t.Fatal("Kind.BootstrapKubeconfig() error = nil, want not nil before any kubeconfig is generated")
+	}
+
+	kubeconfig, err := k.GetKubeconfig(ctx, clusterName)
+	if err != nil {
+		t.Fatalf("Kind.GetKubeconfig() error = %v, wantErr nil", err)
+	}
+	if !strings.HasPrefix(kubeconfig, filepath.Clean(writer.Dir())) {
+		t.Errorf("Kind.GetKubeconfig() = %s, want it in the cluster folder %s, which is mounted in the tools container", kubeconfig, writer.Dir())
+	}
+	info, err := os.Stat(kubeconfig)
+	if err != nil {
+		t.Fatalf("kubeconfig %s not written: %v", kubeconfig, err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("kubeconfig permissions = %o, want 600", perm)
+	}
+	if got, err := k.BootstrapKubeconfig(clusterName); err != nil || got != kubeconfig {
+		t.Errorf("Kind.BootstrapKubeconfig() = %s, %v, want %s, nil", got, err, kubeconfig)
+	}
+
+	if err = k.Close(ctx); err != nil {
+		t.Fatalf("Kind.Close() error = %v, wantErr nil", err)
+	}
+	if _, err = os.Stat(kubeconfig); !os.IsNotExist(err) {
+		t.Errorf("kubeconfig %s still exists after Close", kubeconfig)
+	}
+	if _, err = k.BootstrapKubeconfig(clusterName); err == nil {
+		t.Error("Kind.BootstrapKubeconfig() error = nil, want not nil after Close")
+	}
+}
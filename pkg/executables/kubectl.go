@@ -2,16 +2,22 @@ package executables
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/version"
 	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -23,7 +29,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
-	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -33,18 +39,22 @@ const (
 )
 
 var (
-	capiClustersResourceType          = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
-	eksaClusterResourceType           = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
-	eksaVSphereDatacenterResourceType = fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
-	eksaVSphereMachineResourceType    = fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group)
-	eksaAwsResourceType               = fmt.Sprintf("awsdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
-	eksaGitOpsResourceType            = fmt.Sprintf("gitopsconfigs.%s", v1alpha1.GroupVersion.Group)
-	eksaOIDCResourceType              = fmt.Sprintf("oidcconfigs.%s", v1alpha1.GroupVersion.Group)
-	eksaAwsIamResourceType            = fmt.Sprintf("awsiamconfigs.%s", v1alpha1.GroupVersion.Group)
-	etcdadmClustersResourceType       = fmt.Sprintf("etcdadmclusters.%s", etcdv1.GroupVersion.Group)
-	bundlesResourceType               = fmt.Sprintf("bundles.%s", releasev1alpha1.GroupVersion.Group)
-	clusterResourceSetResourceType    = fmt.Sprintf("clusterresourcesets.%s", addons.GroupVersion.Group)
-	kubeadmControlPlaneResourceType   = fmt.Sprintf("kubeadmcontrolplanes.controlplane.%s", clusterv1.GroupVersion.Group)
+	capiClustersResourceType             = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+	eksaClusterResourceType              = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	eksaVSphereDatacenterResourceType    = fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaVSphereMachineResourceType       = fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaAwsResourceType                  = fmt.Sprintf("awsdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaDockerDatacenterResourceType     = fmt.Sprintf("dockerdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaTinkerbellDatacenterResourceType = fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaTinkerbellMachineResourceType    = fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaGitOpsResourceType               = fmt.Sprintf("gitopsconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaOIDCResourceType                 = fmt.Sprintf("oidcconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaAwsIamResourceType               = fmt.Sprintf("awsiamconfigs.%s", v1alpha1.GroupVersion.Group)
+	etcdadmClustersResourceType          = fmt.Sprintf("etcdadmclusters.%s", etcdv1.GroupVersion.Group)
+	bundlesResourceType                  = fmt.Sprintf("bundles.%s", releasev1alpha1.GroupVersion.Group)
+	releasesResourceType                 = fmt.Sprintf("releases.%s", releasev1alpha1.GroupVersion.Group)
+	clusterResourceSetResourceType       = fmt.Sprintf("clusterresourcesets.%s", addons.GroupVersion.Group)
+	kubeadmControlPlaneResourceType      = fmt.Sprintf("kubeadmcontrolplanes.controlplane.%s", clusterv1.GroupVersion.Group)
 )
 
 type Kubectl struct {
@@ -189,8 +199,21 @@ func (k *Kubectl) WaitForDeployment(ctx context.Context, cluster *types.Cluster,
 	return k.Wait(ctx, cluster.KubeconfigFile, timeout, condition, "deployments/"+target, namespace)
 }
 
+// WaitForCRDsEstablished waits until the API server serves all the CRDs, so custom resources of their kinds can be created
+func (k *Kubectl) WaitForCRDsEstablished(ctx context.Context, cluster *types.Cluster, timeout string, crds ...string) error {
+	params := []string{"wait", "--timeout", retrier.ScaleTimeout(timeout), "--for=condition=Established"}
+	for _, crd := range crds {
+		params = append(params, "customresourcedefinitions/"+crd)
+	}
+	params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error waiting for CRDs to be established: %v", err)
+	}
+	return nil
+}
+
 func (k *Kubectl) Wait(ctx context.Context, kubeconfig string, timeout string, forCondition string, property string, namespace string) error {
-	_, err := k.Execute(ctx, "wait", "--timeout", timeout,
+	_, err := k.Execute(ctx, "wait", "--timeout", retrier.ScaleTimeout(timeout),
 		"--for=condition="+forCondition, property, "--kubeconfig", kubeconfig, "-n", namespace)
 	if err != nil {
 		return fmt.Errorf("error executing wait: %v", err)
@@ -270,6 +293,99 @@ func (k *Kubectl) DeleteCluster(ctx context.Context, managementCluster, clusterT
 	return nil
 }
 
+// SetCAPIClusterPaused sets spec.paused in the CAPI cluster object, which stops CAPI
+// controllers from reconciling the cluster and all the objects that belong to it
+func (k *Kubectl) SetCAPIClusterPaused(ctx context.Context, managementCluster *types.Cluster, clusterName string, paused bool) error {
+	params := []string{
+		"patch", capiClustersResourceType, clusterName,
+		"--type=merge", fmt.Sprintf("-p={\"spec\":{\"paused\":%t}}", paused),
+		"--kubeconfig", managementCluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace,
+	}
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("error setting paused to %t in CAPI cluster %s: %v", paused, clusterName, err)
+	}
+	return nil
+}
+
+// SetEksaClusterEtcdStatus records the health of the external etcd members in the status of the EKS-A cluster
+func (k *Kubectl) SetEksaClusterEtcdStatus(ctx context.Context, managementCluster *types.Cluster, clusterName, namespace string, status *v1alpha1.EtcdStatus) error {
+	patch, err := json.Marshal(map[string]interface{}{"status": map[string]interface{}{"etcd": status}})
+	if err != nil {
+		return fmt.Errorf("error marshalling etcd status of cluster %s: %v", clusterName, err)
+	}
+	params := []string{
+		"patch", eksaClusterResourceType, clusterName,
+		"--type=merge", "-p=" + string(patch),
+		"--kubeconfig", managementCluster.KubeconfigFile, "--namespace", namespace,
+	}
+	if _, err = k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error setting etcd status of cluster %s: %v", clusterName, err)
+	}
+	return nil
+}
+
+func (k *Kubectl) CreateCertificateSigningRequest(ctx context.Context, kubeconfigFile string, csr *certificatesv1.CertificateSigningRequest) error {
+	data, err := json.Marshal(csr)
+	if err != nil {
+		return fmt.Errorf("error marshalling certificate signing request %s: %v", csr.Name, err)
+	}
+	if _, err = k.ExecuteWithStdin(ctx, data, "create", "-f", "-", "--kubeconfig", kubeconfigFile); err != nil {
+		return fmt.Errorf("error creating certificate signing request %s: %v", csr.Name, err)
+	}
+	return nil
+}
+
+func (k *Kubectl) ApproveCertificateSigningRequest(ctx context.Context, kubeconfigFile, name string) error {
+	if _, err := k.Execute(ctx, "certificate", "approve", name, "--kubeconfig", kubeconfigFile); err != nil {
+		return fmt.Errorf("error approving certificate signing request %s: %v", name, err)
+	}
+	return nil
+}
+
+func (k *Kubectl) GetCertificateSigningRequest(ctx context.Context, kubeconfigFile, name string) (*certificatesv1.CertificateSigningRequest, error) {
+	stdOut, err := k.Execute(ctx, "get", "certificatesigningrequests.certificates.k8s.io", name, "-o", "json", "--kubeconfig", kubeconfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("error getting certificate signing request %s: %v", name, err)
+	}
+	response := &certificatesv1.CertificateSigningRequest{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing get certificate signing request response: %v", err)
+	}
+	return response, nil
+}
+
+func (k *Kubectl) DeleteCertificateSigningRequest(ctx context.Context, kubeconfigFile, name string) error {
+	if _, err := k.Execute(ctx, "delete", "certificatesigningrequests.certificates.k8s.io", name, "--ignore-not-found", "--kubeconfig", kubeconfigFile); err != nil {
+		return fmt.Errorf("error deleting certificate signing request %s: %v", name, err)
+	}
+	return nil
+}
+
+// GetObjectNames returns the objects of all the resource types in the namespace as <resource>.<group>/<name>.
+// An empty selector returns all the objects
+func (k *Kubectl) GetObjectNames(ctx context.Context, cluster *types.Cluster, namespace, selector string, resourceTypes ...string) ([]string, error) {
+	params := []string{
+		"get", strings.Join(resourceTypes, ","), "-o", "name",
+		"--kubeconfig", cluster.KubeconfigFile, "--namespace", namespace,
+	}
+	if selector != "" {
+		params = append(params, "--selector", selector)
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting objects %s: %v", strings.Join(resourceTypes, ","), err)
+	}
+
+	var names []string
+	for _, line := range strings.Split(stdOut.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
 func (k *Kubectl) ListCluster(ctx context.Context) error {
 	params := []string{"get", "pods", "-A", "-o", "jsonpath={..image}"}
 	output, err := k.Execute(ctx, params...)
@@ -288,7 +404,7 @@ func (k *Kubectl) ListCluster(ctx context.Context) error {
 
 	sort.Strings(list)
 	for _, value := range list {
-		logger.Info(value)
+		l.Info(value)
 	}
 	return nil
 }
@@ -345,7 +461,7 @@ func (k *Kubectl) ValidateControlPlaneNodes(ctx context.Context, cluster *types.
 }
 
 func (k *Kubectl) ValidateWorkerNodes(ctx context.Context, cluster *types.Cluster, clusterName string) error {
-	logger.V(6).Info("waiting for nodes", "cluster", clusterName)
+	l.V(6).Info("waiting for nodes", "cluster", clusterName)
 	deployments, err := k.GetMachineDeployments(ctx, WithCluster(cluster), WithNamespace(constants.EksaSystemNamespace))
 	if err != nil {
 		return err
@@ -411,7 +527,7 @@ func (k *Kubectl) ValidatePods(ctx context.Context, kubeconfig string) error {
 			}
 		}
 	}
-	logger.Info("All pods are running")
+	l.Info("All pods are running")
 	return nil
 }
 
@@ -469,6 +585,10 @@ type ClustersResponse struct {
 	Items []types.CAPICluster `json:"items,omitempty"`
 }
 
+type CertificatesResponse struct {
+	Items []types.Certificate `json:"items,omitempty"`
+}
+
 type GitOpsConfigResponse struct {
 	Items []*v1alpha1.GitOpsConfig `json:"items,omitempty"`
 }
@@ -634,6 +754,135 @@ func (k *Kubectl) GetDeployments(ctx context.Context, opts ...KubectlOpt) ([]app
 	return response.Items, nil
 }
 
+func (k *Kubectl) GetDaemonSets(ctx context.Context, opts ...KubectlOpt) ([]appsv1.DaemonSet, error) {
+	params := []string{"get", "daemonsets", "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting daemonsets: %v", err)
+	}
+
+	response := &appsv1.DaemonSetList{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get daemonsets response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+func (k *Kubectl) GetNodes(ctx context.Context, opts ...KubectlOpt) ([]corev1.Node, error) {
+	params := []string{"get", "nodes", "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting nodes: %v", err)
+	}
+
+	response := &corev1.NodeList{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get nodes response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+func (k *Kubectl) GetServices(ctx context.Context, opts ...KubectlOpt) ([]corev1.Service, error) {
+	params := []string{"get", "services", "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting services: %v", err)
+	}
+
+	response := &corev1.ServiceList{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get services response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+func (k *Kubectl) GetVolumeAttachments(ctx context.Context, opts ...KubectlOpt) ([]storagev1.VolumeAttachment, error) {
+	params := []string{"get", "volumeattachments", "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting volumeattachments: %v", err)
+	}
+
+	response := &storagev1.VolumeAttachmentList{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get volumeattachments response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+func (k *Kubectl) GetValidatingWebhookConfigurations(ctx context.Context, cluster *types.Cluster) ([]admissionregistrationv1.ValidatingWebhookConfiguration, error) {
+	params := []string{"get", "validatingwebhookconfigurations", "-o", "json", "--kubeconfig", cluster.KubeconfigFile}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validating webhook configurations: %v", err)
+	}
+
+	response := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing get validating webhook configurations response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+func (k *Kubectl) GetMutatingWebhookConfigurations(ctx context.Context, cluster *types.Cluster) ([]admissionregistrationv1.MutatingWebhookConfiguration, error) {
+	params := []string{"get", "mutatingwebhookconfigurations", "-o", "json", "--kubeconfig", cluster.KubeconfigFile}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mutating webhook configurations: %v", err)
+	}
+
+	response := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing get mutating webhook configurations response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+func (k *Kubectl) GetEndpoints(ctx context.Context, cluster *types.Cluster, name, namespace string) (*corev1.Endpoints, error) {
+	params := []string{"get", "endpoints", name, "-o", "json", "--kubeconfig", cluster.KubeconfigFile, "--namespace", namespace}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting endpoints: %v", err)
+	}
+
+	response := &corev1.Endpoints{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing get endpoints response: %v", err)
+	}
+
+	return response, nil
+}
+
+// GetCertificates returns the cert-manager Certificates in all namespaces
+func (k *Kubectl) GetCertificates(ctx context.Context, cluster *types.Cluster) ([]types.Certificate, error) {
+	params := []string{"get", "certificates.cert-manager.io", "-o", "json", "--all-namespaces", "--kubeconfig", cluster.KubeconfigFile}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting certificates: %v", err)
+	}
+
+	response := &CertificatesResponse{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing get certificates response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
 func (k *Kubectl) GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error) {
 	return k.GetSecret(ctx, name, WithKubeconfig(kubeconfigFile), WithNamespace(namespace))
 }
@@ -671,7 +920,7 @@ func (k *Kubectl) GetKubeadmControlPlanes(ctx context.Context, opts ...KubectlOp
 }
 
 func (k *Kubectl) GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...KubectlOpt) (*controlplanev1.KubeadmControlPlane, error) {
-	logger.V(6).Info("Getting KubeadmControlPlane CRDs", "cluster", clusterName)
+	l.V(6).Info("Getting KubeadmControlPlane CRDs", "cluster", clusterName)
 	params := []string{"get", kubeadmControlPlaneResourceType, clusterName, "-o", "json"}
 	applyOpts(&params, opts...)
 	stdOut, err := k.Execute(ctx, params...)
@@ -786,6 +1035,23 @@ func (k *Kubectl) GetEksaCluster(ctx context.Context, cluster *types.Cluster, cl
 	return response, nil
 }
 
+// GetEksaClusters returns the EKS-A clusters of all the namespaces of the cluster
+func (k *Kubectl) GetEksaClusters(ctx context.Context, cluster *types.Cluster) ([]v1alpha1.Cluster, error) {
+	params := []string{"get", eksaClusterResourceType, "-A", "-o", "json", "--kubeconfig", cluster.KubeconfigFile}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting eksa clusters: %v", err)
+	}
+
+	response := &v1alpha1.ClusterList{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get eksa clusters response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
 func (k *Kubectl) SearchVsphereMachineConfig(ctx context.Context, name string, kubeconfigFile string, namespace string) ([]*v1alpha1.VSphereMachineConfig, error) {
 	params := []string{
 		"get", eksaVSphereMachineResourceType, "-o", "json", "--kubeconfig",
@@ -969,6 +1235,54 @@ func (k *Kubectl) GetEksaAWSDatacenterConfig(ctx context.Context, awsDatacenterC
 	return response, nil
 }
 
+func (k *Kubectl) GetEksaDockerDatacenterConfig(ctx context.Context, dockerDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.DockerDatacenterConfig, error) {
+	params := []string{"get", eksaDockerDatacenterResourceType, dockerDatacenterConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting eksa docker datacenter config %v", err)
+	}
+
+	response := &v1alpha1.DockerDatacenterConfig{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get eksa docker datacenter config response: %v", err)
+	}
+
+	return response, nil
+}
+
+func (k *Kubectl) GetEksaTinkerbellDatacenterConfig(ctx context.Context, tinkerbellDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.TinkerbellDatacenterConfig, error) {
+	params := []string{"get", eksaTinkerbellDatacenterResourceType, tinkerbellDatacenterConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting eksa tinkerbell datacenter config %v", err)
+	}
+
+	response := &v1alpha1.TinkerbellDatacenterConfig{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get eksa tinkerbell datacenter config response: %v", err)
+	}
+
+	return response, nil
+}
+
+func (k *Kubectl) GetEksaTinkerbellMachineConfig(ctx context.Context, tinkerbellMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.TinkerbellMachineConfig, error) {
+	params := []string{"get", eksaTinkerbellMachineResourceType, tinkerbellMachineConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting eksa tinkerbell machine config %v", err)
+	}
+
+	response := &v1alpha1.TinkerbellMachineConfig{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get eksa tinkerbell machine config response: %v", err)
+	}
+
+	return response, nil
+}
+
 func (k *Kubectl) GetCurrentClusterContext(ctx context.Context, cluster *types.Cluster) (string, error) {
 	params := []string{"config", "view", "--kubeconfig", cluster.KubeconfigFile, "--minify", "--raw", "-o", "jsonpath={.contexts[0].name}"}
 	stdOut, err := k.Execute(ctx, params...)
@@ -980,7 +1294,7 @@ func (k *Kubectl) GetCurrentClusterContext(ctx context.Context, cluster *types.C
 }
 
 func (k *Kubectl) GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...KubectlOpt) (*etcdv1.EtcdadmCluster, error) {
-	logger.V(6).Info("Getting EtcdadmCluster CRD", "cluster", clusterName)
+	l.V(6).Info("Getting EtcdadmCluster CRD", "cluster", clusterName)
 	params := []string{"get", etcdadmClustersResourceType, fmt.Sprintf("%s-etcd", clusterName), "-o", "json"}
 	applyOpts(&params, opts...)
 	stdOut, err := k.Execute(ctx, params...)
@@ -1032,6 +1346,41 @@ func (k *Kubectl) GetBundles(ctx context.Context, kubeconfigFile, name, namespac
 	return response, nil
 }
 
+// ListBundles returns the Bundles of all the namespaces
+func (k *Kubectl) ListBundles(ctx context.Context, kubeconfigFile string) ([]releasev1alpha1.Bundles, error) {
+	params := []string{"get", bundlesResourceType, "-A", "-o", "json", "--kubeconfig", kubeconfigFile}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing Bundles with kubectl: %v", err)
+	}
+
+	response := &releasev1alpha1.BundlesList{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing Bundles list response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+// GetEksaRelease returns the EKS-A Release manifest stored in the cluster, or nil if it doesn't exist
+func (k *Kubectl) GetEksaRelease(ctx context.Context, kubeconfigFile, name, namespace string) (*releasev1alpha1.Release, error) {
+	params := []string{"get", releasesResourceType, name, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace, "--ignore-not-found"}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Release with kubectl: %v", err)
+	}
+	if len(bytes.TrimSpace(stdOut.Bytes())) == 0 {
+		return nil, nil
+	}
+
+	response := &releasev1alpha1.Release{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing Release response: %v", err)
+	}
+
+	return response, nil
+}
+
 func (k *Kubectl) GetClusterResourceSet(ctx context.Context, kubeconfigFile, name, namespace string) (*addons.ClusterResourceSet, error) {
 	params := []string{"get", clusterResourceSetResourceType, name, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
@@ -1062,10 +1411,69 @@ func (k *Kubectl) GetConfigMap(ctx context.Context, kubeconfigFile, name, namesp
 	return response, nil
 }
 
+// CreateLease creates the lease and fails if it already exists
+func (k *Kubectl) CreateLease(ctx context.Context, kubeconfigFile string, lease *coordinationv1.Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return fmt.Errorf("error marshalling lease: %v", err)
+	}
+
+	params := []string{"create", "-f", "-", "--kubeconfig", kubeconfigFile}
+	if _, err = k.ExecuteWithStdin(ctx, data, params...); err != nil {
+		return fmt.Errorf("error creating lease: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateLease replaces the lease. The replace fails when the lease changed since it was read,
+// as long as its resourceVersion is set
+func (k *Kubectl) UpdateLease(ctx context.Context, kubeconfigFile string, lease *coordinationv1.Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return fmt.Errorf("error marshalling lease: %v", err)
+	}
+
+	params := []string{"replace", "-f", "-", "--kubeconfig", kubeconfigFile}
+	if _, err = k.ExecuteWithStdin(ctx, data, params...); err != nil {
+		return fmt.Errorf("error updating lease: %v", err)
+	}
+
+	return nil
+}
+
+func (k *Kubectl) GetLease(ctx context.Context, kubeconfigFile, name, namespace string) (*coordinationv1.Lease, error) {
+	params := []string{"get", "lease", name, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting lease with kubectl: %v", err)
+	}
+
+	response := &coordinationv1.Lease{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing lease response: %v", err)
+	}
+
+	return response, nil
+}
+
+func (k *Kubectl) DeleteLease(ctx context.Context, kubeconfigFile, name, namespace string) error {
+	params := []string{"delete", "lease", name, "--ignore-not-found", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error deleting lease: %v", err)
+	}
+
+	return nil
+}
+
 func (k *Kubectl) SetDaemonSetImage(ctx context.Context, kubeconfigFile, name, namespace, container, image string) error {
 	return k.setImage(ctx, "daemonset", name, container, image, WithNamespace(namespace), WithKubeconfig(kubeconfigFile))
 }
 
+func (k *Kubectl) SetDeploymentImage(ctx context.Context, kubeconfigFile, name, namespace, container, image string) error {
+	return k.setImage(ctx, "deployment", name, container, image, WithNamespace(namespace), WithKubeconfig(kubeconfigFile))
+}
+
 func (k *Kubectl) setImage(ctx context.Context, kind, name, container, image string, opts ...KubectlOpt) error {
 	params := []string{"set", "image", fmt.Sprintf("%s/%s", kind, name), fmt.Sprintf("%s=%s", container, image)}
 	applyOpts(&params, opts...)
@@ -1202,3 +1610,170 @@ func (k *Kubectl) GetDaemonSet(ctx context.Context, name, namespace, kubeconfig
 
 	return obj, nil
 }
+
+// RolloutStatus waits until the rollout of the deployment, daemonset or statefulset completes or the timeout expires
+func (k *Kubectl) RolloutStatus(ctx context.Context, kind, name, timeout string, opts ...KubectlOpt) error {
+	params := []string{"rollout", "status", fmt.Sprintf("%s/%s", kind, name), "--timeout", retrier.ScaleTimeout(timeout)}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error waiting for rollout of %s/%s: %v", kind, name, err)
+	}
+
+	return nil
+}
+
+// Scale sets the number of replicas of the deployment or statefulset
+func (k *Kubectl) Scale(ctx context.Context, kind, name string, replicas int, opts ...KubectlOpt) error {
+	params := []string{"scale", fmt.Sprintf("%s/%s", kind, name), "--replicas", strconv.Itoa(replicas)}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error scaling %s/%s: %v", kind, name, err)
+	}
+
+	return nil
+}
+
+// RolloutRestart triggers a new rollout of the deployment, daemonset or statefulset, recreating all its pods
+func (k *Kubectl) RolloutRestart(ctx context.Context, kind, name string, opts ...KubectlOpt) error {
+	params := []string{"rollout", "restart", fmt.Sprintf("%s/%s", kind, name)}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error restarting %s/%s: %v", kind, name, err)
+	}
+
+	return nil
+}
+
+// CordonNode marks the node as unschedulable
+func (k *Kubectl) CordonNode(ctx context.Context, node string, opts ...KubectlOpt) error {
+	params := []string{"cordon", node}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error cordoning node %s: %v", node, err)
+	}
+
+	return nil
+}
+
+// UncordonNode marks the node as schedulable
+func (k *Kubectl) UncordonNode(ctx context.Context, node string, opts ...KubectlOpt) error {
+	params := []string{"uncordon", node}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error uncordoning node %s: %v", node, err)
+	}
+
+	return nil
+}
+
+// LabelNode sets a label in the node, overwriting its current value
+func (k *Kubectl) LabelNode(ctx context.Context, node, key, value string, opts ...KubectlOpt) error {
+	params := []string{"label", "node", node, fmt.Sprintf("%s=%s", key, value), "--overwrite"}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error labeling node %s: %v", node, err)
+	}
+
+	return nil
+}
+
+// RemoveNodeLabel removes a label from the node, it doesn't fail if the node doesn't have it
+func (k *Kubectl) RemoveNodeLabel(ctx context.Context, node, key string, opts ...KubectlOpt) error {
+	params := []string{"label", "node", node, key + "-"}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error removing label from node %s: %v", node, err)
+	}
+
+	return nil
+}
+
+// DrainOptions configures how the pods are evicted from a node
+type DrainOptions struct {
+	// Timeout is the time to wait for all the pods to be evicted, like "5m". No timeout if empty
+	Timeout string
+	// GracePeriodSeconds overrides the termination grace period of the pods. The pods' own is used if nil
+	GracePeriodSeconds *int
+	// DisableEviction deletes the pods instead of evicting them, which bypasses the PodDisruptionBudgets
+	DisableEviction bool
+	// Force deletes the pods not managed by a controller, which won't be recreated in another node
+	Force bool
+}
+
+// DrainNode cordons the node and evicts its pods. DaemonSet pods are left in the node and the
+// emptyDir volumes are deleted. Evictions blocked by a PodDisruptionBudget are retried until the timeout
+func (k *Kubectl) DrainNode(ctx context.Context, node string, drainOpts DrainOptions, opts ...KubectlOpt) error {
+	params := []string{"drain", node, "--ignore-daemonsets", "--delete-emptydir-data"}
+	if drainOpts.Timeout != "" {
+		params = append(params, "--timeout", retrier.ScaleTimeout(drainOpts.Timeout))
+	}
+	if drainOpts.GracePeriodSeconds != nil {
+		params = append(params, "--grace-period", strconv.Itoa(*drainOpts.GracePeriodSeconds))
+	}
+	if drainOpts.DisableEviction {
+		params = append(params, "--disable-eviction")
+	}
+	if drainOpts.Force {
+		params = append(params, "--force")
+	}
+	applyOpts(&params, opts...)
+
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error draining node %s: %v", node, err)
+	}
+
+	return nil
+}
+
+// DrainNodeInCluster drains the node, deleting the pods not managed by a controller
+func (k *Kubectl) DrainNodeInCluster(ctx context.Context, cluster *types.Cluster, node, timeout string) error {
+	return k.DrainNode(ctx, node, DrainOptions{Timeout: timeout, Force: true}, WithCluster(cluster))
+}
+
+// DeleteService deletes a Service and waits until it's gone, which for LoadBalancer services
+// includes releasing the load balancer in the infrastructure
+func (k *Kubectl) DeleteService(ctx context.Context, name, namespace string, opts ...KubectlOpt) error {
+	params := []string{"delete", "service", name, "--namespace", namespace, "--ignore-not-found"}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error deleting service %s/%s: %v", namespace, name, err)
+	}
+
+	return nil
+}
+
+// DeletePod deletes a Pod and waits until it's gone
+func (k *Kubectl) DeletePod(ctx context.Context, name, namespace string, opts ...KubectlOpt) error {
+	params := []string{"delete", "pod", name, "--namespace", namespace, "--ignore-not-found"}
+	applyOpts(&params, opts...)
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error deleting pod %s/%s: %v", namespace, name, err)
+	}
+
+	return nil
+}
+
+// DeleteMachine deletes a CAPI Machine, which drains its node and deletes its infrastructure
+func (k *Kubectl) DeleteMachine(ctx context.Context, managementCluster *types.Cluster, name, namespace string) error {
+	params := []string{"delete", fmt.Sprintf("machines.%s", clusterv1.GroupVersion.Group), name, "--namespace", namespace, "--kubeconfig", managementCluster.KubeconfigFile}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error deleting machine %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// GetPodLogs returns the last tailLines log lines of a container. With previous, the logs are the ones of the
+// last terminated instance of the container, which are the useful ones for a container that keeps crashing
+func (k *Kubectl) GetPodLogs(ctx context.Context, kubeconfigFile, name, namespace, container string, tailLines int, previous bool) (string, error) {
+	params := []string{"logs", name, "--namespace", namespace, "--container", container, fmt.Sprintf("--tail=%d", tailLines), "--kubeconfig", kubeconfigFile}
+	if previous {
+		params = append(params, "--previous")
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return "", fmt.Errorf("error getting logs of pod %s/%s: %v", namespace, name, err)
+	}
+
+	return stdOut.String(), nil
+}
@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
@@ -25,6 +28,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const (
@@ -262,6 +266,27 @@ func TestKubectlWaitError(t *testing.T) {
 	}
 }
 
+func TestKubectlWaitForCRDsEstablishedSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx,
+		"wait", "--timeout", "1m", "--for=condition=Established",
+		"customresourcedefinitions/clusters.anywhere.eks.amazonaws.com", "customresourcedefinitions/bundles.anywhere.eks.amazonaws.com",
+		"--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.WaitForCRDsEstablished(tt.ctx, tt.cluster, "1m", "clusters.anywhere.eks.amazonaws.com", "bundles.anywhere.eks.amazonaws.com")).To(Succeed())
+}
+
+func TestKubectlWaitForCRDsEstablishedError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx,
+		"wait", "--timeout", "1m", "--for=condition=Established", "customresourcedefinitions/clusters.anywhere.eks.amazonaws.com",
+		"--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, errors.New("timed out"))
+
+	tt.Expect(tt.k.WaitForCRDsEstablished(tt.ctx, tt.cluster, "1m", "clusters.anywhere.eks.amazonaws.com")).To(MatchError(ContainSubstring("timed out")))
+}
+
 func TestKubectlSaveLogSuccess(t *testing.T) {
 	filename := "testfile"
 	_, writer := test.NewWriter(t)
@@ -315,6 +340,119 @@ func TestKubectlDeleteClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestKubectlSetCAPIClusterPaused(t *testing.T) {
+	tt := newKubectlTest(t)
+	expectedParam := []string{
+		"patch", capiClustersResourceType, "cluster-name",
+		"--type=merge", "-p={\"spec\":{\"paused\":true}}",
+		"--kubeconfig", tt.kubeconfig, "--namespace", constants.EksaSystemNamespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, expectedParam).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.SetCAPIClusterPaused(tt.ctx, tt.cluster, "cluster-name", true)).To(Succeed())
+}
+
+func TestKubectlSetCAPIClusterPausedError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error in patch"))
+
+	tt.Expect(tt.k.SetCAPIClusterPaused(tt.ctx, tt.cluster, "cluster-name", false)).To(MatchError(ContainSubstring("error setting paused to false in CAPI cluster cluster-name")))
+}
+
+func TestKubectlSetEksaClusterEtcdStatus(t *testing.T) {
+	tt := newKubectlTest(t)
+	status := &v1alpha1.EtcdStatus{
+		LastCheckTime: metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Members:       []v1alpha1.EtcdMemberStatus{{Endpoint: "https://10.0.0.1:2379", Healthy: true, DBSize: 1024}},
+	}
+	expectedParam := []string{
+		"patch", "clusters.anywhere.eks.amazonaws.com", "cluster-name",
+		"--type=merge", `-p={"status":{"etcd":{"lastCheckTime":"2022-01-01T00:00:00Z","members":[{"endpoint":"https://10.0.0.1:2379","healthy":true,"dbSize":1024}]}}}`,
+		"--kubeconfig", tt.kubeconfig, "--namespace", "default",
+	}
+	tt.e.EXPECT().Execute(tt.ctx, expectedParam).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.SetEksaClusterEtcdStatus(tt.ctx, tt.cluster, "cluster-name", "default", status)).To(Succeed())
+}
+
+func TestKubectlSetEksaClusterEtcdStatusError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error in patch"))
+
+	tt.Expect(tt.k.SetEksaClusterEtcdStatus(tt.ctx, tt.cluster, "cluster-name", "default", &v1alpha1.EtcdStatus{})).To(MatchError(ContainSubstring("error setting etcd status of cluster cluster-name")))
+}
+
+func TestKubectlCreateCertificateSigningRequest(t *testing.T) {
+	tt := newKubectlTest(t)
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "eksa-credentials-1"},
+		Spec:       certificatesv1.CertificateSigningRequestSpec{SignerName: certificatesv1.KubeAPIServerClientSignerName},
+	}
+	data, err := json.Marshal(csr)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, data, []string{"create", "-f", "-", "--kubeconfig", tt.kubeconfig}).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.CreateCertificateSigningRequest(tt.ctx, tt.kubeconfig, csr)).To(Succeed())
+}
+
+func TestKubectlApproveCertificateSigningRequest(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, []string{"certificate", "approve", "eksa-credentials-1", "--kubeconfig", tt.kubeconfig}).Return(bytes.Buffer{}, errors.New("forbidden"))
+
+	tt.Expect(tt.k.ApproveCertificateSigningRequest(tt.ctx, tt.kubeconfig, "eksa-credentials-1")).To(MatchError("error approving certificate signing request eksa-credentials-1: forbidden"))
+}
+
+func TestKubectlGetCertificateSigningRequest(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, []string{"get", "certificatesigningrequests.certificates.k8s.io", "eksa-credentials-1", "-o", "json", "--kubeconfig", tt.kubeconfig}).
+		Return(*bytes.NewBufferString(`{"metadata":{"name":"eksa-credentials-1"},"status":{"certificate":"Y2VydA=="}}`), nil)
+
+	csr, err := tt.k.GetCertificateSigningRequest(tt.ctx, tt.kubeconfig, "eksa-credentials-1")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(csr.Status.Certificate).To(Equal([]byte("cert")))
+}
+
+func TestKubectlDeleteCertificateSigningRequest(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, []string{"delete", "certificatesigningrequests.certificates.k8s.io", "eksa-credentials-1", "--ignore-not-found", "--kubeconfig", tt.kubeconfig}).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DeleteCertificateSigningRequest(tt.ctx, tt.kubeconfig, "eksa-credentials-1")).To(Succeed())
+}
+
+func TestKubectlGetObjectNames(t *testing.T) {
+	tt := newKubectlTest(t)
+	expectedParam := []string{
+		"get", "clusters.cluster.x-k8s.io,machines.cluster.x-k8s.io", "-o", "name",
+		"--kubeconfig", tt.kubeconfig, "--namespace", constants.EksaSystemNamespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, expectedParam).Return(*bytes.NewBufferString("cluster.cluster.x-k8s.io/mgmt\nmachine.cluster.x-k8s.io/mgmt-cp\n"), nil)
+
+	got, err := tt.k.GetObjectNames(tt.ctx, tt.cluster, constants.EksaSystemNamespace, "", "clusters.cluster.x-k8s.io", "machines.cluster.x-k8s.io")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(Equal([]string{"cluster.cluster.x-k8s.io/mgmt", "machine.cluster.x-k8s.io/mgmt-cp"}))
+}
+
+func TestKubectlGetObjectNamesWithSelector(t *testing.T) {
+	tt := newKubectlTest(t)
+	expectedParam := []string{
+		"get", "machines.cluster.x-k8s.io", "-o", "name",
+		"--kubeconfig", tt.kubeconfig, "--namespace", "capi", "--selector", "cluster.x-k8s.io/cluster-name in (w-1)",
+	}
+	tt.e.EXPECT().Execute(tt.ctx, expectedParam).Return(*bytes.NewBufferString("machine.cluster.x-k8s.io/w-1-cp\n"), nil)
+
+	got, err := tt.k.GetObjectNames(tt.ctx, tt.cluster, "capi", "cluster.x-k8s.io/cluster-name in (w-1)", "machines.cluster.x-k8s.io")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(Equal([]string{"machine.cluster.x-k8s.io/w-1-cp"}))
+}
+
+func TestKubectlGetObjectNamesError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("no resource type"))
+
+	_, err := tt.k.GetObjectNames(tt.ctx, tt.cluster, constants.EksaSystemNamespace, "", "clusters.cluster.x-k8s.io")
+	tt.Expect(err).To(MatchError("error getting objects clusters.cluster.x-k8s.io: no resource type"))
+}
+
 func TestKubectlDeleteClusterError(t *testing.T) {
 	kubeconfigFile := "c.kubeconfig"
 	managementCluster := &types.Cluster{
@@ -348,6 +486,16 @@ func TestKubectlGetMachines(t *testing.T) {
 			jsonResponseFile: "testdata/kubectl_machines_no_node_ref_no_labels.json",
 			wantMachines: []types.Machine{
 				{
+					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -390,6 +538,16 @@ func TestKubectlGetMachines(t *testing.T) {
 					},
 				},
 				{
+					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-md-0-8xltl",
+						},
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -419,11 +577,19 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
 						},
 					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -434,12 +600,20 @@ func TestKubectlGetMachines(t *testing.T) {
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
 							"machine-template-hash":            "663441929",
 						},
 					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-md-0-8xltl",
+						},
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -456,11 +630,19 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
 						},
 					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -509,12 +691,20 @@ func TestKubectlGetMachines(t *testing.T) {
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
 							"machine-template-hash":            "663441929",
 						},
 					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-md-0-8xltl",
+						},
+					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
 							APIVersion: "v1",
@@ -549,11 +739,19 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name": "eksa-test-capd",
 							"cluster.x-k8s.io/etcd-cluster": "",
 						},
 					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -796,6 +994,32 @@ func TestKubectlGetEKSAClusters(t *testing.T) {
 	}
 }
 
+func TestKubectlGetEksaClusters(t *testing.T) {
+	tt := newKubectlTest(t)
+	response := `{"apiVersion": "v1", "kind": "List", "items": [
+		{"apiVersion": "anywhere.eks.amazonaws.com/v1alpha1", "kind": "Cluster", "metadata": {"name": "mgmt", "namespace": "default"}, "spec": {"managementCluster": {"name": "mgmt"}}},
+		{"apiVersion": "anywhere.eks.amazonaws.com/v1alpha1", "kind": "Cluster", "metadata": {"name": "w01", "namespace": "default"}, "spec": {"managementCluster": {"name": "mgmt"}}}
+	]}`
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		[]string{"get", "clusters.anywhere.eks.amazonaws.com", "-A", "-o", "json", "--kubeconfig", tt.kubeconfig},
+	).Return(*bytes.NewBufferString(response), nil)
+
+	clusters, err := tt.k.GetEksaClusters(tt.ctx, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(clusters).To(HaveLen(2))
+	tt.Expect(clusters[1].Name).To(Equal("w01"))
+	tt.Expect(clusters[1].ManagedBy()).To(Equal("mgmt"))
+}
+
+func TestKubectlGetEksaClustersError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error"))
+
+	_, err := tt.k.GetEksaClusters(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("error getting eksa clusters: error"))
+}
+
 func TestKubectlGetGetApiServerUrlSuccess(t *testing.T) {
 	wantUrl := "https://127.0.0.1:37479"
 	k, ctx, cluster, e := newKubectl(t)
@@ -997,6 +1221,50 @@ func TestKubectlGetDeploymentsWithAllNamespaces(t *testing.T) {
 	}
 }
 
+func TestKubectlGetDaemonSetsWithNamespace(t *testing.T) {
+	tests := []struct {
+		testName           string
+		jsonResponseFile   string
+		wantDaemonSetNames []string
+	}{
+		{
+			testName:           "no daemonsets",
+			jsonResponseFile:   "testdata/kubectl_no_deployments.json",
+			wantDaemonSetNames: []string{},
+		},
+		{
+			testName:         "multiple daemonsets",
+			jsonResponseFile: "testdata/kubectl_daemonsets.json",
+			wantDaemonSetNames: []string{
+				"cilium",
+				"kube-proxy",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			k, ctx, cluster, e := newKubectl(t)
+			fileContent := test.ReadFile(t, tt.jsonResponseFile)
+			e.EXPECT().Execute(ctx, []string{"get", "daemonsets", "-o", "json", "--kubeconfig", cluster.KubeconfigFile, "--namespace", "kube-system"}).Return(*bytes.NewBufferString(fileContent), nil)
+
+			gotDaemonSets, err := k.GetDaemonSets(ctx, executables.WithCluster(cluster), executables.WithNamespace("kube-system"))
+			if err != nil {
+				t.Fatalf("Kubectl.GetDaemonSets() error = %v, want nil", err)
+			}
+
+			gotNames := make([]string, 0, len(gotDaemonSets))
+			for _, d := range gotDaemonSets {
+				gotNames = append(gotNames, d.Name)
+			}
+
+			if !reflect.DeepEqual(gotNames, tt.wantDaemonSetNames) {
+				t.Fatalf("Kubectl.GetDaemonSets() daemonsets = %+v, want %+v", gotNames, tt.wantDaemonSetNames)
+			}
+		})
+	}
+}
+
 func TestKubectlGetDeploymentsWithServerSkipTLSAndToken(t *testing.T) {
 	server := "https://127.0.0.1:37479"
 	token := "token"
@@ -1287,6 +1555,59 @@ func TestKubectlGetBundles(t *testing.T) {
 	tt.Expect(gotBundles).To(Equal(wantBundles))
 }
 
+func TestKubectlListBundles(t *testing.T) {
+	tt := newKubectlTest(t)
+	wantBundles := test.Bundles(t)
+	listJson, err := json.Marshal(&releasev1alpha1.BundlesList{Items: []releasev1alpha1.Bundles{*wantBundles}})
+	if err != nil {
+		t.Fatalf("Failed marshalling Bundles list: %s", err)
+	}
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "bundles.anywhere.eks.amazonaws.com", "-A", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(*bytes.NewBuffer(listJson), nil)
+
+	gotBundles, err := tt.k.ListBundles(tt.ctx, tt.cluster.KubeconfigFile)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(gotBundles).To(Equal([]releasev1alpha1.Bundles{*wantBundles}))
+}
+
+func TestKubectlGetEksaRelease(t *testing.T) {
+	tt := newKubectlTest(t)
+	wantRelease := &releasev1alpha1.Release{
+		Spec: releasev1alpha1.ReleaseSpec{
+			LatestVersion: "v0.9.0",
+			Releases:      []releasev1alpha1.EksARelease{{Version: "v0.9.0", Number: 10, BundleManifestUrl: "https://bundles/10.yaml"}},
+		},
+	}
+	releaseJson, err := json.Marshal(wantRelease)
+	if err != nil {
+		t.Fatalf("Failed marshalling Release: %s", err)
+	}
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "releases.anywhere.eks.amazonaws.com", "eksa-releases", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", "eksa-system", "--ignore-not-found",
+	).Return(*bytes.NewBuffer(releaseJson), nil)
+
+	gotRelease, err := tt.k.GetEksaRelease(tt.ctx, tt.cluster.KubeconfigFile, "eksa-releases", "eksa-system")
+	tt.Expect(err).To(BeNil())
+	tt.Expect(gotRelease).To(Equal(wantRelease))
+}
+
+func TestKubectlGetEksaReleaseNotFound(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "releases.anywhere.eks.amazonaws.com", "eksa-releases", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", "eksa-system", "--ignore-not-found",
+	).Return(bytes.Buffer{}, nil)
+
+	gotRelease, err := tt.k.GetEksaRelease(tt.ctx, tt.cluster.KubeconfigFile, "eksa-releases", "eksa-system")
+	tt.Expect(err).To(BeNil())
+	tt.Expect(gotRelease).To(BeNil())
+}
+
 func TestKubectlGetClusterResourceSet(t *testing.T) {
 	tt := newKubectlTest(t)
 	resourceSetJson := test.ReadFile(t, "testdata/kubectl_clusterresourceset.json")
@@ -1360,6 +1681,152 @@ spec:
 	tt.Expect(gotConfigmap).To(Equal(wantConfigmap))
 }
 
+func TestKubectlCreateLease(t *testing.T) {
+	tt := newKubectlTest(t)
+	holder := "admin@host (pid 10)"
+	lease := &coordinationv1.Lease{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "coordination.k8s.io/v1", Kind: "Lease"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-operation-lock", Namespace: tt.namespace},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+	}
+	data, _ := json.Marshal(lease)
+
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, data, "create", "-f", "-", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.CreateLease(tt.ctx, tt.cluster.KubeconfigFile, lease)).To(Succeed())
+}
+
+func TestKubectlCreateLeaseError(t *testing.T) {
+	tt := newKubectlTest(t)
+	lease := &coordinationv1.Lease{}
+	data, _ := json.Marshal(lease)
+
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, data, "create", "-f", "-", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, errors.New("AlreadyExists"))
+
+	tt.Expect(tt.k.CreateLease(tt.ctx, tt.cluster.KubeconfigFile, lease)).To(MatchError("error creating lease: AlreadyExists"))
+}
+
+func TestKubectlUpdateLease(t *testing.T) {
+	tt := newKubectlTest(t)
+	holder := "admin@host (pid 10)"
+	lease := &coordinationv1.Lease{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "coordination.k8s.io/v1", Kind: "Lease"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-operation-lock", Namespace: tt.namespace, ResourceVersion: "12"},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+	}
+	data, _ := json.Marshal(lease)
+
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, data, "replace", "-f", "-", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.UpdateLease(tt.ctx, tt.cluster.KubeconfigFile, lease)).To(Succeed())
+}
+
+func TestKubectlUpdateLeaseError(t *testing.T) {
+	tt := newKubectlTest(t)
+	lease := &coordinationv1.Lease{}
+	data, _ := json.Marshal(lease)
+
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, data, "replace", "-f", "-", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, errors.New("Conflict"))
+
+	tt.Expect(tt.k.UpdateLease(tt.ctx, tt.cluster.KubeconfigFile, lease)).To(MatchError("error updating lease: Conflict"))
+}
+
+func TestKubectlGetLease(t *testing.T) {
+	tt := newKubectlTest(t)
+	holder := "admin@host (pid 10)"
+	wantLease := &coordinationv1.Lease{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "coordination.k8s.io/v1", Kind: "Lease"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-operation-lock", Namespace: tt.namespace},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+	}
+	leaseJson, _ := json.Marshal(wantLease)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "lease", "test-operation-lock", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(*bytes.NewBuffer(leaseJson), nil)
+
+	gotLease, err := tt.k.GetLease(tt.ctx, tt.cluster.KubeconfigFile, "test-operation-lock", tt.namespace)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(gotLease).To(Equal(wantLease))
+}
+
+func TestKubectlDeleteLease(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"delete", "lease", "test-operation-lock", "--ignore-not-found", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DeleteLease(tt.ctx, tt.cluster.KubeconfigFile, "test-operation-lock", tt.namespace)).To(Succeed())
+}
+
+func TestKubectlGetPodLogs(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"logs", "capi-controller-manager-0", "--namespace", tt.namespace, "--container", "manager", "--tail=20", "--kubeconfig", tt.cluster.KubeconfigFile, "--previous",
+	).Return(*bytes.NewBufferString("panic: invalid memory address\n"), nil)
+
+	logs, err := tt.k.GetPodLogs(tt.ctx, tt.cluster.KubeconfigFile, "capi-controller-manager-0", tt.namespace, "manager", 20, true)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(logs).To(Equal("panic: invalid memory address\n"))
+}
+
+func TestKubectlGetPodLogsError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"logs", "capi-controller-manager-0", "--namespace", tt.namespace, "--container", "manager", "--tail=20", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("pod not found"))
+
+	_, err := tt.k.GetPodLogs(tt.ctx, tt.cluster.KubeconfigFile, "capi-controller-manager-0", tt.namespace, "manager", 20, false)
+	tt.Expect(err).To(MatchError(ContainSubstring("pod not found")))
+}
+
+func TestKubectlGetEndpoints(t *testing.T) {
+	tt := newKubectlTest(t)
+	wantEndpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook-service", Namespace: tt.namespace},
+		Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	}
+	endpointsJson, _ := json.Marshal(wantEndpoints)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "endpoints", "webhook-service", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(*bytes.NewBuffer(endpointsJson), nil)
+
+	gotEndpoints, err := tt.k.GetEndpoints(tt.ctx, tt.cluster, "webhook-service", tt.namespace)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(gotEndpoints).To(Equal(wantEndpoints))
+}
+
+func TestKubectlGetCertificates(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "certificates.cert-manager.io", "-o", "json", "--all-namespaces", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(*bytes.NewBufferString(`{"items":[{"metadata":{"name":"serving-cert","namespace":"eksa-system"},"status":{"notAfter":"2022-05-11T10:00:00Z"}}]}`), nil)
+
+	gotCertificates, err := tt.k.GetCertificates(tt.ctx, tt.cluster)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(gotCertificates).To(HaveLen(1))
+	tt.Expect(gotCertificates[0].Metadata.Name).To(Equal("serving-cert"))
+	tt.Expect(gotCertificates[0].Status.NotAfter.Format("2006-01-02")).To(Equal("2022-05-11"))
+}
+
+func TestKubectlGetValidatingWebhookConfigurationsError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "validatingwebhookconfigurations", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("forbidden"))
+
+	_, err := tt.k.GetValidatingWebhookConfigurations(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError("error getting validating webhook configurations: forbidden"))
+}
+
 func TestKubectlSetDaemonSetImage(t *testing.T) {
 	tt := newKubectlTest(t)
 	daemonSetName := "ds-1"
@@ -1374,6 +1841,17 @@ func TestKubectlSetDaemonSetImage(t *testing.T) {
 	tt.Expect(tt.k.SetDaemonSetImage(tt.ctx, tt.cluster.KubeconfigFile, daemonSetName, tt.namespace, container, image)).To(Succeed())
 }
 
+func TestKubectlSetDeploymentImage(t *testing.T) {
+	tt := newKubectlTest(t)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"set", "image", "deployment/eksa-controller-manager", "manager=public.ecr.aws/image2", "--namespace", tt.namespace, "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.SetDeploymentImage(tt.ctx, tt.cluster.KubeconfigFile, "eksa-controller-manager", tt.namespace, "manager", "public.ecr.aws/image2")).To(Succeed())
+}
+
 func TestKubectlCheckCAPIProviderExistsNotInstalled(t *testing.T) {
 	tt := newKubectlTest(t)
 	providerName := "providerName"
@@ -1483,3 +1961,173 @@ func TestKubectlGetDaemonSetError(t *testing.T) {
 		return tt.k.GetDaemonSet(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
 	}).testError()
 }
+
+func TestKubectlRolloutStatus(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"rollout", "status", "deployment/coredns", "--timeout", "5m", "--namespace", "kube-system", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.RolloutStatus(tt.ctx, "deployment", "coredns", "5m", executables.WithNamespace("kube-system"), executables.WithCluster(tt.cluster))).To(Succeed())
+}
+
+func TestKubectlScale(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"scale", "deployment/coredns", "--replicas", "3", "--namespace", "kube-system", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.Scale(tt.ctx, "deployment", "coredns", 3, executables.WithNamespace("kube-system"), executables.WithCluster(tt.cluster))).To(Succeed())
+}
+
+func TestKubectlRolloutRestartError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"rollout", "restart", "daemonset/cilium", "--namespace", "kube-system", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("daemonsets.apps \"cilium\" not found"))
+
+	tt.Expect(tt.k.RolloutRestart(tt.ctx, "daemonset", "cilium", executables.WithNamespace("kube-system"), executables.WithCluster(tt.cluster))).To(
+		MatchError(ContainSubstring("error restarting daemonset/cilium")),
+	)
+}
+
+func TestKubectlCordonAndUncordonNode(t *testing.T) {
+	tt := newKubectlTest(t)
+	node := "my-cluster-md-0-abcde"
+	tt.e.EXPECT().Execute(tt.ctx, "cordon", node, "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "uncordon", node, "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.CordonNode(tt.ctx, node, executables.WithCluster(tt.cluster))).To(Succeed())
+	tt.Expect(tt.k.UncordonNode(tt.ctx, node, executables.WithCluster(tt.cluster))).To(Succeed())
+}
+
+func TestKubectlDrainNodeDefaults(t *testing.T) {
+	tt := newKubectlTest(t)
+	node := "my-cluster-md-0-abcde"
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"drain", node, "--ignore-daemonsets", "--delete-emptydir-data", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DrainNode(tt.ctx, node, executables.DrainOptions{}, executables.WithCluster(tt.cluster))).To(Succeed())
+}
+
+func TestKubectlDrainNodeWithOptions(t *testing.T) {
+	tt := newKubectlTest(t)
+	node := "my-cluster-md-0-abcde"
+	gracePeriod := 30
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"drain", node, "--ignore-daemonsets", "--delete-emptydir-data", "--timeout", "10m", "--grace-period", "30", "--disable-eviction", "--force", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	drainOpts := executables.DrainOptions{
+		Timeout:            "10m",
+		GracePeriodSeconds: &gracePeriod,
+		DisableEviction:    true,
+		Force:              true,
+	}
+	tt.Expect(tt.k.DrainNode(tt.ctx, node, drainOpts, executables.WithCluster(tt.cluster))).To(Succeed())
+}
+
+func TestKubectlDeleteMachine(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"delete", "machines.cluster.x-k8s.io", "my-cluster-md-0-abcde", "--namespace", "eksa-system", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DeleteMachine(tt.ctx, tt.cluster, "my-cluster-md-0-abcde", "eksa-system")).To(Succeed())
+}
+
+func TestKubectlGetEksaDockerDatacenterConfig(t *testing.T) {
+	tt := newKubectlTest(t)
+	want := &v1alpha1.DockerDatacenterConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.DockerDatacenterKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: tt.namespace},
+	}
+	configJson, _ := json.Marshal(want)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "dockerdatacenterconfigs.anywhere.eks.amazonaws.com", "test", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(*bytes.NewBuffer(configJson), nil)
+
+	got, err := tt.k.GetEksaDockerDatacenterConfig(tt.ctx, "test", tt.cluster.KubeconfigFile, tt.namespace)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(got).To(Equal(want))
+}
+
+func TestKubectlGetEksaTinkerbellMachineConfig(t *testing.T) {
+	tt := newKubectlTest(t)
+	want := &v1alpha1.TinkerbellMachineConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.TinkerbellMachineConfigKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cp", Namespace: tt.namespace},
+		Spec:       v1alpha1.TinkerbellMachineConfigSpec{OSFamily: v1alpha1.Ubuntu},
+	}
+	configJson, _ := json.Marshal(want)
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "tinkerbellmachineconfigs.anywhere.eks.amazonaws.com", "test-cp", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(*bytes.NewBuffer(configJson), nil)
+
+	got, err := tt.k.GetEksaTinkerbellMachineConfig(tt.ctx, "test-cp", tt.cluster.KubeconfigFile, tt.namespace)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(got).To(Equal(want))
+}
+
+func TestKubectlGetNodes(t *testing.T) {
+	tt := newKubectlTest(t)
+	list := &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}}}
+	listJson, _ := json.Marshal(list)
+	tt.e.EXPECT().Execute(tt.ctx, "get", "nodes", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile).Return(*bytes.NewBuffer(listJson), nil)
+
+	got, err := tt.k.GetNodes(tt.ctx, executables.WithCluster(tt.cluster))
+	tt.Expect(err).To(BeNil())
+	tt.Expect(got).To(Equal(list.Items))
+}
+
+func TestKubectlGetServices(t *testing.T) {
+	tt := newKubectlTest(t)
+	list := &corev1.ServiceList{Items: []corev1.Service{{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: tt.namespace},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}}}
+	listJson, _ := json.Marshal(list)
+	tt.e.EXPECT().Execute(tt.ctx, "get", "services", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "-A").Return(*bytes.NewBuffer(listJson), nil)
+
+	got, err := tt.k.GetServices(tt.ctx, executables.WithCluster(tt.cluster), executables.WithAllNamespaces())
+	tt.Expect(err).To(BeNil())
+	tt.Expect(got).To(Equal(list.Items))
+}
+
+func TestKubectlGetVolumeAttachmentsError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "get", "volumeattachments", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, errors.New("error"))
+
+	_, err := tt.k.GetVolumeAttachments(tt.ctx, executables.WithCluster(tt.cluster))
+	tt.Expect(err).To(MatchError(ContainSubstring("error getting volumeattachments")))
+}
+
+func TestKubectlDeleteServiceAndPod(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "delete", "service", "ingress", "--namespace", tt.namespace, "--ignore-not-found", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "delete", "pod", "db-0", "--namespace", tt.namespace, "--ignore-not-found", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DeleteService(tt.ctx, "ingress", tt.namespace, executables.WithCluster(tt.cluster))).To(Succeed())
+	tt.Expect(tt.k.DeletePod(tt.ctx, "db-0", tt.namespace, executables.WithCluster(tt.cluster))).To(Succeed())
+}
+
+func TestKubectlLabelNodeAndRemoveLabel(t *testing.T) {
+	tt := newKubectlTest(t)
+	node := "my-cluster-md-0-abcde"
+	tt.e.EXPECT().Execute(tt.ctx, "label", "node", node, "anywhere.eks.amazonaws.com/cni=custom", "--overwrite", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "label", "node", node, "anywhere.eks.amazonaws.com/cni-", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.LabelNode(tt.ctx, node, "anywhere.eks.amazonaws.com/cni", "custom", executables.WithCluster(tt.cluster))).To(Succeed())
+	tt.Expect(tt.k.RemoveNodeLabel(tt.ctx, node, "anywhere.eks.amazonaws.com/cni", executables.WithCluster(tt.cluster))).To(Succeed())
+}
@@ -0,0 +1,50 @@
+package executables
+
+import (
+	"bytes"
+	"sync"
+)
+
+// LogWriter is an io.Writer that logs each line written to it at a verbosity level.
+// It can be used as the stdout or stderr of a Command to stream its output to the logger
+type LogWriter struct {
+	level  int
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func NewLogWriter(level int) *LogWriter {
+	return &LogWriter{level: level}
+}
+
+func (w *LogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buffer.Write(p)
+	for {
+		i := bytes.IndexByte(w.buffer.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		w.log(w.buffer.Next(i + 1))
+	}
+
+	return len(p), nil
+}
+
+// Flush logs the last line when it doesn't end in a new line
+func (w *LogWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buffer.Len() > 0 {
+		w.log(w.buffer.Next(w.buffer.Len()))
+	}
+}
+
+func (w *LogWriter) log(line []byte) {
+	if line := string(bytes.TrimRight(line, "\r\n")); line != "" {
+		l.V(w.level).Info(line)
+	}
+}
@@ -0,0 +1,164 @@
+package executables
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+// DefaultOverridesRetention is how many versions of each component are kept in the overrides layer of a cluster
+const DefaultOverridesRetention = 2
+
+// OverridesLayerDir returns the folder of the overrides layer of a cluster, the local repository clusterctl reads the
+// components from. It has a folder per component and a folder per version inside it, one for each version installed
+func OverridesLayerDir(clusterName string) string {
+	return filepath.Join(workspace.Default().ClusterDir(clusterName), generatedDir, overridesDir)
+}
+
+// PruneOverridesLayer removes the versions of each component in the overrides layer of the cluster but keep of them.
+// The current component/version folders, the ones the bundle in use references, are always kept and the rest of the
+// keep are the highest of the other versions. It returns the removed folders
+func PruneOverridesLayer(clusterName string, keep int, current ...string) ([]string, error) {
+	if keep < 1 {
+		return nil, fmt.Errorf("overrides layer retention must keep at least 1 version, got %d", keep)
+	}
+
+	root := OverridesLayerDir(clusterName)
+	components, err := ioutil.ReadDir(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed reading overrides layer of cluster %s: %v", clusterName, err)
+	}
+
+	var removed []string
+	var errs []error
+	for _, component := range components {
+		if !component.IsDir() {
+			continue
+		}
+		componentDir := filepath.Join(root, component.Name())
+		versions, err := ioutil.ReadDir(componentDir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed reading versions of %s: %v", component.Name(), err))
+			continue
+		}
+		versions = dirs(versions)
+		if len(versions) <= keep {
+			continue
+		}
+
+		for _, version := range versionsToRemove(component.Name(), versions, keep, current) {
+			dir := filepath.Join(componentDir, version.Name())
+			if err = os.RemoveAll(dir); err != nil {
+				errs = append(errs, fmt.Errorf("failed removing %s: %v", dir, err))
+				continue
+			}
+			logger.V(4).Info("Removed old overrides layer version", "component", component.Name(), "version", version.Name())
+			removed = append(removed, dir)
+		}
+	}
+
+	return removed, kerrors.NewAggregate(errs)
+}
+
+// PurgeOverridesLayer removes the overrides layer and the clusterctl config of the cluster. Both are written again
+// by the next clusterctl command, so they are only needed while an operation runs
+func PurgeOverridesLayer(clusterName string) error {
+	if err := os.RemoveAll(OverridesLayerDir(clusterName)); err != nil {
+		return fmt.Errorf("failed removing overrides layer of cluster %s: %v", clusterName, err)
+	}
+	config := filepath.Join(workspace.Default().ClusterDir(clusterName), generatedDir, clusterctlConfigFile)
+	if err := os.Remove(config); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed removing clusterctl config of cluster %s: %v", clusterName, err)
+	}
+	return nil
+}
+
+// OverridesRetention cleans up the overrides layer of a cluster once an operation succeeds, keeping Keep versions of
+// each component. With Keep 0 the whole layer is purged
+type OverridesRetention struct {
+	Keep     int
+	Provider providers.Provider
+}
+
+// CleanUp prunes or purges the overrides layer of the cluster. The versions the bundle of the cluster spec references
+// are never pruned
+func (r OverridesRetention) CleanUp(clusterName string, clusterSpec *cluster.Spec) error {
+	if r.Keep <= 0 {
+		return PurgeOverridesLayer(clusterName)
+	}
+	removed, err := PruneOverridesLayer(clusterName, r.Keep, OverridesLayerVersions(clusterSpec, r.Provider)...)
+	if len(removed) > 0 {
+		logger.V(3).Info("Pruned overrides layer", "cluster", clusterName, "removed", len(removed), "keep", r.Keep)
+	}
+	return err
+}
+
+// versionsToRemove keeps the current versions of the component and fills the rest of keep with the highest other
+// versions. Folder names that aren't versions sort after all versions
+func versionsToRemove(component string, versions []os.FileInfo, keep int, current []string) []os.FileInfo {
+	currentVersions := map[string]bool{}
+	for _, folder := range current {
+		if filepath.Dir(folder) == component {
+			currentVersions[filepath.Base(folder)] = true
+		}
+	}
+
+	var others []os.FileInfo
+	for _, version := range versions {
+		if currentVersions[version.Name()] {
+			keep--
+			continue
+		}
+		others = append(others, version)
+	}
+	if keep < 0 {
+		keep = 0
+	}
+	if len(others) <= keep {
+		return nil
+	}
+
+	sort.SliceStable(others, func(i, j int) bool {
+		return higherVersion(others[i].Name(), others[j].Name())
+	})
+	return others[keep:]
+}
+
+func higherVersion(a, b string) bool {
+	va, errA := semver.New(a)
+	vb, errB := semver.New(b)
+	switch {
+	case errA == nil && errB == nil:
+		return va.GreaterThan(vb)
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a > b
+	}
+}
+
+func dirs(infos []os.FileInfo) []os.FileInfo {
+	d := infos[:0]
+	for _, info := range infos {
+		if info.IsDir() {
+			d = append(d, info)
+		}
+	}
+	return d
+}
@@ -0,0 +1,151 @@
+package executables_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockproviders "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// inTempDir runs the test from a temporary folder, since the cluster folders are relative to the working directory
+func inTempDir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func writeOverridesVersion(t *testing.T, component, version string, modTime time.Time) {
+	t.Helper()
+	dir := filepath.Join(executables.OverridesLayerDir("mgmt"), component, version)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "components.yaml"), []byte("kind: List"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(dir, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPruneOverridesLayer(t *testing.T) {
+	g := NewWithT(t)
+	inTempDir(t)
+	now := time.Now()
+	writeOverridesVersion(t, "cluster-api", "v1.0.0", now)
+	writeOverridesVersion(t, "cluster-api", "v1.0.2", now.Add(-2*time.Hour))
+	writeOverridesVersion(t, "cluster-api", "v1.0.1", now.Add(-time.Hour))
+	writeOverridesVersion(t, "cert-manager", "v1.5.3", now.Add(-3*time.Hour))
+
+	removed, err := executables.PruneOverridesLayer("mgmt", 2)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(removed).To(ConsistOf(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.0")))
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.1")).To(BeADirectory())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.2")).To(BeADirectory())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cert-manager", "v1.5.3")).To(BeADirectory())
+}
+
+func TestPruneOverridesLayerKeepsCurrentVersions(t *testing.T) {
+	g := NewWithT(t)
+	inTempDir(t)
+	now := time.Now()
+	writeOverridesVersion(t, "cluster-api", "v1.0.0", now.Add(-3*time.Hour))
+	writeOverridesVersion(t, "cluster-api", "v1.0.1", now.Add(-2*time.Hour))
+	writeOverridesVersion(t, "cluster-api", "v1.0.2", now.Add(-time.Hour))
+	writeOverridesVersion(t, "cluster-api", "v1.0.3", now)
+	writeOverridesVersion(t, "cert-manager", "v1.5.3", now.Add(-time.Hour))
+	writeOverridesVersion(t, "cert-manager", "v1.6.0", now)
+
+	removed, err := executables.PruneOverridesLayer("mgmt", 2, "cluster-api/v1.0.0", "cert-manager/v1.5.3")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(removed).To(ConsistOf(
+		filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.1"),
+		filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.2"),
+	))
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.0")).To(BeADirectory())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.3")).To(BeADirectory())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cert-manager", "v1.5.3")).To(BeADirectory())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cert-manager", "v1.6.0")).To(BeADirectory())
+}
+
+func TestPruneOverridesLayerKeepsAllCurrentVersions(t *testing.T) {
+	g := NewWithT(t)
+	inTempDir(t)
+	now := time.Now()
+	writeOverridesVersion(t, "cluster-api", "v1.0.0", now)
+	writeOverridesVersion(t, "cluster-api", "v1.0.1", now)
+	writeOverridesVersion(t, "cluster-api", "v1.0.2", now)
+
+	removed, err := executables.PruneOverridesLayer("mgmt", 1, "cluster-api/v1.0.0", "cluster-api/v1.0.1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(removed).To(ConsistOf(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.2")))
+}
+
+func TestPruneOverridesLayerNoLayer(t *testing.T) {
+	g := NewWithT(t)
+	inTempDir(t)
+
+	removed, err := executables.PruneOverridesLayer("mgmt", 1)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(removed).To(BeEmpty())
+}
+
+func TestPruneOverridesLayerInvalidRetention(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := executables.PruneOverridesLayer("mgmt", 0)
+	g.Expect(err).To(MatchError("overrides layer retention must keep at least 1 version, got 0"))
+}
+
+func TestOverridesRetentionCleanUpPurges(t *testing.T) {
+	g := NewWithT(t)
+	inTempDir(t)
+	writeOverridesVersion(t, "cluster-api", "v1.0.1", time.Now())
+	config := filepath.Join("mgmt", "generated", "clusterctl_tmp.yaml")
+	g.Expect(os.WriteFile(config, []byte("providers: []"), 0o644)).To(Succeed())
+
+	g.Expect(executables.OverridesRetention{Keep: 0}.CleanUp("mgmt", nil)).To(Succeed())
+	g.Expect(executables.OverridesLayerDir("mgmt")).NotTo(BeAnExistingFile())
+	g.Expect(config).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join("mgmt", "generated")).To(BeADirectory())
+}
+
+func TestOverridesRetentionCleanUpKeepsBundleVersions(t *testing.T) {
+	g := NewWithT(t)
+	inTempDir(t)
+	provider := mockproviders.NewMockProvider(gomock.NewController(t))
+	provider.EXPECT().GetInfrastructureBundle(clusterSpec).Return(&types.InfrastructureBundle{FolderName: "infrastructure-vsphere/v0.7.8"})
+	now := time.Now()
+	writeOverridesVersion(t, "cluster-api", "v0.3.19", now.Add(-time.Hour))
+	writeOverridesVersion(t, "cluster-api", "v0.3.23", now)
+	writeOverridesVersion(t, "cluster-api", "v1.0.1", now)
+	writeOverridesVersion(t, "infrastructure-vsphere", "v0.7.8", now.Add(-time.Hour))
+	writeOverridesVersion(t, "infrastructure-vsphere", "v1.0.1", now)
+
+	g.Expect(executables.OverridesRetention{Keep: 1, Provider: provider}.CleanUp("mgmt", clusterSpec)).To(Succeed())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v0.3.19")).To(BeADirectory())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v0.3.23")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "cluster-api", "v1.0.1")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "infrastructure-vsphere", "v0.7.8")).To(BeADirectory())
+	g.Expect(filepath.Join("mgmt", "generated", "overrides", "infrastructure-vsphere", "v1.0.1")).NotTo(BeAnExistingFile())
+}
+
+func TestOverridesLayerDirInWorkspace(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv("EKSA_WORKSPACE", "/home/admin/clusters")
+	defer os.Unsetenv("EKSA_WORKSPACE")
+
+	g.Expect(executables.OverridesLayerDir("mgmt")).To(Equal("/home/admin/clusters/mgmt/generated/overrides"))
+}
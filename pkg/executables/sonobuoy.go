@@ -5,8 +5,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-
-	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 const sonobuoyPath = "./sonobuoy"
@@ -22,7 +20,7 @@ func NewSonobuoy(executable Executable) *Sonobuoy {
 }
 
 func (k *Sonobuoy) Run(ctx context.Context, contextName string, args ...string) (string, error) {
-	logger.Info("Starting sonobuoy tests")
+	l.Info("Starting sonobuoy tests")
 	executionArgs := []string{
 		"--context",
 		contextName,
@@ -52,7 +50,7 @@ func (k *Sonobuoy) GetResults(ctx context.Context, contextName string, args ...s
 		return "", fmt.Errorf("error executing sonobuoy retrieve: %v", err)
 	}
 	outputFile := strings.TrimSpace(output.String())
-	logger.Info("Sonobuoy results file: " + outputFile)
+	l.Info("Sonobuoy results file: " + outputFile)
 
 	executionArgs = []string{
 		"results",
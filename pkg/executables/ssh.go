@@ -0,0 +1,90 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const sshPath = "ssh"
+
+// sshOptions don't prompt for passwords
+var sshOptions = []string{
+	"-o", "BatchMode=yes",
+	"-o", "ConnectTimeout=30",
+}
+
+// SSHHost is a machine ssh logs into with a private key. Address is an IP or name, optionally with a port.
+// Its host key is verified against KnownHostsFile. Without it, any host key is accepted, for the nodes,
+// whose host keys change every time a machine is replaced
+type SSHHost struct {
+	Address        string
+	User           string
+	PrivateKeyPath string
+	KnownHostsFile string
+}
+
+func (h SSHHost) args() []string {
+	args := append([]string{"-i", h.PrivateKeyPath}, sshOptions...)
+	if h.KnownHostsFile != "" {
+		args = append(args, "-o", "StrictHostKeyChecking=yes", "-o", "UserKnownHostsFile="+sshConfigQuote(h.KnownHostsFile))
+	} else {
+		args = append(args, "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null")
+	}
+	address := h.Address
+	if host, port, err := net.SplitHostPort(h.Address); err == nil {
+		args = append(args, "-p", port)
+		address = host
+	}
+	return append(args, fmt.Sprintf("%s@%s", h.User, address))
+}
+
+type SSH struct {
+	executable Executable
+}
+
+func NewSSH(executable Executable) *SSH {
+	return &SSH{
+		executable: executable,
+	}
+}
+
+// RunCommand runs command in host and returns its output. When bastion is not nil, the connection is proxied
+// through it, for node networks that aren't reachable from the admin machine
+func (s *SSH) RunCommand(ctx context.Context, host SSHHost, bastion *SSHHost, command string) (string, error) {
+	params := host.args()
+	if bastion != nil {
+		// the proxy command is run by a shell, ProxyJump can't take a private key for the bastion
+		proxy := append([]string{sshPath}, bastion.args()...)
+		proxy = append(proxy[:len(proxy)-1], "-W", "%h:%p", proxy[len(proxy)-1])
+		for i, arg := range proxy {
+			proxy[i] = shellQuote(arg)
+		}
+		params = append([]string{"-o", "ProxyCommand=" + strings.Join(proxy, " ")}, params...)
+	}
+	params = append(params, command)
+
+	out, err := s.executable.Execute(ctx, params...)
+	if err != nil {
+		return "", fmt.Errorf("failed running command in %s: %v", host.Address, err)
+	}
+
+	return out.String(), nil
+}
+
+// shellQuote single quotes s for a shell when it has characters the shell would interpret
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@%") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// sshConfigQuote double quotes s for an ssh option value when it has spaces, which separate the values otherwise
+func sshConfigQuote(s string) string {
+	if !strings.ContainsAny(s, " \t") {
+		return s
+	}
+	return `"` + s + `"`
+}
@@ -0,0 +1,86 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+type sshTest struct {
+	*WithT
+	ctx     context.Context
+	s       *executables.SSH
+	e       *mocks.MockExecutable
+	node    executables.SSHHost
+	options []string
+}
+
+func newSSHTest(t *testing.T) *sshTest {
+	e := mocks.NewMockExecutable(gomock.NewController(t))
+	return &sshTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		s:       executables.NewSSH(e),
+		e:       e,
+		node:    executables.SSHHost{Address: "10.0.1.10", User: "ec2-user", PrivateKeyPath: "/home/user/.ssh/node"},
+		options: []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=30", "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null"},
+	}
+}
+
+func TestSSHRunCommand(t *testing.T) {
+	tt := newSSHTest(t)
+	args := append(append([]string{"-i", "/home/user/.ssh/node"}, tt.options...), "ec2-user@10.0.1.10", "hostname")
+	tt.e.EXPECT().Execute(tt.ctx, args).Return(*bytes.NewBufferString("node-1\n"), nil)
+
+	tt.Expect(tt.s.RunCommand(tt.ctx, tt.node, nil, "hostname")).To(Equal("node-1\n"))
+}
+
+func TestSSHRunCommandWithPort(t *testing.T) {
+	tt := newSSHTest(t)
+	tt.node.Address = "10.0.1.10:2222"
+	args := append(append([]string{"-i", "/home/user/.ssh/node"}, tt.options...), "-p", "2222", "ec2-user@10.0.1.10", "hostname")
+	tt.e.EXPECT().Execute(tt.ctx, args).Return(*bytes.NewBufferString("node-1\n"), nil)
+
+	tt.Expect(tt.s.RunCommand(tt.ctx, tt.node, nil, "hostname")).To(Equal("node-1\n"))
+}
+
+func TestSSHRunCommandThroughBastion(t *testing.T) {
+	tt := newSSHTest(t)
+	bastion := &executables.SSHHost{Address: "bastion.example.com:2200", User: "jump", PrivateKeyPath: "/home/user/.ssh/bastion"}
+	proxy := "ProxyCommand=ssh -i /home/user/.ssh/bastion -o BatchMode=yes -o ConnectTimeout=30 -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -p 2200 -W %h:%p jump@bastion.example.com"
+	args := append(append([]string{"-o", proxy, "-i", "/home/user/.ssh/node"}, tt.options...), "ec2-user@10.0.1.10", "hostname")
+	tt.e.EXPECT().Execute(tt.ctx, args).Return(*bytes.NewBufferString("node-1\n"), nil)
+
+	tt.Expect(tt.s.RunCommand(tt.ctx, tt.node, bastion, "hostname")).To(Equal("node-1\n"))
+}
+
+func TestSSHRunCommandThroughBastionVerifiesHostKey(t *testing.T) {
+	tt := newSSHTest(t)
+	bastion := &executables.SSHHost{
+		Address:        "bastion.example.com",
+		User:           "jump",
+		PrivateKeyPath: "/home/my user/.ssh/bastion",
+		KnownHostsFile: "/home/my user/.ssh/known_hosts",
+	}
+	proxy := `ProxyCommand=ssh -i '/home/my user/.ssh/bastion' -o BatchMode=yes -o ConnectTimeout=30 -o StrictHostKeyChecking=yes ` +
+		`-o 'UserKnownHostsFile="/home/my user/.ssh/known_hosts"' -W %h:%p jump@bastion.example.com`
+	args := append(append([]string{"-o", proxy, "-i", "/home/user/.ssh/node"}, tt.options...), "ec2-user@10.0.1.10", "hostname")
+	tt.e.EXPECT().Execute(tt.ctx, args).Return(*bytes.NewBufferString("node-1\n"), nil)
+
+	tt.Expect(tt.s.RunCommand(tt.ctx, tt.node, bastion, "hostname")).To(Equal("node-1\n"))
+}
+
+func TestSSHRunCommandError(t *testing.T) {
+	tt := newSSHTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("connection refused"))
+
+	_, err := tt.s.RunCommand(tt.ctx, tt.node, nil, "hostname")
+	tt.Expect(err).To(MatchError("failed running command in 10.0.1.10: connection refused"))
+}
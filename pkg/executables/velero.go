@@ -0,0 +1,77 @@
+package executables
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	veleroPath = "velero"
+
+	veleroPhaseCompleted = "Completed"
+)
+
+type Velero struct {
+	executable Executable
+}
+
+func NewVelero(executable Executable) *Velero {
+	return &Velero{
+		executable: executable,
+	}
+}
+
+type veleroStatus struct {
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// CreateBackup backs up the resources and volume snapshots of the namespaces in the cluster, all the namespaces if empty,
+// and waits until the backup finishes. It requires the Velero server to be installed in the cluster
+func (v *Velero) CreateBackup(ctx context.Context, cluster *types.Cluster, name string, namespaces []string) error {
+	params := []string{"backup", "create", name, "--snapshot-volumes", "--wait", "--kubeconfig", cluster.KubeconfigFile}
+	if len(namespaces) > 0 {
+		params = append(params, "--include-namespaces", strings.Join(namespaces, ","))
+	}
+
+	if _, err := v.executable.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("failed creating velero backup %s: %v", name, err)
+	}
+
+	return v.checkCompleted(ctx, cluster, "backup", name)
+}
+
+// Restore restores the backup with the given name in the cluster and waits until the restore finishes
+func (v *Velero) Restore(ctx context.Context, cluster *types.Cluster, backupName, restoreName string) error {
+	params := []string{"restore", "create", restoreName, "--from-backup", backupName, "--wait", "--kubeconfig", cluster.KubeconfigFile}
+	if _, err := v.executable.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("failed restoring velero backup %s: %v", backupName, err)
+	}
+
+	return v.checkCompleted(ctx, cluster, "restore", restoreName)
+}
+
+// checkCompleted fails if a backup or restore didn't complete. velero create --wait succeeds even if the backup
+// or restore fails or only partially succeeds
+func (v *Velero) checkCompleted(ctx context.Context, cluster *types.Cluster, kind, name string) error {
+	stdOut, err := v.executable.Execute(ctx, kind, "get", name, "-o", "json", "--kubeconfig", cluster.KubeconfigFile)
+	if err != nil {
+		return fmt.Errorf("failed getting velero %s %s: %v", kind, name, err)
+	}
+
+	status := &veleroStatus{}
+	if err = json.Unmarshal(stdOut.Bytes(), status); err != nil {
+		return fmt.Errorf("failed parsing velero %s %s: %v", kind, name, err)
+	}
+
+	if status.Status.Phase != veleroPhaseCompleted {
+		return fmt.Errorf("velero %s %s didn't complete, phase is %s, check it with velero %s describe %s --details", kind, name, status.Status.Phase, kind, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type veleroTest struct {
+	*WithT
+	ctx     context.Context
+	v       *executables.Velero
+	e       *mocks.MockExecutable
+	cluster *types.Cluster
+}
+
+func newVeleroTest(t *testing.T) *veleroTest {
+	ctrl := gomock.NewController(t)
+	e := mocks.NewMockExecutable(ctrl)
+	return &veleroTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		v:     executables.NewVelero(e),
+		e:     e,
+		cluster: &types.Cluster{
+			Name:           "w01",
+			KubeconfigFile: "w01/w01-eks-a-cluster.kubeconfig",
+		},
+	}
+}
+
+func TestVeleroCreateBackupSuccess(t *testing.T) {
+	tt := newVeleroTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "backup", "create", "b1", "--snapshot-volumes", "--wait", "--kubeconfig", tt.cluster.KubeconfigFile, "--include-namespaces", "default,apps").Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "backup", "get", "b1", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile).Return(*bytes.NewBufferString(`{"status":{"phase":"Completed"}}`), nil)
+
+	tt.Expect(tt.v.CreateBackup(tt.ctx, tt.cluster, "b1", []string{"default", "apps"})).To(Succeed())
+}
+
+func TestVeleroCreateBackupAllNamespaces(t *testing.T) {
+	tt := newVeleroTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "backup", "create", "b1", "--snapshot-volumes", "--wait", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "backup", "get", "b1", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile).Return(*bytes.NewBufferString(`{"status":{"phase":"Completed"}}`), nil)
+
+	tt.Expect(tt.v.CreateBackup(tt.ctx, tt.cluster, "b1", nil)).To(Succeed())
+}
+
+func TestVeleroCreateBackupError(t *testing.T) {
+	tt := newVeleroTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("velero not installed"))
+
+	tt.Expect(tt.v.CreateBackup(tt.ctx, tt.cluster, "b1", nil)).To(MatchError("failed creating velero backup b1: velero not installed"))
+}
+
+func TestVeleroCreateBackupPartiallyFailed(t *testing.T) {
+	tt := newVeleroTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "backup", "create", "b1", "--snapshot-volumes", "--wait", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "backup", "get", "b1", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile).Return(*bytes.NewBufferString(`{"status":{"phase":"PartiallyFailed"}}`), nil)
+
+	tt.Expect(tt.v.CreateBackup(tt.ctx, tt.cluster, "b1", nil)).To(MatchError("velero backup b1 didn't complete, phase is PartiallyFailed, check it with velero backup describe b1 --details"))
+}
+
+func TestVeleroRestoreSuccess(t *testing.T) {
+	tt := newVeleroTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "restore", "create", "r1", "--from-backup", "b1", "--wait", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "restore", "get", "r1", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile).Return(*bytes.NewBufferString(`{"status":{"phase":"Completed"}}`), nil)
+
+	tt.Expect(tt.v.Restore(tt.ctx, tt.cluster, "b1", "r1")).To(Succeed())
+}
+
+func TestVeleroRestoreInvalidStatus(t *testing.T) {
+	tt := newVeleroTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "restore", "create", "r1", "--from-backup", "b1", "--wait", "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+	tt.e.EXPECT().Execute(tt.ctx, "restore", "get", "r1", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile).Return(*bytes.NewBufferString(`not json`), nil)
+
+	tt.Expect(tt.v.Restore(tt.ctx, tt.cluster, "b1", "r1")).To(MatchError(ContainSubstring("failed parsing velero restore r1")))
+}
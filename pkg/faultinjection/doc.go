@@ -0,0 +1,17 @@
+/*
+Package faultinjection makes chosen tasks and executable calls fail on purpose, to test the rollback, resume and
+cleanup paths of the workflows end to end without breaking real infrastructure. It's only meant for tests and is
+disabled unless EnvVar is set.
+
+EnvVar holds rules separated by ";". Each rule is a list of key=value conditions separated by ",", and an executable
+call fails when it matches all the conditions of a rule:
+
+	task=<name>      the call is made while the task runs, for example task=upgrade-workload-cluster
+	exec=<cli args>  the binary is cli and the args include the given words in order, for example exec=kubectl apply
+	nth=<n>          only the nth call matching the other conditions fails, instead of all of them
+
+For example, EKSA_FAULT_INJECTION="exec=clusterctl move,nth=2;task=delete-bootstrap-cluster" fails the second
+clusterctl move and every call made by the delete-bootstrap-cluster task. Failing the calls, instead of the tasks
+themselves, lets each task go through its own error handling.
+*/
+package faultinjection
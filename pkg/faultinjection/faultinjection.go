@@ -0,0 +1,194 @@
+package faultinjection
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// EnvVar enables fault injection with the rules it holds
+const EnvVar = "EKSA_FAULT_INJECTION"
+
+// InjectedError is the error returned for the calls that match a rule
+type InjectedError struct {
+	Rule    string
+	Command string
+}
+
+func (e *InjectedError) Error() string {
+	return fmt.Sprintf("injected failure for %q by %s rule %q", e.Command, EnvVar, e.Rule)
+}
+
+// Rule fails the executable calls matching all its conditions
+type Rule struct {
+	Task string
+	CLI  string
+	Args []string
+	// Nth is the only matching call that fails, counting from 1. All the matching calls fail when it's 0
+	Nth int
+
+	spec    string
+	matched int
+}
+
+// Injector holds the rules and the task running, since executable calls don't know which task made them
+type Injector struct {
+	mu          sync.Mutex
+	rules       []*Rule
+	currentTask string
+}
+
+// Parse returns an Injector with the rules of spec, in the EnvVar format
+func Parse(spec string) (*Injector, error) {
+	i := &Injector{}
+	for _, r := range strings.Split(spec, ";") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		rule, err := parseRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s rule %q: %v", EnvVar, r, err)
+		}
+		i.rules = append(i.rules, rule)
+	}
+	return i, nil
+}
+
+func parseRule(spec string) (*Rule, error) {
+	rule := &Rule{spec: spec}
+	for _, condition := range strings.Split(spec, ",") {
+		kv := strings.SplitN(condition, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[1]) == "" {
+			return nil, fmt.Errorf("condition %q is not key=value", condition)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch key {
+		case "task":
+			rule.Task = value
+		case "exec":
+			words := strings.Fields(value)
+			rule.CLI, rule.Args = words[0], words[1:]
+		case "nth":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("nth must be a positive number, got %q", value)
+			}
+			rule.Nth = n
+		default:
+			return nil, fmt.Errorf("unknown condition %q, it must be task, exec or nth", key)
+		}
+	}
+	if rule.Task == "" && rule.CLI == "" {
+		return nil, fmt.Errorf("it needs a task or an exec condition")
+	}
+	return rule, nil
+}
+
+// StartTask records the task running, for the rules with a task condition
+func (i *Injector) StartTask(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.currentTask = name
+}
+
+// FinishTask records that no task is running
+func (i *Injector) FinishTask() {
+	i.StartTask("")
+}
+
+// Exec returns an InjectedError if the call of cli with args matches a rule, nil otherwise
+func (i *Injector) Exec(cli string, args []string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	cli = filepath.Base(cli)
+	for _, rule := range i.rules {
+		if !rule.matches(i.currentTask, cli, args) {
+			continue
+		}
+		rule.matched++
+		if rule.Nth != 0 && rule.matched != rule.Nth {
+			continue
+		}
+		command := strings.TrimSpace(cli + " " + strings.Join(args, " "))
+		logger.Info("Warning: injecting failure", "rule", rule.spec, "task", i.currentTask, "command", command)
+		return &InjectedError{Rule: rule.spec, Command: command}
+	}
+	return nil
+}
+
+func (r *Rule) matches(task, cli string, args []string) bool {
+	if r.Task != "" && r.Task != task {
+		return false
+	}
+	if r.CLI == "" {
+		return true
+	}
+	return r.CLI == cli && containsInOrder(args, r.Args)
+}
+
+// containsInOrder reports whether words appear in args in the same order, not necessarily next to each other
+func containsInOrder(args, words []string) bool {
+	n := 0
+	for _, arg := range args {
+		if n < len(words) && arg == words[n] {
+			n++
+		}
+	}
+	return n == len(words)
+}
+
+var (
+	global     *Injector
+	globalOnce sync.Once
+)
+
+// fromEnv returns the Injector with the rules of EnvVar, nil when it's not set or invalid
+func fromEnv() *Injector {
+	globalOnce.Do(func() {
+		spec := os.Getenv(EnvVar)
+		if spec == "" {
+			return
+		}
+		i, err := Parse(spec)
+		if err != nil {
+			logger.Error(err, "Fault injection disabled")
+			return
+		}
+		logger.Info("Warning: fault injection enabled, this is only meant for tests", "rules", spec)
+		global = i
+	})
+	return global
+}
+
+// Enabled reports whether EnvVar has valid rules
+func Enabled() bool {
+	return fromEnv() != nil
+}
+
+// StartTask records the task running in the Injector of EnvVar, if enabled
+func StartTask(name string) {
+	if i := fromEnv(); i != nil {
+		i.StartTask(name)
+	}
+}
+
+// FinishTask records that no task is running in the Injector of EnvVar, if enabled
+func FinishTask() {
+	if i := fromEnv(); i != nil {
+		i.FinishTask()
+	}
+}
+
+// Exec checks the call of cli with args against the rules of EnvVar, if enabled
+func Exec(cli string, args []string) error {
+	if i := fromEnv(); i != nil {
+		return i.Exec(cli, args)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package faultinjection_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/faultinjection"
+)
+
+func TestInjectorExec(t *testing.T) {
+	g := NewWithT(t)
+	i, err := faultinjection.Parse("exec=kubectl apply eksa-system")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(i.Exec("kubectl", []string{"get", "pods", "--namespace", "eksa-system"})).To(Succeed())
+	g.Expect(i.Exec("/usr/bin/clusterctl", []string{"apply", "eksa-system"})).To(Succeed())
+
+	err = i.Exec("/usr/bin/kubectl", []string{"apply", "-f", "-", "--namespace", "eksa-system"})
+	injected := &faultinjection.InjectedError{}
+	g.Expect(errors.As(err, &injected)).To(BeTrue())
+	g.Expect(injected.Rule).To(Equal("exec=kubectl apply eksa-system"))
+	g.Expect(err).To(MatchError(`injected failure for "kubectl apply -f - --namespace eksa-system" by EKSA_FAULT_INJECTION rule "exec=kubectl apply eksa-system"`))
+}
+
+func TestInjectorExecNth(t *testing.T) {
+	g := NewWithT(t)
+	i, err := faultinjection.Parse("exec=clusterctl move,nth=2")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(i.Exec("clusterctl", []string{"move"})).To(Succeed())
+	g.Expect(i.Exec("clusterctl", []string{"init"})).To(Succeed())
+	g.Expect(i.Exec("clusterctl", []string{"move"})).NotTo(Succeed())
+	g.Expect(i.Exec("clusterctl", []string{"move"})).To(Succeed())
+}
+
+func TestInjectorTask(t *testing.T) {
+	g := NewWithT(t)
+	i, err := faultinjection.Parse("task=delete-bootstrap-cluster ; task=upgrade-workload-cluster,exec=kubectl wait")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(i.Exec("kind", []string{"delete", "cluster"})).To(Succeed())
+
+	i.StartTask("delete-bootstrap-cluster")
+	g.Expect(i.Exec("kind", []string{"delete", "cluster"})).NotTo(Succeed())
+	i.FinishTask()
+
+	i.StartTask("upgrade-workload-cluster")
+	g.Expect(i.Exec("kubectl", []string{"apply"})).To(Succeed())
+	g.Expect(i.Exec("kubectl", []string{"wait", "--for=condition=Ready"})).NotTo(Succeed())
+	i.FinishTask()
+	g.Expect(i.Exec("kubectl", []string{"wait"})).To(Succeed())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{
+			spec:    "nth=2",
+			wantErr: `invalid EKSA_FAULT_INJECTION rule "nth=2": it needs a task or an exec condition`,
+		},
+		{
+			spec:    "exec=kubectl,nth=0",
+			wantErr: `invalid EKSA_FAULT_INJECTION rule "exec=kubectl,nth=0": nth must be a positive number, got "0"`,
+		},
+		{
+			spec:    "command=kubectl",
+			wantErr: `invalid EKSA_FAULT_INJECTION rule "command=kubectl": unknown condition "command", it must be task, exec or nth`,
+		},
+		{
+			spec:    "task",
+			wantErr: `invalid EKSA_FAULT_INJECTION rule "task": condition "task" is not key=value`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := faultinjection.Parse(tc.spec)
+			g.Expect(err).To(MatchError(tc.wantErr))
+		})
+	}
+}
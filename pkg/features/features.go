@@ -6,6 +6,11 @@ const (
 	TinkerbellProviderEnvVar = "TINKERBELL_PROVIDER"
 	FullLifecycleAPIEnvVar   = "FULL_LIFECYCLE_API"
 	FullLifecycleGate        = "FullLifecycleAPI"
+	TaintsSupportGate        = "TaintsSupport"
+	NodeLabelsSupportGate    = "NodeLabelsSupport"
+	TinkerbellProviderGate   = "TinkerbellProvider"
+	PodSecuritySupportEnvVar = "POD_SECURITY_SUPPORT"
+	PodSecuritySupportGate   = "PodSecuritySupport"
 )
 
 func FeedGates(featureGates []string) {
@@ -15,6 +20,9 @@ func FeedGates(featureGates []string) {
 type Feature struct {
 	Name     string
 	IsActive func() bool
+	// Gate is the name used to enable the feature with a feature gate. The env var takes precedence when set
+	Gate   string
+	EnvVar string
 }
 
 func IsActive(feature Feature) bool {
@@ -24,14 +32,18 @@ func IsActive(feature Feature) bool {
 func TaintsSupport() Feature {
 	return Feature{
 		Name:     "Taints support",
-		IsActive: globalFeatures.isActiveForEnvVar(TaintsSupportEnvVar),
+		IsActive: globalFeatures.isActiveForEnvVarOrGate(TaintsSupportEnvVar, TaintsSupportGate),
+		Gate:     TaintsSupportGate,
+		EnvVar:   TaintsSupportEnvVar,
 	}
 }
 
 func NodeLabelsSupport() Feature {
 	return Feature{
 		Name:     "Node labels support",
-		IsActive: globalFeatures.isActiveForEnvVar(NodeLabelsSupportEnvVar),
+		IsActive: globalFeatures.isActiveForEnvVarOrGate(NodeLabelsSupportEnvVar, NodeLabelsSupportGate),
+		Gate:     NodeLabelsSupportGate,
+		EnvVar:   NodeLabelsSupportEnvVar,
 	}
 }
 
@@ -39,12 +51,27 @@ func FullLifecycleAPI() Feature {
 	return Feature{
 		Name:     "Full lifecycle API support through the EKS-A controller",
 		IsActive: globalFeatures.isActiveForEnvVarOrGate(FullLifecycleAPIEnvVar, FullLifecycleGate),
+		Gate:     FullLifecycleGate,
+		EnvVar:   FullLifecycleAPIEnvVar,
 	}
 }
 
 func TinkerbellProvider() Feature {
 	return Feature{
 		Name:     "Tinkerbell provider support",
-		IsActive: globalFeatures.isActiveForEnvVar(TinkerbellProviderEnvVar),
+		IsActive: globalFeatures.isActiveForEnvVarOrGate(TinkerbellProviderEnvVar, TinkerbellProviderGate),
+		Gate:     TinkerbellProviderGate,
+		EnvVar:   TinkerbellProviderEnvVar,
+	}
+}
+
+// PodSecuritySupport gates the podSecurity cluster spec, since the PodSecurity admission plugin needs a Kubernetes
+// version newer than the ones in the current bundles
+func PodSecuritySupport() Feature {
+	return Feature{
+		Name:     "Pod security support",
+		IsActive: globalFeatures.isActiveForEnvVarOrGate(PodSecuritySupportEnvVar, PodSecuritySupportGate),
+		Gate:     PodSecuritySupportGate,
+		EnvVar:   PodSecuritySupportEnvVar,
 	}
 }
@@ -34,6 +34,7 @@ func setupContext(t *testing.T) {
 		// cleanup cache
 		globalFeatures.cache = newMutexMap()
 		globalFeatures.initGates = sync.Once{}
+		globalFeatures.gates = map[string]string{}
 		if set {
 			os.Setenv(fakeFeatureEnvVar, envVarOrgValue)
 		} else {
@@ -75,3 +76,14 @@ func TestIsActiveWithFeatureGatesTrue(t *testing.T) {
 
 	g.Expect(IsActive(fakeFeatureWithGate())).To(BeTrue())
 }
+
+func TestFeedGatesAfterIsActive(t *testing.T) {
+	g := NewWithT(t)
+	setupContext(t)
+
+	os.Unsetenv(fakeFeatureEnvVar)
+	g.Expect(IsActive(fakeFeatureWithGate())).To(BeFalse())
+
+	FeedGates([]string{fmt.Sprintf("%s=true", fakeFeatureGate)})
+	g.Expect(IsActive(fakeFeatureWithGate())).To(BeTrue())
+}
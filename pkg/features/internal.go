@@ -31,6 +31,8 @@ func (f *features) feedGates(featureGates []string) {
 
 			f.gates[pairs[0]] = pairs[1]
 		}
+		// Features checked before the gates were fed need to be evaluated again
+		f.cache.clear()
 	})
 }
 
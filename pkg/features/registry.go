@@ -0,0 +1,69 @@
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// registry holds every feature that can be enabled with a feature gate.
+// New experimental features need to be added here to be accepted in the cluster feature gates
+var registry = []func() Feature{
+	TaintsSupport,
+	NodeLabelsSupport,
+	FullLifecycleAPI,
+	TinkerbellProvider,
+	PodSecuritySupport,
+}
+
+// All returns all the registered features
+func All() []Feature {
+	all := make([]Feature, 0, len(registry))
+	for _, f := range registry {
+		all = append(all, f())
+	}
+	return all
+}
+
+// Active returns the registered features currently enabled, either through their env var or their gate
+func Active() []Feature {
+	var active []Feature
+	for _, f := range All() {
+		if f.IsActive() {
+			active = append(active, f)
+		}
+	}
+	return active
+}
+
+// ValidateGates checks that all the gates have the form name=true|false and belong to a registered feature
+func ValidateGates(gates []string) error {
+	known := map[string]bool{}
+	for _, f := range All() {
+		known[f.Gate] = true
+	}
+
+	for _, gate := range gates {
+		pairs := strings.SplitN(gate, "=", 2)
+		if len(pairs) != 2 {
+			return fmt.Errorf("invalid feature gate %s, it should have the form name=true|false", gate)
+		}
+		if !known[pairs[0]] {
+			return fmt.Errorf("unknown feature gate %s, supported gates: %s", pairs[0], strings.Join(gateNames(known), ", "))
+		}
+		if pairs[1] != "true" && pairs[1] != "false" {
+			return fmt.Errorf("invalid value %s for feature gate %s, it should be true or false", pairs[1], pairs[0])
+		}
+	}
+
+	return nil
+}
+
+func gateNames(known map[string]bool) []string {
+	names := make([]string, 0, len(known))
+	for n := range known {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
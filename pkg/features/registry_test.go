@@ -0,0 +1,83 @@
+package features
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAllFeaturesHaveGates(t *testing.T) {
+	g := NewWithT(t)
+	gates := map[string]bool{}
+	for _, f := range All() {
+		g.Expect(f.Gate).NotTo(BeEmpty(), "feature %s doesn't have a gate", f.Name)
+		g.Expect(gates).NotTo(HaveKey(f.Gate), "gate %s is registered more than once", f.Gate)
+		gates[f.Gate] = true
+	}
+}
+
+func TestActive(t *testing.T) {
+	g := NewWithT(t)
+	setupContext(t)
+	for _, f := range All() {
+		value, set := os.LookupEnv(f.EnvVar)
+		envVar := f.EnvVar
+		t.Cleanup(func() {
+			if set {
+				os.Setenv(envVar, value)
+			} else {
+				os.Unsetenv(envVar)
+			}
+		})
+		os.Unsetenv(f.EnvVar)
+	}
+	os.Setenv(TaintsSupportEnvVar, "true")
+
+	FeedGates([]string{TinkerbellProviderGate + "=true", NodeLabelsSupportGate + "=false"})
+
+	var active []string
+	for _, f := range Active() {
+		active = append(active, f.Gate)
+	}
+	g.Expect(active).To(ConsistOf(TaintsSupportGate, TinkerbellProviderGate))
+}
+
+func TestValidateGates(t *testing.T) {
+	tests := []struct {
+		name    string
+		gates   []string
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			gates: []string{TinkerbellProviderGate + "=true", FullLifecycleGate + "=false"},
+		},
+		{
+			name:    "missing value",
+			gates:   []string{TinkerbellProviderGate},
+			wantErr: "invalid feature gate TinkerbellProvider, it should have the form name=true|false",
+		},
+		{
+			name:    "unknown gate",
+			gates:   []string{"Unknown=true"},
+			wantErr: "unknown feature gate Unknown, supported gates: FullLifecycleAPI, NodeLabelsSupport, PodSecuritySupport, TaintsSupport, TinkerbellProvider",
+		},
+		{
+			name:    "invalid value",
+			gates:   []string{TaintsSupportGate + "=yes"},
+			wantErr: "invalid value yes for feature gate TaintsSupport, it should be true or false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateGates(tt.gates)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package files
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ArtifactsIndexFile is the name of the index written in the root of the downloaded artifacts directory
+const ArtifactsIndexFile = "artifacts-index.yaml"
+
+// ArtifactsIndex maps the URI of each downloaded artifact to its path, relative to the artifacts directory
+type ArtifactsIndex struct {
+	Artifacts map[string]string `json:"artifacts"`
+}
+
+func NewArtifactsIndex() *ArtifactsIndex {
+	return &ArtifactsIndex{Artifacts: map[string]string{}}
+}
+
+func (i *ArtifactsIndex) Add(uri, path string) {
+	i.Artifacts[uri] = filepath.ToSlash(path)
+}
+
+func (i *ArtifactsIndex) Has(uri string) bool {
+	_, ok := i.Artifacts[uri]
+	return ok
+}
+
+// Write stores the index in dir, so the artifacts can be found after extracting the downloads tarball
+func (i *ArtifactsIndex) Write(dir string) error {
+	content, err := yaml.Marshal(i)
+	if err != nil {
+		return fmt.Errorf("failed marshalling artifacts index: %v", err)
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, ArtifactsIndexFile), content, 0o644); err != nil {
+		return fmt.Errorf("failed writing artifacts index: %v", err)
+	}
+
+	return nil
+}
+
+// ReadArtifactsIndex reads the index of an extracted artifacts directory
+func ReadArtifactsIndex(dir string) (*ArtifactsIndex, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, ArtifactsIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading artifacts index, make sure %s is a directory extracted from 'eksctl anywhere download artifacts': %v", dir, err)
+	}
+
+	index := NewArtifactsIndex()
+	if err = yaml.Unmarshal(content, index); err != nil {
+		return nil, fmt.Errorf("failed parsing artifacts index: %v", err)
+	}
+
+	return index, nil
+}
@@ -0,0 +1,71 @@
+package files
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const etagSuffix = ".etag"
+
+// etagCache stores downloaded files in disk together with their ETag.
+// All methods are no-ops on a nil cache so callers don't need to check if caching is enabled.
+type etagCache struct {
+	dir string
+}
+
+func newEtagCache(dir string) *etagCache {
+	return &etagCache{dir: dir}
+}
+
+func (c *etagCache) path(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *etagCache) get(uri string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(c.path(uri))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (c *etagCache) etag(uri string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	if _, ok := c.get(uri); !ok {
+		return "", false
+	}
+	etag, err := ioutil.ReadFile(c.path(uri) + etagSuffix)
+	if err != nil || len(etag) == 0 {
+		return "", false
+	}
+	return string(etag), true
+}
+
+func (c *etagCache) put(uri, etag string, data []byte) {
+	if c == nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		logger.V(4).Info("Failed creating file cache dir", "dir", c.dir, "error", err)
+		return
+	}
+	path := c.path(uri)
+	if err := ioutil.WriteFile(path, data, 0o644); err != nil {
+		logger.V(4).Info("Failed caching file", "url", uri, "error", err)
+		return
+	}
+	if err := ioutil.WriteFile(path+etagSuffix, []byte(etag), 0o644); err != nil {
+		logger.V(4).Info("Failed caching file etag", "url", uri, "error", err)
+	}
+}
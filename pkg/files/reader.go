@@ -2,22 +2,53 @@ package files
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 const (
 	httpsScheme = "https"
 	embedScheme = "embed"
+
+	defaultRateLimitMaxWait = 1 * time.Minute
+	maxRateLimitRetries     = 3
 )
 
+var githubHosts = map[string]bool{
+	"github.com":                true,
+	"api.github.com":            true,
+	"raw.githubusercontent.com": true,
+}
+
 type Reader struct {
-	embedFS    embed.FS
-	httpClient *http.Client
-	userAgent  string
+	embedFS          embed.FS
+	httpClient       *http.Client
+	userAgent        string
+	mirrors          []mirror
+	cache            *etagCache
+	rateLimitMaxWait time.Duration
+	githubToken      string
+	localArtifacts   *localArtifacts
+}
+
+// localArtifacts serves the https files from a downloaded artifacts directory
+type localArtifacts struct {
+	dir   string
+	index *ArtifactsIndex
+}
+
+// mirror serves the same files as origin under a different base url
+type mirror struct {
+	origin, url string
 }
 
 type ReaderOpt func(*Reader)
@@ -34,11 +65,59 @@ func WithUserAgent(userAgent string) ReaderOpt {
 	}
 }
 
+func WithHTTPClient(client *http.Client) ReaderOpt {
+	return func(s *Reader) {
+		s.httpClient = client
+	}
+}
+
+// WithMirror makes the reader try mirrorBaseURL before originBaseURL for every https file under originBaseURL.
+// Mirrors are tried in the order they are added and the origin is used as the last resort.
+func WithMirror(originBaseURL, mirrorBaseURL string) ReaderOpt {
+	return func(s *Reader) {
+		s.mirrors = append(s.mirrors, mirror{
+			origin: strings.TrimSuffix(originBaseURL, "/"),
+			url:    strings.TrimSuffix(mirrorBaseURL, "/"),
+		})
+	}
+}
+
+// WithCacheDir enables caching https files in dir. Cached files are revalidated with their ETag
+// and are used as a fallback when no server can serve the file
+func WithCacheDir(dir string) ReaderOpt {
+	return func(s *Reader) {
+		s.cache = newEtagCache(dir)
+	}
+}
+
+// WithRateLimitMaxWait sets the max time to wait for a rate limit to reset before giving up on a server
+func WithRateLimitMaxWait(wait time.Duration) ReaderOpt {
+	return func(s *Reader) {
+		s.rateLimitMaxWait = wait
+	}
+}
+
+// WithGithubToken authenticates the requests to GitHub hosts, which get a much higher rate limit
+func WithGithubToken(token string) ReaderOpt {
+	return func(s *Reader) {
+		s.githubToken = token
+	}
+}
+
+// WithLocalArtifacts reads the https files from the artifacts in dir, downloaded with 'eksctl anywhere download artifacts'.
+// Files not in the index fail without trying to download them, so the reader never needs network access
+func WithLocalArtifacts(dir string, index *ArtifactsIndex) ReaderOpt {
+	return func(s *Reader) {
+		s.localArtifacts = &localArtifacts{dir: dir, index: index}
+	}
+}
+
 func NewReader(opts ...ReaderOpt) *Reader {
 	r := &Reader{
-		embedFS:    embed.FS{},
-		httpClient: &http.Client{},
-		userAgent:  "eks-a/unknown",
+		embedFS:          embed.FS{},
+		httpClient:       &http.Client{},
+		userAgent:        "eks-a/unknown",
+		rateLimitMaxWait: defaultRateLimitMaxWait,
 	}
 
 	for _, o := range opts {
@@ -56,6 +135,9 @@ func (r *Reader) ReadFile(uri string) ([]byte, error) {
 
 	switch url.Scheme {
 	case httpsScheme:
+		if r.localArtifacts != nil {
+			return r.localArtifacts.readFile(uri)
+		}
 		return r.readHttpFile(uri)
 	case embedScheme:
 		return r.readEmbedFile(url)
@@ -65,24 +147,107 @@ func (r *Reader) ReadFile(uri string) ([]byte, error) {
 }
 
 func (r *Reader) readHttpFile(uri string) ([]byte, error) {
-	request, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed creating http GET request for downloading file: %v", err)
+	candidates := r.candidateURLs(uri)
+	var errs []string
+	for _, candidate := range candidates {
+		data, err := r.downloadFile(candidate)
+		if err == nil {
+			return data, nil
+		}
+		logger.V(4).Info("Failed downloading file", "url", candidate, "error", err)
+		errs = append(errs, err.Error())
 	}
 
-	request.Header.Set("User-Agent", r.userAgent)
-	resp, err := r.httpClient.Do(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed reading file from url [%s]: %v", uri, err)
+	if r.cache != nil {
+		for _, candidate := range candidates {
+			if data, ok := r.cache.get(candidate); ok {
+				logger.V(2).Info("Warning: using cached file, couldn't download a fresh copy", "url", candidate)
+				return data, nil
+			}
+		}
 	}
-	defer resp.Body.Close()
 
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed reading file from url [%s]: %v", uri, err)
+	return nil, errors.New(strings.Join(errs, "; "))
+}
+
+func (r *Reader) candidateURLs(uri string) []string {
+	candidates := make([]string, 0, len(r.mirrors)+1)
+	for _, m := range r.mirrors {
+		if strings.HasPrefix(uri, m.origin+"/") {
+			candidates = append(candidates, m.url+strings.TrimPrefix(uri, m.origin))
+		}
 	}
 
-	return data, nil
+	return append(candidates, uri)
+}
+
+func (r *Reader) downloadFile(uri string) ([]byte, error) {
+	for retries := 0; ; retries++ {
+		request, err := http.NewRequest("GET", uri, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating http GET request for downloading file: %v", err)
+		}
+
+		request.Header.Set("User-Agent", r.userAgent)
+		if r.githubToken != "" && githubHosts[request.URL.Hostname()] {
+			request.Header.Set("Authorization", "token "+r.githubToken)
+		}
+		if etag, ok := r.cache.etag(uri); ok {
+			request.Header.Set("If-None-Match", etag)
+		}
+
+		resp, err := r.httpClient.Do(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading file from url [%s]: %v", uri, err)
+		}
+
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed reading file from url [%s]: %v", uri, err)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			r.cache.put(uri, resp.Header.Get("ETag"), data)
+			return data, nil
+		case resp.StatusCode == http.StatusNotModified:
+			if data, ok := r.cache.get(uri); ok {
+				return data, nil
+			}
+			return nil, fmt.Errorf("failed reading file from url [%s]: not modified but missing in cache", uri)
+		case isRateLimited(resp):
+			wait := rateLimitWait(resp, time.Now())
+			if retries >= maxRateLimitRetries || wait > r.rateLimitMaxWait {
+				return nil, fmt.Errorf("failed reading file from url [%s]: rate limited, retry after %s", uri, wait)
+			}
+			logger.V(2).Info("Rate limited, waiting before retrying", "url", uri, "wait", wait)
+			time.Sleep(wait)
+		default:
+			return nil, fmt.Errorf("failed reading file from url [%s]: unexpected status code %d", uri, resp.StatusCode)
+		}
+	}
+}
+
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	// GitHub returns 403 when the rate limit is exceeded
+	return resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+func rateLimitWait(resp *http.Response, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if wait := time.Unix(reset, 0).Sub(now); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return time.Second
 }
 
 func (r *Reader) readEmbedFile(url *url.URL) ([]byte, error) {
@@ -94,6 +259,15 @@ func (r *Reader) readEmbedFile(url *url.URL) ([]byte, error) {
 	return data, nil
 }
 
+func (l *localArtifacts) readFile(uri string) ([]byte, error) {
+	path, ok := l.index.Artifacts[uri]
+	if !ok {
+		return nil, fmt.Errorf("file [%s] is not in the local artifacts and can't be downloaded without network access", uri)
+	}
+
+	return readLocalFile(filepath.Join(l.dir, filepath.FromSlash(path)))
+}
+
 func readLocalFile(filename string) ([]byte, error) {
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
@@ -2,7 +2,14 @@ package files_test
 
 import (
 	"embed"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -71,3 +78,162 @@ func TestReaderReadFileSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestReaderReadFileHttpsMirror(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasPrefix(req.URL.Path, "/mirror/") {
+			w.Write([]byte("from mirror"))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r := files.NewReader(
+		files.WithHTTPClient(server.Client()),
+		files.WithMirror(server.URL+"/origin", server.URL+"/mirror"),
+	)
+	got, err := r.ReadFile(server.URL + "/origin/manifest.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("from mirror"))
+}
+
+func TestReaderReadFileHttpsMirrorFallbackToOrigin(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasPrefix(req.URL.Path, "/origin/") {
+			w.Write([]byte("from origin"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	r := files.NewReader(
+		files.WithHTTPClient(server.Client()),
+		files.WithMirror(server.URL+"/origin", server.URL+"/mirror"),
+	)
+	got, err := r.ReadFile(server.URL + "/origin/manifest.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("from origin"))
+}
+
+func TestReaderReadFileHttpsUnexpectedStatusCode(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	r := files.NewReader(files.WithHTTPClient(server.Client()))
+	_, err := r.ReadFile(server.URL + "/manifest.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code 404")))
+}
+
+func TestReaderReadFileHttpsETagCache(t *testing.T) {
+	g := NewWithT(t)
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	r := files.NewReader(files.WithHTTPClient(server.Client()), files.WithCacheDir(t.TempDir()))
+	for i := 0; i < 2; i++ {
+		got, err := r.ReadFile(server.URL + "/manifest.yaml")
+		g.Expect(err).To(BeNil())
+		g.Expect(string(got)).To(Equal("content"))
+	}
+	g.Expect(requests).To(Equal(2))
+}
+
+func TestReaderReadFileHttpsCacheFallback(t *testing.T) {
+	g := NewWithT(t)
+	available := true
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	r := files.NewReader(files.WithHTTPClient(server.Client()), files.WithCacheDir(t.TempDir()))
+	_, err := r.ReadFile(server.URL + "/manifest.yaml")
+	g.Expect(err).To(BeNil())
+
+	available = false
+	got, err := r.ReadFile(server.URL + "/manifest.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("content"))
+}
+
+func TestReaderReadFileHttpsRateLimitRetry(t *testing.T) {
+	g := NewWithT(t)
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	r := files.NewReader(files.WithHTTPClient(server.Client()))
+	got, err := r.ReadFile(server.URL + "/manifest.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("content"))
+	g.Expect(requests).To(Equal(2))
+}
+
+func TestReaderReadFileHttpsRateLimitExceedsMaxWait(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	r := files.NewReader(files.WithHTTPClient(server.Client()), files.WithRateLimitMaxWait(time.Second))
+	_, err := r.ReadFile(server.URL + "/manifest.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("rate limited")))
+}
+
+func TestReaderReadFileHttpsLocalArtifacts(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(dir, "cilium"), 0o755)).To(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(dir, "cilium", "cilium.yaml"), []byte("from artifacts"), 0o644)).To(Succeed())
+
+	index := files.NewArtifactsIndex()
+	index.Add("https://anywhere-assets.eks.amazonaws.com/cilium/cilium.yaml", filepath.Join("cilium", "cilium.yaml"))
+	g.Expect(index.Write(dir)).To(Succeed())
+	index, err := files.ReadArtifactsIndex(dir)
+	g.Expect(err).To(BeNil())
+
+	r := files.NewReader(files.WithLocalArtifacts(dir, index))
+	got, err := r.ReadFile("https://anywhere-assets.eks.amazonaws.com/cilium/cilium.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("from artifacts"))
+
+	_, err = r.ReadFile("https://anywhere-assets.eks.amazonaws.com/flux/gotk.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("is not in the local artifacts")))
+}
+
+func TestReadArtifactsIndexMissing(t *testing.T) {
+	g := NewWithT(t)
+	_, err := files.ReadArtifactsIndex(t.TempDir())
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading artifacts index")))
+}
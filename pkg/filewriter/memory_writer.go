@@ -0,0 +1,94 @@
+package filewriter
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// InMemoryWriter is a FileWriter that keeps the files in memory instead of writing them to disk, so EKS-A
+// can run in containers with a read-only filesystem. The paths it returns follow the same layout as the
+// disk writer, but they don't exist in disk, so they can only be read back from the writer and can't be passed
+// to the CLI tools
+type InMemoryWriter struct {
+	dir   string
+	files *memoryFiles
+}
+
+type memoryFiles struct {
+	sync.Mutex
+	content map[string][]byte
+}
+
+func NewInMemoryWriter(dir string) *InMemoryWriter {
+	return &InMemoryWriter{
+		dir:   dir,
+		files: &memoryFiles{content: map[string][]byte{}},
+	}
+}
+
+func (w *InMemoryWriter) Write(fileName string, content []byte, f ...FileOptionsFunc) (string, error) {
+	op := defaultFileOptions()
+	for _, optionFunc := range f {
+		optionFunc(op)
+	}
+	currentDir := w.dir
+	if op.IsTemp {
+		currentDir = filepath.Join(w.dir, DefaultTmpFolder)
+	}
+	filePath := filepath.Join(currentDir, fileName)
+
+	w.files.Lock()
+	defer w.files.Unlock()
+	w.files.content[filePath] = append([]byte(nil), content...)
+
+	return filePath, nil
+}
+
+// Read returns the content of a file written by this writer or any of its sub writers
+func (w *InMemoryWriter) Read(path string) (content []byte, found bool) {
+	w.files.Lock()
+	defer w.files.Unlock()
+	content, found = w.files.content[filepath.Clean(path)]
+	return content, found
+}
+
+// Files returns the paths of all the files written by this writer or any of its sub writers
+func (w *InMemoryWriter) Files() []string {
+	w.files.Lock()
+	defer w.files.Unlock()
+	paths := make([]string, 0, len(w.files.content))
+	for path := range w.files.content {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// WithDir returns a writer for a sub folder that shares the files with this one
+func (w *InMemoryWriter) WithDir(dir string) (FileWriter, error) {
+	return &InMemoryWriter{dir: filepath.Join(w.dir, dir), files: w.files}, nil
+}
+
+func (w *InMemoryWriter) Dir() string {
+	return w.dir
+}
+
+func (w *InMemoryWriter) CleanUp() {
+	w.removeUnder(w.dir)
+}
+
+func (w *InMemoryWriter) CleanUpTemp() {
+	w.removeUnder(filepath.Join(w.dir, DefaultTmpFolder))
+}
+
+func (w *InMemoryWriter) removeUnder(dir string) {
+	dir = filepath.Clean(dir)
+	prefix := dir + string(filepath.Separator)
+	w.files.Lock()
+	defer w.files.Unlock()
+	for path := range w.files.content {
+		if dir == "." || strings.HasPrefix(path, prefix) {
+			delete(w.files.content, path)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package filewriter_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+func TestInMemoryWriterWrite(t *testing.T) {
+	g := NewWithT(t)
+	w := filewriter.NewInMemoryWriter("cluster")
+
+	tempPath, err := w.Write("temp.yaml", []byte("temp"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tempPath).To(Equal(filepath.Join("cluster", filewriter.DefaultTmpFolder, "temp.yaml")))
+
+	persistentPath, err := w.Write("persistent.yaml", []byte("persistent"), filewriter.PersistentFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(persistentPath).To(Equal(filepath.Join("cluster", "persistent.yaml")))
+
+	content, found := w.Read(tempPath)
+	g.Expect(found).To(BeTrue())
+	g.Expect(content).To(Equal([]byte("temp")))
+	g.Expect(w.Files()).To(ConsistOf(tempPath, persistentPath))
+
+	_, err = os.Stat("cluster")
+	g.Expect(os.IsNotExist(err)).To(BeTrue(), "in memory writer should not create the folder in disk")
+}
+
+func TestInMemoryWriterWithDir(t *testing.T) {
+	g := NewWithT(t)
+	w := filewriter.NewInMemoryWriter("cluster")
+
+	sub, err := w.WithDir("logs")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sub.Dir()).To(Equal(filepath.Join("cluster", "logs")))
+
+	path, err := sub.Write("pod.log", []byte("log"), filewriter.PersistentFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(path).To(Equal(filepath.Join("cluster", "logs", "pod.log")))
+
+	content, found := w.Read(path)
+	g.Expect(found).To(BeTrue())
+	g.Expect(content).To(Equal([]byte("log")))
+}
+
+func TestInMemoryWriterCleanUp(t *testing.T) {
+	g := NewWithT(t)
+	w := filewriter.NewInMemoryWriter("cluster")
+
+	tempPath, err := w.Write("temp.yaml", []byte("temp"))
+	g.Expect(err).NotTo(HaveOccurred())
+	persistentPath, err := w.Write("persistent.yaml", []byte("persistent"), filewriter.PersistentFile)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	w.CleanUpTemp()
+	g.Expect(w.Files()).To(ConsistOf(persistentPath))
+	_, found := w.Read(tempPath)
+	g.Expect(found).To(BeFalse())
+
+	w.CleanUp()
+	g.Expect(w.Files()).To(BeEmpty())
+}
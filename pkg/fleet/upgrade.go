@@ -0,0 +1,184 @@
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type Status string
+
+const (
+	StatusSucceeded Status = "Succeeded"
+	StatusFailed    Status = "Failed"
+	// StatusSkipped clusters were not upgraded because the orchestration halted before their turn
+	StatusSkipped Status = "Skipped"
+)
+
+// Upgrader upgrades a single workload cluster
+type Upgrader interface {
+	Upgrade(ctx context.Context, cluster string) error
+}
+
+// UpgraderFunc adapts a function to an Upgrader
+type UpgraderFunc func(ctx context.Context, cluster string) error
+
+func (f UpgraderFunc) Upgrade(ctx context.Context, cluster string) error {
+	return f(ctx, cluster)
+}
+
+// Strategy defines the order and the concurrency of the upgrades of a fleet
+type Strategy struct {
+	// Canary clusters are upgraded first, one at a time. The rest of the fleet is only upgraded if all of them succeed
+	Canary []string
+	// MaxParallel is the maximum number of clusters upgraded at the same time after the canaries. Defaults to 1
+	MaxParallel int
+	// HaltOnFailure stops starting new upgrades after the first failure. The upgrades in progress are not interrupted
+	HaltOnFailure bool
+}
+
+// Result is the outcome of the upgrade of one cluster
+type Result struct {
+	Cluster  string        `json:"cluster"`
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Report aggregates the results of all the clusters, in the order they were given
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Succeeded returns true if all the clusters were upgraded
+func (r *Report) Succeeded() bool {
+	return r.Count(StatusSucceeded) == len(r.Results)
+}
+
+// Orchestrator upgrades the workload clusters of a fleet following a Strategy
+type Orchestrator struct {
+	upgrader Upgrader
+	strategy Strategy
+	now      func() time.Time
+}
+
+func NewOrchestrator(upgrader Upgrader, strategy Strategy) *Orchestrator {
+	if strategy.MaxParallel < 1 {
+		strategy.MaxParallel = 1
+	}
+	return &Orchestrator{
+		upgrader: upgrader,
+		strategy: strategy,
+		now:      time.Now,
+	}
+}
+
+// Upgrade runs the upgrades of the clusters and returns the result of each one.
+// Canaries that are not in the clusters list are rejected
+func (o *Orchestrator) Upgrade(ctx context.Context, clusters []string) (*Report, error) {
+	index := make(map[string]int, len(clusters))
+	for i, c := range clusters {
+		if _, ok := index[c]; ok {
+			return nil, fmt.Errorf("cluster %s is listed more than once", c)
+		}
+		index[c] = i
+	}
+
+	isCanary := make(map[string]bool, len(o.strategy.Canary))
+	for _, c := range o.strategy.Canary {
+		if _, ok := index[c]; !ok {
+			return nil, fmt.Errorf("canary cluster %s is not one of the clusters to upgrade", c)
+		}
+		isCanary[c] = true
+	}
+
+	report := &Report{Results: make([]Result, len(clusters))}
+	for i, c := range clusters {
+		report.Results[i] = Result{Cluster: c, Status: StatusSkipped}
+	}
+
+	for _, c := range o.strategy.Canary {
+		logger.Info("Upgrading canary cluster", "cluster", c)
+		result := o.upgrade(ctx, c)
+		report.Results[index[c]] = result
+		if result.Status == StatusFailed {
+			logger.Info("Canary cluster upgrade failed, the rest of the clusters won't be upgraded", "cluster", c)
+			return report, nil
+		}
+	}
+
+	var rest []string
+	for _, c := range clusters {
+		if !isCanary[c] {
+			rest = append(rest, c)
+		}
+	}
+	o.upgradeParallel(ctx, rest, func(r Result) { report.Results[index[r.Cluster]] = r })
+
+	return report, nil
+}
+
+func (o *Orchestrator) upgradeParallel(ctx context.Context, clusters []string, record func(Result)) {
+	var (
+		wg     sync.WaitGroup
+		lock   sync.Mutex
+		halted bool
+	)
+	slots := make(chan struct{}, o.strategy.MaxParallel)
+
+	for _, c := range clusters {
+		slots <- struct{}{}
+
+		lock.Lock()
+		stop := halted || ctx.Err() != nil
+		lock.Unlock()
+		if stop {
+			<-slots
+			break
+		}
+
+		wg.Add(1)
+		go func(cluster string) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			result := o.upgrade(ctx, cluster)
+
+			lock.Lock()
+			defer lock.Unlock()
+			record(result)
+			if result.Status == StatusFailed && o.strategy.HaltOnFailure && !halted {
+				logger.Info("Cluster upgrade failed, halting the fleet upgrade", "cluster", cluster)
+				halted = true
+			}
+		}(c)
+	}
+
+	wg.Wait()
+}
+
+func (o *Orchestrator) upgrade(ctx context.Context, cluster string) Result {
+	start := o.now()
+	result := Result{Cluster: cluster, Status: StatusSucceeded}
+	if err := o.upgrader.Upgrade(ctx, cluster); err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+	result.Duration = o.now().Sub(start)
+	logger.V(3).Info("Cluster upgrade finished", "cluster", cluster, "status", result.Status)
+	return result
+}
@@ -0,0 +1,163 @@
+package fleet_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/fleet"
+)
+
+type fakeUpgrader struct {
+	lock        sync.Mutex
+	failures    map[string]bool
+	upgraded    []string
+	running     int
+	maxRunning  int
+	upgradeTime time.Duration
+}
+
+func (f *fakeUpgrader) Upgrade(ctx context.Context, cluster string) error {
+	f.lock.Lock()
+	f.upgraded = append(f.upgraded, cluster)
+	f.running++
+	if f.running > f.maxRunning {
+		f.maxRunning = f.running
+	}
+	f.lock.Unlock()
+
+	time.Sleep(f.upgradeTime)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.running--
+	if f.failures[cluster] {
+		return errors.New("upgrade failed")
+	}
+	return nil
+}
+
+func statuses(r *fleet.Report) map[string]fleet.Status {
+	s := map[string]fleet.Status{}
+	for _, result := range r.Results {
+		s[result.Cluster] = result.Status
+	}
+	return s
+}
+
+func TestOrchestratorUpgradeCanaryFirst(t *testing.T) {
+	g := NewWithT(t)
+	upgrader := &fakeUpgrader{}
+	o := fleet.NewOrchestrator(upgrader, fleet.Strategy{Canary: []string{"c"}})
+
+	report, err := o.Upgrade(context.Background(), []string{"a", "b", "c"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(upgrader.upgraded).To(Equal([]string{"c", "a", "b"}))
+	g.Expect(report.Succeeded()).To(BeTrue())
+	g.Expect(report.Results[2].Cluster).To(Equal("c"), "results keep the order of the clusters")
+}
+
+func TestOrchestratorUpgradeCanaryFailure(t *testing.T) {
+	g := NewWithT(t)
+	upgrader := &fakeUpgrader{failures: map[string]bool{"c": true}}
+	o := fleet.NewOrchestrator(upgrader, fleet.Strategy{Canary: []string{"c"}, MaxParallel: 2})
+
+	report, err := o.Upgrade(context.Background(), []string{"a", "b", "c"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(upgrader.upgraded).To(Equal([]string{"c"}))
+	g.Expect(statuses(report)).To(Equal(map[string]fleet.Status{
+		"a": fleet.StatusSkipped,
+		"b": fleet.StatusSkipped,
+		"c": fleet.StatusFailed,
+	}))
+	g.Expect(report.Results[2].Error).To(Equal("upgrade failed"))
+	g.Expect(report.Succeeded()).To(BeFalse())
+}
+
+func TestOrchestratorUpgradeMaxParallel(t *testing.T) {
+	g := NewWithT(t)
+	upgrader := &fakeUpgrader{upgradeTime: 10 * time.Millisecond}
+	o := fleet.NewOrchestrator(upgrader, fleet.Strategy{MaxParallel: 2})
+
+	report, err := o.Upgrade(context.Background(), []string{"a", "b", "c", "d", "e"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(upgrader.upgraded).To(ConsistOf("a", "b", "c", "d", "e"))
+	g.Expect(upgrader.maxRunning).To(Equal(2))
+	g.Expect(report.Count(fleet.StatusSucceeded)).To(Equal(5))
+}
+
+func TestOrchestratorUpgradeHaltOnFailure(t *testing.T) {
+	g := NewWithT(t)
+	upgrader := &fakeUpgrader{failures: map[string]bool{"b": true}}
+	o := fleet.NewOrchestrator(upgrader, fleet.Strategy{HaltOnFailure: true})
+
+	report, err := o.Upgrade(context.Background(), []string{"a", "b", "c"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(upgrader.upgraded).To(Equal([]string{"a", "b"}))
+	g.Expect(statuses(report)).To(Equal(map[string]fleet.Status{
+		"a": fleet.StatusSucceeded,
+		"b": fleet.StatusFailed,
+		"c": fleet.StatusSkipped,
+	}))
+}
+
+func TestOrchestratorUpgradeContinueOnFailure(t *testing.T) {
+	g := NewWithT(t)
+	upgrader := &fakeUpgrader{failures: map[string]bool{"b": true}}
+	o := fleet.NewOrchestrator(upgrader, fleet.Strategy{})
+
+	report, err := o.Upgrade(context.Background(), []string{"a", "b", "c"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(upgrader.upgraded).To(Equal([]string{"a", "b", "c"}))
+	g.Expect(report.Count(fleet.StatusFailed)).To(Equal(1))
+	g.Expect(report.Count(fleet.StatusSucceeded)).To(Equal(2))
+}
+
+func TestOrchestratorUpgradeCancelled(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	o := fleet.NewOrchestrator(fleet.UpgraderFunc(func(ctx context.Context, cluster string) error {
+		cancel()
+		return nil
+	}), fleet.Strategy{})
+
+	report, err := o.Upgrade(ctx, []string{"a", "b"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses(report)).To(Equal(map[string]fleet.Status{
+		"a": fleet.StatusSucceeded,
+		"b": fleet.StatusSkipped,
+	}))
+}
+
+func TestOrchestratorUpgradeInvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []string
+		canary   []string
+		wantErr  string
+	}{
+		{
+			name:     "duplicated cluster",
+			clusters: []string{"a", "a"},
+			wantErr:  "cluster a is listed more than once",
+		},
+		{
+			name:     "unknown canary",
+			clusters: []string{"a"},
+			canary:   []string{"b"},
+			wantErr:  "canary cluster b is not one of the clusters to upgrade",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			o := fleet.NewOrchestrator(&fakeUpgrader{}, fleet.Strategy{Canary: tt.canary})
+			_, err := o.Upgrade(context.Background(), tt.clusters)
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type MachineClient interface {
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+}
+
+type ResourceProvider interface {
+	ListResources(ctx context.Context, tags map[string]string) ([]providers.Resource, error)
+	DeleteResource(ctx context.Context, resource providers.Resource) error
+}
+
+// Collector finds the infrastructure resources tagged for a cluster that are not backing any of its CAPI Machines,
+// usually left behind by failed create, upgrade or delete operations
+type Collector struct {
+	provider ResourceProvider
+	machines MachineClient
+}
+
+func NewCollector(provider ResourceProvider, machines MachineClient) *Collector {
+	return &Collector{
+		provider: provider,
+		machines: machines,
+	}
+}
+
+// FindOrphans returns the resources of the cluster that don't back any of the Machines in the management cluster.
+// Resources that don't back a machine, like load balancers, are only orphans when the whole cluster is gone,
+// which is signaled with a nil management cluster: then all the resources of the cluster are orphans
+func (c *Collector) FindOrphans(ctx context.Context, clusterName string, managementCluster *types.Cluster) ([]providers.Resource, error) {
+	resources, err := c.provider.ListResources(ctx, providers.ClusterTags(clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("failed listing resources for cluster %s: %v", clusterName, err)
+	}
+	logger.V(3).Info("Found tagged resources", "cluster", clusterName, "count", len(resources))
+
+	if managementCluster == nil || len(resources) == 0 {
+		return resources, nil
+	}
+
+	machines, err := c.machines.GetMachines(ctx, managementCluster, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting machines for cluster %s: %v", clusterName, err)
+	}
+	// Not finding any machine most likely means the wrong management cluster, deleting everything would be too dangerous
+	if len(machines) == 0 {
+		return nil, fmt.Errorf("no machines found for cluster %s in the management cluster %s", clusterName, managementCluster.Name)
+	}
+
+	live := make(types.Lookup, 2*len(machines))
+	for _, m := range machines {
+		live[m.Metadata.Name] = struct{}{}
+		live[m.Spec.InfrastructureRef.Name] = struct{}{}
+	}
+
+	var orphans []providers.Resource
+	for _, r := range resources {
+		if r.Machine != "" && !live.IsPresent(r.Machine) {
+			orphans = append(orphans, r)
+		}
+	}
+
+	return orphans, nil
+}
+
+// Delete deletes all the resources, continuing after failures and returning all the errors
+func (c *Collector) Delete(ctx context.Context, resources []providers.Resource) error {
+	var errs []error
+	for _, r := range resources {
+		logger.V(2).Info("Deleting orphaned resource", "kind", r.Kind, "name", r.Name)
+		if err := c.provider.DeleteResource(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("failed deleting %s %s: %v", r.Kind, r.Name, err))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
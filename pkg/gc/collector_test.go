@@ -0,0 +1,107 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/gc"
+	"github.com/aws/eks-anywhere/pkg/gc/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type collectorTest struct {
+	*WithT
+	ctx               context.Context
+	provider          *mocks.MockResourceProvider
+	machines          *mocks.MockMachineClient
+	collector         *gc.Collector
+	managementCluster *types.Cluster
+}
+
+func newCollectorTest(t *testing.T) *collectorTest {
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockResourceProvider(ctrl)
+	machines := mocks.NewMockMachineClient(ctrl)
+	return &collectorTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		provider:  provider,
+		machines:  machines,
+		collector: gc.NewCollector(provider, machines),
+		managementCluster: &types.Cluster{
+			Name:           "mgmt",
+			KubeconfigFile: "mgmt.kubeconfig",
+		},
+	}
+}
+
+func machine(name, infraMachine string) types.Machine {
+	return types.Machine{
+		Metadata: types.MachineMetadata{Name: name},
+		Spec: types.MachineSpec{
+			InfrastructureRef: types.ResourceRef{Name: infraMachine},
+		},
+	}
+}
+
+var (
+	lb          = providers.Resource{Kind: "Container", Name: "test-lb"}
+	cpResource  = providers.Resource{Kind: "Container", Name: "test-cp-abcde", Machine: "test-cp-abcde"}
+	mdResource  = providers.Resource{Kind: "VirtualMachine", Name: "/dc/vm/test-md-0-fghij", Machine: "test-md-0-fghij"}
+	oldResource = providers.Resource{Kind: "VirtualMachine", Name: "/dc/vm/test-md-0-klmno", Machine: "test-md-0-klmno"}
+)
+
+func TestFindOrphans(t *testing.T) {
+	tt := newCollectorTest(t)
+	tt.provider.EXPECT().ListResources(tt.ctx, providers.ClusterTags("test")).Return([]providers.Resource{lb, cpResource, mdResource, oldResource}, nil)
+	tt.machines.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test").Return([]types.Machine{
+		machine("test-cp-abcde", "test-cp-vwxyz"),
+		machine("test-md-0-54d8f-qrstu", "test-md-0-fghij"),
+	}, nil)
+
+	orphans, err := tt.collector.FindOrphans(tt.ctx, "test", tt.managementCluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(orphans).To(Equal([]providers.Resource{oldResource}))
+}
+
+func TestFindOrphansClusterDeleted(t *testing.T) {
+	tt := newCollectorTest(t)
+	resources := []providers.Resource{lb, cpResource}
+	tt.provider.EXPECT().ListResources(tt.ctx, providers.ClusterTags("test")).Return(resources, nil)
+
+	orphans, err := tt.collector.FindOrphans(tt.ctx, "test", nil)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(orphans).To(Equal(resources))
+}
+
+func TestFindOrphansNoMachines(t *testing.T) {
+	tt := newCollectorTest(t)
+	tt.provider.EXPECT().ListResources(tt.ctx, providers.ClusterTags("test")).Return([]providers.Resource{cpResource}, nil)
+	tt.machines.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test").Return(nil, nil)
+
+	_, err := tt.collector.FindOrphans(tt.ctx, "test", tt.managementCluster)
+	tt.Expect(err).To(MatchError("no machines found for cluster test in the management cluster mgmt"))
+}
+
+func TestFindOrphansErrorListing(t *testing.T) {
+	tt := newCollectorTest(t)
+	tt.provider.EXPECT().ListResources(tt.ctx, providers.ClusterTags("test")).Return(nil, errors.New("govc failed"))
+
+	_, err := tt.collector.FindOrphans(tt.ctx, "test", tt.managementCluster)
+	tt.Expect(err).To(MatchError("failed listing resources for cluster test: govc failed"))
+}
+
+func TestDelete(t *testing.T) {
+	tt := newCollectorTest(t)
+	tt.provider.EXPECT().DeleteResource(tt.ctx, cpResource).Return(errors.New("container not found"))
+	tt.provider.EXPECT().DeleteResource(tt.ctx, mdResource).Return(nil)
+	tt.provider.EXPECT().DeleteResource(tt.ctx, oldResource).Return(errors.New("vm locked"))
+
+	err := tt.collector.Delete(tt.ctx, []providers.Resource{cpResource, mdResource, oldResource})
+	tt.Expect(err).To(MatchError("[failed deleting Container test-cp-abcde: container not found, failed deleting VirtualMachine /dc/vm/test-md-0-klmno: vm locked]"))
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/gc/collector.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	providers "github.com/aws/eks-anywhere/pkg/providers"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMachineClient is a mock of MachineClient interface.
+type MockMachineClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineClientMockRecorder
+}
+
+// MockMachineClientMockRecorder is the mock recorder for MockMachineClient.
+type MockMachineClientMockRecorder struct {
+	mock *MockMachineClient
+}
+
+// NewMockMachineClient creates a new mock instance.
+func NewMockMachineClient(ctrl *gomock.Controller) *MockMachineClient {
+	mock := &MockMachineClient{ctrl: ctrl}
+	mock.recorder = &MockMachineClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineClient) EXPECT() *MockMachineClientMockRecorder {
+	return m.recorder
+}
+
+// GetMachines mocks base method.
+func (m *MockMachineClient) GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", ctx, cluster, clusterName)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockMachineClientMockRecorder) GetMachines(ctx, cluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockMachineClient)(nil).GetMachines), ctx, cluster, clusterName)
+}
+
+// MockResourceProvider is a mock of ResourceProvider interface.
+type MockResourceProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceProviderMockRecorder
+}
+
+// MockResourceProviderMockRecorder is the mock recorder for MockResourceProvider.
+type MockResourceProviderMockRecorder struct {
+	mock *MockResourceProvider
+}
+
+// NewMockResourceProvider creates a new mock instance.
+func NewMockResourceProvider(ctrl *gomock.Controller) *MockResourceProvider {
+	mock := &MockResourceProvider{ctrl: ctrl}
+	mock.recorder = &MockResourceProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceProvider) EXPECT() *MockResourceProviderMockRecorder {
+	return m.recorder
+}
+
+// DeleteResource mocks base method.
+func (m *MockResourceProvider) DeleteResource(ctx context.Context, resource providers.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResource", ctx, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResource indicates an expected call of DeleteResource.
+func (mr *MockResourceProviderMockRecorder) DeleteResource(ctx, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockResourceProvider)(nil).DeleteResource), ctx, resource)
+}
+
+// ListResources mocks base method.
+func (m *MockResourceProvider) ListResources(ctx context.Context, tags map[string]string) ([]providers.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResources", ctx, tags)
+	ret0, _ := ret[0].([]providers.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResources indicates an expected call of ListResources.
+func (mr *MockResourceProviderMockRecorder) ListResources(ctx, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResources", reflect.TypeOf((*MockResourceProvider)(nil).ListResources), ctx, tags)
+}
@@ -0,0 +1,50 @@
+#!/bin/bash
+# Managed by EKS Anywhere, CIS hardening profile
+# Checks the hardening configuration applied at bootstrap is in effect and prints a report
+
+passed=0
+failed=0
+
+check() {
+  local description=$1 expected=$2 actual=$3
+  if [ "$expected" == "$actual" ]; then
+    echo "PASS ${description}"
+    passed=$((passed + 1))
+  else
+    echo "FAIL ${description}: expected '${expected}', got '${actual}'"
+    failed=$((failed + 1))
+  fi
+}
+
+echo "EKS Anywhere CIS hardening report for $(hostname) at $(date -u +%Y-%m-%dT%H:%M:%SZ)"
+
+while IFS='=' read -r key value; do
+  key=$(echo "$key" | xargs)
+  value=$(echo "$value" | xargs)
+  [ -z "$key" ] || [[ "$key" == \#* ]] && continue
+  check "sysctl ${key}" "$value" "$(sysctl -n "$key" 2>/dev/null)"
+done < /etc/sysctl.d/90-eksa-cis.conf
+
+sshd_config=$(sshd -T 2>/dev/null)
+while read -r key value; do
+  [ -z "$key" ] || [[ "$key" == \#* ]] && continue
+  key=$(echo "$key" | tr '[:upper:]' '[:lower:]')
+  value=$(echo "$value" | tr '[:upper:]' '[:lower:]')
+  check "sshd ${key}" "$value" "$(echo "$sshd_config" | awk -v k="$key" '$1 == k { $1 = ""; sub(/^ /, ""); print; exit }')"
+done < /etc/ssh/sshd_config.d/90-eksa-cis.conf
+
+if command -v auditctl >/dev/null 2>&1; then
+  expected_rules=$(grep -c '^-w' /etc/audit/rules.d/90-eksa-cis.rules)
+  loaded_rules=$(auditctl -l 2>/dev/null | grep -c -F -f <(grep '^-w' /etc/audit/rules.d/90-eksa-cis.rules | awk '{ print $2 }'))
+  check "auditd rules loaded" "$expected_rules" "$loaded_rules"
+else
+  check "auditd installed" "yes" "no"
+fi
+
+if grep -q -- '--protect-kernel-defaults=true' /var/lib/kubelet/kubeadm-flags.env 2>/dev/null; then
+  check "kubelet protect-kernel-defaults" "true" "true"
+else
+  check "kubelet protect-kernel-defaults" "true" "false"
+fi
+
+echo "Summary: ${passed} passed, ${failed} failed"
@@ -0,0 +1,67 @@
+package hardening
+
+import (
+	_ "embed"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+var (
+	//go:embed config/cis/sysctl.conf
+	cisSysctl string
+
+	//go:embed config/cis/audit.rules
+	cisAuditRules string
+
+	//go:embed config/cis/sshd.conf
+	cisSshd string
+
+	//go:embed config/cis/report.sh
+	report string
+)
+
+// Profile contains the bootstrap customizations that apply a hardening profile to a machine
+type Profile struct {
+	Files               []v1alpha1.BootstrapFile
+	PreKubeadmCommands  []string
+	PostKubeadmCommands []string
+	KubeletExtraArgs    clusterapi.ExtraArgs
+}
+
+// ProfileFor returns the bootstrap customizations for a hardening profile.
+// It returns an empty Profile when no hardening profile is set
+func ProfileFor(profile v1alpha1.HardeningProfile) Profile {
+	switch profile {
+	case v1alpha1.CISHardeningProfile:
+		return cisProfile()
+	default:
+		return Profile{}
+	}
+}
+
+// cisProfile applies the CIS aligned kernel parameters, auditd rules and ssh daemon configuration before
+// kubeadm runs, so the kubelet can start with protect-kernel-defaults, and writes a report of the
+// checks once the node has joined the cluster
+func cisProfile() Profile {
+	return Profile{
+		Files: []v1alpha1.BootstrapFile{
+			{Path: constants.CISSysctlPath, Content: cisSysctl, Owner: "root:root", Permissions: "0644"},
+			{Path: constants.CISAuditRulesPath, Content: cisAuditRules, Owner: "root:root", Permissions: "0640"},
+			{Path: constants.CISSshdConfigPath, Content: cisSshd, Owner: "root:root", Permissions: "0600"},
+			{Path: constants.HardeningReportPath, Content: report, Owner: "root:root", Permissions: "0755"},
+		},
+		PreKubeadmCommands: []string{
+			"sysctl --system",
+			"if command -v augenrules >/dev/null 2>&1; then augenrules --load; fi",
+			"systemctl restart ssh",
+		},
+		PostKubeadmCommands: []string{
+			constants.HardeningReportPath + " > " + constants.HardeningReportLogsPath + " 2>&1",
+		},
+		KubeletExtraArgs: clusterapi.ExtraArgs{
+			"protect-kernel-defaults": "true",
+		},
+	}
+}
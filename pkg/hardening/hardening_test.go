@@ -0,0 +1,52 @@
+package hardening_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/hardening"
+)
+
+func TestProfileForNone(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(hardening.ProfileFor("")).To(Equal(hardening.Profile{}))
+}
+
+func TestProfileForCIS(t *testing.T) {
+	g := NewWithT(t)
+	profile := hardening.ProfileFor(v1alpha1.CISHardeningProfile)
+
+	paths := make([]string, 0, len(profile.Files))
+	for _, f := range profile.Files {
+		g.Expect(f.Content).NotTo(BeEmpty())
+		paths = append(paths, f.Path)
+	}
+	g.Expect(paths).To(ConsistOf(
+		"/etc/sysctl.d/90-eksa-cis.conf",
+		"/etc/audit/rules.d/90-eksa-cis.rules",
+		"/etc/ssh/sshd_config.d/90-eksa-cis.conf",
+		"/usr/local/bin/eksa-hardening-report.sh",
+	))
+	g.Expect(profile.PreKubeadmCommands).To(ContainElement("sysctl --system"))
+	g.Expect(profile.PostKubeadmCommands).To(ConsistOf("/usr/local/bin/eksa-hardening-report.sh > /var/log/eksa-hardening-report.log 2>&1"))
+	g.Expect(profile.KubeletExtraArgs).To(HaveKeyWithValue("protect-kernel-defaults", "true"))
+}
+
+func TestProfileForCISProtectKernelDefaults(t *testing.T) {
+	g := NewWithT(t)
+	profile := hardening.ProfileFor(v1alpha1.CISHardeningProfile)
+
+	// The kubelet fails to start with protect-kernel-defaults if these differ from its expected values
+	for _, param := range []string{
+		"vm.overcommit_memory = 1",
+		"vm.panic_on_oom = 0",
+		"kernel.panic = 10",
+		"kernel.panic_on_oops = 1",
+		"kernel.keys.root_maxkeys = 1000000",
+		"kernel.keys.root_maxbytes = 25000000",
+	} {
+		g.Expect(profile.Files[0].Content).To(ContainSubstring(param))
+	}
+}
@@ -0,0 +1,157 @@
+package hardware
+
+import (
+	"fmt"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// Catalog is a validated hardware inventory, where clusters find the machines for their nodes
+type Catalog struct {
+	machines []Machine
+}
+
+// NewCatalog validates the machines, each on its own and against the others, and returns their catalog
+func NewCatalog(machines []Machine) (*Catalog, error) {
+	var errs []error
+	for i := range machines {
+		errs = append(errs, machines[i].validate()...)
+	}
+	errs = append(errs, duplicates(machines)...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid hardware inventory: %v", kerrors.NewAggregate(errs))
+	}
+
+	return &Catalog{machines: machines}, nil
+}
+
+func duplicates(machines []Machine) []error {
+	fields := []struct {
+		name  string
+		value func(Machine) string
+	}{
+		{name: "id", value: func(m Machine) string { return m.ID }},
+		{name: "hostname", value: func(m Machine) string { return m.Hostname }},
+		{name: "ipAddress", value: func(m Machine) string { return m.IPAddress }},
+		{name: "macAddress", value: func(m Machine) string { return strings.ToLower(m.MACAddress) }},
+		{name: "bmcIPAddress", value: func(m Machine) string { return m.BMCIPAddress }},
+	}
+
+	var errs []error
+	for _, field := range fields {
+		seen := map[string]string{}
+		for _, m := range machines {
+			v := field.value(m)
+			if v == "" {
+				continue
+			}
+			if other, ok := seen[v]; ok {
+				errs = append(errs, fmt.Errorf("machines %s and %s have the same %s %s", other, m.name(), field.name, v))
+				continue
+			}
+			seen[v] = m.name()
+		}
+	}
+
+	return errs
+}
+
+// Machines returns all the machines of the catalog
+func (c *Catalog) Machines() []Machine {
+	return c.machines
+}
+
+// Select returns the machines matching the selector
+func (c *Catalog) Select(selector Selector) []Machine {
+	var selected []Machine
+	for _, m := range c.machines {
+		if selector.Matches(m) {
+			selected = append(selected, m)
+		}
+	}
+	return selected
+}
+
+// Requirement is a number of machines a cluster needs for a group of nodes
+type Requirement struct {
+	Name     string
+	Count    int
+	Selector Selector
+}
+
+// ValidateRequirements checks the catalog has enough machines for all the requirements together,
+// without using a machine twice. Machines are matched to the requirements with augmenting paths, so a machine
+// taken by a requirement is moved to another one that can use it when a later requirement needs it
+func (c *Catalog) ValidateRequirements(requirements []Requirement) error {
+	candidates := make([][]int, len(requirements))
+	for r, requirement := range requirements {
+		for m, machine := range c.machines {
+			if requirement.Selector.Matches(machine) {
+				candidates[r] = append(candidates[r], m)
+			}
+		}
+	}
+
+	owners := make([]int, len(c.machines))
+	for m := range owners {
+		owners[m] = -1
+	}
+
+	var errs []error
+	for r, requirement := range requirements {
+		assigned := 0
+		for assigned < requirement.Count && assign(r, candidates, owners, make([]bool, len(c.machines))) {
+			assigned++
+		}
+		if assigned < requirement.Count {
+			errs = append(errs, fmt.Errorf("not enough hardware for %s: %d machines with %s required, %d available", requirement.Name, requirement.Count, requirement.Selector, assigned))
+		}
+	}
+	if len(errs) > 0 {
+		return kerrors.NewAggregate(errs)
+	}
+
+	return nil
+}
+
+// assign finds a machine for one more node of requirement r. owners holds the requirement each machine is
+// assigned to, -1 when free. A machine assigned to another requirement is taken if that requirement can be
+// assigned a different machine, recursively
+func assign(r int, candidates [][]int, owners []int, visited []bool) bool {
+	for _, m := range candidates[r] {
+		if visited[m] {
+			continue
+		}
+		visited[m] = true
+		if owners[m] == -1 || assign(owners[m], candidates, owners, visited) {
+			owners[m] = r
+			return true
+		}
+	}
+	return false
+}
+
+// RequirementsForCluster returns the machines the cluster nodes need, using the hardware selectors of
+// their machine configs. Node groups whose machine config has no selector can use any machine
+func RequirementsForCluster(cluster *v1alpha1.Cluster, machineConfigs map[string]*v1alpha1.TinkerbellMachineConfig) []Requirement {
+	selector := func(ref *v1alpha1.Ref) Selector {
+		if ref == nil || machineConfigs[ref.Name] == nil {
+			return nil
+		}
+		return Selector(machineConfigs[ref.Name].Spec.HardwareSelector)
+	}
+
+	cp := cluster.Spec.ControlPlaneConfiguration
+	requirements := []Requirement{{Name: "control plane", Count: cp.Count, Selector: selector(cp.MachineGroupRef)}}
+	if etcd := cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		requirements = append(requirements, Requirement{Name: "etcd", Count: etcd.Count, Selector: selector(etcd.MachineGroupRef)})
+	}
+	for _, w := range cluster.Spec.WorkerNodeGroupConfigurations {
+		requirements = append(requirements, Requirement{Name: "worker node group " + w.Name, Count: w.Count, Selector: selector(w.MachineGroupRef)})
+	}
+
+	return requirements
+}
@@ -0,0 +1,124 @@
+package hardware_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/hardware"
+)
+
+func machine(id string, labels map[string]string) hardware.Machine {
+	n := id[len(id)-1:]
+	return hardware.Machine{
+		ID:         id,
+		Hostname:   "host-" + id,
+		IPAddress:  "10.0.0." + n,
+		MACAddress: "00:00:00:00:00:0" + n,
+		Disk:       "/dev/sda",
+		Labels:     labels,
+	}
+}
+
+func TestNewCatalogInvalidMachines(t *testing.T) {
+	g := NewWithT(t)
+	duplicated := machine("m-2", nil)
+	duplicated.ID = "m-1"
+	duplicated.MACAddress = "00:00:00:00:00:01"
+	invalid := hardware.Machine{ID: "m-3", Hostname: "host-3", IPAddress: "10.0.0.300", MACAddress: "mac", Disk: "sda", BMCIPAddress: "10.0.1.3"}
+
+	_, err := hardware.NewCatalog([]hardware.Machine{machine("m-1", nil), duplicated, invalid})
+	g.Expect(err).To(MatchError(ContainSubstring("machine m-3: invalid macAddress mac")))
+	g.Expect(err).To(MatchError(ContainSubstring("machine m-3: invalid ipAddress 10.0.0.300")))
+	g.Expect(err).To(MatchError(ContainSubstring("machine m-3: disk sda should be a device path like /dev/sda")))
+	g.Expect(err).To(MatchError(ContainSubstring("machine m-3: bmcUsername and bmcPassword are required with bmcIPAddress")))
+	g.Expect(err).To(MatchError(ContainSubstring("machines m-1 and m-1 have the same id m-1")))
+	g.Expect(err).To(MatchError(ContainSubstring("machines m-1 and m-1 have the same macAddress 00:00:00:00:00:01")))
+}
+
+func TestCatalogSelect(t *testing.T) {
+	g := NewWithT(t)
+	catalog, err := hardware.NewCatalog([]hardware.Machine{
+		machine("m-1", map[string]string{"type": "cp", "rack": "1"}),
+		machine("m-2", map[string]string{"type": "cp", "rack": "2"}),
+		machine("m-3", map[string]string{"type": "worker"}),
+	})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(catalog.Select(hardware.Selector{"type": "cp"})).To(HaveLen(2))
+	g.Expect(catalog.Select(hardware.Selector{"type": "cp", "rack": "2"})).To(ConsistOf(catalog.Machines()[1]))
+	g.Expect(catalog.Select(nil)).To(HaveLen(3))
+}
+
+func TestCatalogValidateRequirements(t *testing.T) {
+	g := NewWithT(t)
+	catalog, err := hardware.NewCatalog([]hardware.Machine{
+		machine("m-1", map[string]string{"type": "cp"}),
+		machine("m-2", map[string]string{"type": "worker"}),
+		machine("m-3", map[string]string{"type": "worker"}),
+	})
+	g.Expect(err).To(BeNil())
+
+	// The workers without selector take the only cp machine first, it's moved to the control plane later
+	g.Expect(catalog.ValidateRequirements([]hardware.Requirement{
+		{Name: "worker node group md-0", Count: 2},
+		{Name: "control plane", Count: 1, Selector: hardware.Selector{"type": "cp"}},
+	})).To(Succeed())
+
+	err = catalog.ValidateRequirements([]hardware.Requirement{
+		{Name: "control plane", Count: 3, Selector: hardware.Selector{"type": "cp"}},
+		{Name: "worker node group md-0", Count: 2, Selector: hardware.Selector{"type": "worker"}},
+	})
+	g.Expect(err).To(MatchError("not enough hardware for control plane: 3 machines with labels type=cp required, 1 available"))
+}
+
+func TestCatalogValidateRequirementsMovesMachines(t *testing.T) {
+	g := NewWithT(t)
+	catalog, err := hardware.NewCatalog([]hardware.Machine{
+		machine("m-1", map[string]string{"type": "cp", "rack": "1"}),
+		machine("m-2", map[string]string{"rack": "1"}),
+		machine("m-3", map[string]string{"type": "cp"}),
+	})
+	g.Expect(err).To(BeNil())
+
+	// Both selectors have one label and m-1 matches both, the control plane has to use m-3 instead
+	g.Expect(catalog.ValidateRequirements([]hardware.Requirement{
+		{Name: "control plane", Count: 1, Selector: hardware.Selector{"type": "cp"}},
+		{Name: "worker node group md-0", Count: 2, Selector: hardware.Selector{"rack": "1"}},
+	})).To(Succeed())
+
+	err = catalog.ValidateRequirements([]hardware.Requirement{
+		{Name: "control plane", Count: 2, Selector: hardware.Selector{"type": "cp"}},
+		{Name: "worker node group md-0", Count: 2, Selector: hardware.Selector{"rack": "1"}},
+	})
+	g.Expect(err).To(MatchError("not enough hardware for worker node group md-0: 2 machines with labels rack=1 required, 1 available"))
+}
+
+func TestRequirementsForCluster(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Count:           3,
+				MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.TinkerbellMachineConfigKind, Name: "cp"},
+			},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: 2, MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.TinkerbellMachineConfigKind, Name: "worker"}},
+			},
+		},
+	}
+	machineConfigs := map[string]*v1alpha1.TinkerbellMachineConfig{
+		"cp": {
+			ObjectMeta: v1.ObjectMeta{Name: "cp"},
+			Spec:       v1alpha1.TinkerbellMachineConfigSpec{HardwareSelector: v1alpha1.HardwareSelector{"type": "cp"}},
+		},
+		"worker": {ObjectMeta: v1.ObjectMeta{Name: "worker"}},
+	}
+
+	g.Expect(hardware.RequirementsForCluster(cluster, machineConfigs)).To(Equal([]hardware.Requirement{
+		{Name: "control plane", Count: 3, Selector: hardware.Selector{"type": "cp"}},
+		{Name: "worker node group md-0", Count: 2},
+	}))
+}
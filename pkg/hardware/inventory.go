@@ -0,0 +1,133 @@
+package hardware
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/substitution"
+)
+
+// csvColumns maps the columns of a csv inventory to the machine fields. The header row names the columns,
+// so they can be in any order and the optional ones can be left out
+var csvColumns = map[string]func(m *Machine, value string) error{
+	"id":           func(m *Machine, v string) error { m.ID = v; return nil },
+	"hostname":     func(m *Machine, v string) error { m.Hostname = v; return nil },
+	"ip_address":   func(m *Machine, v string) error { m.IPAddress = v; return nil },
+	"netmask":      func(m *Machine, v string) error { m.Netmask = v; return nil },
+	"gateway":      func(m *Machine, v string) error { m.Gateway = v; return nil },
+	"mac":          func(m *Machine, v string) error { m.MACAddress = v; return nil },
+	"disk":         func(m *Machine, v string) error { m.Disk = v; return nil },
+	"bmc_ip":       func(m *Machine, v string) error { m.BMCIPAddress = v; return nil },
+	"bmc_username": func(m *Machine, v string) error { m.BMCUsername = v; return nil },
+	"bmc_password": func(m *Machine, v string) error { m.BMCPassword = v; return nil },
+	"bmc_protocol": func(m *Machine, v string) error { m.BMCProtocol = v; return nil },
+	"labels":       func(m *Machine, v string) (err error) { m.Labels, err = parseLabels(v); return err },
+}
+
+// Inventory is the yaml format of the hardware inventory
+type Inventory struct {
+	Machines []Machine `json:"machines"`
+}
+
+// ReadInventory reads a csv or yaml inventory file, depending on its extension, and returns its catalog.
+// The placeholders in the file, like ${BMC_PASSWORD} or ${vault://bmc#password}, are substituted first,
+// so the BMC credentials don't need to be stored in the inventory
+func ReadInventory(path string) (*Catalog, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening hardware inventory: %v", err)
+	}
+	content, err = substitution.Substitute(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed resolving hardware inventory placeholders: %v", err)
+	}
+
+	var machines []Machine
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		machines, err = ParseCSV(bytes.NewReader(content))
+	case ".yaml", ".yml":
+		machines, err = ParseYAML(bytes.NewReader(content))
+	default:
+		return nil, fmt.Errorf("unsupported hardware inventory %s, it should be a .csv or .yaml file", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCatalog(machines)
+}
+
+// ParseCSV reads the machines of a csv inventory. The first row is the header with the column names
+func ParseCSV(r io.Reader) ([]Machine, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed reading hardware inventory csv: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("hardware inventory csv is empty")
+	}
+
+	header := records[0]
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if _, ok := csvColumns[header[i]]; !ok {
+			return nil, fmt.Errorf("unknown hardware inventory column %s", column)
+		}
+	}
+
+	machines := make([]Machine, 0, len(records)-1)
+	for row, record := range records[1:] {
+		m := Machine{}
+		for i, value := range record {
+			if err := csvColumns[header[i]](&m, strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("invalid hardware inventory row %d: %v", row+2, err)
+			}
+		}
+		machines = append(machines, m)
+	}
+
+	return machines, nil
+}
+
+// ParseYAML reads the machines of a yaml inventory
+func ParseYAML(r io.Reader) ([]Machine, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading hardware inventory yaml: %v", err)
+	}
+
+	inventory := &Inventory{}
+	if err = yaml.UnmarshalStrict(content, inventory); err != nil {
+		return nil, fmt.Errorf("failed parsing hardware inventory yaml: %v", err)
+	}
+
+	return inventory.Machines, nil
+}
+
+// parseLabels parses the labels of a csv row, in the key=value|key=value format
+func parseLabels(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	labels := map[string]string{}
+	for _, label := range strings.Split(value, "|") {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid label %s, labels should be in the format key=value|key=value", label)
+		}
+		labels[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return labels, nil
+}
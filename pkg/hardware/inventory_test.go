@@ -0,0 +1,94 @@
+package hardware_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/hardware"
+)
+
+func TestReadInventoryCSVAndYAMLMatch(t *testing.T) {
+	g := NewWithT(t)
+	fromCSV, err := hardware.ReadInventory("testdata/inventory.csv")
+	g.Expect(err).To(BeNil())
+	fromYAML, err := hardware.ReadInventory("testdata/inventory.yaml")
+	g.Expect(err).To(BeNil())
+
+	g.Expect(fromCSV.Machines()).To(HaveLen(3))
+	g.Expect(fromCSV.Machines()).To(Equal(fromYAML.Machines()))
+	g.Expect(fromCSV.Machines()[0]).To(Equal(hardware.Machine{
+		ID:           "cp-1",
+		Hostname:     "eksa-cp-1",
+		IPAddress:    "10.10.0.11",
+		Netmask:      "255.255.255.0",
+		Gateway:      "10.10.0.1",
+		MACAddress:   "00:00:00:00:00:01",
+		Disk:         "/dev/sda",
+		BMCIPAddress: "10.10.1.11",
+		BMCUsername:  "admin",
+		BMCPassword:  "pass",
+		Labels:       map[string]string{"type": "cp", "rack": "1"},
+	}))
+}
+
+func TestReadInventoryUnsupportedExtension(t *testing.T) {
+	g := NewWithT(t)
+	_, err := hardware.ReadInventory("testdata/inventory.json")
+	g.Expect(err).To(MatchError(ContainSubstring("failed opening hardware inventory")))
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			csv:     "",
+			wantErr: "hardware inventory csv is empty",
+		},
+		{
+			name:    "unknown column",
+			csv:     "id,hostname,rack\n",
+			wantErr: "unknown hardware inventory column rack",
+		},
+		{
+			name:    "invalid labels",
+			csv:     "id,labels\nm-1,type\n",
+			wantErr: "invalid hardware inventory row 2: invalid label type, labels should be in the format key=value|key=value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := hardware.ParseCSV(strings.NewReader(tt.csv))
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
+func TestParseYAMLUnknownField(t *testing.T) {
+	g := NewWithT(t)
+	_, err := hardware.ParseYAML(strings.NewReader("machines:\n- id: m-1\n  rack: 1\n"))
+	g.Expect(err).To(MatchError(ContainSubstring("failed parsing hardware inventory yaml")))
+}
+
+func TestReadInventorySubstitutesSecrets(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv("TEST_BMC_PASSWORD", "secret")
+	defer os.Unsetenv("TEST_BMC_PASSWORD")
+	path := filepath.Join(t.TempDir(), "inventory.csv")
+	g.Expect(ioutil.WriteFile(path, []byte("id,hostname,ip_address,mac,disk,bmc_ip,bmc_username,bmc_password,bmc_protocol\n"+
+		"m-1,host-1,10.0.0.1,00:00:00:00:00:01,/dev/sda,10.0.1.1,admin,${TEST_BMC_PASSWORD},ipmi\n"), 0o644)).To(Succeed())
+
+	catalog, err := hardware.ReadInventory(path)
+	g.Expect(err).To(BeNil())
+	g.Expect(catalog.Machines()[0].BMCPassword).To(Equal("secret"))
+	g.Expect(catalog.Machines()[0].Protocol()).To(Equal(hardware.BMCProtocolIPMI))
+}
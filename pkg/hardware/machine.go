@@ -0,0 +1,123 @@
+package hardware
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Machine is a bare metal machine of the hardware inventory
+type Machine struct {
+	ID           string            `json:"id"`
+	Hostname     string            `json:"hostname"`
+	IPAddress    string            `json:"ipAddress"`
+	Netmask      string            `json:"netmask,omitempty"`
+	Gateway      string            `json:"gateway,omitempty"`
+	MACAddress   string            `json:"macAddress"`
+	Disk         string            `json:"disk"`
+	BMCIPAddress string            `json:"bmcIPAddress,omitempty"`
+	BMCUsername  string            `json:"bmcUsername,omitempty"`
+	BMCPassword  string            `json:"bmcPassword,omitempty"`
+	BMCProtocol  string            `json:"bmcProtocol,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+// BMC protocols of the machines. Redfish is used when a machine doesn't set one
+const (
+	BMCProtocolRedfish = "redfish"
+	BMCProtocolIPMI    = "ipmi"
+)
+
+// HasBMC returns true if the machine can be managed through its BMC
+func (m *Machine) HasBMC() bool {
+	return m.BMCIPAddress != ""
+}
+
+func (m *Machine) validate() []error {
+	var errs []error
+	for _, required := range []struct{ field, value string }{
+		{field: "id", value: m.ID},
+		{field: "hostname", value: m.Hostname},
+		{field: "ipAddress", value: m.IPAddress},
+		{field: "macAddress", value: m.MACAddress},
+		{field: "disk", value: m.Disk},
+	} {
+		if required.value == "" {
+			errs = append(errs, fmt.Errorf("machine %s: %s is required", m.name(), required.field))
+		}
+	}
+
+	if m.MACAddress != "" {
+		if _, err := net.ParseMAC(m.MACAddress); err != nil {
+			errs = append(errs, fmt.Errorf("machine %s: invalid macAddress %s", m.name(), m.MACAddress))
+		}
+	}
+	for _, ip := range []struct{ field, value string }{
+		{field: "ipAddress", value: m.IPAddress},
+		{field: "netmask", value: m.Netmask},
+		{field: "gateway", value: m.Gateway},
+		{field: "bmcIPAddress", value: m.BMCIPAddress},
+	} {
+		if ip.value != "" && net.ParseIP(ip.value) == nil {
+			errs = append(errs, fmt.Errorf("machine %s: invalid %s %s", m.name(), ip.field, ip.value))
+		}
+	}
+	if m.Disk != "" && !strings.HasPrefix(m.Disk, "/dev/") {
+		errs = append(errs, fmt.Errorf("machine %s: disk %s should be a device path like /dev/sda", m.name(), m.Disk))
+	}
+	if m.HasBMC() && (m.BMCUsername == "" || m.BMCPassword == "") {
+		errs = append(errs, fmt.Errorf("machine %s: bmcUsername and bmcPassword are required with bmcIPAddress", m.name()))
+	}
+	if m.BMCProtocol != "" && m.BMCProtocol != BMCProtocolRedfish && m.BMCProtocol != BMCProtocolIPMI {
+		errs = append(errs, fmt.Errorf("machine %s: unsupported bmcProtocol %s, it should be %s or %s", m.name(), m.BMCProtocol, BMCProtocolRedfish, BMCProtocolIPMI))
+	}
+	if !m.HasBMC() && (m.BMCUsername != "" || m.BMCPassword != "") {
+		errs = append(errs, fmt.Errorf("machine %s: bmcIPAddress is required with the BMC credentials", m.name()))
+	}
+
+	return errs
+}
+
+// Protocol returns the protocol to reach the machine BMC
+func (m *Machine) Protocol() string {
+	if m.BMCProtocol == "" {
+		return BMCProtocolRedfish
+	}
+	return m.BMCProtocol
+}
+
+// name identifies the machine in the errors, even when some of its fields are missing
+func (m *Machine) name() string {
+	if m.ID != "" {
+		return m.ID
+	}
+	if m.Hostname != "" {
+		return m.Hostname
+	}
+	return m.MACAddress
+}
+
+// Selector matches the machines with all its labels. An empty selector matches all the machines
+type Selector map[string]string
+
+func (s Selector) Matches(m Machine) bool {
+	for k, v := range s {
+		if m.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Selector) String() string {
+	if len(s) == 0 {
+		return "any labels"
+	}
+	labels := make([]string, 0, len(s))
+	for k, v := range s {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	return "labels " + strings.Join(labels, ",")
+}
@@ -0,0 +1,190 @@
+package healthprobe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// State is the state of the operation reported by the server
+type State string
+
+const (
+	// Preparing is the state before the first workflow task starts, while the dependencies are built
+	Preparing State = "preparing"
+	// Running is the state once the first workflow task starts
+	Running State = "running"
+	// Failed is the state after a workflow task failed, while the operation collects diagnostics and exits
+	Failed State = "failed"
+)
+
+// Status is the progress of the operation served as json in /status
+type Status struct {
+	Operation              string       `json:"operation"`
+	Cluster                string       `json:"cluster"`
+	State                  State        `json:"state"`
+	StartTime              time.Time    `json:"startTime"`
+	ElapsedSeconds         float64      `json:"elapsedSeconds"`
+	CurrentTask            string       `json:"currentTask,omitempty"`
+	CurrentTaskSeconds     float64      `json:"currentTaskSeconds,omitempty"`
+	CompletedTasks         []TaskStatus `json:"completedTasks"`
+	LastActivity           time.Time    `json:"lastActivity"`
+	SecondsSinceLastActive float64      `json:"secondsSinceLastActivity"`
+}
+
+// TaskStatus is a finished workflow task
+type TaskStatus struct {
+	Name            string  `json:"name"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// Server serves the progress of a long running operation over HTTP, so Jobs and pipelines can health check
+// and observe it. /healthz answers while the process is alive and /status returns the Status as json.
+// It implements interfaces.ProgressSink to follow the workflow tasks
+type Server struct {
+	mu          sync.Mutex
+	status      Status
+	taskStart   time.Time
+	now         func() time.Time
+	server      *http.Server
+	listener    net.Listener
+	serveErrors chan error
+}
+
+// Opt configures the Server
+type Opt func(*Server)
+
+// WithNow sets the clock of the server, for tests
+func WithNow(now func() time.Time) Opt {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer returns a Server for the operation on cluster. It doesn't listen until Start is called
+func NewServer(operation, cluster string, opts ...Opt) *Server {
+	s := &Server{now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	start := s.now()
+	s.status = Status{
+		Operation:      operation,
+		Cluster:        cluster,
+		State:          Preparing,
+		StartTime:      start,
+		CompletedTasks: []TaskStatus{},
+		LastActivity:   start,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/status", s.statusHandler)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	return s
+}
+
+// Start listens on address, like 127.0.0.1:8090, and serves in the background until Close is called
+func (s *Server) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed starting health probe server: %v", err)
+	}
+	s.listener = listener
+	s.serveErrors = make(chan error, 1)
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.serveErrors <- err
+		}
+		close(s.serveErrors)
+	}()
+	logger.V(3).Info("Health probe server started", "address", listener.Addr().String())
+
+	return nil
+}
+
+// Address returns the address the server listens on, empty before Start
+func (s *Server) Address() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Close stops the server. It implements types.Closer
+func (s *Server) Close(ctx context.Context) error {
+	if s == nil || s.listener == nil {
+		return nil
+	}
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed stopping health probe server: %v", err)
+	}
+
+	return <-s.serveErrors
+}
+
+// TaskStarted implements interfaces.ProgressSink
+func (s *Server) TaskStarted(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if s.status.State == Preparing {
+		s.status.State = Running
+	}
+	s.status.CurrentTask = name
+	s.taskStart = now
+	s.status.LastActivity = now
+}
+
+// TaskFinished implements interfaces.ProgressSink
+func (s *Server) TaskFinished(name string, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task := TaskStatus{Name: name, DurationSeconds: duration.Seconds()}
+	if err != nil {
+		task.Error = err.Error()
+		s.status.State = Failed
+	}
+	s.status.CompletedTasks = append(s.status.CompletedTasks, task)
+	s.status.CurrentTask = ""
+	s.status.LastActivity = s.now()
+}
+
+// Status returns the current progress of the operation
+func (s *Server) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	status := s.status
+	status.CompletedTasks = append([]TaskStatus{}, s.status.CompletedTasks...)
+	status.ElapsedSeconds = now.Sub(status.StartTime).Seconds()
+	status.SecondsSinceLastActive = now.Sub(status.LastActivity).Seconds()
+	if status.CurrentTask != "" {
+		status.CurrentTaskSeconds = now.Sub(s.taskStart).Seconds()
+	}
+
+	return status
+}
+
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
+
+func (s *Server) statusHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s.Status()); err != nil {
+		logger.V(4).Info("Failed writing health probe status", "error", err)
+	}
+}
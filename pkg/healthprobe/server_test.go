@@ -0,0 +1,123 @@
+package healthprobe_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/healthprobe"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2022, time.March, 4, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestServerStatusPreparing(t *testing.T) {
+	g := NewWithT(t)
+	c := newClock()
+	s := healthprobe.NewServer("create", "test-cluster", healthprobe.WithNow(c.Now))
+	c.advance(30 * time.Second)
+
+	status := s.Status()
+	g.Expect(status.Operation).To(Equal("create"))
+	g.Expect(status.Cluster).To(Equal("test-cluster"))
+	g.Expect(status.State).To(Equal(healthprobe.Preparing))
+	g.Expect(status.ElapsedSeconds).To(Equal(30.0))
+	g.Expect(status.SecondsSinceLastActive).To(Equal(30.0))
+	g.Expect(status.CompletedTasks).To(BeEmpty())
+}
+
+func TestServerStatusTasks(t *testing.T) {
+	g := NewWithT(t)
+	c := newClock()
+	s := healthprobe.NewServer("upgrade", "test-cluster", healthprobe.WithNow(c.Now))
+
+	s.TaskStarted("setup-and-validate")
+	c.advance(time.Minute)
+	s.TaskFinished("setup-and-validate", time.Minute, nil)
+	s.TaskStarted("upgrade-workload-cluster")
+	c.advance(2 * time.Minute)
+
+	status := s.Status()
+	g.Expect(status.State).To(Equal(healthprobe.Running))
+	g.Expect(status.CurrentTask).To(Equal("upgrade-workload-cluster"))
+	g.Expect(status.CurrentTaskSeconds).To(Equal(120.0))
+	g.Expect(status.ElapsedSeconds).To(Equal(180.0))
+	g.Expect(status.SecondsSinceLastActive).To(Equal(120.0))
+	g.Expect(status.CompletedTasks).To(Equal([]healthprobe.TaskStatus{
+		{Name: "setup-and-validate", DurationSeconds: 60},
+	}))
+}
+
+func TestServerStatusFailedTask(t *testing.T) {
+	g := NewWithT(t)
+	s := healthprobe.NewServer("create", "test-cluster", healthprobe.WithNow(newClock().Now))
+
+	s.TaskStarted("bootstrap-cluster-init")
+	s.TaskFinished("bootstrap-cluster-init", time.Second, errors.New("kind failed"))
+
+	status := s.Status()
+	g.Expect(status.State).To(Equal(healthprobe.Failed))
+	g.Expect(status.CurrentTask).To(BeEmpty())
+	g.Expect(status.CompletedTasks).To(Equal([]healthprobe.TaskStatus{
+		{Name: "bootstrap-cluster-init", DurationSeconds: 1, Error: "kind failed"},
+	}))
+}
+
+func TestServerServesHealthzAndStatus(t *testing.T) {
+	g := NewWithT(t)
+	s := healthprobe.NewServer("create", "test-cluster")
+	g.Expect(s.Start("127.0.0.1:0")).To(Succeed())
+	defer func() {
+		g.Expect(s.Close(context.Background())).To(Succeed())
+	}()
+	s.TaskStarted("setup-and-validate")
+
+	resp, err := http.Get("http://" + s.Address() + "/healthz")
+	g.Expect(err).NotTo(HaveOccurred())
+	resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+	resp, err = http.Get("http://" + s.Address() + "/status")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	status := healthprobe.Status{}
+	g.Expect(json.NewDecoder(resp.Body).Decode(&status)).To(Succeed())
+	g.Expect(status.State).To(Equal(healthprobe.Running))
+	g.Expect(status.CurrentTask).To(Equal("setup-and-validate"))
+}
+
+func TestServerStartAddressInUse(t *testing.T) {
+	g := NewWithT(t)
+	s := healthprobe.NewServer("create", "test-cluster")
+	g.Expect(s.Start("127.0.0.1:0")).To(Succeed())
+	defer s.Close(context.Background())
+
+	other := healthprobe.NewServer("create", "test-cluster")
+	g.Expect(other.Start(s.Address())).To(MatchError(ContainSubstring("failed starting health probe server")))
+}
+
+func TestServerCloseNotStarted(t *testing.T) {
+	g := NewWithT(t)
+	var s *healthprobe.Server
+	g.Expect(s.Close(context.Background())).To(Succeed())
+	g.Expect(healthprobe.NewServer("create", "test-cluster").Close(context.Background())).To(Succeed())
+}
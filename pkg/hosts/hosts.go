@@ -0,0 +1,165 @@
+package hosts
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	coreDNSConfigMap = "coredns"
+	coreDNSNamespace = "kube-system"
+	corefileKey      = "Corefile"
+
+	// the hosts block is delimited with these comments so it can be replaced or removed on upgrades
+	corefileBlockStart = "# eks-anywhere host entries"
+	corefileBlockEnd   = "# end eks-anywhere host entries"
+)
+
+// Lines returns the host entries in /etc/hosts format, one line per entry
+func Lines(entries []v1alpha1.HostEntry) []string {
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		lines = append(lines, fmt.Sprintf("%s %s", e.IP, strings.Join(e.Hostnames, " ")))
+	}
+	return lines
+}
+
+// LookupFunc resolves a host name, net.LookupHost in the admin machine
+type LookupFunc func(host string) ([]string, error)
+
+// ValidateResolvable checks that every name can be resolved one way or another: it's an IP,
+// it's in the host entries or the admin machine DNS resolves it. The names that don't resolve
+// are returned together in the error so they can be added to hostEntries in one go
+func ValidateResolvable(names []string, entries []v1alpha1.HostEntry, lookup LookupFunc) error {
+	static := map[string]bool{}
+	for _, e := range entries {
+		for _, h := range e.Hostnames {
+			static[strings.ToLower(h)] = true
+		}
+	}
+
+	var unresolved []string
+	for _, name := range names {
+		if name == "" || net.ParseIP(name) != nil || static[strings.ToLower(name)] {
+			continue
+		}
+		if _, err := lookup(name); err != nil {
+			unresolved = append(unresolved, name)
+		}
+	}
+
+	if len(unresolved) > 0 {
+		sort.Strings(unresolved)
+		return fmt.Errorf("names %s can't be resolved, add them to the cluster hostEntries or to the admin machine DNS", strings.Join(unresolved, ", "))
+	}
+	return nil
+}
+
+// CorefileWithEntries returns the CoreDNS Corefile with a hosts block serving the entries,
+// so the pods resolve them like the nodes do. A block added before is replaced
+// and it's removed when there are no entries
+func CorefileWithEntries(corefile string, entries []v1alpha1.HostEntry) (string, error) {
+	lines := strings.Split(strings.TrimRight(corefile, "\n"), "\n")
+	out := make([]string, 0, len(lines)+len(entries)+5)
+	inBlock := false
+	for _, l := range lines {
+		switch strings.TrimSpace(l) {
+		case corefileBlockStart:
+			inBlock = true
+			continue
+		case corefileBlockEnd:
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			out = append(out, l)
+		}
+	}
+
+	if len(entries) == 0 {
+		return strings.Join(out, "\n") + "\n", nil
+	}
+
+	// the hosts plugin has to be set before forward for the entries to take precedence over the upstream DNS
+	forward := -1
+	for i, l := range out {
+		if strings.HasPrefix(strings.TrimSpace(l), "forward ") {
+			forward = i
+			break
+		}
+	}
+	if forward < 0 {
+		return "", fmt.Errorf("can't add host entries to Corefile, it doesn't have a forward plugin")
+	}
+
+	indent := out[forward][:len(out[forward])-len(strings.TrimLeft(out[forward], " \t"))]
+	block := []string{indent + corefileBlockStart, indent + "hosts {"}
+	for _, l := range Lines(entries) {
+		block = append(block, indent+"   "+l)
+	}
+	block = append(block, indent+"   fallthrough", indent+"}", indent+corefileBlockEnd)
+
+	result := make([]string, 0, len(out)+len(block))
+	result = append(result, out[:forward]...)
+	result = append(result, block...)
+	result = append(result, out[forward:]...)
+	return strings.Join(result, "\n") + "\n", nil
+}
+
+// CoreDNSClient reads and applies the CoreDNS config of a cluster
+type CoreDNSClient interface {
+	GetConfigMap(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.ConfigMap, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// ConfigureCoreDNS sets the host entries in the CoreDNS Corefile of the cluster, removing them when entries is empty.
+// CoreDNS reloads the Corefile by itself, so the pods don't need to be restarted
+func ConfigureCoreDNS(ctx context.Context, client CoreDNSClient, cluster *types.Cluster, entries []v1alpha1.HostEntry) error {
+	cm, err := client.GetConfigMap(ctx, cluster.KubeconfigFile, coreDNSConfigMap, coreDNSNamespace)
+	if err != nil {
+		return fmt.Errorf("failed reading CoreDNS config: %v", err)
+	}
+
+	corefile, err := CorefileWithEntries(cm.Data[corefileKey], entries)
+	if err != nil {
+		return err
+	}
+	if corefile == cm.Data[corefileKey] {
+		return nil
+	}
+
+	data := make(map[string]string, len(cm.Data))
+	for k, v := range cm.Data {
+		data[k] = v
+	}
+	data[corefileKey] = corefile
+	manifest, err := yaml.Marshal(&corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      coreDNSConfigMap,
+			Namespace: coreDNSNamespace,
+		},
+		Data: data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed generating CoreDNS config: %v", err)
+	}
+
+	if err = client.ApplyKubeSpecFromBytes(ctx, cluster, manifest); err != nil {
+		return fmt.Errorf("failed applying CoreDNS config: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package hosts_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/hosts"
+)
+
+var entries = []v1alpha1.HostEntry{
+	{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local", "registry"}},
+	{IP: "10.0.0.11", Hostnames: []string{"vcenter.lab.local"}},
+}
+
+func TestLines(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(hosts.Lines(entries)).To(Equal([]string{
+		"10.0.0.10 registry.lab.local registry",
+		"10.0.0.11 vcenter.lab.local",
+	}))
+}
+
+func TestValidateResolvable(t *testing.T) {
+	lookup := func(host string) ([]string, error) {
+		if host == "github.com" {
+			return []string{"140.82.112.3"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr string
+	}{
+		{
+			name:  "ip, host entry and dns",
+			names: []string{"10.0.0.12", "VCenter.lab.local", "github.com", ""},
+		},
+		{
+			name:    "unresolved names",
+			names:   []string{"registry.lab.local", "git.lab.local", "nexus.lab.local"},
+			wantErr: "names git.lab.local, nexus.lab.local can't be resolved, add them to the cluster hostEntries or to the admin machine DNS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := hosts.ValidateResolvable(tt.names, entries, lookup)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestCorefileWithEntries(t *testing.T) {
+	g := NewWithT(t)
+	corefile := test.ReadFile(t, "testdata/corefile")
+
+	got, err := hosts.CorefileWithEntries(corefile, entries)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, got, "testdata/expected_corefile")
+
+	got, err = hosts.CorefileWithEntries(got, entries)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, got, "testdata/expected_corefile")
+}
+
+func TestCorefileWithEntriesRemoved(t *testing.T) {
+	g := NewWithT(t)
+	corefile := test.ReadFile(t, "testdata/expected_corefile")
+
+	got, err := hosts.CorefileWithEntries(corefile, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, got, "testdata/corefile")
+}
+
+func TestCorefileWithEntriesNoForward(t *testing.T) {
+	g := NewWithT(t)
+	_, err := hosts.CorefileWithEntries(".:53 {\n    errors\n}\n", entries)
+	g.Expect(err).To(MatchError("can't add host entries to Corefile, it doesn't have a forward plugin"))
+}
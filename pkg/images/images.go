@@ -0,0 +1,274 @@
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+const configFileName = "image-builder-config.json"
+
+// Hypervisor is the platform an image is built for
+type Hypervisor string
+
+const (
+	VSphere   Hypervisor = "vsphere"
+	BareMetal Hypervisor = "baremetal"
+)
+
+// imageExtensions are the files image-builder produces for each hypervisor
+var imageExtensions = map[Hypervisor]string{
+	VSphere:   ".ova",
+	BareMetal: ".gz",
+}
+
+// ImageBuilderClient runs image-builder, implemented by executables.ImageBuilder
+type ImageBuilderClient interface {
+	BuildImage(ctx context.Context, osFamily, hypervisor, releaseChannel, configFile, outputDir string) error
+}
+
+// Registrar makes a built image available to the provider of the hypervisor and returns where it's registered
+type Registrar interface {
+	RegisterImage(ctx context.Context, image *Image) (string, error)
+}
+
+// Customization are the changes made to the node images on top of the EKS-D Kubernetes version
+type Customization struct {
+	ExtraPackages []string
+	HTTPProxy     string
+	HTTPSProxy    string
+	NoProxy       []string
+	CACertFiles   []string
+}
+
+// VSphereConfig is the vCenter where image-builder builds vSphere images
+type VSphereConfig struct {
+	Server       string
+	Username     string
+	Password     string
+	Insecure     bool
+	Datacenter   string
+	Datastore    string
+	Network      string
+	Folder       string
+	ResourcePool string
+}
+
+// Spec describes the image to build
+type Spec struct {
+	OSFamily       v1alpha1.OSFamily
+	Hypervisor     Hypervisor
+	VersionsBundle *cluster.VersionsBundle
+	Customization  Customization
+	// VSphere is required for the vSphere hypervisor
+	VSphere *VSphereConfig
+}
+
+// Image is a node image built by image-builder
+type Image struct {
+	Name        string
+	Path        string
+	OSFamily    v1alpha1.OSFamily
+	Hypervisor  Hypervisor
+	KubeVersion string
+	EksDRelease string
+	// Location is where the provider registered the image, like the path of a vSphere template. Empty if it wasn't registered
+	Location string
+}
+
+// Builder builds node images with image-builder and registers them with the provider. Only vSphere has a registrar,
+// bare metal images are left in the writer folder
+type Builder struct {
+	client    ImageBuilderClient
+	writer    filewriter.FileWriter
+	registrar Registrar
+}
+
+type BuilderOpt func(*Builder)
+
+// WithRegistrar registers every built image with the provider of its hypervisor
+func WithRegistrar(registrar Registrar) BuilderOpt {
+	return func(b *Builder) {
+		b.registrar = registrar
+	}
+}
+
+func NewBuilder(client ImageBuilderClient, writer filewriter.FileWriter, opts ...BuilderOpt) *Builder {
+	b := &Builder{client: client, writer: writer}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Build builds the image of the spec in the writer folder and registers it, if the builder has a registrar
+func (b *Builder) Build(ctx context.Context, spec Spec) (*Image, error) {
+	if spec.OSFamily != v1alpha1.Ubuntu {
+		return nil, fmt.Errorf("image-builder only builds %s images, %s images come from the bundle", v1alpha1.Ubuntu, spec.OSFamily)
+	}
+	extension, ok := imageExtensions[spec.Hypervisor]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hypervisor %s, it should be %s or %s", spec.Hypervisor, VSphere, BareMetal)
+	}
+	if spec.Hypervisor == VSphere && spec.VSphere == nil {
+		return nil, fmt.Errorf("the vCenter config is required to build %s images", VSphere)
+	}
+
+	vars, err := imageVars(spec)
+	if err != nil {
+		return nil, err
+	}
+	config, err := builderConfig(spec, vars)
+	if err != nil {
+		return nil, err
+	}
+	// The config has the vCenter credentials
+	configFile, err := b.writer.Write(configFileName, config, filewriter.PersistentFile, filewriter.Permission0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed writing image-builder config: %v", err)
+	}
+	outputDir := filepath.Dir(configFile)
+
+	eksd := spec.VersionsBundle.EksD
+	if err = b.client.BuildImage(ctx, string(spec.OSFamily), string(spec.Hypervisor), eksd.ReleaseChannel, configFile, outputDir); err != nil {
+		return nil, err
+	}
+
+	path, err := newestFile(outputDir, extension)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &Image{
+		Name:        imageName(spec, vars),
+		Path:        path,
+		OSFamily:    spec.OSFamily,
+		Hypervisor:  spec.Hypervisor,
+		KubeVersion: eksd.KubeVersion,
+		EksDRelease: eksd.Name,
+	}
+
+	if b.registrar != nil {
+		if image.Location, err = b.registrar.RegisterImage(ctx, image); err != nil {
+			return nil, fmt.Errorf("failed registering image %s: %v", image.Path, err)
+		}
+	}
+
+	return image, nil
+}
+
+// imageName follows the naming of the default templates, with a hash of the image variables so images with different
+// customizations don't collide. Where the image is built doesn't change its name
+func imageName(spec Spec, vars map[string]string) string {
+	// Maps are marshalled with sorted keys, the hash is stable
+	content, _ := json.Marshal(vars)
+	eksd := spec.VersionsBundle.EksD
+	return fmt.Sprintf("%s-%s-%s-custom-%s", spec.OSFamily, eksd.KubeVersion, eksd.Name, fmt.Sprintf("%x", sha256.Sum256(content))[:7])
+}
+
+// imageVars returns the image-builder variables for the Kubernetes version of the bundle and the customizations
+func imageVars(spec Spec) (map[string]string, error) {
+	eksd := spec.VersionsBundle.EksD
+	c := spec.Customization
+	config := map[string]string{
+		"eksd_release_name":         eksd.Name,
+		"eksd_release_manifest_url": eksd.EksDReleaseUrl,
+		"kubernetes_semver":         eksd.KubeVersion,
+	}
+	if len(c.ExtraPackages) > 0 {
+		config["extra_debs"] = strings.Join(c.ExtraPackages, " ")
+	}
+	if c.HTTPProxy != "" {
+		config["http_proxy"] = c.HTTPProxy
+	}
+	if c.HTTPSProxy != "" {
+		config["https_proxy"] = c.HTTPSProxy
+	}
+	if len(c.NoProxy) > 0 {
+		config["no_proxy"] = strings.Join(c.NoProxy, ",")
+	}
+	if len(c.CACertFiles) > 0 {
+		certs, err := caCerts(c.CACertFiles)
+		if err != nil {
+			return nil, err
+		}
+		config["additional_ca_certs"] = certs
+	}
+
+	return config, nil
+}
+
+// builderConfig returns the image-builder config, the image variables plus where the image is built for the hypervisor
+func builderConfig(spec Spec, vars map[string]string) ([]byte, error) {
+	config := make(map[string]string, len(vars))
+	for k, v := range vars {
+		config[k] = v
+	}
+	if v := spec.VSphere; spec.Hypervisor == VSphere && v != nil {
+		config["vcenter_server"] = v.Server
+		config["username"] = v.Username
+		config["password"] = v.Password
+		config["insecure_connection"] = strconv.FormatBool(v.Insecure)
+		config["datacenter"] = v.Datacenter
+		config["datastore"] = v.Datastore
+		config["network"] = v.Network
+		config["folder"] = v.Folder
+		config["resource_pool"] = v.ResourcePool
+	}
+
+	content, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed marshalling image-builder config: %v", err)
+	}
+
+	return content, nil
+}
+
+// caCerts concatenates the CA certificate files in one PEM bundle
+func caCerts(files []string) (string, error) {
+	var certs []string
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			return "", fmt.Errorf("failed reading CA certificate: %v", err)
+		}
+		certs = append(certs, strings.TrimSpace(string(content)))
+	}
+
+	return strings.Join(certs, "\n") + "\n", nil
+}
+
+// newestFile returns the last modified file with the extension in dir, the image image-builder just produced
+func newestFile(dir, extension string) (string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed reading image-builder output: %v", err)
+	}
+
+	var images []os.FileInfo
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), extension) {
+			images = append(images, e)
+		}
+	}
+	if len(images) == 0 {
+		return "", fmt.Errorf("image-builder didn't produce any %s image in %s", extension, dir)
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].ModTime().After(images[j].ModTime())
+	})
+
+	return filepath.Join(dir, images[0].Name()), nil
+}
@@ -0,0 +1,149 @@
+package images_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/images"
+	"github.com/aws/eks-anywhere/pkg/images/mocks"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type imagesTest struct {
+	*WithT
+	ctx       context.Context
+	dir       string
+	client    *mocks.MockImageBuilderClient
+	registrar *mocks.MockRegistrar
+	builder   *images.Builder
+	spec      images.Spec
+}
+
+func newImagesTest(t *testing.T) *imagesTest {
+	ctrl := gomock.NewController(t)
+	dir, writer := test.NewWriter(t)
+	client := mocks.NewMockImageBuilderClient(ctrl)
+	registrar := mocks.NewMockRegistrar(ctrl)
+	return &imagesTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		dir:       dir,
+		client:    client,
+		registrar: registrar,
+		builder:   images.NewBuilder(client, writer, images.WithRegistrar(registrar)),
+		spec: images.Spec{
+			OSFamily:   v1alpha1.Ubuntu,
+			Hypervisor: images.VSphere,
+			VersionsBundle: &cluster.VersionsBundle{
+				VersionsBundle: &releasev1alpha1.VersionsBundle{
+					EksD: releasev1alpha1.EksDRelease{
+						Name:           "kubernetes-1-21-eks-4",
+						ReleaseChannel: "1-21",
+						KubeVersion:    "v1.21.2",
+						EksDReleaseUrl: "https://distro.eks.amazonaws.com/kubernetes-1-21/kubernetes-1-21-eks-4.yaml",
+					},
+				},
+			},
+			Customization: images.Customization{
+				ExtraPackages: []string{"nfs-common", "open-iscsi"},
+				HTTPProxy:     "http://proxy:3128",
+				HTTPSProxy:    "http://proxy:3128",
+				NoProxy:       []string{"10.0.0.0/8", ".internal"},
+				CACertFiles:   []string{"testdata/ca.crt"},
+			},
+			VSphere: &images.VSphereConfig{
+				Server:       "vsphere_server",
+				Username:     "vsphere_username",
+				Password:     "vsphere_password",
+				Datacenter:   "SDDC-Datacenter",
+				Datastore:    "/SDDC-Datacenter/datastore/WorkloadDatastore",
+				Network:      "/SDDC-Datacenter/network/sddc-cgw-network-1",
+				Folder:       "/SDDC-Datacenter/vm",
+				ResourcePool: "*/Resources/Compute-ResourcePool",
+			},
+		},
+	}
+}
+
+// expectBuild fakes an image-builder run that writes the image to the output folder
+func (tt *imagesTest) expectBuild(hypervisor, imageFile string) {
+	outputDir := filepath.Clean(tt.dir)
+	configFile := filepath.Join(outputDir, "image-builder-config.json")
+	tt.client.EXPECT().BuildImage(tt.ctx, "ubuntu", hypervisor, "1-21", configFile, outputDir).DoAndReturn(
+		func(_ context.Context, _, _, _, _, outputDir string) error {
+			return ioutil.WriteFile(filepath.Join(outputDir, imageFile), []byte("image"), 0o644)
+		},
+	)
+}
+
+func TestBuilderBuildAndRegister(t *testing.T) {
+	tt := newImagesTest(t)
+	tt.expectBuild("vsphere", "ubuntu-2004-kube-v1.21.2.ova")
+	tt.registrar.EXPECT().RegisterImage(tt.ctx, gomock.Any()).Return("/SDDC-Datacenter/vm/Templates/custom", nil)
+
+	image, err := tt.builder.Build(tt.ctx, tt.spec)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(image.Path).To(Equal(filepath.Join(filepath.Clean(tt.dir), "ubuntu-2004-kube-v1.21.2.ova")))
+	tt.Expect(image.Name).To(MatchRegexp(`^ubuntu-v1.21.2-kubernetes-1-21-eks-4-custom-[0-9a-f]{7}$`))
+	tt.Expect(image.EksDRelease).To(Equal("kubernetes-1-21-eks-4"))
+	tt.Expect(image.Location).To(Equal("/SDDC-Datacenter/vm/Templates/custom"))
+	test.AssertFilesEquals(t, filepath.Join(tt.dir, "image-builder-config.json"), "testdata/expected_image_builder_config.json")
+}
+
+func TestBuilderBuildNameIndependentOfVCenter(t *testing.T) {
+	tt := newImagesTest(t)
+	tt.expectBuild("vsphere", "ubuntu.ova")
+	tt.registrar.EXPECT().RegisterImage(tt.ctx, gomock.Any()).Return("", nil)
+	image, err := tt.builder.Build(tt.ctx, tt.spec)
+	tt.Expect(err).To(BeNil())
+
+	tt.spec.VSphere.Datastore = "/SDDC-Datacenter/datastore/OtherDatastore"
+	tt.expectBuild("vsphere", "ubuntu.ova")
+	tt.registrar.EXPECT().RegisterImage(tt.ctx, gomock.Any()).Return("", nil)
+	other, err := tt.builder.Build(tt.ctx, tt.spec)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(other.Name).To(Equal(image.Name))
+}
+
+func TestBuilderBuildVSphereWithoutVCenter(t *testing.T) {
+	tt := newImagesTest(t)
+	tt.spec.VSphere = nil
+
+	_, err := tt.builder.Build(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError("the vCenter config is required to build vsphere images"))
+}
+
+func TestBuilderBuildRegisterError(t *testing.T) {
+	tt := newImagesTest(t)
+	tt.expectBuild("vsphere", "ubuntu.ova")
+	tt.registrar.EXPECT().RegisterImage(tt.ctx, gomock.Any()).Return("", errors.New("library not found"))
+
+	_, err := tt.builder.Build(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("failed registering image")))
+}
+
+func TestBuilderBuildNoImageProduced(t *testing.T) {
+	tt := newImagesTest(t)
+	tt.spec.Hypervisor = images.BareMetal
+	tt.expectBuild("baremetal", "ubuntu.ova")
+
+	_, err := tt.builder.Build(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("image-builder didn't produce any .gz image")))
+}
+
+func TestBuilderBuildUnsupportedOS(t *testing.T) {
+	tt := newImagesTest(t)
+	tt.spec.OSFamily = v1alpha1.Bottlerocket
+
+	_, err := tt.builder.Build(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError("image-builder only builds ubuntu images, bottlerocket images come from the bundle"))
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/images/images.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	images "github.com/aws/eks-anywhere/pkg/images"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImageBuilderClient is a mock of ImageBuilderClient interface.
+type MockImageBuilderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageBuilderClientMockRecorder
+}
+
+// MockImageBuilderClientMockRecorder is the mock recorder for MockImageBuilderClient.
+type MockImageBuilderClientMockRecorder struct {
+	mock *MockImageBuilderClient
+}
+
+// NewMockImageBuilderClient creates a new mock instance.
+func NewMockImageBuilderClient(ctrl *gomock.Controller) *MockImageBuilderClient {
+	mock := &MockImageBuilderClient{ctrl: ctrl}
+	mock.recorder = &MockImageBuilderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageBuilderClient) EXPECT() *MockImageBuilderClientMockRecorder {
+	return m.recorder
+}
+
+// BuildImage mocks base method.
+func (m *MockImageBuilderClient) BuildImage(ctx context.Context, osFamily, hypervisor, releaseChannel, configFile, outputDir string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildImage", ctx, osFamily, hypervisor, releaseChannel, configFile, outputDir)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BuildImage indicates an expected call of BuildImage.
+func (mr *MockImageBuilderClientMockRecorder) BuildImage(ctx, osFamily, hypervisor, releaseChannel, configFile, outputDir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildImage", reflect.TypeOf((*MockImageBuilderClient)(nil).BuildImage), ctx, osFamily, hypervisor, releaseChannel, configFile, outputDir)
+}
+
+// MockRegistrar is a mock of Registrar interface.
+type MockRegistrar struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistrarMockRecorder
+}
+
+// MockRegistrarMockRecorder is the mock recorder for MockRegistrar.
+type MockRegistrarMockRecorder struct {
+	mock *MockRegistrar
+}
+
+// NewMockRegistrar creates a new mock instance.
+func NewMockRegistrar(ctrl *gomock.Controller) *MockRegistrar {
+	mock := &MockRegistrar{ctrl: ctrl}
+	mock.recorder = &MockRegistrarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistrar) EXPECT() *MockRegistrarMockRecorder {
+	return m.recorder
+}
+
+// RegisterImage mocks base method.
+func (m *MockRegistrar) RegisterImage(ctx context.Context, image *images.Image) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterImage", ctx, image)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterImage indicates an expected call of RegisterImage.
+func (mr *MockRegistrarMockRecorder) RegisterImage(ctx, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterImage", reflect.TypeOf((*MockRegistrar)(nil).RegisterImage), ctx, image)
+}
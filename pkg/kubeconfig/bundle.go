@@ -0,0 +1,201 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// MergedFile is the kubeconfig of the bundle with the contexts of all the clusters
+	MergedFile = "kubeconfig"
+	// IndexFile lists the clusters of the bundle with their kubeconfig and context
+	IndexFile = "index.yaml"
+
+	clusterKubeconfigPattern = "%s-eks-a-cluster.kubeconfig"
+)
+
+// FromClusterFolder returns the path of the kubeconfig the CLI writes for a cluster, in the cluster folder inside dir
+func FromClusterFolder(dir, clusterName string) string {
+	return filepath.Join(dir, clusterName, fmt.Sprintf(clusterKubeconfigPattern, clusterName))
+}
+
+// ContextName is the name of the context of a cluster in the kubeconfigs of a bundle
+func ContextName(clusterName string) string {
+	return fmt.Sprintf("%s-admin@%s", clusterName, clusterName)
+}
+
+func userName(clusterName string) string {
+	return clusterName + "-admin"
+}
+
+// Entry is a cluster in the index of a bundle. Kubeconfig is relative to the bundle directory
+type Entry struct {
+	Name       string `json:"name"`
+	Context    string `json:"context"`
+	Server     string `json:"server,omitempty"`
+	Kubeconfig string `json:"kubeconfig"`
+}
+
+// Index lists the clusters of a bundle
+type Index struct {
+	Clusters []Entry `json:"clusters"`
+	dir      string
+}
+
+// KubeconfigPath returns the path of the kubeconfig of a cluster of the bundle
+func (i *Index) KubeconfigPath(clusterName string) (string, error) {
+	for _, e := range i.Clusters {
+		if e.Name == clusterName {
+			return filepath.Join(i.dir, e.Kubeconfig), nil
+		}
+	}
+	return "", fmt.Errorf("cluster %s is not in the kubeconfig bundle %s", clusterName, i.dir)
+}
+
+// MergedPath returns the path of the kubeconfig with the contexts of all the clusters of the bundle
+func (i *Index) MergedPath() string {
+	return filepath.Join(i.dir, MergedFile)
+}
+
+// LoadIndex reads the index of the bundle in dir
+func LoadIndex(dir string) (*Index, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, IndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading kubeconfig bundle index: %v", err)
+	}
+	index := &Index{}
+	if err = yaml.UnmarshalStrict(content, index); err != nil {
+		return nil, fmt.Errorf("failed parsing kubeconfig bundle index: %v", err)
+	}
+	index.dir = dir
+	return index, nil
+}
+
+// WriteBundle writes to dir a kubeconfig for each cluster, with its context renamed to <name>-admin@<name>,
+// a kubeconfig with all the contexts merged and the index of the clusters. clusters maps the cluster names to their kubeconfig files
+func WriteBundle(dir string, clusters map[string]string) (*Index, error) {
+	names := make([]string, 0, len(clusters))
+	for name := range clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed creating kubeconfig bundle directory: %v", err)
+	}
+
+	index := &Index{dir: dir}
+	merged := clientcmdapi.NewConfig()
+	for _, name := range names {
+		config, err := normalize(name, clusters[name])
+		if err != nil {
+			return nil, err
+		}
+
+		file := name + ".kubeconfig"
+		if err = write(config, filepath.Join(dir, file)); err != nil {
+			return nil, fmt.Errorf("failed writing kubeconfig of cluster %s: %v", name, err)
+		}
+
+		for k, v := range config.Clusters {
+			merged.Clusters[k] = v
+		}
+		for k, v := range config.AuthInfos {
+			merged.AuthInfos[k] = v
+		}
+		for k, v := range config.Contexts {
+			merged.Contexts[k] = v
+		}
+		index.Clusters = append(index.Clusters, Entry{
+			Name:       name,
+			Context:    ContextName(name),
+			Server:     config.Clusters[name].Server,
+			Kubeconfig: file,
+		})
+	}
+	if len(names) > 0 {
+		merged.CurrentContext = ContextName(names[0])
+	}
+
+	if err := write(merged, filepath.Join(dir, MergedFile)); err != nil {
+		return nil, fmt.Errorf("failed writing merged kubeconfig: %v", err)
+	}
+
+	content, err := yaml.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, IndexFile), content, 0o644); err != nil {
+		return nil, fmt.Errorf("failed writing kubeconfig bundle index: %v", err)
+	}
+	return index, nil
+}
+
+// normalize keeps only the current context of the kubeconfig, renaming it, its cluster and its user after the cluster,
+// so the contexts of different clusters don't collide when merged
+func normalize(clusterName, file string) (*clientcmdapi.Config, error) {
+	config, err := clientcmd.LoadFromFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading kubeconfig of cluster %s: %v", clusterName, err)
+	}
+
+	context, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig %s of cluster %s doesn't have a current context", file, clusterName)
+	}
+	cluster, ok := config.Clusters[context.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig %s of cluster %s doesn't have the cluster %s", file, clusterName, context.Cluster)
+	}
+	user, ok := config.AuthInfos[context.AuthInfo]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig %s of cluster %s doesn't have the user %s", file, clusterName, context.AuthInfo)
+	}
+
+	// files referenced by the kubeconfig, like certificates, must keep working from the bundle directory
+	base, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+	if err = clientcmd.ResolvePaths(clientcmd.GetClusterFileReferences(cluster), base); err != nil {
+		return nil, err
+	}
+	if err = clientcmd.ResolvePaths(clientcmd.GetAuthInfoFileReferences(user), base); err != nil {
+		return nil, err
+	}
+
+	normalized := clientcmdapi.NewConfig()
+	normalized.Clusters[clusterName] = cluster
+	normalized.AuthInfos[userName(clusterName)] = user
+	normalized.Contexts[ContextName(clusterName)] = &clientcmdapi.Context{
+		Cluster:   clusterName,
+		AuthInfo:  userName(clusterName),
+		Namespace: context.Namespace,
+	}
+	normalized.CurrentContext = ContextName(clusterName)
+	return normalized, nil
+}
+
+// write serializes the kubeconfig with sigs.k8s.io/yaml instead of the clientcmd codec, with the same v1 format
+func write(config *clientcmdapi.Config, filename string) error {
+	out := &clientcmdv1.Config{}
+	if err := clientcmdv1.Convert_api_Config_To_v1_Config(config, out, nil); err != nil {
+		return err
+	}
+	out.APIVersion = clientcmdv1.SchemeGroupVersion.Version
+	out.Kind = "Config"
+
+	content, err := yaml.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, content, 0o600)
+}
@@ -0,0 +1,114 @@
+package kubeconfig_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig/mocks"
+)
+
+func TestWriteBundle(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	index, err := kubeconfig.WriteBundle(dir, map[string]string{
+		"w02": kubeconfig.FromClusterFolder("testdata", "w02"),
+		"w01": kubeconfig.FromClusterFolder("testdata", "w01"),
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(index.Clusters).To(Equal([]kubeconfig.Entry{
+		{Name: "w01", Context: "w01-admin@w01", Server: "https://10.0.0.1:6443", Kubeconfig: "w01.kubeconfig"},
+		{Name: "w02", Context: "w02-admin@w02", Server: "https://10.0.0.2:6443", Kubeconfig: "w02.kubeconfig"},
+	}))
+
+	merged, err := clientcmd.LoadFromFile(index.MergedPath())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(merged.CurrentContext).To(Equal("w01-admin@w01"))
+	g.Expect(merged.Contexts).To(HaveLen(2))
+	g.Expect(merged.Contexts["w02-admin@w02"].Cluster).To(Equal("w02"))
+	g.Expect(merged.Contexts["w02-admin@w02"].AuthInfo).To(Equal("w02-admin"))
+	g.Expect(merged.Contexts["w02-admin@w02"].Namespace).To(Equal("apps"))
+	g.Expect(merged.AuthInfos["w02-admin"].Token).To(Equal("token-02"))
+
+	ca, err := filepath.Abs("testdata/w02/ca.crt")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(merged.Clusters["w02"].CertificateAuthority).To(Equal(ca))
+
+	w01, err := index.KubeconfigPath("w01")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(w01).To(Equal(filepath.Join(dir, "w01.kubeconfig")))
+	config, err := clientcmd.LoadFromFile(w01)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Contexts).To(HaveLen(1))
+	g.Expect(config.CurrentContext).To(Equal("w01-admin@w01"))
+}
+
+func TestWriteBundleFromManagementCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockSecretClient(gomock.NewController(t))
+	content, err := ioutil.ReadFile(kubeconfig.FromClusterFolder("testdata", "w01"))
+	g.Expect(err).NotTo(HaveOccurred())
+	client.EXPECT().GetSecretFromNamespace(ctx, "mgmt.kubeconfig", "w01-kubeconfig", "eksa-system").Return(&corev1.Secret{
+		Data: map[string][]byte{"value": content},
+	}, nil)
+
+	index, err := kubeconfig.WriteBundleFromManagementCluster(ctx, client, "mgmt.kubeconfig", t.TempDir(), []string{"w01"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(index.Clusters).To(Equal([]kubeconfig.Entry{
+		{Name: "w01", Context: "w01-admin@w01", Server: "https://10.0.0.1:6443", Kubeconfig: "w01.kubeconfig"},
+	}))
+}
+
+func TestWriteBundleFromManagementClusterSecretMissing(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockSecretClient(gomock.NewController(t))
+	client.EXPECT().GetSecretFromNamespace(ctx, "mgmt.kubeconfig", "w01-kubeconfig", "eksa-system").Return(nil, errors.New("secrets \"w01-kubeconfig\" not found"))
+
+	_, err := kubeconfig.WriteBundleFromManagementCluster(ctx, client, "mgmt.kubeconfig", t.TempDir(), []string{"w01"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading kubeconfig of cluster w01 from the management cluster")))
+}
+
+func TestLoadIndex(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	_, err := kubeconfig.WriteBundle(dir, map[string]string{"w01": kubeconfig.FromClusterFolder("testdata", "w01")})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	index, err := kubeconfig.LoadIndex(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	path, err := index.KubeconfigPath("w01")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(path).To(Equal(filepath.Join(dir, "w01.kubeconfig")))
+	g.Expect(index.MergedPath()).To(Equal(filepath.Join(dir, "kubeconfig")))
+
+	_, err = index.KubeconfigPath("w02")
+	g.Expect(err).To(MatchError("cluster w02 is not in the kubeconfig bundle " + dir))
+}
+
+func TestLoadIndexMissing(t *testing.T) {
+	g := NewWithT(t)
+	_, err := kubeconfig.LoadIndex(t.TempDir())
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading kubeconfig bundle index")))
+}
+
+func TestWriteBundleInvalidKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	_, err := kubeconfig.WriteBundle(t.TempDir(), map[string]string{"w03": "testdata/invalid.kubeconfig"})
+	g.Expect(err).To(MatchError("kubeconfig testdata/invalid.kubeconfig of cluster w03 doesn't have a current context"))
+
+	_, err = kubeconfig.WriteBundle(t.TempDir(), map[string]string{"w03": "testdata/missing.kubeconfig"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading kubeconfig of cluster w03")))
+	g.Expect(os.IsNotExist(err)).To(BeFalse())
+}
@@ -0,0 +1,305 @@
+package kubeconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	// MinCredentialsTTL and MaxCredentialsTTL bound the validity of the minted certificates
+	MinCredentialsTTL = 10 * time.Minute
+	MaxCredentialsTTL = 24 * time.Hour
+
+	// reservedIdentityPrefix is the prefix of the users and groups of Kubernetes components, like system:masters,
+	// which bypasses RBAC, or system:nodes
+	reservedIdentityPrefix = "system:"
+	csrNamePrefix          = "eksa-credentials-"
+)
+
+// CertificateSigningRequestClient issues certificates through the CertificateSigningRequest API of a cluster
+type CertificateSigningRequestClient interface {
+	CreateCertificateSigningRequest(ctx context.Context, kubeconfigFile string, csr *certificatesv1.CertificateSigningRequest) error
+	ApproveCertificateSigningRequest(ctx context.Context, kubeconfigFile, name string) error
+	GetCertificateSigningRequest(ctx context.Context, kubeconfigFile, name string) (*certificatesv1.CertificateSigningRequest, error)
+	DeleteCertificateSigningRequest(ctx context.Context, kubeconfigFile, name string) error
+}
+
+// MinterClient reads the kubeconfig of the workload clusters from their management cluster and issues certificates
+// through the CertificateSigningRequest API of the workload clusters
+type MinterClient interface {
+	CertificateSigningRequestClient
+	SecretClient
+}
+
+// CredentialsRequest identifies the credentials minted for a user of a workload cluster. The user and groups are the
+// subject of the certificate, so they are the identity the RBAC rules of the workload cluster apply to.
+// Kubeconfig is the management cluster kubeconfig of the issuer, the signing requests are created and approved with
+// the admin kubeconfig of the workload cluster read from it
+type CredentialsRequest struct {
+	Cluster    string
+	Kubeconfig string
+	User       string
+	Groups     []string
+	TTL        time.Duration
+}
+
+// Validate checks the request has a cluster, a kubeconfig and a user, that it doesn't ask for the identity of
+// Kubernetes components and that its TTL is in bounds
+func (r CredentialsRequest) Validate() error {
+	if r.Cluster == "" {
+		return errors.New("cluster name is required")
+	}
+	if r.Kubeconfig == "" {
+		return errors.New("management cluster kubeconfig is required")
+	}
+	if r.User == "" {
+		return errors.New("user is required")
+	}
+	if strings.HasPrefix(r.User, reservedIdentityPrefix) {
+		return fmt.Errorf("user %s is reserved for Kubernetes components", r.User)
+	}
+	for _, group := range r.Groups {
+		if strings.HasPrefix(group, reservedIdentityPrefix) {
+			return fmt.Errorf("group %s is reserved for Kubernetes components", group)
+		}
+	}
+	if r.TTL < MinCredentialsTTL || r.TTL > MaxCredentialsTTL {
+		return fmt.Errorf("ttl %s must be between %s and %s", r.TTL, MinCredentialsTTL, MaxCredentialsTTL)
+	}
+	return nil
+}
+
+// Credentials are a client certificate for a workload cluster and its private key, PEM encoded
+type Credentials struct {
+	Certificate []byte
+	Key         []byte
+	Expiration  time.Time
+}
+
+// Minter mints short-lived client certificates for workload clusters through their CertificateSigningRequest API.
+// The cluster CA signs them in the API server, so its private key never leaves the cluster, and the signer caps
+// their validity to the requested expiration. The certificates are minted on the issuer side, with the admin
+// kubeconfig of the workload cluster read from the management cluster, and embedded in the kubeconfigs it writes
+type Minter struct {
+	client        MinterClient
+	retrier       *retrier.Retrier
+	kubeconfigDir string
+}
+
+type MinterOpt func(*Minter)
+
+// WithMinterRetrier sets the retrier that waits for the certificates to be issued
+func WithMinterRetrier(r *retrier.Retrier) MinterOpt {
+	return func(m *Minter) {
+		m.retrier = r
+	}
+}
+
+// WithKubeconfigDir sets the directory the admin kubeconfig of the workload cluster is written to while the certificate
+// is issued, by default the temporary directory. The client needs to be able to read it
+func WithKubeconfigDir(dir string) MinterOpt {
+	return func(m *Minter) {
+		m.kubeconfigDir = dir
+	}
+}
+
+func NewMinter(client MinterClient, opts ...MinterOpt) *Minter {
+	m := &Minter{
+		client:  client,
+		retrier: retrier.NewWithMaxRetries(30, time.Second),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// WriteKubeconfig mints credentials for the request and writes a kubeconfig for the workload cluster that embeds them.
+// The endpoint and CA of the cluster are read from its admin kubeconfig in the management cluster. The kubeconfig
+// only works until the credentials expire, and doesn't need any other credentials to be used
+func (m *Minter) WriteKubeconfig(ctx context.Context, r CredentialsRequest, filename string) (*Credentials, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	admin, err := FromManagementCluster(ctx, m.client, r.Kubeconfig, r.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := clientcmd.Load(admin)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing kubeconfig of cluster %s: %v", r.Cluster, err)
+	}
+	current, ok := issuer.Contexts[issuer.CurrentContext]
+	if !ok || issuer.Clusters[current.Cluster] == nil {
+		return nil, fmt.Errorf("kubeconfig of cluster %s doesn't have a current cluster", r.Cluster)
+	}
+
+	credentials, err := m.mint(ctx, r, admin)
+	if err != nil {
+		return nil, err
+	}
+
+	user := fmt.Sprintf("%s-%s", r.Cluster, r.User)
+	contextName := fmt.Sprintf("%s@%s", r.User, r.Cluster)
+	config := clientcmdapi.NewConfig()
+	config.Clusters[r.Cluster] = issuer.Clusters[current.Cluster]
+	config.AuthInfos[user] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: credentials.Certificate,
+		ClientKeyData:         credentials.Key,
+	}
+	config.Contexts[contextName] = &clientcmdapi.Context{Cluster: r.Cluster, AuthInfo: user}
+	config.CurrentContext = contextName
+
+	if err = write(config, filename); err != nil {
+		return nil, fmt.Errorf("failed writing kubeconfig of cluster %s: %v", r.Cluster, err)
+	}
+	return credentials, nil
+}
+
+// Mint returns a client certificate for the user and groups of the request, valid for its TTL. It requests the
+// certificate to the kube-apiserver-client signer of the workload cluster, approves the request and waits for the
+// certificate, with the admin kubeconfig of the workload cluster read from the management cluster
+func (m *Minter) Mint(ctx context.Context, r CredentialsRequest) (*Credentials, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	admin, err := FromManagementCluster(ctx, m.client, r.Kubeconfig, r.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	return m.mint(ctx, r, admin)
+}
+
+func (m *Minter) mint(ctx context.Context, r CredentialsRequest, adminKubeconfig []byte) (*Credentials, error) {
+	// kubectl reads the kubeconfig from a file, it's only readable by the user and removed once the certificate is issued
+	adminFile, err := ioutil.TempFile(m.kubeconfigDir, fmt.Sprintf("%s-issuer-*.kubeconfig", r.Cluster))
+	if err != nil {
+		return nil, fmt.Errorf("failed writing kubeconfig of cluster %s: %v", r.Cluster, err)
+	}
+	defer os.Remove(adminFile.Name())
+	_, err = adminFile.Write(adminKubeconfig)
+	if closeErr := adminFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed writing kubeconfig of cluster %s: %v", r.Cluster, err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed generating private key: %v", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: r.User, Organization: r.Groups},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating certificate request for user %s: %v", r.User, err)
+	}
+	suffix := make([]byte, 8)
+	if _, err = rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed generating certificate signing request name: %v", err)
+	}
+
+	expirationSeconds := int32(r.TTL.Seconds())
+	csr := &certificatesv1.CertificateSigningRequest{
+		TypeMeta:   metav1.TypeMeta{APIVersion: certificatesv1.SchemeGroupVersion.String(), Kind: "CertificateSigningRequest"},
+		ObjectMeta: metav1.ObjectMeta{Name: csrNamePrefix + hex.EncodeToString(suffix)},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+			SignerName:        certificatesv1.KubeAPIServerClientSignerName,
+			ExpirationSeconds: &expirationSeconds,
+			Usages:            []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment, certificatesv1.UsageClientAuth},
+		},
+	}
+	certPEM, err := m.issue(ctx, r, adminFile.Name(), csr)
+	if err != nil {
+		return nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, fmt.Errorf("certificate issued for user %s isn't PEM encoded", r.User)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing certificate issued for user %s: %v", r.User, err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding private key: %v", err)
+	}
+
+	// The signer decides the expiration, it might be shorter than requested
+	return &Credentials{
+		Certificate: certPEM,
+		Key:         pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		Expiration:  cert.NotAfter,
+	}, nil
+}
+
+// issue creates and approves the signing request and returns the certificate once the signer issues it.
+// The request is deleted afterwards, the certificate is only returned to the caller
+func (m *Minter) issue(ctx context.Context, r CredentialsRequest, kubeconfigFile string, csr *certificatesv1.CertificateSigningRequest) ([]byte, error) {
+	if err := m.client.CreateCertificateSigningRequest(ctx, kubeconfigFile, csr); err != nil {
+		return nil, fmt.Errorf("failed requesting certificate for user %s to cluster %s: %v", r.User, r.Cluster, err)
+	}
+	defer func() {
+		if err := m.client.DeleteCertificateSigningRequest(ctx, kubeconfigFile, csr.Name); err != nil {
+			logger.V(3).Info("Certificate signing request not deleted", "name", csr.Name, "error", err)
+		}
+	}()
+
+	if err := m.client.ApproveCertificateSigningRequest(ctx, kubeconfigFile, csr.Name); err != nil {
+		return nil, fmt.Errorf("failed approving certificate signing request %s: %v", csr.Name, err)
+	}
+
+	var certificate []byte
+	var rejected error
+	err := m.retrier.RetryWithContext(ctx, func() error {
+		issued, err := m.client.GetCertificateSigningRequest(ctx, kubeconfigFile, csr.Name)
+		if err != nil {
+			return err
+		}
+		for _, c := range issued.Status.Conditions {
+			if (c.Type == certificatesv1.CertificateDenied || c.Type == certificatesv1.CertificateFailed) && c.Status == corev1.ConditionTrue {
+				rejected = fmt.Errorf("certificate signing request %s is %s: %s", csr.Name, strings.ToLower(string(c.Type)), c.Message)
+				return nil
+			}
+		}
+		if len(issued.Status.Certificate) == 0 {
+			return fmt.Errorf("certificate signing request %s not issued yet", csr.Name)
+		}
+		certificate = issued.Status.Certificate
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for certificate of user %s: %v", r.User, err)
+	}
+	if rejected != nil {
+		return nil, rejected
+	}
+	return certificate, nil
+}
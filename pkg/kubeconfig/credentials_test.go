@@ -0,0 +1,296 @@
+package kubeconfig_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+type minterTest struct {
+	*WithT
+	ctx     context.Context
+	client  *mocks.MockMinterClient
+	now     time.Time
+	request kubeconfig.CredentialsRequest
+}
+
+func newMinterTest(t *testing.T) *minterTest {
+	return &minterTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		client: mocks.NewMockMinterClient(gomock.NewController(t)),
+		now:    time.Now().Truncate(time.Second),
+		request: kubeconfig.CredentialsRequest{
+			Cluster:    "w01",
+			Kubeconfig: "mgmt.kubeconfig",
+			User:       "alice",
+			Groups:     []string{"dev", "ops"},
+			TTL:        time.Hour,
+		},
+	}
+}
+
+func (tt *minterTest) minter() *kubeconfig.Minter {
+	return kubeconfig.NewMinter(tt.client, kubeconfig.WithMinterRetrier(retrier.NewWithMaxRetries(3, 0)))
+}
+
+// newCA returns a new RSA CA, standing for the CA of the workload cluster
+func (tt *minterTest) newCA() (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	tt.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             tt.now.Add(-time.Hour),
+		NotAfter:              tt.now.Add(24 * time.Hour * 365),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	tt.Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return cert, key
+}
+
+// sign issues the certificate of the request like the kube-apiserver-client signer, honoring its expiration
+func (tt *minterTest) sign(csr *certificatesv1.CertificateSigningRequest, ca *x509.Certificate, caKey *rsa.PrivateKey) []byte {
+	block, _ := pem.Decode(csr.Spec.Request)
+	tt.Expect(block).NotTo(BeNil())
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	tt.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      request.Subject,
+		NotBefore:    tt.now,
+		NotAfter:     tt.now.Add(time.Duration(*csr.Spec.ExpirationSeconds) * time.Second),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, request.PublicKey, caKey)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// expectSecret expects the admin kubeconfig of the workload cluster to be read from the management cluster
+func (tt *minterTest) expectSecret() {
+	content, err := ioutil.ReadFile(kubeconfig.FromClusterFolder("testdata", "w01"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.client.EXPECT().GetSecretFromNamespace(tt.ctx, "mgmt.kubeconfig", "w01-kubeconfig", "eksa-system").Return(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "w01-kubeconfig"},
+		Data:       map[string][]byte{"value": content},
+	}, nil)
+}
+
+// expectRequest expects the signing request to be created and approved with the admin kubeconfig of the workload
+// cluster, it returns the created request
+func (tt *minterTest) expectRequest() *certificatesv1.CertificateSigningRequest {
+	tt.expectSecret()
+	created := &certificatesv1.CertificateSigningRequest{}
+	tt.client.EXPECT().CreateCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, kubeconfigFile string, csr *certificatesv1.CertificateSigningRequest) error {
+			// the signing request is created with the admin kubeconfig of the workload cluster, not the management one
+			tt.Expect(ioutil.ReadFile(kubeconfigFile)).To(ContainSubstring("server: https://10.0.0.1:6443"))
+			*created = *csr
+			return nil
+		},
+	)
+	tt.client.EXPECT().ApproveCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(nil)
+	tt.client.EXPECT().DeleteCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(nil)
+	return created
+}
+
+func (tt *minterTest) parseCertificate(certificate []byte) *x509.Certificate {
+	block, _ := pem.Decode(certificate)
+	tt.Expect(block).NotTo(BeNil())
+	cert, err := x509.ParseCertificate(block.Bytes)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return cert
+}
+
+func TestMinterMint(t *testing.T) {
+	tt := newMinterTest(t)
+	ca, caKey := tt.newCA()
+	created := tt.expectRequest()
+	gomock.InOrder(
+		tt.client.EXPECT().GetCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(&certificatesv1.CertificateSigningRequest{}, nil),
+		tt.client.EXPECT().GetCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, name string) (*certificatesv1.CertificateSigningRequest, error) {
+				tt.Expect(name).To(Equal(created.Name))
+				issued := created.DeepCopy()
+				issued.Status.Certificate = tt.sign(created, ca, caKey)
+				return issued, nil
+			},
+		),
+	)
+
+	credential, err := tt.minter().Mint(tt.ctx, tt.request)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(created.Name).To(HavePrefix("eksa-credentials-"))
+	tt.Expect(created.Spec.SignerName).To(Equal(certificatesv1.KubeAPIServerClientSignerName))
+	tt.Expect(*created.Spec.ExpirationSeconds).To(Equal(int32(3600)))
+	tt.Expect(created.Spec.Usages).To(ContainElement(certificatesv1.UsageClientAuth))
+	tt.Expect(credential.Expiration).To(BeTemporally("==", tt.now.Add(time.Hour)))
+	tt.Expect(string(credential.Key)).To(ContainSubstring("EC PRIVATE KEY"))
+
+	cert := tt.parseCertificate(credential.Certificate)
+	tt.Expect(cert.Subject.CommonName).To(Equal("alice"))
+	tt.Expect(cert.Subject.Organization).To(Equal([]string{"dev", "ops"}))
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, CurrentTime: tt.now, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestMinterMintDenied(t *testing.T) {
+	tt := newMinterTest(t)
+	tt.expectRequest()
+	tt.client.EXPECT().GetCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(&certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "eksa-credentials-1"},
+		Status: certificatesv1.CertificateSigningRequestStatus{
+			Conditions: []certificatesv1.CertificateSigningRequestCondition{
+				{Type: certificatesv1.CertificateApproved, Status: corev1.ConditionTrue},
+				{Type: certificatesv1.CertificateFailed, Status: corev1.ConditionTrue, Message: "invalid usages"},
+			},
+		},
+	}, nil)
+
+	_, err := tt.minter().Mint(tt.ctx, tt.request)
+	tt.Expect(err).To(MatchError(MatchRegexp("certificate signing request eksa-credentials-[0-9a-f]+ is failed: invalid usages")))
+}
+
+func TestMinterMintErrors(t *testing.T) {
+	tt := newMinterTest(t)
+	tt.expectSecret()
+	tt.client.EXPECT().CreateCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(errors.New("certificatesigningrequests is forbidden"))
+	_, err := tt.minter().Mint(tt.ctx, tt.request)
+	tt.Expect(err).To(MatchError("failed requesting certificate for user alice to cluster w01: certificatesigningrequests is forbidden"))
+
+	tt.expectSecret()
+	tt.client.EXPECT().CreateCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(nil)
+	tt.client.EXPECT().ApproveCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(errors.New("signers is forbidden"))
+	tt.client.EXPECT().DeleteCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(nil)
+	_, err = tt.minter().Mint(tt.ctx, tt.request)
+	tt.Expect(err).To(MatchError(ContainSubstring("signers is forbidden")))
+
+	tt.expectRequest()
+	tt.client.EXPECT().GetCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).Return(&certificatesv1.CertificateSigningRequest{}, nil).Times(3)
+	_, err = tt.minter().Mint(tt.ctx, tt.request)
+	tt.Expect(err).To(MatchError(MatchRegexp("failed waiting for certificate of user alice: certificate signing request eksa-credentials-[0-9a-f]+ not issued yet")))
+}
+
+func TestCredentialsRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(r *kubeconfig.CredentialsRequest)
+		wantErr string
+	}{
+		{
+			name:    "no cluster",
+			update:  func(r *kubeconfig.CredentialsRequest) { r.Cluster = "" },
+			wantErr: "cluster name is required",
+		},
+		{
+			name:    "no kubeconfig",
+			update:  func(r *kubeconfig.CredentialsRequest) { r.Kubeconfig = "" },
+			wantErr: "management cluster kubeconfig is required",
+		},
+		{
+			name:    "no user",
+			update:  func(r *kubeconfig.CredentialsRequest) { r.User = "" },
+			wantErr: "user is required",
+		},
+		{
+			name:    "reserved user",
+			update:  func(r *kubeconfig.CredentialsRequest) { r.User = "system:kube-controller-manager" },
+			wantErr: "user system:kube-controller-manager is reserved for Kubernetes components",
+		},
+		{
+			name:    "privileged group",
+			update:  func(r *kubeconfig.CredentialsRequest) { r.Groups = []string{"dev", "system:masters"} },
+			wantErr: "group system:masters is reserved for Kubernetes components",
+		},
+		{
+			name:    "ttl too short",
+			update:  func(r *kubeconfig.CredentialsRequest) { r.TTL = 5 * time.Minute },
+			wantErr: "ttl 5m0s must be between 10m0s and 24h0m0s",
+		},
+		{
+			name:    "ttl too long",
+			update:  func(r *kubeconfig.CredentialsRequest) { r.TTL = 48 * time.Hour },
+			wantErr: "ttl 48h0m0s must be between 10m0s and 24h0m0s",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newMinterTest(t)
+			tc.update(&tt.request)
+			tt.Expect(tt.request.Validate()).To(MatchError(tc.wantErr))
+		})
+	}
+}
+
+func TestMinterMintRejectsPrivilegedGroups(t *testing.T) {
+	tt := newMinterTest(t)
+	tt.request.Groups = []string{"system:masters"}
+
+	_, err := tt.minter().Mint(tt.ctx, tt.request)
+	tt.Expect(err).To(MatchError("group system:masters is reserved for Kubernetes components"))
+}
+
+func TestMinterMintKubeconfigSecretMissing(t *testing.T) {
+	tt := newMinterTest(t)
+	tt.client.EXPECT().GetSecretFromNamespace(tt.ctx, "mgmt.kubeconfig", "w01-kubeconfig", "eksa-system").Return(nil, errors.New("secrets \"w01-kubeconfig\" not found"))
+
+	_, err := tt.minter().Mint(tt.ctx, tt.request)
+	tt.Expect(err).To(MatchError("failed reading kubeconfig of cluster w01 from the management cluster: secrets \"w01-kubeconfig\" not found"))
+}
+
+func TestMinterWriteKubeconfig(t *testing.T) {
+	tt := newMinterTest(t)
+	ca, caKey := tt.newCA()
+	created := tt.expectRequest()
+	tt.client.EXPECT().GetCertificateSigningRequest(tt.ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ string) (*certificatesv1.CertificateSigningRequest, error) {
+			issued := created.DeepCopy()
+			issued.Status.Certificate = tt.sign(created, ca, caKey)
+			return issued, nil
+		},
+	)
+	filename := filepath.Join(t.TempDir(), "w01-alice.kubeconfig")
+
+	credentials, err := tt.minter().WriteKubeconfig(tt.ctx, tt.request, filename)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.LoadFromFile(filename)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.CurrentContext).To(Equal("alice@w01"))
+	tt.Expect(config.Clusters["w01"].Server).To(Equal("https://10.0.0.1:6443"))
+	tt.Expect(config.Clusters["w01"].CertificateAuthorityData).To(Equal([]byte("ca-01")))
+	user := config.AuthInfos["w01-alice"]
+	tt.Expect(user.Exec).To(BeNil())
+	tt.Expect(user.ClientCertificateData).To(Equal(credentials.Certificate))
+	tt.Expect(user.ClientKeyData).To(Equal(credentials.Key))
+	tt.Expect(tt.parseCertificate(user.ClientCertificateData).Subject.CommonName).To(Equal("alice"))
+}
@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/kubeconfig (interfaces: MinterClient,SecretClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/certificates/v1"
+	v10 "k8s.io/api/core/v1"
+)
+
+// MockMinterClient is a mock of MinterClient interface.
+type MockMinterClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMinterClientMockRecorder
+}
+
+// MockMinterClientMockRecorder is the mock recorder for MockMinterClient.
+type MockMinterClientMockRecorder struct {
+	mock *MockMinterClient
+}
+
+// NewMockMinterClient creates a new mock instance.
+func NewMockMinterClient(ctrl *gomock.Controller) *MockMinterClient {
+	mock := &MockMinterClient{ctrl: ctrl}
+	mock.recorder = &MockMinterClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMinterClient) EXPECT() *MockMinterClientMockRecorder {
+	return m.recorder
+}
+
+// ApproveCertificateSigningRequest mocks base method.
+func (m *MockMinterClient) ApproveCertificateSigningRequest(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveCertificateSigningRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveCertificateSigningRequest indicates an expected call of ApproveCertificateSigningRequest.
+func (mr *MockMinterClientMockRecorder) ApproveCertificateSigningRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveCertificateSigningRequest", reflect.TypeOf((*MockMinterClient)(nil).ApproveCertificateSigningRequest), arg0, arg1, arg2)
+}
+
+// CreateCertificateSigningRequest mocks base method.
+func (m *MockMinterClient) CreateCertificateSigningRequest(arg0 context.Context, arg1 string, arg2 *v1.CertificateSigningRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCertificateSigningRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCertificateSigningRequest indicates an expected call of CreateCertificateSigningRequest.
+func (mr *MockMinterClientMockRecorder) CreateCertificateSigningRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCertificateSigningRequest", reflect.TypeOf((*MockMinterClient)(nil).CreateCertificateSigningRequest), arg0, arg1, arg2)
+}
+
+// DeleteCertificateSigningRequest mocks base method.
+func (m *MockMinterClient) DeleteCertificateSigningRequest(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCertificateSigningRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCertificateSigningRequest indicates an expected call of DeleteCertificateSigningRequest.
+func (mr *MockMinterClientMockRecorder) DeleteCertificateSigningRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCertificateSigningRequest", reflect.TypeOf((*MockMinterClient)(nil).DeleteCertificateSigningRequest), arg0, arg1, arg2)
+}
+
+// GetCertificateSigningRequest mocks base method.
+func (m *MockMinterClient) GetCertificateSigningRequest(arg0 context.Context, arg1, arg2 string) (*v1.CertificateSigningRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificateSigningRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.CertificateSigningRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificateSigningRequest indicates an expected call of GetCertificateSigningRequest.
+func (mr *MockMinterClientMockRecorder) GetCertificateSigningRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificateSigningRequest", reflect.TypeOf((*MockMinterClient)(nil).GetCertificateSigningRequest), arg0, arg1, arg2)
+}
+
+// GetSecretFromNamespace mocks base method.
+func (m *MockMinterClient) GetSecretFromNamespace(arg0 context.Context, arg1, arg2, arg3 string) (*v10.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretFromNamespace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v10.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretFromNamespace indicates an expected call of GetSecretFromNamespace.
+func (mr *MockMinterClientMockRecorder) GetSecretFromNamespace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretFromNamespace", reflect.TypeOf((*MockMinterClient)(nil).GetSecretFromNamespace), arg0, arg1, arg2, arg3)
+}
+
+// MockSecretClient is a mock of SecretClient interface.
+type MockSecretClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretClientMockRecorder
+}
+
+// MockSecretClientMockRecorder is the mock recorder for MockSecretClient.
+type MockSecretClientMockRecorder struct {
+	mock *MockSecretClient
+}
+
+// NewMockSecretClient creates a new mock instance.
+func NewMockSecretClient(ctrl *gomock.Controller) *MockSecretClient {
+	mock := &MockSecretClient{ctrl: ctrl}
+	mock.recorder = &MockSecretClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretClient) EXPECT() *MockSecretClientMockRecorder {
+	return m.recorder
+}
+
+// GetSecretFromNamespace mocks base method.
+func (m *MockSecretClient) GetSecretFromNamespace(arg0 context.Context, arg1, arg2, arg3 string) (*v10.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretFromNamespace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v10.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretFromNamespace indicates an expected call of GetSecretFromNamespace.
+func (mr *MockSecretClientMockRecorder) GetSecretFromNamespace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretFromNamespace", reflect.TypeOf((*MockSecretClient)(nil).GetSecretFromNamespace), arg0, arg1, arg2, arg3)
+}
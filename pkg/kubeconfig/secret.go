@@ -0,0 +1,64 @@
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// capiKubeconfigSecretKey is the key of the kubeconfig in the secret Cluster API writes for each cluster
+const capiKubeconfigSecretKey = "value"
+
+// SecretClient reads secrets from a cluster
+type SecretClient interface {
+	GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error)
+}
+
+// CAPISecretName returns the name of the secret with the admin kubeconfig Cluster API writes for the cluster
+func CAPISecretName(clusterName string) string {
+	return fmt.Sprintf("%s-kubeconfig", clusterName)
+}
+
+// FromManagementCluster returns the admin kubeconfig of a workload cluster, read from the Cluster API secret in its
+// management cluster. It doesn't depend on the cluster being created from the local folder
+func FromManagementCluster(ctx context.Context, client SecretClient, managementKubeconfig, clusterName string) ([]byte, error) {
+	secret, err := client.GetSecretFromNamespace(ctx, managementKubeconfig, CAPISecretName(clusterName), constants.EksaSystemNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading kubeconfig of cluster %s from the management cluster: %v", clusterName, err)
+	}
+	content, ok := secret.Data[capiKubeconfigSecretKey]
+	if !ok || len(content) == 0 {
+		return nil, fmt.Errorf("kubeconfig secret %s of cluster %s doesn't have a kubeconfig", secret.Name, clusterName)
+	}
+	return content, nil
+}
+
+// WriteBundleFromManagementCluster writes a bundle to dir like WriteBundle, with the kubeconfigs of the clusters read
+// from the Cluster API secrets in their management cluster instead of the cluster folders
+func WriteBundleFromManagementCluster(ctx context.Context, client SecretClient, managementKubeconfig, dir string, clusters []string) (*Index, error) {
+	tmp, err := ioutil.TempDir("", "eksa-kubeconfigs")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	files := make(map[string]string, len(clusters))
+	for _, c := range clusters {
+		content, err := FromManagementCluster(ctx, client, managementKubeconfig, c)
+		if err != nil {
+			return nil, err
+		}
+		files[c] = filepath.Join(tmp, c+".kubeconfig")
+		if err = ioutil.WriteFile(files[c], content, 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	return WriteBundle(dir, files)
+}
@@ -0,0 +1,35 @@
+package localpath
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/manifest.yaml
+var manifestTemplate string
+
+// GenerateManifest returns the manifest for local-path-provisioner and its local-path StorageClass,
+// set as the cluster default, which provisions the volumes as directories in the node running the pod
+func GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
+	config := clusterSpec.Spec.LocalPathStorage
+	if config == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have a local path storage configuration", clusterSpec.Name)
+	}
+
+	data := map[string]interface{}{
+		"provisionerImage": clusterSpec.VersionsBundle.LocalPathProvisioner.Provisioner.VersionedImage(),
+		// The helper pods that create and delete the volume directories need a shell,
+		// which the provisioner image doesn't have
+		"helperImage": clusterSpec.VersionsBundle.Eksa.CliTools.VersionedImage(),
+		"path":        config.PathOrDefault(),
+	}
+
+	manifest, err := templater.Execute(manifestTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("error generating local path storage manifest: %v", err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,46 @@
+package localpath_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/localpath"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func givenClusterSpec(config *v1alpha1.LocalPathStorageConfiguration) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.LocalPathStorage = config
+		s.VersionsBundle.LocalPathProvisioner.Provisioner = releasev1alpha1.Image{
+			URI: "public.ecr.aws/l0g8r8j6/rancher/local-path-provisioner:v0.0.20-eks-a-v0.0.0-dev-build.581",
+		}
+		s.VersionsBundle.Eksa.CliTools = releasev1alpha1.Image{
+			URI: "public.ecr.aws/l0g8r8j6/eks-anywhere-cli-tools:v0.1.0-eks-a-v0.0.0-dev-build.581",
+		}
+	})
+}
+
+func TestGenerateManifestDefaultPath(t *testing.T) {
+	g := NewWithT(t)
+	manifest, err := localpath.GenerateManifest(givenClusterSpec(&v1alpha1.LocalPathStorageConfiguration{}))
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results_default_path.yaml")
+}
+
+func TestGenerateManifestCustomPath(t *testing.T) {
+	g := NewWithT(t)
+	manifest, err := localpath.GenerateManifest(givenClusterSpec(&v1alpha1.LocalPathStorageConfiguration{Path: "/var/lib/volumes"}))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(manifest)).To(ContainSubstring(`"paths": ["/var/lib/volumes"]`))
+}
+
+func TestGenerateManifestNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	_, err := localpath.GenerateManifest(givenClusterSpec(nil))
+	g.Expect(err).To(MatchError("cluster test-cluster doesn't have a local path storage configuration"))
+}
@@ -0,0 +1,466 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// FileName is the name of the local lock file, written in the cluster folder
+	FileName            = "eksa-operation.lock"
+	operationAnnotation = "anywhere.eks.amazonaws.com/operation"
+	// DefaultTTL is how long a lock stays valid without being renewed. Locks are renewed every third of it while held,
+	// so a lock older than that was left behind by an operation that isn't running anymore and is taken over
+	DefaultTTL = 2 * time.Minute
+)
+
+type LeaseClient interface {
+	CreateLease(ctx context.Context, kubeconfigFile string, lease *coordinationv1.Lease) error
+	GetLease(ctx context.Context, kubeconfigFile, name, namespace string) (*coordinationv1.Lease, error)
+	UpdateLease(ctx context.Context, kubeconfigFile string, lease *coordinationv1.Lease) error
+	DeleteLease(ctx context.Context, kubeconfigFile, name, namespace string) error
+}
+
+// Holder identifies who is running an operation on a cluster and since when
+type Holder struct {
+	Identity   string    `json:"identity"`
+	Operation  string    `json:"operation"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	RenewedAt  time.Time `json:"renewedAt"`
+}
+
+// lastRenewal returns when the lock was last renewed, falling back to when it was acquired for locks
+// written before they were renewed
+func (h Holder) lastRenewal() time.Time {
+	if h.RenewedAt.IsZero() {
+		return h.AcquiredAt
+	}
+
+	return h.RenewedAt
+}
+
+// HeldError is returned when the cluster is already locked by another operation
+type HeldError struct {
+	Cluster string
+	Holder  Holder
+	// Remediation tells how to release the lock if the operation holding it is not running anymore
+	Remediation string
+}
+
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("cluster %s is locked: %s operation in progress by %s since %s. If that operation is not running anymore, %s",
+		e.Cluster, e.Holder.Operation, e.Holder.Identity, e.Holder.AcquiredAt.Format(time.RFC3339), e.Remediation)
+}
+
+// Locker serializes the operations that mutate a cluster with a local lock file in the cluster folder, for operations
+// run from the same machine, and a Lease in the management cluster, for operations run from different machines
+type Locker struct {
+	client   LeaseClient
+	identity string
+	now      func() time.Time
+	dir      string
+	ttl      time.Duration
+}
+
+type LockerOpt func(*Locker)
+
+// WithIdentity overrides the identity recorded as lock holder, by default user@host (pid N)
+func WithIdentity(identity string) LockerOpt {
+	return func(l *Locker) {
+		l.identity = identity
+	}
+}
+
+// WithDir sets the directory with the cluster folders the lock file is written to, by default the current directory
+func WithDir(dir string) LockerOpt {
+	return func(l *Locker) {
+		l.dir = dir
+	}
+}
+
+func WithNow(now func() time.Time) LockerOpt {
+	return func(l *Locker) {
+		l.now = now
+	}
+}
+
+// WithTTL overrides how long a lock stays valid without being renewed, by default DefaultTTL
+func WithTTL(ttl time.Duration) LockerOpt {
+	return func(l *Locker) {
+		l.ttl = ttl
+	}
+}
+
+func NewLocker(client LeaseClient, opts ...LockerOpt) *Locker {
+	l := &Locker{
+		client:   client,
+		identity: defaultIdentity(),
+		now:      time.Now,
+		dir:      ".",
+		ttl:      DefaultTTL,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Lock takes the lock of the cluster for the operation. The lease is only taken when managementCluster is not nil,
+// since clusters that don't exist yet and don't have a management cluster can only be mutated from the local machine.
+// The lock is renewed in the background until it's released, and a lock that wasn't renewed for longer than its TTL
+// is taken over
+func (l *Locker) Lock(ctx context.Context, clusterName, operation string, managementCluster *types.Cluster) (*Lock, error) {
+	acquiredAt := l.now().UTC().Truncate(time.Second)
+	holder := Holder{
+		Identity:   l.identity,
+		Operation:  operation,
+		AcquiredAt: acquiredAt,
+		RenewedAt:  acquiredAt,
+	}
+
+	lock := &Lock{
+		client:      l.client,
+		now:         l.now,
+		ttl:         l.ttl,
+		holder:      holder,
+		clusterName: clusterName,
+		filePath:    filepath.Join(l.dir, clusterName, FileName),
+	}
+
+	if err := lock.createFile(clusterName, holder); err != nil {
+		return nil, err
+	}
+
+	if managementCluster != nil {
+		if err := lock.createLease(ctx, clusterName, holder, managementCluster); err != nil {
+			lock.removeFile()
+			return nil, err
+		}
+	}
+
+	lock.startRenewal(ctx)
+
+	logger.V(3).Info("Cluster locked", "cluster", clusterName, "operation", operation)
+	return lock, nil
+}
+
+// Lock is a lock held on a cluster, which needs to be released when the operation finishes
+type Lock struct {
+	client            LeaseClient
+	now               func() time.Time
+	ttl               time.Duration
+	holder            Holder
+	clusterName       string
+	filePath          string
+	leaseName         string
+	managementCluster *types.Cluster
+	stopRenewal       context.CancelFunc
+	renewalDone       chan struct{}
+}
+
+// Release stops renewing the lock, releases the lease and removes the lock file
+func (l *Lock) Release(ctx context.Context) error {
+	if l.stopRenewal != nil {
+		l.stopRenewal()
+		<-l.renewalDone
+	}
+
+	if l.managementCluster != nil {
+		if err := l.client.DeleteLease(ctx, l.managementCluster.KubeconfigFile, l.leaseName, constants.EksaSystemNamespace); err != nil {
+			return fmt.Errorf("failed releasing cluster lease %s: %v", l.leaseName, err)
+		}
+	}
+
+	return l.removeFile()
+}
+
+func (l *Lock) createFile(clusterName string, holder Holder) error {
+	if err := os.MkdirAll(filepath.Dir(l.filePath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating cluster folder for lock file: %v", err)
+	}
+
+	err := l.writeNewFile(holder)
+	if !errors.Is(err, os.ErrExist) {
+		return err
+	}
+
+	existing, err := l.readFile()
+	if err != nil {
+		return err
+	}
+	if !l.expired(existing.lastRenewal()) {
+		return l.heldFileError(clusterName, existing)
+	}
+
+	logger.Info("Taking over stale cluster lock file", "cluster", clusterName, "holder", existing.Identity, "operation", existing.Operation, "lastRenewal", existing.lastRenewal().Format(time.RFC3339))
+	if err = l.removeFile(); err != nil {
+		return err
+	}
+
+	// Another operation can take over the stale lock at the same time, only one of them creates the file again
+	err = l.writeNewFile(holder)
+	if errors.Is(err, os.ErrExist) {
+		if existing, err = l.readFile(); err != nil {
+			return err
+		}
+		return l.heldFileError(clusterName, existing)
+	}
+
+	return err
+}
+
+// writeNewFile writes the lock file, failing with os.ErrExist if it already exists
+func (l *Lock) writeNewFile(holder Holder) error {
+	content, err := yaml.Marshal(holder)
+	if err != nil {
+		return fmt.Errorf("failed marshalling lock file: %v", err)
+	}
+
+	f, err := os.OpenFile(l.filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed creating lock file: %v", err)
+	}
+	defer f.Close()
+
+	if _, err = f.Write(content); err != nil {
+		return fmt.Errorf("failed writing lock file: %v", err)
+	}
+
+	return nil
+}
+
+func (l *Lock) readFile() (Holder, error) {
+	holder := Holder{}
+	content, err := ioutil.ReadFile(l.filePath)
+	if err != nil {
+		return holder, fmt.Errorf("failed reading lock file: %v", err)
+	}
+
+	if err = yaml.Unmarshal(content, &holder); err != nil {
+		return holder, fmt.Errorf("failed parsing lock file %s: %v", l.filePath, err)
+	}
+
+	return holder, nil
+}
+
+func (l *Lock) heldFileError(clusterName string, holder Holder) error {
+	return &HeldError{
+		Cluster:     clusterName,
+		Holder:      holder,
+		Remediation: fmt.Sprintf("remove the lock file %s", l.filePath),
+	}
+}
+
+// expired returns true when a lock last renewed at lastRenewal wasn't renewed within its TTL
+func (l *Lock) expired(lastRenewal time.Time) bool {
+	return !lastRenewal.IsZero() && l.now().After(lastRenewal.Add(l.ttl))
+}
+
+func (l *Lock) removeFile() error {
+	if err := os.Remove(l.filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed removing lock file: %v", err)
+	}
+
+	return nil
+}
+
+func (l *Lock) createLease(ctx context.Context, clusterName string, holder Holder, managementCluster *types.Cluster) error {
+	name := LeaseName(clusterName)
+	acquireTime := metav1.NewMicroTime(holder.AcquiredAt)
+	renewTime := metav1.NewMicroTime(holder.RenewedAt)
+	leaseDuration := int32(l.ttl.Seconds())
+	lease := &coordinationv1.Lease{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: coordinationv1.SchemeGroupVersion.String(),
+			Kind:       "Lease",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   constants.EksaSystemNamespace,
+			Annotations: map[string]string{operationAnnotation: holder.Operation},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder.Identity,
+			AcquireTime:          &acquireTime,
+			RenewTime:            &renewTime,
+			LeaseDurationSeconds: &leaseDuration,
+		},
+	}
+
+	err := l.client.CreateLease(ctx, managementCluster.KubeconfigFile, lease)
+	if err == nil {
+		l.leaseName = name
+		l.managementCluster = managementCluster
+		return nil
+	}
+
+	// Creating the lease fails when it already exists, in which case the holder is reported
+	existing, getErr := l.client.GetLease(ctx, managementCluster.KubeconfigFile, name, constants.EksaSystemNamespace)
+	if getErr != nil {
+		return fmt.Errorf("failed creating cluster lease %s: %v", name, err)
+	}
+
+	if l.leaseExpired(existing) {
+		stale := holderFromLease(existing)
+		logger.Info("Taking over stale cluster lease", "cluster", clusterName, "lease", name, "holder", stale.Identity, "operation", stale.Operation)
+		// The replace keeps the resourceVersion of the stale lease, so it fails if another operation took it over first
+		lease.ResourceVersion = existing.ResourceVersion
+		if err = l.client.UpdateLease(ctx, managementCluster.KubeconfigFile, lease); err != nil {
+			return fmt.Errorf("failed taking over stale cluster lease %s: %v", name, err)
+		}
+		l.leaseName = name
+		l.managementCluster = managementCluster
+		return nil
+	}
+
+	return &HeldError{
+		Cluster:     clusterName,
+		Holder:      holderFromLease(existing),
+		Remediation: fmt.Sprintf("delete the lease %s in namespace %s of the management cluster", name, constants.EksaSystemNamespace),
+	}
+}
+
+// leaseExpired returns true when the lease wasn't renewed within its duration. Leases without a duration,
+// taken before leases were renewed, expire after the TTL of the lock
+func (l *Lock) leaseExpired(lease *coordinationv1.Lease) bool {
+	lastRenewal := lease.Spec.RenewTime
+	if lastRenewal == nil {
+		lastRenewal = lease.Spec.AcquireTime
+	}
+	if lastRenewal == nil {
+		return false
+	}
+
+	ttl := l.ttl
+	if lease.Spec.LeaseDurationSeconds != nil {
+		ttl = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+
+	return l.now().After(lastRenewal.Add(ttl))
+}
+
+// startRenewal renews the lock every third of its TTL until the lock is released or ctx is cancelled
+func (l *Lock) startRenewal(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	l.stopRenewal = cancel
+	l.renewalDone = make(chan struct{})
+
+	go func() {
+		defer close(l.renewalDone)
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.renew(ctx)
+			}
+		}
+	}()
+}
+
+// renew updates the renewal time of the lock file and the lease. Failing to renew is only logged, the operation
+// keeps running and the lock expires if it's not renewed before its TTL
+func (l *Lock) renew(ctx context.Context) {
+	l.holder.RenewedAt = l.now().UTC().Truncate(time.Second)
+
+	if err := l.renewFile(); err != nil {
+		logger.Info("Warning: failed renewing cluster lock file", "cluster", l.clusterName, "error", err.Error())
+	}
+
+	if l.managementCluster != nil {
+		if err := l.renewLease(ctx); err != nil {
+			logger.Info("Warning: failed renewing cluster lease", "cluster", l.clusterName, "error", err.Error())
+		}
+	}
+}
+
+func (l *Lock) renewFile() error {
+	existing, err := l.readFile()
+	if err != nil {
+		return err
+	}
+	if existing.Identity != l.holder.Identity || !existing.AcquiredAt.Equal(l.holder.AcquiredAt) {
+		return fmt.Errorf("lock file %s was taken over by %s", l.filePath, existing.Identity)
+	}
+
+	content, err := yaml.Marshal(l.holder)
+	if err != nil {
+		return fmt.Errorf("failed marshalling lock file: %v", err)
+	}
+
+	if err = ioutil.WriteFile(l.filePath, content, 0o644); err != nil {
+		return fmt.Errorf("failed writing lock file: %v", err)
+	}
+
+	return nil
+}
+
+func (l *Lock) renewLease(ctx context.Context) error {
+	lease, err := l.client.GetLease(ctx, l.managementCluster.KubeconfigFile, l.leaseName, constants.EksaSystemNamespace)
+	if err != nil {
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.holder.Identity {
+		return fmt.Errorf("lease %s was taken over by %s", l.leaseName, holderFromLease(lease).Identity)
+	}
+
+	renewTime := metav1.NewMicroTime(l.holder.RenewedAt)
+	lease.Spec.RenewTime = &renewTime
+	return l.client.UpdateLease(ctx, l.managementCluster.KubeconfigFile, lease)
+}
+
+// LeaseName returns the name of the Lease that locks the cluster in the management cluster
+func LeaseName(clusterName string) string {
+	return fmt.Sprintf("%s-operation-lock", clusterName)
+}
+
+func holderFromLease(lease *coordinationv1.Lease) Holder {
+	holder := Holder{
+		Identity:  "unknown",
+		Operation: lease.Annotations[operationAnnotation],
+	}
+	if lease.Spec.HolderIdentity != nil {
+		holder.Identity = *lease.Spec.HolderIdentity
+	}
+	if lease.Spec.AcquireTime != nil {
+		holder.AcquiredAt = lease.Spec.AcquireTime.Time
+	}
+	if holder.Operation == "" {
+		holder.Operation = "unknown"
+	}
+
+	return holder
+}
+
+func defaultIdentity() string {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return fmt.Sprintf("%s@%s (pid %d)", name, host, os.Getpid())
+}
@@ -0,0 +1,233 @@
+package lock_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/lock"
+	"github.com/aws/eks-anywhere/pkg/lock/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var acquiredAt = time.Date(2022, 2, 10, 15, 4, 5, 0, time.UTC)
+
+type lockTest struct {
+	*WithT
+	ctx               context.Context
+	client            *mocks.MockLeaseClient
+	managementCluster *types.Cluster
+	locker            *lock.Locker
+}
+
+func newLockTest(t *testing.T) *lockTest {
+	// The lock file is written in the cluster folder, relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	client := mocks.NewMockLeaseClient(gomock.NewController(t))
+	return &lockTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		client:            client,
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		locker: lock.NewLocker(client,
+			lock.WithIdentity("admin@host (pid 10)"),
+			lock.WithNow(func() time.Time { return acquiredAt }),
+		),
+	}
+}
+
+func TestLockerLockLocalOnly(t *testing.T) {
+	tt := newLockTest(t)
+
+	l, err := tt.locker.Lock(tt.ctx, "test-cluster", "create", nil)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(filepath.Join("test-cluster", lock.FileName)).To(BeAnExistingFile())
+
+	_, err = tt.locker.Lock(tt.ctx, "test-cluster", "upgrade", nil)
+	tt.Expect(err).To(MatchError("cluster test-cluster is locked: create operation in progress by admin@host (pid 10) since 2022-02-10T15:04:05Z. " +
+		"If that operation is not running anymore, remove the lock file test-cluster/eksa-operation.lock"))
+
+	tt.Expect(l.Release(tt.ctx)).To(Succeed())
+	tt.Expect(filepath.Join("test-cluster", lock.FileName)).NotTo(BeAnExistingFile())
+}
+
+func TestLockerLockWithDir(t *testing.T) {
+	tt := newLockTest(t)
+	locker := lock.NewLocker(tt.client, lock.WithDir("workspace"))
+
+	l, err := locker.Lock(tt.ctx, "test-cluster", "create", nil)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(filepath.Join("workspace", "test-cluster", lock.FileName)).To(BeAnExistingFile())
+
+	tt.Expect(l.Release(tt.ctx)).To(Succeed())
+	tt.Expect(filepath.Join("workspace", "test-cluster", lock.FileName)).NotTo(BeAnExistingFile())
+}
+
+func TestLockerLockWithLease(t *testing.T) {
+	tt := newLockTest(t)
+	tt.client.EXPECT().CreateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, lease *coordinationv1.Lease) error {
+			tt.Expect(lease.Name).To(Equal("test-cluster-operation-lock"))
+			tt.Expect(lease.Namespace).To(Equal("eksa-system"))
+			tt.Expect(*lease.Spec.HolderIdentity).To(Equal("admin@host (pid 10)"))
+			tt.Expect(lease.Spec.AcquireTime.Time).To(Equal(acquiredAt))
+			tt.Expect(lease.Spec.RenewTime.Time).To(Equal(acquiredAt))
+			tt.Expect(*lease.Spec.LeaseDurationSeconds).To(Equal(int32(120)))
+			return nil
+		},
+	)
+	tt.client.EXPECT().DeleteLease(tt.ctx, "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system")
+
+	l, err := tt.locker.Lock(tt.ctx, "test-cluster", "upgrade", tt.managementCluster)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(l.Release(tt.ctx)).To(Succeed())
+	tt.Expect(filepath.Join("test-cluster", lock.FileName)).NotTo(BeAnExistingFile())
+}
+
+func TestLockerLockLeaseHeld(t *testing.T) {
+	tt := newLockTest(t)
+	holder := "ops@jumpbox (pid 42)"
+	since := metav1.NewMicroTime(acquiredAt.Add(-time.Hour))
+	renewed := metav1.NewMicroTime(acquiredAt.Add(-time.Minute))
+	duration := int32(120)
+	tt.client.EXPECT().CreateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any()).Return(errors.New("AlreadyExists"))
+	tt.client.EXPECT().GetLease(tt.ctx, "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system").Return(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"anywhere.eks.amazonaws.com/operation": "upgrade"}},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder, AcquireTime: &since, RenewTime: &renewed, LeaseDurationSeconds: &duration},
+	}, nil)
+
+	_, err := tt.locker.Lock(tt.ctx, "test-cluster", "upgrade", tt.managementCluster)
+	var heldErr *lock.HeldError
+	tt.Expect(errors.As(err, &heldErr)).To(BeTrue())
+	tt.Expect(heldErr.Holder.Identity).To(Equal(holder))
+	tt.Expect(err).To(MatchError(ContainSubstring("upgrade operation in progress by ops@jumpbox (pid 42) since 2022-02-10T14:04:05Z")))
+	// The local lock is released when the lease can't be taken
+	tt.Expect(filepath.Join("test-cluster", lock.FileName)).NotTo(BeAnExistingFile())
+}
+
+func TestLockerLockLeaseCreateError(t *testing.T) {
+	tt := newLockTest(t)
+	tt.client.EXPECT().CreateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any()).Return(errors.New("connection refused"))
+	tt.client.EXPECT().GetLease(tt.ctx, "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system").Return(nil, errors.New("connection refused"))
+
+	_, err := tt.locker.Lock(tt.ctx, "test-cluster", "upgrade", tt.managementCluster)
+	tt.Expect(err).To(MatchError("failed creating cluster lease test-cluster-operation-lock: connection refused"))
+}
+
+func TestLockReleaseLeaseError(t *testing.T) {
+	tt := newLockTest(t)
+	tt.client.EXPECT().CreateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any())
+	tt.client.EXPECT().DeleteLease(tt.ctx, "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system").Return(errors.New("forbidden"))
+
+	l, err := tt.locker.Lock(tt.ctx, "test-cluster", "upgrade", tt.managementCluster)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(l.Release(tt.ctx)).To(MatchError("failed releasing cluster lease test-cluster-operation-lock: forbidden"))
+}
+
+func TestLockerLockTakesOverStaleFile(t *testing.T) {
+	tt := newLockTest(t)
+	tt.Expect(os.MkdirAll("test-cluster", os.ModePerm)).To(Succeed())
+	stale := "identity: ops@laptop (pid 42)\noperation: upgrade\nacquiredAt: \"2022-02-10T13:00:00Z\"\nrenewedAt: \"2022-02-10T14:00:00Z\"\n"
+	tt.Expect(ioutil.WriteFile(filepath.Join("test-cluster", lock.FileName), []byte(stale), 0o644)).To(Succeed())
+
+	l, err := tt.locker.Lock(tt.ctx, "test-cluster", "create", nil)
+	tt.Expect(err).To(BeNil())
+	content, err := ioutil.ReadFile(filepath.Join("test-cluster", lock.FileName))
+	tt.Expect(err).To(BeNil())
+	tt.Expect(string(content)).To(ContainSubstring("identity: admin@host (pid 10)"))
+
+	tt.Expect(l.Release(tt.ctx)).To(Succeed())
+}
+
+func TestLockerLockFileRenewedWithinTTL(t *testing.T) {
+	tt := newLockTest(t)
+	tt.Expect(os.MkdirAll("test-cluster", os.ModePerm)).To(Succeed())
+	held := "identity: ops@laptop (pid 42)\noperation: upgrade\nacquiredAt: \"2022-02-10T13:00:00Z\"\nrenewedAt: \"2022-02-10T15:03:05Z\"\n"
+	tt.Expect(ioutil.WriteFile(filepath.Join("test-cluster", lock.FileName), []byte(held), 0o644)).To(Succeed())
+
+	_, err := tt.locker.Lock(tt.ctx, "test-cluster", "create", nil)
+	tt.Expect(err).To(MatchError(ContainSubstring("upgrade operation in progress by ops@laptop (pid 42) since 2022-02-10T13:00:00Z")))
+}
+
+func TestLockerLockTakesOverStaleLease(t *testing.T) {
+	tt := newLockTest(t)
+	holder := "ops@jumpbox (pid 42)"
+	renewed := metav1.NewMicroTime(acquiredAt.Add(-time.Hour))
+	duration := int32(120)
+	tt.client.EXPECT().CreateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any()).Return(errors.New("AlreadyExists"))
+	tt.client.EXPECT().GetLease(tt.ctx, "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system").Return(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{ResourceVersion: "5"},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder, RenewTime: &renewed, LeaseDurationSeconds: &duration},
+	}, nil)
+	tt.client.EXPECT().UpdateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, lease *coordinationv1.Lease) error {
+			tt.Expect(lease.ResourceVersion).To(Equal("5"))
+			tt.Expect(*lease.Spec.HolderIdentity).To(Equal("admin@host (pid 10)"))
+			tt.Expect(lease.Spec.RenewTime.Time).To(Equal(acquiredAt))
+			return nil
+		},
+	)
+	tt.client.EXPECT().DeleteLease(tt.ctx, "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system")
+
+	l, err := tt.locker.Lock(tt.ctx, "test-cluster", "upgrade", tt.managementCluster)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(l.Release(tt.ctx)).To(Succeed())
+}
+
+func TestLockerLockTakeOverStaleLeaseConflict(t *testing.T) {
+	tt := newLockTest(t)
+	since := metav1.NewMicroTime(acquiredAt.Add(-time.Hour))
+	tt.client.EXPECT().CreateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any()).Return(errors.New("AlreadyExists"))
+	tt.client.EXPECT().GetLease(tt.ctx, "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system").Return(&coordinationv1.Lease{
+		Spec: coordinationv1.LeaseSpec{AcquireTime: &since},
+	}, nil)
+	tt.client.EXPECT().UpdateLease(tt.ctx, "mgmt.kubeconfig", gomock.Any()).Return(errors.New("Conflict"))
+
+	_, err := tt.locker.Lock(tt.ctx, "test-cluster", "upgrade", tt.managementCluster)
+	tt.Expect(err).To(MatchError("failed taking over stale cluster lease test-cluster-operation-lock: Conflict"))
+	tt.Expect(filepath.Join("test-cluster", lock.FileName)).NotTo(BeAnExistingFile())
+}
+
+func TestLockRenews(t *testing.T) {
+	tt := newLockTest(t)
+	identity := "admin@host (pid 10)"
+	locker := lock.NewLocker(tt.client, lock.WithIdentity(identity), lock.WithTTL(30*time.Millisecond))
+	renewed := make(chan struct{}, 1)
+	tt.client.EXPECT().CreateLease(gomock.Any(), "mgmt.kubeconfig", gomock.Any())
+	tt.client.EXPECT().GetLease(gomock.Any(), "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system").Return(&coordinationv1.Lease{
+		Spec: coordinationv1.LeaseSpec{HolderIdentity: &identity},
+	}, nil).MinTimes(1)
+	tt.client.EXPECT().UpdateLease(gomock.Any(), "mgmt.kubeconfig", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, lease *coordinationv1.Lease) error {
+			tt.Expect(lease.Spec.RenewTime).NotTo(BeNil())
+			select {
+			case renewed <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+	).MinTimes(1)
+	tt.client.EXPECT().DeleteLease(gomock.Any(), "mgmt.kubeconfig", "test-cluster-operation-lock", "eksa-system")
+
+	l, err := locker.Lock(tt.ctx, "test-cluster", "upgrade", tt.managementCluster)
+	tt.Expect(err).To(BeNil())
+	tt.Eventually(renewed).Should(Receive())
+	tt.Expect(l.Release(tt.ctx)).To(Succeed())
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/lock/lock.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/coordination/v1"
+)
+
+// MockLeaseClient is a mock of LeaseClient interface.
+type MockLeaseClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaseClientMockRecorder
+}
+
+// MockLeaseClientMockRecorder is the mock recorder for MockLeaseClient.
+type MockLeaseClientMockRecorder struct {
+	mock *MockLeaseClient
+}
+
+// NewMockLeaseClient creates a new mock instance.
+func NewMockLeaseClient(ctrl *gomock.Controller) *MockLeaseClient {
+	mock := &MockLeaseClient{ctrl: ctrl}
+	mock.recorder = &MockLeaseClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeaseClient) EXPECT() *MockLeaseClientMockRecorder {
+	return m.recorder
+}
+
+// CreateLease mocks base method.
+func (m *MockLeaseClient) CreateLease(ctx context.Context, kubeconfigFile string, lease *v1.Lease) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLease", ctx, kubeconfigFile, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLease indicates an expected call of CreateLease.
+func (mr *MockLeaseClientMockRecorder) CreateLease(ctx, kubeconfigFile, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLease", reflect.TypeOf((*MockLeaseClient)(nil).CreateLease), ctx, kubeconfigFile, lease)
+}
+
+// DeleteLease mocks base method.
+func (m *MockLeaseClient) DeleteLease(ctx context.Context, kubeconfigFile, name, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLease", ctx, kubeconfigFile, name, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLease indicates an expected call of DeleteLease.
+func (mr *MockLeaseClientMockRecorder) DeleteLease(ctx, kubeconfigFile, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLease", reflect.TypeOf((*MockLeaseClient)(nil).DeleteLease), ctx, kubeconfigFile, name, namespace)
+}
+
+// GetLease mocks base method.
+func (m *MockLeaseClient) GetLease(ctx context.Context, kubeconfigFile, name, namespace string) (*v1.Lease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLease", ctx, kubeconfigFile, name, namespace)
+	ret0, _ := ret[0].(*v1.Lease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLease indicates an expected call of GetLease.
+func (mr *MockLeaseClientMockRecorder) GetLease(ctx, kubeconfigFile, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLease", reflect.TypeOf((*MockLeaseClient)(nil).GetLease), ctx, kubeconfigFile, name, namespace)
+}
+
+// UpdateLease mocks base method.
+func (m *MockLeaseClient) UpdateLease(ctx context.Context, kubeconfigFile string, lease *v1.Lease) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLease", ctx, kubeconfigFile, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLease indicates an expected call of UpdateLease.
+func (mr *MockLeaseClientMockRecorder) UpdateLease(ctx, kubeconfigFile, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLease", reflect.TypeOf((*MockLeaseClient)(nil).UpdateLease), ctx, kubeconfigFile, lease)
+}
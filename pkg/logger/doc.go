@@ -38,5 +38,19 @@ the end users because it increases the verbosity without providing information t
 Logging errors:
 
 A proper error management should always be preferred to the usage of log.Error.
+
+Subsystems:
+
+The executables, workflows and providers packages log through their subsystem logger, returned by For
+and assigned to a package variable named l, so it doesn't shadow the standard log package:
+
+	var l = logger.For(logger.Providers)
+
+Init can set a different verbosity for each subsystem, for example to get the traces of the external
+commands at level 6 while the rest of the operation logs at level 0.
+
+Sinks:
+
+Init writes the logs to all the configured sinks, in console or json format, to stderr or to a file.
 */
 package logger
@@ -17,14 +17,31 @@ const (
 var (
 	l    logr.Logger = logr.Discard()
 	once sync.Once
+	// root is the package logger before filtering it by level, used by the subsystems with their own level
+	root            logr.Logger = logr.Discard()
+	subsystemLevels             = map[Subsystem]int{}
 )
 
 func set(logger logr.Logger) {
+	setWithSubsystems(logger, logger, nil)
+}
+
+func setWithSubsystems(filtered, unfiltered logr.Logger, levels map[Subsystem]int) {
 	once.Do(func() {
-		l = logger
+		l = filtered
+		root = unfiltered
+		for s, level := range levels {
+			subsystemLevels[s] = level
+		}
 	})
 }
 
+// Set sets logger as the package logger, for programs that embed EKS Anywhere and use their own logger.
+// The package logger can only be set once, so it should be called before InitZap or any operation
+func Set(logger logr.Logger) {
+	set(logger)
+}
+
 // Get returns the logger instance that has been previously set.
 // If no logger has been set, it returns a null logger.
 func Get() logr.Logger {
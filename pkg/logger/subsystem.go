@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// Subsystem is a part of EKS Anywhere whose log verbosity can be set independently of the package logger level,
+// for example to trace the external commands without the debug logs of the rest of the operation
+type Subsystem string
+
+const (
+	Executables Subsystem = "executables"
+	Workflows   Subsystem = "workflows"
+	Providers   Subsystem = "providers"
+)
+
+var subsystems = []Subsystem{Executables, Workflows, Providers}
+
+// SubsystemLogger logs like the package functions, but with the verbosity configured for its subsystem.
+// Subsystems without a level log with the package logger level
+type SubsystemLogger struct {
+	subsystem Subsystem
+}
+
+// For returns the logger of the subsystem. It can be created before the package logger is initialized,
+// since the subsystem level is resolved on every call
+func For(subsystem Subsystem) *SubsystemLogger {
+	return &SubsystemLogger{subsystem: subsystem}
+}
+
+// Logger returns the logr.Logger of the subsystem with its current verbosity
+func (s *SubsystemLogger) Logger() logr.Logger {
+	level, ok := subsystemLevels[s.subsystem]
+	if !ok {
+		return l
+	}
+
+	return &levelFilter{sink: root, max: level}
+}
+
+func (s *SubsystemLogger) Info(msg string, keysAndValues ...interface{}) {
+	s.Logger().Info(msg, keysAndValues...)
+}
+
+func (s *SubsystemLogger) V(level int) logr.Logger {
+	return s.Logger().V(level)
+}
+
+func (s *SubsystemLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.Logger().Error(err, msg, keysAndValues...)
+}
+
+func (s *SubsystemLogger) MarkPass(msg string, keysAndValues ...interface{}) {
+	s.Logger().V(0).Info(markPass+msg, keysAndValues...)
+}
+
+func (s *SubsystemLogger) MarkSuccess(msg string, keysAndValues ...interface{}) {
+	s.Logger().V(0).Info(markSuccess+msg, keysAndValues...)
+}
+
+func (s *SubsystemLogger) MarkFail(msg string, keysAndValues ...interface{}) {
+	s.Logger().V(0).Info(markFailed+msg, keysAndValues...)
+}
+
+func (s *SubsystemLogger) MaxLogging() bool {
+	return s.Logger().V(maxLogging).Enabled()
+}
+
+// ParseSubsystemLevels parses subsystem levels in the subsystem=level format, like executables=6
+func ParseSubsystemLevels(values []string) (map[Subsystem]int, error) {
+	levels := make(map[Subsystem]int, len(values))
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid subsystem log level %s, it should be in the format subsystem=level", v)
+		}
+
+		subsystem := Subsystem(strings.TrimSpace(parts[0]))
+		if !isSubsystem(subsystem) {
+			return nil, fmt.Errorf("unknown log subsystem %s, supported subsystems: %s", subsystem, supportedSubsystems())
+		}
+
+		level, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || level < 0 {
+			return nil, fmt.Errorf("invalid log level for subsystem %s: %s", subsystem, parts[1])
+		}
+		levels[subsystem] = level
+	}
+
+	return levels, nil
+}
+
+func isSubsystem(subsystem Subsystem) bool {
+	for _, s := range subsystems {
+		if s == subsystem {
+			return true
+		}
+	}
+	return false
+}
+
+func supportedSubsystems() string {
+	names := make([]string, 0, len(subsystems))
+	for _, s := range subsystems {
+		names = append(names, string(s))
+	}
+	return strings.Join(names, ", ")
+}
+
+// levelFilter drops the info logs above max verbosity. The sinks are built with the highest verbosity of the
+// package logger and the subsystems, so each of them needs to filter its own level
+type levelFilter struct {
+	sink logr.Logger
+	v    int
+	max  int
+}
+
+func (f *levelFilter) Enabled() bool {
+	return f.v <= f.max && f.sink.Enabled()
+}
+
+func (f *levelFilter) Info(msg string, keysAndValues ...interface{}) {
+	if f.v <= f.max {
+		f.sink.Info(msg, keysAndValues...)
+	}
+}
+
+func (f *levelFilter) Error(err error, msg string, keysAndValues ...interface{}) {
+	f.sink.Error(err, msg, keysAndValues...)
+}
+
+func (f *levelFilter) V(level int) logr.Logger {
+	return &levelFilter{sink: f.sink.V(level), v: f.v + level, max: f.max}
+}
+
+func (f *levelFilter) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return &levelFilter{sink: f.sink.WithValues(keysAndValues...), v: f.v, max: f.max}
+}
+
+func (f *levelFilter) WithName(name string) logr.Logger {
+	return &levelFilter{sink: f.sink.WithName(name), v: f.v, max: f.max}
+}
@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+)
+
+// resetLogger allows initializing the package logger again in each test
+func resetLogger(t *testing.T) {
+	reset := func() {
+		once = sync.Once{}
+		l = logr.Discard()
+		root = logr.Discard()
+		subsystemLevels = map[Subsystem]int{}
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func readJSONLogs(t *testing.T, path string) []map[string]interface{} {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logs []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		entry := map[string]interface{}{}
+		if err = json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid json log line %s: %v", line, err)
+		}
+		logs = append(logs, entry)
+	}
+
+	return logs
+}
+
+func TestInitSubsystemLevels(t *testing.T) {
+	g := NewWithT(t)
+	resetLogger(t)
+	logFile := filepath.Join(t.TempDir(), "eksa.log")
+
+	g.Expect(Init(Config{
+		Level:           2,
+		SubsystemLevels: map[Subsystem]int{Executables: 6, Workflows: 0},
+		Sinks:           []Sink{{Format: JSONFormat, Path: logFile}},
+	})).To(Succeed())
+
+	V(2).Info("Package log")
+	V(3).Info("Package debug log")
+	For(Executables).V(6).Info("Executable trace")
+	For(Executables).V(7).Info("Executable manifest")
+	For(Workflows).Info("Workflow log")
+	For(Workflows).V(1).Info("Workflow detail")
+	For(Providers).V(2).Info("Provider log")
+	For(Workflows).Error(nil, "Workflow error")
+
+	var messages []string
+	for _, entry := range readJSONLogs(t, logFile) {
+		messages = append(messages, entry["msg"].(string))
+	}
+	g.Expect(messages).To(Equal([]string{"Package log", "Executable trace", "Workflow log", "Provider log", "Workflow error"}))
+	g.Expect(For(Executables).MaxLogging()).To(BeFalse())
+	g.Expect(For(Executables).V(6).Enabled()).To(BeTrue())
+	g.Expect(V(6).Enabled()).To(BeFalse())
+}
+
+func TestInitQuiet(t *testing.T) {
+	g := NewWithT(t)
+	resetLogger(t)
+	logFile := filepath.Join(t.TempDir(), "eksa.log")
+
+	// The stderr sink is dropped before it's built, so its invalid format doesn't fail
+	g.Expect(Init(Config{
+		Sinks: []Sink{{Format: "yaml"}, {Format: JSONFormat, Path: logFile}},
+		Quiet: true,
+	})).To(Succeed())
+
+	Info("Quiet log")
+
+	logs := readJSONLogs(t, logFile)
+	g.Expect(logs).To(HaveLen(1))
+	g.Expect(logs[0]["msg"]).To(Equal("Quiet log"))
+}
+
+func TestInitInvalidFormat(t *testing.T) {
+	g := NewWithT(t)
+	resetLogger(t)
+
+	g.Expect(Init(Config{Sinks: []Sink{{Format: "yaml"}}})).To(MatchError("invalid log format yaml, supported formats: console, json"))
+}
+
+func TestSubsystemWithoutInit(t *testing.T) {
+	g := NewWithT(t)
+	resetLogger(t)
+
+	g.Expect(For(Executables).V(0).Enabled()).To(BeFalse())
+}
+
+func TestParseSubsystemLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[Subsystem]int
+		wantErr string
+	}{
+		{
+			name:   "valid levels",
+			values: []string{"executables=6", " workflows = 2"},
+			want:   map[Subsystem]int{Executables: 6, Workflows: 2},
+		},
+		{
+			name:    "unknown subsystem",
+			values:  []string{"capi=4"},
+			wantErr: "unknown log subsystem capi, supported subsystems: executables, workflows, providers",
+		},
+		{
+			name:    "missing level",
+			values:  []string{"executables"},
+			wantErr: "invalid subsystem log level executables, it should be in the format subsystem=level",
+		},
+		{
+			name:    "invalid level",
+			values:  []string{"providers=-1"},
+			wantErr: "invalid log level for subsystem providers: -1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := ParseSubsystemLevels(tt.values)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
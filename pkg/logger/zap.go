@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/go-logr/zapr"
@@ -9,42 +10,125 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// Format is the encoding of a log sink
+type Format string
+
+const (
+	ConsoleFormat Format = "console"
+	JSONFormat    Format = "json"
+)
+
+// Sink is a destination for the logs
+type Sink struct {
+	Format Format
+	// Path is the file the logs are appended to. Empty writes to stderr
+	Path string
+}
+
+// Config configures the package logger built by Init
+type Config struct {
+	// Level is the verbosity of the package logger and the subsystems without their own level
+	Level int
+	// SubsystemLevels overrides the verbosity of some subsystems, higher or lower than Level
+	SubsystemLevels map[Subsystem]int
+	// Sinks are all the destinations of the logs. No sinks logs to stderr in console format
+	Sinks []Sink
+	// Quiet drops the sinks writing to stderr, so only the file sinks get the logs
+	Quiet bool
+}
+
 // InitZap creates a zap logger with the provided verbosity level
 // and sets it as the package logger.
 // 0 is the least verbose and 10 the most verbose.
 // The package logger can only be init once, so subsequent calls to this method
 // won't have any effect
 func InitZap(level int, opts ...LoggerOpt) error {
-	cfg := zap.NewDevelopmentConfig()
-	cfg.Level = zap.NewAtomicLevelAt(zapcore.Level(-1 * level))
-	cfg.EncoderConfig.EncodeLevel = nil
-	cfg.EncoderConfig.EncodeTime = NullTimeEncoder
-	cfg.DisableCaller = true
-	cfg.DisableStacktrace = true
+	return Init(Config{Level: level}, opts...)
+}
 
-	// Only enabling this at level 4 because that's when
-	// our debugging levels start. Ref: doc.go
-	if level >= 4 {
-		cfg.EncoderConfig.EncodeLevel = VLevelEncoder
-		cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+// Init creates a zap logger writing to all the sinks in the config and sets it as the package logger.
+// Like InitZap, it only has effect the first time the package logger is set
+func Init(config Config, opts ...LoggerOpt) error {
+	sinks := config.Sinks
+	if len(sinks) == 0 {
+		sinks = []Sink{{Format: ConsoleFormat}}
+	}
+	if config.Quiet {
+		sinks = fileSinks(sinks)
 	}
 
-	zapLog, err := cfg.Build()
-	if err != nil {
-		return fmt.Errorf("error creating zap logger: %v", err)
+	// The cores log at the highest verbosity requested, each logger filters its own level
+	maxLevel := config.Level
+	for _, level := range config.SubsystemLevels {
+		if level > maxLevel {
+			maxLevel = level
+		}
 	}
 
-	logr := zapr.NewLogger(zapLog)
-	for _, opt := range opts {
-		opt(&logr)
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for _, sink := range sinks {
+		core, err := newCore(sink, maxLevel)
+		if err != nil {
+			return err
+		}
+		cores = append(cores, core)
 	}
 
-	set(logr)
-	l.V(4).Info("Logger init completed", "vlevel", level)
+	unfiltered := zapr.NewLogger(zap.New(zapcore.NewTee(cores...)))
+	for _, opt := range opts {
+		opt(&unfiltered)
+	}
 
+	setWithSubsystems(&levelFilter{sink: unfiltered, max: config.Level}, unfiltered, config.SubsystemLevels)
+	l.V(4).Info("Logger init completed", "vlevel", config.Level)
 	return nil
 }
 
+func fileSinks(sinks []Sink) []Sink {
+	files := make([]Sink, 0, len(sinks))
+	for _, sink := range sinks {
+		if sink.Path != "" {
+			files = append(files, sink)
+		}
+	}
+	return files
+}
+
+func newCore(sink Sink, level int) (zapcore.Core, error) {
+	var encoder zapcore.Encoder
+	switch sink.Format {
+	case ConsoleFormat, "":
+		cfg := zap.NewDevelopmentEncoderConfig()
+		cfg.EncodeLevel = nil
+		cfg.EncodeTime = NullTimeEncoder
+		// Only enabling this at level 4 because that's when
+		// our debugging levels start. Ref: doc.go
+		if level >= 4 {
+			cfg.EncodeLevel = VLevelEncoder
+			cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		}
+		encoder = zapcore.NewConsoleEncoder(cfg)
+	case JSONFormat:
+		cfg := zap.NewProductionEncoderConfig()
+		cfg.EncodeLevel = VLevelEncoder
+		cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(cfg)
+	default:
+		return nil, fmt.Errorf("invalid log format %s, supported formats: %s, %s", sink.Format, ConsoleFormat, JSONFormat)
+	}
+
+	writer := zapcore.Lock(os.Stderr)
+	if sink.Path != "" {
+		f, err := os.OpenFile(sink.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("error opening log file: %v", err)
+		}
+		writer = zapcore.Lock(f)
+	}
+
+	return zapcore.NewCore(encoder, writer, zap.NewAtomicLevelAt(zapcore.Level(-1*level))), nil
+}
+
 // VLevelEncoder serializes a Level to V + v-level number,
 func VLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
 	enc.AppendString(fmt.Sprintf("V%d", -1*int(l)))
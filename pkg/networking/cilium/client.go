@@ -37,7 +37,7 @@ func newRetrier(client Client) *retrierClient {
 }
 
 func (c *retrierClient) Apply(ctx context.Context, cluster *types.Cluster, data []byte) error {
-	return c.Retry(
+	return c.RetryWithContext(ctx,
 		func() error {
 			return c.ApplyKubeSpecFromBytes(ctx, cluster, data)
 		},
@@ -45,7 +45,7 @@ func (c *retrierClient) Apply(ctx context.Context, cluster *types.Cluster, data
 }
 
 func (c *retrierClient) Delete(ctx context.Context, cluster *types.Cluster, data []byte) error {
-	return c.Retry(
+	return c.RetryWithContext(ctx,
 		func() error {
 			return c.DeleteKubeSpecFromBytes(ctx, cluster, data)
 		},
@@ -53,7 +53,7 @@ func (c *retrierClient) Delete(ctx context.Context, cluster *types.Cluster, data
 }
 
 func (c *retrierClient) WaitForPreflightDaemonSet(ctx context.Context, cluster *types.Cluster) error {
-	return c.Retry(
+	return c.RetryWithContext(ctx,
 		func() error {
 			return c.checkPreflightDaemonSetReady(ctx, cluster)
 		},
@@ -83,7 +83,7 @@ func (c *retrierClient) checkPreflightDaemonSetReady(ctx context.Context, cluste
 }
 
 func (c *retrierClient) WaitForPreflightDeployment(ctx context.Context, cluster *types.Cluster) error {
-	return c.Retry(
+	return c.RetryWithContext(ctx,
 		func() error {
 			return c.checkPreflightDeploymentReady(ctx, cluster)
 		},
@@ -104,7 +104,7 @@ func (c *retrierClient) checkPreflightDeploymentReady(ctx context.Context, clust
 }
 
 func (c *retrierClient) WaitForCiliumDaemonSet(ctx context.Context, cluster *types.Cluster) error {
-	return c.Retry(
+	return c.RetryWithContext(ctx,
 		func() error {
 			return c.checkCiliumDaemonSetReady(ctx, cluster)
 		},
@@ -125,7 +125,7 @@ func (c *retrierClient) checkCiliumDaemonSetReady(ctx context.Context, cluster *
 }
 
 func (c *retrierClient) WaitForCiliumDeployment(ctx context.Context, cluster *types.Cluster) error {
-	return c.Retry(
+	return c.RetryWithContext(ctx,
 		func() error {
 			return c.checkCiliumDeploymentReady(ctx, cluster)
 		},
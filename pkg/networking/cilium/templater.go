@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/semver"
 )
@@ -73,6 +74,8 @@ func (c *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec) ([
 	return manifest, nil
 }
 
+const apiServerPort = 6443
+
 type values map[string]interface{}
 
 func (c values) set(value interface{}, path ...string) {
@@ -89,7 +92,7 @@ func (c values) set(value interface{}, path ...string) {
 }
 
 func templateValues(spec *cluster.Spec) values {
-	return values{
+	v := values{
 		"cni": values{
 			"chainingMode": "portmap",
 		},
@@ -118,6 +121,16 @@ func templateValues(spec *cluster.Spec) values {
 			},
 		},
 	}
+
+	if spec.Spec.ClusterNetwork.KubeProxy.ModeOrDefault() == v1alpha1.KubeProxyModeDisabled {
+		// without kube-proxy the kubernetes service doesn't work until Cilium is running,
+		// so it reaches the API server through the control plane endpoint
+		v.set("strict", "kubeProxyReplacement")
+		v.set(spec.Spec.ControlPlaneConfiguration.Endpoint.Host, "k8sServiceHost")
+		v.set(apiServerPort, "k8sServicePort")
+	}
+
+	return v
 }
 
 func getChartUriAndVersion(spec *cluster.Spec) (uri, version string) {
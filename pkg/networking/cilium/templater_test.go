@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium/mocks"
@@ -174,6 +175,23 @@ func TestTemplaterGenerateManifestSuccess(t *testing.T) {
 	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
 }
 
+func TestTemplaterGenerateManifestKubeProxyDisabled(t *testing.T) {
+	tt := newtemplaterTest(t)
+	tt.spec.Spec.ClusterNetwork.KubeProxy = &v1alpha1.KubeProxyConfiguration{Mode: v1alpha1.KubeProxyModeDisabled}
+	tt.spec.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	tt.h.EXPECT().Template(tt.ctx, tt.uri, tt.version, tt.namespace, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _ string, v interface{}) ([]byte, error) {
+			values, err := json.Marshal(v)
+			tt.Expect(err).NotTo(HaveOccurred())
+			tt.Expect(string(values)).To(ContainSubstring(`"k8sServiceHost":"1.2.3.4","k8sServicePort":6443`))
+			tt.Expect(string(values)).To(ContainSubstring(`"kubeProxyReplacement":"strict"`))
+			return tt.manifest, nil
+		},
+	)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest))
+}
+
 func TestTemplaterGenerateManifestError(t *testing.T) {
 	tt := newtemplaterTest(t)
 	tt.expectHelmTemplateWith(gomock.Any()).Return(nil, errors.New("error from helm")) // Using any because we only want to test the returned error
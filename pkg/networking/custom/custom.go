@@ -0,0 +1,38 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// Custom is the networking of the clusters whose CNI is installed and upgraded by the user. EKS Anywhere only
+// installs it with the user manifest when the cluster is created
+type Custom struct{}
+
+func NewCustom() *Custom {
+	return &Custom{}
+}
+
+// GenerateManifest returns the manifest of the cluster customCNI, read from its path or URL
+func (c *Custom) GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
+	customCNI := clusterSpec.Cluster.Spec.ClusterNetwork.CustomCNI
+	if customCNI == nil || customCNI.Manifest == "" {
+		return nil, fmt.Errorf("customCNI.manifest is required for cni %s", v1alpha1.Custom)
+	}
+	manifest, err := clusterSpec.LoadManifest(releasev1alpha1.Manifest{URI: customCNI.Manifest})
+	if err != nil {
+		return nil, fmt.Errorf("failed reading custom cni manifest: %v", err)
+	}
+
+	return manifest.Content, nil
+}
+
+// Upgrade doesn't change the CNI, it's upgraded by the user
+func (c *Custom) Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error) {
+	return nil, nil
+}
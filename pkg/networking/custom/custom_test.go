@@ -0,0 +1,56 @@
+package custom_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/networking/custom"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestCustomGenerateManifest(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "w01"
+		s.Cluster.Spec.ClusterNetwork.CustomCNI = &v1alpha1.CustomCNIConfig{Manifest: "testdata/cni.yaml"}
+	})
+	want, err := os.ReadFile("testdata/cni.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	manifest, err := custom.NewCustom().GenerateManifest(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(manifest).To(Equal(want))
+}
+
+func TestCustomGenerateManifestMissing(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) { s.Name = "w01" })
+
+	_, err := custom.NewCustom().GenerateManifest(spec)
+	g.Expect(err).To(MatchError("customCNI.manifest is required for cni custom"))
+}
+
+func TestCustomGenerateManifestReadError(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CustomCNI = &v1alpha1.CustomCNIConfig{Manifest: "testdata/missing.yaml"}
+	})
+
+	_, err := custom.NewCustom().GenerateManifest(spec)
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading custom cni manifest")))
+}
+
+func TestCustomUpgradeDoesNothing(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) { s.Name = "w01" })
+
+	diff, err := custom.NewCustom().Upgrade(context.Background(), &types.Cluster{Name: "w01"}, spec, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeNil())
+}
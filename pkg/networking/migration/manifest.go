@@ -0,0 +1,106 @@
+package migration
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const defaultNamespace = "default"
+
+// manifestObjects splits a multi document manifest into its objects, skipping the empty documents
+func manifestObjects(manifest []byte) ([]*unstructured.Unstructured, error) {
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	var objs []*unstructured.Unstructured
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest: %v", err)
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, fmt.Errorf("parsing manifest object: %v", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+}
+
+// daemonSets returns the namespace/name of the DaemonSets in the manifest, the ones that run the CNI in the nodes
+func daemonSets(manifest []byte) (map[string]bool, error) {
+	objs, err := manifestObjects(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, obj := range objs {
+		if obj.GetKind() == "DaemonSet" {
+			names[daemonSetKey(obj.GetNamespace(), obj.GetName())] = true
+		}
+	}
+	return names, nil
+}
+
+func daemonSetKey(namespace, name string) string {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return namespace + "/" + name
+}
+
+// withNodeSelector returns the manifest with its DaemonSets restricted to the nodes with the label
+func withNodeSelector(manifest []byte, key, value string) ([]byte, error) {
+	objs, err := manifestObjects(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		if obj.GetKind() == "DaemonSet" {
+			selector, _, err := unstructured.NestedStringMap(obj.Object, "spec", "template", "spec", "nodeSelector")
+			if err != nil {
+				return nil, fmt.Errorf("reading node selector of DaemonSet %s: %v", obj.GetName(), err)
+			}
+			if selector == nil {
+				selector = map[string]string{}
+			}
+			selector[key] = value
+			if err := unstructured.SetNestedStringMap(obj.Object, selector, "spec", "template", "spec", "nodeSelector"); err != nil {
+				return nil, fmt.Errorf("setting node selector of DaemonSet %s: %v", obj.GetName(), err)
+			}
+		}
+
+		resource, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		resources = append(resources, resource)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+func clusterManifest(cluster *v1alpha1.Cluster) ([]byte, error) {
+	content, err := yaml.Marshal(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling cluster %s: %v", cluster.Name, err)
+	}
+	return content, nil
+}
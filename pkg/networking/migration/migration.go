@@ -0,0 +1,538 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// NodeLabel is set in the nodes during a migration to the CNI that runs in each of them.
+// The DaemonSets of both CNIs are restricted to the nodes with their value
+const NodeLabel = "anywhere.eks.amazonaws.com/cni"
+
+const (
+	defaultDrainTimeout = 10 * time.Minute
+	defaultNodeTimeout  = 10 * time.Minute
+	defaultPollInterval = 5 * time.Second
+
+	controlPlaneNodeLabel = "node-role.kubernetes.io/control-plane"
+	masterNodeLabel       = "node-role.kubernetes.io/master"
+)
+
+type KubectlClient interface {
+	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
+	UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error
+	RemoveAnnotation(ctx context.Context, resourceType, objectName string, key string, opts ...executables.KubectlOpt) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	GetNodes(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Node, error)
+	LabelNode(ctx context.Context, node, key, value string, opts ...executables.KubectlOpt) error
+	RemoveNodeLabel(ctx context.Context, node, key string, opts ...executables.KubectlOpt) error
+	CordonNode(ctx context.Context, node string, opts ...executables.KubectlOpt) error
+	UncordonNode(ctx context.Context, node string, opts ...executables.KubectlOpt) error
+	DrainNodeInCluster(ctx context.Context, cluster *types.Cluster, node, timeout string) error
+	GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Pod, error)
+	DeletePod(ctx context.Context, name, namespace string, opts ...executables.KubectlOpt) error
+}
+
+// Plan is the migration of a cluster from the CNI it runs to another one
+type Plan struct {
+	From v1alpha1.CNI
+	To   v1alpha1.CNI
+	// SourceManifest installs the CNI the cluster runs, its resources are deleted at the end of the migration
+	SourceManifest []byte
+	// TargetManifest installs the CNI the cluster is migrated to
+	TargetManifest []byte
+	// CustomCNI is recorded in the cluster spec when it's migrated to the custom CNI
+	CustomCNI *v1alpha1.CustomCNIConfig
+}
+
+// NewPlan returns the plan to migrate between the EKS Anywhere managed Cilium and a user managed CNI, in either direction.
+// customManifest is the manifest of the user managed CNI, it's needed in both directions to tell its DaemonSets
+func NewPlan(from, to v1alpha1.CNI, ciliumManifest, customManifest []byte) (*Plan, error) {
+	switch {
+	case from == v1alpha1.Cilium && to == v1alpha1.Custom:
+		return &Plan{From: from, To: to, SourceManifest: ciliumManifest, TargetManifest: customManifest}, nil
+	case from == v1alpha1.Custom && to == v1alpha1.Cilium:
+		return &Plan{From: from, To: to, SourceManifest: customManifest, TargetManifest: ciliumManifest}, nil
+	case from == to:
+		return nil, fmt.Errorf("cluster already runs cni %s", to)
+	default:
+		return nil, fmt.Errorf("migrating cni from %s to %s is not supported, only between %s and %s", from, to, v1alpha1.Cilium, v1alpha1.Custom)
+	}
+}
+
+// reverse returns the plan that moves the nodes back to the source CNI
+func (p *Plan) reverse() *Plan {
+	return &Plan{From: p.To, To: p.From, SourceManifest: p.TargetManifest, TargetManifest: p.SourceManifest}
+}
+
+// Migrator moves the nodes of a cluster from one CNI to another, one node at a time,
+// and records the new CNI in the cluster spec once all of them run it
+type Migrator struct {
+	client            KubectlClient
+	workloadCluster   *types.Cluster
+	managementCluster *types.Cluster
+	drainTimeout      time.Duration
+	nodeTimeout       time.Duration
+	pollInterval      time.Duration
+}
+
+type MigratorOpt func(*Migrator)
+
+// WithDrainTimeout bounds the time to drain each node. Defaults to 10 minutes
+func WithDrainTimeout(timeout time.Duration) MigratorOpt {
+	return func(m *Migrator) {
+		if timeout > 0 {
+			m.drainTimeout = timeout
+		}
+	}
+}
+
+// WithNodeTimeout bounds the time to wait for the new CNI to be ready in each node. Defaults to 10 minutes
+func WithNodeTimeout(timeout time.Duration) MigratorOpt {
+	return func(m *Migrator) {
+		if timeout > 0 {
+			m.nodeTimeout = timeout
+		}
+	}
+}
+
+// WithPollInterval sets how often the CNI pods of a node are checked
+func WithPollInterval(interval time.Duration) MigratorOpt {
+	return func(m *Migrator) {
+		m.pollInterval = interval
+	}
+}
+
+// NewMigrator returns a Migrator for workloadCluster, whose cluster object lives in managementCluster.
+// Both are the same for self managed clusters
+func NewMigrator(client KubectlClient, workloadCluster, managementCluster *types.Cluster, opts ...MigratorOpt) *Migrator {
+	m := &Migrator{
+		client:            client,
+		workloadCluster:   workloadCluster,
+		managementCluster: managementCluster,
+		drainTimeout:      defaultDrainTimeout,
+		nodeTimeout:       defaultNodeTimeout,
+		pollInterval:      defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// PreCheck validates the cluster can be migrated with the plan: the cluster object runs the source CNI,
+// both manifests have DaemonSets to steer and all the nodes are ready
+func (m *Migrator) PreCheck(ctx context.Context, plan *Plan) error {
+	cluster, err := m.client.GetEksaCluster(ctx, m.managementCluster, m.workloadCluster.Name)
+	if err != nil {
+		return err
+	}
+	if current := cluster.Spec.ClusterNetwork.CNI; current != plan.From {
+		return fmt.Errorf("cluster %s runs cni %s, not %s", m.workloadCluster.Name, current, plan.From)
+	}
+
+	for cni, manifest := range map[v1alpha1.CNI][]byte{plan.From: plan.SourceManifest, plan.To: plan.TargetManifest} {
+		names, err := daemonSets(manifest)
+		if err != nil {
+			return fmt.Errorf("invalid %s manifest: %v", cni, err)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("%s manifest has no DaemonSet, it can't be migrated node by node", cni)
+		}
+	}
+
+	nodes, err := m.client.GetNodes(ctx, executables.WithCluster(m.workloadCluster))
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if !nodeReady(node) {
+			return fmt.Errorf("node %s is not ready", node.Name)
+		}
+		if value, ok := node.Labels[NodeLabel]; ok && value != string(plan.From) && value != string(plan.To) {
+			return fmt.Errorf("node %s is labeled with %s=%s from another migration", node.Name, NodeLabel, value)
+		}
+	}
+
+	return nil
+}
+
+// Migrate runs the plan. The nodes are migrated one at a time, workers first: each one is cordoned, drained and
+// labeled with the new CNI, which replaces the old one in the node. The nodes that join the cluster during the migration,
+// like the ones of a machine rollout, are labeled with the new CNI right away. A failed migration can be resumed by
+// running it again, the nodes already migrated are skipped, or undone with Rollback
+func (m *Migrator) Migrate(ctx context.Context, plan *Plan) error {
+	if err := m.PreCheck(ctx, plan); err != nil {
+		return fmt.Errorf("cni migration pre-checks failed: %v", err)
+	}
+
+	logger.Info("Preparing the cni migration", "from", plan.From, "to", plan.To)
+	nodes, err := m.prepare(ctx, plan)
+	if err != nil {
+		return err
+	}
+
+	if err := m.migrateNodes(ctx, plan, nodes); err != nil {
+		return fmt.Errorf("%v, rerun the migration to resume it or roll it back", err)
+	}
+
+	nodes, err = m.labelJoinedNodes(ctx, plan.To)
+	if err != nil {
+		return err
+	}
+	logger.Info("Handing off the cni ownership", "cni", plan.To)
+	if err := m.release(ctx, plan, nodes); err != nil {
+		return err
+	}
+	return m.recordCNI(ctx, plan)
+}
+
+// Rollback moves the nodes migrated by a failed Migrate back to the source CNI of the plan and restores it in all the
+// nodes, leaving the cluster spec untouched. It's only possible before the cluster spec records the target CNI, after
+// that the cluster has to be migrated back with the reverse plan
+func (m *Migrator) Rollback(ctx context.Context, plan *Plan) error {
+	cluster, err := m.client.GetEksaCluster(ctx, m.managementCluster, m.workloadCluster.Name)
+	if err != nil {
+		return err
+	}
+	if current := cluster.Spec.ClusterNetwork.CNI; current != plan.From {
+		return fmt.Errorf("cluster %s already runs cni %s, migrate it back to %s instead", m.workloadCluster.Name, current, plan.From)
+	}
+
+	logger.Info("Rolling back the cni migration", "from", plan.To, "to", plan.From)
+	reverse := plan.reverse()
+	nodes, err := m.labelJoinedNodes(ctx, reverse.To)
+	if err != nil {
+		return err
+	}
+	if err := m.restrictDaemonSets(ctx, plan); err != nil {
+		return err
+	}
+	sortNodes(nodes)
+
+	if err := m.migrateNodes(ctx, reverse, nodes); err != nil {
+		return fmt.Errorf("%v, rerun the rollback to resume it", err)
+	}
+
+	nodes, err = m.labelJoinedNodes(ctx, reverse.To)
+	if err != nil {
+		return err
+	}
+	logger.Info("Restoring the cni in all the nodes", "cni", reverse.To)
+	return m.release(ctx, reverse, nodes)
+}
+
+// migrateNodes migrates, in order, the nodes not running the target CNI yet. Before each node, the nodes that joined
+// the cluster are labeled with the target CNI and the nodes that left it are skipped
+func (m *Migrator) migrateNodes(ctx context.Context, plan *Plan, nodes []corev1.Node) error {
+	for _, node := range nodes {
+		if node.Labels[NodeLabel] == string(plan.To) {
+			logger.V(3).Info("Node already migrated, skipping", "node", node.Name)
+			continue
+		}
+		current, err := m.labelJoinedNodes(ctx, plan.To)
+		if err != nil {
+			return err
+		}
+		if !hasNode(current, node.Name) {
+			logger.V(3).Info("Node left the cluster, skipping", "node", node.Name)
+			continue
+		}
+		logger.Info("Migrating node", "node", node.Name, "cni", plan.To)
+		if err := m.migrateNode(ctx, plan, node.Name); err != nil {
+			return fmt.Errorf("migrating node %s: %v", node.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// prepare labels the nodes that aren't being migrated yet with the source CNI and restricts the DaemonSets of
+// both CNIs to their nodes. It returns the nodes in the order they are migrated
+func (m *Migrator) prepare(ctx context.Context, plan *Plan) ([]corev1.Node, error) {
+	nodes, err := m.labelJoinedNodes(ctx, plan.From)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.restrictDaemonSets(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	sortNodes(nodes)
+	return nodes, nil
+}
+
+// labelJoinedNodes labels the nodes without the CNI label with cni and returns all the nodes. Before the DaemonSets are
+// restricted, those are the nodes of the cluster. After that, they are the nodes that joined the cluster since, which run
+// no CNI until they are labeled. They have no pods to move, so they don't need to be drained
+func (m *Migrator) labelJoinedNodes(ctx context.Context, cni v1alpha1.CNI) ([]corev1.Node, error) {
+	nodes, err := m.client.GetNodes(ctx, executables.WithCluster(m.workloadCluster))
+	if err != nil {
+		return nil, err
+	}
+	for i, node := range nodes {
+		if _, ok := node.Labels[NodeLabel]; ok {
+			continue
+		}
+		logger.V(3).Info("Labeling node with the cni", "node", node.Name, "cni", cni)
+		if err := m.client.LabelNode(ctx, node.Name, NodeLabel, string(cni), executables.WithCluster(m.workloadCluster)); err != nil {
+			return nil, err
+		}
+		if nodes[i].Labels == nil {
+			nodes[i].Labels = map[string]string{}
+		}
+		nodes[i].Labels[NodeLabel] = string(cni)
+	}
+
+	return nodes, nil
+}
+
+// restrictDaemonSets restricts the DaemonSets of both CNIs to the nodes labeled with them
+func (m *Migrator) restrictDaemonSets(ctx context.Context, plan *Plan) error {
+	for cni, manifest := range map[v1alpha1.CNI][]byte{plan.From: plan.SourceManifest, plan.To: plan.TargetManifest} {
+		restricted, err := withNodeSelector(manifest, NodeLabel, string(cni))
+		if err != nil {
+			return err
+		}
+		if err := m.client.ApplyKubeSpecFromBytes(ctx, m.workloadCluster, restricted); err != nil {
+			return fmt.Errorf("applying %s manifest restricted to its nodes: %v", cni, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) migrateNode(ctx context.Context, plan *Plan, node string) error {
+	if err := m.client.CordonNode(ctx, node, executables.WithCluster(m.workloadCluster)); err != nil {
+		return err
+	}
+	if err := m.client.DrainNodeInCluster(ctx, m.workloadCluster, node, m.drainTimeout.String()); err != nil {
+		return err
+	}
+	if err := m.client.LabelNode(ctx, node, NodeLabel, string(plan.To), executables.WithCluster(m.workloadCluster)); err != nil {
+		return err
+	}
+	if err := m.waitForCNI(ctx, plan, node); err != nil {
+		return err
+	}
+	if err := m.restartPods(ctx, plan, node); err != nil {
+		return err
+	}
+
+	return m.client.UncordonNode(ctx, node, executables.WithCluster(m.workloadCluster))
+}
+
+// waitForCNI waits until the source CNI pods are gone from the node and the target CNI ones are ready
+func (m *Migrator) waitForCNI(ctx context.Context, plan *Plan, node string) error {
+	source, err := daemonSets(plan.SourceManifest)
+	if err != nil {
+		return err
+	}
+	target, err := daemonSets(plan.TargetManifest)
+	if err != nil {
+		return err
+	}
+
+	timeout := retrier.Scale(m.nodeTimeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		pods, err := m.nodePods(ctx, node)
+		if err != nil {
+			return err
+		}
+		pending := ""
+		ready := 0
+		for _, pod := range pods {
+			owner := daemonSetOwner(pod)
+			switch {
+			case source[owner]:
+				pending = fmt.Sprintf("%s pod %s is still running", plan.From, pod.Name)
+			case target[owner] && podReady(pod):
+				ready++
+			case target[owner]:
+				pending = fmt.Sprintf("%s pod %s is not ready", plan.To, pod.Name)
+			}
+		}
+		if pending == "" && ready == 0 {
+			pending = fmt.Sprintf("no %s pod is running yet", plan.To)
+		}
+		if pending == "" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for cni %s in the node: %s", timeout, plan.To, pending)
+		}
+		logger.V(4).Info("Waiting for the node cni", "node", node, "status", pending)
+
+		if err := retrier.Sleep(ctx, m.pollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// restartPods deletes the pods left in the node after the drain, like the ones of other DaemonSets,
+// so they are recreated with a network from the new CNI. Pods in the host network don't depend on the CNI
+func (m *Migrator) restartPods(ctx context.Context, plan *Plan, node string) error {
+	target, err := daemonSets(plan.TargetManifest)
+	if err != nil {
+		return err
+	}
+	pods, err := m.nodePods(ctx, node)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if pod.Spec.HostNetwork || target[daemonSetOwner(pod)] || isMirrorPod(pod) {
+			continue
+		}
+		logger.V(3).Info("Restarting pod in the new cni", "pod", pod.Name, "namespace", pod.Namespace)
+		if err := m.client.DeletePod(ctx, pod.Name, pod.Namespace, executables.WithCluster(m.workloadCluster)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// release removes the source CNI and lifts the node restriction of the target CNI
+func (m *Migrator) release(ctx context.Context, plan *Plan, nodes []corev1.Node) error {
+	if err := m.client.DeleteKubeSpecFromBytes(ctx, m.workloadCluster, plan.SourceManifest); err != nil {
+		return fmt.Errorf("deleting %s: %v", plan.From, err)
+	}
+	if err := m.client.ApplyKubeSpecFromBytes(ctx, m.workloadCluster, plan.TargetManifest); err != nil {
+		return fmt.Errorf("applying %s manifest to all the nodes: %v", plan.To, err)
+	}
+	for _, node := range nodes {
+		if err := m.client.RemoveNodeLabel(ctx, node.Name, NodeLabel, executables.WithCluster(m.workloadCluster)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordCNI sets the CNI of the plan in the cluster spec, with its customCNI for the custom CNI. The CNI is immutable
+// for the webhook unless the cluster reconciliation is paused, so it's paused while the spec is updated
+func (m *Migrator) recordCNI(ctx context.Context, plan *Plan) error {
+	cni := plan.To
+	cluster, err := m.client.GetEksaCluster(ctx, m.managementCluster, m.workloadCluster.Name)
+	if err != nil {
+		return err
+	}
+
+	opts := []executables.KubectlOpt{executables.WithCluster(m.managementCluster), executables.WithNamespace(cluster.Namespace)}
+	wasPaused := cluster.IsReconcilePaused()
+	if !wasPaused {
+		annotations := map[string]string{cluster.PausedAnnotation(): "true"}
+		if err := m.client.UpdateAnnotation(ctx, cluster.ResourceType(), cluster.Name, annotations, append(opts, executables.WithOverwrite())...); err != nil {
+			return err
+		}
+		cluster.PauseReconcile()
+	}
+
+	cluster.Spec.ClusterNetwork.CNI = cni
+	cluster.Spec.ClusterNetwork.CustomCNI = nil
+	if cni == v1alpha1.Custom {
+		cluster.Spec.ClusterNetwork.CustomCNI = plan.CustomCNI
+	}
+	cluster.TypeMeta.APIVersion = v1alpha1.GroupVersion.String()
+	cluster.TypeMeta.Kind = v1alpha1.ClusterKind
+	cluster.ManagedFields = nil
+	cluster.ResourceVersion = ""
+	cluster.Status = v1alpha1.ClusterStatus{}
+	content, err := clusterManifest(cluster)
+	if err != nil {
+		return err
+	}
+	if err := m.client.ApplyKubeSpecFromBytes(ctx, m.managementCluster, content); err != nil {
+		return fmt.Errorf("recording cni %s in the cluster spec: %v", cni, err)
+	}
+
+	if !wasPaused {
+		return m.client.RemoveAnnotation(ctx, cluster.ResourceType(), cluster.Name, cluster.PausedAnnotation(), opts...)
+	}
+	return nil
+}
+
+func (m *Migrator) nodePods(ctx context.Context, node string) ([]corev1.Pod, error) {
+	pods, err := m.client.GetPods(ctx, executables.WithCluster(m.workloadCluster), executables.WithAllNamespaces())
+	if err != nil {
+		return nil, err
+	}
+	nodePods := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Spec.NodeName == node && pod.DeletionTimestamp == nil {
+			nodePods = append(nodePods, pod)
+		}
+	}
+	return nodePods, nil
+}
+
+func daemonSetOwner(pod corev1.Pod) string {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return daemonSetKey(pod.Namespace, owner.Name)
+		}
+	}
+	return ""
+}
+
+func isMirrorPod(pod corev1.Pod) bool {
+	_, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]
+	return ok
+}
+
+func podReady(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func nodeReady(node corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// sortNodes sorts the nodes in the order they are migrated: workers first, then control plane nodes
+func sortNodes(nodes []corev1.Node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if isControlPlane(nodes[i]) != isControlPlane(nodes[j]) {
+			return !isControlPlane(nodes[i])
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+func hasNode(nodes []corev1.Node, name string) bool {
+	for _, node := range nodes {
+		if node.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isControlPlane(node corev1.Node) bool {
+	_, cp := node.Labels[controlPlaneNodeLabel]
+	_, master := node.Labels[masterNodeLabel]
+	return cp || master
+}
@@ -0,0 +1,333 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networking/migration"
+	"github.com/aws/eks-anywhere/pkg/networking/migration/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var (
+	ciliumManifest = []byte(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cilium
+  namespace: kube-system
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: cilium
+  namespace: kube-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: cilium-agent
+`)
+	customManifest = []byte(`apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: calico-node
+  namespace: calico-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: calico-node
+`)
+)
+
+type migratorTest struct {
+	*WithT
+	ctx     context.Context
+	client  *mocks.MockKubectlClient
+	cluster *types.Cluster
+	eksa    *v1alpha1.Cluster
+	plan    *migration.Plan
+}
+
+func newMigratorTest(t *testing.T) *migratorTest {
+	ctrl := gomock.NewController(t)
+	plan, err := migration.NewPlan(v1alpha1.Cilium, v1alpha1.Custom, ciliumManifest, customManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan.CustomCNI = &v1alpha1.CustomCNIConfig{Manifest: "calico.yaml"}
+	return &migratorTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		client:  mocks.NewMockKubectlClient(ctrl),
+		cluster: &types.Cluster{Name: "w01", KubeconfigFile: "w01.kubeconfig"},
+		eksa: &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default", ResourceVersion: "10"},
+			Spec: v1alpha1.ClusterSpec{
+				ClusterNetwork: v1alpha1.ClusterNetwork{CNI: v1alpha1.Cilium},
+			},
+		},
+		plan: plan,
+	}
+}
+
+func (tt *migratorTest) migrator() *migration.Migrator {
+	return migration.NewMigrator(tt.client, tt.cluster, tt.cluster, migration.WithPollInterval(time.Millisecond), migration.WithNodeTimeout(50*time.Millisecond))
+}
+
+func node(name string, labels map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func pod(name, namespace, node, daemonSet string, ready bool) corev1.Pod {
+	p := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.PodSpec{NodeName: node},
+	}
+	if daemonSet != "" {
+		p.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: daemonSet}}
+	}
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+	return p
+}
+
+func (tt *migratorTest) expectNodeMigration(name string) {
+	gomock.InOrder(
+		tt.client.EXPECT().CordonNode(tt.ctx, name, gomock.Any()),
+		tt.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, name, "10m0s"),
+		tt.client.EXPECT().LabelNode(tt.ctx, name, migration.NodeLabel, "custom", gomock.Any()),
+		tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return([]corev1.Pod{
+			pod("calico-node-"+name, "calico-system", name, "calico-node", true),
+		}, nil).Times(2),
+		tt.client.EXPECT().UncordonNode(tt.ctx, name, gomock.Any()),
+	)
+}
+
+func TestNewPlan(t *testing.T) {
+	g := NewWithT(t)
+	plan, err := migration.NewPlan(v1alpha1.Custom, v1alpha1.Cilium, ciliumManifest, customManifest)
+	g.Expect(err).To(Succeed())
+	g.Expect(plan.SourceManifest).To(Equal(customManifest))
+	g.Expect(plan.TargetManifest).To(Equal(ciliumManifest))
+}
+
+func TestNewPlanUnsupported(t *testing.T) {
+	g := NewWithT(t)
+	_, err := migration.NewPlan(v1alpha1.Kindnetd, v1alpha1.Custom, ciliumManifest, customManifest)
+	g.Expect(err).To(MatchError(ContainSubstring("migrating cni from kindnetd to custom is not supported")))
+
+	_, err = migration.NewPlan(v1alpha1.Cilium, v1alpha1.Cilium, ciliumManifest, customManifest)
+	g.Expect(err).To(MatchError("cluster already runs cni cilium"))
+}
+
+func TestMigratorMigrate(t *testing.T) {
+	tt := newMigratorTest(t)
+	cp := node("cp", map[string]string{"node-role.kubernetes.io/control-plane": ""})
+	md := node("md-0", nil)
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{cp, md}, nil).Times(2)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{
+		node("cp", map[string]string{"node-role.kubernetes.io/control-plane": "", migration.NodeLabel: "cilium"}),
+		node("md-0", map[string]string{migration.NodeLabel: "cilium"}),
+	}, nil).Times(3)
+	tt.client.EXPECT().LabelNode(tt.ctx, "cp", migration.NodeLabel, "cilium", gomock.Any())
+	tt.client.EXPECT().LabelNode(tt.ctx, "md-0", migration.NodeLabel, "cilium", gomock.Any())
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(2)
+
+	gomock.InOrder(
+		tt.client.EXPECT().CordonNode(tt.ctx, "md-0", gomock.Any()),
+		tt.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "md-0", "10m0s"),
+		tt.client.EXPECT().LabelNode(tt.ctx, "md-0", migration.NodeLabel, "custom", gomock.Any()),
+		tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return([]corev1.Pod{
+			pod("cilium-md", "kube-system", "md-0", "cilium", true),
+		}, nil),
+		tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return([]corev1.Pod{
+			pod("calico-node-md", "calico-system", "md-0", "calico-node", true),
+			pod("metrics-agent", "monitoring", "md-0", "metrics-agent", true),
+			pod("web", "apps", "cp", "", true),
+		}, nil).Times(2),
+		tt.client.EXPECT().DeletePod(tt.ctx, "metrics-agent", "monitoring", gomock.Any()),
+		tt.client.EXPECT().UncordonNode(tt.ctx, "md-0", gomock.Any()),
+		tt.client.EXPECT().CordonNode(tt.ctx, "cp", gomock.Any()),
+	)
+	tt.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "cp", "10m0s")
+	tt.client.EXPECT().LabelNode(tt.ctx, "cp", migration.NodeLabel, "custom", gomock.Any())
+	tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return([]corev1.Pod{
+		pod("calico-node-cp", "calico-system", "cp", "calico-node", true),
+	}, nil).Times(2)
+	tt.client.EXPECT().UncordonNode(tt.ctx, "cp", gomock.Any())
+
+	tt.client.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, ciliumManifest)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, customManifest)
+	tt.client.EXPECT().RemoveNodeLabel(tt.ctx, "cp", migration.NodeLabel, gomock.Any())
+	tt.client.EXPECT().RemoveNodeLabel(tt.ctx, "md-0", migration.NodeLabel, gomock.Any())
+
+	recorded := tt.eksa.DeepCopy()
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(recorded, nil)
+	tt.client.EXPECT().UpdateAnnotation(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01", map[string]string{recorded.PausedAnnotation(): "true"}, gomock.Any())
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, content []byte) error {
+			tt.Expect(string(content)).To(ContainSubstring("cni: custom"))
+			tt.Expect(string(content)).To(ContainSubstring("manifest: calico.yaml"))
+			tt.Expect(string(content)).NotTo(ContainSubstring("resourceVersion"))
+			return nil
+		},
+	)
+	tt.client.EXPECT().RemoveAnnotation(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01", recorded.PausedAnnotation(), gomock.Any())
+
+	tt.Expect(tt.migrator().Migrate(tt.ctx, tt.plan)).To(Succeed())
+}
+
+func TestMigratorMigrateResumesSkippingMigratedNodes(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.eksa.PauseReconcile()
+	nodes := []corev1.Node{
+		node("md-0", map[string]string{migration.NodeLabel: "custom"}),
+		node("md-1", map[string]string{migration.NodeLabel: "cilium"}),
+	}
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil).Times(2)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return(nodes, nil).Times(4)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(4)
+	tt.expectNodeMigration("md-1")
+	tt.client.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, ciliumManifest)
+	tt.client.EXPECT().RemoveNodeLabel(tt.ctx, gomock.Any(), migration.NodeLabel, gomock.Any()).Times(2)
+
+	tt.Expect(tt.migrator().Migrate(tt.ctx, tt.plan)).To(Succeed())
+}
+
+func TestMigratorPreCheckWrongCNI(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.eksa.Spec.ClusterNetwork.CNI = v1alpha1.Custom
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+
+	tt.Expect(tt.migrator().PreCheck(tt.ctx, tt.plan)).To(MatchError("cluster w01 runs cni custom, not cilium"))
+}
+
+func TestMigratorPreCheckNoDaemonSet(t *testing.T) {
+	tt := newMigratorTest(t)
+	plan, err := migration.NewPlan(v1alpha1.Cilium, v1alpha1.Custom, ciliumManifest, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\n"))
+	tt.Expect(err).To(Succeed())
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+
+	tt.Expect(tt.migrator().PreCheck(tt.ctx, plan)).To(MatchError("custom manifest has no DaemonSet, it can't be migrated node by node"))
+}
+
+func TestMigratorPreCheckNodeNotReady(t *testing.T) {
+	tt := newMigratorTest(t)
+	notReady := node("md-0", nil)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{notReady}, nil)
+
+	tt.Expect(tt.migrator().PreCheck(tt.ctx, tt.plan)).To(MatchError("node md-0 is not ready"))
+}
+
+func TestMigratorMigrateNodeTimeout(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{node("md-0", map[string]string{migration.NodeLabel: "cilium"})}, nil).Times(3)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(2)
+	tt.client.EXPECT().CordonNode(tt.ctx, "md-0", gomock.Any())
+	tt.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "md-0", "10m0s")
+	tt.client.EXPECT().LabelNode(tt.ctx, "md-0", migration.NodeLabel, "custom", gomock.Any())
+	tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return([]corev1.Pod{
+		pod("calico-node-md", "calico-system", "md-0", "calico-node", false),
+	}, nil).MinTimes(1)
+
+	err := tt.migrator().Migrate(tt.ctx, tt.plan)
+	tt.Expect(err).To(MatchError(ContainSubstring("migrating node md-0: timed out")))
+	tt.Expect(err).To(MatchError(ContainSubstring("rerun the migration to resume it or roll it back")))
+	tt.Expect(err).To(MatchError(ContainSubstring("custom pod calico-node-md is not ready")))
+}
+
+func TestMigratorMigrateDrainError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{node("md-0", nil)}, nil).Times(2)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{node("md-0", map[string]string{migration.NodeLabel: "cilium"})}, nil)
+	tt.client.EXPECT().LabelNode(tt.ctx, "md-0", migration.NodeLabel, "cilium", gomock.Any())
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(2)
+	tt.client.EXPECT().CordonNode(tt.ctx, "md-0", gomock.Any())
+	tt.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "md-0", "10m0s").Return(errors.New("error draining"))
+
+	tt.Expect(tt.migrator().Migrate(tt.ctx, tt.plan)).To(MatchError("migrating node md-0: error draining, rerun the migration to resume it or roll it back"))
+}
+
+func TestMigratorMigrateLabelsJoinedNodes(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.eksa.PauseReconcile()
+	md0 := node("md-0", map[string]string{migration.NodeLabel: "cilium"})
+	md1 := node("md-1", map[string]string{migration.NodeLabel: "cilium"})
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil).Times(2)
+	gomock.InOrder(
+		tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{md0, md1}, nil).Times(3),
+		// md-2 replaces md-1 while md-0 is migrated
+		tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{
+			node("md-0", map[string]string{migration.NodeLabel: "custom"}),
+			node("md-2", nil),
+		}, nil),
+		tt.client.EXPECT().LabelNode(tt.ctx, "md-2", migration.NodeLabel, "custom", gomock.Any()),
+		tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return([]corev1.Node{
+			node("md-0", map[string]string{migration.NodeLabel: "custom"}),
+			node("md-2", map[string]string{migration.NodeLabel: "custom"}),
+		}, nil),
+	)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(4)
+	tt.expectNodeMigration("md-0")
+	tt.client.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, ciliumManifest)
+	tt.client.EXPECT().RemoveNodeLabel(tt.ctx, "md-0", migration.NodeLabel, gomock.Any())
+	tt.client.EXPECT().RemoveNodeLabel(tt.ctx, "md-2", migration.NodeLabel, gomock.Any())
+
+	tt.Expect(tt.migrator().Migrate(tt.ctx, tt.plan)).To(Succeed())
+}
+
+func TestMigratorRollback(t *testing.T) {
+	tt := newMigratorTest(t)
+	nodes := []corev1.Node{
+		node("md-0", map[string]string{migration.NodeLabel: "custom"}),
+		node("md-1", map[string]string{migration.NodeLabel: "cilium"}),
+	}
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+	tt.client.EXPECT().GetNodes(tt.ctx, gomock.Any()).Return(nodes, nil).Times(3)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(2)
+	gomock.InOrder(
+		tt.client.EXPECT().CordonNode(tt.ctx, "md-0", gomock.Any()),
+		tt.client.EXPECT().DrainNodeInCluster(tt.ctx, tt.cluster, "md-0", "10m0s"),
+		tt.client.EXPECT().LabelNode(tt.ctx, "md-0", migration.NodeLabel, "cilium", gomock.Any()),
+		tt.client.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any()).Return([]corev1.Pod{
+			pod("cilium-md", "kube-system", "md-0", "cilium", true),
+		}, nil).Times(2),
+		tt.client.EXPECT().UncordonNode(tt.ctx, "md-0", gomock.Any()),
+	)
+	tt.client.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, customManifest)
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, ciliumManifest)
+	tt.client.EXPECT().RemoveNodeLabel(tt.ctx, "md-0", migration.NodeLabel, gomock.Any())
+	tt.client.EXPECT().RemoveNodeLabel(tt.ctx, "md-1", migration.NodeLabel, gomock.Any())
+
+	tt.Expect(tt.migrator().Rollback(tt.ctx, tt.plan)).To(Succeed())
+}
+
+func TestMigratorRollbackAfterHandoff(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.eksa.Spec.ClusterNetwork.CNI = v1alpha1.Custom
+	tt.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "w01").Return(tt.eksa, nil)
+
+	tt.Expect(tt.migrator().Rollback(tt.ctx, tt.plan)).To(MatchError("cluster w01 already runs cni custom, migrate it back to cilium instead"))
+}
@@ -0,0 +1,269 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/networking/migration/migration.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytes mocks base method.
+func (m *MockKubectlClient) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytes", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytes indicates an expected call of ApplyKubeSpecFromBytes.
+func (mr *MockKubectlClientMockRecorder) ApplyKubeSpecFromBytes(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockKubectlClient)(nil).ApplyKubeSpecFromBytes), ctx, cluster, data)
+}
+
+// CordonNode mocks base method.
+func (m *MockKubectlClient) CordonNode(ctx context.Context, node string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, node}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CordonNode", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CordonNode indicates an expected call of CordonNode.
+func (mr *MockKubectlClientMockRecorder) CordonNode(ctx, node interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, node}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CordonNode", reflect.TypeOf((*MockKubectlClient)(nil).CordonNode), varargs...)
+}
+
+// DeleteKubeSpecFromBytes mocks base method.
+func (m *MockKubectlClient) DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKubeSpecFromBytes", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKubeSpecFromBytes indicates an expected call of DeleteKubeSpecFromBytes.
+func (mr *MockKubectlClientMockRecorder) DeleteKubeSpecFromBytes(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKubeSpecFromBytes", reflect.TypeOf((*MockKubectlClient)(nil).DeleteKubeSpecFromBytes), ctx, cluster, data)
+}
+
+// DeletePod mocks base method.
+func (m *MockKubectlClient) DeletePod(ctx context.Context, name, namespace string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, name, namespace}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeletePod", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePod indicates an expected call of DeletePod.
+func (mr *MockKubectlClientMockRecorder) DeletePod(ctx, name, namespace interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, name, namespace}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePod", reflect.TypeOf((*MockKubectlClient)(nil).DeletePod), varargs...)
+}
+
+// DrainNodeInCluster mocks base method.
+func (m *MockKubectlClient) DrainNodeInCluster(ctx context.Context, cluster *types.Cluster, node, timeout string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainNodeInCluster", ctx, cluster, node, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DrainNodeInCluster indicates an expected call of DrainNodeInCluster.
+func (mr *MockKubectlClientMockRecorder) DrainNodeInCluster(ctx, cluster, node, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainNodeInCluster", reflect.TypeOf((*MockKubectlClient)(nil).DrainNodeInCluster), ctx, cluster, node, timeout)
+}
+
+// GetEksaCluster mocks base method.
+func (m *MockKubectlClient) GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEksaCluster", ctx, cluster, clusterName)
+	ret0, _ := ret[0].(*v1alpha1.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEksaCluster indicates an expected call of GetEksaCluster.
+func (mr *MockKubectlClientMockRecorder) GetEksaCluster(ctx, cluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaCluster", reflect.TypeOf((*MockKubectlClient)(nil).GetEksaCluster), ctx, cluster, clusterName)
+}
+
+// GetNodes mocks base method.
+func (m *MockKubectlClient) GetNodes(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.Node, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetNodes", varargs...)
+	ret0, _ := ret[0].([]v1.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodes indicates an expected call of GetNodes.
+func (mr *MockKubectlClientMockRecorder) GetNodes(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockKubectlClient)(nil).GetNodes), varargs...)
+}
+
+// GetPods mocks base method.
+func (m *MockKubectlClient) GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.Pod, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPods", varargs...)
+	ret0, _ := ret[0].([]v1.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPods indicates an expected call of GetPods.
+func (mr *MockKubectlClientMockRecorder) GetPods(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPods", reflect.TypeOf((*MockKubectlClient)(nil).GetPods), varargs...)
+}
+
+// LabelNode mocks base method.
+func (m *MockKubectlClient) LabelNode(ctx context.Context, node, key, value string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, node, key, value}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LabelNode", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LabelNode indicates an expected call of LabelNode.
+func (mr *MockKubectlClientMockRecorder) LabelNode(ctx, node, key, value interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, node, key, value}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LabelNode", reflect.TypeOf((*MockKubectlClient)(nil).LabelNode), varargs...)
+}
+
+// RemoveAnnotation mocks base method.
+func (m *MockKubectlClient) RemoveAnnotation(ctx context.Context, resourceType, objectName, key string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, resourceType, objectName, key}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveAnnotation", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAnnotation indicates an expected call of RemoveAnnotation.
+func (mr *MockKubectlClientMockRecorder) RemoveAnnotation(ctx, resourceType, objectName, key interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, resourceType, objectName, key}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAnnotation", reflect.TypeOf((*MockKubectlClient)(nil).RemoveAnnotation), varargs...)
+}
+
+// RemoveNodeLabel mocks base method.
+func (m *MockKubectlClient) RemoveNodeLabel(ctx context.Context, node, key string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, node, key}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveNodeLabel", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveNodeLabel indicates an expected call of RemoveNodeLabel.
+func (mr *MockKubectlClientMockRecorder) RemoveNodeLabel(ctx, node, key interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, node, key}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNodeLabel", reflect.TypeOf((*MockKubectlClient)(nil).RemoveNodeLabel), varargs...)
+}
+
+// UncordonNode mocks base method.
+func (m *MockKubectlClient) UncordonNode(ctx context.Context, node string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, node}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UncordonNode", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UncordonNode indicates an expected call of UncordonNode.
+func (mr *MockKubectlClientMockRecorder) UncordonNode(ctx, node interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, node}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UncordonNode", reflect.TypeOf((*MockKubectlClient)(nil).UncordonNode), varargs...)
+}
+
+// UpdateAnnotation mocks base method.
+func (m *MockKubectlClient) UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, resourceType, objectName, annotations}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateAnnotation", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotation indicates an expected call of UpdateAnnotation.
+func (mr *MockKubectlClientMockRecorder) UpdateAnnotation(ctx, resourceType, objectName, annotations interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, resourceType, objectName, annotations}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotation", reflect.TypeOf((*MockKubectlClient)(nil).UpdateAnnotation), varargs...)
+}
@@ -7,6 +7,7 @@ import (
 
 type NetClient interface {
 	DialTimeout(network, address string, timeout time.Duration) (net.Conn, error)
+	LookupHost(host string) ([]string, error)
 }
 
 type DefaultNetClient struct{}
@@ -14,3 +15,7 @@ type DefaultNetClient struct{}
 func (n *DefaultNetClient) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
 	return net.DialTimeout(network, address, timeout)
 }
+
+func (n *DefaultNetClient) LookupHost(host string) ([]string, error) {
+	return net.LookupHost(host)
+}
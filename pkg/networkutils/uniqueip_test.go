@@ -19,6 +19,10 @@ func (n *DummyNetClient) DialTimeout(network, address string, timeout time.Durat
 	return nil, errors.New("")
 }
 
+func (n *DummyNetClient) LookupHost(host string) ([]string, error) {
+	return nil, errors.New("no such host")
+}
+
 func TestGenerateUniqueIP(t *testing.T) {
 	cidrBlock := "1.2.3.4/16"
 
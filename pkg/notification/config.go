@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// LoadConfig reads a local notifications config file, with the same fields as the cluster spec notifications.
+// It keeps secrets like the Slack webhook URL out of the cluster spec
+func LoadConfig(path string) (*v1alpha1.NotificationsConfiguration, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading notifications config: %v", err)
+	}
+
+	config := &v1alpha1.NotificationsConfiguration{}
+	if err = yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("failed parsing notifications config %s: %v", path, err)
+	}
+	if err = v1alpha1.ValidateNotifications(config); err != nil {
+		return nil, fmt.Errorf("invalid notifications config %s: %v", path, err)
+	}
+	return config, nil
+}
+
+// ForCluster builds the notifiers of the cluster spec and the local config file, if any
+func ForCluster(cluster *v1alpha1.Cluster, configFile string) (Notifiers, error) {
+	notifiers, err := New(cluster.Spec.Notifications)
+	if err != nil {
+		return nil, err
+	}
+	if configFile == "" {
+		return notifiers, nil
+	}
+
+	config, err := LoadConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+	local, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	return append(notifiers, local...), nil
+}
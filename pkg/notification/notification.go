@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+type EventType string
+
+const (
+	OperationStarted   EventType = "OperationStarted"
+	OperationSucceeded EventType = "OperationSucceeded"
+	OperationFailed    EventType = "OperationFailed"
+	ClusterHealthy     EventType = "ClusterHealthy"
+	ClusterUnhealthy   EventType = "ClusterUnhealthy"
+)
+
+// Event is a lifecycle event of a cluster: a CLI operation starting or finishing, or the controller
+// detecting a change in the cluster health
+type Event struct {
+	Type      EventType `json:"type"`
+	Cluster   string    `json:"cluster"`
+	Operation string    `json:"operation,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Summary is a one line description of the event for the notifiers that send text
+func (e Event) Summary() string {
+	var s string
+	switch e.Type {
+	case OperationStarted:
+		s = fmt.Sprintf("EKS Anywhere %s of cluster %s started", e.Operation, e.Cluster)
+	case OperationSucceeded:
+		s = fmt.Sprintf("EKS Anywhere %s of cluster %s succeeded", e.Operation, e.Cluster)
+	case OperationFailed:
+		s = fmt.Sprintf("EKS Anywhere %s of cluster %s failed", e.Operation, e.Cluster)
+	case ClusterHealthy:
+		s = fmt.Sprintf("EKS Anywhere cluster %s is healthy", e.Cluster)
+	case ClusterUnhealthy:
+		s = fmt.Sprintf("EKS Anywhere cluster %s is unhealthy", e.Cluster)
+	default:
+		s = fmt.Sprintf("EKS Anywhere cluster %s: %s", e.Cluster, e.Type)
+	}
+	if e.Message != "" {
+		s = fmt.Sprintf("%s: %s", s, e.Message)
+	}
+	return s
+}
+
+// Notifier sends events to an external system
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Notifiers sends each event to all the notifiers, even if some of them fail
+type Notifiers []Notifier
+
+func (n Notifiers) Notify(ctx context.Context, event Event) error {
+	var errs []error
+	for _, notifier := range n {
+		if err := notifier.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// New builds the notifiers for the config, which can be nil
+func New(config *v1alpha1.NotificationsConfiguration) (Notifiers, error) {
+	if config == nil {
+		return nil, nil
+	}
+	if err := v1alpha1.ValidateNotifications(config); err != nil {
+		return nil, err
+	}
+
+	var notifiers Notifiers
+	if config.Slack != nil {
+		notifiers = append(notifiers, NewSlack(config.Slack.WebhookURL))
+	}
+	for _, w := range config.Webhooks {
+		notifiers = append(notifiers, NewWebhook(w.URL))
+	}
+	if config.SNS != nil {
+		sns, err := NewSNS(config.SNS.TopicARN)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, sns)
+	}
+	return notifiers, nil
+}
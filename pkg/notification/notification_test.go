@@ -0,0 +1,158 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/notification"
+)
+
+var event = notification.Event{
+	Type:      notification.OperationFailed,
+	Cluster:   "test-cluster",
+	Operation: "upgrade",
+	Message:   "waiting for control plane to be ready",
+	Time:      time.Date(2022, time.March, 4, 12, 0, 0, 0, time.UTC),
+}
+
+type recorder struct {
+	bodies []string
+	status int
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	r.bodies = append(r.bodies, string(body))
+	if r.status != 0 {
+		w.WriteHeader(r.status)
+	}
+}
+
+func newServer(t *testing.T, r *recorder) *httptest.Server {
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEventSummary(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(event.Summary()).To(Equal("EKS Anywhere upgrade of cluster test-cluster failed: waiting for control plane to be ready"))
+	g.Expect(notification.Event{Type: notification.ClusterHealthy, Cluster: "test-cluster"}.Summary()).To(Equal("EKS Anywhere cluster test-cluster is healthy"))
+}
+
+func TestSlackNotify(t *testing.T) {
+	g := NewWithT(t)
+	r := &recorder{}
+	server := newServer(t, r)
+
+	g.Expect(notification.NewSlack(server.URL).Notify(context.Background(), event)).To(Succeed())
+	g.Expect(r.bodies).To(Equal([]string{`{"text":"EKS Anywhere upgrade of cluster test-cluster failed: waiting for control plane to be ready"}`}))
+}
+
+func TestWebhookNotify(t *testing.T) {
+	g := NewWithT(t)
+	r := &recorder{}
+	server := newServer(t, r)
+
+	g.Expect(notification.NewWebhook(server.URL).Notify(context.Background(), event)).To(Succeed())
+	g.Expect(r.bodies).To(HaveLen(1))
+	got := notification.Event{}
+	g.Expect(json.Unmarshal([]byte(r.bodies[0]), &got)).To(Succeed())
+	g.Expect(got).To(Equal(event))
+}
+
+func TestWebhookNotifyErrorStatus(t *testing.T) {
+	g := NewWithT(t)
+	r := &recorder{status: http.StatusForbidden}
+	server := newServer(t, r)
+
+	err := notification.NewWebhook(server.URL).Notify(context.Background(), event)
+	g.Expect(err).To(MatchError("failed sending event to webhook: 403 Forbidden: "))
+}
+
+type fakeSNS struct {
+	snsiface.SNSAPI
+	inputs []*sns.PublishInput
+	err    error
+}
+
+func (f *fakeSNS) PublishWithContext(_ aws.Context, input *sns.PublishInput, _ ...request.Option) (*sns.PublishOutput, error) {
+	f.inputs = append(f.inputs, input)
+	return &sns.PublishOutput{}, f.err
+}
+
+func TestSNSNotify(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeSNS{}
+	topic := "arn:aws:sns:us-west-2:123456789012:eksa"
+
+	g.Expect(notification.NewSNSWithClient(topic, client).Notify(context.Background(), event)).To(Succeed())
+	g.Expect(client.inputs).To(HaveLen(1))
+	g.Expect(aws.StringValue(client.inputs[0].TopicArn)).To(Equal(topic))
+	g.Expect(aws.StringValue(client.inputs[0].Subject)).To(Equal(event.Summary()))
+	g.Expect(aws.StringValue(client.inputs[0].Message)).To(ContainSubstring(`"type":"OperationFailed"`))
+}
+
+func TestSNSNotifyError(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeSNS{err: errors.New("access denied")}
+
+	err := notification.NewSNSWithClient("arn:aws:sns:us-west-2:123456789012:eksa", client).Notify(context.Background(), event)
+	g.Expect(err).To(MatchError("failed publishing event to sns topic arn:aws:sns:us-west-2:123456789012:eksa: access denied"))
+}
+
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(context.Context, notification.Event) error {
+	return errors.New("unreachable")
+}
+
+func TestNotifiersNotifyAll(t *testing.T) {
+	g := NewWithT(t)
+	r := &recorder{}
+	server := newServer(t, r)
+
+	notifiers := notification.Notifiers{failingNotifier{}, notification.NewWebhook(server.URL)}
+	g.Expect(notifiers.Notify(context.Background(), event)).To(MatchError("unreachable"))
+	g.Expect(r.bodies).To(HaveLen(1))
+}
+
+func TestForCluster(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{}
+	cluster.Spec.Notifications = &v1alpha1.NotificationsConfiguration{
+		Webhooks: []v1alpha1.WebhookNotification{{URL: "https://hooks.example.com/eksa"}},
+	}
+
+	notifiers, err := notification.ForCluster(cluster, "testdata/notifications.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(notifiers).To(HaveLen(3))
+}
+
+func TestForClusterNoConfig(t *testing.T) {
+	g := NewWithT(t)
+	notifiers, err := notification.ForCluster(&v1alpha1.Cluster{}, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(notifiers).To(BeEmpty())
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := notification.LoadConfig("testdata/notifications_invalid.yaml")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(strings.HasPrefix(err.Error(), "invalid notifications config testdata/notifications_invalid.yaml: sns notification topicARN my-topic is invalid")).To(BeTrue())
+}
@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Slack posts the events summary to a channel through an incoming webhook
+type Slack struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func NewSlack(webhookURL string, opts ...HTTPOpt) *Slack {
+	return &Slack{webhookURL: webhookURL, client: newHTTPClient(opts)}
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func (s *Slack) Notify(ctx context.Context, event Event) error {
+	if err := postJSON(ctx, s.client, s.webhookURL, slackMessage{Text: event.Summary()}); err != nil {
+		return fmt.Errorf("failed sending event to slack: %v", err)
+	}
+	return nil
+}
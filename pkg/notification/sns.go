@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// the SNS subject can't be longer than this
+const snsMaxSubject = 100
+
+// SNS publishes the events to a topic. The subject is the event summary and the message the event json,
+// so subscribers like email read the summary and the ones like lambda or SQS parse the json
+type SNS struct {
+	topicARN string
+	client   snsiface.SNSAPI
+}
+
+// NewSNS builds a notifier for the topic with the default AWS credentials chain: environment variables,
+// shared credentials file or instance role
+func NewSNS(topicARN string) (*SNS, error) {
+	region, err := v1alpha1.SNSTopicRegion(topicARN)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating aws session for sns notifications: %v", err)
+	}
+	return NewSNSWithClient(topicARN, sns.New(sess)), nil
+}
+
+func NewSNSWithClient(topicARN string, client snsiface.SNSAPI) *SNS {
+	return &SNS{topicARN: topicARN, client: client}
+}
+
+func (s *SNS) Notify(ctx context.Context, event Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed marshalling event for sns: %v", err)
+	}
+	subject := event.Summary()
+	if len(subject) > snsMaxSubject {
+		subject = subject[:snsMaxSubject-3] + "..."
+	}
+
+	_, err = s.client.PublishWithContext(ctx, &sns.PublishInput{
+		TopicArn: aws.String(s.topicARN),
+		Subject:  aws.String(subject),
+		Message:  aws.String(string(message)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed publishing event to sns topic %s: %v", s.topicARN, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const httpTimeout = 10 * time.Second
+
+// HTTPOpt configures the Slack and webhook notifiers
+type HTTPOpt func(*http.Client)
+
+// WithHTTPClient replaces the http client used by the Slack and webhook notifiers
+func WithHTTPClient(client *http.Client) HTTPOpt {
+	return func(c *http.Client) {
+		*c = *client
+	}
+}
+
+func newHTTPClient(opts []HTTPOpt) *http.Client {
+	c := &http.Client{Timeout: httpTimeout}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Webhook sends the events as json to a url
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, opts ...HTTPOpt) *Webhook {
+	return &Webhook{url: url, client: newHTTPClient(opts)}
+}
+
+func (w *Webhook) Notify(ctx context.Context, event Event) error {
+	if err := postJSON(ctx, w.client, w.url, event); err != nil {
+		return fmt.Errorf("failed sending event to webhook: %v", err)
+	}
+	return nil
+}
+
+// postJSON posts the body as json and fails if the response isn't a 2xx
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed building request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package podsecurity
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/admission-configuration.yaml
+var admissionConfigurationTemplate string
+
+// systemNamespaces run the components installed by EKS Anywhere, some of which need host access,
+// so they are always exempted to keep the cluster working with the baseline and restricted levels
+var systemNamespaces = []string{
+	constants.KubeSystemNamespace,
+	constants.EksaSystemNamespace,
+	constants.CapiSystemNamespace,
+	constants.CapiKubeadmBootstrapSystemNamespace,
+	constants.CapiKubeadmControlPlaneSystemNamespace,
+	constants.CapiWebhookSystemNamespace,
+	constants.CapvSystemNamespace,
+	constants.CapdSystemNamespace,
+	constants.CertManagerNamespace,
+	constants.EtcdAdmBootstrapProviderSystemNamespace,
+	constants.EtcdAdmControllerSystemNamespace,
+	constants.LocalPathStorageNamespace,
+}
+
+// GenerateAdmissionConfiguration returns the API server admission configuration for the PodSecurity plugin,
+// with the default levels from the cluster spec for the namespaces without pod-security.kubernetes.io labels
+func GenerateAdmissionConfiguration(config *v1alpha1.PodSecurityConfiguration) (string, error) {
+	data := map[string]interface{}{
+		"enforce":        levelOrDefault(config.Enforce),
+		"audit":          levelOrDefault(config.Audit),
+		"warn":           levelOrDefault(config.Warn),
+		"usernames":      config.Exemptions.Usernames,
+		"runtimeClasses": config.Exemptions.RuntimeClasses,
+		"namespaces":     exemptedNamespaces(config),
+	}
+
+	admissionConfiguration, err := templater.Execute(admissionConfigurationTemplate, data)
+	if err != nil {
+		return "", fmt.Errorf("error generating pod security admission configuration: %v", err)
+	}
+	return strings.TrimSuffix(string(admissionConfiguration), "\n"), nil
+}
+
+func levelOrDefault(level v1alpha1.PodSecurityLevel) v1alpha1.PodSecurityLevel {
+	if level == "" {
+		return v1alpha1.PodSecurityPrivileged
+	}
+	return level
+}
+
+func exemptedNamespaces(config *v1alpha1.PodSecurityConfiguration) []string {
+	set := map[string]struct{}{}
+	for _, n := range systemNamespaces {
+		set[n] = struct{}{}
+	}
+	for _, n := range config.Exemptions.Namespaces {
+		set[n] = struct{}{}
+	}
+	namespaces := make([]string, 0, len(set))
+	for n := range set {
+		namespaces = append(namespaces, n)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
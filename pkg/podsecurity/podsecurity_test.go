@@ -0,0 +1,34 @@
+package podsecurity_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/podsecurity"
+)
+
+func TestGenerateAdmissionConfigurationDefaults(t *testing.T) {
+	g := NewWithT(t)
+	config, err := podsecurity.GenerateAdmissionConfiguration(&v1alpha1.PodSecurityConfiguration{})
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, config, "testdata/expected_results_defaults.yaml")
+}
+
+func TestGenerateAdmissionConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	config, err := podsecurity.GenerateAdmissionConfiguration(&v1alpha1.PodSecurityConfiguration{
+		Enforce: v1alpha1.PodSecurityBaseline,
+		Audit:   v1alpha1.PodSecurityRestricted,
+		Warn:    v1alpha1.PodSecurityRestricted,
+		Exemptions: v1alpha1.PodSecurityExemptions{
+			Usernames:      []string{"system:serviceaccount:monitoring:node-exporter"},
+			RuntimeClasses: []string{"kata"},
+			Namespaces:     []string{"monitoring", "kube-system"},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, config, "testdata/expected_results.yaml")
+}
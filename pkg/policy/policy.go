@@ -0,0 +1,258 @@
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/rego"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type Severity string
+
+const (
+	// SeverityError violations stop the operation before the manifests are applied
+	SeverityError Severity = "error"
+	// SeverityWarning violations are only logged
+	SeverityWarning Severity = "warning"
+)
+
+// Policy is a rule the generated manifests must follow. Deny is the body of a rego rule evaluated
+// with each object of the Kinds as input, the object violates the policy when the rule is true
+type Policy struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Kinds       []string `json:"kinds,omitempty"`
+	Deny        string   `json:"deny"`
+	Message     string   `json:"message,omitempty"`
+	Severity    Severity `json:"severity,omitempty"`
+
+	query rego.PreparedEvalQuery
+}
+
+// Bundle is a file of policies, usually maintained by the platform team of an organization
+type Bundle struct {
+	Policies []Policy `json:"policies"`
+}
+
+// Violation is an object that doesn't follow a policy
+type Violation struct {
+	Policy    string
+	Severity  Severity
+	Kind      string
+	Name      string
+	Namespace string
+	Message   string
+}
+
+func (v Violation) String() string {
+	name := v.Name
+	if v.Namespace != "" {
+		name = v.Namespace + "/" + v.Name
+	}
+	return fmt.Sprintf("policy %s: %s %s: %s", v.Policy, v.Kind, name, v.Message)
+}
+
+// Engine evaluates the policies of a set of bundles in process, without any external service
+type Engine struct {
+	policies []Policy
+}
+
+// NewEngine compiles the policies of the bundles and checks they are valid
+func NewEngine(bundles ...Bundle) (*Engine, error) {
+	e := &Engine{}
+	names := map[string]bool{}
+	for _, b := range bundles {
+		for _, p := range b.Policies {
+			p := p
+			if p.Name == "" {
+				return nil, errors.New("policy name is required")
+			}
+			if names[p.Name] {
+				return nil, fmt.Errorf("policy %s is defined more than once", p.Name)
+			}
+			names[p.Name] = true
+
+			if p.Deny == "" {
+				return nil, fmt.Errorf("policy %s doesn't have a deny expression", p.Name)
+			}
+			query, err := compile(len(e.policies), p)
+			if err != nil {
+				return nil, fmt.Errorf("policy %s deny expression is invalid: %v", p.Name, err)
+			}
+			p.query = query
+
+			switch p.Severity {
+			case "":
+				p.Severity = SeverityError
+			case SeverityError, SeverityWarning:
+			default:
+				return nil, fmt.Errorf("policy %s severity %s is invalid, it must be %s or %s", p.Name, p.Severity, SeverityError, SeverityWarning)
+			}
+			if p.Message == "" {
+				p.Message = p.Description
+			}
+			if p.Message == "" {
+				p.Message = "denied"
+			}
+			e.policies = append(e.policies, p)
+		}
+	}
+	return e, nil
+}
+
+// compile builds a rego module with the deny rule of the policy in its own package, so the
+// rules of different policies never merge
+func compile(index int, p Policy) (rego.PreparedEvalQuery, error) {
+	pkg := fmt.Sprintf("eksa.policies.p%d", index)
+	module := fmt.Sprintf("package %s\n\ndeny {\n%s\n}\n", pkg, p.Deny)
+	return rego.New(
+		rego.Query("data."+pkg+".deny"),
+		rego.Module(p.Name+".rego", module),
+	).PrepareForEval(context.Background())
+}
+
+// Load reads the policy bundles from files or from the yaml files of directories
+func Load(paths ...string) (*Engine, error) {
+	var bundles []Bundle
+	for _, path := range paths {
+		files, err := bundleFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			content, err := ioutil.ReadFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("failed reading policy bundle: %v", err)
+			}
+			b := Bundle{}
+			if err = yaml.UnmarshalStrict(content, &b); err != nil {
+				return nil, fmt.Errorf("failed parsing policy bundle %s: %v", f, err)
+			}
+			bundles = append(bundles, b)
+		}
+	}
+	return NewEngine(bundles...)
+}
+
+func bundleFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading policy bundle: %v", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Evaluate returns the violations of the objects in a multi-document manifest
+func (e *Engine) Evaluate(manifest []byte) ([]Violation, error) {
+	var violations []Violation
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed reading manifest for policy evaluation: %v", err)
+		}
+
+		obj := map[string]interface{}{}
+		if err = yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, fmt.Errorf("failed parsing object for policy evaluation: %v", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		v, err := e.evaluateObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	return violations, nil
+}
+
+func (e *Engine) evaluateObject(obj map[string]interface{}) ([]Violation, error) {
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+
+	var violations []Violation
+	for _, p := range e.policies {
+		if len(p.Kinds) > 0 && !contains(p.Kinds, kind) {
+			continue
+		}
+		results, err := p.query.Eval(context.Background(), rego.EvalInput(obj))
+		if err != nil {
+			return nil, fmt.Errorf("failed evaluating policy %s on %s %s: %v", p.Name, kind, name, err)
+		}
+		if results.Allowed() {
+			violations = append(violations, Violation{
+				Policy:    p.Name,
+				Severity:  p.Severity,
+				Kind:      kind,
+				Name:      name,
+				Namespace: namespace,
+				Message:   p.Message,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// Enforce evaluates the manifest, logs the warnings and returns an error with all the error violations
+func (e *Engine) Enforce(manifest []byte) error {
+	violations, err := e.Evaluate(manifest)
+	if err != nil {
+		return err
+	}
+
+	var denied []string
+	for _, v := range violations {
+		if v.Severity == SeverityWarning {
+			logger.Info("Warning: manifest doesn't follow policy", "violation", v.String())
+			continue
+		}
+		denied = append(denied, v.String())
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("manifests denied by policies:\n%s", strings.Join(denied, "\n"))
+	}
+	return nil
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
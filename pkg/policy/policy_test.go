@@ -0,0 +1,125 @@
+package policy_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/policy"
+)
+
+func TestLoadAndEvaluate(t *testing.T) {
+	g := NewWithT(t)
+	engine, err := policy.Load("testdata/bundles/bundle.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	manifest, err := ioutil.ReadFile("testdata/manifest.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	violations, err := engine.Evaluate(manifest)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(violations).To(Equal([]policy.Violation{
+		{
+			Policy:    "no-public-endpoint",
+			Severity:  policy.SeverityError,
+			Kind:      "VSphereCluster",
+			Name:      "test",
+			Namespace: "eksa-system",
+			Message:   "control plane endpoint must be in 10.0.0.0/8",
+		},
+		{
+			Policy:    "mandatory-taints",
+			Severity:  policy.SeverityWarning,
+			Kind:      "KubeadmConfigTemplate",
+			Name:      "test-md-0",
+			Namespace: "eksa-system",
+			Message:   "worker nodes must have the dedicated taint",
+		},
+	}))
+}
+
+func TestEnforce(t *testing.T) {
+	g := NewWithT(t)
+	engine, err := policy.Load("testdata/bundles/bundle.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	manifest, err := ioutil.ReadFile("testdata/manifest.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(engine.Enforce(manifest)).To(MatchError(
+		"manifests denied by policies:\npolicy no-public-endpoint: VSphereCluster eksa-system/test: control plane endpoint must be in 10.0.0.0/8",
+	))
+}
+
+func TestEnforceOnlyWarnings(t *testing.T) {
+	g := NewWithT(t)
+	engine, err := policy.NewEngine(policy.Bundle{Policies: []policy.Policy{
+		{Name: "no-taints", Deny: "input.spec.template.spec.joinConfiguration.nodeRegistration.taints", Severity: policy.SeverityWarning},
+	}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	manifest, err := ioutil.ReadFile("testdata/manifest.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(engine.Enforce(manifest)).To(Succeed())
+}
+
+func TestLoadDirectory(t *testing.T) {
+	g := NewWithT(t)
+	engine, err := policy.Load("testdata/bundles")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	violations, err := engine.Evaluate([]byte("kind: KubeadmConfigTemplate\nmetadata:\n  name: md\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Policy).To(Equal("mandatory-taints"))
+}
+
+func TestLoadInvalidExpression(t *testing.T) {
+	g := NewWithT(t)
+	_, err := policy.Load("testdata/invalid_bundle.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("policy broken deny expression is invalid")))
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	_, err := policy.Load("testdata/missing.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading policy bundle")))
+}
+
+func TestNewEngineErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []policy.Policy
+		wantErr  string
+	}{
+		{
+			name:     "missing name",
+			policies: []policy.Policy{{Deny: "input.spec"}},
+			wantErr:  "policy name is required",
+		},
+		{
+			name:     "duplicated name",
+			policies: []policy.Policy{{Name: "a", Deny: "input.spec"}, {Name: "a", Deny: "input.spec"}},
+			wantErr:  "policy a is defined more than once",
+		},
+		{
+			name:     "missing deny",
+			policies: []policy.Policy{{Name: "a"}},
+			wantErr:  "policy a doesn't have a deny expression",
+		},
+		{
+			name:     "invalid severity",
+			policies: []policy.Policy{{Name: "a", Deny: "input.spec", Severity: "fatal"}},
+			wantErr:  "policy a severity fatal is invalid, it must be error or warning",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := policy.NewEngine(policy.Bundle{Policies: tt.policies})
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
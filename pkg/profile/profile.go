@@ -0,0 +1,180 @@
+package profile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/clustertemplate"
+	"github.com/aws/eks-anywhere/pkg/substitution"
+)
+
+// DefaultFile is the profiles file used when none is given, relative to the home directory
+const DefaultFile = ".eks-anywhere/profiles.yaml"
+
+// Config is a profiles file, with one profile per environment the clusters are deployed to
+type Config struct {
+	Profiles []Profile `json:"profiles"`
+}
+
+// Profile is the configuration of an environment, like the dev, stage or prod vCenter, kept out of the cluster config
+// so the same cluster config can be deployed to any of them:
+//   - Variables are set as environment variables for the operation. They feed the ${VAR} placeholders of the cluster
+//     config and the provider credentials, like EKSA_VSPHERE_USERNAME. Their values can be secret references
+//   - Overrides are partial objects merged into the cluster config objects, like the vCenter server of the
+//     VSphereDatacenterConfig, or the registry mirror and proxy of the Cluster
+//   - Timeout is the default --timeout of the operations in the environment
+type Profile struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Variables   map[string]string        `json:"variables,omitempty"`
+	Overrides   []map[string]interface{} `json:"overrides,omitempty"`
+	Timeout     *metav1.Duration         `json:"timeout,omitempty"`
+}
+
+// DefaultFilePath returns the path of DefaultFile in the home directory of the user
+func DefaultFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed finding default profiles file: %v", err)
+	}
+	return filepath.Join(home, DefaultFile), nil
+}
+
+// ReadConfig reads and validates a profiles file
+func ReadConfig(filename string) (*Config, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading profiles file: %v", err)
+	}
+	config := &Config{}
+	if err = yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("failed parsing profiles file %s: %v", filename, err)
+	}
+	if err = config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid profiles file %s: %v", filename, err)
+	}
+	return config, nil
+}
+
+func (c *Config) validate() error {
+	var errs []error
+	names := map[string]bool{}
+	for i, p := range c.Profiles {
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("profile %d doesn't have a name", i))
+			continue
+		}
+		if names[p.Name] {
+			errs = append(errs, fmt.Errorf("profile %s is defined more than once", p.Name))
+		}
+		names[p.Name] = true
+		for _, o := range p.Overrides {
+			if kind, _ := o["kind"].(string); kind == "" {
+				errs = append(errs, fmt.Errorf("profile %s has an override without kind", p.Name))
+			}
+		}
+		if p.Timeout != nil && p.Timeout.Duration < 0 {
+			errs = append(errs, fmt.Errorf("profile %s has a negative timeout", p.Name))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// Profile returns the profile with the given name
+func (c *Config) Profile(name string) (*Profile, error) {
+	for i := range c.Profiles {
+		if c.Profiles[i].Name == name {
+			return &c.Profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("profile %s not found, available profiles: %s", name, strings.Join(c.names(), ", "))
+}
+
+func (c *Config) names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for _, p := range c.Profiles {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetEnv sets the variables of the profile as environment variables. Variables already set in the environment are
+// kept, so a single value can still be changed for one run. Secret references in the values are resolved
+func (p *Profile) SetEnv() error {
+	var errs []error
+	for _, name := range p.variableNames() {
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		value, err := substitution.Substitute([]byte(p.Variables[name]))
+		if err == nil {
+			value, err = substitution.ResolveSecrets(value)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("variable %s: %v", name, err))
+			continue
+		}
+		if err = os.Setenv(name, string(value)); err != nil {
+			errs = append(errs, fmt.Errorf("variable %s: %v", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed setting the variables of profile %s: %v", p.Name, kerrors.NewAggregate(errs))
+	}
+	return nil
+}
+
+func (p *Profile) variableNames() []string {
+	names := make([]string, 0, len(p.Variables))
+	for name := range p.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply merges the overrides of the profile into a multi document cluster config. The placeholders of the cluster
+// config are kept, they are substituted when the result is read
+func (p *Profile) Apply(clusterConfig []byte) ([]byte, error) {
+	if len(p.Overrides) == 0 {
+		return clusterConfig, nil
+	}
+	content, err := clustertemplate.MergeOverrides(clusterConfig, p.Overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed merging the overrides of profile %s: %v", p.Name, err)
+	}
+	return content, nil
+}
+
+// TimeoutOrDefault returns the timeout of the profile, or d when it doesn't set one
+func (p *Profile) TimeoutOrDefault(d time.Duration) time.Duration {
+	if p.Timeout == nil {
+		return d
+	}
+	return p.Timeout.Duration
+}
+
+// Load reads the profile with the given name from the profiles file, or DefaultFile when filename is empty
+func Load(filename, name string) (*Profile, error) {
+	if filename == "" {
+		var err error
+		if filename, err = DefaultFilePath(); err != nil {
+			return nil, err
+		}
+	}
+	config, err := ReadConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+	return config.Profile(name)
+}
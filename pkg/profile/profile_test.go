@@ -0,0 +1,143 @@
+package profile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/profile"
+)
+
+const profiles = `profiles:
+- name: dev
+  variables:
+    VSPHERE_SERVER: vcenter-dev.example.com
+    EKSA_PROFILE_TEST_PASSWORD: ${env://EKSA_PROFILE_TEST_SECRET}
+  overrides:
+  - kind: VSphereDatacenterConfig
+    spec:
+      server: vcenter-dev.example.com
+  - kind: Cluster
+    spec:
+      proxyConfiguration:
+        httpProxy: http://proxy-dev:3128
+  timeout: 90m
+- name: prod
+`
+
+const clusterConfig = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: test-cluster
+spec:
+  kubernetesVersion: "1.21"
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereDatacenterConfig
+metadata:
+  name: test-cluster
+spec:
+  datacenter: SDDC-Datacenter
+  server: ${VSPHERE_SERVER}
+`
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestLoad(t *testing.T) {
+	g := NewWithT(t)
+	p, err := profile.Load(writeFile(t, profiles), "dev")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Name).To(Equal("dev"))
+	g.Expect(p.TimeoutOrDefault(0)).To(Equal(90 * time.Minute))
+}
+
+func TestLoadNotFound(t *testing.T) {
+	g := NewWithT(t)
+	_, err := profile.Load(writeFile(t, profiles), "stage")
+	g.Expect(err).To(MatchError("profile stage not found, available profiles: dev, prod"))
+}
+
+func TestReadConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "profiles:\n- name: dev\n  endpoint: vcenter\n",
+			wantErr: "failed parsing profiles file",
+		},
+		{
+			name:    "duplicated profile",
+			content: "profiles:\n- name: dev\n- name: dev\n",
+			wantErr: "profile dev is defined more than once",
+		},
+		{
+			name:    "missing name",
+			content: "profiles:\n- description: dev\n",
+			wantErr: "profile 0 doesn't have a name",
+		},
+		{
+			name:    "override without kind",
+			content: "profiles:\n- name: dev\n  overrides:\n  - spec: {}\n",
+			wantErr: "profile dev has an override without kind",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := profile.ReadConfig(writeFile(t, tt.content))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestProfileSetEnv(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv("EKSA_PROFILE_TEST_SECRET", "secret")
+	defer os.Unsetenv("EKSA_PROFILE_TEST_SECRET")
+	os.Setenv("VSPHERE_SERVER", "vcenter-override.example.com")
+	defer os.Unsetenv("VSPHERE_SERVER")
+	defer os.Unsetenv("EKSA_PROFILE_TEST_PASSWORD")
+	p, err := profile.Load(writeFile(t, profiles), "dev")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(p.SetEnv()).To(Succeed())
+	g.Expect(os.Getenv("EKSA_PROFILE_TEST_PASSWORD")).To(Equal("secret"))
+	g.Expect(os.Getenv("VSPHERE_SERVER")).To(Equal("vcenter-override.example.com"))
+}
+
+func TestProfileApply(t *testing.T) {
+	g := NewWithT(t)
+	p, err := profile.Load(writeFile(t, profiles), "dev")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	content, err := p.Apply([]byte(clusterConfig))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring("server: vcenter-dev.example.com"))
+	g.Expect(string(content)).To(ContainSubstring("datacenter: SDDC-Datacenter"))
+	g.Expect(string(content)).To(ContainSubstring("httpProxy: http://proxy-dev:3128"))
+	g.Expect(string(content)).To(ContainSubstring(`kubernetesVersion: "1.21"`))
+}
+
+func TestProfileApplyNoOverrides(t *testing.T) {
+	g := NewWithT(t)
+	p, err := profile.Load(writeFile(t, profiles), "prod")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	content, err := p.Apply([]byte(clusterConfig))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal(clusterConfig))
+	g.Expect(p.TimeoutOrDefault(time.Hour)).To(Equal(time.Hour))
+}
@@ -0,0 +1,75 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+)
+
+// Destruction describes what a destructive step is about to delete, so it can be reviewed before it runs
+type Destruction struct {
+	// Action is the question asked, like "Delete cluster my-cluster"
+	Action string
+	// Resources lists everything the step deletes, shown before asking
+	Resources []string
+}
+
+// Confirmer asks for confirmation before destructive steps. The same confirmation is used by all the
+// commands, so they all skip it with the flag in AssumeYesFlag and all refuse to run unattended without it
+type Confirmer struct {
+	prompter    *TerminalPrompter
+	out         io.Writer
+	assumeYes   bool
+	interactive bool
+}
+
+// AssumeYesFlag is the flag that skips the confirmations, for automation
+const AssumeYesFlag = "--yes"
+
+type ConfirmerOpt func(*Confirmer)
+
+// WithAssumeYes confirms all the destructive steps without asking
+func WithAssumeYes(assumeYes bool) ConfirmerOpt {
+	return func(c *Confirmer) {
+		c.assumeYes = assumeYes
+	}
+}
+
+// WithInteractive sets if there is someone to answer the questions.
+// A non interactive Confirmer fails unless it assumes yes
+func WithInteractive(interactive bool) ConfirmerOpt {
+	return func(c *Confirmer) {
+		c.interactive = interactive
+	}
+}
+
+func NewConfirmer(in io.Reader, out io.Writer, opts ...ConfirmerOpt) *Confirmer {
+	c := &Confirmer{
+		prompter:    NewTerminalPrompter(in, out),
+		out:         out,
+		interactive: true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Confirm shows the resources to be deleted and asks to go ahead. It returns false when the answer is no
+func (c *Confirmer) Confirm(d Destruction) (bool, error) {
+	if c.assumeYes {
+		return true, nil
+	}
+	if !c.interactive {
+		return false, fmt.Errorf("%s requires confirmation but there is no terminal to ask it, rerun with %s to confirm it", d.Action, AssumeYesFlag)
+	}
+
+	if len(d.Resources) > 0 {
+		fmt.Fprintln(c.out, "The following resources will be deleted:")
+		for _, r := range d.Resources {
+			fmt.Fprintf(c.out, "  - %s\n", r)
+		}
+	}
+
+	return c.prompter.Confirm(d.Action + "?")
+}
@@ -0,0 +1,93 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TerminalPrompter asks questions in a terminal and reads the answers line by line
+type TerminalPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func NewTerminalPrompter(in io.Reader, out io.Writer) *TerminalPrompter {
+	return &TerminalPrompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Select asks to choose one of the options by its number, repeating the question until the answer is valid
+func (p *TerminalPrompter) Select(label string, options []string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("no options to select %s from", label)
+	}
+
+	fmt.Fprintf(p.out, "Select the %s:\n", label)
+	for i, o := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, o)
+	}
+
+	for {
+		fmt.Fprintf(p.out, "Enter a number [1-%d]: ", len(options))
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(options) {
+			return options[n-1], nil
+		}
+		fmt.Fprintf(p.out, "Invalid selection %q\n", answer)
+	}
+}
+
+// Input asks for a free form value, repeating the question until the answer is not empty
+func (p *TerminalPrompter) Input(label string) (string, error) {
+	for {
+		fmt.Fprintf(p.out, "Enter the %s: ", label)
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer != "" {
+			return answer, nil
+		}
+	}
+}
+
+// Confirm asks a yes or no question, repeating it until the answer is valid. An empty answer is a no
+func (p *TerminalPrompter) Confirm(label string) (bool, error) {
+	for {
+		fmt.Fprintf(p.out, "%s [y/N]: ", label)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "", "n", "no":
+			return false, nil
+		}
+		fmt.Fprintf(p.out, "Invalid answer %q\n", answer)
+	}
+}
+
+func (p *TerminalPrompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		return strings.TrimSpace(line), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed reading answer: %v", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,85 @@
+package prompt_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/prompt"
+)
+
+func TestTerminalPrompterSelect(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	p := prompt.NewTerminalPrompter(strings.NewReader("3\nabc\n2\n"), out)
+
+	got, err := p.Select("datastore", []string{"ds-1", "ds-2"})
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(Equal("ds-2"))
+	g.Expect(out.String()).To(ContainSubstring("  2) ds-2"))
+	g.Expect(out.String()).To(ContainSubstring(`Invalid selection "abc"`))
+}
+
+func TestTerminalPrompterSelectNoAnswer(t *testing.T) {
+	g := NewWithT(t)
+	p := prompt.NewTerminalPrompter(strings.NewReader(""), &bytes.Buffer{})
+
+	_, err := p.Select("datastore", []string{"ds-1", "ds-2"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading answer")))
+}
+
+func TestTerminalPrompterInput(t *testing.T) {
+	g := NewWithT(t)
+	p := prompt.NewTerminalPrompter(strings.NewReader("\n 1.2.3.4"), &bytes.Buffer{})
+
+	got, err := p.Input("control plane endpoint")
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(Equal("1.2.3.4"))
+}
+
+func TestTerminalPrompterConfirm(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	p := prompt.NewTerminalPrompter(strings.NewReader("maybe\nYes\n\n"), out)
+
+	got, err := p.Confirm("Delete 2 resources?")
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(BeTrue())
+	g.Expect(out.String()).To(ContainSubstring(`Invalid answer "maybe"`))
+
+	got, err = p.Confirm("Delete 2 resources?")
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(BeFalse())
+}
+
+func TestConfirmerConfirm(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	c := prompt.NewConfirmer(strings.NewReader("y\n"), out)
+
+	got, err := c.Confirm(prompt.Destruction{Action: "Delete cluster test", Resources: []string{"cluster test", "bootstrap cluster test-eks-a-cluster"}})
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(BeTrue())
+	g.Expect(out.String()).To(Equal("The following resources will be deleted:\n  - cluster test\n  - bootstrap cluster test-eks-a-cluster\nDelete cluster test? [y/N]: "))
+}
+
+func TestConfirmerAssumeYes(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	c := prompt.NewConfirmer(strings.NewReader(""), out, prompt.WithAssumeYes(true), prompt.WithInteractive(false))
+
+	got, err := c.Confirm(prompt.Destruction{Action: "Delete cluster test"})
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(BeTrue())
+	g.Expect(out.String()).To(BeEmpty())
+}
+
+func TestConfirmerNotInteractive(t *testing.T) {
+	g := NewWithT(t)
+	c := prompt.NewConfirmer(strings.NewReader("y\n"), &bytes.Buffer{}, prompt.WithInteractive(false))
+
+	_, err := c.Confirm(prompt.Destruction{Action: "Delete cluster test"})
+	g.Expect(err).To(MatchError("Delete cluster test requires confirmation but there is no terminal to ask it, rerun with --yes to confirm it"))
+}
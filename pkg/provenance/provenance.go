@@ -0,0 +1,72 @@
+package provenance
+
+import (
+	_ "embed"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+//go:embed config/manifest.yaml
+var manifestTemplate string
+
+const (
+	eksaComponentsOverrideKey      = "eksaComponentsOverride"
+	eksaControllerImageOverrideKey = "eksaControllerImageOverride"
+)
+
+// ConfigMapName returns the name of the ConfigMap that holds the provenance of a cluster
+func ConfigMapName(clusterName string) string {
+	return clusterName + "-provenance"
+}
+
+// GenerateManifest returns the ConfigMap that records how a cluster was built: the EKS-A and bundles
+// versions, the EKS-D release, whether it runs in FIPS mode with the FIPS images in use and the
+// EKS-A components overrides, if any, so it can be checked during audits
+func GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
+	bundlesVersion := ""
+	if clusterSpec.Bundles != nil {
+		bundlesVersion = fmt.Sprint(clusterSpec.Bundles.Spec.Number)
+	}
+
+	fipsImages := make([]string, 0, len(clusterSpec.FIPSImages()))
+	for _, image := range clusterSpec.FIPSImages() {
+		fipsImages = append(fipsImages, image.VersionedImage())
+	}
+
+	overrides := clusterSpec.EksaOverrides()
+
+	data := map[string]interface{}{
+		"name":              ConfigMapName(clusterSpec.Name),
+		"namespace":         constants.EksaSystemNamespace,
+		"eksaVersion":       version.Get().GitVersion,
+		"bundlesVersion":    bundlesVersion,
+		"kubernetesVersion": string(clusterSpec.Spec.KubernetesVersion),
+		"eksdRelease":       clusterSpec.VersionsBundle.EksD.Name,
+		"fipsEnabled":       clusterSpec.Spec.FIPSEnabled,
+		"fipsImages":        fipsImages,
+
+		eksaComponentsOverrideKey:      overrides.ComponentsManifest,
+		eksaControllerImageOverrideKey: overrides.ControllerImage,
+	}
+
+	manifest, err := templater.Execute(manifestTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("error generating provenance manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+// EksaOverrides returns the EKS-A components overrides recorded in the provenance ConfigMap, the ones installed
+// by the last create or upgrade, no matter if they came from the environment or from the cluster annotations
+func EksaOverrides(configMap *corev1.ConfigMap) cluster.EksaOverrides {
+	return cluster.EksaOverrides{
+		ComponentsManifest: configMap.Data[eksaComponentsOverrideKey],
+		ControllerImage:    configMap.Data[eksaControllerImageOverrideKey],
+	}
+}
@@ -0,0 +1,71 @@
+package provenance_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/provenance"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func givenClusterSpec(fipsEnabled bool) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = v1alpha1.Kube121
+		s.Spec.FIPSEnabled = fipsEnabled
+		s.Bundles.Spec.Number = 5
+		s.VersionsBundle.EksD.Name = "kubernetes-1-21-eks-8"
+		s.VersionsBundle.FIPS = &releasev1alpha1.FIPSBundle{
+			Version:       "v0.0.1",
+			KubeAPIServer: releasev1alpha1.Image{URI: "public.ecr.aws/eks-distro/kubernetes/kube-apiserver:v1.21.2-eks-1-21-8-fips"},
+			Etcd:          releasev1alpha1.Image{URI: "public.ecr.aws/eks-distro/etcd-io/etcd:v3.4.16-eks-1-21-8-fips"},
+			CoreDNS:       releasev1alpha1.Image{URI: "public.ecr.aws/eks-distro/coredns/coredns:v1.8.3-eks-1-21-8-fips"},
+			Pause:         releasev1alpha1.Image{URI: "public.ecr.aws/eks-distro/kubernetes/pause:v1.21.2-eks-1-21-8-fips"},
+			KubeVip:       releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/plunder-app/kube-vip:v0.3.7-eks-a-fips"},
+		}
+	})
+}
+
+func TestGenerateManifest(t *testing.T) {
+	g := NewWithT(t)
+	manifest, err := provenance.GenerateManifest(givenClusterSpec(false))
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results.yaml")
+}
+
+func TestGenerateManifestFIPS(t *testing.T) {
+	g := NewWithT(t)
+	manifest, err := provenance.GenerateManifest(givenClusterSpec(true))
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results_fips.yaml")
+}
+
+func TestGenerateManifestEksaOverrides(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv(cluster.EksaComponentsOverrideEnvVar, "https://example.com/eksa-components.yaml")
+	defer os.Unsetenv(cluster.EksaComponentsOverrideEnvVar)
+	os.Setenv(cluster.EksaControllerImageOverrideEnvVar, "example.com/eks-anywhere-cluster-controller:patched")
+	defer os.Unsetenv(cluster.EksaControllerImageOverrideEnvVar)
+	manifest, err := provenance.GenerateManifest(givenClusterSpec(false))
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results_overrides.yaml")
+}
+
+func TestEksaOverrides(t *testing.T) {
+	g := NewWithT(t)
+	configMap := &corev1.ConfigMap{
+		Data: map[string]string{
+			"eksaVersion":                 "v0.6.0",
+			"eksaControllerImageOverride": "example.com/eks-anywhere-cluster-controller:patched",
+		},
+	}
+	g.Expect(provenance.EksaOverrides(configMap)).To(Equal(cluster.EksaOverrides{
+		ControllerImage: "example.com/eks-anywhere-cluster-controller:patched",
+	}))
+}
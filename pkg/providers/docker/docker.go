@@ -17,18 +17,24 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/podsecurity"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
+	"github.com/aws/eks-anywhere/pkg/substitution"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
+var l = logger.For(logger.Providers)
+
 const (
 	githubTokenEnvVar = "GITHUB_TOKEN"
+	// capdClusterLabel is added by CAPD to all the containers of a cluster
+	capdClusterLabel      = "io.x-k8s.kind.cluster"
+	containerResourceKind = "Container"
 )
 
 //go:embed config/template-cp.yaml
@@ -41,6 +47,10 @@ var eksaDockerResourceType = fmt.Sprintf("dockerdatacenterconfigs.%s", v1alpha1.
 
 type ProviderClient interface {
 	GetDockerLBPort(ctx context.Context, clusterName string) (port string, err error)
+	Version(ctx context.Context) (int, error)
+	AllocatedMemory(ctx context.Context) (uint64, error)
+	ListContainers(ctx context.Context, label string) ([]string, error)
+	RemoveContainer(ctx context.Context, name string) error
 }
 
 type provider struct {
@@ -94,13 +104,81 @@ func (p *provider) DeleteResources(_ context.Context, _ *cluster.Spec) error {
 }
 
 func (p *provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
-	logger.Info("Warning: The docker infrastructure provider is meant for local development and testing only")
+	l.Info("Warning: The docker infrastructure provider is meant for local development and testing only")
 	if clusterSpec.Spec.ControlPlaneConfiguration.Endpoint != nil && clusterSpec.Spec.ControlPlaneConfiguration.Endpoint.Host != "" {
 		return fmt.Errorf("specifying endpoint host configuration in Cluster is not supported")
 	}
+	if len(clusterSpec.Spec.ResourceTags) > 0 {
+		l.Info("Warning: The docker infrastructure provider doesn't support resource tags, they won't be added to the containers")
+	}
+	return nil
+}
+
+// ValidateAccess checks that the docker client is installed and the daemon is running
+func (p *provider) ValidateAccess(ctx context.Context) error {
+	if _, err := p.docker.Version(ctx); err != nil {
+		return err
+	}
+
+	if _, err := p.docker.AllocatedMemory(ctx); err != nil {
+		return fmt.Errorf("failed connecting to the docker daemon: %v", err)
+	}
+
+	return nil
+}
+
+// ListResources returns the containers of the cluster set in the cluster name tag. CAPD doesn't support custom
+// labels, so the containers can only be queried by cluster name
+func (p *provider) ListResources(ctx context.Context, tags map[string]string) ([]providers.Resource, error) {
+	clusterName, ok := tags[providers.ClusterNameTag]
+	if !ok {
+		return nil, fmt.Errorf("docker provider can only list resources by the %s tag", providers.ClusterNameTag)
+	}
+	for k, v := range tags {
+		if k == providers.ClusterNameTag || (k == providers.ManagedByTag && v == providers.ManagedByValue) {
+			continue
+		}
+		return nil, fmt.Errorf("docker provider can't list resources by the %s tag", k)
+	}
+
+	names, err := p.docker.ListContainers(ctx, fmt.Sprintf("%s=%s", capdClusterLabel, clusterName))
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]providers.Resource, 0, len(names))
+	for _, name := range names {
+		resource := providers.Resource{
+			Kind: containerResourceKind,
+			Name: name,
+			Tags: providers.ClusterTags(clusterName),
+		}
+		// CAPD names the machine containers after the CAPI Machines
+		if name != loadBalancerContainerName(clusterName) {
+			resource.Machine = name
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// DeleteResource removes a container returned by ListResources
+func (p *provider) DeleteResource(ctx context.Context, resource providers.Resource) error {
+	if resource.Kind != containerResourceKind {
+		return fmt.Errorf("docker provider can't delete resources of kind %s", resource.Kind)
+	}
+	return p.docker.RemoveContainer(ctx, resource.Name)
+}
+
+// DeleteResourceTags is a no-op: the tags are container labels, deleted with the containers
+func (p *provider) DeleteResourceTags(ctx context.Context, clusterSpec *cluster.Spec) error {
 	return nil
 }
 
+func loadBalancerContainerName(clusterName string) string {
+	return fmt.Sprintf("%s-lb", clusterName)
+}
+
 func (p *provider) SetupAndValidateDeleteCluster(ctx context.Context) error {
 	return nil
 }
@@ -141,6 +219,15 @@ func (d *DockerTemplateBuilder) EtcdMachineTemplateName(clusterName string) stri
 
 func (d *DockerTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	values := buildTemplateMapCP(clusterSpec)
+
+	if clusterSpec.Spec.PodSecurity != nil {
+		admissionConfiguration, err := podsecurity.GenerateAdmissionConfiguration(clusterSpec.Spec.PodSecurity)
+		if err != nil {
+			return nil, err
+		}
+		values["podSecurityAdmissionConfiguration"] = admissionConfiguration
+	}
+
 	for _, buildOption := range buildOptions {
 		buildOption(values)
 	}
@@ -150,7 +237,7 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluste
 		return nil, err
 	}
 
-	return bytes, nil
+	return substitution.ResolveSecrets(bytes)
 }
 
 func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, templateNames map[string]string) (content []byte, err error) {
@@ -171,19 +258,25 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spe
 		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return substitution.ResolveSecrets(templater.AppendYamlResources(workerSpecs...))
 }
 
 func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
-	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
-	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
+	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs().
+		Append(clusterapi.TlsPolicyEtcdExtraArgs(clusterSpec.Spec.TLSPolicy))
+	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.TlsPolicyExtraArgs(clusterSpec.Spec.TLSPolicy))
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.TlsPolicyExtraArgs(clusterSpec.Spec.TLSPolicy)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Spec.ClusterNetwork.DNS.ResolvConf)).
 		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Spec.PodIAMConfig)).
+		Append(clusterapi.AdmissionExtraArgs(clusterSpec.Spec.Admission, clusterSpec.Spec.KubernetesVersion)).
+		Append(sharedExtraArgs)
+	controllerManagerExtraArgs := clusterapi.AdmissionControllerManagerExtraArgs(clusterSpec.Spec.Admission).
 		Append(sharedExtraArgs)
 
 	values := map[string]interface{}{
@@ -197,9 +290,9 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 		"corednsVersion":             bundle.KubeDistro.CoreDNS.Tag,
 		"kindNodeImage":              bundle.EksD.KindNode.VersionedImage(),
 		"etcdExtraArgs":              etcdExtraArgs.ToPartialYaml(),
-		"etcdCipherSuites":           crypto.SecureCipherSuitesString(),
+		"etcdCipherSuites":           etcdExtraArgs["cipher-suites"],
 		"apiserverExtraArgs":         apiServerExtraArgs.ToPartialYaml(),
-		"controllermanagerExtraArgs": sharedExtraArgs.ToPartialYaml(),
+		"controllermanagerExtraArgs": controllerManagerExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":         sharedExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":           kubeletExtraArgs.ToPartialYaml(),
 		"externalEtcdVersion":        bundle.KubeDistro.EtcdVersion,
@@ -225,8 +318,9 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 }
 
 func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
-	bundle := clusterSpec.VersionsBundle
+	bundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.TlsPolicyExtraArgs(clusterSpec.Spec.TLSPolicy)).
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Spec.ClusterNetwork.DNS.ResolvConf))
 
@@ -237,7 +331,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		"eksaSystemNamespace": constants.EksaSystemNamespace,
 		"kubeletExtraArgs":    kubeletExtraArgs.ToPartialYaml(),
 		"workerReplicas":      workerNodeGroupConfiguration.Count,
-		"workerNodeGroupName": fmt.Sprintf("%s-%s", clusterSpec.Name, workerNodeGroupConfiguration.Name),
+		"workerNodeGroupName": clusterSpec.Cluster.MachineDeploymentName(workerNodeGroupConfiguration.Name),
 	}
 	return values
 }
@@ -246,12 +340,14 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec) bool {
 	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
 }
 
-func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec) bool {
-	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
+func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldWorker, newWorker v1alpha1.WorkerNodeGroupConfiguration) bool {
+	return (oldSpec.WorkerNodeGroupKubernetesVersion(oldWorker) != newSpec.WorkerNodeGroupKubernetesVersion(newWorker)) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number) ||
+		!oldSpec.Cluster.Spec.TLSPolicy.Equal(newSpec.Cluster.Spec.TLSPolicy)
 }
 
 func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec) bool {
-	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
+	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number) ||
+		!oldSpec.Cluster.Spec.TLSPolicy.Equal(newSpec.Cluster.Spec.TLSPolicy)
 }
 
 func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
@@ -274,9 +370,9 @@ func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 
 	workloadTemplateNames := make(map[string]string, len(newClusterSpec.Spec.WorkerNodeGroupConfigurations))
 	for _, workerNodeGroupConfiguration := range newClusterSpec.Spec.WorkerNodeGroupConfigurations {
-		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec)
-		if _, ok := previousWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok && !needsNewWorkloadTemplate {
-			machineDeploymentName := fmt.Sprintf("%s-%s", newClusterSpec.Name, workerNodeGroupConfiguration.Name)
+		prevWorkerNodeGroupConfig, ok := previousWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]
+		if ok && !NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, prevWorkerNodeGroupConfig, workerNodeGroupConfiguration) {
+			machineDeploymentName := newClusterSpec.Cluster.MachineDeploymentName(workerNodeGroupConfiguration.Name)
 			md, err := p.providerKubectlClient.GetMachineDeployment(ctx, workloadCluster, machineDeploymentName, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
 			if err != nil {
 				return nil, nil, err
@@ -364,8 +460,8 @@ func (p *provider) GenerateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 	return controlPlaneSpec, workersSpec, nil
 }
 
-func (p *provider) GenerateStorageClass() []byte {
-	return nil
+func (p *provider) GenerateStorageClass() ([]byte, error) {
+	return nil, nil
 }
 
 func (p *provider) GenerateMHC() ([]byte, error) {
@@ -2,6 +2,7 @@ package docker_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -469,6 +470,35 @@ func TestChangeDiffWithChange(t *testing.T) {
 	tt.Expect(tt.provider.ChangeDiff(clusterSpec, newClusterSpec)).To(Equal(wantDiff))
 }
 
+func TestNeedsNewWorkloadTemplateWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	kube120 := v1alpha1.Kube120
+	oldSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube120
+	})
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube121
+	})
+	oldWorker := v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}
+	pinnedWorker := v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0", KubernetesVersion: &kube120}
+
+	g := NewWithT(t)
+	g.Expect(docker.NeedsNewWorkloadTemplate(oldSpec, newSpec, oldWorker, oldWorker)).To(BeTrue())
+	g.Expect(docker.NeedsNewWorkloadTemplate(oldSpec, newSpec, oldWorker, pinnedWorker)).To(BeFalse())
+}
+
+func TestNeedsNewTemplatesTLSPolicyChanged(t *testing.T) {
+	oldSpec := test.NewClusterSpec()
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.TLSPolicy = &v1alpha1.TLSPolicy{MinVersion: v1alpha1.TLSVersion13}
+	})
+	worker := v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}
+
+	g := NewWithT(t)
+	g.Expect(docker.NeedsNewWorkloadTemplate(oldSpec, newSpec, worker, worker)).To(BeTrue())
+	g.Expect(docker.NeedsNewEtcdTemplate(oldSpec, newSpec)).To(BeTrue())
+	g.Expect(docker.NeedsNewWorkloadTemplate(oldSpec, oldSpec.DeepCopy(), worker, worker)).To(BeFalse())
+}
+
 func TestProviderGenerateCAPISpecForCreateWithPodIAMConfig(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	ctx := context.Background()
@@ -506,6 +536,164 @@ func TestProviderGenerateCAPISpecForCreateWithPodIAMConfig(t *testing.T) {
 	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_pod_iam_expected.yaml")
 }
 
+func TestProviderGenerateCAPISpecForCreateResolvesSecretReferences(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.19"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.VersionsBundle = versionsBundle
+		s.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: 3, MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}}}
+	})
+	clusterSpec.Spec.PodIAMConfig = &v1alpha1.PodIAMConfig{ServiceAccountIssuer: "${env://EKSA_TEST_ISSUER}"}
+	os.Setenv("EKSA_TEST_ISSUER", "https://test")
+	defer os.Unsetenv("EKSA_TEST_ISSUER")
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_pod_iam_expected.yaml")
+	if clusterSpec.Spec.PodIAMConfig.ServiceAccountIssuer != "${env://EKSA_TEST_ISSUER}" {
+		t.Fatalf("cluster spec was modified, issuer is %s", clusterSpec.Spec.PodIAMConfig.ServiceAccountIssuer)
+	}
+}
+
+func TestProviderGenerateCAPISpecForCreateWithPodSecurity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.19"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.VersionsBundle = versionsBundle
+		s.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: 3, MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}}}
+	})
+	clusterSpec.Spec.PodSecurity = &v1alpha1.PodSecurityConfiguration{
+		Enforce: v1alpha1.PodSecurityBaseline,
+		Warn:    v1alpha1.PodSecurityRestricted,
+	}
+
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_pod_security_expected.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithAdmissionProfile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.21"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.VersionsBundle = versionsBundle
+		s.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: 3, MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}}}
+	})
+	clusterSpec.Spec.Admission = &v1alpha1.AdmissionConfiguration{
+		Profile:        v1alpha1.AdmissionProfileCustom,
+		EnablePlugins:  []string{"PodNodeSelector"},
+		DisablePlugins: []string{"DefaultStorageClass"},
+	}
+
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_admission_expected.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithResourceNaming(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.21"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.VersionsBundle = versionsBundle
+		s.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: 3, MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"}}
+	})
+	clusterSpec.Spec.ResourceNaming = &v1alpha1.ResourceNamingConfiguration{
+		MachineDeployment: "{{.ClusterName}}-workers-{{.NodeGroupName}}",
+	}
+
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	_, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(md), "testdata/valid_deployment_md_resource_naming_expected.yaml")
+}
+
 func TestProviderGenerateCAPISpecForCreateWithStackedEtcd(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	ctx := context.Background()
@@ -540,3 +728,104 @@ func TestProviderGenerateCAPISpecForCreateWithStackedEtcd(t *testing.T) {
 	}
 	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_stacked_etcd_expected.yaml")
 }
+
+func TestValidateAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		versionErr error
+		memoryErr  error
+		wantErr    string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:       "docker not installed",
+			versionErr: errors.New("please check if docker is installed and running"),
+			wantErr:    "please check if docker is installed and running",
+		},
+		{
+			name:      "daemon not running",
+			memoryErr: errors.New("cannot connect to the docker daemon"),
+			wantErr:   "failed connecting to the docker daemon: cannot connect to the docker daemon",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			client := dockerMocks.NewMockProviderClient(mockCtrl)
+			kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+			p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+
+			client.EXPECT().Version(ctx).Return(20, tt.versionErr)
+			if tt.versionErr == nil {
+				client.EXPECT().AllocatedMemory(ctx).Return(uint64(6200000001), tt.memoryErr)
+			}
+
+			err := p.ValidateAccess(ctx)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestListResources(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+
+	client.EXPECT().ListContainers(ctx, "io.x-k8s.kind.cluster=test-cluster").Return([]string{"test-cluster-lb", "test-cluster-md-0-abcde"}, nil)
+
+	resources, err := p.ListResources(ctx, providers.ClusterTags("test-cluster"))
+	g.Expect(err).To(BeNil())
+	g.Expect(resources).To(Equal([]providers.Resource{
+		{
+			Kind: "Container",
+			Name: "test-cluster-lb",
+			Tags: providers.ClusterTags("test-cluster"),
+		},
+		{
+			Kind:    "Container",
+			Name:    "test-cluster-md-0-abcde",
+			Tags:    providers.ClusterTags("test-cluster"),
+			Machine: "test-cluster-md-0-abcde",
+		},
+	}))
+}
+
+func TestDeleteResource(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+
+	client.EXPECT().RemoveContainer(ctx, "test-cluster-md-0-abcde").Return(nil)
+
+	g.Expect(p.DeleteResource(ctx, providers.Resource{Kind: "Container", Name: "test-cluster-md-0-abcde"})).To(Succeed())
+	g.Expect(p.DeleteResource(ctx, providers.Resource{Kind: "VirtualMachine", Name: "vm"})).To(MatchError("docker provider can't delete resources of kind VirtualMachine"))
+}
+
+func TestListResourcesUnsupportedTag(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+
+	_, err := p.ListResources(ctx, map[string]string{providers.ClusterNameTag: "test-cluster", "team": "platform"})
+	g.Expect(err).To(MatchError("docker provider can't list resources by the team tag"))
+
+	_, err = p.ListResources(ctx, map[string]string{"team": "platform"})
+	g.Expect(err).To(MatchError("docker provider can only list resources by the anywhere.eks.amazonaws.com/cluster-name tag"))
+}
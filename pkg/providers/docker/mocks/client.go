@@ -40,6 +40,21 @@ func (m *MockProviderClient) EXPECT() *MockProviderClientMockRecorder {
 	return m.recorder
 }
 
+// AllocatedMemory mocks base method.
+func (m *MockProviderClient) AllocatedMemory(arg0 context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocatedMemory", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocatedMemory indicates an expected call of AllocatedMemory.
+func (mr *MockProviderClientMockRecorder) AllocatedMemory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocatedMemory", reflect.TypeOf((*MockProviderClient)(nil).AllocatedMemory), arg0)
+}
+
 // GetDockerLBPort mocks base method.
 func (m *MockProviderClient) GetDockerLBPort(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +70,50 @@ func (mr *MockProviderClientMockRecorder) GetDockerLBPort(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDockerLBPort", reflect.TypeOf((*MockProviderClient)(nil).GetDockerLBPort), arg0, arg1)
 }
 
+// ListContainers mocks base method.
+func (m *MockProviderClient) ListContainers(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContainers", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContainers indicates an expected call of ListContainers.
+func (mr *MockProviderClientMockRecorder) ListContainers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContainers", reflect.TypeOf((*MockProviderClient)(nil).ListContainers), arg0, arg1)
+}
+
+// RemoveContainer mocks base method.
+func (m *MockProviderClient) RemoveContainer(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveContainer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveContainer indicates an expected call of RemoveContainer.
+func (mr *MockProviderClientMockRecorder) RemoveContainer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContainer", reflect.TypeOf((*MockProviderClient)(nil).RemoveContainer), arg0, arg1)
+}
+
+// Version mocks base method.
+func (m *MockProviderClient) Version(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockProviderClientMockRecorder) Version(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockProviderClient)(nil).Version), arg0)
+}
+
 // MockProviderKubectlClient is a mock of ProviderKubectlClient interface.
 type MockProviderKubectlClient struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,15 @@
+package providers
+
+// AuthenticationError is returned when the provider rejects the credentials used to reach its infrastructure
+type AuthenticationError struct {
+	Provider string
+	Err      error
+}
+
+func (e *AuthenticationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
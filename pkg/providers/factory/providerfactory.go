@@ -25,31 +25,41 @@ type ProviderFactory struct {
 }
 
 func (p *ProviderFactory) BuildProvider(clusterConfigFileName string, clusterConfig *v1alpha1.Cluster, skipIpCheck bool, hardwareConfigFile string) (providers.Provider, error) {
+	return p.buildProvider(v1alpha1.ConfigFile(clusterConfigFileName), clusterConfig, skipIpCheck, hardwareConfigFile)
+}
+
+// BuildProviderFromContent builds the provider reading its datacenter and machine configs from a cluster config
+// in memory instead of a file, for programs embedding EKS-A without a writable filesystem
+func (p *ProviderFactory) BuildProviderFromContent(clusterConfigContent []byte, clusterConfig *v1alpha1.Cluster, skipIpCheck bool, hardwareConfigFile string) (providers.Provider, error) {
+	return p.buildProvider(v1alpha1.ConfigContent(clusterConfigContent), clusterConfig, skipIpCheck, hardwareConfigFile)
+}
+
+func (p *ProviderFactory) buildProvider(config v1alpha1.ConfigSource, clusterConfig *v1alpha1.Cluster, skipIpCheck bool, hardwareConfigFile string) (providers.Provider, error) {
 	switch clusterConfig.Spec.DatacenterRef.Kind {
 	case v1alpha1.VSphereDatacenterKind:
-		datacenterConfig, err := v1alpha1.GetVSphereDatacenterConfig(clusterConfigFileName)
+		datacenterConfig, err := config.VSphereDatacenterConfig()
 		if err != nil {
-			return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFileName, err)
+			return nil, fmt.Errorf("unable to get datacenter config from %s: %v", config, err)
 		}
-		machineConfigs, err := v1alpha1.GetVSphereMachineConfigs(clusterConfigFileName)
+		machineConfigs, err := config.VSphereMachineConfigs()
 		if err != nil {
-			return nil, fmt.Errorf("unable to get machine config from file %s: %v", clusterConfigFileName, err)
+			return nil, fmt.Errorf("unable to get machine config from %s: %v", config, err)
 		}
 		return vsphere.NewProvider(datacenterConfig, machineConfigs, clusterConfig, p.VSphereGovcClient, p.VSphereKubectlClient, p.Writer, time.Now, skipIpCheck, p.ClusterResourceSetManager), nil
 	case v1alpha1.TinkerbellDatacenterKind:
-		datacenterConfig, err := v1alpha1.GetTinkerbellDatacenterConfig(clusterConfigFileName)
+		datacenterConfig, err := config.TinkerbellDatacenterConfig()
 		if err != nil {
-			return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFileName, err)
+			return nil, fmt.Errorf("unable to get datacenter config from %s: %v", config, err)
 		}
-		machineConfigs, err := v1alpha1.GetTinkerbellMachineConfigs(clusterConfigFileName)
+		machineConfigs, err := config.TinkerbellMachineConfigs()
 		if err != nil {
-			return nil, fmt.Errorf("unable to get machine config from file %s: %v", clusterConfigFileName, err)
+			return nil, fmt.Errorf("unable to get machine config from %s: %v", config, err)
 		}
 		return tinkerbell.NewProvider(datacenterConfig, machineConfigs, clusterConfig, p.TinkerbellKubectlClient, time.Now, hardwareConfigFile), nil
 	case v1alpha1.DockerDatacenterKind:
-		datacenterConfig, err := v1alpha1.GetDockerDatacenterConfig(clusterConfigFileName)
+		datacenterConfig, err := config.DockerDatacenterConfig()
 		if err != nil {
-			return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFileName, err)
+			return nil, fmt.Errorf("unable to get datacenter config from %s: %v", config, err)
 		}
 		return docker.NewProvider(datacenterConfig, p.DockerClient, p.DockerKubectlClient, time.Now), nil
 	}
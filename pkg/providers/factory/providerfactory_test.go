@@ -2,6 +2,7 @@ package factory_test
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -110,3 +111,22 @@ func TestProviderFactoryBuildProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderFactoryBuildProviderFromContent(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	content, err := os.ReadFile("testdata/cluster_docker.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterConfig := &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{
+		DatacenterRef: v1alpha1.Ref{
+			Kind: v1alpha1.DockerDatacenterKind,
+		},
+	}}
+	p := &factory.ProviderFactory{
+		DockerClient: dockerMocks.NewMockProviderClient(mockCtrl),
+	}
+
+	got, err := p.BuildProviderFromContent(content, clusterConfig, false, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Name()).To(Equal(constants.DockerProviderName))
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/providers (interfaces: Provider,DatacenterConfig,MachineConfig)
+// Source: github.com/aws/eks-anywhere/pkg/providers (interfaces: Provider,DatacenterConfig,MachineConfig,UpgradeHooks)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -110,6 +110,34 @@ func (mr *MockProviderMockRecorder) DatacenterResourceType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatacenterResourceType", reflect.TypeOf((*MockProvider)(nil).DatacenterResourceType))
 }
 
+// DeleteResource mocks base method.
+func (m *MockProvider) DeleteResource(arg0 context.Context, arg1 providers.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResource", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResource indicates an expected call of DeleteResource.
+func (mr *MockProviderMockRecorder) DeleteResource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockProvider)(nil).DeleteResource), arg0, arg1)
+}
+
+// DeleteResourceTags mocks base method.
+func (m *MockProvider) DeleteResourceTags(arg0 context.Context, arg1 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResourceTags", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResourceTags indicates an expected call of DeleteResourceTags.
+func (mr *MockProviderMockRecorder) DeleteResourceTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourceTags", reflect.TypeOf((*MockProvider)(nil).DeleteResourceTags), arg0, arg1)
+}
+
 // DeleteResources mocks base method.
 func (m *MockProvider) DeleteResources(arg0 context.Context, arg1 *cluster.Spec) error {
 	m.ctrl.T.Helper()
@@ -187,11 +215,12 @@ func (mr *MockProviderMockRecorder) GenerateMHC() *gomock.Call {
 }
 
 // GenerateStorageClass mocks base method.
-func (m *MockProvider) GenerateStorageClass() []byte {
+func (m *MockProvider) GenerateStorageClass() ([]byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateStorageClass")
 	ret0, _ := ret[0].([]byte)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateStorageClass indicates an expected call of GenerateStorageClass.
@@ -228,6 +257,21 @@ func (mr *MockProviderMockRecorder) GetInfrastructureBundle(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfrastructureBundle", reflect.TypeOf((*MockProvider)(nil).GetInfrastructureBundle), arg0)
 }
 
+// ListResources mocks base method.
+func (m *MockProvider) ListResources(arg0 context.Context, arg1 map[string]string) ([]providers.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResources", arg0, arg1)
+	ret0, _ := ret[0].([]providers.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResources indicates an expected call of ListResources.
+func (mr *MockProviderMockRecorder) ListResources(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResources", reflect.TypeOf((*MockProvider)(nil).ListResources), arg0, arg1)
+}
+
 // MachineConfigs mocks base method.
 func (m *MockProvider) MachineConfigs() []providers.MachineConfig {
 	m.ctrl.T.Helper()
@@ -383,6 +427,20 @@ func (mr *MockProviderMockRecorder) UpgradeNeeded(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeNeeded", reflect.TypeOf((*MockProvider)(nil).UpgradeNeeded), arg0, arg1, arg2)
 }
 
+// ValidateAccess mocks base method.
+func (m *MockProvider) ValidateAccess(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAccess", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateAccess indicates an expected call of ValidateAccess.
+func (mr *MockProviderMockRecorder) ValidateAccess(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccess", reflect.TypeOf((*MockProvider)(nil).ValidateAccess), arg0)
+}
+
 // ValidateNewSpec mocks base method.
 func (m *MockProvider) ValidateNewSpec(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
 	m.ctrl.T.Helper()
@@ -564,3 +622,68 @@ func (mr *MockMachineConfigMockRecorder) OSFamily() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OSFamily", reflect.TypeOf((*MockMachineConfig)(nil).OSFamily))
 }
+
+// MockUpgradeHooks is a mock of UpgradeHooks interface.
+type MockUpgradeHooks struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpgradeHooksMockRecorder
+}
+
+// MockUpgradeHooksMockRecorder is the mock recorder for MockUpgradeHooks.
+type MockUpgradeHooksMockRecorder struct {
+	mock *MockUpgradeHooks
+}
+
+// NewMockUpgradeHooks creates a new mock instance.
+func NewMockUpgradeHooks(ctrl *gomock.Controller) *MockUpgradeHooks {
+	mock := &MockUpgradeHooks{ctrl: ctrl}
+	mock.recorder = &MockUpgradeHooksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpgradeHooks) EXPECT() *MockUpgradeHooksMockRecorder {
+	return m.recorder
+}
+
+// PostControlPlaneUpgrade mocks base method.
+func (m *MockUpgradeHooks) PostControlPlaneUpgrade(arg0 context.Context, arg1, arg2 *types.Cluster, arg3, arg4 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostControlPlaneUpgrade", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostControlPlaneUpgrade indicates an expected call of PostControlPlaneUpgrade.
+func (mr *MockUpgradeHooksMockRecorder) PostControlPlaneUpgrade(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostControlPlaneUpgrade", reflect.TypeOf((*MockUpgradeHooks)(nil).PostControlPlaneUpgrade), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PreCoreComponentsUpgrade mocks base method.
+func (m *MockUpgradeHooks) PreCoreComponentsUpgrade(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreCoreComponentsUpgrade", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreCoreComponentsUpgrade indicates an expected call of PreCoreComponentsUpgrade.
+func (mr *MockUpgradeHooksMockRecorder) PreCoreComponentsUpgrade(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreCoreComponentsUpgrade", reflect.TypeOf((*MockUpgradeHooks)(nil).PreCoreComponentsUpgrade), arg0, arg1, arg2, arg3)
+}
+
+// PreMachineRollout mocks base method.
+func (m *MockUpgradeHooks) PreMachineRollout(arg0 context.Context, arg1, arg2 *types.Cluster, arg3, arg4 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreMachineRollout", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreMachineRollout indicates an expected call of PreMachineRollout.
+func (mr *MockUpgradeHooksMockRecorder) PreMachineRollout(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreMachineRollout", reflect.TypeOf((*MockUpgradeHooks)(nil).PreMachineRollout), arg0, arg1, arg2, arg3, arg4)
+}
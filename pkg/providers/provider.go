@@ -9,15 +9,22 @@ import (
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// SinglePointsOfFailureFinder is implemented by the providers that can find single points of failure in the placement
+// of the machines, like etcd and control plane machines sharing a datastore or a host. Each finding describes one
+type SinglePointsOfFailureFinder interface {
+	SinglePointsOfFailure(ctx context.Context, clusterSpec *cluster.Spec) ([]string, error)
+}
+
 type Provider interface {
 	Name() string
 	SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error
 	SetupAndValidateDeleteCluster(ctx context.Context) error
+	ValidateAccess(ctx context.Context) error
 	SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 	UpdateSecrets(ctx context.Context, cluster *types.Cluster) error
 	GenerateCAPISpecForCreate(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error)
 	GenerateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currrentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error)
-	GenerateStorageClass() []byte
+	GenerateStorageClass() ([]byte, error)
 	BootstrapSetup(ctx context.Context, clusterConfig *v1alpha1.Cluster, cluster *types.Cluster) error
 	BootstrapClusterOpts() ([]bootstrapper.BootstrapClusterOption, error)
 	UpdateKubeConfig(content *[]byte, clusterName string) error
@@ -35,9 +42,32 @@ type Provider interface {
 	RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpec *cluster.Spec, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, managementCluster *types.Cluster) error
 	UpgradeNeeded(ctx context.Context, newSpec, currentSpec *cluster.Spec) (bool, error)
 	DeleteResources(ctx context.Context, clusterSpec *cluster.Spec) error
+	// ListResources returns the infrastructure resources with all the tags
+	ListResources(ctx context.Context, tags map[string]string) ([]Resource, error)
+	// DeleteResource deletes an infrastructure resource returned by ListResources
+	DeleteResource(ctx context.Context, resource Resource) error
+	// DeleteResourceTags deletes the tags created for the cluster resources once the cluster is deleted
+	DeleteResourceTags(ctx context.Context, clusterSpec *cluster.Spec) error
 	RunPostControlPlaneCreation(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error
 }
 
+// UpgradeHooks is implemented by the providers that need version specific fixups during an upgrade, like CRD
+// migrations or re-importing templates. The upgrade workflow runs the hooks of the providers implementing it
+type UpgradeHooks interface {
+	// PreCoreComponentsUpgrade runs before the networking, CAPI, addon and EKS-A components are upgraded
+	PreCoreComponentsUpgrade(ctx context.Context, managementCluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error
+	// PostControlPlaneUpgrade runs once the upgraded control plane is ready
+	PostControlPlaneUpgrade(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error
+	// PreMachineRollout runs before the new worker machine deployments are applied
+	PreMachineRollout(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error
+}
+
+// ProxyEndpoints is implemented by the providers with infrastructure endpoints that the CLI tools and the
+// bootstrap cluster reach directly, which are added to NO_PROXY when the cluster has a proxy configuration
+type ProxyEndpoints interface {
+	NoProxyEndpoints() []string
+}
+
 type DatacenterConfig interface {
 	Kind() string
 	PauseReconcile()
@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"strconv"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// Standard tags added to every infrastructure resource EKS Anywhere creates
+const (
+	ClusterNameTag  = "anywhere.eks.amazonaws.com/cluster-name"
+	NodeGroupTag    = "anywhere.eks.amazonaws.com/node-group"
+	ManagedByTag    = "anywhere.eks.amazonaws.com/managed-by"
+	BundleNumberTag = "anywhere.eks.amazonaws.com/bundle-number"
+
+	ManagedByValue = "eks-anywhere"
+)
+
+// Node group tag values for the machines that don't belong to a worker node group
+const (
+	ControlPlaneNodeGroup = "control-plane"
+	EtcdNodeGroup         = "etcd"
+)
+
+// Resource is an infrastructure resource created by a provider for a cluster
+type Resource struct {
+	Kind string
+	Name string
+	Tags map[string]string
+	// Machine is the name of the CAPI Machine or infrastructure machine backed by the resource,
+	// empty for resources that don't back a machine, like load balancers
+	Machine string
+}
+
+// ResourceTags returns the tags for the resources of a node group: the custom tags from the cluster spec
+// plus the standard tags, which take precedence
+func ResourceTags(clusterSpec *cluster.Spec, nodeGroup string) map[string]string {
+	tags := ClusterResourceTags(clusterSpec)
+	tags[NodeGroupTag] = nodeGroup
+	tags[BundleNumberTag] = strconv.Itoa(clusterSpec.Bundles.Spec.Number)
+
+	return tags
+}
+
+// ClusterResourceTags returns the tags for the resources shared by all the node groups of a cluster, like folders:
+// the custom tags from the cluster spec plus the standard cluster tags, which take precedence
+func ClusterResourceTags(clusterSpec *cluster.Spec) map[string]string {
+	tags := make(map[string]string, len(clusterSpec.Spec.ResourceTags)+4)
+	for k, v := range clusterSpec.Spec.ResourceTags {
+		tags[k] = v
+	}
+	for k, v := range ClusterTags(clusterSpec.Name) {
+		tags[k] = v
+	}
+
+	return tags
+}
+
+// ClusterTags returns the standard tags shared by all the resources of a cluster, to query them
+func ClusterTags(clusterName string) map[string]string {
+	return map[string]string{
+		ClusterNameTag: clusterName,
+		ManagedByTag:   ManagedByValue,
+	}
+}
+
+// NodeGroups returns the node group tag values for all the machines of a cluster
+func NodeGroups(clusterSpec *cluster.Spec) []string {
+	nodeGroups := []string{ControlPlaneNodeGroup}
+	if clusterSpec.Spec.ExternalEtcdConfiguration != nil {
+		nodeGroups = append(nodeGroups, EtcdNodeGroup)
+	}
+	for _, w := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		nodeGroups = append(nodeGroups, w.Name)
+	}
+
+	return nodeGroups
+}
@@ -0,0 +1,43 @@
+package providers_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func TestResourceTags(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "my-cluster"
+		s.Bundles.Spec.Number = 12
+		s.Spec.ResourceTags = map[string]string{
+			"team":                 providers.ManagedByValue,
+			providers.ManagedByTag: "someone-else",
+		}
+	})
+
+	g.Expect(providers.ResourceTags(clusterSpec, "md-0")).To(Equal(map[string]string{
+		"team":                    "eks-anywhere",
+		providers.ClusterNameTag:  "my-cluster",
+		providers.ManagedByTag:    "eks-anywhere",
+		providers.NodeGroupTag:    "md-0",
+		providers.BundleNumberTag: "12",
+	}))
+}
+
+func TestNodeGroups(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}, {Name: "md-1"}}
+	})
+	g.Expect(providers.NodeGroups(clusterSpec)).To(Equal([]string{"control-plane", "md-0", "md-1"}))
+
+	clusterSpec.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+	g.Expect(providers.NodeGroups(clusterSpec)).To(Equal([]string{"control-plane", "etcd", "md-0", "md-1"}))
+}
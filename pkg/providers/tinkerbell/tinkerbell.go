@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -13,12 +14,16 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/substitution"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
+var l = logger.For(logger.Providers)
+
 const (
 	tinkerbellCertURLKey           = "TINKERBELL_CERT_URL"
 	tinkerbellGRPCAuthKey          = "TINKERBELL_GRPC_AUTHORITY"
@@ -41,6 +46,8 @@ var (
 	requiredEnvs                         = []string{tinkerbellCertURLKey, tinkerbellGRPCAuthKey, tinkerbellIPKey, tinkerbellPBnJGRPCAuthorityKey}
 )
 
+const accessDialTimeout = 5 * time.Second
+
 type tinkerbellProvider struct {
 	clusterConfig          *v1alpha1.Cluster
 	datacenterConfig       *v1alpha1.TinkerbellDatacenterConfig
@@ -51,6 +58,7 @@ type tinkerbellProvider struct {
 	providerKubectlClient ProviderKubectlClient
 	templateBuilder       *TinkerbellTemplateBuilder
 	hardwareConfigFile    string
+	netClient             networkutils.NetClient
 	// TODO: Update hardwareConfig to proper type
 }
 
@@ -84,6 +92,7 @@ func NewProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineC
 		machineConfigs:        machineConfigs,
 		providerKubectlClient: providerKubectlClient,
 		hardwareConfigFile:    hardwareConfigFile,
+		netClient:             &networkutils.DefaultNetClient{},
 		templateBuilder: &TinkerbellTemplateBuilder{
 			datacenterSpec:              &datacenterConfig.Spec,
 			controlPlaneMachineSpec:     controlPlaneMachineSpec,
@@ -94,20 +103,13 @@ func NewProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineC
 	}
 }
 
+// NoProxyEndpoints implements providers.ProxyEndpoints
+func (p *tinkerbellProvider) NoProxyEndpoints() []string {
+	return []string{p.datacenterConfig.Spec.TinkerbellIP}
+}
+
 func (p *tinkerbellProvider) BootstrapClusterOpts() ([]bootstrapper.BootstrapClusterOption, error) {
-	env := map[string]string{}
-	// Adding proxy environment vars to the bootstrap cluster
-	if p.clusterConfig.Spec.ProxyConfiguration != nil {
-		noProxy := fmt.Sprintf("%s,%s", p.clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, p.datacenterConfig.Spec.TinkerbellIP)
-		for _, s := range p.clusterConfig.Spec.ProxyConfiguration.NoProxy {
-			if s != "" {
-				noProxy += "," + s
-			}
-		}
-		env["HTTP_PROXY"] = p.clusterConfig.Spec.ProxyConfiguration.HttpProxy
-		env["HTTPS_PROXY"] = p.clusterConfig.Spec.ProxyConfiguration.HttpsProxy
-		env["NO_PROXY"] = noProxy
-	}
+	env := cluster.ProxyEnv(p.clusterConfig, p.NoProxyEndpoints()...)
 	return []bootstrapper.BootstrapClusterOption{bootstrapper.WithEnv(env)}, nil
 }
 
@@ -142,17 +144,73 @@ func (p *tinkerbellProvider) DeleteResources(ctx context.Context, clusterSpec *c
 }
 
 func (p *tinkerbellProvider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
-	logger.Info("Warning: The tinkerbell infrastructure provider is still in development and should not be used in production")
+	l.Info("Warning: The tinkerbell infrastructure provider is still in development and should not be used in production")
 	if err := setupEnvVars(p.datacenterConfig); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
 	}
 	p.controlPlaneSshAuthKey = p.machineConfigs[p.clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec.Users[0].SshAuthorizedKeys[0]
 	p.workerSshAuthKey = p.machineConfigs[p.clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name].Spec.Users[0].SshAuthorizedKeys[0]
+	if err := p.validateSudo(); err != nil {
+		return err
+	}
 	// TODO: Add more validations
 
 	return nil
 }
 
+// validateSudo checks that the sudo rules of the users, rendered as is in the bootstrap config, are single lines
+func (p *tinkerbellProvider) validateSudo() error {
+	for _, machineConfig := range p.machineConfigs {
+		for _, user := range machineConfig.Spec.Users {
+			if strings.ContainsAny(user.Sudo, "\r\n") {
+				return fmt.Errorf("sudo rule of user %s must be a single line", user.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAccess checks that the gRPC endpoints of the Tinkerbell server and PBnJ are reachable
+func (p *tinkerbellProvider) ValidateAccess(ctx context.Context) error {
+	if err := setupEnvVars(p.datacenterConfig); err != nil {
+		return fmt.Errorf("failed setup and validations: %v", err)
+	}
+
+	endpoints := []struct {
+		name    string
+		address string
+	}{
+		{name: "Tinkerbell server", address: p.datacenterConfig.Spec.TinkerbellGRPCAuth},
+		{name: "PBnJ", address: p.datacenterConfig.Spec.TinkerbellPBnJGRPCAuth},
+	}
+	for _, e := range endpoints {
+		conn, err := p.netClient.DialTimeout("tcp", e.address, accessDialTimeout)
+		if err != nil {
+			return fmt.Errorf("failed connecting to the %s at %s: %v", e.name, e.address, err)
+		}
+		conn.Close()
+		l.MarkPass(fmt.Sprintf("Connected to the %s", e.name))
+	}
+
+	return nil
+}
+
+// ListResources is not supported: the tinkerbell provider runs on existing hardware and doesn't create infrastructure resources
+func (p *tinkerbellProvider) ListResources(ctx context.Context, tags map[string]string) ([]providers.Resource, error) {
+	return nil, errors.New("tinkerbell provider doesn't support listing resources by tags")
+}
+
+// DeleteResource is not supported: the tinkerbell provider doesn't create infrastructure resources
+func (p *tinkerbellProvider) DeleteResource(ctx context.Context, resource providers.Resource) error {
+	return errors.New("tinkerbell provider doesn't support deleting resources")
+}
+
+// DeleteResourceTags is a no-op: the tinkerbell provider doesn't tag resources
+func (p *tinkerbellProvider) DeleteResourceTags(ctx context.Context, clusterSpec *cluster.Spec) error {
+	return nil
+}
+
 func (p *tinkerbellProvider) SetupAndValidateDeleteCluster(ctx context.Context) error {
 	// TODO: validations?
 	if err := setupEnvVars(p.datacenterConfig); err != nil {
@@ -213,7 +271,7 @@ func (vs *TinkerbellTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *c
 	if err != nil {
 		return nil, err
 	}
-	return bytes, nil
+	return substitution.ResolveSecrets(bytes)
 }
 
 func (vs *TinkerbellTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, templateNames map[string]string) (content []byte, err error) {
@@ -235,7 +293,7 @@ func (vs *TinkerbellTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluste
 		}
 		workerSpecs = append(workerSpecs, bytes)
 	}
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return substitution.ResolveSecrets(templater.AppendYamlResources(workerSpecs...))
 }
 
 func (p *tinkerbellProvider) GenerateCAPISpecForCreate(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
@@ -271,9 +329,9 @@ func (p *tinkerbellProvider) GenerateCAPISpecForUpgrade(ctx context.Context, boo
 	return nil, nil, nil
 }
 
-func (p *tinkerbellProvider) GenerateStorageClass() []byte {
+func (p *tinkerbellProvider) GenerateStorageClass() ([]byte, error) {
 	// TODO: determine if we need something else here
-	return nil
+	return nil, nil
 }
 
 func (p *tinkerbellProvider) GenerateMHC() ([]byte, error) {
@@ -390,6 +448,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec v1alp
 		"controlPlaneReplicas":         clusterSpec.Spec.ControlPlaneConfiguration.Count,
 		"controlPlaneSshAuthorizedKey": controlPlaneMachineSpec.Users[0].SshAuthorizedKeys,
 		"controlPlaneSshUsername":      controlPlaneMachineSpec.Users[0].Name,
+		"controlPlaneSshSudo":          controlPlaneMachineSpec.Users[0].Sudo,
 		"eksaSystemNamespace":          constants.EksaSystemNamespace,
 		"format":                       format,
 		"kubernetesVersion":            bundle.KubeDistro.Kubernetes.Tag,
@@ -421,6 +480,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		"workerPoolName":         "md-0",
 		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys,
 		"workerSshUsername":      workerNodeGroupMachineSpec.Users[0].Name,
+		"workerSshSudo":          workerNodeGroupMachineSpec.Users[0].Sudo,
 		"workertemplateOverride": workerNodeGroupMachineSpec.TemplateOverride,
 	}
 	return values
@@ -2,11 +2,15 @@ package tinkerbell
 
 import (
 	"context"
+	"errors"
+	"net"
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -142,3 +146,107 @@ func TestTinkerbellProviderGenerateDeploymentFile(t *testing.T) {
 	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp.yaml")
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
 }
+
+type fakeNetClient struct {
+	unreachable map[string]bool
+	dialed      []string
+}
+
+func (n *fakeNetClient) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	n.dialed = append(n.dialed, address)
+	if n.unreachable[address] {
+		return nil, errors.New("connection refused")
+	}
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func (n *fakeNetClient) LookupHost(host string) ([]string, error) {
+	return []string{host}, nil
+}
+
+func givenAccessProvider(t *testing.T, netClient *fakeNetClient) *tinkerbellProvider {
+	clusterSpecManifest := "cluster_tinkerbell.yaml"
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	datacenterConfig.Spec.TinkerbellGRPCAuth = expectedTinkerbellGRPCAuth
+	datacenterConfig.Spec.TinkerbellPBnJGRPCAuth = expectedTinkerbellPBnJGRPCAuthority
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file: %v", err)
+	}
+	provider := newProvider(t, datacenterConfig, givenMachineConfigs(t, clusterSpecManifest), clusterConfig, mocks.NewMockProviderKubectlClient(gomock.NewController(t)))
+	provider.netClient = netClient
+	return provider
+}
+
+func TestTinkerbellProviderValidateAccess(t *testing.T) {
+	setupContext(t)
+	g := NewWithT(t)
+	netClient := &fakeNetClient{}
+	provider := givenAccessProvider(t, netClient)
+
+	g.Expect(provider.ValidateAccess(context.Background())).To(Succeed())
+	g.Expect(netClient.dialed).To(Equal([]string{expectedTinkerbellGRPCAuth, expectedTinkerbellPBnJGRPCAuthority}))
+}
+
+func TestTinkerbellProviderValidateAccessPBnJUnreachable(t *testing.T) {
+	setupContext(t)
+	g := NewWithT(t)
+	netClient := &fakeNetClient{unreachable: map[string]bool{expectedTinkerbellPBnJGRPCAuthority: true}}
+	provider := givenAccessProvider(t, netClient)
+
+	g.Expect(provider.ValidateAccess(context.Background())).To(MatchError("failed connecting to the PBnJ at 1.2.3.4:42000: connection refused"))
+}
+
+func givenSudoProvider(t *testing.T, sudo string) *tinkerbellProvider {
+	clusterSpecManifest := "cluster_tinkerbell.yaml"
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file: %v", err)
+	}
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	// The control plane and the workers use the test-cp machine config
+	machineConfigs["test-cp"].Spec.Users[0].Sudo = sudo
+	return newProvider(t, givenDatacenterConfig(t, clusterSpecManifest), machineConfigs, clusterConfig, mocks.NewMockProviderKubectlClient(gomock.NewController(t)))
+}
+
+func givenTemplateClusterSpec(clusterConfig *v1alpha1.Cluster) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = clusterConfig
+		s.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.21.2-eks-1-21-4"
+	})
+}
+
+func TestTinkerbellTemplateBuilderSudo(t *testing.T) {
+	g := NewWithT(t)
+	provider := givenSudoProvider(t, "ALL=(ALL) NOPASSWD:/usr/bin/systemctl")
+	clusterSpec := givenTemplateClusterSpec(provider.clusterConfig)
+
+	cp, err := provider.templateBuilder.GenerateCAPISpecControlPlane(clusterSpec)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(cp)).To(ContainSubstring(`sudo: "ALL=(ALL) NOPASSWD:/usr/bin/systemctl"`))
+
+	md, err := provider.templateBuilder.GenerateCAPISpecWorkers(clusterSpec, map[string]string{"md-0": "test-md-0-1"})
+	g.Expect(err).To(BeNil())
+	g.Expect(string(md)).To(ContainSubstring(`sudo: "ALL=(ALL) NOPASSWD:/usr/bin/systemctl"`))
+}
+
+func TestTinkerbellTemplateBuilderDefaultSudo(t *testing.T) {
+	g := NewWithT(t)
+	provider := givenSudoProvider(t, "")
+	clusterSpec := givenTemplateClusterSpec(provider.clusterConfig)
+
+	cp, err := provider.templateBuilder.GenerateCAPISpecControlPlane(clusterSpec)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(cp)).To(ContainSubstring("sudo: ALL=(ALL) NOPASSWD:ALL"))
+}
+
+func TestTinkerbellProviderSetupAndValidateCreateClusterMultilineSudo(t *testing.T) {
+	setupContext(t)
+	g := NewWithT(t)
+	provider := givenSudoProvider(t, "ALL=(ALL) ALL\ntink-user ALL=(ALL) NOPASSWD:ALL")
+
+	err := provider.SetupAndValidateCreateCluster(context.Background(), givenTemplateClusterSpec(provider.clusterConfig))
+	g.Expect(err).To(MatchError("sudo rule of user tink-user must be a single line"))
+}
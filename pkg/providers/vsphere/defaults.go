@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/templates"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -56,24 +55,24 @@ func (d *Defaulter) SetDefaultsForDatacenterConfig(ctx context.Context, datacent
 
 func setDefaultsForEtcdMachineConfig(machineConfig *anywherev1.VSphereMachineConfig) {
 	if machineConfig != nil && machineConfig.Spec.MemoryMiB < 8192 {
-		logger.Info("Warning: VSphereMachineConfig MemoryMiB for etcd machines should not be less than 8192. Defaulting to 8192")
+		l.Info("Warning: VSphereMachineConfig MemoryMiB for etcd machines should not be less than 8192. Defaulting to 8192")
 		machineConfig.Spec.MemoryMiB = 8192
 	}
 }
 
 func setDefaultsForMachineConfig(machineConfig *anywherev1.VSphereMachineConfig) {
 	if machineConfig.Spec.MemoryMiB <= 0 {
-		logger.V(1).Info("VSphereMachineConfig MemoryMiB is not set or is empty. Defaulting to 8192.", "machineConfig", machineConfig.Name)
+		l.V(1).Info("VSphereMachineConfig MemoryMiB is not set or is empty. Defaulting to 8192.", "machineConfig", machineConfig.Name)
 		machineConfig.Spec.MemoryMiB = 8192
 	}
 
 	if machineConfig.Spec.MemoryMiB < 2048 {
-		logger.Info("Warning: VSphereMachineConfig MemoryMiB should not be less than 2048. Defaulting to 2048. Recommended memory is 8192.", "machineConfig", machineConfig.Name)
+		l.Info("Warning: VSphereMachineConfig MemoryMiB should not be less than 2048. Defaulting to 2048. Recommended memory is 8192.", "machineConfig", machineConfig.Name)
 		machineConfig.Spec.MemoryMiB = 2048
 	}
 
 	if machineConfig.Spec.NumCPUs <= 0 {
-		logger.V(1).Info("VSphereMachineConfig NumCPUs is not set or is empty. Defaulting to 2.", "machineConfig", machineConfig.Name)
+		l.V(1).Info("VSphereMachineConfig NumCPUs is not set or is empty. Defaulting to 2.", "machineConfig", machineConfig.Name)
 		machineConfig.Spec.NumCPUs = 2
 	}
 
@@ -86,7 +85,7 @@ func setDefaultsForMachineConfig(machineConfig *anywherev1.VSphereMachineConfig)
 	}
 
 	if machineConfig.Spec.OSFamily == "" {
-		logger.Info("Warning: OS family not specified in machine config specification. Defaulting to Bottlerocket.")
+		l.Info("Warning: OS family not specified in machine config specification. Defaulting to Bottlerocket.")
 		machineConfig.Spec.OSFamily = anywherev1.Bottlerocket
 	}
 
@@ -96,13 +95,16 @@ func setDefaultsForMachineConfig(machineConfig *anywherev1.VSphereMachineConfig)
 		} else {
 			machineConfig.Spec.Users[0].Name = ubuntuDefaultUser
 		}
-		logger.V(1).Info("SSHUsername is not set or is empty for VSphereMachineConfig, using default", "machineConfig", machineConfig.Name, "user", machineConfig.Spec.Users[0].Name)
+		l.V(1).Info("SSHUsername is not set or is empty for VSphereMachineConfig, using default", "machineConfig", machineConfig.Name, "user", machineConfig.Spec.Users[0].Name)
 	}
 }
 
 func (d *Defaulter) setDefaultTemplateIfMissing(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) error {
 	if machineConfig.Spec.Template == "" {
-		logger.V(1).Info("Control plane VSphereMachineConfig template is not set. Using default template.")
+		if spec.Cluster.Spec.FIPSEnabled {
+			return fmt.Errorf("template is required for VSphereMachineConfig %s when FIPS is enabled, the default templates are not FIPS enabled", machineConfig.Name)
+		}
+		l.V(1).Info("Control plane VSphereMachineConfig template is not set. Using default template.")
 		if err := d.setupDefaultTemplate(ctx, spec, machineConfig); err != nil {
 			return err
 		}
@@ -113,7 +115,7 @@ func (d *Defaulter) setDefaultTemplateIfMissing(ctx context.Context, spec *Spec,
 
 func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) error {
 	osFamily := machineConfig.Spec.OSFamily
-	eksd := spec.VersionsBundle.EksD
+	eksd := spec.versionsBundle(machineConfig).EksD
 	var ova releasev1.OvaArchive
 	switch osFamily {
 	case anywherev1.Bottlerocket:
@@ -127,7 +129,7 @@ func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machin
 	templateName := fmt.Sprintf("%s-%s-%s-%s-%s", osFamily, eksd.KubeVersion, eksd.Name, strings.Join(ova.Arch, "-"), ova.SHA256[:7])
 	machineConfig.Spec.Template = filepath.Join("/", spec.datacenterConfig.Spec.Datacenter, defaultTemplatesFolder, templateName)
 
-	tags := requiredTemplateTagsByCategory(spec.Spec, machineConfig)
+	tags := requiredTemplateTagsByCategory(spec, machineConfig)
 
 	// TODO: figure out if it's worth refactoring the factory to be able to reuse across machine configs.
 	templateFactory := templates.NewFactory(d.govc, spec.datacenterConfig.Spec.Datacenter, machineConfig.Spec.Datastore, machineConfig.Spec.ResourcePool, defaultTemplateLibrary)
@@ -147,13 +149,13 @@ func (d *Defaulter) setDiskDefaults(ctx context.Context, machineConfig *anywhere
 	}
 
 	if !templateHasSnapshot {
-		logger.Info("Warning: Your VM template has no snapshots. Defaulting to FullClone mode. VM provisioning might take longer.")
+		l.Info("Warning: Your VM template has no snapshots. Defaulting to FullClone mode. VM provisioning might take longer.")
 		if machineConfig.Spec.DiskGiB < 20 {
-			logger.Info("Warning: VSphereMachineConfig DiskGiB cannot be less than 20. Defaulting to 20.")
+			l.Info("Warning: VSphereMachineConfig DiskGiB cannot be less than 20. Defaulting to 20.")
 			machineConfig.Spec.DiskGiB = 20
 		}
 	} else if machineConfig.Spec.DiskGiB != 25 {
-		logger.Info("Warning: Your VM template includes snapshot(s). LinkedClone mode will be used. DiskGiB cannot be customizable as disks cannot be expanded when using LinkedClone mode. Using default of 25 for DiskGiBs.")
+		l.Info("Warning: Your VM template includes snapshot(s). LinkedClone mode will be used. DiskGiB cannot be customizable as disks cannot be expanded when using LinkedClone mode. Using default of 25 for DiskGiBs.")
 		machineConfig.Spec.DiskGiB = 25
 	}
 
@@ -0,0 +1,191 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const noneOption = "(none)"
+
+// DiscoveryGovcClient is the subset of govc needed to discover the vSphere inventory
+type DiscoveryGovcClient interface {
+	ValidateVCenterAuthentication(ctx context.Context) error
+	IsCertSelfSigned(ctx context.Context) bool
+	GetCertThumbprint(ctx context.Context) (string, error)
+	ConfigureCertThumbprint(ctx context.Context, server, thumbprint string) error
+	ListDatacenters(ctx context.Context) ([]string, error)
+	ListNetworks(ctx context.Context, datacenter string) ([]string, error)
+	ListDatastores(ctx context.Context, datacenter string) ([]string, error)
+	ListResourcePools(ctx context.Context, datacenter string) ([]string, error)
+	ListVMFolders(ctx context.Context, datacenter string) ([]string, error)
+	ListTemplates(ctx context.Context, datacenter string) ([]string, error)
+}
+
+// Prompter asks the user to choose between the discovered values
+type Prompter interface {
+	Select(label string, options []string) (string, error)
+}
+
+// DiscoveryConfig holds the vSphere values used to generate a cluster config.
+// Values already set are validated against the inventory instead of discovered
+type DiscoveryConfig struct {
+	Server       string
+	Thumbprint   string
+	Insecure     bool
+	Datacenter   string
+	Network      string
+	Datastore    string
+	ResourcePool string
+	Folder       string
+	Template     string
+}
+
+// Discovery fills a DiscoveryConfig with values from the vSphere inventory. A value is picked
+// automatically when there is only one option, otherwise the prompter asks the user to choose.
+// Without a prompter, the values with more than one option need to be provided
+type Discovery struct {
+	govc     DiscoveryGovcClient
+	prompter Prompter
+}
+
+func NewDiscovery(govc DiscoveryGovcClient, prompter Prompter) *Discovery {
+	return &Discovery{
+		govc:     govc,
+		prompter: prompter,
+	}
+}
+
+type discoveredValue struct {
+	name     string
+	flag     string
+	value    *string
+	list     func(ctx context.Context, datacenter string) ([]string, error)
+	optional bool
+}
+
+func (d *Discovery) Discover(ctx context.Context, config *DiscoveryConfig) error {
+	if config.Server == "" {
+		return fmt.Errorf("vSphere server is required to discover the cluster config values")
+	}
+
+	if err := d.setupConnection(ctx, config); err != nil {
+		return err
+	}
+
+	datacenter := discoveredValue{
+		name:  "datacenter",
+		flag:  "datacenter",
+		value: &config.Datacenter,
+		list: func(ctx context.Context, _ string) ([]string, error) {
+			return d.govc.ListDatacenters(ctx)
+		},
+	}
+	if err := d.discover(ctx, "", datacenter); err != nil {
+		return err
+	}
+
+	values := []discoveredValue{
+		{name: "network", flag: "network", value: &config.Network, list: d.govc.ListNetworks},
+		{name: "datastore", flag: "datastore", value: &config.Datastore, list: d.govc.ListDatastores},
+		{name: "resource pool", flag: "resource-pool", value: &config.ResourcePool, list: d.govc.ListResourcePools},
+		{name: "vm folder", flag: "folder", value: &config.Folder, list: d.govc.ListVMFolders, optional: true},
+		{name: "template", flag: "template", value: &config.Template, list: d.govc.ListTemplates, optional: true},
+	}
+	for _, v := range values {
+		if err := d.discover(ctx, config.Datacenter, v); err != nil {
+			return err
+		}
+	}
+
+	if config.Template == "" {
+		l.Info("No template selected, the default template for the OS family will be imported when creating the cluster")
+	}
+
+	return nil
+}
+
+func (d *Discovery) setupConnection(ctx context.Context, config *DiscoveryConfig) error {
+	if err := os.Setenv(vSphereServerKey, config.Server); err != nil {
+		return fmt.Errorf("unable to set %s: %v", vSphereServerKey, err)
+	}
+	if err := os.Setenv(govcInsecure, strconv.FormatBool(config.Insecure)); err != nil {
+		return fmt.Errorf("unable to set %s: %v", govcInsecure, err)
+	}
+
+	if err := d.govc.ValidateVCenterAuthentication(ctx); err != nil {
+		return err
+	}
+
+	if config.Insecure {
+		return nil
+	}
+
+	if config.Thumbprint == "" && d.govc.IsCertSelfSigned(ctx) {
+		thumbprint, err := d.govc.GetCertThumbprint(ctx)
+		if err != nil {
+			return err
+		}
+		l.Info("vCenter uses a self signed certificate, adding its thumbprint to the cluster config", "thumbprint", thumbprint)
+		config.Thumbprint = thumbprint
+	}
+
+	if config.Thumbprint != "" {
+		if err := d.govc.ConfigureCertThumbprint(ctx, config.Server, config.Thumbprint); err != nil {
+			return fmt.Errorf("failed configuring govc cert thumbprint: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Discovery) discover(ctx context.Context, datacenter string, v discoveredValue) error {
+	options, err := v.list(ctx, datacenter)
+	if err != nil {
+		return err
+	}
+
+	if *v.value != "" {
+		for _, o := range options {
+			if o == *v.value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s %s not found, available values: %s", v.name, *v.value, strings.Join(options, ", "))
+	}
+
+	switch {
+	case len(options) == 0 && v.optional:
+		return nil
+	case len(options) == 0:
+		return fmt.Errorf("no %s found", v.name)
+	case len(options) == 1 && !v.optional:
+		l.V(2).Info("Using the only available value", v.name, options[0])
+		*v.value = options[0]
+		return nil
+	case d.prompter != nil:
+		return d.prompt(v, options)
+	case v.optional:
+		return nil
+	default:
+		return fmt.Errorf("found more than one %s, choose one with --%s: %s", v.name, v.flag, strings.Join(options, ", "))
+	}
+}
+
+func (d *Discovery) prompt(v discoveredValue, options []string) error {
+	if v.optional {
+		options = append([]string{noneOption}, options...)
+	}
+
+	selected, err := d.prompter.Select(v.name, options)
+	if err != nil {
+		return fmt.Errorf("failed selecting %s: %v", v.name, err)
+	}
+	if selected != noneOption {
+		*v.value = selected
+	}
+
+	return nil
+}
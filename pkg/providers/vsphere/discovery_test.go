@@ -0,0 +1,131 @@
+package vsphere_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+)
+
+type discoveryTest struct {
+	*WithT
+	ctx      context.Context
+	govc     *mocks.MockDiscoveryGovcClient
+	prompter *mocks.MockPrompter
+	config   *vsphere.DiscoveryConfig
+}
+
+func newDiscoveryTest(t *testing.T) *discoveryTest {
+	for _, env := range []string{"VSPHERE_SERVER", "GOVC_INSECURE"} {
+		value, ok := os.LookupEnv(env)
+		env := env
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(env, value)
+			} else {
+				os.Unsetenv(env)
+			}
+		})
+	}
+
+	ctrl := gomock.NewController(t)
+	return &discoveryTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		govc:     mocks.NewMockDiscoveryGovcClient(ctrl),
+		prompter: mocks.NewMockPrompter(ctrl),
+		config:   &vsphere.DiscoveryConfig{Server: "vcenter.example.com", Insecure: true},
+	}
+}
+
+func (tt *discoveryTest) expectInventory() {
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx).Return(nil)
+	tt.govc.EXPECT().ListDatacenters(tt.ctx).Return([]string{"SDDC-Datacenter"}, nil)
+	tt.govc.EXPECT().ListNetworks(tt.ctx, "SDDC-Datacenter").Return([]string{"/SDDC-Datacenter/network/sddc-cgw-network-1"}, nil)
+	tt.govc.EXPECT().ListDatastores(tt.ctx, "SDDC-Datacenter").Return([]string{"/SDDC-Datacenter/datastore/ds-1", "/SDDC-Datacenter/datastore/ds-2"}, nil)
+	tt.govc.EXPECT().ListResourcePools(tt.ctx, "SDDC-Datacenter").Return([]string{"/SDDC-Datacenter/host/Cluster-1/Resources"}, nil)
+	tt.govc.EXPECT().ListVMFolders(tt.ctx, "SDDC-Datacenter").Return([]string{"/SDDC-Datacenter/vm/eksa"}, nil)
+	tt.govc.EXPECT().ListTemplates(tt.ctx, "SDDC-Datacenter").Return(nil, nil)
+}
+
+func TestDiscoverInteractive(t *testing.T) {
+	tt := newDiscoveryTest(t)
+	tt.expectInventory()
+	tt.prompter.EXPECT().Select("datastore", []string{"/SDDC-Datacenter/datastore/ds-1", "/SDDC-Datacenter/datastore/ds-2"}).Return("/SDDC-Datacenter/datastore/ds-2", nil)
+	tt.prompter.EXPECT().Select("vm folder", []string{"(none)", "/SDDC-Datacenter/vm/eksa"}).Return("/SDDC-Datacenter/vm/eksa", nil)
+
+	d := vsphere.NewDiscovery(tt.govc, tt.prompter)
+	tt.Expect(d.Discover(tt.ctx, tt.config)).To(Succeed())
+	tt.Expect(tt.config).To(Equal(&vsphere.DiscoveryConfig{
+		Server:       "vcenter.example.com",
+		Insecure:     true,
+		Datacenter:   "SDDC-Datacenter",
+		Network:      "/SDDC-Datacenter/network/sddc-cgw-network-1",
+		Datastore:    "/SDDC-Datacenter/datastore/ds-2",
+		ResourcePool: "/SDDC-Datacenter/host/Cluster-1/Resources",
+		Folder:       "/SDDC-Datacenter/vm/eksa",
+	}))
+	tt.Expect(os.Getenv("VSPHERE_SERVER")).To(Equal("vcenter.example.com"))
+	tt.Expect(os.Getenv("GOVC_INSECURE")).To(Equal("true"))
+}
+
+func TestDiscoverFromFlags(t *testing.T) {
+	tt := newDiscoveryTest(t)
+	tt.expectInventory()
+	tt.config.Datastore = "/SDDC-Datacenter/datastore/ds-1"
+
+	d := vsphere.NewDiscovery(tt.govc, nil)
+	tt.Expect(d.Discover(tt.ctx, tt.config)).To(Succeed())
+	tt.Expect(tt.config.Datastore).To(Equal("/SDDC-Datacenter/datastore/ds-1"))
+	tt.Expect(tt.config.Folder).To(BeEmpty())
+	tt.Expect(tt.config.Template).To(BeEmpty())
+}
+
+func TestDiscoverMultipleValuesNotInteractive(t *testing.T) {
+	tt := newDiscoveryTest(t)
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx).Return(nil)
+	tt.govc.EXPECT().ListDatacenters(tt.ctx).Return([]string{"SDDC-Datacenter"}, nil)
+	tt.govc.EXPECT().ListNetworks(tt.ctx, "SDDC-Datacenter").Return([]string{"/SDDC-Datacenter/network/sddc-cgw-network-1"}, nil)
+	tt.govc.EXPECT().ListDatastores(tt.ctx, "SDDC-Datacenter").Return([]string{"/SDDC-Datacenter/datastore/ds-1", "/SDDC-Datacenter/datastore/ds-2"}, nil)
+
+	d := vsphere.NewDiscovery(tt.govc, nil)
+	tt.Expect(d.Discover(tt.ctx, tt.config)).To(MatchError(ContainSubstring("found more than one datastore, choose one with --datastore")))
+}
+
+func TestDiscoverValueNotFound(t *testing.T) {
+	tt := newDiscoveryTest(t)
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx).Return(nil)
+	tt.govc.EXPECT().ListDatacenters(tt.ctx).Return([]string{"SDDC-Datacenter"}, nil)
+	tt.config.Datacenter = "Other-Datacenter"
+
+	d := vsphere.NewDiscovery(tt.govc, nil)
+	tt.Expect(d.Discover(tt.ctx, tt.config)).To(MatchError("datacenter Other-Datacenter not found, available values: SDDC-Datacenter"))
+}
+
+func TestDiscoverSelfSignedCert(t *testing.T) {
+	tt := newDiscoveryTest(t)
+	tt.config.Insecure = false
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx).Return(nil)
+	tt.govc.EXPECT().IsCertSelfSigned(tt.ctx).Return(true)
+	tt.govc.EXPECT().GetCertThumbprint(tt.ctx).Return("AB:CD", nil)
+	tt.govc.EXPECT().ConfigureCertThumbprint(tt.ctx, "vcenter.example.com", "AB:CD").Return(nil)
+	tt.govc.EXPECT().ListDatacenters(tt.ctx).Return(nil, errors.New("error listing"))
+
+	d := vsphere.NewDiscovery(tt.govc, nil)
+	tt.Expect(d.Discover(tt.ctx, tt.config)).To(MatchError("error listing"))
+	tt.Expect(tt.config.Thumbprint).To(Equal("AB:CD"))
+}
+
+func TestDiscoverMissingServer(t *testing.T) {
+	tt := newDiscoveryTest(t)
+	tt.config.Server = ""
+
+	d := vsphere.NewDiscovery(tt.govc, nil)
+	tt.Expect(d.Discover(tt.ctx, tt.config)).To(MatchError(ContainSubstring("vSphere server is required")))
+}
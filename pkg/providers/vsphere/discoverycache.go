@@ -0,0 +1,239 @@
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	discoveryCacheFile       = "vsphere-discovery-cache.json"
+	defaultDiscoveryCacheTTL = time.Hour
+	// DiscoveryCacheTTLEnvVar overrides how long the discovery results are reused, 0 disables the cache
+	DiscoveryCacheTTLEnvVar = "EKSA_VSPHERE_DISCOVERY_CACHE_TTL"
+)
+
+type discoveryCacheEntry struct {
+	Value   json.RawMessage `json:"value"`
+	Expires time.Time       `json:"expires"`
+}
+
+// machineConfigSetup is the result of ValidateVCenterSetupMachineConfig, the full paths it sets in the machine config
+type machineConfigSetup struct {
+	Datastore    string `json:"datastore"`
+	Folder       string `json:"folder"`
+	ResourcePool string `json:"resourcePool"`
+}
+
+// discoveryCache wraps the govc client to reuse the discovery results validated by previous operations against
+// the same vCenter, like datastore, network and template paths and template tags. They are persisted in the cluster
+// folder until they expire. Only the results of previous operations are reused, so every operation validates the
+// environment once and large vCenters aren't queried again for the same lookups on each run
+type discoveryCache struct {
+	ProviderGovcClient
+	writer   filewriter.FileWriter
+	server   string
+	ttl      time.Duration
+	now      types.NowFunc
+	previous map[string]discoveryCacheEntry
+	current  map[string]discoveryCacheEntry
+	// loaded tells whether the results of the previous operations were read. They're read on first use,
+	// since the writer folder isn't known until the provider is used
+	loaded bool
+	// templates are the template paths found, only their tags are cached since VM tags change
+	templates map[string]bool
+}
+
+// newDiscoveryCache returns nil when the cache is disabled
+func newDiscoveryCache(govc ProviderGovcClient, writer filewriter.FileWriter, server string, now types.NowFunc) *discoveryCache {
+	ttl := defaultDiscoveryCacheTTL
+	if env, ok := os.LookupEnv(DiscoveryCacheTTLEnvVar); ok {
+		d, err := time.ParseDuration(env)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid vSphere discovery cache ttl", "env", DiscoveryCacheTTLEnvVar, "value", env)
+		} else {
+			ttl = d
+		}
+	}
+	if ttl <= 0 || writer == nil {
+		return nil
+	}
+
+	c := &discoveryCache{
+		ProviderGovcClient: govc,
+		writer:             writer,
+		server:             server,
+		ttl:                ttl,
+		now:                now,
+		previous:           map[string]discoveryCacheEntry{},
+		current:            map[string]discoveryCacheEntry{},
+		templates:          map[string]bool{},
+	}
+
+	return c
+}
+
+func (c *discoveryCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	content, err := ioutil.ReadFile(filepath.Join(c.writer.Dir(), discoveryCacheFile))
+	if err != nil {
+		return
+	}
+	entries := map[string]discoveryCacheEntry{}
+	if err = json.Unmarshal(content, &entries); err != nil {
+		logger.V(4).Info("Ignoring invalid vSphere discovery cache", "error", err)
+		return
+	}
+
+	now := c.now()
+	for k, e := range entries {
+		if now.Before(e.Expires) {
+			c.previous[k] = e
+			c.current[k] = e
+		}
+	}
+}
+
+func (c *discoveryCache) key(parts ...string) string {
+	return strings.Join(append([]string{c.server}, parts...), "|")
+}
+
+// get unmarshals into value the result of a previous operation for key, returning false if there's none
+func (c *discoveryCache) get(key string, value interface{}) bool {
+	c.load()
+	e, ok := c.previous[key]
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(e.Value, value); err != nil {
+		return false
+	}
+	logger.V(4).Info("Using cached vSphere discovery result", "key", key)
+
+	return true
+}
+
+func (c *discoveryCache) set(key string, value interface{}) {
+	c.load()
+	content, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	c.current[key] = discoveryCacheEntry{Value: content, Expires: c.now().Add(c.ttl)}
+}
+
+// save persists the discovery results once the provider validations succeed, so results that failed
+// them, like a template missing tags that the user fixes, are looked up again by the next operation
+func (c *discoveryCache) save() {
+	if c == nil {
+		return
+	}
+	c.load()
+	content, err := json.MarshalIndent(c.current, "", "  ")
+	if err != nil {
+		return
+	}
+	if _, err = c.writer.Write(discoveryCacheFile, content, filewriter.PersistentFile); err != nil {
+		logger.V(4).Info("Failed writing vSphere discovery cache", "error", err)
+	}
+}
+
+func (c *discoveryCache) DatacenterExists(ctx context.Context, datacenter string) (bool, error) {
+	key := c.key("datacenter", datacenter)
+	var exists bool
+	if c.get(key, &exists) {
+		return exists, nil
+	}
+
+	exists, err := c.ProviderGovcClient.DatacenterExists(ctx, datacenter)
+	if err == nil && exists {
+		c.set(key, exists)
+	}
+
+	return exists, err
+}
+
+func (c *discoveryCache) NetworkExists(ctx context.Context, network string) (bool, error) {
+	key := c.key("network", network)
+	var exists bool
+	if c.get(key, &exists) {
+		return exists, nil
+	}
+
+	exists, err := c.ProviderGovcClient.NetworkExists(ctx, network)
+	if err == nil && exists {
+		c.set(key, exists)
+	}
+
+	return exists, err
+}
+
+func (c *discoveryCache) SearchTemplate(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig) (string, error) {
+	key := c.key("template", datacenter, machineConfig.Spec.Template)
+	var path string
+	if c.get(key, &path) {
+		c.templates[path] = true
+		return path, nil
+	}
+
+	path, err := c.ProviderGovcClient.SearchTemplate(ctx, datacenter, machineConfig)
+	if err == nil && path != "" {
+		c.templates[path] = true
+		c.set(key, path)
+	}
+
+	return path, err
+}
+
+func (c *discoveryCache) GetTags(ctx context.Context, path string) ([]string, error) {
+	if !c.templates[path] {
+		return c.ProviderGovcClient.GetTags(ctx, path)
+	}
+
+	key := c.key("tags", path)
+	var tags []string
+	if c.get(key, &tags) {
+		return tags, nil
+	}
+
+	tags, err := c.ProviderGovcClient.GetTags(ctx, path)
+	if err == nil {
+		c.set(key, tags)
+	}
+
+	return tags, err
+}
+
+func (c *discoveryCache) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, selfSigned *bool) error {
+	key := c.key("machineconfig", datacenterConfig.Spec.Datacenter, machineConfig.Spec.Datastore, machineConfig.Spec.Folder, machineConfig.Spec.ResourcePool)
+	setup := &machineConfigSetup{}
+	if c.get(key, setup) {
+		machineConfig.Spec.Datastore = setup.Datastore
+		machineConfig.Spec.Folder = setup.Folder
+		machineConfig.Spec.ResourcePool = setup.ResourcePool
+		return nil
+	}
+
+	if err := c.ProviderGovcClient.ValidateVCenterSetupMachineConfig(ctx, datacenterConfig, machineConfig, selfSigned); err != nil {
+		return err
+	}
+	c.set(key, &machineConfigSetup{
+		Datastore:    machineConfig.Spec.Datastore,
+		Folder:       machineConfig.Spec.Folder,
+		ResourcePool: machineConfig.Spec.ResourcePool,
+	})
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package vsphere
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+)
+
+// newTestWriter returns a writer in a temp folder, so the discovery cache of the tests isn't left in the package
+func newTestWriter(t *testing.T) filewriter.FileWriter {
+	writer, err := filewriter.NewWriter(t.TempDir())
+	if err != nil {
+		t.Fatalf("error creating writer for test: %v", err)
+	}
+	return writer
+}
+
+func TestDiscoveryCacheReusesSavedResults(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	writer := newTestWriter(t)
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	now := time.Now()
+	clock := func() time.Time { return now }
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "SDDC"}}
+	newMachineConfig := func() *v1alpha1.VSphereMachineConfig {
+		return &v1alpha1.VSphereMachineConfig{Spec: v1alpha1.VSphereMachineConfigSpec{
+			Template: "ubuntu", Datastore: "ds", ResourcePool: "*/Resources",
+		}}
+	}
+
+	govc.EXPECT().NetworkExists(ctx, "/SDDC/network/VM Network").Return(true, nil)
+	govc.EXPECT().SearchTemplate(ctx, "SDDC", gomock.Any()).Return("/SDDC/vm/ubuntu", nil)
+	govc.EXPECT().GetTags(ctx, "/SDDC/vm/ubuntu").Return([]string{"os:ubuntu"}, nil)
+	govc.EXPECT().ValidateVCenterSetupMachineConfig(ctx, datacenterConfig, gomock.Any(), nil).DoAndReturn(
+		func(_ context.Context, _ *v1alpha1.VSphereDatacenterConfig, m *v1alpha1.VSphereMachineConfig, _ *bool) error {
+			m.Spec.Datastore = "/SDDC/datastore/ds"
+			m.Spec.ResourcePool = "/SDDC/host/Cluster/Resources"
+			return nil
+		},
+	)
+	govc.EXPECT().GetTags(ctx, "/SDDC/vm/test-vm").Return([]string{"team:a"}, nil).Times(2)
+
+	first := newDiscoveryCache(govc, writer, "vcenter", clock)
+	g.Expect(first.NetworkExists(ctx, "/SDDC/network/VM Network")).To(BeTrue())
+	g.Expect(first.SearchTemplate(ctx, "SDDC", newMachineConfig())).To(Equal("/SDDC/vm/ubuntu"))
+	g.Expect(first.GetTags(ctx, "/SDDC/vm/ubuntu")).To(Equal([]string{"os:ubuntu"}))
+	g.Expect(first.ValidateVCenterSetupMachineConfig(ctx, datacenterConfig, newMachineConfig(), nil)).To(Succeed())
+	g.Expect(first.GetTags(ctx, "/SDDC/vm/test-vm")).To(Equal([]string{"team:a"}))
+	first.save()
+
+	second := newDiscoveryCache(govc, writer, "vcenter", clock)
+	g.Expect(second.NetworkExists(ctx, "/SDDC/network/VM Network")).To(BeTrue())
+	g.Expect(second.SearchTemplate(ctx, "SDDC", newMachineConfig())).To(Equal("/SDDC/vm/ubuntu"))
+	g.Expect(second.GetTags(ctx, "/SDDC/vm/ubuntu")).To(Equal([]string{"os:ubuntu"}))
+	machineConfig := newMachineConfig()
+	g.Expect(second.ValidateVCenterSetupMachineConfig(ctx, datacenterConfig, machineConfig, nil)).To(Succeed())
+	g.Expect(machineConfig.Spec.Datastore).To(Equal("/SDDC/datastore/ds"))
+	g.Expect(machineConfig.Spec.ResourcePool).To(Equal("/SDDC/host/Cluster/Resources"))
+	g.Expect(second.GetTags(ctx, "/SDDC/vm/test-vm")).To(Equal([]string{"team:a"}), "VM tags are not cached")
+}
+
+func TestDiscoveryCacheExpiredOrNotSaved(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	writer := newTestWriter(t)
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	govc.EXPECT().DatacenterExists(ctx, "SDDC").Return(true, nil).Times(3)
+
+	notSaved := newDiscoveryCache(govc, writer, "vcenter", clock)
+	g.Expect(notSaved.DatacenterExists(ctx, "SDDC")).To(BeTrue())
+
+	saved := newDiscoveryCache(govc, writer, "vcenter", clock)
+	g.Expect(saved.DatacenterExists(ctx, "SDDC")).To(BeTrue())
+	saved.save()
+
+	now = now.Add(defaultDiscoveryCacheTTL + time.Second)
+	expired := newDiscoveryCache(govc, writer, "vcenter", clock)
+	g.Expect(expired.DatacenterExists(ctx, "SDDC")).To(BeTrue())
+}
+
+func TestDiscoveryCacheDisabled(t *testing.T) {
+	g := NewWithT(t)
+	writer := newTestWriter(t)
+	os.Setenv(DiscoveryCacheTTLEnvVar, "0")
+	defer os.Unsetenv(DiscoveryCacheTTLEnvVar)
+
+	g.Expect(newDiscoveryCache(nil, writer, "vcenter", time.Now)).To(BeNil())
+}
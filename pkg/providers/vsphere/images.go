@@ -0,0 +1,49 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/images"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/templates"
+)
+
+// TemplateRegistrar imports the OVAs built by image-builder as templates of the datacenter. They are tagged
+// like the default templates, so the template validations accept them for the EKS-D release they were built for
+type TemplateRegistrar struct {
+	govc             ProviderGovcClient
+	datacenterConfig *anywherev1.VSphereDatacenterConfig
+	machineConfig    *anywherev1.VSphereMachineConfig
+}
+
+// NewTemplateRegistrar returns a registrar that deploys the templates in the datastore and resource pool of machineConfig
+func NewTemplateRegistrar(govc ProviderGovcClient, datacenterConfig *anywherev1.VSphereDatacenterConfig, machineConfig *anywherev1.VSphereMachineConfig) *TemplateRegistrar {
+	return &TemplateRegistrar{
+		govc:             govc,
+		datacenterConfig: datacenterConfig,
+		machineConfig:    machineConfig,
+	}
+}
+
+// RegisterImage imports the OVA in the templates library, unless a template with the same name exists, and returns the template path
+func (r *TemplateRegistrar) RegisterImage(ctx context.Context, image *images.Image) (string, error) {
+	if image.Hypervisor != images.VSphere {
+		return "", fmt.Errorf("vsphere can't register %s images", image.Hypervisor)
+	}
+
+	datacenter := r.datacenterConfig.Spec.Datacenter
+	template := &anywherev1.VSphereMachineConfig{
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			Template: filepath.Join("/", datacenter, defaultTemplatesFolder, image.Name),
+			OSFamily: image.OSFamily,
+		},
+	}
+	factory := templates.NewFactory(r.govc, datacenter, r.machineConfig.Spec.Datastore, r.machineConfig.Spec.ResourcePool, defaultTemplateLibrary)
+	if err := factory.CreateIfMissing(ctx, datacenter, template, image.Path, templateTagsByCategory(image.EksDRelease, image.OSFamily)); err != nil {
+		return "", err
+	}
+
+	return template.Spec.Template, nil
+}
@@ -0,0 +1,65 @@
+package vsphere_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/images"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+)
+
+func newTemplateRegistrar(t *testing.T) (*vsphere.TemplateRegistrar, *mocks.MockProviderGovcClient) {
+	govc := mocks.NewMockProviderGovcClient(gomock.NewController(t))
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "SDDC-Datacenter"}}
+	machineConfig := &v1alpha1.VSphereMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cp"},
+		Spec:       v1alpha1.VSphereMachineConfigSpec{Datastore: "datastore", ResourcePool: "pool"},
+	}
+	return vsphere.NewTemplateRegistrar(govc, datacenterConfig, machineConfig), govc
+}
+
+func TestTemplateRegistrarRegisterImageExistingTemplate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	registrar, govc := newTemplateRegistrar(t)
+	image := &images.Image{
+		Name:        "ubuntu-v1.21.2-kubernetes-1-21-eks-4-custom-1234567",
+		Path:        "cluster/images/ubuntu.ova",
+		OSFamily:    v1alpha1.Ubuntu,
+		Hypervisor:  images.VSphere,
+		EksDRelease: "kubernetes-1-21-eks-4",
+	}
+	templatePath := "/SDDC-Datacenter/vm/Templates/ubuntu-v1.21.2-kubernetes-1-21-eks-4-custom-1234567"
+	govc.EXPECT().SearchTemplate(ctx, "SDDC-Datacenter", gomock.Any()).Return(templatePath, nil)
+
+	g.Expect(registrar.RegisterImage(ctx, image)).To(Equal(templatePath))
+}
+
+func TestTemplateRegistrarRegisterImageImportError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	registrar, govc := newTemplateRegistrar(t)
+	image := &images.Image{Name: "custom", Path: "ubuntu.ova", OSFamily: v1alpha1.Ubuntu, Hypervisor: images.VSphere}
+	govc.EXPECT().SearchTemplate(ctx, "SDDC-Datacenter", gomock.Any()).Return("", nil)
+	govc.EXPECT().LibraryElementExists(ctx, "eks-a-templates").Return(true, nil)
+	govc.EXPECT().GetLibraryElementContentVersion(ctx, "eks-a-templates/custom").Return("-1", nil)
+	govc.EXPECT().ImportTemplate(ctx, "eks-a-templates", "ubuntu.ova", "custom").Return(errors.New("no space"))
+
+	_, err := registrar.RegisterImage(ctx, image)
+	g.Expect(err).To(MatchError("failed importing template into library: no space"))
+}
+
+func TestTemplateRegistrarRegisterImageWrongHypervisor(t *testing.T) {
+	g := NewWithT(t)
+	registrar, _ := newTemplateRegistrar(t)
+
+	_, err := registrar.RegisterImage(context.Background(), &images.Image{Hypervisor: images.BareMetal})
+	g.Expect(err).To(MatchError("vsphere can't register baremetal images"))
+}
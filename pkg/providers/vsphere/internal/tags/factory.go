@@ -8,6 +8,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+var l = logger.For(logger.Providers)
+
 type Factory struct {
 	client GovcClient
 }
@@ -18,6 +20,8 @@ type GovcClient interface {
 	AddTag(ctx context.Context, path, tag string) error
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
+	CreateCategoryForVMAndFolder(ctx context.Context, name string) error
+	TagIDs(ctx context.Context) (map[string]string, error)
 }
 
 func NewFactory(client GovcClient) *Factory {
@@ -25,7 +29,7 @@ func NewFactory(client GovcClient) *Factory {
 }
 
 func (f *Factory) TagTemplate(ctx context.Context, templatePath string, tagsByCategory map[string][]string) error {
-	logger.V(2).Info("Tagging template", "template", templatePath)
+	l.V(2).Info("Tagging template", "template", templatePath)
 	categories, err := f.client.ListCategories(ctx)
 	if err != nil {
 		return fmt.Errorf("failed listing vsphere categories: %v", err)
@@ -40,7 +44,7 @@ func (f *Factory) TagTemplate(ctx context.Context, templatePath string, tagsByCa
 	tagsLookup := types.SliceToLookup(tags)
 	for category, tags := range tagsByCategory {
 		if !categoriesLookup.IsPresent(category) {
-			logger.V(3).Info("Creating category", "category", category)
+			l.V(3).Info("Creating category", "category", category)
 			if err = f.client.CreateCategoryForVM(ctx, category); err != nil {
 				return fmt.Errorf("failed creating category for tags: %v", err)
 			}
@@ -48,13 +52,13 @@ func (f *Factory) TagTemplate(ctx context.Context, templatePath string, tagsByCa
 
 		for _, tag := range tags {
 			if !tagsLookup.IsPresent(tag) {
-				logger.V(3).Info("Creating tag", "tag", tag, "category", category)
+				l.V(3).Info("Creating tag", "tag", tag, "category", category)
 				if err = f.client.CreateTag(ctx, tag, category); err != nil {
 					return fmt.Errorf("failed creating tag before tagging template: %v", err)
 				}
 			}
 
-			logger.V(3).Info("Adding tag to template", "tag", tag, "template", templatePath)
+			l.V(3).Info("Adding tag to template", "tag", tag, "template", templatePath)
 			if err = f.client.AddTag(ctx, templatePath, tag); err != nil {
 				return fmt.Errorf("failed tagging template: %v", err)
 			}
@@ -63,3 +67,48 @@ func (f *Factory) TagTemplate(ctx context.Context, templatePath string, tagsByCa
 
 	return nil
 }
+
+// EnsureTags creates the categories and tags that don't exist and returns the ids of all the tags by tag name.
+// The new categories can be attached to VMs and folders
+func (f *Factory) EnsureTags(ctx context.Context, tagsByCategory map[string][]string) (map[string]string, error) {
+	categories, err := f.client.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing vsphere categories: %v", err)
+	}
+
+	ids, err := f.client.TagIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing vsphere tags: %v", err)
+	}
+
+	categoriesLookup := types.SliceToLookup(categories)
+	created := false
+	for category, tags := range tagsByCategory {
+		if !categoriesLookup.IsPresent(category) {
+			l.V(3).Info("Creating category", "category", category)
+			if err = f.client.CreateCategoryForVMAndFolder(ctx, category); err != nil {
+				return nil, fmt.Errorf("failed creating category for tags: %v", err)
+			}
+		}
+
+		for _, tag := range tags {
+			if _, ok := ids[tag]; !ok {
+				l.V(3).Info("Creating tag", "tag", tag, "category", category)
+				if err = f.client.CreateTag(ctx, tag, category); err != nil {
+					return nil, fmt.Errorf("failed creating tag: %v", err)
+				}
+				created = true
+			}
+		}
+	}
+
+	if !created {
+		return ids, nil
+	}
+
+	if ids, err = f.client.TagIDs(ctx); err != nil {
+		return nil, fmt.Errorf("failed listing vsphere tags: %v", err)
+	}
+
+	return ids, nil
+}
@@ -3,6 +3,7 @@ package tags_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -126,3 +127,57 @@ func TestFactoryTagTemplateSuccess(t *testing.T) {
 
 	tt.assertSuccessFromTagTemplate()
 }
+
+func TestFactoryEnsureTagsErrorTagIDs(t *testing.T) {
+	tt := newTagTest(t)
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return(nil, nil)
+	tt.govc.EXPECT().TagIDs(tt.ctx).Return(nil, tt.dummyError)
+
+	if _, err := tt.factory.EnsureTags(tt.ctx, tt.tagsByCategory); err == nil {
+		t.Fatal("factory.EnsureTags() err = nil, want err not nil")
+	}
+}
+
+func TestFactoryEnsureTagsAllExist(t *testing.T) {
+	tt := newTagTest(t)
+	ids := map[string]string{
+		"kubernetesChannel:1.19": "urn:1",
+		"eksd:1.19":              "urn:2",
+		"eksd:1.19.4":            "urn:3",
+	}
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"kubernetesChannel", "eksd"}, nil)
+	tt.govc.EXPECT().TagIDs(tt.ctx).Return(ids, nil)
+
+	got, err := tt.factory.EnsureTags(tt.ctx, tt.tagsByCategory)
+	if err != nil {
+		t.Fatalf("factory.EnsureTags() err = %v, want err = nil", err)
+	}
+	if !reflect.DeepEqual(got, ids) {
+		t.Fatalf("factory.EnsureTags() = %v, want %v", got, ids)
+	}
+}
+
+func TestFactoryEnsureTagsSuccess(t *testing.T) {
+	tt := newTagTest(t)
+	ids := map[string]string{
+		"kubernetesChannel:1.19": "urn:1",
+		"eksd:1.19":              "urn:2",
+		"eksd:1.19.4":            "urn:3",
+	}
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"kubernetesChannel"}, nil)
+	gomock.InOrder(
+		tt.govc.EXPECT().TagIDs(tt.ctx).Return(map[string]string{"eksd:1.19": "urn:2"}, nil),
+		tt.govc.EXPECT().TagIDs(tt.ctx).Return(ids, nil),
+	)
+	tt.govc.EXPECT().CreateTag(tt.ctx, "kubernetesChannel:1.19", "kubernetesChannel").Return(nil)
+	tt.govc.EXPECT().CreateCategoryForVMAndFolder(tt.ctx, "eksd").Return(nil)
+	tt.govc.EXPECT().CreateTag(tt.ctx, "eksd:1.19.4", "eksd").Return(nil)
+
+	got, err := tt.factory.EnsureTags(tt.ctx, tt.tagsByCategory)
+	if err != nil {
+		t.Fatalf("factory.EnsureTags() err = %v, want err = nil", err)
+	}
+	if !reflect.DeepEqual(got, ids) {
+		t.Fatalf("factory.EnsureTags() = %v, want %v", got, ids)
+	}
+}
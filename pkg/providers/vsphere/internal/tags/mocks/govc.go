@@ -62,6 +62,20 @@ func (mr *MockGovcClientMockRecorder) CreateCategoryForVM(ctx, name interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVM", reflect.TypeOf((*MockGovcClient)(nil).CreateCategoryForVM), ctx, name)
 }
 
+// CreateCategoryForVMAndFolder mocks base method.
+func (m *MockGovcClient) CreateCategoryForVMAndFolder(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryForVMAndFolder", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategoryForVMAndFolder indicates an expected call of CreateCategoryForVMAndFolder.
+func (mr *MockGovcClientMockRecorder) CreateCategoryForVMAndFolder(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVMAndFolder", reflect.TypeOf((*MockGovcClient)(nil).CreateCategoryForVMAndFolder), ctx, name)
+}
+
 // CreateTag mocks base method.
 func (m *MockGovcClient) CreateTag(ctx context.Context, tag, category string) error {
 	m.ctrl.T.Helper()
@@ -105,3 +119,18 @@ func (mr *MockGovcClientMockRecorder) ListTags(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockGovcClient)(nil).ListTags), ctx)
 }
+
+// TagIDs mocks base method.
+func (m *MockGovcClient) TagIDs(ctx context.Context) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagIDs", ctx)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagIDs indicates an expected call of TagIDs.
+func (mr *MockGovcClientMockRecorder) TagIDs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagIDs", reflect.TypeOf((*MockGovcClient)(nil).TagIDs), ctx)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/tags"
 )
 
+var l = logger.For(logger.Providers)
+
 const (
 	libraryContentCorrupted    = "1"
 	libraryContentDoesNotExist = "-1"
@@ -38,6 +40,8 @@ type GovcClient interface {
 	AddTag(ctx context.Context, path, tag string) error
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
+	CreateCategoryForVMAndFolder(ctx context.Context, name string) error
+	TagIDs(ctx context.Context) (map[string]string, error)
 }
 
 func NewFactory(client GovcClient, datacenter, datastore, resourcePool, templateLibrary string) *Factory {
@@ -58,11 +62,11 @@ func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machin
 	}
 	if err == nil && len(templateFullPath) > 0 {
 		machineConfig.Spec.Template = templateFullPath // TODO: move this out of the factory into the defaulter, it's a side effect
-		logger.V(2).Info("Template already exists. Skipping creation", "template", machineConfig.Spec.Template)
+		l.V(2).Info("Template already exists. Skipping creation", "template", machineConfig.Spec.Template)
 		return nil
 	}
 
-	logger.V(2).Info("Template not available. Creating", "template", machineConfig.Spec.Template)
+	l.V(2).Info("Template not available. Creating", "template", machineConfig.Spec.Template)
 
 	osFamily := machineConfig.Spec.OSFamily
 	if err = f.createTemplate(ctx, machineConfig.Spec.Template, ovaURL, string(osFamily)); err != nil {
@@ -80,7 +84,7 @@ func (f *Factory) createTemplate(ctx context.Context, templatePath, ovaURL, osFa
 		return err
 	}
 
-	logger.Info("Creating template. This might take a while.") // TODO: add rough estimate timing?
+	l.Info("Creating template. This might take a while.") // TODO: add rough estimate timing?
 	templateName := filepath.Base(templatePath)
 	templateDir := filepath.Dir(templatePath)
 
@@ -106,7 +110,7 @@ func (f *Factory) createLibraryIfMissing(ctx context.Context) error {
 	}
 
 	if !libraryExists {
-		logger.V(2).Info("Creating library", "library", f.templateLibrary)
+		l.V(2).Info("Creating library", "library", f.templateLibrary)
 		if err = f.client.CreateLibrary(ctx, f.datastore, f.templateLibrary); err != nil {
 			return fmt.Errorf("failed creating library for new template: %v", err)
 		}
@@ -130,7 +134,7 @@ func (f *Factory) importOVAIfMissing(ctx context.Context, templateName, ovaURL s
 	}
 
 	if contentVersion == libraryContentDoesNotExist {
-		logger.V(2).Info("Importing template from ova url", "ova", ovaURL)
+		l.V(2).Info("Importing template from ova url", "ova", ovaURL)
 		if err = f.client.ImportTemplate(ctx, f.templateLibrary, ovaURL, templateName); err != nil {
 			return fmt.Errorf("failed importing template into library: %v", err)
 		}
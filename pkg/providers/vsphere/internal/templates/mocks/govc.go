@@ -63,6 +63,20 @@ func (mr *MockGovcClientMockRecorder) CreateCategoryForVM(ctx, name interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVM", reflect.TypeOf((*MockGovcClient)(nil).CreateCategoryForVM), ctx, name)
 }
 
+// CreateCategoryForVMAndFolder mocks base method.
+func (m *MockGovcClient) CreateCategoryForVMAndFolder(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryForVMAndFolder", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategoryForVMAndFolder indicates an expected call of CreateCategoryForVMAndFolder.
+func (mr *MockGovcClientMockRecorder) CreateCategoryForVMAndFolder(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVMAndFolder", reflect.TypeOf((*MockGovcClient)(nil).CreateCategoryForVMAndFolder), ctx, name)
+}
+
 // CreateLibrary mocks base method.
 func (m *MockGovcClient) CreateLibrary(ctx context.Context, datastore, library string) error {
 	m.ctrl.T.Helper()
@@ -207,3 +221,18 @@ func (mr *MockGovcClientMockRecorder) SearchTemplate(ctx, datacenter, machineCon
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTemplate", reflect.TypeOf((*MockGovcClient)(nil).SearchTemplate), ctx, datacenter, machineConfig)
 }
+
+// TagIDs mocks base method.
+func (m *MockGovcClient) TagIDs(ctx context.Context) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagIDs", ctx)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagIDs indicates an expected call of TagIDs.
+func (mr *MockGovcClientMockRecorder) TagIDs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagIDs", reflect.TypeOf((*MockGovcClient)(nil).TagIDs), ctx)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/providers/vsphere (interfaces: ProviderGovcClient,ProviderKubectlClient,ClusterResourceSetManager)
+// Source: github.com/aws/eks-anywhere/pkg/providers/vsphere (interfaces: ProviderGovcClient,ProviderKubectlClient,ClusterResourceSetManager,DiscoveryGovcClient,Prompter)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -83,6 +83,20 @@ func (mr *MockProviderGovcClientMockRecorder) CreateCategoryForVM(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVM", reflect.TypeOf((*MockProviderGovcClient)(nil).CreateCategoryForVM), arg0, arg1)
 }
 
+// CreateCategoryForVMAndFolder mocks base method.
+func (m *MockProviderGovcClient) CreateCategoryForVMAndFolder(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryForVMAndFolder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategoryForVMAndFolder indicates an expected call of CreateCategoryForVMAndFolder.
+func (mr *MockProviderGovcClientMockRecorder) CreateCategoryForVMAndFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVMAndFolder", reflect.TypeOf((*MockProviderGovcClient)(nil).CreateCategoryForVMAndFolder), arg0, arg1)
+}
+
 // CreateLibrary mocks base method.
 func (m *MockProviderGovcClient) CreateLibrary(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -140,6 +154,34 @@ func (mr *MockProviderGovcClientMockRecorder) DeleteLibraryElement(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLibraryElement", reflect.TypeOf((*MockProviderGovcClient)(nil).DeleteLibraryElement), arg0, arg1)
 }
 
+// DeleteTag mocks base method.
+func (m *MockProviderGovcClient) DeleteTag(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockProviderGovcClientMockRecorder) DeleteTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockProviderGovcClient)(nil).DeleteTag), arg0, arg1)
+}
+
+// DeleteVM mocks base method.
+func (m *MockProviderGovcClient) DeleteVM(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVM", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVM indicates an expected call of DeleteVM.
+func (mr *MockProviderGovcClientMockRecorder) DeleteVM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVM", reflect.TypeOf((*MockProviderGovcClient)(nil).DeleteVM), arg0, arg1)
+}
+
 // DeployTemplateFromLibrary mocks base method.
 func (m *MockProviderGovcClient) DeployTemplateFromLibrary(arg0 context.Context, arg1, arg2, arg3, arg4, arg5, arg6 string, arg7 bool) error {
 	m.ctrl.T.Helper()
@@ -287,6 +329,21 @@ func (mr *MockProviderGovcClientMockRecorder) ListTags(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockProviderGovcClient)(nil).ListTags), arg0)
 }
 
+// ListVMsWithTag mocks base method.
+func (m *MockProviderGovcClient) ListVMsWithTag(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVMsWithTag", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVMsWithTag indicates an expected call of ListVMsWithTag.
+func (mr *MockProviderGovcClientMockRecorder) ListVMsWithTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVMsWithTag", reflect.TypeOf((*MockProviderGovcClient)(nil).ListVMsWithTag), arg0, arg1)
+}
+
 // NetworkExists mocks base method.
 func (m *MockProviderGovcClient) NetworkExists(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -302,6 +359,35 @@ func (mr *MockProviderGovcClientMockRecorder) NetworkExists(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkExists", reflect.TypeOf((*MockProviderGovcClient)(nil).NetworkExists), arg0, arg1)
 }
 
+// RemoveTag mocks base method.
+func (m *MockProviderGovcClient) RemoveTag(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockProviderGovcClientMockRecorder) RemoveTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockProviderGovcClient)(nil).RemoveTag), arg0, arg1, arg2)
+}
+
+// ResourcePoolHosts mocks base method.
+func (m *MockProviderGovcClient) ResourcePoolHosts(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourcePoolHosts", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResourcePoolHosts indicates an expected call of ResourcePoolHosts.
+func (mr *MockProviderGovcClientMockRecorder) ResourcePoolHosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourcePoolHosts", reflect.TypeOf((*MockProviderGovcClient)(nil).ResourcePoolHosts), arg0, arg1)
+}
+
 // SearchTemplate mocks base method.
 func (m *MockProviderGovcClient) SearchTemplate(arg0 context.Context, arg1 string, arg2 *v1alpha1.VSphereMachineConfig) (string, error) {
 	m.ctrl.T.Helper()
@@ -317,6 +403,36 @@ func (mr *MockProviderGovcClientMockRecorder) SearchTemplate(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTemplate", reflect.TypeOf((*MockProviderGovcClient)(nil).SearchTemplate), arg0, arg1, arg2)
 }
 
+// TagAttached mocks base method.
+func (m *MockProviderGovcClient) TagAttached(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagAttached", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagAttached indicates an expected call of TagAttached.
+func (mr *MockProviderGovcClientMockRecorder) TagAttached(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagAttached", reflect.TypeOf((*MockProviderGovcClient)(nil).TagAttached), arg0, arg1)
+}
+
+// TagIDs mocks base method.
+func (m *MockProviderGovcClient) TagIDs(arg0 context.Context) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagIDs", arg0)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagIDs indicates an expected call of TagIDs.
+func (mr *MockProviderGovcClientMockRecorder) TagIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagIDs", reflect.TypeOf((*MockProviderGovcClient)(nil).TagIDs), arg0)
+}
+
 // TemplateHasSnapshot mocks base method.
 func (m *MockProviderGovcClient) TemplateHasSnapshot(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -411,6 +527,20 @@ func (mr *MockProviderKubectlClientMockRecorder) ApplyKubeSpecFromBytes(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockProviderKubectlClient)(nil).ApplyKubeSpecFromBytes), arg0, arg1, arg2)
 }
 
+// ApplyKubeSpecFromBytesForce mocks base method.
+func (m *MockProviderKubectlClient) ApplyKubeSpecFromBytesForce(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytesForce", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytesForce indicates an expected call of ApplyKubeSpecFromBytesForce.
+func (mr *MockProviderKubectlClientMockRecorder) ApplyKubeSpecFromBytesForce(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesForce", reflect.TypeOf((*MockProviderKubectlClient)(nil).ApplyKubeSpecFromBytesForce), arg0, arg1, arg2)
+}
+
 // ApplyTolerationsFromTaintsToDaemonSet mocks base method.
 func (m *MockProviderKubectlClient) ApplyTolerationsFromTaintsToDaemonSet(arg0 context.Context, arg1, arg2 []v1.Taint, arg3, arg4 string) error {
 	m.ctrl.T.Helper()
@@ -719,3 +849,211 @@ func (mr *MockClusterResourceSetManagerMockRecorder) ForceUpdate(arg0, arg1, arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceUpdate", reflect.TypeOf((*MockClusterResourceSetManager)(nil).ForceUpdate), arg0, arg1, arg2, arg3, arg4)
 }
+
+// MockDiscoveryGovcClient is a mock of DiscoveryGovcClient interface.
+type MockDiscoveryGovcClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscoveryGovcClientMockRecorder
+}
+
+// MockDiscoveryGovcClientMockRecorder is the mock recorder for MockDiscoveryGovcClient.
+type MockDiscoveryGovcClientMockRecorder struct {
+	mock *MockDiscoveryGovcClient
+}
+
+// NewMockDiscoveryGovcClient creates a new mock instance.
+func NewMockDiscoveryGovcClient(ctrl *gomock.Controller) *MockDiscoveryGovcClient {
+	mock := &MockDiscoveryGovcClient{ctrl: ctrl}
+	mock.recorder = &MockDiscoveryGovcClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscoveryGovcClient) EXPECT() *MockDiscoveryGovcClientMockRecorder {
+	return m.recorder
+}
+
+// ConfigureCertThumbprint mocks base method.
+func (m *MockDiscoveryGovcClient) ConfigureCertThumbprint(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureCertThumbprint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureCertThumbprint indicates an expected call of ConfigureCertThumbprint.
+func (mr *MockDiscoveryGovcClientMockRecorder) ConfigureCertThumbprint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureCertThumbprint", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ConfigureCertThumbprint), arg0, arg1, arg2)
+}
+
+// GetCertThumbprint mocks base method.
+func (m *MockDiscoveryGovcClient) GetCertThumbprint(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertThumbprint", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertThumbprint indicates an expected call of GetCertThumbprint.
+func (mr *MockDiscoveryGovcClientMockRecorder) GetCertThumbprint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertThumbprint", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).GetCertThumbprint), arg0)
+}
+
+// IsCertSelfSigned mocks base method.
+func (m *MockDiscoveryGovcClient) IsCertSelfSigned(arg0 context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCertSelfSigned", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCertSelfSigned indicates an expected call of IsCertSelfSigned.
+func (mr *MockDiscoveryGovcClientMockRecorder) IsCertSelfSigned(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCertSelfSigned", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).IsCertSelfSigned), arg0)
+}
+
+// ListDatacenters mocks base method.
+func (m *MockDiscoveryGovcClient) ListDatacenters(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatacenters", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatacenters indicates an expected call of ListDatacenters.
+func (mr *MockDiscoveryGovcClientMockRecorder) ListDatacenters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatacenters", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ListDatacenters), arg0)
+}
+
+// ListDatastores mocks base method.
+func (m *MockDiscoveryGovcClient) ListDatastores(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatastores", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatastores indicates an expected call of ListDatastores.
+func (mr *MockDiscoveryGovcClientMockRecorder) ListDatastores(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatastores", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ListDatastores), arg0, arg1)
+}
+
+// ListNetworks mocks base method.
+func (m *MockDiscoveryGovcClient) ListNetworks(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworks", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworks indicates an expected call of ListNetworks.
+func (mr *MockDiscoveryGovcClientMockRecorder) ListNetworks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworks", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ListNetworks), arg0, arg1)
+}
+
+// ListResourcePools mocks base method.
+func (m *MockDiscoveryGovcClient) ListResourcePools(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcePools", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcePools indicates an expected call of ListResourcePools.
+func (mr *MockDiscoveryGovcClientMockRecorder) ListResourcePools(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcePools", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ListResourcePools), arg0, arg1)
+}
+
+// ListTemplates mocks base method.
+func (m *MockDiscoveryGovcClient) ListTemplates(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockDiscoveryGovcClientMockRecorder) ListTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ListTemplates), arg0, arg1)
+}
+
+// ListVMFolders mocks base method.
+func (m *MockDiscoveryGovcClient) ListVMFolders(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVMFolders", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVMFolders indicates an expected call of ListVMFolders.
+func (mr *MockDiscoveryGovcClientMockRecorder) ListVMFolders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVMFolders", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ListVMFolders), arg0, arg1)
+}
+
+// ValidateVCenterAuthentication mocks base method.
+func (m *MockDiscoveryGovcClient) ValidateVCenterAuthentication(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVCenterAuthentication", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVCenterAuthentication indicates an expected call of ValidateVCenterAuthentication.
+func (mr *MockDiscoveryGovcClientMockRecorder) ValidateVCenterAuthentication(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterAuthentication", reflect.TypeOf((*MockDiscoveryGovcClient)(nil).ValidateVCenterAuthentication), arg0)
+}
+
+// MockPrompter is a mock of Prompter interface.
+type MockPrompter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrompterMockRecorder
+}
+
+// MockPrompterMockRecorder is the mock recorder for MockPrompter.
+type MockPrompterMockRecorder struct {
+	mock *MockPrompter
+}
+
+// NewMockPrompter creates a new mock instance.
+func NewMockPrompter(ctrl *gomock.Controller) *MockPrompter {
+	mock := &MockPrompter{ctrl: ctrl}
+	mock.recorder = &MockPrompterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrompter) EXPECT() *MockPrompterMockRecorder {
+	return m.recorder
+}
+
+// Select mocks base method.
+func (m *MockPrompter) Select(arg0 string, arg1 []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Select", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Select indicates an expected call of Select.
+func (mr *MockPrompterMockRecorder) Select(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockPrompter)(nil).Select), arg0, arg1)
+}
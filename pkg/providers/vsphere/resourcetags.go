@@ -0,0 +1,293 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/tags"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const virtualMachineResourceKind = "VirtualMachine"
+
+// Each resource tag key is a vSphere category and each key and value pair a tag in that category, named
+// like the template tags so they are unique across categories
+func resourceTagName(key, value string) string {
+	return fmt.Sprintf("%s:%s", key, value)
+}
+
+func parseResourceTagName(name string) (key, value string, ok bool) {
+	s := strings.SplitN(name, ":", 2)
+	if len(s) != 2 {
+		return "", "", false
+	}
+	return s[0], s[1], true
+}
+
+// ResourceTagClient creates, attaches and deletes the vSphere tags of the cluster resources
+type ResourceTagClient interface {
+	tags.GovcClient
+	GetTags(ctx context.Context, path string) ([]string, error)
+	RemoveTag(ctx context.Context, path, tag string) error
+	DeleteTag(ctx context.Context, tag string) error
+	TagAttached(ctx context.Context, tag string) (bool, error)
+}
+
+// ResourceTagger manages the vSphere tags of the cluster resources: the VMs, which CAPV tags with the ids set in
+// the machine templates, and the VM folders
+type ResourceTagger struct {
+	client ResourceTagClient
+}
+
+func NewResourceTagger(client ResourceTagClient) *ResourceTagger {
+	return &ResourceTagger{client: client}
+}
+
+// EnsureTags creates the tags for the machines of all the node groups, attaches the cluster tags to the folders
+// and returns the tag ids by node group, to set in the machine templates
+func (t *ResourceTagger) EnsureTags(ctx context.Context, clusterSpec *cluster.Spec, folders []string) (map[string][]string, error) {
+	tagsByCategory := map[string][]string{}
+	tagNamesByNodeGroup := map[string][]string{}
+	for _, nodeGroup := range providers.NodeGroups(clusterSpec) {
+		for key, value := range providers.ResourceTags(clusterSpec, nodeGroup) {
+			name := resourceTagName(key, value)
+			tagsByCategory[key] = appendIfMissing(tagsByCategory[key], name)
+			tagNamesByNodeGroup[nodeGroup] = append(tagNamesByNodeGroup[nodeGroup], name)
+		}
+	}
+
+	ids, err := tags.NewFactory(t.client).EnsureTags(ctx, tagsByCategory)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating resource tags: %v", err)
+	}
+
+	tagIDs := make(map[string][]string, len(tagNamesByNodeGroup))
+	for nodeGroup, names := range tagNamesByNodeGroup {
+		for _, name := range names {
+			id, ok := ids[name]
+			if !ok {
+				return nil, fmt.Errorf("resource tag %s not found in vCenter", name)
+			}
+			tagIDs[nodeGroup] = append(tagIDs[nodeGroup], id)
+		}
+		sort.Strings(tagIDs[nodeGroup])
+	}
+
+	clusterTags := resourceTagNames(providers.ClusterResourceTags(clusterSpec))
+	for _, folder := range folders {
+		for _, name := range clusterTags {
+			l.V(4).Info("Adding tag to folder", "tag", name, "folder", folder)
+			if err := t.client.AddTag(ctx, folder, name); err != nil {
+				return nil, fmt.Errorf("failed tagging folder: %v", err)
+			}
+		}
+	}
+
+	return tagIDs, nil
+}
+
+// DeleteTags detaches the cluster tags from the folders and deletes the tags of the cluster that aren't attached
+// to any other object. It must run after the cluster VMs are deleted.
+// Folders shared with other clusters keep the tags that aren't specific to this cluster
+func (t *ResourceTagger) DeleteTags(ctx context.Context, clusterSpec *cluster.Spec, folders []string) error {
+	clusterTags := providers.ClusterResourceTags(clusterSpec)
+	for _, folder := range folders {
+		attached, err := t.client.GetTags(ctx, folder)
+		if err != nil {
+			return fmt.Errorf("failed getting tags of folder %s: %v", folder, err)
+		}
+		attachedLookup := types.SliceToLookup(attached)
+		shared := sharedWithOtherClusters(attached, clusterSpec.Name)
+		for _, name := range resourceTagNames(clusterTags) {
+			key, _, _ := parseResourceTagName(name)
+			if !attachedLookup.IsPresent(name) || (shared && key != providers.ClusterNameTag) {
+				continue
+			}
+			l.V(4).Info("Removing tag from folder", "tag", name, "folder", folder)
+			if err := t.client.RemoveTag(ctx, folder, name); err != nil {
+				return fmt.Errorf("failed removing tag from folder: %v", err)
+			}
+		}
+	}
+
+	ids, err := t.client.TagIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed listing vsphere tags: %v", err)
+	}
+
+	var names []string
+	for _, nodeGroup := range providers.NodeGroups(clusterSpec) {
+		for _, name := range resourceTagNames(providers.ResourceTags(clusterSpec, nodeGroup)) {
+			names = appendIfMissing(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := ids[name]; !ok {
+			continue
+		}
+		inUse, err := t.client.TagAttached(ctx, name)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			l.V(4).Info("Keeping resource tag still attached to other objects", "tag", name)
+			continue
+		}
+		l.V(3).Info("Deleting resource tag", "tag", name)
+		if err := t.client.DeleteTag(ctx, name); err != nil {
+			return fmt.Errorf("failed deleting resource tag: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func sharedWithOtherClusters(folderTags []string, clusterName string) bool {
+	for _, name := range folderTags {
+		if key, value, ok := parseResourceTagName(name); ok && key == providers.ClusterNameTag && value != clusterName {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceTagNames(resourceTags map[string]string) []string {
+	names := make([]string, 0, len(resourceTags))
+	for key, value := range resourceTags {
+		names = append(names, resourceTagName(key, value))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResourceFolders returns the folders of the machines of a cluster, without duplicates
+func ResourceFolders(machineConfigs ...*anywherev1.VSphereMachineConfigSpec) []string {
+	var folders []string
+	for _, m := range machineConfigs {
+		if m != nil && m.Folder != "" {
+			folders = appendIfMissing(folders, m.Folder)
+		}
+	}
+	sort.Strings(folders)
+	return folders
+}
+
+// ensureResourceTags creates the vSphere tags for the machines of all the node groups and sets their ids
+// in the template builder, so CAPV attaches them to the VMs
+func (p *vsphereProvider) ensureResourceTags(ctx context.Context, clusterSpec *cluster.Spec) error {
+	tagIDs, err := p.resourceTagger.EnsureTags(ctx, clusterSpec, p.resourceFolders())
+	if err != nil {
+		return err
+	}
+
+	p.templateBuilder.resourceTagIDs = tagIDs
+	return nil
+}
+
+// DeleteResourceTags detaches the cluster tags from its folders and deletes the tags no longer in use
+func (p *vsphereProvider) DeleteResourceTags(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if err := SetupEnvVars(p.datacenterConfig); err != nil {
+		return fmt.Errorf("failed setup and validations: %v", err)
+	}
+	return p.resourceTagger.DeleteTags(ctx, clusterSpec, p.resourceFolders())
+}
+
+func (p *vsphereProvider) resourceFolders() []string {
+	specs := make([]*anywherev1.VSphereMachineConfigSpec, 0, len(p.machineConfigs))
+	for _, m := range p.machineConfigs {
+		specs = append(specs, &m.Spec)
+	}
+	return ResourceFolders(specs...)
+}
+
+// ListResources returns the VMs with all the tags attached
+func (p *vsphereProvider) ListResources(ctx context.Context, resourceTags map[string]string) ([]providers.Resource, error) {
+	if err := SetupEnvVars(p.datacenterConfig); err != nil {
+		return nil, fmt.Errorf("failed setup and validations: %v", err)
+	}
+
+	if len(resourceTags) == 0 {
+		return nil, fmt.Errorf("at least one tag is required to list resources")
+	}
+
+	var vms []string
+	first := true
+	for key, value := range resourceTags {
+		tagged, err := p.providerGovcClient.ListVMsWithTag(ctx, resourceTagName(key, value))
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			vms = tagged
+			first = false
+		} else {
+			vms = intersect(vms, tagged)
+		}
+		if len(vms) == 0 {
+			return nil, nil
+		}
+	}
+
+	resources := make([]providers.Resource, 0, len(vms))
+	for _, vm := range vms {
+		attached, err := p.providerGovcClient.GetTags(ctx, vm)
+		if err != nil {
+			return nil, err
+		}
+		vmTags := map[string]string{}
+		for _, name := range attached {
+			if key, value, ok := parseResourceTagName(name); ok {
+				vmTags[key] = value
+			}
+		}
+		l.V(4).Info("Found tagged VM", "vm", vm, "tags", vmTags)
+		resources = append(resources, providers.Resource{
+			Kind: virtualMachineResourceKind,
+			Name: vm,
+			Tags: vmTags,
+			// CAPV names the VMs after the VSphereMachines
+			Machine: path.Base(vm),
+		})
+	}
+
+	return resources, nil
+}
+
+// DeleteResource powers off and deletes a VM returned by ListResources
+func (p *vsphereProvider) DeleteResource(ctx context.Context, resource providers.Resource) error {
+	if resource.Kind != virtualMachineResourceKind {
+		return fmt.Errorf("vsphere provider can't delete resources of kind %s", resource.Kind)
+	}
+	if err := SetupEnvVars(p.datacenterConfig); err != nil {
+		return fmt.Errorf("failed setup and validations: %v", err)
+	}
+	return p.providerGovcClient.DeleteVM(ctx, resource.Name)
+}
+
+func appendIfMissing(s []string, v string) []string {
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
+
+func intersect(a, b []string) []string {
+	lookup := types.SliceToLookup(b)
+	var r []string
+	for _, v := range a {
+		if lookup.IsPresent(v) {
+			r = append(r, v)
+		}
+	}
+	return r
+}
@@ -0,0 +1,185 @@
+package vsphere
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func TestEnsureResourceTags(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Spec.ResourceTags = map[string]string{"team": "platform"}
+	tt.clusterSpec.Bundles.Spec.Number = 5
+
+	ids := map[string]string{}
+	for _, nodeGroup := range providers.NodeGroups(tt.clusterSpec) {
+		for k, v := range providers.ResourceTags(tt.clusterSpec, nodeGroup) {
+			ids[resourceTagName(k, v)] = "urn:" + resourceTagName(k, v)
+		}
+	}
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"team", providers.ClusterNameTag, providers.ManagedByTag, providers.NodeGroupTag, providers.BundleNumberTag}, nil)
+	tt.govc.EXPECT().TagIDs(tt.ctx).Return(ids, nil)
+	for _, folder := range tt.provider.resourceFolders() {
+		tt.govc.EXPECT().AddTag(tt.ctx, folder, "anywhere.eks.amazonaws.com/cluster-name:test")
+		tt.govc.EXPECT().AddTag(tt.ctx, folder, "anywhere.eks.amazonaws.com/managed-by:eks-anywhere")
+		tt.govc.EXPECT().AddTag(tt.ctx, folder, "team:platform")
+	}
+
+	tt.Expect(tt.provider.ensureResourceTags(tt.ctx, tt.clusterSpec)).To(Succeed())
+	tt.Expect(tt.provider.templateBuilder.resourceTagIDs[providers.ControlPlaneNodeGroup]).To(Equal([]string{
+		"urn:anywhere.eks.amazonaws.com/bundle-number:5",
+		"urn:anywhere.eks.amazonaws.com/cluster-name:test",
+		"urn:anywhere.eks.amazonaws.com/managed-by:eks-anywhere",
+		"urn:anywhere.eks.amazonaws.com/node-group:control-plane",
+		"urn:team:platform",
+	}))
+	worker := tt.clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Name
+	tt.Expect(tt.provider.templateBuilder.resourceTagIDs[worker]).To(ContainElement("urn:anywhere.eks.amazonaws.com/node-group:" + worker))
+}
+
+func TestEnsureResourceTagsErrorCreatingTag(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return(nil, nil)
+	tt.govc.EXPECT().TagIDs(tt.ctx).Return(nil, nil)
+	tt.govc.EXPECT().CreateCategoryForVMAndFolder(tt.ctx, gomock.Any()).Return(nil).AnyTimes()
+	tt.govc.EXPECT().CreateTag(tt.ctx, gomock.Any(), gomock.Any()).Return(errors.New("permission denied"))
+
+	tt.Expect(tt.provider.ensureResourceTags(tt.ctx, tt.clusterSpec)).To(MatchError("failed creating resource tags: failed creating tag: permission denied"))
+}
+
+func TestEnsureResourceTagsErrorTaggingFolder(t *testing.T) {
+	tt := newProviderTest(t)
+	ids := map[string]string{}
+	for _, nodeGroup := range providers.NodeGroups(tt.clusterSpec) {
+		for k, v := range providers.ResourceTags(tt.clusterSpec, nodeGroup) {
+			ids[resourceTagName(k, v)] = "urn:" + resourceTagName(k, v)
+		}
+	}
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{providers.ClusterNameTag, providers.ManagedByTag, providers.NodeGroupTag, providers.BundleNumberTag}, nil)
+	tt.govc.EXPECT().TagIDs(tt.ctx).Return(ids, nil)
+	tt.govc.EXPECT().AddTag(tt.ctx, gomock.Any(), gomock.Any()).Return(errors.New("category can't be attached to folders"))
+
+	tt.Expect(tt.provider.ensureResourceTags(tt.ctx, tt.clusterSpec)).To(MatchError("failed tagging folder: category can't be attached to folders"))
+}
+
+func TestResourceTaggerDeleteTags(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Bundles.Spec.Number = 5
+	folder := "/SDDC-Datacenter/vm/test"
+	clusterTag := "anywhere.eks.amazonaws.com/cluster-name:test"
+	managedByTag := "anywhere.eks.amazonaws.com/managed-by:eks-anywhere"
+	bundleTag := "anywhere.eks.amazonaws.com/bundle-number:5"
+	controlPlaneTag := "anywhere.eks.amazonaws.com/node-group:control-plane"
+
+	tt.govc.EXPECT().GetTags(tt.ctx, folder).Return([]string{clusterTag, managedByTag}, nil)
+	tt.govc.EXPECT().RemoveTag(tt.ctx, folder, clusterTag)
+	tt.govc.EXPECT().RemoveTag(tt.ctx, folder, managedByTag)
+	tt.govc.EXPECT().TagIDs(tt.ctx).Return(map[string]string{
+		clusterTag:      "urn:1",
+		managedByTag:    "urn:2",
+		bundleTag:       "urn:3",
+		controlPlaneTag: "urn:4",
+	}, nil)
+	tt.govc.EXPECT().TagAttached(tt.ctx, clusterTag).Return(false, nil)
+	tt.govc.EXPECT().TagAttached(tt.ctx, managedByTag).Return(true, nil)
+	tt.govc.EXPECT().TagAttached(tt.ctx, bundleTag).Return(true, nil)
+	tt.govc.EXPECT().TagAttached(tt.ctx, controlPlaneTag).Return(false, nil)
+	tt.govc.EXPECT().DeleteTag(tt.ctx, clusterTag)
+	tt.govc.EXPECT().DeleteTag(tt.ctx, controlPlaneTag)
+
+	tt.Expect(NewResourceTagger(tt.govc).DeleteTags(tt.ctx, tt.clusterSpec, []string{folder})).To(Succeed())
+}
+
+func TestResourceTaggerDeleteTagsSharedFolder(t *testing.T) {
+	tt := newProviderTest(t)
+	folder := "/SDDC-Datacenter/vm/shared"
+	clusterTag := "anywhere.eks.amazonaws.com/cluster-name:test"
+
+	tt.govc.EXPECT().GetTags(tt.ctx, folder).Return([]string{
+		clusterTag,
+		"anywhere.eks.amazonaws.com/cluster-name:other",
+		"anywhere.eks.amazonaws.com/managed-by:eks-anywhere",
+	}, nil)
+	tt.govc.EXPECT().RemoveTag(tt.ctx, folder, clusterTag)
+	tt.govc.EXPECT().TagIDs(tt.ctx).Return(map[string]string{clusterTag: "urn:1"}, nil)
+	tt.govc.EXPECT().TagAttached(tt.ctx, clusterTag).Return(false, nil)
+	tt.govc.EXPECT().DeleteTag(tt.ctx, clusterTag)
+
+	tt.Expect(NewResourceTagger(tt.govc).DeleteTags(tt.ctx, tt.clusterSpec, []string{folder})).To(Succeed())
+}
+
+func TestResourceFolders(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(ResourceFolders(
+		&v1alpha1.VSphereMachineConfigSpec{Folder: "/SDDC-Datacenter/vm/b"},
+		&v1alpha1.VSphereMachineConfigSpec{},
+		nil,
+		&v1alpha1.VSphereMachineConfigSpec{Folder: "/SDDC-Datacenter/vm/a"},
+		&v1alpha1.VSphereMachineConfigSpec{Folder: "/SDDC-Datacenter/vm/b"},
+	)).To(Equal([]string{"/SDDC-Datacenter/vm/a", "/SDDC-Datacenter/vm/b"}))
+}
+
+func TestListResources(t *testing.T) {
+	tt := newProviderTest(t)
+	cpVM := "/SDDC-Datacenter/vm/test-abcde"
+	otherVM := "/SDDC-Datacenter/vm/other-abcde"
+	tt.govc.EXPECT().ListVMsWithTag(tt.ctx, "anywhere.eks.amazonaws.com/cluster-name:test").Return([]string{cpVM}, nil)
+	tt.govc.EXPECT().ListVMsWithTag(tt.ctx, "anywhere.eks.amazonaws.com/managed-by:eks-anywhere").Return([]string{cpVM, otherVM}, nil)
+	tt.govc.EXPECT().GetTags(tt.ctx, cpVM).Return([]string{
+		"anywhere.eks.amazonaws.com/cluster-name:test",
+		"anywhere.eks.amazonaws.com/managed-by:eks-anywhere",
+		"team:platform",
+		"untagged",
+	}, nil)
+
+	resources, err := tt.provider.ListResources(tt.ctx, providers.ClusterTags("test"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(resources).To(Equal([]providers.Resource{
+		{
+			Kind: "VirtualMachine",
+			Name: cpVM,
+			Tags: map[string]string{
+				providers.ClusterNameTag: "test",
+				providers.ManagedByTag:   "eks-anywhere",
+				"team":                   "platform",
+			},
+			Machine: "test-abcde",
+		},
+	}))
+}
+
+func TestDeleteResource(t *testing.T) {
+	tt := newProviderTest(t)
+	vm := "/SDDC-Datacenter/vm/test-abcde"
+	tt.govc.EXPECT().DeleteVM(tt.ctx, vm).Return(nil)
+
+	tt.Expect(tt.provider.DeleteResource(tt.ctx, providers.Resource{Kind: "VirtualMachine", Name: vm})).To(Succeed())
+}
+
+func TestDeleteResourceUnsupportedKind(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.Expect(tt.provider.DeleteResource(tt.ctx, providers.Resource{Kind: "Container", Name: "test"})).To(MatchError("vsphere provider can't delete resources of kind Container"))
+}
+
+func TestListResourcesNoTags(t *testing.T) {
+	tt := newProviderTest(t)
+	_, err := tt.provider.ListResources(tt.ctx, nil)
+	tt.Expect(err).To(MatchError("at least one tag is required to list resources"))
+}
+
+func TestNeedsNewTemplatesResourceTagsChanged(t *testing.T) {
+	tt := newProviderTest(t)
+	oldSpec := tt.clusterSpec.DeepCopy()
+	tt.clusterSpec.Spec.ResourceTags = map[string]string{"team": "platform"}
+	vmc := tt.machineConfigs[tt.clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	worker := tt.clusterSpec.Spec.WorkerNodeGroupConfigurations[0]
+
+	tt.Expect(NeedsNewControlPlaneTemplate(oldSpec, tt.clusterSpec, tt.datacenterConfig, tt.datacenterConfig, vmc, vmc)).To(BeTrue())
+	tt.Expect(NeedsNewEtcdTemplate(oldSpec, tt.clusterSpec, tt.datacenterConfig, tt.datacenterConfig, vmc, vmc)).To(BeTrue())
+	tt.Expect(NeedsNewWorkloadTemplate(oldSpec, tt.clusterSpec, tt.datacenterConfig, tt.datacenterConfig, vmc, vmc, worker, worker)).To(BeTrue())
+}
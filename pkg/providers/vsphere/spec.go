@@ -51,3 +51,14 @@ func (s *Spec) machineConfigs() []*anywherev1.VSphereMachineConfig {
 
 	return machineConfigs
 }
+
+// versionsBundle returns the versions bundle for the nodes using the given machine config.
+// Machine configs used by worker node groups that override the kubernetes version get that version's bundle.
+func (s *Spec) versionsBundle(machineConfig *anywherev1.VSphereMachineConfig) *cluster.VersionsBundle {
+	for _, w := range s.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef != nil && w.MachineGroupRef.Name == machineConfig.Name {
+			return s.WorkerNodeGroupVersionsBundle(w)
+		}
+	}
+	return s.VersionsBundle
+}
@@ -5,23 +5,33 @@ import (
 	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
-func requiredTemplateTags(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSphereMachineConfig) []string {
-	tagsByCategory := requiredTemplateTagsByCategory(clusterSpec, machineConfig)
-	tags := make([]string, 0, len(tagsByCategory))
+// fipsTemplateTag identifies the templates built with a FIPS enabled OS. EKS Anywhere doesn't provide
+// FIPS enabled OVAs so it's never added to the default templates, the user has to tag them.
+const fipsTemplateTag = "fips:enabled"
+
+func requiredTemplateTags(spec *Spec, machineConfig *v1alpha1.VSphereMachineConfig) []string {
+	tagsByCategory := requiredTemplateTagsByCategory(spec, machineConfig)
+	tags := make([]string, 0, len(tagsByCategory)+1)
 	for _, t := range tagsByCategory {
 		tags = append(tags, t...)
 	}
 
+	if spec.Cluster.Spec.FIPSEnabled {
+		tags = append(tags, fipsTemplateTag)
+	}
+
 	return tags
 }
 
-func requiredTemplateTagsByCategory(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSphereMachineConfig) map[string][]string {
-	osFamily := machineConfig.Spec.OSFamily
+func requiredTemplateTagsByCategory(spec *Spec, machineConfig *v1alpha1.VSphereMachineConfig) map[string][]string {
+	return templateTagsByCategory(spec.versionsBundle(machineConfig).EksD.Name, machineConfig.Spec.OSFamily)
+}
+
+func templateTagsByCategory(eksdRelease string, osFamily v1alpha1.OSFamily) map[string][]string {
 	return map[string][]string{
-		"eksdRelease": {fmt.Sprintf("eksdRelease:%s", clusterSpec.VersionsBundle.EksD.Name)},
+		"eksdRelease": {fmt.Sprintf("eksdRelease:%s", eksdRelease)},
 		"os":          {fmt.Sprintf("os:%s", strings.ToLower(string(osFamily)))},
 	}
 }
@@ -5,13 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/hosts"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// githubHost is the git server of the GitOps configs, Flux in the cluster clones the repository from it
+const githubHost = "github.com"
+
+var sshUsernameRegex = regexp.MustCompile(`^[a-z_][a-z0-9_-]*$`)
+
 type Validator struct {
 	govc      ProviderGovcClient
 	netClient networkutils.NetClient
@@ -28,12 +39,15 @@ func (v *Validator) validateVCenterAccess(ctx context.Context, server string) er
 	if err := v.govc.ValidateVCenterConnection(ctx, server); err != nil {
 		return fmt.Errorf("failed validating connection to vCenter: %v", err)
 	}
-	logger.MarkPass("Connected to server")
+	l.MarkPass("Connected to server")
 
 	if err := v.govc.ValidateVCenterAuthentication(ctx); err != nil {
-		return fmt.Errorf("failed validating credentials for vCenter: %v", err)
+		return &providers.AuthenticationError{
+			Provider: constants.VSphereProviderName,
+			Err:      fmt.Errorf("failed validating credentials for vCenter: %v", err),
+		}
 	}
-	logger.MarkPass("Authenticated to vSphere")
+	l.MarkPass("Authenticated to vSphere")
 
 	return nil
 }
@@ -50,12 +64,12 @@ func (v *Validator) ValidateVCenterConfig(ctx context.Context, datacenterConfig
 	if err := v.validateDatacenter(ctx, datacenterConfig.Spec.Datacenter); err != nil {
 		return err
 	}
-	logger.MarkPass("Datacenter validated")
+	l.MarkPass("Datacenter validated")
 
 	if err := v.validateNetwork(ctx, datacenterConfig.Spec.Network); err != nil {
 		return err
 	}
-	logger.MarkPass("Network validated")
+	l.MarkPass("Network validated")
 
 	return nil
 }
@@ -81,7 +95,7 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		return errors.New("VSphereMachineConfig datastore for control plane is not set or is empty")
 	}
 	if len(controlPlaneMachineConfig.Spec.Folder) <= 0 {
-		logger.Info("VSphereMachineConfig folder for control plane is not set or is empty. Will default to root vSphere folder.")
+		l.Info("VSphereMachineConfig folder for control plane is not set or is empty. Will default to root vSphere folder.")
 	}
 	if len(controlPlaneMachineConfig.Spec.ResourcePool) <= 0 {
 		return errors.New("VSphereMachineConfig VM resourcePool for control plane is not set or is empty")
@@ -89,6 +103,14 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 	if controlPlaneMachineConfig.Spec.OSFamily != anywherev1.Bottlerocket && controlPlaneMachineConfig.Spec.OSFamily != anywherev1.Ubuntu {
 		return fmt.Errorf("control plane osFamily: %s is not supported, please use one of the following: %s, %s", controlPlaneMachineConfig.Spec.OSFamily, anywherev1.Bottlerocket, anywherev1.Ubuntu)
 	}
+	if err := v.validateHostEntries(vsphereClusterSpec); err != nil {
+		return err
+	}
+	// the kube-proxy config is applied with kubeadm commands, which the Bottlerocket nodes don't run
+	if vsphereClusterSpec.Cluster.Spec.ClusterNetwork.KubeProxy.ModeOrDefault() != anywherev1.KubeProxyModeIPTables &&
+		controlPlaneMachineConfig.Spec.OSFamily == anywherev1.Bottlerocket {
+		return fmt.Errorf("kubeProxy mode %s is not supported for osFamily %s", vsphereClusterSpec.Cluster.Spec.ClusterNetwork.KubeProxy.Mode, anywherev1.Bottlerocket)
+	}
 
 	workerNodeGroupConfigs := vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations
 	for _, workerNodeGroupConfig := range workerNodeGroupConfigs {
@@ -98,6 +120,9 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 	}
 
 	var workerNodeGroupMachineConfigs []*anywherev1.VSphereMachineConfig
+	// worker node groups overriding the kubernetes version need their own template
+	var skewedWorkerNodeGroupMachineConfigs []*anywherev1.VSphereMachineConfig
+	machineConfigKubeVersions := map[string]anywherev1.KubernetesVersion{}
 	for _, workerNodeGroupConfiguration := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		if workerNodeGroupConfiguration.MachineGroupRef == nil {
 			return errors.New("must specify machineGroupRef for worker nodes")
@@ -111,7 +136,7 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 			return errors.New("VSphereMachineConfig datastore for worker nodes is not set or is empty")
 		}
 		if len(workerNodeGroupMachineConfig.Spec.Folder) <= 0 {
-			logger.Info("VSphereMachineConfig folder for worker nodes is not set or is empty. Will default to root vSphere folder.")
+			l.Info("VSphereMachineConfig folder for worker nodes is not set or is empty. Will default to root vSphere folder.")
 		}
 		if len(workerNodeGroupMachineConfig.Spec.ResourcePool) <= 0 {
 			return errors.New("VSphereMachineConfig VM resourcePool for worker nodes is not set or is empty")
@@ -122,6 +147,18 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		if controlPlaneMachineConfig.Spec.OSFamily != workerNodeGroupMachineConfig.Spec.OSFamily {
 			return errors.New("control plane and worker nodes must have the same osFamily specified")
 		}
+		workerKubeVersion := vsphereClusterSpec.WorkerNodeGroupKubernetesVersion(workerNodeGroupConfiguration)
+		if v, ok := machineConfigKubeVersions[workerNodeGroupMachineConfig.Name]; ok && v != workerKubeVersion {
+			return fmt.Errorf("VSphereMachineConfig %s can't be shared by worker node groups with different kubernetes versions", workerNodeGroupMachineConfig.Name)
+		}
+		machineConfigKubeVersions[workerNodeGroupMachineConfig.Name] = workerKubeVersion
+		if workerKubeVersion != vsphereClusterSpec.Cluster.Spec.KubernetesVersion {
+			if workerNodeGroupMachineConfig.Name == controlPlaneMachineConfig.Name {
+				return fmt.Errorf("worker node group %s overrides the kubernetes version and can't share VSphereMachineConfig %s with the control plane", workerNodeGroupConfiguration.Name, workerNodeGroupMachineConfig.Name)
+			}
+			skewedWorkerNodeGroupMachineConfigs = append(skewedWorkerNodeGroupMachineConfigs, workerNodeGroupMachineConfig)
+			continue
+		}
 		if controlPlaneMachineConfig.Spec.Template != workerNodeGroupMachineConfig.Spec.Template {
 			return errors.New("control plane and worker nodes must have the same template specified")
 		}
@@ -138,7 +175,7 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 			return errors.New("VSphereMachineConfig datastore for etcd machines is not set or is empty")
 		}
 		if len(etcdMachineConfig.Spec.Folder) <= 0 {
-			logger.Info("VSphereMachineConfig folder for etcd machines is not set or is empty. Will default to root vSphere folder.")
+			l.Info("VSphereMachineConfig folder for etcd machines is not set or is empty. Will default to root vSphere folder.")
 		}
 		if len(etcdMachineConfig.Spec.ResourcePool) <= 0 {
 			return errors.New("VSphereMachineConfig VM resourcePool for etcd machines is not set or is empty")
@@ -166,6 +203,14 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		return errors.New("control plane and etcd machines must have the same osFamily specified")
 	}
 
+	if etcdMachineConfig != nil && (len(etcdMachineConfig.Spec.PreKubeadmCommands) > 0 || len(etcdMachineConfig.Spec.PostKubeadmCommands) > 0 || len(etcdMachineConfig.Spec.Files) > 0) {
+		return fmt.Errorf("preKubeadmCommands, postKubeadmCommands and files are not supported for etcd VSphereMachineConfig %v", etcdMachineConfig.Name)
+	}
+
+	if etcdMachineConfig != nil && etcdMachineConfig.Spec.HardeningProfile != "" {
+		return fmt.Errorf("hardeningProfile is not supported for etcd VSphereMachineConfig %v", etcdMachineConfig.Name)
+	}
+
 	if err := v.validateSSHUsername(controlPlaneMachineConfig); err == nil {
 		for _, wnConfig := range workerNodeGroupMachineConfigs {
 			if err = v.validateSSHUsername(wnConfig); err != nil {
@@ -185,6 +230,19 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		if machineConfig.Namespace != vsphereClusterSpec.Cluster.Namespace {
 			return errors.New("VSphereMachineConfig and Cluster objects must have the same namespace specified")
 		}
+		if err := machineConfig.ValidateBootstrapCustomizations(); err != nil {
+			return fmt.Errorf("error validating bootstrap customizations for VSphereMachineConfig %v: %v", machineConfig.Name, err)
+		}
+		if err := machineConfig.ValidateHardeningProfile(); err != nil {
+			return fmt.Errorf("error validating hardening profile for VSphereMachineConfig %v: %v", machineConfig.Name, err)
+		}
+		if err := machineConfig.ValidateNetworkInterfaces(); err != nil {
+			return fmt.Errorf("error validating network interfaces for VSphereMachineConfig %v: %v", machineConfig.Name, err)
+		}
+	}
+
+	if err := v.validateNetworkInterfaces(ctx, vsphereClusterSpec, controlPlaneMachineConfig, etcdMachineConfig); err != nil {
+		return err
 	}
 
 	if vsphereClusterSpec.datacenterConfig.Namespace != vsphereClusterSpec.Cluster.Namespace {
@@ -192,10 +250,16 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 	}
 
 	if err := v.validateTemplate(ctx, vsphereClusterSpec, controlPlaneMachineConfig); err != nil {
-		logger.V(1).Info("Control plane template validation failed.")
+		l.V(1).Info("Control plane template validation failed.")
 		return err
 	}
-	logger.MarkPass("Control plane and Workload templates validated")
+	for _, machineConfig := range skewedWorkerNodeGroupMachineConfigs {
+		if err := v.validateTemplate(ctx, vsphereClusterSpec, machineConfig); err != nil {
+			l.V(1).Info("Worker node group template validation failed.", "machineConfig", machineConfig.Name)
+			return err
+		}
+	}
+	l.MarkPass("Control plane and Workload templates validated")
 
 	if etcdMachineConfig != nil {
 		if etcdMachineConfig.Spec.Template != controlPlaneMachineConfig.Spec.Template {
@@ -206,6 +270,136 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 	return v.validateDatastoreUsage(ctx, vsphereClusterSpec, controlPlaneMachineConfig, etcdMachineConfig)
 }
 
+// singlePointsOfFailure returns the placements of the control plane and etcd machines where a single failure takes
+// down several of them: etcd and the control plane sharing a datastore, and machines that can only be placed on one
+// host because their resource pool belongs to a standalone host, or to a cluster with a single host
+func (v *Validator) singlePointsOfFailure(ctx context.Context, vsphereClusterSpec *Spec) ([]string, error) {
+	var findings []string
+	controlPlaneMachineConfig := vsphereClusterSpec.controlPlaneMachineConfig()
+	etcdMachineConfig := vsphereClusterSpec.etcdMachineConfig()
+	if controlPlaneMachineConfig == nil {
+		return nil, nil
+	}
+
+	if etcdMachineConfig != nil && etcdMachineConfig.Spec.Datastore == controlPlaneMachineConfig.Spec.Datastore {
+		findings = append(findings, fmt.Sprintf("etcd and control plane machines share datastore %s", controlPlaneMachineConfig.Spec.Datastore))
+	}
+
+	controlPlaneHost, err := v.singleHost(ctx, controlPlaneMachineConfig.Spec.ResourcePool)
+	if err != nil {
+		return nil, err
+	}
+	if count := vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count; controlPlaneHost != "" && count > 1 {
+		findings = append(findings, fmt.Sprintf("the %d control plane machines are placed on the single host %s of resource pool %s", count, controlPlaneHost, controlPlaneMachineConfig.Spec.ResourcePool))
+	}
+
+	if etcdMachineConfig == nil {
+		return findings, nil
+	}
+	etcdHost, err := v.singleHost(ctx, etcdMachineConfig.Spec.ResourcePool)
+	if err != nil {
+		return nil, err
+	}
+	if count := vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Count; etcdHost != "" && count > 1 {
+		findings = append(findings, fmt.Sprintf("the %d etcd machines are placed on the single host %s of resource pool %s", count, etcdHost, etcdMachineConfig.Spec.ResourcePool))
+	}
+	if etcdHost != "" && etcdHost == controlPlaneHost {
+		findings = append(findings, fmt.Sprintf("etcd and control plane machines are placed on the same host %s", etcdHost))
+	}
+
+	return findings, nil
+}
+
+// singleHost returns the host of a resource pool that can only place its machines on one host, empty when it has
+// several hosts. Resource pools that aren't a full path can't be resolved to their hosts, so they are not checked
+func (v *Validator) singleHost(ctx context.Context, resourcePool string) (string, error) {
+	if !strings.HasPrefix(resourcePool, "/") {
+		l.V(3).Info("Resource pool is not a full path, skipping the check of its hosts", "resourcePool", resourcePool)
+		return "", nil
+	}
+
+	hosts, err := v.govc.ResourcePoolHosts(ctx, resourcePool)
+	if err != nil {
+		return "", err
+	}
+	if len(hosts) != 1 {
+		return "", nil
+	}
+
+	return hosts[0], nil
+}
+
+// validateNetworkInterfaces checks the networks of the machine network interfaces exist in vCenter. Static addresses
+// are shared by all the machines of a machine config, so they are only accepted for worker node groups with a
+// single machine, which are rolled out deleting the old machine first. The control plane and etcd always add the
+// new machine before deleting the old one
+func (v *Validator) validateNetworkInterfaces(ctx context.Context, spec *Spec, controlPlaneMachineConfig, etcdMachineConfig *anywherev1.VSphereMachineConfig) error {
+	if controlPlaneMachineConfig.Spec.HasStaticNetworkInterfaces() {
+		return fmt.Errorf("static ipAddresses are not supported for the control plane VSphereMachineConfig %v", controlPlaneMachineConfig.Name)
+	}
+	if etcdMachineConfig != nil && etcdMachineConfig.Spec.HasStaticNetworkInterfaces() {
+		return fmt.Errorf("static ipAddresses are not supported for the etcd VSphereMachineConfig %v", etcdMachineConfig.Name)
+	}
+	staticMachineConfigGroups := map[string]string{}
+	for _, workerNodeGroupConfiguration := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := spec.workerMachineConfig(workerNodeGroupConfiguration)
+		if !machineConfig.Spec.HasStaticNetworkInterfaces() {
+			continue
+		}
+		if workerNodeGroupConfiguration.Count != 1 {
+			return fmt.Errorf("worker node group %s uses static ipAddresses of VSphereMachineConfig %v and must have a single machine", workerNodeGroupConfiguration.Name, machineConfig.Name)
+		}
+		if group, ok := staticMachineConfigGroups[machineConfig.Name]; ok {
+			return fmt.Errorf("worker node groups %s and %s can't share VSphereMachineConfig %v with static ipAddresses", group, workerNodeGroupConfiguration.Name, machineConfig.Name)
+		}
+		staticMachineConfigGroups[machineConfig.Name] = workerNodeGroupConfiguration.Name
+	}
+
+	validated := map[string]struct{}{spec.datacenterConfig.Spec.Network: {}}
+	for _, machineConfig := range spec.machineConfigs() {
+		for _, nic := range machineConfig.Spec.NetworkInterfacesInDatacenter(spec.datacenterConfig.Spec.Datacenter) {
+			if _, ok := validated[nic.Network]; ok {
+				continue
+			}
+			if err := v.validateNetwork(ctx, nic.Network); err != nil {
+				return fmt.Errorf("error validating network interface %s of VSphereMachineConfig %v: %v", nic.Name, machineConfig.Name, err)
+			}
+			validated[nic.Network] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// validateHostEntries checks the names the nodes need, vCenter, the registry mirror and the git server,
+// resolve with the host entries or the admin machine DNS. Only the Ubuntu templates write the entries to /etc/hosts,
+// so none of the machine configs of the cluster can be Bottlerocket
+func (v *Validator) validateHostEntries(spec *Spec) error {
+	entries := spec.Cluster.Spec.HostEntries
+	if len(entries) == 0 {
+		return nil
+	}
+	for _, machineConfig := range spec.machineConfigs() {
+		if machineConfig.Spec.OSFamily == anywherev1.Bottlerocket {
+			return fmt.Errorf("host entries are not supported for osFamily %s, used by VSphereMachineConfig %s", anywherev1.Bottlerocket, machineConfig.Name)
+		}
+	}
+
+	names := []string{spec.datacenterConfig.Spec.Server}
+	if spec.Cluster.Spec.RegistryMirrorConfiguration != nil {
+		names = append(names, spec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint)
+	}
+	if spec.GitOpsConfig != nil {
+		names = append(names, githubHost)
+	}
+	if err := hosts.ValidateResolvable(names, entries, v.netClient.LookupHost); err != nil {
+		return err
+	}
+	l.MarkPass("Host names validated")
+
+	return nil
+}
+
 func (v *Validator) validateControlPlaneIp(ip string) error {
 	// check if controlPlaneEndpointIp is valid
 	parsedIp := net.ParseIP(ip)
@@ -215,9 +409,44 @@ func (v *Validator) validateControlPlaneIp(ip string) error {
 	return nil
 }
 
+// validateSSHUsername validates the users of the machine config. The first key of the first user is set up, or
+// generated when empty, by the provider, the additional keys and users are rendered as is in the bootstrap config
 func (v *Validator) validateSSHUsername(machineConfig *anywherev1.VSphereMachineConfig) error {
-	if machineConfig.Spec.OSFamily == anywherev1.Bottlerocket && machineConfig.Spec.Users[0].Name != bottlerocketDefaultUser {
-		return fmt.Errorf("SSHUsername %s is invalid. Please use 'ec2-user' for Bottlerocket", machineConfig.Spec.Users[0].Name)
+	users := machineConfig.Spec.Users
+	if machineConfig.Spec.OSFamily == anywherev1.Bottlerocket {
+		if users[0].Name != bottlerocketDefaultUser {
+			return fmt.Errorf("SSHUsername %s is invalid. Please use 'ec2-user' for Bottlerocket", users[0].Name)
+		}
+		if len(users) > 1 || len(users[0].SshAuthorizedKeys) > 1 || users[0].Sudo != "" {
+			return errors.New("bottlerocket only supports the ec2-user user with a single sshAuthorizedKey and no sudo rule")
+		}
+		return nil
+	}
+
+	names := make(map[string]bool, len(users))
+	for i, user := range users {
+		if !sshUsernameRegex.MatchString(user.Name) {
+			return fmt.Errorf("SSHUsername %s is invalid, it can only have lowercase letters, numbers, dashes and underscores and must start with a letter or an underscore", user.Name)
+		}
+		if names[user.Name] {
+			return fmt.Errorf("SSHUsername %s is repeated", user.Name)
+		}
+		names[user.Name] = true
+		if strings.ContainsAny(user.Sudo, "\r\n") {
+			return fmt.Errorf("sudo rule of user %s must be a single line", user.Name)
+		}
+
+		keys := user.SshAuthorizedKeys
+		if i == 0 {
+			keys = keys[1:]
+		} else if len(keys) == 0 {
+			return fmt.Errorf("user %s must have at least one sshAuthorizedKey", user.Name)
+		}
+		for _, key := range keys {
+			if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key)); err != nil {
+				return fmt.Errorf("sshAuthorizedKey of user %s is invalid: %v", user.Name, err)
+			}
+		}
 	}
 	return nil
 }
@@ -254,7 +483,7 @@ func (v *Validator) validateTemplateTags(ctx context.Context, spec *Spec, machin
 	}
 
 	tagsLookup := types.SliceToLookup(tags)
-	for _, t := range requiredTemplateTags(spec.Spec, machineConfig) {
+	for _, t := range requiredTemplateTags(spec, machineConfig) {
 		if !tagsLookup.IsPresent(t) {
 			// TODO: maybe add help text about to how to tag a template?
 			return fmt.Errorf("template %s is missing tag %s", machineConfig.Spec.Template, t)
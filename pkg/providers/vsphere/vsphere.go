@@ -3,7 +3,9 @@ package vsphere
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -26,17 +28,23 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/hardening"
+	"github.com/aws/eks-anywhere/pkg/hosts"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/podsecurity"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/substitution"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
+var l = logger.For(logger.Providers)
+
 const (
 	CredentialsObjectName    = "vsphere-credentials"
 	EksavSphereUsernameKey   = "EKSA_VSPHERE_USERNAME"
@@ -66,8 +74,8 @@ var defaultClusterConfigMD string
 //go:embed config/secret.yaml
 var defaultSecretObject string
 
-//go:embed config/defaultStorageClass.yaml
-var defaultStorageClass []byte
+//go:embed config/storageClasses.yaml
+var storageClassesTemplate string
 
 //go:embed config/machine-health-check-template.yaml
 var mhcTemplate []byte
@@ -91,10 +99,12 @@ type vsphereProvider struct {
 	providerGovcClient     ProviderGovcClient
 	providerKubectlClient  ProviderKubectlClient
 	writer                 filewriter.FileWriter
+	discoveryCache         *discoveryCache
 	controlPlaneSshAuthKey string
 	workerSshAuthKey       string
 	etcdSshAuthKey         string
 	templateBuilder        *VsphereTemplateBuilder
+	resourceTagger         *ResourceTagger
 	skipIpCheck            bool
 	resourceSetManager     ClusterResourceSetManager
 	Retrier                *retrier.Retrier
@@ -109,6 +119,7 @@ type ProviderGovcClient interface {
 	DeleteLibraryElement(ctx context.Context, element string) error
 	TemplateHasSnapshot(ctx context.Context, template string) (bool, error)
 	GetWorkloadAvailableSpace(ctx context.Context, datastore string) (float64, error)
+	ResourcePoolHosts(ctx context.Context, resourcePool string) ([]string, error)
 	ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, selfSigned *bool) error
 	ValidateVCenterConnection(ctx context.Context, server string) error
 	ValidateVCenterAuthentication(ctx context.Context) error
@@ -126,10 +137,18 @@ type ProviderGovcClient interface {
 	AddTag(ctx context.Context, path, tag string) error
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
+	CreateCategoryForVMAndFolder(ctx context.Context, name string) error
+	TagIDs(ctx context.Context) (map[string]string, error)
+	ListVMsWithTag(ctx context.Context, tag string) ([]string, error)
+	DeleteVM(ctx context.Context, path string) error
+	RemoveTag(ctx context.Context, path, tag string) error
+	DeleteTag(ctx context.Context, tag string) error
+	TagAttached(ctx context.Context, tag string) (bool, error)
 }
 
 type ProviderKubectlClient interface {
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
 	GetNamespace(ctx context.Context, kubeconfig string, namespace string) error
 	CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error
 	LoadSecret(ctx context.Context, secretObject string, secretObjType string, secretObjectName string, kubeConfFile string) error
@@ -182,6 +201,10 @@ func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, ma
 		}
 	}
 	retrier := retrier.NewWithMaxRetries(maxRetries, backOffPeriod)
+	discoveryCache := newDiscoveryCache(providerGovcClient, writer, datacenterConfig.Spec.Server, now)
+	if discoveryCache != nil {
+		providerGovcClient = discoveryCache
+	}
 	return &vsphereProvider{
 		datacenterConfig:      datacenterConfig,
 		machineConfigs:        machineConfigs,
@@ -195,12 +218,15 @@ func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, ma
 			workerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
 			etcdMachineSpec:             etcdMachineSpec,
 			now:                         now,
+			resourceNaming:              clusterConfig.Spec.ResourceNaming,
 		},
+		resourceTagger:     NewResourceTagger(providerGovcClient),
 		skipIpCheck:        skipIpCheck,
 		resourceSetManager: resourceSetManager,
 		Retrier:            retrier,
 		validator:          NewValidator(providerGovcClient, netClient),
 		defaulter:          NewDefaulter(providerGovcClient),
+		discoveryCache:     discoveryCache,
 	}
 }
 
@@ -209,19 +235,13 @@ func (p *vsphereProvider) UpdateKubeConfig(_ *[]byte, _ string) error {
 	return nil
 }
 
+// NoProxyEndpoints implements providers.ProxyEndpoints
+func (p *vsphereProvider) NoProxyEndpoints() []string {
+	return []string{p.datacenterConfig.Spec.Server}
+}
+
 func (p *vsphereProvider) BootstrapClusterOpts() ([]bootstrapper.BootstrapClusterOption, error) {
-	env := map[string]string{}
-	if p.clusterConfig.Spec.ProxyConfiguration != nil {
-		noProxy := fmt.Sprintf("%s,%s", p.clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, p.datacenterConfig.Spec.Server)
-		for _, s := range p.clusterConfig.Spec.ProxyConfiguration.NoProxy {
-			if s != "" {
-				noProxy += "," + s
-			}
-		}
-		env["HTTP_PROXY"] = p.clusterConfig.Spec.ProxyConfiguration.HttpProxy
-		env["HTTPS_PROXY"] = p.clusterConfig.Spec.ProxyConfiguration.HttpsProxy
-		env["NO_PROXY"] = noProxy
-	}
+	env := cluster.ProxyEnv(p.clusterConfig, p.NoProxyEndpoints()...)
 	return []bootstrapper.BootstrapClusterOption{bootstrapper.WithEnv(env)}, nil
 }
 
@@ -341,7 +361,7 @@ func (p *vsphereProvider) parseSSHAuthKey(key *string) error {
 }
 
 func (p *vsphereProvider) generateSSHAuthKey(username string) (string, error) {
-	logger.Info("Provided VSphereMachineConfig sshAuthorizedKey is not set or is empty, auto-generating new key pair...")
+	l.Info("Provided VSphereMachineConfig sshAuthorizedKey is not set or is empty, auto-generating new key pair...")
 	keygenerator, _ := crypto.NewKeyGenerator(p.writer)
 	sshAuthorizedKeyBytes, err := keygenerator.GenerateSSHKeyPair("", "", privateKeyFileName, publicKeyFileName, username)
 	if err != nil || sshAuthorizedKeyBytes == nil {
@@ -361,6 +381,27 @@ func (p *vsphereProvider) DeleteResources(ctx context.Context, clusterSpec *clus
 	return p.providerKubectlClient.DeleteEksaDatacenterConfig(ctx, eksaVSphereDatacenterResourceType, p.datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, p.datacenterConfig.Namespace)
 }
 
+func (p *vsphereProvider) ValidateAccess(ctx context.Context) error {
+	if err := SetupEnvVars(p.datacenterConfig); err != nil {
+		return fmt.Errorf("failed setup and validations: %v", err)
+	}
+
+	if err := p.validator.validateVCenterAccess(ctx, p.datacenterConfig.Spec.Server); err != nil {
+		return err
+	}
+
+	if err := p.validator.validateThumbprint(ctx, p.datacenterConfig); err != nil {
+		return err
+	}
+
+	if err := p.validator.validateDatacenter(ctx, p.datacenterConfig.Spec.Datacenter); err != nil {
+		return err
+	}
+	l.MarkPass("Datacenter validated")
+
+	return nil
+}
+
 func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
 	if err := SetupEnvVars(p.datacenterConfig); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
@@ -387,6 +428,7 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 	if err := p.validator.ValidateClusterMachineConfigs(ctx, vSphereClusterSpec); err != nil {
 		return err
 	}
+	p.discoveryCache.save()
 
 	if err := p.setupSSHAuthKeysForCreate(); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
@@ -412,8 +454,12 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 		}
 	}
 
+	if err := p.ensureResourceTags(ctx, clusterSpec); err != nil {
+		return err
+	}
+
 	if p.skipIpCheck {
-		logger.Info("Skipping check for whether control plane ip is in use")
+		l.Info("Skipping check for whether control plane ip is in use")
 		return nil
 	}
 
@@ -423,6 +469,16 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 	return nil
 }
 
+// SinglePointsOfFailure returns the placements of the etcd and control plane machines where a single datastore or
+// host failure takes down several of them
+func (p *vsphereProvider) SinglePointsOfFailure(ctx context.Context, clusterSpec *cluster.Spec) ([]string, error) {
+	if err := SetupEnvVars(p.datacenterConfig); err != nil {
+		return nil, err
+	}
+
+	return p.validator.singlePointsOfFailure(ctx, NewSpec(clusterSpec, p.machineConfigs, p.datacenterConfig))
+}
+
 func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if err := SetupEnvVars(p.datacenterConfig); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
@@ -449,23 +505,41 @@ func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cl
 	if err := p.validator.ValidateClusterMachineConfigs(ctx, vSphereClusterSpec); err != nil {
 		return err
 	}
+	p.discoveryCache.save()
 
 	err := p.setupSSHAuthKeysForUpgrade()
 	if err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
 	}
-	err = p.validateMachineConfigsNameUniqueness(ctx, cluster, clusterSpec)
+	prevSpec, err := p.providerKubectlClient.GetEksaCluster(ctx, cluster, clusterSpec.GetName())
 	if err != nil {
 		return fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
 	}
-	return nil
-}
-
-func (p *vsphereProvider) validateMachineConfigsNameUniqueness(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
-	prevSpec, err := p.providerKubectlClient.GetEksaCluster(ctx, cluster, clusterSpec.GetName())
+	err = p.validateMachineConfigsNameUniqueness(ctx, cluster, prevSpec, clusterSpec)
 	if err != nil {
+		return fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
+	}
+	if err = p.validateControlPlaneEndpointMigration(prevSpec, vSphereClusterSpec); err != nil {
 		return err
 	}
+	return p.ensureResourceTags(ctx, clusterSpec)
+}
+
+// validateControlPlaneEndpointMigration checks the new control plane endpoint isn't in use by another machine
+// when the upgrade migrates the control plane to it, the same as for a new cluster
+func (p *vsphereProvider) validateControlPlaneEndpointMigration(prevSpec *v1alpha1.Cluster, vSphereClusterSpec *Spec) error {
+	if prevSpec.Spec.ControlPlaneConfiguration.Endpoint.Equal(vSphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint) {
+		return nil
+	}
+	if p.skipIpCheck {
+		l.Info("Skipping check for whether the new control plane ip is in use")
+		return nil
+	}
+
+	return p.validator.validateControlPlaneIpUniqueness(vSphereClusterSpec)
+}
+
+func (p *vsphereProvider) validateMachineConfigsNameUniqueness(ctx context.Context, cluster *types.Cluster, prevSpec *v1alpha1.Cluster, clusterSpec *cluster.Spec) error {
 
 	cpMachineConfigName := clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
 	if prevSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name != cpMachineConfigName {
@@ -522,22 +596,54 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc
 	if oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
 		return true
 	}
-	if oldSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host != newSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host {
+	if controlPlaneEndpointChanged(oldSpec, newSpec) {
 		return true
 	}
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if resourceTagsChanged(oldSpec, newSpec) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
-func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
-	if oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
+func controlPlaneEndpointChanged(oldSpec, newSpec *cluster.Spec) bool {
+	return !oldSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Equal(newSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint)
+}
+
+func resourceTagsChanged(oldSpec, newSpec *cluster.Spec) bool {
+	return !v1alpha1.MapEqual(oldSpec.Cluster.Spec.ResourceTags, newSpec.Cluster.Spec.ResourceTags)
+}
+
+// tlsPolicyChanged returns true if the TLS policy changed. The kubelet and etcd args live in the
+// kubeadm config templates, so the machines need to be rolled out to pick up the new policy
+func tlsPolicyChanged(oldSpec, newSpec *cluster.Spec) bool {
+	return !oldSpec.Cluster.Spec.TLSPolicy.Equal(newSpec.Cluster.Spec.TLSPolicy)
+}
+
+// hostEntriesChanged returns true if the host entries changed. They are written to /etc/hosts by the
+// kubeadm config templates, so the machines need to be rolled out to pick up the new entries
+func hostEntriesChanged(oldSpec, newSpec *cluster.Spec) bool {
+	return !v1alpha1.HostEntriesSliceEqual(oldSpec.Cluster.Spec.HostEntries, newSpec.Cluster.Spec.HostEntries)
+}
+
+func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig, oldWorker, newWorker v1alpha1.WorkerNodeGroupConfiguration) bool {
+	if oldSpec.WorkerNodeGroupKubernetesVersion(oldWorker) != newSpec.WorkerNodeGroupKubernetesVersion(newWorker) {
 		return true
 	}
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if resourceTagsChanged(oldSpec, newSpec) {
+		return true
+	}
+	if tlsPolicyChanged(oldSpec, newSpec) {
+		return true
+	}
+	if hostEntriesChanged(oldSpec, newSpec) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
@@ -548,6 +654,15 @@ func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alph
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if resourceTagsChanged(oldSpec, newSpec) {
+		return true
+	}
+	if tlsPolicyChanged(oldSpec, newSpec) {
+		return true
+	}
+	if hostEntriesChanged(oldSpec, newSpec) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
@@ -579,11 +694,43 @@ func AnyImmutableFieldChanged(oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig,
 	if oldVmc.Spec.Template != newVmc.Spec.Template {
 		return true
 	}
+	if !reflect.DeepEqual(oldVmc.Spec.PreKubeadmCommands, newVmc.Spec.PreKubeadmCommands) {
+		return true
+	}
+	if !reflect.DeepEqual(oldVmc.Spec.PostKubeadmCommands, newVmc.Spec.PostKubeadmCommands) {
+		return true
+	}
+	if !reflect.DeepEqual(oldVmc.Spec.Files, newVmc.Spec.Files) {
+		return true
+	}
+	if oldVmc.Spec.HardeningProfile != newVmc.Spec.HardeningProfile {
+		return true
+	}
+	if !reflect.DeepEqual(oldVmc.Spec.NetworkInterfaces, newVmc.Spec.NetworkInterfaces) {
+		return true
+	}
 	return false
 }
 
-func NewVsphereTemplateBuilder(datacenterSpec *v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.VSphereMachineConfigSpec, workerNodeGroupMachineSpecs map[string]v1alpha1.VSphereMachineConfigSpec, now types.NowFunc, fromController bool) providers.TemplateBuilder {
-	return &VsphereTemplateBuilder{
+// TemplateBuilderOpt configures the VsphereTemplateBuilder
+type TemplateBuilderOpt func(*VsphereTemplateBuilder)
+
+// WithResourceNaming names the machine templates, and so the VMs, with the resourceNaming vm template of the cluster
+func WithResourceNaming(resourceNaming *v1alpha1.ResourceNamingConfiguration) TemplateBuilderOpt {
+	return func(vs *VsphereTemplateBuilder) {
+		vs.resourceNaming = resourceNaming
+	}
+}
+
+// WithResourceTagIDs sets the ids of the vSphere tags CAPV attaches to the VMs of each node group
+func WithResourceTagIDs(tagIDs map[string][]string) TemplateBuilderOpt {
+	return func(vs *VsphereTemplateBuilder) {
+		vs.resourceTagIDs = tagIDs
+	}
+}
+
+func NewVsphereTemplateBuilder(datacenterSpec *v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.VSphereMachineConfigSpec, workerNodeGroupMachineSpecs map[string]v1alpha1.VSphereMachineConfigSpec, now types.NowFunc, fromController bool, opts ...TemplateBuilderOpt) providers.TemplateBuilder {
+	vs := &VsphereTemplateBuilder{
 		datacenterSpec:              datacenterSpec,
 		controlPlaneMachineSpec:     controlPlaneMachineSpec,
 		workerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
@@ -591,6 +738,10 @@ func NewVsphereTemplateBuilder(datacenterSpec *v1alpha1.VSphereDatacenterConfigS
 		now:                         now,
 		fromController:              fromController,
 	}
+	for _, opt := range opts {
+		opt(vs)
+	}
+	return vs
 }
 
 type VsphereTemplateBuilder struct {
@@ -600,21 +751,28 @@ type VsphereTemplateBuilder struct {
 	etcdMachineSpec             *v1alpha1.VSphereMachineConfigSpec
 	now                         types.NowFunc
 	fromController              bool
+	// resourceTagIDs are the ids of the vSphere tags for the machines of each node group
+	resourceTagIDs map[string][]string
+	// resourceNaming names the machine templates, CAPI names the VMs after them
+	resourceNaming *v1alpha1.ResourceNamingConfiguration
 }
 
 func (vs *VsphereTemplateBuilder) WorkerMachineTemplateName(clusterName, workerNodeGroupName string) string {
 	t := vs.now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-%s-%d", clusterName, workerNodeGroupName, t)
+	prefix := vs.resourceNaming.VMNamePrefix(clusterName, workerNodeGroupName, fmt.Sprintf("%s-%s", clusterName, workerNodeGroupName))
+	return fmt.Sprintf("%s-%d", prefix, t)
 }
 
 func (vs *VsphereTemplateBuilder) CPMachineTemplateName(clusterName string) string {
 	t := vs.now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-control-plane-template-%d", clusterName, t)
+	prefix := vs.resourceNaming.VMNamePrefix(clusterName, providers.ControlPlaneNodeGroup, fmt.Sprintf("%s-control-plane-template", clusterName))
+	return fmt.Sprintf("%s-%d", prefix, t)
 }
 
 func (vs *VsphereTemplateBuilder) EtcdMachineTemplateName(clusterName string) string {
 	t := vs.now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-etcd-template-%d", clusterName, t)
+	prefix := vs.resourceNaming.VMNamePrefix(clusterName, providers.EtcdNodeGroup, fmt.Sprintf("%s-etcd-template", clusterName))
+	return fmt.Sprintf("%s-%d", prefix, t)
 }
 
 func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
@@ -623,6 +781,16 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *clus
 		etcdMachineSpec = *vs.etcdMachineSpec
 	}
 	values := buildTemplateMapCP(clusterSpec, *vs.datacenterSpec, *vs.controlPlaneMachineSpec, etcdMachineSpec)
+	values["controlPlaneTagIDs"] = vs.resourceTagIDs[providers.ControlPlaneNodeGroup]
+	values["etcdTagIDs"] = vs.resourceTagIDs[providers.EtcdNodeGroup]
+
+	if clusterSpec.Spec.PodSecurity != nil {
+		admissionConfiguration, err := podsecurity.GenerateAdmissionConfiguration(clusterSpec.Spec.PodSecurity)
+		if err != nil {
+			return nil, err
+		}
+		values["podSecurityAdmissionConfiguration"] = admissionConfiguration
+	}
 
 	for _, buildOption := range buildOptions {
 		buildOption(values)
@@ -633,11 +801,10 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *clus
 		return nil, err
 	}
 
-	return bytes, nil
+	return substitution.ResolveSecrets(bytes)
 }
 
-func (vs *VsphereTemplateBuilder) isCgroupDriverSystemd(clusterSpec *cluster.Spec) (bool, error) {
-	bundle := clusterSpec.VersionsBundle
+func (vs *VsphereTemplateBuilder) isCgroupDriverSystemd(bundle *cluster.VersionsBundle) (bool, error) {
 	k8sVersion, err := semver.New(bundle.KubeDistro.Kubernetes.Tag)
 	if err != nil {
 		return false, fmt.Errorf("error parsing kubernetes version %v: %v", bundle.KubeDistro.Kubernetes.Tag, err)
@@ -649,16 +816,16 @@ func (vs *VsphereTemplateBuilder) isCgroupDriverSystemd(clusterSpec *cluster.Spe
 }
 
 func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, templateNames map[string]string) (content []byte, err error) {
-	// pin cgroupDriver to systemd for k8s >= 1.21 when generating template in controller
-	// remove this check once the controller supports order upgrade.
-	// i.e. control plane, etcd upgrade before worker nodes.
-	cgroupDriverSystemd, err := vs.isCgroupDriverSystemd(clusterSpec)
-	if err != nil {
-		return nil, err
-	}
-
 	workerSpecs := make([][]byte, 0, len(clusterSpec.Spec.WorkerNodeGroupConfigurations))
 	for _, workerNodeGroupConfiguration := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		// pin cgroupDriver to systemd for k8s >= 1.21 when generating template in controller
+		// remove this check once the controller supports order upgrade.
+		// i.e. control plane, etcd upgrade before worker nodes.
+		cgroupDriverSystemd, err := vs.isCgroupDriverSystemd(clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration))
+		if err != nil {
+			return nil, err
+		}
+
 		values := buildTemplateMapMD(clusterSpec, *vs.datacenterSpec, vs.workerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name], workerNodeGroupConfiguration)
 		_, ok := templateNames[workerNodeGroupConfiguration.Name]
 		if templateNames != nil && ok {
@@ -668,6 +835,7 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.S
 		}
 
 		values["cgroupDriverSystemd"] = cgroupDriverSystemd
+		values["workerTagIDs"] = vs.resourceTagIDs[workerNodeGroupConfiguration.Name]
 
 		bytes, err := templater.Execute(defaultClusterConfigMD, values)
 		if err != nil {
@@ -676,74 +844,112 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.S
 		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return templater.AppendYamlResources(workerSpecs...), nil
+	return substitution.ResolveSecrets(templater.AppendYamlResources(workerSpecs...))
+}
+
+// bootstrapFilePaths are the paths of the files the templates write in the machines, the same ones the
+// machine configs reserve so custom files can't overwrite them
+func bootstrapFilePaths() map[string]interface{} {
+	return map[string]interface{}{
+		"kubeVipManifestPath":        constants.KubeVipManifestPath,
+		"auditPolicyPath":            constants.AuditPolicyPath,
+		"admissionConfigurationPath": constants.AdmissionConfigurationPath,
+		"containerdProxyConfigPath":  constants.ContainerdProxyConfigPath,
+		"containerdConfigAppendPath": constants.ContainerdConfigAppendPath,
+		"awsIamAuthKubeconfigPath":   constants.AwsIamAuthKubeconfigPath,
+		"awsIamAuthCertPath":         constants.AwsIamAuthCertPath,
+		"awsIamAuthKeyPath":          constants.AwsIamAuthKeyPath,
+	}
 }
 
 func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec, etcdMachineSpec v1alpha1.VSphereMachineConfigSpec) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
-	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
-	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
+	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs().
+		Append(clusterapi.FIPSEtcdTlsExtraArgs(clusterSpec.Spec.FIPSEnabled)).
+		Append(clusterapi.TlsPolicyEtcdExtraArgs(clusterSpec.Spec.TLSPolicy))
+	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.FIPSTlsExtraArgs(clusterSpec.Spec.FIPSEnabled)).
+		Append(clusterapi.TlsPolicyExtraArgs(clusterSpec.Spec.TLSPolicy))
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.FIPSTlsExtraArgs(clusterSpec.Spec.FIPSEnabled)).
+		Append(clusterapi.TlsPolicyExtraArgs(clusterSpec.Spec.TLSPolicy)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration)).
+		Append(hardening.ProfileFor(controlPlaneMachineSpec.HardeningProfile).KubeletExtraArgs)
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Spec.PodIAMConfig)).
+		Append(clusterapi.AdmissionExtraArgs(clusterSpec.Spec.Admission, clusterSpec.Spec.KubernetesVersion)).
+		Append(sharedExtraArgs)
+	controllerManagerExtraArgs := clusterapi.AdmissionControllerManagerExtraArgs(clusterSpec.Spec.Admission).
 		Append(sharedExtraArgs)
 
 	values := map[string]interface{}{
-		"clusterName":                          clusterSpec.ObjectMeta.Name,
-		"controlPlaneEndpointIp":               clusterSpec.Spec.ControlPlaneConfiguration.Endpoint.Host,
-		"controlPlaneReplicas":                 clusterSpec.Spec.ControlPlaneConfiguration.Count,
-		"kubernetesRepository":                 bundle.KubeDistro.Kubernetes.Repository,
-		"kubernetesVersion":                    bundle.KubeDistro.Kubernetes.Tag,
-		"etcdRepository":                       bundle.KubeDistro.Etcd.Repository,
-		"etcdImageTag":                         bundle.KubeDistro.Etcd.Tag,
-		"corednsRepository":                    bundle.KubeDistro.CoreDNS.Repository,
-		"corednsVersion":                       bundle.KubeDistro.CoreDNS.Tag,
-		"nodeDriverRegistrarImage":             bundle.KubeDistro.NodeDriverRegistrar.VersionedImage(),
-		"livenessProbeImage":                   bundle.KubeDistro.LivenessProbe.VersionedImage(),
-		"externalAttacherImage":                bundle.KubeDistro.ExternalAttacher.VersionedImage(),
-		"externalProvisionerImage":             bundle.KubeDistro.ExternalProvisioner.VersionedImage(),
-		"thumbprint":                           datacenterSpec.Thumbprint,
-		"vsphereDatacenter":                    datacenterSpec.Datacenter,
-		"controlPlaneVsphereDatastore":         controlPlaneMachineSpec.Datastore,
-		"controlPlaneVsphereFolder":            controlPlaneMachineSpec.Folder,
-		"managerImage":                         bundle.VSphere.Manager.VersionedImage(),
-		"kubeVipImage":                         bundle.VSphere.KubeVip.VersionedImage(),
-		"driverImage":                          bundle.VSphere.Driver.VersionedImage(),
-		"syncerImage":                          bundle.VSphere.Syncer.VersionedImage(),
-		"insecure":                             datacenterSpec.Insecure,
-		"vsphereNetwork":                       datacenterSpec.Network,
-		"controlPlaneVsphereResourcePool":      controlPlaneMachineSpec.ResourcePool,
-		"vsphereServer":                        datacenterSpec.Server,
-		"controlPlaneVsphereStoragePolicyName": controlPlaneMachineSpec.StoragePolicyName,
-		"vsphereTemplate":                      controlPlaneMachineSpec.Template,
-		"controlPlaneVMsMemoryMiB":             controlPlaneMachineSpec.MemoryMiB,
-		"controlPlaneVMsNumCPUs":               controlPlaneMachineSpec.NumCPUs,
-		"controlPlaneDiskGiB":                  controlPlaneMachineSpec.DiskGiB,
-		"controlPlaneSshUsername":              controlPlaneMachineSpec.Users[0].Name,
-		"podCidrs":                             clusterSpec.Spec.ClusterNetwork.Pods.CidrBlocks,
-		"serviceCidrs":                         clusterSpec.Spec.ClusterNetwork.Services.CidrBlocks,
-		"etcdExtraArgs":                        etcdExtraArgs.ToPartialYaml(),
-		"etcdCipherSuites":                     crypto.SecureCipherSuitesString(),
-		"apiserverExtraArgs":                   apiServerExtraArgs.ToPartialYaml(),
-		"controllermanagerExtraArgs":           sharedExtraArgs.ToPartialYaml(),
-		"schedulerExtraArgs":                   sharedExtraArgs.ToPartialYaml(),
-		"kubeletExtraArgs":                     kubeletExtraArgs.ToPartialYaml(),
-		"format":                               format,
-		"externalEtcdVersion":                  bundle.KubeDistro.EtcdVersion,
-		"etcdImage":                            bundle.KubeDistro.EtcdImage.VersionedImage(),
-		"eksaSystemNamespace":                  constants.EksaSystemNamespace,
-		"auditPolicy":                          common.GetAuditPolicy(),
-		"resourceSetName":                      resourceSetName(clusterSpec),
-		"eksaVsphereUsername":                  os.Getenv(EksavSphereUsernameKey),
-		"eksaVspherePassword":                  os.Getenv(EksavSpherePasswordKey),
+		"clusterName":                             clusterSpec.ObjectMeta.Name,
+		"controlPlaneEndpointIp":                  clusterSpec.Spec.ControlPlaneConfiguration.Endpoint.Host,
+		"controlPlaneReplicas":                    clusterSpec.Spec.ControlPlaneConfiguration.Count,
+		"kubernetesRepository":                    bundle.KubeDistro.Kubernetes.Repository,
+		"kubernetesVersion":                       bundle.KubeDistro.Kubernetes.Tag,
+		"etcdRepository":                          bundle.KubeDistro.Etcd.Repository,
+		"etcdImageTag":                            bundle.KubeDistro.Etcd.Tag,
+		"corednsRepository":                       bundle.KubeDistro.CoreDNS.Repository,
+		"corednsVersion":                          bundle.KubeDistro.CoreDNS.Tag,
+		"nodeDriverRegistrarImage":                bundle.KubeDistro.NodeDriverRegistrar.VersionedImage(),
+		"livenessProbeImage":                      bundle.KubeDistro.LivenessProbe.VersionedImage(),
+		"externalAttacherImage":                   bundle.KubeDistro.ExternalAttacher.VersionedImage(),
+		"externalProvisionerImage":                bundle.KubeDistro.ExternalProvisioner.VersionedImage(),
+		"thumbprint":                              datacenterSpec.Thumbprint,
+		"vsphereDatacenter":                       datacenterSpec.Datacenter,
+		"controlPlaneVsphereDatastore":            controlPlaneMachineSpec.Datastore,
+		"controlPlaneVsphereFolder":               controlPlaneMachineSpec.Folder,
+		"managerImage":                            bundle.VSphere.Manager.VersionedImage(),
+		"kubeVipImage":                            bundle.VSphere.KubeVip.VersionedImage(),
+		"driverImage":                             bundle.VSphere.Driver.VersionedImage(),
+		"syncerImage":                             bundle.VSphere.Syncer.VersionedImage(),
+		"insecure":                                datacenterSpec.Insecure,
+		"vsphereNetwork":                          datacenterSpec.Network,
+		"controlPlaneVsphereResourcePool":         controlPlaneMachineSpec.ResourcePool,
+		"vsphereServer":                           datacenterSpec.Server,
+		"controlPlaneVsphereStoragePolicyName":    controlPlaneMachineSpec.StoragePolicyName,
+		"vsphereTemplate":                         controlPlaneMachineSpec.Template,
+		"controlPlaneVMsMemoryMiB":                controlPlaneMachineSpec.MemoryMiB,
+		"controlPlaneVMsNumCPUs":                  controlPlaneMachineSpec.NumCPUs,
+		"controlPlaneDiskGiB":                     controlPlaneMachineSpec.DiskGiB,
+		"controlPlaneSshUsername":                 controlPlaneMachineSpec.Users[0].Name,
+		"controlPlaneSshAdditionalAuthorizedKeys": controlPlaneMachineSpec.Users[0].SshAuthorizedKeys[1:],
+		"controlPlaneSshSudo":                     controlPlaneMachineSpec.Users[0].Sudo,
+		"controlPlaneAdditionalSshUsers":          controlPlaneMachineSpec.Users[1:],
+		"controlPlaneNetworkInterfaces":           controlPlaneMachineSpec.NetworkInterfacesInDatacenter(datacenterSpec.Datacenter),
+		"podCidrs":                                clusterSpec.Spec.ClusterNetwork.Pods.CidrBlocks,
+		"serviceCidrs":                            clusterSpec.Spec.ClusterNetwork.Services.CidrBlocks,
+		"etcdExtraArgs":                           etcdExtraArgs.ToPartialYaml(),
+		"etcdCipherSuites":                        etcdExtraArgs["cipher-suites"],
+		"apiserverExtraArgs":                      apiServerExtraArgs.ToPartialYaml(),
+		"controllermanagerExtraArgs":              controllerManagerExtraArgs.ToPartialYaml(),
+		"schedulerExtraArgs":                      sharedExtraArgs.ToPartialYaml(),
+		"kubeletExtraArgs":                        kubeletExtraArgs.ToPartialYaml(),
+		"format":                                  format,
+		"externalEtcdVersion":                     bundle.KubeDistro.EtcdVersion,
+		"etcdImage":                               bundle.KubeDistro.EtcdImage.VersionedImage(),
+		"eksaSystemNamespace":                     constants.EksaSystemNamespace,
+		"auditPolicy":                             common.GetAuditPolicy(),
+		"resourceSetName":                         resourceSetName(clusterSpec),
+		"eksaVsphereUsername":                     os.Getenv(EksavSphereUsernameKey),
+		"eksaVspherePassword":                     os.Getenv(EksavSpherePasswordKey),
+	}
+	for k, v := range bootstrapFilePaths() {
+		values[k] = v
+	}
+
+	if len(clusterSpec.Spec.HostEntries) > 0 {
+		values["hostEntries"] = hosts.Lines(clusterSpec.Spec.HostEntries)
 	}
 
 	if clusterSpec.Spec.RegistryMirrorConfiguration != nil {
 		values["registryMirrorConfiguration"] = net.JoinHostPort(clusterSpec.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Spec.RegistryMirrorConfiguration.Port)
+		values["registryMirrorCACertPath"] = constants.RegistryMirrorCACertPath(values["registryMirrorConfiguration"].(string))
+		values["mirroredRegistries"] = clusterSpec.ImageRegistries()
 		if len(clusterSpec.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
 			values["registryCACert"] = clusterSpec.Spec.RegistryMirrorConfiguration.CACertContent
 		}
@@ -782,6 +988,10 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		values["etcdVsphereResourcePool"] = etcdMachineSpec.ResourcePool
 		values["etcdVsphereStoragePolicyName"] = etcdMachineSpec.StoragePolicyName
 		values["etcdSshUsername"] = etcdMachineSpec.Users[0].Name
+		values["etcdSshAdditionalAuthorizedKeys"] = etcdMachineSpec.Users[0].SshAuthorizedKeys[1:]
+		values["etcdSshSudo"] = etcdMachineSpec.Users[0].Sudo
+		values["etcdAdditionalSshUsers"] = etcdMachineSpec.Users[1:]
+		values["etcdNetworkInterfaces"] = etcdMachineSpec.NetworkInterfacesInDatacenter(datacenterSpec.Datacenter)
 	}
 
 	if controlPlaneMachineSpec.OSFamily == v1alpha1.Bottlerocket {
@@ -800,42 +1010,132 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		values["awsIamAuth"] = true
 	}
 
+	addBootstrapCustomizationsValues(values, "controlPlane", controlPlaneMachineSpec)
+	addKubeProxyValues(values, clusterSpec.Spec.ClusterNetwork.KubeProxy)
+
+	if datacenterSpec.CloudProvider != nil {
+		values["cloudProvider"] = datacenterSpec.CloudProvider
+		values["cloudProviderConfigHash"] = cloudProviderConfigHash(datacenterSpec.CloudProvider)
+	}
+
+	return values
+}
+
+// addBootstrapCustomizationsValues sets the files and pre/post kubeadm commands of the hardening profile
+// followed by the custom ones in the machine config
+func addBootstrapCustomizationsValues(values map[string]interface{}, prefix string, machineSpec v1alpha1.VSphereMachineConfigSpec) {
+	profile := hardening.ProfileFor(machineSpec.HardeningProfile)
+
+	preKubeadmCommands := append(append([]string{}, profile.PreKubeadmCommands...), machineSpec.PreKubeadmCommands...)
+	if len(preKubeadmCommands) > 0 {
+		values[prefix+"PreKubeadmCommands"] = preKubeadmCommands
+	}
+
+	postKubeadmCommands := append(append([]string{}, machineSpec.PostKubeadmCommands...), profile.PostKubeadmCommands...)
+	if len(postKubeadmCommands) > 0 {
+		values[prefix+"PostKubeadmCommands"] = postKubeadmCommands
+	}
+
+	files := bootstrapFilesValues(append(append([]v1alpha1.BootstrapFile{}, profile.Files...), machineSpec.Files...))
+	if len(files) > 0 {
+		values[prefix+"Files"] = files
+	}
+}
+
+// addKubeProxyValues adds the kube-proxy config to the control plane bootstrap customizations,
+// along with the commands that apply it to the kubeadm config
+func addKubeProxyValues(values map[string]interface{}, config *v1alpha1.KubeProxyConfiguration) {
+	if kubeProxyConfig := clusterapi.KubeProxyConfig(config); kubeProxyConfig != "" {
+		files, _ := values["controlPlaneFiles"].([]v1alpha1.BootstrapFile)
+		values["controlPlaneFiles"] = append(files, v1alpha1.BootstrapFile{
+			Path:    clusterapi.KubeProxyConfigPath,
+			Content: strings.TrimSuffix(kubeProxyConfig, "\n"),
+		})
+	}
+
+	if commands := clusterapi.KubeProxyPreKubeadmCommands(config); len(commands) > 0 {
+		preKubeadmCommands, _ := values["controlPlanePreKubeadmCommands"].([]string)
+		values["controlPlanePreKubeadmCommands"] = append(commands, preKubeadmCommands...)
+	}
+
+	if commands := clusterapi.KubeProxyPostKubeadmCommands(config); len(commands) > 0 {
+		postKubeadmCommands, _ := values["controlPlanePostKubeadmCommands"].([]string)
+		values["controlPlanePostKubeadmCommands"] = append(commands, postKubeadmCommands...)
+	}
+
+	if clusterapi.SkipKubeProxy(config) {
+		values["skipKubeProxy"] = true
+	}
+}
+
+// bootstrapFilesValues trims the trailing new line from the files content since the templates render it
+// as a literal block, which already ends in one
+func bootstrapFilesValues(files []v1alpha1.BootstrapFile) []v1alpha1.BootstrapFile {
+	values := make([]v1alpha1.BootstrapFile, 0, len(files))
+	for _, f := range files {
+		f.Content = strings.TrimSuffix(f.Content, "\n")
+		values = append(values, f)
+	}
 	return values
 }
 
+// cloudProviderConfigHash identifies the cloud provider config so the CPI pods are rolled out when it changes,
+// they only read it on start
+func cloudProviderConfigHash(config *v1alpha1.VSphereCloudProviderConfig) string {
+	c, _ := json.Marshal(config)
+	return fmt.Sprintf("%x", sha256.Sum256(c))[:16]
+}
+
 func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, workerNodeGroupMachineSpec v1alpha1.VSphereMachineConfigSpec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
-	bundle := clusterSpec.VersionsBundle
+	bundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	format := "cloud-config"
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.FIPSTlsExtraArgs(clusterSpec.Spec.FIPSEnabled)).
+		Append(clusterapi.TlsPolicyExtraArgs(clusterSpec.Spec.TLSPolicy)).
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(hardening.ProfileFor(workerNodeGroupMachineSpec.HardeningProfile).KubeletExtraArgs)
 
 	values := map[string]interface{}{
-		"clusterName":                    clusterSpec.ObjectMeta.Name,
-		"kubernetesVersion":              bundle.KubeDistro.Kubernetes.Tag,
-		"thumbprint":                     datacenterSpec.Thumbprint,
-		"vsphereDatacenter":              datacenterSpec.Datacenter,
-		"workerVsphereDatastore":         workerNodeGroupMachineSpec.Datastore,
-		"workerVsphereFolder":            workerNodeGroupMachineSpec.Folder,
-		"vsphereNetwork":                 datacenterSpec.Network,
-		"workerVsphereResourcePool":      workerNodeGroupMachineSpec.ResourcePool,
-		"vsphereServer":                  datacenterSpec.Server,
-		"workerVsphereStoragePolicyName": workerNodeGroupMachineSpec.StoragePolicyName,
-		"vsphereTemplate":                workerNodeGroupMachineSpec.Template,
-		"workloadVMsMemoryMiB":           workerNodeGroupMachineSpec.MemoryMiB,
-		"workloadVMsNumCPUs":             workerNodeGroupMachineSpec.NumCPUs,
-		"workloadDiskGiB":                workerNodeGroupMachineSpec.DiskGiB,
-		"workerSshUsername":              workerNodeGroupMachineSpec.Users[0].Name,
-		"format":                         format,
-		"eksaSystemNamespace":            constants.EksaSystemNamespace,
-		"kubeletExtraArgs":               kubeletExtraArgs.ToPartialYaml(),
-		"vsphereWorkerSshAuthorizedKey":  workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
-		"workerReplicas":                 workerNodeGroupConfiguration.Count,
-		"workerNodeGroupName":            fmt.Sprintf("%s-%s", clusterSpec.Name, workerNodeGroupConfiguration.Name),
+		"clusterName":                       clusterSpec.ObjectMeta.Name,
+		"kubernetesVersion":                 bundle.KubeDistro.Kubernetes.Tag,
+		"thumbprint":                        datacenterSpec.Thumbprint,
+		"vsphereDatacenter":                 datacenterSpec.Datacenter,
+		"workerVsphereDatastore":            workerNodeGroupMachineSpec.Datastore,
+		"workerVsphereFolder":               workerNodeGroupMachineSpec.Folder,
+		"vsphereNetwork":                    datacenterSpec.Network,
+		"workerVsphereResourcePool":         workerNodeGroupMachineSpec.ResourcePool,
+		"vsphereServer":                     datacenterSpec.Server,
+		"workerVsphereStoragePolicyName":    workerNodeGroupMachineSpec.StoragePolicyName,
+		"vsphereTemplate":                   workerNodeGroupMachineSpec.Template,
+		"workloadVMsMemoryMiB":              workerNodeGroupMachineSpec.MemoryMiB,
+		"workloadVMsNumCPUs":                workerNodeGroupMachineSpec.NumCPUs,
+		"workloadDiskGiB":                   workerNodeGroupMachineSpec.DiskGiB,
+		"workerSshUsername":                 workerNodeGroupMachineSpec.Users[0].Name,
+		"workerSshAdditionalAuthorizedKeys": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[1:],
+		"workerSshSudo":                     workerNodeGroupMachineSpec.Users[0].Sudo,
+		"workerAdditionalSshUsers":          workerNodeGroupMachineSpec.Users[1:],
+		"workerNetworkInterfaces":           workerNodeGroupMachineSpec.NetworkInterfacesInDatacenter(datacenterSpec.Datacenter),
+		"workerStaticAddresses":             workerNodeGroupMachineSpec.HasStaticNetworkInterfaces(),
+		"format":                            format,
+		"eksaSystemNamespace":               constants.EksaSystemNamespace,
+		"kubeletExtraArgs":                  kubeletExtraArgs.ToPartialYaml(),
+		"vsphereWorkerSshAuthorizedKey":     workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
+		"workerReplicas":                    workerNodeGroupConfiguration.Count,
+		"workerNodeGroupName":               clusterSpec.Cluster.MachineDeploymentName(workerNodeGroupConfiguration.Name),
+	}
+	for k, v := range bootstrapFilePaths() {
+		values[k] = v
+	}
+
+	if len(clusterSpec.Spec.HostEntries) > 0 {
+		values["hostEntries"] = hosts.Lines(clusterSpec.Spec.HostEntries)
 	}
 
 	if clusterSpec.Spec.RegistryMirrorConfiguration != nil {
 		values["registryMirrorConfiguration"] = net.JoinHostPort(clusterSpec.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Spec.RegistryMirrorConfiguration.Port)
+		values["registryMirrorCACertPath"] = constants.RegistryMirrorCACertPath(values["registryMirrorConfiguration"].(string))
+		values["mirroredRegistries"] = clusterSpec.ImageRegistries()
 		if len(clusterSpec.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
 			values["registryCACert"] = clusterSpec.Spec.RegistryMirrorConfiguration.CACertContent
 		}
@@ -871,6 +1171,8 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketBootstrap.Bootstrap.Tag()
 	}
 
+	addBootstrapCustomizationsValues(values, "worker", workerNodeGroupMachineSpec)
+
 	return values
 }
 
@@ -912,7 +1214,7 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 			return nil, nil, err
 		}
 		if !needsNewWorkloadTemplate {
-			machineDeploymentName := fmt.Sprintf("%s-%s", newClusterSpec.Name, workerNodeGroupConfiguration.Name)
+			machineDeploymentName := newClusterSpec.Cluster.MachineDeploymentName(workerNodeGroupConfiguration.Name)
 			md, err := p.providerKubectlClient.GetMachineDeployment(ctx, workloadCluster, machineDeploymentName, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
 			if err != nil {
 				return nil, nil, err
@@ -960,6 +1262,14 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 		values["vsphereControlPlaneSshAuthorizedKey"] = p.controlPlaneSshAuthKey
 		values["vsphereEtcdSshAuthorizedKey"] = p.etcdSshAuthKey
 		values["etcdTemplateName"] = etcdTemplateName
+		if controlPlaneEndpointChanged(currentSpec, newClusterSpec) {
+			// Keep the previous endpoint in the serving cert so existing clients can still
+			// reach the api server while the control plane machines are rolled
+			values["apiServerCertSANs"] = []string{
+				newClusterSpec.Spec.ControlPlaneConfiguration.Endpoint.Host,
+				currentSpec.Spec.ControlPlaneConfiguration.Endpoint.Host,
+			}
+		}
 	}
 	controlPlaneSpec, err = p.templateBuilder.GenerateCAPISpecControlPlane(newClusterSpec, cpOpt)
 	if err != nil {
@@ -1012,14 +1322,24 @@ func (p *vsphereProvider) GenerateCAPISpecForCreate(ctx context.Context, cluster
 	return controlPlaneSpec, workersSpec, nil
 }
 
-func (p *vsphereProvider) GenerateStorageClass() []byte {
-	return defaultStorageClass
+// GenerateStorageClass returns the storage classes of the datacenter config, provisioned by the vSphere CSI driver
+func (p *vsphereProvider) GenerateStorageClass() ([]byte, error) {
+	values := map[string]interface{}{
+		"storageClasses": p.datacenterConfig.StorageClassesOrDefault(),
+	}
+	storageClasses, err := templater.Execute(storageClassesTemplate, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed generating storage classes: %v", err)
+	}
+
+	return storageClasses, nil
 }
 
 func (p *vsphereProvider) GenerateMHC() ([]byte, error) {
 	data := map[string]string{
-		"clusterName":         p.clusterConfig.Name,
-		"eksaSystemNamespace": constants.EksaSystemNamespace,
+		"clusterName":           p.clusterConfig.Name,
+		"eksaSystemNamespace":   constants.EksaSystemNamespace,
+		"machineDeploymentName": p.clusterConfig.MachineDeploymentName("md-0"),
 	}
 	mhc, err := templater.Execute(string(mhcTemplate), data)
 	if err != nil {
@@ -1182,6 +1502,12 @@ func (p *vsphereProvider) ValidateNewSpec(ctx context.Context, cluster *types.Cl
 		return fmt.Errorf("spec.thumbprint is immutable. Previous value %s, new value %s", oSpec.Thumbprint, nSpec.Thumbprint)
 	}
 
+	if !prevSpec.Spec.ControlPlaneConfiguration.Endpoint.Equal(clusterSpec.Spec.ControlPlaneConfiguration.Endpoint) {
+		if err := p.validator.validateControlPlaneIpUniqueness(NewSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)); err != nil {
+			return err
+		}
+	}
+
 	secretChanged, err := p.secretContentsChanged(ctx, cluster)
 	if err != nil {
 		return err
@@ -1195,12 +1521,12 @@ func (p *vsphereProvider) ValidateNewSpec(ctx context.Context, cluster *types.Cl
 
 func (p *vsphereProvider) needsNewMachineTemplate(ctx context.Context, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, vdc *v1alpha1.VSphereDatacenterConfig, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration) (bool, error) {
 	workerMachineConfig := p.machineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name]
-	if _, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
+	if prevWorkerNodeGroupConfig, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
 		workerVmc, err := p.providerKubectlClient.GetEksaVSphereMachineConfig(ctx, workerNodeGroupConfiguration.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Namespace)
 		if err != nil {
 			return false, err
 		}
-		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, workerVmc, workerMachineConfig)
+		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, workerVmc, workerMachineConfig, prevWorkerNodeGroupConfig, workerNodeGroupConfiguration)
 		return needsNewWorkloadTemplate, nil
 	}
 	return true, nil
@@ -1260,7 +1586,7 @@ func (p *vsphereProvider) RunPostControlPlaneUpgrade(ctx context.Context, oldClu
 	// Even if we create a new ClusterResourceSet, if such resources already exist in the cluster, they won't be reapplied
 	// The long term solution is to add this capability to the cluster-api controller,
 	// with a new mode like "ReApplyOnChanges" or "ReApplyOnCreate" vs the current "ReApplyOnce"
-	err := p.Retrier.Retry(
+	err := p.Retrier.RetryWithContext(ctx,
 		func() error {
 			return p.resourceSetManager.ForceUpdate(ctx, resourceSetName(clusterSpec), constants.EksaSystemNamespace, managementCluster, workloadCluster)
 		},
@@ -1268,6 +1594,26 @@ func (p *vsphereProvider) RunPostControlPlaneUpgrade(ctx context.Context, oldClu
 	if err != nil {
 		return fmt.Errorf("failed updating the vsphere provider resource set post upgrade: %v", err)
 	}
+
+	return p.updateStorageClasses(ctx, workloadCluster)
+}
+
+// updateStorageClasses applies the storage classes of the datacenter config to the cluster. The parameters of a
+// storage class are immutable, so changed storage classes are recreated. Volumes already provisioned are not affected
+func (p *vsphereProvider) updateStorageClasses(ctx context.Context, workloadCluster *types.Cluster) error {
+	storageClasses, err := p.GenerateStorageClass()
+	if err != nil {
+		return err
+	}
+
+	err = p.Retrier.RetryWithContext(ctx,
+		func() error {
+			return p.providerKubectlClient.ApplyKubeSpecFromBytesForce(ctx, workloadCluster, storageClasses)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed updating storage classes post upgrade: %v", err)
+	}
 	return nil
 }
 
@@ -30,7 +30,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -53,11 +55,13 @@ const (
 )
 
 type DummyProviderGovcClient struct {
-	osTag string
+	osTag  string
+	tagIDs map[string]string
+	hosts  map[string][]string
 }
 
 func NewDummyProviderGovcClient() *DummyProviderGovcClient {
-	return &DummyProviderGovcClient{osTag: ubuntuOSTag}
+	return &DummyProviderGovcClient{osTag: ubuntuOSTag, tagIDs: map[string]string{}}
 }
 
 func (pc *DummyProviderGovcClient) TemplateHasSnapshot(ctx context.Context, template string) (bool, error) {
@@ -68,6 +72,10 @@ func (pc *DummyProviderGovcClient) GetWorkloadAvailableSpace(ctx context.Context
 	return math.MaxFloat64, nil
 }
 
+func (pc *DummyProviderGovcClient) ResourcePoolHosts(ctx context.Context, resourcePool string) ([]string, error) {
+	return pc.hosts[resourcePool], nil
+}
+
 func (pc *DummyProviderGovcClient) DeployTemplate(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig) error {
 	return nil
 }
@@ -137,7 +145,7 @@ func (pc *DummyProviderGovcClient) ImportTemplate(ctx context.Context, library,
 }
 
 func (pc *DummyProviderGovcClient) GetTags(ctx context.Context, path string) (tags []string, err error) {
-	return []string{eksd119ReleaseTag, eksd121ReleaseTag, pc.osTag}, nil
+	return []string{eksd119ReleaseTag, eksd121ReleaseTag, pc.osTag, fipsTemplateTag}, nil
 }
 
 func (pc *DummyProviderGovcClient) ListTags(ctx context.Context) ([]string, error) {
@@ -145,6 +153,23 @@ func (pc *DummyProviderGovcClient) ListTags(ctx context.Context) ([]string, erro
 }
 
 func (pc *DummyProviderGovcClient) CreateTag(ctx context.Context, tag, category string) error {
+	pc.tagIDs[tag] = fmt.Sprintf("urn:vmomi:InventoryServiceTag:%s:GLOBAL", tag)
+	return nil
+}
+
+func (pc *DummyProviderGovcClient) TagIDs(ctx context.Context) (map[string]string, error) {
+	ids := make(map[string]string, len(pc.tagIDs))
+	for k, v := range pc.tagIDs {
+		ids[k] = v
+	}
+	return ids, nil
+}
+
+func (pc *DummyProviderGovcClient) ListVMsWithTag(ctx context.Context, tag string) ([]string, error) {
+	return nil, nil
+}
+
+func (pc *DummyProviderGovcClient) DeleteVM(ctx context.Context, path string) error {
 	return nil
 }
 
@@ -160,6 +185,22 @@ func (pc *DummyProviderGovcClient) CreateCategoryForVM(ctx context.Context, name
 	return nil
 }
 
+func (pc *DummyProviderGovcClient) CreateCategoryForVMAndFolder(ctx context.Context, name string) error {
+	return nil
+}
+
+func (pc *DummyProviderGovcClient) RemoveTag(ctx context.Context, path, tag string) error {
+	return nil
+}
+
+func (pc *DummyProviderGovcClient) DeleteTag(ctx context.Context, tag string) error {
+	return nil
+}
+
+func (pc *DummyProviderGovcClient) TagAttached(ctx context.Context, tag string) (bool, error) {
+	return false, nil
+}
+
 type DummyNetClient struct{}
 
 func (n *DummyNetClient) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
@@ -170,6 +211,13 @@ func (n *DummyNetClient) DialTimeout(network, address string, timeout time.Durat
 	return nil, errors.New("")
 }
 
+func (n *DummyNetClient) LookupHost(host string) ([]string, error) {
+	if host == "vsphere_server" {
+		return []string{"10.0.0.5"}, nil
+	}
+	return nil, errors.New("no such host")
+}
+
 func givenClusterConfig(t *testing.T, fileName string) *v1alpha1.Cluster {
 	return givenClusterSpec(t, fileName).Cluster
 }
@@ -397,7 +445,7 @@ func newProviderWithGovc(t *testing.T, datacenterConfig *v1alpha1.VSphereDatacen
 }
 
 func newProvider(t *testing.T, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfigs map[string]*v1alpha1.VSphereMachineConfig, clusterConfig *v1alpha1.Cluster, govc ProviderGovcClient, kubectl ProviderKubectlClient, resourceSetManager ClusterResourceSetManager) *vsphereProvider {
-	_, writer := test.NewWriter(t)
+	writer := newTestWriter(t)
 	return NewProviderCustomNet(
 		datacenterConfig,
 		machineConfigs,
@@ -826,13 +874,515 @@ func TestProviderGenerateCAPISpecForCreateWithMultipleWorkerNodeGroups(t *testin
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_main_multiple_worker_node_groups.yaml")
 }
 
+func TestProviderGenerateCAPISpecForCreateWithCloudProviderConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_cloud_provider.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cloud_provider_cp.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithBootstrapCustomizations(t *testing.T) {
+	clusterSpecManifest := "cluster_bootstrap_customizations.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_bootstrap_customizations_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_bootstrap_customizations_md.yaml")
+}
+
+const testBreakGlassSSHKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKFQk8b0iDICj/+qjT3anVEnewVakomjeuPbTkuHYcj0"
+
+func TestProviderGenerateCAPISpecForCreateWithSSHUsers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	breakGlass := v1alpha1.UserConfiguration{Name: "breakglass", SshAuthorizedKeys: []string{testBreakGlassSSHKey}, Sudo: "ALL=(ALL) ALL"}
+	machineConfigs["test-cp"].Spec.Users[0].Sudo = "ALL=(ALL) NOPASSWD:/usr/bin/systemctl"
+	machineConfigs["test-cp"].Spec.Users = append(machineConfigs["test-cp"].Spec.Users, breakGlass)
+	machineConfigs["test-wn"].Spec.Users = append(machineConfigs["test-wn"].Spec.Users, v1alpha1.UserConfiguration{Name: "audit", SshAuthorizedKeys: []string{testBreakGlassSSHKey}})
+	machineConfigs["test-etcd"].Spec.Users[0].SshAuthorizedKeys = append(machineConfigs["test-etcd"].Spec.Users[0].SshAuthorizedKeys, testBreakGlassSSHKey)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_ssh_users_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_ssh_users_md.yaml")
+}
+
+func TestSetupAndValidateCreateClusterInvalidSSHUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    v1alpha1.UserConfiguration
+		wantErr string
+	}{
+		{
+			name:    "invalid name",
+			user:    v1alpha1.UserConfiguration{Name: "Break Glass", SshAuthorizedKeys: []string{testBreakGlassSSHKey}},
+			wantErr: "SSHUsername Break Glass is invalid",
+		},
+		{
+			name:    "repeated name",
+			user:    v1alpha1.UserConfiguration{Name: "capv", SshAuthorizedKeys: []string{testBreakGlassSSHKey}},
+			wantErr: "SSHUsername capv is repeated",
+		},
+		{
+			name:    "no key",
+			user:    v1alpha1.UserConfiguration{Name: "breakglass"},
+			wantErr: "user breakglass must have at least one sshAuthorizedKey",
+		},
+		{
+			name:    "invalid key",
+			user:    v1alpha1.UserConfiguration{Name: "breakglass", SshAuthorizedKeys: []string{"ssh-rsa invalid"}},
+			wantErr: "sshAuthorizedKey of user breakglass is invalid",
+		},
+		{
+			name:    "multiline sudo",
+			user:    v1alpha1.UserConfiguration{Name: "breakglass", SshAuthorizedKeys: []string{testBreakGlassSSHKey}, Sudo: "ALL=(ALL) ALL\nbreakglass ALL=(ALL) NOPASSWD:ALL"},
+			wantErr: "sudo rule of user breakglass must be a single line",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+			datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+			machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+			machineConfigs["test-wn"].Spec.Users = append(machineConfigs["test-wn"].Spec.Users, tt.user)
+			provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, mocks.NewMockProviderKubectlClient(gomock.NewController(t)))
+			setupContext(t)
+
+			err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("SetupAndValidateCreateCluster() error = %v, want error containing %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProviderGenerateCAPISpecForCreateWithNetworkInterfaces(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = 1
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	machineConfigs["test-cp"].Spec.NetworkInterfaces = []v1alpha1.VSphereNetworkInterface{
+		{Name: "management", Network: "/SDDC-Datacenter/network/sddc-cgw-network-1"},
+		{Name: "workload", Network: "workload-network", MTU: 9000},
+	}
+	machineConfigs["test-etcd"].Spec.NetworkInterfaces = []v1alpha1.VSphereNetworkInterface{
+		{Name: "management", Network: "/SDDC-Datacenter/network/sddc-cgw-network-1"},
+	}
+	machineConfigs["test-wn"].Spec.NetworkInterfaces = []v1alpha1.VSphereNetworkInterface{
+		{Name: "management", Network: "/SDDC-Datacenter/network/sddc-cgw-network-1"},
+		{
+			Name:        "storage",
+			Network:     "storage-network",
+			IPAddresses: []string{"172.16.0.10/24"},
+			Gateway:     "172.16.0.1",
+			Nameservers: []string{"172.16.0.2"},
+		},
+	}
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_network_interfaces_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_network_interfaces_md.yaml")
+}
+
+func TestSetupAndValidateCreateClusterInvalidNetworkInterfaces(t *testing.T) {
+	static := []v1alpha1.VSphereNetworkInterface{{Name: "storage", Network: "storage-network", IPAddresses: []string{"172.16.0.10/24"}}}
+	tests := []struct {
+		name          string
+		machineConfig string
+		interfaces    []v1alpha1.VSphereNetworkInterface
+		workerCount   int
+		wantErr       string
+	}{
+		{
+			name:          "duplicated interface",
+			machineConfig: "test-wn",
+			interfaces:    []v1alpha1.VSphereNetworkInterface{{Name: "storage", Network: "a"}, {Name: "storage", Network: "b"}},
+			workerCount:   3,
+			wantErr:       "network interface storage is defined more than once",
+		},
+		{
+			name:          "static control plane",
+			machineConfig: "test-cp",
+			interfaces:    static,
+			workerCount:   3,
+			wantErr:       "static ipAddresses are not supported for the control plane VSphereMachineConfig test-cp",
+		},
+		{
+			name:          "static etcd",
+			machineConfig: "test-etcd",
+			interfaces:    static,
+			workerCount:   3,
+			wantErr:       "static ipAddresses are not supported for the etcd VSphereMachineConfig test-etcd",
+		},
+		{
+			name:          "static worker node group with several machines",
+			machineConfig: "test-wn",
+			interfaces:    static,
+			workerCount:   3,
+			wantErr:       "worker node group md-0 uses static ipAddresses of VSphereMachineConfig test-wn and must have a single machine",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+			clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = tt.workerCount
+			datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+			machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+			machineConfigs[tt.machineConfig].Spec.NetworkInterfaces = tt.interfaces
+			provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, mocks.NewMockProviderKubectlClient(gomock.NewController(t)))
+			setupContext(t)
+
+			err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("SetupAndValidateCreateCluster() error = %v, want error containing %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProviderGenerateCAPISpecForCreateWithCISHardeningProfile(t *testing.T) {
+	clusterSpecManifest := "cluster_cis_hardening.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cis_hardening_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_cis_hardening_md.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithFIPS(t *testing.T) {
+	clusterSpecManifest := "cluster_main.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Spec.FIPSEnabled = true
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_fips_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_fips_md.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithTLSPolicy(t *testing.T) {
+	clusterSpecManifest := "cluster_main.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Spec.TLSPolicy = &v1alpha1.TLSPolicy{
+		MinVersion:   v1alpha1.TLSVersion12,
+		CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305"},
+	}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_tls_policy_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_tls_policy_md.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithPodSecurity(t *testing.T) {
+	clusterSpecManifest := "cluster_main.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Spec.PodSecurity = &v1alpha1.PodSecurityConfiguration{
+		Enforce: v1alpha1.PodSecurityRestricted,
+		Exemptions: v1alpha1.PodSecurityExemptions{
+			Namespaces: []string{"monitoring"},
+		},
+	}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_pod_security_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_main_md.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithHostEntries(t *testing.T) {
+	clusterSpecManifest := "cluster_main.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{
+		{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local", "registry"}},
+		{IP: "10.0.0.11", Hostnames: []string{"git.lab.local"}},
+	}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_host_entries_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_host_entries_md.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithKubeProxyIPVS(t *testing.T) {
+	clusterSpecManifest := "cluster_main.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Spec.ClusterNetwork.KubeProxy = &v1alpha1.KubeProxyConfiguration{
+		Mode: v1alpha1.KubeProxyModeIPVS,
+		IPVS: &v1alpha1.KubeProxyIPVSConfiguration{Scheduler: "lc", StrictARP: true},
+	}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_kube_proxy_ipvs_cp.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithKubeProxyDisabled(t *testing.T) {
+	clusterSpecManifest := "cluster_main.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Spec.ClusterNetwork.KubeProxy = &v1alpha1.KubeProxyConfiguration{Mode: v1alpha1.KubeProxyModeDisabled}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_kube_proxy_disabled_cp.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithAdmissionProfile(t *testing.T) {
+	clusterSpecManifest := "cluster_main.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Spec.Admission = &v1alpha1.AdmissionConfiguration{Profile: v1alpha1.AdmissionProfileRestricted}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	ctx := context.Background()
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_admission_restricted_cp.yaml")
+}
+
+func TestNeedsNewTemplatesTLSPolicyChanged(t *testing.T) {
+	tt := newProviderTest(t)
+	oldSpec := tt.clusterSpec.DeepCopy()
+	tt.clusterSpec.Spec.TLSPolicy = &v1alpha1.TLSPolicy{MinVersion: v1alpha1.TLSVersion13}
+	vmc := tt.machineConfigs[tt.clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	worker := tt.clusterSpec.Spec.WorkerNodeGroupConfigurations[0]
+
+	tt.Expect(NeedsNewEtcdTemplate(oldSpec, tt.clusterSpec, tt.datacenterConfig, tt.datacenterConfig, vmc, vmc)).To(BeTrue())
+	tt.Expect(NeedsNewWorkloadTemplate(oldSpec, tt.clusterSpec, tt.datacenterConfig, tt.datacenterConfig, vmc, vmc, worker, worker)).To(BeTrue())
+}
+
+func TestNeedsNewTemplatesHostEntriesChanged(t *testing.T) {
+	tt := newProviderTest(t)
+	oldSpec := tt.clusterSpec.DeepCopy()
+	tt.clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+	vmc := tt.machineConfigs[tt.clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	worker := tt.clusterSpec.Spec.WorkerNodeGroupConfigurations[0]
+
+	tt.Expect(NeedsNewEtcdTemplate(oldSpec, tt.clusterSpec, tt.datacenterConfig, tt.datacenterConfig, vmc, vmc)).To(BeTrue())
+	tt.Expect(NeedsNewWorkloadTemplate(oldSpec, tt.clusterSpec, tt.datacenterConfig, tt.datacenterConfig, vmc, vmc, worker, worker)).To(BeTrue())
+}
+
+func TestSetupAndValidateCreateClusterHardeningProfileInEtcdMachineConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_cis_hardening.yaml"
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	machineConfigs["test-etcd"].Spec.HardeningProfile = v1alpha1.CISHardeningProfile
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, mocks.NewMockProviderKubectlClient(gomock.NewController(t)))
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "hardeningProfile is not supported for etcd VSphereMachineConfig test-etcd", err)
+}
+
+func TestSetupAndValidateCreateClusterBootstrapCustomizationsInEtcdMachineConfig(t *testing.T) {
+	clusterSpecManifest := "cluster_bootstrap_customizations.yaml"
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	machineConfigs["test-etcd"].Spec.PreKubeadmCommands = []string{"echo etcd"}
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, mocks.NewMockProviderKubectlClient(gomock.NewController(t)))
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "preKubeadmCommands, postKubeadmCommands and files are not supported for etcd VSphereMachineConfig test-etcd", err)
+}
+
 func TestProviderGenerateStorageClass(t *testing.T) {
 	provider := givenProvider(t)
 
-	storageClassManifest := provider.GenerateStorageClass()
-	if storageClassManifest == nil {
-		t.Fatalf("Expected storageClassManifest")
+	storageClassManifest, err := provider.GenerateStorageClass()
+	if err != nil {
+		t.Fatalf("failed to generate storage class: %v", err)
 	}
+	test.AssertContentToFile(t, string(storageClassManifest), "testdata/expected_results_default_storage_class.yaml")
+}
+
+func TestProviderGenerateStorageClassFromDatacenterConfig(t *testing.T) {
+	provider := givenProvider(t)
+	provider.datacenterConfig.Spec.StorageClasses = []v1alpha1.VSphereStorageClass{
+		{Name: "gold", StoragePolicyName: "Gold Policy", Default: true},
+		{Name: "local-xfs", DatastoreURL: "ds:///vmfs/volumes/vsan:52e5d4b1/", FsType: "xfs"},
+	}
+
+	storageClassManifest, err := provider.GenerateStorageClass()
+	if err != nil {
+		t.Fatalf("failed to generate storage class: %v", err)
+	}
+	test.AssertContentToFile(t, string(storageClassManifest), "testdata/expected_results_storage_classes.yaml")
 }
 
 func TestProviderGenerateCAPISpecForCreateWithBottlerocketAndExternalEtcd(t *testing.T) {
@@ -1050,6 +1600,70 @@ func TestSetupAndValidateCreateClusterNoUsername(t *testing.T) {
 	thenErrorExpected(t, "failed setup and validations: EKSA_VSPHERE_USERNAME is not set or is empty", err)
 }
 
+func TestProviderSinglePointsOfFailure(t *testing.T) {
+	clusterSpecManifest := "cluster_bottlerocket_external_etcd.yaml"
+	controlPlanePool := "/SDDC-Datacenter/host/Cluster-01/Resources"
+	etcdPool := "/SDDC-Datacenter/host/Cluster-02/Resources/etcd"
+	tests := []struct {
+		name          string
+		etcdDatastore string
+		hosts         map[string][]string
+		want          []string
+	}{
+		{
+			name: "shared datastore",
+			want: []string{"etcd and control plane machines share datastore /SDDC-Datacenter/datastore/WorkloadDatastore"},
+		},
+		{
+			name:          "several hosts",
+			etcdDatastore: "/SDDC-Datacenter/datastore/EtcdDatastore",
+			hosts: map[string][]string{
+				controlPlanePool: {"/SDDC-Datacenter/host/Cluster-01/esxi-1", "/SDDC-Datacenter/host/Cluster-01/esxi-2"},
+				etcdPool:         {"/SDDC-Datacenter/host/Cluster-02/esxi-3", "/SDDC-Datacenter/host/Cluster-02/esxi-4"},
+			},
+		},
+		{
+			name:          "single shared host",
+			etcdDatastore: "/SDDC-Datacenter/datastore/EtcdDatastore",
+			hosts: map[string][]string{
+				controlPlanePool: {"/SDDC-Datacenter/host/esxi-1"},
+				etcdPool:         {"/SDDC-Datacenter/host/esxi-1"},
+			},
+			want: []string{
+				"the 3 control plane machines are placed on the single host /SDDC-Datacenter/host/esxi-1 of resource pool " + controlPlanePool,
+				"the 3 etcd machines are placed on the single host /SDDC-Datacenter/host/esxi-1 of resource pool " + etcdPool,
+				"etcd and control plane machines are placed on the same host /SDDC-Datacenter/host/esxi-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			setupContext(t)
+			kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+			resourceSetManager := mocks.NewMockClusterResourceSetManager(mockCtrl)
+			clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+			datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+			machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+			if tt.hosts != nil {
+				machineConfigs["test-cp"].Spec.ResourcePool = controlPlanePool
+				machineConfigs["test-etcd"].Spec.ResourcePool = etcdPool
+			}
+			if tt.etcdDatastore != "" {
+				machineConfigs["test-etcd"].Spec.Datastore = tt.etcdDatastore
+			}
+			govc := NewDummyProviderGovcClient()
+			govc.hosts = tt.hosts
+			provider := newProvider(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, govc, kubectl, resourceSetManager)
+
+			got, err := provider.SinglePointsOfFailure(context.Background(), clusterSpec)
+			g.Expect(err).To(BeNil())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestSetupAndValidateCreateClusterNoPassword(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := givenEmptyClusterSpec()
@@ -1273,6 +1887,26 @@ func TestSetupAndValidateUpgradeCluster(t *testing.T) {
 	}
 }
 
+func TestSetupAndValidateUpgradeClusterEndpointMigrationUsedIp(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	cluster := &types.Cluster{}
+	provider := givenProvider(t)
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	provider.providerKubectlClient = kubectl
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+
+	kubectl.EXPECT().GetEksaCluster(ctx, cluster, clusterSpec.GetName()).Return(clusterSpec.Cluster.DeepCopy(), nil)
+	clusterSpec.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "255.255.255.255"}
+	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec)
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host <255.255.255.255> is already in use, please provide a unique IP", err)
+}
+
 func TestSetupAndValidateUpgradeClusterNoUsername(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := givenEmptyClusterSpec()
@@ -2126,6 +2760,64 @@ func TestSetupAndValidateCreateClusterOsFamilyInvalid(t *testing.T) {
 	thenErrorExpected(t, "control plane osFamily: rhel is not supported, please use one of the following: bottlerocket, ubuntu", err)
 }
 
+func TestSetupAndValidateCreateClusterHostEntriesBottlerocket(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+	provider := givenProvider(t)
+	controlPlaneMachineConfigName := clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	provider.machineConfigs[controlPlaneMachineConfigName].Spec.OSFamily = v1alpha1.Bottlerocket
+	var tctx testContext
+	tctx.SaveContext()
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	thenErrorExpected(t, "host entries are not supported for osFamily bottlerocket, used by VSphereMachineConfig "+controlPlaneMachineConfigName, err)
+}
+
+func TestSetupAndValidateCreateClusterHostEntriesBottlerocketWorkers(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+	provider := givenProvider(t)
+	workerMachineConfigName := "test-wn"
+	provider.machineConfigs[workerMachineConfigName] = provider.machineConfigs[clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].DeepCopy()
+	provider.machineConfigs[workerMachineConfigName].Name = workerMachineConfigName
+	provider.machineConfigs[workerMachineConfigName].Spec.OSFamily = v1alpha1.Bottlerocket
+	clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name = workerMachineConfigName
+	var tctx testContext
+	tctx.SaveContext()
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	thenErrorExpected(t, "host entries are not supported for osFamily bottlerocket, used by VSphereMachineConfig "+workerMachineConfigName, err)
+}
+
+func TestSetupAndValidateCreateClusterKubeProxyBottlerocket(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	clusterSpec.Spec.ClusterNetwork.KubeProxy = &v1alpha1.KubeProxyConfiguration{Mode: v1alpha1.KubeProxyModeIPVS}
+	provider := givenProvider(t)
+	controlPlaneMachineConfigName := clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	provider.machineConfigs[controlPlaneMachineConfigName].Spec.OSFamily = v1alpha1.Bottlerocket
+	var tctx testContext
+	tctx.SaveContext()
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	thenErrorExpected(t, "kubeProxy mode ipvs is not supported for osFamily bottlerocket", err)
+}
+
+func TestSetupAndValidateCreateClusterHostEntriesUnresolvedNames(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+	clusterSpec.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{Endpoint: "nexus.lab.local", Port: "443"}
+	provider := givenProvider(t)
+	var tctx testContext
+	tctx.SaveContext()
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	thenErrorExpected(t, "names nexus.lab.local can't be resolved, add them to the cluster hostEntries or to the admin machine DNS", err)
+}
+
 func TestSetupAndValidateCreateClusterOsFamilyInvalidWorkerNode(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := givenEmptyClusterSpec()
@@ -2289,6 +2981,32 @@ func TestSetupAndValidateCreateClusterTemplateMissingTags(t *testing.T) {
 	thenErrorPrefixExpected(t, "template "+testTemplate+" is missing tag ", err)
 }
 
+func TestSetupAndValidateCreateClusterFIPSTemplateMissingTag(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Spec.FIPSEnabled = true
+
+	tt.setExpectationForSetup()
+	tt.setExpectationsForDefaultDiskGovcCalls()
+	tt.setExpectationForVCenterValidation()
+	tt.setExpectationsForMachineConfigsVCenterValidation()
+
+	for _, mc := range tt.machineConfigs {
+		tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, mc).Return(mc.Spec.Template, nil)
+	}
+	controlPlaneMachineConfigName := tt.clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	controlPlaneMachineConfig := tt.machineConfigs[controlPlaneMachineConfigName]
+
+	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig).Return(controlPlaneMachineConfig.Spec.Template, nil)
+	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return([]string{
+		"eksdRelease:" + tt.clusterSpec.VersionsBundle.EksD.Name,
+		"os:" + string(controlPlaneMachineConfig.Spec.OSFamily),
+	}, nil)
+
+	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
+
+	thenErrorExpected(t, "template "+testTemplate+" is missing tag fips:enabled", err)
+}
+
 func TestSetupAndValidateCreateClusterErrorGettingTags(t *testing.T) {
 	tt := newProviderTest(t)
 	errorMessage := "failed getting tags"
@@ -2346,6 +3064,20 @@ func TestSetupAndValidateCreateClusterDefaultTemplate(t *testing.T) {
 	}
 }
 
+func TestSetupAndValidateCreateClusterFIPSDefaultTemplate(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	clusterSpec.Spec.FIPSEnabled = true
+	provider := givenProvider(t)
+	controlPlaneMachineConfigName := clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	provider.machineConfigs[controlPlaneMachineConfigName].Spec.Template = ""
+	setupContext(t)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "failed setting default values for vsphere machine configs: template is required for VSphereMachineConfig "+controlPlaneMachineConfigName+" when FIPS is enabled, the default templates are not FIPS enabled", err)
+}
+
 func TestGetInfrastructureBundleSuccess(t *testing.T) {
 	tests := []struct {
 		testName    string
@@ -2817,9 +3549,19 @@ func TestVsphereProviderRunPostControlPlaneUpgrade(t *testing.T) {
 	tt := newProviderTest(t)
 
 	tt.resourceSetManager.EXPECT().ForceUpdate(tt.ctx, "test-crs-0", "eksa-system", tt.managementCluster, tt.workloadCluster)
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.workloadCluster, gomock.Any())
 	tt.Expect(tt.provider.RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, tt.workloadCluster, tt.managementCluster)).To(Succeed())
 }
 
+func TestVsphereProviderRunPostControlPlaneUpgradeStorageClassesError(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.provider.Retrier = retrier.NewWithMaxRetries(1, 0)
+
+	tt.resourceSetManager.EXPECT().ForceUpdate(tt.ctx, "test-crs-0", "eksa-system", tt.managementCluster, tt.workloadCluster)
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.workloadCluster, gomock.Any()).Return(errors.New("forbidden"))
+	tt.Expect(tt.provider.RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, tt.workloadCluster, tt.managementCluster)).To(MatchError("failed updating storage classes post upgrade: forbidden"))
+}
+
 func TestProviderUpgradeNeeded(t *testing.T) {
 	testCases := []struct {
 		testName               string
@@ -3030,3 +3772,25 @@ func TestSetupAndValidateCreateManagementDoesNotCheckIfMachineAndDataCenterExist
 	}
 	assert.NoError(t, err, "No error should be returned")
 }
+
+func TestValidateAccessSuccess(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.datacenterConfig.Spec.Insecure = true
+	tt.govc.EXPECT().ValidateVCenterConnection(tt.ctx, tt.datacenterConfig.Spec.Server).Return(nil)
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx).Return(nil)
+	tt.govc.EXPECT().DatacenterExists(tt.ctx, tt.datacenterConfig.Spec.Datacenter).Return(true, nil)
+
+	tt.Expect(tt.provider.ValidateAccess(tt.ctx)).To(Succeed())
+}
+
+func TestValidateAccessAuthenticationError(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.govc.EXPECT().ValidateVCenterConnection(tt.ctx, tt.datacenterConfig.Spec.Server).Return(nil)
+	tt.govc.EXPECT().ValidateVCenterAuthentication(tt.ctx).Return(errors.New("invalid credentials"))
+
+	err := tt.provider.ValidateAccess(tt.ctx)
+	tt.Expect(err).To(MatchError("failed validating credentials for vCenter: invalid credentials"))
+	var authErr *providers.AuthenticationError
+	tt.Expect(errors.As(err, &authErr)).To(BeTrue())
+	tt.Expect(authErr.Provider).To(Equal("vsphere"))
+}
@@ -0,0 +1,185 @@
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// DefaultGateTimeout is how long a gate without a timeout is waited for
+	DefaultGateTimeout = 10 * time.Minute
+
+	defaultNamespace    = "default"
+	defaultHTTPStatus   = http.StatusOK
+	defaultPollInterval = 5 * time.Second
+)
+
+// Client waits for the workloads of the gates in the cluster. executables.Kubectl implements it
+type Client interface {
+	RolloutStatus(ctx context.Context, kind, name, timeout string, opts ...executables.KubectlOpt) error
+	Wait(ctx context.Context, kubeconfig string, timeout string, forCondition string, property string, namespace string) error
+}
+
+// Waiter waits for the readiness gates of a cluster to pass
+type Waiter struct {
+	client       Client
+	httpClient   *http.Client
+	pollInterval time.Duration
+}
+
+type WaiterOpt func(*Waiter)
+
+// WithPollInterval sets how often the HTTP gates are checked until they pass, and the objects of the DaemonSet and Job
+// gates until they exist
+func WithPollInterval(interval time.Duration) WaiterOpt {
+	return func(w *Waiter) {
+		w.pollInterval = interval
+	}
+}
+
+// WithHTTPClient sets the client the HTTP gates are checked with
+func WithHTTPClient(client *http.Client) WaiterOpt {
+	return func(w *Waiter) {
+		w.httpClient = client
+	}
+}
+
+func NewWaiter(client Client, opts ...WaiterOpt) *Waiter {
+	w := &Waiter{
+		client:       client,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		pollInterval: defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Wait waits for the gates in order, each one up to its own timeout, and fails with the first one that doesn't pass
+func (w *Waiter) Wait(ctx context.Context, cluster *types.Cluster, gates []v1alpha1.ReadinessGate) error {
+	for _, gate := range gates {
+		logger.Info("Waiting for readiness gate", "gate", gate.Name)
+		if err := w.waitForGate(ctx, cluster, gate); err != nil {
+			return fmt.Errorf("readiness gate %s didn't pass: %v", gate.Name, err)
+		}
+		logger.V(3).Info("Readiness gate passed", "gate", gate.Name)
+	}
+	return nil
+}
+
+func (w *Waiter) waitForGate(ctx context.Context, cluster *types.Cluster, gate v1alpha1.ReadinessGate) error {
+	timeout, err := gateTimeout(gate)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case gate.HTTP != nil:
+		return w.waitForHTTP(ctx, gate.HTTP, timeout)
+	case gate.DaemonSet != nil:
+		return w.waitForObject(ctx, "daemonset", gate.DaemonSet.Name, timeout, func(ctx context.Context) error {
+			return w.client.RolloutStatus(ctx, "daemonset", gate.DaemonSet.Name, timeout.String(),
+				executables.WithCluster(cluster), executables.WithNamespace(namespace(gate.DaemonSet)),
+			)
+		})
+	case gate.Job != nil:
+		return w.waitForObject(ctx, "job", gate.Job.Name, timeout, func(ctx context.Context) error {
+			return w.client.Wait(ctx, cluster.KubeconfigFile, timeout.String(), "complete",
+				fmt.Sprintf("job/%s", gate.Job.Name), namespace(gate.Job),
+			)
+		})
+	default:
+		return fmt.Errorf("gate doesn't have any check")
+	}
+}
+
+// waitForObject runs wait until it passes or fails for a reason other than the object not existing yet, since the
+// workloads that create it can still be coming up. The object has the whole gate timeout to appear and be ready
+func (w *Waiter) waitForObject(ctx context.Context, kind, name string, timeout time.Duration, wait func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, retrier.Scale(timeout))
+	defer cancel()
+	for {
+		err := wait(ctx)
+		if err == nil || !isNotFound(err) {
+			return err
+		}
+		logger.V(4).Info("Readiness gate object not found yet", "kind", kind, "name", name)
+
+		if retrier.Sleep(ctx, w.pollInterval) != nil {
+			return fmt.Errorf("%s %s wasn't created in %s: %v", kind, name, timeout, err)
+		}
+	}
+}
+
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), "NotFound")
+}
+
+func (w *Waiter) waitForHTTP(ctx context.Context, gate *v1alpha1.HTTPReadinessGate, timeout time.Duration) error {
+	expected := gate.ExpectedStatus
+	if expected == 0 {
+		expected = defaultHTTPStatus
+	}
+
+	timeout = retrier.Scale(timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var last string
+	for {
+		status, err := w.getStatus(ctx, gate.URL)
+		if err == nil && status == expected {
+			return nil
+		}
+		if err != nil {
+			last = err.Error()
+		} else {
+			last = fmt.Sprintf("status %d", status)
+		}
+		logger.V(4).Info("HTTP readiness gate not passing yet", "url", gate.URL, "last", last)
+
+		if retrier.Sleep(ctx, w.pollInterval) != nil {
+			return fmt.Errorf("%s didn't return status %d in %s, last response: %s", gate.URL, expected, timeout, last)
+		}
+	}
+}
+
+func (w *Waiter) getStatus(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func gateTimeout(gate v1alpha1.ReadinessGate) (time.Duration, error) {
+	if gate.Timeout == "" {
+		return DefaultGateTimeout, nil
+	}
+	timeout, err := time.ParseDuration(gate.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %s: %v", gate.Timeout, err)
+	}
+	return timeout, nil
+}
+
+func namespace(object *v1alpha1.ObjectReadinessGate) string {
+	if object.Namespace == "" {
+		return defaultNamespace
+	}
+	return object.Namespace
+}
@@ -0,0 +1,126 @@
+package readiness_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/readiness"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type fakeClient struct {
+	calls []string
+	err   error
+	// errs are returned by the first calls, before err
+	errs []error
+}
+
+func (f *fakeClient) nextErr() error {
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return err
+	}
+	return f.err
+}
+
+func (f *fakeClient) RolloutStatus(_ context.Context, kind, name, timeout string, opts ...executables.KubectlOpt) error {
+	params := []string{"rollout", kind, name, timeout}
+	for _, opt := range opts {
+		opt(&params)
+	}
+	f.calls = append(f.calls, params...)
+	return f.nextErr()
+}
+
+func (f *fakeClient) Wait(_ context.Context, kubeconfig, timeout, forCondition, property, namespace string) error {
+	f.calls = append(f.calls, "wait", kubeconfig, timeout, forCondition, property, namespace)
+	return f.nextErr()
+}
+
+var cluster = &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+
+func TestWaitObjectGates(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeClient{}
+	gates := []v1alpha1.ReadinessGate{
+		{Name: "agent", DaemonSet: &v1alpha1.ObjectReadinessGate{Name: "security-agent", Namespace: "security"}, Timeout: "5m"},
+		{Name: "smoke-test", Job: &v1alpha1.ObjectReadinessGate{Name: "smoke-test"}},
+	}
+
+	g.Expect(readiness.NewWaiter(client).Wait(context.Background(), cluster, gates)).To(Succeed())
+	g.Expect(client.calls).To(Equal([]string{
+		"rollout", "daemonset", "security-agent", "5m0s", "--kubeconfig", "test.kubeconfig", "--namespace", "security",
+		"wait", "test.kubeconfig", "10m0s", "complete", "job/smoke-test", "default",
+	}))
+}
+
+func TestWaitObjectGateError(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeClient{err: errors.New("timed out waiting for the condition")}
+	gates := []v1alpha1.ReadinessGate{{Name: "smoke-test", Job: &v1alpha1.ObjectReadinessGate{Name: "smoke-test"}}}
+
+	err := readiness.NewWaiter(client).Wait(context.Background(), cluster, gates)
+	g.Expect(err).To(MatchError("readiness gate smoke-test didn't pass: timed out waiting for the condition"))
+}
+
+func TestWaitObjectGateNotFoundYet(t *testing.T) {
+	g := NewWithT(t)
+	notFound := errors.New(`Error from server (NotFound): daemonsets.apps "security-agent" not found`)
+	client := &fakeClient{errs: []error{notFound, notFound}}
+	gates := []v1alpha1.ReadinessGate{{Name: "agent", DaemonSet: &v1alpha1.ObjectReadinessGate{Name: "security-agent"}}}
+
+	waiter := readiness.NewWaiter(client, readiness.WithPollInterval(time.Millisecond))
+	g.Expect(waiter.Wait(context.Background(), cluster, gates)).To(Succeed())
+	g.Expect(client.calls).To(HaveLen(3 * 8))
+}
+
+func TestWaitObjectGateNeverCreated(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeClient{err: errors.New(`Error from server (NotFound): jobs.batch "smoke-test" not found`)}
+	gates := []v1alpha1.ReadinessGate{{Name: "smoke-test", Job: &v1alpha1.ObjectReadinessGate{Name: "smoke-test"}, Timeout: "50ms"}}
+
+	waiter := readiness.NewWaiter(client, readiness.WithPollInterval(10*time.Millisecond))
+	err := waiter.Wait(context.Background(), cluster, gates)
+	g.Expect(err).To(MatchError(ContainSubstring("readiness gate smoke-test didn't pass: job smoke-test wasn't created in 50ms")))
+}
+
+func TestWaitHTTPGate(t *testing.T) {
+	g := NewWithT(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	gates := []v1alpha1.ReadinessGate{{Name: "ingress", HTTP: &v1alpha1.HTTPReadinessGate{URL: server.URL, ExpectedStatus: http.StatusNoContent}}}
+
+	waiter := readiness.NewWaiter(&fakeClient{}, readiness.WithPollInterval(time.Millisecond))
+	g.Expect(waiter.Wait(context.Background(), cluster, gates)).To(Succeed())
+	g.Expect(requests).To(Equal(3))
+}
+
+func TestWaitHTTPGateTimeout(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	gates := []v1alpha1.ReadinessGate{{Name: "ingress", HTTP: &v1alpha1.HTTPReadinessGate{URL: server.URL}, Timeout: "50ms"}}
+
+	waiter := readiness.NewWaiter(&fakeClient{}, readiness.WithPollInterval(10*time.Millisecond))
+	err := waiter.Wait(context.Background(), cluster, gates)
+	g.Expect(err).To(MatchError(ContainSubstring("readiness gate ingress didn't pass: " + server.URL + " didn't return status 200 in 50ms")))
+}
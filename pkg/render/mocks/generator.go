@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/render/render.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTemplateGenerator is a mock of TemplateGenerator interface.
+type MockTemplateGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateGeneratorMockRecorder
+}
+
+// MockTemplateGeneratorMockRecorder is the mock recorder for MockTemplateGenerator.
+type MockTemplateGeneratorMockRecorder struct {
+	mock *MockTemplateGenerator
+}
+
+// NewMockTemplateGenerator creates a new mock instance.
+func NewMockTemplateGenerator(ctrl *gomock.Controller) *MockTemplateGenerator {
+	mock := &MockTemplateGenerator{ctrl: ctrl}
+	mock.recorder = &MockTemplateGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateGenerator) EXPECT() *MockTemplateGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateCAPISpecForCreate mocks base method.
+func (m *MockTemplateGenerator) GenerateCAPISpecForCreate(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateCAPISpecForCreate", ctx, cluster, clusterSpec)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateCAPISpecForCreate indicates an expected call of GenerateCAPISpecForCreate.
+func (mr *MockTemplateGeneratorMockRecorder) GenerateCAPISpecForCreate(ctx, cluster, clusterSpec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCAPISpecForCreate", reflect.TypeOf((*MockTemplateGenerator)(nil).GenerateCAPISpecForCreate), ctx, cluster, clusterSpec)
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const redacted = "<redacted>"
+
+// TemplateGenerator builds the CAPI manifests of a cluster, implemented by the providers
+type TemplateGenerator interface {
+	GenerateCAPISpecForCreate(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error)
+}
+
+// Manifests are the CAPI manifests a create would apply for a cluster
+type Manifests struct {
+	ClusterName  string
+	ControlPlane []byte
+	Workers      []byte
+}
+
+// Renderer runs the template generation of a provider without a bootstrap cluster, so the CAPI objects
+// of a cluster config can be reviewed before running the create
+type Renderer struct {
+	generator TemplateGenerator
+	secrets   []string
+}
+
+type RendererOpt func(*Renderer)
+
+// WithRedactedValues replaces the values, like credentials the templates read from the environment, in the manifests
+func WithRedactedValues(values ...string) RendererOpt {
+	return func(r *Renderer) {
+		for _, v := range values {
+			if v != "" {
+				r.secrets = append(r.secrets, v)
+			}
+		}
+	}
+}
+
+func NewRenderer(generator TemplateGenerator, opts ...RendererOpt) *Renderer {
+	r := &Renderer{generator: generator}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Render generates the control plane and worker manifests of the cluster spec
+func (r *Renderer) Render(ctx context.Context, clusterSpec *cluster.Spec) (*Manifests, error) {
+	controlPlane, workers, err := r.generator.GenerateCAPISpecForCreate(ctx, nil, clusterSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed rendering CAPI templates: %v", err)
+	}
+
+	return &Manifests{
+		ClusterName:  clusterSpec.Name,
+		ControlPlane: r.redact(controlPlane),
+		Workers:      r.redact(workers),
+	}, nil
+}
+
+func (r *Renderer) redact(manifest []byte) []byte {
+	for _, secret := range r.secrets {
+		manifest = bytes.ReplaceAll(manifest, []byte(secret), []byte(redacted))
+	}
+	return manifest
+}
+
+// Write prints both manifests, each after a comment naming it
+func (m *Manifests) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# Control plane manifest of cluster %s\n%s", m.ClusterName, m.ControlPlane); err != nil {
+		return fmt.Errorf("failed writing control plane manifest: %v", err)
+	}
+	if _, err := fmt.Fprintf(w, "\n---\n# Workers manifest of cluster %s\n%s", m.ClusterName, m.Workers); err != nil {
+		return fmt.Errorf("failed writing workers manifest: %v", err)
+	}
+
+	return nil
+}
+
+// WriteFiles writes each manifest to its own file. They are named apart from the files of the create,
+// so rendering in the cluster folder never replaces them
+func (m *Manifests) WriteFiles(writer filewriter.FileWriter) (controlPlaneFile, workersFile string, err error) {
+	controlPlaneFile, err = writer.Write(fmt.Sprintf("%s-control-plane-rendered.yaml", m.ClusterName), m.ControlPlane, filewriter.PersistentFile)
+	if err != nil {
+		return "", "", fmt.Errorf("failed writing control plane manifest: %v", err)
+	}
+	workersFile, err = writer.Write(fmt.Sprintf("%s-workers-rendered.yaml", m.ClusterName), m.Workers, filewriter.PersistentFile)
+	if err != nil {
+		return "", "", fmt.Errorf("failed writing workers manifest: %v", err)
+	}
+
+	return controlPlaneFile, workersFile, nil
+}
@@ -0,0 +1,81 @@
+package render_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/render"
+	"github.com/aws/eks-anywhere/pkg/render/mocks"
+)
+
+type renderTest struct {
+	*WithT
+	ctx       context.Context
+	generator *mocks.MockTemplateGenerator
+	spec      *cluster.Spec
+}
+
+func newRenderTest(t *testing.T) *renderTest {
+	return &renderTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		generator: mocks.NewMockTemplateGenerator(gomock.NewController(t)),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "test-cluster"
+		}),
+	}
+}
+
+func TestRendererRender(t *testing.T) {
+	tt := newRenderTest(t)
+	tt.generator.EXPECT().GenerateCAPISpecForCreate(tt.ctx, nil, tt.spec).Return(
+		[]byte("kind: KubeadmControlPlane\npassword: \"s3cret\"\n"),
+		[]byte("kind: MachineDeployment\n"),
+		nil,
+	)
+
+	manifests, err := render.NewRenderer(tt.generator, render.WithRedactedValues("s3cret", "")).Render(tt.ctx, tt.spec)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(string(manifests.ControlPlane)).To(Equal("kind: KubeadmControlPlane\npassword: \"<redacted>\"\n"))
+
+	out := &bytes.Buffer{}
+	tt.Expect(manifests.Write(out)).To(Succeed())
+	tt.Expect(out.String()).To(Equal(`# Control plane manifest of cluster test-cluster
+kind: KubeadmControlPlane
+password: "<redacted>"
+
+---
+# Workers manifest of cluster test-cluster
+kind: MachineDeployment
+`))
+}
+
+func TestRendererRenderError(t *testing.T) {
+	tt := newRenderTest(t)
+	tt.generator.EXPECT().GenerateCAPISpecForCreate(tt.ctx, nil, tt.spec).Return(nil, nil, errors.New("missing template"))
+
+	_, err := render.NewRenderer(tt.generator).Render(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError("failed rendering CAPI templates: missing template"))
+}
+
+func TestManifestsWriteFiles(t *testing.T) {
+	g := NewWithT(t)
+	folder, writer := test.NewWriter(t)
+	manifests := &render.Manifests{ClusterName: "test-cluster", ControlPlane: []byte("cp"), Workers: []byte("md")}
+
+	controlPlaneFile, workersFile, err := manifests.WriteFiles(writer)
+	g.Expect(err).To(BeNil())
+	g.Expect(controlPlaneFile).To(Equal(filepath.Join(folder, "test-cluster-control-plane-rendered.yaml")))
+	content, err := ioutil.ReadFile(workersFile)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(Equal("md"))
+}
@@ -0,0 +1,139 @@
+package retrier
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	waitMultiplier   = 1.0
+	waitMultiplierMu sync.RWMutex
+)
+
+// SetWaitMultiplier scales every timeout and wait of the retriers and polling loops, for environments where everything
+// takes longer than usual, like nested virtualization. It must be at least 1
+func SetWaitMultiplier(m float64) error {
+	if m < 1 || math.IsInf(m, 0) || math.IsNaN(m) {
+		return fmt.Errorf("wait multiplier must be a number greater or equal to 1, got %v", m)
+	}
+	waitMultiplierMu.Lock()
+	defer waitMultiplierMu.Unlock()
+	waitMultiplier = m
+	return nil
+}
+
+// WaitMultiplier returns the multiplier set with SetWaitMultiplier, 1 by default
+func WaitMultiplier() float64 {
+	waitMultiplierMu.RLock()
+	defer waitMultiplierMu.RUnlock()
+	return waitMultiplier
+}
+
+// Scale returns d multiplied by the wait multiplier, capped to the max duration
+func Scale(d time.Duration) time.Duration {
+	m := WaitMultiplier()
+	if m == 1 {
+		return d
+	}
+	scaled := float64(d) * m
+	if scaled >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(scaled)
+}
+
+// ScaleTimeout scales a timeout in the format of the kubectl --timeout flag, like "60m". It's returned
+// unchanged if it can't be parsed
+func ScaleTimeout(timeout string) string {
+	d, err := time.ParseDuration(timeout)
+	if err != nil || WaitMultiplier() == 1 {
+		return timeout
+	}
+	return Scale(d).Round(time.Second).String()
+}
+
+// Sleep waits for d, returning the context error if it's done before
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// DefaultMaxWait caps the wait of a Backoff with no Max
+const DefaultMaxWait = 5 * time.Minute
+
+// Backoff is an exponential backoff: the wait starts at Initial and is multiplied by Factor after each retry, up to Max.
+// Each wait is then randomized by up to Jitter times itself, so callers retrying at the same time spread out
+type Backoff struct {
+	Initial time.Duration
+	// Max is DefaultMaxWait when not set
+	Max time.Duration
+	// Factor is 1 when not set, for a constant wait
+	Factor float64
+	// Jitter is a fraction of the wait, between 0 and 1
+	Jitter float64
+}
+
+// Wait returns the wait before the next retry, after totalRetries executions
+func (b Backoff) Wait(totalRetries int) time.Duration {
+	factor := b.Factor
+	if factor < 1 {
+		factor = 1
+	}
+	max := b.Max
+	if max <= 0 {
+		max = DefaultMaxWait
+	}
+	wait := float64(b.Initial) * math.Pow(factor, float64(totalRetries-1))
+	if math.IsNaN(wait) || wait > float64(max) {
+		wait = float64(max)
+	}
+	return jitter(time.Duration(wait), b.Jitter)
+}
+
+// WithBackoff sets a retry policy that always retries, waiting as set by the backoff
+func WithBackoff(b Backoff) RetrierOpt {
+	return func(r *Retrier) {
+		r.retryPolicy = func(totalRetries int, _ error) (bool, time.Duration) {
+			return true, b.Wait(totalRetries)
+		}
+	}
+}
+
+// WithJitter randomizes the waits of the retry policy by up to fraction times the wait
+func WithJitter(fraction float64) RetrierOpt {
+	return func(r *Retrier) {
+		r.jitter = fraction
+	}
+}
+
+var (
+	random   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	randomMu sync.Mutex
+)
+
+// jitter returns d plus a random duration between -fraction*d and fraction*d
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	randomMu.Lock()
+	delta := (random.Float64()*2 - 1) * fraction * float64(d)
+	randomMu.Unlock()
+	return d + time.Duration(delta)
+}
@@ -0,0 +1,100 @@
+package retrier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+func setWaitMultiplier(t *testing.T, m float64) {
+	if err := retrier.SetWaitMultiplier(m); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = retrier.SetWaitMultiplier(1) })
+}
+
+func TestSetWaitMultiplierInvalid(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(retrier.SetWaitMultiplier(0.5)).To(MatchError("wait multiplier must be a number greater or equal to 1, got 0.5"))
+	g.Expect(retrier.WaitMultiplier()).To(Equal(1.0))
+}
+
+func TestScale(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(retrier.Scale(time.Minute)).To(Equal(time.Minute))
+	g.Expect(retrier.ScaleTimeout("60m")).To(Equal("60m"))
+
+	setWaitMultiplier(t, 2.5)
+	g.Expect(retrier.Scale(time.Minute)).To(Equal(150 * time.Second))
+	g.Expect(retrier.Scale(time.Duration(1 << 62))).To(Equal(time.Duration(1<<63 - 1)))
+	g.Expect(retrier.ScaleTimeout("60m")).To(Equal("2h30m0s"))
+	g.Expect(retrier.ScaleTimeout("forever")).To(Equal("forever"))
+}
+
+func TestBackoffWait(t *testing.T) {
+	g := NewWithT(t)
+	b := retrier.Backoff{Initial: time.Second, Max: 5 * time.Second, Factor: 2}
+	g.Expect(b.Wait(1)).To(Equal(time.Second))
+	g.Expect(b.Wait(2)).To(Equal(2 * time.Second))
+	g.Expect(b.Wait(3)).To(Equal(4 * time.Second))
+	g.Expect(b.Wait(4)).To(Equal(5 * time.Second))
+
+	constant := retrier.Backoff{Initial: time.Second}
+	g.Expect(constant.Wait(10)).To(Equal(time.Second))
+}
+
+func TestBackoffWaitWithoutMax(t *testing.T) {
+	g := NewWithT(t)
+	b := retrier.Backoff{Initial: time.Second, Factor: 2}
+	g.Expect(b.Wait(8)).To(Equal(128 * time.Second))
+	g.Expect(b.Wait(10)).To(Equal(retrier.DefaultMaxWait))
+	g.Expect(b.Wait(5000)).To(Equal(retrier.DefaultMaxWait))
+}
+
+func TestBackoffWaitJitter(t *testing.T) {
+	g := NewWithT(t)
+	b := retrier.Backoff{Initial: 10 * time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		g.Expect(b.Wait(1)).To(BeNumerically("~", 10*time.Second, 2*time.Second))
+	}
+}
+
+func TestRetryWithContextStopsWhenContextDone(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	r := retrier.New(time.Hour, retrier.WithBackoff(retrier.Backoff{Initial: time.Hour}))
+	calls := 0
+
+	err := r.RetryWithContext(ctx, func() error {
+		calls++
+		cancel()
+		return errors.New("not ready")
+	})
+	g.Expect(err).To(MatchError("not ready"))
+	g.Expect(calls).To(Equal(1))
+}
+
+func TestRetryScalesTimeout(t *testing.T) {
+	g := NewWithT(t)
+	setWaitMultiplier(t, 3)
+	r := retrier.New(20*time.Millisecond, retrier.WithBackoff(retrier.Backoff{Initial: 5 * time.Millisecond}))
+	start := time.Now()
+
+	err := r.Retry(func() error { return errors.New("not ready") })
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 60*time.Millisecond))
+}
+
+func TestSleep(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(retrier.Sleep(context.Background(), time.Millisecond)).To(Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(retrier.Sleep(ctx, time.Hour)).To(MatchError(context.Canceled))
+}
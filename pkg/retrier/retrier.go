@@ -1,6 +1,7 @@
 package retrier
 
 import (
+	"context"
 	"math"
 	"time"
 
@@ -11,6 +12,7 @@ type Retrier struct {
 	retryPolicy   RetryPolicy
 	timeout       time.Duration
 	backoffFactor *float32
+	jitter        float64
 }
 
 type (
@@ -20,8 +22,12 @@ type (
 	RetrierOpt  func(*Retrier)
 )
 
+// DefaultJitter is the fraction to randomize the waits by for callers that opt in with WithJitter or Backoff.Jitter
+const DefaultJitter = 0.1
+
 // New creates a new retrier with a global timeout (max time allowed for the whole execution)
-// The default retry policy is to always retry with no wait time in between retries
+// The default retry policy is to always retry with no wait time in between retries. The waits
+// are only randomized with WithJitter
 func New(timeout time.Duration, opts ...RetrierOpt) *Retrier {
 	r := &Retrier{
 		timeout:     timeout,
@@ -63,10 +69,17 @@ func WithRetryPolicy(policy RetryPolicy) RetrierOpt {
 // Retry runs the fn function until it either successful completes (not error),
 // the set timeout reached or the retry policy aborts the execution
 func (r *Retrier) Retry(fn func() error) error {
+	return r.RetryWithContext(context.Background(), fn)
+}
+
+// RetryWithContext is Retry, but it also stops, returning the last error, when the context is done.
+// The timeout and the waits of the policy are scaled by the wait multiplier
+func (r *Retrier) RetryWithContext(ctx context.Context, fn func() error) error {
 	start := time.Now()
+	timeout := Scale(r.timeout)
 	retries := 0
 	var err error
-	for retry := true; retry; retry = time.Since(start) < r.timeout {
+	for retry := true; retry; retry = time.Since(start) < timeout {
 		err = fn()
 		retries += 1
 		if err == nil {
@@ -83,8 +96,12 @@ func (r *Retrier) Retry(fn func() error) error {
 		if r.backoffFactor != nil {
 			wait = wait * time.Duration(*r.backoffFactor*float32(retries))
 		}
+		wait = jitter(Scale(wait), r.jitter)
 		logger.V(5).Info("Sleeping before next retry", "time", wait)
-		time.Sleep(wait)
+		if ctxErr := Sleep(ctx, wait); ctxErr != nil {
+			logger.V(5).Info("Context done, returning error", "retries", retries, "reason", ctxErr.Error())
+			return err
+		}
 	}
 
 	logger.V(5).Info("Timeout reached. Returning error", "retries", retries, "duration", time.Since(start), "error", err)
@@ -0,0 +1,266 @@
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/substitution"
+)
+
+// config holds a copy of the CRDs in config/crd/bases for the user facing kinds, kept in sync by make generate-manifests
+
+//go:embed config/*.yaml
+var crdsFS embed.FS
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+type crd struct {
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Versions []struct {
+			Name    string `json:"name"`
+			Storage bool   `json:"storage"`
+			Schema  struct {
+				OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// Schema is the subset of an OpenAPI v3 schema used to validate EKS-A objects
+type Schema struct {
+	Type                  string             `json:"type,omitempty"`
+	Properties            map[string]*Schema `json:"properties,omitempty"`
+	Items                 *Schema            `json:"items,omitempty"`
+	AdditionalProperties  *Schema            `json:"additionalProperties,omitempty"`
+	Required              []string           `json:"required,omitempty"`
+	Enum                  []interface{}      `json:"enum,omitempty"`
+	PreserveUnknownFields bool               `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+}
+
+type kindSchema struct {
+	apiVersion string
+	kind       string
+	storage    bool
+	raw        json.RawMessage
+	schema     *Schema
+}
+
+// Validator validates EKS-A objects against the schemas of the EKS-A CRDs
+type Validator struct {
+	schemas map[string]*kindSchema
+}
+
+func NewValidator() (*Validator, error) {
+	files, err := crdsFS.ReadDir("config")
+	if err != nil {
+		return nil, fmt.Errorf("failed reading embedded crds: %v", err)
+	}
+
+	v := &Validator{schemas: map[string]*kindSchema{}}
+	for _, f := range files {
+		content, err := crdsFS.ReadFile("config/" + f.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed reading embedded crd [%s]: %v", f.Name(), err)
+		}
+
+		c := &crd{}
+		if err = yaml.Unmarshal(content, c); err != nil {
+			return nil, fmt.Errorf("failed parsing embedded crd [%s]: %v", f.Name(), err)
+		}
+
+		for _, version := range c.Spec.Versions {
+			s := &Schema{}
+			if err = json.Unmarshal(version.Schema.OpenAPIV3Schema, s); err != nil {
+				return nil, fmt.Errorf("failed parsing schema for %s %s: %v", c.Spec.Names.Kind, version.Name, err)
+			}
+			apiVersion := c.Spec.Group + "/" + version.Name
+			v.schemas[schemaKey(apiVersion, c.Spec.Names.Kind)] = &kindSchema{
+				apiVersion: apiVersion,
+				kind:       c.Spec.Names.Kind,
+				storage:    version.Storage,
+				raw:        version.Schema.OpenAPIV3Schema,
+				schema:     s,
+			}
+		}
+	}
+
+	return v, nil
+}
+
+func schemaKey(apiVersion, kind string) string {
+	return apiVersion + ", Kind=" + kind
+}
+
+// Kinds returns the sorted list of kinds with a schema
+func (v *Validator) Kinds() []string {
+	kinds := make([]string, 0, len(v.schemas))
+	seen := map[string]bool{}
+	for _, s := range v.schemas {
+		if !seen[s.kind] {
+			seen[s.kind] = true
+			kinds = append(kinds, s.kind)
+		}
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+// JSONSchema returns the JSON Schema for kind in its storage api version, which is the one the cli reads.
+// Unknown fields are disallowed so editors can flag typos the same way the validation does
+func (v *Validator) JSONSchema(kind string) ([]byte, error) {
+	s := v.schemaForKind(kind)
+	if s == nil {
+		return nil, fmt.Errorf("no schema for kind %s", kind)
+	}
+
+	schema := map[string]interface{}{}
+	if err := json.Unmarshal(s.raw, &schema); err != nil {
+		return nil, fmt.Errorf("failed parsing schema for %s: %v", kind, err)
+	}
+
+	disallowAdditionalProperties(schema)
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = s.kind
+	schema["required"] = appendMissing(toStrings(schema["required"]), "apiVersion", "kind")
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		properties["apiVersion"] = map[string]interface{}{"type": "string", "enum": []string{s.apiVersion}}
+		properties["kind"] = map[string]interface{}{"type": "string", "enum": []string{s.kind}}
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func (v *Validator) schemaForKind(kind string) *kindSchema {
+	for _, s := range v.schemas {
+		if s.kind == kind && s.storage {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func disallowAdditionalProperties(schema map[string]interface{}) {
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		if _, ok := schema["additionalProperties"]; !ok && schema["x-kubernetes-preserve-unknown-fields"] != true {
+			schema["additionalProperties"] = false
+		}
+		for _, p := range properties {
+			if child, ok := p.(map[string]interface{}); ok {
+				disallowAdditionalProperties(child)
+			}
+		}
+	}
+
+	for _, key := range []string{"items", "additionalProperties"} {
+		if child, ok := schema[key].(map[string]interface{}); ok {
+			disallowAdditionalProperties(child)
+		}
+	}
+}
+
+func toStrings(value interface{}) []string {
+	values, _ := value.([]interface{})
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		if str, ok := v.(string); ok {
+			s = append(s, str)
+		}
+	}
+
+	return s
+}
+
+func appendMissing(values []string, new ...string) []string {
+	for _, n := range new {
+		found := false
+		for _, v := range values {
+			if v == n {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, n)
+		}
+	}
+
+	return values
+}
+
+// ValidateFile validates all the EKS-A objects in a multi document yaml file
+func (v *Validator) ValidateFile(filename string) error {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("unable to read file due to: %v", err)
+	}
+
+	content, err = substitution.Substitute(content)
+	if err != nil {
+		return fmt.Errorf("unable to substitute values in %s: %v", filename, err)
+	}
+
+	return v.Validate(content)
+}
+
+// Validate validates all the EKS-A objects in a multi document yaml.
+// Documents with a kind that doesn't belong to EKS-A are ignored.
+// The returned error is a FieldErrors if the content doesn't match the schemas
+func (v *Validator) Validate(content []byte) error {
+	var errs FieldErrors
+	for i, doc := range strings.Split(string(content), v1alpha1.YamlSeparator) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return fmt.Errorf("failed parsing document %d: %v", i, err)
+		}
+
+		apiVersion, _ := obj["apiVersion"].(string)
+		kind, _ := obj["kind"].(string)
+		s, ok := v.schemas[schemaKey(apiVersion, kind)]
+		if !ok {
+			if kindSchema := v.schemaForKind(kind); kindSchema != nil && sameGroup(apiVersion, kindSchema.apiVersion) {
+				errs = append(errs, FieldError{Object: objectName(kind, obj), Field: "apiVersion", Detail: fmt.Sprintf("unsupported value %q, must be %s", apiVersion, kindSchema.apiVersion)})
+			}
+			continue
+		}
+
+		errs = append(errs, validate(objectName(kind, obj), "", obj, s.schema)...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// sameGroup avoids reporting kinds from other api groups with the same name, like the CAPI Cluster
+func sameGroup(apiVersion, other string) bool {
+	return strings.SplitN(apiVersion, "/", 2)[0] == strings.SplitN(other, "/", 2)[0]
+}
+
+func objectName(kind string, obj map[string]interface{}) string {
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		if name, ok := metadata["name"].(string); ok && name != "" {
+			return kind + " " + name
+		}
+	}
+
+	return kind
+}
@@ -0,0 +1,194 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+func TestValidatorValidateFileValid(t *testing.T) {
+	g := NewWithT(t)
+	v, err := schema.NewValidator()
+	g.Expect(err).To(BeNil())
+	g.Expect(v.ValidateFile("testdata/cluster_valid.yaml")).To(Succeed())
+}
+
+func TestValidatorValidateFileInvalid(t *testing.T) {
+	g := NewWithT(t)
+	v, err := schema.NewValidator()
+	g.Expect(err).To(BeNil())
+
+	err = v.ValidateFile("testdata/cluster_invalid.yaml")
+	var fieldErrs schema.FieldErrors
+	g.Expect(errors.As(err, &fieldErrs)).To(BeTrue())
+	g.Expect(fieldErrs).To(ConsistOf(
+		schema.FieldError{Object: "Cluster eksa-unit-test", Field: "spec.controlPlaneConfiguration.count", Detail: "expected integer, got string"},
+		schema.FieldError{Object: "Cluster eksa-unit-test", Field: "spec.workerNodeGroupConfigurations[0].machineGroupRefs", Detail: "unknown field"},
+		schema.FieldError{Object: "VSphereDatacenterConfig eksa-unit-test", Field: "spec.insecure", Detail: "expected boolean, got string"},
+	))
+}
+
+func TestValidatorValidate(t *testing.T) {
+	tests := []struct {
+		testName string
+		content  string
+		wantErr  string
+	}{
+		{
+			testName: "other api group",
+			content: `apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: test
+spec:
+  anything: true
+`,
+		},
+		{
+			testName: "unsupported api version",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1
+kind: GitOpsConfig
+metadata:
+  name: test
+`,
+			wantErr: `GitOpsConfig test: apiVersion: unsupported value "anywhere.eks.amazonaws.com/v1", must be anywhere.eks.amazonaws.com/v1alpha1`,
+		},
+		{
+			testName: "missing required field",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: AWSIamConfig
+metadata:
+  name: test
+spec:
+  backendMode:
+    - mountedfile
+`,
+			wantErr: "AWSIamConfig test: spec.awsRegion: required field is missing",
+		},
+		{
+			testName: "missing required number defaulted by cli",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereMachineConfig
+metadata:
+  name: test
+spec:
+  datastore: datastore
+  folder: folder
+  osFamily: ubuntu
+  resourcePool: pool
+  template: template
+  users:
+    - name: capv
+      sshAuthorizedKeys:
+        - key
+`,
+		},
+		{
+			testName: "scalar as string",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: test
+spec:
+  kubernetesVersion: 1.21
+  registryMirrorConfiguration:
+    endpoint: 1.2.3.4
+    port: 443
+`,
+		},
+		{
+			testName: "v1beta1 version",
+			content: `apiVersion: anywhere.eks.amazonaws.com/v1beta1
+kind: Cluster
+metadata:
+  name: test
+spec:
+  clusterNetwork:
+    cniConfig:
+      cilium: {}
+  workerNodeGroups:
+    - count: 1
+`,
+			wantErr: "Cluster test: spec.workerNodeGroups[0].name: required field is missing",
+		},
+		{
+			testName: "invalid yaml",
+			content:  "kind: [",
+			wantErr:  "failed parsing document 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			v, err := schema.NewValidator()
+			g.Expect(err).To(BeNil())
+
+			err = v.Validate([]byte(tt.content))
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatorKinds(t *testing.T) {
+	g := NewWithT(t)
+	v, err := schema.NewValidator()
+	g.Expect(err).To(BeNil())
+	g.Expect(v.Kinds()).To(ContainElements("Cluster", "VSphereDatacenterConfig", "VSphereMachineConfig", "DockerDatacenterConfig", "GitOpsConfig", "OIDCConfig", "AWSIamConfig"))
+	g.Expect(v.Kinds()).NotTo(ContainElement("Bundles"))
+}
+
+func TestValidatorJSONSchema(t *testing.T) {
+	g := NewWithT(t)
+	v, err := schema.NewValidator()
+	g.Expect(err).To(BeNil())
+
+	content, err := v.JSONSchema("Cluster")
+	g.Expect(err).To(BeNil())
+
+	jsonSchema := map[string]interface{}{}
+	g.Expect(json.Unmarshal(content, &jsonSchema)).To(Succeed())
+	g.Expect(jsonSchema["$schema"]).To(Equal("http://json-schema.org/draft-07/schema#"))
+	g.Expect(jsonSchema["required"]).To(ContainElements("apiVersion", "kind"))
+
+	properties := jsonSchema["properties"].(map[string]interface{})
+	g.Expect(properties["kind"]).To(HaveKeyWithValue("enum", ConsistOf("Cluster")))
+	g.Expect(properties["apiVersion"]).To(HaveKeyWithValue("enum", ConsistOf("anywhere.eks.amazonaws.com/v1alpha1")))
+	g.Expect(properties["spec"]).To(HaveKeyWithValue("additionalProperties", false))
+	g.Expect(properties["metadata"]).NotTo(HaveKey("additionalProperties"))
+}
+
+func TestValidatorJSONSchemaUnknownKind(t *testing.T) {
+	g := NewWithT(t)
+	v, err := schema.NewValidator()
+	g.Expect(err).To(BeNil())
+
+	_, err = v.JSONSchema("Bundles")
+	g.Expect(err).NotTo(BeNil())
+}
+
+// The embedded crds need to be updated with make generate-manifests every time the api changes
+func TestEmbeddedCRDsInSync(t *testing.T) {
+	g := NewWithT(t)
+	embedded, err := filepath.Glob("config/*.yaml")
+	g.Expect(err).To(BeNil())
+	g.Expect(embedded).NotTo(BeEmpty())
+
+	for _, f := range embedded {
+		got, err := ioutil.ReadFile(f)
+		g.Expect(err).To(BeNil())
+		want, err := ioutil.ReadFile(filepath.Join("../../config/crd/bases", filepath.Base(f)))
+		g.Expect(err).To(BeNil())
+		g.Expect(string(got)).To(Equal(string(want)), "%s is out of sync, run make generate-manifests", f)
+	}
+}
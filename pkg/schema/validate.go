@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// FieldError describes a field of an object that doesn't match its schema
+type FieldError struct {
+	Object string
+	Field  string
+	Detail string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Object, e.Field, e.Detail)
+}
+
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func validate(object, path string, value interface{}, s *Schema) []FieldError {
+	if value == nil {
+		return nil
+	}
+
+	fail := func(format string, args ...interface{}) []FieldError {
+		return []FieldError{{Object: object, Field: fieldPath(path), Detail: fmt.Sprintf(format, args...)}}
+	}
+
+	if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+		return fail("unsupported value %v, must be one of %v", value, s.Enum)
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fail("expected object, got %s", typeName(value))
+		}
+		return validateObject(object, path, obj, s)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fail("expected array, got %s", typeName(value))
+		}
+		var errs []FieldError
+		if s.Items != nil {
+			for i, item := range items {
+				errs = append(errs, validate(object, fmt.Sprintf("%s[%d]", path, i), item, s.Items)...)
+			}
+		}
+		return errs
+	case "string":
+		// yaml scalars are converted to strings when parsing the cluster config, so "port: 443" is valid
+		if _, ok := value.(map[string]interface{}); ok {
+			return fail("expected string, got %s", typeName(value))
+		}
+		if _, ok := value.([]interface{}); ok {
+			return fail("expected string, got %s", typeName(value))
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			return fail("expected integer, got %s", typeName(value))
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fail("expected number, got %s", typeName(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fail("expected boolean, got %s", typeName(value))
+		}
+	}
+
+	return nil
+}
+
+func validateObject(object, path string, obj map[string]interface{}, s *Schema) []FieldError {
+	var errs []FieldError
+	for _, required := range s.Required {
+		if _, ok := obj[required]; !ok && !defaultedByCli(s.Properties[required]) {
+			errs = append(errs, FieldError{Object: object, Field: fieldPath(joinField(path, required)), Detail: "required field is missing"})
+		}
+	}
+
+	// objects without properties, like metadata, are free form
+	freeForm := len(s.Properties) == 0 || s.PreserveUnknownFields
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if property, ok := s.Properties[k]; ok {
+			errs = append(errs, validate(object, joinField(path, k), obj[k], property)...)
+			continue
+		}
+		if s.AdditionalProperties != nil {
+			errs = append(errs, validate(object, joinField(path, k), obj[k], s.AdditionalProperties)...)
+			continue
+		}
+		if !freeForm {
+			errs = append(errs, FieldError{Object: object, Field: fieldPath(joinField(path, k)), Detail: "unknown field"})
+		}
+	}
+
+	return errs
+}
+
+// defaultedByCli returns true for numbers and booleans: their zero value is a valid input
+// that the cli replaces with a default, so they can be omitted even if the crd requires them
+func defaultedByCli(s *Schema) bool {
+	if s == nil {
+		return false
+	}
+
+	switch s.Type {
+	case "integer", "number", "boolean":
+		return true
+	default:
+		return false
+	}
+}
+
+func joinField(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+
+	return path
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		if e == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
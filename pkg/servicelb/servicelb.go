@@ -0,0 +1,78 @@
+package servicelb
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/manifest.yaml
+var manifestTemplate string
+
+// globalPool is the kube-vip cloud provider key suffix for the pool used by the namespaces without their own pool
+const globalPool = "global"
+
+// GenerateManifest returns the manifest for the service load balancer configured in the cluster spec:
+// kube-vip in services mode, which advertises the LoadBalancer service addresses from every node,
+// and the kube-vip cloud provider, which assigns them from the address pools
+func GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
+	config := clusterSpec.Spec.ServiceLoadBalancer
+	if config == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have a service load balancer configuration", clusterSpec.Name)
+	}
+
+	data := map[string]interface{}{
+		"kubeVipImage":       clusterSpec.VersionsBundle.KubeVip.KubeVip.VersionedImage(),
+		"cloudProviderImage": clusterSpec.VersionsBundle.KubeVip.CloudProvider.VersionedImage(),
+		"pools":              addressPools(clusterSpec),
+	}
+
+	if config.BGP != nil {
+		peers := make([]string, 0, len(config.BGP.Peers))
+		for _, peer := range config.BGP.Peers {
+			// kube-vip peer format is address:as:password:multihop
+			peers = append(peers, fmt.Sprintf("%s:%d::false", peer.Address, peer.ASN))
+		}
+		data["bgp"] = true
+		data["bgpLocalAsn"] = config.BGP.LocalASN
+		data["bgpPeers"] = strings.Join(peers, ",")
+	}
+
+	manifest, err := templater.Execute(manifestTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("error generating service load balancer manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+// addressPools returns the kube-vip cloud provider configuration, where each pool is
+// a cidr-<namespace> key with the CIDRs and a range-<namespace> key with the IP ranges
+func addressPools(clusterSpec *cluster.Spec) map[string]string {
+	pools := map[string]string{}
+	for _, pool := range clusterSpec.Spec.ServiceLoadBalancer.AddressPools {
+		namespace := pool.Namespace
+		if namespace == "" {
+			namespace = globalPool
+		}
+
+		var cidrs, ranges []string
+		for _, address := range pool.Addresses {
+			if strings.Contains(address, "/") {
+				cidrs = append(cidrs, address)
+			} else {
+				ranges = append(ranges, strings.ReplaceAll(address, " ", ""))
+			}
+		}
+
+		if len(cidrs) > 0 {
+			pools["cidr-"+namespace] = strings.Join(cidrs, ",")
+		}
+		if len(ranges) > 0 {
+			pools["range-"+namespace] = strings.Join(ranges, ",")
+		}
+	}
+	return pools
+}
@@ -0,0 +1,75 @@
+package servicelb_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/servicelb"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func givenClusterSpec(config *v1alpha1.ServiceLoadBalancerConfiguration) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.ServiceLoadBalancer = config
+		s.VersionsBundle.KubeVip = releasev1alpha1.KubeVipBundle{
+			KubeVip: releasev1alpha1.Image{
+				URI: "public.ecr.aws/l0g8r8j6/plunder-app/kube-vip:v0.3.7-eks-a-v0.0.0-dev-build.581",
+			},
+			CloudProvider: releasev1alpha1.Image{
+				URI: "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip-cloud-provider:v0.0.2-eks-a-v0.0.0-dev-build.581",
+			},
+		}
+	})
+}
+
+func TestGenerateManifestL2(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := givenClusterSpec(&v1alpha1.ServiceLoadBalancerConfiguration{
+		AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{
+			{
+				Addresses: []string{"10.0.0.0/28", "10.0.1.10-10.0.1.20"},
+			},
+			{
+				Namespace: "apps",
+				Addresses: []string{"10.0.2.0/29", "10.0.3.0/29"},
+			},
+		},
+	})
+
+	manifest, err := servicelb.GenerateManifest(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results_l2.yaml")
+}
+
+func TestGenerateManifestBGP(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := givenClusterSpec(&v1alpha1.ServiceLoadBalancerConfiguration{
+		AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{
+			{
+				Addresses: []string{"10.0.0.0/28"},
+			},
+		},
+		BGP: &v1alpha1.ServiceLoadBalancerBGP{
+			LocalASN: 65000,
+			Peers: []v1alpha1.ServiceLoadBalancerBGPPeer{
+				{Address: "192.168.0.1", ASN: 65001},
+				{Address: "192.168.0.2", ASN: 65001},
+			},
+		},
+	})
+
+	manifest, err := servicelb.GenerateManifest(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_results_bgp.yaml")
+}
+
+func TestGenerateManifestNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	_, err := servicelb.GenerateManifest(givenClusterSpec(nil))
+	g.Expect(err).To(MatchError("cluster test-cluster doesn't have a service load balancer configuration"))
+}
@@ -0,0 +1,235 @@
+package substitution
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	referenceSeparator = "://"
+	defaultSeparator   = ":-"
+)
+
+// SecretProvider resolves the secret references for one scheme.
+// For a reference like vault://secret/data/vsphere#password, Resolve gets secret/data/vsphere#password
+type SecretProvider interface {
+	Resolve(ref string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to a SecretProvider
+type SecretProviderFunc func(ref string) (string, error)
+
+func (f SecretProviderFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+var (
+	providersLock sync.RWMutex
+	providers     = map[string]SecretProvider{
+		"env":  SecretProviderFunc(resolveEnv),
+		"file": SecretProviderFunc(resolveFile),
+	}
+)
+
+// RegisterSecretProvider makes a SecretProvider available for all the references with the given scheme.
+// Registering a scheme twice replaces the previous provider
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	providers[scheme] = provider
+}
+
+func secretProvider(scheme string) (SecretProvider, bool) {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	p, ok := providers[scheme]
+	return p, ok
+}
+
+func resolveEnv(ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return value, nil
+}
+
+func resolveFile(ref string) (string, error) {
+	content, err := ioutil.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret file: %v", err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// mode selects which placeholders are replaced
+type mode int
+
+const (
+	// loadMode replaces the environment variables and checks that the secret references resolve, keeping them
+	loadMode mode = iota
+	// renderMode only replaces the secret references with registered providers
+	renderMode
+)
+
+// Substitute replaces the placeholders in a yaml cluster config:
+//   - ${VAR} is replaced with the value of the environment variable VAR, failing if it's not set
+//   - ${VAR:-default} uses default when VAR is not set or empty
+//   - $${ is kept as a literal ${
+//
+// Secret references, ${scheme://ref}, are checked with the provider registered for scheme but kept as they are,
+// so the secrets don't end up in the cluster objects. ResolveSecrets replaces them in the rendered manifests.
+// file:// and env:// are always available.
+//
+// Comment lines are not substituted. All the placeholders that can't be resolved are reported in the returned error
+func Substitute(content []byte) ([]byte, error) {
+	return substitute(content, loadMode)
+}
+
+// ResolveSecrets replaces the secret references, ${scheme://ref}, with the secret returned by the provider registered
+// for scheme. Other placeholders and references with unknown schemes are kept, since they can be part of the content.
+// $${scheme://ref} is kept as a literal ${scheme://ref}
+func ResolveSecrets(content []byte) ([]byte, error) {
+	return substitute(content, renderMode)
+}
+
+func substitute(content []byte, m mode) ([]byte, error) {
+	var out bytes.Buffer
+	var errs []error
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			var lineErrs []error
+			line, lineErrs = substituteLine(line, m)
+			errs = append(errs, lineErrs...)
+		}
+		out.WriteString(line)
+		out.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read content: %v", err)
+	}
+
+	if len(errs) > 0 {
+		return nil, kerrors.NewAggregate(errs)
+	}
+
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+	}
+	return out.Bytes(), nil
+}
+
+func substituteLine(line string, m mode) (string, []error) {
+	var b strings.Builder
+	var errs []error
+	for {
+		start := strings.Index(line, "${")
+		if start == -1 {
+			b.WriteString(line)
+			return b.String(), errs
+		}
+
+		if start > 0 && line[start-1] == '$' {
+			// References stay escaped until they are rendered, so the literal isn't resolved then
+			if isReference(line[start+2:]) == (m == renderMode) {
+				b.WriteString(line[:start-1])
+				b.WriteString("${")
+			} else {
+				b.WriteString(line[:start+2])
+			}
+			line = line[start+2:]
+			continue
+		}
+
+		end := strings.Index(line[start:], "}")
+		if end == -1 {
+			if m == loadMode {
+				errs = append(errs, fmt.Errorf("unclosed placeholder in %q", line[start:]))
+			}
+			b.WriteString(line)
+			return b.String(), errs
+		}
+		end += start
+
+		placeholder := line[start+2 : end]
+		value, err := resolve(placeholder, m)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		b.WriteString(line[:start])
+		b.WriteString(value)
+		line = line[end+1:]
+	}
+}
+
+// isReference returns true if content starts with a secret reference, closed by a }
+func isReference(content string) bool {
+	end := strings.Index(content, "}")
+	return end != -1 && strings.Contains(content[:end], referenceSeparator)
+}
+
+// resolve returns the value placeholder is replaced with in mode m, which is the placeholder itself when it's kept
+func resolve(placeholder string, m mode) (string, error) {
+	kept := "${" + placeholder + "}"
+	if i := strings.Index(placeholder, referenceSeparator); i != -1 {
+		scheme, ref := placeholder[:i], placeholder[i+len(referenceSeparator):]
+		provider, ok := secretProvider(scheme)
+		if !ok {
+			if m == renderMode {
+				return kept, nil
+			}
+			return kept, fmt.Errorf("no secret provider registered for %s, available providers: %s", placeholder, strings.Join(registeredSchemes(), ", "))
+		}
+		value, err := provider.Resolve(ref)
+		if err != nil {
+			return kept, fmt.Errorf("failed resolving secret %s: %v", placeholder, err)
+		}
+		if m == loadMode {
+			return kept, nil
+		}
+		return value, nil
+	}
+
+	if m == renderMode {
+		return kept, nil
+	}
+
+	name, defaultValue, hasDefault := placeholder, "", false
+	if i := strings.Index(placeholder, defaultSeparator); i != -1 {
+		name, defaultValue, hasDefault = placeholder[:i], placeholder[i+len(defaultSeparator):], true
+	}
+	if name == "" {
+		return "", fmt.Errorf("empty placeholder ${%s}", placeholder)
+	}
+
+	value, ok := os.LookupEnv(name)
+	if hasDefault && value == "" {
+		return defaultValue, nil
+	}
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+func registeredSchemes() []string {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	schemes := make([]string, 0, len(providers))
+	for s := range providers {
+		schemes = append(schemes, s)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
@@ -0,0 +1,199 @@
+package substitution_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/substitution"
+)
+
+func setEnv(t *testing.T, key, value string) {
+	previous, ok := os.LookupEnv(key)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+	os.Setenv(key, value)
+}
+
+func TestSubstitute(t *testing.T) {
+	setEnv(t, "EKSA_TEST_NAME", "my-cluster")
+	setEnv(t, "EKSA_TEST_EMPTY", "")
+	os.Unsetenv("EKSA_TEST_UNSET")
+
+	secretFile := filepath.Join(t.TempDir(), "thumbprint")
+	if err := ioutil.WriteFile(secretFile, []byte("AB:CD:EF\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		testName string
+		content  string
+		want     string
+		wantErr  string
+	}{
+		{
+			testName: "no placeholders",
+			content:  "name: test\n",
+			want:     "name: test\n",
+		},
+		{
+			testName: "env var",
+			content:  "name: ${EKSA_TEST_NAME}\nnamespace: ${EKSA_TEST_NAME}-ns",
+			want:     "name: my-cluster\nnamespace: my-cluster-ns",
+		},
+		{
+			testName: "default value for unset var",
+			content:  "count: ${EKSA_TEST_UNSET:-3}\n",
+			want:     "count: 3\n",
+		},
+		{
+			testName: "default value for empty var",
+			content:  "count: ${EKSA_TEST_EMPTY:-3}\n",
+			want:     "count: 3\n",
+		},
+		{
+			testName: "empty var",
+			content:  "name: \"${EKSA_TEST_EMPTY}\"\n",
+			want:     "name: \"\"\n",
+		},
+		{
+			testName: "env reference is kept",
+			content:  "name: ${env://EKSA_TEST_NAME}\n",
+			want:     "name: ${env://EKSA_TEST_NAME}\n",
+		},
+		{
+			testName: "file reference is kept",
+			content:  "thumbprint: ${file://" + secretFile + "}\n",
+			want:     "thumbprint: ${file://" + secretFile + "}\n",
+		},
+		{
+			testName: "escaped placeholder",
+			content:  "command: echo $${HOME}\n",
+			want:     "command: echo ${HOME}\n",
+		},
+		{
+			testName: "escaped reference stays escaped",
+			content:  "command: echo $${env://HOME}\n",
+			want:     "command: echo $${env://HOME}\n",
+		},
+		{
+			testName: "comments are ignored",
+			content:  "# use ${EKSA_TEST_UNSET}\nname: test\n",
+			want:     "# use ${EKSA_TEST_UNSET}\nname: test\n",
+		},
+		{
+			testName: "unset vars are all reported",
+			content:  "name: ${EKSA_TEST_UNSET}\nnamespace: ${env://EKSA_TEST_UNSET}\n",
+			wantErr:  "[environment variable EKSA_TEST_UNSET is not set, failed resolving secret env://EKSA_TEST_UNSET: environment variable EKSA_TEST_UNSET is not set]",
+		},
+		{
+			testName: "unknown provider",
+			content:  "password: ${unknown://secret}\n",
+			wantErr:  "no secret provider registered for unknown://secret, available providers: env, file",
+		},
+		{
+			testName: "unclosed placeholder",
+			content:  "name: ${EKSA_TEST_NAME\n",
+			wantErr:  `unclosed placeholder in "${EKSA_TEST_NAME"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := substitution.Substitute([]byte(tt.content))
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(string(got)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	setEnv(t, "EKSA_TEST_NAME", "my-cluster")
+	os.Unsetenv("EKSA_TEST_UNSET")
+
+	secretFile := filepath.Join(t.TempDir(), "thumbprint")
+	if err := ioutil.WriteFile(secretFile, []byte("AB:CD:EF\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		testName string
+		content  string
+		want     string
+		wantErr  string
+	}{
+		{
+			testName: "env reference",
+			content:  "name: ${env://EKSA_TEST_NAME}\n",
+			want:     "name: my-cluster\n",
+		},
+		{
+			testName: "file reference",
+			content:  "thumbprint: ${file://" + secretFile + "}",
+			want:     "thumbprint: AB:CD:EF",
+		},
+		{
+			testName: "env vars and unknown schemes are kept",
+			content:  "command: echo ${HOME} $${HOME} ${unknown://secret} ${EKSA_TEST_NAME\n",
+			want:     "command: echo ${HOME} $${HOME} ${unknown://secret} ${EKSA_TEST_NAME\n",
+		},
+		{
+			testName: "escaped reference",
+			content:  "command: echo $${env://EKSA_TEST_NAME}\n",
+			want:     "command: echo ${env://EKSA_TEST_NAME}\n",
+		},
+		{
+			testName: "unset reference",
+			content:  "name: ${env://EKSA_TEST_UNSET}\n",
+			wantErr:  "failed resolving secret env://EKSA_TEST_UNSET: environment variable EKSA_TEST_UNSET is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := substitution.ResolveSecrets([]byte(tt.content))
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(string(got)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSubstituteAndResolveRegisteredProvider(t *testing.T) {
+	g := NewWithT(t)
+	substitution.RegisterSecretProvider("test", substitution.SecretProviderFunc(func(ref string) (string, error) {
+		if ref == "missing" {
+			return "", errors.New("secret not found")
+		}
+		return "resolved-" + ref, nil
+	}))
+
+	got, err := substitution.Substitute([]byte("password: ${test://vsphere#password}\n"))
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("password: ${test://vsphere#password}\n"))
+
+	got, err = substitution.ResolveSecrets(got)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(got)).To(Equal("password: resolved-vsphere#password\n"))
+
+	_, err = substitution.Substitute([]byte("password: ${test://missing}\n"))
+	g.Expect(err).To(MatchError("failed resolving secret test://missing: secret not found"))
+}
@@ -0,0 +1,135 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// Deadline bounds the time a whole operation can take. Each task can use the fraction
+// of Timeout set in Budgets, limited by the time left. Tasks without a budget can use all the time left
+type Deadline struct {
+	Timeout time.Duration
+	Budgets map[string]float64
+	// GracePeriod is the time given to all the tasks that run after the deadline trips, together,
+	// like collecting diagnostics and cleaning up
+	GracePeriod time.Duration
+}
+
+func (d *Deadline) budget(taskName string) time.Duration {
+	fraction, ok := d.Budgets[taskName]
+	if !ok {
+		return 0
+	}
+	return time.Duration(float64(d.Timeout) * fraction)
+}
+
+// DeadlineExceededError is returned by the task runner when a task runs past its budget or past the operation timeout
+type DeadlineExceededError struct {
+	Task        string
+	TaskElapsed time.Duration
+	// Budget is zero when the task didn't have its own budget and the operation timeout tripped
+	Budget  time.Duration
+	Timeout time.Duration
+	Cause   error
+}
+
+func (e *DeadlineExceededError) Error() string {
+	var msg string
+	if e.Budget > 0 {
+		msg = fmt.Sprintf("task %s exceeded its time budget of %s (%s operation timeout)", e.Task, e.Budget, e.Timeout)
+	} else {
+		msg = fmt.Sprintf("operation exceeded its timeout of %s during task %s, which ran for %s", e.Timeout, e.Task, e.TaskElapsed.Round(time.Second))
+	}
+	if e.Cause != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Cause)
+	}
+	return msg
+}
+
+// deadlineTracker keeps the contexts for an operation with a deadline
+type deadlineTracker struct {
+	deadline     *Deadline
+	parent       context.Context
+	operation    context.Context
+	cancel       context.CancelFunc
+	tripped      bool
+	taskDuration map[string]time.Duration
+	taskOrder    []string
+	// grace bounds all the tasks that run after the deadline trips, so they share a single grace period
+	grace       context.Context
+	graceCancel context.CancelFunc
+}
+
+func newDeadlineTracker(ctx context.Context, deadline *Deadline) *deadlineTracker {
+	t := &deadlineTracker{
+		deadline:     deadline,
+		parent:       ctx,
+		taskDuration: map[string]time.Duration{},
+	}
+	t.operation, t.cancel = context.WithTimeout(ctx, deadline.Timeout)
+	return t
+}
+
+// taskContext returns the context a task should run with
+func (t *deadlineTracker) taskContext(taskName string) (context.Context, context.CancelFunc) {
+	if t.tripped {
+		return context.WithCancel(t.grace)
+	}
+	if budget := t.deadline.budget(taskName); budget > 0 {
+		return context.WithTimeout(t.operation, budget)
+	}
+	return context.WithCancel(t.operation)
+}
+
+// taskDone records the time spent in a task and checks if the task tripped the deadline
+func (t *deadlineTracker) taskDone(taskCtx context.Context, taskName string, elapsed time.Duration, commandContext *CommandContext) {
+	if _, ok := t.taskDuration[taskName]; !ok {
+		t.taskOrder = append(t.taskOrder, taskName)
+	}
+	t.taskDuration[taskName] += elapsed
+
+	if t.tripped || taskCtx.Err() != context.DeadlineExceeded {
+		return
+	}
+	t.tripped = true
+	t.grace, t.graceCancel = context.WithTimeout(t.parent, t.deadline.GracePeriod)
+
+	err := &DeadlineExceededError{
+		Task:        taskName,
+		TaskElapsed: elapsed,
+		Timeout:     t.deadline.Timeout,
+		Cause:       commandContext.OriginalError,
+	}
+	if t.operation.Err() == nil {
+		err.Budget = t.deadline.budget(taskName)
+	}
+	commandContext.OriginalError = err
+
+	logger.MarkFail("Operation deadline exceeded", "task", taskName, "error", err)
+	t.logTimeSpent()
+	if t.deadline.GracePeriod > 0 {
+		logger.Info("Running the remaining tasks with a grace period", "gracePeriod", t.deadline.GracePeriod)
+	}
+}
+
+func (t *deadlineTracker) logTimeSpent() {
+	tasks := make([]string, len(t.taskOrder))
+	copy(tasks, t.taskOrder)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return t.taskDuration[tasks[i]] > t.taskDuration[tasks[j]]
+	})
+	for _, name := range tasks {
+		logger.Info("Time spent", "task", name, "duration", t.taskDuration[name].Round(time.Second))
+	}
+}
+
+func (t *deadlineTracker) close() {
+	t.cancel()
+	if t.graceCancel != nil {
+		t.graceCancel()
+	}
+}
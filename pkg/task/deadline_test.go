@@ -0,0 +1,109 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+// waitTask waits until its context is done or its duration passes
+type waitTask struct {
+	name     string
+	duration time.Duration
+	next     task.Task
+	ctxErr   error
+	elapsed  time.Duration
+}
+
+func (w *waitTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	start := time.Now()
+	defer func() { w.elapsed = time.Since(start) }()
+	select {
+	case <-ctx.Done():
+		w.ctxErr = ctx.Err()
+		commandContext.SetError(errors.New("command killed"))
+	case <-time.After(w.duration):
+	}
+	return w.next
+}
+
+func (w *waitTask) Name() string {
+	return w.name
+}
+
+func TestTaskRunnerWithDeadlineSuccess(t *testing.T) {
+	g := NewWithT(t)
+	second := &waitTask{name: "second", duration: time.Millisecond}
+	first := &waitTask{name: "first", duration: time.Millisecond, next: second}
+
+	runner := task.NewTaskRunner(first, task.WithDeadline(task.Deadline{
+		Timeout: time.Minute,
+		Budgets: map[string]float64{"first": 0.5},
+	}))
+	g.Expect(runner.RunTask(context.Background(), &task.CommandContext{})).To(Succeed())
+	g.Expect(first.ctxErr).To(BeNil())
+	g.Expect(second.ctxErr).To(BeNil())
+}
+
+func TestTaskRunnerWithDeadlineTaskBudgetExceeded(t *testing.T) {
+	g := NewWithT(t)
+	diagnostics := &waitTask{name: "diagnostics", duration: time.Millisecond}
+	first := &waitTask{name: "first", duration: time.Minute, next: diagnostics}
+
+	runner := task.NewTaskRunner(first, task.WithDeadline(task.Deadline{
+		Timeout:     time.Second,
+		Budgets:     map[string]float64{"first": 0.05},
+		GracePeriod: time.Minute,
+	}))
+	err := runner.RunTask(context.Background(), &task.CommandContext{})
+
+	var deadlineErr *task.DeadlineExceededError
+	g.Expect(errors.As(err, &deadlineErr)).To(BeTrue())
+	g.Expect(deadlineErr.Task).To(Equal("first"))
+	g.Expect(deadlineErr.Budget).To(Equal(50 * time.Millisecond))
+	g.Expect(err).To(MatchError("task first exceeded its time budget of 50ms (1s operation timeout): command killed"))
+	g.Expect(first.ctxErr).To(Equal(context.DeadlineExceeded))
+	g.Expect(diagnostics.ctxErr).To(BeNil(), "tasks after the deadline should run with the grace period")
+}
+
+func TestTaskRunnerWithDeadlineOperationTimeoutExceeded(t *testing.T) {
+	g := NewWithT(t)
+	third := &waitTask{name: "third", duration: time.Millisecond}
+	second := &waitTask{name: "second", duration: time.Minute, next: third}
+	first := &waitTask{name: "first", duration: time.Millisecond, next: second}
+
+	runner := task.NewTaskRunner(first, task.WithDeadline(task.Deadline{
+		Timeout: 50 * time.Millisecond,
+		Budgets: map[string]float64{"first": 0.5},
+	}))
+	err := runner.RunTask(context.Background(), &task.CommandContext{})
+
+	var deadlineErr *task.DeadlineExceededError
+	g.Expect(errors.As(err, &deadlineErr)).To(BeTrue())
+	g.Expect(deadlineErr.Task).To(Equal("second"))
+	g.Expect(deadlineErr.Budget).To(BeZero())
+	g.Expect(err).To(MatchError(ContainSubstring("operation exceeded its timeout of 50ms during task second")))
+	g.Expect(third.ctxErr).To(Equal(context.DeadlineExceeded), "without grace period the remaining tasks have no time")
+}
+
+func TestTaskRunnerWithDeadlineGracePeriodShared(t *testing.T) {
+	g := NewWithT(t)
+	cleanup := &waitTask{name: "cleanup", duration: time.Minute}
+	diagnostics := &waitTask{name: "diagnostics", duration: 250 * time.Millisecond, next: cleanup}
+	first := &waitTask{name: "first", duration: time.Minute, next: diagnostics}
+
+	runner := task.NewTaskRunner(first, task.WithDeadline(task.Deadline{
+		Timeout:     50 * time.Millisecond,
+		GracePeriod: 300 * time.Millisecond,
+	}))
+	g.Expect(runner.RunTask(context.Background(), &task.CommandContext{})).NotTo(Succeed())
+
+	g.Expect(diagnostics.ctxErr).To(BeNil())
+	g.Expect(cleanup.ctxErr).To(Equal(context.DeadlineExceeded))
+	g.Expect(cleanup.elapsed).To(BeNumerically("<", 200*time.Millisecond), "the tasks after the deadline should share the grace period")
+}
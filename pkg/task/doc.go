@@ -0,0 +1,10 @@
+/*
+Package task runs the chains of tasks the cluster workflows are made of.
+
+A Task runs with the shared CommandContext and returns the next task, or nil to stop the chain.
+Errors are set in the context with SetError, which keeps the first one, so the tasks that clean up
+or collect diagnostics after a failure can still run. The TaskRunner returns that first error.
+
+TaskRunnerOpt funcs add a Deadline to the chain or report its progress to an interfaces.ProgressSink.
+*/
+package task
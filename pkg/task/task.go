@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/faultinjection"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -27,13 +28,23 @@ type CommandContext struct {
 	Validations        interfaces.Validator
 	Writer             filewriter.FileWriter
 	CAPIManager        interfaces.CAPIManager
+	WorkloadBackup     interfaces.WorkloadBackup
+	WorkloadEviction   interfaces.WorkloadEviction
+	MachinePower       interfaces.MachinePower
 	ClusterSpec        *cluster.Spec
 	CurrentClusterSpec *cluster.Spec
 	UpgradeChangeDiff  *types.ChangeDiff
+	ComponentsOnly     bool
 	BootstrapCluster   *types.Cluster
 	WorkloadCluster    *types.Cluster
 	Profiler           *Profiler
 	OriginalError      error
+	// Warnings are the problems that didn't fail the operation but need attention
+	Warnings []string
+	// Artifacts are the files and objects produced by the operation, like backups
+	Artifacts []string
+	// ValidationReport is the file the report of the setup and validations is written to, none when empty
+	ValidationReport string
 }
 
 func (c *CommandContext) SetError(err error) {
@@ -42,6 +53,14 @@ func (c *CommandContext) SetError(err error) {
 	}
 }
 
+func (c *CommandContext) AddWarning(warning string) {
+	c.Warnings = append(c.Warnings, warning)
+}
+
+func (c *CommandContext) AddArtifact(artifact string) {
+	c.Artifacts = append(c.Artifacts, artifact)
+}
+
 type Profiler struct {
 	metrics map[string]map[string]time.Duration
 	starts  map[string]map[string]time.Time
@@ -95,37 +114,97 @@ func (pp *Profiler) logProfileSummary(taskName string) {
 	}
 }
 
-// Manages Task execution
-type taskRunner struct {
-	task Task
+// TaskRunner runs a chain of tasks, starting from the first one, until a task returns no next task
+type TaskRunner struct {
+	task     Task
+	deadline *Deadline
+	progress interfaces.ProgressSink
+}
+
+type TaskRunnerOpt func(*TaskRunner)
+
+// WithDeadline makes the runner fail the operation when a task exceeds its budget or the whole operation exceeds the timeout
+func WithDeadline(deadline Deadline) TaskRunnerOpt {
+	return func(r *TaskRunner) {
+		r.deadline = &deadline
+	}
+}
+
+// WithProgressSink notifies the sink when each task starts and finishes
+func WithProgressSink(sink interfaces.ProgressSink) TaskRunnerOpt {
+	return func(r *TaskRunner) {
+		r.progress = sink
+	}
 }
 
-// executes Task
-func (pr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContext) error {
+// RunTask runs the tasks and returns the first error set in the command context
+func (pr *TaskRunner) RunTask(ctx context.Context, commandContext *CommandContext) error {
 	commandContext.Profiler = &Profiler{
 		metrics: make(map[string]map[string]time.Duration),
 		starts:  make(map[string]map[string]time.Time),
 	}
+	var tracker *deadlineTracker
+	if pr.deadline != nil {
+		tracker = newDeadlineTracker(ctx, pr.deadline)
+		defer tracker.close()
+	}
 	task := pr.task
 	start := time.Now()
 	defer taskRunnerFinalBlock(start)
 	for task != nil {
 		logger.V(4).Info("Task start", "task_name", task.Name())
 		commandContext.Profiler.SetStartTask(task.Name())
-		nextTask := task.Run(ctx, commandContext)
+		if pr.progress != nil {
+			pr.progress.TaskStarted(task.Name())
+		}
+		previousErr := commandContext.OriginalError
+		if faultinjection.Enabled() {
+			faultinjection.StartTask(task.Name())
+		}
+		var nextTask Task
+		if tracker != nil {
+			nextTask = runTaskWithDeadline(task, tracker, commandContext)
+		} else {
+			nextTask = task.Run(ctx, commandContext)
+		}
+		faultinjection.FinishTask()
 		commandContext.Profiler.MarkDoneTask(task.Name())
 		commandContext.Profiler.logProfileSummary(task.Name())
+		if pr.progress != nil {
+			pr.progress.TaskFinished(task.Name(), commandContext.Profiler.Metrics()[task.Name()][task.Name()], taskError(previousErr, commandContext))
+		}
 		task = nextTask
 	}
 	return commandContext.OriginalError
 }
 
+func runTaskWithDeadline(task Task, tracker *deadlineTracker, commandContext *CommandContext) Task {
+	taskCtx, cancel := tracker.taskContext(task.Name())
+	defer cancel()
+	start := time.Now()
+	nextTask := task.Run(taskCtx, commandContext)
+	tracker.taskDone(taskCtx, task.Name(), time.Since(start), commandContext)
+	return nextTask
+}
+
+// taskError returns the error set by the last task, ignoring the ones set by previous tasks
+func taskError(previousErr error, commandContext *CommandContext) error {
+	if previousErr != nil {
+		return nil
+	}
+	return commandContext.OriginalError
+}
+
 func taskRunnerFinalBlock(startTime time.Time) {
 	logger.V(4).Info("Tasks completed", "duration", time.Since(startTime))
 }
 
-func NewTaskRunner(task Task) *taskRunner {
-	return &taskRunner{
+func NewTaskRunner(task Task, opts ...TaskRunnerOpt) *TaskRunner {
+	r := &TaskRunner{
 		task: task,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
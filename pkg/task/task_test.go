@@ -2,12 +2,14 @@ package task_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/aws/eks-anywhere/pkg/task"
 	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
+	mockinterfaces "github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
 )
 
 func TestTaskRunnerRunTask(t *testing.T) {
@@ -55,3 +57,37 @@ func TestTaskRunnerRunTask(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskRunnerRunTaskWithProgressSink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	cmdContext := &task.CommandContext{}
+	taskA := mocktasks.NewMockTask(ctrl)
+	taskB := mocktasks.NewMockTask(ctrl)
+	taskC := mocktasks.NewMockTask(ctrl)
+	sink := mockinterfaces.NewMockProgressSink(ctrl)
+	wantErr := errors.New("error in task B")
+
+	taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	taskC.EXPECT().Name().Return("taskC").AnyTimes()
+	taskA.EXPECT().Run(ctx, cmdContext).Return(taskB)
+	taskB.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(ctx context.Context, c *task.CommandContext) task.Task {
+		c.SetError(wantErr)
+		return taskC
+	})
+	taskC.EXPECT().Run(ctx, cmdContext).Return(nil)
+	gomock.InOrder(
+		sink.EXPECT().TaskStarted("taskA"),
+		sink.EXPECT().TaskFinished("taskA", gomock.Any(), nil),
+		sink.EXPECT().TaskStarted("taskB"),
+		sink.EXPECT().TaskFinished("taskB", gomock.Any(), wantErr),
+		sink.EXPECT().TaskStarted("taskC"),
+		sink.EXPECT().TaskFinished("taskC", gomock.Any(), nil),
+	)
+
+	err := task.NewTaskRunner(taskA, task.WithProgressSink(sink)).RunTask(ctx, cmdContext)
+	if err != wantErr {
+		t.Fatalf("RunTask() error = %v, want %v", err, wantErr)
+	}
+}
@@ -10,6 +10,7 @@ type Deployment struct {
 
 type Machine struct {
 	Metadata MachineMetadata `json:"metadata"`
+	Spec     MachineSpec     `json:"spec"`
 	Status   MachineStatus   `json:"status"`
 }
 
@@ -28,9 +29,14 @@ type MachineStatus struct {
 }
 
 type MachineMetadata struct {
+	Name   string            `json:"name,omitempty"`
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+type MachineSpec struct {
+	InfrastructureRef ResourceRef `json:"infrastructureRef"`
+}
+
 type ResourceRef struct {
 	APIVersion string `json:"apiVersion"`
 	Kind       string `json:"kind"`
@@ -48,11 +54,31 @@ type Condition struct {
 	Status ConditionStatus `json:"status"`
 }
 
+// Certificate is a cert-manager Certificate
+type Certificate struct {
+	Metadata CertificateMetadata `json:"metadata"`
+	Status   CertificateStatus   `json:"status"`
+}
+
+type CertificateMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type CertificateStatus struct {
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+}
+
 type CAPICluster struct {
 	Metadata Metadata
+	Spec     CAPIClusterSpec
 	Status   ClusterStatus
 }
 
+type CAPIClusterSpec struct {
+	Paused bool
+}
+
 type ClusterStatus struct {
 	Phase string
 }
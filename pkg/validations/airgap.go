@@ -0,0 +1,81 @@
+package validations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/files"
+)
+
+// AirGapEgress lists every artifact of the operation that would be fetched from the internet: the release and
+// component manifests missing from the local artifacts, the images not pulled through the registry mirror and
+// the GitHub repository of GitOps
+func AirGapEgress(clusterSpec *cluster.Spec, artifacts *files.ArtifactsIndex) []string {
+	egress := map[string]bool{}
+	local := func(uri string) bool {
+		return artifacts != nil && artifacts.Has(uri)
+	}
+
+	if uri := clusterSpec.GetReleaseManifestUrl(); uri != "" && !local(uri) {
+		egress["manifest "+uri] = true
+	}
+	for _, manifests := range clusterSpec.VersionsBundle.Manifests() {
+		for _, manifest := range manifests {
+			if manifest.URI != "" && !local(manifest.URI) {
+				egress["manifest "+manifest.URI] = true
+			}
+		}
+	}
+
+	if clusterSpec.Cluster.Spec.DatacenterRef.Kind == v1alpha1.VSphereDatacenterKind {
+		for _, ova := range clusterSpec.VersionsBundle.Ovas() {
+			if ova.URI != "" && !local(ova.URI) {
+				egress["ova "+ova.URI] = true
+			}
+		}
+	}
+
+	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration == nil {
+		for _, image := range clusterSpec.VersionsBundle.Images() {
+			if image.URI != "" {
+				egress["image "+image.URI] = true
+			}
+		}
+	}
+
+	if clusterSpec.GitOpsConfig != nil && clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository != "" {
+		github := clusterSpec.GitOpsConfig.Spec.Flux.Github
+		egress[fmt.Sprintf("git repository github.com/%s/%s", github.Owner, github.Repository)] = true
+	}
+
+	list := make([]string, 0, len(egress))
+	for e := range egress {
+		list = append(list, e)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// ValidateStrictAirGapped fails listing the artifacts that would need internet access, see AirGapEgress
+func ValidateStrictAirGapped(clusterSpec *cluster.Spec, artifacts *files.ArtifactsIndex) error {
+	egress := AirGapEgress(clusterSpec, artifacts)
+	if len(egress) == 0 {
+		return nil
+	}
+
+	var reasons []string
+	if artifacts == nil {
+		reasons = append(reasons, "no local artifacts, pass --artifacts-dir")
+	}
+	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration == nil {
+		reasons = append(reasons, "no registryMirrorConfiguration")
+	}
+	reason := ""
+	if len(reasons) > 0 {
+		reason = fmt.Sprintf(" (%s)", strings.Join(reasons, ", "))
+	}
+	return fmt.Errorf("%d artifacts require internet access%s: %s", len(egress), reason, strings.Join(egress, ", "))
+}
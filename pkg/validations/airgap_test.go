@@ -0,0 +1,113 @@
+package validations_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestValidateStrictAirGapped(t *testing.T) {
+	eksdManifest := "https://distro.eks.amazonaws.com/kubernetes-1-21-eks-4.yaml"
+	ovaURI := "https://anywhere-assets.eks.amazonaws.com/ubuntu-v1.21.ova"
+	tests := []struct {
+		name         string
+		noMirror     bool
+		noArtifacts  bool
+		pauseImage   string
+		github       bool
+		vsphere      bool
+		localOva     bool
+		wantEgress   []string
+		wantErrParts []string
+	}{
+		{
+			name: "everything local",
+		},
+		{
+			name:         "no registry mirror",
+			noMirror:     true,
+			wantEgress:   []string{"image public.ecr.aws/eks-anywhere/cli-tools:v0.1.0"},
+			wantErrParts: []string{"no registryMirrorConfiguration", "image public.ecr.aws/eks-anywhere/cli-tools:v0.1.0"},
+		},
+		{
+			name:         "no local artifacts",
+			noArtifacts:  true,
+			wantEgress:   []string{"manifest " + eksdManifest},
+			wantErrParts: []string{"no local artifacts, pass --artifacts-dir", "manifest " + eksdManifest},
+		},
+		{
+			name:       "image from another registry through the mirror",
+			pauseImage: "registry.k8s.io/pause:3.5",
+		},
+		{
+			name:         "image from another registry without mirror",
+			noMirror:     true,
+			pauseImage:   "registry.k8s.io/pause:3.5",
+			wantEgress:   []string{"image public.ecr.aws/eks-anywhere/cli-tools:v0.1.0", "image registry.k8s.io/pause:3.5"},
+			wantErrParts: []string{"2 artifacts require internet access (no registryMirrorConfiguration): image public.ecr.aws/eks-anywhere/cli-tools:v0.1.0, image registry.k8s.io/pause:3.5"},
+		},
+		{
+			name:         "vsphere ova",
+			vsphere:      true,
+			wantEgress:   []string{"ova " + ovaURI},
+			wantErrParts: []string{"1 artifacts require internet access: ova " + ovaURI},
+		},
+		{
+			name:     "local vsphere ova",
+			vsphere:  true,
+			localOva: true,
+		},
+		{
+			name:         "github gitops",
+			github:       true,
+			wantEgress:   []string{"git repository github.com/aws/flux-config"},
+			wantErrParts: []string{"git repository github.com/aws/flux-config"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundle.EksD.EksDReleaseUrl = eksdManifest
+				s.VersionsBundle.Eksa.CliTools.URI = "public.ecr.aws/eks-anywhere/cli-tools:v0.1.0"
+				s.VersionsBundle.KubeDistro.Pause.URI = tt.pauseImage
+				if !tt.noMirror {
+					s.Cluster.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4"}
+				}
+				if tt.vsphere {
+					s.Cluster.Spec.DatacenterRef.Kind = v1alpha1.VSphereDatacenterKind
+					s.VersionsBundle.EksD.Ova.Ubuntu.URI = ovaURI
+				}
+				if tt.github {
+					s.GitOpsConfig = &v1alpha1.GitOpsConfig{
+						Spec: v1alpha1.GitOpsConfigSpec{Flux: v1alpha1.Flux{Github: v1alpha1.Github{Owner: "aws", Repository: "flux-config"}}},
+					}
+				}
+			})
+			var index *files.ArtifactsIndex
+			if !tt.noArtifacts {
+				index = files.NewArtifactsIndex()
+				index.Add(eksdManifest, "eks-distro/kubernetes-1-21-eks-4.yaml")
+				if tt.localOva {
+					index.Add(ovaURI, "ova/ubuntu-v1.21.ova")
+				}
+			}
+
+			g.Expect(validations.AirGapEgress(spec, index)).To(ConsistOf(tt.wantEgress))
+			err := validations.ValidateStrictAirGapped(spec, index)
+			if len(tt.wantErrParts) == 0 {
+				g.Expect(err).To(BeNil())
+			} else {
+				for _, part := range tt.wantErrParts {
+					g.Expect(err).To(MatchError(ContainSubstring(part)))
+				}
+			}
+		})
+	}
+}
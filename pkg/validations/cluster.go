@@ -1,12 +1,32 @@
 package validations
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
 )
 
+// ValidateFeatureGates checks the feature gates set in the cluster annotations and logs
+// all the active features, so the operation logs show which experimental features were used
+func ValidateFeatureGates(clusterSpec *cluster.Spec) error {
+	if err := features.ValidateGates(clusterSpec.Cluster.FeatureGates()); err != nil {
+		return err
+	}
+
+	for _, f := range features.Active() {
+		logger.Info("Experimental feature enabled", "feature", f.Name, "gate", f.Gate)
+	}
+
+	return nil
+}
+
 func ValidateTaintsSupport(clusterSpec *cluster.Spec) error {
 	if !features.IsActive(features.TaintsSupport()) {
 		if len(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints) > 0 {
@@ -29,3 +49,66 @@ func ValidateNodeLabelsSupport(clusterSpec *cluster.Spec) error {
 	}
 	return nil
 }
+
+// ValidatePodSecuritySupport checks the podSecurity spec is only set with the PodSecuritySupport feature enabled
+func ValidatePodSecuritySupport(clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.Spec.PodSecurity != nil && !features.IsActive(features.PodSecuritySupport()) {
+		return fmt.Errorf("Pod security feature is not enabled. Please set the env variable %s.", features.PodSecuritySupportEnvVar)
+	}
+	return nil
+}
+
+// ValidateAirGapped checks that every manifest in the cluster bundle can be read from the local artifacts
+// and that the images are pulled from a registry mirror, so the operation doesn't need external network access
+func ValidateAirGapped(clusterSpec *cluster.Spec, artifacts *files.ArtifactsIndex) error {
+	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration == nil {
+		return fmt.Errorf("registryMirrorConfiguration is required to pull images without network access")
+	}
+
+	var missing []string
+	for _, manifests := range clusterSpec.VersionsBundle.Manifests() {
+		for _, manifest := range manifests {
+			if manifest.URI != "" && !artifacts.Has(manifest.URI) {
+				missing = append(missing, manifest.URI)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("artifacts not found in local artifacts, download them again for this bundle: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// ValidateSinglePointsOfFailure checks the control plane and etcd topology doesn't have single points of failure
+// that defeat the replicas of the cluster: a single external etcd machine behind several control plane machines
+// and, for the providers that can find them, machines placed on a shared datastore or host.
+// When allowed, they are logged as warnings instead of failing the validation
+func ValidateSinglePointsOfFailure(ctx context.Context, clusterSpec *cluster.Spec, provider providers.Provider, allowed bool) error {
+	var findings []string
+	controlPlaneCount := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count
+	if etcd := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil && etcd.Count == 1 && controlPlaneCount > 1 {
+		findings = append(findings, fmt.Sprintf("the %d control plane machines share a single external etcd machine", controlPlaneCount))
+	}
+
+	if finder, ok := provider.(providers.SinglePointsOfFailureFinder); ok {
+		placement, err := finder.SinglePointsOfFailure(ctx, clusterSpec)
+		if err != nil {
+			return fmt.Errorf("failed checking the placement of the machines: %v", err)
+		}
+		findings = append(findings, placement...)
+	}
+
+	if len(findings) == 0 {
+		return nil
+	}
+	if allowed {
+		for _, f := range findings {
+			logger.Info("Warning: single point of failure acknowledged", "topology", f)
+		}
+		return nil
+	}
+	return fmt.Errorf("single points of failure in the cluster topology: %s", strings.Join(findings, "; "))
+}
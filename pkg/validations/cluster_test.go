@@ -0,0 +1,187 @@
+package validations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestValidateFeatureGates(t *testing.T) {
+	tests := []struct {
+		name    string
+		gates   string
+		wantErr string
+	}{
+		{
+			name: "no gates",
+		},
+		{
+			name:  "valid gates",
+			gates: "TaintsSupport=true,NodeLabelsSupport=false",
+		},
+		{
+			name:    "unknown gate",
+			gates:   "NewUpgradeStrategy=true",
+			wantErr: "unknown feature gate NewUpgradeStrategy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				if tt.gates != "" {
+					s.Cluster.Annotations = map[string]string{"anywhere.eks.amazonaws.com/feature-gates": tt.gates}
+				}
+			})
+
+			err := validations.ValidateFeatureGates(spec)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatePodSecuritySupport(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validations.ValidatePodSecuritySupport(test.NewClusterSpec())).To(Succeed())
+
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.PodSecurity = &v1alpha1.PodSecurityConfiguration{Enforce: v1alpha1.PodSecurityBaseline}
+	})
+	g.Expect(validations.ValidatePodSecuritySupport(spec)).To(MatchError("Pod security feature is not enabled. Please set the env variable POD_SECURITY_SUPPORT."))
+}
+
+func TestValidateAirGapped(t *testing.T) {
+	tests := []struct {
+		name     string
+		mirror   bool
+		artifact string
+		wantErr  string
+	}{
+		{
+			name:     "all artifacts present",
+			mirror:   true,
+			artifact: "https://distro.eks.amazonaws.com/kubernetes-1-21-eks-4.yaml",
+		},
+		{
+			name:     "no registry mirror",
+			artifact: "https://distro.eks.amazonaws.com/kubernetes-1-21-eks-4.yaml",
+			wantErr:  "registryMirrorConfiguration is required",
+		},
+		{
+			name:    "missing artifact",
+			mirror:  true,
+			wantErr: "artifacts not found in local artifacts, download them again for this bundle: https://distro.eks.amazonaws.com/kubernetes-1-21-eks-4.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundle.EksD.EksDReleaseUrl = "https://distro.eks.amazonaws.com/kubernetes-1-21-eks-4.yaml"
+				if tt.mirror {
+					s.Cluster.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4"}
+				}
+			})
+			index := files.NewArtifactsIndex()
+			if tt.artifact != "" {
+				index.Add(tt.artifact, "eks-distro/kubernetes-1-21-eks-4.yaml")
+			}
+
+			err := validations.ValidateAirGapped(spec, index)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+// placementProvider is a provider that finds single points of failure in the placement of the machines
+type placementProvider struct {
+	providers.Provider
+	findings []string
+	err      error
+}
+
+func (p placementProvider) SinglePointsOfFailure(_ context.Context, _ *cluster.Spec) ([]string, error) {
+	return p.findings, p.err
+}
+
+func TestValidateSinglePointsOfFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane int
+		etcd         *v1alpha1.ExternalEtcdConfiguration
+		provider     providers.Provider
+		allowed      bool
+		wantErr      string
+	}{
+		{
+			name:         "single stacked control plane",
+			controlPlane: 1,
+		},
+		{
+			name:         "external etcd",
+			controlPlane: 3,
+			etcd:         &v1alpha1.ExternalEtcdConfiguration{Count: 3},
+			provider:     placementProvider{},
+		},
+		{
+			name:         "single external etcd with several control plane machines",
+			controlPlane: 3,
+			etcd:         &v1alpha1.ExternalEtcdConfiguration{Count: 1},
+			wantErr:      "single points of failure in the cluster topology: the 3 control plane machines share a single external etcd machine",
+		},
+		{
+			name:         "shared placement",
+			controlPlane: 3,
+			etcd:         &v1alpha1.ExternalEtcdConfiguration{Count: 3},
+			provider:     placementProvider{findings: []string{"etcd and control plane machines share datastore ds", "etcd and control plane machines are placed on the same host esxi"}},
+			wantErr:      "single points of failure in the cluster topology: etcd and control plane machines share datastore ds; etcd and control plane machines are placed on the same host esxi",
+		},
+		{
+			name:         "placement not found",
+			controlPlane: 3,
+			provider:     placementProvider{err: errors.New("govc failed")},
+			allowed:      true,
+			wantErr:      "failed checking the placement of the machines: govc failed",
+		},
+		{
+			name:         "acknowledged",
+			controlPlane: 3,
+			etcd:         &v1alpha1.ExternalEtcdConfiguration{Count: 1},
+			provider:     placementProvider{findings: []string{"etcd and control plane machines share datastore ds"}},
+			allowed:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.Count = tt.controlPlane
+				s.Cluster.Spec.ExternalEtcdConfiguration = tt.etcd
+			})
+
+			err := validations.ValidateSinglePointsOfFailure(context.Background(), spec, tt.provider, tt.allowed)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
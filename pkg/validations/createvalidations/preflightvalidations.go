@@ -16,6 +16,11 @@ func (u *CreateValidations) PreflightValidations(ctx context.Context) (err error
 	}
 
 	createValidations := []validations.ValidationResult{
+		{
+			Name:        "validate feature gates",
+			Remediation: "check the feature gates in the anywhere.eks.amazonaws.com/feature-gates cluster annotation",
+			Err:         validations.ValidateFeatureGates(u.Opts.Spec),
+		},
 		{
 			Name:        "validate taints support",
 			Remediation: "ensure TAINTS_SUPPORT env variable is set",
@@ -28,6 +33,39 @@ func (u *CreateValidations) PreflightValidations(ctx context.Context) (err error
 			Err:         validations.ValidateNodeLabelsSupport(u.Opts.Spec),
 			Silent:      true,
 		},
+		{
+			Name:        "validate pod security support",
+			Remediation: "ensure POD_SECURITY_SUPPORT env variable is set",
+			Err:         validations.ValidatePodSecuritySupport(u.Opts.Spec),
+			Silent:      true,
+		},
+		{
+			Name:        "validate single points of failure",
+			Remediation: "use several external etcd machines with several control plane machines and place etcd and the control plane on different datastores and hosts, or acknowledge it with --allow-single-points-of-failure",
+			Err:         validations.ValidateSinglePointsOfFailure(ctx, u.Opts.Spec, u.Opts.Provider, u.Opts.AllowSinglePointsOfFailure),
+		},
+	}
+
+	if u.Opts.Spec.Cluster.Spec.ProxyConfiguration != nil {
+		createValidations = append(
+			createValidations,
+			validations.ValidationResult{
+				Name:        "validate proxy",
+				Remediation: "check the proxy is reachable from this machine and allows CONNECT to the hosts listed, or add them to noProxy if they are reached directly",
+				Err:         validations.ValidateProxy(ctx, u.Opts.Spec),
+			},
+		)
+	}
+
+	if u.Opts.LocalArtifacts != nil {
+		createValidations = append(
+			createValidations,
+			validations.ValidationResult{
+				Name:        "validate air-gapped create",
+				Remediation: "download the artifacts for this version with 'eksctl anywhere download artifacts' and configure a registry mirror with the images",
+				Err:         validations.ValidateAirGapped(u.Opts.Spec, u.Opts.LocalArtifacts),
+			},
+		)
 	}
 
 	if u.Opts.Spec.IsManaged() {
@@ -35,7 +73,7 @@ func (u *CreateValidations) PreflightValidations(ctx context.Context) (err error
 			createValidations,
 			validations.ValidationResult{
 				Name:        "validate cluster name",
-				Remediation: "",
+				Remediation: "use a different cluster name, or delete the existing cluster with eksctl anywhere delete cluster",
 				Err:         ValidateClusterNameIsUnique(ctx, k, targetCluster, u.Opts.Spec.Name),
 			},
 			validations.ValidationResult{
@@ -40,3 +40,27 @@ func KubeConfigExists(dir, clusterName string, kubeConfigFileOverride string, ku
 	}
 	return false
 }
+
+// clusterConfigFilePattern is the name of the cluster config written to the cluster folder once the cluster is created
+const clusterConfigFilePattern = "%s-eks-a-cluster.yaml"
+
+// ValidateClusterFolder checks dir, the folder of the cluster files, doesn't belong to another cluster with the same name: a kubeconfig in it belongs to a cluster that exists, and a cluster config with
+// a different spec to a different cluster. A cluster config with the same spec is left by a previous create
+func ValidateClusterFolder(dir string, cluster *v1alpha1.Cluster, kubeconfigPattern string) error {
+	if KubeConfigExists(dir, cluster.Name, "", kubeconfigPattern) {
+		return fmt.Errorf("folder %s has the kubeconfig of an existing cluster named %s, delete that cluster with eksctl anywhere delete cluster, or move the folder away or use a different cluster name to proceed", dir, cluster.Name)
+	}
+
+	configFile := filepath.Join(dir, fmt.Sprintf(clusterConfigFilePattern, cluster.Name))
+	if !FileExists(configFile) {
+		return nil
+	}
+	existing, err := v1alpha1.GetClusterConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("folder %s has a cluster config that can't be read: %v, move the folder away or use a different cluster name to proceed", dir, err)
+	}
+	if !existing.Equal(cluster) {
+		return fmt.Errorf("folder %s has the config of a different cluster named %s, move the folder away or use a different cluster name to proceed", dir, cluster.Name)
+	}
+	return nil
+}
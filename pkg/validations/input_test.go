@@ -2,9 +2,14 @@ package validations_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
@@ -113,3 +118,74 @@ func TestValidateClusterNameArg(t *testing.T) {
 		})
 	}
 }
+
+const folderClusterConfig = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: test-cluster
+spec:
+  controlPlaneConfiguration:
+    count: 3
+  kubernetesVersion: "1.21"
+`
+
+func TestValidateClusterFolder(t *testing.T) {
+	tests := []struct {
+		name         string
+		kubeconfig   bool
+		config       string
+		controlPlane int
+		wantErr      string
+	}{
+		{
+			name:         "empty folder",
+			controlPlane: 3,
+		},
+		{
+			name:         "same cluster config",
+			config:       folderClusterConfig,
+			controlPlane: 3,
+		},
+		{
+			name:         "existing cluster",
+			kubeconfig:   true,
+			controlPlane: 3,
+			wantErr:      "has the kubeconfig of an existing cluster named test-cluster",
+		},
+		{
+			name:         "different cluster config",
+			config:       folderClusterConfig,
+			controlPlane: 1,
+			wantErr:      "has the config of a different cluster named test-cluster",
+		},
+		{
+			name:         "invalid cluster config",
+			config:       "spec: [",
+			controlPlane: 3,
+			wantErr:      "has a cluster config that can't be read",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dir := t.TempDir()
+			if tt.kubeconfig {
+				g.Expect(ioutil.WriteFile(filepath.Join(dir, "test-cluster-eks-a-cluster.kubeconfig"), []byte("kubeconfig"), 0o600)).To(Succeed())
+			}
+			if tt.config != "" {
+				g.Expect(ioutil.WriteFile(filepath.Join(dir, "test-cluster-eks-a-cluster.yaml"), []byte(tt.config), 0o600)).To(Succeed())
+			}
+			cluster := &v1alpha1.Cluster{}
+			cluster.Name = "test-cluster"
+			cluster.Spec.KubernetesVersion = v1alpha1.Kube121
+			cluster.Spec.ControlPlaneConfiguration.Count = tt.controlPlane
+
+			err := validations.ValidateClusterFolder(dir, cluster, "%s-eks-a-cluster.kubeconfig")
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
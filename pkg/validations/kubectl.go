@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/executables"
@@ -27,6 +29,10 @@ type KubectlClient interface {
 	GetEksaAWSIamConfig(ctx context.Context, awsIamConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.AWSIamConfig, error)
 	SearchEksaGitOpsConfig(ctx context.Context, gitOpsConfigName string, kubeconfigFile string, namespace string) ([]*v1alpha1.GitOpsConfig, error)
 	SearchIdentityProviderConfig(ctx context.Context, ipName string, kind string, kubeconfigFile string, namespace string) ([]*v1alpha1.VSphereDatacenterConfig, error)
+	GetValidatingWebhookConfigurations(ctx context.Context, cluster *types.Cluster) ([]admissionregistrationv1.ValidatingWebhookConfiguration, error)
+	GetMutatingWebhookConfigurations(ctx context.Context, cluster *types.Cluster) ([]admissionregistrationv1.MutatingWebhookConfiguration, error)
+	GetEndpoints(ctx context.Context, cluster *types.Cluster, name, namespace string) (*corev1.Endpoints, error)
+	GetCertificates(ctx context.Context, cluster *types.Cluster) ([]types.Certificate, error)
 }
 
 func NewKubectl(t *testing.T) (*executables.Kubectl, context.Context, *types.Cluster, *mockexecutables.MockExecutable) {
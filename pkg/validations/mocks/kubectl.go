@@ -12,6 +12,8 @@ import (
 	executables "github.com/aws/eks-anywhere/pkg/executables"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/admissionregistration/v1"
+	v10 "k8s.io/api/core/v1"
 )
 
 // MockKubectlClient is a mock of KubectlClient interface.
@@ -37,6 +39,21 @@ func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
 	return m.recorder
 }
 
+// GetCertificates mocks base method.
+func (m *MockKubectlClient) GetCertificates(ctx context.Context, cluster *types.Cluster) ([]types.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificates", ctx, cluster)
+	ret0, _ := ret[0].([]types.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificates indicates an expected call of GetCertificates.
+func (mr *MockKubectlClientMockRecorder) GetCertificates(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificates", reflect.TypeOf((*MockKubectlClient)(nil).GetCertificates), ctx, cluster)
+}
+
 // GetClusters mocks base method.
 func (m *MockKubectlClient) GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +144,51 @@ func (mr *MockKubectlClientMockRecorder) GetEksaVSphereDatacenterConfig(ctx, vsp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaVSphereDatacenterConfig", reflect.TypeOf((*MockKubectlClient)(nil).GetEksaVSphereDatacenterConfig), ctx, vsphereDatacenterConfigName, kubeconfigFile, namespace)
 }
 
+// GetEndpoints mocks base method.
+func (m *MockKubectlClient) GetEndpoints(ctx context.Context, cluster *types.Cluster, name, namespace string) (*v10.Endpoints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpoints", ctx, cluster, name, namespace)
+	ret0, _ := ret[0].(*v10.Endpoints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpoints indicates an expected call of GetEndpoints.
+func (mr *MockKubectlClientMockRecorder) GetEndpoints(ctx, cluster, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoints", reflect.TypeOf((*MockKubectlClient)(nil).GetEndpoints), ctx, cluster, name, namespace)
+}
+
+// GetMutatingWebhookConfigurations mocks base method.
+func (m *MockKubectlClient) GetMutatingWebhookConfigurations(ctx context.Context, cluster *types.Cluster) ([]v1.MutatingWebhookConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutatingWebhookConfigurations", ctx, cluster)
+	ret0, _ := ret[0].([]v1.MutatingWebhookConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutatingWebhookConfigurations indicates an expected call of GetMutatingWebhookConfigurations.
+func (mr *MockKubectlClientMockRecorder) GetMutatingWebhookConfigurations(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutatingWebhookConfigurations", reflect.TypeOf((*MockKubectlClient)(nil).GetMutatingWebhookConfigurations), ctx, cluster)
+}
+
+// GetValidatingWebhookConfigurations mocks base method.
+func (m *MockKubectlClient) GetValidatingWebhookConfigurations(ctx context.Context, cluster *types.Cluster) ([]v1.ValidatingWebhookConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatingWebhookConfigurations", ctx, cluster)
+	ret0, _ := ret[0].([]v1.ValidatingWebhookConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatingWebhookConfigurations indicates an expected call of GetValidatingWebhookConfigurations.
+func (mr *MockKubectlClientMockRecorder) GetValidatingWebhookConfigurations(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatingWebhookConfigurations", reflect.TypeOf((*MockKubectlClient)(nil).GetValidatingWebhookConfigurations), ctx, cluster)
+}
+
 // SearchEksaGitOpsConfig mocks base method.
 func (m *MockKubectlClient) SearchEksaGitOpsConfig(ctx context.Context, gitOpsConfigName, kubeconfigFile, namespace string) ([]*v1alpha1.GitOpsConfig, error) {
 	m.ctrl.T.Helper()
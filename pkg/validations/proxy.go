@@ -0,0 +1,170 @@
+package validations
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const proxyDialTimeout = 10 * time.Second
+
+// ProxyRequiredHosts returns the hosts the operation reaches through the proxy: the ones of the manifests,
+// of the images not pulled through the registry mirror and GitHub for GitOps, except the ones in NO_PROXY
+func ProxyRequiredHosts(clusterSpec *cluster.Spec) []string {
+	hosts := map[string]bool{}
+	addURL := func(uri string) {
+		if u, err := url.Parse(uri); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != "" {
+			hosts[u.Hostname()] = true
+		}
+	}
+
+	addURL(clusterSpec.GetReleaseManifestUrl())
+	for _, manifests := range clusterSpec.VersionsBundle.Manifests() {
+		for _, manifest := range manifests {
+			addURL(manifest.URI)
+		}
+	}
+	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration == nil {
+		for _, registry := range clusterSpec.ImageRegistries() {
+			hosts[registry] = true
+		}
+	}
+	if clusterSpec.GitOpsConfig != nil && clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository != "" {
+		hosts["github.com"] = true
+	}
+
+	noProxy := cluster.NoProxy(clusterSpec.Cluster)
+	list := make([]string, 0, len(hosts))
+	for h := range hosts {
+		if !matchesNoProxy(h, noProxy) {
+			list = append(list, h)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+func matchesNoProxy(host string, noProxy []string) bool {
+	for _, e := range noProxy {
+		e = strings.TrimPrefix(e, "*")
+		if host == strings.TrimPrefix(e, ".") || (strings.HasPrefix(e, ".") && strings.HasSuffix(host, e)) ||
+			strings.HasSuffix(host, "."+e) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateProxy checks that the HTTPS proxy of the cluster is reachable and tunnels connections with CONNECT
+// to every host in ProxyRequiredHosts
+func ValidateProxy(ctx context.Context, clusterSpec *cluster.Spec) error {
+	proxy := clusterSpec.Cluster.Spec.ProxyConfiguration
+	if proxy == nil {
+		return nil
+	}
+
+	return validateProxyConnect(ctx, proxy, ProxyRequiredHosts(clusterSpec))
+}
+
+func validateProxyConnect(ctx context.Context, proxy *v1alpha1.ProxyConfiguration, hosts []string) error {
+	proxyURL := proxy.HttpsProxy
+	if proxyURL == "" {
+		proxyURL = proxy.HttpProxy
+	}
+	if !strings.Contains(proxyURL, "://") {
+		proxyURL = "http://" + proxyURL
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy %s: %v", proxyURL, err)
+	}
+
+	// check the proxy is reachable before trying every host
+	dialCtx, cancel := context.WithTimeout(ctx, proxyDialTimeout)
+	defer cancel()
+	conn, err := dialProxy(dialCtx, u)
+	if err != nil {
+		return fmt.Errorf("proxy %s is not reachable: %v", u.Host, err)
+	}
+	conn.Close()
+
+	var failed []string
+	for _, host := range hosts {
+		if err := proxyConnect(ctx, u, net.JoinHostPort(host, "443")); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", host, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("proxy %s can't tunnel HTTPS connections with CONNECT to: %s", u.Host, strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func dialProxy(ctx context.Context, proxy *url.URL) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	if proxy.Scheme == "https" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: proxy.Hostname()}}
+		return tlsDialer.DialContext(ctx, "tcp", hostWithDefaultPort(proxy, "443"))
+	}
+	return dialer.DialContext(ctx, "tcp", hostWithDefaultPort(proxy, "80"))
+}
+
+func hostWithDefaultPort(u *url.URL, port string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// proxyConnect opens a CONNECT tunnel to addr through the proxy. The dial and the CONNECT exchange get
+// proxyDialTimeout, so a host the proxy hangs on doesn't use up the time left for the other hosts
+func proxyConnect(ctx context.Context, proxy *url.URL, addr string) error {
+	ctx, cancel := context.WithTimeout(ctx, proxyDialTimeout)
+	defer cancel()
+
+	conn, err := dialProxy(ctx, proxy)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		credentials := proxy.User.Username() + ":" + password
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+	if err = req.Write(conn); err != nil {
+		return err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("proxy responded %s", resp.Status)
+	}
+
+	return nil
+}
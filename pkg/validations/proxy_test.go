@@ -0,0 +1,91 @@
+package validations_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func proxySpec(proxyURL string, noProxy ...string) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundle.EksD.EksDReleaseUrl = "https://distro.eks.amazonaws.com/kubernetes-1-21-eks-4.yaml"
+		s.VersionsBundle.Eksa.CliTools.URI = "public.ecr.aws/eks-anywhere/cli-tools:v0.1.0"
+		s.VersionsBundle.KubeDistro.Pause.URI = "registry.internal.corp/pause:3.5"
+		s.Cluster.Spec.ProxyConfiguration = &v1alpha1.ProxyConfiguration{
+			HttpProxy:  proxyURL,
+			HttpsProxy: proxyURL,
+			NoProxy:    noProxy,
+		}
+	})
+}
+
+func TestProxyRequiredHosts(t *testing.T) {
+	g := NewWithT(t)
+	spec := proxySpec("http://proxy:3128", ".internal.corp")
+	g.Expect(validations.ProxyRequiredHosts(spec)).To(Equal([]string{"distro.eks.amazonaws.com", "public.ecr.aws"}))
+
+	spec.Cluster.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4"}
+	g.Expect(validations.ProxyRequiredHosts(spec)).To(Equal([]string{"distro.eks.amazonaws.com"}))
+}
+
+func TestValidateProxy(t *testing.T) {
+	var authorization string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Proxy-Authorization")
+		if r.Method != http.MethodConnect || r.Host != "public.ecr.aws:443" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	tests := []struct {
+		name    string
+		proxy   string
+		noProxy []string
+		wantErr string
+	}{
+		{
+			name:    "CONNECT allowed to every host",
+			proxy:   "http://user:pass@" + proxy.Listener.Addr().String(),
+			noProxy: []string{"distro.eks.amazonaws.com", ".internal.corp"},
+		},
+		{
+			name:    "CONNECT refused",
+			proxy:   proxy.Listener.Addr().String(),
+			noProxy: []string{"internal.corp"},
+			wantErr: "can't tunnel HTTPS connections with CONNECT to: distro.eks.amazonaws.com (proxy responded 403 Forbidden)",
+		},
+		{
+			name:    "proxy not reachable",
+			proxy:   "http://127.0.0.1:1",
+			wantErr: "proxy 127.0.0.1:1 is not reachable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validations.ValidateProxy(context.Background(), proxySpec(tt.proxy, tt.noProxy...))
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(authorization).To(Equal("Basic dXNlcjpwYXNz"))
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateProxyNoProxyConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validations.ValidateProxy(context.Background(), test.NewClusterSpec())).To(Succeed())
+}
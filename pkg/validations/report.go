@@ -0,0 +1,234 @@
+package validations
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Severity tells whether a failed validation fails the Runner
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Status is the outcome of a check in the validation report
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusWarning Status = "warning"
+)
+
+// ReportFormat is a format the validation report can be written in
+type ReportFormat string
+
+const (
+	TableFormat ReportFormat = "table"
+	JSONFormat  ReportFormat = "json"
+	JUnitFormat ReportFormat = "junit"
+)
+
+// ReportFormatFromPath returns the format for a report file from its extension: JSON for .json,
+// JUnit XML for .xml and a table for anything else
+func ReportFormatFromPath(path string) ReportFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSONFormat
+	case ".xml":
+		return JUnitFormat
+	default:
+		return TableFormat
+	}
+}
+
+// CheckResult is the outcome of one validation
+type CheckResult struct {
+	Name            string        `json:"name"`
+	Status          Status        `json:"status"`
+	Severity        Severity      `json:"severity"`
+	Duration        time.Duration `json:"-"`
+	DurationSeconds float64       `json:"durationSeconds"`
+	Error           string        `json:"error,omitempty"`
+	Remediation     string        `json:"remediation,omitempty"`
+	RemediationLink string        `json:"remediationLink,omitempty"`
+}
+
+func newCheckResult(result *ValidationResult, duration time.Duration) CheckResult {
+	severity := result.Severity
+	if severity == "" {
+		severity = SeverityError
+	}
+	check := CheckResult{
+		Name:            result.Name,
+		Status:          StatusPassed,
+		Severity:        severity,
+		Duration:        duration,
+		DurationSeconds: duration.Round(time.Millisecond).Seconds(),
+		Remediation:     result.Remediation,
+		RemediationLink: result.RemediationLink,
+	}
+	if result.Err != nil {
+		check.Error = result.Err.Error()
+		check.Status = StatusFailed
+		if severity == SeverityWarning {
+			check.Status = StatusWarning
+		}
+	}
+	return check
+}
+
+// Report is the outcome of all the validations run by a Runner
+type Report struct {
+	StartTime       time.Time     `json:"startTime"`
+	DurationSeconds float64       `json:"durationSeconds"`
+	Passed          int           `json:"passed"`
+	Failed          int           `json:"failed"`
+	Warnings        int           `json:"warnings"`
+	Checks          []CheckResult `json:"checks"`
+}
+
+func (r *Report) add(check CheckResult) {
+	r.Checks = append(r.Checks, check)
+	switch check.Status {
+	case StatusPassed:
+		r.Passed++
+	case StatusFailed:
+		r.Failed++
+	case StatusWarning:
+		r.Warnings++
+	}
+}
+
+// Write renders the report in the format to w
+func (r *Report) Write(w io.Writer, format ReportFormat) error {
+	switch format {
+	case TableFormat:
+		return r.writeTable(w)
+	case JSONFormat:
+		return r.writeJSON(w)
+	case JUnitFormat:
+		return r.writeJUnit(w)
+	default:
+		return fmt.Errorf("unsupported validation report format %q, it must be table, json or junit", format)
+	}
+}
+
+// WriteFile writes the report to path, in the format given by its extension
+func (r *Report) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed creating validation report: %v", err)
+	}
+	if err = r.Write(f, ReportFormatFromPath(path)); err != nil {
+		f.Close()
+		return fmt.Errorf("failed writing validation report: %v", err)
+	}
+	return f.Close()
+}
+
+func (r *Report) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "VALIDATION\tSTATUS\tSEVERITY\tDURATION\tREMEDIATION")
+	for _, c := range r.Checks {
+		remediation := ""
+		if c.Status != StatusPassed {
+			remediation = joinNonEmpty([]string{c.Remediation, c.RemediationLink}, " ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Name, c.Status, c.Severity, c.Duration.Round(time.Millisecond), remediation)
+	}
+	fmt.Fprintf(tw, "\n%d passed, %d failed, %d warnings\n", r.Passed, r.Failed, r.Warnings)
+	return tw.Flush()
+}
+
+func (r *Report) writeJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes the report as a JUnit XML test suite, so CI systems show each validation as a test.
+// Warnings are passing tests with the error in their output, since JUnit has no warning outcome
+func (r *Report) writeJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:      "eksa-validations",
+		Tests:     len(r.Checks),
+		Failures:  r.Failed,
+		Time:      seconds(r.DurationSeconds),
+		Timestamp: r.StartTime.UTC().Format(time.RFC3339),
+	}
+	for _, c := range r.Checks {
+		testCase := junitTestCase{Name: c.Name, ClassName: "validations", Time: seconds(c.DurationSeconds)}
+		details := joinNonEmpty([]string{c.Error, c.Remediation, c.RemediationLink}, "\n")
+		switch c.Status {
+		case StatusFailed:
+			testCase.Failure = &junitFailure{Message: c.Error, Type: string(c.Severity), Text: details}
+		case StatusWarning:
+			testCase.SystemOut = "warning: " + details
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
+
+func joinNonEmpty(elems []string, sep string) string {
+	nonEmpty := make([]string, 0, len(elems))
+	for _, e := range elems {
+		if e != "" {
+			nonEmpty = append(nonEmpty, e)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
@@ -0,0 +1,73 @@
+package validations_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func runReport() *validations.Report {
+	r := validations.NewRunner()
+	r.Register(
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{Name: "kubectl is installed"}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:            "control plane ip is free",
+				Err:             errors.New("ip 1.2.3.4 is in use"),
+				Remediation:     "pick another ip",
+				RemediationLink: "https://anywhere.eks.amazonaws.com/docs/",
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:     "single control plane node",
+				Err:      errors.New("control plane has no redundancy"),
+				Severity: validations.SeverityWarning,
+			}
+		},
+	)
+	_ = r.Run()
+	return r.Report()
+}
+
+func TestReportFormatFromPath(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validations.ReportFormatFromPath("report.json")).To(Equal(validations.JSONFormat))
+	g.Expect(validations.ReportFormatFromPath("out/report.XML")).To(Equal(validations.JUnitFormat))
+	g.Expect(validations.ReportFormatFromPath("report.txt")).To(Equal(validations.TableFormat))
+}
+
+func TestReportWriteTable(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	g.Expect(runReport().Write(out, validations.TableFormat)).To(Succeed())
+
+	g.Expect(out.String()).To(MatchRegexp(`VALIDATION\s+STATUS\s+SEVERITY\s+DURATION\s+REMEDIATION`))
+	g.Expect(out.String()).To(MatchRegexp(`kubectl is installed\s+passed\s+error`))
+	g.Expect(out.String()).To(MatchRegexp(`control plane ip is free\s+failed\s+error\s+\S+\s+pick another ip https://anywhere.eks.amazonaws.com/docs/`))
+	g.Expect(out.String()).To(MatchRegexp(`single control plane node\s+warning\s+warning`))
+	g.Expect(out.String()).To(ContainSubstring("1 passed, 1 failed, 1 warnings"))
+}
+
+func TestReportWriteJUnit(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	g.Expect(runReport().Write(out, validations.JUnitFormat)).To(Succeed())
+
+	g.Expect(out.String()).To(HavePrefix(`<?xml version="1.0" encoding="UTF-8"?>`))
+	g.Expect(out.String()).To(MatchRegexp(`<testsuite name="eksa-validations" tests="3" failures="1" skipped="0" time="[0-9.]+" timestamp="[^"]+">`))
+	g.Expect(out.String()).To(MatchRegexp(`<testcase name="kubectl is installed" classname="validations" time="[0-9.]+"></testcase>`))
+	g.Expect(out.String()).To(ContainSubstring(`<failure message="ip 1.2.3.4 is in use" type="error">ip 1.2.3.4 is in use&#xA;pick another ip&#xA;https://anywhere.eks.amazonaws.com/docs/</failure>`))
+	g.Expect(out.String()).To(ContainSubstring(`<system-out>warning: control plane has no redundancy</system-out>`))
+}
+
+func TestReportWriteUnsupportedFormat(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(runReport().Write(&bytes.Buffer{}, "yaml")).To(MatchError(`unsupported validation report format "yaml", it must be table, json or junit`))
+}
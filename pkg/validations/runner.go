@@ -1,36 +1,117 @@
 package validations
 
-import "errors"
+import (
+	"errors"
+	"time"
 
-var errRunnerValidation = errors.New("validations failed")
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// RunnerError is returned by the Runner when some validations failed. It holds the errors of
+// the failed validations, so callers can find out why they failed with errors.As
+type RunnerError struct {
+	Errs []error
+}
+
+func (e *RunnerError) Error() string {
+	return "validations failed"
+}
+
+// As finds the first error of the failed validations that matches target
+func (e *RunnerError) As(target interface{}) bool {
+	for _, err := range e.Errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
 
 type Validation func() *ValidationResult
 
 type Runner struct {
 	validations []Validation
+	reportFile  string
+	report      *Report
+}
+
+// RunnerOpt configures the optional behavior of the Runner
+type RunnerOpt func(*Runner)
+
+// WithReportFile writes the validation report to path once the validations run. The format is picked from
+// the file extension: JSON for .json, JUnit XML for .xml and a table for anything else
+func WithReportFile(path string) RunnerOpt {
+	return func(r *Runner) {
+		r.reportFile = path
+	}
 }
 
-func NewRunner() *Runner {
-	return &Runner{validations: make([]Validation, 0)}
+func NewRunner(opts ...RunnerOpt) *Runner {
+	r := &Runner{validations: make([]Validation, 0)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *Runner) Register(validations ...Validation) {
 	r.validations = append(r.validations, validations...)
 }
 
+// Run runs all the validations and fails if any validation with SeverityError failed. Validations
+// failed with SeverityWarning are only reported
 func (r *Runner) Run() error {
-	failed := false
+	r.report = &Report{StartTime: time.Now()}
+	var errs []error
 	for _, v := range r.validations {
+		start := time.Now()
 		result := v()
-		result.Report()
-		if result.Err != nil {
-			failed = true
+		check := newCheckResult(result, time.Since(start))
+		r.report.add(check)
+		logCheck(check)
+		if check.Status == StatusFailed {
+			errs = append(errs, result.Err)
+		}
+	}
+	r.report.DurationSeconds = time.Since(r.report.StartTime).Round(time.Millisecond).Seconds()
+
+	if r.reportFile != "" {
+		if err := r.report.WriteFile(r.reportFile); err != nil {
+			logger.Error(err, "Failed writing validation report", "file", r.reportFile)
+		} else {
+			logger.V(3).Info("Validation report written", "file", r.reportFile)
 		}
 	}
 
-	if failed {
-		return errRunnerValidation
+	if len(errs) > 0 {
+		return &RunnerError{Errs: errs}
 	}
 
 	return nil
 }
+
+// Report returns the report of the last Run, nil if the validations haven't run
+func (r *Runner) Report() *Report {
+	return r.report
+}
+
+// logCheck logs the outcome of a check, with the remediation and its link only when they're set
+func logCheck(c CheckResult) {
+	keysAndValues := []interface{}{"duration", c.Duration.Round(time.Millisecond).String()}
+	if c.Status == StatusPassed {
+		logger.MarkPass(capitalize(c.Name), keysAndValues...)
+		return
+	}
+	keysAndValues = append(keysAndValues, "error", c.Error)
+	if c.Remediation != "" {
+		keysAndValues = append(keysAndValues, "remediation", c.Remediation)
+	}
+	if c.RemediationLink != "" {
+		keysAndValues = append(keysAndValues, "link", c.RemediationLink)
+	}
+	if c.Status == StatusWarning {
+		logger.Info("Warning: validation failed", append([]interface{}{"validation", c.Name}, keysAndValues...)...)
+		return
+	}
+	logger.MarkFail("Validation failed", append([]interface{}{"validation", c.Name}, keysAndValues...)...)
+}
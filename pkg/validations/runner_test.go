@@ -1,7 +1,10 @@
 package validations_test
 
 import (
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -44,3 +47,83 @@ func TestRunnerRunSuccess(t *testing.T) {
 
 	g.Expect(r.Run()).To(Succeed())
 }
+
+type testValidationError struct{}
+
+func (e *testValidationError) Error() string {
+	return "test validation failed"
+}
+
+func TestRunnerRunErrorAs(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Err: errors.New("failed"),
+		}
+	})
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Err: &testValidationError{},
+		}
+	})
+
+	err := r.Run()
+	var runnerErr *validations.RunnerError
+	g.Expect(errors.As(err, &runnerErr)).To(BeTrue())
+	g.Expect(runnerErr.Errs).To(HaveLen(2))
+	var validationErr *testValidationError
+	g.Expect(errors.As(err, &validationErr)).To(BeTrue())
+}
+
+func TestRunnerRunWarningDoesNotFail(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:     "warning validation",
+			Err:      errors.New("not recommended"),
+			Severity: validations.SeverityWarning,
+		}
+	})
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "passing validation",
+		}
+	})
+
+	g.Expect(r.Run()).To(Succeed())
+	report := r.Report()
+	g.Expect(report.Passed).To(Equal(1))
+	g.Expect(report.Warnings).To(Equal(1))
+	g.Expect(report.Failed).To(Equal(0))
+	g.Expect(report.Checks[0].Status).To(Equal(validations.StatusWarning))
+	g.Expect(report.Checks[0].Error).To(Equal("not recommended"))
+}
+
+func TestRunnerRunWithReportFile(t *testing.T) {
+	g := NewWithT(t)
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	r := validations.NewRunner(validations.WithReportFile(reportFile))
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:            "failing validation",
+			Err:             errors.New("failed"),
+			Remediation:     "fix it",
+			RemediationLink: "https://anywhere.eks.amazonaws.com/docs/",
+		}
+	})
+
+	g.Expect(r.Run()).NotTo(Succeed())
+	content, err := os.ReadFile(reportFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	report := &validations.Report{}
+	g.Expect(json.Unmarshal(content, report)).To(Succeed())
+	g.Expect(report.Failed).To(Equal(1))
+	g.Expect(report.Checks).To(HaveLen(1))
+	g.Expect(report.Checks[0].Name).To(Equal("failing validation"))
+	g.Expect(report.Checks[0].Status).To(Equal(validations.StatusFailed))
+	g.Expect(report.Checks[0].Severity).To(Equal(validations.SeverityError))
+	g.Expect(report.Checks[0].Remediation).To(Equal("fix it"))
+	g.Expect(report.Checks[0].RemediationLink).To(Equal("https://anywhere.eks.amazonaws.com/docs/"))
+}
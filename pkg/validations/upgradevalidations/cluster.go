@@ -23,3 +23,14 @@ func ValidateClusterObjectExists(ctx context.Context, k validations.KubectlClien
 	}
 	return fmt.Errorf("couldn't find CAPI cluster object for cluster with name %s", cluster.Name)
 }
+
+func ValidateClusterNotInMaintenance(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, clusterName string) error {
+	c, err := k.GetEksaCluster(ctx, cluster, clusterName)
+	if err != nil {
+		return err
+	}
+	if c.IsInMaintenance() {
+		return fmt.Errorf("cluster %s is paused for maintenance", clusterName)
+	}
+	return nil
+}
@@ -57,3 +57,34 @@ func TestValidateClusterPresent(t *testing.T) {
 }
 
 var capiClustersResourceType = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+
+func TestValidateClusterNotInMaintenance(t *testing.T) {
+	tests := []struct {
+		name               string
+		wantErr            error
+		getClusterResponse string
+	}{
+		{
+			name:               "FailureClusterInMaintenance",
+			wantErr:            errors.New("cluster testcluster is paused for maintenance"),
+			getClusterResponse: `{"metadata":{"name":"testcluster","annotations":{"anywhere.eks.amazonaws.com/maintenance":"true"}}}`,
+		},
+		{
+			name:               "SuccessClusterNotInMaintenance",
+			wantErr:            nil,
+			getClusterResponse: `{"metadata":{"name":"testcluster","annotations":{"anywhere.eks.amazonaws.com/paused":"true"}}}`,
+		},
+	}
+
+	k, ctx, cluster, e := validations.NewKubectl(t)
+	cluster.Name = testclustername
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			e.EXPECT().Execute(ctx, []string{"get", "clusters.anywhere.eks.amazonaws.com", "-A", "-o", "jsonpath={.items[0]}", "--kubeconfig", cluster.KubeconfigFile, "--field-selector=metadata.name=" + testclustername}).Return(*bytes.NewBufferString(tc.getClusterResponse), nil)
+			err := upgradevalidations.ValidateClusterNotInMaintenance(ctx, k, cluster, testclustername)
+			if !reflect.DeepEqual(err, tc.wantErr) {
+				t.Errorf("%v got = %v, \nwant %v", tc.name, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -66,30 +67,38 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 		spec.SetDefaultGitOps()
 	}
 
-	if !nSpec.ControlPlaneConfiguration.Endpoint.Equal(oSpec.ControlPlaneConfiguration.Endpoint) {
+	if !nSpec.ControlPlaneConfiguration.Endpoint.Equal(oSpec.ControlPlaneConfiguration.Endpoint) && !spec.IsControlPlaneEndpointMigrationAllowed() {
 		return fmt.Errorf("spec.controlPlaneConfiguration.endpoint is immutable")
 	}
 
 	if !nSpec.ClusterNetwork.Equal(&oSpec.ClusterNetwork) {
-		return fmt.Errorf("spec.clusterNetwork is immutable")
+		if err := unsupportedChange(spec, v1alpha1.ClusterNetworkField, "spec.clusterNetwork"); err != nil {
+			return err
+		}
 	}
 
 	if !nSpec.ProxyConfiguration.Equal(oSpec.ProxyConfiguration) {
-		return fmt.Errorf("spec.proxyConfiguration is immutable")
+		if err := unsupportedChange(spec, v1alpha1.ProxyConfigurationField, "spec.proxyConfiguration"); err != nil {
+			return err
+		}
 	}
 
 	oldETCD := oSpec.ExternalEtcdConfiguration
 	newETCD := nSpec.ExternalEtcdConfiguration
 	if oldETCD != nil && newETCD != nil {
 		if oldETCD.Count != newETCD.Count {
-			return fmt.Errorf("spec.externalEtcdConfiguration is immutable")
+			if err := unsupportedChange(spec, v1alpha1.ExternalEtcdCountField, "spec.externalEtcdConfiguration"); err != nil {
+				return err
+			}
 		}
 	} else if oldETCD != newETCD {
 		return fmt.Errorf("spec.externalEtcdConfiguration is immutable")
 	}
 
 	if !v1alpha1.RefSliceEqual(nSpec.IdentityProviderRefs, oSpec.IdentityProviderRefs) {
-		return fmt.Errorf("spec.identityProviderRefs is immutable")
+		if err := unsupportedChange(spec, v1alpha1.IdentityProviderRefsField, "spec.identityProviderRefs"); err != nil {
+			return err
+		}
 	}
 	if len(nSpec.IdentityProviderRefs) > 0 {
 		for _, nIdentityProvider := range nSpec.IdentityProviderRefs {
@@ -120,3 +129,13 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 
 	return provider.ValidateNewSpec(ctx, cluster, spec)
 }
+
+// unsupportedChange returns an error for a change the upgrade path doesn't support, unless the
+// cluster config forces it. The forced changes are logged so the accepted risk is recorded in the CLI logs
+func unsupportedChange(spec *cluster.Spec, field, path string) error {
+	if spec.Cluster.IsUnsupportedChangeForced(field) {
+		logger.Info("Warning: forcing unsupported change, the upgrade might leave the cluster in a broken state", "field", field)
+		return nil
+	}
+	return fmt.Errorf("%s is immutable", path)
+}
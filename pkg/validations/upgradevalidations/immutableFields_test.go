@@ -0,0 +1,72 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	mockproviders "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+func TestValidateImmutableFieldsForcedUnsupportedChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		force   []string
+		wantErr string
+	}{
+		{
+			name:    "not forced",
+			wantErr: "spec.proxyConfiguration is immutable",
+		},
+		{
+			name:  "forced",
+			force: []string{v1alpha1.ProxyConfigurationField},
+		},
+		{
+			name:    "other field forced",
+			force:   []string{v1alpha1.ClusterNetworkField},
+			wantErr: "spec.proxyConfiguration is immutable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			k := mocks.NewMockKubectlClient(ctrl)
+			provider := mockproviders.NewMockProvider(ctrl)
+			workloadCluster := &types.Cluster{Name: testclustername, KubeconfigFile: "kubeconfig"}
+
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = testclustername
+				s.Spec.ProxyConfiguration = &v1alpha1.ProxyConfiguration{HttpProxy: "1.2.3.4:3128", HttpsProxy: "1.2.3.4:3128"}
+				for _, f := range tt.force {
+					s.Cluster.ForceUnsupportedChange(f)
+				}
+			})
+			prevCluster := clusterSpec.Cluster.DeepCopy()
+			prevCluster.Spec.ProxyConfiguration = nil
+
+			k.EXPECT().GetEksaCluster(ctx, workloadCluster, testclustername).Return(prevCluster, nil)
+			if tt.wantErr == "" {
+				provider.EXPECT().ValidateNewSpec(ctx, workloadCluster, clusterSpec)
+			}
+
+			err := upgradevalidations.ValidateImmutableFields(ctx, k, workloadCluster, clusterSpec, provider)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
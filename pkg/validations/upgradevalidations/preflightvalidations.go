@@ -3,6 +3,7 @@ package upgradevalidations
 import (
 	"context"
 	"fmt"
+	"time"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
@@ -20,6 +21,11 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err erro
 	var upgradeValidations []validations.ValidationResult
 	upgradeValidations = append(
 		upgradeValidations,
+		validations.ValidationResult{
+			Name:        "validate feature gates",
+			Remediation: "check the feature gates in the anywhere.eks.amazonaws.com/feature-gates cluster annotation",
+			Err:         validations.ValidateFeatureGates(u.Opts.Spec),
+		},
 		validations.ValidationResult{
 			Name:        "validate taints support",
 			Remediation: "ensure TAINTS_SUPPORT env variable is set",
@@ -32,6 +38,17 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err erro
 			Err:         validations.ValidateNodeLabelsSupport(u.Opts.Spec),
 			Silent:      true,
 		},
+		validations.ValidationResult{
+			Name:        "validate pod security support",
+			Remediation: "ensure POD_SECURITY_SUPPORT env variable is set",
+			Err:         validations.ValidatePodSecuritySupport(u.Opts.Spec),
+			Silent:      true,
+		},
+		validations.ValidationResult{
+			Name:        "validate single points of failure",
+			Remediation: "use several external etcd machines with several control plane machines and place etcd and the control plane on different datastores and hosts, or acknowledge it with --allow-single-points-of-failure",
+			Err:         validations.ValidateSinglePointsOfFailure(ctx, u.Opts.Spec, u.Opts.Provider, u.Opts.AllowSinglePointsOfFailure),
+		},
 		validations.ValidationResult{
 			Name:        "control plane ready",
 			Remediation: fmt.Sprintf("ensure control plane nodes and pods for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
@@ -52,11 +69,21 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err erro
 			Remediation: "",
 			Err:         k.ValidateClustersCRD(ctx, u.Opts.ManagementCluster),
 		},
+		validations.ValidationResult{
+			Name:        "management cluster webhooks healthy",
+			Remediation: "fix the webhooks as indicated, broken webhooks make the upgrade fail when applying the new objects",
+			Err:         ValidateWebhooksHealthy(ctx, k, u.Opts.ManagementCluster, time.Now()),
+		},
 		validations.ValidationResult{
 			Name:        "cluster object present on workload cluster",
 			Remediation: fmt.Sprintf("ensure that the CAPI cluster object %s representing cluster %s is present", clusterv1.GroupVersion, u.Opts.WorkloadCluster.Name),
 			Err:         ValidateClusterObjectExists(ctx, k, u.Opts.ManagementCluster),
 		},
+		validations.ValidationResult{
+			Name:        "cluster not paused for maintenance",
+			Remediation: fmt.Sprintf("resume cluster %s with 'eksctl anywhere resume cluster' before upgrading it", u.Opts.WorkloadCluster.Name),
+			Err:         ValidateClusterNotInMaintenance(ctx, k, targetCluster, u.Opts.Spec.Name),
+		},
 		validations.ValidationResult{
 			Name:        "upgrade cluster kubernetes version increment",
 			Remediation: "ensure that the cluster kubernetes version is incremented by one minor version exactly (e.g. 1.18 -> 1.19)",
@@ -69,5 +96,16 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err erro
 		},
 	)
 
+	if u.Opts.LocalArtifacts != nil {
+		upgradeValidations = append(
+			upgradeValidations,
+			validations.ValidationResult{
+				Name:        "validate air-gapped upgrade",
+				Remediation: "download the artifacts for this version with 'eksctl anywhere download artifacts' and configure a registry mirror with the images",
+				Err:         validations.ValidateAirGapped(u.Opts.Spec, u.Opts.LocalArtifacts),
+			},
+		)
+	}
+
 	return validations.RunPreflightValidations(upgradeValidations)
 }
@@ -0,0 +1,163 @@
+package upgradevalidations
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// certificateExpiryThreshold is how close to expiring a webhook certificate can be before the upgrade is blocked,
+// enough time for the upgrade to finish
+const certificateExpiryThreshold = 7 * 24 * time.Hour
+
+// webhookNamespaces are the namespaces of the management components whose webhooks are called during upgrades
+var webhookNamespaces = map[string]struct{}{
+	constants.CertManagerNamespace:                    {},
+	constants.CapiSystemNamespace:                     {},
+	constants.CapiWebhookSystemNamespace:              {},
+	constants.CapiKubeadmBootstrapSystemNamespace:     {},
+	constants.CapiKubeadmControlPlaneSystemNamespace:  {},
+	constants.CapvSystemNamespace:                     {},
+	constants.CapdSystemNamespace:                     {},
+	constants.EtcdAdmBootstrapProviderSystemNamespace: {},
+	constants.EtcdAdmControllerSystemNamespace:        {},
+	constants.EksaSystemNamespace:                     {},
+}
+
+// webhook is the part of a validating or mutating webhook needed to check its health
+type webhook struct {
+	configuration string
+	name          string
+	service       *admissionregistrationv1.ServiceReference
+	caBundle      []byte
+}
+
+// ValidateWebhooksHealthy checks that the webhooks of cert-manager, the Cluster API providers and EKS-A in the
+// management cluster have ready endpoints and that their certificates are not about to expire. A broken webhook
+// doesn't show up until the upgrade applies the objects it validates, failing the upgrade halfway through
+func ValidateWebhooksHealthy(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, now time.Time) error {
+	webhooks, err := managementWebhooks(ctx, k, cluster)
+	if err != nil {
+		return err
+	}
+
+	var findings []string
+	services := map[string]bool{}
+	for _, w := range webhooks {
+		svc := fmt.Sprintf("%s/%s", w.service.Namespace, w.service.Name)
+		if _, checked := services[svc]; !checked {
+			services[svc], err = hasReadyEndpoints(ctx, k, cluster, w.service)
+			if err != nil {
+				return err
+			}
+			if !services[svc] {
+				findings = append(findings, fmt.Sprintf("service %s of webhook %s has no ready endpoints, restart its pods with 'kubectl rollout restart deployment -n %s'", svc, w.name, w.service.Namespace))
+			}
+		}
+
+		if len(w.caBundle) == 0 {
+			findings = append(findings, fmt.Sprintf("webhook %s in %s has no CA bundle, restart the cert-manager cainjector with 'kubectl rollout restart deployment -n %s'", w.name, w.configuration, constants.CertManagerNamespace))
+			continue
+		}
+		if notAfter, expiring := expiringCertificate(w.caBundle, now); expiring {
+			findings = append(findings, fmt.Sprintf("CA certificate of webhook %s in %s expires at %s, renew it before upgrading", w.name, w.configuration, notAfter.Format(time.RFC3339)))
+		}
+	}
+
+	certificates, err := k.GetCertificates(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	for _, c := range certificates {
+		if _, ok := webhookNamespaces[c.Metadata.Namespace]; !ok || c.Status.NotAfter == nil {
+			continue
+		}
+		if c.Status.NotAfter.Before(now.Add(certificateExpiryThreshold)) {
+			findings = append(findings, fmt.Sprintf("certificate %s/%s expires at %s, renew it with 'cmctl renew -n %s %s'",
+				c.Metadata.Namespace, c.Metadata.Name, c.Status.NotAfter.Format(time.RFC3339), c.Metadata.Namespace, c.Metadata.Name))
+		}
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("management cluster webhooks are not healthy: %s", strings.Join(findings, "; "))
+	}
+
+	return nil
+}
+
+func managementWebhooks(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster) ([]webhook, error) {
+	validating, err := k.GetValidatingWebhookConfigurations(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	mutating, err := k.GetMutatingWebhookConfigurations(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []webhook
+	for _, c := range validating {
+		for _, w := range c.Webhooks {
+			webhooks = append(webhooks, webhook{configuration: c.Name, name: w.Name, service: w.ClientConfig.Service, caBundle: w.ClientConfig.CABundle})
+		}
+	}
+	for _, c := range mutating {
+		for _, w := range c.Webhooks {
+			webhooks = append(webhooks, webhook{configuration: c.Name, name: w.Name, service: w.ClientConfig.Service, caBundle: w.ClientConfig.CABundle})
+		}
+	}
+
+	managed := webhooks[:0]
+	for _, w := range webhooks {
+		if w.service == nil {
+			continue
+		}
+		if _, ok := webhookNamespaces[w.service.Namespace]; ok {
+			managed = append(managed, w)
+		}
+	}
+	sort.SliceStable(managed, func(i, j int) bool { return managed[i].name < managed[j].name })
+
+	return managed, nil
+}
+
+func hasReadyEndpoints(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, service *admissionregistrationv1.ServiceReference) (bool, error) {
+	endpoints, err := k.GetEndpoints(ctx, cluster, service.Name, service.Namespace)
+	if err != nil {
+		return false, err
+	}
+
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// expiringCertificate returns the earliest expiration of the certificates in the bundle, and whether it's within the threshold
+func expiringCertificate(caBundle []byte, now time.Time) (time.Time, bool) {
+	var earliest time.Time
+	for block, rest := pem.Decode(caBundle); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+	}
+
+	return earliest, !earliest.IsZero() && earliest.Before(now.Add(certificateExpiryThreshold))
+}
@@ -0,0 +1,126 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+var webhookNow = time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+
+func caBundle(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "webhook-ca"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func validatingWebhook(name, namespace, service string, caBundle []byte) admissionregistrationv1.ValidatingWebhookConfiguration {
+	return admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-configuration"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name: name,
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service:  &admissionregistrationv1.ServiceReference{Name: service, Namespace: namespace},
+					CABundle: caBundle,
+				},
+			},
+		},
+	}
+}
+
+func readyEndpoints() *corev1.Endpoints {
+	return &corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	}
+}
+
+type webhooksTest struct {
+	*WithT
+	ctx     context.Context
+	k       *mocks.MockKubectlClient
+	cluster *types.Cluster
+}
+
+func newWebhooksTest(t *testing.T) *webhooksTest {
+	return &webhooksTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		k:       mocks.NewMockKubectlClient(gomock.NewController(t)),
+		cluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+	}
+}
+
+func TestValidateWebhooksHealthySuccess(t *testing.T) {
+	tt := newWebhooksTest(t)
+	notAfter := webhookNow.Add(90 * 24 * time.Hour)
+	tt.k.EXPECT().GetValidatingWebhookConfigurations(tt.ctx, tt.cluster).Return([]admissionregistrationv1.ValidatingWebhookConfiguration{
+		validatingWebhook("validation.cluster.anywhere.amazonaws.com", "eksa-system", "eksa-webhook-service", caBundle(t, notAfter)),
+		validatingWebhook("validation.other.io", "other-system", "other-webhook-service", nil),
+	}, nil)
+	tt.k.EXPECT().GetMutatingWebhookConfigurations(tt.ctx, tt.cluster).Return(nil, nil)
+	tt.k.EXPECT().GetEndpoints(tt.ctx, tt.cluster, "eksa-webhook-service", "eksa-system").Return(readyEndpoints(), nil)
+	tt.k.EXPECT().GetCertificates(tt.ctx, tt.cluster).Return([]types.Certificate{
+		{
+			Metadata: types.CertificateMetadata{Name: "eksa-serving-cert", Namespace: "eksa-system"},
+			Status:   types.CertificateStatus{NotAfter: &notAfter},
+		},
+	}, nil)
+
+	tt.Expect(upgradevalidations.ValidateWebhooksHealthy(tt.ctx, tt.k, tt.cluster, webhookNow)).To(Succeed())
+}
+
+func TestValidateWebhooksHealthyFindings(t *testing.T) {
+	tt := newWebhooksTest(t)
+	expiring := webhookNow.Add(24 * time.Hour)
+	tt.k.EXPECT().GetValidatingWebhookConfigurations(tt.ctx, tt.cluster).Return([]admissionregistrationv1.ValidatingWebhookConfiguration{
+		validatingWebhook("validation.cluster.anywhere.amazonaws.com", "eksa-system", "eksa-webhook-service", nil),
+		validatingWebhook("validation.kubeadmcontrolplane.controlplane.cluster.x-k8s.io", "capi-kubeadm-control-plane-system", "capi-kubeadm-control-plane-webhook-service", caBundle(t, expiring)),
+	}, nil)
+	tt.k.EXPECT().GetMutatingWebhookConfigurations(tt.ctx, tt.cluster).Return(nil, nil)
+	tt.k.EXPECT().GetEndpoints(tt.ctx, tt.cluster, "eksa-webhook-service", "eksa-system").Return(&corev1.Endpoints{}, nil)
+	tt.k.EXPECT().GetEndpoints(tt.ctx, tt.cluster, "capi-kubeadm-control-plane-webhook-service", "capi-kubeadm-control-plane-system").Return(readyEndpoints(), nil)
+	tt.k.EXPECT().GetCertificates(tt.ctx, tt.cluster).Return([]types.Certificate{
+		{
+			Metadata: types.CertificateMetadata{Name: "capi-kubeadm-control-plane-serving-cert", Namespace: "capi-kubeadm-control-plane-system"},
+			Status:   types.CertificateStatus{NotAfter: &expiring},
+		},
+	}, nil)
+
+	err := upgradevalidations.ValidateWebhooksHealthy(tt.ctx, tt.k, tt.cluster, webhookNow)
+	tt.Expect(err).To(MatchError(ContainSubstring("service eksa-system/eksa-webhook-service of webhook validation.cluster.anywhere.amazonaws.com has no ready endpoints, restart its pods with 'kubectl rollout restart deployment -n eksa-system'")))
+	tt.Expect(err).To(MatchError(ContainSubstring("webhook validation.cluster.anywhere.amazonaws.com in validation.cluster.anywhere.amazonaws.com-configuration has no CA bundle")))
+	tt.Expect(err).To(MatchError(ContainSubstring("CA certificate of webhook validation.kubeadmcontrolplane.controlplane.cluster.x-k8s.io in validation.kubeadmcontrolplane.controlplane.cluster.x-k8s.io-configuration expires at 2022-02-11T00:00:00Z")))
+	tt.Expect(err).To(MatchError(ContainSubstring("certificate capi-kubeadm-control-plane-system/capi-kubeadm-control-plane-serving-cert expires at 2022-02-11T00:00:00Z, renew it with 'cmctl renew -n capi-kubeadm-control-plane-system capi-kubeadm-control-plane-serving-cert'")))
+}
@@ -10,7 +10,11 @@ type ValidationResult struct {
 	Name        string
 	Err         error
 	Remediation string
-	Silent      bool
+	// RemediationLink points to the docs explaining how to fix the failure, shown in the validation report
+	RemediationLink string
+	// Severity is SeverityError when not set. Failed validations with SeverityWarning are reported but don't fail the Runner
+	Severity Severity
+	Silent   bool
 }
 
 func (v *ValidationResult) Report() {
@@ -2,6 +2,7 @@ package validations
 
 import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -12,4 +13,7 @@ type Opts struct {
 	WorkloadCluster   *types.Cluster
 	ManagementCluster *types.Cluster
 	Provider          providers.Provider
+	LocalArtifacts    *files.ArtifactsIndex
+	// AllowSinglePointsOfFailure acknowledges the single points of failure of the cluster topology
+	AllowSinglePointsOfFailure bool
 }
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/vipmonitor/monitor.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	net "net"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/coordination/v1"
+)
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// GetLease mocks base method.
+func (m *MockKubectlClient) GetLease(ctx context.Context, kubeconfigFile, name, namespace string) (*v1.Lease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLease", ctx, kubeconfigFile, name, namespace)
+	ret0, _ := ret[0].(*v1.Lease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLease indicates an expected call of GetLease.
+func (mr *MockKubectlClientMockRecorder) GetLease(ctx, kubeconfigFile, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLease", reflect.TypeOf((*MockKubectlClient)(nil).GetLease), ctx, kubeconfigFile, name, namespace)
+}
+
+// MockNetClient is a mock of NetClient interface.
+type MockNetClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetClientMockRecorder
+}
+
+// MockNetClientMockRecorder is the mock recorder for MockNetClient.
+type MockNetClientMockRecorder struct {
+	mock *MockNetClient
+}
+
+// NewMockNetClient creates a new mock instance.
+func NewMockNetClient(ctrl *gomock.Controller) *MockNetClient {
+	mock := &MockNetClient{ctrl: ctrl}
+	mock.recorder = &MockNetClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetClient) EXPECT() *MockNetClientMockRecorder {
+	return m.recorder
+}
+
+// DialTimeout mocks base method.
+func (m *MockNetClient) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DialTimeout", network, address, timeout)
+	ret0, _ := ret[0].(net.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DialTimeout indicates an expected call of DialTimeout.
+func (mr *MockNetClientMockRecorder) DialTimeout(network, address, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialTimeout", reflect.TypeOf((*MockNetClient)(nil).DialTimeout), network, address, timeout)
+}
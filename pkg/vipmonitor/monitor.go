@@ -0,0 +1,258 @@
+package vipmonitor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// LeaseName is the lease kube-vip takes in the control plane nodes to elect the node that holds the VIP
+const LeaseName = "plndr-cp-lock"
+
+const (
+	defaultInterval      = 10 * time.Second
+	defaultWindow        = 5 * time.Minute
+	defaultFlapThreshold = 3
+	dialTimeout          = 3 * time.Second
+	apiServerPort        = "6443"
+)
+
+type KubectlClient interface {
+	GetLease(ctx context.Context, kubeconfigFile, name, namespace string) (*coordinationv1.Lease, error)
+}
+
+type NetClient interface {
+	DialTimeout(network, address string, timeout time.Duration) (net.Conn, error)
+}
+
+// Monitor checks the control plane VIP while the control plane comes up. A VIP that moves between nodes
+// or stops answering, usually because its address is taken by another machine, keeps the control plane
+// from ever being ready, so the monitor fails early instead of letting the wait time out
+type Monitor struct {
+	client        KubectlClient
+	netClient     NetClient
+	interval      time.Duration
+	window        time.Duration
+	flapThreshold int
+}
+
+type MonitorOpt func(*Monitor)
+
+// WithInterval sets how often the VIP is checked. Defaults to 10 seconds
+func WithInterval(interval time.Duration) MonitorOpt {
+	return func(m *Monitor) {
+		m.interval = interval
+	}
+}
+
+// WithFlapThreshold sets how many VIP drops and leader changes within the window are considered flapping.
+// Defaults to 3 in 5 minutes
+func WithFlapThreshold(threshold int, window time.Duration) MonitorOpt {
+	return func(m *Monitor) {
+		m.flapThreshold = threshold
+		m.window = window
+	}
+}
+
+func New(client KubectlClient, netClient NetClient, opts ...MonitorOpt) *Monitor {
+	m := &Monitor{
+		client:        client,
+		netClient:     netClient,
+		interval:      defaultInterval,
+		window:        defaultWindow,
+		flapThreshold: defaultFlapThreshold,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// FlappingError is returned when the VIP flaps. Its message includes the events that were observed
+// and hints to find the cause
+type FlappingError struct {
+	VIP    string
+	Events []Event
+}
+
+func (e *FlappingError) Error() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "control plane VIP %s is flapping, %d drops or leader changes in the last checks:", e.VIP, len(e.Events))
+	for _, event := range e.Events {
+		fmt.Fprintf(b, "\n  %s %s", event.Time.Format(time.RFC3339), event.Message)
+	}
+	b.WriteString("\n" + e.hint())
+	return b.String()
+}
+
+func (e *FlappingError) hint() string {
+	leaderChanges := 0
+	for _, event := range e.Events {
+		if event.Type == LeaderChanged {
+			leaderChanges++
+		}
+	}
+	if leaderChanges*2 > len(e.Events) {
+		return "kube-vip can't keep the leader election lease: check the health of the API server and etcd in the control plane nodes " +
+			"and the latency between them"
+	}
+	return fmt.Sprintf("the VIP stops answering: check that %s is not assigned to another machine, that it's excluded from the DHCP range "+
+		"and that the control plane machines have the network interface kube-vip advertises it on", e.VIP)
+}
+
+// UnreachableError is returned when the monitor stops and the VIP never answered
+type UnreachableError struct {
+	VIP string
+	Err error
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("control plane VIP %s never answered, last error: %v. Check that the address is free and routable "+
+		"from this machine and that kube-vip runs in the control plane machines", e.VIP, e.Err)
+}
+
+type EventType string
+
+const (
+	// Unreachable is a check where the VIP stopped answering after it had answered. The VIP answering at all
+	// means its address was resolved with ARP in the network of the CLI host
+	Unreachable EventType = "Unreachable"
+	// LeaderChanged is a check where the kube-vip lease moved to another node
+	LeaderChanged EventType = "LeaderChanged"
+)
+
+type Event struct {
+	Type    EventType
+	Time    time.Time
+	Message string
+}
+
+// Target is the control plane watched by the monitor
+type Target struct {
+	// VIP is the control plane endpoint host
+	VIP string
+	// Cluster is the workload cluster, its kubeconfig is used to read the kube-vip lease
+	Cluster *types.Cluster
+	// ExpectedMoves is how many times the VIP can move legitimately, like the control plane machines a rolling
+	// upgrade replaces. Each of them allows a drop and a leader change on top of the flap threshold
+	ExpectedMoves int
+}
+
+type state struct {
+	reachable     bool
+	everReachable bool
+	holder        string
+	transitions   int32
+	events        []Event
+	lastDialErr   error
+	leaseChecked  bool
+}
+
+// Watch checks the VIP until the context is done or until the VIP flaps, when it returns a FlappingError.
+// The VIP being unreachable before it answers for the first time is expected, since the control plane is still
+// coming up, but if it never answered by the time the context is done Watch returns an UnreachableError
+func (m *Monitor) Watch(ctx context.Context, target Target) error {
+	s := &state{}
+	for {
+		if err := m.check(ctx, target, s); err != nil {
+			return err
+		}
+
+		if retrier.Sleep(ctx, m.interval) != nil {
+			if !s.everReachable && s.lastDialErr != nil {
+				return &UnreachableError{VIP: target.VIP, Err: s.lastDialErr}
+			}
+			return nil
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context, target Target, s *state) error {
+	now := time.Now()
+	address := net.JoinHostPort(target.VIP, apiServerPort)
+	conn, err := m.netClient.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if s.reachable {
+			m.record(s, Event{Type: Unreachable, Time: now, Message: fmt.Sprintf("VIP %s stopped answering: %v", address, err)})
+		}
+		s.reachable = false
+		s.lastDialErr = err
+		logger.V(4).Info("Control plane VIP not reachable", "vip", address, "error", err)
+		return m.flapping(target, now, s)
+	}
+	conn.Close()
+	s.reachable = true
+	s.everReachable = true
+
+	if target.Cluster != nil && target.Cluster.KubeconfigFile != "" {
+		m.checkLease(ctx, target, now, s)
+	}
+	return m.flapping(target, now, s)
+}
+
+func (m *Monitor) checkLease(ctx context.Context, target Target, now time.Time, s *state) {
+	lease, err := m.client.GetLease(ctx, target.Cluster.KubeconfigFile, LeaseName, constants.KubeSystemNamespace)
+	if err != nil {
+		// The lease is missing until kube-vip wins its first election and the API can be briefly down while
+		// the control plane comes up, neither means the VIP flaps
+		logger.V(4).Info("Can't read kube-vip lease", "error", err)
+		return
+	}
+
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	var transitions int32
+	if lease.Spec.LeaseTransitions != nil {
+		transitions = *lease.Spec.LeaseTransitions
+	}
+
+	if s.leaseChecked {
+		changes := int(transitions - s.transitions)
+		if changes <= 0 && holder != s.holder {
+			changes = 1
+		}
+		for i := 0; i < changes; i++ {
+			m.record(s, Event{Type: LeaderChanged, Time: now, Message: fmt.Sprintf("kube-vip leader moved from %s to %s", s.holder, holder)})
+		}
+	}
+	s.leaseChecked = true
+	s.holder = holder
+	s.transitions = transitions
+}
+
+func (m *Monitor) record(s *state, event Event) {
+	logger.V(3).Info("Control plane VIP event", "type", event.Type, "message", event.Message)
+	s.events = append(s.events, event)
+}
+
+func (m *Monitor) flapping(target Target, now time.Time, s *state) error {
+	recent := s.events[:0]
+	for _, event := range s.events {
+		if now.Sub(event.Time) <= m.window {
+			recent = append(recent, event)
+		}
+	}
+	s.events = recent
+
+	if m.flapThreshold > 0 && len(s.events) >= m.flapThreshold+2*target.ExpectedMoves {
+		events := make([]Event, len(s.events))
+		copy(events, s.events)
+		return &FlappingError{VIP: target.VIP, Events: events}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package vipmonitor_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/vipmonitor"
+	"github.com/aws/eks-anywhere/pkg/vipmonitor/mocks"
+)
+
+type monitorTest struct {
+	*WithT
+	ctx       context.Context
+	client    *mocks.MockKubectlClient
+	netClient *mocks.MockNetClient
+	target    vipmonitor.Target
+}
+
+func newMonitorTest(t *testing.T) *monitorTest {
+	ctrl := gomock.NewController(t)
+	return &monitorTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		client:    mocks.NewMockKubectlClient(ctrl),
+		netClient: mocks.NewMockNetClient(ctrl),
+		target: vipmonitor.Target{
+			VIP:     "1.2.3.4",
+			Cluster: &types.Cluster{Name: "w01", KubeconfigFile: "w01.kubeconfig"},
+		},
+	}
+}
+
+func (tt *monitorTest) monitor() *vipmonitor.Monitor {
+	return vipmonitor.New(tt.client, tt.netClient, vipmonitor.WithInterval(time.Millisecond))
+}
+
+func (tt *monitorTest) expectDial(err error) *gomock.Call {
+	return tt.netClient.EXPECT().DialTimeout("tcp", "1.2.3.4:6443", gomock.Any()).DoAndReturn(
+		func(_, _ string, _ time.Duration) (net.Conn, error) {
+			if err != nil {
+				return nil, err
+			}
+			conn, _ := net.Pipe()
+			return conn, nil
+		},
+	)
+}
+
+func lease(holder string, transitions int32) *coordinationv1.Lease {
+	return &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{HolderIdentity: &holder, LeaseTransitions: &transitions}}
+}
+
+func TestMonitorWatchVIPDrops(t *testing.T) {
+	tt := newMonitorTest(t)
+	dialErr := errors.New("i/o timeout")
+	tt.client.EXPECT().GetLease(tt.ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-1", 0), nil).AnyTimes()
+	gomock.InOrder(
+		tt.expectDial(dialErr),
+		tt.expectDial(nil),
+		tt.expectDial(dialErr),
+		tt.expectDial(nil),
+		tt.expectDial(dialErr),
+		tt.expectDial(nil),
+		tt.expectDial(dialErr),
+	)
+
+	err := tt.monitor().Watch(tt.ctx, tt.target)
+	flapping := &vipmonitor.FlappingError{}
+	tt.Expect(errors.As(err, &flapping)).To(BeTrue())
+	tt.Expect(flapping.Events).To(HaveLen(3))
+	tt.Expect(err.Error()).To(ContainSubstring("check that 1.2.3.4 is not assigned to another machine"))
+}
+
+func TestMonitorWatchLeaderChanges(t *testing.T) {
+	tt := newMonitorTest(t)
+	tt.expectDial(nil).AnyTimes()
+	gomock.InOrder(
+		tt.client.EXPECT().GetLease(tt.ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(nil, errors.New("lease not found")),
+		tt.client.EXPECT().GetLease(tt.ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-1", 1), nil),
+		tt.client.EXPECT().GetLease(tt.ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-2", 2), nil),
+		tt.client.EXPECT().GetLease(tt.ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-1", 4), nil),
+	)
+
+	err := tt.monitor().Watch(tt.ctx, tt.target)
+	tt.Expect(err).To(MatchError(ContainSubstring("control plane VIP 1.2.3.4 is flapping, 3 drops or leader changes")))
+	tt.Expect(err).To(MatchError(ContainSubstring("kube-vip leader moved from cp-1 to cp-2")))
+	tt.Expect(err).To(MatchError(ContainSubstring("kube-vip can't keep the leader election lease")))
+}
+
+func TestMonitorWatchStable(t *testing.T) {
+	tt := newMonitorTest(t)
+	ctx, cancel := context.WithCancel(tt.ctx)
+	checks := 0
+	tt.netClient.EXPECT().DialTimeout("tcp", "1.2.3.4:6443", gomock.Any()).DoAndReturn(
+		func(_, _ string, _ time.Duration) (net.Conn, error) {
+			checks++
+			if checks == 5 {
+				cancel()
+			}
+			conn, _ := net.Pipe()
+			return conn, nil
+		},
+	).MinTimes(5)
+	tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-1", 1), nil).MinTimes(1)
+
+	tt.Expect(tt.monitor().Watch(ctx, tt.target)).To(Succeed())
+}
+
+func TestMonitorWatchNeverReachable(t *testing.T) {
+	tt := newMonitorTest(t)
+	ctx, cancel := context.WithCancel(tt.ctx)
+	checks := 0
+	tt.netClient.EXPECT().DialTimeout("tcp", "1.2.3.4:6443", gomock.Any()).DoAndReturn(
+		func(_, _ string, _ time.Duration) (net.Conn, error) {
+			checks++
+			if checks == 3 {
+				defer cancel()
+			}
+			return nil, errors.New("no route to host")
+		},
+	).MinTimes(3)
+
+	err := tt.monitor().Watch(ctx, tt.target)
+	unreachable := &vipmonitor.UnreachableError{}
+	tt.Expect(errors.As(err, &unreachable)).To(BeTrue())
+	tt.Expect(err).To(MatchError(ContainSubstring("control plane VIP 1.2.3.4 never answered, last error: no route to host")))
+}
+
+func TestMonitorWatchDropsOutsideWindow(t *testing.T) {
+	tt := newMonitorTest(t)
+	ctx, cancel := context.WithCancel(tt.ctx)
+	checks := 0
+	tt.netClient.EXPECT().DialTimeout("tcp", "1.2.3.4:6443", gomock.Any()).DoAndReturn(
+		func(_, _ string, _ time.Duration) (net.Conn, error) {
+			checks++
+			if checks == 20 {
+				cancel()
+			}
+			if checks%2 == 0 {
+				return nil, errors.New("i/o timeout")
+			}
+			conn, _ := net.Pipe()
+			return conn, nil
+		},
+	).MinTimes(20)
+	tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-1", 1), nil).AnyTimes()
+
+	m := vipmonitor.New(tt.client, tt.netClient, vipmonitor.WithInterval(5*time.Millisecond), vipmonitor.WithFlapThreshold(3, time.Nanosecond))
+	tt.Expect(m.Watch(ctx, tt.target)).To(Succeed())
+}
+
+func TestMonitorWatchExpectedMoves(t *testing.T) {
+	tt := newMonitorTest(t)
+	tt.target.ExpectedMoves = 1
+	ctx, cancel := context.WithCancel(tt.ctx)
+	tt.expectDial(nil).AnyTimes()
+	gomock.InOrder(
+		tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-1", 1), nil),
+		tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-2", 2), nil),
+		tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-3", 3), nil),
+		tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-4", 4), nil),
+		tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").DoAndReturn(
+			func(_ context.Context, _, _, _ string) (*coordinationv1.Lease, error) {
+				cancel()
+				return lease("cp-4", 4), nil
+			},
+		),
+		tt.client.EXPECT().GetLease(ctx, "w01.kubeconfig", vipmonitor.LeaseName, "kube-system").Return(lease("cp-4", 4), nil).AnyTimes(),
+	)
+
+	tt.Expect(tt.monitor().Watch(ctx, tt.target)).To(Succeed())
+}
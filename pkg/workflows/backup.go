@@ -0,0 +1,39 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+// backupWorkloadsTask backs up the cluster workloads before an operation and continues with the next task.
+// It's skipped when the workflow runs without a workload backup
+type backupWorkloadsTask struct {
+	operation string
+	next      task.Task
+}
+
+func backupWorkloadsOrNext(commandContext *task.CommandContext, operation string, next task.Task) task.Task {
+	if commandContext.WorkloadBackup == nil {
+		return next
+	}
+	return &backupWorkloadsTask{operation: operation, next: next}
+}
+
+func (s *backupWorkloadsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	l.Info("Backing up cluster workloads")
+	name, err := commandContext.WorkloadBackup.Backup(ctx, s.operation)
+	if err != nil {
+		commandContext.SetError(fmt.Errorf("failed backing up workloads before %s: %v", s.operation, err))
+		return nil
+	}
+	l.Info("Workloads backed up, restore them with eksctl anywhere restore backup if needed", "backup", name)
+	commandContext.AddArtifact(fmt.Sprintf("velero-backup/%s", name))
+
+	return s.next
+}
+
+func (s *backupWorkloadsTask) Name() string {
+	return "backup-workloads"
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -15,26 +14,40 @@ import (
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
+// Create is the create cluster workflow. Its dependencies are injected through NewCreate
 type Create struct {
 	bootstrapper   interfaces.Bootstrapper
 	provider       providers.Provider
 	clusterManager interfaces.ClusterManager
 	addonManager   interfaces.AddonManager
 	writer         filewriter.FileWriter
+	options        options
+	result         *Result
 }
 
+// createBudgets is the fraction of the operation timeout each create task can use
+var createBudgets = map[string]float64{
+	"setup-validate":         0.15,
+	"bootstrap-cluster-init": 0.3,
+}
+
+// NewCreate builds the workflow that creates a cluster, either self managed through a bootstrap cluster
+// or as a workload cluster of the management cluster set in the spec
 func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
-	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter) *Create {
+	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter, opts ...Opt) *Create {
 	return &Create{
 		bootstrapper:   bootstrapper,
 		provider:       provider,
 		clusterManager: clusterManager,
 		addonManager:   addonManager,
 		writer:         writer,
+		options:        newOptions(opts),
 	}
 }
 
+// Run creates the cluster. forceCleanup deletes the bootstrap cluster left by a previous run before starting
 func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator, forceCleanup bool) error {
+	c.result = nil
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
 			Name: clusterSpec.Name,
@@ -43,20 +56,39 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		}
 	}
 	commandContext := &task.CommandContext{
-		Bootstrapper:   c.bootstrapper,
-		Provider:       c.provider,
-		ClusterManager: c.clusterManager,
-		AddonManager:   c.addonManager,
-		ClusterSpec:    clusterSpec,
-		Writer:         c.writer,
-		Validations:    validator,
+		Bootstrapper:     c.bootstrapper,
+		Provider:         c.provider,
+		ClusterManager:   c.clusterManager,
+		AddonManager:     c.addonManager,
+		ClusterSpec:      clusterSpec,
+		Writer:           c.writer,
+		Validations:      validator,
+		ValidationReport: c.options.validationReport,
 	}
 
 	if clusterSpec.ManagementCluster != nil {
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&SetAndValidateTask{}).RunTask(ctx, commandContext)
+	recorder := newResultRecorder("create", clusterSpec.Name)
+	c.options.notifyStarted(ctx, recorder.result)
+	err := task.NewTaskRunner(&SetAndValidateTask{}, c.options.taskRunnerOpts(createBudgets, recorder)...).RunTask(ctx, commandContext)
+	if err != nil {
+		recorder.result.ResourcesLeft = createResourcesLeft(commandContext)
+	}
+	if commandContext.WorkloadCluster != nil {
+		recorder.result.KubeconfigFile = commandContext.WorkloadCluster.KubeconfigFile
+	}
+	c.result = c.options.finishResult(recorder, commandContext, err, nil, clusterSpec)
+	c.options.notifyFinished(ctx, c.result)
+
+	return err
+}
+
+// Result returns the result of the last run, with the outcome and the tasks it ran, also when it failed.
+// It's nil before the first run and when forceCleanup failed deleting the previous bootstrap cluster, since no task ran
+func (c *Create) Result() *Result {
+	return c.result
 }
 
 // task related entities
@@ -65,7 +97,10 @@ type CreateBootStrapClusterTask struct{}
 
 type SetAndValidateTask struct{}
 
-type CreateWorkloadClusterTask struct{}
+type CreateWorkloadClusterTask struct {
+	// resume is set when the CAPI spec was already applied to the bootstrap cluster by a previous run
+	resume bool
+}
 
 type InstallEksaComponentsTask struct{}
 
@@ -75,6 +110,8 @@ type MoveClusterManagementTask struct{}
 
 type WriteClusterConfigTask struct{}
 
+type WaitForReadinessGatesTask struct{}
+
 type DeleteBootstrapClusterTask struct {
 	*CollectDiagnosticsTask
 }
@@ -85,7 +122,31 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 	if commandContext.BootstrapCluster != nil {
 		return &CreateWorkloadClusterTask{}
 	}
-	logger.Info("Creating new bootstrap cluster")
+
+	existing, err := commandContext.Bootstrapper.GetExistingBootstrapCluster(ctx, commandContext.ClusterSpec.Name)
+	if err != nil {
+		commandContext.SetError(fmt.Errorf("%v, try rerunning with --force-cleanup to force delete previously created bootstrap cluster", err))
+		return nil
+	}
+	if err = existing.Collision(); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	if existing != nil {
+		if existing.CAPICluster != nil {
+			l.Info("Found bootstrap cluster from a previous run, resuming cluster creation", "phase", existing.CAPICluster.Status.Phase)
+			commandContext.BootstrapCluster = existing.Cluster
+			return &CreateWorkloadClusterTask{resume: true}
+		}
+
+		l.Info("Deleting bootstrap cluster from a previous run, it doesn't hold any cluster state")
+		if err = commandContext.Bootstrapper.DeleteBootstrapCluster(ctx, existing.Cluster, false); err != nil {
+			commandContext.SetError(err)
+			return nil
+		}
+	}
+
+	l.Info("Creating new bootstrap cluster")
 
 	bootstrapOptions, err := commandContext.Provider.BootstrapClusterOpts()
 	if err != nil {
@@ -100,7 +161,7 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 	}
 	commandContext.BootstrapCluster = bootstrapCluster
 
-	logger.Info("Installing cluster-api providers on bootstrap cluster")
+	l.Info("Installing cluster-api providers on bootstrap cluster")
 	err = commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, bootstrapCluster, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
@@ -108,7 +169,7 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 	}
 
 	if commandContext.ClusterSpec.AWSIamConfig != nil {
-		logger.Info("Creating aws-iam-authenticator certificate and key pair secret on bootstrap cluster")
+		l.Info("Creating aws-iam-authenticator certificate and key pair secret on bootstrap cluster")
 		err = commandContext.ClusterManager.CreateAwsIamAuthCaSecret(ctx, bootstrapCluster)
 		if err != nil {
 			commandContext.SetError(err)
@@ -116,7 +177,7 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 		}
 	}
 
-	logger.Info("Provider specific setup")
+	l.Info("Provider specific setup")
 	err = commandContext.Provider.BootstrapSetup(ctx, commandContext.ClusterSpec.Cluster, bootstrapCluster)
 	if err != nil {
 		commandContext.SetError(err)
@@ -133,11 +194,12 @@ func (s *CreateBootStrapClusterTask) Name() string {
 // SetAndValidateTask implementation
 
 func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Performing setup and validations")
-	runner := validations.NewRunner()
+	l.Info("Performing setup and validations")
+	runner := newValidationRunner(commandContext)
 	runner.Register(s.providerValidation(ctx, commandContext)...)
 	runner.Register(commandContext.AddonManager.Validations(ctx, commandContext.ClusterSpec)...)
 	runner.Register(s.validations(ctx, commandContext)...)
+	runner.Register(commandContext.ClusterManager.CreateManifestPolicyValidations(ctx, commandContext.ClusterSpec, commandContext.Provider)...)
 
 	err := runner.Run()
 	if err != nil {
@@ -176,15 +238,22 @@ func (s *SetAndValidateTask) Name() string {
 // CreateWorkloadClusterTask implementation
 
 func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Creating new workload cluster")
-	workloadCluster, err := commandContext.ClusterManager.CreateWorkloadCluster(ctx, commandContext.BootstrapCluster, commandContext.ClusterSpec, commandContext.Provider)
+	var workloadCluster *types.Cluster
+	var err error
+	if s.resume {
+		l.Info("Waiting for workload cluster from previous run")
+		workloadCluster, err = commandContext.ClusterManager.ResumeWorkloadCluster(ctx, commandContext.BootstrapCluster, commandContext.ClusterSpec, commandContext.Provider)
+	} else {
+		l.Info("Creating new workload cluster")
+		workloadCluster, err = commandContext.ClusterManager.CreateWorkloadCluster(ctx, commandContext.BootstrapCluster, commandContext.ClusterSpec, commandContext.Provider)
+	}
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
 	commandContext.WorkloadCluster = workloadCluster
 
-	logger.Info("Installing networking on workload cluster")
+	l.Info("Installing networking on workload cluster")
 	err = commandContext.ClusterManager.InstallNetworking(ctx, workloadCluster, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
@@ -192,7 +261,7 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 	}
 
 	if commandContext.ClusterSpec.AWSIamConfig != nil {
-		logger.Info("Installing aws-iam-authenticator on workload cluster")
+		l.Info("Installing aws-iam-authenticator on workload cluster")
 		err = commandContext.ClusterManager.InstallAwsIamAuth(ctx, commandContext.BootstrapCluster, workloadCluster, commandContext.ClusterSpec)
 		if err != nil {
 			commandContext.SetError(err)
@@ -200,22 +269,58 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 		}
 	}
 
-	logger.Info("Installing storage class on workload cluster")
-	err = commandContext.ClusterManager.InstallStorageClass(ctx, workloadCluster, commandContext.Provider)
+	if len(commandContext.ClusterSpec.Spec.HostEntries) > 0 {
+		l.Info("Adding host entries to workload cluster DNS")
+		err = commandContext.ClusterManager.InstallHostEntries(ctx, workloadCluster, commandContext.ClusterSpec)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+	}
+
+	if commandContext.ClusterSpec.Spec.CoreDNS != nil {
+		l.Info("Configuring CoreDNS on workload cluster")
+		err = commandContext.ClusterManager.ConfigureCoreDNS(ctx, workloadCluster, commandContext.ClusterSpec)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+	}
+
+	l.Info("Installing storage class on workload cluster")
+	err = commandContext.ClusterManager.InstallStorageClass(ctx, workloadCluster, commandContext.ClusterSpec, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
 
+	if commandContext.ClusterSpec.Spec.ServiceLoadBalancer != nil {
+		l.Info("Installing service load balancer on workload cluster")
+		err = commandContext.ClusterManager.InstallServiceLoadBalancer(ctx, workloadCluster, commandContext.ClusterSpec)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+	}
+
+	if commandContext.ClusterSpec.Spec.CoreAddons != nil {
+		l.Info("Installing core add-ons on workload cluster")
+		err = commandContext.ClusterManager.InstallCoreAddons(ctx, workloadCluster, commandContext.ClusterSpec)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+	}
+
 	if !commandContext.BootstrapCluster.ExistingManagement {
-		logger.Info("Installing cluster-api providers on workload cluster")
+		l.Info("Installing cluster-api providers on workload cluster")
 		err = commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.WorkloadCluster, commandContext.Provider)
 		if err != nil {
 			commandContext.SetError(err)
 			return &CollectDiagnosticsTask{}
 		}
 
-		logger.Info("Installing EKS-A secrets on workload cluster")
+		l.Info("Installing EKS-A secrets on workload cluster")
 		err := commandContext.Provider.UpdateSecrets(ctx, commandContext.WorkloadCluster)
 		if err != nil {
 			commandContext.SetError(err)
@@ -223,7 +328,7 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 		}
 	}
 
-	logger.V(4).Info("Installing machine health checks on bootstrap cluster")
+	l.V(4).Info("Installing machine health checks on bootstrap cluster")
 	err = commandContext.ClusterManager.InstallMachineHealthChecks(ctx, commandContext.BootstrapCluster, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
@@ -243,7 +348,7 @@ func (s *MoveClusterManagementTask) Run(ctx context.Context, commandContext *tas
 	if commandContext.BootstrapCluster.ExistingManagement {
 		return &InstallEksaComponentsTask{}
 	}
-	logger.Info("Moving cluster management from bootstrap to workload cluster")
+	l.Info("Moving cluster management from bootstrap to workload cluster")
 	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef())
 	if err != nil {
 		commandContext.SetError(err)
@@ -261,7 +366,7 @@ func (s *MoveClusterManagementTask) Name() string {
 
 func (s *InstallEksaComponentsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if !commandContext.BootstrapCluster.ExistingManagement {
-		logger.Info("Installing EKS-A custom components (CRD and controller) on workload cluster")
+		l.Info("Installing EKS-A custom components (CRD and controller) on workload cluster")
 		err := commandContext.ClusterManager.InstallCustomComponents(ctx, commandContext.ClusterSpec, commandContext.WorkloadCluster)
 		if err != nil {
 			commandContext.SetError(err)
@@ -269,7 +374,7 @@ func (s *InstallEksaComponentsTask) Run(ctx context.Context, commandContext *tas
 		}
 	}
 
-	logger.Info("Creating EKS-A CRDs instances on workload cluster")
+	l.Info("Creating EKS-A CRDs instances on workload cluster")
 	datacenterConfig := commandContext.Provider.DatacenterConfig()
 	machineConfigs := commandContext.Provider.MachineConfigs()
 
@@ -291,6 +396,13 @@ func (s *InstallEksaComponentsTask) Run(ctx context.Context, commandContext *tas
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
+
+	l.V(4).Info("Applying cluster provenance to workload cluster")
+	err = commandContext.ClusterManager.ApplyProvenance(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
 	return &InstallAddonManagerTask{}
 }
 
@@ -301,11 +413,12 @@ func (s *InstallEksaComponentsTask) Name() string {
 // InstallAddonManagerTask implementation
 
 func (s *InstallAddonManagerTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Installing AddonManager and GitOps Toolkit on workload cluster")
+	l.Info("Installing AddonManager and GitOps Toolkit on workload cluster")
 
 	err := commandContext.AddonManager.InstallGitOps(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec, commandContext.Provider.DatacenterConfig(), commandContext.Provider.MachineConfigs())
 	if err != nil {
-		logger.MarkFail("Error when installing GitOps toolkits on workload cluster; EKS-A will continue with cluster creation, but GitOps will not be enabled", "error", err)
+		l.MarkFail("Error when installing GitOps toolkits on workload cluster; EKS-A will continue with cluster creation, but GitOps will not be enabled", "error", err)
+		commandContext.AddWarning(fmt.Sprintf("GitOps is not enabled, installing the GitOps toolkit failed: %v", err))
 		return &WriteClusterConfigTask{}
 	}
 	return &WriteClusterConfigTask{}
@@ -316,12 +429,15 @@ func (s *InstallAddonManagerTask) Name() string {
 }
 
 func (s *WriteClusterConfigTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Writing cluster config file")
+	l.Info("Writing cluster config file")
 	err := clustermarshaller.WriteClusterConfig(commandContext.ClusterSpec, commandContext.Provider.DatacenterConfig(), commandContext.Provider.MachineConfigs(), commandContext.Writer)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
+	if len(commandContext.ClusterSpec.Spec.ReadinessGates) > 0 {
+		return &WaitForReadinessGatesTask{}
+	}
 	return &DeleteBootstrapClusterTask{}
 }
 
@@ -329,18 +445,37 @@ func (s *WriteClusterConfigTask) Name() string {
 	return "write-cluster-config"
 }
 
+// WaitForReadinessGatesTask implementation
+
+func (s *WaitForReadinessGatesTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	l.Info("Waiting for cluster readiness gates")
+	err := commandContext.ClusterManager.WaitForReadinessGates(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	return &DeleteBootstrapClusterTask{}
+}
+
+func (s *WaitForReadinessGatesTask) Name() string {
+	return "wait-for-readiness-gates"
+}
+
 // DeleteBootstrapClusterTask implementation
 
 func (s *DeleteBootstrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if !commandContext.BootstrapCluster.ExistingManagement {
-		logger.Info("Deleting bootstrap cluster")
+		l.Info("Deleting bootstrap cluster")
 		err := commandContext.Bootstrapper.DeleteBootstrapCluster(ctx, commandContext.BootstrapCluster, false)
-		if err != nil {
+		if err != nil && commandContext.OriginalError == nil {
+			// the cluster is up at this point, a bootstrap cluster that couldn't be cleaned up doesn't fail the create
+			l.Info("Warning: the bootstrap cluster could not be deleted, remove its resources manually", "error", err)
+		} else if err != nil {
 			commandContext.SetError(err)
 		}
 	}
 	if commandContext.OriginalError == nil {
-		logger.MarkSuccess("Cluster created!")
+		l.MarkSuccess("Cluster created!")
 	}
 	return nil
 }
@@ -349,6 +484,18 @@ func (s *DeleteBootstrapClusterTask) Name() string {
 	return "delete-kind-cluster"
 }
 
+// createResourcesLeft lists the clusters a failed create leaves behind, which need to be deleted before retrying
+func createResourcesLeft(commandContext *task.CommandContext) []string {
+	var resources []string
+	if commandContext.BootstrapCluster != nil && !commandContext.BootstrapCluster.ExistingManagement {
+		resources = append(resources, fmt.Sprintf("bootstrap cluster %s", commandContext.BootstrapCluster.Name))
+	}
+	if commandContext.WorkloadCluster != nil {
+		resources = append(resources, fmt.Sprintf("workload cluster %s", commandContext.WorkloadCluster.Name))
+	}
+	return resources
+}
+
 func getManagementCluster(commandContext *task.CommandContext) *types.Cluster {
 	target := commandContext.WorkloadCluster
 	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
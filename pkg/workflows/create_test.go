@@ -2,6 +2,11 @@ package workflows_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -14,6 +19,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
 )
@@ -70,6 +76,7 @@ func (c *createTestSetup) expectSetup() {
 	c.provider.EXPECT().SetupAndValidateCreateCluster(c.ctx, c.clusterSpec)
 	c.provider.EXPECT().Name()
 	c.addonManager.EXPECT().Validations(c.ctx, c.clusterSpec)
+	c.clusterManager.EXPECT().CreateManifestPolicyValidations(c.ctx, c.clusterSpec, c.provider)
 }
 
 func (c *createTestSetup) expectCreateBootstrap() {
@@ -78,6 +85,7 @@ func (c *createTestSetup) expectCreateBootstrap() {
 	}
 
 	gomock.InOrder(
+		c.bootstrapper.EXPECT().GetExistingBootstrapCluster(c.ctx, c.clusterSpec.Name).Return(nil, nil),
 		c.provider.EXPECT().BootstrapClusterOpts().Return(opts, nil),
 		// Checking for not nil because in go you can't compare closures
 		c.bootstrapper.EXPECT().CreateBootstrapCluster(
@@ -100,7 +108,7 @@ func (c *createTestSetup) expectCreateWorkload() {
 			c.ctx, c.workloadCluster, c.clusterSpec,
 		),
 		c.clusterManager.EXPECT().InstallStorageClass(
-			c.ctx, c.workloadCluster, c.provider,
+			c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
 		),
 		c.clusterManager.EXPECT().InstallCAPI(
 			c.ctx, c.clusterSpec, c.workloadCluster, c.provider,
@@ -119,7 +127,7 @@ func (c *createTestSetup) expectCreateWorkloadSkipCAPI() {
 			c.ctx, c.workloadCluster, c.clusterSpec,
 		),
 		c.clusterManager.EXPECT().InstallStorageClass(
-			c.ctx, c.workloadCluster, c.provider,
+			c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
 		),
 	)
 	c.clusterManager.EXPECT().InstallCAPI(
@@ -154,6 +162,8 @@ func (c *createTestSetup) expectInstallEksaComponents() {
 		),
 
 		c.clusterManager.EXPECT().ResumeEKSAControllerReconcile(c.ctx, c.workloadCluster, c.clusterSpec, c.provider),
+
+		c.clusterManager.EXPECT().ApplyProvenance(c.ctx, c.workloadCluster, c.clusterSpec),
 	)
 }
 
@@ -171,6 +181,8 @@ func (c *createTestSetup) skipInstallEksaComponents() {
 		),
 
 		c.clusterManager.EXPECT().ResumeEKSAControllerReconcile(c.ctx, c.bootstrapCluster, c.clusterSpec, c.provider),
+
+		c.clusterManager.EXPECT().ApplyProvenance(c.ctx, c.workloadCluster, c.clusterSpec),
 	)
 }
 
@@ -236,6 +248,194 @@ func TestCreateRunSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateRunDeleteBootstrapFailure(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, test.bootstrapCluster, false).Return(errors.New("containers left behind"))
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunSuccessWithServiceLoadBalancer(t *testing.T) {
+	test := newCreateTest(t)
+	test.clusterSpec.Spec.ServiceLoadBalancer = &v1alpha1.ServiceLoadBalancerConfiguration{
+		AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{{Addresses: []string{"10.0.0.0/28"}}},
+	}
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().CreateWorkloadCluster(
+			test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+		).Return(test.workloadCluster, nil),
+		test.clusterManager.EXPECT().InstallNetworking(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallStorageClass(test.ctx, test.workloadCluster, test.clusterSpec, test.provider),
+		test.clusterManager.EXPECT().InstallServiceLoadBalancer(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.workloadCluster, test.provider),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.workloadCluster),
+	)
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunSuccessWithCoreAddons(t *testing.T) {
+	test := newCreateTest(t)
+	test.clusterSpec.Spec.CoreAddons = &v1alpha1.CoreAddonsConfiguration{MetricsServer: true}
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().CreateWorkloadCluster(
+			test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+		).Return(test.workloadCluster, nil),
+		test.clusterManager.EXPECT().InstallNetworking(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallStorageClass(test.ctx, test.workloadCluster, test.clusterSpec, test.provider),
+		test.clusterManager.EXPECT().InstallCoreAddons(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.workloadCluster, test.provider),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.workloadCluster),
+	)
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunSuccessWithHostEntries(t *testing.T) {
+	test := newCreateTest(t)
+	test.clusterSpec.Spec.HostEntries = []v1alpha1.HostEntry{{IP: "10.0.0.10", Hostnames: []string{"registry.lab.local"}}}
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().CreateWorkloadCluster(
+			test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+		).Return(test.workloadCluster, nil),
+		test.clusterManager.EXPECT().InstallNetworking(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallHostEntries(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallStorageClass(test.ctx, test.workloadCluster, test.clusterSpec, test.provider),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.workloadCluster, test.provider),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.workloadCluster),
+	)
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunSuccessWithCoreDNS(t *testing.T) {
+	test := newCreateTest(t)
+	test.clusterSpec.Spec.CoreDNS = &v1alpha1.CoreDNSConfiguration{UpstreamResolvers: []string{"10.0.0.2"}}
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().CreateWorkloadCluster(
+			test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+		).Return(test.workloadCluster, nil),
+		test.clusterManager.EXPECT().InstallNetworking(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().ConfigureCoreDNS(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallStorageClass(test.ctx, test.workloadCluster, test.clusterSpec, test.provider),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.workloadCluster, test.provider),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.workloadCluster),
+	)
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunSuccessWithReadinessGates(t *testing.T) {
+	test := newCreateTest(t)
+	test.clusterSpec.Spec.ReadinessGates = []v1alpha1.ReadinessGate{{Name: "smoke-test", Job: &v1alpha1.ObjectReadinessGate{Name: "smoke-test"}}}
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.clusterManager.EXPECT().WaitForReadinessGates(test.ctx, test.workloadCluster, test.clusterSpec)
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunReadinessGatesFailed(t *testing.T) {
+	test := newCreateTest(t)
+	test.clusterSpec.Spec.ReadinessGates = []v1alpha1.ReadinessGate{{Name: "smoke-test", Job: &v1alpha1.ObjectReadinessGate{Name: "smoke-test"}}}
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.clusterManager.EXPECT().WaitForReadinessGates(test.ctx, test.workloadCluster, test.clusterSpec).Return(errors.New("readiness gate smoke-test didn't pass"))
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.bootstrapCluster)
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, test.provider, test.clusterSpec, test.workloadCluster)
+	test.expectNotDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	err := test.run()
+	if err == nil || err.Error() != "readiness gate smoke-test didn't pass" {
+		t.Fatalf("Create.Run() err = %v, want readiness gate failed", err)
+	}
+}
+
 func TestCreateRunSuccessForceCleanup(t *testing.T) {
 	test := newCreateTest(t)
 	test.forceCleanup = true
@@ -257,6 +457,106 @@ func TestCreateRunSuccessForceCleanup(t *testing.T) {
 	}
 }
 
+func TestCreateRunForceCleanupFailedResetsResult(t *testing.T) {
+	test := newCreateTest(t)
+	test.provider.EXPECT().SetupAndValidateCreateCluster(test.ctx, test.clusterSpec)
+	test.provider.EXPECT().Name()
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return(errors.New("cluster already exists"))
+	test.clusterManager.EXPECT().CreateManifestPolicyValidations(test.ctx, test.clusterSpec, test.provider)
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want validations failed")
+	}
+	if test.workflow.Result() == nil {
+		t.Fatal("Create.Result() = nil, want the result of the failed validations")
+	}
+
+	test.forceCleanup = true
+	test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, &types.Cluster{Name: "cluster-name"}, gomock.Any()).Return(errors.New("containers left behind"))
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want cleanup failed")
+	}
+	if got := test.workflow.Result(); got != nil {
+		t.Fatalf("Create.Result() = %+v, want nil when no task ran", got)
+	}
+}
+
+func TestCreateRunResumeExistingBootstrap(t *testing.T) {
+	test := newCreateTest(t)
+	test.bootstrapper.EXPECT().GetExistingBootstrapCluster(test.ctx, test.clusterSpec.Name).Return(&bootstrapper.ExistingBootstrapCluster{
+		Cluster:     test.bootstrapCluster,
+		CAPICluster: &types.CAPICluster{Metadata: types.Metadata{Name: test.clusterSpec.Name}},
+	}, nil)
+	test.provider.EXPECT().BootstrapClusterOpts().Times(0)
+	test.bootstrapper.EXPECT().CreateBootstrapCluster(test.ctx, test.clusterSpec, gomock.Any()).Times(0)
+	test.expectSetup()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().ResumeWorkloadCluster(
+			test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+		).Return(test.workloadCluster, nil),
+		test.clusterManager.EXPECT().InstallNetworking(test.ctx, test.workloadCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallStorageClass(test.ctx, test.workloadCluster, test.clusterSpec, test.provider),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.workloadCluster, test.provider),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.workloadCluster),
+	)
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunDeleteExistingBootstrapWithoutState(t *testing.T) {
+	test := newCreateTest(t)
+	staleBootstrap := &types.Cluster{Name: "cluster-name", KubeconfigFile: "stale.kubeconfig"}
+	gomock.InOrder(
+		test.bootstrapper.EXPECT().GetExistingBootstrapCluster(test.ctx, test.clusterSpec.Name).Return(&bootstrapper.ExistingBootstrapCluster{
+			Cluster: staleBootstrap,
+		}, nil),
+		test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, staleBootstrap, false),
+		test.provider.EXPECT().BootstrapClusterOpts().Return(nil, nil),
+		test.bootstrapper.EXPECT().CreateBootstrapCluster(test.ctx, test.clusterSpec).Return(test.bootstrapCluster, nil),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.bootstrapCluster, test.provider),
+		test.provider.EXPECT().BootstrapSetup(test.ctx, test.clusterSpec.Cluster, test.bootstrapCluster),
+	)
+	test.expectSetup()
+	test.expectCreateWorkload()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateRunExistingBootstrapOfOtherClusters(t *testing.T) {
+	test := newCreateTest(t)
+	otherBootstrap := &types.Cluster{Name: "cluster-name", KubeconfigFile: "other.kubeconfig"}
+	test.bootstrapper.EXPECT().GetExistingBootstrapCluster(test.ctx, test.clusterSpec.Name).Return(&bootstrapper.ExistingBootstrapCluster{
+		Cluster:       otherBootstrap,
+		OtherClusters: []string{"other-cluster"},
+	}, nil)
+	test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, otherBootstrap, false).Times(0)
+	test.bootstrapper.EXPECT().CreateBootstrapCluster(test.ctx, test.clusterSpec, gomock.Any()).Times(0)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want err not nil")
+	}
+}
+
 func TestCreateWorkloadClusterRunSuccess(t *testing.T) {
 	managementKubeconfig := "test.kubeconfig"
 	test := newCreateTest(t)
@@ -285,3 +585,115 @@ func TestCreateWorkloadClusterRunSuccess(t *testing.T) {
 		t.Fatalf("Create.Run() err = %v, want err = nil", err)
 	}
 }
+
+func TestCreateRunValidationFailed(t *testing.T) {
+	test := newCreateTest(t)
+	test.provider.EXPECT().SetupAndValidateCreateCluster(test.ctx, test.clusterSpec)
+	test.provider.EXPECT().Name()
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return(errors.New("cluster already exists"))
+	test.clusterManager.EXPECT().CreateManifestPolicyValidations(test.ctx, test.clusterSpec, test.provider)
+
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want validations failed")
+	}
+	if got := test.workflow.Result(); got.Reason != workflows.ReasonValidationFailed || len(got.ResourcesLeft) != 0 {
+		t.Fatalf("Create.Result() = %+v, want reason %s without resources left", got, workflows.ReasonValidationFailed)
+	}
+}
+
+func TestCreateRunManifestPolicyViolated(t *testing.T) {
+	test := newCreateTest(t)
+	test.provider.EXPECT().SetupAndValidateCreateCluster(test.ctx, test.clusterSpec)
+	test.provider.EXPECT().Name()
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.expectPreflightValidationsToPass()
+	test.clusterManager.EXPECT().CreateManifestPolicyValidations(test.ctx, test.clusterSpec, test.provider).Return([]validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{Name: "generated manifests follow the policies", Err: errors.New("denied by policy")}
+		},
+	})
+
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want validations failed")
+	}
+	if got := test.workflow.Result(); got.Reason != workflows.ReasonValidationFailed || len(got.ResourcesLeft) != 0 {
+		t.Fatalf("Create.Result() = %+v, want reason %s without resources left", got, workflows.ReasonValidationFailed)
+	}
+}
+
+func TestCreateRunProviderAuthFailed(t *testing.T) {
+	test := newCreateTest(t)
+	test.provider.EXPECT().SetupAndValidateCreateCluster(test.ctx, test.clusterSpec).Return(&providers.AuthenticationError{
+		Provider: "vsphere",
+		Err:      errors.New("failed validating credentials for vCenter: invalid credentials"),
+	})
+	test.provider.EXPECT().Name()
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.expectPreflightValidationsToPass()
+	test.clusterManager.EXPECT().CreateManifestPolicyValidations(test.ctx, test.clusterSpec, test.provider)
+
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want validations failed")
+	}
+	if got := test.workflow.Result().Reason; got != workflows.ReasonProviderAuthFailed {
+		t.Fatalf("Create.Result().Reason = %s, want %s", got, workflows.ReasonProviderAuthFailed)
+	}
+}
+
+func TestCreateRunPartialCreate(t *testing.T) {
+	test := newCreateTest(t)
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.clusterManager.EXPECT().CreateWorkloadCluster(
+		test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+	).Return(nil, errors.New("control plane not ready"))
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.bootstrapCluster)
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, test.provider, test.clusterSpec, nil)
+
+	if err := test.run(); err == nil {
+		t.Fatal("Create.Run() err = nil, want control plane not ready")
+	}
+	got := test.workflow.Result()
+	if got.Reason != workflows.ReasonPartialCreate {
+		t.Fatalf("Create.Result().Reason = %s, want %s", got.Reason, workflows.ReasonPartialCreate)
+	}
+	if !reflect.DeepEqual(got.ResourcesLeft, []string{"bootstrap cluster bootstrap"}) {
+		t.Fatalf("Create.Result().ResourcesLeft = %v, want the bootstrap cluster", got.ResourcesLeft)
+	}
+}
+
+func TestCreateRunWithResultFile(t *testing.T) {
+	test := newCreateTest(t)
+	resultFile := filepath.Join(t.TempDir(), "cluster-name", "cluster-name-create-result.json")
+	test.workflow = workflows.NewCreate(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, test.writer, workflows.WithResultFile(resultFile))
+	test.workloadCluster.KubeconfigFile = "cluster-name/cluster-name-eks-a-cluster.kubeconfig"
+
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+
+	content, err := ioutil.ReadFile(resultFile)
+	if err != nil {
+		t.Fatalf("reading result file: %v", err)
+	}
+	got := &workflows.Result{}
+	if err = json.Unmarshal(content, got); err != nil {
+		t.Fatalf("parsing result file: %v", err)
+	}
+	if got.KubeconfigFile != test.workloadCluster.KubeconfigFile {
+		t.Fatalf("result file kubeconfigFile = %s, want %s", got.KubeconfigFile, test.workloadCluster.KubeconfigFile)
+	}
+}
@@ -2,55 +2,99 @@ package workflows
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
+// Delete is the delete cluster workflow. Its dependencies are injected through NewDelete
 type Delete struct {
 	bootstrapper   interfaces.Bootstrapper
 	provider       providers.Provider
 	clusterManager interfaces.ClusterManager
 	addonManager   interfaces.AddonManager
+	options        options
+	result         *Result
 }
 
+// deleteBudgets is the fraction of the operation timeout each delete task can use
+var deleteBudgets = map[string]float64{
+	"setup-and-validate":      0.15,
+	"management-cluster-init": 0.3,
+}
+
+// NewDelete builds the workflow that deletes a cluster and its machines
 func NewDelete(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
-	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager) *Delete {
+	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, opts ...Opt) *Delete {
 	return &Delete{
 		bootstrapper:   bootstrapper,
 		provider:       provider,
 		clusterManager: clusterManager,
 		addonManager:   addonManager,
+		options:        newOptions(opts),
 	}
 }
 
+// Run deletes workloadCluster. forceCleanup deletes the bootstrap cluster left by a previous run unless it holds the cluster state
 func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, forceCleanup bool, kubeconfig string) error {
+	c.result = nil
 	if forceCleanup {
-		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
-			Name: workloadCluster.Name,
-		}, false); err != nil {
+		if err := c.cleanupBootstrapCluster(ctx, workloadCluster.Name); err != nil {
 			return err
 		}
 	}
 
 	commandContext := &task.CommandContext{
-		Bootstrapper:    c.bootstrapper,
-		Provider:        c.provider,
-		ClusterManager:  c.clusterManager,
-		AddonManager:    c.addonManager,
-		WorkloadCluster: workloadCluster,
-		ClusterSpec:     clusterSpec,
+		Bootstrapper:     c.bootstrapper,
+		Provider:         c.provider,
+		ClusterManager:   c.clusterManager,
+		AddonManager:     c.addonManager,
+		WorkloadCluster:  workloadCluster,
+		ClusterSpec:      clusterSpec,
+		WorkloadBackup:   c.options.workloadBackup,
+		WorkloadEviction: c.options.workloadEviction,
+		MachinePower:     c.options.machinePower,
 	}
 
 	if clusterSpec.ManagementCluster != nil {
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidate{}).RunTask(ctx, commandContext)
+	recorder := newResultRecorder("delete", workloadCluster.Name)
+	c.options.notifyStarted(ctx, recorder.result)
+	err := task.NewTaskRunner(&setupAndValidate{}, c.options.taskRunnerOpts(deleteBudgets, recorder)...).RunTask(ctx, commandContext)
+	c.result = c.options.finishResult(recorder, commandContext, err, clusterSpec, nil)
+	c.options.notifyFinished(ctx, c.result)
+
+	return err
+}
+
+// Result returns the result of the last run, with the outcome and the tasks it ran, also when it failed.
+// It's nil before the first run and when forceCleanup failed deleting the previous bootstrap cluster, since no task ran
+func (c *Delete) Result() *Result {
+	return c.result
+}
+
+// cleanupBootstrapCluster deletes the bootstrap cluster left by a previous run unless it holds the state of the cluster,
+// which means the previous delete failed after moving the cluster to it. Deleting it would leave the machines behind
+func (c *Delete) cleanupBootstrapCluster(ctx context.Context, clusterName string) error {
+	existing, err := c.bootstrapper.GetExistingBootstrapCluster(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	if existing.CAPICluster != nil {
+		l.Info("Keeping bootstrap cluster from a previous delete, it holds the cluster state", "cluster", clusterName)
+		return nil
+	}
+
+	return c.bootstrapper.DeleteBootstrapCluster(ctx, existing.Cluster, false)
 }
 
 type setupAndValidate struct{}
@@ -65,32 +109,84 @@ type deleteWorkloadCluster struct{}
 
 type cleanupGitRepo struct{}
 
+type powerOffMachines struct{}
+
 type deleteManagementCluster struct {
 	*CollectDiagnosticsTask
 }
 
 func (s *setupAndValidate) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Performing provider setup and validations")
+	if err := validateDeletionProtection(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	l.Info("Performing provider setup and validations")
 	err := commandContext.Provider.SetupAndValidateDeleteCluster(ctx)
 	if err != nil {
 		commandContext.SetError(err)
 		return nil
 	}
-	return &createManagementCluster{}
+	return backupWorkloadsOrNext(commandContext, "delete", evictWorkloadsOrNext(commandContext, &createManagementCluster{}))
 }
 
 func (s *setupAndValidate) Name() string {
 	return "setup-and-validate"
 }
 
+// validateDeletionProtection fails when deletion protection is enabled in the cluster config or in the cluster object,
+// which lives in the management cluster for managed clusters. The object is only checked on a best effort basis
+// since a cluster that failed to be created might not have it
+func validateDeletionProtection(ctx context.Context, commandContext *task.CommandContext) error {
+	name := commandContext.ClusterSpec.Name
+	err := fmt.Errorf("cluster %s has deletion protection enabled, set spec.deletionProtection to false and upgrade the cluster before deleting it", name)
+	if commandContext.ClusterSpec.Cluster.Spec.DeletionProtection {
+		return err
+	}
+
+	holder := commandContext.WorkloadCluster
+	if commandContext.BootstrapCluster != nil {
+		holder = commandContext.BootstrapCluster
+	}
+	protected, getErr := commandContext.ClusterManager.IsDeletionProtected(ctx, holder, name)
+	if getErr != nil {
+		l.Info("Warning: Unable to check the deletion protection of the cluster object", "cluster", name, "error", getErr)
+		return nil
+	}
+	if protected {
+		return err
+	}
+	return nil
+}
+
 func (s *createManagementCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
 		return &deleteWorkloadCluster{}
 	}
-	logger.Info("Creating management cluster")
+
+	existing, err := commandContext.Bootstrapper.GetExistingBootstrapCluster(ctx, commandContext.ClusterSpec.Name)
+	if err != nil {
+		commandContext.SetError(fmt.Errorf("%v, try rerunning with --force-cleanup to force delete previously created bootstrap cluster", err))
+		return nil
+	}
+	if existing != nil {
+		if existing.CAPICluster != nil {
+			l.Info("Found bootstrap cluster from a previous delete holding the cluster state, resuming cluster deletion")
+			commandContext.BootstrapCluster = existing.Cluster
+			return &deleteWorkloadCluster{}
+		}
+
+		l.Info("Deleting bootstrap cluster from a previous run, it doesn't hold any cluster state")
+		if err = commandContext.Bootstrapper.DeleteBootstrapCluster(ctx, existing.Cluster, false); err != nil {
+			commandContext.SetError(err)
+			return nil
+		}
+	}
+
+	l.Info("Creating management cluster")
 	bootstrapOptions, err := commandContext.Provider.BootstrapClusterOpts()
 	if err != nil {
-		logger.Error(err, "Error getting management options from provider")
+		l.Error(err, "Error getting management options from provider")
 		commandContext.SetError(err)
 		return nil
 	}
@@ -110,7 +206,7 @@ func (s *createManagementCluster) Name() string {
 }
 
 func (s *installCAPI) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Installing cluster-api providers on management cluster")
+	l.Info("Installing cluster-api providers on management cluster")
 	err := commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
@@ -124,8 +220,8 @@ func (s *installCAPI) Name() string {
 }
 
 func (s *moveClusterManagement) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Moving cluster management from workload cluster")
-	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.WorkloadCluster, commandContext.BootstrapCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef())
+	l.Info("Moving cluster management from workload cluster")
+	err := commandContext.ClusterManager.MoveCAPIAndVerify(ctx, commandContext.WorkloadCluster, commandContext.BootstrapCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef())
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
@@ -138,10 +234,13 @@ func (s *moveClusterManagement) Name() string {
 }
 
 func (s *deleteWorkloadCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Deleting workload cluster")
+	l.Info("Deleting workload cluster")
 	err := commandContext.ClusterManager.DeleteCluster(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.Provider, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
+		if !commandContext.BootstrapCluster.ExistingManagement {
+			l.Info("The cluster state was kept in the bootstrap cluster, rerun the delete to resume it", "bootstrap cluster", commandContext.BootstrapCluster.Name)
+		}
 		return &CollectDiagnosticsTask{}
 	}
 
@@ -153,13 +252,16 @@ func (s *deleteWorkloadCluster) Name() string {
 }
 
 func (s *cleanupGitRepo) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Clean up Git Repo")
+	l.Info("Clean up Git Repo")
 	err := commandContext.AddonManager.CleanupGitRepo(ctx, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
 
+	if commandContext.MachinePower != nil {
+		return &powerOffMachines{}
+	}
 	return &deleteManagementCluster{}
 }
 
@@ -167,6 +269,20 @@ func (s *cleanupGitRepo) Name() string {
 	return "clean-up-git-repo"
 }
 
+func (s *powerOffMachines) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	l.Info("Powering off cluster machines")
+	if err := commandContext.MachinePower.PowerOffMachines(ctx); err != nil {
+		l.Error(err, "Failed powering off some cluster machines, power them off through their BMC")
+		commandContext.AddWarning(fmt.Sprintf("failed powering off machines: %v", err))
+	}
+
+	return &deleteManagementCluster{}
+}
+
+func (s *powerOffMachines) Name() string {
+	return "power-off-machines"
+}
+
 func (s *deleteManagementCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.OriginalError != nil {
 		_ = s.CollectDiagnosticsTask.Run(ctx, commandContext)
@@ -177,9 +293,9 @@ func (s *deleteManagementCluster) Run(ctx context.Context, commandContext *task.
 		}
 		return nil
 	}
-	logger.Info("Bootstrap cluster information missing - skipping delete kind cluster")
+	l.Info("Bootstrap cluster information missing - skipping delete kind cluster")
 	if commandContext.OriginalError == nil {
-		logger.MarkSuccess("Cluster deleted!")
+		l.MarkSuccess("Cluster deleted!")
 	}
 	return nil
 }
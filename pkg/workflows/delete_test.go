@@ -2,13 +2,21 @@ package workflows_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/notification"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/workflows"
@@ -52,6 +60,7 @@ func newDeleteTest(t *testing.T) *deleteTestSetup {
 }
 
 func (c *deleteTestSetup) expectSetup() {
+	c.clusterManager.EXPECT().IsDeletionProtected(c.ctx, gomock.Any(), c.clusterSpec.Name).Return(false, nil)
 	c.provider.EXPECT().SetupAndValidateDeleteCluster(c.ctx)
 }
 
@@ -61,6 +70,7 @@ func (c *deleteTestSetup) expectCreateBootstrap() {
 	}
 
 	gomock.InOrder(
+		c.bootstrapper.EXPECT().GetExistingBootstrapCluster(c.ctx, c.clusterSpec.Name).Return(nil, nil),
 		c.provider.EXPECT().BootstrapClusterOpts().Return(opts, nil),
 		c.bootstrapper.EXPECT().CreateBootstrapCluster(
 			c.ctx, gomock.Not(gomock.Nil()), gomock.Not(gomock.Nil()),
@@ -113,7 +123,7 @@ func (c *deleteTestSetup) expectCleanupGitRepo() {
 
 func (c *deleteTestSetup) expectMoveManagement() {
 	gomock.InOrder(
-		c.clusterManager.EXPECT().MoveCAPI(
+		c.clusterManager.EXPECT().MoveCAPIAndVerify(
 			c.ctx, c.workloadCluster, c.bootstrapCluster, c.workloadCluster.Name, c.clusterSpec, gomock.Any(),
 		),
 	)
@@ -121,7 +131,7 @@ func (c *deleteTestSetup) expectMoveManagement() {
 
 func (c *deleteTestSetup) expectNotToMoveManagement() {
 	gomock.InOrder(
-		c.clusterManager.EXPECT().MoveCAPI(
+		c.clusterManager.EXPECT().MoveCAPIAndVerify(
 			c.ctx, c.workloadCluster, c.bootstrapCluster, c.workloadCluster.Name, gomock.Any(),
 		).Times(0),
 	)
@@ -147,6 +157,49 @@ func TestDeleteRunSuccess(t *testing.T) {
 	}
 }
 
+func TestDeleteRunDeletionProtectionInConfig(t *testing.T) {
+	test := newDeleteTest(t)
+	test.clusterSpec.Cluster.Spec.DeletionProtection = true
+
+	err := test.run()
+	if err == nil || !strings.Contains(err.Error(), "has deletion protection enabled") {
+		t.Fatalf("Delete.Run() error = %v, want deletion protection error", err)
+	}
+}
+
+func TestDeleteRunDeletionProtectionInClusterObject(t *testing.T) {
+	test := newDeleteTest(t)
+	test.clusterManager.EXPECT().IsDeletionProtected(test.ctx, test.workloadCluster, test.clusterSpec.Name).Return(true, nil)
+
+	err := test.run()
+	if err == nil || !strings.Contains(err.Error(), "has deletion protection enabled") {
+		t.Fatalf("Delete.Run() error = %v, want deletion protection error", err)
+	}
+}
+
+func TestDeleteRunDeletionProtectionInManagementCluster(t *testing.T) {
+	test := newDeleteTest(t)
+	management := &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig", ExistingManagement: true}
+	test.clusterSpec.ManagementCluster = management
+	test.clusterManager.EXPECT().IsDeletionProtected(test.ctx, management, test.clusterSpec.Name).Return(true, nil)
+
+	err := test.run()
+	if err == nil || !strings.Contains(err.Error(), "has deletion protection enabled") {
+		t.Fatalf("Delete.Run() error = %v, want deletion protection error", err)
+	}
+}
+
+func TestDeleteRunDeletionProtectionUnknown(t *testing.T) {
+	test := newDeleteTest(t)
+	test.clusterManager.EXPECT().IsDeletionProtected(test.ctx, test.workloadCluster, test.clusterSpec.Name).Return(false, errors.New("cluster not found"))
+	test.provider.EXPECT().SetupAndValidateDeleteCluster(test.ctx).Return(errors.New("provider error"))
+
+	err := test.run()
+	if err == nil || err.Error() != "provider error" {
+		t.Fatalf("Delete.Run() error = %v, want provider error", err)
+	}
+}
+
 func TestDeleteWorkloadRunSuccess(t *testing.T) {
 	test := newDeleteTest(t)
 	test.expectSetup()
@@ -167,3 +220,273 @@ func TestDeleteWorkloadRunSuccess(t *testing.T) {
 		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
 	}
 }
+
+func TestDeleteRunResumeExistingBootstrap(t *testing.T) {
+	test := newDeleteTest(t)
+	test.expectSetup()
+	test.bootstrapper.EXPECT().GetExistingBootstrapCluster(test.ctx, test.clusterSpec.Name).Return(&bootstrapper.ExistingBootstrapCluster{
+		Cluster:     test.bootstrapCluster,
+		CAPICluster: &types.CAPICluster{Metadata: types.Metadata{Name: test.clusterSpec.Name}},
+	}, nil)
+	test.expectNotToCreateBootstrap()
+	test.expectNotToMoveManagement()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectDeleteBootstrap()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteRunForceCleanupKeepsBootstrapWithState(t *testing.T) {
+	test := newDeleteTest(t)
+	test.forceCleanup = true
+	existing := &bootstrapper.ExistingBootstrapCluster{
+		Cluster:     test.bootstrapCluster,
+		CAPICluster: &types.CAPICluster{Metadata: types.Metadata{Name: test.clusterSpec.Name}},
+	}
+	test.bootstrapper.EXPECT().GetExistingBootstrapCluster(test.ctx, test.workloadCluster.Name).Return(existing, nil)
+	test.expectSetup()
+	test.bootstrapper.EXPECT().GetExistingBootstrapCluster(test.ctx, test.clusterSpec.Name).Return(existing, nil)
+	test.expectNotToCreateBootstrap()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectDeleteBootstrap()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteRunKeepsBootstrapWhenDeleteFails(t *testing.T) {
+	test := newDeleteTest(t)
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectMoveManagement()
+	test.clusterManager.EXPECT().DeleteCluster(test.ctx, test.bootstrapCluster, test.workloadCluster, test.provider, test.clusterSpec).Return(errors.New("timed out"))
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.bootstrapCluster)
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, test.provider, test.clusterSpec, test.workloadCluster)
+	test.expectNotToDeleteBootstrap()
+
+	if err := test.run(); err == nil {
+		t.Fatal("Delete.Run() err = nil, want err not nil")
+	}
+}
+
+func TestDeleteRunWithWorkloadBackup(t *testing.T) {
+	test := newDeleteTest(t)
+	backup := mocks.NewMockWorkloadBackup(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithWorkloadBackup(backup))
+	test.expectSetup()
+	backup.EXPECT().Backup(test.ctx, "delete").Return("eksa-delete-cluster-name", nil)
+	test.expectCreateBootstrap()
+	test.expectMoveManagement()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectDeleteBootstrap()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteRunWorkloadBackupFails(t *testing.T) {
+	test := newDeleteTest(t)
+	backup := mocks.NewMockWorkloadBackup(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithWorkloadBackup(backup))
+	test.expectSetup()
+	backup.EXPECT().Backup(test.ctx, "delete").Return("", errors.New("velero not installed"))
+	test.expectNotToCreateBootstrap()
+	test.expectNotToMoveManagement()
+
+	err := test.run()
+	if err == nil || err.Error() != "failed backing up workloads before delete: velero not installed" {
+		t.Fatalf("Delete.Run() err = %v, want err = failed backing up workloads before delete: velero not installed", err)
+	}
+}
+
+func TestDeleteRunWithWorkloadEviction(t *testing.T) {
+	test := newDeleteTest(t)
+	eviction := mocks.NewMockWorkloadEviction(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithWorkloadEviction(eviction))
+	test.expectSetup()
+	eviction.EXPECT().Evict(test.ctx)
+	test.expectCreateBootstrap()
+	test.expectMoveManagement()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectDeleteBootstrap()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestDeleteRunWorkloadEvictionFails(t *testing.T) {
+	test := newDeleteTest(t)
+	eviction := mocks.NewMockWorkloadEviction(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithWorkloadEviction(eviction))
+	test.expectSetup()
+	eviction.EXPECT().Evict(test.ctx).Return(errors.New("timed out waiting for volumes"))
+	test.expectNotToCreateBootstrap()
+	test.expectNotToMoveManagement()
+
+	want := "failed evicting workloads before delete: timed out waiting for volumes, rerun without --evict-workloads to skip it"
+	if err := test.run(); err == nil || err.Error() != want {
+		t.Fatalf("Delete.Run() err = %v, want err = %s", err, want)
+	}
+}
+
+func TestDeleteRunPowersOffMachines(t *testing.T) {
+	test := newDeleteTest(t)
+	power := mocks.NewMockMachinePower(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithMachinesPowerOff(power))
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectMoveManagement()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	power.EXPECT().PowerOffMachines(test.ctx).Return(errors.New("machine m-1: timeout"))
+	test.expectDeleteBootstrap()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+	if warnings := test.workflow.Result().Warnings; !reflect.DeepEqual(warnings, []string{"failed powering off machines: machine m-1: timeout"}) {
+		t.Fatalf("Delete.Result().Warnings = %v, want the power off failure", warnings)
+	}
+}
+
+func TestDeleteRunWithProgressSink(t *testing.T) {
+	test := newDeleteTest(t)
+	sink := mocks.NewMockProgressSink(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithProgressSink(sink))
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectMoveManagement()
+	test.expectDeleteBootstrap()
+	started, finished := []string{}, []string{}
+	sink.EXPECT().TaskStarted(gomock.Any()).Do(func(name string) { started = append(started, name) }).AnyTimes()
+	sink.EXPECT().TaskFinished(gomock.Any(), gomock.Any(), nil).Do(func(name string, _ time.Duration, _ error) { finished = append(finished, name) }).AnyTimes()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+	if len(started) == 0 || started[0] != "setup-and-validate" {
+		t.Fatalf("ProgressSink.TaskStarted() calls = %v, want first call = setup-and-validate", started)
+	}
+	if !reflect.DeepEqual(started, finished) {
+		t.Fatalf("ProgressSink.TaskFinished() calls = %v, want %v", finished, started)
+	}
+}
+
+func TestDeleteRunWithResultFile(t *testing.T) {
+	test := newDeleteTest(t)
+	resultFile := filepath.Join(t.TempDir(), "cluster-name", "cluster-name-delete-result.json")
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithResultFile(resultFile))
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectMoveManagement()
+	test.expectDeleteBootstrap()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+
+	content, err := ioutil.ReadFile(resultFile)
+	if err != nil {
+		t.Fatalf("reading result file: %v", err)
+	}
+	got := &workflows.Result{}
+	if err = json.Unmarshal(content, got); err != nil {
+		t.Fatalf("parsing result file: %v", err)
+	}
+	if !reflect.DeepEqual(got, test.workflow.Result()) {
+		t.Fatalf("result file = %+v, want %+v", got, test.workflow.Result())
+	}
+	if got.Operation != "delete" || got.Cluster != "workload" || got.Outcome != workflows.OutcomeSucceeded || got.Error != "" {
+		t.Fatalf("Delete.Result() = %+v, want succeeded delete of workload", got)
+	}
+	if len(got.Tasks) == 0 || got.Tasks[0].Name != "setup-and-validate" {
+		t.Fatalf("Delete.Result().Tasks = %v, want first task = setup-and-validate", got.Tasks)
+	}
+	if got.VersionsBefore == nil || got.VersionsAfter != nil {
+		t.Fatalf("Delete.Result() versions before = %v, after = %v, want only before", got.VersionsBefore, got.VersionsAfter)
+	}
+}
+
+func TestDeleteRunResultFailed(t *testing.T) {
+	test := newDeleteTest(t)
+	backup := mocks.NewMockWorkloadBackup(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithWorkloadBackup(backup))
+	test.expectSetup()
+	backup.EXPECT().Backup(test.ctx, "delete").Return("", errors.New("velero not installed"))
+	test.expectNotToCreateBootstrap()
+	test.expectNotToMoveManagement()
+
+	if err := test.run(); err == nil {
+		t.Fatal("Delete.Run() err = nil, want err")
+	}
+
+	got := test.workflow.Result()
+	if got.Outcome != workflows.OutcomeFailed || got.Error != "failed backing up workloads before delete: velero not installed" {
+		t.Fatalf("Delete.Result() = %+v, want failed with backup error", got)
+	}
+	last := got.Tasks[len(got.Tasks)-1]
+	if last.Name != "backup-workloads" || last.Error == "" {
+		t.Fatalf("Delete.Result() last task = %+v, want failed backup-workloads", last)
+	}
+}
+
+func TestDeleteRunWithNotifier(t *testing.T) {
+	test := newDeleteTest(t)
+	notifier := mocks.NewMockNotifier(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager, workflows.WithNotifier(notifier))
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectMoveManagement()
+	test.expectDeleteBootstrap()
+	events := []notification.Event{}
+	notifier.EXPECT().Notify(test.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e notification.Event) error {
+		events = append(events, e)
+		return errors.New("slack is down")
+	}).Times(2)
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+	if len(events) != 2 || events[0].Type != notification.OperationStarted || events[1].Type != notification.OperationSucceeded {
+		t.Fatalf("Notifier.Notify() events = %+v, want started and succeeded", events)
+	}
+	if events[1].Cluster != "workload" || events[1].Operation != "delete" {
+		t.Fatalf("Notifier.Notify() event = %+v, want delete of workload", events[1])
+	}
+}
+
+func TestDeleteRunWithNotifierFailure(t *testing.T) {
+	test := newDeleteTest(t)
+	backup := mocks.NewMockWorkloadBackup(gomock.NewController(t))
+	notifier := mocks.NewMockNotifier(gomock.NewController(t))
+	test.workflow = workflows.NewDelete(test.bootstrapper, test.provider, test.clusterManager, test.addonManager,
+		workflows.WithWorkloadBackup(backup), workflows.WithNotifier(notifier))
+	test.expectSetup()
+	backup.EXPECT().Backup(test.ctx, "delete").Return("", errors.New("velero not installed"))
+	test.expectNotToCreateBootstrap()
+	test.expectNotToMoveManagement()
+	var last notification.Event
+	notifier.EXPECT().Notify(test.ctx, gomock.Any()).Do(func(_ context.Context, e notification.Event) { last = e }).Times(2)
+
+	if err := test.run(); err == nil {
+		t.Fatal("Delete.Run() err = nil, want backup error")
+	}
+	if last.Type != notification.OperationFailed || last.Message != "failed backing up workloads before delete: velero not installed" {
+		t.Fatalf("Notifier.Notify() last event = %+v, want failed with backup error", last)
+	}
+}
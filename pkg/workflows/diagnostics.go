@@ -3,7 +3,6 @@ package workflows
 import (
 	"context"
 
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/task"
 )
 
@@ -19,7 +18,7 @@ type CollectMgmtClusterDiagnosticsTask struct{}
 // CollectDiagnosticsTask implementation
 
 func (s *CollectDiagnosticsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("collecting cluster diagnostics")
+	l.Info("collecting cluster diagnostics")
 	_ = s.CollectMgmtClusterDiagnosticsTask.Run(ctx, commandContext)
 	_ = s.CollectWorkloadClusterDiagnosticsTask.Run(ctx, commandContext)
 	return nil
@@ -32,7 +31,7 @@ func (s *CollectDiagnosticsTask) Name() string {
 // CollectWorkloadClusterDiagnosticsTask implementation
 
 func (s *CollectWorkloadClusterDiagnosticsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("collecting workload cluster diagnostics")
+	l.Info("collecting workload cluster diagnostics")
 	_ = commandContext.ClusterManager.SaveLogsWorkloadCluster(ctx, commandContext.Provider, commandContext.ClusterSpec, commandContext.WorkloadCluster)
 	return nil
 }
@@ -44,7 +43,7 @@ func (s *CollectWorkloadClusterDiagnosticsTask) Name() string {
 // CollectMgmtClusterDiagnosticsTask implementation
 
 func (s *CollectMgmtClusterDiagnosticsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("collecting management cluster diagnostics")
+	l.Info("collecting management cluster diagnostics")
 	_ = commandContext.ClusterManager.SaveLogsManagementCluster(ctx, commandContext.BootstrapCluster)
 	return nil
 }
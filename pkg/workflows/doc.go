@@ -0,0 +1,40 @@
+/*
+Package workflows implements the create, upgrade and delete cluster operations of eksctl anywhere
+so they can also be embedded in other Go programs.
+
+Each workflow is a chain of tasks run by the task package. The workflows only depend on the
+interfaces of the workflows/interfaces package, so any of them can be replaced. The dependencies
+package builds the implementations used by the CLI:
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithBootstrapper().
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(clusterConfigFile, clusterSpec.Cluster, false, "").
+		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
+		WithWriter().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer deps.Close(ctx)
+
+	create := workflows.NewCreate(deps.Bootstrapper, deps.Provider, deps.ClusterManager, deps.FluxAddonClient, deps.Writer,
+		workflows.WithTimeout(2*time.Hour),
+		workflows.WithProgressSink(sink),
+	)
+	err = create.Run(ctx, clusterSpec, validator, false)
+
+Optional behavior is set with Opt funcs:
+
+  - WithTimeout bounds the time the operation can take.
+  - WithWorkloadBackup backs up the workloads before any destructive change.
+  - WithProgressSink reports the start and end of each task, so programs can show the progress in their own way.
+  - WithResultFile writes the Result of the operation as json when it finishes.
+
+After Run, the Result method returns the outcome, the duration of each task, the cluster versions before and after,
+the artifacts produced and the warnings of the operation, so programs don't have to parse the logs.
+
+The workflows log through the logger package. Programs with their own logr.Logger can set it with logger.Set
+before running any workflow.
+*/
+package workflows
@@ -0,0 +1,35 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+// evictWorkloadsTask evicts the cluster workloads before it's deleted and continues with the next task.
+// It's skipped when the workflow runs without a workload eviction
+type evictWorkloadsTask struct {
+	next task.Task
+}
+
+func evictWorkloadsOrNext(commandContext *task.CommandContext, next task.Task) task.Task {
+	if commandContext.WorkloadEviction == nil {
+		return next
+	}
+	return &evictWorkloadsTask{next: next}
+}
+
+func (s *evictWorkloadsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	l.Info("Evicting cluster workloads")
+	if err := commandContext.WorkloadEviction.Evict(ctx); err != nil {
+		commandContext.SetError(fmt.Errorf("failed evicting workloads before delete: %v, rerun without --evict-workloads to skip it", err))
+		return nil
+	}
+
+	return s.next
+}
+
+func (s *evictWorkloadsTask) Name() string {
+	return "evict-workloads"
+}
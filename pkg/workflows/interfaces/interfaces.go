@@ -2,28 +2,35 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/notification"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
+// Bootstrapper manages the temporary kind cluster the workflows use to create, upgrade and delete clusters
 type Bootstrapper interface {
 	CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.Spec, opts ...bootstrapper.BootstrapClusterOption) (*types.Cluster, error)
 	DeleteBootstrapCluster(context.Context, *types.Cluster, bool) error
+	GetExistingBootstrapCluster(ctx context.Context, clusterName string) (*bootstrapper.ExistingBootstrapCluster, error)
 }
 
+// ClusterManager runs the cluster operations the workflows are made of. clustermanager.ClusterManager implements it
 type ClusterManager interface {
 	MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error
+	MoveCAPIAndVerify(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error
 	CreateWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error)
+	ResumeWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error)
 	UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) error
 	InstallCAPI(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error
 	InstallNetworking(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 	UpgradeNetworking(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
-	InstallStorageClass(ctx context.Context, cluster *types.Cluster, provider providers.Provider) error
+	InstallStorageClass(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	SaveLogsManagementCluster(ctx context.Context, cluster *types.Cluster) error
 	SaveLogsWorkloadCluster(ctx context.Context, provider providers.Provider, spec *cluster.Spec, cluster *types.Cluster) error
 	InstallCustomComponents(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error
@@ -34,11 +41,21 @@ type ClusterManager interface {
 	EKSAClusterSpecChanged(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) (bool, error)
 	InstallMachineHealthChecks(ctx context.Context, workloadCluster *types.Cluster, provider providers.Provider) error
 	GetCurrentClusterSpec(ctx context.Context, cluster *types.Cluster, clusterName string) (*cluster.Spec, error)
+	IsDeletionProtected(ctx context.Context, cluster *types.Cluster, clusterName string) (bool, error)
 	Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 	InstallAwsIamAuth(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	CreateAwsIamAuthCaSecret(ctx context.Context, cluster *types.Cluster) error
+	InstallServiceLoadBalancer(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	InstallHostEntries(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	InstallCoreAddons(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	ConfigureCoreDNS(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	ApplyProvenance(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	WaitForReadinessGates(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	CreateManifestPolicyValidations(ctx context.Context, clusterSpec *cluster.Spec, provider providers.Provider) []validations.Validation
+	UpgradeManifestPolicyValidations(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) []validations.Validation
 }
 
+// AddonManager manages the GitOps configuration of the cluster. addonclients.FluxAddonClient implements it
 type AddonManager interface {
 	InstallGitOps(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
 	PauseGitOpsKustomization(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
@@ -51,11 +68,45 @@ type AddonManager interface {
 	UpdateLegacyFileStructure(ctx context.Context, currentSpec, newSpec *cluster.Spec) error
 }
 
+// Validator runs the preflight validations before a workflow makes any change
 type Validator interface {
 	PreflightValidations(ctx context.Context) error
 }
 
+// CAPIManager upgrades the Cluster API providers of a management cluster. clusterapi.Manager implements it
 type CAPIManager interface {
 	Upgrade(ctx context.Context, managementCluster *types.Cluster, provider providers.Provider, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 	EnsureEtcdProvidersInstallation(ctx context.Context, managementCluster *types.Cluster, provider providers.Provider, currSpec *cluster.Spec) error
 }
+
+// WorkloadBackup backs up the cluster workloads and returns the name of the backup
+type WorkloadBackup interface {
+	Backup(ctx context.Context, operation string) (string, error)
+}
+
+// WorkloadEviction evicts the cluster workloads before it's deleted, releasing the infrastructure resources they hold
+type WorkloadEviction interface {
+	Evict(ctx context.Context) error
+}
+
+// MachinePower powers off the bare metal machines of a cluster once they aren't used anymore
+type MachinePower interface {
+	PowerOffMachines(ctx context.Context) error
+}
+
+// ProgressSink receives the progress of a workflow, for programs that report it in their own way
+type ProgressSink interface {
+	TaskStarted(name string)
+	// TaskFinished receives the error the task set, nil if it succeeded
+	TaskFinished(name string, duration time.Duration, err error)
+}
+
+// Notifier sends the start and end of the workflow to external systems like Slack
+type Notifier interface {
+	Notify(ctx context.Context, event notification.Event) error
+}
+
+// WorkspaceCleaner removes the files a workflow leaves in the folder of a cluster once they aren't needed anymore
+type WorkspaceCleaner interface {
+	CleanUp(clusterName string, clusterSpec *cluster.Spec) error
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,AddonManager,Validator,CAPIManager)
+// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,AddonManager,Validator,CAPIManager,WorkloadBackup,WorkloadEviction,MachinePower,ProgressSink,Notifier,WorkspaceCleaner)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -7,9 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	bootstrapper "github.com/aws/eks-anywhere/pkg/bootstrapper"
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	notification "github.com/aws/eks-anywhere/pkg/notification"
 	providers "github.com/aws/eks-anywhere/pkg/providers"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	validations "github.com/aws/eks-anywhere/pkg/validations"
@@ -73,6 +75,21 @@ func (mr *MockBootstrapperMockRecorder) DeleteBootstrapCluster(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBootstrapCluster", reflect.TypeOf((*MockBootstrapper)(nil).DeleteBootstrapCluster), arg0, arg1, arg2)
 }
 
+// GetExistingBootstrapCluster mocks base method.
+func (m *MockBootstrapper) GetExistingBootstrapCluster(arg0 context.Context, arg1 string) (*bootstrapper.ExistingBootstrapCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExistingBootstrapCluster", arg0, arg1)
+	ret0, _ := ret[0].(*bootstrapper.ExistingBootstrapCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExistingBootstrapCluster indicates an expected call of GetExistingBootstrapCluster.
+func (mr *MockBootstrapperMockRecorder) GetExistingBootstrapCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExistingBootstrapCluster", reflect.TypeOf((*MockBootstrapper)(nil).GetExistingBootstrapCluster), arg0, arg1)
+}
+
 // MockClusterManager is a mock of ClusterManager interface.
 type MockClusterManager struct {
 	ctrl     *gomock.Controller
@@ -110,6 +127,34 @@ func (mr *MockClusterManagerMockRecorder) ApplyBundles(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBundles", reflect.TypeOf((*MockClusterManager)(nil).ApplyBundles), arg0, arg1, arg2)
 }
 
+// ApplyProvenance mocks base method.
+func (m *MockClusterManager) ApplyProvenance(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyProvenance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyProvenance indicates an expected call of ApplyProvenance.
+func (mr *MockClusterManagerMockRecorder) ApplyProvenance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyProvenance", reflect.TypeOf((*MockClusterManager)(nil).ApplyProvenance), arg0, arg1, arg2)
+}
+
+// ConfigureCoreDNS mocks base method.
+func (m *MockClusterManager) ConfigureCoreDNS(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureCoreDNS", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureCoreDNS indicates an expected call of ConfigureCoreDNS.
+func (mr *MockClusterManagerMockRecorder) ConfigureCoreDNS(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureCoreDNS", reflect.TypeOf((*MockClusterManager)(nil).ConfigureCoreDNS), arg0, arg1, arg2)
+}
+
 // CreateAwsIamAuthCaSecret mocks base method.
 func (m *MockClusterManager) CreateAwsIamAuthCaSecret(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -138,6 +183,20 @@ func (mr *MockClusterManagerMockRecorder) CreateEKSAResources(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEKSAResources", reflect.TypeOf((*MockClusterManager)(nil).CreateEKSAResources), arg0, arg1, arg2, arg3, arg4)
 }
 
+// CreateManifestPolicyValidations mocks base method.
+func (m *MockClusterManager) CreateManifestPolicyValidations(arg0 context.Context, arg1 *cluster.Spec, arg2 providers.Provider) []validations.Validation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManifestPolicyValidations", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]validations.Validation)
+	return ret0
+}
+
+// CreateManifestPolicyValidations indicates an expected call of CreateManifestPolicyValidations.
+func (mr *MockClusterManagerMockRecorder) CreateManifestPolicyValidations(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManifestPolicyValidations", reflect.TypeOf((*MockClusterManager)(nil).CreateManifestPolicyValidations), arg0, arg1, arg2)
+}
+
 // CreateWorkloadCluster mocks base method.
 func (m *MockClusterManager) CreateWorkloadCluster(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) (*types.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -225,6 +284,20 @@ func (mr *MockClusterManagerMockRecorder) InstallCAPI(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCAPI", reflect.TypeOf((*MockClusterManager)(nil).InstallCAPI), arg0, arg1, arg2, arg3)
 }
 
+// InstallCoreAddons mocks base method.
+func (m *MockClusterManager) InstallCoreAddons(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallCoreAddons", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallCoreAddons indicates an expected call of InstallCoreAddons.
+func (mr *MockClusterManagerMockRecorder) InstallCoreAddons(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCoreAddons", reflect.TypeOf((*MockClusterManager)(nil).InstallCoreAddons), arg0, arg1, arg2)
+}
+
 // InstallCustomComponents mocks base method.
 func (m *MockClusterManager) InstallCustomComponents(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -239,6 +312,20 @@ func (mr *MockClusterManagerMockRecorder) InstallCustomComponents(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCustomComponents", reflect.TypeOf((*MockClusterManager)(nil).InstallCustomComponents), arg0, arg1, arg2)
 }
 
+// InstallHostEntries mocks base method.
+func (m *MockClusterManager) InstallHostEntries(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallHostEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallHostEntries indicates an expected call of InstallHostEntries.
+func (mr *MockClusterManagerMockRecorder) InstallHostEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallHostEntries", reflect.TypeOf((*MockClusterManager)(nil).InstallHostEntries), arg0, arg1, arg2)
+}
+
 // InstallMachineHealthChecks mocks base method.
 func (m *MockClusterManager) InstallMachineHealthChecks(arg0 context.Context, arg1 *types.Cluster, arg2 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -267,18 +354,47 @@ func (mr *MockClusterManagerMockRecorder) InstallNetworking(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallNetworking", reflect.TypeOf((*MockClusterManager)(nil).InstallNetworking), arg0, arg1, arg2)
 }
 
+// InstallServiceLoadBalancer mocks base method.
+func (m *MockClusterManager) InstallServiceLoadBalancer(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallServiceLoadBalancer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallServiceLoadBalancer indicates an expected call of InstallServiceLoadBalancer.
+func (mr *MockClusterManagerMockRecorder) InstallServiceLoadBalancer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallServiceLoadBalancer", reflect.TypeOf((*MockClusterManager)(nil).InstallServiceLoadBalancer), arg0, arg1, arg2)
+}
+
 // InstallStorageClass mocks base method.
-func (m *MockClusterManager) InstallStorageClass(arg0 context.Context, arg1 *types.Cluster, arg2 providers.Provider) error {
+func (m *MockClusterManager) InstallStorageClass(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallStorageClass", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "InstallStorageClass", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallStorageClass indicates an expected call of InstallStorageClass.
-func (mr *MockClusterManagerMockRecorder) InstallStorageClass(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockClusterManagerMockRecorder) InstallStorageClass(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallStorageClass", reflect.TypeOf((*MockClusterManager)(nil).InstallStorageClass), arg0, arg1, arg2, arg3)
+}
+
+// IsDeletionProtected mocks base method.
+func (m *MockClusterManager) IsDeletionProtected(arg0 context.Context, arg1 *types.Cluster, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDeletionProtected", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDeletionProtected indicates an expected call of IsDeletionProtected.
+func (mr *MockClusterManagerMockRecorder) IsDeletionProtected(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallStorageClass", reflect.TypeOf((*MockClusterManager)(nil).InstallStorageClass), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDeletionProtected", reflect.TypeOf((*MockClusterManager)(nil).IsDeletionProtected), arg0, arg1, arg2)
 }
 
 // MoveCAPI mocks base method.
@@ -300,6 +416,25 @@ func (mr *MockClusterManagerMockRecorder) MoveCAPI(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCAPI", reflect.TypeOf((*MockClusterManager)(nil).MoveCAPI), varargs...)
 }
 
+// MoveCAPIAndVerify mocks base method.
+func (m *MockClusterManager) MoveCAPIAndVerify(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 string, arg4 *cluster.Spec, arg5 ...types.NodeReadyChecker) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveCAPIAndVerify", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCAPIAndVerify indicates an expected call of MoveCAPIAndVerify.
+func (mr *MockClusterManagerMockRecorder) MoveCAPIAndVerify(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCAPIAndVerify", reflect.TypeOf((*MockClusterManager)(nil).MoveCAPIAndVerify), varargs...)
+}
+
 // PauseEKSAControllerReconcile mocks base method.
 func (m *MockClusterManager) PauseEKSAControllerReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -328,6 +463,21 @@ func (mr *MockClusterManagerMockRecorder) ResumeEKSAControllerReconcile(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeEKSAControllerReconcile", reflect.TypeOf((*MockClusterManager)(nil).ResumeEKSAControllerReconcile), arg0, arg1, arg2, arg3)
 }
 
+// ResumeWorkloadCluster mocks base method.
+func (m *MockClusterManager) ResumeWorkloadCluster(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) (*types.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeWorkloadCluster", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeWorkloadCluster indicates an expected call of ResumeWorkloadCluster.
+func (mr *MockClusterManagerMockRecorder) ResumeWorkloadCluster(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeWorkloadCluster", reflect.TypeOf((*MockClusterManager)(nil).ResumeWorkloadCluster), arg0, arg1, arg2, arg3)
+}
+
 // SaveLogsManagementCluster mocks base method.
 func (m *MockClusterManager) SaveLogsManagementCluster(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -385,6 +535,20 @@ func (mr *MockClusterManagerMockRecorder) UpgradeCluster(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeCluster", reflect.TypeOf((*MockClusterManager)(nil).UpgradeCluster), arg0, arg1, arg2, arg3, arg4)
 }
 
+// UpgradeManifestPolicyValidations mocks base method.
+func (m *MockClusterManager) UpgradeManifestPolicyValidations(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 *cluster.Spec, arg4 providers.Provider) []validations.Validation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeManifestPolicyValidations", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]validations.Validation)
+	return ret0
+}
+
+// UpgradeManifestPolicyValidations indicates an expected call of UpgradeManifestPolicyValidations.
+func (mr *MockClusterManagerMockRecorder) UpgradeManifestPolicyValidations(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeManifestPolicyValidations", reflect.TypeOf((*MockClusterManager)(nil).UpgradeManifestPolicyValidations), arg0, arg1, arg2, arg3, arg4)
+}
+
 // UpgradeNetworking mocks base method.
 func (m *MockClusterManager) UpgradeNetworking(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) (*types.ChangeDiff, error) {
 	m.ctrl.T.Helper()
@@ -400,6 +564,20 @@ func (mr *MockClusterManagerMockRecorder) UpgradeNetworking(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeNetworking", reflect.TypeOf((*MockClusterManager)(nil).UpgradeNetworking), arg0, arg1, arg2, arg3)
 }
 
+// WaitForReadinessGates mocks base method.
+func (m *MockClusterManager) WaitForReadinessGates(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForReadinessGates", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForReadinessGates indicates an expected call of WaitForReadinessGates.
+func (mr *MockClusterManagerMockRecorder) WaitForReadinessGates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForReadinessGates", reflect.TypeOf((*MockClusterManager)(nil).WaitForReadinessGates), arg0, arg1, arg2)
+}
+
 // MockAddonManager is a mock of AddonManager interface.
 type MockAddonManager struct {
 	ctrl     *gomock.Controller
@@ -638,3 +816,236 @@ func (mr *MockCAPIManagerMockRecorder) Upgrade(arg0, arg1, arg2, arg3, arg4 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockCAPIManager)(nil).Upgrade), arg0, arg1, arg2, arg3, arg4)
 }
+
+// MockWorkloadBackup is a mock of WorkloadBackup interface.
+type MockWorkloadBackup struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkloadBackupMockRecorder
+}
+
+// MockWorkloadBackupMockRecorder is the mock recorder for MockWorkloadBackup.
+type MockWorkloadBackupMockRecorder struct {
+	mock *MockWorkloadBackup
+}
+
+// NewMockWorkloadBackup creates a new mock instance.
+func NewMockWorkloadBackup(ctrl *gomock.Controller) *MockWorkloadBackup {
+	mock := &MockWorkloadBackup{ctrl: ctrl}
+	mock.recorder = &MockWorkloadBackupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkloadBackup) EXPECT() *MockWorkloadBackupMockRecorder {
+	return m.recorder
+}
+
+// Backup mocks base method.
+func (m *MockWorkloadBackup) Backup(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockWorkloadBackupMockRecorder) Backup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockWorkloadBackup)(nil).Backup), arg0, arg1)
+}
+
+// MockWorkloadEviction is a mock of WorkloadEviction interface.
+type MockWorkloadEviction struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkloadEvictionMockRecorder
+}
+
+// MockWorkloadEvictionMockRecorder is the mock recorder for MockWorkloadEviction.
+type MockWorkloadEvictionMockRecorder struct {
+	mock *MockWorkloadEviction
+}
+
+// NewMockWorkloadEviction creates a new mock instance.
+func NewMockWorkloadEviction(ctrl *gomock.Controller) *MockWorkloadEviction {
+	mock := &MockWorkloadEviction{ctrl: ctrl}
+	mock.recorder = &MockWorkloadEvictionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkloadEviction) EXPECT() *MockWorkloadEvictionMockRecorder {
+	return m.recorder
+}
+
+// Evict mocks base method.
+func (m *MockWorkloadEviction) Evict(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evict", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Evict indicates an expected call of Evict.
+func (mr *MockWorkloadEvictionMockRecorder) Evict(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evict", reflect.TypeOf((*MockWorkloadEviction)(nil).Evict), arg0)
+}
+
+// MockMachinePower is a mock of MachinePower interface.
+type MockMachinePower struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachinePowerMockRecorder
+}
+
+// MockMachinePowerMockRecorder is the mock recorder for MockMachinePower.
+type MockMachinePowerMockRecorder struct {
+	mock *MockMachinePower
+}
+
+// NewMockMachinePower creates a new mock instance.
+func NewMockMachinePower(ctrl *gomock.Controller) *MockMachinePower {
+	mock := &MockMachinePower{ctrl: ctrl}
+	mock.recorder = &MockMachinePowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachinePower) EXPECT() *MockMachinePowerMockRecorder {
+	return m.recorder
+}
+
+// PowerOffMachines mocks base method.
+func (m *MockMachinePower) PowerOffMachines(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOffMachines", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOffMachines indicates an expected call of PowerOffMachines.
+func (mr *MockMachinePowerMockRecorder) PowerOffMachines(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOffMachines", reflect.TypeOf((*MockMachinePower)(nil).PowerOffMachines), arg0)
+}
+
+// MockProgressSink is a mock of ProgressSink interface.
+type MockProgressSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockProgressSinkMockRecorder
+}
+
+// MockProgressSinkMockRecorder is the mock recorder for MockProgressSink.
+type MockProgressSinkMockRecorder struct {
+	mock *MockProgressSink
+}
+
+// NewMockProgressSink creates a new mock instance.
+func NewMockProgressSink(ctrl *gomock.Controller) *MockProgressSink {
+	mock := &MockProgressSink{ctrl: ctrl}
+	mock.recorder = &MockProgressSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProgressSink) EXPECT() *MockProgressSinkMockRecorder {
+	return m.recorder
+}
+
+// TaskFinished mocks base method.
+func (m *MockProgressSink) TaskFinished(arg0 string, arg1 time.Duration, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TaskFinished", arg0, arg1, arg2)
+}
+
+// TaskFinished indicates an expected call of TaskFinished.
+func (mr *MockProgressSinkMockRecorder) TaskFinished(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskFinished", reflect.TypeOf((*MockProgressSink)(nil).TaskFinished), arg0, arg1, arg2)
+}
+
+// TaskStarted mocks base method.
+func (m *MockProgressSink) TaskStarted(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TaskStarted", arg0)
+}
+
+// TaskStarted indicates an expected call of TaskStarted.
+func (mr *MockProgressSinkMockRecorder) TaskStarted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskStarted", reflect.TypeOf((*MockProgressSink)(nil).TaskStarted), arg0)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(arg0 context.Context, arg1 notification.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), arg0, arg1)
+}
+
+// MockWorkspaceCleaner is a mock of WorkspaceCleaner interface.
+type MockWorkspaceCleaner struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceCleanerMockRecorder
+}
+
+// MockWorkspaceCleanerMockRecorder is the mock recorder for MockWorkspaceCleaner.
+type MockWorkspaceCleanerMockRecorder struct {
+	mock *MockWorkspaceCleaner
+}
+
+// NewMockWorkspaceCleaner creates a new mock instance.
+func NewMockWorkspaceCleaner(ctrl *gomock.Controller) *MockWorkspaceCleaner {
+	mock := &MockWorkspaceCleaner{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceCleanerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceCleaner) EXPECT() *MockWorkspaceCleanerMockRecorder {
+	return m.recorder
+}
+
+// CleanUp mocks base method.
+func (m *MockWorkspaceCleaner) CleanUp(arg0 string, arg1 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanUp", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanUp indicates an expected call of CleanUp.
+func (mr *MockWorkspaceCleanerMockRecorder) CleanUp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUp", reflect.TypeOf((*MockWorkspaceCleaner)(nil).CleanUp), arg0, arg1)
+}
@@ -0,0 +1,200 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/notification"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+var l = logger.For(logger.Workflows)
+
+// diagnosticsGracePeriod is the time given to collect diagnostics and clean up after an operation times out
+const diagnosticsGracePeriod = 10 * time.Minute
+
+// Opt configures the optional behavior of the Create, Upgrade and Delete workflows
+type Opt func(*options)
+
+type options struct {
+	timeout          time.Duration
+	workloadBackup   interfaces.WorkloadBackup
+	workloadEviction interfaces.WorkloadEviction
+	machinePower     interfaces.MachinePower
+	progress         interfaces.ProgressSink
+	componentsOnly   bool
+	resultFile       string
+	notifier         interfaces.Notifier
+	workspaceCleaner interfaces.WorkspaceCleaner
+	validationReport string
+}
+
+// WithTimeout bounds the time the whole workflow can take. The timeout is split between the
+// workflow tasks following the workflow budgets, so a stuck task fails the operation early
+func WithTimeout(timeout time.Duration) Opt {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithWorkloadBackup backs up the cluster workloads before the workflow makes any destructive change,
+// and fails the workflow if the backup fails
+func WithWorkloadBackup(backup interfaces.WorkloadBackup) Opt {
+	return func(o *options) {
+		o.workloadBackup = backup
+	}
+}
+
+// WithWorkloadEviction evicts the cluster workloads before the cluster is deleted, and fails the
+// workflow if they can't be evicted. It's only used by the Delete workflow
+func WithWorkloadEviction(eviction interfaces.WorkloadEviction) Opt {
+	return func(o *options) {
+		o.workloadEviction = eviction
+	}
+}
+
+// WithMachinesPowerOff powers off the bare metal machines once the cluster is deleted. Failing to power
+// them off is reported as a warning, since the cluster is already gone
+func WithMachinesPowerOff(power interfaces.MachinePower) Opt {
+	return func(o *options) {
+		o.machinePower = power
+	}
+}
+
+// WithProgressSink reports the start and end of each workflow task to the sink
+func WithProgressSink(sink interfaces.ProgressSink) Opt {
+	return func(o *options) {
+		o.progress = sink
+	}
+}
+
+// WithComponentsOnly limits the upgrade to the management components: Cluster API providers, EKS-A controller,
+// Flux and Cilium. The machines and the Kubernetes versions of the cluster are left untouched
+func WithComponentsOnly() Opt {
+	return func(o *options) {
+		o.componentsOnly = true
+	}
+}
+
+// WithResultFile writes the Result of the workflow as json to path when it finishes, whether it succeeded or not
+func WithResultFile(path string) Opt {
+	return func(o *options) {
+		o.resultFile = path
+	}
+}
+
+// WithNotifier sends the start and the outcome of the workflow to the notifier
+func WithNotifier(notifier interfaces.Notifier) Opt {
+	return func(o *options) {
+		o.notifier = notifier
+	}
+}
+
+// WithWorkspaceCleanup cleans up the folders of the clusters once the workflow succeeds. Failing to clean up
+// is logged but doesn't fail the operation. It's only used by the Upgrade workflow
+func WithWorkspaceCleanup(cleaner interfaces.WorkspaceCleaner) Opt {
+	return func(o *options) {
+		o.workspaceCleaner = cleaner
+	}
+}
+
+// WithValidationReport writes the report of the setup and validations to path, as a table, JSON or JUnit XML
+// depending on the file extension. It's only used by the Create and Upgrade workflows
+func WithValidationReport(path string) Opt {
+	return func(o *options) {
+		o.validationReport = path
+	}
+}
+
+func newOptions(opts []Opt) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o options) taskRunnerOpts(budgets map[string]float64, recorder *resultRecorder) []task.TaskRunnerOpt {
+	sinks := progressSinks{recorder}
+	if o.progress != nil {
+		sinks = append(sinks, o.progress)
+	}
+	opts := []task.TaskRunnerOpt{task.WithProgressSink(sinks)}
+	if o.timeout > 0 {
+		opts = append(opts, task.WithDeadline(task.Deadline{
+			Timeout:     o.timeout,
+			Budgets:     budgets,
+			GracePeriod: diagnosticsGracePeriod,
+		}))
+	}
+
+	return opts
+}
+
+// finishResult completes the result of the run and writes it to the result file, if any. Failing to write
+// the result is logged but doesn't fail the operation, which already finished
+func (o options) finishResult(recorder *resultRecorder, commandContext *task.CommandContext, err error, before, after *cluster.Spec) *Result {
+	result := recorder.finish(commandContext, err, before, after)
+	if o.resultFile != "" {
+		if writeErr := writeResult(o.resultFile, result); writeErr != nil {
+			l.Error(writeErr, "Failed writing operation result", "file", o.resultFile)
+		} else {
+			l.V(3).Info("Operation result written", "file", o.resultFile)
+		}
+	}
+
+	return result
+}
+
+// notifyStarted sends the start of the workflow to the notifier, if any. Failing to notify is logged
+// but doesn't fail the operation
+func (o options) notifyStarted(ctx context.Context, result *Result) {
+	o.notify(ctx, notification.Event{
+		Type:      notification.OperationStarted,
+		Cluster:   result.Cluster,
+		Operation: result.Operation,
+		Time:      result.StartTime,
+	})
+}
+
+// notifyFinished sends the outcome of the workflow to the notifier, if any
+func (o options) notifyFinished(ctx context.Context, result *Result) {
+	duration := time.Duration(result.DurationSeconds * float64(time.Second))
+	event := notification.Event{
+		Type:      notification.OperationSucceeded,
+		Cluster:   result.Cluster,
+		Operation: result.Operation,
+		Message:   fmt.Sprintf("took %s", duration.Round(time.Second)),
+		Time:      result.StartTime.Add(duration),
+	}
+	if result.Outcome == OutcomeFailed {
+		event.Type = notification.OperationFailed
+		event.Message = result.Error
+	}
+	o.notify(ctx, event)
+}
+
+func (o options) notify(ctx context.Context, event notification.Event) {
+	if o.notifier == nil {
+		return
+	}
+	if err := o.notifier.Notify(ctx, event); err != nil {
+		l.Error(err, "Failed sending notification", "event", event.Type)
+	}
+}
+
+// cleanUpWorkspace runs the workspace cleaner, if any, on the folder of each cluster with the spec the workflow applied
+func (o options) cleanUpWorkspace(clusterSpec *cluster.Spec, clusterNames ...string) {
+	if o.workspaceCleaner == nil {
+		return
+	}
+	for _, name := range clusterNames {
+		if err := o.workspaceCleaner.CleanUp(name, clusterSpec); err != nil {
+			l.Error(err, "Failed cleaning up cluster folder", "cluster", name)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package workflows
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+type Outcome string
+
+const (
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+)
+
+// FailureReason classifies why a workflow failed, so automation can react without matching error messages
+type FailureReason string
+
+const (
+	ReasonValidationFailed   FailureReason = "ValidationFailed"
+	ReasonProviderAuthFailed FailureReason = "ProviderAuthenticationFailed"
+	ReasonTimeout            FailureReason = "Timeout"
+	// ReasonPartialCreate is set when a create fails after creating resources that need to be cleaned up,
+	// listed in the ResourcesLeft of the result. It takes precedence over a timeout
+	ReasonPartialCreate FailureReason = "PartialCreate"
+)
+
+// Result describes how a workflow run went, so automation wrapping the workflows doesn't need to parse the logs
+type Result struct {
+	Operation string  `json:"operation"`
+	Cluster   string  `json:"cluster"`
+	Outcome   Outcome `json:"outcome"`
+	Error     string  `json:"error,omitempty"`
+	// Reason is empty when the workflow succeeded or failed for a reason without its own classification
+	Reason          FailureReason `json:"reason,omitempty"`
+	ResourcesLeft   []string      `json:"resourcesLeft,omitempty"`
+	StartTime       time.Time     `json:"startTime"`
+	DurationSeconds float64       `json:"durationSeconds"`
+	Tasks           []TaskResult  `json:"tasks"`
+	VersionsBefore  *Versions     `json:"versionsBefore,omitempty"`
+	VersionsAfter   *Versions     `json:"versionsAfter,omitempty"`
+	KubeconfigFile  string        `json:"kubeconfigFile,omitempty"`
+	Artifacts       []string      `json:"artifacts,omitempty"`
+	Warnings        []string      `json:"warnings,omitempty"`
+}
+
+type TaskResult struct {
+	Name            string  `json:"name"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// Versions are the versions a cluster runs
+type Versions struct {
+	KubernetesVersion string `json:"kubernetesVersion"`
+	EksDRelease       string `json:"eksDRelease,omitempty"`
+	BundlesNumber     int    `json:"bundlesNumber,omitempty"`
+}
+
+// resultRecorder builds the Result of a workflow from the progress of its tasks
+type resultRecorder struct {
+	result *Result
+	now    func() time.Time
+}
+
+func newResultRecorder(operation, clusterName string) *resultRecorder {
+	r := &resultRecorder{now: time.Now}
+	r.result = &Result{
+		Operation: operation,
+		Cluster:   clusterName,
+		StartTime: r.now().UTC(),
+		Tasks:     []TaskResult{},
+	}
+	return r
+}
+
+func (r *resultRecorder) TaskStarted(name string) {}
+
+func (r *resultRecorder) TaskFinished(name string, duration time.Duration, err error) {
+	t := TaskResult{Name: name, DurationSeconds: duration.Seconds()}
+	if err != nil {
+		t.Error = err.Error()
+	}
+	r.result.Tasks = append(r.result.Tasks, t)
+}
+
+// finish completes the result with the outcome of the run and what the tasks left in the command context
+func (r *resultRecorder) finish(commandContext *task.CommandContext, err error, before, after *cluster.Spec) *Result {
+	r.result.DurationSeconds = r.now().UTC().Sub(r.result.StartTime).Seconds()
+	r.result.Outcome = OutcomeSucceeded
+	if err != nil {
+		r.result.Outcome = OutcomeFailed
+		r.result.Error = err.Error()
+		r.result.Reason = failureReason(err, r.result.ResourcesLeft)
+	}
+	r.result.VersionsBefore = versionsOf(before)
+	r.result.VersionsAfter = versionsOf(after)
+	r.result.Artifacts = commandContext.Artifacts
+	r.result.Warnings = commandContext.Warnings
+
+	return r.result
+}
+
+func failureReason(err error, resourcesLeft []string) FailureReason {
+	var authErr *providers.AuthenticationError
+	var runnerErr *validations.RunnerError
+	var deadlineErr *task.DeadlineExceededError
+	switch {
+	case len(resourcesLeft) > 0:
+		return ReasonPartialCreate
+	case errors.As(err, &deadlineErr):
+		return ReasonTimeout
+	case errors.As(err, &authErr):
+		return ReasonProviderAuthFailed
+	case errors.As(err, &runnerErr):
+		return ReasonValidationFailed
+	default:
+		return ""
+	}
+}
+
+func versionsOf(spec *cluster.Spec) *Versions {
+	if spec == nil || spec.Cluster == nil {
+		return nil
+	}
+
+	v := &Versions{KubernetesVersion: string(spec.Cluster.Spec.KubernetesVersion)}
+	if spec.VersionsBundle != nil {
+		v.EksDRelease = spec.VersionsBundle.EksD.Name
+	}
+	if spec.Bundles != nil {
+		v.BundlesNumber = spec.Bundles.Spec.Number
+	}
+
+	return v
+}
+
+// writeResult writes the result as json to path, creating its folder if needed
+func writeResult(path string, result *Result) error {
+	content, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed marshalling operation result: %v", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating folder for operation result: %v", err)
+	}
+
+	if err = ioutil.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed writing operation result: %v", err)
+	}
+
+	return nil
+}
+
+// progressSinks notifies several sinks of the progress of a workflow
+type progressSinks []interfaces.ProgressSink
+
+func (s progressSinks) TaskStarted(name string) {
+	for _, sink := range s {
+		sink.TaskStarted(name)
+	}
+}
+
+func (s progressSinks) TaskFinished(name string, duration time.Duration, err error) {
+	for _, sink := range s {
+		sink.TaskFinished(name, duration, err)
+	}
+}
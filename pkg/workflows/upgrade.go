@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
+// Upgrade is the upgrade cluster workflow. Its dependencies are injected through NewUpgrade
 type Upgrade struct {
 	bootstrapper      interfaces.Bootstrapper
 	provider          providers.Provider
@@ -23,11 +24,21 @@ type Upgrade struct {
 	writer            filewriter.FileWriter
 	capiManager       interfaces.CAPIManager
 	upgradeChangeDiff *types.ChangeDiff
+	options           options
+	result            *Result
 }
 
+// upgradeBudgets is the fraction of the operation timeout each upgrade task can use
+var upgradeBudgets = map[string]float64{
+	"setup-and-validate":     0.15,
+	"bootstrap-cluster-init": 0.3,
+}
+
+// NewUpgrade builds the workflow that upgrades a cluster to the spec, including its CAPI providers,
+// networking and GitOps configuration
 func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
 	capiManager interfaces.CAPIManager,
-	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter) *Upgrade {
+	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter, opts ...Opt) *Upgrade {
 	upgradeChangeDiff := types.NewChangeDiff()
 	return &Upgrade{
 		bootstrapper:      bootstrapper,
@@ -37,11 +48,15 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 		writer:            writer,
 		capiManager:       capiManager,
 		upgradeChangeDiff: upgradeChangeDiff,
+		options:           newOptions(opts),
 	}
 }
 
+// Run upgrades workloadCluster to clusterSpec. forceCleanup deletes the bootstrap cluster left by a previous run before starting.
+// When clusterSpec has a separate management cluster, the whole upgrade runs against it and no bootstrap cluster is used
 func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup bool) error {
-	if forceCleanup {
+	c.result = nil
+	if forceCleanup && clusterSpec.ManagementCluster == nil {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
 			Name: clusterSpec.Name,
 		}, true); err != nil {
@@ -60,13 +75,32 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCl
 		Writer:            c.writer,
 		CAPIManager:       c.capiManager,
 		UpgradeChangeDiff: c.upgradeChangeDiff,
+		WorkloadBackup:    c.options.workloadBackup,
+		ComponentsOnly:    c.options.componentsOnly,
+		ValidationReport:  c.options.validationReport,
 	}
 
 	if clusterSpec.ManagementCluster != nil {
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidateTasks{}).RunTask(ctx, commandContext)
+	recorder := newResultRecorder("upgrade", clusterSpec.Name)
+	c.options.notifyStarted(ctx, recorder.result)
+	err := task.NewTaskRunner(&setupAndValidateTasks{}, c.options.taskRunnerOpts(upgradeBudgets, recorder)...).RunTask(ctx, commandContext)
+	recorder.result.KubeconfigFile = workloadCluster.KubeconfigFile
+	c.result = c.options.finishResult(recorder, commandContext, err, commandContext.CurrentClusterSpec, clusterSpec)
+	c.options.notifyFinished(ctx, c.result)
+	if err == nil {
+		c.options.cleanUpWorkspace(clusterSpec, workspaceClusters(clusterSpec)...)
+	}
+
+	return err
+}
+
+// Result returns the result of the last run, with the outcome and the tasks it ran, also when it failed.
+// It's nil before the first run and when forceCleanup failed deleting the previous bootstrap cluster, since no task ran
+func (c *Upgrade) Result() *Result {
+	return c.result
 }
 
 type setupAndValidateTasks struct{}
@@ -106,9 +140,11 @@ type resumeFluxReconcile struct{}
 type writeClusterConfigTask struct{}
 
 func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Performing setup and validations")
-	runner := validations.NewRunner()
+	l.Info("Performing setup and validations")
+	runner := newValidationRunner(commandContext)
 	runner.Register(s.validations(ctx, commandContext)...)
+	target := getManagementCluster(commandContext)
+	runner.Register(commandContext.ClusterManager.UpgradeManifestPolicyValidations(ctx, target, target, commandContext.ClusterSpec, commandContext.Provider)...)
 
 	err := runner.Run()
 	if err != nil {
@@ -116,7 +152,7 @@ func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.Co
 		return nil
 	}
 
-	return &updateSecrets{}
+	return backupWorkloadsOrNext(commandContext, "upgrade", &updateSecrets{})
 }
 
 func (s *setupAndValidateTasks) validations(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
@@ -159,7 +195,7 @@ func (s *updateSecrets) Name() string {
 func (s *ensureEtcdCAPIComponentsExistTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
-	logger.Info("Ensuring etcd CAPI providers exist on management cluster before upgrade")
+	l.Info("Ensuring etcd CAPI providers exist on management cluster before upgrade")
 	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, target, commandContext.ClusterSpec.Name)
 	if err != nil {
 		commandContext.SetError(err)
@@ -180,7 +216,21 @@ func (s *ensureEtcdCAPIComponentsExistTask) Name() string {
 func (s *upgradeCoreComponents) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
-	logger.Info("Upgrading core components")
+	if commandContext.ComponentsOnly {
+		if err := validateComponentsOnlyUpgrade(commandContext.CurrentClusterSpec, commandContext.ClusterSpec); err != nil {
+			commandContext.SetError(err)
+			return nil
+		}
+	}
+
+	l.Info("Upgrading core components")
+
+	if hooks, ok := commandContext.Provider.(providers.UpgradeHooks); ok {
+		if err := hooks.PreCoreComponentsUpgrade(ctx, target, commandContext.CurrentClusterSpec, commandContext.ClusterSpec); err != nil {
+			commandContext.SetError(fmt.Errorf("error running provider pre core components upgrade hook: %v", err))
+			return &CollectDiagnosticsTask{}
+		}
+	}
 
 	changeDiff, err := commandContext.ClusterManager.UpgradeNetworking(ctx, target, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
 	if err != nil {
@@ -216,9 +266,38 @@ func (s *upgradeCoreComponents) Run(ctx context.Context, commandContext *task.Co
 	}
 	commandContext.UpgradeChangeDiff.Append(changeDiff)
 
+	if commandContext.ComponentsOnly {
+		l.MarkSuccess("Management components upgraded!")
+		return nil
+	}
+
 	return &upgradeNeeded{}
 }
 
+// validateComponentsOnlyUpgrade fails if the new spec changes the Kubernetes version of the control plane or of any
+// worker node group, since a components only upgrade doesn't roll out the machines
+func validateComponentsOnlyUpgrade(currentSpec, newSpec *cluster.Spec) error {
+	if currentSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
+		return fmt.Errorf("components only upgrade can't change the kubernetes version from %s to %s",
+			currentSpec.Cluster.Spec.KubernetesVersion, newSpec.Cluster.Spec.KubernetesVersion)
+	}
+
+	currentVersions := make(map[string]v1alpha1.KubernetesVersion, len(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		currentVersions[w.Name] = currentSpec.WorkerNodeGroupKubernetesVersion(w)
+	}
+
+	for _, w := range newSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		currentVersion, ok := currentVersions[w.Name]
+		if newVersion := newSpec.WorkerNodeGroupKubernetesVersion(w); ok && currentVersion != newVersion {
+			return fmt.Errorf("components only upgrade can't change the kubernetes version of worker node group %s from %s to %s",
+				w.Name, currentVersion, newVersion)
+		}
+	}
+
+	return nil
+}
+
 func (s *upgradeCoreComponents) Name() string {
 	return "upgrade-core-components"
 }
@@ -228,7 +307,7 @@ func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandCon
 		commandContext.SetError(err)
 		return nil
 	} else if upgradeNeeded {
-		logger.V(3).Info("Provider needs a cluster upgrade")
+		l.V(3).Info("Provider needs a cluster upgrade")
 		return &pauseEksaAndFluxReconcile{}
 	}
 
@@ -244,7 +323,7 @@ func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandCon
 	}
 
 	if !diff {
-		logger.Info("No upgrades needed from cluster spec")
+		l.Info("No upgrades needed from cluster spec")
 		return nil
 	}
 
@@ -258,14 +337,14 @@ func (s *upgradeNeeded) Name() string {
 func (s *pauseEksaAndFluxReconcile) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
-	logger.Info("Pausing EKS-A cluster controller reconcile")
+	l.Info("Pausing EKS-A cluster controller reconcile")
 	err := commandContext.ClusterManager.PauseEKSAControllerReconcile(ctx, target, commandContext.CurrentClusterSpec, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
 
-	logger.Info("Pausing Flux kustomization")
+	l.Info("Pausing Flux kustomization")
 	err = commandContext.AddonManager.PauseGitOpsKustomization(ctx, target, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
@@ -282,7 +361,7 @@ func (s *createBootstrapClusterTask) Run(ctx context.Context, commandContext *ta
 	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
 		return &upgradeWorkloadClusterTask{}
 	}
-	logger.Info("Creating bootstrap cluster")
+	l.Info("Creating bootstrap cluster")
 	bootstrapOptions, err := commandContext.Provider.BootstrapClusterOpts()
 	if err != nil {
 		commandContext.SetError(err)
@@ -304,7 +383,7 @@ func (s *createBootstrapClusterTask) Name() string {
 }
 
 func (s *installCAPITask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Installing cluster-api providers on bootstrap cluster")
+	l.Info("Installing cluster-api providers on bootstrap cluster")
 	err := commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
@@ -318,7 +397,7 @@ func (s *installCAPITask) Name() string {
 }
 
 func (s *moveManagementToBootstrapTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Moving cluster management from workload to bootstrap cluster")
+	l.Info("Moving cluster management from workload to bootstrap cluster")
 	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.WorkloadCluster, commandContext.BootstrapCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
 	if err != nil {
 		commandContext.SetError(err)
@@ -334,7 +413,7 @@ func (s *moveManagementToBootstrapTask) Name() string {
 func (s *upgradeWorkloadClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
-	logger.Info("Upgrading workload cluster")
+	l.Info("Upgrading workload cluster")
 	err := commandContext.ClusterManager.UpgradeCluster(ctx, commandContext.BootstrapCluster, target, commandContext.ClusterSpec, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
@@ -362,7 +441,7 @@ func (s *moveManagementToWorkloadTask) Run(ctx context.Context, commandContext *
 	if commandContext.BootstrapCluster.ExistingManagement {
 		return &updateClusterAndGitResources{}
 	}
-	logger.Info("Moving cluster management from bootstrap to workload cluster")
+	l.Info("Moving cluster management from bootstrap to workload cluster")
 	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
 	if err != nil {
 		commandContext.SetError(err)
@@ -383,7 +462,7 @@ func (s *moveManagementToWorkloadTask) Name() string {
 func (s *updateClusterAndGitResources) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
-	logger.Info("Applying new EKS-A cluster resource; resuming reconcile")
+	l.Info("Applying new EKS-A cluster resource; resuming reconcile")
 	datacenterConfig := commandContext.Provider.DatacenterConfig()
 	machineConfigs := commandContext.Provider.MachineConfigs()
 	err := commandContext.ClusterManager.CreateEKSAResources(ctx, target, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
@@ -392,14 +471,21 @@ func (s *updateClusterAndGitResources) Run(ctx context.Context, commandContext *
 		return &CollectDiagnosticsTask{}
 	}
 
-	logger.Info("Resuming EKS-A controller reconciliation")
+	l.Info("Resuming EKS-A controller reconciliation")
 	err = commandContext.ClusterManager.ResumeEKSAControllerReconcile(ctx, target, commandContext.ClusterSpec, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
 
-	logger.Info("Updating Git Repo with new EKS-A cluster spec")
+	l.V(4).Info("Applying cluster provenance to workload cluster")
+	err = commandContext.ClusterManager.ApplyProvenance(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	l.Info("Updating Git Repo with new EKS-A cluster spec")
 	err = commandContext.AddonManager.UpdateGitEksaSpec(ctx, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
 	if err != nil {
 		commandContext.SetError(err)
@@ -415,14 +501,14 @@ func (s *updateClusterAndGitResources) Name() string {
 func (s *resumeFluxReconcile) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
-	logger.Info("Forcing reconcile Git repo with latest commit")
+	l.Info("Forcing reconcile Git repo with latest commit")
 	err := commandContext.AddonManager.ForceReconcileGitRepo(ctx, target, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
 
-	logger.Info("Resuming Flux kustomization")
+	l.Info("Resuming Flux kustomization")
 	err = commandContext.AddonManager.ResumeGitOpsKustomization(ctx, target, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
@@ -436,7 +522,7 @@ func (s *resumeFluxReconcile) Name() string {
 }
 
 func (s *writeClusterConfigTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Writing cluster config file")
+	l.Info("Writing cluster config file")
 	err := clustermarshaller.WriteClusterConfig(commandContext.ClusterSpec, commandContext.Provider.DatacenterConfig(), commandContext.Provider.MachineConfigs(), commandContext.Writer)
 	if err != nil {
 		commandContext.SetError(err)
@@ -457,14 +543,30 @@ func (s *deleteBootstrapClusterTask) Run(ctx context.Context, commandContext *ta
 			commandContext.SetError(err)
 		}
 		if commandContext.OriginalError == nil {
-			logger.MarkSuccess("Cluster upgraded!")
+			l.MarkSuccess("Cluster upgraded!")
+		}
+		return nil
+	}
+	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
+		if commandContext.OriginalError == nil {
+			l.MarkSuccess("Cluster upgraded!")
 		}
 		return nil
 	}
-	logger.Info("Bootstrap cluster information missing - skipping delete kind cluster")
+	l.Info("Bootstrap cluster information missing - skipping delete kind cluster")
 	return nil
 }
 
 func (s *deleteBootstrapClusterTask) Name() string {
 	return "delete-kind-cluster"
 }
+
+// workspaceClusters returns the clusters whose folders the upgrade writes to: the upgraded cluster and,
+// for workload clusters, the management cluster the clusterctl commands run against
+func workspaceClusters(clusterSpec *cluster.Spec) []string {
+	names := []string{clusterSpec.Name}
+	if clusterSpec.ManagementCluster != nil && clusterSpec.ManagementCluster.Name != clusterSpec.Name {
+		names = append(names, clusterSpec.ManagementCluster.Name)
+	}
+	return names
+}
@@ -90,6 +90,7 @@ func newUpgradeTest(t *testing.T) *upgradeTestSetup {
 func (c *upgradeTestSetup) expectSetup() {
 	c.provider.EXPECT().SetupAndValidateUpgradeCluster(c.ctx, gomock.Any(), c.newClusterSpec)
 	c.provider.EXPECT().Name()
+	c.clusterManager.EXPECT().UpgradeManifestPolicyValidations(c.ctx, gomock.Any(), gomock.Any(), c.newClusterSpec, gomock.Any())
 }
 
 func (c *upgradeTestSetup) expectUpdateSecrets(expectedCluster *types.Cluster) {
@@ -232,6 +233,12 @@ func (c *upgradeTestSetup) expectResumeEKSAControllerReconcile(expecteCluster *t
 	)
 }
 
+func (c *upgradeTestSetup) expectApplyProvenance(expectedCluster *types.Cluster) {
+	gomock.InOrder(
+		c.clusterManager.EXPECT().ApplyProvenance(c.ctx, expectedCluster, c.newClusterSpec),
+	)
+}
+
 func (c *upgradeTestSetup) expectPauseGitOpsKustomization(expectedCluster *types.Cluster) {
 	gomock.InOrder(
 		c.addonManager.EXPECT().PauseGitOpsKustomization(
@@ -379,6 +386,7 @@ func TestUpgradeRunSuccess(t *testing.T) {
 	test.expectMachineConfigs()
 	test.expectCreateEKSAResources(test.workloadCluster)
 	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectApplyProvenance(test.workloadCluster)
 	test.expectUpdateGitEksaSpec()
 	test.expectForceReconcileGitRepo(test.workloadCluster)
 	test.expectResumeGitOpsKustomization(test.workloadCluster)
@@ -409,6 +417,7 @@ func TestUpgradeRunProviderNeedsUpgradeSuccess(t *testing.T) {
 	test.expectMachineConfigs()
 	test.expectCreateEKSAResources(test.workloadCluster)
 	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectApplyProvenance(test.workloadCluster)
 	test.expectUpdateGitEksaSpec()
 	test.expectForceReconcileGitRepo(test.workloadCluster)
 	test.expectResumeGitOpsKustomization(test.workloadCluster)
@@ -444,45 +453,183 @@ func TestUpgradeRunFailedUpgrade(t *testing.T) {
 
 func TestUpgradeWorkloadRunSuccess(t *testing.T) {
 	test := newUpgradeTest(t)
-	test.newClusterSpec.SetSelfManaged()
+	test.expectWorkloadUpgrade()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeWorkloadRunForceCleanupSuccess(t *testing.T) {
+	test := newUpgradeTest(t)
+	test.forceCleanup = true
+	test.expectWorkloadUpgrade()
+	test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, gomock.Any(), gomock.Any()).Times(0)
 
-	test.bootstrapCluster.Name = "management-cluster"
-	test.bootstrapCluster.ExistingManagement = true
-	test.bootstrapCluster.KubeconfigFile = "kubeconfig.yaml"
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func (c *upgradeTestSetup) expectWorkloadUpgrade() {
+	c.newClusterSpec.SetSelfManaged()
 
-	test.workloadCluster.KubeconfigFile = "wl-kubeconfig.yaml"
+	c.bootstrapCluster.Name = "management-cluster"
+	c.bootstrapCluster.ExistingManagement = true
+	c.bootstrapCluster.KubeconfigFile = "kubeconfig.yaml"
 
-	test.newClusterSpec.ManagementCluster = &types.Cluster{
-		Name:               test.bootstrapCluster.Name,
+	c.workloadCluster.KubeconfigFile = "wl-kubeconfig.yaml"
+
+	c.newClusterSpec.ManagementCluster = &types.Cluster{
+		Name:               c.bootstrapCluster.Name,
 		KubeconfigFile:     "kubeconfig.yaml",
 		ExistingManagement: true,
 	}
 
+	c.expectSetup()
+	c.expectPreflightValidationsToPass()
+	c.expectUpdateSecrets(c.bootstrapCluster)
+	c.expectEnsureEtcdCAPIComponentsExistTask(c.bootstrapCluster)
+	c.expectUpgradeCoreComponents(c.bootstrapCluster)
+	c.expectProviderNoUpgradeNeeded()
+	c.expectVerifyClusterSpecChanged(c.bootstrapCluster)
+	c.expectPauseEKSAControllerReconcile(c.bootstrapCluster)
+	c.expectPauseGitOpsKustomization(c.bootstrapCluster)
+	c.expectNotToCreateBootstrap()
+	c.expectNotToMoveManagementToBootstrap()
+	c.expectNotToMoveManagementToWorkload()
+	c.expectWriteClusterConfig()
+	c.expectNotToDeleteBootstrap()
+	c.expectDatacenterConfig()
+	c.expectMachineConfigs()
+	c.expectCreateEKSAResources(c.bootstrapCluster)
+	c.expectResumeEKSAControllerReconcile(c.bootstrapCluster)
+	c.expectApplyProvenance(c.workloadCluster)
+	c.expectUpdateGitEksaSpec()
+	c.expectForceReconcileGitRepo(c.bootstrapCluster)
+	c.expectResumeGitOpsKustomization(c.bootstrapCluster)
+	c.expectUpgradeWorkload(c.bootstrapCluster)
+}
+
+func TestUpgradeRunWorkloadBackupFails(t *testing.T) {
+	test := newUpgradeTest(t)
+	backup := mocks.NewMockWorkloadBackup(gomock.NewController(t))
+	test.workflow = workflows.NewUpgrade(test.bootstrapper, test.provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, workflows.WithWorkloadBackup(backup))
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
-	test.expectUpdateSecrets(test.bootstrapCluster)
-	test.expectEnsureEtcdCAPIComponentsExistTask(test.bootstrapCluster)
-	test.expectUpgradeCoreComponents(test.bootstrapCluster)
-	test.expectProviderNoUpgradeNeeded()
-	test.expectVerifyClusterSpecChanged(test.bootstrapCluster)
-	test.expectPauseEKSAControllerReconcile(test.bootstrapCluster)
-	test.expectPauseGitOpsKustomization(test.bootstrapCluster)
-	test.expectNotToCreateBootstrap()
-	test.expectNotToMoveManagementToBootstrap()
-	test.expectNotToMoveManagementToWorkload()
-	test.expectWriteClusterConfig()
-	test.expectNotToDeleteBootstrap()
-	test.expectDatacenterConfig()
-	test.expectMachineConfigs()
-	test.expectCreateEKSAResources(test.bootstrapCluster)
-	test.expectResumeEKSAControllerReconcile(test.bootstrapCluster)
-	test.expectUpdateGitEksaSpec()
-	test.expectForceReconcileGitRepo(test.bootstrapCluster)
-	test.expectResumeGitOpsKustomization(test.bootstrapCluster)
-	test.expectUpgradeWorkload(test.bootstrapCluster)
+	backup.EXPECT().Backup(test.ctx, "upgrade").Return("", errors.New("velero not installed"))
+	test.expectPauseEKSAControllerReconcileNotToBeCalled()
+	test.expectCreateBootstrapNotToBeCalled()
+
+	err := test.run()
+	if err == nil {
+		t.Fatal("Upgrade.Run() err = nil, want err not nil")
+	}
+}
+
+func TestUpgradeRunComponentsOnlySuccess(t *testing.T) {
+	test := newUpgradeTest(t)
+	test.workflow = workflows.NewUpgrade(test.bootstrapper, test.provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, workflows.WithComponentsOnly())
+	test.currentClusterSpec = test.newClusterSpec.DeepCopy()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.expectUpgradeCoreComponents(test.workloadCluster)
+	test.expectPauseEKSAControllerReconcileNotToBeCalled()
+	test.expectCreateBootstrapNotToBeCalled()
 
 	err := test.run()
 	if err != nil {
 		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
 	}
 }
+
+type providerWithUpgradeHooks struct {
+	*providermocks.MockProvider
+	*providermocks.MockUpgradeHooks
+}
+
+func TestUpgradeRunPreCoreComponentsUpgradeHookFails(t *testing.T) {
+	test := newUpgradeTest(t)
+	hooks := providermocks.NewMockUpgradeHooks(gomock.NewController(t))
+	test.workflow = workflows.NewUpgrade(test.bootstrapper, providerWithUpgradeHooks{test.provider, hooks}, test.capiManager, test.clusterManager, test.addonManager, test.writer)
+	test.currentClusterSpec = test.newClusterSpec.DeepCopy()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.capiManager.EXPECT().EnsureEtcdProvidersInstallation(test.ctx, test.workloadCluster, gomock.Any(), test.currentClusterSpec)
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.workloadCluster, test.newClusterSpec.Name).Return(test.currentClusterSpec, nil)
+	hooks.EXPECT().PreCoreComponentsUpgrade(test.ctx, test.workloadCluster, test.currentClusterSpec, test.newClusterSpec).Return(errors.New("crd migration failed"))
+	test.clusterManager.EXPECT().UpgradeNetworking(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, gomock.Any())
+	test.clusterManager.EXPECT().SaveLogsWorkloadCluster(test.ctx, gomock.Any(), test.newClusterSpec, test.workloadCluster)
+
+	err := test.run()
+	if err == nil || err.Error() != "error running provider pre core components upgrade hook: crd migration failed" {
+		t.Fatalf("Upgrade.Run() err = %v, want err = error running provider pre core components upgrade hook", err)
+	}
+}
+
+func TestUpgradeRunComponentsOnlyKubernetesVersionChange(t *testing.T) {
+	test := newUpgradeTest(t)
+	test.workflow = workflows.NewUpgrade(test.bootstrapper, test.provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, workflows.WithComponentsOnly())
+	test.newClusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube120
+	test.newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+	test.currentClusterSpec = test.newClusterSpec.DeepCopy()
+	workerVersion := v1alpha1.Kube121
+	test.newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion = &workerVersion
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.workloadCluster, test.newClusterSpec.Name).Return(test.currentClusterSpec, nil)
+	test.clusterManager.EXPECT().UpgradeNetworking(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := test.run()
+	if err == nil || err.Error() != "components only upgrade can't change the kubernetes version of worker node group md-0 from 1.20 to 1.21" {
+		t.Fatalf("Upgrade.Run() err = %v, want err = components only upgrade can't change the kubernetes version", err)
+	}
+}
+
+func TestUpgradeWorkloadRunCleansUpWorkspace(t *testing.T) {
+	test := newUpgradeTest(t)
+	cleaner := mocks.NewMockWorkspaceCleaner(gomock.NewController(t))
+	test.workflow = workflows.NewUpgrade(test.bootstrapper, test.provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, workflows.WithWorkspaceCleanup(cleaner))
+	test.expectWorkloadUpgrade()
+	cleaner.EXPECT().CleanUp(test.newClusterSpec.Name, test.newClusterSpec).Return(errors.New("permission denied"))
+	cleaner.EXPECT().CleanUp("management-cluster", test.newClusterSpec).Return(nil)
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeRunFailedUpgradeDoesNotCleanUpWorkspace(t *testing.T) {
+	test := newUpgradeTest(t)
+	cleaner := mocks.NewMockWorkspaceCleaner(gomock.NewController(t))
+	test.workflow = workflows.NewUpgrade(test.bootstrapper, test.provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, workflows.WithWorkspaceCleanup(cleaner))
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.expectUpgradeCoreComponents(test.workloadCluster)
+	test.expectProviderNoUpgradeNeeded()
+	test.expectVerifyClusterSpecChanged(test.workloadCluster)
+	test.expectPauseEKSAControllerReconcile(test.workloadCluster)
+	test.expectPauseGitOpsKustomization(test.workloadCluster)
+	test.expectCreateBootstrap()
+	test.expectMoveManagementToBootstrap()
+	test.expectUpgradeWorkloadToReturn(test.workloadCluster, errors.New("failed upgrading"))
+	test.expectMoveManagementToWorkload()
+	test.expectSaveLogs(test.workloadCluster)
+	cleaner.EXPECT().CleanUp(gomock.Any(), gomock.Any()).Times(0)
+
+	err := test.run()
+	if err == nil {
+		t.Fatal("Upgrade.Run() err = nil, want err not nil")
+	}
+}
@@ -0,0 +1,16 @@
+package workflows
+
+import (
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// newValidationRunner returns the Runner for the setup and validations task, writing its report to the
+// file of the command context, if any
+func newValidationRunner(commandContext *task.CommandContext) *validations.Runner {
+	if commandContext.ValidationReport == "" {
+		return validations.NewRunner()
+	}
+	commandContext.AddArtifact(commandContext.ValidationReport)
+	return validations.NewRunner(validations.WithReportFile(commandContext.ValidationReport))
+}
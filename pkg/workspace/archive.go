@@ -0,0 +1,283 @@
+package workspace
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/lock"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// Export writes the folders of the clusters to w as a gzipped tarball, with an index of those clusters at its root.
+// It fails if an operation is running on any of the clusters, since their folders would be exported half written
+func (w *Workspace) Export(out io.Writer, clusters []string) error {
+	index, err := w.Refresh()
+	if err != nil {
+		return err
+	}
+	exported := &Index{UpdatedAt: index.UpdatedAt}
+	for _, name := range clusters {
+		entry := index.Cluster(name)
+		if entry == nil {
+			return fmt.Errorf("cluster %s not found in workspace %s", name, w.dir)
+		}
+		if err = w.Locked(name); err != nil {
+			return err
+		}
+		exported.Clusters = append(exported.Clusters, *entry)
+	}
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	content, err := yaml.Marshal(exported)
+	if err != nil {
+		return fmt.Errorf("failed marshalling workspace index: %v", err)
+	}
+	if err = tw.WriteHeader(&tar.Header{Name: IndexFile, Mode: 0o644, Size: int64(len(content)), ModTime: exported.UpdatedAt}); err != nil {
+		return fmt.Errorf("failed writing workspace archive: %v", err)
+	}
+	if _, err = tw.Write(content); err != nil {
+		return fmt.Errorf("failed writing workspace archive: %v", err)
+	}
+	for _, entry := range exported.Clusters {
+		if err = w.addCluster(tw, entry.Name); err != nil {
+			return fmt.Errorf("failed exporting cluster %s: %v", entry.Name, err)
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("failed writing workspace archive: %v", err)
+	}
+	return gz.Close()
+}
+
+func (w *Workspace) addCluster(tw *tar.Writer, clusterName string) error {
+	return filepath.Walk(w.ClusterDir(clusterName), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(w.dir, path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			logger.V(4).Info("Skipping file that is not regular from workspace archive", "file", name)
+			return nil
+		}
+		if info.Name() == lock.FileName {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// ImportOpt configures an Import
+type ImportOpt func(*importOptions)
+
+type importOptions struct {
+	overwrite bool
+}
+
+// WithOverwrite replaces the folders of the clusters that already exist in the workspace, which fail the import otherwise
+func WithOverwrite() ImportOpt {
+	return func(o *importOptions) {
+		o.overwrite = true
+	}
+}
+
+// Import extracts a tarball written by Export into the workspace and returns the imported clusters. The archive
+// is fully extracted and checked before any cluster folder of the workspace is touched, so a bad archive leaves
+// the workspace as it was. The replaced cluster folders are only removed once every cluster is imported and
+// are put back if any of them fails
+func (w *Workspace) Import(in io.Reader, opts ...ImportOpt) ([]string, error) {
+	o := &importOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed creating workspace %s: %v", w.dir, err)
+	}
+	staging, err := ioutil.TempDir(w.dir, ".eksa-import-")
+	if err != nil {
+		return nil, fmt.Errorf("failed creating import folder: %v", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err = extract(in, staging); err != nil {
+		return nil, err
+	}
+	archive := New(staging)
+	index, err := archive.LoadIndex()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace archive: %v", err)
+	}
+
+	clusters := make([]string, 0, len(index.Clusters))
+	for _, entry := range index.Clusters {
+		if entry.Name == "" || entry.Name != filepath.Base(entry.Name) || strings.HasPrefix(entry.Name, ".") {
+			return nil, fmt.Errorf("invalid workspace archive: invalid cluster name %q", entry.Name)
+		}
+		if !archive.isCluster(entry.Name) {
+			return nil, fmt.Errorf("invalid workspace archive: cluster %s doesn't have a kubeconfig or a cluster config", entry.Name)
+		}
+		if exists(w.ClusterDir(entry.Name)) {
+			if !o.overwrite {
+				return nil, fmt.Errorf("cluster %s already exists in workspace %s, import with overwrite to replace it", entry.Name, w.dir)
+			}
+			if err = w.Locked(entry.Name); err != nil {
+				return nil, err
+			}
+		}
+		clusters = append(clusters, entry.Name)
+	}
+	sort.Strings(clusters)
+
+	replaced := make([]replacedCluster, 0, len(clusters))
+	for _, name := range clusters {
+		r, err := w.replaceCluster(name, archive.ClusterDir(name), staging)
+		if err != nil {
+			w.restoreClusters(replaced)
+			return nil, err
+		}
+		replaced = append(replaced, r)
+		logger.V(3).Info("Cluster imported", "cluster", name, "workspace", w.dir)
+	}
+
+	if _, err = w.Refresh(); err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// replacedCluster is a cluster folder moved into the workspace by an import, with the folder it replaced,
+// if any, kept in the import folder until every cluster is imported
+type replacedCluster struct {
+	name string
+	old  string
+}
+
+// replaceCluster moves the imported folder into the workspace, moving the existing one out of the way first.
+// If the imported folder can't be moved, the existing one is moved back
+func (w *Workspace) replaceCluster(name, imported, staging string) (replacedCluster, error) {
+	r := replacedCluster{name: name}
+	dest := w.ClusterDir(name)
+	if exists(dest) {
+		r.old = filepath.Join(staging, ".replaced-"+name)
+		if err := os.Rename(dest, r.old); err != nil {
+			return r, fmt.Errorf("failed replacing cluster %s: %v", name, err)
+		}
+	}
+	if err := os.Rename(imported, dest); err != nil {
+		if r.old != "" {
+			if rerr := os.Rename(r.old, dest); rerr != nil {
+				logger.Info("Warning: failed restoring cluster folder", "cluster", name, "folder", r.old, "error", rerr)
+			}
+		}
+		return r, fmt.Errorf("failed importing cluster %s: %v", name, err)
+	}
+	return r, nil
+}
+
+// restoreClusters puts back the folders replaced by a failed import, so the workspace is left as it was
+func (w *Workspace) restoreClusters(replaced []replacedCluster) {
+	for i := len(replaced) - 1; i >= 0; i-- {
+		r := replaced[i]
+		dest := w.ClusterDir(r.name)
+		if err := os.RemoveAll(dest); err != nil {
+			logger.Info("Warning: failed removing imported cluster folder", "cluster", r.name, "error", err)
+			continue
+		}
+		if r.old == "" {
+			continue
+		}
+		if err := os.Rename(r.old, dest); err != nil {
+			logger.Info("Warning: failed restoring cluster folder", "cluster", r.name, "folder", r.old, "error", err)
+		}
+	}
+}
+
+// extract writes the directories and regular files of the tarball to dir, rejecting any entry that would
+// end up outside of it
+func extract(in io.Reader, dir string) error {
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return fmt.Errorf("invalid workspace archive: %v", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid workspace archive: %v", err)
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid workspace archive: entry %s is outside of the workspace", header.Name)
+		}
+		path := filepath.Join(dir, name)
+		mode := os.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(path, mode|0o700); err != nil {
+				return fmt.Errorf("failed extracting %s: %v", header.Name, err)
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fmt.Errorf("failed extracting %s: %v", header.Name, err)
+			}
+			if err = writeFile(path, tr, mode); err != nil {
+				return fmt.Errorf("failed extracting %s: %v", header.Name, err)
+			}
+		default:
+			return fmt.Errorf("invalid workspace archive: entry %s is not a file or a directory", header.Name)
+		}
+	}
+}
+
+func writeFile(path string, content io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,84 @@
+package workspace_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+func TestWorkspaceExportImport(t *testing.T) {
+	g := NewWithT(t)
+	source := newWorkspace(t, time.Now())
+	archive := &bytes.Buffer{}
+	g.Expect(source.Export(archive, []string{"mgmt"})).To(Succeed())
+
+	dest := workspace.New(filepath.Join(t.TempDir(), "workspace"))
+	clusters, err := dest.Import(bytes.NewReader(archive.Bytes()))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusters).To(Equal([]string{"mgmt"}))
+
+	content, err := os.ReadFile(filepath.Join(dest.Dir(), "mgmt", "generated", "overrides", "cluster-api", "v1.0.1", "core-components.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("kind: List"))
+	info, err := os.Stat(filepath.Join(dest.Dir(), "mgmt", "mgmt-eks-a-cluster.kubeconfig"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+	g.Expect(filepath.Join(dest.Dir(), "w01")).NotTo(BeADirectory())
+
+	index, err := dest.LoadIndex()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(index.Cluster("mgmt")).NotTo(BeNil())
+
+	_, err = dest.Import(bytes.NewReader(archive.Bytes()))
+	g.Expect(err).To(MatchError(ContainSubstring("cluster mgmt already exists in workspace")))
+
+	writeFile(t, filepath.Join(dest.Dir(), "mgmt", "stale.yaml"), "stale")
+	_, err = dest.Import(bytes.NewReader(archive.Bytes()), workspace.WithOverwrite())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(filepath.Join(dest.Dir(), "mgmt", "stale.yaml")).NotTo(BeAnExistingFile())
+}
+
+func TestWorkspaceExportLockedCluster(t *testing.T) {
+	g := NewWithT(t)
+	w := newWorkspace(t, time.Now())
+	writeFile(t, filepath.Join(w.ClusterDir("mgmt"), "eksa-operation.lock"), "identity: admin")
+
+	err := w.Export(&bytes.Buffer{}, []string{"mgmt"})
+	g.Expect(err).To(MatchError(ContainSubstring("cluster mgmt has an operation in progress")))
+}
+
+func TestWorkspaceExportUnknownCluster(t *testing.T) {
+	g := NewWithT(t)
+	w := newWorkspace(t, time.Now())
+
+	err := w.Export(&bytes.Buffer{}, []string{"eksa-kubeconfigs"})
+	g.Expect(err).To(MatchError(ContainSubstring("cluster eksa-kubeconfigs not found in workspace")))
+}
+
+func TestWorkspaceImportRejectsEntriesOutsideWorkspace(t *testing.T) {
+	g := NewWithT(t)
+	archive := &bytes.Buffer{}
+	gz := gzip.NewWriter(archive)
+	tw := tar.NewWriter(gz)
+	content := []byte("pwned")
+	g.Expect(tw.WriteHeader(&tar.Header{Name: "../outside", Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+	_, err := tw.Write(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tw.Close()).To(Succeed())
+	g.Expect(gz.Close()).To(Succeed())
+
+	root := t.TempDir()
+	w := workspace.New(filepath.Join(root, "workspace"))
+	_, err = w.Import(archive)
+	g.Expect(err).To(MatchError("invalid workspace archive: entry ../outside is outside of the workspace"))
+	g.Expect(filepath.Join(root, "outside")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(root, "workspace", "outside")).NotTo(BeAnExistingFile())
+}
@@ -0,0 +1,200 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/lock"
+)
+
+const (
+	// EnvVar overrides the default workspace directory, the current directory
+	EnvVar = "EKSA_WORKSPACE"
+	// IndexFile lists the clusters of the workspace, in its root directory
+	IndexFile = "eksa-workspace.yaml"
+
+	clusterConfigPattern = "%s-eks-a-cluster.yaml"
+)
+
+// Workspace is the directory with a folder per cluster, where the CLI keeps the state of each cluster: cluster config,
+// kubeconfig, generated manifests, operation results and logs. All the paths in the index are relative to the
+// workspace, so the directory can be moved or copied to another machine as a whole
+type Workspace struct {
+	dir string
+	now func() time.Time
+}
+
+// Opt configures a Workspace
+type Opt func(*Workspace)
+
+// WithNow overrides the clock used to timestamp the index
+func WithNow(now func() time.Time) Opt {
+	return func(w *Workspace) {
+		w.now = now
+	}
+}
+
+// New returns the workspace in dir
+func New(dir string, opts ...Opt) *Workspace {
+	w := &Workspace{dir: dir, now: time.Now}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Default returns the workspace in the directory of EnvVar, or the current directory if it's not set
+func Default(opts ...Opt) *Workspace {
+	dir := os.Getenv(EnvVar)
+	if dir == "" {
+		dir = "."
+	}
+	return New(dir, opts...)
+}
+
+// Dir returns the root directory of the workspace
+func (w *Workspace) Dir() string {
+	return w.dir
+}
+
+// ClusterDir returns the folder of a cluster in the workspace
+func (w *Workspace) ClusterDir(clusterName string) string {
+	return filepath.Join(w.dir, clusterName)
+}
+
+// Entry is a cluster in the index of a workspace. The paths are relative to the workspace directory
+type Entry struct {
+	Name          string    `json:"name"`
+	Dir           string    `json:"dir"`
+	Kubeconfig    string    `json:"kubeconfig,omitempty"`
+	ClusterConfig string    `json:"clusterConfig,omitempty"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// Index lists the clusters of a workspace
+type Index struct {
+	UpdatedAt time.Time `json:"updatedAt"`
+	Clusters  []Entry   `json:"clusters"`
+}
+
+// Cluster returns the entry of a cluster, nil if it's not in the index
+func (i *Index) Cluster(name string) *Entry {
+	for n := range i.Clusters {
+		if i.Clusters[n].Name == name {
+			return &i.Clusters[n]
+		}
+	}
+	return nil
+}
+
+// Clusters returns the names of the clusters with a folder in the workspace, those with a kubeconfig or a cluster config
+func (w *Workspace) Clusters() ([]string, error) {
+	dirs, err := ioutil.ReadDir(w.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading workspace %s: %v", w.dir, err)
+	}
+	var clusters []string
+	for _, d := range dirs {
+		if d.IsDir() && w.isCluster(d.Name()) {
+			clusters = append(clusters, d.Name())
+		}
+	}
+	return clusters, nil
+}
+
+func (w *Workspace) isCluster(name string) bool {
+	return exists(kubeconfig.FromClusterFolder(w.dir, name)) || exists(w.clusterConfig(name))
+}
+
+func (w *Workspace) clusterConfig(name string) string {
+	return filepath.Join(w.ClusterDir(name), fmt.Sprintf(clusterConfigPattern, name))
+}
+
+// Refresh rebuilds the index from the cluster folders and writes it to the workspace. Clusters whose folder
+// didn't change keep their previous UpdatedAt
+func (w *Workspace) Refresh() (*Index, error) {
+	previous, err := w.LoadIndex()
+	if err != nil {
+		previous = &Index{}
+	}
+	clusters, err := w.Clusters()
+	if err != nil {
+		return nil, err
+	}
+
+	index := &Index{UpdatedAt: w.now().UTC().Truncate(time.Second), Clusters: make([]Entry, 0, len(clusters))}
+	for _, name := range clusters {
+		entry := Entry{Name: name, Dir: name}
+		if exists(kubeconfig.FromClusterFolder(w.dir, name)) {
+			entry.Kubeconfig, _ = filepath.Rel(w.dir, kubeconfig.FromClusterFolder(w.dir, name))
+		}
+		if exists(w.clusterConfig(name)) {
+			entry.ClusterConfig, _ = filepath.Rel(w.dir, w.clusterConfig(name))
+		}
+		entry.UpdatedAt = index.UpdatedAt
+		if p := previous.Cluster(name); p != nil && !w.modifiedSince(name, p.UpdatedAt) {
+			entry.UpdatedAt = p.UpdatedAt
+		}
+		index.Clusters = append(index.Clusters, entry)
+	}
+	sort.Slice(index.Clusters, func(i, j int) bool { return index.Clusters[i].Name < index.Clusters[j].Name })
+
+	content, err := yaml.Marshal(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshalling workspace index: %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(w.dir, IndexFile), content, 0o644); err != nil {
+		return nil, fmt.Errorf("failed writing workspace index: %v", err)
+	}
+	return index, nil
+}
+
+// LoadIndex reads the index of the workspace
+func (w *Workspace) LoadIndex() (*Index, error) {
+	content, err := ioutil.ReadFile(filepath.Join(w.dir, IndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading workspace index: %v", err)
+	}
+	index := &Index{}
+	if err = yaml.UnmarshalStrict(content, index); err != nil {
+		return nil, fmt.Errorf("failed parsing workspace index: %v", err)
+	}
+	return index, nil
+}
+
+// Locked returns an error if an operation holds the local lock of the cluster, since its folder is being written
+func (w *Workspace) Locked(clusterName string) error {
+	if exists(filepath.Join(w.ClusterDir(clusterName), lock.FileName)) {
+		return fmt.Errorf("cluster %s has an operation in progress, wait for it to finish or remove %s if it's not running anymore",
+			clusterName, filepath.Join(w.ClusterDir(clusterName), lock.FileName))
+	}
+	return nil
+}
+
+// modifiedSince reports whether any file in the cluster folder was written after t
+func (w *Workspace) modifiedSince(clusterName string, t time.Time) bool {
+	errModified := errors.New("modified")
+	err := filepath.Walk(w.ClusterDir(clusterName), func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(t) {
+			return errModified
+		}
+		return nil
+	})
+	return err != nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
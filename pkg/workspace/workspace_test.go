@@ -0,0 +1,74 @@
+package workspace_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/workspace"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newWorkspace returns a workspace with the cluster folders of mgmt and w01, and a folder that isn't a cluster
+func newWorkspace(t *testing.T, now time.Time) *workspace.Workspace {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "mgmt", "mgmt-eks-a-cluster.kubeconfig"), "kubeconfig")
+	writeFile(t, filepath.Join(dir, "mgmt", "mgmt-eks-a-cluster.yaml"), "kind: Cluster")
+	writeFile(t, filepath.Join(dir, "mgmt", "generated", "overrides", "cluster-api", "v1.0.1", "core-components.yaml"), "kind: List")
+	writeFile(t, filepath.Join(dir, "w01", "w01-eks-a-cluster.yaml"), "kind: Cluster")
+	writeFile(t, filepath.Join(dir, "eksa-kubeconfigs", "kubeconfig"), "kubeconfig")
+	return workspace.New(dir, workspace.WithNow(func() time.Time { return now }))
+}
+
+func TestWorkspaceRefresh(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	w := newWorkspace(t, now)
+
+	index, err := w.Refresh()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(index.Clusters).To(Equal([]workspace.Entry{
+		{Name: "mgmt", Dir: "mgmt", Kubeconfig: "mgmt/mgmt-eks-a-cluster.kubeconfig", ClusterConfig: "mgmt/mgmt-eks-a-cluster.yaml", UpdatedAt: now},
+		{Name: "w01", Dir: "w01", ClusterConfig: "w01/w01-eks-a-cluster.yaml", UpdatedAt: now},
+	}))
+
+	loaded, err := w.LoadIndex()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(loaded).To(Equal(index))
+}
+
+func TestWorkspaceRefreshKeepsUpdatedAtOfUnchangedClusters(t *testing.T) {
+	g := NewWithT(t)
+	first := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	w := newWorkspace(t, first)
+	_, err := w.Refresh()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	second := first.Add(time.Hour)
+	w = workspace.New(w.Dir(), workspace.WithNow(func() time.Time { return second }))
+	index, err := w.Refresh()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(index.UpdatedAt).To(Equal(second))
+	g.Expect(index.Cluster("mgmt").UpdatedAt).To(Equal(first))
+}
+
+func TestWorkspaceDefault(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv(workspace.EnvVar, "/var/lib/eksa")
+	defer os.Unsetenv(workspace.EnvVar)
+
+	w := workspace.Default()
+	g.Expect(w.Dir()).To(Equal("/var/lib/eksa"))
+	g.Expect(w.ClusterDir("mgmt")).To(Equal("/var/lib/eksa/mgmt"))
+}
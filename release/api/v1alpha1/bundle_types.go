@@ -70,12 +70,20 @@ type VersionsBundle struct {
 	Eksa                   EksaBundle                  `json:"eksa"`
 	Cilium                 CiliumBundle                `json:"cilium"`
 	Kindnetd               KindnetdBundle              `json:"kindnetd"`
+	KubeVip                KubeVipBundle               `json:"kubeVip"`
+	LocalPathProvisioner   LocalPathProvisionerBundle  `json:"localPathProvisioner"`
 	Flux                   FluxBundle                  `json:"flux"`
 	BottleRocketBootstrap  BottlerocketBootstrapBundle `json:"bottlerocketBootstrap"`
 	BottleRocketAdmin      BottlerocketAdminBundle     `json:"bottlerocketAdmin"`
 	ExternalEtcdBootstrap  EtcdadmBootstrapBundle      `json:"etcdadmBootstrap"`
 	ExternalEtcdController EtcdadmControllerBundle     `json:"etcdadmController"`
 	Tinkerbell             TinkerbellBundle            `json:"tinkerbell"`
+	// +optional
+	FIPS *FIPSBundle `json:"fips,omitempty"`
+	// +optional
+	MetricsServer MetricsServerBundle `json:"metricsServer,omitempty"`
+	// +optional
+	NodeProblemDetector NodeProblemDetectorBundle `json:"nodeProblemDetector,omitempty"`
 }
 
 type EksDRelease struct {
@@ -202,6 +210,38 @@ type KindnetdBundle struct {
 	Manifest Manifest `json:"manifest"`
 }
 
+type KubeVipBundle struct {
+	Version       string `json:"version,omitempty"`
+	KubeVip       Image  `json:"kubeVip"`
+	CloudProvider Image  `json:"cloudProvider"`
+}
+
+type LocalPathProvisionerBundle struct {
+	Version     string `json:"version,omitempty"`
+	Provisioner Image  `json:"provisioner"`
+}
+
+// FIPSBundle contains the FIPS 140-2 validated builds of the images that run in the cluster nodes
+type FIPSBundle struct {
+	Version string `json:"version,omitempty"`
+	// The repository and tag of this image are used for all the Kubernetes components
+	KubeAPIServer Image `json:"kubeApiServer"`
+	Etcd          Image `json:"etcd"`
+	CoreDNS       Image `json:"coreDns"`
+	Pause         Image `json:"pause"`
+	KubeVip       Image `json:"kubeVip"`
+}
+
+type MetricsServerBundle struct {
+	Version       string `json:"version,omitempty"`
+	MetricsServer Image  `json:"metricsServer"`
+}
+
+type NodeProblemDetectorBundle struct {
+	Version             string `json:"version,omitempty"`
+	NodeProblemDetector Image  `json:"nodeProblemDetector"`
+}
+
 type FluxBundle struct {
 	Version                string `json:"version,omitempty"`
 	SourceController       Image  `json:"sourceController"`
@@ -392,6 +392,26 @@ func (in *EtcdadmControllerBundle) DeepCopy() *EtcdadmControllerBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FIPSBundle) DeepCopyInto(out *FIPSBundle) {
+	*out = *in
+	in.KubeAPIServer.DeepCopyInto(&out.KubeAPIServer)
+	in.Etcd.DeepCopyInto(&out.Etcd)
+	in.CoreDNS.DeepCopyInto(&out.CoreDNS)
+	in.Pause.DeepCopyInto(&out.Pause)
+	in.KubeVip.DeepCopyInto(&out.KubeVip)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FIPSBundle.
+func (in *FIPSBundle) DeepCopy() *FIPSBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(FIPSBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluxBundle) DeepCopyInto(out *FluxBundle) {
 	*out = *in
@@ -447,6 +467,23 @@ func (in *KindnetdBundle) DeepCopy() *KindnetdBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVipBundle) DeepCopyInto(out *KubeVipBundle) {
+	*out = *in
+	in.KubeVip.DeepCopyInto(&out.KubeVip)
+	in.CloudProvider.DeepCopyInto(&out.CloudProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVipBundle.
+func (in *KubeVipBundle) DeepCopy() *KubeVipBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVipBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmBootstrapBundle) DeepCopyInto(out *KubeadmBootstrapBundle) {
 	*out = *in
@@ -485,6 +522,22 @@ func (in *KubeadmControlPlaneBundle) DeepCopy() *KubeadmControlPlaneBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalPathProvisionerBundle) DeepCopyInto(out *LocalPathProvisionerBundle) {
+	*out = *in
+	in.Provisioner.DeepCopyInto(&out.Provisioner)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalPathProvisionerBundle.
+func (in *LocalPathProvisionerBundle) DeepCopy() *LocalPathProvisionerBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalPathProvisionerBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Manifest) DeepCopyInto(out *Manifest) {
 	*out = *in
@@ -500,6 +553,38 @@ func (in *Manifest) DeepCopy() *Manifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsServerBundle) DeepCopyInto(out *MetricsServerBundle) {
+	*out = *in
+	in.MetricsServer.DeepCopyInto(&out.MetricsServer)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsServerBundle.
+func (in *MetricsServerBundle) DeepCopy() *MetricsServerBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsServerBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProblemDetectorBundle) DeepCopyInto(out *NodeProblemDetectorBundle) {
+	*out = *in
+	in.NodeProblemDetector.DeepCopyInto(&out.NodeProblemDetector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProblemDetectorBundle.
+func (in *NodeProblemDetectorBundle) DeepCopy() *NodeProblemDetectorBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProblemDetectorBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OvaArchive) DeepCopyInto(out *OvaArchive) {
 	*out = *in
@@ -677,12 +762,21 @@ func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	in.Eksa.DeepCopyInto(&out.Eksa)
 	in.Cilium.DeepCopyInto(&out.Cilium)
 	out.Kindnetd = in.Kindnetd
+	in.KubeVip.DeepCopyInto(&out.KubeVip)
+	in.LocalPathProvisioner.DeepCopyInto(&out.LocalPathProvisioner)
 	in.Flux.DeepCopyInto(&out.Flux)
 	in.BottleRocketBootstrap.DeepCopyInto(&out.BottleRocketBootstrap)
 	in.BottleRocketAdmin.DeepCopyInto(&out.BottleRocketAdmin)
 	in.ExternalEtcdBootstrap.DeepCopyInto(&out.ExternalEtcdBootstrap)
 	in.ExternalEtcdController.DeepCopyInto(&out.ExternalEtcdController)
 	in.Tinkerbell.DeepCopyInto(&out.Tinkerbell)
+	if in.FIPS != nil {
+		in, out := &in.FIPS, &out.FIPS
+		*out = new(FIPSBundle)
+		(*in).DeepCopyInto(*out)
+	}
+	in.MetricsServer.DeepCopyInto(&out.MetricsServer)
+	in.NodeProblemDetector.DeepCopyInto(&out.NodeProblemDetector)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionsBundle.
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const kubeVipProjectPath = "projects/plunder-app/kube-vip"
@@ -59,3 +61,54 @@ func (r *ReleaseConfig) GetKubeVipAssets() ([]Artifact, error) {
 
 	return artifacts, nil
 }
+
+// GetKubeVipBundle returns the bundle with the kube-vip images used to provide LoadBalancer services,
+// kube-vip in services mode and the kube-vip cloud provider that assigns them the addresses
+func (r *ReleaseConfig) GetKubeVipBundle(imageDigests map[string]string) (anywherev1alpha1.KubeVipBundle, error) {
+	kubeVipBundleArtifacts := map[string][]Artifact{
+		"kube-vip":                r.BundleArtifactsTable["kube-vip"],
+		"kube-vip-cloud-provider": r.BundleArtifactsTable["kube-vip-cloud-provider"],
+	}
+
+	var sourceBranch string
+	bundleImageArtifacts := map[string]anywherev1alpha1.Image{}
+	artifactHashes := []string{}
+
+	for componentName, artifacts := range kubeVipBundleArtifacts {
+		for _, artifact := range artifacts {
+			imageArtifact := artifact.Image
+			if componentName == "kube-vip" {
+				sourceBranch = imageArtifact.SourcedFromBranch
+			}
+
+			bundleImageArtifact := anywherev1alpha1.Image{
+				Name:        imageArtifact.AssetName,
+				Description: fmt.Sprintf("Container image for %s image", imageArtifact.AssetName),
+				OS:          imageArtifact.OS,
+				Arch:        imageArtifact.Arch,
+				URI:         imageArtifact.ReleaseImageURI,
+				ImageDigest: imageDigests[imageArtifact.ReleaseImageURI],
+			}
+
+			bundleImageArtifacts[imageArtifact.AssetName] = bundleImageArtifact
+			artifactHashes = append(artifactHashes, bundleImageArtifact.ImageDigest)
+		}
+	}
+
+	componentChecksum := generateComponentHash(artifactHashes)
+	version, err := BuildComponentVersion(
+		newVersionerWithGITTAG(r.BuildRepoSource, kubeVipProjectPath, sourceBranch, r),
+		componentChecksum,
+	)
+	if err != nil {
+		return anywherev1alpha1.KubeVipBundle{}, errors.Wrapf(err, "Error getting version for kube-vip")
+	}
+
+	bundle := anywherev1alpha1.KubeVipBundle{
+		Version:       version,
+		KubeVip:       bundleImageArtifacts["kube-vip"],
+		CloudProvider: bundleImageArtifacts["kube-vip-cloud-provider"],
+	}
+
+	return bundle, nil
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const localPathProvisonerProjectPath = "projects/rancher/local-path-provisioner"
@@ -59,3 +61,44 @@ func (r *ReleaseConfig) GetLocalPathProvisionerAssets() ([]Artifact, error) {
 
 	return artifacts, nil
 }
+
+func (r *ReleaseConfig) GetLocalPathProvisionerBundle(imageDigests map[string]string) (anywherev1alpha1.LocalPathProvisionerBundle, error) {
+	artifacts := r.BundleArtifactsTable["local-path-provisioner"]
+
+	var sourceBranch string
+	bundleImageArtifacts := map[string]anywherev1alpha1.Image{}
+	artifactHashes := []string{}
+
+	for _, artifact := range artifacts {
+		imageArtifact := artifact.Image
+		sourceBranch = imageArtifact.SourcedFromBranch
+
+		bundleImageArtifact := anywherev1alpha1.Image{
+			Name:        imageArtifact.AssetName,
+			Description: fmt.Sprintf("Container image for %s image", imageArtifact.AssetName),
+			OS:          imageArtifact.OS,
+			Arch:        imageArtifact.Arch,
+			URI:         imageArtifact.ReleaseImageURI,
+			ImageDigest: imageDigests[imageArtifact.ReleaseImageURI],
+		}
+
+		bundleImageArtifacts[imageArtifact.AssetName] = bundleImageArtifact
+		artifactHashes = append(artifactHashes, bundleImageArtifact.ImageDigest)
+	}
+
+	componentChecksum := generateComponentHash(artifactHashes)
+	version, err := BuildComponentVersion(
+		newVersionerWithGITTAG(r.BuildRepoSource, localPathProvisonerProjectPath, sourceBranch, r),
+		componentChecksum,
+	)
+	if err != nil {
+		return anywherev1alpha1.LocalPathProvisionerBundle{}, errors.Wrapf(err, "Error getting version for local-path-provisioner")
+	}
+
+	bundle := anywherev1alpha1.LocalPathProvisionerBundle{
+		Version:     version,
+		Provisioner: bundleImageArtifacts["local-path-provisioner"],
+	}
+
+	return bundle, nil
+}
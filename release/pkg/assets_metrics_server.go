@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const metricsServerProjectPath = "projects/kubernetes-sigs/metrics-server"
+
+// GetMetricsServerAssets returns the eks-a artifacts for metrics-server
+func (r *ReleaseConfig) GetMetricsServerAssets() ([]Artifact, error) {
+	gitTag, err := r.readGitTag(metricsServerProjectPath, r.BuildRepoBranchName)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+
+	name := "metrics-server"
+	repoName := fmt.Sprintf("kubernetes-sigs/%s", name)
+	tagOptions := map[string]string{
+		"gitTag":      gitTag,
+		"projectPath": metricsServerProjectPath,
+	}
+
+	sourceImageUri, sourcedFromBranch, err := r.GetSourceImageURI(name, repoName, tagOptions)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+	releaseImageUri, err := r.GetReleaseImageURI(name, repoName, tagOptions)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+
+	imageArtifact := &ImageArtifact{
+		AssetName:         name,
+		SourceImageURI:    sourceImageUri,
+		ReleaseImageURI:   releaseImageUri,
+		Arch:              []string{"amd64"},
+		OS:                "linux",
+		GitTag:            gitTag,
+		ProjectPath:       metricsServerProjectPath,
+		SourcedFromBranch: sourcedFromBranch,
+	}
+	artifacts := []Artifact{Artifact{Image: imageArtifact}}
+
+	return artifacts, nil
+}
+
+func (r *ReleaseConfig) GetMetricsServerBundle(imageDigests map[string]string) (anywherev1alpha1.MetricsServerBundle, error) {
+	artifacts := r.BundleArtifactsTable["metrics-server"]
+
+	var sourceBranch string
+	bundleImageArtifacts := map[string]anywherev1alpha1.Image{}
+	artifactHashes := []string{}
+
+	for _, artifact := range artifacts {
+		imageArtifact := artifact.Image
+		sourceBranch = imageArtifact.SourcedFromBranch
+
+		bundleImageArtifact := anywherev1alpha1.Image{
+			Name:        imageArtifact.AssetName,
+			Description: fmt.Sprintf("Container image for %s image", imageArtifact.AssetName),
+			OS:          imageArtifact.OS,
+			Arch:        imageArtifact.Arch,
+			URI:         imageArtifact.ReleaseImageURI,
+			ImageDigest: imageDigests[imageArtifact.ReleaseImageURI],
+		}
+
+		bundleImageArtifacts[imageArtifact.AssetName] = bundleImageArtifact
+		artifactHashes = append(artifactHashes, bundleImageArtifact.ImageDigest)
+	}
+
+	componentChecksum := generateComponentHash(artifactHashes)
+	version, err := BuildComponentVersion(
+		newVersionerWithGITTAG(r.BuildRepoSource, metricsServerProjectPath, sourceBranch, r),
+		componentChecksum,
+	)
+	if err != nil {
+		return anywherev1alpha1.MetricsServerBundle{}, errors.Wrapf(err, "Error getting version for metrics-server")
+	}
+
+	bundle := anywherev1alpha1.MetricsServerBundle{
+		Version:       version,
+		MetricsServer: bundleImageArtifacts["metrics-server"],
+	}
+
+	return bundle, nil
+}
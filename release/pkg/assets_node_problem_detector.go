@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const nodeProblemDetectorProjectPath = "projects/kubernetes/node-problem-detector"
+
+// GetNodeProblemDetectorAssets returns the eks-a artifacts for node-problem-detector
+func (r *ReleaseConfig) GetNodeProblemDetectorAssets() ([]Artifact, error) {
+	gitTag, err := r.readGitTag(nodeProblemDetectorProjectPath, r.BuildRepoBranchName)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+
+	name := "node-problem-detector"
+	repoName := fmt.Sprintf("kubernetes/%s", name)
+	tagOptions := map[string]string{
+		"gitTag":      gitTag,
+		"projectPath": nodeProblemDetectorProjectPath,
+	}
+
+	sourceImageUri, sourcedFromBranch, err := r.GetSourceImageURI(name, repoName, tagOptions)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+	releaseImageUri, err := r.GetReleaseImageURI(name, repoName, tagOptions)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+
+	imageArtifact := &ImageArtifact{
+		AssetName:         name,
+		SourceImageURI:    sourceImageUri,
+		ReleaseImageURI:   releaseImageUri,
+		Arch:              []string{"amd64"},
+		OS:                "linux",
+		GitTag:            gitTag,
+		ProjectPath:       nodeProblemDetectorProjectPath,
+		SourcedFromBranch: sourcedFromBranch,
+	}
+	artifacts := []Artifact{Artifact{Image: imageArtifact}}
+
+	return artifacts, nil
+}
+
+func (r *ReleaseConfig) GetNodeProblemDetectorBundle(imageDigests map[string]string) (anywherev1alpha1.NodeProblemDetectorBundle, error) {
+	artifacts := r.BundleArtifactsTable["node-problem-detector"]
+
+	var sourceBranch string
+	bundleImageArtifacts := map[string]anywherev1alpha1.Image{}
+	artifactHashes := []string{}
+
+	for _, artifact := range artifacts {
+		imageArtifact := artifact.Image
+		sourceBranch = imageArtifact.SourcedFromBranch
+
+		bundleImageArtifact := anywherev1alpha1.Image{
+			Name:        imageArtifact.AssetName,
+			Description: fmt.Sprintf("Container image for %s image", imageArtifact.AssetName),
+			OS:          imageArtifact.OS,
+			Arch:        imageArtifact.Arch,
+			URI:         imageArtifact.ReleaseImageURI,
+			ImageDigest: imageDigests[imageArtifact.ReleaseImageURI],
+		}
+
+		bundleImageArtifacts[imageArtifact.AssetName] = bundleImageArtifact
+		artifactHashes = append(artifactHashes, bundleImageArtifact.ImageDigest)
+	}
+
+	componentChecksum := generateComponentHash(artifactHashes)
+	version, err := BuildComponentVersion(
+		newVersionerWithGITTAG(r.BuildRepoSource, nodeProblemDetectorProjectPath, sourceBranch, r),
+		componentChecksum,
+	)
+	if err != nil {
+		return anywherev1alpha1.NodeProblemDetectorBundle{}, errors.Wrapf(err, "Error getting version for node-problem-detector")
+	}
+
+	bundle := anywherev1alpha1.NodeProblemDetectorBundle{
+		Version:             version,
+		NodeProblemDetector: bundleImageArtifacts["node-problem-detector"],
+	}
+
+	return bundle, nil
+}
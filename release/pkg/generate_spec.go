@@ -121,6 +121,26 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 		return nil, errors.Wrapf(err, "Error getting bundle for Kindnetd")
 	}
 
+	kubeVipBundle, err := r.GetKubeVipBundle(imageDigests)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting bundle for kube-vip")
+	}
+
+	localPathProvisionerBundle, err := r.GetLocalPathProvisionerBundle(imageDigests)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting bundle for local-path-provisioner")
+	}
+
+	metricsServerBundle, err := r.GetMetricsServerBundle(imageDigests)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting bundle for metrics-server")
+	}
+
+	nodeProblemDetectorBundle, err := r.GetNodeProblemDetectorBundle(imageDigests)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting bundle for node-problem-detector")
+	}
+
 	fluxBundle, err := r.GetFluxBundle(imageDigests)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error getting bundle for Flux controllers")
@@ -201,12 +221,16 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 			Eksa:                   eksaBundle,
 			Cilium:                 ciliumBundle,
 			Kindnetd:               kindnetdBundle,
+			KubeVip:                kubeVipBundle,
+			LocalPathProvisioner:   localPathProvisionerBundle,
 			Flux:                   fluxBundle,
 			ExternalEtcdBootstrap:  etcdadmBootstrapBundle,
 			ExternalEtcdController: etcdadmControllerBundle,
 			BottleRocketBootstrap:  bottlerocketBootstrapBundle,
 			BottleRocketAdmin:      bottlerocketAdminBundle,
 			Tinkerbell:             tinkerbellBundle,
+			MetricsServer:          metricsServerBundle,
+			NodeProblemDetector:    nodeProblemDetectorBundle,
 		}
 		versionsBundles = append(versionsBundles, versionsBundle)
 	}
@@ -244,8 +268,11 @@ func (r *ReleaseConfig) GenerateBundleArtifactsTable() (map[string][]Artifact, e
 		"cert-manager":                 r.GetCertManagerAssets,
 		"cilium":                       r.GetCiliumAssets,
 		"local-path-provisioner":       r.GetLocalPathProvisionerAssets,
+		"metrics-server":               r.GetMetricsServerAssets,
+		"node-problem-detector":        r.GetNodeProblemDetectorAssets,
 		"kube-rbac-proxy":              r.GetKubeRbacProxyAssets,
 		"kube-vip":                     r.GetKubeVipAssets,
+		"kube-vip-cloud-provider":      r.GetKubeVipCloudProviderAssets,
 		"flux":                         r.GetFluxAssets,
 		"etcdadm-bootstrap-provider":   r.GetEtcdadmBootstrapAssets,
 		"etcdadm-controller":           r.GetEtcdadmControllerAssets,
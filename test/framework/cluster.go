@@ -294,7 +294,7 @@ func (e *ClusterE2ETest) DeleteCluster(opts ...CommandOpt) {
 }
 
 func (e *ClusterE2ETest) deleteCluster(opts ...CommandOpt) {
-	deleteClusterArgs := []string{"delete", "cluster", e.ClusterName, "-v", "4"}
+	deleteClusterArgs := []string{"delete", "cluster", e.ClusterName, "-v", "4", "--yes"}
 	if getBundlesOverride() == "true" {
 		deleteClusterArgs = append(deleteClusterArgs, "--bundles-override", defaultBundleReleaseManifestFile)
 	}
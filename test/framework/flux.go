@@ -304,10 +304,9 @@ func (e *ClusterE2ETest) machineTemplateName(ctx context.Context) (string, error
 func (e *ClusterE2ETest) validateFluxDeployments(ctx context.Context) error {
 	deploymentReplicas := 1
 	expectedDeployments := map[string]int{
-		"helm-controller":         deploymentReplicas,
-		"kustomize-controller":    deploymentReplicas,
-		"notification-controller": deploymentReplicas,
-		"source-controller":       deploymentReplicas,
+		"helm-controller":      deploymentReplicas,
+		"kustomize-controller": deploymentReplicas,
+		"source-controller":    deploymentReplicas,
 	}
 	return e.validateDeployments(ctx, fluxSystemNamespace, expectedDeployments)
 }